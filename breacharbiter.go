@@ -9,13 +9,12 @@ import (
 	"sync/atomic"
 
 	"github.com/coreos/bbolt"
-	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
-	"github.com/roasbeef/btcd/blockchain"
+	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
@@ -37,6 +36,12 @@ var (
 	justiceTxnBucket = []byte("justice-txn")
 )
 
+// justiceConfTarget is the number of blocks after the confirmation of a breach
+// transaction within which we aim to sweep the breached outputs. As the
+// cheating party is able to claim their outputs once the timelocks expire, we
+// sweep them with a high priority.
+const justiceConfTarget = 2
+
 // BreachConfig bundles the required subsystems used by the breach arbiter. An
 // instance of BreachConfig is passed to newBreachArbiter during instantiation.
 type BreachConfig struct {
@@ -51,36 +56,24 @@ type BreachConfig struct {
 	// it should respond to channel closure.
 	DB *channeldb.DB
 
-	// Estimator is used by the breach arbiter to determine an appropriate
-	// fee level when generating, signing, and broadcasting sweep
-	// transactions.
-	Estimator lnwallet.FeeEstimator
-
-	// GenSweepScript generates the receiving scripts for swept outputs.
-	GenSweepScript func() ([]byte, error)
-
 	// Notifier provides a publish/subscribe interface for event driven
 	// notifications regarding the confirmation of txids.
 	Notifier chainntnfs.ChainNotifier
 
-	// PublishTransaction facilitates the process of broadcasting a
-	// transaction to the network.
-	PublishTransaction func(*wire.MsgTx) error
-
 	// SubscribeChannelEvents is a function closure that allows goroutines
 	// within the breachArbiter to be notified of potential on-chain events
 	// related to the channels they're watching.
 	SubscribeChannelEvents func(wire.OutPoint) (*contractcourt.ChainEventSubscription, error)
 
-	// Signer is used by the breach arbiter to generate sweep transactions,
-	// which move coins from previously open channels back to the user's
-	// wallet.
-	Signer lnwallet.Signer
-
 	// Store is a persistent resource that maintains information regarding
 	// breached channels. This is used in conjunction with DB to recover
 	// from crashes, restarts, or other failures.
 	Store RetributionStore
+
+	// SweepInput offers a breached output to the sweeper, which will
+	// sweep it into the wallet, batched together with other inputs. The
+	// returned channel is sent on once the output has been spent.
+	SweepInput func(sweep.Input, uint32) (chan sweep.Result, error)
 }
 
 // breachArbiter is a special subsystem which is responsible for watching and
@@ -415,7 +408,7 @@ out:
 // when we go to sweep a breached commitment transaction, but the cheating
 // party has already attempted to take it to the second level
 func convertToSecondLevelRevoke(bo *breachedOutput, breachInfo *retributionInfo,
-	spendDetails *chainntnfs.SpendDetail) {
+	spendingTx *wire.MsgTx) {
 
 	// In this case, we'll modify the witness type of this output to
 	// actually prepare for a second level revoke.
@@ -423,7 +416,6 @@ func convertToSecondLevelRevoke(bo *breachedOutput, breachInfo *retributionInfo,
 
 	// We'll also redirect the outpoint to this second level output, so the
 	// spending transaction updates it inputs accordingly.
-	spendingTx := spendDetails.SpendingTx
 	oldOp := bo.outpoint
 	bo.outpoint = wire.OutPoint{
 		Hash:  spendingTx.TxHash(),
@@ -479,200 +471,142 @@ func (b *breachArbiter) exactRetribution(confChan *chainntnfs.ConfirmationEvent,
 	brarLog.Debugf("Breach transaction %v has been confirmed, sweeping "+
 		"revoked funds", breachInfo.commitHash)
 
-	finalTx, err := b.cfg.Store.GetFinalizedTxn(&breachInfo.chanPoint)
-	if err != nil {
-		brarLog.Errorf("unable to get finalized txn for"+
-			"chanid=%v: %v", &breachInfo.chanPoint, err)
-		return
+	// With the breach transaction confirmed, we'll offer all breached
+	// outputs to the sweeper, which will claim ALL the funds within the
+	// channel. As the remote party is able to claim their outputs once
+	// their timelocks expire, we'll ask the sweeper to get the outputs
+	// confirmed quickly.
+	deadline := breachConfHeight + justiceConfTarget
+
+	resultChans := make([]chan sweep.Result, len(breachInfo.breachedOutputs))
+	for i := range breachInfo.breachedOutputs {
+		resultChan, err := b.sweepBreachedOutput(
+			&breachInfo.breachedOutputs[i], breachInfo, deadline,
+		)
+		if err != nil {
+			brarLog.Errorf("unable to sweep breached output %v: %v",
+				breachInfo.breachedOutputs[i].outpoint, err)
+			return
+		}
+		resultChans[i] = resultChan
 	}
 
-	// If this retribution has not been finalized before, we will first
-	// construct a sweep transaction and write it to disk. This will allow
-	// the breach arbiter to re-register for notifications for the justice
-	// txid.
-	spendNtfns := make(map[wire.OutPoint]*chainntnfs.SpendEvent)
-
-secondLevelCheck:
-	if finalTx == nil {
-		// Before we create the justice tx, we need to check to see if
-		// any of the active HTLC's on the commitment transactions has
-		// been spent. In this case, we'll need to go to the second
-		// level to sweep them before the remote party can.
-		for i := 0; i < len(breachInfo.breachedOutputs); i++ {
-			breachedOutput := &breachInfo.breachedOutputs[i]
-
-			// If this isn't an HTLC output, then we can skip it.
-			if breachedOutput.witnessType != lnwallet.HtlcAcceptedRevoke &&
-				breachedOutput.witnessType != lnwallet.HtlcOfferedRevoke {
-				continue
-			}
+	// Now we'll wait for all outputs to be swept. If the cheating party
+	// managed to take one of the HTLC outputs to the second level before
+	// we could sweep it, we'll sweep the second level output instead.
+	for i := range breachInfo.breachedOutputs {
+		breachedOutput := &breachInfo.breachedOutputs[i]
+		resultChan := resultChans[i]
 
-			brarLog.Debugf("Checking for second-level attempt on "+
-				"HTLC(%v) for ChannelPoint(%v)",
-				breachedOutput.outpoint, breachInfo.chanPoint)
+	waitForSweep:
+		var result sweep.Result
+		select {
+		case result = <-resultChan:
+		case <-b.quit:
+			return
+		}
 
-			// Now that we have an HTLC output, we'll quickly check
-			// to see if it has been spent or not. If we have
-			// already registered for a notification for this
-			// output, we'll reuse it.
-			spendNtfn, ok := spendNtfns[breachedOutput.outpoint]
-			if !ok {
-				spendNtfn, err = b.cfg.Notifier.RegisterSpendNtfn(
-					&breachedOutput.outpoint,
-					breachInfo.breachHeight,
-				)
-				if err != nil {
-					brarLog.Errorf("unable to check for "+
-						"spentness of out_point=%v: %v",
-						breachedOutput.outpoint, err)
-
-					// Registration may have failed if
-					// we've been instructed to shutdown.
-					// If so, return here to avoid entering
-					// an infinite loop.
-					select {
-					case <-b.quit:
-						return
-					default:
-						continue
-					}
-				}
-				spendNtfns[breachedOutput.outpoint] = spendNtfn
+		witnessType := breachedOutput.witnessType
+		isHtlcRevoke := witnessType == lnwallet.HtlcAcceptedRevoke ||
+			witnessType == lnwallet.HtlcOfferedRevoke
+
+		switch {
+		case result.Err == nil:
+			brarLog.Debugf("Breached output %v swept by justice "+
+				"tx %v", breachedOutput.outpoint,
+				result.Tx.TxHash())
+
+		// The output has been taken to the second level! In this
+		// case we'll morph our initial revoke spend to instead point
+		// to the second level output, and update the sign descriptor
+		// in the process.
+		case result.Err == sweep.ErrRemoteSpend && isHtlcRevoke:
+			convertToSecondLevelRevoke(
+				breachedOutput, breachInfo, result.Tx,
+			)
+
+			var err error
+			resultChan, err = b.sweepBreachedOutput(
+				breachedOutput, breachInfo, deadline,
+			)
+			if err != nil {
+				brarLog.Errorf("unable to sweep second level "+
+					"output %v: %v",
+					breachedOutput.outpoint, err)
+				return
 			}
 
-			select {
-			// The output has been taken to the second level!
-			case spendDetails, ok := <-spendNtfn.Spend:
-				if !ok {
-					return
-				}
-				delete(spendNtfns, breachedOutput.outpoint)
-
-				// In this case we'll morph our initial revoke
-				// spend to instead point to the second level
-				// output, and update the sign descriptor in
-				// the process.
-				convertToSecondLevelRevoke(
-					breachedOutput, breachInfo, spendDetails,
-				)
-
-			// It hasn't been spent so we'll continue.
-			default:
-			}
-		}
+			goto waitForSweep
 
-		// With the breach transaction confirmed, we now create the
-		// justice tx which will claim ALL the funds within the
-		// channel.
-		finalTx, err = b.createJusticeTx(breachInfo)
-		if err != nil {
-			brarLog.Errorf("unable to create justice tx: %v", err)
-			return
-		}
+		// The remote party swept an output before we did, there's
+		// nothing left for us to do for this output.
+		case result.Err == sweep.ErrRemoteSpend:
+			brarLog.Warnf("Breached output %v for "+
+				"ChannelPoint(%v) was swept by the remote "+
+				"party", breachedOutput.outpoint,
+				breachInfo.chanPoint)
 
-		// Persist our finalized justice transaction before making an
-		// attempt to broadcast.
-		err := b.cfg.Store.Finalize(&breachInfo.chanPoint, finalTx)
-		if err != nil {
-			brarLog.Errorf("unable to finalize justice tx for "+
-				"chanid=%v: %v", &breachInfo.chanPoint, err)
+		default:
+			brarLog.Errorf("unable to sweep breached output %v: %v",
+				breachedOutput.outpoint, result.Err)
 			return
 		}
 	}
 
-	brarLog.Debugf("Broadcasting justice tx: %v", newLogClosure(func() string {
-		return spew.Sdump(finalTx)
-	}))
+	// Compute both the total value of funds being swept and the amount of
+	// funds that were revoked from the counter party.
+	var totalFunds, revokedFunds btcutil.Amount
+	for _, input := range breachInfo.breachedOutputs {
+		totalFunds += input.Amount()
 
-	// We'll now attempt to broadcast the transaction which finalized the
-	// channel's retribution against the cheating counter party.
-	err = b.cfg.PublishTransaction(finalTx)
-	if err != nil {
-		brarLog.Errorf("unable to broadcast "+
-			"justice tx: %v", err)
-		if err == lnwallet.ErrDoubleSpend {
-			brarLog.Infof("Attempting to transfer HTLC revocations " +
-				"to the second level")
-			finalTx = nil
-
-			// Txn publication may fail if we're shutting down.
-			// If so, return to avoid entering an infinite loop.
-			select {
-			case <-b.quit:
-				return
-			default:
-				goto secondLevelCheck
-			}
+		// If the output being revoked is the remote commitment output
+		// or an offered HTLC output, it's amount contributes to the
+		// value of funds being revoked from the counter party.
+		switch input.WitnessType() {
+		case lnwallet.CommitmentRevoke:
+			revokedFunds += input.Amount()
+		case lnwallet.HtlcOfferedRevoke:
+			revokedFunds += input.Amount()
+		default:
 		}
 	}
 
-	// As a conclusionary step, we register for a notification to be
-	// dispatched once the justice tx is confirmed. After confirmation we
-	// notify the caller that initiated the retribution workflow that the
-	// deed has been done.
-	justiceTXID := finalTx.TxHash()
-	confChan, err = b.cfg.Notifier.RegisterConfirmationsNtfn(
-		&justiceTXID, 1, breachConfHeight)
+	brarLog.Infof("Justice for ChannelPoint(%v) has been served, %v "+
+		"revoked funds (%v total) have been claimed",
+		breachInfo.chanPoint, revokedFunds, totalFunds)
+
+	// With the channel closed, mark it in the database as such.
+	err := b.cfg.DB.MarkChanFullyClosed(&breachInfo.chanPoint)
 	if err != nil {
-		brarLog.Errorf("unable to register for conf for txid: %v",
-			justiceTXID)
+		brarLog.Errorf("unable to mark chan as closed: %v", err)
 		return
 	}
 
-	select {
-	case _, ok := <-confChan.Confirmed:
-		if !ok {
-			return
-		}
-
-		// Compute both the total value of funds being swept and the
-		// amount of funds that were revoked from the counter party.
-		var totalFunds, revokedFunds btcutil.Amount
-		for _, input := range breachInfo.breachedOutputs {
-			totalFunds += input.Amount()
-
-			// If the output being revoked is the remote commitment
-			// output or an offered HTLC output, it's amount
-			// contributes to the value of funds being revoked from
-			// the counter party.
-			switch input.WitnessType() {
-			case lnwallet.CommitmentRevoke:
-				revokedFunds += input.Amount()
-			case lnwallet.HtlcOfferedRevoke:
-				revokedFunds += input.Amount()
-			default:
-			}
-		}
-
-		brarLog.Infof("Justice for ChannelPoint(%v) has "+
-			"been served, %v revoked funds (%v total) "+
-			"have been claimed", breachInfo.chanPoint,
-			revokedFunds, totalFunds)
+	// Justice has been carried out; we can safely delete the retribution
+	// info from the database.
+	err = b.cfg.Store.Remove(&breachInfo.chanPoint)
+	if err != nil {
+		brarLog.Errorf("unable to remove retribution from the db: %v",
+			err)
+	}
 
-		// With the channel closed, mark it in the database as such.
-		err := b.cfg.DB.MarkChanFullyClosed(&breachInfo.chanPoint)
-		if err != nil {
-			brarLog.Errorf("unable to mark chan as closed: %v", err)
-			return
-		}
+	// TODO(roasbeef): add peer to blacklist?
 
-		// Justice has been carried out; we can safely delete the
-		// retribution info from the database.
-		err = b.cfg.Store.Remove(&breachInfo.chanPoint)
-		if err != nil {
-			brarLog.Errorf("unable to remove retribution "+
-				"from the db: %v", err)
-		}
+	// TODO(roasbeef): close other active channels with offending peer
+}
 
-		// TODO(roasbeef): add peer to blacklist?
+// sweepBreachedOutput offers a breached output to the sweeper, returning the
+// channel over which the outcome of the sweep is delivered.
+func (b *breachArbiter) sweepBreachedOutput(bo *breachedOutput,
+	breachInfo *retributionInfo, deadline uint32) (chan sweep.Result,
+	error) {
 
-		// TODO(roasbeef): close other active channels with offending
-		// peer
+	input := sweep.NewBaseInput(
+		&bo.outpoint, bo.witnessType, &bo.signDesc,
+		breachInfo.breachHeight,
+	)
 
-		return
-	case <-b.quit:
-		return
-	}
+	return b.cfg.SweepInput(input, deadline)
 }
 
 // breachObserver notifies the breachArbiter contract observer goroutine that a
@@ -997,167 +931,6 @@ func newRetributionInfo(chanPoint *wire.OutPoint,
 	}
 }
 
-// createJusticeTx creates a transaction which exacts "justice" by sweeping ALL
-// the funds within the channel which we are now entitled to due to a breach of
-// the channel's contract by the counterparty. This function returns a *fully*
-// signed transaction with the witness for each input fully in place.
-func (b *breachArbiter) createJusticeTx(
-	r *retributionInfo) (*wire.MsgTx, error) {
-
-	// We will assemble the breached outputs into a slice of spendable
-	// outputs, while simultaneously computing the estimated weight of the
-	// transaction.
-	var (
-		spendableOutputs []SpendableOutput
-		weightEstimate   lnwallet.TxWeightEstimator
-	)
-
-	// Allocate enough space to potentially hold each of the breached
-	// outputs in the retribution info.
-	spendableOutputs = make([]SpendableOutput, 0, len(r.breachedOutputs))
-
-	// The justice transaction we construct will be a segwit transaction
-	// that pays to a p2wkh output. Components such as the version,
-	// nLockTime, and output are already included in the TxWeightEstimator.
-	weightEstimate.AddP2WKHOutput()
-
-	// Next, we iterate over the breached outputs contained in the
-	// retribution info.  For each, we switch over the witness type such
-	// that we contribute the appropriate weight for each input and witness,
-	// finally adding to our list of spendable outputs.
-	for i := range r.breachedOutputs {
-		// Grab locally scoped reference to breached output.
-		input := &r.breachedOutputs[i]
-
-		// First, select the appropriate estimated witness weight for
-		// the give witness type of this breached output. If the witness
-		// type is unrecognized, we will omit it from the transaction.
-		var witnessWeight int
-		switch input.WitnessType() {
-		case lnwallet.CommitmentNoDelay:
-			witnessWeight = lnwallet.P2WKHWitnessSize
-
-		case lnwallet.CommitmentRevoke:
-			witnessWeight = lnwallet.ToLocalPenaltyWitnessSize
-
-		case lnwallet.HtlcOfferedRevoke:
-			witnessWeight = lnwallet.OfferedHtlcPenaltyWitnessSize
-
-		case lnwallet.HtlcAcceptedRevoke:
-			witnessWeight = lnwallet.AcceptedHtlcPenaltyWitnessSize
-
-		case lnwallet.HtlcSecondLevelRevoke:
-			witnessWeight = lnwallet.SecondLevelHtlcPenaltyWitnessSize
-
-		default:
-			brarLog.Warnf("breached output in retribution info "+
-				"contains unexpected witness type: %v",
-				input.WitnessType())
-			continue
-		}
-		weightEstimate.AddWitnessInput(witnessWeight)
-
-		// Finally, append this input to our list of spendable outputs.
-		spendableOutputs = append(spendableOutputs, input)
-	}
-
-	txVSize := int64(weightEstimate.VSize())
-	return b.sweepSpendableOutputsTxn(txVSize, spendableOutputs...)
-}
-
-// sweepSpendableOutputsTxn creates a signed transaction from a sequence of
-// spendable outputs by sweeping the funds into a single p2wkh output.
-func (b *breachArbiter) sweepSpendableOutputsTxn(txVSize int64,
-	inputs ...SpendableOutput) (*wire.MsgTx, error) {
-
-	// First, we obtain a new public key script from the wallet which we'll
-	// sweep the funds to.
-	// TODO(roasbeef): possibly create many outputs to minimize change in
-	// the future?
-	pkScript, err := b.cfg.GenSweepScript()
-	if err != nil {
-		return nil, err
-	}
-
-	// Compute the total amount contained in the inputs.
-	var totalAmt btcutil.Amount
-	for _, input := range inputs {
-		totalAmt += input.Amount()
-	}
-
-	// We'll actually attempt to target inclusion within the next two
-	// blocks as we'd like to sweep these funds back into our wallet ASAP.
-	feePerVSize, err := b.cfg.Estimator.EstimateFeePerVSize(2)
-	if err != nil {
-		return nil, err
-	}
-	txFee := feePerVSize.FeeForVSize(txVSize)
-
-	// TODO(roasbeef): already start to siphon their funds into fees
-	sweepAmt := int64(totalAmt - txFee)
-
-	// With the fee calculated, we can now create the transaction using the
-	// information gathered above and the provided retribution information.
-	txn := wire.NewMsgTx(2)
-
-	// We begin by adding the output to which our funds will be deposited.
-	txn.AddTxOut(&wire.TxOut{
-		PkScript: pkScript,
-		Value:    sweepAmt,
-	})
-
-	// Next, we add all of the spendable outputs as inputs to the
-	// transaction.
-	for _, input := range inputs {
-		txn.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: *input.OutPoint(),
-		})
-	}
-
-	// Before signing the transaction, check to ensure that it meets some
-	// basic validity requirements.
-	btx := btcutil.NewTx(txn)
-	if err := blockchain.CheckTransactionSanity(btx); err != nil {
-		return nil, err
-	}
-
-	// Create a sighash cache to improve the performance of hashing and
-	// signing SigHashAll inputs.
-	hashCache := txscript.NewTxSigHashes(txn)
-
-	// Create a closure that encapsulates the process of initializing a
-	// particular output's witness generation function, computing the
-	// witness, and attaching it to the transaction. This function accepts
-	// an integer index representing the intended txin index, and the
-	// breached output from which it will spend.
-	addWitness := func(idx int, so SpendableOutput) error {
-		// First, we construct a valid witness for this outpoint and
-		// transaction using the SpendableOutput's witness generation
-		// function.
-		witness, err := so.BuildWitness(b.cfg.Signer, txn, hashCache,
-			idx)
-		if err != nil {
-			return err
-		}
-
-		// Then, we add the witness to the transaction at the
-		// appropriate txin index.
-		txn.TxIn[idx].Witness = witness
-
-		return nil
-	}
-
-	// Finally, generate a witness for each output and attach it to the
-	// transaction.
-	for i, input := range inputs {
-		if err := addWitness(i, input); err != nil {
-			return nil, err
-		}
-	}
-
-	return txn, nil
-}
-
 // RetributionStore provides an interface for managing a persistent map from
 // wire.OutPoint -> retributionInfo. Upon learning of a breach, a BreachArbiter
 // should record the retributionInfo for the breached channel, which serves a
@@ -27,6 +27,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/shachain"
+	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/txscript"
@@ -1213,24 +1214,19 @@ func createTestArbiter(t *testing.T, chainEvents *contractcourt.ChainEventSubscr
 		return newRetributionStore(db)
 	})
 
-	aliceKeyPriv, _ := btcec.PrivKeyFromBytes(btcec.S256(),
-		alicesPrivKey)
-	signer := &mockSigner{key: aliceKeyPriv}
-
 	// Assemble our test arbiter.
 	notifier := makeMockSpendNotifier()
 	ba := newBreachArbiter(&BreachConfig{
-		CloseLink:      func(_ *wire.OutPoint, _ htlcswitch.ChannelCloseType) {},
-		DB:             db,
-		Estimator:      &lnwallet.StaticFeeEstimator{FeeRate: 50},
-		GenSweepScript: func() ([]byte, error) { return nil, nil },
+		CloseLink: func(_ *wire.OutPoint, _ htlcswitch.ChannelCloseType) {},
+		DB:        db,
 		SubscribeChannelEvents: func(_ wire.OutPoint) (*contractcourt.ChainEventSubscription, error) {
 			return chainEvents, nil
 		},
-		Signer:             signer,
-		Notifier:           notifier,
-		PublishTransaction: func(_ *wire.MsgTx) error { return nil },
-		Store:              store,
+		Notifier: notifier,
+		Store:    store,
+		SweepInput: func(_ sweep.Input, _ uint32) (chan sweep.Result, error) {
+			return make(chan sweep.Result, 1), nil
+		},
 	})
 
 	if err := ba.Start(); err != nil {
@@ -1345,9 +1341,10 @@ func createInitChannels(revocationWindow int) (*lnwallet.LightningChannel, *lnwa
 	}
 	aliceCommitPoint := lnwallet.ComputeCommitmentPoint(aliceFirstRevoke[:])
 
-	aliceCommitTx, bobCommitTx, err := lnwallet.CreateCommitmentTxns(channelBal,
-		channelBal, &aliceCfg, &bobCfg, aliceCommitPoint, bobCommitPoint,
-		*fundingTxIn)
+	aliceCommitTx, bobCommitTx, err := lnwallet.CreateCommitmentTxns(
+		channeldb.SingleFunder, channelBal, channelBal, &aliceCfg,
+		&bobCfg, aliceCommitPoint, bobCommitPoint, *fundingTxIn,
+	)
 	if err != nil {
 		return nil, nil, nil, err
 	}
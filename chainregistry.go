@@ -44,6 +44,11 @@ const (
 	defaultLitecoinTimeLockDelta = 576
 	defaultLitecoinStaticFeeRate = lnwallet.SatPerVByte(200)
 	defaultLitecoinDustLimit     = btcutil.Amount(54600)
+
+	// feeEstimateCacheDuration is the amount of time a fee estimate for a
+	// particular confirmation target is reused before the fee estimators
+	// are consulted again.
+	feeEstimateCacheDuration = time.Minute
 )
 
 // defaultBtcChannelConstraints is the default set of channel constraints that are
@@ -125,6 +130,7 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 
 	cc := &chainControl{}
 
+	var staticFeeRate lnwallet.SatPerVByte
 	switch registeredChains.PrimaryChain() {
 	case bitcoinChain:
 		cc.routingPolicy = htlcswitch.ForwardingPolicy{
@@ -133,9 +139,7 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 			FeeRate:       cfg.Bitcoin.FeeRate,
 			TimeLockDelta: cfg.Bitcoin.TimeLockDelta,
 		}
-		cc.feeEstimator = lnwallet.StaticFeeEstimator{
-			FeeRate: defaultBitcoinStaticFeeRate,
-		}
+		staticFeeRate = defaultBitcoinStaticFeeRate
 	case litecoinChain:
 		cc.routingPolicy = htlcswitch.ForwardingPolicy{
 			MinHTLC:       cfg.Litecoin.MinHTLC,
@@ -143,13 +147,14 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 			FeeRate:       cfg.Litecoin.FeeRate,
 			TimeLockDelta: cfg.Litecoin.TimeLockDelta,
 		}
-		cc.feeEstimator = lnwallet.StaticFeeEstimator{
-			FeeRate: defaultLitecoinStaticFeeRate,
-		}
+		staticFeeRate = defaultLitecoinStaticFeeRate
 	default:
 		return nil, nil, fmt.Errorf("Default routing policy for "+
 			"chain %v is unknown", registeredChains.PrimaryChain())
 	}
+	cc.feeEstimator = lnwallet.StaticFeeEstimator{
+		FeeRate: staticFeeRate,
+	}
 
 	walletConfig := &btcwallet.Config{
 		PrivatePass:  privateWalletPw,
@@ -337,34 +342,30 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 			// Finally, we'll re-initialize the fee estimator, as
 			// if we're using bitcoind as a backend, then we can
 			// use live fee estimates, rather than a statically
-			// coded value.
-			fallBackFeeRate := lnwallet.SatPerVByte(25)
+			// coded value. We use a zero fall back fee rate, such
+			// that the static fee rate is used instead if the
+			// node is unable to produce an estimate.
 			cc.feeEstimator, err = lnwallet.NewBitcoindFeeEstimator(
-				*rpcConfig, fallBackFeeRate,
+				*rpcConfig, 0,
 			)
 			if err != nil {
 				return nil, nil, err
 			}
-			if err := cc.feeEstimator.Start(); err != nil {
-				return nil, nil, err
-			}
 		} else if cfg.Litecoin.Active {
 			ltndLog.Infof("Initializing litecoind backed fee estimator")
 
 			// Finally, we'll re-initialize the fee estimator, as
 			// if we're using litecoind as a backend, then we can
 			// use live fee estimates, rather than a statically
-			// coded value.
-			fallBackFeeRate := lnwallet.SatPerVByte(25)
+			// coded value. We use a zero fall back fee rate, such
+			// that the static fee rate is used instead if the
+			// node is unable to produce an estimate.
 			cc.feeEstimator, err = lnwallet.NewBitcoindFeeEstimator(
-				*rpcConfig, fallBackFeeRate,
+				*rpcConfig, 0,
 			)
 			if err != nil {
 				return nil, nil, err
 			}
-			if err := cc.feeEstimator.Start(); err != nil {
-				return nil, nil, err
-			}
 		}
 	case "btcd", "ltcd":
 		// Otherwise, we'll be speaking directly via RPC to a node.
@@ -458,23 +459,52 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 			// Finally, we'll re-initialize the fee estimator, as
 			// if we're using btcd as a backend, then we can use
 			// live fee estimates, rather than a statically coded
-			// value.
-			fallBackFeeRate := lnwallet.SatPerVByte(25)
+			// value. We use a zero fall back fee rate, such that
+			// the static fee rate is used instead if the node is
+			// unable to produce an estimate.
 			cc.feeEstimator, err = lnwallet.NewBtcdFeeEstimator(
-				*rpcConfig, fallBackFeeRate,
+				*rpcConfig, 0,
 			)
 			if err != nil {
 				return nil, nil, err
 			}
-			if err := cc.feeEstimator.Start(); err != nil {
-				return nil, nil, err
-			}
 		}
 	default:
 		return nil, nil, fmt.Errorf("unknown node type: %s",
 			homeChainConfig.Node)
 	}
 
+	// With the fee estimator of our backend selected, we'll wrap it within
+	// a composite fee estimator. If a fee estimation URL was specified,
+	// it'll be consulted first, followed by the backend's estimator, while
+	// the static fee rate of the chain is used if neither of them is able
+	// to produce an estimate. All estimates are capped to the maximum fee
+	// rate, such that a single faulty estimate can't blow out our fees.
+	var feeEstimators []lnwallet.FeeEstimator
+	if cfg.FeeURL != "" {
+		ltndLog.Infof("Initializing web API fee estimator using %v",
+			cfg.FeeURL)
+
+		webEstimator := lnwallet.NewWebAPIFeeEstimator(
+			lnwallet.SparseConfFeeSource{URL: cfg.FeeURL}, 0,
+			cfg.net.Dial,
+		)
+		feeEstimators = append(feeEstimators, webEstimator)
+	}
+	feeEstimators = append(feeEstimators, cc.feeEstimator)
+
+	cc.feeEstimator, err = lnwallet.NewCompositeFeeEstimator(
+		feeEstimators, staticFeeRate,
+		lnwallet.SatPerVByte(cfg.MaxFeeRate), feeEstimateCacheDuration,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cc.feeEstimator.Start(); err != nil {
+		return nil, nil, err
+	}
+	walletConfig.FeeEstimator = cc.feeEstimator
+
 	wc, err := btcwallet.New(*walletConfig)
 	if err != nil {
 		fmt.Printf("unable to create wallet controller: %v\n", err)
@@ -0,0 +1,74 @@
+package chanacceptor
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ChainedAcceptor represents a conjunction of ChannelAcceptor results. A
+// channel is only accepted if every acceptor within the chain accepts it. An
+// empty chain accepts all channels.
+type ChainedAcceptor struct {
+	// acceptorID is incremented for each acceptor added to the chain, and
+	// is used as the identifier with which the acceptor can be removed.
+	//
+	// NOTE: This MUST be used atomically.
+	acceptorID uint64
+
+	acceptors    map[uint64]ChannelAcceptor
+	acceptorsMtx sync.RWMutex
+}
+
+// A compile time check to ensure ChainedAcceptor implements the
+// ChannelAcceptor interface.
+var _ ChannelAcceptor = (*ChainedAcceptor)(nil)
+
+// NewChainedAcceptor initializes an empty ChainedAcceptor.
+func NewChainedAcceptor() *ChainedAcceptor {
+	return &ChainedAcceptor{
+		acceptors: make(map[uint64]ChannelAcceptor),
+	}
+}
+
+// AddAcceptor adds a ChannelAcceptor to the chain, and returns the identifier
+// with which it can later be removed.
+func (c *ChainedAcceptor) AddAcceptor(acceptor ChannelAcceptor) uint64 {
+	id := atomic.AddUint64(&c.acceptorID, 1)
+
+	c.acceptorsMtx.Lock()
+	c.acceptors[id] = acceptor
+	c.acceptorsMtx.Unlock()
+
+	return id
+}
+
+// RemoveAcceptor removes the ChannelAcceptor with the given identifier from
+// the chain.
+func (c *ChainedAcceptor) RemoveAcceptor(id uint64) {
+	c.acceptorsMtx.Lock()
+	delete(c.acceptors, id)
+	c.acceptorsMtx.Unlock()
+}
+
+// Accept evaluates the results of all ChannelAcceptors in the chain, and
+// returns the error of the first one that doesn't accept the channel.
+//
+// NOTE: Part of the ChannelAcceptor interface.
+func (c *ChainedAcceptor) Accept(req *ChannelAcceptRequest) error {
+	// We'll copy the current set of acceptors so that we don't hold the
+	// lock while they're deciding, as they may be slow to respond.
+	c.acceptorsMtx.RLock()
+	acceptors := make([]ChannelAcceptor, 0, len(c.acceptors))
+	for _, acceptor := range c.acceptors {
+		acceptors = append(acceptors, acceptor)
+	}
+	c.acceptorsMtx.RUnlock()
+
+	for _, acceptor := range acceptors {
+		if err := acceptor.Accept(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
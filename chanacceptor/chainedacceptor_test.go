@@ -0,0 +1,67 @@
+package chanacceptor
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestChainedAcceptor tests that a channel is only accepted by the chain if
+// every acceptor within it accepts the channel, and that acceptors can be
+// removed from the chain.
+func TestChainedAcceptor(t *testing.T) {
+	t.Parallel()
+
+	req := &ChannelAcceptRequest{
+		OpenChanMsg: &lnwire.OpenChannel{
+			FundingAmount: 100000,
+		},
+	}
+
+	// An empty chain should accept any channel.
+	chain := NewChainedAcceptor()
+	if err := chain.Accept(req); err != nil {
+		t.Fatalf("expected empty chain to accept channel: %v", err)
+	}
+
+	// We'll add an acceptor that only accepts channels of at least
+	// 100000 sats, which should accept our channel.
+	minSizeID := chain.AddAcceptor(AcceptorFunc(
+		func(req *ChannelAcceptRequest) error {
+			if req.OpenChanMsg.FundingAmount < 100000 {
+				return &RejectError{Reason: "channel too small"}
+			}
+			return nil
+		},
+	))
+	if err := chain.Accept(req); err != nil {
+		t.Fatalf("expected chain to accept channel: %v", err)
+	}
+
+	// Once we add an acceptor that rejects all channels, the chain should
+	// reject our channel with its reason.
+	rejectAllID := chain.AddAcceptor(AcceptorFunc(
+		func(req *ChannelAcceptRequest) error {
+			return &RejectError{Reason: "no channels wanted"}
+		},
+	))
+	if minSizeID == rejectAllID {
+		t.Fatalf("expected acceptors to have unique ids")
+	}
+
+	err := chain.Accept(req)
+	rejectErr, ok := err.(*RejectError)
+	if !ok {
+		t.Fatalf("expected RejectError, got %v", err)
+	}
+	if rejectErr.Reason != "no channels wanted" {
+		t.Fatalf("unexpected rejection reason: %v", rejectErr.Reason)
+	}
+
+	// Finally, removing the rejecting acceptor should make the chain
+	// accept our channel once again.
+	chain.RemoveAcceptor(rejectAllID)
+	if err := chain.Accept(req); err != nil {
+		t.Fatalf("expected chain to accept channel: %v", err)
+	}
+}
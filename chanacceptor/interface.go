@@ -0,0 +1,63 @@
+package chanacceptor
+
+import (
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// ChannelAcceptRequest is a struct containing the requesting node's public key
+// along with the lnwire.OpenChannel message that they sent when requesting an
+// inbound channel. This information is provided to each acceptor so that they
+// can each leverage their own decision-making with this information.
+type ChannelAcceptRequest struct {
+	// Node is the public key of the node requesting to open a channel.
+	Node *btcec.PublicKey
+
+	// OpenChanMsg is the actual OpenChannel protocol message that the peer
+	// sent to us.
+	OpenChanMsg *lnwire.OpenChannel
+}
+
+// RejectError is returned by a ChannelAcceptor when it rejects a channel. Its
+// reason is relayed to the remote peer within an lnwire.Error, so it must not
+// contain any private information.
+type RejectError struct {
+	// Reason is a human readable description of why the channel was
+	// rejected.
+	Reason string
+}
+
+// A compile time check to ensure RejectError implements the error interface.
+var _ error = (*RejectError)(nil)
+
+// Error returns the reason for the rejection of the channel.
+//
+// NOTE: Part of the error interface.
+func (e *RejectError) Error() string {
+	return e.Reason
+}
+
+// ChannelAcceptor is an interface that represents a predicate on the data
+// contained in ChannelAcceptRequest.
+type ChannelAcceptor interface {
+	// Accept returns nil if the channel described by the request should
+	// be accepted. Otherwise, a RejectError is returned if the channel
+	// was rejected, or any other error if we were unable to come to a
+	// decision.
+	Accept(req *ChannelAcceptRequest) error
+}
+
+// AcceptorFunc is an adapter that allows the use of an ordinary function as a
+// ChannelAcceptor.
+type AcceptorFunc func(req *ChannelAcceptRequest) error
+
+// A compile time check to ensure AcceptorFunc implements the ChannelAcceptor
+// interface.
+var _ ChannelAcceptor = (AcceptorFunc)(nil)
+
+// Accept calls the function with the given request.
+//
+// NOTE: Part of the ChannelAcceptor interface.
+func (f AcceptorFunc) Accept(req *ChannelAcceptRequest) error {
+	return f(req)
+}
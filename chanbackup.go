@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/chanbackup"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// fetchNodeAddrs returns the set of addresses that we've used in the past to
+// reach the target node. If we don't have a LinkNode for the node, then an
+// empty set of addresses is returned.
+func fetchNodeAddrs(chanDB *channeldb.DB,
+	nodePub *btcec.PublicKey) ([]net.Addr, error) {
+
+	linkNode, err := chanDB.FetchLinkNode(nodePub)
+	switch {
+	case err == channeldb.ErrNodeNotFound:
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return linkNode.Addresses, nil
+}
+
+// fetchBackupForChan attempts to create a plaintext static channel backup for
+// the target channel identified by its channel point. If we're unable to find
+// the target channel, then an error will be returned.
+func fetchBackupForChan(chanPoint wire.OutPoint,
+	chanDB *channeldb.DB) (*chanbackup.Single, error) {
+
+	// First, we'll query the channel source to see if the channel is known
+	// and open within the database.
+	channels, err := chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
+	}
+
+	for _, channel := range channels {
+		if channel.FundingOutpoint != chanPoint {
+			continue
+		}
+
+		// Once we have the target channel, we can assemble the backup
+		// using the addresses we have on file for the channel peer.
+		nodeAddrs, err := fetchNodeAddrs(chanDB, channel.IdentityPub)
+		if err != nil {
+			return nil, err
+		}
+
+		single := chanbackup.NewSingle(channel, nodeAddrs)
+		return &single, nil
+	}
+
+	return nil, fmt.Errorf("unable to find target channel %v", chanPoint)
+}
+
+// fetchStaticChanBackups will return a plaintext static channel back up for
+// all known active/open channels within the passed channel database.
+func fetchStaticChanBackups(
+	chanDB *channeldb.DB) ([]chanbackup.Single, error) {
+
+	// First, we'll query the backup source for information concerning all
+	// currently open and available channels.
+	channels, err := chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
+	}
+
+	// Now that we have all the channels, we'll use the chanSource to
+	// obtain any auxiliary information we need to craft a backup for each
+	// channel.
+	staticChanBackups := make([]chanbackup.Single, 0, len(channels))
+	for _, channel := range channels {
+		nodeAddrs, err := fetchNodeAddrs(chanDB, channel.IdentityPub)
+		if err != nil {
+			return nil, err
+		}
+
+		staticChanBackups = append(
+			staticChanBackups,
+			chanbackup.NewSingle(channel, nodeAddrs),
+		)
+	}
+
+	return staticChanBackups, nil
+}
+
+// channelNotifier is an implementation of the chanbackup.ChannelNotifier
+// interface. New channels are fed to it by the funding manager once their
+// funding transaction has been broadcast, and closed channels by the chain
+// arbitrator once they've been fully resolved. It then dispatches these events
+// to all active subscribers.
+type channelNotifier struct {
+	chanDB *channeldb.DB
+
+	mu              sync.Mutex
+	subscriptionID  uint64
+	chanSubscribers map[uint64]*chanSubscriber
+
+	quit chan struct{}
+}
+
+// chanSubscriber is an active subscription to channel events as registered
+// with the channelNotifier.
+type chanSubscriber struct {
+	updates chan chanbackup.ChannelEvent
+
+	cancelOnce sync.Once
+	cancel     chan struct{}
+}
+
+// A compile-time check to ensure that channelNotifier implements the
+// chanbackup.ChannelNotifier interface.
+var _ chanbackup.ChannelNotifier = (*channelNotifier)(nil)
+
+// newChannelNotifier creates a new channelNotifier backed by the passed
+// channel database.
+func newChannelNotifier(chanDB *channeldb.DB) *channelNotifier {
+	return &channelNotifier{
+		chanDB:          chanDB,
+		chanSubscribers: make(map[uint64]*chanSubscriber),
+		quit:            make(chan struct{}),
+	}
+}
+
+// Stop signals all active subscribers that the notifier is exiting.
+func (c *channelNotifier) Stop() {
+	close(c.quit)
+}
+
+// SubscribeChans requests a new channel subscription relative to the initial
+// set of known channels. Any channels that were opened or closed since the set
+// of known channels was assembled will be delivered as the first event of the
+// subscription.
+//
+// NOTE: This is part of the chanbackup.ChannelNotifier interface.
+func (c *channelNotifier) SubscribeChans(
+	startingChans map[wire.OutPoint]struct{}) (*chanbackup.ChannelSubscription,
+	error) {
+
+	ltndLog.Infof("New channel subscription created for backups, "+
+		"num_starting_chans=%v", len(startingChans))
+
+	sub := &chanSubscriber{
+		updates: make(chan chanbackup.ChannelEvent, 1),
+		cancel:  make(chan struct{}),
+	}
+
+	c.mu.Lock()
+	subID := c.subscriptionID
+	c.subscriptionID++
+	c.chanSubscribers[subID] = sub
+
+	// Now that the subscriber is registered, we won't miss any future
+	// events. We'll now compare the current set of channels within the
+	// database against the starting set, so we can notify the subscriber
+	// of any channels that were opened or closed in the meantime.
+	catchUpEvent, err := c.catchUpEvent(startingChans)
+	if err != nil {
+		delete(c.chanSubscribers, subID)
+		c.mu.Unlock()
+		return nil, err
+	}
+	if len(catchUpEvent.NewChans) != 0 ||
+		len(catchUpEvent.ClosedChans) != 0 {
+
+		sub.updates <- *catchUpEvent
+	}
+	c.mu.Unlock()
+
+	return &chanbackup.ChannelSubscription{
+		ChanUpdates: sub.updates,
+		Cancel: func() {
+			sub.cancelOnce.Do(func() {
+				close(sub.cancel)
+
+				c.mu.Lock()
+				delete(c.chanSubscribers, subID)
+				c.mu.Unlock()
+			})
+		},
+	}, nil
+}
+
+// catchUpEvent returns a channel event that describes the difference between
+// the passed set of known channels, and the channels currently stored within
+// the database.
+//
+// NOTE: This method MUST be called with the mutex held.
+func (c *channelNotifier) catchUpEvent(
+	knownChans map[wire.OutPoint]struct{}) (*chanbackup.ChannelEvent, error) {
+
+	channels, err := c.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
+	}
+
+	var event chanbackup.ChannelEvent
+	currentChans := make(map[wire.OutPoint]struct{}, len(channels))
+	for _, channel := range channels {
+		currentChans[channel.FundingOutpoint] = struct{}{}
+
+		if _, ok := knownChans[channel.FundingOutpoint]; ok {
+			continue
+		}
+
+		nodeAddrs, err := fetchNodeAddrs(c.chanDB, channel.IdentityPub)
+		if err != nil {
+			return nil, err
+		}
+
+		event.NewChans = append(event.NewChans,
+			chanbackup.ChannelWithAddrs{
+				OpenChannel: channel,
+				Addrs:       nodeAddrs,
+			},
+		)
+	}
+
+	for chanPoint := range knownChans {
+		if _, ok := currentChans[chanPoint]; ok {
+			continue
+		}
+
+		event.ClosedChans = append(event.ClosedChans, chanPoint)
+	}
+
+	return &event, nil
+}
+
+// notifySubscribers delivers the passed event to all active subscribers.
+func (c *channelNotifier) notifySubscribers(event chanbackup.ChannelEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, sub := range c.chanSubscribers {
+		select {
+		case sub.updates <- event:
+		case <-sub.cancel:
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// NotifyNewChannel notifies all subscribers of a newly created channel, along
+// with the addresses that we used to reach the channel peer.
+func (c *channelNotifier) NotifyNewChannel(channel *channeldb.OpenChannel,
+	nodeAddrs []net.Addr) {
+
+	c.notifySubscribers(chanbackup.ChannelEvent{
+		NewChans: []chanbackup.ChannelWithAddrs{
+			{
+				OpenChannel: channel,
+				Addrs:       nodeAddrs,
+			},
+		},
+	})
+}
+
+// NotifyClosedChannel notifies all subscribers that the channel identified by
+// the passed channel point has been closed.
+func (c *channelNotifier) NotifyClosedChannel(chanPoint wire.OutPoint) {
+	c.notifySubscribers(chanbackup.ChannelEvent{
+		ClosedChans: []wire.OutPoint{chanPoint},
+	})
+}
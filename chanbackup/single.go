@@ -0,0 +1,439 @@
+package chanbackup
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// SingleBackupVersion denotes the version of the single static channel backup.
+// Based on this version, we know how to pack/unpack serialized versions of the
+// backup.
+type SingleBackupVersion byte
+
+const (
+	// DefaultSingleVersion is the default version of the single channel
+	// backup. The serialized version of this static channel backup is
+	// simply: version || SCB. Where SCB is the known format of the
+	// version.
+	DefaultSingleVersion = 0
+
+	// AnchorsCommitVersion is the version of the single channel backup for
+	// channels that make use of the anchor commitment format. The
+	// serialized format is identical to that of DefaultSingleVersion.
+	AnchorsCommitVersion = 1
+)
+
+// Single is a static description of an existing channel that can be used for
+// the purposes of backing up. The fields in this struct allow a node to
+// recover the settled funds within a channel in the case of partial or
+// complete data loss. We provide the network address that we last used to
+// connect to the peer as well, in case the node stops advertising the IP on
+// the network for whatever reason.
+type Single struct {
+	// Version is the version that should be observed when attempting to
+	// pack the single backup.
+	Version SingleBackupVersion
+
+	// IsInitiator is true if we were the initiator of the channel, and
+	// false otherwise. We'll need to know this information in order to
+	// properly re-derive the state hint information.
+	IsInitiator bool
+
+	// ChainHash is a hash which represents the blockchain that this
+	// channel will be opened within. This value is typically the genesis
+	// hash. In the case that the original chain went through a contentious
+	// hard-fork, then this value will be tweaked using the unique fork
+	// point on each branch.
+	ChainHash chainhash.Hash
+
+	// FundingOutpoint is the outpoint of the final funding transaction.
+	// This value uniquely and globally identities the channel within the
+	// target blockchain as specified by the chain hash parameter.
+	FundingOutpoint wire.OutPoint
+
+	// ShortChannelID encodes the exact location in the chain in which the
+	// channel was initially confirmed. This includes: the block height,
+	// transaction index, and the output within the target transaction.
+	ShortChannelID lnwire.ShortChannelID
+
+	// RemoteNodePub is the identity public key of the remote node this
+	// channel has been established with.
+	RemoteNodePub *btcec.PublicKey
+
+	// Addresses is a list of IP address in which either we were able to
+	// reach the node over in the past, OR we received an incoming
+	// authenticated connection for the stored identity public key.
+	Addresses []net.Addr
+
+	// Capacity is the size of the original channel.
+	Capacity btcutil.Amount
+
+	// LocalChanCfg is our local channel configuration. It contains all the
+	// information we need to re-derive the keys we used within the
+	// channel. Most importantly, it allows to derive the base public
+	// that's used to deriving the key used within the non-delayed
+	// pay-to-self output on the commitment transaction for a node. With
+	// this information, we can re-derive the private key needed to sweep
+	// the funds on-chain.
+	//
+	// NOTE: Of the items in the ChannelConstraints, we only write the CSV
+	// delay.
+	LocalChanCfg channeldb.ChannelConfig
+
+	// RemoteChanCfg is the remote channel confirmation. We store this as
+	// well since we'll need some of their keys to re-derive things like
+	// the state hint obfuscator which will allow us to recognize the
+	// state their broadcast on chain.
+	//
+	// NOTE: Of the items in the ChannelConstraints, we only write the CSV
+	// delay.
+	RemoteChanCfg channeldb.ChannelConfig
+
+	// ShaChainRootDesc describes how to derive the private key that was
+	// used as the shachain root for this channel. If the channel tracks
+	// the locator of the key, then only the locator is stored. Otherwise,
+	// the public key of the root is stored, and we'll need to scan the
+	// revocation root key family in order to find the matching private
+	// key.
+	ShaChainRootDesc keychain.KeyDescriptor
+}
+
+// NewSingle creates a new static channel backup based on an existing open
+// channel. We also pass in the set of addresses that we used in the past to
+// connect to the channel peer.
+func NewSingle(channel *channeldb.OpenChannel,
+	nodeAddrs []net.Addr) Single {
+
+	var shaChainRootDesc keychain.KeyDescriptor
+
+	// If the channel has a populated RevocationKeyLocator, then we can
+	// just store that instead of the public key.
+	revKeyLoc := channel.RevocationKeyLocator
+	if revKeyLoc.Family == keychain.KeyFamilyRevocationRoot {
+		shaChainRootDesc = keychain.KeyDescriptor{
+			KeyLocator: revKeyLoc,
+		}
+	} else {
+		// Otherwise, we'll need to obtain a public point for the
+		// shachain root and store that instead, such that the backup's
+		// plaintext doesn't carry any private information. When we go
+		// to recover, we'll present this in order to derive the
+		// private key.
+		var b bytes.Buffer
+		_ = channel.RevocationProducer.Encode(&b) // Can't fail.
+
+		_, shaChainPoint := btcec.PrivKeyFromBytes(
+			btcec.S256(), b.Bytes(),
+		)
+		shaChainRootDesc = keychain.KeyDescriptor{
+			PubKey: shaChainPoint,
+			KeyLocator: keychain.KeyLocator{
+				Family: keychain.KeyFamilyRevocationRoot,
+			},
+		}
+	}
+
+	version := SingleBackupVersion(DefaultSingleVersion)
+	if channel.ChanType.HasAnchors() {
+		version = AnchorsCommitVersion
+	}
+
+	return Single{
+		Version:          version,
+		IsInitiator:      channel.IsInitiator,
+		ChainHash:        channel.ChainHash,
+		FundingOutpoint:  channel.FundingOutpoint,
+		ShortChannelID:   channel.ShortChanID,
+		RemoteNodePub:    channel.IdentityPub,
+		Addresses:        nodeAddrs,
+		Capacity:         channel.Capacity,
+		LocalChanCfg:     channel.LocalChanCfg,
+		RemoteChanCfg:    channel.RemoteChanCfg,
+		ShaChainRootDesc: shaChainRootDesc,
+	}
+}
+
+// writeKeyDesc serializes the passed key descriptor into the target
+// io.Writer. As the public key of a descriptor is optional, we'll prefix it
+// with a single byte that indicates if it's present.
+func writeKeyDesc(w io.Writer, desc *keychain.KeyDescriptor) error {
+	err := lnwire.WriteElements(
+		w, uint32(desc.Family), desc.Index,
+	)
+	if err != nil {
+		return err
+	}
+
+	if desc.PubKey == nil {
+		return lnwire.WriteElements(w, uint8(0))
+	}
+
+	return lnwire.WriteElements(w, uint8(1), desc.PubKey)
+}
+
+// readKeyDesc reads a key descriptor serialized by writeKeyDesc from the
+// passed io.Reader.
+func readKeyDesc(r io.Reader, desc *keychain.KeyDescriptor) error {
+	var (
+		family    uint32
+		hasPubKey uint8
+	)
+	err := lnwire.ReadElements(r, &family, &desc.Index, &hasPubKey)
+	if err != nil {
+		return err
+	}
+	desc.Family = keychain.KeyFamily(family)
+
+	if hasPubKey == 0 {
+		return nil
+	}
+
+	return lnwire.ReadElements(r, &desc.PubKey)
+}
+
+// writeChanConfig serializes the portion of the passed channel config that we
+// need for recovery purposes into the target io.Writer.
+func writeChanConfig(w io.Writer, c *channeldb.ChannelConfig) error {
+	if err := lnwire.WriteElements(w, c.CsvDelay); err != nil {
+		return err
+	}
+
+	keys := []*keychain.KeyDescriptor{
+		&c.MultiSigKey, &c.RevocationBasePoint, &c.PaymentBasePoint,
+		&c.DelayBasePoint, &c.HtlcBasePoint,
+	}
+	for _, key := range keys {
+		if err := writeKeyDesc(w, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readChanConfig reads a channel config serialized by writeChanConfig from
+// the passed io.Reader.
+func readChanConfig(r io.Reader, c *channeldb.ChannelConfig) error {
+	if err := lnwire.ReadElements(r, &c.CsvDelay); err != nil {
+		return err
+	}
+
+	keys := []*keychain.KeyDescriptor{
+		&c.MultiSigKey, &c.RevocationBasePoint, &c.PaymentBasePoint,
+		&c.DelayBasePoint, &c.HtlcBasePoint,
+	}
+	for _, key := range keys {
+		if err := readKeyDesc(r, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Serialize attempts to write out the serialized version of the target
+// StaticChannelBackup into the passed io.Writer.
+func (s *Single) Serialize(w io.Writer) error {
+	// Check to ensure that we'll only attempt to serialize a version that
+	// we're aware of.
+	switch s.Version {
+	case DefaultSingleVersion:
+	case AnchorsCommitVersion:
+	default:
+		return fmt.Errorf("unable to serialize w/ unknown "+
+			"version: %v", s.Version)
+	}
+
+	var isInitiator uint8
+	if s.IsInitiator {
+		isInitiator = 1
+	}
+
+	// First we gather the SCB as is into a temporary buffer so we can
+	// determine the total length. Before we write out the serialized SCB,
+	// we write the length which allows us to skip any Singles that we
+	// don't know of when decoding a multi.
+	var singleBytes bytes.Buffer
+	if err := lnwire.WriteElements(&singleBytes, isInitiator); err != nil {
+		return err
+	}
+	if _, err := singleBytes.Write(s.ChainHash[:]); err != nil {
+		return err
+	}
+	err := lnwire.WriteElements(
+		&singleBytes, s.FundingOutpoint, s.ShortChannelID,
+		s.RemoteNodePub, s.Addresses, s.Capacity,
+	)
+	if err != nil {
+		return err
+	}
+	if err := writeChanConfig(&singleBytes, &s.LocalChanCfg); err != nil {
+		return err
+	}
+	if err := writeChanConfig(&singleBytes, &s.RemoteChanCfg); err != nil {
+		return err
+	}
+	if err := writeKeyDesc(&singleBytes, &s.ShaChainRootDesc); err != nil {
+		return err
+	}
+
+	return lnwire.WriteElements(
+		w, byte(s.Version), uint16(len(singleBytes.Bytes())),
+		singleBytes.Bytes(),
+	)
+}
+
+// PackToWriter is similar to the Serialize method, but takes the operation a
+// step further by encrypting the raw bytes of the static channel backup. For
+// encryption we use the chacha20poly1305 AEAD cipher with a random 12-byte
+// nonce. The key used for encryption is derived from the
+// keychain.KeyFamilyStaticBackup family of the passed keyRing, see
+// genEncryptionKey for the details. When using the AEAD, we pass the nonce as
+// associated data such that we'll be able to package the two together for
+// storage. Before writing out the encrypted payload, we prepend the nonce to
+// the final blob.
+func (s *Single) PackToWriter(w io.Writer, keyRing keychain.KeyRing) error {
+	// First, we'll serialize the SCB (StaticChannelBackup) into a
+	// temporary buffer so we can store it in a temporary place before we
+	// go to encrypt the entire thing.
+	var rawBytes bytes.Buffer
+	if err := s.Serialize(&rawBytes); err != nil {
+		return err
+	}
+
+	// Finally, we'll encrypt the raw serialized SCB (using the nonce as
+	// associated data), and write out the ciphertext prepend with the
+	// nonce that we used to the passed io.Reader.
+	return encryptPayloadToWriter(rawBytes, w, keyRing)
+}
+
+// Deserialize attempts to read the raw plaintext serialized SCB from the
+// passed io.Reader. If the method is successful, then the target
+// StaticChannelBackup will be fully populated.
+func (s *Single) Deserialize(r io.Reader) error {
+	// First, we'll need to read the version of this single-back up so we
+	// can know how to unpack each of the SCB.
+	var version byte
+	err := lnwire.ReadElements(r, &version)
+	if err != nil {
+		return err
+	}
+
+	s.Version = SingleBackupVersion(version)
+
+	switch s.Version {
+	case DefaultSingleVersion:
+	case AnchorsCommitVersion:
+	default:
+		return fmt.Errorf("unable to de-serialize w/ unknown "+
+			"version: %v", s.Version)
+	}
+
+	var length uint16
+	if err := lnwire.ReadElements(r, &length); err != nil {
+		return err
+	}
+
+	var isInitiator uint8
+	if err := lnwire.ReadElements(r, &isInitiator); err != nil {
+		return err
+	}
+	s.IsInitiator = isInitiator == 1
+
+	if _, err := io.ReadFull(r, s.ChainHash[:]); err != nil {
+		return err
+	}
+
+	err = lnwire.ReadElements(
+		r, &s.FundingOutpoint, &s.ShortChannelID, &s.RemoteNodePub,
+		&s.Addresses, &s.Capacity,
+	)
+	if err != nil {
+		return err
+	}
+	if err := readChanConfig(r, &s.LocalChanCfg); err != nil {
+		return err
+	}
+	if err := readChanConfig(r, &s.RemoteChanCfg); err != nil {
+		return err
+	}
+
+	return readKeyDesc(r, &s.ShaChainRootDesc)
+}
+
+// UnpackFromReader is similar to Deserialize method, but it expects the passed
+// io.Reader to contain an encrypt SCB. Refer to the PackToWriter method for
+// details w.r.t the encryption scheme used. If we're unable to decrypt the
+// payload for whatever reason (wrong key, wrong nonce, etc), then this method
+// will return an error.
+func (s *Single) UnpackFromReader(r io.Reader, keyRing keychain.KeyRing) error {
+	plaintext, err := decryptPayloadFromReader(r, keyRing)
+	if err != nil {
+		return err
+	}
+
+	// Finally, we'll pack the bytes into a reader to we can deserialize
+	// the plaintext bytes of the SCB.
+	backupReader := bytes.NewReader(plaintext)
+	return s.Deserialize(backupReader)
+}
+
+// PackStaticChanBackups accepts a set of existing open channels, and a
+// keychain.KeyRing, and returns a map of outpoints to the serialized+encrypted
+// static channel backups. The passed keyRing should be backed by the users
+// root HD seed in order to ensure full determinism.
+func PackStaticChanBackups(backups []Single,
+	keyRing keychain.KeyRing) (map[wire.OutPoint][]byte, error) {
+
+	packedBackups := make(map[wire.OutPoint][]byte)
+	for _, chanBackup := range backups {
+		chanPoint := chanBackup.FundingOutpoint
+
+		var b bytes.Buffer
+		err := chanBackup.PackToWriter(&b, keyRing)
+		if err != nil {
+			return nil, fmt.Errorf("unable to pack chan backup "+
+				"for %v: %v", chanPoint, err)
+		}
+
+		packedBackups[chanPoint] = b.Bytes()
+	}
+
+	return packedBackups, nil
+}
+
+// PackedSingles represents a series of fully packed SCBs. This may be the
+// combination of a series of individual SCBs in order to batch their
+// unpacking.
+type PackedSingles [][]byte
+
+// Unpack attempts to decrypt the passed set of encrypted SCBs and deserialize
+// each one into a new SCB struct. The passed keyRing should be backed by the
+// same HD seed as was used to encrypt the set of backups in the first place.
+// If we're unable to decrypt any of the back ups, then we'll return an error.
+func (p PackedSingles) Unpack(keyRing keychain.KeyRing) ([]Single, error) {
+	backups := make([]Single, len(p))
+	for i, encryptedBackup := range p {
+		var backup Single
+
+		backupReader := bytes.NewReader(encryptedBackup)
+		err := backup.UnpackFromReader(backupReader, keyRing)
+		if err != nil {
+			return nil, err
+		}
+
+		backups[i] = backup
+	}
+
+	return backups, nil
+}
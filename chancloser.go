@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/davecgh/go-spew/spew"
@@ -27,6 +28,19 @@ var (
 	// ErrInvalidState is returned when the closing state machine receives
 	// a message while it is in an unknown state.
 	ErrInvalidState = fmt.Errorf("invalid state")
+
+	// ErrUpfrontShutdownScriptMismatch is returned when the remote party
+	// sends a shutdown message with a delivery script that differs from
+	// the upfront shutdown script it committed to when opening the
+	// channel.
+	ErrUpfrontShutdownScriptMismatch = fmt.Errorf("shutdown script does " +
+		"not match upfront shutdown script")
+
+	// ErrProposalExceedsMaxFee is returned when the remote party insists
+	// on a closing transaction fee above the maximum fee we're willing to
+	// pay, after we've already offered our maximum fee.
+	ErrProposalExceedsMaxFee = fmt.Errorf("latest fee proposal exceeds " +
+		"max fee")
 )
 
 // closeState represents all the possible states the channel closer state
@@ -114,6 +128,11 @@ type channelCloser struct {
 	// offer when starting negotiation. This will be used as a baseline.
 	idealFeeSat btcutil.Amount
 
+	// maxFeeSat is the maximum fee that we're willing to pay for the
+	// closing transaction. We'll never propose or accept a fee above it.
+	// If zero, then the negotiated fee isn't constrained.
+	maxFeeSat btcutil.Amount
+
 	// lastFeeProposal is the last fee that we proposed to the remote
 	// party. We'll use this as a pivot point to rachet our next offer up,
 	// or down, or simply accept the remote party's prior offer.
@@ -174,6 +193,20 @@ func newChannelCloser(cfg chanCloseCfg, deliveryScript []byte,
 		idealFeeSat = channelCommitFee
 	}
 
+	// If the initiator of the closure specified a maximum fee, then we'll
+	// also ensure that our ideal fee doesn't exceed it.
+	var maxFeeSat btcutil.Amount
+	if closeReq != nil && closeReq.MaxFeePerKw != 0 {
+		maxFeeSat = cfg.channel.CalcFee(closeReq.MaxFeePerKw)
+		if idealFeeSat > maxFeeSat {
+			peerLog.Infof("Ideal starting fee of %v is greater "+
+				"than max fee of %v, clamping",
+				int64(idealFeeSat), int64(maxFeeSat))
+
+			idealFeeSat = maxFeeSat
+		}
+	}
+
 	peerLog.Infof("Ideal fee for closure of ChannelPoint(%v) is: %v sat",
 		cfg.channel.ChannelPoint(), int64(idealFeeSat))
 
@@ -186,6 +219,7 @@ func newChannelCloser(cfg chanCloseCfg, deliveryScript []byte,
 		cfg:                 cfg,
 		negotiationHeight:   negotiationHeight,
 		idealFeeSat:         idealFeeSat,
+		maxFeeSat:           maxFeeSat,
 		closeCtx:            closeCtx,
 		localDeliveryScript: deliveryScript,
 		priorFeeOffers:      make(map[btcutil.Amount]*lnwire.ClosingSigned),
@@ -264,6 +298,28 @@ func (c *channelCloser) CloseRequest() *htlcswitch.ChanClose {
 	return c.closeReq
 }
 
+// validateShutdownScript returns an error if the remote party committed to an
+// upfront shutdown script when the channel was opened, and the passed delivery
+// script from their shutdown message doesn't match it.
+func (c *channelCloser) validateShutdownScript(
+	deliveryScript lnwire.DeliveryAddress) error {
+
+	upfrontScript := c.cfg.channel.State().RemoteShutdownScript
+	if len(upfrontScript) == 0 {
+		return nil
+	}
+
+	if !bytes.Equal(upfrontScript, deliveryScript) {
+		peerLog.Warnf("ChannelPoint(%v): remote shutdown script %x "+
+			"doesn't match upfront shutdown script %x",
+			c.chanPoint, deliveryScript, upfrontScript)
+
+		return ErrUpfrontShutdownScriptMismatch
+	}
+
+	return nil
+}
+
 // ProcessCloseMsg attempts to process the next message in the closing series.
 // This method will update the state accordingly and return two primary values:
 // the next set of messages to be sent, and a bool indicating if the fee
@@ -284,6 +340,14 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 				"instead have %v", spew.Sdump(msg))
 		}
 
+		// If the other party committed to an upfront shutdown script
+		// when the channel was opened, then we'll ensure that their
+		// shutdown message pays out to it.
+		err := c.validateShutdownScript(shutDownMsg.Address)
+		if err != nil {
+			return nil, false, err
+		}
+
 		// Next, we'll note the other party's preference for their
 		// delivery address. We'll use this when we craft the closure
 		// transaction.
@@ -334,6 +398,13 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 				"instead have %v", spew.Sdump(msg))
 		}
 
+		// Before recording their delivery script, we'll ensure that it
+		// matches any upfront shutdown script they committed to.
+		err := c.validateShutdownScript(shutDownMsg.Address)
+		if err != nil {
+			return nil, false, err
+		}
+
 		// Now that we know this is a valid shutdown message, we'll
 		// record their preferred delivery closing script.
 		c.remoteDeliveryScript = shutDownMsg.Address
@@ -379,6 +450,21 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 		// prior offers, then we'll attempt to rachet the fee closer to
 		remoteProposedFee := closeSignedMsg.FeeSatoshis
 		if _, ok := c.priorFeeOffers[remoteProposedFee]; !ok {
+			// If the remote party is still proposing a fee above
+			// our maximum after we've already offered it, then
+			// there's no fee we can agree on, so we'll fail the
+			// negotiation.
+			if c.maxFeeSat != 0 && remoteProposedFee > c.maxFeeSat &&
+				c.lastFeeProposal == c.maxFeeSat {
+
+				peerLog.Warnf("ChannelPoint(%v): remote fee "+
+					"proposal of %v exceeds max fee of %v",
+					c.chanPoint, int64(remoteProposedFee),
+					int64(c.maxFeeSat))
+
+				return nil, false, ErrProposalExceedsMaxFee
+			}
+
 			// We'll now attempt to rachet towards a fee deemed
 			// acceptable by both parties, factoring in our ideal
 			// fee rate, and the last proposed fee by both sides.
@@ -387,6 +473,17 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 				remoteProposedFee,
 			)
 
+			// We'll never propose a fee above our maximum, so
+			// we'll clamp our compromise to it if needed.
+			if c.maxFeeSat != 0 && feeProposal > c.maxFeeSat {
+				peerLog.Infof("ChannelPoint(%v): compromise "+
+					"fee of %v exceeds max fee, clamping "+
+					"to %v", c.chanPoint, int64(feeProposal),
+					int64(c.maxFeeSat))
+
+				feeProposal = c.maxFeeSat
+			}
+
 			// With our new fee proposal calculated, we'll craft a
 			// new close signed signature to send to the other
 			// party so we can continue the fee negotiation
@@ -1,10 +1,13 @@
 package channeldb
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"net"
 
 	"github.com/btcsuite/go-socks/socks"
+	"github.com/lightningnetwork/lnd/torsvc"
 )
 
 // addressType specifies the network protocol and version that should be used
@@ -59,6 +62,62 @@ func encodeTCPAddr(w io.Writer, addr *net.TCPAddr) error {
 	return nil
 }
 
+// encodeOnionAddr serializes an onion address into its compact raw bytes
+// representation.
+func encodeOnionAddr(w io.Writer, addr *torsvc.OnionAddr) error {
+	var suffixIndex int
+	hostLen := len(addr.OnionService)
+	switch hostLen {
+	case torsvc.V2Len:
+		if _, err := w.Write([]byte{uint8(v2OnionAddr)}); err != nil {
+			return err
+		}
+		suffixIndex = torsvc.V2Len - torsvc.OnionSuffixLen
+	case torsvc.V3Len:
+		if _, err := w.Write([]byte{uint8(v3OnionAddr)}); err != nil {
+			return err
+		}
+		suffixIndex = torsvc.V3Len - torsvc.OnionSuffixLen
+	default:
+		return errors.New("unknown onion service length")
+	}
+
+	suffix := addr.OnionService[suffixIndex:]
+	if suffix != torsvc.OnionSuffix {
+		return fmt.Errorf("invalid suffix \"%v\"", suffix)
+	}
+
+	host, err := torsvc.Base32Encoding.DecodeString(
+		addr.OnionService[:suffixIndex],
+	)
+	if err != nil {
+		return err
+	}
+
+	// Sanity check the decoded length.
+	switch {
+	case hostLen == torsvc.V2Len && len(host) != torsvc.V2DecodedLen:
+		return fmt.Errorf("onion service %v decoded to invalid host %x",
+			addr.OnionService, host)
+
+	case hostLen == torsvc.V3Len && len(host) != torsvc.V3DecodedLen:
+		return fmt.Errorf("onion service %v decoded to invalid host %x",
+			addr.OnionService, host)
+	}
+
+	if _, err := w.Write(host); err != nil {
+		return err
+	}
+
+	var port [2]byte
+	byteOrder.PutUint16(port[:], uint16(addr.Port))
+	if _, err := w.Write(port[:]); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // deserializeAddr reads the serialized raw representation of an address and
 // deserializes it into the actual address, to avoid performing address
 // resolution in the database module
@@ -70,7 +129,6 @@ func deserializeAddr(r io.Reader) (net.Addr, error) {
 		return nil, err
 	}
 
-	// TODO(roasbeef): also add onion addrs
 	switch addressType(scratch[0]) {
 	case tcp4Addr:
 		addr := &net.TCPAddr{}
@@ -96,6 +154,44 @@ func deserializeAddr(r io.Reader) (net.Addr, error) {
 		}
 		addr.Port = int(byteOrder.Uint16(scratch[:2]))
 		address = addr
+	case v2OnionAddr:
+		var h [torsvc.V2DecodedLen]byte
+		if _, err := io.ReadFull(r, h[:]); err != nil {
+			return nil, err
+		}
+
+		var p [2]byte
+		if _, err := io.ReadFull(r, p[:]); err != nil {
+			return nil, err
+		}
+
+		onionService := torsvc.Base32Encoding.EncodeToString(h[:])
+		onionService += torsvc.OnionSuffix
+		port := int(byteOrder.Uint16(p[:]))
+
+		address = &torsvc.OnionAddr{
+			OnionService: onionService,
+			Port:         port,
+		}
+	case v3OnionAddr:
+		var h [torsvc.V3DecodedLen]byte
+		if _, err := io.ReadFull(r, h[:]); err != nil {
+			return nil, err
+		}
+
+		var p [2]byte
+		if _, err := io.ReadFull(r, p[:]); err != nil {
+			return nil, err
+		}
+
+		onionService := torsvc.Base32Encoding.EncodeToString(h[:])
+		onionService += torsvc.OnionSuffix
+		port := int(byteOrder.Uint16(p[:]))
+
+		address = &torsvc.OnionAddr{
+			OnionService: onionService,
+			Port:         port,
+		}
 	default:
 		return nil, ErrUnknownAddressType
 	}
@@ -110,6 +206,8 @@ func serializeAddr(w io.Writer, address net.Addr) error {
 	switch addr := address.(type) {
 	case *net.TCPAddr:
 		return encodeTCPAddr(w, addr)
+	case *torsvc.OnionAddr:
+		return encodeOnionAddr(w, addr)
 
 	// If this is a proxied address (due to the connection being
 	// established over a SOCKs proxy, then we'll convert it into its
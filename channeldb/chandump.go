@@ -0,0 +1,378 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// chanDumpVersion is the current version of the ChannelDump
+	// serialization format.
+	chanDumpVersion byte = 0
+
+	// maxDumpValueSize is the maximum size of a single key or value
+	// within a serialized bucket. A commitment carrying the maximum
+	// number of HTLCs, along with their onion blobs, comfortably fits
+	// within this limit.
+	maxDumpValueSize = 1 << 24
+
+	// dumpEntryValue and dumpEntryBucket indicate whether a serialized
+	// bucket entry is a plain value or a nested bucket.
+	dumpEntryValue  byte = 0
+	dumpEntryBucket byte = 1
+)
+
+// ChannelDump is a portable snapshot of the complete on-disk state of a single
+// open channel, intended to allow reproducing channel state machine failures
+// outside of the node they occurred on. Rather than re-encoding the channel
+// state, the dump contains a verbatim copy of the channel's bucket, which
+// includes both commitments, the revocation state, the pending remote
+// commitment and the revocation log, along with the channel's forwarding
+// packages. This ensures any corruption of the stored state is preserved
+// within the dump.
+//
+// NOTE: The dump contains the root of our revocation tree for the channel, as
+// well as the secrets revealed by the remote party. Anybody in possession of
+// a dump is therefore able to revoke our commitments, and punish the remote
+// party for broadcasting theirs. Dumps should only be shared with trusted
+// parties, and only for channels that are already closed.
+type ChannelDump struct {
+	// DbVersion is the version of the database the dump was created from.
+	// A dump can only be restored into a database of the same version.
+	DbVersion uint32
+
+	// ChainHash is the genesis hash of the chain the channel resides on.
+	ChainHash chainhash.Hash
+
+	// IdentityPub is the identity public key of the remote party.
+	IdentityPub *btcec.PublicKey
+
+	// ChanPoint is the outpoint of the channel's funding output.
+	ChanPoint wire.OutPoint
+
+	// ShortChanID is the short channel ID of the channel, under which its
+	// forwarding packages are stored.
+	ShortChanID lnwire.ShortChannelID
+
+	// ChanState holds the serialized contents of the channel's bucket.
+	ChanState []byte
+
+	// FwdPkgs holds the serialized contents of the channel's forwarding
+	// package bucket. This will be empty if the channel has no forwarding
+	// packages.
+	FwdPkgs []byte
+}
+
+// DumpChannel creates a ChannelDump of the open channel identified by the
+// passed channel point.
+func (d *DB) DumpChannel(chanPoint *wire.OutPoint) (*ChannelDump, error) {
+	meta, err := d.FetchMeta(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var chanPointBuf bytes.Buffer
+	if err := writeOutpoint(&chanPointBuf, chanPoint); err != nil {
+		return nil, err
+	}
+
+	dump := &ChannelDump{
+		DbVersion: meta.DbVersionNumber,
+		ChanPoint: *chanPoint,
+	}
+	err = d.View(func(tx *bolt.Tx) error {
+		openChanBucket := tx.Bucket(openChannelBucket)
+		if openChanBucket == nil {
+			return ErrNoActiveChannels
+		}
+
+		// As channels are indexed by the identity of the remote party
+		// and the chain they reside on, we'll need to scan through
+		// each node's chain buckets in order to locate the channel.
+		var chanBucket *bolt.Bucket
+		err := openChanBucket.ForEach(func(nodePub, v []byte) error {
+			nodeChanBucket := openChanBucket.Bucket(nodePub)
+			if v != nil || nodeChanBucket == nil {
+				return nil
+			}
+
+			return nodeChanBucket.ForEach(func(chainHash, v []byte) error {
+				chainBucket := nodeChanBucket.Bucket(chainHash)
+				if v != nil || chainBucket == nil {
+					return nil
+				}
+
+				bucket := chainBucket.Bucket(chanPointBuf.Bytes())
+				if bucket == nil {
+					return nil
+				}
+
+				identityPub, err := btcec.ParsePubKey(
+					nodePub, btcec.S256(),
+				)
+				if err != nil {
+					return err
+				}
+
+				chanBucket = bucket
+				dump.IdentityPub = identityPub
+				copy(dump.ChainHash[:], chainHash)
+
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+		if chanBucket == nil {
+			return ErrChannelNotFound
+		}
+
+		// We'll decode the channel to obtain its short channel ID,
+		// which is required to locate its forwarding packages.
+		channel, err := fetchOpenChannel(chanBucket, chanPoint)
+		if err != nil {
+			return err
+		}
+		dump.ShortChanID = channel.ShortChanID
+
+		var chanState bytes.Buffer
+		if err := dumpBucket(&chanState, chanBucket); err != nil {
+			return err
+		}
+		dump.ChanState = chanState.Bytes()
+
+		fwdPkgBkt := tx.Bucket(fwdPackagesKey)
+		if fwdPkgBkt == nil {
+			return nil
+		}
+
+		source := makeLogKey(channel.ShortChanID.ToUint64())
+		sourceBkt := fwdPkgBkt.Bucket(source[:])
+		if sourceBkt == nil {
+			return nil
+		}
+
+		var fwdPkgs bytes.Buffer
+		if err := dumpBucket(&fwdPkgs, sourceBkt); err != nil {
+			return err
+		}
+		dump.FwdPkgs = fwdPkgs.Bytes()
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dump, nil
+}
+
+// RestoreChannelDump writes the channel state contained within the passed
+// dump to the database, returning the restored channel. The channel must not
+// already exist within the database. This is intended to be used with a
+// fresh, temporary database in order to inspect or replay the state of a
+// channel from another node.
+func (d *DB) RestoreChannelDump(dump *ChannelDump) (*OpenChannel, error) {
+	meta, err := d.FetchMeta(nil)
+	if err != nil {
+		return nil, err
+	}
+	if meta.DbVersionNumber != dump.DbVersion {
+		return nil, fmt.Errorf("channel dump was created from db "+
+			"version %v, but db is at version %v", dump.DbVersion,
+			meta.DbVersionNumber)
+	}
+
+	var channel *OpenChannel
+	err = d.Update(func(tx *bolt.Tx) error {
+		chanBucket, err := updateChanBucket(
+			tx, dump.IdentityPub, &dump.ChanPoint, dump.ChainHash,
+		)
+		if err != nil {
+			return err
+		}
+		if chanBucket.Get(chanInfoKey) != nil {
+			return fmt.Errorf("channel %v already exists",
+				dump.ChanPoint)
+		}
+
+		err = restoreBucket(bytes.NewReader(dump.ChanState), chanBucket)
+		if err != nil {
+			return fmt.Errorf("unable to restore channel state: %v",
+				err)
+		}
+
+		if len(dump.FwdPkgs) != 0 {
+			fwdPkgBkt, err := tx.CreateBucketIfNotExists(
+				fwdPackagesKey,
+			)
+			if err != nil {
+				return err
+			}
+
+			source := makeLogKey(dump.ShortChanID.ToUint64())
+			sourceBkt, err := fwdPkgBkt.CreateBucket(source[:])
+			if err != nil {
+				return err
+			}
+
+			err = restoreBucket(
+				bytes.NewReader(dump.FwdPkgs), sourceBkt,
+			)
+			if err != nil {
+				return fmt.Errorf("unable to restore forwarding "+
+					"packages: %v", err)
+			}
+		}
+
+		channel, err = fetchOpenChannel(chanBucket, &dump.ChanPoint)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	channel.Db = d
+
+	return channel, nil
+}
+
+// Serialize writes the channel dump to the passed io.Writer.
+func (c *ChannelDump) Serialize(w io.Writer) error {
+	if _, err := w.Write([]byte{chanDumpVersion}); err != nil {
+		return err
+	}
+
+	err := writeElements(
+		w, c.DbVersion, c.ChainHash, c.IdentityPub, c.ChanPoint,
+		c.ShortChanID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := wire.WriteVarBytes(w, 0, c.ChanState); err != nil {
+		return err
+	}
+
+	return wire.WriteVarBytes(w, 0, c.FwdPkgs)
+}
+
+// Deserialize reads a channel dump previously written with Serialize from the
+// passed io.Reader.
+func (c *ChannelDump) Deserialize(r io.Reader) error {
+	var version [1]byte
+	if _, err := io.ReadFull(r, version[:]); err != nil {
+		return err
+	}
+	if version[0] != chanDumpVersion {
+		return fmt.Errorf("unknown channel dump version: %v",
+			version[0])
+	}
+
+	err := readElements(
+		r, &c.DbVersion, &c.ChainHash, &c.IdentityPub, &c.ChanPoint,
+		&c.ShortChanID,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.ChanState, err = wire.ReadVarBytes(
+		r, 0, bolt.MaxValueSize, "chan state",
+	)
+	if err != nil {
+		return err
+	}
+
+	c.FwdPkgs, err = wire.ReadVarBytes(
+		r, 0, bolt.MaxValueSize, "fwd pkgs",
+	)
+	return err
+}
+
+// dumpBucket serializes the contents of a bucket, recursing into any nested
+// buckets. Each entry is written as its key, followed by a byte indicating
+// whether the entry is a value or a nested bucket, then either the value or
+// the serialized nested bucket. As bolt doesn't allow empty keys, the end of
+// the bucket is marked with an empty key.
+func dumpBucket(w io.Writer, bucket *bolt.Bucket) error {
+	err := bucket.ForEach(func(k, v []byte) error {
+		if err := wire.WriteVarBytes(w, 0, k); err != nil {
+			return err
+		}
+
+		if v == nil {
+			_, err := w.Write([]byte{dumpEntryBucket})
+			if err != nil {
+				return err
+			}
+
+			return dumpBucket(w, bucket.Bucket(k))
+		}
+
+		if _, err := w.Write([]byte{dumpEntryValue}); err != nil {
+			return err
+		}
+
+		return wire.WriteVarBytes(w, 0, v)
+	})
+	if err != nil {
+		return err
+	}
+
+	return wire.WriteVarBytes(w, 0, nil)
+}
+
+// restoreBucket writes the contents of a bucket serialized with dumpBucket
+// into the passed bucket.
+func restoreBucket(r io.Reader, bucket *bolt.Bucket) error {
+	for {
+		k, err := wire.ReadVarBytes(r, 0, maxDumpValueSize, "key")
+		if err != nil {
+			return err
+		}
+		if len(k) == 0 {
+			return nil
+		}
+
+		var entryType [1]byte
+		if _, err := io.ReadFull(r, entryType[:]); err != nil {
+			return err
+		}
+
+		switch entryType[0] {
+		case dumpEntryBucket:
+			nested, err := bucket.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+			if err := restoreBucket(r, nested); err != nil {
+				return err
+			}
+
+		case dumpEntryValue:
+			v, err := wire.ReadVarBytes(
+				r, 0, maxDumpValueSize, "value",
+			)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(k, v); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unknown bucket entry type: %v",
+				entryType[0])
+		}
+	}
+}
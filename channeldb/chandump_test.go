@@ -0,0 +1,160 @@
+package channeldb
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestChannelDumpRestore tests that the full state of a channel, including its
+// revocation log, pending remote commitment and forwarding packages, can be
+// dumped, serialized, and restored into another database.
+func TestChannelDumpRestore(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	channel, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := channel.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	// We'll extend a new commitment to the remote party which adds an
+	// HTLC, then advance the tail of their commitment chain, which will
+	// populate both the revocation log and the forwarding packages of the
+	// channel.
+	logUpdates := []LogUpdate{
+		{
+			LogIndex: 0,
+			UpdateMsg: &lnwire.UpdateAddHTLC{
+				ID:     0,
+				Amount: lnwire.NewMSatFromSatoshis(100),
+				Expiry: 25,
+			},
+		},
+	}
+	commitDiff := &CommitDiff{
+		Commitment: channel.RemoteCommitment,
+		CommitSig: &lnwire.CommitSig{
+			ChanID:    lnwire.ChannelID(key),
+			CommitSig: wireSig,
+		},
+		LogUpdates:        logUpdates,
+		OpenedCircuitKeys: []CircuitKey{},
+		ClosedCircuitKeys: []CircuitKey{},
+	}
+	commitDiff.Commitment.CommitHeight = 1
+	if err := channel.AppendRemoteCommitChain(commitDiff); err != nil {
+		t.Fatalf("unable to add to commit chain: %v", err)
+	}
+
+	fwdPkg := NewFwdPkg(channel.ShortChanID, 1, logUpdates, nil)
+	if err := channel.AdvanceCommitChainTail(fwdPkg); err != nil {
+		t.Fatalf("unable to advance commit chain tail: %v", err)
+	}
+
+	// Finally, we'll extend yet another commitment to the remote party,
+	// which will remain pending within the dump.
+	commitDiff.Commitment.CommitHeight = 2
+	commitDiff.LogUpdates = []LogUpdate{}
+	if err := channel.AppendRemoteCommitChain(commitDiff); err != nil {
+		t.Fatalf("unable to add to commit chain: %v", err)
+	}
+
+	// Dumping an unknown channel should fail.
+	unknownChanPoint := wire.OutPoint{Hash: key, Index: 1}
+	if _, err := cdb.DumpChannel(&unknownChanPoint); err != ErrChannelNotFound {
+		t.Fatalf("expected ErrChannelNotFound, got %v", err)
+	}
+
+	dump, err := cdb.DumpChannel(&channel.FundingOutpoint)
+	if err != nil {
+		t.Fatalf("unable to dump channel: %v", err)
+	}
+
+	// The dump should survive a serialization round trip unaltered.
+	var b bytes.Buffer
+	if err := dump.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize dump: %v", err)
+	}
+	var dump2 ChannelDump
+	if err := dump2.Deserialize(&b); err != nil {
+		t.Fatalf("unable to deserialize dump: %v", err)
+	}
+	if !reflect.DeepEqual(dump, &dump2) {
+		t.Fatalf("dumps don't match: expected %v, got %v",
+			spew.Sdump(dump), spew.Sdump(&dump2))
+	}
+
+	// We'll now restore the dump into a fresh database, and ensure the
+	// full state of the channel was carried over.
+	restoreDB, cleanUp2, err := makeTestDB()
+	defer cleanUp2()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	restored, err := restoreDB.RestoreChannelDump(&dump2)
+	if err != nil {
+		t.Fatalf("unable to restore channel dump: %v", err)
+	}
+
+	if restored.ShortChanID != channel.ShortChanID {
+		t.Fatalf("short chan ids don't match: expected %v, got %v",
+			channel.ShortChanID, restored.ShortChanID)
+	}
+	assertCommitmentEqual(
+		t, &channel.LocalCommitment, &restored.LocalCommitment,
+	)
+	assertCommitmentEqual(
+		t, &channel.RemoteCommitment, &restored.RemoteCommitment,
+	)
+
+	prevCommit, err := restored.FindPreviousState(0)
+	if err != nil {
+		t.Fatalf("unable to fetch revocation log entry: %v", err)
+	}
+	origPrevCommit, err := channel.FindPreviousState(0)
+	if err != nil {
+		t.Fatalf("unable to fetch revocation log entry: %v", err)
+	}
+	assertCommitmentEqual(t, origPrevCommit, prevCommit)
+
+	commitTip, err := restored.RemoteCommitChainTip()
+	if err != nil {
+		t.Fatalf("unable to fetch commit chain tip: %v", err)
+	}
+	if !reflect.DeepEqual(commitDiff, commitTip) {
+		t.Fatalf("commit diffs don't match: expected %v, got %v",
+			spew.Sdump(commitDiff), spew.Sdump(commitTip))
+	}
+
+	fwdPkgs, err := restored.LoadFwdPkgs()
+	if err != nil {
+		t.Fatalf("unable to load fwd pkgs: %v", err)
+	}
+	origFwdPkgs, err := channel.LoadFwdPkgs()
+	if err != nil {
+		t.Fatalf("unable to load fwd pkgs: %v", err)
+	}
+	if !reflect.DeepEqual(origFwdPkgs, fwdPkgs) {
+		t.Fatalf("fwd pkgs don't match: expected %v, got %v",
+			spew.Sdump(origFwdPkgs), spew.Sdump(fwdPkgs))
+	}
+
+	// Restoring the same dump twice should fail, as the channel already
+	// exists.
+	if _, err := restoreDB.RestoreChannelDump(&dump2); err == nil {
+		t.Fatalf("expected restoring existing channel to fail")
+	}
+}
@@ -72,6 +72,22 @@ var (
 	// bucket represent the remote height at which these htlcs were
 	// accepted.
 	fwdPackageLogBucket = []byte("fwd-package-log-key")
+
+	// chanRestoredKey is set within the bucket of a channel that has been
+	// restored from a static channel backup. The presence of this key
+	// indicates that the channel only contains the minimal amount of state
+	// required to sweep our funds once the remote party force closes.
+	chanRestoredKey = []byte("chan-restored-key")
+
+	// revocationKeyLocKey stores the key locator of the key that was used
+	// to derive the root of our revocation tree for a particular channel.
+	revocationKeyLocKey = []byte("revocation-key-locator-key")
+
+	// dataLossCommitPointKey stores the commitment point received from the
+	// remote party during channel reestablishment in the case that we've
+	// detected that we've lost channel state. This point allows us to
+	// sweep our funds from their commitment once they force close.
+	dataLossCommitPointKey = []byte("data-loss-commit-point-key")
 )
 
 var (
@@ -97,12 +113,18 @@ var (
 	// decoded because the byte slice is of an invalid length.
 	ErrInvalidCircuitKeyLen = fmt.Errorf(
 		"length of serialized circuit key must be 16 bytes")
+
+	// ErrNoCommitPoint is returned when no data loss commit point is found
+	// in the database.
+	ErrNoCommitPoint = fmt.Errorf("no commit point found")
 )
 
 // ChannelType is an enum-like type that describes one of several possible
 // channel types. Each open channel is associated with a particular type as the
 // channel type may determine how higher level operations are conducted such as
-// fee negotiation, channel closing, the format of HTLCs, etc.
+// fee negotiation, channel closing, the format of HTLCs, etc. Aside from the
+// funding type held in the lowest bit, the type carries a set of bits that
+// describe the format of the channel's commitment transactions.
 // TODO(roasbeef): split up per-chain?
 type ChannelType uint8
 
@@ -118,8 +140,26 @@ const (
 	// funds towards the total capacity of the channel. The channel may be
 	// funded symmetrically or asymmetrically.
 	DualFunder = 1
+
+	// AnchorOutputsBit indicates that the channel makes use of the anchor
+	// commitment format, wherein each commitment transaction carries an
+	// additional small output for each party, which can be used to bump
+	// the fee of the commitment through CPFP.
+	AnchorOutputsBit ChannelType = 1 << 1
 )
 
+// IsSingleFunder returns true if the channel type is one of the known single
+// funder variants.
+func (c ChannelType) IsSingleFunder() bool {
+	return c&DualFunder == 0
+}
+
+// HasAnchors returns true if the channel makes use of the anchor commitment
+// format.
+func (c ChannelType) HasAnchors() bool {
+	return c&AnchorOutputsBit == AnchorOutputsBit
+}
+
 // ChannelConstraints represents a set of constraints meant to allow a node to
 // limit their exposure, enact flow control and ensure that all HTLCs are
 // economically relevant This struct will be mirrored for both sides of the
@@ -327,6 +367,12 @@ type OpenChannel struct {
 	// Channels in this state should never be added to the htlc switch.
 	IsBorked bool
 
+	// IsRestored indicates that this channel was restored from a static
+	// channel backup. Restored channels only contain the state required
+	// to sweep our funds on chain once the remote party force closes, so
+	// they're always borked as well.
+	IsRestored bool
+
 	// FundingBroadcastHeight is the height in which the funding
 	// transaction was broadcast. This value can be used by higher level
 	// sub-systems to determine if a channel is stale and/or should have
@@ -363,6 +409,17 @@ type OpenChannel struct {
 	// RemoteChanCfg is the channel configuration for the remote node.
 	RemoteChanCfg ChannelConfig
 
+	// LocalShutdownScript is the script to which we committed to pay our
+	// funds upon a cooperative close of the channel during its funding.
+	// If empty, we didn't commit to any script.
+	LocalShutdownScript lnwire.DeliveryAddress
+
+	// RemoteShutdownScript is the script to which the remote party
+	// committed to pay its funds upon a cooperative close of the channel
+	// during its funding. If non-empty, any Shutdown message from the
+	// remote party paying to a different script must be rejected.
+	RemoteShutdownScript lnwire.DeliveryAddress
+
 	// LocalCommitment is the current local commitment state for the local
 	// party. This is stored distinct from the state of of the remote party
 	// as there are certain asymmetric parameters which affect the
@@ -398,6 +455,15 @@ type OpenChannel struct {
 	// implementation of secret store is shachain store.
 	RevocationStore shachain.Store
 
+	// RevocationKeyLocator is the locator of the key that was used to
+	// derive the root of our revocation tree. Storing the locator allows
+	// a static channel backup to re-derive the revocation producer without
+	// including any secret material.
+	//
+	// NOTE: This will be the zero value for channels that were created
+	// before the locator was tracked.
+	RevocationKeyLocator keychain.KeyLocator
+
 	// Packager is used to create and update forwarding packages for this
 	// channel, which encodes all necessary information to recover from
 	// failures and reforward HTLCs that were not fully processed.
@@ -595,6 +661,79 @@ func (c *OpenChannel) MarkBorked() error {
 	return nil
 }
 
+// MarkDataLoss marks the channel as borked and stores the commitment point
+// sent by the remote party during channel reestablishment once we've detected
+// that we've lost channel state. The stored point can later be used to sweep
+// our funds from the remote party's commitment transaction once they force
+// close the channel.
+func (c *OpenChannel) MarkDataLoss(commitPoint *btcec.PublicKey) error {
+	c.Lock()
+	defer c.Unlock()
+
+	var b bytes.Buffer
+	if err := writeElement(&b, commitPoint); err != nil {
+		return err
+	}
+
+	if err := c.Db.Update(func(tx *bolt.Tx) error {
+		chanBucket, err := updateChanBucket(tx, c.IdentityPub,
+			&c.FundingOutpoint, c.ChainHash)
+		if err != nil {
+			return err
+		}
+
+		channel, err := fetchOpenChannel(chanBucket, &c.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+
+		channel.IsBorked = true
+		if err := putOpenChannel(chanBucket, channel); err != nil {
+			return err
+		}
+
+		return chanBucket.Put(dataLossCommitPointKey, b.Bytes())
+	}); err != nil {
+		return err
+	}
+
+	c.IsBorked = true
+
+	return nil
+}
+
+// DataLossCommitPoint retrieves the commitment point stored by MarkDataLoss.
+// If no commitment point has been stored, then ErrNoCommitPoint is returned.
+func (c *OpenChannel) DataLossCommitPoint() (*btcec.PublicKey, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	var commitPoint *btcec.PublicKey
+	err := c.Db.View(func(tx *bolt.Tx) error {
+		chanBucket, err := readChanBucket(tx, c.IdentityPub,
+			&c.FundingOutpoint, c.ChainHash)
+		switch err {
+		case nil:
+		case ErrNoChanDBExists, ErrNoActiveChannels:
+			return ErrNoCommitPoint
+		default:
+			return err
+		}
+
+		pointBytes := chanBucket.Get(dataLossCommitPointKey)
+		if pointBytes == nil {
+			return ErrNoCommitPoint
+		}
+
+		return readElement(bytes.NewReader(pointBytes), &commitPoint)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commitPoint, nil
+}
+
 // putChannel serializes, and stores the current state of the channel in its
 // entirety.
 func putOpenChannel(chanBucket *bolt.Bucket, channel *OpenChannel) error {
@@ -616,6 +755,12 @@ func putOpenChannel(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return fmt.Errorf("unable to store chan revocations: %v", err)
 	}
 
+	// We'll also write out the information required to recover the
+	// channel from a static backup, which is stored under its own keys.
+	if err := putChanRecoveryInfo(chanBucket, channel); err != nil {
+		return fmt.Errorf("unable to store chan recovery info: %v", err)
+	}
+
 	return nil
 }
 
@@ -646,6 +791,12 @@ func fetchOpenChannel(chanBucket *bolt.Bucket,
 		return nil, fmt.Errorf("unable to fetch chan revocations: %v", err)
 	}
 
+	// We'll also read the information required to recover the channel
+	// from a static backup, if it's present.
+	if err := fetchChanRecoveryInfo(chanBucket, channel); err != nil {
+		return nil, fmt.Errorf("unable to fetch chan recovery info: %v", err)
+	}
+
 	channel.Packager = NewChannelPackager(channel.ShortChanID)
 
 	return channel, nil
@@ -1813,7 +1964,11 @@ func putChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 	}
 
 	// For single funder channels that we initiated, write the funding txn.
-	if channel.ChanType == SingleFunder && channel.IsInitiator {
+	// Channels restored from a static backup don't have the funding txn
+	// available, so we'll skip it for those.
+	if channel.ChanType.IsSingleFunder() && channel.IsInitiator &&
+		!channel.IsRestored {
+
 		if err := writeElement(&w, channel.FundingTxn); err != nil {
 			return err
 		}
@@ -1834,6 +1989,13 @@ func putChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
+	if err := writeElements(&w,
+		[]byte(channel.LocalShutdownScript),
+		[]byte(channel.RemoteShutdownScript),
+	); err != nil {
+		return err
+	}
+
 	return chanBucket.Put(chanInfoKey, w.Bytes())
 }
 
@@ -1921,7 +2083,11 @@ func fetchChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 	}
 
 	// For single funder channels that we initiated, read the funding txn.
-	if channel.ChanType == SingleFunder && channel.IsInitiator {
+	// Channels restored from a static backup won't have it stored.
+	isRestored := chanBucket.Get(chanRestoredKey) != nil
+	if channel.ChanType.IsSingleFunder() && channel.IsInitiator &&
+		!isRestored {
+
 		if err := readElement(r, &channel.FundingTxn); err != nil {
 			return err
 		}
@@ -1943,6 +2109,20 @@ func fetchChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
+	// Channels stored before upfront shutdown scripts were introduced
+	// won't have them, so we'll only read them if they're present.
+	var localShutdownScript, remoteShutdownScript []byte
+	err := readElements(r, &localShutdownScript, &remoteShutdownScript)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if len(localShutdownScript) > 0 {
+		channel.LocalShutdownScript = localShutdownScript
+	}
+	if len(remoteShutdownScript) > 0 {
+		channel.RemoteShutdownScript = remoteShutdownScript
+	}
+
 	channel.Packager = NewChannelPackager(channel.ShortChanID)
 
 	return nil
@@ -2026,6 +2206,49 @@ func fetchChanRevocationState(chanBucket *bolt.Bucket, channel *OpenChannel) err
 	return readElements(r, &channel.RemoteNextRevocation)
 }
 
+func putChanRecoveryInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var b bytes.Buffer
+	err := writeElements(
+		&b, uint32(channel.RevocationKeyLocator.Family),
+		channel.RevocationKeyLocator.Index,
+	)
+	if err != nil {
+		return err
+	}
+	if err := chanBucket.Put(revocationKeyLocKey, b.Bytes()); err != nil {
+		return err
+	}
+
+	if !channel.IsRestored {
+		return nil
+	}
+
+	return chanBucket.Put(chanRestoredKey, []byte{0x01})
+}
+
+func fetchChanRecoveryInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
+	channel.IsRestored = chanBucket.Get(chanRestoredKey) != nil
+
+	// Channels created before the revocation key locator was tracked
+	// won't have it stored, so we'll leave it as the zero value.
+	locBytes := chanBucket.Get(revocationKeyLocKey)
+	if locBytes == nil {
+		return nil
+	}
+
+	var family uint32
+	err := readElements(
+		bytes.NewReader(locBytes), &family,
+		&channel.RevocationKeyLocator.Index,
+	)
+	if err != nil {
+		return err
+	}
+	channel.RevocationKeyLocator.Family = keychain.KeyFamily(family)
+
+	return nil
+}
+
 func deleteOpenChannel(chanBucket *bolt.Bucket, chanPointBytes []byte) error {
 
 	if err := chanBucket.Delete(chanInfoKey); err != nil {
@@ -2045,6 +2268,16 @@ func deleteOpenChannel(chanBucket *bolt.Bucket, chanPointBytes []byte) error {
 		return err
 	}
 
+	if err := chanBucket.Delete(revocationKeyLocKey); err != nil {
+		return err
+	}
+	if err := chanBucket.Delete(chanRestoredKey); err != nil {
+		return err
+	}
+	if err := chanBucket.Delete(dataLossCommitPointKey); err != nil {
+		return err
+	}
+
 	if diff := chanBucket.Get(commitDiffKey); diff != nil {
 		return chanBucket.Delete(commitDiffKey)
 	}
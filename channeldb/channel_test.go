@@ -221,6 +221,11 @@ func createTestChannelState(cdb *DB) (*OpenChannel, error) {
 		Db:                      cdb,
 		Packager:                NewChannelPackager(chanID),
 		FundingTxn:              testTx,
+		RevocationKeyLocator: keychain.KeyLocator{
+			Family: keychain.KeyFamilyRevocationRoot,
+			Index:  9,
+		},
+		LocalShutdownScript: bytes.Repeat([]byte{2}, 22),
 	}, nil
 }
 
@@ -830,3 +835,116 @@ func TestFetchClosedChannels(t *testing.T) {
 			"got %v", 0, len(closed))
 	}
 }
+
+// TestRestoreChannelShells tests that we're able to insert a partially
+// populated channel shell into the database, and that the channel is then
+// marked as restored and borked.
+func TestRestoreChannelShells(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	// First, we'll make our channel shell, it will only have the minimal
+	// amount of information required for us to initiate the data loss
+	// protection feature.
+	channelState, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	channelShell := &ChannelShell{
+		Chan: channelState,
+		NodeAddrs: []net.Addr{
+			&net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 18555},
+		},
+	}
+	if err := cdb.RestoreChannelShells(channelShell); err != nil {
+		t.Fatalf("unable to restore channel shell: %v", err)
+	}
+
+	// Now that the channel has been inserted, we'll attempt to query for
+	// it to ensure we can properly locate it via various means.
+	nodeChans, err := cdb.FetchOpenChannels(channelState.IdentityPub)
+	if err != nil {
+		t.Fatalf("unable find channel: %v", err)
+	}
+	if len(nodeChans) != 1 {
+		t.Fatalf("wrong number of channels: expected %v, got %v", 1,
+			len(nodeChans))
+	}
+
+	// The channel should be marked as both restored and borked.
+	if !nodeChans[0].IsRestored {
+		t.Fatalf("channel should be marked as restored")
+	}
+	if !nodeChans[0].IsBorked {
+		t.Fatalf("channel should be marked as borked")
+	}
+
+	// We should also be able to find the link node that was inserted by
+	// the restoration process.
+	linkNode, err := cdb.FetchLinkNode(channelState.IdentityPub)
+	if err != nil {
+		t.Fatalf("unable to fetch link node: %v", err)
+	}
+	if !reflect.DeepEqual(linkNode.Addresses, channelShell.NodeAddrs) {
+		t.Fatalf("addrs don't match: expected %v, got %v",
+			channelShell.NodeAddrs, linkNode.Addresses)
+	}
+
+	// Restoring the same shell again shouldn't result in an error, as the
+	// method is meant to be idempotent.
+	if err := cdb.RestoreChannelShells(channelShell); err != nil {
+		t.Fatalf("unable to restore channel shell: %v", err)
+	}
+}
+
+// TestDataLossCommitPoint tests that the commitment point stored once we've
+// detected that we've lost state can be retrieved from the database.
+func TestDataLossCommitPoint(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	channelState, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := channelState.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	// Before any data loss has been marked, we shouldn't be able to find
+	// a commitment point.
+	_, err = channelState.DataLossCommitPoint()
+	if err != ErrNoCommitPoint {
+		t.Fatalf("expected ErrNoCommitPoint, instead got: %v", err)
+	}
+
+	// We'll now mark the data loss, after which the channel should be
+	// borked and the point retrievable.
+	commitPoint := privKey.PubKey()
+	if err := channelState.MarkDataLoss(commitPoint); err != nil {
+		t.Fatalf("unable to mark data loss: %v", err)
+	}
+	if !channelState.IsBorked {
+		t.Fatalf("channel should be marked as borked")
+	}
+
+	dbPoint, err := channelState.DataLossCommitPoint()
+	if err != nil {
+		t.Fatalf("unable to fetch commit point: %v", err)
+	}
+	if !dbPoint.IsEqual(commitPoint) {
+		t.Fatalf("commit point mismatch: expected %x, got %x",
+			commitPoint.SerializeCompressed(),
+			dbPoint.SerializeCompressed())
+	}
+}
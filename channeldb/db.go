@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/coreos/bbolt"
 	"github.com/go-errors/errors"
@@ -517,6 +519,84 @@ func (d *DB) MarkChanFullyClosed(chanPoint *wire.OutPoint) error {
 	})
 }
 
+// ChannelShell is a shell of a channel that is meant to be used for channel
+// recovery purposes. It contains a minimal OpenChannel instance along with
+// the addresses that the remote node was known to be reachable at.
+type ChannelShell struct {
+	// NodeAddrs is the set of addresses that the remote node has been
+	// known to be reachable at in the past.
+	NodeAddrs []net.Addr
+
+	// Chan is a shell of an OpenChannel. It only contains the items
+	// required to restore the channel on disk.
+	Chan *OpenChannel
+}
+
+// RestoreChannelShells reconstructs the state of a set of channels from their
+// ChannelShells. For each shell, we'll write the channel to disk marked as
+// restored and borked, then create a LinkNode with the passed node addresses
+// so we'll attempt to connect to the remote party on startup. This method is
+// idempotent: channels that already exist on disk won't be modified.
+func (d *DB) RestoreChannelShells(channelShells ...*ChannelShell) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		for _, channelShell := range channelShells {
+			channel := channelShell.Chan
+
+			// We'll mark the channel as restored, which signals to
+			// the other sub-systems that they shouldn't attempt to
+			// use this channel as a regular one.
+			channel.IsRestored = true
+			channel.IsBorked = true
+			channel.Db = d
+
+			chanBucket, err := updateChanBucket(
+				tx, channel.IdentityPub, &channel.FundingOutpoint,
+				channel.ChainHash,
+			)
+			if err != nil {
+				return err
+			}
+
+			// If we already have this channel on disk, then we'll
+			// skip it in order to not clobber any existing state.
+			if chanBucket.Get(chanInfoKey) != nil {
+				continue
+			}
+
+			if err := putOpenChannel(chanBucket, channel); err != nil {
+				return err
+			}
+
+			nodeInfoBucket, err := tx.CreateBucketIfNotExists(
+				nodeInfoBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			// If a LinkNode for this identity public key already
+			// exists, then we don't need to create a new one.
+			nodePub := channel.IdentityPub.SerializeCompressed()
+			if nodeInfoBucket.Get(nodePub) != nil {
+				continue
+			}
+
+			linkNode := &LinkNode{
+				Network:     wire.MainNet,
+				IdentityPub: channel.IdentityPub,
+				LastSeen:    time.Now(),
+				Addresses:   channelShell.NodeAddrs,
+				db:          d,
+			}
+			if err := putLinkNode(nodeInfoBucket, linkNode); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 // syncVersions function is used for safe db version synchronization. It
 // applies migration functions to the current database and recovers the
 // previous state of db if at least one error/panic appeared during migration.
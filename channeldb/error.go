@@ -15,6 +15,10 @@ var (
 	// channels within the database.
 	ErrNoActiveChannels = fmt.Errorf("no active channels exist")
 
+	// ErrChannelNotFound is returned when a targeted open channel can't be
+	// found.
+	ErrChannelNotFound = fmt.Errorf("unable to locate channel")
+
 	// ErrNoPastDeltas is returned when the channel delta bucket hasn't been
 	// created.
 	ErrNoPastDeltas = fmt.Errorf("channel has no recorded deltas")
@@ -31,10 +35,33 @@ var (
 	// payment hash already exists.
 	ErrDuplicateInvoice = fmt.Errorf("invoice with payment hash already exists")
 
+	// ErrInvoiceAlreadySettled is returned when the invoice is already
+	// settled.
+	ErrInvoiceAlreadySettled = fmt.Errorf("invoice already settled")
+
+	// ErrInvoiceAlreadyCanceled is returned when the invoice is already
+	// canceled.
+	ErrInvoiceAlreadyCanceled = fmt.Errorf("invoice already canceled")
+
+	// ErrInvoiceExpired is returned when the invoice has expired.
+	ErrInvoiceExpired = fmt.Errorf("invoice expired")
+
+	// ErrInvoiceStillOpen is returned when a hold invoice is settled
+	// before an HTLC paying to it has been accepted.
+	ErrInvoiceStillOpen = fmt.Errorf("invoice still open")
+
 	// ErrNoPaymentsCreated is returned when bucket of payments hasn't been
 	// created.
 	ErrNoPaymentsCreated = fmt.Errorf("there are no existing payments")
 
+	// ErrPaymentShardsNotFound is returned when no shards have been
+	// recorded for the targeted payment hash.
+	ErrPaymentShardsNotFound = fmt.Errorf("unable to locate payment shards")
+
+	// ErrPaymentShardNotFound is returned when the targeted shard of a
+	// payment can't be found.
+	ErrPaymentShardNotFound = fmt.Errorf("unable to locate payment shard")
+
 	// ErrNodeNotFound is returned when node bucket exists, but node with
 	// specific identity can't be found.
 	ErrNodeNotFound = fmt.Errorf("link node with target identity not found")
@@ -1139,6 +1139,38 @@ func (c *ChannelGraph) HasLightningNode(nodePub [33]byte) (time.Time, bool, erro
 	return updateTime, exists, nil
 }
 
+// IsPublicNode determines whether the node with the given public key is
+// publicly advertised within the network, which is the case if at least one of
+// its channels has been announced through a channel authentication proof.
+func (c *ChannelGraph) IsPublicNode(nodePub [33]byte) (bool, error) {
+	var isPublic bool
+	err := c.db.View(func(tx *bolt.Tx) error {
+		node := &LightningNode{
+			PubKeyBytes: nodePub,
+			db:          c.db,
+		}
+		return node.ForEachChannel(tx, func(_ *bolt.Tx,
+			info *ChannelEdgeInfo, _, _ *ChannelEdgePolicy) error {
+
+			if info.AuthProof != nil {
+				isPublic = true
+			}
+			return nil
+		})
+	})
+	switch {
+	// If no edges have been added to the graph yet, then the node can't
+	// be public.
+	case err == ErrGraphNoEdgesFound:
+		return false, nil
+
+	case err != nil:
+		return false, err
+	}
+
+	return isPublic, nil
+}
+
 // ForEachChannel iterates through all the outgoing channel edges from this
 // node, executing the passed callback with each edge as its sole argument. The
 // first edge policy is the outgoing edge *to* the connecting node, while the
@@ -1771,6 +1803,314 @@ func (c *ChannelGraph) ChannelView() ([]wire.OutPoint, error) {
 	return chanPoints, nil
 }
 
+// ChannelEdge represents the complete set of information for a channel edge
+// in the known channel graph. This struct couples the core information of the
+// edge as well as each of the known advertised edge policies.
+type ChannelEdge struct {
+	// Info contains all the static information describing the channel.
+	Info *ChannelEdgeInfo
+
+	// Policy1 points to the "first" edge policy of the channel containing
+	// the dynamic information required to properly route through the
+	// edge.
+	Policy1 *ChannelEdgePolicy
+
+	// Policy2 points to the "second" edge policy of the channel containing
+	// the dynamic information required to properly route through the
+	// edge.
+	Policy2 *ChannelEdgePolicy
+}
+
+// HighestChanID returns the "highest" known channel ID in the channel graph.
+// This represents the "newest" channel from the PoV of the chain. This method
+// can be used by peers to quickly determine if they're graphs are in sync.
+// If the graph doesn't contain any channels yet, then zero is returned.
+func (c *ChannelGraph) HighestChanID() (uint64, error) {
+	var cid uint64
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		// As the edge index is keyed by the big-endian encoding of the
+		// channel ID, the last key within the index is the highest
+		// channel ID that we know of.
+		lastChanID, _ := edgeIndex.Cursor().Last()
+
+		// If there's no key, then this means that we don't actually
+		// know of any channels, so we'll return a predicable error.
+		if lastChanID == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		cid = byteOrder.Uint64(lastChanID)
+		return nil
+	})
+	if err != nil && err != ErrGraphNoEdgesFound {
+		return 0, err
+	}
+
+	return cid, nil
+}
+
+// ChanUpdatesInHorizon returns all the known channel edges which have at
+// least one edge policy that has been updated within the target horizon.
+//
+// NOTE: As we don't maintain an index of edge updates by time, this requires
+// a full traversal of the channel graph.
+func (c *ChannelGraph) ChanUpdatesInHorizon(startTime,
+	endTime time.Time) ([]ChannelEdge, error) {
+
+	inHorizon := func(policy *ChannelEdgePolicy) bool {
+		if policy == nil {
+			return false
+		}
+
+		return !policy.LastUpdate.Before(startTime) &&
+			!policy.LastUpdate.After(endTime)
+	}
+
+	var edgesInHorizon []ChannelEdge
+	err := c.ForEachChannel(func(info *ChannelEdgeInfo,
+		policy1, policy2 *ChannelEdgePolicy) error {
+
+		if !inHorizon(policy1) && !inHorizon(policy2) {
+			return nil
+		}
+
+		edgesInHorizon = append(edgesInHorizon, ChannelEdge{
+			Info:    info,
+			Policy1: policy1,
+			Policy2: policy2,
+		})
+
+		return nil
+	})
+	if err != nil && err != ErrGraphNoEdgesFound &&
+		err != ErrGraphNotFound {
+
+		return nil, err
+	}
+
+	return edgesInHorizon, nil
+}
+
+// NodeUpdatesInHorizon returns all the known lightning node which have an
+// update timestamp within the passed range. This method can be used by two
+// nodes to quickly determine if they have the same set of up to date node
+// announcements.
+//
+// NOTE: As we don't maintain an index of node updates by time, this requires
+// a full traversal of all the nodes within the graph.
+func (c *ChannelGraph) NodeUpdatesInHorizon(startTime,
+	endTime time.Time) ([]LightningNode, error) {
+
+	var nodesInHorizon []LightningNode
+	err := c.ForEachNode(nil, func(_ *bolt.Tx, node *LightningNode) error {
+		// Nodes we only know of through a channel announcement don't
+		// have an update that can be sent to our peers.
+		if !node.HaveNodeAnnouncement {
+			return nil
+		}
+
+		if node.LastUpdate.Before(startTime) ||
+			node.LastUpdate.After(endTime) {
+
+			return nil
+		}
+
+		nodesInHorizon = append(nodesInHorizon, *node)
+		return nil
+	})
+	if err != nil && err != ErrGraphNotFound {
+		return nil, err
+	}
+
+	return nodesInHorizon, nil
+}
+
+// FilterKnownChanIDs takes a set of channel IDs and return the subset of chan
+// ID's that we don't know of in the passed set. In other words, we perform a
+// set difference of our set of chan ID's and the ones passed in. This method
+// can be used by callers to determine the set of channels another peer knows
+// of that we don't.
+func (c *ChannelGraph) FilterKnownChanIDs(chanIDs []uint64) ([]uint64, error) {
+	var newChanIDs []uint64
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		// We'll run through the set of chanIDs and collate only the
+		// set of channel that are unable to be found within our db.
+		var cidBytes [8]byte
+		for _, cid := range chanIDs {
+			byteOrder.PutUint64(cidBytes[:], cid)
+
+			if v := edgeIndex.Get(cidBytes[:]); v == nil {
+				newChanIDs = append(newChanIDs, cid)
+			}
+		}
+
+		return nil
+	})
+	switch {
+	// If we don't know of any edges yet, then we'll return the entire set
+	// of chan IDs specified.
+	case err == ErrGraphNoEdgesFound:
+		return chanIDs, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return newChanIDs, nil
+}
+
+// FilterChannelRange returns the channel ID's of all known channels which
+// were mined in a block height within the passed range. This method can be
+// used to quickly share with a peer the set of channels we know of within a
+// particular range to catch them up after a period of time offline.
+func (c *ChannelGraph) FilterChannelRange(startHeight,
+	endHeight uint32) ([]uint64, error) {
+
+	var chanIDs []uint64
+
+	startChanID := &lnwire.ShortChannelID{
+		BlockHeight: startHeight,
+	}
+
+	endChanID := lnwire.ShortChannelID{
+		BlockHeight: endHeight,
+		TxIndex:     math.MaxUint32 & 0x00ffffff,
+		TxPosition:  math.MaxUint16,
+	}
+
+	// As we need to perform a range scan, we'll convert the starting and
+	// ending height to their corresponding values when encoded using short
+	// channel ID's.
+	var chanIDStart, chanIDEnd [8]byte
+	byteOrder.PutUint64(chanIDStart[:], startChanID.ToUint64())
+	byteOrder.PutUint64(chanIDEnd[:], endChanID.ToUint64())
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		cursor := edgeIndex.Cursor()
+
+		// We'll now iterate through the database, and find each
+		// channel ID that resides within the specified range.
+		for k, _ := cursor.Seek(chanIDStart[:]); k != nil &&
+			bytes.Compare(k, chanIDEnd[:]) <= 0; k, _ = cursor.Next() {
+
+			// This channel ID rests within the target range, so
+			// we'll convert it into an integer and add it to our
+			// returned set.
+			chanIDs = append(chanIDs, byteOrder.Uint64(k))
+		}
+
+		return nil
+	})
+	switch {
+	// If we don't know of any channels yet, then there's nothing to
+	// filter, so we'll return an empty slice.
+	case err == ErrGraphNoEdgesFound:
+		return chanIDs, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return chanIDs, nil
+}
+
+// FetchChanInfos returns the set of channel edges that correspond to the
+// passed channel ID's. If an edge in the query is unknown to the database, it
+// will be skipped and the result will contain only those edges that exist at
+// the time of the query. This can be used to respond to peer queries that are
+// seeking to fill in gaps in their view of the channel graph.
+func (c *ChannelGraph) FetchChanInfos(chanIDs []uint64) ([]ChannelEdge, error) {
+	var (
+		chanEdges []ChannelEdge
+		cidBytes  [8]byte
+	)
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return ErrGraphNotFound
+		}
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		for _, cid := range chanIDs {
+			byteOrder.PutUint64(cidBytes[:], cid)
+
+			// First, we'll fetch the static edge information. If
+			// the edge is unknown, we will skip the edge and
+			// continue gathering all known edges.
+			edgeInfo, err := fetchChanEdgeInfo(
+				edgeIndex, cidBytes[:],
+			)
+			switch {
+			case err == ErrEdgeNotFound:
+				continue
+			case err != nil:
+				return err
+			}
+
+			// With the static information obtained, we'll now
+			// fetch the dynamic policy info.
+			edge1, edge2, err := fetchChanEdgePolicies(
+				edgeIndex, edges, nodes, cidBytes[:], c.db,
+			)
+			if err != nil {
+				return err
+			}
+
+			chanEdges = append(chanEdges, ChannelEdge{
+				Info:    &edgeInfo,
+				Policy1: edge1,
+				Policy2: edge2,
+			})
+		}
+
+		return nil
+	})
+	if err != nil && err != ErrGraphNoEdgesFound &&
+		err != ErrGraphNotFound {
+
+		return nil, err
+	}
+
+	return chanEdges, nil
+}
+
 // NewChannelEdgePolicy returns a new blank ChannelEdgePolicy.
 func (c *ChannelGraph) NewChannelEdgePolicy() *ChannelEdgePolicy {
 	return &ChannelEdgePolicy{db: c.db}
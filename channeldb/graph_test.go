@@ -17,6 +17,7 @@ import (
 	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/torsvc"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -27,7 +28,11 @@ var (
 		Port: 9000}
 	anotherAddr, _ = net.ResolveTCPAddr("tcp",
 		"[2001:db8:85a3:0:0:8a2e:370:7334]:80")
-	testAddrs = []net.Addr{testAddr, anotherAddr}
+	testOnionAddr = &torsvc.OnionAddr{
+		OnionService: "vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3ah7ngmcopnpyyd.onion",
+		Port:         9735,
+	}
+	testAddrs = []net.Addr{testAddr, anotherAddr, testOnionAddr}
 
 	randSource = prand.NewSource(time.Now().Unix())
 	randInts   = prand.New(randSource)
@@ -1241,3 +1246,755 @@ func compareEdgePolicies(a, b *ChannelEdgePolicy) error {
 	}
 	return nil
 }
+
+// createChannelEdge creates a new channel edge between the two passed nodes
+// with the given short channel ID, along with an edge policy for each
+// direction of the channel.
+func createChannelEdge(db *DB, node1, node2 *LightningNode,
+	shortChanID lnwire.ShortChannelID) (*ChannelEdgeInfo,
+	*ChannelEdgePolicy, *ChannelEdgePolicy) {
+
+	var (
+		firstNode  *LightningNode
+		secondNode *LightningNode
+	)
+	if bytes.Compare(node1.PubKeyBytes[:], node2.PubKeyBytes[:]) == -1 {
+		firstNode = node1
+		secondNode = node2
+	} else {
+		firstNode = node2
+		secondNode = node1
+	}
+
+	chanID := shortChanID.ToUint64()
+	outpoint := wire.OutPoint{
+		Hash:  rev,
+		Index: uint32(shortChanID.TxPosition),
+	}
+	outpoint.Hash[0] = byte(shortChanID.BlockHeight)
+	outpoint.Hash[1] = byte(shortChanID.TxIndex)
+
+	edgeInfo := &ChannelEdgeInfo{
+		ChannelID: chanID,
+		ChainHash: key,
+		AuthProof: &ChannelAuthProof{
+			NodeSig1Bytes:    testSig.Serialize(),
+			NodeSig2Bytes:    testSig.Serialize(),
+			BitcoinSig1Bytes: testSig.Serialize(),
+			BitcoinSig2Bytes: testSig.Serialize(),
+		},
+		ChannelPoint: outpoint,
+		Capacity:     9000,
+	}
+	copy(edgeInfo.NodeKey1Bytes[:], firstNode.PubKeyBytes[:])
+	copy(edgeInfo.NodeKey2Bytes[:], secondNode.PubKeyBytes[:])
+	copy(edgeInfo.BitcoinKey1Bytes[:], firstNode.PubKeyBytes[:])
+	copy(edgeInfo.BitcoinKey2Bytes[:], secondNode.PubKeyBytes[:])
+
+	edge1 := randEdgePolicy(chanID, outpoint, db)
+	edge1.SigBytes = testSig.Serialize()
+	edge1.Flags = 0
+	edge1.Node = secondNode
+
+	edge2 := randEdgePolicy(chanID, outpoint, db)
+	edge2.SigBytes = testSig.Serialize()
+	edge2.Flags = 1
+	edge2.Node = firstNode
+
+	return edgeInfo, edge1, edge2
+}
+
+// createTestNodePair creates two new test vertexes and adds them to the
+// graph.
+func createTestNodePair(t *testing.T, graph *ChannelGraph) (*LightningNode,
+	*LightningNode) {
+
+	node1, err := createTestVertex(graph.db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	if err := graph.AddLightningNode(node1); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+	node2, err := createTestVertex(graph.db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	if err := graph.AddLightningNode(node2); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+
+	return node1, node2
+}
+
+// TestHighestChanID tests that we're able to properly retrieve the highest
+// known channel ID in the database.
+func TestHighestChanID(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// If we don't yet have any channels in the database, then we should
+	// get a channel ID of zero if we ask for the highest channel ID.
+	bestID, err := graph.HighestChanID()
+	if err != nil {
+		t.Fatalf("unable to get highest ID: %v", err)
+	}
+	if bestID != 0 {
+		t.Fatalf("best ID w/ no chan should be zero, is instead: %v",
+			bestID)
+	}
+
+	node1, node2 := createTestNodePair(t, graph)
+
+	// The first channel with be at height 10, while the other will be at
+	// height 100.
+	edge1, _, _ := createChannelEdge(
+		db, node1, node2, lnwire.ShortChannelID{BlockHeight: 10},
+	)
+	edge2, _, _ := createChannelEdge(
+		db, node1, node2, lnwire.ShortChannelID{BlockHeight: 100},
+	)
+
+	// Now that the edges has been created, we'll add them to the graph,
+	// in reverse order to ensure the order of insertion doesn't matter.
+	if err := graph.AddChannelEdge(edge2); err != nil {
+		t.Fatalf("unable to create channel edge: %v", err)
+	}
+	if err := graph.AddChannelEdge(edge1); err != nil {
+		t.Fatalf("unable to create channel edge: %v", err)
+	}
+
+	// Our highest chan ID should be that of the second edge, as it was
+	// confirmed at a greater height.
+	bestID, err = graph.HighestChanID()
+	if err != nil {
+		t.Fatalf("unable to get highest ID: %v", err)
+	}
+	if bestID != edge2.ChannelID {
+		t.Fatalf("expected %v got %v", edge2.ChannelID, bestID)
+	}
+}
+
+// TestIsPublicNode tests that a node is only considered public once at least
+// one of its channels has been announced.
+func TestIsPublicNode(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// We'll create two pairs of nodes, the first of which will share an
+	// announced channel, while the second will only share a private one.
+	publicNode1, publicNode2 := createTestNodePair(t, graph)
+	privateNode1, privateNode2 := createTestNodePair(t, graph)
+
+	assertPublic := func(node *LightningNode, expected bool) {
+		t.Helper()
+
+		isPublic, err := graph.IsPublicNode(node.PubKeyBytes)
+		if err != nil {
+			t.Fatalf("unable to determine if node is public: %v",
+				err)
+		}
+		if isPublic != expected {
+			t.Fatalf("expected node %x public=%v, got %v",
+				node.PubKeyBytes, expected, isPublic)
+		}
+	}
+
+	// As no channels exist yet, none of the nodes should be public.
+	assertPublic(publicNode1, false)
+	assertPublic(privateNode1, false)
+
+	addEdge := func(node1, node2 *LightningNode, height uint32,
+		announced bool) {
+
+		edgeInfo, edge1, edge2 := createChannelEdge(
+			db, node1, node2,
+			lnwire.ShortChannelID{BlockHeight: height},
+		)
+		if !announced {
+			edgeInfo.AuthProof = nil
+		}
+		if err := graph.AddChannelEdge(edgeInfo); err != nil {
+			t.Fatalf("unable to create channel edge: %v", err)
+		}
+		if err := graph.UpdateEdgePolicy(edge1); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+		if err := graph.UpdateEdgePolicy(edge2); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+	}
+	addEdge(publicNode1, publicNode2, 10, true)
+	addEdge(privateNode1, privateNode2, 20, false)
+
+	assertPublic(publicNode1, true)
+	assertPublic(publicNode2, true)
+	assertPublic(privateNode1, false)
+	assertPublic(privateNode2, false)
+}
+
+// TestChanUpdatesInHorizon tests the we're able to properly retrieve all known
+// channel updates within a specific time horizon.
+func TestChanUpdatesInHorizon(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// If we issue an arbitrary query before any channel updates are
+	// inserted in the database, we should get zero results.
+	chanUpdates, err := graph.ChanUpdatesInHorizon(
+		time.Unix(999, 0), time.Unix(9999, 0),
+	)
+	if err != nil {
+		t.Fatalf("unable to updates for updates: %v", err)
+	}
+	if len(chanUpdates) != 0 {
+		t.Fatalf("expected 0 chan updates, instead got %v",
+			len(chanUpdates))
+	}
+
+	node1, node2 := createTestNodePair(t, graph)
+
+	// We'll now create 10 channels whose edge policies are updated at
+	// increasing times, each an hour apart.
+	const numChans = 10
+	startTime := time.Unix(1234, 0)
+	endTime := startTime
+	edges := make([]ChannelEdge, 0, numChans)
+	for i := 0; i < numChans; i++ {
+		edge, edge1, edge2 := createChannelEdge(
+			db, node1, node2, lnwire.ShortChannelID{
+				BlockHeight: uint32(i + 1),
+			},
+		)
+		if err := graph.AddChannelEdge(edge); err != nil {
+			t.Fatalf("unable to create channel edge: %v", err)
+		}
+
+		edge1UpdateTime := endTime
+		edge2UpdateTime := edge1UpdateTime.Add(time.Second)
+		endTime = endTime.Add(time.Hour)
+
+		edge1.LastUpdate = edge1UpdateTime
+		edge2.LastUpdate = edge2UpdateTime
+
+		if err := graph.UpdateEdgePolicy(edge1); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+		if err := graph.UpdateEdgePolicy(edge2); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+
+		edges = append(edges, ChannelEdge{
+			Info:    edge,
+			Policy1: edge1,
+			Policy2: edge2,
+		})
+	}
+
+	// With our channels loaded, we'll now start our series of queries.
+	queryCases := []struct {
+		start time.Time
+		end   time.Time
+
+		resp []ChannelEdge
+	}{
+		// If we query for a time range that's strictly below our set
+		// of updates, then we'll get an empty result back.
+		{
+			start: time.Unix(100, 0),
+			end:   time.Unix(200, 0),
+		},
+
+		// If we query for a time range that's well beyond our set of
+		// updates, we should get an empty set of results back.
+		{
+			start: time.Unix(99999, 0),
+			end:   time.Unix(999999, 0),
+		},
+
+		// If we query for the start time, and 10 seconds directly
+		// after it, we should only get a single update, that first
+		// one.
+		{
+			start: time.Unix(1234, 0),
+			end:   startTime.Add(time.Second * 10),
+
+			resp: []ChannelEdge{edges[0]},
+		},
+
+		// If we add 10 minutes to the end of the first update, then
+		// we should get the first update again.
+		{
+			start: startTime,
+			end:   startTime.Add(time.Minute * 10),
+
+			resp: []ChannelEdge{edges[0]},
+		},
+
+		// If we query for the first 5 hours, then we should get
+		// exactly 5 results.
+		{
+			start: startTime,
+			end:   startTime.Add(time.Hour * 4).Add(time.Minute),
+
+			resp: edges[:5],
+		},
+
+		// If we use the start and end time as is, we should get the
+		// entire range.
+		{
+			start: startTime,
+			end:   endTime,
+
+			resp: edges,
+		},
+	}
+	for i, queryCase := range queryCases {
+		resp, err := graph.ChanUpdatesInHorizon(
+			queryCase.start, queryCase.end,
+		)
+		if err != nil {
+			t.Fatalf("unable to query for updates: %v", err)
+		}
+
+		if len(resp) != len(queryCase.resp) {
+			t.Fatalf("expected %v chans, got %v chans",
+				len(queryCase.resp), len(resp))
+		}
+
+		for j := 0; j < len(resp); j++ {
+			chanExp := queryCase.resp[j]
+			chanRet := resp[j]
+
+			assertEdgeInfoEqual(t, chanExp.Info, chanRet.Info)
+
+			err := compareEdgePolicies(chanExp.Policy1, chanRet.Policy1)
+			if err != nil {
+				t.Fatalf("case #%v: %v", i, err)
+			}
+			err = compareEdgePolicies(chanExp.Policy2, chanRet.Policy2)
+			if err != nil {
+				t.Fatalf("case #%v: %v", i, err)
+			}
+		}
+	}
+}
+
+// TestNodeUpdatesInHorizon tests that we're able to properly scan and retrieve
+// the most recent node updates within a particular time horizon.
+func TestNodeUpdatesInHorizon(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	startTime := time.Unix(1234, 0)
+	endTime := startTime
+
+	// If we issue an arbitrary query before we insert any nodes into the
+	// database, then we shouldn't get any results back.
+	nodeUpdates, err := graph.NodeUpdatesInHorizon(
+		time.Unix(999, 0), time.Unix(9999, 0),
+	)
+	if err != nil {
+		t.Fatalf("unable to query for node updates: %v", err)
+	}
+	if len(nodeUpdates) != 0 {
+		t.Fatalf("expected 0 node updates, instead got %v",
+			len(nodeUpdates))
+	}
+
+	// We'll create 10 node announcements, each with an update timestamp 10
+	// seconds after the other.
+	const numNodes = 10
+	nodeAnns := make(map[[33]byte]*LightningNode, numNodes)
+	for i := 0; i < numNodes; i++ {
+		nodeAnn, err := createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create test vertex: %v", err)
+		}
+
+		// The node ann will use the current end time as its last
+		// update them, then we'll add 10 seconds in order to create
+		// the proper update time for the next node announcement.
+		updateTime := endTime
+		endTime = updateTime.Add(time.Second * 10)
+
+		nodeAnn.LastUpdate = updateTime
+
+		nodeAnns[nodeAnn.PubKeyBytes] = nodeAnn
+
+		if err := graph.AddLightningNode(nodeAnn); err != nil {
+			t.Fatalf("unable to add lightning node: %v", err)
+		}
+	}
+
+	queryCases := []struct {
+		start time.Time
+		end   time.Time
+
+		numResults int
+	}{
+		// If we query for a time range that's strictly below our set
+		// of updates, then we'll get an empty result back.
+		{
+			start: time.Unix(100, 0),
+			end:   time.Unix(200, 0),
+		},
+
+		// If we query for a time range that's well beyond our set of
+		// updates, we should get an empty set of results back.
+		{
+			start: time.Unix(99999, 0),
+			end:   time.Unix(999999, 0),
+		},
+
+		// If we skip he first time epoch with out start time, then we
+		// should get back every now but the first.
+		{
+			start: startTime.Add(time.Second * 10),
+			end:   endTime,
+
+			numResults: numNodes - 1,
+		},
+
+		// If we query for the range as is, we should get all 10
+		// announcements back.
+		{
+			start: startTime,
+			end:   endTime,
+
+			numResults: numNodes,
+		},
+
+		// If we reduce the ending time to cut off the last node, then
+		// we should get all but the last node.
+		{
+			start: startTime,
+			end:   endTime.Add(-time.Second * 20),
+
+			numResults: numNodes - 1,
+		},
+	}
+	for _, queryCase := range queryCases {
+		resp, err := graph.NodeUpdatesInHorizon(
+			queryCase.start, queryCase.end,
+		)
+		if err != nil {
+			t.Fatalf("unable to query for nodes: %v", err)
+		}
+
+		if len(resp) != queryCase.numResults {
+			t.Fatalf("expected %v nodes, got %v nodes",
+				queryCase.numResults, len(resp))
+		}
+
+		for _, node := range resp {
+			expectedNode, ok := nodeAnns[node.PubKeyBytes]
+			if !ok {
+				t.Fatalf("unknown node %x returned",
+					node.PubKeyBytes)
+			}
+
+			if err := compareNodes(expectedNode, &node); err != nil {
+				t.Fatalf("nodes don't match: %v", err)
+			}
+		}
+	}
+}
+
+// TestFilterKnownChanIDs tests that we're able to properly perform the set
+// differences of an incoming set of channel ID's, and those that we already
+// know of on disk.
+func TestFilterKnownChanIDs(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// If we try to filter out a set of channel ID's before we even know of
+	// any channels, then we should get the entire set back.
+	preChanIDs := []uint64{1, 2, 3, 4}
+	filteredIDs, err := graph.FilterKnownChanIDs(preChanIDs)
+	if err != nil {
+		t.Fatalf("unable to filter chan IDs: %v", err)
+	}
+	if !reflect.DeepEqual(preChanIDs, filteredIDs) {
+		t.Fatalf("chan IDs shouldn't have been filtered!")
+	}
+
+	node1, node2 := createTestNodePair(t, graph)
+
+	// Next, we'll add 5 channel ID's to the graph, each of them having a
+	// block height 10 blocks after the previous.
+	const numChans = 5
+	chanIDs := make([]uint64, 0, numChans)
+	for i := 0; i < numChans; i++ {
+		chanID := lnwire.ShortChannelID{
+			BlockHeight: uint32(i + 1),
+		}
+		edge, _, _ := createChannelEdge(db, node1, node2, chanID)
+		if err := graph.AddChannelEdge(edge); err != nil {
+			t.Fatalf("unable to create channel edge: %v", err)
+		}
+
+		chanIDs = append(chanIDs, chanID.ToUint64())
+	}
+
+	queryCases := []struct {
+		queryIDs []uint64
+
+		resp []uint64
+	}{
+		// If we attempt to filter out all chanIDs we know of, the
+		// response should be the empty set.
+		{
+			queryIDs: chanIDs,
+		},
+
+		// If we query for a set of ID's that we didn't insert, we
+		// should get the same set back.
+		{
+			queryIDs: []uint64{99, 100},
+			resp:     []uint64{99, 100},
+		},
+
+		// If we query for a super-set of our the chan ID's inserted,
+		// we should only get those new chanIDs back.
+		{
+			queryIDs: append(chanIDs, []uint64{99, 101}...),
+			resp:     []uint64{99, 101},
+		},
+	}
+
+	for _, queryCase := range queryCases {
+		resp, err := graph.FilterKnownChanIDs(queryCase.queryIDs)
+		if err != nil {
+			t.Fatalf("unable to filter chan IDs: %v", err)
+		}
+
+		if !reflect.DeepEqual(resp, queryCase.resp) {
+			t.Fatalf("expected %v, got %v", spew.Sdump(queryCase.resp),
+				spew.Sdump(resp))
+		}
+	}
+}
+
+// TestFilterChannelRange tests that we're able to properly retrieve the full
+// set of short channel ID's for a given block range.
+func TestFilterChannelRange(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// If we try to filter a channel range before we have any channels
+	// inserted, we should get an empty slice of results.
+	resp, err := graph.FilterChannelRange(10, 100)
+	if err != nil {
+		t.Fatalf("unable to filter channels: %v", err)
+	}
+	if len(resp) != 0 {
+		t.Fatalf("expected zero chans, instead got %v", len(resp))
+	}
+
+	node1, node2 := createTestNodePair(t, graph)
+
+	// We'll now create 10 channels, with 2 channels per block height,
+	// starting at height 1 and spaced 10 blocks apart.
+	const (
+		startHeight = 1
+		endHeight   = 50
+		numChans    = 10
+	)
+	channelRanges := make([]uint64, 0, numChans)
+	for i := 0; i < numChans/2; i++ {
+		chanHeight := startHeight + uint32(i*10)
+
+		chanID1 := lnwire.ShortChannelID{
+			BlockHeight: chanHeight,
+		}
+		channel1, _, _ := createChannelEdge(db, node1, node2, chanID1)
+		if err := graph.AddChannelEdge(channel1); err != nil {
+			t.Fatalf("unable to create channel edge: %v", err)
+		}
+
+		chanID2 := lnwire.ShortChannelID{
+			BlockHeight: chanHeight,
+			TxIndex:     2,
+		}
+		channel2, _, _ := createChannelEdge(db, node1, node2, chanID2)
+		if err := graph.AddChannelEdge(channel2); err != nil {
+			t.Fatalf("unable to create channel edge: %v", err)
+		}
+
+		channelRanges = append(
+			channelRanges, chanID1.ToUint64(), chanID2.ToUint64(),
+		)
+	}
+
+	queryCases := []struct {
+		startHeight uint32
+		endHeight   uint32
+
+		resp []uint64
+	}{
+		// If we query for the entire range, then we should get the
+		// same set of short channel IDs back.
+		{
+			startHeight: startHeight,
+			endHeight:   endHeight,
+
+			resp: channelRanges,
+		},
+
+		// If we query for a range of channels right before our range,
+		// we shouldn't get any results back.
+		{
+			startHeight: 0,
+			endHeight:   0,
+		},
+
+		// If we only query for the last height (range wise), we should
+		// only get that last channel.
+		{
+			startHeight: startHeight + 40,
+			endHeight:   endHeight,
+
+			resp: channelRanges[8:],
+		},
+
+		// If we query for just the first height, we should only get
+		// back that channel.
+		{
+			startHeight: startHeight,
+			endHeight:   startHeight,
+
+			resp: channelRanges[:2],
+		},
+	}
+	for i, queryCase := range queryCases {
+		resp, err := graph.FilterChannelRange(
+			queryCase.startHeight, queryCase.endHeight,
+		)
+		if err != nil {
+			t.Fatalf("unable to issue range query: %v", err)
+		}
+
+		if !reflect.DeepEqual(resp, queryCase.resp) {
+			t.Fatalf("case #%v: expected %v, got %v", i,
+				queryCase.resp, resp)
+		}
+	}
+}
+
+// TestFetchChanInfos tests that we're able to properly retrieve the full set
+// of ChannelEdge structs for a given set of short channel ID's.
+func TestFetchChanInfos(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	node1, node2 := createTestNodePair(t, graph)
+
+	// We'll make 3 test channels, and store the full set of channel
+	// information for each of them.
+	const numChans = 3
+	edges := make([]ChannelEdge, 0, numChans)
+	edgeQuery := make([]uint64, 0, numChans)
+	for i := 0; i < numChans; i++ {
+		chanID := lnwire.ShortChannelID{
+			BlockHeight: uint32(i + 1),
+		}
+		channel, edge1, edge2 := createChannelEdge(
+			db, node1, node2, chanID,
+		)
+		if err := graph.AddChannelEdge(channel); err != nil {
+			t.Fatalf("unable to create channel edge: %v", err)
+		}
+		if err := graph.UpdateEdgePolicy(edge1); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+		if err := graph.UpdateEdgePolicy(edge2); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+
+		edges = append(edges, ChannelEdge{
+			Info:    channel,
+			Policy1: edge1,
+			Policy2: edge2,
+		})
+
+		edgeQuery = append(edgeQuery, chanID.ToUint64())
+	}
+
+	// We'll also add a channel ID that we don't know of, it should simply
+	// be skipped within the response.
+	edgeQuery = append(edgeQuery, 500)
+
+	// With our set of channels created, we'll now query for the set of
+	// channel infos we just inserted.
+	resp, err := graph.FetchChanInfos(edgeQuery)
+	if err != nil {
+		t.Fatalf("unable to fetch chan edges: %v", err)
+	}
+	if len(resp) != len(edges) {
+		t.Fatalf("expected %v edges, instead got %v", len(edges),
+			len(resp))
+	}
+
+	for i := 0; i < len(resp); i++ {
+		assertEdgeInfoEqual(t, edges[i].Info, resp[i].Info)
+
+		err := compareEdgePolicies(edges[i].Policy1, resp[i].Policy1)
+		if err != nil {
+			t.Fatalf("edge doesn't match: %v", err)
+		}
+		err = compareEdgePolicies(edges[i].Policy2, resp[i].Policy2)
+		if err != nil {
+			t.Fatalf("edge doesn't match: %v", err)
+		}
+	}
+}
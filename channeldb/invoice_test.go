@@ -97,7 +97,7 @@ func TestInvoiceWorkflow(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch invoice: %v", err)
 	}
-	if !dbInvoice2.Terms.Settled {
+	if dbInvoice2.Terms.State != ContractSettled {
 		t.Fatalf("invoice should now be settled but isn't")
 	}
 
@@ -155,3 +155,276 @@ func TestInvoiceWorkflow(t *testing.T) {
 		}
 	}
 }
+
+// TestHoldInvoiceWorkflow asserts that a hold invoice can be added using only
+// its payment hash, transitions through the accepted state, and is settled
+// once its preimage is revealed.
+func TestHoldInvoiceWorkflow(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(10000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	preimage := invoice.Terms.PaymentPreimage
+	paymentHash := sha256.Sum256(preimage[:])
+
+	// A hold invoice must not carry a preimage.
+	if err := db.AddHoldInvoice(invoice, paymentHash); err == nil {
+		t.Fatalf("expected hold invoice with preimage to be rejected")
+	}
+
+	invoice.Terms.PaymentPreimage = UnknownPreimage
+	if err := db.AddHoldInvoice(invoice, paymentHash); err != nil {
+		t.Fatalf("unable to add hold invoice: %v", err)
+	}
+
+	dbInvoice, err := db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to find invoice: %v", err)
+	}
+	if dbInvoice.Terms.State != ContractOpen {
+		t.Fatalf("expected open invoice, got %v", dbInvoice.Terms.State)
+	}
+
+	// Settling the invoice before an HTLC has been accepted should fail.
+	if _, err := db.SettleHoldInvoice(preimage); err != ErrInvoiceStillOpen {
+		t.Fatalf("expected ErrInvoiceStillOpen, got: %v", err)
+	}
+
+	// Accept the invoice twice, the second call should be a no-op.
+	for i := 0; i < 2; i++ {
+		dbInvoice, err = db.AcceptHoldInvoice(paymentHash)
+		if err != nil {
+			t.Fatalf("unable to accept invoice: %v", err)
+		}
+		if dbInvoice.Terms.State != ContractAccepted {
+			t.Fatalf("expected accepted invoice, got %v",
+				dbInvoice.Terms.State)
+		}
+	}
+
+	// The accepted invoice should still be returned as pending.
+	pending, err := db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch pending invoices: %v", err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 pending invoice, got %v", len(pending))
+	}
+
+	// Now settle the invoice, which should record the preimage.
+	dbInvoice, err = db.SettleHoldInvoice(preimage)
+	if err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	if dbInvoice.Terms.State != ContractSettled {
+		t.Fatalf("expected settled invoice, got %v",
+			dbInvoice.Terms.State)
+	}
+	if dbInvoice.Terms.PaymentPreimage != preimage {
+		t.Fatalf("preimage not stored with settled invoice")
+	}
+	if dbInvoice.SettleDate.IsZero() {
+		t.Fatalf("invoice should have non-zero SettledDate but isn't")
+	}
+
+	// A settled invoice can no longer be canceled.
+	if _, err := db.CancelInvoice(paymentHash); err != ErrInvoiceAlreadySettled {
+		t.Fatalf("expected ErrInvoiceAlreadySettled, got: %v", err)
+	}
+}
+
+// TestCancelInvoice asserts that an open invoice can be canceled, after which
+// it can no longer be settled.
+func TestCancelInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(10000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	if err := db.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	paymentHash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+
+	// Canceling the invoice twice should succeed, as the second
+	// cancellation is a no-op.
+	for i := 0; i < 2; i++ {
+		dbInvoice, err := db.CancelInvoice(paymentHash)
+		if err != nil {
+			t.Fatalf("unable to cancel invoice: %v", err)
+		}
+		if dbInvoice.Terms.State != ContractCanceled {
+			t.Fatalf("expected canceled invoice, got %v",
+				dbInvoice.Terms.State)
+		}
+	}
+
+	// The canceled invoice should not be returned as pending.
+	pending, err := db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch pending invoices: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending invoices, got %v", len(pending))
+	}
+
+	if err := db.SettleInvoice(paymentHash); err != ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got: %v", err)
+	}
+
+	var unknownHash [32]byte
+	if _, err := db.CancelInvoice(unknownHash); err != ErrInvoiceNotFound {
+		t.Fatalf("expected ErrInvoiceNotFound, got: %v", err)
+	}
+}
+
+// TestExpireInvoice asserts that only open invoices can expire, and that
+// expired invoices can no longer be settled.
+func TestExpireInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Add two invoices, one of which we'll settle before attempting to
+	// expire both.
+	var hashes [2][32]byte
+	for i := range hashes {
+		invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(10000))
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		if err := db.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+		hashes[i] = sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+	}
+
+	if err := db.SettleInvoice(hashes[1]); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+
+	dbInvoice, err := db.ExpireInvoice(hashes[0])
+	if err != nil {
+		t.Fatalf("unable to expire invoice: %v", err)
+	}
+	if dbInvoice.Terms.State != ContractExpired {
+		t.Fatalf("expected expired invoice, got %v",
+			dbInvoice.Terms.State)
+	}
+
+	if err := db.SettleInvoice(hashes[0]); err != ErrInvoiceExpired {
+		t.Fatalf("expected ErrInvoiceExpired, got: %v", err)
+	}
+
+	// Expiring the settled invoice should leave it untouched.
+	dbInvoice, err = db.ExpireInvoice(hashes[1])
+	if err != nil {
+		t.Fatalf("unable to expire invoice: %v", err)
+	}
+	if dbInvoice.Terms.State != ContractSettled {
+		t.Fatalf("expected settled invoice, got %v",
+			dbInvoice.Terms.State)
+	}
+}
+
+// TestDeleteCanceledInvoices asserts that only canceled and expired invoices
+// created before the cutoff are deleted, and that their payment hashes can be
+// reused afterwards.
+func TestDeleteCanceledInvoices(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	cutoff := time.Unix(time.Now().Unix(), 0)
+	oldDate := cutoff.Add(-time.Hour)
+
+	addInvoice := func(creationDate time.Time, hold bool) (*Invoice,
+		[32]byte) {
+
+		invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(10000))
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		invoice.CreationDate = creationDate
+		hash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+
+		if hold {
+			invoice.Terms.PaymentPreimage = UnknownPreimage
+			err = db.AddHoldInvoice(invoice, hash)
+		} else {
+			err = db.AddInvoice(invoice)
+		}
+		if err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+
+		return invoice, hash
+	}
+
+	// An old canceled hold invoice and an old expired invoice should both
+	// be deleted.
+	canceledHold, canceledHash := addInvoice(oldDate, true)
+	if _, err := db.CancelInvoice(canceledHash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	_, expiredHash := addInvoice(oldDate, false)
+	if _, err := db.ExpireInvoice(expiredHash); err != nil {
+		t.Fatalf("unable to expire invoice: %v", err)
+	}
+
+	// A recently canceled invoice, and an old open invoice should both be
+	// kept.
+	_, recentHash := addInvoice(cutoff, false)
+	if _, err := db.CancelInvoice(recentHash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	_, openHash := addInvoice(oldDate, false)
+
+	numDeleted, err := db.DeleteCanceledInvoices(cutoff)
+	if err != nil {
+		t.Fatalf("unable to delete invoices: %v", err)
+	}
+	if numDeleted != 2 {
+		t.Fatalf("expected 2 deleted invoices, got %v", numDeleted)
+	}
+
+	for _, hash := range [][32]byte{canceledHash, expiredHash} {
+		if _, err := db.LookupInvoice(hash); err != ErrInvoiceNotFound {
+			t.Fatalf("expected ErrInvoiceNotFound, got: %v", err)
+		}
+	}
+	for _, hash := range [][32]byte{recentHash, openHash} {
+		if _, err := db.LookupInvoice(hash); err != nil {
+			t.Fatalf("unable to find invoice: %v", err)
+		}
+	}
+
+	// The payment hash of a deleted invoice can be used again.
+	canceledHold.Terms.State = ContractOpen
+	if err := db.AddHoldInvoice(canceledHold, canceledHash); err != nil {
+		t.Fatalf("unable to re-add invoice: %v", err)
+	}
+}
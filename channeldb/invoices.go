@@ -52,6 +52,57 @@ const (
 	MaxPaymentRequestSize = 4096
 )
 
+var (
+	// UnknownPreimage is an all-zeroes preimage that indicates that the
+	// preimage for this invoice is not yet known. Invoices created with
+	// only a payment hash, also known as hold invoices, carry this
+	// preimage until they are settled.
+	UnknownPreimage [32]byte
+)
+
+// ContractState describes the state the invoice is in.
+type ContractState uint8
+
+const (
+	// ContractOpen means the invoice has only been created.
+	ContractOpen ContractState = 0
+
+	// ContractSettled means the htlc is settled and the invoice has been
+	// paid.
+	ContractSettled ContractState = 1
+
+	// ContractCanceled means the invoice has been canceled.
+	ContractCanceled ContractState = 2
+
+	// ContractAccepted means the HTLC has been accepted but not settled
+	// yet. This state is only reachable by hold invoices, whose preimage
+	// isn't known until the invoice is explicitly settled.
+	ContractAccepted ContractState = 3
+
+	// ContractExpired means the invoice's expiry passed before it was
+	// paid. Like canceled invoices, expired invoices can no longer be
+	// settled.
+	ContractExpired ContractState = 4
+)
+
+// String returns a human readable identifier for the ContractState type.
+func (c ContractState) String() string {
+	switch c {
+	case ContractOpen:
+		return "Open"
+	case ContractSettled:
+		return "Settled"
+	case ContractCanceled:
+		return "Canceled"
+	case ContractAccepted:
+		return "Accepted"
+	case ContractExpired:
+		return "Expired"
+	}
+
+	return "Unknown"
+}
+
 // ContractTerm is a companion struct to the Invoice struct. This struct houses
 // the necessary conditions required before the invoice can be considered fully
 // settled by the payee.
@@ -65,9 +116,11 @@ type ContractTerm struct {
 	// HTLC which can be satisfied by the above preimage.
 	Value lnwire.MilliSatoshi
 
-	// Settled indicates if this particular contract term has been fully
-	// settled by the payer.
-	Settled bool
+	// State describes the state the invoice is in. Invoices start out
+	// open, and move to either settled, canceled or expired. Hold
+	// invoices additionally pass through the accepted state while the
+	// HTLC paying to them is being held.
+	State ContractState
 }
 
 // Invoice is a payment invoice generated by a payee in order to request
@@ -135,6 +188,26 @@ func validateInvoice(i *Invoice) error {
 // insertion will be aborted and rejected due to the strict policy banning any
 // duplicate payment hashes.
 func (d *DB) AddInvoice(i *Invoice) error {
+	paymentHash := sha256.Sum256(i.Terms.PaymentPreimage[:])
+	return d.addInvoice(i, paymentHash)
+}
+
+// AddHoldInvoice inserts a hold invoice into the database. In contrast to a
+// regular invoice, the preimage of a hold invoice isn't known when it is
+// created, so the invoice is indexed under the passed payment hash instead.
+// The invoice's preimage must be set to UnknownPreimage, and is only filled
+// in once the invoice is settled via SettleHoldInvoice.
+func (d *DB) AddHoldInvoice(i *Invoice, paymentHash [32]byte) error {
+	if i.Terms.PaymentPreimage != UnknownPreimage {
+		return fmt.Errorf("hold invoice cannot carry a preimage")
+	}
+
+	return d.addInvoice(i, paymentHash)
+}
+
+// addInvoice inserts the invoice into the database, indexing it under the
+// passed payment hash.
+func (d *DB) addInvoice(i *Invoice, paymentHash [32]byte) error {
 	if err := validateInvoice(i); err != nil {
 		return err
 	}
@@ -151,7 +224,6 @@ func (d *DB) AddInvoice(i *Invoice) error {
 
 		// Ensure that an invoice an identical payment hash doesn't
 		// already exist within the index.
-		paymentHash := sha256.Sum256(i.Terms.PaymentPreimage[:])
 		if invoiceIndex.Get(paymentHash[:]) != nil {
 			return ErrDuplicateInvoice
 		}
@@ -170,7 +242,9 @@ func (d *DB) AddInvoice(i *Invoice) error {
 			invoiceNum = byteOrder.Uint32(invoiceCounter)
 		}
 
-		return putInvoice(invoices, invoiceIndex, i, invoiceNum)
+		return putInvoice(
+			invoices, invoiceIndex, i, invoiceNum, paymentHash,
+		)
 	})
 }
 
@@ -217,8 +291,8 @@ func (d *DB) LookupInvoice(paymentHash [32]byte) (*Invoice, error) {
 }
 
 // FetchAllInvoices returns all invoices currently stored within the database.
-// If the pendingOnly param is true, then only unsettled invoices will be
-// returned, skipping all invoices that are fully settled.
+// If the pendingOnly param is true, then only open or accepted invoices will
+// be returned, skipping all invoices that are settled or canceled.
 func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 	var invoices []*Invoice
 
@@ -242,7 +316,10 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 				return err
 			}
 
-			if pendingOnly && invoice.Terms.Settled {
+			if pendingOnly &&
+				invoice.Terms.State != ContractOpen &&
+				invoice.Terms.State != ContractAccepted {
+
 				return nil
 			}
 
@@ -261,9 +338,225 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 // SettleInvoice attempts to mark an invoice corresponding to the passed
 // payment hash as fully settled. If an invoice matching the passed payment
 // hash doesn't existing within the database, then the action will fail with a
-// "not found" error.
+// "not found" error. Canceled invoices can't be settled.
 func (d *DB) SettleInvoice(paymentHash [32]byte) error {
-	return d.Update(func(tx *bolt.Tx) error {
+	_, err := d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		switch invoice.Terms.State {
+
+		// Add idempotency to duplicate settles, return here to avoid
+		// overwriting the previous info.
+		case ContractSettled:
+			return errInvoiceUnchanged
+
+		case ContractCanceled:
+			return ErrInvoiceAlreadyCanceled
+
+		case ContractExpired:
+			return ErrInvoiceExpired
+		}
+
+		invoice.Terms.State = ContractSettled
+		invoice.SettleDate = time.Now()
+
+		return nil
+	})
+
+	return err
+}
+
+// AcceptHoldInvoice marks the open hold invoice matching the passed payment
+// hash as accepted, signalling that an HTLC paying to it is being held until
+// the invoice is either settled or canceled. Accepting an already accepted
+// invoice is a no-op. The updated invoice is returned.
+func (d *DB) AcceptHoldInvoice(paymentHash [32]byte) (*Invoice, error) {
+	return d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		switch invoice.Terms.State {
+		case ContractAccepted:
+			return errInvoiceUnchanged
+
+		case ContractSettled:
+			return ErrInvoiceAlreadySettled
+
+		case ContractCanceled:
+			return ErrInvoiceAlreadyCanceled
+
+		case ContractExpired:
+			return ErrInvoiceExpired
+		}
+
+		invoice.Terms.State = ContractAccepted
+
+		return nil
+	})
+}
+
+// SettleHoldInvoice settles the accepted hold invoice whose payment hash
+// matches the passed preimage, storing the now revealed preimage along with
+// the invoice. Settling an already settled invoice is a no-op. The updated
+// invoice is returned.
+func (d *DB) SettleHoldInvoice(preimage [32]byte) (*Invoice, error) {
+	paymentHash := sha256.Sum256(preimage[:])
+	return d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		switch invoice.Terms.State {
+		case ContractSettled:
+			return errInvoiceUnchanged
+
+		case ContractCanceled:
+			return ErrInvoiceAlreadyCanceled
+
+		case ContractExpired:
+			return ErrInvoiceExpired
+
+		case ContractOpen:
+			return ErrInvoiceStillOpen
+		}
+
+		invoice.Terms.PaymentPreimage = preimage
+		invoice.Terms.State = ContractSettled
+		invoice.SettleDate = time.Now()
+
+		return nil
+	})
+}
+
+// CancelInvoice attempts to cancel the invoice corresponding to the passed
+// payment hash. Settled invoices can't be canceled, while canceling an already
+// canceled or expired invoice is a no-op. The updated invoice is returned.
+func (d *DB) CancelInvoice(paymentHash [32]byte) (*Invoice, error) {
+	return d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		switch invoice.Terms.State {
+		case ContractCanceled, ContractExpired:
+			return errInvoiceUnchanged
+
+		case ContractSettled:
+			return ErrInvoiceAlreadySettled
+		}
+
+		invoice.Terms.State = ContractCanceled
+
+		return nil
+	})
+}
+
+// ExpireInvoice marks the invoice corresponding to the passed payment hash as
+// expired. Only open invoices can expire: invoices that are already settled,
+// canceled or expired are left untouched, as are accepted hold invoices whose
+// HTLCs are still being held. The invoice is returned in its resulting state.
+func (d *DB) ExpireInvoice(paymentHash [32]byte) (*Invoice, error) {
+	return d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		if invoice.Terms.State != ContractOpen {
+			return errInvoiceUnchanged
+		}
+
+		invoice.Terms.State = ContractExpired
+
+		return nil
+	})
+}
+
+// DeleteCanceledInvoices removes all canceled and expired invoices that were
+// created before the passed time from the database. The payment hashes of the
+// removed invoices are released, such that they can be used by new invoices.
+// The number of deleted invoices is returned.
+func (d *DB) DeleteCanceledInvoices(createdBefore time.Time) (int, error) {
+	var numDeleted int
+	err := d.Update(func(tx *bolt.Tx) error {
+		invoices := tx.Bucket(invoiceBucket)
+		if invoices == nil {
+			return nil
+		}
+		invoiceIndex := invoices.Bucket(invoiceIndexBucket)
+		if invoiceIndex == nil {
+			return nil
+		}
+
+		// First, we'll collect the keys of all invoices that should be
+		// removed, as the bucket can't be modified while iterating
+		// over it.
+		deleted := make(map[string]struct{})
+		err := invoices.ForEach(func(k, v []byte) error {
+			// Skip the nested index bucket.
+			if v == nil {
+				return nil
+			}
+
+			invoice, err := deserializeInvoice(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			state := invoice.Terms.State
+			if state != ContractCanceled && state != ContractExpired {
+				return nil
+			}
+			if !invoice.CreationDate.Before(createdBefore) {
+				return nil
+			}
+
+			deleted[string(k)] = struct{}{}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// The preimage of canceled hold invoices isn't known, so we
+		// scan the payment hash index for the entries that point to
+		// the deleted invoices.
+		var deletedHashes [][]byte
+		err = invoiceIndex.ForEach(func(k, v []byte) error {
+			if bytes.Equal(k, numInvoicesKey) {
+				return nil
+			}
+			if _, ok := deleted[string(v)]; ok {
+				hash := make([]byte, len(k))
+				copy(hash, k)
+				deletedHashes = append(deletedHashes, hash)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, hash := range deletedHashes {
+			if err := invoiceIndex.Delete(hash); err != nil {
+				return err
+			}
+		}
+		for invoiceKey := range deleted {
+			if err := invoices.Delete([]byte(invoiceKey)); err != nil {
+				return err
+			}
+		}
+
+		numDeleted = len(deleted)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numDeleted, nil
+}
+
+// errInvoiceUnchanged is returned by an invoice update callback to signal that
+// the invoice is already in the desired state, and doesn't need to be written
+// back to disk.
+var errInvoiceUnchanged = fmt.Errorf("invoice unchanged")
+
+// updateInvoice fetches the invoice matching the passed payment hash, applies
+// the update callback to it, and writes the result back to the database. If
+// the callback returns errInvoiceUnchanged, the invoice is returned as is
+// without being written.
+func (d *DB) updateInvoice(paymentHash [32]byte,
+	update func(*Invoice) error) (*Invoice, error) {
+
+	var invoice *Invoice
+	err := d.Update(func(tx *bolt.Tx) error {
 		invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
 		if err != nil {
 			return err
@@ -280,12 +573,34 @@ func (d *DB) SettleInvoice(paymentHash [32]byte) error {
 			return ErrInvoiceNotFound
 		}
 
-		return settleInvoice(invoices, invoiceNum)
+		invoice, err = fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+
+		switch err := update(invoice); {
+		case err == errInvoiceUnchanged:
+			return nil
+		case err != nil:
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := serializeInvoice(&buf, invoice); err != nil {
+			return err
+		}
+
+		return invoices.Put(invoiceNum[:], buf.Bytes())
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
 }
 
 func putInvoice(invoices *bolt.Bucket, invoiceIndex *bolt.Bucket,
-	i *Invoice, invoiceNum uint32) error {
+	i *Invoice, invoiceNum uint32, paymentHash [32]byte) error {
 
 	// Create the invoice key which is just the big-endian representation
 	// of the invoice number.
@@ -304,7 +619,6 @@ func putInvoice(invoices *bolt.Bucket, invoiceIndex *bolt.Bucket,
 	// Add the payment hash to the invoice index. This will let us quickly
 	// identify if we can settle an incoming payment, and also to possibly
 	// allow a single invoice to have multiple payment installations.
-	paymentHash := sha256.Sum256(i.Terms.PaymentPreimage[:])
 	if err := invoiceIndex.Put(paymentHash[:], invoiceKey[:]); err != nil {
 		return err
 	}
@@ -357,7 +671,7 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 		return err
 	}
 
-	if err := binary.Write(w, byteOrder, i.Terms.Settled); err != nil {
+	if err := binary.Write(w, byteOrder, i.Terms.State); err != nil {
 		return err
 	}
 
@@ -419,32 +733,9 @@ func deserializeInvoice(r io.Reader) (*Invoice, error) {
 	}
 	invoice.Terms.Value = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
 
-	if err := binary.Read(r, byteOrder, &invoice.Terms.Settled); err != nil {
+	if err := binary.Read(r, byteOrder, &invoice.Terms.State); err != nil {
 		return nil, err
 	}
 
 	return invoice, nil
 }
-
-func settleInvoice(invoices *bolt.Bucket, invoiceNum []byte) error {
-	invoice, err := fetchInvoice(invoiceNum, invoices)
-	if err != nil {
-		return err
-	}
-
-	// Add idempotency to duplicate settles, return here to avoid
-	// overwriting the previous info.
-	if invoice.Terms.Settled {
-		return nil
-	}
-
-	invoice.Terms.Settled = true
-	invoice.SettleDate = time.Now()
-
-	var buf bytes.Buffer
-	if err := serializeInvoice(&buf, invoice); err != nil {
-		return nil
-	}
-
-	return invoices.Put(invoiceNum[:], buf.Bytes())
-}
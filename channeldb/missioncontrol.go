@@ -0,0 +1,211 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// missionControlBucket is the name of the bucket within the database
+	// that stores the outcomes of past payment attempts, as learned by the
+	// router's mission control.
+	//
+	// Within the mission control bucket, the results of each directed
+	// node pair are keyed by the concatenation of the compressed public
+	// keys of the pair's source and destination nodes.
+	missionControlBucket = []byte("mission-control")
+)
+
+// MissionControlPair holds the outcomes of past payment attempts that
+// attempted to forward an HTLC from one node to another. For both the last
+// failed and the last successful attempt, the time and amount of the HTLC are
+// recorded.
+type MissionControlPair struct {
+	// From is the compressed public key of the node that forwarded the
+	// HTLC.
+	From [33]byte
+
+	// To is the compressed public key of the node the HTLC was forwarded
+	// to.
+	To [33]byte
+
+	// FailTime is the time of the last failed attempt. It is the zero
+	// time if no failure has been recorded.
+	FailTime time.Time
+
+	// FailAmt is the amount of the last failed attempt. A zero amount
+	// denotes a failure that isn't related to the amount, such that
+	// attempts of any amount are expected to fail.
+	FailAmt lnwire.MilliSatoshi
+
+	// SuccessTime is the time of the last successful attempt. It is the
+	// zero time if no success has been recorded.
+	SuccessTime time.Time
+
+	// SuccessAmt is the amount of the last successful attempt.
+	SuccessAmt lnwire.MilliSatoshi
+}
+
+// PutMissionControlPairs adds the passed node pair results to the database,
+// replacing any results previously stored for the same pairs.
+func (d *DB) PutMissionControlPairs(pairs ...*MissionControlPair) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		results, err := tx.CreateBucketIfNotExists(
+			missionControlBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, pair := range pairs {
+			var b bytes.Buffer
+			if err := serializeMissionControlPair(&b, pair); err != nil {
+				return err
+			}
+
+			err := results.Put(missionControlPairKey(pair), b.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FetchMissionControlPairs returns all node pair results stored within the
+// database.
+func (d *DB) FetchMissionControlPairs() ([]*MissionControlPair, error) {
+	var pairs []*MissionControlPair
+
+	err := d.View(func(tx *bolt.Tx) error {
+		results := tx.Bucket(missionControlBucket)
+		if results == nil {
+			return nil
+		}
+
+		return results.ForEach(func(k, v []byte) error {
+			pair, err := deserializeMissionControlPair(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+			copy(pair.From[:], k[:33])
+			copy(pair.To[:], k[33:])
+
+			pairs = append(pairs, pair)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+// ResetMissionControl deletes all node pair results from the database.
+func (d *DB) ResetMissionControl() error {
+	return d.Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket(missionControlBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// missionControlPairKey returns the key the results of the passed node pair
+// are stored under.
+func missionControlPairKey(pair *MissionControlPair) []byte {
+	var k [66]byte
+	copy(k[:33], pair.From[:])
+	copy(k[33:], pair.To[:])
+
+	return k[:]
+}
+
+// serializeTime encodes the passed time as the number of nanoseconds since
+// the unix epoch, using zero for the zero time.
+func serializeTime(w io.Writer, t time.Time) error {
+	var scratch [8]byte
+	if !t.IsZero() {
+		byteOrder.PutUint64(scratch[:], uint64(t.UnixNano()))
+	}
+
+	_, err := w.Write(scratch[:])
+	return err
+}
+
+// deserializeTime decodes a time encoded by serializeTime.
+func deserializeTime(r io.Reader) (time.Time, error) {
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return time.Time{}, err
+	}
+
+	unixNano := byteOrder.Uint64(scratch[:])
+	if unixNano == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(0, int64(unixNano)), nil
+}
+
+func serializeMissionControlPair(w io.Writer, p *MissionControlPair) error {
+	var scratch [8]byte
+
+	if err := serializeTime(w, p.FailTime); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(p.FailAmt))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if err := serializeTime(w, p.SuccessTime); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(p.SuccessAmt))
+	_, err := w.Write(scratch[:])
+	return err
+}
+
+func deserializeMissionControlPair(r io.Reader) (*MissionControlPair, error) {
+	var (
+		scratch [8]byte
+		err     error
+	)
+
+	p := &MissionControlPair{}
+
+	p.FailTime, err = deserializeTime(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	p.FailAmt = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	p.SuccessTime, err = deserializeTime(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	p.SuccessAmt = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	return p, nil
+}
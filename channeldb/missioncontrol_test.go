@@ -0,0 +1,79 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestMissionControlPairStorage tests that node pair results can be stored,
+// replaced, fetched and reset.
+func TestMissionControlPairStorage(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Fetching the results before any have been stored should return an
+	// empty set.
+	pairs, err := db.FetchMissionControlPairs()
+	if err != nil {
+		t.Fatalf("unable to fetch pairs: %v", err)
+	}
+	if len(pairs) != 0 {
+		t.Fatalf("expected no pairs, got %v", len(pairs))
+	}
+
+	// We'll store two pairs, one of which only has a failure recorded,
+	// which should come back with a zero success time.
+	pair1 := &MissionControlPair{
+		From:        [33]byte{1},
+		To:          [33]byte{2},
+		FailTime:    time.Unix(0, 1000),
+		FailAmt:     lnwire.MilliSatoshi(500),
+		SuccessTime: time.Unix(0, 500),
+		SuccessAmt:  lnwire.MilliSatoshi(100),
+	}
+	pair2 := &MissionControlPair{
+		From:     [33]byte{2},
+		To:       [33]byte{1},
+		FailTime: time.Unix(0, 2000),
+	}
+	if err := db.PutMissionControlPairs(pair1, pair2); err != nil {
+		t.Fatalf("unable to store pairs: %v", err)
+	}
+
+	assertPairs := func(expected ...*MissionControlPair) {
+		t.Helper()
+
+		pairs, err := db.FetchMissionControlPairs()
+		if err != nil {
+			t.Fatalf("unable to fetch pairs: %v", err)
+		}
+		if !reflect.DeepEqual(pairs, expected) {
+			t.Fatalf("pairs mismatch: expected %v, got %v",
+				spew.Sdump(expected), spew.Sdump(pairs))
+		}
+	}
+	assertPairs(pair1, pair2)
+
+	// Storing new results for an existing pair should replace them.
+	pair1.SuccessTime = time.Unix(0, 3000)
+	pair1.SuccessAmt = lnwire.MilliSatoshi(1000)
+	if err := db.PutMissionControlPairs(pair1); err != nil {
+		t.Fatalf("unable to store pair: %v", err)
+	}
+	assertPairs(pair1, pair2)
+
+	// Finally, after resetting mission control, no results should remain.
+	if err := db.ResetMissionControl(); err != nil {
+		t.Fatalf("unable to reset mission control: %v", err)
+	}
+	assertPairs()
+}
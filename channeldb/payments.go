@@ -17,8 +17,75 @@ var (
 	// which is a monotonically increasing uint64.  BoltDB's sequence
 	// feature is used for generating monotonically increasing id.
 	paymentBucket = []byte("payments")
+
+	// paymentShardsBucket is the name of the bucket within the database
+	// that stores the shards of all payments sent.
+	//
+	// Within the payment shards bucket, a sub-bucket is created for each
+	// payment hash. Each sub-bucket stores the shards sent for the
+	// payment, keyed by their shard ID which is a monotonically increasing
+	// uint64 obtained from the sub-bucket's sequence.
+	paymentShardsBucket = []byte("payment-shards")
 )
 
+// ShardStatus describes the status of a single shard of a payment.
+type ShardStatus uint8
+
+const (
+	// ShardInFlight denotes that the shard has been sent, and is awaiting
+	// its result.
+	ShardInFlight ShardStatus = 0
+
+	// ShardSucceeded denotes that the shard has been settled by the
+	// destination.
+	ShardSucceeded ShardStatus = 1
+
+	// ShardFailed denotes that the shard has been failed back to us.
+	ShardFailed ShardStatus = 2
+)
+
+// String returns a human readable identifier for the shard status.
+func (s ShardStatus) String() string {
+	switch s {
+	case ShardInFlight:
+		return "InFlight"
+	case ShardSucceeded:
+		return "Succeeded"
+	case ShardFailed:
+		return "Failed"
+	}
+
+	return "Unknown"
+}
+
+// PaymentShard is a single HTLC sent as part of a payment. A payment that is
+// split across multiple paths consists of several shards, each carrying a
+// part of the total payment amount along its own route.
+type PaymentShard struct {
+	// ShardID uniquely identifies the shard among the shards of the same
+	// payment. It is assigned when the shard is added to the database.
+	ShardID uint64
+
+	// Amount is the amount delivered to the destination by this shard,
+	// excluding fees.
+	Amount lnwire.MilliSatoshi
+
+	// Fee is the total fee paid for this shard along its route.
+	Fee lnwire.MilliSatoshi
+
+	// TimeLock is the total time-lock of the HTLC extended to the first
+	// hop of the shard's route.
+	TimeLock uint32
+
+	// Path encodes the path taken by the shard. The path excludes the
+	// outgoing node and consists of the compressed public key of each of
+	// the nodes involved in the route.
+	Path [][33]byte
+
+	// Status is the current status of the shard.
+	Status ShardStatus
+}
+
 // OutgoingPayment represents a successful payment between the daemon and a
 // remote node. Details such as the total fee paid, and the time of the payment
 // are stored.
@@ -116,7 +183,7 @@ func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 	return payments, nil
 }
 
-// DeleteAllPayments deletes all payments from DB.
+// DeleteAllPayments deletes all payments, along with their shards, from DB.
 func (db *DB) DeleteAllPayments() error {
 	return db.Update(func(tx *bolt.Tx) error {
 		err := tx.DeleteBucket(paymentBucket)
@@ -124,11 +191,202 @@ func (db *DB) DeleteAllPayments() error {
 			return err
 		}
 
+		err = tx.DeleteBucket(paymentShardsBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
 		_, err = tx.CreateBucket(paymentBucket)
 		return err
 	})
 }
 
+// AddPaymentShard records a newly sent shard of the payment identified by the
+// passed payment hash. A unique shard ID is assigned to the shard, and written
+// to its ShardID field.
+func (db *DB) AddPaymentShard(paymentHash [32]byte, shard *PaymentShard) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		shards, err := tx.CreateBucketIfNotExists(paymentShardsBucket)
+		if err != nil {
+			return err
+		}
+
+		paymentShards, err := shards.CreateBucketIfNotExists(
+			paymentHash[:],
+		)
+		if err != nil {
+			return err
+		}
+
+		shardID, err := paymentShards.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := serializePaymentShard(&b, shard); err != nil {
+			return err
+		}
+
+		var shardIDBytes [8]byte
+		byteOrder.PutUint64(shardIDBytes[:], shardID)
+
+		if err := paymentShards.Put(shardIDBytes[:], b.Bytes()); err != nil {
+			return err
+		}
+
+		shard.ShardID = shardID
+
+		return nil
+	})
+}
+
+// UpdatePaymentShard sets the status of the shard with the given ID of the
+// payment identified by the passed payment hash.
+func (db *DB) UpdatePaymentShard(paymentHash [32]byte, shardID uint64,
+	status ShardStatus) error {
+
+	return db.Update(func(tx *bolt.Tx) error {
+		shards := tx.Bucket(paymentShardsBucket)
+		if shards == nil {
+			return ErrPaymentShardsNotFound
+		}
+
+		paymentShards := shards.Bucket(paymentHash[:])
+		if paymentShards == nil {
+			return ErrPaymentShardsNotFound
+		}
+
+		var shardIDBytes [8]byte
+		byteOrder.PutUint64(shardIDBytes[:], shardID)
+
+		shardBytes := paymentShards.Get(shardIDBytes[:])
+		if shardBytes == nil {
+			return ErrPaymentShardNotFound
+		}
+
+		shard, err := deserializePaymentShard(bytes.NewReader(shardBytes))
+		if err != nil {
+			return err
+		}
+		shard.Status = status
+
+		var b bytes.Buffer
+		if err := serializePaymentShard(&b, shard); err != nil {
+			return err
+		}
+
+		return paymentShards.Put(shardIDBytes[:], b.Bytes())
+	})
+}
+
+// FetchPaymentShards returns all shards sent for the payment identified by the
+// passed payment hash, in the order they were sent.
+func (db *DB) FetchPaymentShards(paymentHash [32]byte) ([]*PaymentShard, error) {
+	var paymentShards []*PaymentShard
+
+	err := db.View(func(tx *bolt.Tx) error {
+		shards := tx.Bucket(paymentShardsBucket)
+		if shards == nil {
+			return ErrPaymentShardsNotFound
+		}
+
+		shardBucket := shards.Bucket(paymentHash[:])
+		if shardBucket == nil {
+			return ErrPaymentShardsNotFound
+		}
+
+		return shardBucket.ForEach(func(k, v []byte) error {
+			shard, err := deserializePaymentShard(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+			shard.ShardID = byteOrder.Uint64(k)
+
+			paymentShards = append(paymentShards, shard)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentShards, nil
+}
+
+func serializePaymentShard(w io.Writer, s *PaymentShard) error {
+	var scratch [8]byte
+
+	byteOrder.PutUint64(scratch[:], uint64(s.Amount))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(s.Fee))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], s.TimeLock)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], uint32(len(s.Path)))
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	for _, hop := range s.Path {
+		if _, err := w.Write(hop[:]); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{byte(s.Status)})
+	return err
+}
+
+func deserializePaymentShard(r io.Reader) (*PaymentShard, error) {
+	var scratch [8]byte
+
+	s := &PaymentShard{}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	s.Amount = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	s.Fee = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	s.TimeLock = byteOrder.Uint32(scratch[:4])
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	pathLen := byteOrder.Uint32(scratch[:4])
+
+	s.Path = make([][33]byte, pathLen)
+	for i := uint32(0); i < pathLen; i++ {
+		if _, err := io.ReadFull(r, s.Path[i][:]); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return nil, err
+	}
+	s.Status = ShardStatus(scratch[0])
+
+	return s, nil
+}
+
 func serializeOutgoingPayment(w io.Writer, p *OutgoingPayment) error {
 	var scratch [8]byte
 
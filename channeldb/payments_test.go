@@ -195,3 +195,99 @@ func TestOutgoingPaymentWorkflow(t *testing.T) {
 			len(paymentsAfterDeletion), 0)
 	}
 }
+
+// TestPaymentShards asserts that the shards of a payment can be added,
+// updated and retrieved, and that they are removed along with all other
+// payments.
+func TestPaymentShards(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	var paymentHash [32]byte
+	copy(paymentHash[:], rev[:])
+
+	// Before any shards are sent, none should be found.
+	_, err = db.FetchPaymentShards(paymentHash)
+	if err != ErrPaymentShardsNotFound {
+		t.Fatalf("expected ErrPaymentShardsNotFound, got %v", err)
+	}
+
+	fakePath := make([][33]byte, 2)
+	for i := 0; i < 2; i++ {
+		copy(fakePath[i][:], bytes.Repeat([]byte{byte(i)}, 33))
+	}
+
+	shards := []*PaymentShard{
+		{
+			Amount:   60000,
+			Fee:      10,
+			TimeLock: 500,
+			Path:     fakePath,
+			Status:   ShardInFlight,
+		},
+		{
+			Amount:   40000,
+			Fee:      3,
+			TimeLock: 510,
+			Path:     fakePath[:1],
+			Status:   ShardInFlight,
+		},
+	}
+	for i, shard := range shards {
+		if err := db.AddPaymentShard(paymentHash, shard); err != nil {
+			t.Fatalf("unable to add shard: %v", err)
+		}
+
+		if shard.ShardID != uint64(i+1) {
+			t.Fatalf("expected shard id %v, got %v", i+1,
+				shard.ShardID)
+		}
+	}
+
+	// Mark the first shard as failed, and the second one as succeeded.
+	err = db.UpdatePaymentShard(
+		paymentHash, shards[0].ShardID, ShardFailed,
+	)
+	if err != nil {
+		t.Fatalf("unable to update shard: %v", err)
+	}
+	shards[0].Status = ShardFailed
+
+	err = db.UpdatePaymentShard(
+		paymentHash, shards[1].ShardID, ShardSucceeded,
+	)
+	if err != nil {
+		t.Fatalf("unable to update shard: %v", err)
+	}
+	shards[1].Status = ShardSucceeded
+
+	// Updating an unknown shard should fail.
+	err = db.UpdatePaymentShard(paymentHash, 100, ShardFailed)
+	if err != ErrPaymentShardNotFound {
+		t.Fatalf("expected ErrPaymentShardNotFound, got %v", err)
+	}
+
+	dbShards, err := db.FetchPaymentShards(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch shards: %v", err)
+	}
+	if !reflect.DeepEqual(dbShards, shards) {
+		t.Fatalf("shards mismatch: expected %v, got %v",
+			spew.Sdump(shards), spew.Sdump(dbShards))
+	}
+
+	// Finally, deleting all payments should also remove the shards.
+	if err := db.DeleteAllPayments(); err != nil {
+		t.Fatalf("unable to delete payments from DB: %v", err)
+	}
+
+	_, err = db.FetchPaymentShards(paymentHash)
+	if err != ErrPaymentShardsNotFound {
+		t.Fatalf("expected ErrPaymentShardsNotFound, got %v", err)
+	}
+}
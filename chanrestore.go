@@ -0,0 +1,250 @@
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/lightningnetwork/lnd/chanbackup"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/contractcourt"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/shachain"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// maxShaChainRootScan is the maximum number of keys within the
+	// revocation root key family that we'll scan in order to locate the
+	// shachain root of a restored channel if the backup only carries the
+	// public key of the root.
+	maxShaChainRootScan = 5000
+)
+
+// chanDBRestorer is an implementation of the chanbackup.ChannelRestorer
+// interface that is able to properly map a Single backup, into a
+// channeldb.ChannelShell which is required to fully restore a channel. We
+// also need the secret key ring in order to re-derive the shachain root of
+// the channel.
+type chanDBRestorer struct {
+	db *channeldb.DB
+
+	secretKeys keychain.SecretKeyRing
+
+	chainArb *contractcourt.ChainArbitrator
+}
+
+// A compile-time check to ensure that chanDBRestorer implements the
+// chanbackup.ChannelRestorer interface.
+var _ chanbackup.ChannelRestorer = (*chanDBRestorer)(nil)
+
+// deriveShaChainRoot re-derives the private key that was used as the shachain
+// root of a channel from the key descriptor stored within its backup. If the
+// descriptor only carries a public key, then we'll scan the revocation root
+// key family until we find a matching key.
+func (c *chanDBRestorer) deriveShaChainRoot(
+	rootDesc keychain.KeyDescriptor) (*btcec.PrivateKey,
+	keychain.KeyLocator, error) {
+
+	// If the backup doesn't carry a public key, then the locator is all
+	// we need to derive the private key directly.
+	if rootDesc.PubKey == nil {
+		privKey, err := c.secretKeys.DerivePrivKey(rootDesc)
+		if err != nil {
+			return nil, keychain.KeyLocator{}, err
+		}
+
+		return privKey, rootDesc.KeyLocator, nil
+	}
+
+	// Otherwise, we'll need to scan the revocation root key family for
+	// the private key that matches the public key within the backup.
+	for i := uint32(0); i < maxShaChainRootScan; i++ {
+		keyLoc := keychain.KeyLocator{
+			Family: keychain.KeyFamilyRevocationRoot,
+			Index:  i,
+		}
+		privKey, err := c.secretKeys.DerivePrivKey(keychain.KeyDescriptor{
+			KeyLocator: keyLoc,
+		})
+		if err != nil {
+			return nil, keychain.KeyLocator{}, err
+		}
+
+		if privKey.PubKey().IsEqual(rootDesc.PubKey) {
+			return privKey, keyLoc, nil
+		}
+	}
+
+	return nil, keychain.KeyLocator{}, fmt.Errorf("unable to locate "+
+		"shachain root for pubkey=%x within first %v keys",
+		rootDesc.PubKey.SerializeCompressed(), maxShaChainRootScan)
+}
+
+// openChannelShell maps the static channel back up into an open channel
+// "shell". We say shell as this doesn't include all the information required
+// to continue to use the channel, only the minimal amount of information to
+// insert this shell channel back into the database.
+func (c *chanDBRestorer) openChannelShell(
+	backup chanbackup.Single) (*channeldb.ChannelShell, error) {
+
+	// First, we'll need to re-derive the shachain root, as it's required
+	// in order to generate the commitment points we'll present to the
+	// remote party during the data loss recovery protocol.
+	privKey, revKeyLoc, err := c.deriveShaChainRoot(
+		backup.ShaChainRootDesc,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive shachain root key: "+
+			"%v", err)
+	}
+	revRoot, err := chainhash.NewHash(privKey.Serialize())
+	if err != nil {
+		return nil, err
+	}
+	shaChainProducer := shachain.NewRevocationProducer(*revRoot)
+
+	// We'll give both commitments a transaction that spends the funding
+	// outpoint, as this is the only part of the commitment that we're
+	// able to reconstruct from the backup alone.
+	newCommitTx := func() *wire.MsgTx {
+		commitTx := wire.NewMsgTx(2)
+		commitTx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: backup.FundingOutpoint,
+		})
+		return commitTx
+	}
+
+	// Note that we mark the channel as a single funder channel, as this
+	// is the only channel type that we currently support. Channels that
+	// were backed up with the anchors version additionally make use of
+	// the anchor commitment format.
+	chanType := channeldb.ChannelType(channeldb.SingleFunder)
+	if backup.Version == chanbackup.AnchorsCommitVersion {
+		chanType |= channeldb.AnchorOutputsBit
+	}
+
+	chanShell := channeldb.ChannelShell{
+		NodeAddrs: backup.Addresses,
+		Chan: &channeldb.OpenChannel{
+			ChanType:                chanType,
+			ChainHash:               backup.ChainHash,
+			IsInitiator:             backup.IsInitiator,
+			Capacity:                backup.Capacity,
+			FundingOutpoint:         backup.FundingOutpoint,
+			ShortChanID:             backup.ShortChannelID,
+			IdentityPub:             backup.RemoteNodePub,
+			LocalChanCfg:            backup.LocalChanCfg,
+			RemoteChanCfg:           backup.RemoteChanCfg,
+			RemoteCurrentRevocation: backup.RemoteNodePub,
+			RevocationStore:         shachain.NewRevocationStore(),
+			RevocationProducer:      shaChainProducer,
+			RevocationKeyLocator:    revKeyLoc,
+			LocalCommitment: channeldb.ChannelCommitment{
+				CommitTx: newCommitTx(),
+			},
+			RemoteCommitment: channeldb.ChannelCommitment{
+				CommitTx: newCommitTx(),
+			},
+		},
+	}
+
+	return &chanShell, nil
+}
+
+// RestoreChansFromSingles attempts to map the set of single channel backups
+// to channel shells that will be stored persistently. Once these shells have
+// been stored on disk, we'll be able to connect to the channel peer and
+// execute the data loss recovery protocol.
+//
+// NOTE: Part of the chanbackup.ChannelRestorer interface.
+func (c *chanDBRestorer) RestoreChansFromSingles(backups ...chanbackup.Single) error {
+	channelShells := make([]*channeldb.ChannelShell, 0, len(backups))
+	for _, backup := range backups {
+		chanShell, err := c.openChannelShell(backup)
+		if err != nil {
+			return err
+		}
+
+		channelShells = append(channelShells, chanShell)
+	}
+
+	ltndLog.Infof("Inserting %v restored channel shells into DB",
+		len(channelShells))
+
+	// Now that we have all the backups mapped into a series of channel
+	// shells, we'll insert them all into the database.
+	if err := c.db.RestoreChannelShells(channelShells...); err != nil {
+		return err
+	}
+
+	// Finally, we'll launch a chain watcher for each of the restored
+	// channels, so we're able to sweep our funds once the remote party
+	// force closes the channel.
+	for _, chanShell := range channelShells {
+		err := c.chainArb.WatchNewChannel(chanShell.Chan)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// A compile-time check to ensure that our server implements the
+// chanbackup.PeerConnector interface.
+var _ chanbackup.PeerConnector = (*server)(nil)
+
+// ConnectPeer attempts to connect to the target node at the set of available
+// addresses. Once this method returns with a nil error, the connector
+// should attempt to persistently connect to the target peer in the background
+// as a persistent attempt.
+//
+// NOTE: Part of the chanbackup.PeerConnector interface.
+func (s *server) ConnectPeer(nodePub *btcec.PublicKey,
+	addrs []net.Addr) error {
+
+	// If we're already connected to the peer, then we'll disconnect them
+	// first, as the channel reestablishment that kicks off the data loss
+	// recovery protocol is only carried out once a new connection is
+	// established.
+	if _, err := s.FindPeer(nodePub); err == nil {
+		if err := s.DisconnectPeer(nodePub); err != nil {
+			return err
+		}
+	}
+
+	if len(addrs) == 0 {
+		ltndLog.Warnf("No known addresses for node=%x, unable to "+
+			"connect", nodePub.SerializeCompressed())
+		return nil
+	}
+
+	// For each of the known addresses, we'll attempt to launch a
+	// persistent connection to the (pub, addr) pair. In the event that
+	// any of them connect, all the other stale requests will be
+	// cancelled.
+	for _, addr := range addrs {
+		netAddr := &lnwire.NetAddress{
+			IdentityKey: nodePub,
+			Address:     addr,
+			ChainNet:    activeNetParams.Net,
+		}
+
+		ltndLog.Infof("Attempting to connect to %v for channel "+
+			"backup restore", netAddr)
+
+		// Attempt to connect to the peer using this full address. If
+		// we're unable to connect to them, then we'll try the next
+		// address in place of it.
+		if err := s.ConnectToPeer(netAddr, true); err != nil {
+			ltndLog.Errorf("unable to connect to %v to "+
+				"complete channel backup restore: %v", netAddr, err)
+			continue
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -12,6 +13,7 @@ import (
 	"math"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -20,8 +22,12 @@ import (
 	"github.com/awalterschulze/gographviz"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 	"github.com/urfave/cli"
 	"golang.org/x/crypto/ssh/terminal"
@@ -388,7 +394,15 @@ var openChannelCommand = cli.Command{
 	of the funding output is returned.
 
 	One can manually set the fee to be used for the funding transaction via either
-	the --conf_target or --sat_per_byte arguments. This is optional.`,
+	the --conf_target or --sat_per_byte arguments. This is optional.
+
+	If the --psbt flag is set, then the channel is funded by an external
+	wallet rather than by lnd's wallet. Once the funding output has been
+	negotiated with the remote node, a base64 encoded PSBT containing it is
+	returned, which should be funded and signed by the external wallet. The
+	signed transaction must then be handed back to lnd with the finalizepsbt
+	command, after which the funding flow resumes. The command keeps waiting
+	for the funding flow to resume in the meantime.`,
 	ArgsUsage: "node-key local-amt push-amt",
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -446,6 +460,20 @@ var openChannelCommand = cli.Command{
 				"not set, we will scale the value according to the " +
 				"channel size",
 		},
+		cli.StringFlag{
+			Name: "close_address",
+			Usage: "(optional) an address to which our funds will " +
+				"be paid upon a cooperative close of the " +
+				"channel. The address is committed to when " +
+				"the channel is opened, and can't be changed " +
+				"later on",
+		},
+		cli.BoolFlag{
+			Name: "psbt",
+			Usage: "(optional) fund the channel with an external " +
+				"wallet through a PSBT, rather than with the " +
+				"internal wallet",
+		},
 	},
 	Action: actionDecorator(openChannel),
 }
@@ -470,6 +498,8 @@ func openChannel(ctx *cli.Context) error {
 		SatPerByte:     ctx.Int64("sat_per_byte"),
 		MinHtlcMsat:    ctx.Int64("min_htlc_msat"),
 		RemoteCsvDelay: uint32(ctx.Uint64("remote_csv_delay")),
+		CloseAddress:   ctx.String("close_address"),
+		PsbtFunding:    ctx.Bool("psbt"),
 	}
 
 	switch {
@@ -552,6 +582,25 @@ func openChannel(ctx *cli.Context) error {
 		}
 
 		switch update := resp.Update.(type) {
+		case *lnrpc.OpenStatusUpdate_PsbtFund:
+			psbtFund := update.PsbtFund
+			printJSON(struct {
+				PendingChanID  string `json:"pending_chan_id"`
+				FundingAddress string `json:"funding_address"`
+				FundingAmount  int64  `json:"funding_amount"`
+				Psbt           string `json:"psbt"`
+			}{
+				PendingChanID:  hex.EncodeToString(psbtFund.PendingChanId),
+				FundingAddress: psbtFund.FundingAddress,
+				FundingAmount:  psbtFund.FundingAmount,
+				Psbt: base64.StdEncoding.EncodeToString(
+					psbtFund.Psbt,
+				),
+			})
+
+			fmt.Fprintln(os.Stderr, "Waiting for the funding "+
+				"transaction to be supplied with finalizepsbt...")
+
 		case *lnrpc.OpenStatusUpdate_ChanPending:
 			txid, err := chainhash.NewHash(update.ChanPending.Txid)
 			if err != nil {
@@ -605,6 +654,223 @@ func openChannel(ctx *cli.Context) error {
 	}
 }
 
+var batchOpenChannelCommand = cli.Command{
+	Name:      "batchopenchannel",
+	Usage:     "Open multiple channels within a single funding transaction.",
+	ArgsUsage: "channels-json-string [--conf_target=N] [--sat_per_byte=P]",
+	Description: `
+	Attempt to open a new channel to each of the specified existing peers,
+	all funded by a single funding transaction. If the funding workflow with
+	any of the peers fails, then none of the channels are opened. Once the
+	funding transaction has been broadcast, the channelPoint (txid:vout) of
+	each funding output is returned.
+
+	The channels-json-string param decodes the channels to open in the
+	following format:
+
+	    '[{"node_pubkey": "HexPubKey", "local_amt": NumSatoshis,
+	       "push_amt": NumSatoshis, "private": Bool,
+	       "min_htlc_msat": NumMilliSatoshis, "remote_csv_delay": NumBlocks,
+	       "close_address": "Addr"}, ...]'
+
+	Only node_pubkey and local_amt are required for each channel.
+
+	One can manually set the fee to be used for the funding transaction via either
+	the --conf_target or --sat_per_byte arguments. This is optional.`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the " +
+				"transaction *should* confirm in, will be " +
+				"used for fee estimation",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) a manual fee expressed in " +
+				"sat/byte that should be used when crafting " +
+				"the transaction",
+		},
+	},
+	Action: actionDecorator(batchOpenChannel),
+}
+
+func batchOpenChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 {
+		cli.ShowCommandHelp(ctx, "batchopenchannel")
+		return nil
+	}
+
+	if ctx.IsSet("conf_target") && ctx.IsSet("sat_per_byte") {
+		return fmt.Errorf("either conf_target or sat_per_byte should be " +
+			"set, but not both")
+	}
+
+	var channels []struct {
+		NodePubkey     string `json:"node_pubkey"`
+		LocalAmt       int64  `json:"local_amt"`
+		PushAmt        int64  `json:"push_amt"`
+		Private        bool   `json:"private"`
+		MinHtlcMsat    int64  `json:"min_htlc_msat"`
+		RemoteCsvDelay uint32 `json:"remote_csv_delay"`
+		CloseAddress   string `json:"close_address"`
+	}
+	jsonChannels := ctx.Args().First()
+	if err := json.Unmarshal([]byte(jsonChannels), &channels); err != nil {
+		return fmt.Errorf("unable to decode channels: %v", err)
+	}
+
+	req := &lnrpc.BatchOpenChannelRequest{
+		TargetConf: int32(ctx.Int64("conf_target")),
+		SatPerByte: ctx.Int64("sat_per_byte"),
+	}
+	for _, channel := range channels {
+		nodePubHex, err := hex.DecodeString(channel.NodePubkey)
+		if err != nil {
+			return fmt.Errorf("unable to decode node public key: %v", err)
+		}
+
+		req.Channels = append(req.Channels, &lnrpc.BatchOpenChannel{
+			NodePubkey:         nodePubHex,
+			LocalFundingAmount: channel.LocalAmt,
+			PushSat:            channel.PushAmt,
+			Private:            channel.Private,
+			MinHtlcMsat:        channel.MinHtlcMsat,
+			RemoteCsvDelay:     channel.RemoteCsvDelay,
+			CloseAddress:       channel.CloseAddress,
+		})
+	}
+
+	resp, err := client.BatchOpenChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	chanPoints := make([]string, 0, len(resp.PendingChannels))
+	for _, pendingChan := range resp.PendingChannels {
+		txid, err := chainhash.NewHash(pendingChan.Txid)
+		if err != nil {
+			return err
+		}
+
+		chanPoints = append(chanPoints, fmt.Sprintf("%v:%v", txid,
+			pendingChan.OutputIndex))
+	}
+
+	printJSON(struct {
+		ChannelPoints []string `json:"channel_points"`
+	}{
+		ChannelPoints: chanPoints,
+	})
+
+	return nil
+}
+
+var finalizePsbtCommand = cli.Command{
+	Name:      "finalizepsbt",
+	Usage:     "Resume the funding of a channel funded through a PSBT.",
+	ArgsUsage: "pending-chan-id [--signed_psbt=P | --final_raw_tx=T]",
+	Description: `
+	Resume the funding flow of a channel opened with openchannel --psbt,
+	handing lnd the funding transaction crafted and signed by the external
+	wallet. The funding transaction must contain the funding output
+	returned by openchannel, and all of its inputs must spend segwit
+	outputs. It can either be supplied as a finalized, base64 encoded PSBT
+	with --signed_psbt, or as a hex encoded raw transaction with
+	--final_raw_tx.
+
+	The funding transaction is only broadcast by lnd once the remote node
+	has signed the initial commitment transaction of the channel.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pending_chan_id",
+			Usage: "the hex encoded pending channel ID",
+		},
+		cli.StringFlag{
+			Name: "signed_psbt",
+			Usage: "the base64 encoded, finalized PSBT of the " +
+				"funding transaction",
+		},
+		cli.StringFlag{
+			Name: "final_raw_tx",
+			Usage: "the hex encoded, fully signed funding " +
+				"transaction",
+		},
+	},
+	Action: actionDecorator(finalizePsbt),
+}
+
+func finalizePsbt(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "finalizepsbt")
+		return nil
+	}
+
+	var (
+		pendingChanIDHex string
+		err              error
+	)
+	switch {
+	case ctx.IsSet("pending_chan_id"):
+		pendingChanIDHex = ctx.String("pending_chan_id")
+	case ctx.Args().Present():
+		pendingChanIDHex = ctx.Args().First()
+	default:
+		return fmt.Errorf("pending channel ID argument missing")
+	}
+
+	req := &lnrpc.FinalizePsbtFundingRequest{}
+	req.PendingChanId, err = hex.DecodeString(pendingChanIDHex)
+	if err != nil {
+		return fmt.Errorf("unable to decode pending channel ID: %v",
+			err)
+	}
+
+	switch {
+	case ctx.IsSet("signed_psbt") && ctx.IsSet("final_raw_tx"):
+		return fmt.Errorf("either signed_psbt or final_raw_tx should " +
+			"be set, but not both")
+
+	case ctx.IsSet("signed_psbt"):
+		req.SignedPsbt, err = base64.StdEncoding.DecodeString(
+			ctx.String("signed_psbt"),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to decode psbt: %v", err)
+		}
+
+	case ctx.IsSet("final_raw_tx"):
+		req.FinalRawTx, err = hex.DecodeString(
+			ctx.String("final_raw_tx"),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to decode funding tx: %v",
+				err)
+		}
+
+	default:
+		return fmt.Errorf("either signed_psbt or final_raw_tx must " +
+			"be set")
+	}
+
+	resp, err := client.FinalizePsbtFunding(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 // TODO(roasbeef): also allow short relative channel ID.
 
 var closeChannelCommand = cli.Command{
@@ -621,7 +887,10 @@ var closeChannelCommand = cli.Command{
 	In the case of a cooperative closure, One can manually set the fee to
 	be used for the closing transaction via either the --conf_target or
 	--sat_per_byte arguments. This will be the starting value used during
-	fee negotiation. This is optional.`,
+	fee negotiation. This is optional. A maximum fee rate for the
+	negotiation can also be set via the --max_sat_per_byte argument, in
+	which case the closure will fail if the remote party insists on a
+	higher fee.`,
 	ArgsUsage: "funding_txid [output_index [time_limit]]",
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -659,6 +928,12 @@ var closeChannelCommand = cli.Command{
 				"sat/byte that should be used when crafting " +
 				"the transaction",
 		},
+		cli.Int64Flag{
+			Name: "max_sat_per_byte",
+			Usage: "(optional) the maximum fee expressed in " +
+				"sat/byte that we're willing to pay for a " +
+				"cooperative closure transaction",
+		},
 	},
 	Action: actionDecorator(closeChannel),
 }
@@ -675,10 +950,11 @@ func closeChannel(ctx *cli.Context) error {
 
 	// TODO(roasbeef): implement time deadline within server
 	req := &lnrpc.CloseChannelRequest{
-		ChannelPoint: &lnrpc.ChannelPoint{},
-		Force:        ctx.Bool("force"),
-		TargetConf:   int32(ctx.Int64("conf_target")),
-		SatPerByte:   ctx.Int64("sat_per_byte"),
+		ChannelPoint:  &lnrpc.ChannelPoint{},
+		Force:         ctx.Bool("force"),
+		TargetConf:    int32(ctx.Int64("conf_target")),
+		SatPerByte:    ctx.Int64("sat_per_byte"),
+		MaxSatPerByte: ctx.Int64("max_sat_per_byte"),
 	}
 
 	args := ctx.Args()
@@ -1460,6 +1736,12 @@ var sendPaymentCommand = cli.Command{
 	it'll use the hash of all zeroes. This mode allows one to quickly test
 	payment connectivity without having to create an invoice at the
 	destination.
+
+	The --keysend flag sends a spontaneous payment to the destination,
+	without requiring an invoice. The preimage of the payment is generated
+	locally and delivered to the destination within the onion, so no
+	payment hash may be specified. The destination must accept such
+	payments.
 	`,
 	ArgsUsage: "dest amt payment_hash final_cltv_delta | --pay_req=[payment request]",
 	Flags: []cli.Flag{
@@ -1480,6 +1762,12 @@ var sendPaymentCommand = cli.Command{
 			Name:  "debug_send",
 			Usage: "use the debug rHash when sending the HTLC",
 		},
+		cli.BoolFlag{
+			Name: "keysend",
+			Usage: "send a spontaneous payment without an invoice, " +
+				"delivering a locally generated preimage to " +
+				"the destination",
+		},
 		cli.StringFlag{
 			Name:  "pay_req",
 			Usage: "a zpay32 encoded payment request to fulfill",
@@ -1488,10 +1776,20 @@ var sendPaymentCommand = cli.Command{
 			Name:  "final_cltv_delta",
 			Usage: "the number of blocks the last hop has to reveal the preimage",
 		},
+		maxShardsFlag,
 	},
 	Action: sendPayment,
 }
 
+// maxShardsFlag is the flag used by the payment commands to allow a payment to
+// be split into several shards.
+var maxShardsFlag = cli.Uint64Flag{
+	Name: "max_shards",
+	Usage: "(optional) the maximum number of shards the payment may " +
+		"be split into, each sent along a different path, if the " +
+		"destination supports multi-path payments",
+}
+
 func sendPayment(ctx *cli.Context) error {
 	// Show command help if no arguments provided
 	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
@@ -1547,6 +1845,18 @@ func sendPayment(ctx *cli.Context) error {
 			Amt:  amount,
 		}
 
+		if ctx.Bool("keysend") {
+			if ctx.IsSet("payment_hash") || args.Present() {
+				return fmt.Errorf("do not provide a payment " +
+					"hash with keysend")
+			}
+
+			req.KeySend = true
+			req.FinalCltvDelta = int32(ctx.Int64("final_cltv_delta"))
+
+			return sendPaymentRequest(ctx, req)
+		}
+
 		if ctx.Bool("debug_send") && (ctx.IsSet("payment_hash") || args.Present()) {
 			return fmt.Errorf("do not provide a payment hash with debug send")
 		} else if !ctx.Bool("debug_send") {
@@ -1590,6 +1900,8 @@ func sendPaymentRequest(ctx *cli.Context, req *lnrpc.SendRequest) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
+	req.MaxShards = uint32(ctx.Uint64("max_shards"))
+
 	paymentStream, err := client.SendPayment(context.Background())
 	if err != nil {
 		return err
@@ -1607,13 +1919,15 @@ func sendPaymentRequest(ctx *cli.Context, req *lnrpc.SendRequest) error {
 	paymentStream.CloseSend()
 
 	printJSON(struct {
-		E string       `json:"payment_error"`
-		P string       `json:"payment_preimage"`
-		R *lnrpc.Route `json:"payment_route"`
+		E string         `json:"payment_error"`
+		P string         `json:"payment_preimage"`
+		R *lnrpc.Route   `json:"payment_route"`
+		S []*lnrpc.Route `json:"shard_routes,omitempty"`
 	}{
 		E: resp.PaymentError,
 		P: hex.EncodeToString(resp.PaymentPreimage),
 		R: resp.PaymentRoute,
+		S: resp.ShardRoutes,
 	})
 
 	return nil
@@ -1633,6 +1947,7 @@ var payInvoiceCommand = cli.Command{
 			Usage: "(optional) number of satoshis to fulfill the " +
 				"invoice",
 		},
+		maxShardsFlag,
 	},
 	Action: actionDecorator(payInvoice),
 }
@@ -1709,6 +2024,12 @@ var addInvoiceCommand = cli.Command{
 				"specified an expiry of 3600 seconds (1 hour) " +
 				"is implied.",
 		},
+		cli.BoolFlag{
+			Name: "private",
+			Usage: "encode routing hints in the invoice with " +
+				"private channels in order to assist the " +
+				"payer in reaching you",
+		},
 	},
 	Action: actionDecorator(addInvoice),
 }
@@ -1767,6 +2088,7 @@ func addInvoice(ctx *cli.Context) error {
 		DescriptionHash: descHash,
 		FallbackAddr:    ctx.String("fallback_addr"),
 		Expiry:          ctx.Int64("expiry"),
+		Private:         ctx.Bool("private"),
 	}
 
 	resp, err := client.AddInvoice(context.Background(), invoice)
@@ -1835,66 +2157,319 @@ func lookupInvoice(ctx *cli.Context) error {
 	return nil
 }
 
-var listInvoicesCommand = cli.Command{
-	Name:  "listinvoices",
-	Usage: "List all invoices currently stored.",
+var addHoldInvoiceCommand = cli.Command{
+	Name:  "addholdinvoice",
+	Usage: "Add a new hold invoice.",
+	Description: `
+	Add a new invoice, expressing intent for a future payment.
+
+	Invoices without an amount can be created by not supplying any
+	parameters or providing an amount of 0. These invoices allow the payee
+	to specify the amount of satoshis they wish to send.
+
+	In contrast to regular invoices, a hold invoice is created from only
+	the payment hash. Incoming payments to the invoice are held until the
+	invoice is settled with settleinvoice, or canceled with cancelinvoice.`,
+	ArgsUsage: "hash [amt]",
 	Flags: []cli.Flag{
-		cli.BoolFlag{
-			Name: "pending_only",
-			Usage: "toggles if all invoices should be returned, or only " +
-				"those that are currently unsettled",
+		cli.StringFlag{
+			Name: "memo",
+			Usage: "a description of the payment to attach along " +
+				"with the invoice (default=\"\")",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the amt of satoshis in this invoice",
+		},
+		cli.StringFlag{
+			Name: "description_hash",
+			Usage: "SHA-256 hash of the description of the payment. " +
+				"Used if the purpose of payment cannot naturally " +
+				"fit within the memo. If provided this will be " +
+				"used instead of the description(memo) field in " +
+				"the encoded invoice.",
+		},
+		cli.StringFlag{
+			Name: "fallback_addr",
+			Usage: "fallback on-chain address that can be used in " +
+				"case the lightning payment fails",
+		},
+		cli.Int64Flag{
+			Name: "expiry",
+			Usage: "the invoice's expiry time in seconds. If not " +
+				"specified an expiry of 3600 seconds (1 hour) " +
+				"is implied.",
 		},
 	},
-	Action: actionDecorator(listInvoices),
+	Action: actionDecorator(addHoldInvoice),
 }
 
-func listInvoices(ctx *cli.Context) error {
+func addHoldInvoice(ctx *cli.Context) error {
+	var (
+		descHash []byte
+		amt      int64
+		err      error
+	)
+
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	pendingOnly := true
-	if !ctx.Bool("pending_only") {
-		pendingOnly = false
+	args := ctx.Args()
+	if !args.Present() {
+		return fmt.Errorf("hash argument missing")
 	}
 
-	req := &lnrpc.ListInvoiceRequest{
-		PendingOnly: pendingOnly,
+	hash, err := hex.DecodeString(args.First())
+	if err != nil {
+		return fmt.Errorf("unable to parse hash: %v", err)
 	}
+	args = args.Tail()
 
-	invoices, err := client.ListInvoices(context.Background(), req)
+	switch {
+	case ctx.IsSet("amt"):
+		amt = ctx.Int64("amt")
+	case args.Present():
+		amt, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode amt argument: %v", err)
+		}
+	}
+
+	descHash, err = hex.DecodeString(ctx.String("description_hash"))
+	if err != nil {
+		return fmt.Errorf("unable to parse description_hash: %v", err)
+	}
+
+	invoice := &lnrpc.AddHoldInvoiceRequest{
+		Memo:            ctx.String("memo"),
+		Hash:            hash,
+		Value:           amt,
+		DescriptionHash: descHash,
+		FallbackAddr:    ctx.String("fallback_addr"),
+		Expiry:          ctx.Int64("expiry"),
+	}
+
+	resp, err := client.AddHoldInvoice(context.Background(), invoice)
 	if err != nil {
 		return err
 	}
 
-	printRespJSON(invoices)
+	printJSON(struct {
+		PayReq string `json:"pay_req"`
+	}{
+		PayReq: resp.PaymentRequest,
+	})
 
 	return nil
 }
 
-var describeGraphCommand = cli.Command{
-	Name: "describegraph",
-	Description: "Prints a human readable version of the known channel " +
-		"graph from the PoV of the node",
-	Usage: "Describe the network graph",
+var settleInvoiceCommand = cli.Command{
+	Name:  "settleinvoice",
+	Usage: "Reveal a preimage and use it to settle the corresponding invoice.",
+	Description: `
+	Settle an accepted hold invoice by revealing the preimage of its
+	payment hash. The payments being held for the invoice are settled as
+	a result.`,
+	ArgsUsage: "preimage",
 	Flags: []cli.Flag{
-		cli.BoolFlag{
-			Name:  "render",
-			Usage: "If set, then an image of graph will be generated and displayed. The generated image is stored within the current directory with a file name of 'graph.svg'",
+		cli.StringFlag{
+			Name: "preimage",
+			Usage: "the hex-encoded preimage (32 byte) which will " +
+				"allow settling an incoming HTLC payable to this " +
+				"preimage.",
 		},
 	},
-	Action: actionDecorator(describeGraph),
+	Action: actionDecorator(settleInvoice),
 }
 
-func describeGraph(ctx *cli.Context) error {
+func settleInvoice(ctx *cli.Context) error {
+	var (
+		preimage []byte
+		err      error
+	)
+
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &lnrpc.ChannelGraphRequest{}
+	args := ctx.Args()
 
-	graph, err := client.DescribeGraph(context.Background(), req)
-	if err != nil {
-		return err
-	}
+	switch {
+	case ctx.IsSet("preimage"):
+		preimage, err = hex.DecodeString(ctx.String("preimage"))
+	case args.Present():
+		preimage, err = hex.DecodeString(args.First())
+	default:
+		return fmt.Errorf("preimage argument missing")
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to parse preimage: %v", err)
+	}
+
+	invoice := &lnrpc.SettleInvoiceMsg{
+		Preimage: preimage,
+	}
+
+	resp, err := client.SettleInvoice(context.Background(), invoice)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var cancelInvoiceCommand = cli.Command{
+	Name:  "cancelinvoice",
+	Usage: "Cancels a (hold) invoice",
+	Description: `
+	Cancel an invoice, failing back any payments that are being held for
+	it. A canceled invoice can no longer be paid.`,
+	ArgsUsage: "paymenthash",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "paymenthash",
+			Usage: "the hex-encoded payment hash (32 byte) for which the " +
+				"corresponding invoice will be canceled.",
+		},
+	},
+	Action: actionDecorator(cancelInvoice),
+}
+
+func cancelInvoice(ctx *cli.Context) error {
+	var (
+		paymentHash []byte
+		err         error
+	)
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("paymenthash"):
+		paymentHash, err = hex.DecodeString(ctx.String("paymenthash"))
+	case args.Present():
+		paymentHash, err = hex.DecodeString(args.First())
+	default:
+		return fmt.Errorf("paymenthash argument missing")
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to parse paymenthash: %v", err)
+	}
+
+	invoice := &lnrpc.CancelInvoiceMsg{
+		PaymentHash: paymentHash,
+	}
+
+	resp, err := client.CancelInvoice(context.Background(), invoice)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var deleteCanceledInvoicesCommand = cli.Command{
+	Name:  "deletecanceledinvoices",
+	Usage: "Delete canceled and expired invoices",
+	Description: `
+	Remove all canceled and expired invoices from the invoice database. If
+	--created_before is set, only invoices created before the given unix
+	timestamp are removed.`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "created_before",
+			Usage: "(optional) only delete invoices created before " +
+				"this unix timestamp",
+		},
+	},
+	Action: actionDecorator(deleteCanceledInvoices),
+}
+
+func deleteCanceledInvoices(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.DeleteCanceledInvoicesRequest{
+		CreatedBefore: ctx.Int64("created_before"),
+	}
+
+	resp, err := client.DeleteCanceledInvoices(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listInvoicesCommand = cli.Command{
+	Name:  "listinvoices",
+	Usage: "List all invoices currently stored.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "pending_only",
+			Usage: "toggles if all invoices should be returned, or only " +
+				"those that are currently unsettled",
+		},
+	},
+	Action: actionDecorator(listInvoices),
+}
+
+func listInvoices(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	pendingOnly := true
+	if !ctx.Bool("pending_only") {
+		pendingOnly = false
+	}
+
+	req := &lnrpc.ListInvoiceRequest{
+		PendingOnly: pendingOnly,
+	}
+
+	invoices, err := client.ListInvoices(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(invoices)
+
+	return nil
+}
+
+var describeGraphCommand = cli.Command{
+	Name: "describegraph",
+	Description: "Prints a human readable version of the known channel " +
+		"graph from the PoV of the node",
+	Usage: "Describe the network graph",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "render",
+			Usage: "If set, then an image of graph will be generated and displayed. The generated image is stored within the current directory with a file name of 'graph.svg'",
+		},
+	},
+	Action: actionDecorator(describeGraph),
+}
+
+func describeGraph(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ChannelGraphRequest{}
+
+	graph, err := client.DescribeGraph(context.Background(), req)
+	if err != nil {
+		return err
+	}
 
 	// If the draw flag is on, then we'll use the 'dot' command to create a
 	// visualization of the graph itself.
@@ -2793,3 +3368,966 @@ func forwardingHistory(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var exportChanBackupCommand = cli.Command{
+	Name:      "exportchanbackup",
+	Usage:     "Obtain a static channel back up for a selected channel, or all known channels",
+	ArgsUsage: "[chan_point] [--all] [--output_file]",
+	Description: `
+	This command allows a user to export a Static Channel Backup (SCB) for
+	a selected channel. SCB's are encrypted backups of a channel's initial
+	state that are encrypted with a key derived from the seed of a user. In
+	the case of partial or complete data loss, the SCB will allow the user
+	to reclaim settled funds in the channel at its final state. The
+	exported channel backups can be restored at a later time using the
+	restorechanbackup command.
+
+	This command will return one of two types of channel backups depending
+	on the set of passed arguments:
+
+	   * If a target channel point is specified, then a single channel
+	     backup containing only the information for that channel will be
+	     returned.
+
+	   * If the --all flag is passed, then a multi-channel backup will be
+	     returned. A multi backup is a single encrypted blob (displayed in
+	     hex encoding) that contains several channels in a single cipher
+	     text.
+
+	Both of the backup types can be restored using the restorechanbackup
+	command.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "chan_point",
+			Usage: "the target channel to obtain an SCB for",
+		},
+		cli.BoolFlag{
+			Name: "all",
+			Usage: "if specified, then a multi backup of all " +
+				"active channels will be returned",
+		},
+		cli.StringFlag{
+			Name: "output_file",
+			Usage: "if specified, then rather than printing a " +
+				"JSON output of the backup, the raw packed " +
+				"backup will be written to the target file",
+		},
+	},
+	Action: actionDecorator(exportChanBackup),
+}
+
+// parseChanPoint parses a channel point of the form txid:index into its proto
+// representation.
+func parseChanPoint(s string) (*lnrpc.ChannelPoint, error) {
+	split := strings.Split(s, ":")
+	if len(split) != 2 {
+		return nil, fmt.Errorf("expecting chan_point to be in format of: " +
+			"txid:index")
+	}
+
+	index, err := strconv.ParseInt(split[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode output index: %v", err)
+	}
+
+	return &lnrpc.ChannelPoint{
+		FundingTxid: &lnrpc.ChannelPoint_FundingTxidStr{
+			FundingTxidStr: split[0],
+		},
+		OutputIndex: uint32(index),
+	}, nil
+}
+
+// chanPointString returns the txid:index string representation of a proto
+// channel point.
+func chanPointString(chanPoint *lnrpc.ChannelPoint) (string, error) {
+	var txid string
+	switch chanPoint.GetFundingTxid().(type) {
+	case *lnrpc.ChannelPoint_FundingTxidBytes:
+		hash, err := chainhash.NewHash(chanPoint.GetFundingTxidBytes())
+		if err != nil {
+			return "", err
+		}
+		txid = hash.String()
+
+	case *lnrpc.ChannelPoint_FundingTxidStr:
+		txid = chanPoint.GetFundingTxidStr()
+	}
+
+	return fmt.Sprintf("%v:%v", txid, chanPoint.OutputIndex), nil
+}
+
+func exportChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "exportchanbackup")
+		return nil
+	}
+
+	var chanPointStr string
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPointStr = ctx.String("chan_point")
+
+	case args.Present():
+		chanPointStr = args.First()
+
+	case !ctx.IsSet("all"):
+		return fmt.Errorf("must specify chan_point if --all isn't set")
+	}
+
+	if chanPointStr != "" {
+		chanPointRPC, err := parseChanPoint(chanPointStr)
+		if err != nil {
+			return err
+		}
+
+		chanBackup, err := client.ExportChannelBackup(
+			ctxb, &lnrpc.ExportChannelBackupRequest{
+				ChanPoint: chanPointRPC,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		if ctx.IsSet("output_file") {
+			return ioutil.WriteFile(
+				ctx.String("output_file"),
+				chanBackup.ChanBackup,
+				0666,
+			)
+		}
+
+		printJSON(struct {
+			ChanPoint  string `json:"chan_point"`
+			ChanBackup string `json:"chan_backup"`
+		}{
+			ChanPoint:  chanPointStr,
+			ChanBackup: hex.EncodeToString(chanBackup.ChanBackup),
+		})
+		return nil
+	}
+
+	if !ctx.IsSet("all") {
+		return fmt.Errorf("if a channel isn't specified, --all must be")
+	}
+
+	chanBackup, err := client.ExportAllChannelBackups(
+		ctxb, &lnrpc.ChanBackupExportRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsSet("output_file") {
+		return ioutil.WriteFile(
+			ctx.String("output_file"),
+			chanBackup.MultiChanBackup.MultiChanBackup,
+			0666,
+		)
+	}
+
+	var chanPoints []string
+	for _, chanPoint := range chanBackup.MultiChanBackup.ChanPoints {
+		chanPointStr, err := chanPointString(chanPoint)
+		if err != nil {
+			return err
+		}
+
+		chanPoints = append(chanPoints, chanPointStr)
+	}
+
+	printJSON(struct {
+		ChanPoints      []string `json:"chan_points"`
+		MultiChanBackup string   `json:"multi_chan_backup"`
+	}{
+		ChanPoints: chanPoints,
+		MultiChanBackup: hex.EncodeToString(
+			chanBackup.MultiChanBackup.MultiChanBackup,
+		),
+	})
+
+	return nil
+}
+
+var verifyChanBackupCommand = cli.Command{
+	Name:      "verifychanbackup",
+	Usage:     "Verify an existing channel backup",
+	ArgsUsage: "[--single_backup] [--multi_backup] [--multi_file]",
+	Description: `
+	This command allows a user to verify an existing Single or Multi channel
+	backup for integrity. This is useful when a user has a backup, but is
+	unsure as to if it's valid or for the target node.
+
+	The command will accept backups in one of three forms:
+
+	   * A single channel packed SCB, which can be obtained from
+	     exportchanbackup. This should be passed in hex encoded format.
+
+	   * A packed multi-channel SCB, which couples several individual
+	     static channel backups in single blob.
+
+	   * A file path which points to a packed multi-channel backup within a
+	     file, using the same format that lnd does in its channel.backup
+	     file.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "single_backup",
+			Usage: "a hex encoded single channel backup obtained " +
+				"from exportchanbackup",
+		},
+		cli.StringFlag{
+			Name: "multi_backup",
+			Usage: "a hex encoded multi-channel backup obtained " +
+				"from exportchanbackup",
+		},
+		cli.StringFlag{
+			Name:  "multi_file",
+			Usage: "the path to a multi-channel back up file",
+		},
+	},
+	Action: actionDecorator(verifyChanBackup),
+}
+
+// parseChanBackups parses the channel backup specified by the set of passed
+// command line flags. Exactly one of --single_backup, --multi_backup, or
+// --multi_file must be specified.
+func parseChanBackups(ctx *cli.Context) (*lnrpc.RestoreChanBackupRequest, error) {
+	switch {
+	case ctx.IsSet("single_backup"):
+		packedBackup, err := hex.DecodeString(
+			ctx.String("single_backup"),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode single packed "+
+				"backup: %v", err)
+		}
+
+		return &lnrpc.RestoreChanBackupRequest{
+			Backup: &lnrpc.RestoreChanBackupRequest_ChanBackups{
+				ChanBackups: &lnrpc.ChannelBackups{
+					ChanBackups: []*lnrpc.ChannelBackup{
+						{
+							ChanBackup: packedBackup,
+						},
+					},
+				},
+			},
+		}, nil
+
+	case ctx.IsSet("multi_backup"):
+		packedMulti, err := hex.DecodeString(
+			ctx.String("multi_backup"),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode multi packed "+
+				"backup: %v", err)
+		}
+
+		return &lnrpc.RestoreChanBackupRequest{
+			Backup: &lnrpc.RestoreChanBackupRequest_MultiChanBackup{
+				MultiChanBackup: packedMulti,
+			},
+		}, nil
+
+	case ctx.IsSet("multi_file"):
+		packedMulti, err := ioutil.ReadFile(ctx.String("multi_file"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode multi packed "+
+				"backup: %v", err)
+		}
+
+		return &lnrpc.RestoreChanBackupRequest{
+			Backup: &lnrpc.RestoreChanBackupRequest_MultiChanBackup{
+				MultiChanBackup: packedMulti,
+			},
+		}, nil
+
+	default:
+		return nil, errors.New("one of --single_backup, " +
+			"--multi_backup, or --multi_file must be set")
+	}
+}
+
+func verifyChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "verifychanbackup")
+		return nil
+	}
+
+	backups, err := parseChanBackups(ctx)
+	if err != nil {
+		return err
+	}
+
+	var verifyReq lnrpc.ChanBackupSnapshot
+	switch backup := backups.Backup.(type) {
+	case *lnrpc.RestoreChanBackupRequest_ChanBackups:
+		verifyReq.SingleChanBackups = backup.ChanBackups
+
+	case *lnrpc.RestoreChanBackupRequest_MultiChanBackup:
+		verifyReq.MultiChanBackup = &lnrpc.MultiChanBackup{
+			MultiChanBackup: backup.MultiChanBackup,
+		}
+	}
+
+	resp, err := client.VerifyChanBackup(ctxb, &verifyReq)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var restoreChanBackupCommand = cli.Command{
+	Name: "restorechanbackup",
+	Usage: "Restore an existing single or multi-channel static channel " +
+		"backup",
+	ArgsUsage: "[--single_backup] [--multi_backup] [--multi_file]",
+	Description: `
+	Allows a user to restore a Static Channel Backup (SCB) that was
+	obtained either via the exportchanbackup command, or from lnd's
+	automatically managed channel.backup file. This command should be used
+	if a user is attempting to restore a channel due to data loss on a
+	running node restored with the same seed as the node that created the
+	channel. If successful, this command will allow the user to recover
+	the settled funds stored in the recovered channels.
+
+	The command will accept backups in one of three forms:
+
+	   * A single channel packed SCB, which can be obtained from
+	     exportchanbackup. This should be passed in hex encoded format.
+
+	   * A packed multi-channel SCB, which couples several individual
+	     static channel backups in single blob.
+
+	   * A file path which points to a packed multi-channel backup within a
+	     file, using the same format that lnd does in its channel.backup
+	     file.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "single_backup",
+			Usage: "a hex encoded single channel backup obtained " +
+				"from exportchanbackup",
+		},
+		cli.StringFlag{
+			Name: "multi_backup",
+			Usage: "a hex encoded multi-channel backup obtained " +
+				"from exportchanbackup",
+		},
+		cli.StringFlag{
+			Name:  "multi_file",
+			Usage: "the path to a multi-channel back up file",
+		},
+	},
+	Action: actionDecorator(restoreChanBackup),
+}
+
+func restoreChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "restorechanbackup")
+		return nil
+	}
+
+	req, err := parseChanBackups(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.RestoreChannelBackups(ctxb, req)
+	if err != nil {
+		return fmt.Errorf("unable to restore chan backups: %v", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var dumpChannelCommand = cli.Command{
+	Name:      "dumpchannel",
+	Usage:     "Dump the full state of a channel into a portable file",
+	ArgsUsage: "chan_point --output_file=F",
+	Description: `
+	Dumps the complete on-disk state of a channel, including both
+	commitments, the revocation log, the shachain revocation state and the
+	forwarding packages of the channel, into a file which can be replayed
+	using the replaychannel command. This is intended to allow reproducing
+	channel failures, such as a commitment signature mismatch, outside of
+	the node they occurred on.
+
+	As this command reads the channel database directly, it doesn't
+	require a connection to lnd, however lnd MUST be stopped while it
+	runs, as the database can't be opened while lnd holds its lock.
+
+	NOTE: The dump contains the revocation secrets of the channel, so
+	anybody in possession of it is able to revoke the commitments of the
+	channel. Only share dumps of closed channels, and only with trusted
+	parties.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "chan_point",
+			Usage: "the channel to dump, in the form txid:index",
+		},
+		cli.StringFlag{
+			Name:  "output_file",
+			Usage: "the file to write the channel dump to",
+		},
+		cli.StringFlag{
+			Name:  "network",
+			Value: "mainnet",
+			Usage: "the network the channel resides on",
+		},
+		cli.StringFlag{
+			Name: "chandb_dir",
+			Usage: "the directory containing the channel database, " +
+				"defaults to the graph directory of the " +
+				"network within lnddir",
+		},
+	},
+	Action: actionDecorator(dumpChannel),
+}
+
+// parseOutPoint parses an outpoint of the form txid:index.
+func parseOutPoint(s string) (*wire.OutPoint, error) {
+	split := strings.Split(s, ":")
+	if len(split) != 2 {
+		return nil, fmt.Errorf("expecting chan_point to be in format of: " +
+			"txid:index")
+	}
+
+	txid, err := chainhash.NewHashFromStr(split[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode txid: %v", err)
+	}
+
+	index, err := strconv.ParseUint(split[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode output index: %v", err)
+	}
+
+	return wire.NewOutPoint(txid, uint32(index)), nil
+}
+
+func dumpChannel(ctx *cli.Context) error {
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "dumpchannel")
+		return nil
+	}
+
+	var chanPointStr string
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPointStr = ctx.String("chan_point")
+	case ctx.Args().Present():
+		chanPointStr = ctx.Args().First()
+	default:
+		return fmt.Errorf("chan_point argument missing")
+	}
+	chanPoint, err := parseOutPoint(chanPointStr)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.IsSet("output_file") {
+		return fmt.Errorf("output_file argument missing")
+	}
+
+	chanDBDir := cleanAndExpandPath(ctx.String("chandb_dir"))
+	if !ctx.IsSet("chandb_dir") {
+		lndDir := cleanAndExpandPath(ctx.GlobalString("lnddir"))
+		chanDBDir = filepath.Join(
+			lndDir, "data", "graph", ctx.String("network"),
+		)
+	}
+
+	chanDB, err := channeldb.Open(chanDBDir)
+	if err != nil {
+		return fmt.Errorf("unable to open channel db: %v", err)
+	}
+	defer chanDB.Close()
+
+	dump, err := chanDB.DumpChannel(chanPoint)
+	if err != nil {
+		return fmt.Errorf("unable to dump channel: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := dump.Serialize(&b); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(ctx.String("output_file"), b.Bytes(), 0600)
+}
+
+var replayChannelCommand = cli.Command{
+	Name:      "replaychannel",
+	Usage:     "Replay the history of a dumped channel to locate divergences",
+	ArgsUsage: "dump_file",
+	Description: `
+	Restores a channel dump created with the dumpchannel command into a
+	temporary channel database, reconstructs the channel state machine
+	from it, and replays the history of the channel in order to pinpoint
+	where the persisted state of the channel diverges from the state
+	derived by the channel state machine.
+
+	Each commitment of the remote party within the revocation log is
+	re-created and verified, along with the log updates locked in at each
+	height, followed by our current commitment and the signatures of the
+	remote party for it. The first divergence found is reported.
+
+	This command doesn't require a connection to lnd, nor access to the
+	wallet of the node the dump was created on.
+	`,
+	Action: actionDecorator(replayChannel),
+}
+
+type replayDivergence struct {
+	CommitHeight uint64 `json:"commit_height"`
+	Chain        string `json:"chain"`
+	Reason       string `json:"reason"`
+}
+
+type replayChannelResponse struct {
+	ChanPoint          string            `json:"chan_point"`
+	RemotePubkey       string            `json:"remote_pubkey"`
+	LocalCommitHeight  uint64            `json:"local_commit_height"`
+	RemoteCommitHeight uint64            `json:"remote_commit_height"`
+	NumRemoteCommits   int               `json:"num_remote_commits_replayed"`
+	NumFwdPkgs         int               `json:"num_fwd_pkgs_replayed"`
+	Divergence         *replayDivergence `json:"divergence"`
+}
+
+func replayChannel(ctx *cli.Context) error {
+	// Show command help if no arguments provided
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelp(ctx, "replaychannel")
+		return nil
+	}
+
+	dumpFile, err := os.Open(cleanAndExpandPath(ctx.Args().First()))
+	if err != nil {
+		return fmt.Errorf("unable to open channel dump: %v", err)
+	}
+	defer dumpFile.Close()
+
+	var dump channeldb.ChannelDump
+	if err := dump.Deserialize(dumpFile); err != nil {
+		return fmt.Errorf("unable to decode channel dump: %v", err)
+	}
+
+	// We'll restore the dump into a temporary channel database, as the
+	// channel state machine requires a database backed channel.
+	tempDir, err := ioutil.TempDir("", "replaychannel")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	chanDB, err := channeldb.Open(tempDir)
+	if err != nil {
+		return fmt.Errorf("unable to open channel db: %v", err)
+	}
+	defer chanDB.Close()
+
+	channel, err := chanDB.RestoreChannelDump(&dump)
+	if err != nil {
+		return fmt.Errorf("unable to restore channel dump: %v", err)
+	}
+
+	report, err := lnwallet.ReplayChannel(channel)
+	if err != nil {
+		return fmt.Errorf("unable to replay channel: %v", err)
+	}
+
+	resp := &replayChannelResponse{
+		ChanPoint: dump.ChanPoint.String(),
+		RemotePubkey: hex.EncodeToString(
+			dump.IdentityPub.SerializeCompressed(),
+		),
+		LocalCommitHeight:  report.LocalCommitHeight,
+		RemoteCommitHeight: report.RemoteCommitHeight,
+		NumRemoteCommits:   report.NumRemoteCommits,
+		NumFwdPkgs:         report.NumFwdPkgs,
+	}
+	if report.Divergence != nil {
+		chain := "remote"
+		if report.Divergence.LocalCommit {
+			chain = "local"
+		}
+
+		resp.Divergence = &replayDivergence{
+			CommitHeight: report.Divergence.CommitHeight,
+			Chain:        chain,
+			Reason:       report.Divergence.Reason.Error(),
+		}
+	}
+
+	printJSON(resp)
+	return nil
+}
+
+var queryMissionControlCommand = cli.Command{
+	Name:  "querymc",
+	Usage: "Query the internal mission control state.",
+	Description: `
+	Returns the outcomes of past payment attempts recorded by mission
+	control, for each pair of nodes that payments were attempted to be
+	forwarded between. The output can be saved to a file and imported into
+	another node using importmc.`,
+	Action: actionDecorator(queryMissionControl),
+}
+
+func queryMissionControl(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.QueryMissionControlRequest{}
+	resp, err := client.QueryMissionControl(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var importMissionControlCommand = cli.Command{
+	Name:      "importmc",
+	Usage:     "Import mission control state exported by querymc.",
+	ArgsUsage: "mc_file",
+	Description: `
+	Merges the node pair results in the passed file, as written by the
+	output of querymc, into the history of mission control. For each pair,
+	results are only applied if they are more recent than those already
+	known.`,
+	Action: actionDecorator(importMissionControl),
+}
+
+func importMissionControl(ctx *cli.Context) error {
+	// Show command help if no arguments provided
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelp(ctx, "importmc")
+		return nil
+	}
+
+	mcFile, err := os.Open(cleanAndExpandPath(ctx.Args().First()))
+	if err != nil {
+		return fmt.Errorf("unable to open mission control file: %v",
+			err)
+	}
+	defer mcFile.Close()
+
+	var history lnrpc.QueryMissionControlResponse
+	if err := jsonpb.Unmarshal(mcFile, &history); err != nil {
+		return fmt.Errorf("unable to decode mission control file: %v",
+			err)
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ImportMissionControlRequest{
+		Pairs: history.Pairs,
+	}
+	resp, err := client.ImportMissionControl(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var resetMissionControlCommand = cli.Command{
+	Name:  "resetmc",
+	Usage: "Reset the internal mission control state.",
+	Description: `
+	Clears all outcomes of past payment attempts recorded by mission
+	control, such that all channels are considered with the a priori
+	success probability again.`,
+	Action: actionDecorator(resetMissionControl),
+}
+
+func resetMissionControl(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ResetMissionControlRequest{}
+	resp, err := client.ResetMissionControl(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var buildRouteCommand = cli.Command{
+	Name:  "buildroute",
+	Usage: "Build a route along a list of hops.",
+	Description: `
+	Builds a route that delivers the given amount along the passed hops,
+	starting at our own node. The hops may be specified by the public keys
+	of their nodes, by their channel ids, or by both, in which case a zero
+	channel id leaves it to the node to pick the cheapest channel to the
+	node of the hop. The fees and time locks of the route are computed from
+	the channel policies known to the node.
+
+	The output can be passed to sendtoroute to pay along the route.`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the amount to deliver expressed in satoshis",
+		},
+		cli.Int64Flag{
+			Name: "final_cltv_delta",
+			Usage: "the cltv delta of the final hop (default: " +
+				"the node's default)",
+		},
+		cli.StringFlag{
+			Name: "hops",
+			Usage: "a comma separated list of the hex-encoded " +
+				"public keys of the nodes along the route, " +
+				"excluding our own node",
+		},
+		cli.StringFlag{
+			Name:  "chan_ids",
+			Usage: "a comma separated list of the channels along the route",
+		},
+	},
+	Action: actionDecorator(buildRoute),
+}
+
+func buildRoute(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if !ctx.IsSet("amt") {
+		return fmt.Errorf("amt argument missing")
+	}
+	if !ctx.IsSet("hops") && !ctx.IsSet("chan_ids") {
+		return fmt.Errorf("either hops or chan_ids must be specified")
+	}
+
+	req := &lnrpc.BuildRouteRequest{
+		AmtMsat: int64(lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(ctx.Int64("amt")),
+		)),
+		FinalCltvDelta: int32(ctx.Int64("final_cltv_delta")),
+	}
+	if ctx.IsSet("hops") {
+		req.HopPubkeys = strings.Split(ctx.String("hops"), ",")
+	}
+	if ctx.IsSet("chan_ids") {
+		chanIDStrs := strings.Split(ctx.String("chan_ids"), ",")
+		for _, chanIDStr := range chanIDStrs {
+			chanID, err := strconv.ParseUint(chanIDStr, 10, 64)
+			if err != nil {
+				return fmt.Errorf("unable to parse chan_id %v: %v",
+					chanIDStr, err)
+			}
+
+			req.ChanIds = append(req.ChanIds, chanID)
+		}
+	}
+
+	resp, err := client.BuildRoute(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var sendToRouteCommand = cli.Command{
+	Name:      "sendtoroute",
+	Usage:     "Send a payment along a set of routes.",
+	ArgsUsage: "[payment_hash] [routes]",
+	Description: `
+	Sends a payment along the passed routes, rather than along routes found
+	by the node itself. The routes are attempted in order, until either the
+	payment succeeds or all routes have failed.
+
+	The routes are passed as JSON, in the format of the output of either
+	queryroutes or buildroute. If the routes are given as '-', they are
+	read from stdin, such that the output of those commands can be piped
+	into sendtoroute:
+
+	lncli queryroutes --dest=<dest> --amt=<amt> | lncli sendtoroute --payment_hash=<hash> -`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash",
+			Usage: "the hex-encoded hash to use within the payment's HTLC",
+		},
+		cli.StringFlag{
+			Name: "routes",
+			Usage: "the JSON encoded routes to send along, or '-' " +
+				"to read them from stdin",
+		},
+	},
+	Action: actionDecorator(sendToRoute),
+}
+
+func sendToRoute(ctx *cli.Context) error {
+	args := ctx.Args()
+
+	var paymentHash string
+	switch {
+	case ctx.IsSet("payment_hash"):
+		paymentHash = ctx.String("payment_hash")
+	case args.Present():
+		paymentHash = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("payment hash argument missing")
+	}
+
+	var routesJSON string
+	switch {
+	case ctx.IsSet("routes"):
+		routesJSON = ctx.String("routes")
+	case args.Present():
+		routesJSON = args.First()
+	default:
+		return fmt.Errorf("routes argument missing")
+	}
+
+	if routesJSON == "-" {
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("unable to read routes from stdin: %v",
+				err)
+		}
+		routesJSON = string(b)
+	}
+
+	// The routes may either be the output of queryroutes, or the single
+	// route returned by buildroute.
+	var (
+		routes    []*lnrpc.Route
+		queryResp lnrpc.QueryRoutesResponse
+		buildResp lnrpc.BuildRouteResponse
+	)
+	err := jsonpb.UnmarshalString(routesJSON, &queryResp)
+	if err == nil {
+		routes = queryResp.Routes
+	} else {
+		err := jsonpb.UnmarshalString(routesJSON, &buildResp)
+		if err != nil {
+			return fmt.Errorf("unable to decode routes: %v", err)
+		}
+		if buildResp.Route != nil {
+			routes = []*lnrpc.Route{buildResp.Route}
+		}
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("no routes provided")
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.SendToRouteRequest{
+		PaymentHashString: paymentHash,
+		Routes:            routes,
+	}
+	resp, err := client.SendToRoute(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var rebalanceCommand = cli.Command{
+	Name:  "rebalance",
+	Usage: "Move funds between channels by paying ourselves.",
+	Description: `
+	Moves funds from one of our channels to another by paying ourselves.
+	The payment leaves our node through the outgoing channel, and returns
+	through the last hop channel, increasing the local balance of the
+	latter. The route in between is found by the node, and must not exceed
+	the fee limit.`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  "outgoing_chan_id",
+			Usage: "the channel through which the payment leaves our node",
+		},
+		cli.Uint64Flag{
+			Name: "last_hop_chan_id",
+			Usage: "the channel through which the payment returns " +
+				"to our node",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the amount to move expressed in satoshis",
+		},
+		cli.Int64Flag{
+			Name: "fee_limit",
+			Usage: "the maximum total fee to pay for the rebalance " +
+				"expressed in satoshis",
+		},
+	},
+	Action: actionDecorator(rebalance),
+}
+
+func rebalance(ctx *cli.Context) error {
+	switch {
+	case !ctx.IsSet("outgoing_chan_id"):
+		return fmt.Errorf("outgoing_chan_id argument missing")
+	case !ctx.IsSet("last_hop_chan_id"):
+		return fmt.Errorf("last_hop_chan_id argument missing")
+	case !ctx.IsSet("amt"):
+		return fmt.Errorf("amt argument missing")
+	case !ctx.IsSet("fee_limit"):
+		return fmt.Errorf("fee_limit argument missing")
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.RebalanceRequest{
+		OutgoingChanId: ctx.Uint64("outgoing_chan_id"),
+		LastHopChanId:  ctx.Uint64("last_hop_chan_id"),
+		Amt:            ctx.Int64("amt"),
+		FeeLimit:       ctx.Int64("fee_limit"),
+	}
+	resp, err := client.Rebalance(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
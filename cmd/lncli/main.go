@@ -200,6 +200,8 @@ func main() {
 		connectCommand,
 		disconnectCommand,
 		openChannelCommand,
+		batchOpenChannelCommand,
+		finalizePsbtCommand,
 		closeChannelCommand,
 		closeAllChannelsCommand,
 		listPeersCommand,
@@ -211,6 +213,10 @@ func main() {
 		payInvoiceCommand,
 		addInvoiceCommand,
 		lookupInvoiceCommand,
+		addHoldInvoiceCommand,
+		settleInvoiceCommand,
+		cancelInvoiceCommand,
+		deleteCanceledInvoicesCommand,
 		listInvoicesCommand,
 		listChannelsCommand,
 		listPaymentsCommand,
@@ -228,6 +234,17 @@ func main() {
 		feeReportCommand,
 		updateChannelPolicyCommand,
 		forwardingHistoryCommand,
+		exportChanBackupCommand,
+		verifyChanBackupCommand,
+		restoreChanBackupCommand,
+		dumpChannelCommand,
+		replayChannelCommand,
+		queryMissionControlCommand,
+		importMissionControlCommand,
+		resetMissionControlCommand,
+		buildRouteCommand,
+		sendToRouteCommand,
+		rebalanceCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
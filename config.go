@@ -5,6 +5,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -20,8 +21,12 @@ import (
 
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/chanbackup"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/torsvc"
+	"github.com/lightningnetwork/lnd/watchtower"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcutil"
 )
@@ -31,6 +36,7 @@ const (
 	defaultDataDirname        = "data"
 	defaultChainSubDirname    = "chain"
 	defaultGraphSubDirname    = "graph"
+	defaultTowerSubDirname    = "watchtower"
 	defaultTLSCertFilename    = "tls.cert"
 	defaultTLSKeyFilename     = "tls.key"
 	defaultAdminMacFilename   = "admin.macaroon"
@@ -44,11 +50,21 @@ const (
 	defaultPeerPort           = 9735
 	defaultRPCHost            = "localhost"
 	defaultMaxPendingChannels = 1
+	defaultAcceptorTimeout    = 15 * time.Second
+	defaultInterceptorTimeout = 30 * time.Second
+	defaultMaxFeeRate         = 1000
 	defaultNoEncryptWallet    = false
 	defaultTrickleDelay       = 30 * 1000
+	defaultNumGraphSyncPeers  = 3
+
+	defaultTorControlPort          = 9051
+	defaultTorV2PrivateKeyFilename = "v2_onion_private_key"
+	defaultTorV3PrivateKeyFilename = "v3_onion_private_key"
 
 	defaultBroadcastDelta = 10
 
+	defaultPrometheusListen = "localhost:8989"
+
 	// minTimeLockDelta is the minimum timelock we require for incoming
 	// HTLCs on our channels.
 	minTimeLockDelta = 4
@@ -78,6 +94,10 @@ var (
 
 	defaultBitcoindDir  = btcutil.AppDataDir("bitcoin", false)
 	defaultLitecoindDir = btcutil.AppDataDir("litecoin", false)
+
+	defaultTorControl = net.JoinHostPort(
+		"localhost", strconv.Itoa(defaultTorControlPort),
+	)
 )
 
 type chainConfig struct {
@@ -136,6 +156,26 @@ type torConfig struct {
 	Socks           string `long:"socks" description:"The port that Tor's exposed SOCKS5 proxy is listening on. Using Tor allows outbound-only connections (listening will be disabled) -- NOTE port must be between 1024 and 65535"`
 	DNS             string `long:"dns" description:"The DNS server as IP:PORT that Tor will use for SRV queries - NOTE must have TCP resolution enabled"`
 	StreamIsolation bool   `long:"streamisolation" description:"Enable Tor stream isolation by randomizing user credentials for each connection."`
+	Control         string `long:"control" description:"The host:port that Tor is listening on for Tor control connections"`
+	Password        string `long:"password" description:"The password used to authenticate with the Tor control port. If not set, cookie authentication will be used"`
+	V2              bool   `long:"v2" description:"Automatically set up a v2 onion service to listen for inbound connections"`
+	V3              bool   `long:"v3" description:"Automatically set up a v3 onion service to listen for inbound connections"`
+	PrivateKeyPath  string `long:"privatekeypath" description:"The path to the private key of the onion service being created. Defaults to a file within lnddir named after the onion service version"`
+}
+
+type prometheusConfig struct {
+	Active bool   `long:"active" description:"If the Prometheus metrics exporter should be active or not"`
+	Listen string `long:"listen" description:"The host:port on which metrics will be served for scraping at /metrics"`
+}
+
+type routingConfig struct {
+	AprioriHopProb  float64       `long:"apriorihopprob" description:"The assumed probability of success of a hop that no payments have been attempted through yet"`
+	PenaltyHalfLife time.Duration `long:"penaltyhalflife" description:"The time after which half of the penalty applied to a hop after a failed payment attempt has decayed"`
+	AttemptCost     int64         `long:"attemptcost" description:"The virtual cost in satoshis of a payment attempt, which is weighed against the fees of a route during path finding"`
+}
+
+type wtClientConfig struct {
+	PrivateTowerURIs []string `long:"private-tower-uris" description:"Specifies the URIs of private watchtowers to use in backing up revoked states. URIs must be of the form <pubkey>@<addr>. Only 1 URI is supported at this time, if none are provided the tower client will not be enabled."`
 }
 
 // config defines the configuration options for lnd.
@@ -156,6 +196,7 @@ type config struct {
 	AdminMacPath   string `long:"adminmacaroonpath" description:"Path to write the admin macaroon for lnd's RPC and REST services if it doesn't exist"`
 	ReadMacPath    string `long:"readonlymacaroonpath" description:"Path to write the read-only macaroon for lnd's RPC and REST services if it doesn't exist"`
 	InvoiceMacPath string `long:"invoicemacaroonpath" description:"Path to the invoice-only macaroon for lnd's RPC and REST services if it doesn't exist"`
+	BackupFilePath string `long:"backupfilepath" description:"The target location of the channel backup file"`
 	LogDir         string `long:"logdir" description:"Directory to log output."`
 
 	RPCListeners  []string `long:"rpclisten" description:"Add an interface/port to listen for RPC connections"`
@@ -176,6 +217,20 @@ type config struct {
 	UnsafeReplay       bool `long:"unsafe-replay" description:"Causes a link to replay the adds on its commitment txn after starting up, this enables testing of the sphinx replay logic."`
 	MaxPendingChannels int  `long:"maxpendingchannels" description:"The maximum number of incoming pending channels permitted per peer."`
 
+	MaxChanSize int64 `long:"maxchansize" description:"The largest channel size (in satoshis) that we'll open or accept. Channels above 16777216 satoshis require wumbo-channels to be set, and are only made with peers that support them. Defaults to 16777216, or 10 BTC if wumbo-channels is set."`
+	WumboChans  bool  `long:"wumbo-channels" description:"If set, then lnd will signal support for channels above 16777216 satoshis, and will open and accept them with peers that support them as well."`
+
+	AnchorCommitments bool `long:"anchors" description:"If set, then lnd will signal support for the anchor commitment format, and use it for new channels with peers that support it as well. Anchor outputs allow the fee of a force closed commitment to be bumped through CPFP."`
+
+	UpfrontShutdown bool `long:"upfront-shutdown" description:"If set, then lnd will commit to a fresh wallet address as its upfront shutdown script when accepting channels from peers that support upfront shutdown scripts. Cooperative closes of these channels will only ever pay our funds to the committed address."`
+
+	AcceptorTimeout time.Duration `long:"acceptortimeout" description:"Time after which an inbound channel request that hasn't been responded to by a client of the ChannelAcceptor RPC is rejected."`
+
+	InterceptorTimeout time.Duration `long:"interceptortimeout" description:"Time after which a forwarded HTLC that hasn't been resolved by the client of the HtlcInterceptor RPC is resumed."`
+
+	FeeURL     string `long:"feeurl" description:"Optional URL of a fee estimation API, returning fee rates in sat/vbyte for a set of confirmation targets in the form {\"fee_by_block_target\": {\"2\": 70, \"6\": 25}}. If set, it is consulted before the fee estimates of the chain backend."`
+	MaxFeeRate int64  `long:"maxfeerate" description:"The maximum fee rate in sat/vbyte that will be used for on-chain transactions and commitment fee updates. Any higher fee estimate is capped to this value."`
+
 	Bitcoin      *chainConfig    `group:"Bitcoin" namespace:"bitcoin"`
 	BtcdMode     *btcdConfig     `group:"btcd" namespace:"btcd"`
 	BitcoindMode *bitcoindConfig `group:"bitcoind" namespace:"bitcoind"`
@@ -189,12 +244,24 @@ type config struct {
 
 	Tor *torConfig `group:"Tor" namespace:"tor"`
 
+	Watchtower *watchtower.Conf `group:"watchtower" namespace:"watchtower"`
+
+	WtClient *wtClientConfig `group:"wtclient" namespace:"wtclient"`
+
+	Prometheus *prometheusConfig `group:"prometheus" namespace:"prometheus"`
+
+	Routing *routingConfig `group:"routing" namespace:"routing"`
+
 	NoNetBootstrap bool `long:"nobootstrap" description:"If true, then automatic network bootstrapping will not be attempted."`
 
 	NoEncryptWallet bool `long:"noencryptwallet" description:"If set, wallet will be encrypted using the default passphrase."`
 
+	AcceptKeySend bool `long:"accept-keysend" description:"If true, spontaneous payments made without an invoice, carrying their preimage within the onion, will be accepted. An invoice is created and settled for each of them."`
+
 	TrickleDelay int `long:"trickledelay" description:"Time in milliseconds between each release of announcements to the network"`
 
+	NumGraphSyncPeers int `long:"numgraphsyncpeers" description:"The number of peers that support gossip queries that we'll actively sync our channel graph with, requesting the channels we don't yet know of. Any remaining peers will only be sent the updates they request."`
+
 	Alias string `long:"alias" description:"The node alias. Used as a moniker by peers and intelligence services"`
 	Color string `long:"color" description:"The color of the node in hex format (i.e. '#3399FF'). Used to customize node appearance in intelligence services"`
 
@@ -254,6 +321,9 @@ func loadConfig() (*config, error) {
 			RPCHost: defaultRPCHost,
 		},
 		MaxPendingChannels: defaultMaxPendingChannels,
+		AcceptorTimeout:    defaultAcceptorTimeout,
+		InterceptorTimeout: defaultInterceptorTimeout,
+		MaxFeeRate:         defaultMaxFeeRate,
 		NoEncryptWallet:    defaultNoEncryptWallet,
 		Autopilot: &autoPilotConfig{
 			MaxChannels:    5,
@@ -261,9 +331,25 @@ func loadConfig() (*config, error) {
 			MinChannelSize: int64(minChanFundingSize),
 			MaxChannelSize: int64(maxFundingAmount),
 		},
-		TrickleDelay: defaultTrickleDelay,
-		Alias:        defaultAlias,
-		Color:        defaultColor,
+		Tor: &torConfig{
+			Control: defaultTorControl,
+		},
+		TrickleDelay:      defaultTrickleDelay,
+		NumGraphSyncPeers: defaultNumGraphSyncPeers,
+		Alias:             defaultAlias,
+		Color:             defaultColor,
+		Watchtower:        &watchtower.Conf{},
+		WtClient:          &wtClientConfig{},
+		Prometheus: &prometheusConfig{
+			Listen: defaultPrometheusListen,
+		},
+		Routing: &routingConfig{
+			AprioriHopProb:  routing.DefaultAprioriHopProbability,
+			PenaltyHalfLife: routing.DefaultPenaltyHalfLife,
+			AttemptCost: int64(
+				routing.DefaultAttemptCost.ToSatoshis(),
+			),
+		},
 	}
 
 	// Pre-parse the command line options to pick up an alternative config
@@ -371,13 +457,67 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
+	// Channels above the protocol's soft-limit for channel size can only
+	// be made if large channels have been enabled. If no maximum channel
+	// size was specified, we'll use the default for the enabled mode.
+	switch {
+	case cfg.MaxChanSize == 0 && cfg.WumboChans:
+		cfg.MaxChanSize = int64(maxWumboFundingAmount)
+
+	case cfg.MaxChanSize == 0:
+		cfg.MaxChanSize = int64(maxFundingAmount)
+
+	case cfg.MaxChanSize < int64(minChanFundingSize):
+		str := "%s: maxchansize must be at least %d"
+		err := fmt.Errorf(str, funcName, int64(minChanFundingSize))
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+
+	case cfg.MaxChanSize > int64(maxFundingAmount) && !cfg.WumboChans:
+		str := "%s: maxchansize may only exceed %d if " +
+			"wumbo-channels is set"
+		err := fmt.Errorf(str, funcName, int64(maxFundingAmount))
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// The maximum fee rate must allow for transactions paying at least the
+	// minimum relay fee.
+	if cfg.MaxFeeRate < int64(lnwallet.FeePerVSizeFloor) {
+		str := "%s: maxfeerate must be at least %d sat/vbyte"
+		err := fmt.Errorf(str, funcName, int64(lnwallet.FeePerVSizeFloor))
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// Ensure that the parameters of the probability model used to find
+	// routes for payments are sane.
+	if cfg.Routing.AprioriHopProb <= 0 || cfg.Routing.AprioriHopProb > 1 {
+		str := "%s: routing.apriorihopprob must be within (0, 1]"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.Routing.PenaltyHalfLife <= 0 {
+		str := "%s: routing.penaltyhalflife must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.Routing.AttemptCost < 0 {
+		str := "%s: routing.attemptcost must be non-negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
 	// Ensure that the specified values for the min and max channel size
 	// don't are within the bounds of the normal chan size constraints.
 	if cfg.Autopilot.MinChannelSize < int64(minChanFundingSize) {
 		cfg.Autopilot.MinChannelSize = int64(minChanFundingSize)
 	}
-	if cfg.Autopilot.MaxChannelSize > int64(maxFundingAmount) {
-		cfg.Autopilot.MaxChannelSize = int64(maxFundingAmount)
+	if cfg.Autopilot.MaxChannelSize > cfg.MaxChanSize {
+		cfg.Autopilot.MaxChannelSize = cfg.MaxChanSize
 	}
 
 	// Setup dial and DNS resolution functions depending on the specified
@@ -414,8 +554,11 @@ func loadConfig() (*config, error) {
 		}
 
 		// If we are using Tor, since we only want connections routed
-		// through Tor, listening is disabled.
-		cfg.DisableListen = true
+		// through Tor, listening is disabled, unless we'll be
+		// accepting inbound connections through an onion service.
+		if !cfg.Tor.V2 && !cfg.Tor.V3 {
+			cfg.DisableListen = true
+		}
 
 	} else if cfg.Tor.Socks != "" || cfg.Tor.DNS != "" {
 		// Both TorSocks and TorDNS must be set.
@@ -426,6 +569,33 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
+	// Only one type of onion service can be created for our listener at
+	// a time.
+	if cfg.Tor.V2 && cfg.Tor.V3 {
+		return nil, fmt.Errorf("%s: either tor.v2 or tor.v3 can be "+
+			"set, but not both", funcName)
+	}
+
+	// If an onion service is to be created, then we'll default to storing
+	// its private key within the lnd directory, so that it remains
+	// reachable at the same address across restarts.
+	switch {
+	case cfg.Tor.PrivateKeyPath != "":
+		cfg.Tor.PrivateKeyPath = cleanAndExpandPath(
+			cfg.Tor.PrivateKeyPath,
+		)
+
+	case cfg.Tor.V2:
+		cfg.Tor.PrivateKeyPath = filepath.Join(
+			lndDir, defaultTorV2PrivateKeyFilename,
+		)
+
+	case cfg.Tor.V3:
+		cfg.Tor.PrivateKeyPath = filepath.Join(
+			lndDir, defaultTorV3PrivateKeyFilename,
+		)
+	}
+
 	switch {
 	// At this moment, multiple active chains are not supported.
 	case cfg.Litecoin.Active && cfg.Bitcoin.Active:
@@ -653,6 +823,20 @@ func loadConfig() (*config, error) {
 		)
 	}
 
+	// If a custom channel backup file path wasn't specified, then we'll
+	// place the backup file within the network directory of the primary
+	// chain.
+	if cfg.BackupFilePath == "" {
+		cfg.BackupFilePath = filepath.Join(
+			cfg.DataDir, defaultChainSubDirname,
+			registeredChains.PrimaryChain().String(),
+			normalizeNetwork(activeNetParams.Name),
+			chanbackup.DefaultBackupFileName,
+		)
+	} else {
+		cfg.BackupFilePath = cleanAndExpandPath(cfg.BackupFilePath)
+	}
+
 	// Append the network type to the log directory so it is "namespaced"
 	// per network in the same fashion as the data directory.
 	cfg.LogDir = filepath.Join(cfg.LogDir,
@@ -721,6 +905,30 @@ func loadConfig() (*config, error) {
 	cfg.Listeners = normalizeAddresses(cfg.Listeners,
 		strconv.Itoa(defaultPeerPort))
 
+	// If the watchtower is active, listen on the default watchtower port if
+	// no listeners were specified, then normalize the addresses.
+	if cfg.Watchtower.Active {
+		if len(cfg.Watchtower.RawListeners) == 0 {
+			addr := fmt.Sprintf(":%d", watchtower.DefaultPeerPort)
+			cfg.Watchtower.RawListeners = append(
+				cfg.Watchtower.RawListeners, addr,
+			)
+		}
+
+		cfg.Watchtower.RawListeners = normalizeAddresses(
+			cfg.Watchtower.RawListeners,
+			strconv.Itoa(watchtower.DefaultPeerPort),
+		)
+	}
+
+	// The tower client currently only supports backing up to a single
+	// private tower.
+	if len(cfg.WtClient.PrivateTowerURIs) > 1 {
+		str := "%s: only 1 value for wtclient.private-tower-uris is " +
+			"supported"
+		return nil, fmt.Errorf(str, funcName)
+	}
+
 	// Warn about missing config file only after all other configuration is
 	// done.  This prevents the warning on help messages and invalid
 	// options.  Note this should go directly before the return.
@@ -847,6 +1055,46 @@ func noiseDial(idPriv *btcec.PrivateKey) func(net.Addr) (net.Conn, error) {
 	}
 }
 
+// parsePrivateTowerURI parses a watchtower URI of the form <pubkey>@<addr> into
+// a network address. If the address doesn't specify a port, the default
+// watchtower port is used.
+func parsePrivateTowerURI(uri string) (*lnwire.NetAddress, error) {
+	parts := strings.Split(uri, "@")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("private tower URI must be of the "+
+			"form <pubkey>@<addr>, got: %v", uri)
+	}
+
+	pubKeyBytes, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	addr := parts[1]
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(
+			addr, strconv.Itoa(watchtower.DefaultPeerPort),
+		)
+	}
+
+	// We use ResolveTCPAddr here in case we wish to resolve hosts over
+	// Tor.
+	tcpAddr, err := cfg.net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnwire.NetAddress{
+		IdentityKey: pubKey,
+		Address:     tcpAddr,
+		ChainNet:    activeNetParams.Net,
+	}, nil
+}
+
 func parseRPCParams(cConfig *chainConfig, nodeConfig interface{}, net chainCode,
 	funcName string) error {
 
@@ -11,6 +11,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
 )
 
 // ContractResolutions is a wrapper struct around the two forms of resolutions
@@ -28,15 +29,21 @@ type ContractResolutions struct {
 	// HtlcResolutions contains all data required to fully resolve any
 	// incoming+outgoing HTLC's present within the commitment transaction.
 	HtlcResolutions lnwallet.HtlcResolutions
+
+	// AnchorResolution contains the data required to sweep our anchor
+	// output, in order to bump the fee of our commitment transaction. This
+	// is nil if the commitment doesn't carry an anchor for us.
+	AnchorResolution *lnwallet.AnchorResolution
 }
 
 // IsEmpty returns true if the set of resolutions is "empty". A resolution is
-// empty if: our commitment output has been trimmed, and we don't have any
-// incoming or outgoing HTLC's active.
+// empty if: our commitment output has been trimmed, we don't have any
+// incoming or outgoing HTLC's active, and there's no anchor for us to sweep.
 func (c *ContractResolutions) IsEmpty() bool {
 	return c.CommitResolution == nil &&
 		len(c.HtlcResolutions.IncomingHTLCs) == 0 &&
-		len(c.HtlcResolutions.OutgoingHTLCs) == 0
+		len(c.HtlcResolutions.OutgoingHTLCs) == 0 &&
+		c.AnchorResolution == nil
 }
 
 // ArbitratorLog is the primary source of persistent storage for the
@@ -185,6 +192,10 @@ const (
 	// sweeping out direct commitment output form the remote party's
 	// commitment transaction.
 	resolverUnilateralSweep = 4
+
+	// resolverAnchor is the type of resolver that's tasked with bumping
+	// the fee of our commitment transaction by sweeping our anchor output.
+	resolverAnchor = 5
 )
 
 // resolverIDLen is the size of the resolver ID key. This is 36 bytes as we get
@@ -352,6 +363,8 @@ func (b *boltArbitratorLog) writeResolver(contractBucket *bolt.Bucket,
 		rType = resolverIncomingContest
 	case *commitSweepResolver:
 		rType = resolverUnilateralSweep
+	case *anchorResolver:
+		rType = resolverAnchor
 	}
 	if _, err := buf.Write([]byte{byte(rType)}); err != nil {
 		return err
@@ -486,6 +499,14 @@ func (b *boltArbitratorLog) FetchUnresolvedContracts() ([]ContractResolver, erro
 
 				res = sweepRes
 
+			case resolverAnchor:
+				anchorRes := &anchorResolver{}
+				if err := anchorRes.Decode(resReader); err != nil {
+					return err
+				}
+
+				res = anchorRes
+
 			default:
 				return fmt.Errorf("unknown resolver type: %v", resType)
 			}
@@ -621,6 +642,23 @@ func (b *boltArbitratorLog) LogContractResolutions(c *ContractResolutions) error
 			}
 		}
 
+		// Finally, we'll write out the anchor resolution, if any. As
+		// it was added after the other resolutions, it may be absent
+		// from resolutions written by older versions.
+		if c.AnchorResolution == nil {
+			if err := binary.Write(&b, endian, false); err != nil {
+				return err
+			}
+		} else {
+			if err := binary.Write(&b, endian, true); err != nil {
+				return err
+			}
+			err = encodeAnchorResolution(&b, c.AnchorResolution)
+			if err != nil {
+				return err
+			}
+		}
+
 		return scopeBucket.Put(resolutionsKey, b.Bytes())
 	})
 }
@@ -701,6 +739,24 @@ func (b *boltArbitratorLog) FetchContractResolutions() (*ContractResolutions, er
 			}
 		}
 
+		// Finally, we'll read out the anchor resolution. Resolutions
+		// written by older versions end here, so we'll treat a missing
+		// anchor resolution as absent.
+		var haveAnchorRes bool
+		err = binary.Read(resReader, endian, &haveAnchorRes)
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+		if haveAnchorRes {
+			c.AnchorResolution = &lnwallet.AnchorResolution{}
+			return decodeAnchorResolution(
+				resReader, c.AnchorResolution,
+			)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -1042,3 +1098,52 @@ func decodeCommitResolution(r io.Reader,
 
 	return binary.Read(r, endian, &c.MaturityDelay)
 }
+
+func encodeAnchorResolution(w io.Writer,
+	a *lnwallet.AnchorResolution) error {
+
+	if _, err := w.Write(a.CommitAnchor.Hash[:]); err != nil {
+		return err
+	}
+	err := binary.Write(w, endian, a.CommitAnchor.Index)
+	if err != nil {
+		return err
+	}
+
+	err = lnwallet.WriteSignDescriptor(w, &a.AnchorSignDescriptor)
+	if err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, endian, int64(a.CommitFee)); err != nil {
+		return err
+	}
+
+	return binary.Write(w, endian, a.CommitWeight)
+}
+
+func decodeAnchorResolution(r io.Reader,
+	a *lnwallet.AnchorResolution) error {
+
+	_, err := io.ReadFull(r, a.CommitAnchor.Hash[:])
+	if err != nil {
+		return err
+	}
+	err = binary.Read(r, endian, &a.CommitAnchor.Index)
+	if err != nil {
+		return err
+	}
+
+	err = lnwallet.ReadSignDescriptor(r, &a.AnchorSignDescriptor)
+	if err != nil {
+		return err
+	}
+
+	var commitFee int64
+	if err := binary.Read(r, endian, &commitFee); err != nil {
+		return err
+	}
+	a.CommitFee = btcutil.Amount(commitFee)
+
+	return binary.Read(r, endian, &a.CommitWeight)
+}
@@ -234,6 +234,29 @@ func assertResolversEqual(t *testing.T, originalResolver ContractResolver,
 			t.Fatalf("expected %v, got %v", ogRes.chanPoint,
 				diskRes.chanPoint)
 		}
+
+	case *anchorResolver:
+		diskRes := diskResolver.(*anchorResolver)
+		if !reflect.DeepEqual(ogRes.anchorResolution, diskRes.anchorResolution) {
+			t.Fatalf("resolution mismatch: expected %v, got %v",
+				ogRes.anchorResolution, diskRes.anchorResolution)
+		}
+		if ogRes.resolved != diskRes.resolved {
+			t.Fatalf("expected %v, got %v", ogRes.resolved,
+				diskRes.resolved)
+		}
+		if ogRes.broadcastHeight != diskRes.broadcastHeight {
+			t.Fatalf("expected %v, got %v",
+				ogRes.broadcastHeight, diskRes.broadcastHeight)
+		}
+		if ogRes.chanPoint != diskRes.chanPoint {
+			t.Fatalf("expected %v, got %v", ogRes.chanPoint,
+				diskRes.chanPoint)
+		}
+		if ogRes.deadline != diskRes.deadline {
+			t.Fatalf("expected %v, got %v", ogRes.deadline,
+				diskRes.deadline)
+		}
 	}
 }
 
@@ -295,6 +318,18 @@ func TestContractInsertionRetrieval(t *testing.T) {
 			chanPoint:       testChanPoint1,
 			sweepTx:         nil,
 		},
+		&anchorResolver{
+			anchorResolution: lnwallet.AnchorResolution{
+				CommitAnchor:         randOutPoint(),
+				AnchorSignDescriptor: testSignDesc,
+				CommitFee:            1000,
+				CommitWeight:         1124,
+			},
+			resolved:        false,
+			broadcastHeight: 109,
+			chanPoint:       testChanPoint1,
+			deadline:        150,
+		},
 	}
 
 	// All resolvers require a unique ResolverKey() output. To achieve this
@@ -320,6 +355,7 @@ func TestContractInsertionRetrieval(t *testing.T) {
 	resolverMap[string(resolvers[2].ResolverKey())] = resolvers[2]
 	resolverMap[string(resolvers[3].ResolverKey())] = resolvers[3]
 	resolverMap[string(resolvers[4].ResolverKey())] = resolvers[4]
+	resolverMap[string(resolvers[5].ResolverKey())] = resolvers[5]
 
 	// Now, we'll insert the resolver into the log.
 	if err := testLog.InsertUnresolvedContracts(resolvers...); err != nil {
@@ -530,6 +566,12 @@ func TestContractResolutionsStorage(t *testing.T) {
 				},
 			},
 		},
+		AnchorResolution: &lnwallet.AnchorResolution{
+			CommitAnchor:         randOutPoint(),
+			AnchorSignDescriptor: testSignDesc,
+			CommitFee:            1000,
+			CommitWeight:         1124,
+		},
 	}
 
 	// Insert the resolution into the database, then immediately retrieve
@@ -9,6 +9,7 @@ import (
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
@@ -54,13 +55,6 @@ type ChainArbitratorConfig struct {
 	// transaction is already confirmed, by the time the HTLC expires.
 	BroadcastDelta uint32
 
-	// NewSweepAddr is a function that returns a new address under control
-	// by the wallet. We'll use this to sweep any no-delay outputs as a
-	// result of unilateral channel closes.
-	//
-	// NOTE: This SHOULD return a p2wkh script.
-	NewSweepAddr func() ([]byte, error)
-
 	// PublishTx reliably broadcasts a transaction to the network. Once
 	// this function exits without an error, then they transaction MUST
 	// continually be rebroadcast if needed.
@@ -86,6 +80,11 @@ type ChainArbitratorConfig struct {
 	// returned.
 	IsOurAddress func(btcutil.Address) bool
 
+	// NotifyClosedChannel is a function closure that the ChainArbitrator
+	// will use to notify the rest of the daemon that a channel has been
+	// fully resolved, and is no longer being watched.
+	NotifyClosedChannel func(wire.OutPoint)
+
 	// IncubateOutput sends either a incoming HTLC, an outgoing HTLC, or
 	// both to the utxo nursery. Once this function returns, the nursery
 	// should have safely persisted the outputs to disk, and should start
@@ -110,11 +109,13 @@ type ChainArbitratorConfig struct {
 	// SignDescriptor.
 	Signer lnwallet.Signer
 
-	// FeeEstimator will be used to return fee estimates.
-	FeeEstimator lnwallet.FeeEstimator
-
 	// ChainIO allows us to query the state of the current main chain.
 	ChainIO lnwallet.BlockChainIO
+
+	// SweepInput offers an output to the sweeper, which will sweep it
+	// into the wallet, batched together with other inputs. The returned
+	// channel is sent on once the output has been spent.
+	SweepInput func(sweep.Input, uint32) (chan sweep.Result, error)
 }
 
 // ChainArbitrator is a sub-system that oversees the on-chain resolution of all
@@ -299,6 +300,10 @@ func (c *ChainArbitrator) resolveContract(chanPoint wire.OutPoint,
 	}
 	c.Unlock()
 
+	// Now that the channel has been fully resolved, we'll notify any
+	// sub-systems that track the set of open channels.
+	c.cfg.NotifyClosedChannel(chanPoint)
+
 	return nil
 }
 
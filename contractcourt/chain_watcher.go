@@ -507,11 +507,35 @@ func (c *chainWatcher) dispatchRemoteClose(commitSpend *chainntnfs.SpendDetail,
 	log.Infof("Unilateral close of ChannelPoint(%v) "+
 		"detected", c.chanState.FundingOutpoint)
 
-	// First, we'll create a closure summary that contains all the
-	// materials required to let each subscriber sweep the funds in the
-	// channel on-chain.
+	// If we've lost state for this channel, either as we detected that
+	// we're behind the remote party or as the channel was restored from a
+	// static backup, then the commitment point we have on disk is stale.
+	// In this case, we'll use the point that the remote party sent us
+	// during channel reestablishment in order to sweep our funds.
+	commitPoint := c.chanState.RemoteCurrentRevocation
+	dataLossPoint, err := c.chanState.DataLossCommitPoint()
+	switch {
+	case err == nil:
+		log.Infof("Using data loss commit point to sweep funds from "+
+			"ChannelPoint(%v)", c.chanState.FundingOutpoint)
+
+		commitPoint = dataLossPoint
+
+	case err == channeldb.ErrNoCommitPoint && c.chanState.IsRestored:
+		log.Warnf("ChannelPoint(%v) was restored from a backup, but "+
+			"no commit point was received from the remote party, "+
+			"unable to sweep funds", c.chanState.FundingOutpoint)
+
+	case err != channeldb.ErrNoCommitPoint:
+		return fmt.Errorf("unable to fetch data loss commit "+
+			"point: %v", err)
+	}
+
+	// With the commitment point known, we'll create a closure summary
+	// that contains all the materials required to let each subscriber
+	// sweep the funds in the channel on-chain.
 	uniClose, err := lnwallet.NewUnilateralCloseSummary(c.chanState,
-		c.signer, c.pCache, commitSpend, remoteCommit,
+		c.signer, c.pCache, commitSpend, remoteCommit, commitPoint,
 	)
 	if err != nil {
 		return err
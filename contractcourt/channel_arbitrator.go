@@ -480,6 +480,7 @@ func (c *ChannelArbitrator) stateStep(bestHeight uint32, bestHash *chainhash.Has
 			CommitHash:       closeTx.TxHash(),
 			CommitResolution: closeSummary.CommitResolution,
 			HtlcResolutions:  *closeSummary.HtlcResolutions,
+			AnchorResolution: closeSummary.AnchorResolution,
 		}
 
 		// Now that the transaction has been broadcast, we can mark
@@ -496,11 +497,12 @@ func (c *ChannelArbitrator) stateStep(bestHeight uint32, bestHash *chainhash.Has
 		// With the channel force closed, we'll now log our
 		// resolutions, then advance our state forward.
 		log.Infof("ChannelArbitrator(%v): logging contract "+
-			"resolutions: commit=%v, num_htlcs=%v",
+			"resolutions: commit=%v, num_htlcs=%v, anchor=%v",
 			c.cfg.ChanPoint,
 			closeSummary.CommitResolution != nil,
 			len(closeSummary.HtlcResolutions.IncomingHTLCs)+
-				len(closeSummary.HtlcResolutions.OutgoingHTLCs))
+				len(closeSummary.HtlcResolutions.OutgoingHTLCs),
+			closeSummary.AnchorResolution != nil)
 
 		err = c.log.LogContractResolutions(&contractRes)
 		if err != nil {
@@ -1142,6 +1144,30 @@ func (c *ChannelArbitrator) prepContractResolutions(htlcActions ChainActionMap,
 		htlcResolvers = append(htlcResolvers, resolver)
 	}
 
+	// If our commitment carries an anchor for us, then we'll create a
+	// resolver to bump the fee of the commitment if needed. The commitment
+	// should confirm before the earliest of our outgoing HTLCs times out,
+	// as we'd otherwise be unable to time it out on-chain in time.
+	if contractResolutions.AnchorResolution != nil {
+		var deadline uint32
+		for _, htlc := range outgoingResolutions {
+			if deadline == 0 || htlc.Expiry < deadline {
+				deadline = htlc.Expiry
+			}
+		}
+
+		resKit.Quit = make(chan struct{})
+		resolver := &anchorResolver{
+			anchorResolution: *contractResolutions.AnchorResolution,
+			broadcastHeight:  height,
+			chanPoint:        c.cfg.ChanPoint,
+			deadline:         deadline,
+			ResolverKit:      resKit,
+		}
+
+		htlcResolvers = append(htlcResolvers, resolver)
+	}
+
 	return htlcResolvers, msgsToSend, nil
 }
 
@@ -12,7 +12,7 @@ import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
-	"github.com/roasbeef/btcd/txscript"
+	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/roasbeef/btcd/wire"
 )
 
@@ -462,109 +462,48 @@ func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
 	// If we don't have a success transaction, then this means that this is
 	// an output on the remote party's commitment transaction.
 	if h.htlcResolution.SignedSuccessTx == nil {
-		// If we don't already have the sweep transaction constructed,
-		// we'll do so and broadcast it.
-		if h.sweepTx == nil {
-			log.Infof("%T(%x): crafting sweep tx for "+
-				"incoming+remote htlc confirmed", h,
-				h.payHash[:])
-
-			// In this case, we can sweep it directly from the
-			// commitment output. We'll first grab a fresh address
-			// from the wallet to sweep the output.
-			addr, err := h.NewSweepAddr()
-			if err != nil {
-				return nil, err
-			}
-
-			// With out address obtained, we'll query for an
-			// estimate to be confirmed at ease.
-			//
-			// TODO(roasbeef): signal up if fee would be too large
-			// to sweep singly, need to batch
-			feePerVSize, err := h.FeeEstimator.EstimateFeePerVSize(6)
-			if err != nil {
-				return nil, err
-			}
-
-			log.Debugf("%T(%x): using %v sat/vbyte to sweep htlc"+
-				"incoming+remote htlc confirmed", h,
-				h.payHash[:], int64(feePerVSize))
-
-			// Using a weight estimator, we'll compute the total
-			// fee required, and from that the value we'll end up
-			// with.
-			totalVSize := (&lnwallet.TxWeightEstimator{}).
-				AddWitnessInput(lnwallet.OfferedHtlcSuccessWitnessSize).
-				AddP2WKHOutput().VSize()
-			totalFees := feePerVSize.FeeForVSize(int64(totalVSize))
-			sweepAmt := h.htlcResolution.SweepSignDesc.Output.Value -
-				int64(totalFees)
-
-			// With the fee computation finished, we'll now
-			// construct the sweep transaction.
-			htlcPoint := h.htlcResolution.ClaimOutpoint
-			h.sweepTx = wire.NewMsgTx(2)
-			h.sweepTx.AddTxIn(&wire.TxIn{
-				PreviousOutPoint: htlcPoint,
-			})
-			h.sweepTx.AddTxOut(&wire.TxOut{
-				PkScript: addr,
-				Value:    sweepAmt,
-			})
-
-			// With the transaction fully assembled, we can now
-			// generate a valid witness for the transaction.
-			h.htlcResolution.SweepSignDesc.SigHashes = txscript.NewTxSigHashes(
-				h.sweepTx,
-			)
-			h.sweepTx.TxIn[0].Witness, err = lnwallet.SenderHtlcSpendRedeem(
-				h.Signer, &h.htlcResolution.SweepSignDesc, h.sweepTx,
-				h.htlcResolution.Preimage[:],
-			)
-			if err != nil {
-				return nil, err
-			}
-
-			log.Infof("%T(%x): crafted sweep tx=%v", h,
-				h.payHash[:], spew.Sdump(h.sweepTx))
-
-			// With the sweep transaction confirmed, we'll now
-			// Checkpoint our state.
-			if err := h.Checkpoint(h); err != nil {
-				log.Errorf("unable to Checkpoint: %v", err)
-			}
-
-			// Finally, we'll broadcast the sweep transaction to
-			// the network.
-			//
-			// TODO(roasbeef): validate first?
-			if err := h.PublishTx(h.sweepTx); err != nil {
-				log.Infof("%T(%x): unable to publish tx: %v",
-					h, h.payHash[:], err)
-				return nil, err
-			}
-		}
-
-		// With the sweep transaction broadcast, we'll wait for its
-		// confirmation.
-		sweepTXID := h.sweepTx.TxHash()
-		confNtfn, err := h.Notifier.RegisterConfirmationsNtfn(
-			&sweepTXID, 1, h.broadcastHeight,
+		log.Infof("%T(%x): offering incoming+remote htlc to the "+
+			"sweeper", h, h.payHash[:])
+
+		// In this case, we can sweep it directly from the commitment
+		// output. We'll hand the output off to the sweeper, which
+		// will batch it with other inputs and bump its fee until it
+		// confirms. If we're restarting, the sweeper will detect the
+		// spend of our earlier sweep transaction.
+		input := sweep.NewHtlcSucceedInput(
+			&h.htlcResolution.ClaimOutpoint,
+			&h.htlcResolution.SweepSignDesc,
+			h.htlcResolution.Preimage[:], h.broadcastHeight,
 		)
+		resultChan, err := h.SweepInput(input, 0)
 		if err != nil {
+			log.Errorf("%T(%x): unable to sweep htlc: %v", h,
+				h.payHash[:], err)
 			return nil, err
 		}
 
-		log.Infof("%T(%x): waiting for sweep tx (txid=%v) to be "+
-			"confirmed", h, h.payHash[:], sweepTXID)
-
+		// Wait for the htlc output to be spent, which happens when
+		// either the sweep transaction confirms, or the remote party
+		// swept the output using the timeout clause.
 		select {
-		case _, ok := <-confNtfn.Confirmed:
-			if !ok {
-				return nil, fmt.Errorf("quitting")
+		case result := <-resultChan:
+			switch {
+			case result.Err == sweep.ErrRemoteSpend:
+				log.Warnf("%T(%x): htlc swept by remote "+
+					"party: %v", h, h.payHash[:],
+					result.Tx.TxHash())
+
+			case result.Err != nil:
+				log.Errorf("%T(%x): unable to sweep htlc: %v",
+					h, h.payHash[:], result.Err)
+				return nil, result.Err
 			}
 
+			h.sweepTx = result.Tx
+
+			log.Infof("%T(%x): sweep tx (txid=%v) confirmed", h,
+				h.payHash[:], h.sweepTx.TxHash())
+
 		case <-h.Quit:
 			return nil, fmt.Errorf("quitting")
 		}
@@ -1247,58 +1186,41 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 	// party broadcast the commitment transaction then we'll create it now.
 	case c.sweepTx == nil && !isLocalCommitTx:
 		// Now that the commitment transaction has confirmed, we'll
-		// craft a transaction to sweep this output into the wallet.
-		signDesc := c.commitResolution.SelfOutputSignDesc
-
-		// First, we'll estimate the total weight so we can compute
-		// fees properly. We'll use a lax estimate, as this output is
-		// in no immediate danger.
-		feePerVSize, err := c.FeeEstimator.EstimateFeePerVSize(6)
+		// offer this output to the sweeper, which will sweep it into
+		// the wallet. As this output is in no immediate danger, we
+		// don't pass a deadline.
+		input := sweep.NewBaseInput(
+			&c.commitResolution.SelfOutPoint,
+			lnwallet.CommitmentNoDelay,
+			&c.commitResolution.SelfOutputSignDesc,
+			c.broadcastHeight,
+		)
+		resultChan, err := c.SweepInput(input, 0)
 		if err != nil {
+			log.Errorf("%T(%v): unable to sweep commit output: %v",
+				c, c.chanPoint, err)
 			return nil, err
 		}
 
-		log.Debugf("%T(%v): using %v sat/vsize for sweep tx", c,
-			c.chanPoint, int64(feePerVSize))
+		log.Infof("%T(%v): offered commit output to the sweeper", c,
+			c.chanPoint)
 
-		totalVSize := (&lnwallet.TxWeightEstimator{}).
-			AddP2PKHInput().
-			AddP2WKHOutput().VSize()
-		totalFees := feePerVSize.FeeForVSize(int64(totalVSize))
-		sweepAmt := signDesc.Output.Value - int64(totalFees)
+		select {
+		case result := <-resultChan:
+			if result.Err != nil {
+				log.Errorf("%T(%v): unable to sweep commit "+
+					"output: %v", c, c.chanPoint,
+					result.Err)
+				return nil, result.Err
+			}
 
-		c.sweepTx = wire.NewMsgTx(2)
-		c.sweepTx.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: c.commitResolution.SelfOutPoint,
-		})
-		sweepAddr, err := c.NewSweepAddr()
-		if err != nil {
-			return nil, err
-		}
-		c.sweepTx.AddTxOut(&wire.TxOut{
-			PkScript: sweepAddr,
-			Value:    sweepAmt,
-		})
-
-		// With the transaction fully assembled, we can now generate a
-		// valid witness for the transaction.
-		signDesc.SigHashes = txscript.NewTxSigHashes(c.sweepTx)
-		c.sweepTx.TxIn[0].Witness, err = lnwallet.CommitSpendNoDelay(
-			c.Signer, &signDesc, c.sweepTx,
-		)
-		if err != nil {
-			return nil, err
-		}
+			c.sweepTx = result.Tx
 
-		log.Infof("%T(%v): sweeping commit output with tx=%v", c,
-			c.chanPoint, spew.Sdump(c.sweepTx))
+			log.Infof("%T(%v): commit output swept by txid=%v",
+				c, c.chanPoint, c.sweepTx.TxHash())
 
-		// Finally, we'll broadcast the sweep transaction to the
-		// network.
-		if err := c.PublishTx(c.sweepTx); err != nil {
-			log.Errorf("%T(%v): unable to publish sweep tx: %v",
-				c, c.chanPoint, err)
-			return nil, err
+		case <-c.Quit:
+			return nil, fmt.Errorf("quitting")
 		}
 
 		// With the sweep transaction confirmed, we'll now Checkpoint
@@ -1479,3 +1401,240 @@ func (c *commitSweepResolver) AttachResolverKit(r ResolverKit) {
 // A compile time assertion to ensure commitSweepResolver meets the
 // ContractResolver interface.
 var _ ContractResolver = (*commitSweepResolver)(nil)
+
+// anchorResolver is a resolver that will attempt to bump the fee of our
+// commitment transaction, by sweeping our anchor output within a child
+// transaction (CPFP). This ensures our commitment confirms in time, even if
+// the fee it commits to has become insufficient since it was signed. The
+// contract is resolved once our anchor has been swept, or once a commitment
+// transaction has confirmed without the need to do so.
+type anchorResolver struct {
+	// anchorResolution contains all the data required to sweep our anchor
+	// output.
+	anchorResolution lnwallet.AnchorResolution
+
+	// resolved reflects if the contract has been fully resolved or not.
+	resolved bool
+
+	// broadcastHeight is the height that the original contract was
+	// broadcast to the main-chain at. We'll use this value to bound any
+	// historical queries to the chain for spends/confirmations.
+	broadcastHeight uint32
+
+	// chanPoint is the channel point of the original contract.
+	chanPoint wire.OutPoint
+
+	// deadline is the height by which the commitment transaction should
+	// confirm, in order for us to be able to time out our outgoing HTLCs.
+	// A zero value means that there's no deadline.
+	deadline uint32
+
+	ResolverKit
+}
+
+// ResolverKey returns an identifier which should be globally unique for this
+// particular resolver within the chain the original contract resides within.
+func (c *anchorResolver) ResolverKey() []byte {
+	key := newResolverID(c.anchorResolution.CommitAnchor)
+	return key[:]
+}
+
+// Resolve instructs the contract resolver to resolve the output on-chain. Once
+// the output has been *fully* resolved, the function should return immediately
+// with a nil ContractResolver value for the first return value.  In the case
+// that the contract requires further resolution, then another resolve is
+// returned.
+//
+// NOTE: This function MUST be run as a goroutine.
+func (c *anchorResolver) Resolve() (ContractResolver, error) {
+	// If we're already resolved, then we can exit early.
+	if c.resolved {
+		return nil, nil
+	}
+
+	// We'll offer our anchor to the sweeper, which will bump the fee of
+	// the commitment if it doesn't pay a sufficient fee rate to confirm
+	// by our deadline.
+	input := sweep.NewCpfpInput(
+		&c.anchorResolution.CommitAnchor, lnwallet.CommitmentAnchor,
+		&c.anchorResolution.AnchorSignDescriptor, c.broadcastHeight,
+		&sweep.TxInfo{
+			Fee:    c.anchorResolution.CommitFee,
+			Weight: c.anchorResolution.CommitWeight,
+		},
+	)
+	resultChan, err := c.SweepInput(input, c.deadline)
+	if err != nil {
+		log.Errorf("%T(%v): unable to sweep anchor: %v", c,
+			c.chanPoint, err)
+		return nil, err
+	}
+
+	log.Infof("%T(%v): offered anchor to the sweeper, deadline=%v", c,
+		c.chanPoint, c.deadline)
+
+	// If the commitment of the remote party confirms instead of ours, our
+	// anchor will never exist, so we'll also watch the funding output. As
+	// spends may be detected before they confirm, we'll wait for the
+	// spending transaction to confirm before giving up on our anchor.
+	spendNtfn, err := c.Notifier.RegisterSpendNtfn(
+		&c.chanPoint, c.broadcastHeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer spendNtfn.Cancel()
+
+	var (
+		spendChan       = spendNtfn.Spend
+		remoteCommitted <-chan *chainntnfs.TxConfirmation
+	)
+
+resolve:
+	for {
+		select {
+		case result := <-resultChan:
+			switch result.Err {
+			case nil:
+				log.Infof("%T(%v): anchor swept by txid=%v", c,
+					c.chanPoint, result.Tx.TxHash())
+
+			case sweep.ErrParentConfirmed:
+				log.Infof("%T(%v): commitment confirmed, no "+
+					"need to sweep anchor", c, c.chanPoint)
+
+			case sweep.ErrRemoteSpend:
+				log.Infof("%T(%v): anchor swept by another "+
+					"party", c, c.chanPoint)
+
+			default:
+				log.Errorf("%T(%v): unable to sweep anchor: %v",
+					c, c.chanPoint, result.Err)
+				return nil, result.Err
+			}
+
+			break resolve
+
+		case spend, ok := <-spendChan:
+			if !ok {
+				return nil, fmt.Errorf("quitting")
+			}
+			spendChan = nil
+
+			commitHash := c.anchorResolution.CommitAnchor.Hash
+			if *spend.SpenderTxHash == commitHash {
+				continue
+			}
+
+			log.Infof("%T(%v): funding output spent by txid=%v, "+
+				"waiting for it to confirm", c, c.chanPoint,
+				spend.SpenderTxHash)
+
+			confNtfn, err := c.Notifier.RegisterConfirmationsNtfn(
+				spend.SpenderTxHash, 1, c.broadcastHeight,
+			)
+			if err != nil {
+				return nil, err
+			}
+			remoteCommitted = confNtfn.Confirmed
+
+		case _, ok := <-remoteCommitted:
+			if !ok {
+				return nil, fmt.Errorf("quitting")
+			}
+
+			log.Infof("%T(%v): commitment of remote party "+
+				"confirmed, abandoning anchor", c, c.chanPoint)
+
+			break resolve
+
+		case <-c.Quit:
+			return nil, fmt.Errorf("quitting")
+		}
+	}
+
+	c.resolved = true
+	return nil, c.Checkpoint(c)
+}
+
+// Stop signals the resolver to cancel any current resolution processes, and
+// suspend.
+//
+// NOTE: Part of the ContractResolver interface.
+func (c *anchorResolver) Stop() {
+	close(c.Quit)
+}
+
+// IsResolved returns true if the stored state in the resolve is fully
+// resolved. In this case the target output can be forgotten.
+//
+// NOTE: Part of the ContractResolver interface.
+func (c *anchorResolver) IsResolved() bool {
+	return c.resolved
+}
+
+// Encode writes an encoded version of the ContractResolver into the passed
+// Writer.
+//
+// NOTE: Part of the ContractResolver interface.
+func (c *anchorResolver) Encode(w io.Writer) error {
+	if err := encodeAnchorResolution(w, &c.anchorResolution); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, endian, c.resolved); err != nil {
+		return err
+	}
+	if err := binary.Write(w, endian, c.broadcastHeight); err != nil {
+		return err
+	}
+	if _, err := w.Write(c.chanPoint.Hash[:]); err != nil {
+		return err
+	}
+	err := binary.Write(w, endian, c.chanPoint.Index)
+	if err != nil {
+		return err
+	}
+
+	return binary.Write(w, endian, c.deadline)
+}
+
+// Decode attempts to decode an encoded ContractResolver from the passed Reader
+// instance, returning an active ContractResolver instance.
+//
+// NOTE: Part of the ContractResolver interface.
+func (c *anchorResolver) Decode(r io.Reader) error {
+	if err := decodeAnchorResolution(r, &c.anchorResolution); err != nil {
+		return err
+	}
+
+	if err := binary.Read(r, endian, &c.resolved); err != nil {
+		return err
+	}
+	if err := binary.Read(r, endian, &c.broadcastHeight); err != nil {
+		return err
+	}
+	_, err := io.ReadFull(r, c.chanPoint.Hash[:])
+	if err != nil {
+		return err
+	}
+	err = binary.Read(r, endian, &c.chanPoint.Index)
+	if err != nil {
+		return err
+	}
+
+	return binary.Read(r, endian, &c.deadline)
+}
+
+// AttachResolverKit should be called once a resolved is successfully decoded
+// from its stored format. This struct delivers a generic tool kit that
+// resolvers need to complete their duty.
+//
+// NOTE: Part of the ContractResolver interface.
+func (c *anchorResolver) AttachResolverKit(r ResolverKit) {
+	c.ResolverKit = r
+}
+
+// A compile time assertion to ensure anchorResolver meets the
+// ContractResolver interface.
+var _ ContractResolver = (*anchorResolver)(nil)
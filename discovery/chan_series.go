@@ -0,0 +1,298 @@
+package discovery
+
+import (
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// ChannelGraphTimeSeries is an interface that provides time and block based
+// querying into our view of the channel graph. New channels will have
+// monotonically increasing block heights, and new channel updates will have
+// increasing timestamps. Once we connect to a peer, we'll use the methods in
+// this interface to determine if we're already in sync, or need to request
+// some new information from them.
+type ChannelGraphTimeSeries interface {
+	// HighestChanID should return the channel ID of the channel we know
+	// of that's furthest in the target chain. This channel will have a
+	// block height that's close to the current tip of the main chain as
+	// we know it. We'll use this to start our QueryChannelRange dance with
+	// the remote node.
+	HighestChanID() (*lnwire.ShortChannelID, error)
+
+	// UpdatesInHorizon returns all known channel and node updates with an
+	// update timestamp between the start time and end time. We'll use
+	// this to catch up a remote node to the set of channel updates that
+	// they may have missed out on within the target chain.
+	UpdatesInHorizon(startTime time.Time,
+		endTime time.Time) ([]lnwire.Message, error)
+
+	// FilterKnownChanIDs takes a set of channel ID's and returns a
+	// filtered set of chan ID's. This filtered set of chan ID's represents
+	// the ID's that we don't know of which were in the passed superSet.
+	FilterKnownChanIDs(
+		superSet []lnwire.ShortChannelID) ([]lnwire.ShortChannelID, error)
+
+	// FilterChannelRange returns the set of channels that we created
+	// between the start height and the end height. We'll use this to
+	// respond to a remote peer's QueryChannelRange message.
+	FilterChannelRange(startHeight,
+		endHeight uint32) ([]lnwire.ShortChannelID, error)
+
+	// FetchChanAnns returns a full set of channel announcements as well as
+	// their updates that match the set of specified short channel ID's.
+	// We'll use this to reply to a QueryShortChanIDs message sent by a
+	// remote peer. The response will contain a unique set of
+	// ChannelAnnouncements, the latest ChannelUpdate for each of the
+	// announcements, and a unique set of NodeAnnouncements.
+	FetchChanAnns(
+		shortChanIDs []lnwire.ShortChannelID) ([]lnwire.Message, error)
+}
+
+// chanSeries is an implementation of the ChannelGraphTimeSeries
+// interface backed by the channeldb ChannelGraph database. We'll provide this
+// implementation to the AuthenticatedGossiper so it can properly use the
+// in-protocol channel range queries to quickly and efficiently synchronize
+// our channel state with all peers.
+type chanSeries struct {
+	graph *channeldb.ChannelGraph
+}
+
+// NewChanSeries constructs a new instance of the chanSeries implementation
+// of the ChannelGraphTimeSeries interface, backed by the passed channel
+// graph.
+func NewChanSeries(graph *channeldb.ChannelGraph) ChannelGraphTimeSeries {
+	return &chanSeries{
+		graph: graph,
+	}
+}
+
+// HighestChanID should return the channel ID of the channel we know of that's
+// furthest in the target chain. This channel will have a block height that's
+// close to the current tip of the main chain as we know it. We'll use this to
+// start our QueryChannelRange dance with the remote node.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *chanSeries) HighestChanID() (*lnwire.ShortChannelID, error) {
+	chanID, err := c.graph.HighestChanID()
+	if err != nil {
+		return nil, err
+	}
+
+	shortChanID := lnwire.NewShortChanIDFromInt(chanID)
+	return &shortChanID, nil
+}
+
+// UpdatesInHorizon returns all known channel and node updates with an update
+// timestamp between the start time and end time. We'll use this to catch up a
+// remote node to the set of channel updates that they may have missed out on
+// within the target chain.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *chanSeries) UpdatesInHorizon(startTime time.Time,
+	endTime time.Time) ([]lnwire.Message, error) {
+
+	var updates []lnwire.Message
+
+	inHorizon := func(policy *channeldb.ChannelEdgePolicy) bool {
+		if policy == nil {
+			return false
+		}
+
+		return !policy.LastUpdate.Before(startTime) &&
+			!policy.LastUpdate.After(endTime)
+	}
+
+	// First, we'll query for all the set of channels that have an update
+	// that falls within the specified horizon.
+	chansInHorizon, err := c.graph.ChanUpdatesInHorizon(
+		startTime, endTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range chansInHorizon {
+		// If the channel hasn't been fully advertised yet, or is a
+		// private channel, then we'll skip it as we can't construct a
+		// full authentication proof if one is requested.
+		if channel.Info.AuthProof == nil {
+			continue
+		}
+
+		chanAnn, edge1, edge2, err := createChanAnnouncement(
+			channel.Info.AuthProof, channel.Info, channel.Policy1,
+			channel.Policy2,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// As the channel announcement is needed to validate any of its
+		// updates, it's always sent. The updates themselves are only
+		// sent if they fall within the horizon.
+		updates = append(updates, chanAnn)
+		if edge1 != nil && inHorizon(channel.Policy1) {
+			updates = append(updates, edge1)
+		}
+		if edge2 != nil && inHorizon(channel.Policy2) {
+			updates = append(updates, edge2)
+		}
+	}
+
+	// Next, we'll send out all the node announcements that have an update
+	// within the horizon as well. We send these second to ensure that they
+	// follow any active channels they have.
+	nodeAnnsInHorizon, err := c.graph.NodeUpdatesInHorizon(
+		startTime, endTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeAnn := range nodeAnnsInHorizon {
+		nodeUpdate, err := makeNodeAnn(&nodeAnn)
+		if err != nil {
+			return nil, err
+		}
+
+		updates = append(updates, nodeUpdate)
+	}
+
+	return updates, nil
+}
+
+// FilterKnownChanIDs takes a set of channel ID's and returns a filtered set
+// of chan ID's. This filtered set of chan ID's represents the ID's that we
+// don't know of which were in the passed superSet.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *chanSeries) FilterKnownChanIDs(
+	superSet []lnwire.ShortChannelID) ([]lnwire.ShortChannelID, error) {
+
+	chanIDs := make([]uint64, 0, len(superSet))
+	for _, chanID := range superSet {
+		chanIDs = append(chanIDs, chanID.ToUint64())
+	}
+
+	newChanIDs, err := c.graph.FilterKnownChanIDs(chanIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredIDs := make([]lnwire.ShortChannelID, 0, len(newChanIDs))
+	for _, chanID := range newChanIDs {
+		filteredIDs = append(
+			filteredIDs, lnwire.NewShortChanIDFromInt(chanID),
+		)
+	}
+
+	return filteredIDs, nil
+}
+
+// FilterChannelRange returns the set of channels that we created between the
+// start height and the end height. We'll use this to respond to a remote
+// peer's QueryChannelRange message.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *chanSeries) FilterChannelRange(startHeight,
+	endHeight uint32) ([]lnwire.ShortChannelID, error) {
+
+	chansInRange, err := c.graph.FilterChannelRange(startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	chanResp := make([]lnwire.ShortChannelID, 0, len(chansInRange))
+	for _, chanID := range chansInRange {
+		chanResp = append(
+			chanResp, lnwire.NewShortChanIDFromInt(chanID),
+		)
+	}
+
+	return chanResp, nil
+}
+
+// FetchChanAnns returns a full set of channel announcements as well as their
+// updates that match the set of specified short channel ID's. We'll use this
+// to reply to a QueryShortChanIDs message sent by a remote peer. The response
+// will contain a unique set of ChannelAnnouncements, the latest ChannelUpdate
+// for each of the announcements, and a unique set of NodeAnnouncements.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *chanSeries) FetchChanAnns(
+	shortChanIDs []lnwire.ShortChannelID) ([]lnwire.Message, error) {
+
+	chanIDs := make([]uint64, 0, len(shortChanIDs))
+	for _, chanID := range shortChanIDs {
+		chanIDs = append(chanIDs, chanID.ToUint64())
+	}
+
+	channels, err := c.graph.FetchChanInfos(chanIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll use this map to ensure we don't send the same node
+	// announcement more than one time as one node may have many channel
+	// anns we'll need to send.
+	nodePubsSent := make(map[[33]byte]struct{})
+
+	chanAnns := make([]lnwire.Message, 0, len(channels)*3)
+	for _, channel := range channels {
+		// If the channel doesn't have an authentication proof, then we
+		// won't send it over as it may not yet be finalized, or be a
+		// non-advertised channel.
+		if channel.Info.AuthProof == nil {
+			continue
+		}
+
+		chanAnn, edge1, edge2, err := createChanAnnouncement(
+			channel.Info.AuthProof, channel.Info, channel.Policy1,
+			channel.Policy2,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		chanAnns = append(chanAnns, chanAnn)
+		if edge1 != nil {
+			chanAnns = append(chanAnns, edge1)
+		}
+		if edge2 != nil {
+			chanAnns = append(chanAnns, edge2)
+		}
+
+		// Each policy points to the node it leads to, so between the
+		// two of them we'll be able to send the announcements of both
+		// nodes of the channel.
+		for _, policy := range []*channeldb.ChannelEdgePolicy{
+			channel.Policy1, channel.Policy2,
+		} {
+			if policy == nil || policy.Node == nil {
+				continue
+			}
+
+			node := policy.Node
+			if !node.HaveNodeAnnouncement {
+				continue
+			}
+			if _, ok := nodePubsSent[node.PubKeyBytes]; ok {
+				continue
+			}
+
+			nodeAnn, err := makeNodeAnn(node)
+			if err != nil {
+				return nil, err
+			}
+
+			chanAnns = append(chanAnns, nodeAnn)
+			nodePubsSent[node.PubKeyBytes] = struct{}{}
+		}
+	}
+
+	return chanAnns, nil
+}
+
+// A compile-time assertion to ensure that chanSeries meets the
+// ChannelGraphTimeSeries interface.
+var _ ChannelGraphTimeSeries = (*chanSeries)(nil)
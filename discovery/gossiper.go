@@ -121,6 +121,21 @@ type Config struct {
 	// TODO(roasbeef): extract ann crafting + sign from fundingMgr into
 	// here?
 	AnnSigner lnwallet.MessageSigner
+
+	// ChannelSeries is the interface we'll use to query our view of the
+	// channel graph, in order to synchronize it with the peers that
+	// understand the gossip query messages.
+	ChannelSeries ChannelGraphTimeSeries
+
+	// Encoding is the encoding type that we'll use for the set of short
+	// channel ID's within the gossip queries and replies sent to our
+	// peers.
+	Encoding lnwire.ShortChanIDEncoding
+
+	// NumActiveSyncers is the number of peers understanding the gossip
+	// query messages that we'll actively synchronize our channel graph
+	// with. For any additional peers, we'll only answer their queries.
+	NumActiveSyncers int
 }
 
 // AuthenticatedGossiper is a subsystem which is responsible for receiving
@@ -196,6 +211,19 @@ type AuthenticatedGossiper struct {
 	rejectMtx     sync.RWMutex
 	recentRejects map[uint64]struct{}
 
+	// peerSyncers keeps track of all the gossip syncers we maintain for
+	// peers that understand the gossip query messages. We'll use this to
+	// route gossip queries to the proper syncer, and to filter the
+	// broadcast of new announcements to these peers according to their
+	// update horizon.
+	peerSyncers map[routing.Vertex]*gossipSyncer
+	syncerMtx   sync.RWMutex
+
+	// msgsReceived tracks the number of gossip messages we've received
+	// from remote peers, keyed by their message type.
+	msgsReceived map[lnwire.MessageType]uint64
+	msgsMtx      sync.Mutex
+
 	sync.Mutex
 }
 
@@ -218,6 +246,8 @@ func New(cfg Config, selfKey *btcec.PublicKey) (*AuthenticatedGossiper, error) {
 		waitingProofs:           storage,
 		channelMtx:              multimutex.NewMutex(),
 		recentRejects:           make(map[uint64]struct{}),
+		peerSyncers:             make(map[routing.Vertex]*gossipSyncer),
+		msgsReceived:            make(map[lnwire.MessageType]uint64),
 	}, nil
 }
 
@@ -236,24 +266,6 @@ func (d *AuthenticatedGossiper) SynchronizeNode(pub *btcec.PublicKey) error {
 	// containing all the messages to be sent to the target peer.
 	var announceMessages []lnwire.Message
 
-	makeNodeAnn := func(n *channeldb.LightningNode) (*lnwire.NodeAnnouncement, error) {
-		alias, _ := lnwire.NewNodeAlias(n.Alias)
-
-		wireSig, err := lnwire.NewSigFromRawSignature(n.AuthSigBytes)
-		if err != nil {
-			return nil, err
-		}
-		return &lnwire.NodeAnnouncement{
-			Signature: wireSig,
-			Timestamp: uint32(n.LastUpdate.Unix()),
-			Addresses: n.Addresses,
-			NodeID:    n.PubKeyBytes,
-			Features:  n.Features.RawFeatureVector,
-			RGBColor:  n.Color,
-			Alias:     alias,
-		}, nil
-	}
-
 	// As peers are expecting channel announcements before node
 	// announcements, we first retrieve the initial announcement, as well as
 	// the latest channel update announcement for both of the directed edges
@@ -402,6 +414,104 @@ func (d *AuthenticatedGossiper) Stop() {
 
 	close(d.quit)
 	d.wg.Wait()
+
+	// With the main handler exited, we'll also stop all of the gossip
+	// syncers we're maintaining for our peers.
+	d.syncerMtx.Lock()
+	for peer, syncer := range d.peerSyncers {
+		syncer.Stop()
+		delete(d.peerSyncers, peer)
+	}
+	d.syncerMtx.Unlock()
+}
+
+// InitSyncState is called by outside sub-systems when a connection is
+// established to a new peer that understands the gossip query messages. We'll
+// allocate a new gossip syncer for it, and start any goroutines needed to
+// handle new queries. Only up to NumActiveSyncers peers will be queried for
+// the channels we're missing, while for any other peers we'll only answer
+// their queries.
+func (d *AuthenticatedGossiper) InitSyncState(syncPeer *btcec.PublicKey) error {
+	d.syncerMtx.Lock()
+	defer d.syncerMtx.Unlock()
+
+	// If we already have a syncer for this peer, then it's left over from
+	// a prior connection, so we'll stop it before creating a fresh one.
+	peerPub := routing.NewVertex(syncPeer)
+	if oldSyncer, ok := d.peerSyncers[peerPub]; ok {
+		go oldSyncer.Stop()
+	}
+
+	// We'll only actively sync with this peer if we haven't yet reached
+	// our target number of active syncers.
+	var numActiveSyncers int
+	for peer, syncer := range d.peerSyncers {
+		if peer != peerPub && syncer.cfg.activeSync {
+			numActiveSyncers++
+		}
+	}
+	activeSync := numActiveSyncers < d.cfg.NumActiveSyncers
+
+	log.Infof("Creating new gossipSyncer for peer=%x, active_sync=%v",
+		peerPub[:], activeSync)
+
+	encoding := d.cfg.Encoding
+	syncer := newGossiperSyncer(gossipSyncerCfg{
+		chainHash:     d.cfg.ChainHash,
+		peerPub:       peerPub,
+		activeSync:    activeSync,
+		channelSeries: d.cfg.ChannelSeries,
+		encodingType:  encoding,
+		chunkSize:     encodingTypeToChunkSize[encoding],
+		sendToPeer: func(msgs ...lnwire.Message) error {
+			return d.cfg.SendToPeer(syncPeer, msgs...)
+		},
+	})
+	d.peerSyncers[peerPub] = syncer
+
+	return syncer.Start()
+}
+
+// PruneSyncState is called by outside sub-systems once a peer that we were
+// previously connected to has been disconnected. In this case we can stop the
+// existing gossipSyncer assigned to the peer and free up resources.
+func (d *AuthenticatedGossiper) PruneSyncState(peer *btcec.PublicKey) {
+	d.syncerMtx.Lock()
+	defer d.syncerMtx.Unlock()
+
+	peerPub := routing.NewVertex(peer)
+	syncer, ok := d.peerSyncers[peerPub]
+	if !ok {
+		return
+	}
+
+	log.Infof("Removing gossipSyncer for peer=%x", peerPub[:])
+
+	delete(d.peerSyncers, peerPub)
+
+	// The syncer may currently be blocked on sending a message to the
+	// peer, so we'll stop it asynchronously to ensure we don't hold up
+	// the caller.
+	go syncer.Stop()
+}
+
+// findGossipSyncer is a utility method used by the gossiper to locate the
+// gossip syncer for an inbound message so we can properly dispatch the
+// incoming message.
+func (d *AuthenticatedGossiper) findGossipSyncer(
+	pub *btcec.PublicKey) (*gossipSyncer, error) {
+
+	d.syncerMtx.RLock()
+	syncer, ok := d.peerSyncers[routing.NewVertex(pub)]
+	d.syncerMtx.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("received gossip query from peer "+
+			"%x, which doesn't have a gossip syncer",
+			pub.SerializeCompressed())
+	}
+
+	return syncer, nil
 }
 
 // ProcessRemoteAnnouncement sends a new remote announcement message along with
@@ -412,6 +522,43 @@ func (d *AuthenticatedGossiper) Stop() {
 func (d *AuthenticatedGossiper) ProcessRemoteAnnouncement(msg lnwire.Message,
 	src *btcec.PublicKey) chan error {
 
+	d.msgsMtx.Lock()
+	d.msgsReceived[msg.MsgType()]++
+	d.msgsMtx.Unlock()
+
+	// Gossip queries and their replies, as well as the gossip filter of
+	// the remote peer, are handled by the gossip syncer we maintain for
+	// the peer, rather than by the main network handler.
+	switch m := msg.(type) {
+	case *lnwire.QueryShortChanIDs,
+		*lnwire.QueryChannelRange,
+		*lnwire.ReplyChannelRange,
+		*lnwire.ReplyShortChanIDsEnd:
+
+		errChan := make(chan error, 1)
+		syncer, err := d.findGossipSyncer(src)
+		if err != nil {
+			errChan <- err
+			return errChan
+		}
+
+		syncer.ProcessQueryMsg(msg)
+
+		errChan <- nil
+		return errChan
+
+	case *lnwire.GossipTimestampRange:
+		errChan := make(chan error, 1)
+		syncer, err := d.findGossipSyncer(src)
+		if err != nil {
+			errChan <- err
+			return errChan
+		}
+
+		errChan <- syncer.ApplyGossipFilter(m)
+		return errChan
+	}
+
 	nMsg := &networkMsg{
 		msg:      msg,
 		isRemote: true,
@@ -428,6 +575,21 @@ func (d *AuthenticatedGossiper) ProcessRemoteAnnouncement(msg lnwire.Message,
 	return nMsg.err
 }
 
+// ReceivedMsgCounts returns the number of gossip messages that have been
+// received from remote peers since the gossiper was created, keyed by their
+// message type.
+func (d *AuthenticatedGossiper) ReceivedMsgCounts() map[lnwire.MessageType]uint64 {
+	d.msgsMtx.Lock()
+	defer d.msgsMtx.Unlock()
+
+	counts := make(map[lnwire.MessageType]uint64, len(d.msgsReceived))
+	for msgType, num := range d.msgsReceived {
+		counts[msgType] = num
+	}
+
+	return counts
+}
+
 // ProcessLocalAnnouncement sends a new remote announcement message along with
 // the peer that sent the routing message. The announcement will be processed
 // then added to a queue for batched trickled announcement to all connected
@@ -993,12 +1155,32 @@ func (d *AuthenticatedGossiper) networkHandler() {
 			log.Infof("Broadcasting batch of %v new announcements",
 				len(announcementBatch))
 
+			// We'll first give all the peers that understand the
+			// gossip query messages a chance to filter the batch
+			// according to their update horizon.
+			d.syncerMtx.RLock()
+			syncerPeers := make(
+				map[routing.Vertex]struct{}, len(d.peerSyncers),
+			)
+			for peer, syncer := range d.peerSyncers {
+				syncer.FilterGossipMsgs(announcementBatch...)
+				syncerPeers[peer] = struct{}{}
+			}
+			d.syncerMtx.RUnlock()
+
 			// If we have new things to announce then broadcast
-			// them to all our immediately connected peers.
+			// them to all our immediately connected peers,
+			// skipping those we've already handled above.
 			for _, msgChunk := range announcementBatch {
-				err := d.cfg.Broadcast(
-					msgChunk.senders, msgChunk.msg,
-				)
+				skips := make(map[routing.Vertex]struct{})
+				for peer := range msgChunk.senders {
+					skips[peer] = struct{}{}
+				}
+				for peer := range syncerPeers {
+					skips[peer] = struct{}{}
+				}
+
+				err := d.cfg.Broadcast(skips, msgChunk.msg)
 				if err != nil {
 					log.Errorf("unable to send batch "+
 						"announcements: %v", err)
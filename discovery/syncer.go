@@ -0,0 +1,937 @@
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// syncerState is an enum that represents the current state of the
+// gossipSyncer.  As the syncer is a state machine, we'll gate our actions
+// based off of the current state and the next incoming message.
+type syncerState uint32
+
+const (
+	// syncingChans is the default state of the gossipSyncer. We start in
+	// this state when a new peer first connects and we don't yet know if
+	// we're fully synchronized.
+	syncingChans syncerState = iota
+
+	// waitingQueryRangeReply is the second main phase of the gossipSyncer.
+	// We enter this state after we send out our first QueryChannelRange
+	// query. We'll stay in this state until the remote party sends us a
+	// ReplyChannelRange message with the complete bit set, indicating
+	// they've responded to our query entirely. After this state, we'll
+	// transition to queryNewChannels in order to request any channels we
+	// don't yet know of.
+	waitingQueryRangeReply
+
+	// queryNewChannels is the third main phase of the gossipSyncer.  In
+	// this phase we'll send out all of our QueryShortChanIDs messages in
+	// response to the new channels that we don't yet know about.
+	queryNewChannels
+
+	// waitingQueryChanReply is the fourth main phase of the gossipSyncer.
+	// We enter this phase once we've sent off a query chunk to the remote
+	// peer.  We'll stay in this phase until we receive a
+	// ReplyShortChanIDsEnd message which indicates that the remote party
+	// has responded to all of our requests.
+	waitingQueryChanReply
+
+	// chansSynced is the terminal stage of the gossipSyncer. Once we enter
+	// this phase, we'll send out our update horizon, which filters out the
+	// set of channel updates that we're interested in. In this state,
+	// we'll be able to accept any outgoing messages from the
+	// AuthenticatedGossiper, and decide if we should forward them to our
+	// target peer based on its update horizon.
+	chansSynced
+)
+
+// String returns a human readable string describing the target syncerState.
+func (s syncerState) String() string {
+	switch s {
+	case syncingChans:
+		return "syncingChans"
+
+	case waitingQueryRangeReply:
+		return "waitingQueryRangeReply"
+
+	case queryNewChannels:
+		return "queryNewChannels"
+
+	case waitingQueryChanReply:
+		return "waitingQueryChanReply"
+
+	case chansSynced:
+		return "chansSynced"
+
+	default:
+		return "UNKNOWN STATE"
+	}
+}
+
+var (
+	// encodingTypeToChunkSize maps an encoding type, to the max number of
+	// short chan ID's using the encoding type that we can fit into a
+	// single message safely. As the zlib encoding can expand
+	// incompressible input by a few bytes at most, the same limit applies
+	// to both encodings.
+	encodingTypeToChunkSize = map[lnwire.ShortChanIDEncoding]int32{
+		lnwire.EncodingSortedPlain: 8000,
+		lnwire.EncodingSortedZlib:  8000,
+	}
+
+	// ErrGossipSyncerExiting signals that the syncer has been killed.
+	ErrGossipSyncerExiting = errors.New("gossip syncer exiting")
+)
+
+const (
+	// chanRangeQueryBuffer is the number of blocks back that we'll go when
+	// asking the remote peer for any channels they know of beyond
+	// our highest known channel ID.
+	chanRangeQueryBuffer = 144
+)
+
+// gossipSyncerCfg is a struct that packages all the information a
+// gossipSyncer needs to carry out its duties.
+type gossipSyncerCfg struct {
+	// chainHash is the chain that this syncer is responsible for.
+	chainHash chainhash.Hash
+
+	// peerPub is the public key of the peer we're syncing with, serialized
+	// in compressed format.
+	peerPub routing.Vertex
+
+	// activeSync if true, indicates that we should actively reconcile our
+	// channel graph with the remote peer, by querying them for the
+	// channels we're missing. Once the initial sync is complete, we'll
+	// also request the peer to send us any new gossip they receive. If
+	// false, we'll only answer the queries of the remote peer.
+	activeSync bool
+
+	// channelSeries is the primary interface that we'll use to generate
+	// our queries and respond to the queries of the remote peer.
+	channelSeries ChannelGraphTimeSeries
+
+	// encodingType is the encoding type that we'll use for the set of
+	// short channel ID's within our own queries and replies.
+	encodingType lnwire.ShortChanIDEncoding
+
+	// chunkSize is the max number of short chan IDs using the syncer's
+	// encoding type that we can fit into a single message safely.
+	chunkSize int32
+
+	// sendToPeer is a function closure that should send the set of
+	// targeted messages to the peer we've been assigned to sync the graph
+	// state from.
+	sendToPeer func(...lnwire.Message) error
+}
+
+// gossipSyncer is a struct that handles synchronizing the channel graph state
+// with a remote peer. The gossipSyncer implements a state machine that will
+// progressively ensure we're synchronized with the channel state of the remote
+// node. Once both nodes have been synchronized, we'll use an update filter to
+// filter out which messages should be sent to a remote peer based on their
+// update horizon. If the update horizon isn't specified, then we won't send
+// them any channels updates at all.
+type gossipSyncer struct {
+	started uint32
+	stopped uint32
+
+	// state is the current state of the gossipSyncer.
+	//
+	// NOTE: This variable MUST be used atomically.
+	state uint32
+
+	// remoteUpdateHorizon is the update horizon of the remote peer. We'll
+	// use this to properly filter out any messages.
+	remoteUpdateHorizon *lnwire.GossipTimestampRange
+
+	// localUpdateHorizon is our local update horizon, we'll use this to
+	// determine if we've already sent out our update.
+	localUpdateHorizon *lnwire.GossipTimestampRange
+
+	// gossipMsgs is a channel that all replies to our queries from the
+	// target peer will be sent over.
+	gossipMsgs chan lnwire.Message
+
+	// queryMsgs is a channel that all queries from the target peer will
+	// be sent over. These are handled separately from the replies to our
+	// own queries, such that both peers are able to make progress on
+	// their syncing at the same time.
+	queryMsgs chan lnwire.Message
+
+	// bufferedChanRangeReplies is used in the waitingQueryRangeReply
+	// state to buffer all the chunked responses to our query.
+	bufferedChanRangeReplies []lnwire.ShortChannelID
+
+	// newChansToQuery is used to pass the set of channels we should query
+	// for from the waitingQueryRangeReply state to the queryNewChannels
+	// state.
+	newChansToQuery []lnwire.ShortChannelID
+
+	cfg gossipSyncerCfg
+
+	sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newGossiperSyncer returns a new instance of the gossipSyncer populated using
+// the passed config.
+func newGossiperSyncer(cfg gossipSyncerCfg) *gossipSyncer {
+	// Peers that we won't actively sync with start out in the terminal
+	// state, as we'll only ever answer their queries.
+	initialState := syncingChans
+	if !cfg.activeSync {
+		initialState = chansSynced
+	}
+
+	return &gossipSyncer{
+		cfg:        cfg,
+		state:      uint32(initialState),
+		gossipMsgs: make(chan lnwire.Message, 100),
+		queryMsgs:  make(chan lnwire.Message, 100),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Start starts the gossipSyncer and any goroutines that it needs to carry out
+// its duties.
+func (g *gossipSyncer) Start() error {
+	if !atomic.CompareAndSwapUint32(&g.started, 0, 1) {
+		return nil
+	}
+
+	log.Debugf("Starting gossipSyncer(%x)", g.cfg.peerPub[:])
+
+	g.wg.Add(2)
+	go g.channelGraphSyncer()
+	go g.replyHandler()
+
+	return nil
+}
+
+// Stop signals the gossipSyncer for a graceful exit, then waits until it has
+// exited.
+func (g *gossipSyncer) Stop() error {
+	if !atomic.CompareAndSwapUint32(&g.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Debugf("Stopping gossipSyncer(%x)", g.cfg.peerPub[:])
+
+	close(g.quit)
+	g.wg.Wait()
+
+	return nil
+}
+
+// channelGraphSyncer is the main goroutine responsible for ensuring that we
+// properly sync channel graph state with the remote peer, and also that we only
+// send them messages which actually pass their defined update horizon.
+//
+// NOTE: This MUST be run as a goroutine.
+func (g *gossipSyncer) channelGraphSyncer() {
+	defer g.wg.Done()
+
+	for {
+		state := g.syncState()
+		log.Debugf("gossipSyncer(%x): state=%v", g.cfg.peerPub[:],
+			state)
+
+		switch state {
+		// When we're in this state, we're trying to synchronize our
+		// view of the network with the remote peer. We'll kick off
+		// this sync by asking them for the set of channels they
+		// understand.
+		case syncingChans:
+			// If we're in this state, then we'll send the remote
+			// peer our opening QueryChannelRange message.
+			queryRangeMsg, err := g.genChanRangeQuery()
+			if err != nil {
+				log.Errorf("unable to gen chan range query: %v",
+					err)
+				return
+			}
+
+			err = g.cfg.sendToPeer(queryRangeMsg)
+			if err != nil {
+				log.Errorf("unable to send chan range query: %v",
+					err)
+				return
+			}
+
+			// With the message sent successfully, we'll transition
+			// into the next state where we wait for their reply.
+			g.setSyncState(waitingQueryRangeReply)
+
+		// In this state, we've sent out our initial channel range
+		// query and are waiting for the final response from the remote
+		// peer before we perform a diff to see which channels they know
+		// of that we don't.
+		case waitingQueryRangeReply:
+			select {
+			case msg := <-g.gossipMsgs:
+				// The remote peer is sending a response to our
+				// initial query, we'll collate this response,
+				// and see if it's the final one in the series.
+				// If so, we can then transition to querying
+				// for the new channels.
+				queryReply, ok := msg.(*lnwire.ReplyChannelRange)
+				if !ok {
+					log.Warnf("Unexpected message %T in "+
+						"state %v", msg, state)
+					continue
+				}
+
+				err := g.processChanRangeReply(queryReply)
+				if err != nil {
+					log.Errorf("unable to process chan "+
+						"range query: %v", err)
+					return
+				}
+
+			case <-g.quit:
+				return
+			}
+
+		// We'll enter this state once we've discovered which channels
+		// the remote party knows of that we don't yet know of
+		// ourselves.
+		case queryNewChannels:
+			// First, we'll attempt to continue our channel
+			// synchronization by continuing to send off another
+			// query chunk.
+			done, err := g.synchronizeChanIDs()
+			if err != nil {
+				log.Errorf("unable to sync chan IDs: %v", err)
+				return
+			}
+
+			// If this wasn't our last query, then we'll need to
+			// transition to our waiting state.
+			if !done {
+				g.setSyncState(waitingQueryChanReply)
+			}
+
+		// In this state, we've just sent off a new query for channels
+		// that we don't yet know of. We'll remain in this state until
+		// the remote party signals they've responded to our query in
+		// totality.
+		case waitingQueryChanReply:
+			// Once we've sent off our query, we'll wait for either
+			// an ending reply from the remote peer.
+			select {
+			case msg := <-g.gossipMsgs:
+				// If this is the final reply to one of our
+				// queries, then we'll loop back into our query
+				// state to send off the remaining query chunks.
+				_, ok := msg.(*lnwire.ReplyShortChanIDsEnd)
+				if !ok {
+					log.Warnf("Unexpected message %T in "+
+						"state %v", msg, state)
+					continue
+				}
+
+				g.setSyncState(queryNewChannels)
+
+			case <-g.quit:
+				return
+			}
+
+		// This is our final terminal state where we'll only reply to
+		// any further queries by the remote peer.
+		case chansSynced:
+			// If we're actively syncing with this peer, we'll send
+			// over our update horizon to ensure we receive any new
+			// updates from this point forward.
+			if g.cfg.activeSync {
+				if err := g.sendGossipTimestampRange(); err != nil {
+					log.Errorf("unable to send update "+
+						"horizon: %v", err)
+					return
+				}
+			}
+
+			// Any further replies from the remote peer are
+			// unexpected at this point, so we'll simply drain them
+			// until we exit.
+			for {
+				select {
+				case msg := <-g.gossipMsgs:
+					log.Warnf("Unexpected message %T in "+
+						"state %v", msg, state)
+
+				case <-g.quit:
+					return
+				}
+			}
+		}
+	}
+}
+
+// replyHandler is responsible for answering the queries of the remote peer.
+// This is done within its own goroutine, such that we're able to respond to
+// the remote peer while we're still waiting on the replies to our own
+// queries.
+//
+// NOTE: This MUST be run as a goroutine.
+func (g *gossipSyncer) replyHandler() {
+	defer g.wg.Done()
+
+	for {
+		select {
+		case msg := <-g.queryMsgs:
+			err := g.replyPeerQueries(msg)
+			switch {
+			case err == ErrGossipSyncerExiting:
+				return
+
+			case err != nil:
+				log.Errorf("unable to reply to peer query: %v",
+					err)
+			}
+
+		case <-g.quit:
+			return
+		}
+	}
+}
+
+// sendGossipTimestampRange constructs and sets a GossipTimestampRange for the
+// syncer and sends it to the remote peer. From this point on, the remote peer
+// will send us any new gossip it receives.
+func (g *gossipSyncer) sendGossipTimestampRange() error {
+	// We'll only request updates from this point forward, as we've
+	// already queried for the channels we were missing.
+	updateHorizon := &lnwire.GossipTimestampRange{
+		ChainHash:      g.cfg.chainHash,
+		FirstTimestamp: uint32(time.Now().Unix()),
+		TimestampRange: math.MaxUint32,
+	}
+
+	log.Infof("gossipSyncer(%x): requesting new updates from %v onwards",
+		g.cfg.peerPub[:],
+		time.Unix(int64(updateHorizon.FirstTimestamp), 0))
+
+	if err := g.cfg.sendToPeer(updateHorizon); err != nil {
+		return err
+	}
+
+	g.Lock()
+	g.localUpdateHorizon = updateHorizon
+	g.Unlock()
+
+	return nil
+}
+
+// synchronizeChanIDs is called by the channelGraphSyncer when we need to query
+// the remote peer for its known set of channel IDs within a particular block
+// range. This method will be called continually until the entire range has
+// been queried for with a response received. We'll chunk our requests as
+// required to ensure they fit into a single message. We may re-enter this
+// state in the case that chunking is required.
+func (g *gossipSyncer) synchronizeChanIDs() (bool, error) {
+	// If we're in this state yet there are no more new channels to query
+	// for, then we'll transition to our final synced state and return true
+	// to signal that we're fully synchronized.
+	if len(g.newChansToQuery) == 0 {
+		log.Infof("gossipSyncer(%x): no more chans to query",
+			g.cfg.peerPub[:])
+
+		g.setSyncState(chansSynced)
+		return true, nil
+	}
+
+	// Otherwise, we'll issue our next chunked query to receive replies
+	// for.
+	var queryChunk []lnwire.ShortChannelID
+
+	// If the number of channels to query for is less than the chunk size,
+	// then we can issue a single query.
+	if int32(len(g.newChansToQuery)) < g.cfg.chunkSize {
+		queryChunk = g.newChansToQuery
+		g.newChansToQuery = nil
+
+	} else {
+		// Otherwise, we'll need to only query for the next chunk.
+		// We'll slice into our query chunk, then slide down our main
+		// pointer down by the chunk size.
+		queryChunk = g.newChansToQuery[:g.cfg.chunkSize]
+		g.newChansToQuery = g.newChansToQuery[g.cfg.chunkSize:]
+	}
+
+	log.Infof("gossipSyncer(%x): querying for %v new channels",
+		g.cfg.peerPub[:], len(queryChunk))
+
+	// With our chunk obtained, we'll send over our next query, then return
+	// false indicating that we're not yet fully synced.
+	err := g.cfg.sendToPeer(&lnwire.QueryShortChanIDs{
+		ChainHash:    g.cfg.chainHash,
+		EncodingType: g.cfg.encodingType,
+		ShortChanIDs: queryChunk,
+	})
+
+	return false, err
+}
+
+// processChanRangeReply is called each time the gossipSyncer receives a new
+// reply to the initial range query to discover new channels that it didn't
+// previously know of.
+func (g *gossipSyncer) processChanRangeReply(msg *lnwire.ReplyChannelRange) error {
+	g.bufferedChanRangeReplies = append(
+		g.bufferedChanRangeReplies, msg.ShortChanIDs...,
+	)
+
+	log.Infof("gossipSyncer(%x): buffering chan range reply of size=%v",
+		g.cfg.peerPub[:], len(msg.ShortChanIDs))
+
+	// If this isn't the last response, then we can exit as we've already
+	// buffered the latest portion of the streaming reply.
+	if msg.Complete == 0 {
+		return nil
+	}
+
+	log.Infof("gossipSyncer(%x): filtering through %v chans",
+		g.cfg.peerPub[:], len(g.bufferedChanRangeReplies))
+
+	// Otherwise, this is the final response, so we'll now check to see
+	// which channels they know of that we don't.
+	newChans, err := g.cfg.channelSeries.FilterKnownChanIDs(
+		g.bufferedChanRangeReplies,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to filter chan ids: %v", err)
+	}
+
+	// As we've received the entirety of the reply, we no longer need to
+	// hold on to the set of buffered replies, so we'll let that be garbage
+	// collected now.
+	g.bufferedChanRangeReplies = nil
+
+	// If there aren't any channels that we don't know of, then we can
+	// switch straight to our terminal state.
+	if len(newChans) == 0 {
+		log.Infof("gossipSyncer(%x): remote peer has no new chans",
+			g.cfg.peerPub[:])
+
+		g.setSyncState(chansSynced)
+		return nil
+	}
+
+	// Otherwise, we'll set the set of channels that we need to query for
+	// the next state, and also transition our state.
+	g.newChansToQuery = newChans
+	g.setSyncState(queryNewChannels)
+
+	log.Infof("gossipSyncer(%x): starting query for %v new chans",
+		g.cfg.peerPub[:], len(newChans))
+
+	return nil
+}
+
+// genChanRangeQuery generates the initial message we'll send to the remote
+// party when we're kicking off the channel graph synchronization upon
+// connection.
+func (g *gossipSyncer) genChanRangeQuery() (*lnwire.QueryChannelRange, error) {
+	// First, we'll query our channel graph time series for its highest
+	// known channel ID.
+	newestChan, err := g.cfg.channelSeries.HighestChanID()
+	if err != nil {
+		return nil, err
+	}
+
+	// Once we have the chan ID of the newest, we'll obtain the block
+	// height of the channel, then subtract our default horizon to ensure
+	// we don't miss any channels. By default, we go back 1 day from the
+	// newest channel.
+	var startHeight uint32
+	switch {
+	case newestChan.BlockHeight <= chanRangeQueryBuffer:
+		startHeight = 0
+
+	default:
+		startHeight = newestChan.BlockHeight - chanRangeQueryBuffer
+	}
+
+	log.Infof("gossipSyncer(%x): requesting new chans from height=%v "+
+		"and %v blocks after", g.cfg.peerPub[:], startHeight,
+		math.MaxUint32-startHeight)
+
+	// Finally, we'll craft the channel range query, using our starting
+	// height, then asking for all known channels to the foreseeable end of
+	// the main chain.
+	return &lnwire.QueryChannelRange{
+		ChainHash:        g.cfg.chainHash,
+		FirstBlockHeight: startHeight,
+		NumBlocks:        math.MaxUint32 - startHeight,
+	}, nil
+}
+
+// replyPeerQueries is called in response to any query by the remote peer.
+// We'll examine our state and send back our best response.
+func (g *gossipSyncer) replyPeerQueries(msg lnwire.Message) error {
+	switch msg := msg.(type) {
+
+	// In this state, we'll also handle any incoming channel range queries
+	// from the remote peer as they're trying to sync their state as well.
+	case *lnwire.QueryChannelRange:
+		return g.replyChanRangeQuery(msg)
+
+	// If the remote peer skips straight to requesting new channels that
+	// they don't know of, then we'll ensure that we also handle this case.
+	case *lnwire.QueryShortChanIDs:
+		return g.replyShortChanIDs(msg)
+
+	default:
+		return fmt.Errorf("unknown message: %T", msg)
+	}
+}
+
+// replyChanRangeQuery will be dispatched in response to a channel range query
+// by the remote node. We'll query the channel time series for channels that
+// meet the channel range, then chunk our responses to the remote node. We also
+// ensure that our final fragment carries the "complete" bit to indicate the
+// end of our streaming response.
+func (g *gossipSyncer) replyChanRangeQuery(query *lnwire.QueryChannelRange) error {
+	// If the remote peer is querying a chain we don't know of, then we'll
+	// signal this by replying with the complete bit unset and no channels.
+	if query.ChainHash != g.cfg.chainHash {
+		log.Warnf("Remote peer requested channel range for unknown "+
+			"chain=%v", query.ChainHash)
+
+		return g.cfg.sendToPeer(&lnwire.ReplyChannelRange{
+			QueryChannelRange: *query,
+			Complete:          0,
+			EncodingType:      g.cfg.encodingType,
+		})
+	}
+
+	log.Infof("gossipSyncer(%x): filtering chan range: start_height=%v, "+
+		"num_blocks=%v", g.cfg.peerPub[:], query.FirstBlockHeight,
+		query.NumBlocks)
+
+	// Next, we'll consult the time series to obtain the set of known
+	// channel ID's that match their query.
+	startBlock := query.FirstBlockHeight
+	channelRange, err := g.cfg.channelSeries.FilterChannelRange(
+		startBlock, query.LastBlockHeight(),
+	)
+	if err != nil {
+		return err
+	}
+
+	numChannels := int32(len(channelRange))
+	numChansSent := int32(0)
+	for {
+		// We'll send our this response in a streaming manner,
+		// chunk-by-chunk. We do this as there's a transport message
+		// size limit which we'll need to adhere to.
+		var channelChunk []lnwire.ShortChannelID
+
+		// We'll calculate how many channels we have left to send
+		// before we'd run out.
+		numRemainingChans := numChannels - numChansSent
+
+		// If we have enough channels to fill up a full chunk, then
+		// we'll do so. Otherwise, this is the final chunk we'll send
+		// to the remote peer.
+		isFinalChunk := numRemainingChans <= g.cfg.chunkSize
+		if isFinalChunk {
+			channelChunk = channelRange[numChansSent:]
+		} else {
+			channelChunk = channelRange[numChansSent : numChansSent+
+				g.cfg.chunkSize]
+		}
+
+		log.Infof("gossipSyncer(%x): sending range chunk of size=%v",
+			g.cfg.peerPub[:], len(channelChunk))
+
+		// The final chunk of our response carries the complete bit,
+		// signalling the end of our streaming response.
+		var complete uint8
+		if isFinalChunk {
+			complete = 1
+		}
+
+		err := g.cfg.sendToPeer(&lnwire.ReplyChannelRange{
+			QueryChannelRange: *query,
+			Complete:          complete,
+			EncodingType:      g.cfg.encodingType,
+			ShortChanIDs:      channelChunk,
+		})
+		if err != nil {
+			return err
+		}
+
+		// If this was the final chunk, then we'll exit now as our
+		// response is now complete.
+		if isFinalChunk {
+			return nil
+		}
+
+		numChansSent += int32(len(channelChunk))
+
+		select {
+		case <-g.quit:
+			return ErrGossipSyncerExiting
+		default:
+		}
+	}
+}
+
+// replyShortChanIDs will be dispatched in response to a query by the remote
+// node for information concerning a set of short channel ID's. Our response
+// will be sent in a streaming chunked manner to ensure that we remain below
+// the current transport level message size.
+func (g *gossipSyncer) replyShortChanIDs(query *lnwire.QueryShortChanIDs) error {
+	// Before responding, we'll check to ensure that the remote peer is
+	// querying for the same chain that we're on. If not, we'll send back a
+	// response with a complete value of zero to indicate we're on a
+	// different chain.
+	if query.ChainHash != g.cfg.chainHash {
+		log.Warnf("Remote peer requested QueryShortChanIDs for "+
+			"chain=%v, we're on chain=%v", query.ChainHash,
+			g.cfg.chainHash)
+
+		return g.cfg.sendToPeer(&lnwire.ReplyShortChanIDsEnd{
+			ChainHash: query.ChainHash,
+			Complete:  0,
+		})
+	}
+
+	if len(query.ShortChanIDs) == 0 {
+		log.Infof("gossipSyncer(%x): ignoring query for blank short "+
+			"chan ID's", g.cfg.peerPub[:])
+		return nil
+	}
+
+	log.Infof("gossipSyncer(%x): fetching chan anns for %v chans",
+		g.cfg.peerPub[:], len(query.ShortChanIDs))
+
+	// Now that we know we're on the same chain, we'll query the channel
+	// time series for the set of messages that we know of which satisfies
+	// the requirement of being a chan ann, chan update, or a node ann
+	// related to the set of queried channels.
+	replyMsgs, err := g.cfg.channelSeries.FetchChanAnns(query.ShortChanIDs)
+	if err != nil {
+		return fmt.Errorf("unable to fetch chan anns for %v..., %v",
+			query.ShortChanIDs[0].ToUint64(), err)
+	}
+
+	// Finally, we'll send out all the announcements, followed by a
+	// ReplyShortChanIDsEnd message to signal that we've sent everything
+	// we know of in response to the query.
+	replyMsgs = append(replyMsgs, &lnwire.ReplyShortChanIDsEnd{
+		ChainHash: query.ChainHash,
+		Complete:  1,
+	})
+
+	return g.cfg.sendToPeer(replyMsgs...)
+}
+
+// ApplyGossipFilter applies a gossiper filter sent by the remote node to the
+// state machine. Once applied, we'll ensure that we don't forward any messages
+// to the peer that aren't within the time range of the filter.
+func (g *gossipSyncer) ApplyGossipFilter(filter *lnwire.GossipTimestampRange) error {
+	// Filters for chains other than our own are of no use to us, so we'll
+	// ignore them.
+	if filter.ChainHash != g.cfg.chainHash {
+		return fmt.Errorf("gossip filter for unknown chain=%v",
+			filter.ChainHash)
+	}
+
+	g.Lock()
+
+	g.remoteUpdateHorizon = filter
+
+	startTime := time.Unix(int64(g.remoteUpdateHorizon.FirstTimestamp), 0)
+	endTime := startTime.Add(
+		time.Duration(g.remoteUpdateHorizon.TimestampRange) * time.Second,
+	)
+
+	g.Unlock()
+
+	// Now that the remote peer has applied their filter, we'll query the
+	// database for all the messages that are beyond this filter.
+	newUpdatestoSend, err := g.cfg.channelSeries.UpdatesInHorizon(
+		startTime, endTime,
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("gossipSyncer(%x): applying new update horizon: start=%v, "+
+		"end=%v, backlog_size=%v", g.cfg.peerPub[:], startTime, endTime,
+		len(newUpdatestoSend))
+
+	// If we don't have any to send, then we can return early.
+	if len(newUpdatestoSend) == 0 {
+		return nil
+	}
+
+	// We'll conclude by launching a goroutine to send out any updates.
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		if err := g.cfg.sendToPeer(newUpdatestoSend...); err != nil {
+			log.Errorf("unable to send messages for peer catch "+
+				"up: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// FilterGossipMsgs takes a set of gossip messages, and only send it to a peer
+// iff the message is within the bounds of their set gossip filter. If the peer
+// doesn't have a gossip filter set, then no messages will be forwarded.
+func (g *gossipSyncer) FilterGossipMsgs(msgs ...msgWithSenders) {
+	// If the peer doesn't have an update horizon set, then we won't send
+	// it any new update messages.
+	g.Lock()
+	if g.remoteUpdateHorizon == nil {
+		g.Unlock()
+		return
+	}
+
+	// If we've been signalled to exit, or are exiting, then we'll stop
+	// short.
+	if atomic.LoadUint32(&g.stopped) == 1 {
+		g.Unlock()
+		return
+	}
+
+	// Now that we know we have an update horizon set, we'll filter out all
+	// messages that don't fall within the update horizon.
+	startTime := time.Unix(int64(g.remoteUpdateHorizon.FirstTimestamp), 0)
+	endTime := startTime.Add(
+		time.Duration(g.remoteUpdateHorizon.TimestampRange) * time.Second,
+	)
+
+	g.Unlock()
+
+	passesFilter := func(timeStamp uint32) bool {
+		t := time.Unix(int64(timeStamp), 0)
+		return !t.Before(startTime) && !t.After(endTime)
+	}
+
+	// We'll index the channel updates within the batch by their short
+	// channel ID, such that we can decide whether a channel announcement
+	// should be sent along based on its accompanying updates.
+	chanUpdateIndex := make(
+		map[lnwire.ShortChannelID][]*lnwire.ChannelUpdate,
+	)
+	for _, msg := range msgs {
+		chanUpdate, ok := msg.msg.(*lnwire.ChannelUpdate)
+		if !ok {
+			continue
+		}
+
+		chanUpdateIndex[chanUpdate.ShortChannelID] = append(
+			chanUpdateIndex[chanUpdate.ShortChannelID], chanUpdate,
+		)
+	}
+
+	msgsToSend := make([]lnwire.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		// If the target peer is the peer that sent us this message,
+		// then we'll exit early as we don't need to filter this
+		// message.
+		if _, ok := msg.senders[g.cfg.peerPub]; ok {
+			continue
+		}
+
+		switch msg := msg.msg.(type) {
+
+		// For each channel announcement message, we'll only send this
+		// message if the channel updates for the channel are between
+		// our time range. If the batch doesn't contain any updates for
+		// the channel, then the announcement is new to us, so we'll
+		// pass it along.
+		case *lnwire.ChannelAnnouncement:
+			chanUpdates, ok := chanUpdateIndex[msg.ShortChannelID]
+			if !ok {
+				msgsToSend = append(msgsToSend, msg)
+				continue
+			}
+
+			for _, chanUpdate := range chanUpdates {
+				if passesFilter(chanUpdate.Timestamp) {
+					msgsToSend = append(msgsToSend, msg)
+					break
+				}
+			}
+
+		// For each channel update, we'll only send it if the timestamp
+		// is between our time range.
+		case *lnwire.ChannelUpdate:
+			if passesFilter(msg.Timestamp) {
+				msgsToSend = append(msgsToSend, msg)
+			}
+
+		// Similarly, we only send node announcements if the update
+		// timestamp is between our set gossip filter time range.
+		case *lnwire.NodeAnnouncement:
+			if passesFilter(msg.Timestamp) {
+				msgsToSend = append(msgsToSend, msg)
+			}
+		}
+	}
+
+	log.Tracef("gossipSyncer(%x): filtered gossip msgs: set=%v, sent=%v",
+		g.cfg.peerPub[:], len(msgs), len(msgsToSend))
+
+	if len(msgsToSend) == 0 {
+		return
+	}
+
+	// As sending to the peer blocks until the messages have been written
+	// out, we'll do so within a goroutine to not hold up the broadcast to
+	// our other peers.
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		if err := g.cfg.sendToPeer(msgsToSend...); err != nil {
+			log.Errorf("unable to send gossip msgs: %v", err)
+		}
+	}()
+}
+
+// ProcessQueryMsg is used by outside callers to pass new channel time series
+// queries to the internal processing goroutine.
+func (g *gossipSyncer) ProcessQueryMsg(msg lnwire.Message) {
+	// Queries from the remote peer are handled by our reply handler, while
+	// the replies to our own queries drive our state machine forward.
+	msgChan := g.gossipMsgs
+	switch msg.(type) {
+	case *lnwire.QueryChannelRange, *lnwire.QueryShortChanIDs:
+		msgChan = g.queryMsgs
+	}
+
+	select {
+	case msgChan <- msg:
+	case <-g.quit:
+	}
+}
+
+// setSyncState sets the gossip syncer's state to the given state.
+func (g *gossipSyncer) setSyncState(state syncerState) {
+	atomic.StoreUint32(&g.state, uint32(state))
+}
+
+// syncState returns the current syncerState of the target gossipSyncer.
+func (g *gossipSyncer) syncState() syncerState {
+	return syncerState(atomic.LoadUint32(&g.state))
+}
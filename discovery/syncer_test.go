@@ -0,0 +1,984 @@
+package discovery
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/roasbeef/btcd/chaincfg"
+)
+
+const (
+	defaultEncoding = lnwire.EncodingSortedPlain
+)
+
+var (
+	defaultChainHash = *chaincfg.MainNetParams.GenesisHash
+)
+
+// horizonQuery couples the start and end time of a single UpdatesInHorizon
+// call made to the mockChannelGraphTimeSeries.
+type horizonQuery struct {
+	start time.Time
+	end   time.Time
+}
+
+// mockChannelGraphTimeSeries is a mock implementation of the
+// ChannelGraphTimeSeries interface, backed by a static set of channels and
+// messages. All the queries made to it are recorded, such that tests can
+// assert that they were made as expected.
+type mockChannelGraphTimeSeries struct {
+	sync.Mutex
+
+	highestID lnwire.ShortChannelID
+
+	// chans is the sorted set of channels the mock graph knows of.
+	chans []lnwire.ShortChannelID
+
+	// updates is the set of messages returned for any horizon query.
+	updates        []lnwire.Message
+	horizonQueries []horizonQuery
+
+	// chanAnns is the set of messages returned for any short channel ID
+	// query.
+	chanAnns []lnwire.Message
+	annReqs  [][]lnwire.ShortChannelID
+}
+
+func newMockChannelGraphTimeSeries(
+	hID lnwire.ShortChannelID) *mockChannelGraphTimeSeries {
+
+	return &mockChannelGraphTimeSeries{
+		highestID: hID,
+	}
+}
+
+func (m *mockChannelGraphTimeSeries) HighestChanID() (*lnwire.ShortChannelID,
+	error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	highestID := m.highestID
+	return &highestID, nil
+}
+
+func (m *mockChannelGraphTimeSeries) UpdatesInHorizon(startTime time.Time,
+	endTime time.Time) ([]lnwire.Message, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.horizonQueries = append(m.horizonQueries, horizonQuery{
+		start: startTime,
+		end:   endTime,
+	})
+
+	return m.updates, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FilterKnownChanIDs(
+	superSet []lnwire.ShortChannelID) ([]lnwire.ShortChannelID, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	knownChans := make(map[lnwire.ShortChannelID]struct{})
+	for _, chanID := range m.chans {
+		knownChans[chanID] = struct{}{}
+	}
+
+	var newChans []lnwire.ShortChannelID
+	for _, chanID := range superSet {
+		if _, ok := knownChans[chanID]; !ok {
+			newChans = append(newChans, chanID)
+		}
+	}
+
+	return newChans, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FilterChannelRange(startHeight,
+	endHeight uint32) ([]lnwire.ShortChannelID, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	var chansInRange []lnwire.ShortChannelID
+	for _, chanID := range m.chans {
+		if chanID.BlockHeight < startHeight ||
+			chanID.BlockHeight > endHeight {
+
+			continue
+		}
+
+		chansInRange = append(chansInRange, chanID)
+	}
+
+	return chansInRange, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FetchChanAnns(
+	shortChanIDs []lnwire.ShortChannelID) ([]lnwire.Message, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.annReqs = append(m.annReqs, shortChanIDs)
+
+	return m.chanAnns, nil
+}
+
+var _ ChannelGraphTimeSeries = (*mockChannelGraphTimeSeries)(nil)
+
+func newTestSyncer(hID lnwire.ShortChannelID, activeSync bool,
+	chunkSize int32) (chan []lnwire.Message, *gossipSyncer,
+	*mockChannelGraphTimeSeries) {
+
+	msgChan := make(chan []lnwire.Message, 20)
+	cfg := gossipSyncerCfg{
+		chainHash:    defaultChainHash,
+		activeSync:   activeSync,
+		encodingType: defaultEncoding,
+		chunkSize:    chunkSize,
+		sendToPeer: func(msgs ...lnwire.Message) error {
+			msgChan <- msgs
+			return nil
+		},
+	}
+	copy(cfg.peerPub[:], nodeKeyPub1.SerializeCompressed())
+
+	chanSeries := newMockChannelGraphTimeSeries(hID)
+	cfg.channelSeries = chanSeries
+
+	syncer := newGossiperSyncer(cfg)
+
+	return msgChan, syncer, chanSeries
+}
+
+// assertNoMsgSent asserts that the syncer doesn't send any messages to the
+// remote peer within a short period of time.
+func assertNoMsgSent(t *testing.T, msgChan chan []lnwire.Message) {
+	t.Helper()
+
+	select {
+	case msgs := <-msgChan:
+		t.Fatalf("expected no msgs to be sent, instead got: %v",
+			spew.Sdump(msgs))
+
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+// receiveMsgs waits for the syncer to send a set of messages to the remote
+// peer, and returns them.
+func receiveMsgs(t *testing.T, msgChan chan []lnwire.Message) []lnwire.Message {
+	t.Helper()
+
+	select {
+	case msgs := <-msgChan:
+		return msgs
+
+	case <-time.After(time.Second * 5):
+		t.Fatalf("no msgs sent by syncer")
+		return nil
+	}
+}
+
+// TestGossipSyncerFilterGossipMsgsNoHorizon tests that if the remote peer
+// doesn't have a horizon set, then we won't send any incoming messages to it.
+func TestGossipSyncerFilterGossipMsgsNoHorizon(t *testing.T) {
+	t.Parallel()
+
+	// First, we'll create a gossipSyncer instance with a canned sendToPeer
+	// message to allow us to intercept their potential sends.
+	msgChan, syncer, _ := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), true, 1000,
+	)
+
+	// With the syncer created, we'll create a set of messages to filter
+	// through the gossiper to the target peer.
+	msgs := []msgWithSenders{
+		{
+			msg: &lnwire.NodeAnnouncement{Timestamp: uint32(time.Now().Unix())},
+		},
+		{
+			msg: &lnwire.NodeAnnouncement{Timestamp: uint32(time.Now().Unix())},
+		},
+	}
+
+	// We'll then attempt to filter the set of messages through the target
+	// peer.
+	syncer.FilterGossipMsgs(msgs...)
+
+	// As the remote peer doesn't yet have a gossip timestamp set, we
+	// shouldn't receive any outbound messages.
+	assertNoMsgSent(t, msgChan)
+}
+
+// TestGossipSyncerFilterGossipMsgsAllInHorizon tests that we're able to
+// properly filter out a set of incoming messages based on the set remote
+// update horizon for a peer. We test all message types, messages on either
+// side of the horizon, as well as messages sent to us by the peer itself.
+func TestGossipSyncerFilterGossipMsgsAllInHorizon(t *testing.T) {
+	t.Parallel()
+
+	// First, we'll create a gossipSyncer instance with a canned sendToPeer
+	// message to allow us to intercept their potential sends.
+	msgChan, syncer, _ := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), true, 1000,
+	)
+
+	// We'll create then apply a remote horizon for the target peer with a
+	// set of manually selected timestamps.
+	remoteHorizon := &lnwire.GossipTimestampRange{
+		FirstTimestamp: 1000,
+		TimestampRange: 1000,
+	}
+	syncer.remoteUpdateHorizon = remoteHorizon
+
+	// With the syncer created, we'll create a set of messages to filter
+	// through the gossiper to the target peer. Our message will consist of
+	// one node announcement above the horizon, one below. Additionally,
+	// we'll include a chan ann with an update below the horizon, one with
+	// an update timestamp above the horizon, and one without any updates
+	// at all.
+	msgs := []msgWithSenders{
+		{
+			// Node ann above horizon.
+			msg: &lnwire.NodeAnnouncement{Timestamp: 2001},
+		},
+		{
+			// Node ann below horizon.
+			msg: &lnwire.NodeAnnouncement{Timestamp: 5},
+		},
+		{
+			// Node ann within horizon.
+			msg: &lnwire.NodeAnnouncement{Timestamp: 1500},
+		},
+		{
+			// Chan ann with an update below the horizon.
+			msg: &lnwire.ChannelAnnouncement{
+				ShortChannelID: lnwire.NewShortChanIDFromInt(10),
+			},
+		},
+		{
+			msg: &lnwire.ChannelUpdate{
+				ShortChannelID: lnwire.NewShortChanIDFromInt(10),
+				Timestamp:      5,
+			},
+		},
+		{
+			// Chan ann with an update within the horizon.
+			msg: &lnwire.ChannelAnnouncement{
+				ShortChannelID: lnwire.NewShortChanIDFromInt(15),
+			},
+		},
+		{
+			msg: &lnwire.ChannelUpdate{
+				ShortChannelID: lnwire.NewShortChanIDFromInt(15),
+				Timestamp:      1500,
+			},
+		},
+		{
+			// Chan ann without any accompanying updates.
+			msg: &lnwire.ChannelAnnouncement{
+				ShortChannelID: lnwire.NewShortChanIDFromInt(20),
+			},
+		},
+		{
+			// Node ann within horizon, but sent to us by the
+			// target peer itself.
+			msg: &lnwire.NodeAnnouncement{Timestamp: 1600},
+			senders: map[routing.Vertex]struct{}{
+				syncer.cfg.peerPub: {},
+			},
+		},
+	}
+
+	// The set of messages that should pass the filter are the node ann
+	// and the chan ann along with its update within the horizon, as well
+	// as the chan ann without any updates.
+	expectedMsgs := []lnwire.Message{
+		msgs[2].msg, msgs[5].msg, msgs[6].msg, msgs[7].msg,
+	}
+
+	// Now that we have our set of messages, we'll filter them through the
+	// target peer.
+	syncer.FilterGossipMsgs(msgs...)
+
+	sentMsgs := receiveMsgs(t, msgChan)
+	if !reflect.DeepEqual(sentMsgs, expectedMsgs) {
+		t.Fatalf("wrong msgs sent: expected %v, got %v",
+			spew.Sdump(expectedMsgs), spew.Sdump(sentMsgs))
+	}
+}
+
+// TestGossipSyncerApplyGossipFilter tests that once a gossip filter is applied
+// for the remote peer, then we send the peer all known messages which are
+// within their desired time horizon.
+func TestGossipSyncerApplyGossipFilter(t *testing.T) {
+	t.Parallel()
+
+	// First, we'll create a gossipSyncer instance with a canned sendToPeer
+	// message to allow us to intercept their potential sends.
+	msgChan, syncer, chanSeries := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), true, 1000,
+	)
+
+	// We'll apply a gossip filter for a chain other than our own, which
+	// should be rejected.
+	remoteHorizon := &lnwire.GossipTimestampRange{
+		FirstTimestamp: uint32(time.Now().Unix()),
+		TimestampRange: math.MaxUint32,
+	}
+	if err := syncer.ApplyGossipFilter(remoteHorizon); err == nil {
+		t.Fatalf("expected gossip filter for unknown chain to fail")
+	}
+
+	// Next, we'll apply a proper filter. As the graph doesn't have any
+	// updates within the horizon yet, no messages should be sent.
+	remoteHorizon.ChainHash = defaultChainHash
+	if err := syncer.ApplyGossipFilter(remoteHorizon); err != nil {
+		t.Fatalf("unable to apply filter: %v", err)
+	}
+	assertNoMsgSent(t, msgChan)
+
+	// The graph should have been queried using the horizon of the remote
+	// peer.
+	startTime := time.Unix(int64(remoteHorizon.FirstTimestamp), 0)
+	expectedQuery := horizonQuery{
+		start: startTime,
+		end: startTime.Add(
+			time.Duration(remoteHorizon.TimestampRange) * time.Second,
+		),
+	}
+	chanSeries.Lock()
+	if len(chanSeries.horizonQueries) != 1 {
+		t.Fatalf("expected 1 horizon query, got %v",
+			len(chanSeries.horizonQueries))
+	}
+	if !reflect.DeepEqual(chanSeries.horizonQueries[0], expectedQuery) {
+		t.Fatalf("wrong horizon query: expected %v, got %v",
+			expectedQuery, chanSeries.horizonQueries[0])
+	}
+
+	// Now we'll add a set of updates within the horizon to the graph.
+	updates := []lnwire.Message{
+		&lnwire.ChannelAnnouncement{
+			ShortChannelID: lnwire.NewShortChanIDFromInt(25),
+		},
+		&lnwire.ChannelUpdate{
+			ShortChannelID: lnwire.NewShortChanIDFromInt(25),
+			Timestamp:      remoteHorizon.FirstTimestamp,
+		},
+	}
+	chanSeries.updates = updates
+	chanSeries.Unlock()
+
+	// If the remote peer applies its filter once more, then the backlog
+	// of updates should be sent to it.
+	if err := syncer.ApplyGossipFilter(remoteHorizon); err != nil {
+		t.Fatalf("unable to apply filter: %v", err)
+	}
+
+	sentMsgs := receiveMsgs(t, msgChan)
+	if !reflect.DeepEqual(sentMsgs, updates) {
+		t.Fatalf("wrong msgs sent: expected %v, got %v",
+			spew.Sdump(updates), spew.Sdump(sentMsgs))
+	}
+}
+
+// TestGossipSyncerReplyShortChanIDs tests that in the case of a known chain
+// hash for a QueryShortChanIDs, we'll return the set of matching
+// announcements, as well as an ending ReplyShortChanIDsEnd message.
+func TestGossipSyncerReplyShortChanIDs(t *testing.T) {
+	t.Parallel()
+
+	// First, we'll create a gossipSyncer instance with a canned sendToPeer
+	// message to allow us to intercept their potential sends.
+	msgChan, syncer, chanSeries := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), true, 1000,
+	)
+
+	// If the remote peer queries for a chain we don't know of, then we
+	// should reply with a ReplyShortChanIDsEnd with the complete bit
+	// unset.
+	err := syncer.replyShortChanIDs(&lnwire.QueryShortChanIDs{
+		ShortChanIDs: []lnwire.ShortChannelID{
+			lnwire.NewShortChanIDFromInt(1),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to reply to query: %v", err)
+	}
+
+	sentMsgs := receiveMsgs(t, msgChan)
+	expectedEnd := &lnwire.ReplyShortChanIDsEnd{
+		Complete: 0,
+	}
+	if !reflect.DeepEqual(sentMsgs, []lnwire.Message{expectedEnd}) {
+		t.Fatalf("wrong msgs sent: expected %v, got %v",
+			spew.Sdump(expectedEnd), spew.Sdump(sentMsgs))
+	}
+
+	// Next, we'll query for a set of channels on our own chain. The
+	// announcements that the graph returns should be sent to the remote
+	// peer, followed by the ending message.
+	queryChans := []lnwire.ShortChannelID{
+		lnwire.NewShortChanIDFromInt(1),
+		lnwire.NewShortChanIDFromInt(2),
+		lnwire.NewShortChanIDFromInt(3),
+	}
+	queryReply := []lnwire.Message{
+		&lnwire.ChannelAnnouncement{
+			ShortChannelID: lnwire.NewShortChanIDFromInt(20),
+		},
+		&lnwire.ChannelUpdate{
+			ShortChannelID: lnwire.NewShortChanIDFromInt(20),
+			Timestamp:      uint32(time.Now().Unix()),
+		},
+		&lnwire.NodeAnnouncement{Timestamp: 10},
+	}
+	chanSeries.Lock()
+	chanSeries.chanAnns = queryReply
+	chanSeries.Unlock()
+
+	err = syncer.replyShortChanIDs(&lnwire.QueryShortChanIDs{
+		ChainHash:    defaultChainHash,
+		ShortChanIDs: queryChans,
+	})
+	if err != nil {
+		t.Fatalf("unable to reply to query: %v", err)
+	}
+
+	chanSeries.Lock()
+	if len(chanSeries.annReqs) != 1 {
+		t.Fatalf("expected 1 chan ann query, got %v",
+			len(chanSeries.annReqs))
+	}
+	if !reflect.DeepEqual(chanSeries.annReqs[0], queryChans) {
+		t.Fatalf("wrong chan ann query: expected %v, got %v",
+			queryChans, chanSeries.annReqs[0])
+	}
+	chanSeries.Unlock()
+
+	expectedMsgs := append(queryReply, &lnwire.ReplyShortChanIDsEnd{
+		ChainHash: defaultChainHash,
+		Complete:  1,
+	})
+	sentMsgs = receiveMsgs(t, msgChan)
+	if !reflect.DeepEqual(sentMsgs, expectedMsgs) {
+		t.Fatalf("wrong msgs sent: expected %v, got %v",
+			spew.Sdump(expectedMsgs), spew.Sdump(sentMsgs))
+	}
+}
+
+// TestGossipSyncerReplyChanRangeQuery tests that if we receive a
+// QueryChannelRange message, then we'll properly send back a chunked reply to
+// the remote peer.
+func TestGossipSyncerReplyChanRangeQuery(t *testing.T) {
+	t.Parallel()
+
+	// We'll use a smaller chunk size so we can easily test all the edge
+	// cases.
+	const chunkSize = 2
+
+	// First, we'll create a gossipSyncer instance with a canned sendToPeer
+	// message to allow us to intercept their potential sends.
+	msgChan, syncer, chanSeries := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), true, chunkSize,
+	)
+
+	// Next, we'll craft a query to ask for all the new chan ID's after
+	// block 100.
+	query := &lnwire.QueryChannelRange{
+		ChainHash:        defaultChainHash,
+		FirstBlockHeight: 100,
+		NumBlocks:        50,
+	}
+
+	// We'll then populate the graph with a set of channels, of which only
+	// the first five are within the query range.
+	resp := []lnwire.ShortChannelID{
+		{BlockHeight: 100},
+		{BlockHeight: 101},
+		{BlockHeight: 102},
+		{BlockHeight: 120},
+		{BlockHeight: 149},
+	}
+	chanSeries.Lock()
+	chanSeries.chans = append(
+		resp, lnwire.ShortChannelID{BlockHeight: 150},
+	)
+	chanSeries.Unlock()
+
+	// With our set up complete, we'll now attempt to reply to the query.
+	if err := syncer.replyChanRangeQuery(query); err != nil {
+		t.Fatalf("unable to issue query: %v", err)
+	}
+
+	// As our chunk size is two, and we have five channels in range, we
+	// should receive three replies, with only the last one having the
+	// complete bit set.
+	var respMsgs []lnwire.ShortChannelID
+	for i := 0; i < 3; i++ {
+		msgs := receiveMsgs(t, msgChan)
+		if len(msgs) != 1 {
+			t.Fatalf("expected 1 msg, got %v", len(msgs))
+		}
+
+		rangeResp, ok := msgs[0].(*lnwire.ReplyChannelRange)
+		if !ok {
+			t.Fatalf("expected ReplyChannelRange instead got %T",
+				msgs[0])
+		}
+
+		// Only the last message should have the complete bit set.
+		expectedComplete := uint8(0)
+		if i == 2 {
+			expectedComplete = 1
+		}
+		if rangeResp.Complete != expectedComplete {
+			t.Fatalf("reply #%v: expected complete=%v, got %v", i,
+				expectedComplete, rangeResp.Complete)
+		}
+
+		if rangeResp.QueryChannelRange != *query {
+			t.Fatalf("reply #%v doesn't include original query", i)
+		}
+
+		respMsgs = append(respMsgs, rangeResp.ShortChanIDs...)
+	}
+
+	// We should get back exactly the set of channels within the range.
+	if !reflect.DeepEqual(resp, respMsgs) {
+		t.Fatalf("mismatched response: expected %v, got %v",
+			spew.Sdump(resp), spew.Sdump(respMsgs))
+	}
+
+	// Finally, a query for a range we don't know of any channels within
+	// should result in a single, empty and complete reply.
+	query.FirstBlockHeight = 1000
+	if err := syncer.replyChanRangeQuery(query); err != nil {
+		t.Fatalf("unable to issue query: %v", err)
+	}
+
+	msgs := receiveMsgs(t, msgChan)
+	expectedReply := &lnwire.ReplyChannelRange{
+		QueryChannelRange: *query,
+		Complete:          1,
+		EncodingType:      defaultEncoding,
+	}
+	if !reflect.DeepEqual(msgs, []lnwire.Message{expectedReply}) {
+		t.Fatalf("wrong reply: expected %v, got %v",
+			spew.Sdump(expectedReply), spew.Sdump(msgs))
+	}
+}
+
+// TestGossipSyncerGenChanRangeQuery tests that given the current best known
+// channel ID, we properly generate a correct initial channel range response.
+func TestGossipSyncerGenChanRangeQuery(t *testing.T) {
+	t.Parallel()
+
+	// First, we'll create a gossipSyncer instance with a canned sendToPeer
+	// message to allow us to intercept their potential sends.
+	const startingHeight = 200
+	_, syncer, chanSeries := newTestSyncer(
+		lnwire.ShortChannelID{BlockHeight: startingHeight}, true, 1000,
+	)
+
+	// If we now ask the syncer to generate an initial range query, it
+	// should return a start height that's back chanRangeQueryBuffer
+	// blocks.
+	rangeQuery, err := syncer.genChanRangeQuery()
+	if err != nil {
+		t.Fatalf("unable to resp: %v", err)
+	}
+
+	firstHeight := uint32(startingHeight - chanRangeQueryBuffer)
+	if rangeQuery.FirstBlockHeight != firstHeight {
+		t.Fatalf("incorrect chan range query: expected %v, got %v",
+			firstHeight, rangeQuery.FirstBlockHeight)
+	}
+	if rangeQuery.NumBlocks != math.MaxUint32-firstHeight {
+		t.Fatalf("wrong num blocks: expected %v, got %v",
+			math.MaxUint32-firstHeight, rangeQuery.NumBlocks)
+	}
+
+	// If the highest channel we know of is within the buffer, then we
+	// should query for all channels starting from the genesis block.
+	chanSeries.Lock()
+	chanSeries.highestID = lnwire.ShortChannelID{
+		BlockHeight: chanRangeQueryBuffer - 1,
+	}
+	chanSeries.Unlock()
+
+	rangeQuery, err = syncer.genChanRangeQuery()
+	if err != nil {
+		t.Fatalf("unable to resp: %v", err)
+	}
+	if rangeQuery.FirstBlockHeight != 0 {
+		t.Fatalf("expected start height of zero, got %v",
+			rangeQuery.FirstBlockHeight)
+	}
+	if rangeQuery.NumBlocks != math.MaxUint32 {
+		t.Fatalf("wrong num blocks: expected %v, got %v",
+			uint32(math.MaxUint32), rangeQuery.NumBlocks)
+	}
+}
+
+// TestGossipSyncerProcessChanRangeReply tests that we'll properly buffer
+// channel range replies until we have the complete version. If no new
+// channels were discovered, then we should go directly to the chanSsSynced
+// state. Otherwise, we should go to the queryNewChannels states.
+func TestGossipSyncerProcessChanRangeReply(t *testing.T) {
+	t.Parallel()
+
+	// First, we'll create a gossipSyncer instance with a canned sendToPeer
+	// message to allow us to intercept their potential sends.
+	_, syncer, chanSeries := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), true, 1000,
+	)
+
+	startingState := syncer.syncState()
+
+	replies := []*lnwire.ReplyChannelRange{
+		{
+			ShortChanIDs: []lnwire.ShortChannelID{
+				lnwire.NewShortChanIDFromInt(10),
+			},
+		},
+		{
+			ShortChanIDs: []lnwire.ShortChannelID{
+				lnwire.NewShortChanIDFromInt(11),
+			},
+		},
+		{
+			Complete: 1,
+			ShortChanIDs: []lnwire.ShortChannelID{
+				lnwire.NewShortChanIDFromInt(12),
+			},
+		},
+	}
+
+	// We'll begin by sending the syncer a set of non-complete channel
+	// range replies.
+	if err := syncer.processChanRangeReply(replies[0]); err != nil {
+		t.Fatalf("unable to process reply: %v", err)
+	}
+	if err := syncer.processChanRangeReply(replies[1]); err != nil {
+		t.Fatalf("unable to process reply: %v", err)
+	}
+
+	// At this point, we should still be in our starting state as the
+	// query hasn't finished.
+	if syncer.syncState() != startingState {
+		t.Fatalf("state should not have transitioned")
+	}
+
+	// We'll let the graph know of the second channel, such that only the
+	// remaining two are considered new.
+	chanSeries.Lock()
+	chanSeries.chans = []lnwire.ShortChannelID{
+		lnwire.NewShortChanIDFromInt(11),
+	}
+	chanSeries.Unlock()
+
+	// If we send the final message, then we should transition to
+	// queryNewChannels as we've sent a non-empty set of new channels.
+	if err := syncer.processChanRangeReply(replies[2]); err != nil {
+		t.Fatalf("unable to process reply: %v", err)
+	}
+
+	if syncer.syncState() != queryNewChannels {
+		t.Fatalf("wrong state: expected %v instead got %v",
+			queryNewChannels, syncer.syncState())
+	}
+
+	expectedNewChans := []lnwire.ShortChannelID{
+		lnwire.NewShortChanIDFromInt(10),
+		lnwire.NewShortChanIDFromInt(12),
+	}
+	if !reflect.DeepEqual(syncer.newChansToQuery, expectedNewChans) {
+		t.Fatalf("new chans not set properly: %v vs %v",
+			spew.Sdump(syncer.newChansToQuery),
+			spew.Sdump(expectedNewChans))
+	}
+
+	// If the remote peer only knows of channels we already know of, then
+	// we should transition directly to the chansSynced state.
+	syncer.setSyncState(waitingQueryRangeReply)
+	err := syncer.processChanRangeReply(&lnwire.ReplyChannelRange{
+		Complete: 1,
+		ShortChanIDs: []lnwire.ShortChannelID{
+			lnwire.NewShortChanIDFromInt(11),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to process reply: %v", err)
+	}
+	if syncer.syncState() != chansSynced {
+		t.Fatalf("wrong state: expected %v instead got %v",
+			chansSynced, syncer.syncState())
+	}
+}
+
+// TestGossipSyncerSynchronizeChanIDs tests that we properly request chunks of
+// the short chan ID's which were unknown to us. We'll ensure that we request
+// chunk by chunk, and after the last chunk, we return true indicating that we
+// can transition to the synced stage.
+func TestGossipSyncerSynchronizeChanIDs(t *testing.T) {
+	t.Parallel()
+
+	// We'll modify the chunk size to be a smaller value, so we can ensure
+	// our chunk parsing works properly. With this value we should get 3
+	// queries: two full chunks, and one lingering chunk.
+	const chunkSize = 2
+
+	// First, we'll create a gossipSyncer instance with a canned sendToPeer
+	// message to allow us to intercept their potential sends.
+	msgChan, syncer, _ := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), true, chunkSize,
+	)
+
+	// Next, we'll construct a set of chan ID's that we should query for,
+	// and set them as newChansToQuery within the state machine.
+	newChanIDs := []lnwire.ShortChannelID{
+		lnwire.NewShortChanIDFromInt(1),
+		lnwire.NewShortChanIDFromInt(2),
+		lnwire.NewShortChanIDFromInt(3),
+		lnwire.NewShortChanIDFromInt(4),
+		lnwire.NewShortChanIDFromInt(5),
+	}
+	syncer.newChansToQuery = newChanIDs
+
+	for i := 0; i < chunkSize*2; i += 2 {
+		// With our set up complete, we'll request a sync of chan ID's.
+		done, err := syncer.synchronizeChanIDs()
+		if err != nil {
+			t.Fatalf("unable to sync chan IDs: %v", err)
+		}
+
+		// At this point, we shouldn't yet be done as only 2 items
+		// should have been queried for.
+		if done {
+			t.Fatalf("syncer shown as done, but shouldn't be!")
+		}
+
+		// We should've received a new message from the syncer.
+		msgs := receiveMsgs(t, msgChan)
+		queryMsg, ok := msgs[0].(*lnwire.QueryShortChanIDs)
+		if !ok {
+			t.Fatalf("expected QueryShortChanIDs instead got %T",
+				msgs[0])
+		}
+
+		// The query message should have queried for the first two
+		// chan ID's, and nothing more.
+		if !reflect.DeepEqual(queryMsg.ShortChanIDs,
+			newChanIDs[i:i+chunkSize]) {
+
+			t.Fatalf("wrong query: expected %v, got %v",
+				spew.Sdump(newChanIDs[i:i+chunkSize]),
+				queryMsg.ShortChanIDs)
+		}
+
+		// With the proper message sent out, the internal state of the
+		// syncer should reflect that it still has more channels to
+		// query for.
+		if !reflect.DeepEqual(syncer.newChansToQuery,
+			newChanIDs[i+chunkSize:]) {
+
+			t.Fatalf("incorrect chans to query for: expected %v, "+
+				"got %v", spew.Sdump(newChanIDs[i+chunkSize:]),
+				syncer.newChansToQuery)
+		}
+	}
+
+	// At this point, only one more channel should be lingering for the
+	// syncer to query for.
+	if !reflect.DeepEqual(newChanIDs[chunkSize*2:], syncer.newChansToQuery) {
+		t.Fatalf("wrong chans to query: expected %v, got %v",
+			newChanIDs[chunkSize*2:], syncer.newChansToQuery)
+	}
+
+	// If we issue another query, the syncer should query for the final
+	// lingering channel.
+	done, err := syncer.synchronizeChanIDs()
+	if err != nil {
+		t.Fatalf("unable to sync chan IDs: %v", err)
+	}
+	if done {
+		t.Fatalf("syncer shown as done, but shouldn't be!")
+	}
+
+	msgs := receiveMsgs(t, msgChan)
+	queryMsg, ok := msgs[0].(*lnwire.QueryShortChanIDs)
+	if !ok {
+		t.Fatalf("expected QueryShortChanIDs instead got %T", msgs[0])
+	}
+
+	// The query issued should simply be the last item.
+	if !reflect.DeepEqual(queryMsg.ShortChanIDs, newChanIDs[chunkSize*2:]) {
+		t.Fatalf("wrong query: expected %v, got %v",
+			spew.Sdump(newChanIDs[chunkSize*2:]),
+			queryMsg.ShortChanIDs)
+	}
+
+	// There also should be no more channels to query.
+	if len(syncer.newChansToQuery) != 0 {
+		t.Fatalf("should be no more chans to query for, instead have %v",
+			spew.Sdump(syncer.newChansToQuery))
+	}
+
+	// With no more channels left to query for, the next call should
+	// signal that we're done, and transition to the synced state.
+	done, err = syncer.synchronizeChanIDs()
+	if err != nil {
+		t.Fatalf("unable to sync chan IDs: %v", err)
+	}
+	if !done {
+		t.Fatalf("syncer should be finished!")
+	}
+	if syncer.syncState() != chansSynced {
+		t.Fatalf("wrong state: expected %v instead got %v",
+			chansSynced, syncer.syncState())
+	}
+}
+
+// TestGossipSyncerRoutineSync tests all state transitions of the main syncer
+// goroutine. This ensures that given an encounter with a peer that has a set
+// of distinct channels, then we'll properly synchronize our channel state with
+// them.
+func TestGossipSyncerRoutineSync(t *testing.T) {
+	t.Parallel()
+
+	// We'll modify the chunk size to be a smaller value, so we can ensure
+	// our chunk parsing works properly. With this value we should get 3
+	// queries: two full chunks, and one lingering chunk.
+	const chunkSize = 2
+
+	// First, we'll create two gossipSyncer instances with a canned
+	// sendToPeer message to allow us to intercept their potential sends.
+	// Only the first one will actively sync with the other.
+	highestID := lnwire.ShortChannelID{
+		BlockHeight: 1144,
+	}
+	msgChan1, syncer1, chanSeries1 := newTestSyncer(
+		highestID, true, chunkSize,
+	)
+	msgChan2, syncer2, chanSeries2 := newTestSyncer(
+		highestID, false, chunkSize,
+	)
+
+	// The first syncer only knows of a subset of the channels the second
+	// one knows of.
+	chanSeries1.chans = []lnwire.ShortChannelID{
+		{BlockHeight: 1000},
+	}
+	chanSeries2.chans = []lnwire.ShortChannelID{
+		{BlockHeight: 1000},
+		{BlockHeight: 1001},
+		{BlockHeight: 1002},
+		{BlockHeight: 1003},
+		{BlockHeight: 1004},
+		{BlockHeight: 1005},
+	}
+	chanSeries2.chanAnns = []lnwire.Message{
+		&lnwire.ChannelAnnouncement{},
+	}
+
+	if err := syncer1.Start(); err != nil {
+		t.Fatalf("unable to start syncer: %v", err)
+	}
+	defer syncer1.Stop()
+	if err := syncer2.Start(); err != nil {
+		t.Fatalf("unable to start syncer: %v", err)
+	}
+	defer syncer2.Stop()
+
+	// deliverMsgs delivers the messages sent by one syncer to the other,
+	// as a peer would after receiving them.
+	deliverMsgs := func(msgs []lnwire.Message, target *gossipSyncer) {
+		for _, msg := range msgs {
+			switch msg := msg.(type) {
+			case *lnwire.GossipTimestampRange:
+				err := target.ApplyGossipFilter(msg)
+				if err != nil {
+					t.Fatalf("unable to apply filter: %v",
+						err)
+				}
+
+			case *lnwire.QueryShortChanIDs,
+				*lnwire.QueryChannelRange,
+				*lnwire.ReplyChannelRange,
+				*lnwire.ReplyShortChanIDsEnd:
+
+				target.ProcessQueryMsg(msg)
+			}
+		}
+	}
+
+	// We'll now shuttle the messages between both syncers, until the
+	// first one has fully synced, and sent its update horizon to the
+	// second.
+	timeout := time.After(time.Second * 10)
+	for syncer2.remoteHorizon() == nil {
+		select {
+		case msgs := <-msgChan1:
+			deliverMsgs(msgs, syncer2)
+
+		case msgs := <-msgChan2:
+			deliverMsgs(msgs, syncer1)
+
+		case <-timeout:
+			t.Fatalf("syncers didn't sync, state=%v",
+				syncer1.syncState())
+		}
+	}
+
+	if syncer1.syncState() != chansSynced {
+		t.Fatalf("wrong state: expected %v instead got %v",
+			chansSynced, syncer1.syncState())
+	}
+
+	// The first syncer should have queried the second for all of the
+	// channels it didn't know of, in chunks.
+	expectedQueries := [][]lnwire.ShortChannelID{
+		chanSeries2.chans[1:3],
+		chanSeries2.chans[3:5],
+		chanSeries2.chans[5:],
+	}
+	chanSeries2.Lock()
+	annReqs := chanSeries2.annReqs
+	chanSeries2.Unlock()
+	if !reflect.DeepEqual(annReqs, expectedQueries) {
+		t.Fatalf("wrong chan queries: expected %v, got %v",
+			spew.Sdump(expectedQueries), spew.Sdump(annReqs))
+	}
+
+	// As the second syncer isn't actively syncing, it should never have
+	// sent a query of its own, nor have requested any updates.
+	if syncer2.syncState() != chansSynced {
+		t.Fatalf("wrong state: expected %v instead got %v",
+			chansSynced, syncer2.syncState())
+	}
+	if syncer1.remoteHorizon() != nil {
+		t.Fatalf("passive syncer shouldn't send an update horizon")
+	}
+}
+
+// remoteHorizon returns the update horizon of the remote peer of the syncer,
+// if it has been set.
+func (g *gossipSyncer) remoteHorizon() *lnwire.GossipTimestampRange {
+	g.Lock()
+	defer g.Unlock()
+
+	return g.remoteUpdateHorizon
+}
@@ -102,6 +102,30 @@ func createChanAnnouncement(chanProof *channeldb.ChannelAuthProof,
 	return chanAnn, edge1Ann, edge2Ann, nil
 }
 
+// makeNodeAnn is a helper function which re-creates the authenticated node
+// announcement of the passed node from the information stored within the
+// database.
+func makeNodeAnn(node *channeldb.LightningNode) (*lnwire.NodeAnnouncement,
+	error) {
+
+	alias, _ := lnwire.NewNodeAlias(node.Alias)
+
+	wireSig, err := lnwire.NewSigFromRawSignature(node.AuthSigBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnwire.NodeAnnouncement{
+		Signature: wireSig,
+		Timestamp: uint32(node.LastUpdate.Unix()),
+		Addresses: node.Addresses,
+		NodeID:    node.PubKeyBytes,
+		Features:  node.Features.RawFeatureVector,
+		RGBColor:  node.Color,
+		Alias:     alias,
+	}, nil
+}
+
 // copyPubKey performs a copy of the target public key, setting a fresh curve
 // parameter during the process.
 func copyPubKey(pub *btcec.PublicKey) *btcec.PublicKey {
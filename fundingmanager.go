@@ -16,6 +16,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chanacceptor"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/keychain"
@@ -25,6 +26,7 @@ import (
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 )
@@ -37,11 +39,15 @@ const (
 	// accepted within the Lightning Protocol Currently. This limit is
 	// currently defined in BOLT-0002, and serves as an initial
 	// precautionary limit while implementations are battle tested in the
-	// real world.
-	//
-	// TODO(roasbeef): add command line param to modify
+	// real world. Larger channels can only be made with peers that signal
+	// the wumbo channels feature bit.
 	maxFundingAmount = btcutil.Amount(1 << 24)
 
+	// maxWumboFundingAmount is the default maximum channel size used when
+	// large channels have been enabled, but no explicit limit has been
+	// configured.
+	maxWumboFundingAmount = btcutil.Amount(10 * btcutil.SatoshiPerBitcoin)
+
 	// minBtcRemoteDelay and maxBtcRemoteDelay is the extremes of the
 	// Bitcoin CSV delay we will require the remote to use for its
 	// commitment transaction. The actual delay we will require will be
@@ -66,6 +72,14 @@ const (
 	minChanFundingSize = btcutil.Amount(20000)
 )
 
+var (
+	// ErrFundingManagerShuttingDown is returned to callers of the funding
+	// manager if it's shutting down before their request could be
+	// processed.
+	ErrFundingManagerShuttingDown = errors.New("funding manager shutting " +
+		"down")
+)
+
 // reservationWithCtx encapsulates a pending channel reservation. This wrapper
 // struct is used internally within the funding manager to track and progress
 // the funding workflow initiated by incoming/outgoing methods from the target
@@ -86,6 +100,14 @@ type reservationWithCtx struct {
 
 	updates chan *lnrpc.OpenStatusUpdate
 	err     chan error
+
+	// batch is the batch funding workflow this reservation is a part of,
+	// if any.
+	batch *batchFundingCtx
+
+	// psbtFunding denotes whether the funding transaction of the
+	// reservation will be crafted by an external wallet through a PSBT.
+	psbtFunding bool
 }
 
 // isLocked checks the reservation's timestamp to determine whether it is locked.
@@ -122,6 +144,58 @@ func (r *reservationWithCtx) updateTimestamp() {
 type initFundingMsg struct {
 	peerAddress *lnwire.NetAddress
 	*openChanReq
+
+	// batch is the batch funding workflow the channel will be opened
+	// within, if any.
+	batch *batchFundingCtx
+}
+
+// initBatchFundingMsg is sent by an outside subsystem to the funding manager
+// in order to kick off a batch funding workflow, opening several channels
+// which are all funded by a single funding transaction. Either all of the
+// channels are opened, or none of them are.
+type initBatchFundingMsg struct {
+	// channels holds the parameters of each channel to be opened within
+	// the batch, along with the peer it should be opened with.
+	channels []*initFundingMsg
+
+	// fundingFeePerVSize is the fee rate used for the shared funding
+	// transaction.
+	fundingFeePerVSize lnwallet.SatPerVByte
+
+	// chanPoints is used to deliver the channel points of the pending
+	// channels, in the order of their requests, once the funding
+	// transaction has been broadcast.
+	chanPoints chan []*wire.OutPoint
+	err        chan error
+}
+
+// batchFundingCtx tracks the progress of each of the reservations within a
+// batch funding workflow. As the funding transaction can only be created once
+// every remote peer has accepted their channel, and only be broadcast once
+// every remote peer has signed our commitment transaction, each reservation
+// signals the batch once it reaches either of these steps.
+type batchFundingCtx struct {
+	batch *lnwallet.FundingBatch
+
+	// pendingChanIDs and reservations hold the pending channel ID and
+	// context of each reservation within the batch, in the order they
+	// were created.
+	pendingChanIDs [][32]byte
+	reservations   []*reservationWithCtx
+
+	// accepted is sent upon once a reservation within the batch has
+	// processed the contribution of the remote peer.
+	accepted chan struct{}
+
+	// signed is sent upon once a reservation within the batch has
+	// received the signature of the remote peer for our commitment
+	// transaction.
+	signed chan struct{}
+
+	// err is shared by all reservations within the batch, and is sent
+	// upon if any of them fail.
+	err chan error
 }
 
 // fundingOpenMsg couples an lnwire.OpenChannel message with the peer who sent
@@ -130,6 +204,19 @@ type initFundingMsg struct {
 type fundingOpenMsg struct {
 	msg         *lnwire.OpenChannel
 	peerAddress *lnwire.NetAddress
+
+	// accepted is set once the channel acceptors have accepted the
+	// channel, such that they aren't consulted again when the message is
+	// handed back to the funding manager.
+	accepted bool
+}
+
+// chanAcceptorResultMsg carries the decision of the channel acceptors about
+// the channel proposed by an lnwire.OpenChannel message back to the funding
+// manager. A nil error indicates that the channel was accepted.
+type chanAcceptorResultMsg struct {
+	openMsg *fundingOpenMsg
+	err     error
 }
 
 // fundingAcceptMsg couples an lnwire.AcceptChannel message with the peer who
@@ -171,6 +258,15 @@ type fundingErrorMsg struct {
 	peerAddress *lnwire.NetAddress
 }
 
+// psbtFundingMsg is sent by an outside subsystem to the funding manager in
+// order to resume the funding workflow of a PSBT funded channel, once the
+// funding transaction has been crafted and signed by an external wallet.
+type psbtFundingMsg struct {
+	pendingChanID [32]byte
+	fundingTx     *wire.MsgTx
+	err           chan error
+}
+
 // pendingChannels is a map instantiated per-peer which tracks all active
 // pending single funded channels indexed by their pending channel identifier,
 // which is a set of 32-bytes generated via a CSPRNG.
@@ -189,6 +285,52 @@ func newSerializedKey(pubKey *btcec.PublicKey) serializedPubKey {
 	return s
 }
 
+// scaledNumConfs returns the number of confirmations we require for a channel
+// of the given size, with the given amount pushed to us, to be considered
+// open. The number is scaled linearly between 3 and 6 confirmations depending
+// on our stake in the channel, reaching 6 confirmations at maxFundingAmount.
+// Wumbo channels above that size always require 6 confirmations.
+func scaledNumConfs(chanAmt btcutil.Amount,
+	pushAmt lnwire.MilliSatoshi) uint16 {
+
+	// TODO(halseth): Use 1 as minimum?
+	minConf := uint64(3)
+	maxConf := uint64(6)
+
+	// Wumbo channels would otherwise exceed the maximum number of
+	// confirmations, so we'll clamp them to it right away.
+	stake := lnwire.NewMSatFromSatoshis(chanAmt) + pushAmt
+	maxChannelSize := lnwire.NewMSatFromSatoshis(maxFundingAmount)
+	if stake >= maxChannelSize {
+		return uint16(maxConf)
+	}
+
+	conf := maxConf * uint64(stake) / uint64(maxChannelSize)
+	if conf < minConf {
+		conf = minConf
+	}
+	return uint16(conf)
+}
+
+// scaledRemoteDelay returns the CSV delay we require the remote party to use
+// for a channel of the given size. The delay is scaled linearly from minDelay
+// blocks for small channels to maxDelay blocks for channels of
+// maxFundingAmount. Wumbo channels above that size always use maxDelay.
+func scaledRemoteDelay(chanAmt btcutil.Amount, minDelay,
+	maxDelay uint16) uint16 {
+
+	// The scaled delay of wumbo channels can exceed the range of a
+	// uint16, so we'll clamp it to the maximum before converting it.
+	delay := btcutil.Amount(maxDelay) * chanAmt / maxFundingAmount
+	if delay < btcutil.Amount(minDelay) {
+		return minDelay
+	}
+	if delay > btcutil.Amount(maxDelay) {
+		return maxDelay
+	}
+	return uint16(delay)
+}
+
 // fundingConfig defines the configuration for the FundingManager. All elements
 // within the configuration MUST be non-nil for the FundingManager to carry out
 // its duties.
@@ -315,6 +457,27 @@ type fundingConfig struct {
 	// ReservationTimeout is the length of idle time that must pass before a
 	// reservation is considered a zombie.
 	ReservationTimeout time.Duration
+
+	// MaxChanSize is the largest channel that we'll open or accept. Only
+	// peers that signal support for the wumbo channels feature bit may
+	// have channels larger than maxFundingAmount with us.
+	MaxChanSize btcutil.Amount
+
+	// AnchorCommitments denotes whether we should use the anchor
+	// commitment format for new channels with peers that signal support
+	// for it.
+	AnchorCommitments bool
+
+	// OpenChannelPredicate is a predicate on the lnwire.OpenChannel message
+	// and on the requesting node's public key that returns nil if the
+	// inbound channel should be accepted.
+	OpenChannelPredicate chanacceptor.ChannelAcceptor
+
+	// UpfrontShutdownScript generates the script we commit to pay our
+	// funds to upon a cooperative close of an inbound channel. It's only
+	// used with peers that signal support for upfront shutdown scripts.
+	// If nil, then we won't commit to any script for inbound channels.
+	UpfrontShutdownScript func() (lnwire.DeliveryAddress, error)
 }
 
 // fundingManager acts as an orchestrator/bridge between the wallet's
@@ -371,6 +534,10 @@ type fundingManager struct {
 	// requests from a local subsystem within the daemon.
 	fundingRequests chan *initFundingMsg
 
+	// batchFundingRequests is a channel used to receive batch channel
+	// initiation requests from a local subsystem within the daemon.
+	batchFundingRequests chan *initBatchFundingMsg
+
 	// newChanBarriers is a map from a channel ID to a 'barrier' which will
 	// be signalled once the channel is fully open. This barrier acts as a
 	// synchronization point for any incoming/outgoing HTLCs before the
@@ -434,6 +601,7 @@ func newFundingManager(cfg fundingConfig) (*fundingManager, error) {
 		newChanBarriers:             make(map[lnwire.ChannelID]chan struct{}),
 		fundingMsgs:                 make(chan interface{}, msgBufferSize),
 		fundingRequests:             make(chan *initFundingMsg, msgBufferSize),
+		batchFundingRequests:        make(chan *initBatchFundingMsg, msgBufferSize),
 		localDiscoverySignals:       make(map[lnwire.ChannelID]chan struct{}),
 		handleFundingLockedBarriers: make(map[lnwire.ChannelID]struct{}),
 		queries:                     make(chan interface{}, 1),
@@ -481,7 +649,7 @@ func (f *fundingManager) Start() error {
 		// already broadcast this transaction. Otherwise, we simply log
 		// the error as there isn't anything we can currently do to
 		// recover.
-		if channel.ChanType == channeldb.SingleFunder &&
+		if channel.ChanType.IsSingleFunder() &&
 			channel.IsInitiator {
 
 			err := f.cfg.PublishTransaction(channel.FundingTxn)
@@ -776,6 +944,10 @@ func (f *fundingManager) failFundingFlow(peer *btcec.PublicKey,
 	case lnwallet.ReservationError:
 		msg = lnwire.ErrorData(e.Error())
 
+	// Let the reason for rejecting the channel be sent to the remote.
+	case *chanacceptor.RejectError:
+		msg = lnwire.ErrorData(e.Error())
+
 	// Send the status code.
 	case lnwire.ErrorCode:
 		msg = lnwire.ErrorData{byte(e)}
@@ -823,6 +995,8 @@ func (f *fundingManager) reservationCoordinator() {
 			switch fmsg := msg.(type) {
 			case *fundingOpenMsg:
 				f.handleFundingOpen(fmsg)
+			case *chanAcceptorResultMsg:
+				f.handleChanAcceptorResult(fmsg)
 			case *fundingAcceptMsg:
 				f.handleFundingAccept(fmsg)
 			case *fundingCreatedMsg:
@@ -834,10 +1008,15 @@ func (f *fundingManager) reservationCoordinator() {
 				go f.handleFundingLocked(fmsg)
 			case *fundingErrorMsg:
 				f.handleErrorMsg(fmsg)
+			case *psbtFundingMsg:
+				f.handlePsbtFunding(fmsg)
 			}
 		case req := <-f.fundingRequests:
 			f.handleInitFundingMsg(req)
 
+		case req := <-f.batchFundingRequests:
+			f.handleInitBatchFundingMsg(req)
+
 		case <-zombieSweepTicker.C:
 			f.pruneZombieReservations()
 
@@ -887,12 +1066,53 @@ func (f *fundingManager) processFundingOpen(msg *lnwire.OpenChannel,
 	peerAddress *lnwire.NetAddress) {
 
 	select {
-	case f.fundingMsgs <- &fundingOpenMsg{msg, peerAddress}:
+	case f.fundingMsgs <- &fundingOpenMsg{msg: msg, peerAddress: peerAddress}:
 	case <-f.quit:
 		return
 	}
 }
 
+// consultChanAcceptors asks the channel acceptors whether the channel proposed
+// by the passed message should be accepted, and hands their decision back to
+// the reservationCoordinator.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *fundingManager) consultChanAcceptors(fmsg *fundingOpenMsg) {
+	defer f.wg.Done()
+
+	chanReq := &chanacceptor.ChannelAcceptRequest{
+		Node:        fmsg.peerAddress.IdentityKey,
+		OpenChanMsg: fmsg.msg,
+	}
+	err := f.cfg.OpenChannelPredicate.Accept(chanReq)
+
+	select {
+	case f.fundingMsgs <- &chanAcceptorResultMsg{fmsg, err}:
+	case <-f.quit:
+	}
+}
+
+// handleChanAcceptorResult continues the funding flow of a channel once the
+// channel acceptors have accepted it, or relays the reason for its rejection
+// to the remote peer otherwise.
+func (f *fundingManager) handleChanAcceptorResult(res *chanAcceptorResultMsg) {
+	fmsg := res.openMsg
+	if res.err != nil {
+		fndgLog.Infof("Channel acceptor rejected pendingId=%x from "+
+			"peer(%x): %v", fmsg.msg.PendingChannelID,
+			fmsg.peerAddress.IdentityKey.SerializeCompressed(),
+			res.err)
+		f.failFundingFlow(
+			fmsg.peerAddress.IdentityKey, fmsg.msg.PendingChannelID,
+			res.err,
+		)
+		return
+	}
+
+	fmsg.accepted = true
+	f.handleFundingOpen(fmsg)
+}
+
 // handleFundingOpen creates an initial 'ChannelReservation' within the wallet,
 // then responds to the source peer with an accept channel message progressing
 // the funding workflow.
@@ -935,14 +1155,26 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	}
 
 	// We'll reject any request to create a channel that's above the
-	// current soft-limit for channel size.
-	if msg.FundingAmount > maxFundingAmount {
+	// maximum channel size we're willing to have with this peer.
+	if msg.FundingAmount > f.maxChanSize(fmsg.peerAddress.IdentityKey) {
 		f.failFundingFlow(
 			fmsg.peerAddress.IdentityKey, fmsg.msg.PendingChannelID,
 			lnwire.ErrChanTooLarge)
 		return
 	}
 
+	// Finally, we'll consult the channel acceptors to ensure the channel
+	// is one we're willing to accept. As they may take a while to come to
+	// a decision, we'll wait for it within its own goroutine, so that we
+	// don't stall the funding flows of other channels in the meantime.
+	// Once the channel is accepted, this message will be handled once
+	// more, re-checking the conditions above as they may have changed.
+	if !fmsg.accepted {
+		f.wg.Add(1)
+		go f.consultChanAcceptors(fmsg)
+		return
+	}
+
 	fndgLog.Infof("Recv'd fundingRequest(amt=%v, push=%v, delay=%v, "+
 		"pendingId=%x) from peer(%x)", amt, msg.PushAmount,
 		msg.CsvDelay, msg.PendingChannelID,
@@ -959,6 +1191,7 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		lnwallet.SatPerKWeight(msg.FeePerKiloWeight), 0,
 		fmsg.peerAddress.IdentityKey, fmsg.peerAddress.Address,
 		&chainHash, msg.ChannelFlags,
+		f.anchorCommitments(fmsg.peerAddress.IdentityKey),
 	)
 	if err != nil {
 		fndgLog.Errorf("Unable to initialize reservation: %v", err)
@@ -1061,6 +1294,29 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		return
 	}
 
+	// If the initiator committed to an upfront shutdown script, then we'll
+	// record it so that we can reject any cooperative close that attempts
+	// to pay their funds elsewhere.
+	reservation.SetRemoteShutdownScript(msg.UpfrontShutdownScript)
+
+	// If we're configured to commit to an upfront shutdown script
+	// ourselves, then we'll do so as long as the initiator understands
+	// the commitment, as it otherwise won't be enforced.
+	var shutdownScript lnwire.DeliveryAddress
+	peerKey := fmsg.peerAddress.IdentityKey
+	if f.cfg.UpfrontShutdownScript != nil &&
+		f.checkUpfrontShutdownSupport(peerKey) == nil {
+
+		shutdownScript, err = f.cfg.UpfrontShutdownScript()
+		if err != nil {
+			fndgLog.Errorf("unable to generate upfront shutdown "+
+				"script: %v", err)
+			f.failFundingFlow(peerKey, msg.PendingChannelID, err)
+			return
+		}
+		reservation.SetLocalShutdownScript(shutdownScript)
+	}
+
 	fndgLog.Infof("Sending fundingResp for pendingID(%x)",
 		msg.PendingChannelID)
 	fndgLog.Debugf("Remote party accepted commitment constraints: %v",
@@ -1070,20 +1326,21 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	// contribution in the next message of the workflow.
 	ourContribution := reservation.OurContribution()
 	fundingAccept := lnwire.AcceptChannel{
-		PendingChannelID:     msg.PendingChannelID,
-		DustLimit:            ourContribution.DustLimit,
-		MaxValueInFlight:     maxValue,
-		ChannelReserve:       chanReserve,
-		MinAcceptDepth:       uint32(numConfsReq),
-		HtlcMinimum:          ourContribution.MinHTLC,
-		CsvDelay:             remoteCsvDelay,
-		MaxAcceptedHTLCs:     maxHtlcs,
-		FundingKey:           ourContribution.MultiSigKey.PubKey,
-		RevocationPoint:      ourContribution.RevocationBasePoint.PubKey,
-		PaymentPoint:         ourContribution.PaymentBasePoint.PubKey,
-		DelayedPaymentPoint:  ourContribution.DelayBasePoint.PubKey,
-		HtlcPoint:            ourContribution.HtlcBasePoint.PubKey,
-		FirstCommitmentPoint: ourContribution.FirstCommitmentPoint,
+		PendingChannelID:      msg.PendingChannelID,
+		DustLimit:             ourContribution.DustLimit,
+		MaxValueInFlight:      maxValue,
+		ChannelReserve:        chanReserve,
+		MinAcceptDepth:        uint32(numConfsReq),
+		HtlcMinimum:           ourContribution.MinHTLC,
+		CsvDelay:              remoteCsvDelay,
+		MaxAcceptedHTLCs:      maxHtlcs,
+		FundingKey:            ourContribution.MultiSigKey.PubKey,
+		RevocationPoint:       ourContribution.RevocationBasePoint.PubKey,
+		PaymentPoint:          ourContribution.PaymentBasePoint.PubKey,
+		DelayedPaymentPoint:   ourContribution.DelayBasePoint.PubKey,
+		HtlcPoint:             ourContribution.HtlcBasePoint.PubKey,
+		FirstCommitmentPoint:  ourContribution.FirstCommitmentPoint,
+		UpfrontShutdownScript: shutdownScript,
 	}
 	err = f.cfg.SendToPeer(fmsg.peerAddress.IdentityKey, &fundingAccept)
 	if err != nil {
@@ -1180,6 +1437,12 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		},
 	}
 	remoteContribution.CsvDelay = f.cfg.RequiredRemoteDelay(resCtx.chanAmt)
+
+	// If the responder committed to an upfront shutdown script, then we'll
+	// record it so that we can reject any cooperative close that attempts
+	// to pay their funds elsewhere.
+	resCtx.reservation.SetRemoteShutdownScript(msg.UpfrontShutdownScript)
+
 	err = resCtx.reservation.ProcessContribution(remoteContribution)
 	if err != nil {
 		fndgLog.Errorf("Unable to process contribution from %v: %v",
@@ -1195,6 +1458,158 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 	fndgLog.Debugf("Remote party accepted commitment constraints: %v",
 		spew.Sdump(remoteContribution.ChannelConfig.ChannelConstraints))
 
+	// If this reservation is part of a batch, then the funding transaction
+	// can only be created once every peer within the batch has accepted
+	// their channel, so we'll hand control over to the batch.
+	if resCtx.batch != nil {
+		resCtx.batch.accepted <- struct{}{}
+		return
+	}
+
+	// If the funding transaction will be crafted by an external wallet,
+	// then we'll pause the funding flow here, and hand the caller a PSBT
+	// describing the funding output. The flow is resumed once the signed
+	// funding transaction is supplied through processPsbtFunding.
+	if resCtx.psbtFunding {
+		err := f.sendPsbtFundingUpdate(resCtx, pendingChanID)
+		if err != nil {
+			f.failFundingFlow(fmsg.peerAddress.IdentityKey,
+				msg.PendingChannelID, err)
+			resCtx.err <- err
+		}
+		return
+	}
+
+	err = f.sendFundingCreated(resCtx, pendingChanID)
+	if err != nil {
+		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
+			msg.PendingChannelID, err)
+		resCtx.err <- err
+		return
+	}
+}
+
+// sendPsbtFundingUpdate sends the caller of a PSBT funded reservation a PSBT
+// template containing the funding output of the channel, which must be funded
+// and signed by an external wallet for the funding flow to resume.
+func (f *fundingManager) sendPsbtFundingUpdate(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) error {
+
+	packet, err := resCtx.reservation.FundingPsbt()
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		return err
+	}
+
+	fundingOutput := packet.UnsignedTx.TxOut[0]
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		fundingOutput.PkScript, &f.cfg.Wallet.Cfg.NetParams,
+	)
+	if err != nil {
+		return err
+	}
+	if len(addrs) != 1 {
+		return fmt.Errorf("unable to derive address of funding output")
+	}
+
+	fndgLog.Infof("Awaiting psbt funding of %v to %v for pendingID(%x)",
+		btcutil.Amount(fundingOutput.Value), addrs[0], pendingChanID[:])
+
+	resCtx.updates <- &lnrpc.OpenStatusUpdate{
+		Update: &lnrpc.OpenStatusUpdate_PsbtFund{
+			PsbtFund: &lnrpc.ReadyForPsbtFunding{
+				PendingChanId:  pendingChanID[:],
+				FundingAddress: addrs[0].EncodeAddress(),
+				FundingAmount:  fundingOutput.Value,
+				Psbt:           b.Bytes(),
+			},
+		},
+	}
+
+	return nil
+}
+
+// processPsbtFunding sends a message to the funding manager instructing it to
+// resume the funding workflow of the PSBT funded reservation with the given
+// pending channel ID, using the passed funding transaction crafted by an
+// external wallet. If the funding transaction is rejected, then the funding
+// workflow isn't aborted, allowing a corrected transaction to be supplied.
+func (f *fundingManager) processPsbtFunding(pendingChanID [32]byte,
+	fundingTx *wire.MsgTx) error {
+
+	errChan := make(chan error, 1)
+	msg := &psbtFundingMsg{
+		pendingChanID: pendingChanID,
+		fundingTx:     fundingTx,
+		err:           errChan,
+	}
+
+	select {
+	case f.fundingMsgs <- msg:
+	case <-f.quit:
+		return ErrFundingManagerShuttingDown
+	}
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-f.quit:
+		return ErrFundingManagerShuttingDown
+	}
+}
+
+// handlePsbtFunding processes the funding transaction of a PSBT funded
+// reservation, and resumes its funding workflow by sending the funding
+// outpoint, along with our signature for the remote party's commitment
+// transaction, to the remote peer.
+func (f *fundingManager) handlePsbtFunding(msg *psbtFundingMsg) {
+	pendingChanID := msg.pendingChanID
+
+	resCtx, err := f.getReservationCtxByID(pendingChanID)
+	if err != nil {
+		msg.err <- err
+		return
+	}
+	if !resCtx.psbtFunding {
+		msg.err <- fmt.Errorf("pendingID(%x) isn't funded through a "+
+			"psbt", pendingChanID[:])
+		return
+	}
+
+	// Update the timestamp once the psbtFundingMsg has been handled.
+	defer resCtx.updateTimestamp()
+
+	err = resCtx.reservation.ProcessPsbtFunding(msg.fundingTx)
+	if err != nil {
+		fndgLog.Errorf("Unable to process psbt funding tx for "+
+			"pendingID(%x): %v", pendingChanID[:], err)
+		msg.err <- err
+		return
+	}
+
+	err = f.sendFundingCreated(resCtx, pendingChanID)
+	if err != nil {
+		f.failFundingFlow(resCtx.peerAddress.IdentityKey,
+			pendingChanID, err)
+		resCtx.err <- err
+		msg.err <- err
+		return
+	}
+
+	msg.err <- nil
+}
+
+// sendFundingCreated sends the funding outpoint, along with our signature for
+// the remote party's version of the commitment transaction, to the remote peer
+// of the given reservation. The funding transaction of the reservation MUST
+// have been created before calling this method.
+func (f *fundingManager) sendFundingCreated(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) error {
+
 	// Now that we have their contribution, we can extract, then send over
 	// both the funding out point and our signature for their version of
 	// the commitment transaction to the remote peer.
@@ -1226,22 +1641,20 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		PendingChannelID: pendingChanID,
 		FundingPoint:     *outPoint,
 	}
-	fundingCreated.CommitSig, err = lnwire.NewSigFromRawSignature(sig)
+	commitSig, err := lnwire.NewSigFromRawSignature(sig)
 	if err != nil {
 		fndgLog.Errorf("Unable to parse signature: %v", err)
-		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
-			msg.PendingChannelID, err)
-		resCtx.err <- err
-		return
+		return err
 	}
-	err = f.cfg.SendToPeer(fmsg.peerAddress.IdentityKey, fundingCreated)
+	fundingCreated.CommitSig = commitSig
+
+	err = f.cfg.SendToPeer(resCtx.peerAddress.IdentityKey, fundingCreated)
 	if err != nil {
 		fndgLog.Errorf("Unable to send funding complete message: %v", err)
-		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
-			msg.PendingChannelID, err)
-		resCtx.err <- err
-		return
+		return err
 	}
+
+	return nil
 }
 
 // processFundingCreated queues a funding complete message coupled with the
@@ -1492,12 +1905,35 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 	completeChan, err := resCtx.reservation.CompleteReservation(nil, commitSig)
 	if err != nil {
 		fndgLog.Errorf("Unable to complete reservation sign complete: %v", err)
-		resCtx.err <- err
 		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
 			pendingChanID, err)
+		resCtx.err <- err
+		return
+	}
+
+	// If this reservation is part of a batch, then the channel will only
+	// be committed, and the funding transaction broadcast, once every
+	// peer within the batch has signed, so we'll hand control over to the
+	// batch.
+	if resCtx.batch != nil {
+		resCtx.batch.signed <- struct{}{}
 		return
 	}
 
+	f.finalizeFundingFlow(resCtx, pendingChanID, completeChan)
+}
+
+// finalizeFundingFlow hands the pending channel of a completed reservation
+// over to the ChainArbitrator, notifies the caller that the channel is
+// pending, and then waits for the funding transaction to confirm before
+// announcing the channel. The funding transaction MUST have been broadcast
+// before calling this method.
+func (f *fundingManager) finalizeFundingFlow(resCtx *reservationWithCtx,
+	pendingChanID [32]byte, completeChan *channeldb.OpenChannel) {
+
+	peerKey := resCtx.peerAddress.IdentityKey
+	fundingPoint := completeChan.FundingOutpoint
+
 	// Now that we have a finalized reservation for this funding flow,
 	// we'll send the to be active channel to the ChainArbitrator so it can
 	// watch for any on-chin actions before the channel has fully
@@ -2460,6 +2896,65 @@ func (f *fundingManager) initFundingWorkflow(peerAddress *lnwire.NetAddress,
 	}
 }
 
+// maxChanSize returns the largest channel that we're willing to open or
+// accept with the given peer. Channels above maxFundingAmount are only
+// permitted if the peer has signalled support for large channels.
+func (f *fundingManager) maxChanSize(peerKey *btcec.PublicKey) btcutil.Amount {
+	if f.cfg.MaxChanSize <= maxFundingAmount {
+		return f.cfg.MaxChanSize
+	}
+
+	peer, err := f.cfg.FindPeer(peerKey)
+	if err != nil {
+		return maxFundingAmount
+	}
+
+	wumbo := lnwire.WumboChannelsOptional
+	if !peer.remoteLocalFeatures.HasFeature(wumbo) {
+		return maxFundingAmount
+	}
+
+	return f.cfg.MaxChanSize
+}
+
+// anchorCommitments returns whether a new channel with the given peer should
+// make use of the anchor commitment format. This is only the case if both we
+// and the peer signal support for anchor commitments, in which case both sides
+// of the funding flow arrive at the same conclusion.
+func (f *fundingManager) anchorCommitments(peerKey *btcec.PublicKey) bool {
+	if !f.cfg.AnchorCommitments {
+		return false
+	}
+
+	peer, err := f.cfg.FindPeer(peerKey)
+	if err != nil {
+		return false
+	}
+
+	anchors := lnwire.AnchorOutputsOptional
+	return peer.remoteLocalFeatures.HasFeature(anchors)
+}
+
+// checkUpfrontShutdownSupport returns an error if the peer identified by the
+// passed public key hasn't signalled that it understands upfront shutdown
+// scripts.
+func (f *fundingManager) checkUpfrontShutdownSupport(
+	peerKey *btcec.PublicKey) error {
+
+	peer, err := f.cfg.FindPeer(peerKey)
+	if err != nil {
+		return err
+	}
+
+	upfront := lnwire.UpfrontShutdownScriptOptional
+	if !peer.remoteLocalFeatures.HasFeature(upfront) {
+		return fmt.Errorf("peer %x doesn't support upfront shutdown "+
+			"scripts", peerKey.SerializeCompressed())
+	}
+
+	return nil
+}
+
 // handleInitFundingMsg creates a channel reservation within the daemon's
 // wallet, then sends a funding request to the remote peer kicking off the
 // funding workflow.
@@ -2479,6 +2974,24 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		msg.pushAmt, capacity, msg.chainHash, msg.peerAddress.Address,
 		ourDustLimit)
 
+	// We'll only open channels above the protocol's soft-limit for
+	// channel size with peers that have signalled support for them.
+	if maxChanSize := f.maxChanSize(peerKey); capacity > maxChanSize {
+		msg.err <- fmt.Errorf("funding amount is too large, the max "+
+			"channel size with this peer is: %v", maxChanSize)
+		return
+	}
+
+	// If we're committing to an upfront shutdown script, then we'll need
+	// to ensure that the remote peer understands the commitment, otherwise
+	// it won't be enforced.
+	if len(msg.shutdownScript) > 0 {
+		if err := f.checkUpfrontShutdownSupport(peerKey); err != nil {
+			msg.err <- err
+			return
+		}
+	}
+
 	// First, we'll query the fee estimator for a fee that should get the
 	// commitment transaction confirmed by the next few blocks (conf target
 	// of 3). We target the near blocks here to ensure that we'll be able
@@ -2503,12 +3016,32 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 
 	// Initialize a funding reservation with the local wallet. If the
 	// wallet doesn't have enough funds to commit to this channel, then the
-	// request will fail, and be aborted.
-	reservation, err := f.cfg.Wallet.InitChannelReservation(
-		capacity, localAmt, msg.pushAmt, commitFeePerKw,
-		msg.fundingFeePerVSize, peerKey, msg.peerAddress.Address,
-		&msg.chainHash, channelFlags,
-	)
+	// request will fail, and be aborted. If the channel is part of a
+	// batch, then coin selection will instead be performed once the
+	// entire batch is funded. If the channel will be funded by an external
+	// wallet, then no coin selection is performed at all.
+	anchors := f.anchorCommitments(peerKey)
+
+	var reservation *lnwallet.ChannelReservation
+	if msg.batch != nil {
+		reservation, err = msg.batch.batch.InitChannelReservation(
+			capacity, localAmt, msg.pushAmt, commitFeePerKw,
+			peerKey, msg.peerAddress.Address, &msg.chainHash,
+			channelFlags, anchors,
+		)
+	} else if msg.psbtFunding {
+		reservation, err = f.cfg.Wallet.InitPsbtChannelReservation(
+			capacity, localAmt, msg.pushAmt, commitFeePerKw,
+			peerKey, msg.peerAddress.Address, &msg.chainHash,
+			channelFlags, anchors,
+		)
+	} else {
+		reservation, err = f.cfg.Wallet.InitChannelReservation(
+			capacity, localAmt, msg.pushAmt, commitFeePerKw,
+			msg.fundingFeePerVSize, peerKey, msg.peerAddress.Address,
+			&msg.chainHash, channelFlags, anchors,
+		)
+	}
 	if err != nil {
 		msg.err <- err
 		return
@@ -2536,10 +3069,17 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		peerAddress: msg.peerAddress,
 		updates:     msg.updates,
 		err:         msg.err,
+		batch:       msg.batch,
+		psbtFunding: msg.psbtFunding,
 	}
 	f.activeReservations[peerIDKey][chanID] = resCtx
 	f.resMtx.Unlock()
 
+	if msg.batch != nil {
+		msg.batch.pendingChanIDs = append(msg.batch.pendingChanIDs, chanID)
+		msg.batch.reservations = append(msg.batch.reservations, resCtx)
+	}
+
 	// Update the timestamp once the initFundingMsg has been handled.
 	defer resCtx.updateTimestamp()
 
@@ -2558,6 +3098,7 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	// Once the reservation has been created, and indexed, queue a funding
 	// request to the remote peer, kicking off the funding workflow.
 	reservation.RegisterMinHTLC(minHtlc)
+	reservation.SetLocalShutdownScript(msg.shutdownScript)
 	ourContribution := reservation.OurContribution()
 
 	// Finally, we'll use the current value of the channels and our default
@@ -2571,24 +3112,25 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		msg.peerAddress.Address, chanID)
 
 	fundingOpen := lnwire.OpenChannel{
-		ChainHash:            *f.cfg.Wallet.Cfg.NetParams.GenesisHash,
-		PendingChannelID:     chanID,
-		FundingAmount:        capacity,
-		PushAmount:           msg.pushAmt,
-		DustLimit:            ourContribution.DustLimit,
-		MaxValueInFlight:     maxValue,
-		ChannelReserve:       chanReserve,
-		HtlcMinimum:          ourContribution.MinHTLC,
-		FeePerKiloWeight:     uint32(commitFeePerKw),
-		CsvDelay:             remoteCsvDelay,
-		MaxAcceptedHTLCs:     maxHtlcs,
-		FundingKey:           ourContribution.MultiSigKey.PubKey,
-		RevocationPoint:      ourContribution.RevocationBasePoint.PubKey,
-		PaymentPoint:         ourContribution.PaymentBasePoint.PubKey,
-		HtlcPoint:            ourContribution.HtlcBasePoint.PubKey,
-		DelayedPaymentPoint:  ourContribution.DelayBasePoint.PubKey,
-		FirstCommitmentPoint: ourContribution.FirstCommitmentPoint,
-		ChannelFlags:         channelFlags,
+		ChainHash:             *f.cfg.Wallet.Cfg.NetParams.GenesisHash,
+		PendingChannelID:      chanID,
+		FundingAmount:         capacity,
+		PushAmount:            msg.pushAmt,
+		DustLimit:             ourContribution.DustLimit,
+		MaxValueInFlight:      maxValue,
+		ChannelReserve:        chanReserve,
+		HtlcMinimum:           ourContribution.MinHTLC,
+		FeePerKiloWeight:      uint32(commitFeePerKw),
+		CsvDelay:              remoteCsvDelay,
+		MaxAcceptedHTLCs:      maxHtlcs,
+		FundingKey:            ourContribution.MultiSigKey.PubKey,
+		RevocationPoint:       ourContribution.RevocationBasePoint.PubKey,
+		PaymentPoint:          ourContribution.PaymentBasePoint.PubKey,
+		HtlcPoint:             ourContribution.HtlcBasePoint.PubKey,
+		DelayedPaymentPoint:   ourContribution.DelayBasePoint.PubKey,
+		FirstCommitmentPoint:  ourContribution.FirstCommitmentPoint,
+		ChannelFlags:          channelFlags,
+		UpfrontShutdownScript: msg.shutdownScript,
 	}
 	if err := f.cfg.SendToPeer(peerKey, &fundingOpen); err != nil {
 		e := fmt.Errorf("Unable to send funding request message: %v",
@@ -2606,6 +3148,176 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	}
 }
 
+// initBatchFundingWorkflow sends a message to the funding manager instructing
+// it to initiate a batch funding workflow, opening a channel with each of the
+// requested peers within a single funding transaction.
+func (f *fundingManager) initBatchFundingWorkflow(req *initBatchFundingMsg) {
+	f.batchFundingRequests <- req
+}
+
+// handleInitBatchFundingMsg kicks off the funding workflow of each of the
+// channels within a batch, then launches a goroutine which drives the batch to
+// completion once every peer has responded.
+func (f *fundingManager) handleInitBatchFundingMsg(msg *initBatchFundingMsg) {
+	numChans := len(msg.channels)
+
+	fndgLog.Infof("Initiating batch funding of %v channels", numChans)
+
+	// All of the reservations within the batch share a single error
+	// channel. We'll make sure it's large enough that failing
+	// reservations never block the funding manager.
+	batchCtx := &batchFundingCtx{
+		batch:    f.cfg.Wallet.NewFundingBatch(msg.fundingFeePerVSize),
+		accepted: make(chan struct{}, numChans),
+		signed:   make(chan struct{}, numChans),
+		err:      make(chan error, 2*numChans),
+	}
+
+	for _, chanMsg := range msg.channels {
+		// As the caller is only notified once the funding transaction
+		// has been broadcast, the updates of each channel are buffered
+		// so they never block the funding workflow.
+		chanMsg.batch = batchCtx
+		chanMsg.updates = make(chan *lnrpc.OpenStatusUpdate, 2)
+		chanMsg.err = batchCtx.err
+
+		f.handleInitFundingMsg(chanMsg)
+
+		// If we were unable to kick off the funding workflow for this
+		// channel, then there's no point in continuing, as the entire
+		// batch will be aborted.
+		if len(batchCtx.err) != 0 {
+			break
+		}
+	}
+
+	// If any of the channels failed to kick off their funding workflow,
+	// then we'll fail the batch now rather than driving it, as the batch
+	// may not hold the failed reservation, or any reservation at all.
+	select {
+	case err := <-batchCtx.err:
+		f.failBatchFundingFlow(batchCtx, err)
+		msg.err <- err
+		return
+	default:
+	}
+
+	f.wg.Add(1)
+	go f.driveBatchFunding(batchCtx, msg)
+}
+
+// driveBatchFunding waits for every peer within the batch to accept their
+// channel, funds the batch, and then waits for every peer to sign our
+// commitment transaction before broadcasting the shared funding transaction.
+// If any of the reservations within the batch fail, including by being pruned
+// as a zombie, then every reservation within the batch is cancelled.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *fundingManager) driveBatchFunding(batchCtx *batchFundingCtx,
+	msg *initBatchFundingMsg) {
+
+	defer f.wg.Done()
+
+	err := f.waitForBatchSignals(batchCtx, batchCtx.accepted)
+	if err != nil {
+		f.failBatchFundingFlow(batchCtx, err)
+		msg.err <- err
+		return
+	}
+
+	// With every peer having contributed to their channel, we can now
+	// create the shared funding transaction, and send our signature for
+	// each of the remote commitment transactions.
+	fundingTx, err := batchCtx.batch.Fund()
+	if err != nil {
+		f.failBatchFundingFlow(batchCtx, err)
+		msg.err <- err
+		return
+	}
+
+	fndgLog.Infof("Created batch funding tx %v", fundingTx.TxHash())
+
+	for i, resCtx := range batchCtx.reservations {
+		err := f.sendFundingCreated(resCtx, batchCtx.pendingChanIDs[i])
+		if err != nil {
+			f.failBatchFundingFlow(batchCtx, err)
+			msg.err <- err
+			return
+		}
+	}
+
+	err = f.waitForBatchSignals(batchCtx, batchCtx.signed)
+	if err != nil {
+		f.failBatchFundingFlow(batchCtx, err)
+		msg.err <- err
+		return
+	}
+
+	// Every peer has signed our version of their commitment transaction,
+	// so it's now safe to commit the channels and broadcast the funding
+	// transaction.
+	completeChans, err := batchCtx.batch.Publish()
+	if err != nil {
+		f.failBatchFundingFlow(batchCtx, err)
+		msg.err <- err
+		return
+	}
+
+	chanPoints := make([]*wire.OutPoint, 0, len(completeChans))
+	for i, completeChan := range completeChans {
+		f.finalizeFundingFlow(
+			batchCtx.reservations[i], batchCtx.pendingChanIDs[i],
+			completeChan,
+		)
+
+		chanPoints = append(chanPoints, &completeChan.FundingOutpoint)
+	}
+
+	msg.chanPoints <- chanPoints
+}
+
+// waitForBatchSignals waits until every reservation within the batch has sent
+// upon the passed signal channel. An error is returned if any of the
+// reservations fail first.
+func (f *fundingManager) waitForBatchSignals(batchCtx *batchFundingCtx,
+	signals <-chan struct{}) error {
+
+	for i := 0; i < len(batchCtx.reservations); i++ {
+		select {
+		case <-signals:
+		case err := <-batchCtx.err:
+			return err
+		case <-f.quit:
+			return fmt.Errorf("funding manager shutting down")
+		}
+	}
+
+	return nil
+}
+
+// failBatchFundingFlow fails the funding flow of every reservation within the
+// batch, and releases the inputs locked by the batch. Reservations which have
+// already been cancelled, such as the one whose failure caused the batch to
+// fail, are skipped, as their peer has already been notified.
+func (f *fundingManager) failBatchFundingFlow(batchCtx *batchFundingCtx,
+	fundingErr error) {
+
+	fndgLog.Errorf("Failing batch funding of %v channels: %v",
+		len(batchCtx.reservations), fundingErr)
+
+	for i, resCtx := range batchCtx.reservations {
+		peerKey := resCtx.peerAddress.IdentityKey
+		pendingChanID := batchCtx.pendingChanIDs[i]
+		if _, err := f.getReservationCtx(peerKey, pendingChanID); err != nil {
+			continue
+		}
+
+		f.failFundingFlow(peerKey, pendingChanID, fundingErr)
+	}
+
+	batchCtx.batch.Cancel()
+}
+
 // waitUntilChannelOpen is designed to prevent other lnd subsystems from
 // sending new update messages to a channel before the channel is fully
 // opened.
@@ -2658,13 +3370,19 @@ func (f *fundingManager) handleErrorMsg(fmsg *fundingErrorMsg) {
 		return
 	}
 
-	// If we did indeed find the funding workflow, then we'll return the
-	// error back to the caller (if any), and cancel the workflow itself.
+	// If we did indeed find the funding workflow, then we'll cancel the
+	// workflow itself, and return the error back to the caller (if any).
+	// The workflow is cancelled first, ensuring the caller never observes
+	// a reservation which has already failed as still active.
 	lnErr := lnwire.ErrorCode(protocolErr.Data[0])
 	fndgLog.Errorf("Received funding error from %x: %v",
 		peerKey.SerializeCompressed(), string(protocolErr.Data),
 	)
 
+	if _, err := f.cancelReservationCtx(peerKey, chanID); err != nil {
+		fndgLog.Warnf("unable to delete reservation: %v", err)
+	}
+
 	// If this isn't a simple error code, then we'll display the entire
 	// thing.
 	if len(protocolErr.Data) > 1 {
@@ -2678,11 +3396,6 @@ func (f *fundingManager) handleErrorMsg(fmsg *fundingErrorMsg) {
 			lnErr.ToGrpcCode(), lnErr.String(),
 		)
 	}
-
-	if _, err := f.cancelReservationCtx(peerKey, chanID); err != nil {
-		fndgLog.Warnf("unable to delete reservation: %v", err)
-		return
-	}
 }
 
 // pruneZombieReservations loops through all pending reservations and fails the
@@ -2710,6 +3423,18 @@ func (f *fundingManager) pruneZombieReservations() {
 			"chanID:%x)", resCtx.peerAddress.IdentityKey, pendingChanID[:])
 		fndgLog.Warnf(err.Error())
 		f.failFundingFlow(resCtx.peerAddress.IdentityKey, pendingChanID, err)
+
+		// If the reservation is part of a batch, then we'll also
+		// notify the batch, so that it can abort the funding flow of
+		// the remaining reservations. Similarly, the caller of a PSBT
+		// funded reservation is notified, as it may still be waiting
+		// to resume the funding flow.
+		if resCtx.batch != nil || resCtx.psbtFunding {
+			select {
+			case resCtx.err <- err:
+			default:
+			}
+		}
 	}
 }
 
@@ -2767,6 +3492,24 @@ func (f *fundingManager) getReservationCtx(peerKey *btcec.PublicKey,
 	return resCtx, nil
 }
 
+// getReservationCtxByID returns the reservation context of the reservation
+// with the given pending channel ID, regardless of the peer it's with. As
+// pending channel IDs are generated randomly, they're unique across peers.
+func (f *fundingManager) getReservationCtxByID(
+	pendingChanID [32]byte) (*reservationWithCtx, error) {
+
+	f.resMtx.RLock()
+	defer f.resMtx.RUnlock()
+
+	for _, pendingReservations := range f.activeReservations {
+		if resCtx, ok := pendingReservations[pendingChanID]; ok {
+			return resCtx, nil
+		}
+	}
+
+	return nil, errors.Errorf("unknown channel (id: %x)", pendingChanID[:])
+}
+
 // IsPendingChannel returns a boolean indicating whether the channel identified
 // by the pendingChanID and given peer is pending, meaning it is in the process
 // of being funded. After the funding transaction has been confirmed, the
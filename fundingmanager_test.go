@@ -3,23 +3,27 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/btcsuite/btclog"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chanacceptor"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/psbt"
 	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	_ "github.com/roasbeef/btcwallet/walletdb/bdb"
@@ -194,6 +198,9 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 	newChannelsChan := make(chan *newChannelMsg)
 	p := &peer{
 		newChannels: newChannelsChan,
+		remoteLocalFeatures: lnwire.NewFeatureVector(
+			nil, lnwire.LocalFeatures,
+		),
 	}
 
 	sentMessages := make(chan lnwire.Message)
@@ -308,6 +315,8 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		},
 		ZombieSweeperInterval: 1 * time.Hour,
 		ReservationTimeout:    1 * time.Nanosecond,
+		MaxChanSize:           maxFundingAmount,
+		OpenChannelPredicate:  chanacceptor.NewChainedAcceptor(),
 	})
 	if err != nil {
 		t.Fatalf("failed creating fundingManager: %v", err)
@@ -387,6 +396,8 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 		},
 		ZombieSweeperInterval: oldCfg.ZombieSweeperInterval,
 		ReservationTimeout:    oldCfg.ReservationTimeout,
+		MaxChanSize:           oldCfg.MaxChanSize,
+		OpenChannelPredicate:  oldCfg.OpenChannelPredicate,
 	})
 	if err != nil {
 		t.Fatalf("failed recreating aliceFundingManager: %v", err)
@@ -582,6 +593,8 @@ func assertFundingMsgSent(t *testing.T, msgChan chan lnwire.Message,
 		ok      bool
 	)
 	switch msgType {
+	case "OpenChannel":
+		sentMsg, ok = msg.(*lnwire.OpenChannel)
 	case "AcceptChannel":
 		sentMsg, ok = msg.(*lnwire.AcceptChannel)
 	case "FundingCreated":
@@ -1339,6 +1352,287 @@ func TestFundingManagerPeerTimeoutAfterFundingOpen(t *testing.T) {
 	assertNumPendingReservations(t, bob, alicePubKey, 0)
 }
 
+// TestFundingManagerChannelAcceptorReject checks that a channel rejected by
+// the channel acceptor is failed before any reservation is made, and that the
+// reason for the rejection is sent to the remote peer.
+func TestFundingManagerChannelAcceptorReject(t *testing.T) {
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Bob will reject all channels opened by Alice.
+	const rejectReason = "no channels from alice"
+	predicate := bob.fundingMgr.cfg.OpenChannelPredicate
+	acceptor := predicate.(*chanacceptor.ChainedAcceptor)
+	acceptor.AddAcceptor(chanacceptor.AcceptorFunc(
+		func(req *chanacceptor.ChannelAcceptRequest) error {
+			if req.Node.IsEqual(alicePubKey) {
+				return &chanacceptor.RejectError{
+					Reason: rejectReason,
+				}
+			}
+			return nil
+		},
+	))
+
+	// We will consume the channel updates as we go, so no buffering is needed.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	// Create a funding request and start the workflow.
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: 500000,
+		pushAmt:         lnwire.NewMSatFromSatoshis(0),
+		private:         false,
+		updates:         updateChan,
+		err:             errChan,
+	}
+
+	alice.fundingMgr.initFundingWorkflow(bobAddr, initReq)
+
+	// Alice should have sent the OpenChannel message to Bob.
+	var aliceMsg lnwire.Message
+	select {
+	case aliceMsg = <-alice.msgChan:
+	case err := <-initReq.err:
+		t.Fatalf("error init funding workflow: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenChannel message")
+	}
+
+	openChannelReq, ok := aliceMsg.(*lnwire.OpenChannel)
+	if !ok {
+		t.Fatalf("expected OpenChannel to be sent from "+
+			"alice, instead got %T", aliceMsg)
+	}
+
+	// Let Bob handle the init message.
+	bob.fundingMgr.processFundingOpen(openChannelReq, aliceAddr)
+
+	// Bob should reject the channel with an Error containing the reason
+	// given by the channel acceptor.
+	var bobMsg lnwire.Message
+	select {
+	case bobMsg = <-bob.msgChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("bob did not send Error message")
+	}
+
+	errorMsg, ok := bobMsg.(*lnwire.Error)
+	if !ok {
+		t.Fatalf("expected Error to be sent from bob, instead got %T",
+			bobMsg)
+	}
+	if string(errorMsg.Data) != rejectReason {
+		t.Fatalf("expected rejection reason %q, got %q", rejectReason,
+			string(errorMsg.Data))
+	}
+
+	// Bob shouldn't have created a reservation for the channel.
+	assertNumPendingReservations(t, bob, alicePubKey, 0)
+}
+
+// TestFundingManagerMaxChanSize checks that channels larger than
+// maxFundingAmount are only permitted with peers that signal support for
+// them, and that the configured maximum channel size is always honored.
+func TestFundingManagerMaxChanSize(t *testing.T) {
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// With large channels enabled, Alice, who doesn't signal support for
+	// them, should still be limited to maxFundingAmount.
+	bob.fundingMgr.cfg.MaxChanSize = maxWumboFundingAmount
+	maxChanSize := bob.fundingMgr.maxChanSize(alicePubKey)
+	if maxChanSize != maxFundingAmount {
+		t.Fatalf("expected max channel size %v, got %v",
+			maxFundingAmount, maxChanSize)
+	}
+
+	// Once Alice signals support for large channels, the configured
+	// maximum should apply.
+	wumboFeatures := lnwire.NewRawFeatureVector(
+		lnwire.WumboChannelsOptional,
+	)
+	bob.peer.remoteLocalFeatures = lnwire.NewFeatureVector(
+		wumboFeatures, lnwire.LocalFeatures,
+	)
+	maxChanSize = bob.fundingMgr.maxChanSize(alicePubKey)
+	if maxChanSize != maxWumboFundingAmount {
+		t.Fatalf("expected max channel size %v, got %v",
+			maxWumboFundingAmount, maxChanSize)
+	}
+
+	// A configured maximum below the protocol's soft-limit should apply
+	// regardless of the features signalled by Alice.
+	bob.fundingMgr.cfg.MaxChanSize = 100000
+	maxChanSize = bob.fundingMgr.maxChanSize(alicePubKey)
+	if maxChanSize != 100000 {
+		t.Fatalf("expected max channel size %v, got %v", 100000,
+			maxChanSize)
+	}
+}
+
+// TestFundingManagerChannelAcceptorAsync checks that the funding manager keeps
+// handling other requests while waiting for the channel acceptors to decide on
+// an inbound channel, and that the funding flow continues once the channel is
+// accepted.
+func TestFundingManagerChannelAcceptorAsync(t *testing.T) {
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Bob's channel acceptor won't come to a decision until we let it.
+	requests := make(chan *chanacceptor.ChannelAcceptRequest, 1)
+	decision := make(chan error)
+	predicate := bob.fundingMgr.cfg.OpenChannelPredicate
+	acceptor := predicate.(*chanacceptor.ChainedAcceptor)
+	acceptor.AddAcceptor(chanacceptor.AcceptorFunc(
+		func(req *chanacceptor.ChannelAcceptRequest) error {
+			requests <- req
+			return <-decision
+		},
+	))
+
+	// We will consume the channel updates as we go, so no buffering is needed.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	// Create a funding request and start the workflow.
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: 500000,
+		pushAmt:         lnwire.NewMSatFromSatoshis(0),
+		private:         false,
+		updates:         updateChan,
+		err:             errChan,
+	}
+
+	alice.fundingMgr.initFundingWorkflow(bobAddr, initReq)
+
+	// Alice should have sent the OpenChannel message to Bob.
+	openChannelReq := assertFundingMsgSent(
+		t, alice.msgChan, "OpenChannel",
+	).(*lnwire.OpenChannel)
+
+	// Let Bob handle the init message, which should be passed on to his
+	// channel acceptor.
+	bob.fundingMgr.processFundingOpen(openChannelReq, aliceAddr)
+
+	select {
+	case req := <-requests:
+		if req.OpenChanMsg.PendingChannelID !=
+			openChannelReq.PendingChannelID {
+
+			t.Fatalf("acceptor received request for unexpected " +
+				"pending channel")
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("channel acceptor wasn't consulted")
+	}
+
+	// While the acceptor is deciding, Bob shouldn't have responded to
+	// Alice, nor made a reservation, but should still be able to serve
+	// other requests.
+	assertErrorNotSent(t, bob.msgChan)
+	assertNumPendingReservations(t, bob, alicePubKey, 0)
+
+	queryErr := make(chan error, 1)
+	go func() {
+		_, err := bob.fundingMgr.PendingChannels()
+		queryErr <- err
+	}()
+	select {
+	case err := <-queryErr:
+		if err != nil {
+			t.Fatalf("unable to query pending channels: %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("funding manager blocked by channel acceptor")
+	}
+
+	// Once the channel is accepted, Bob should continue the funding flow
+	// by sending AcceptChannel to Alice.
+	decision <- nil
+	assertFundingMsgSent(t, bob.msgChan, "AcceptChannel")
+	assertNumPendingReservations(t, bob, alicePubKey, 1)
+}
+
+// TestFundingManagerUpfrontShutdownResponder checks that if the responder is
+// configured to commit to an upfront shutdown script, then it's included in
+// its AcceptChannel message, but only if the initiator supports upfront
+// shutdown scripts.
+func TestFundingManagerUpfrontShutdownResponder(t *testing.T) {
+	shutdownScript := lnwire.DeliveryAddress(bytes.Repeat([]byte{0x01}, 22))
+
+	tests := []struct {
+		name           string
+		peerSupport    bool
+		expectedScript lnwire.DeliveryAddress
+	}{
+		{
+			name:           "peer supports upfront shutdown",
+			peerSupport:    true,
+			expectedScript: shutdownScript,
+		},
+		{
+			name:           "peer doesn't support upfront shutdown",
+			peerSupport:    false,
+			expectedScript: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			alice, bob := setupFundingManagers(t)
+			defer tearDownFundingManagers(t, alice, bob)
+
+			bob.fundingMgr.cfg.UpfrontShutdownScript = func() (
+				lnwire.DeliveryAddress, error) {
+
+				return shutdownScript, nil
+			}
+			var rawFeatures *lnwire.RawFeatureVector
+			if test.peerSupport {
+				rawFeatures = lnwire.NewRawFeatureVector(
+					lnwire.UpfrontShutdownScriptOptional,
+				)
+			}
+			bob.peer.remoteLocalFeatures = lnwire.NewFeatureVector(
+				rawFeatures, lnwire.LocalFeatures,
+			)
+
+			initReq := &openChanReq{
+				targetPubkey:    bob.privKey.PubKey(),
+				chainHash:       *activeNetParams.GenesisHash,
+				localFundingAmt: 500000,
+				updates:         make(chan *lnrpc.OpenStatusUpdate),
+				err:             make(chan error, 1),
+			}
+			alice.fundingMgr.initFundingWorkflow(bobAddr, initReq)
+
+			openChannelReq := assertFundingMsgSent(
+				t, alice.msgChan, "OpenChannel",
+			).(*lnwire.OpenChannel)
+
+			bob.fundingMgr.processFundingOpen(
+				openChannelReq, aliceAddr,
+			)
+			acceptChannelResponse := assertFundingMsgSent(
+				t, bob.msgChan, "AcceptChannel",
+			).(*lnwire.AcceptChannel)
+
+			script := acceptChannelResponse.UpfrontShutdownScript
+			if !bytes.Equal(script, test.expectedScript) {
+				t.Fatalf("expected shutdown script %x, got "+
+					"%x", test.expectedScript, script)
+			}
+		})
+	}
+}
+
 // TestFundingManagerPeerTimeoutAfterFundingAccept checks that the zombie sweeper
 // will properly clean up a zombie reservation that times out after the
 // fundingAcceptMsg has been handled.
@@ -1934,3 +2228,507 @@ func TestFundingManagerPrivateRestart(t *testing.T) {
 	// from the database, as the channel is announced.
 	assertNoChannelState(t, alice, bob, fundingOutPoint)
 }
+
+// newBatchFundingReq creates a request to fund a batch of channels with Bob of
+// the given sizes.
+func newBatchFundingReq(chanAmts []btcutil.Amount) *initBatchFundingMsg {
+	channels := make([]*initFundingMsg, 0, len(chanAmts))
+	for _, chanAmt := range chanAmts {
+		channels = append(channels, &initFundingMsg{
+			peerAddress: bobAddr,
+			openChanReq: &openChanReq{
+				targetPubkey:    bobPubKey,
+				chainHash:       *activeNetParams.GenesisHash,
+				localFundingAmt: chanAmt,
+			},
+		})
+	}
+
+	return &initBatchFundingMsg{
+		channels:           channels,
+		fundingFeePerVSize: 10,
+		chanPoints:         make(chan []*wire.OutPoint, 1),
+		err:                make(chan error, 1),
+	}
+}
+
+// initBatchFunding kicks off a batch funding workflow from Alice to Bob for
+// channels of the given sizes, and returns the OpenChannel messages Alice sent
+// for each of them.
+func initBatchFunding(t *testing.T, alice *testNode,
+	chanAmts []btcutil.Amount) (*initBatchFundingMsg, []*lnwire.OpenChannel) {
+
+	req := newBatchFundingReq(chanAmts)
+	alice.fundingMgr.initBatchFundingWorkflow(req)
+
+	// Alice should have sent an OpenChannel message to Bob for each of
+	// the channels within the batch.
+	openChannelReqs := make([]*lnwire.OpenChannel, 0, len(chanAmts))
+	for range chanAmts {
+		var aliceMsg lnwire.Message
+		select {
+		case aliceMsg = <-alice.msgChan:
+		case err := <-req.err:
+			t.Fatalf("error init batch funding workflow: %v", err)
+		case <-time.After(time.Second * 5):
+			t.Fatalf("alice did not send OpenChannel message")
+		}
+
+		openChannelReq, ok := aliceMsg.(*lnwire.OpenChannel)
+		if !ok {
+			t.Fatalf("expected OpenChannel to be sent from "+
+				"alice, instead got %T", aliceMsg)
+		}
+		openChannelReqs = append(openChannelReqs, openChannelReq)
+	}
+
+	return req, openChannelReqs
+}
+
+// TestFundingManagerBatchFunding checks that a batch of channels is funded by
+// a single funding transaction, which is only broadcast once every channel
+// within the batch has been signed by the remote peer.
+func TestFundingManagerBatchFunding(t *testing.T) {
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Both channels of the batch will be opened with Bob, so he'll need to
+	// accept more than a single pending channel.
+	cfg.MaxPendingChannels = 2
+
+	chanAmts := []btcutil.Amount{500000, 300000}
+	req, openChannelReqs := initBatchFunding(t, alice, chanAmts)
+	assertNumPendingReservations(t, alice, bobPubKey, 2)
+
+	// Let Bob handle both init messages, and forward his responses to
+	// Alice. Alice shouldn't create the funding transaction until Bob has
+	// accepted both channels.
+	for i, openChannelReq := range openChannelReqs {
+		bob.fundingMgr.processFundingOpen(openChannelReq, aliceAddr)
+		acceptChannelResponse := assertFundingMsgSent(
+			t, bob.msgChan, "AcceptChannel",
+		).(*lnwire.AcceptChannel)
+
+		alice.fundingMgr.processFundingAccept(
+			acceptChannelResponse, bobAddr,
+		)
+
+		if i == len(openChannelReqs)-1 {
+			break
+		}
+
+		select {
+		case msg := <-alice.msgChan:
+			t.Fatalf("alice unexpectedly sent %T before batch was "+
+				"accepted", msg)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	// With both channels accepted, Alice should send a FundingCreated
+	// message for each of them, to which Bob responds with FundingSigned.
+	var fundingSigneds []*lnwire.FundingSigned
+	var fundingPoints []wire.OutPoint
+	for range chanAmts {
+		fundingCreated := assertFundingMsgSent(
+			t, alice.msgChan, "FundingCreated",
+		).(*lnwire.FundingCreated)
+		fundingPoints = append(fundingPoints, fundingCreated.FundingPoint)
+
+		bob.fundingMgr.processFundingCreated(fundingCreated, aliceAddr)
+		fundingSigned := assertFundingMsgSent(
+			t, bob.msgChan, "FundingSigned",
+		).(*lnwire.FundingSigned)
+		fundingSigneds = append(fundingSigneds, fundingSigned)
+	}
+
+	// Both channels should be funded by the same transaction, within
+	// distinct outputs.
+	if fundingPoints[0].Hash != fundingPoints[1].Hash {
+		t.Fatalf("channels funded by different transactions: %v vs %v",
+			fundingPoints[0], fundingPoints[1])
+	}
+	if fundingPoints[0].Index == fundingPoints[1].Index {
+		t.Fatalf("channels share funding output %v", fundingPoints[0])
+	}
+
+	// The funding transaction shouldn't be broadcast after only the first
+	// channel has been signed.
+	alice.fundingMgr.processFundingSigned(fundingSigneds[0], bobAddr)
+	select {
+	case <-alice.publTxChan:
+		t.Fatalf("alice published funding tx before batch was signed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Once the second channel is signed, the funding transaction should be
+	// broadcast, and both channels should be pending.
+	alice.fundingMgr.processFundingSigned(fundingSigneds[1], bobAddr)
+
+	var publ *wire.MsgTx
+	select {
+	case publ = <-alice.publTxChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not publish funding tx")
+	}
+	if publ.TxHash() != fundingPoints[0].Hash {
+		t.Fatalf("published tx %v doesn't match funding tx %v",
+			publ.TxHash(), fundingPoints[0].Hash)
+	}
+
+	var chanPoints []*wire.OutPoint
+	select {
+	case chanPoints = <-req.chanPoints:
+	case err := <-req.err:
+		t.Fatalf("batch funding failed: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("batch funding did not complete")
+	}
+	for i, chanPoint := range chanPoints {
+		if *chanPoint != fundingPoints[i] {
+			t.Fatalf("expected chan point %v, got %v",
+				fundingPoints[i], chanPoint)
+		}
+
+		fundingOutput := publ.TxOut[chanPoint.Index]
+		if fundingOutput.Value != int64(chanAmts[i]) {
+			t.Fatalf("expected funding output of %v, got %v",
+				chanAmts[i], fundingOutput.Value)
+		}
+	}
+
+	assertNumPendingChannelsBecomes(t, alice, 2)
+}
+
+// TestFundingManagerBatchFundingAbort checks that if the funding flow of any
+// channel within a batch fails, then the funding flow of every channel within
+// the batch is failed.
+func TestFundingManagerBatchFundingAbort(t *testing.T) {
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	cfg.MaxPendingChannels = 2
+
+	chanAmts := []btcutil.Amount{500000, 300000}
+	req, openChannelReqs := initBatchFunding(t, alice, chanAmts)
+
+	// Bob accepts the first channel.
+	bob.fundingMgr.processFundingOpen(openChannelReqs[0], aliceAddr)
+	acceptChannelResponse := assertFundingMsgSent(
+		t, bob.msgChan, "AcceptChannel",
+	).(*lnwire.AcceptChannel)
+	alice.fundingMgr.processFundingAccept(acceptChannelResponse, bobAddr)
+
+	// But rejects the second one.
+	alice.fundingMgr.processFundingError(&lnwire.Error{
+		ChanID: openChannelReqs[1].PendingChannelID,
+		Data:   lnwire.ErrorData("channel rejected"),
+	}, bobAddr)
+
+	// Alice should fail the funding flow of the first channel, and notify
+	// the caller. As Bob already rejected the second channel, Alice
+	// shouldn't send him an error for it.
+	assertErrorSent(t, alice.msgChan)
+	assertErrorNotSent(t, alice.msgChan)
+
+	select {
+	case err := <-req.err:
+		if err == nil {
+			t.Fatalf("expected batch funding to fail")
+		}
+	case <-req.chanPoints:
+		t.Fatalf("batch funding unexpectedly succeeded")
+	case <-time.After(time.Second * 5):
+		t.Fatalf("batch funding wasn't aborted")
+	}
+
+	assertNumPendingReservations(t, alice, bobPubKey, 0)
+
+	select {
+	case <-alice.publTxChan:
+		t.Fatalf("alice published funding tx of aborted batch")
+	default:
+	}
+}
+
+// TestFundingManagerBatchFundingInitFailure checks that if the funding flow of
+// any channel within a batch can't be initiated, then the batch is failed with
+// the error of that channel, and the funding flow of every channel initiated
+// before it is failed.
+func TestFundingManagerBatchFundingInitFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		chanAmts []btcutil.Amount
+	}{
+		{
+			name:     "first channel fails",
+			chanAmts: []btcutil.Amount{maxFundingAmount + 1, 300000},
+		},
+		{
+			name:     "second channel fails",
+			chanAmts: []btcutil.Amount{300000, maxFundingAmount + 1},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			alice, bob := setupFundingManagers(t)
+			defer tearDownFundingManagers(t, alice, bob)
+
+			cfg.MaxPendingChannels = 2
+
+			req := newBatchFundingReq(test.chanAmts)
+			alice.fundingMgr.initBatchFundingWorkflow(req)
+
+			// If the first channel was initiated, then Alice
+			// should have sent an OpenChannel message for it,
+			// followed by an error once the batch is failed.
+			if test.chanAmts[0] <= maxFundingAmount {
+				assertFundingMsgSent(
+					t, alice.msgChan, "OpenChannel",
+				)
+				assertErrorSent(t, alice.msgChan)
+			}
+			assertErrorNotSent(t, alice.msgChan)
+
+			// The caller should be notified of the error of the
+			// channel which couldn't be initiated.
+			select {
+			case err := <-req.err:
+				if !strings.Contains(err.Error(), "too large") {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			case <-req.chanPoints:
+				t.Fatalf("batch funding unexpectedly succeeded")
+			case <-time.After(time.Second * 5):
+				t.Fatalf("batch funding wasn't failed")
+			}
+
+			assertNumPendingReservations(t, alice, bobPubKey, 0)
+		})
+	}
+}
+
+// TestFundingManagerPsbtFunding checks that the funding flow of a PSBT funded
+// channel pauses once the funding output has been negotiated, and only resumes
+// once a valid funding transaction has been supplied.
+func TestFundingManagerPsbtFunding(t *testing.T) {
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	const localAmt = btcutil.Amount(500000)
+
+	// Create a PSBT funded funding request and start the workflow.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: localAmt,
+		psbtFunding:     true,
+		updates:         updateChan,
+		err:             errChan,
+	}
+	alice.fundingMgr.initFundingWorkflow(bobAddr, initReq)
+
+	openChannelReq := assertFundingMsgSent(
+		t, alice.msgChan, "OpenChannel",
+	).(*lnwire.OpenChannel)
+	bob.fundingMgr.processFundingOpen(openChannelReq, aliceAddr)
+	acceptChannelResponse := assertFundingMsgSent(
+		t, bob.msgChan, "AcceptChannel",
+	).(*lnwire.AcceptChannel)
+	alice.fundingMgr.processFundingAccept(acceptChannelResponse, bobAddr)
+
+	// Rather than sending FundingCreated, Alice should hand us a PSBT
+	// containing the funding output.
+	var psbtFund *lnrpc.ReadyForPsbtFunding
+	select {
+	case update := <-updateChan:
+		psbtUpdate, ok := update.Update.(*lnrpc.OpenStatusUpdate_PsbtFund)
+		if !ok {
+			t.Fatalf("expected OpenStatusUpdate_PsbtFund, got %T",
+				update.Update)
+		}
+		psbtFund = psbtUpdate.PsbtFund
+	case err := <-errChan:
+		t.Fatalf("error init funding workflow: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenStatusUpdate_PsbtFund")
+	}
+
+	if psbtFund.FundingAmount != int64(localAmt) {
+		t.Fatalf("expected funding amount of %v, got %v", localAmt,
+			psbtFund.FundingAmount)
+	}
+	packet, err := psbt.NewFromRawBytes(
+		bytes.NewReader(psbtFund.Psbt), false,
+	)
+	if err != nil {
+		t.Fatalf("unable to parse psbt: %v", err)
+	}
+	if len(packet.UnsignedTx.TxOut) != 1 {
+		t.Fatalf("expected psbt with a single output, got %v",
+			len(packet.UnsignedTx.TxOut))
+	}
+	fundingOutput := packet.UnsignedTx.TxOut[0]
+	if fundingOutput.Value != int64(localAmt) {
+		t.Fatalf("expected funding output of %v, got %v", localAmt,
+			fundingOutput.Value)
+	}
+
+	select {
+	case msg := <-alice.msgChan:
+		t.Fatalf("alice unexpectedly sent %T before being funded", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	var pendingChanID [32]byte
+	copy(pendingChanID[:], psbtFund.PendingChanId)
+
+	// A funding transaction with a non-segwit input should be rejected,
+	// without failing the funding flow.
+	fundingTx := packet.UnsignedTx.Copy()
+	fundingTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: 1},
+		SignatureScript:  []byte{0x01},
+	})
+	err = alice.fundingMgr.processPsbtFunding(pendingChanID, fundingTx)
+	if err == nil {
+		t.Fatalf("expected funding tx with non-segwit input to be " +
+			"rejected")
+	}
+
+	// The same applies to a funding transaction which doesn't pay the
+	// full channel capacity to the funding output.
+	fundingTx.TxIn[0].SignatureScript = nil
+	fundingTx.TxIn[0].Witness = wire.TxWitness{{0x01}}
+	fundingTx.TxOut[0].Value--
+	err = alice.fundingMgr.processPsbtFunding(pendingChanID, fundingTx)
+	if err == nil {
+		t.Fatalf("expected funding tx with invalid funding output to " +
+			"be rejected")
+	}
+	assertErrorNotSent(t, alice.msgChan)
+	assertNumPendingReservations(t, alice, bobPubKey, 1)
+
+	// Once a valid funding transaction is supplied, the funding flow
+	// should resume.
+	fundingTx.TxOut[0].Value++
+	fundingTx.AddTxOut(&wire.TxOut{Value: 100000, PkScript: []byte{0x00}})
+	err = alice.fundingMgr.processPsbtFunding(pendingChanID, fundingTx)
+	if err != nil {
+		t.Fatalf("unable to process psbt funding: %v", err)
+	}
+
+	fundingCreated := assertFundingMsgSent(
+		t, alice.msgChan, "FundingCreated",
+	).(*lnwire.FundingCreated)
+	if fundingCreated.FundingPoint.Hash != fundingTx.TxHash() {
+		t.Fatalf("expected funding point within %v, got %v",
+			fundingTx.TxHash(), fundingCreated.FundingPoint)
+	}
+
+	// The funding transaction can't be replaced once supplied.
+	err = alice.fundingMgr.processPsbtFunding(pendingChanID, fundingTx)
+	if err == nil {
+		t.Fatalf("expected funding tx to only be accepted once")
+	}
+
+	bob.fundingMgr.processFundingCreated(fundingCreated, aliceAddr)
+	fundingSigned := assertFundingMsgSent(
+		t, bob.msgChan, "FundingSigned",
+	).(*lnwire.FundingSigned)
+	alice.fundingMgr.processFundingSigned(fundingSigned, bobAddr)
+
+	// Finally, Alice should broadcast the supplied funding transaction,
+	// and mark the channel as pending.
+	select {
+	case update := <-updateChan:
+		_, ok := update.Update.(*lnrpc.OpenStatusUpdate_ChanPending)
+		if !ok {
+			t.Fatalf("expected OpenStatusUpdate_ChanPending, "+
+				"got %T", update.Update)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenStatusUpdate_ChanPending")
+	}
+
+	select {
+	case publ := <-alice.publTxChan:
+		if publ.TxHash() != fundingTx.TxHash() {
+			t.Fatalf("published tx %v doesn't match funding tx %v",
+				publ.TxHash(), fundingTx.TxHash())
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not publish funding tx")
+	}
+
+	assertNumPendingChannelsBecomes(t, alice, 1)
+}
+
+// TestScaledFundingParams tests that the number of confirmations and the remote
+// delay required for a channel are scaled with its size, and clamped to their
+// maximum for wumbo channels beyond maxFundingAmount.
+func TestScaledFundingParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		chanAmt btcutil.Amount
+		pushAmt lnwire.MilliSatoshi
+		confs   uint16
+		delay   uint16
+	}{
+		{
+			chanAmt: minChanFundingSize,
+			confs:   3,
+			delay:   minBtcRemoteDelay,
+		},
+		{
+			chanAmt: maxFundingAmount / 2,
+			confs:   3,
+			delay:   maxBtcRemoteDelay / 2,
+		},
+		{
+			chanAmt: maxFundingAmount / 2,
+			pushAmt: lnwire.NewMSatFromSatoshis(maxFundingAmount / 4),
+			confs:   4,
+			delay:   maxBtcRemoteDelay / 2,
+		},
+		{
+			chanAmt: maxFundingAmount,
+			confs:   6,
+			delay:   maxBtcRemoteDelay,
+		},
+		{
+			chanAmt: maxFundingAmount * 64,
+			confs:   6,
+			delay:   maxBtcRemoteDelay,
+		},
+		{
+			chanAmt: maxWumboFundingAmount,
+			pushAmt: lnwire.NewMSatFromSatoshis(maxWumboFundingAmount),
+			confs:   6,
+			delay:   maxBtcRemoteDelay,
+		},
+	}
+
+	for i, test := range tests {
+		confs := scaledNumConfs(test.chanAmt, test.pushAmt)
+		if confs != test.confs {
+			t.Fatalf("test %v: expected %v confs for channel of %v, "+
+				"got %v", i, test.confs, test.chanAmt, confs)
+		}
+
+		delay := scaledRemoteDelay(
+			test.chanAmt, minBtcRemoteDelay, maxBtcRemoteDelay,
+		)
+		if delay != test.delay {
+			t.Fatalf("test %v: expected delay of %v for channel "+
+				"of %v, got %v", i, test.delay, test.chanAmt,
+				delay)
+		}
+	}
+}
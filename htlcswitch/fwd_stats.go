@@ -0,0 +1,64 @@
+package htlcswitch
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// FwdStats is a snapshot of the counters the switch maintains over the HTLCs
+// that have been forwarded through it, or failed back by it and its links.
+type FwdStats struct {
+	// NumForwards is the total number of HTLCs that have been successfully
+	// forwarded through the switch, as signalled by their settlement.
+	NumForwards uint64
+
+	// NumFailures maps an onion failure code to the total number of HTLCs
+	// that have been failed back to their sender with that code.
+	NumFailures map[lnwire.FailCode]uint64
+}
+
+// fwdCounters houses the counters from which a FwdStats snapshot is created.
+// It is safe for concurrent use.
+type fwdCounters struct {
+	mtx         sync.Mutex
+	numForwards uint64
+	numFailures map[lnwire.FailCode]uint64
+}
+
+// newFwdCounters returns a new set of zeroed forwarding counters.
+func newFwdCounters() *fwdCounters {
+	return &fwdCounters{
+		numFailures: make(map[lnwire.FailCode]uint64),
+	}
+}
+
+// recordForward increments the number of successfully forwarded HTLCs.
+func (c *fwdCounters) recordForward() {
+	c.mtx.Lock()
+	c.numForwards++
+	c.mtx.Unlock()
+}
+
+// recordFailure increments the number of HTLCs failed with the given code.
+func (c *fwdCounters) recordFailure(code lnwire.FailCode) {
+	c.mtx.Lock()
+	c.numFailures[code]++
+	c.mtx.Unlock()
+}
+
+// snapshot returns a copy of the current values of the counters.
+func (c *fwdCounters) snapshot() FwdStats {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	stats := FwdStats{
+		NumForwards: c.numForwards,
+		NumFailures: make(map[lnwire.FailCode]uint64, len(c.numFailures)),
+	}
+	for code, num := range c.numFailures {
+		stats.NumFailures[code] = num
+	}
+
+	return stats
+}
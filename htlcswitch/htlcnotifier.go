@@ -0,0 +1,556 @@
+package htlcswitch
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// maxPendingHtlcEvents is the maximum number of events queued up for a single
+// subscriber of the HtlcNotifier. Subscribers that fall this far behind on
+// reading their events are dropped, rather than letting their queue grow
+// without bound.
+const maxPendingHtlcEvents = 10000
+
+// HtlcEventType indicates the role our node played in the HTLC an event
+// relates to.
+type HtlcEventType uint8
+
+const (
+	// HtlcEventTypeSend is used for HTLCs that were initiated by our
+	// node, as part of a payment we send.
+	HtlcEventTypeSend HtlcEventType = iota
+
+	// HtlcEventTypeReceive is used for HTLCs for which our node is the
+	// final hop.
+	HtlcEventTypeReceive
+
+	// HtlcEventTypeForward is used for HTLCs that are forwarded through
+	// our node.
+	HtlcEventTypeForward
+)
+
+// String returns a human readable version of the event type.
+func (h HtlcEventType) String() string {
+	switch h {
+	case HtlcEventTypeSend:
+		return "send"
+	case HtlcEventTypeReceive:
+		return "receive"
+	case HtlcEventTypeForward:
+		return "forward"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(h))
+	}
+}
+
+// FailureDetail provides the reason for which our node failed an HTLC, beyond
+// the failure message that is sent back to its sender.
+type FailureDetail uint8
+
+const (
+	// FailureDetailNone is used when no further detail is available.
+	FailureDetailNone FailureDetail = iota
+
+	// FailureDetailOnionDecode indicates that the onion of the HTLC
+	// couldn't be decoded.
+	FailureDetailOnionDecode
+
+	// FailureDetailOnionEncode indicates that the onion for the next hop
+	// couldn't be encoded.
+	FailureDetailOnionEncode
+
+	// FailureDetailExpiryTooSoon indicates that the HTLC expires too soon
+	// to be accepted.
+	FailureDetailExpiryTooSoon
+
+	// FailureDetailAmountBelowMinimum indicates that the HTLC is below
+	// the minimum HTLC amount of our forwarding policy.
+	FailureDetailAmountBelowMinimum
+
+	// FailureDetailFeeInsufficient indicates that the HTLC doesn't carry
+	// the fee required by our forwarding policy.
+	FailureDetailFeeInsufficient
+
+	// FailureDetailIncorrectCltvExpiry indicates that the time-lock of
+	// the HTLC doesn't match our policy, or the one requested within its
+	// onion.
+	FailureDetailIncorrectCltvExpiry
+
+	// FailureDetailIncorrectAmount indicates that the amount of the HTLC
+	// doesn't match the invoice it pays to, or the one requested within
+	// its onion.
+	FailureDetailIncorrectAmount
+
+	// FailureDetailUnknownInvoice indicates that the HTLC pays to an
+	// invoice we don't know of.
+	FailureDetailUnknownInvoice
+
+	// FailureDetailInvoiceCanceled indicates that the HTLC pays to an
+	// invoice that has been canceled, or has expired.
+	FailureDetailInvoiceCanceled
+
+	// FailureDetailInvalidKeySend indicates that the HTLC is a
+	// spontaneous payment for which no invoice could be created.
+	FailureDetailInvalidKeySend
+
+	// FailureDetailMPPTimeout indicates that the HTLC is a shard of a
+	// multi-path payment that wasn't completed in time.
+	FailureDetailMPPTimeout
+
+	// FailureDetailUnknownNextPeer indicates that we don't have a link to
+	// the outgoing channel of the HTLC.
+	FailureDetailUnknownNextPeer
+
+	// FailureDetailInsufficientBalance indicates that none of the
+	// outgoing links have enough bandwidth to carry the HTLC.
+	FailureDetailInsufficientBalance
+
+	// FailureDetailAddRejected indicates that the outgoing channel
+	// rejected the HTLC, for instance as adding it would dip us below our
+	// channel reserve.
+	FailureDetailAddRejected
+
+	// FailureDetailIncompleteForward indicates that the HTLC was left
+	// half-forwarded, which may happen when recovering from a restart.
+	FailureDetailIncompleteForward
+
+	// FailureDetailIntercepted indicates that the HTLC was failed by the
+	// forward interceptor.
+	FailureDetailIntercepted
+)
+
+// String returns a human readable version of the failure detail.
+func (f FailureDetail) String() string {
+	switch f {
+	case FailureDetailNone:
+		return "no detail"
+	case FailureDetailOnionDecode:
+		return "could not decode onion"
+	case FailureDetailOnionEncode:
+		return "could not encode onion"
+	case FailureDetailExpiryTooSoon:
+		return "expiry too soon"
+	case FailureDetailAmountBelowMinimum:
+		return "amount below minimum"
+	case FailureDetailFeeInsufficient:
+		return "insufficient fee"
+	case FailureDetailIncorrectCltvExpiry:
+		return "incorrect cltv expiry"
+	case FailureDetailIncorrectAmount:
+		return "incorrect amount"
+	case FailureDetailUnknownInvoice:
+		return "unknown invoice"
+	case FailureDetailInvoiceCanceled:
+		return "invoice canceled"
+	case FailureDetailInvalidKeySend:
+		return "invalid keysend"
+	case FailureDetailMPPTimeout:
+		return "multi-path payment timed out"
+	case FailureDetailUnknownNextPeer:
+		return "unknown next peer"
+	case FailureDetailInsufficientBalance:
+		return "insufficient balance"
+	case FailureDetailAddRejected:
+		return "rejected by outgoing channel"
+	case FailureDetailIncompleteForward:
+		return "incomplete forward"
+	case FailureDetailIntercepted:
+		return "failed by interceptor"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(f))
+	}
+}
+
+// HtlcKey uniquely identifies the HTLC an event relates to.
+type HtlcKey struct {
+	// IncomingCircuit is the channel and HTLC index of the incoming HTLC.
+	// For HTLCs sent by our node, the channel is zero and the index is
+	// the payment ID assigned by the switch.
+	IncomingCircuit CircuitKey
+
+	// OutgoingCircuit is the channel and HTLC index of the outgoing HTLC.
+	// It is zero for HTLCs received by our node. If the HTLC was never
+	// added to the outgoing channel, the index is zero.
+	OutgoingCircuit CircuitKey
+}
+
+// String returns a human readable version of the HTLC key.
+func (k HtlcKey) String() string {
+	return fmt.Sprintf("%v -> %v", k.IncomingCircuit, k.OutgoingCircuit)
+}
+
+// HtlcInfo holds the amounts and time-locks of the incoming and outgoing
+// HTLCs of an event. Values that don't apply to the HTLC are zero.
+type HtlcInfo struct {
+	// IncomingTimeLock is the absolute block height at which the incoming
+	// HTLC expires.
+	IncomingTimeLock uint32
+
+	// OutgoingTimeLock is the absolute block height at which the outgoing
+	// HTLC expires.
+	OutgoingTimeLock uint32
+
+	// IncomingAmt is the amount of the incoming HTLC.
+	IncomingAmt lnwire.MilliSatoshi
+
+	// OutgoingAmt is the amount of the outgoing HTLC.
+	OutgoingAmt lnwire.MilliSatoshi
+}
+
+// ForwardingEvent is sent once an HTLC has been added to the outgoing
+// channel, either as part of a forward or a payment we send.
+type ForwardingEvent struct {
+	HtlcKey
+	HtlcInfo
+
+	// HtlcEventType is the role our node plays in the HTLC.
+	HtlcEventType
+
+	// Timestamp is the time at which the event occurred.
+	Timestamp time.Time
+}
+
+// ForwardingFailEvent is sent once an HTLC we forwarded or sent has been
+// failed by a node further down the route.
+type ForwardingFailEvent struct {
+	HtlcKey
+
+	// HtlcEventType is the role our node plays in the HTLC.
+	HtlcEventType
+
+	// FailureMessage is the failure returned by the failing hop. As
+	// failures of forwarded HTLCs are encrypted for the sender, it is
+	// only known for payments we sent, and is nil otherwise.
+	FailureMessage lnwire.FailureMessage
+
+	// FailingHop is the public key of the node that failed the HTLC. Like
+	// the FailureMessage, it is nil if it isn't known to us.
+	FailingHop *btcec.PublicKey
+
+	// Timestamp is the time at which the event occurred.
+	Timestamp time.Time
+}
+
+// LinkFailEvent is sent once our node fails an HTLC itself, either within the
+// incoming link as it doesn't satisfy our policy, or before it could be added
+// to the outgoing channel.
+type LinkFailEvent struct {
+	HtlcKey
+	HtlcInfo
+
+	// HtlcEventType is the role our node plays in the HTLC.
+	HtlcEventType
+
+	// FailureMessage is the failure that is sent back to the sender of
+	// the HTLC.
+	FailureMessage lnwire.FailureMessage
+
+	// FailureDetail is the reason for which we failed the HTLC.
+	FailureDetail FailureDetail
+
+	// Incoming is true if the HTLC was failed by the incoming link, and
+	// false if it failed on its way out.
+	Incoming bool
+
+	// Timestamp is the time at which the event occurred.
+	Timestamp time.Time
+}
+
+// SettleEvent is sent once an HTLC we forwarded, sent or received has been
+// settled.
+type SettleEvent struct {
+	HtlcKey
+
+	// HtlcEventType is the role our node plays in the HTLC.
+	HtlcEventType
+
+	// Timestamp is the time at which the event occurred.
+	Timestamp time.Time
+}
+
+// HtlcEventSubscription represents an intent to receive the HTLC events
+// emitted by the switch and its links. The Events channel is sent upon with
+// each new event, in the order in which they occurred.
+type HtlcEventSubscription struct {
+	// Events is a receive only channel over which new events are sent.
+	// Each event is one of *ForwardingEvent, *ForwardingFailEvent,
+	// *LinkFailEvent or *SettleEvent.
+	Events <-chan interface{}
+
+	// Cancel is a function closure that should be executed when the
+	// client wishes to cancel their subscription. Doing so allows the
+	// HtlcNotifier to free up resources.
+	Cancel func()
+
+	// Quit is closed once the subscription has been canceled, either by
+	// the client, or by the HtlcNotifier because the client fell too far
+	// behind on reading its events.
+	Quit <-chan struct{}
+}
+
+// htlcEventClient is a single subscriber of the HtlcNotifier. Events are
+// queued up for the client, so that a slow client never blocks the switch or
+// its links.
+type htlcEventClient struct {
+	// numPending is the number of events queued up for the client, which
+	// haven't been delivered yet.
+	//
+	// NOTE: This MUST be used atomically.
+	numPending int32
+
+	// ntfnQueue buffers the events handed to the client until they are
+	// delivered.
+	ntfnQueue *chainntnfs.ConcurrentQueue
+
+	// events is the channel that events are delivered to the client
+	// over.
+	events chan interface{}
+
+	quitOnce sync.Once
+	quit     chan struct{}
+}
+
+// stop signals the client's dispatcher to exit.
+func (c *htlcEventClient) stop() {
+	c.quitOnce.Do(func() {
+		close(c.quit)
+	})
+}
+
+// HtlcNotifier notifies its subscribers of the HTLCs that are forwarded,
+// failed and settled by the switch and its links.
+type HtlcNotifier struct {
+	stopped int32
+
+	// now returns the current time, used to timestamp events.
+	now func() time.Time
+
+	clientMtx     sync.Mutex
+	clientCounter uint64
+	clients       map[uint64]*htlcEventClient
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// NewHtlcNotifier creates a new HtlcNotifier, which timestamps its events
+// using the passed closure.
+func NewHtlcNotifier(now func() time.Time) *HtlcNotifier {
+	return &HtlcNotifier{
+		now:     now,
+		clients: make(map[uint64]*htlcEventClient),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Stop signals all subscriptions to exit, and waits for them to do so.
+func (h *HtlcNotifier) Stop() {
+	if !atomic.CompareAndSwapInt32(&h.stopped, 0, 1) {
+		return
+	}
+
+	close(h.quit)
+	h.wg.Wait()
+}
+
+// SubscribeHtlcEvents returns a new subscription which delivers all HTLC
+// events emitted from now on.
+func (h *HtlcNotifier) SubscribeHtlcEvents() (*HtlcEventSubscription, error) {
+	client := &htlcEventClient{
+		ntfnQueue: chainntnfs.NewConcurrentQueue(20),
+		events:    make(chan interface{}),
+		quit:      make(chan struct{}),
+	}
+
+	h.clientMtx.Lock()
+	select {
+	case <-h.quit:
+		h.clientMtx.Unlock()
+		return nil, fmt.Errorf("htlc notifier shutting down")
+	default:
+	}
+
+	clientID := h.clientCounter
+	h.clientCounter++
+	h.clients[clientID] = client
+
+	client.ntfnQueue.Start()
+
+	h.wg.Add(1)
+	go h.dispatchEvents(client)
+	h.clientMtx.Unlock()
+
+	log.Debugf("New htlc event subscription, client %v", clientID)
+
+	return &HtlcEventSubscription{
+		Events: client.events,
+		Cancel: func() {
+			h.clientMtx.Lock()
+			delete(h.clients, clientID)
+			h.clientMtx.Unlock()
+
+			client.stop()
+		},
+		Quit: client.quit,
+	}, nil
+}
+
+// dispatchEvents delivers the events queued up for the client in order, as
+// the client is ready to receive them.
+//
+// NOTE: This MUST be run as a goroutine.
+func (h *HtlcNotifier) dispatchEvents(client *htlcEventClient) {
+	defer h.wg.Done()
+	defer client.ntfnQueue.Stop()
+
+	for {
+		select {
+		case event := <-client.ntfnQueue.ChanOut():
+			select {
+			case client.events <- event:
+				atomic.AddInt32(&client.numPending, -1)
+
+			case <-client.quit:
+				return
+
+			case <-h.quit:
+				return
+			}
+
+		case <-client.quit:
+			return
+
+		case <-h.quit:
+			return
+		}
+	}
+}
+
+// notify hands the passed event to all current subscribers. Subscribers that
+// have too many events queued up already are dropped instead.
+func (h *HtlcNotifier) notify(event interface{}) {
+	h.clientMtx.Lock()
+	defer h.clientMtx.Unlock()
+
+	for clientID, client := range h.clients {
+		if atomic.LoadInt32(&client.numPending) >= maxPendingHtlcEvents {
+			log.Warnf("Dropping htlc event subscription of client "+
+				"%v, which has %v events pending", clientID,
+				maxPendingHtlcEvents)
+
+			delete(h.clients, clientID)
+			client.stop()
+			continue
+		}
+
+		select {
+		case client.ntfnQueue.ChanIn() <- event:
+			atomic.AddInt32(&client.numPending, 1)
+		case <-client.quit:
+		case <-h.quit:
+			return
+		}
+	}
+}
+
+// NotifyForwardingEvent notifies subscribers that an HTLC has been added to
+// the outgoing channel.
+func (h *HtlcNotifier) NotifyForwardingEvent(key HtlcKey, info HtlcInfo,
+	eventType HtlcEventType) {
+
+	log.Tracef("Notifying forward event: %v htlc %v", eventType, key)
+
+	h.notify(&ForwardingEvent{
+		HtlcKey:       key,
+		HtlcInfo:      info,
+		HtlcEventType: eventType,
+		Timestamp:     h.now(),
+	})
+}
+
+// NotifyForwardingFailEvent notifies subscribers that an HTLC has been failed
+// further down the route. The failure and the failing hop are nil if they
+// aren't known to us.
+func (h *HtlcNotifier) NotifyForwardingFailEvent(key HtlcKey,
+	eventType HtlcEventType, failure lnwire.FailureMessage,
+	failingHop *btcec.PublicKey) {
+
+	log.Tracef("Notifying forward fail event: %v htlc %v", eventType,
+		key)
+
+	h.notify(&ForwardingFailEvent{
+		HtlcKey:        key,
+		HtlcEventType:  eventType,
+		FailureMessage: failure,
+		FailingHop:     failingHop,
+		Timestamp:      h.now(),
+	})
+}
+
+// NotifyLinkFailEvent notifies subscribers that our node has failed an HTLC
+// itself, for the given reason.
+func (h *HtlcNotifier) NotifyLinkFailEvent(key HtlcKey, info HtlcInfo,
+	eventType HtlcEventType, failure lnwire.FailureMessage,
+	detail FailureDetail, incoming bool) {
+
+	log.Tracef("Notifying link fail event: %v htlc %v: %v", eventType,
+		key, detail)
+
+	h.notify(&LinkFailEvent{
+		HtlcKey:        key,
+		HtlcInfo:       info,
+		HtlcEventType:  eventType,
+		FailureMessage: failure,
+		FailureDetail:  detail,
+		Incoming:       incoming,
+		Timestamp:      h.now(),
+	})
+}
+
+// NotifySettleEvent notifies subscribers that an HTLC has been settled.
+func (h *HtlcNotifier) NotifySettleEvent(key HtlcKey,
+	eventType HtlcEventType) {
+
+	log.Tracef("Notifying settle event: %v htlc %v", eventType, key)
+
+	h.notify(&SettleEvent{
+		HtlcKey:       key,
+		HtlcEventType: eventType,
+		Timestamp:     h.now(),
+	})
+}
+
+// newHtlcKey returns the key of the HTLC carried by the passed packet.
+func newHtlcKey(pkt *htlcPacket) HtlcKey {
+	return HtlcKey{
+		IncomingCircuit: pkt.inKey(),
+		OutgoingCircuit: pkt.outKey(),
+	}
+}
+
+// newHtlcInfo returns the amounts and time-locks of the HTLC add carried by
+// the passed packet.
+func newHtlcInfo(pkt *htlcPacket, htlc *lnwire.UpdateAddHTLC) HtlcInfo {
+	return HtlcInfo{
+		IncomingTimeLock: pkt.incomingTimeout,
+		OutgoingTimeLock: htlc.Expiry,
+		IncomingAmt:      pkt.incomingAmount,
+		OutgoingAmt:      htlc.Amount,
+	}
+}
+
+// getEventType returns the role our node plays in the HTLC carried by the
+// passed packet.
+func getEventType(pkt *htlcPacket) HtlcEventType {
+	if pkt.incomingChanID == sourceHop {
+		return HtlcEventTypeSend
+	}
+
+	return HtlcEventTypeForward
+}
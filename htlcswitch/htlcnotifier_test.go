@@ -0,0 +1,143 @@
+package htlcswitch
+
+import (
+	"testing"
+	"time"
+)
+
+// TestHtlcNotifierSubscriptions tests that each subscriber of the HtlcNotifier
+// receives all events in order, even if it doesn't read them right away, and
+// that canceled subscriptions no longer receive events.
+func TestHtlcNotifierSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1000, 0)
+	notifier := NewHtlcNotifier(func() time.Time {
+		return timestamp
+	})
+	defer notifier.Stop()
+
+	sub1, err := notifier.SubscribeHtlcEvents()
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	defer sub1.Cancel()
+
+	sub2, err := notifier.SubscribeHtlcEvents()
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+
+	// We'll notify a number of settles before reading any of them, which
+	// shouldn't block the notifier.
+	const numEvents = 50
+	for i := 0; i < numEvents; i++ {
+		key := HtlcKey{
+			IncomingCircuit: CircuitKey{HtlcID: uint64(i)},
+		}
+		notifier.NotifySettleEvent(key, HtlcEventTypeForward)
+	}
+
+	for _, sub := range []*HtlcEventSubscription{sub1, sub2} {
+		for i := 0; i < numEvents; i++ {
+			var event interface{}
+			select {
+			case event = <-sub.Events:
+			case <-time.After(time.Second):
+				t.Fatalf("event %v not received", i)
+			}
+
+			settle, ok := event.(*SettleEvent)
+			switch {
+			case !ok:
+				t.Fatalf("expected settle event, got %T", event)
+
+			case settle.IncomingCircuit.HtlcID != uint64(i):
+				t.Fatalf("expected event %v, got %v", i,
+					settle.IncomingCircuit.HtlcID)
+
+			case !settle.Timestamp.Equal(timestamp):
+				t.Fatalf("expected timestamp %v, got %v",
+					timestamp, settle.Timestamp)
+			}
+		}
+	}
+
+	// Once the second subscription is canceled, only the first one should
+	// receive new events.
+	sub2.Cancel()
+	notifier.NotifyForwardingEvent(
+		HtlcKey{}, HtlcInfo{}, HtlcEventTypeSend,
+	)
+
+	select {
+	case event := <-sub1.Events:
+		if _, ok := event.(*ForwardingEvent); !ok {
+			t.Fatalf("expected forwarding event, got %T", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("event not received")
+	}
+
+	select {
+	case event := <-sub2.Events:
+		t.Fatalf("unexpected event after cancel: %v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestHtlcNotifierDropSlowClient tests that a subscriber which doesn't read its
+// events is dropped once too many of them are queued up, without affecting
+// other subscribers.
+func TestHtlcNotifierDropSlowClient(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewHtlcNotifier(time.Now)
+	defer notifier.Stop()
+
+	slowSub, err := notifier.SubscribeHtlcEvents()
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	defer slowSub.Cancel()
+
+	// Up to the maximum number of pending events, the subscription should
+	// remain active.
+	for i := 0; i < maxPendingHtlcEvents; i++ {
+		notifier.NotifySettleEvent(HtlcKey{}, HtlcEventTypeForward)
+	}
+
+	select {
+	case <-slowSub.Quit:
+		t.Fatalf("subscription dropped before reaching limit")
+	default:
+	}
+
+	// A new subscriber shouldn't be affected by the slow one being
+	// dropped with the next event.
+	sub, err := notifier.SubscribeHtlcEvents()
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	defer sub.Cancel()
+
+	notifier.NotifySettleEvent(HtlcKey{}, HtlcEventTypeForward)
+
+	select {
+	case <-slowSub.Quit:
+	case <-time.After(time.Second):
+		t.Fatalf("slow subscription not dropped")
+	}
+
+	select {
+	case <-sub.Events:
+	case <-time.After(time.Second):
+		t.Fatalf("event not received")
+	}
+
+	select {
+	case <-sub.Quit:
+		t.Fatalf("subscription unexpectedly dropped")
+	default:
+	}
+}
@@ -0,0 +1,164 @@
+package htlcswitch
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// ErrFwdAlreadyResolved is returned when an intercepted forward is
+	// resolved more than once.
+	ErrFwdAlreadyResolved = errors.New("intercepted forward already " +
+		"resolved")
+
+	// ErrInvalidPreimage is returned when an intercepted forward is
+	// settled with a preimage that doesn't match its payment hash.
+	ErrInvalidPreimage = errors.New("preimage doesn't match payment hash")
+)
+
+// InterceptedPacket contains the relevant information of an HTLC forward that
+// is handed to a ForwardInterceptor.
+type InterceptedPacket struct {
+	// IncomingCircuit is the circuit key of the incoming HTLC.
+	IncomingCircuit CircuitKey
+
+	// OutgoingChanID is the channel the sender requested the HTLC to be
+	// forwarded over.
+	OutgoingChanID lnwire.ShortChannelID
+
+	// Hash is the payment hash of the HTLC.
+	Hash [32]byte
+
+	// IncomingAmount is the amount of the incoming HTLC.
+	IncomingAmount lnwire.MilliSatoshi
+
+	// IncomingExpiry is the absolute block height at which the incoming
+	// HTLC expires.
+	IncomingExpiry uint32
+
+	// OutgoingAmount is the amount to forward to the next hop.
+	OutgoingAmount lnwire.MilliSatoshi
+
+	// OutgoingExpiry is the absolute block height at which the outgoing
+	// HTLC should expire.
+	OutgoingExpiry uint32
+
+	// OnionBlob is the onion packet destined for the next hop.
+	OnionBlob [lnwire.OnionPacketSize]byte
+}
+
+// InterceptedForward is an HTLC forward that has been handed to a
+// ForwardInterceptor, and awaits its resolution. Exactly one of Resume, Settle
+// or Fail must be called to resolve it.
+type InterceptedForward interface {
+	// Packet returns the information of the intercepted HTLC forward.
+	Packet() InterceptedPacket
+
+	// Resume continues the forward as if it had never been intercepted.
+	Resume() error
+
+	// Settle settles the incoming HTLC using the passed preimage, without
+	// forwarding it.
+	Settle(preimage [32]byte) error
+
+	// Fail fails the incoming HTLC back to the sender with the passed
+	// failure message, without forwarding it.
+	Fail(failure lnwire.FailureMessage) error
+}
+
+// ForwardInterceptor is a function that is handed every HTLC forward passing
+// through the switch, while an interceptor is registered. It is called within
+// its own goroutine, and must eventually resolve the forward.
+type ForwardInterceptor func(InterceptedForward)
+
+// interceptedForward implements the InterceptedForward interface for a packet
+// held by the switch.
+type interceptedForward struct {
+	// resolved is set to 1 once the forward has been resolved. It must
+	// be used atomically.
+	resolved uint32
+
+	packet     *htlcPacket
+	htlc       *lnwire.UpdateAddHTLC
+	htlcSwitch *Switch
+}
+
+// A compile-time assertion to ensure that interceptedForward implements the
+// InterceptedForward interface.
+var _ InterceptedForward = (*interceptedForward)(nil)
+
+// Packet returns the information of the intercepted HTLC forward.
+//
+// NOTE: Part of the InterceptedForward interface.
+func (f *interceptedForward) Packet() InterceptedPacket {
+	return InterceptedPacket{
+		IncomingCircuit: f.packet.inKey(),
+		OutgoingChanID:  f.packet.outgoingChanID,
+		Hash:            f.htlc.PaymentHash,
+		IncomingAmount:  f.packet.incomingAmount,
+		IncomingExpiry:  f.packet.incomingTimeout,
+		OutgoingAmount:  f.htlc.Amount,
+		OutgoingExpiry:  f.htlc.Expiry,
+		OnionBlob:       f.htlc.OnionBlob,
+	}
+}
+
+// Resume continues the forward as if it had never been intercepted.
+//
+// NOTE: Part of the InterceptedForward interface.
+func (f *interceptedForward) Resume() error {
+	if !f.markResolved() {
+		return ErrFwdAlreadyResolved
+	}
+
+	return f.htlcSwitch.route(f.packet)
+}
+
+// Settle settles the incoming HTLC using the passed preimage, without
+// forwarding it.
+//
+// NOTE: Part of the InterceptedForward interface.
+func (f *interceptedForward) Settle(preimage [32]byte) error {
+	if sha256.Sum256(preimage[:]) != f.htlc.PaymentHash {
+		return ErrInvalidPreimage
+	}
+
+	if !f.markResolved() {
+		return ErrFwdAlreadyResolved
+	}
+
+	return f.htlcSwitch.settleAddPacket(f.packet, preimage)
+}
+
+// Fail fails the incoming HTLC back to the sender with the passed failure
+// message, without forwarding it.
+//
+// NOTE: Part of the InterceptedForward interface.
+func (f *interceptedForward) Fail(failure lnwire.FailureMessage) error {
+	if !f.markResolved() {
+		return ErrFwdAlreadyResolved
+	}
+
+	// As failAddPacket returns the passed error once the failure has been
+	// sent back, only a different error signals that it didn't succeed.
+	failErr := fmt.Errorf("intercepted forward of %v failed: %v",
+		f.packet.inKey(), failure.Code())
+	err := f.htlcSwitch.failAddPacket(
+		f.packet, failure, FailureDetailIntercepted, failErr,
+	)
+	if err != failErr {
+		return err
+	}
+
+	return nil
+}
+
+// markResolved marks the forward as resolved, returning false if it already
+// was.
+func (f *interceptedForward) markResolved() bool {
+	return atomic.CompareAndSwapUint32(&f.resolved, 0, 1)
+}
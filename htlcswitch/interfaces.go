@@ -2,6 +2,7 @@ package htlcswitch
 
 import (
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -17,6 +18,58 @@ type InvoiceDatabase interface {
 	// SettleInvoice attempts to mark an invoice corresponding to the
 	// passed payment hash as fully settled.
 	SettleInvoice(chainhash.Hash) error
+
+	// AcceptInvoice marks the hold invoice corresponding to the passed
+	// payment hash as accepted, and subscribes the passed channel to the
+	// final resolution of the invoice. Once the invoice is either settled
+	// or canceled, a HodlEvent is sent on the channel.
+	AcceptInvoice(chainhash.Hash, chan<- interface{}) error
+
+	// AcceptInvoiceShard adds an HTLC paying part of the invoice
+	// corresponding to the passed payment hash to the set of shards
+	// received for it, and subscribes the passed channel to the
+	// resolution of the set. Once the shards add up to the invoice amount,
+	// or the set times out, a HodlEvent is sent on the channel.
+	AcceptInvoiceShard(chainhash.Hash, channeldb.CircuitKey,
+		lnwire.MilliSatoshi, chan<- interface{}) error
+
+	// AddKeySendInvoice creates an invoice for a spontaneous payment of
+	// the passed amount, which is settled using the preimage delivered by
+	// the sender. An error is returned if spontaneous payments aren't
+	// accepted.
+	AddKeySendInvoice([32]byte, lnwire.MilliSatoshi) error
+
+	// FailInvoiceShards fails back all shards held for the invoice
+	// corresponding to the passed payment hash, if its set of shards
+	// hasn't added up to the invoice amount yet. A HodlEvent is sent to
+	// all channels subscribed to the resolution of the set.
+	FailInvoiceShards(chainhash.Hash)
+
+	// CancelInvoice cancels the invoice corresponding to the passed payment
+	// hash, such that any HTLCs held for it are failed back, and future
+	// HTLCs paying to it are rejected.
+	CancelInvoice(chainhash.Hash) error
+
+	// HodlUnsubscribeAll unsubscribes the passed channel from all hold
+	// invoice resolutions it was subscribed to.
+	HodlUnsubscribeAll(chan<- interface{})
+}
+
+// HodlEvent describes the resolution of a hold invoice, or of a set of shards
+// of a multi-path payment, and is delivered to the links holding HTLCs paying
+// to it.
+type HodlEvent struct {
+	// Hash is the payment hash of the resolved invoice.
+	Hash chainhash.Hash
+
+	// Preimage is the preimage that settles the held HTLCs. If nil, the
+	// invoice was canceled and the held HTLCs are to be failed back.
+	Preimage *[32]byte
+
+	// MPPTimeout is true if the held HTLCs are shards of a multi-path
+	// payment that didn't add up to the invoice amount in time, or that
+	// won't do so anymore, as one of its shards was rejected.
+	MPPTimeout bool
 }
 
 // ChannelLink is an interface which represents the subsystem for managing the
@@ -137,3 +190,14 @@ type ForwardingLog interface {
 	// visualizations, etc.
 	AddForwardingEvents([]channeldb.ForwardingEvent) error
 }
+
+// TowerClient is the primary interface used by the daemon to backup pre-signed
+// justice transactions to watchtowers.
+type TowerClient interface {
+	// BackupState initiates a request to back up a particular revoked
+	// state. If the method returns nil, the backup is guaranteed to be
+	// successful unless the tower is unavailable and client is force quit,
+	// or the justice transaction would create dust outputs when trying to
+	// abide by the negotiated policy.
+	BackupState(*lnwire.ChannelID, *lnwallet.BreachRetribution) error
+}
@@ -1,6 +1,7 @@
 package htlcswitch
 
 import (
+	"bytes"
 	"encoding/binary"
 	"io"
 
@@ -25,6 +26,12 @@ const (
 	LitecoinHop
 )
 
+// KeySendRealm is the realm byte set by the sender of a spontaneous payment
+// within the per-hop payload of the final hop. It signals that the onion
+// contains one additional frame, again addressed to the final hop, which
+// carries the payment preimage chosen by the sender.
+const KeySendRealm byte = 1
+
 // String returns the string representation of the target NetworkHop.
 func (c NetworkHop) String() string {
 	switch c {
@@ -70,6 +77,16 @@ type ForwardingInfo struct {
 	// in the outgoing HTLC.
 	OutgoingCTLV uint32
 
+	// KeySendPreimage is the preimage of a spontaneous payment, delivered
+	// to the final hop within the onion. It is nil for regular payments
+	// made to an invoice.
+	KeySendPreimage *[32]byte
+
+	// MultiPathTotal is the total amount of the multi-path payment this
+	// HTLC is a shard of, as signalled by the sender to the final hop. It
+	// is zero if the sender didn't split the payment.
+	MultiPathTotal lnwire.MilliSatoshi
+
 	// TODO(roasbeef): modify sphinx logic to not just discard the
 	// remaining bytes, instead should include the rest as excess
 }
@@ -109,6 +126,11 @@ type sphinxHopIterator struct {
 	// includes the information required to properly forward the packet to
 	// the next hop.
 	processedPacket *sphinx.ProcessedPacket
+
+	// keySendPreimage is the preimage of a spontaneous payment, extracted
+	// from the additional frame of the onion addressed to us as the final
+	// hop.
+	keySendPreimage *[32]byte
 }
 
 // makeSphinxHopIterator converts a processed packet returned from a sphinx
@@ -122,6 +144,63 @@ func makeSphinxHopIterator(ogPacket *sphinx.OnionPacket,
 	}
 }
 
+// NewKeySendHopData encodes the preimage of a spontaneous payment into the
+// per-hop payload of the additional onion frame addressed to the final hop.
+// As the preimage is exactly as large as the fields of the payload following
+// the realm byte, it's spread across all of them.
+func NewKeySendHopData(preimage [32]byte) sphinx.HopData {
+	hopData := sphinx.HopData{
+		Realm: KeySendRealm,
+	}
+
+	copy(hopData.NextAddress[:], preimage[:8])
+	hopData.ForwardAmount = binary.BigEndian.Uint64(preimage[8:16])
+	hopData.OutgoingCltv = binary.BigEndian.Uint32(preimage[16:20])
+	copy(hopData.ExtraBytes[:], preimage[20:])
+
+	return hopData
+}
+
+// decodeKeySendPreimage extracts the preimage of a spontaneous payment from
+// the per-hop payload it was encoded into by NewKeySendHopData.
+func decodeKeySendPreimage(hopData *sphinx.HopData) [32]byte {
+	var preimage [32]byte
+	copy(preimage[:8], hopData.NextAddress[:])
+	binary.BigEndian.PutUint64(preimage[8:16], hopData.ForwardAmount)
+	binary.BigEndian.PutUint32(preimage[16:20], hopData.OutgoingCltv)
+	copy(preimage[20:], hopData.ExtraBytes[:])
+
+	return preimage
+}
+
+// SetMultiPathTotal signals to the final hop that the HTLC is a shard of a
+// multi-path payment of the passed total amount. The amount is encoded into
+// the otherwise unused padding of the final hop's per-hop payload, which is
+// all zeroes for payments that aren't split.
+func SetMultiPathTotal(hopData *sphinx.HopData, totalAmt lnwire.MilliSatoshi) {
+	binary.BigEndian.PutUint64(hopData.ExtraBytes[:8], uint64(totalAmt))
+}
+
+// decodeMultiPathTotal extracts the total amount of a multi-path payment from
+// the per-hop payload it was encoded into by SetMultiPathTotal.
+func decodeMultiPathTotal(hopData *sphinx.HopData) lnwire.MilliSatoshi {
+	return lnwire.MilliSatoshi(
+		binary.BigEndian.Uint64(hopData.ExtraBytes[:8]),
+	)
+}
+
+// isKeySendPacket returns true if the processed packet is addressed to us as
+// the final hop of a spontaneous payment, meaning its next frame carries the
+// payment preimage.
+func isKeySendPacket(packet *sphinx.ProcessedPacket) bool {
+	var zeroAddress [8]byte
+
+	fwdInst := packet.ForwardingInstructions
+	return packet.Action == sphinx.MoreHops &&
+		fwdInst.Realm == KeySendRealm &&
+		bytes.Equal(fwdInst.NextAddress[:], zeroAddress[:])
+}
+
 // A compile time check to ensure sphinxHopIterator implements the HopIterator
 // interface.
 var _ HopIterator = (*sphinxHopIterator)(nil)
@@ -142,10 +221,14 @@ func (r *sphinxHopIterator) EncodeNextHop(w io.Writer) error {
 func (r *sphinxHopIterator) ForwardingInstructions() ForwardingInfo {
 	fwdInst := r.processedPacket.ForwardingInstructions
 
-	var nextHop lnwire.ShortChannelID
+	var (
+		nextHop        lnwire.ShortChannelID
+		multiPathTotal lnwire.MilliSatoshi
+	)
 	switch r.processedPacket.Action {
 	case sphinx.ExitNode:
 		nextHop = exitHop
+		multiPathTotal = decodeMultiPathTotal(&fwdInst)
 	case sphinx.MoreHops:
 		s := binary.BigEndian.Uint64(fwdInst.NextAddress[:])
 		nextHop = lnwire.NewShortChanIDFromInt(s)
@@ -156,6 +239,8 @@ func (r *sphinxHopIterator) ForwardingInstructions() ForwardingInfo {
 		NextHop:         nextHop,
 		AmountToForward: lnwire.MilliSatoshi(fwdInst.ForwardAmount),
 		OutgoingCTLV:    fwdInst.OutgoingCltv,
+		KeySendPreimage: r.keySendPreimage,
+		MultiPathTotal:  multiPathTotal,
 	}
 }
 
@@ -394,9 +479,119 @@ func (p *OnionProcessor) DecodeHopIterators(id []byte,
 		resp.HopIterator = makeSphinxHopIterator(&onionPkts[i], &packets[i])
 	}
 
+	// Spontaneous payments carry their preimage in an additional frame
+	// addressed to us, which we'll need to process as well.
+	if err := p.decodeKeySendPreimages(id, reqs, resps); err != nil {
+		return resps, err
+	}
+
 	return resps, nil
 }
 
+// decodeKeySendPreimages processes the additional onion frame of all packets
+// within the batch that are spontaneous payments to us, and extracts the
+// payment preimages from them. The frames are processed as a batch of their
+// own, derived from the passed batch id, such that subsequent invocations for
+// the same id yield the same results, rather than being detected as replays.
+func (p *OnionProcessor) decodeKeySendPreimages(id []byte,
+	reqs []DecodeHopIteratorRequest,
+	resps []DecodeHopIteratorResponse) error {
+
+	var keySendIdxs []int
+	for i := range resps {
+		iterator, ok := resps[i].HopIterator.(*sphinxHopIterator)
+		if !ok || !isKeySendPacket(iterator.processedPacket) {
+			continue
+		}
+
+		keySendIdxs = append(keySendIdxs, i)
+	}
+
+	if len(keySendIdxs) == 0 {
+		return nil
+	}
+
+	keySendID := append(append([]byte{}, id...), []byte("keysend")...)
+	tx := p.router.BeginTxn(keySendID, len(keySendIdxs))
+
+	for seqNum, i := range keySendIdxs {
+		iterator := resps[i].HopIterator.(*sphinxHopIterator)
+		err := tx.ProcessOnionPacket(
+			uint16(seqNum), iterator.processedPacket.NextPacket,
+			reqs[i].RHash, reqs[i].IncomingCltv,
+		)
+		switch err {
+		case nil:
+			// success
+
+		case sphinx.ErrInvalidOnionVersion:
+			resps[i].HopIterator = nil
+			resps[i].FailCode = lnwire.CodeInvalidOnionVersion
+
+		case sphinx.ErrInvalidOnionHMAC:
+			resps[i].HopIterator = nil
+			resps[i].FailCode = lnwire.CodeInvalidOnionHmac
+
+		default:
+			log.Errorf("unable to process keysend onion frame: %v",
+				err)
+			resps[i].HopIterator = nil
+			resps[i].FailCode = lnwire.CodeInvalidOnionKey
+		}
+	}
+
+	packets, replays, err := tx.Commit()
+	if err != nil {
+		log.Errorf("unable to process keysend onion frames of batch "+
+			"%x: %v", id, err)
+
+		for _, i := range keySendIdxs {
+			if resps[i].FailCode != lnwire.CodeNone {
+				continue
+			}
+
+			resps[i].HopIterator = nil
+			resps[i].FailCode = lnwire.CodeTemporaryChannelFailure
+		}
+
+		return err
+	}
+
+	for seqNum, i := range keySendIdxs {
+		resp := &resps[i]
+		if resp.FailCode != lnwire.CodeNone {
+			continue
+		}
+
+		if replays.Contains(uint16(seqNum)) {
+			log.Errorf("unable to process keysend onion frame: %v",
+				sphinx.ErrReplayedPacket)
+			resp.HopIterator = nil
+			resp.FailCode = lnwire.CodeTemporaryChannelFailure
+			continue
+		}
+
+		// The frame carrying the preimage must be the very last one
+		// within the onion.
+		packet := &packets[seqNum]
+		if packet.Action != sphinx.ExitNode ||
+			packet.ForwardingInstructions.Realm != KeySendRealm {
+
+			log.Errorf("keysend onion frame isn't the final " +
+				"frame of the onion")
+			resp.HopIterator = nil
+			resp.FailCode = lnwire.CodeInvalidRealm
+			continue
+		}
+
+		preimage := decodeKeySendPreimage(&packet.ForwardingInstructions)
+		iterator := resp.HopIterator.(*sphinxHopIterator)
+		iterator.keySendPreimage = &preimage
+	}
+
+	return nil
+}
+
 // ExtractErrorEncrypter takes an io.Reader which should contain the onion
 // packet as original received by a forwarding node and creates an
 // ErrorEncrypter instance using the derived shared secret. In the case that en
@@ -17,6 +17,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
 )
 
 const (
@@ -29,6 +30,13 @@ const (
 	//
 	// TODO(roasbeef): must be < default delta
 	expiryGraceDelta = 2
+
+	// maxCommitFeeIncrease is the largest factor by which we'll increase
+	// the commitment fee rate of a channel within a single fee update. This
+	// ensures a single faulty fee estimate can't cause the commitment fee
+	// to blow out, as the fee rate needs to be sampled over several blocks
+	// to reach such an estimate.
+	maxCommitFeeIncrease = 10
 )
 
 // ErrInternalLinkFailure is a generic error returned to the remote party so as
@@ -113,6 +121,13 @@ func (t *BatchTicker) Stop() {
 	t.ticker.Stop()
 }
 
+// hodlHtlc contains the information required to settle or fail an incoming
+// HTLC that pays to a hold invoice once the invoice is resolved.
+type hodlHtlc struct {
+	pd         *lnwallet.PaymentDescriptor
+	obfuscator ErrorEncrypter
+}
+
 // ChannelLinkConfig defines the configuration for the channel link. ALL
 // elements within the configuration MUST be non-nil for channel link to carry
 // out its duties.
@@ -181,6 +196,12 @@ type ChannelLinkConfig struct {
 	// channel.
 	ChainEvents *contractcourt.ChainEventSubscription
 
+	// ForceCloseChannel is a function closure that will be used to force
+	// close the channel by broadcasting our latest commitment transaction.
+	// The link calls this once it detects that the remote party has lost
+	// state, as this allows them to sweep their funds on-chain.
+	ForceCloseChannel func(wire.OutPoint) error
+
 	// FeeEstimator is an instance of a live fee estimator which will be
 	// used to dynamically regulate the current fee of the commitment
 	// transaction to ensure timely confirmation.
@@ -193,6 +214,25 @@ type ChannelLinkConfig struct {
 	// (or are close to expiry).
 	BlockEpochs *chainntnfs.BlockEpochEvent
 
+	// HodlExpiryDelta is the number of blocks before its expiry at which
+	// an HTLC held for a hold invoice or a multi-path payment is canceled
+	// back to its sender, so that it doesn't need to be resolved on-chain.
+	HodlExpiryDelta uint32
+
+	// NotifyHtlcFailure is an optional closure that's called with the
+	// onion failure code of each HTLC the link fails back to its sender.
+	NotifyHtlcFailure func(lnwire.FailCode)
+
+	// HtlcNotifier is used to notify subscribers of the HTLCs that are
+	// forwarded, failed and settled by the link.
+	HtlcNotifier *HtlcNotifier
+
+	// TowerClient is an optional engine that manages the signing,
+	// encrypting, and uploading of justice transactions to the daemon's
+	// configured set of watchtowers. If nil, revoked states will not be
+	// backed up.
+	TowerClient TowerClient
+
 	// DebugHTLC should be turned on if you want all HTLCs sent to a node
 	// with the debug htlc R-Hash are immediately settled in the next
 	// available state transition.
@@ -291,6 +331,15 @@ type channelLink struct {
 	// been processed because of the commitment transaction overflow.
 	overflowQueue *packetQueue
 
+	// hodlMap stores the incoming HTLCs paying to hold invoices, indexed
+	// by payment hash. These HTLCs are neither settled nor failed until
+	// the invoice they pay to is resolved.
+	hodlMap map[chainhash.Hash][]hodlHtlc
+
+	// hodlQueue is used to receive the resolutions of hold invoices from
+	// the invoice registry.
+	hodlQueue *chainntnfs.ConcurrentQueue
+
 	// startMailBox directs whether or not to start the mailbox when
 	// starting the link. It may have already been started by the switch.
 	startMailBox bool
@@ -346,6 +395,8 @@ func NewChannelLink(cfg ChannelLinkConfig, channel *lnwallet.LightningChannel,
 		// TODO(roasbeef): just do reserve here?
 		logCommitTimer: time.NewTimer(300 * time.Millisecond),
 		overflowQueue:  newPacketQueue(lnwallet.MaxHTLCNumber / 2),
+		hodlMap:        make(map[chainhash.Hash][]hodlHtlc),
+		hodlQueue:      chainntnfs.NewConcurrentQueue(10),
 		bestHeight:     currentHeight,
 		htlcUpdates:    make(chan []channeldb.HTLC),
 		quit:           make(chan struct{}),
@@ -386,6 +437,7 @@ func (l *channelLink) Start() error {
 
 	l.mailBox.ResetMessages()
 	l.overflowQueue.Start()
+	l.hodlQueue.Start()
 
 	l.wg.Add(1)
 	go l.htlcManager()
@@ -413,6 +465,13 @@ func (l *channelLink) Stop() {
 
 	l.overflowQueue.Stop()
 
+	// Ensure the registry no longer delivers hold invoice resolutions to
+	// this link before tearing down the queue they're sent on.
+	if atomic.LoadInt32(&l.started) == 1 {
+		l.cfg.Registry.HodlUnsubscribeAll(l.hodlQueue.ChanIn())
+		l.hodlQueue.Stop()
+	}
+
 	close(l.quit)
 	l.wg.Wait()
 }
@@ -467,6 +526,20 @@ func shouldAdjustCommitFee(netFee, chanFee lnwallet.SatPerKWeight) bool {
 	}
 }
 
+// boundCommitFeeUpdate returns the fee rate the commitment fee should be
+// updated to given the current network fee, capping any increase to
+// maxCommitFeeIncrease times the current commitment fee.
+func boundCommitFeeUpdate(netFee,
+	chanFee lnwallet.SatPerKWeight) lnwallet.SatPerKWeight {
+
+	maxFee := chanFee * maxCommitFeeIncrease
+	if chanFee > 0 && netFee > maxFee {
+		return maxFee
+	}
+
+	return netFee
+}
+
 // syncChanState attempts to synchronize channel states with the remote party.
 // This method is to be called upon reconnection after the initial funding
 // flow. We'll compare out commitment chains with the remote party, and re-send
@@ -546,9 +619,45 @@ func (l *channelLink) syncChanStates() error {
 		// if we need to re-transmit any messages to the remote party.
 		msgsToReSend, openedCircuits, closedCircuits, err =
 			l.channel.ProcessChanSyncMsg(remoteChanSyncMsg)
-		if err != nil {
-			// TODO(roasbeef): check concrete type of error, act
-			// accordingly
+		switch {
+		case err == nil:
+
+		// If the remote party has lost state, then we'll force close
+		// the channel using our latest commitment, as this is the only
+		// way for them to recover their funds. The force close is
+		// carried out asynchronously, as it'll require this link to be
+		// removed from the switch.
+		case err == lnwallet.ErrCommitSyncRemoteDataLoss:
+			chanPoint := *l.channel.ChannelPoint()
+			go func() {
+				if err := l.cfg.ForceCloseChannel(chanPoint); err != nil {
+					log.Errorf("unable to force close "+
+						"ChannelPoint(%v): %v", chanPoint, err)
+				}
+			}()
+
+			return fmt.Errorf("unable to handle upstream reestablish "+
+				"message: %v", err)
+
+		// If we've lost state, then we'll store the commitment point
+		// sent by the remote party, as this will allow us to sweep our
+		// funds once they force close the channel.
+		case err == lnwallet.ErrCommitSyncDataLoss &&
+			remoteChanSyncMsg.LocalUnrevokedCommitPoint != nil:
+
+			commitPoint := remoteChanSyncMsg.LocalUnrevokedCommitPoint
+			if err := l.channel.State().MarkDataLoss(
+				commitPoint,
+			); err != nil {
+				log.Errorf("unable to mark data loss for "+
+					"ChannelPoint(%v): %v",
+					l.channel.ChannelPoint(), err)
+			}
+
+			return fmt.Errorf("unable to handle upstream reestablish "+
+				"message: %v", err)
+
+		default:
 			return fmt.Errorf("unable to handle upstream reestablish "+
 				"message: %v", err)
 		}
@@ -800,6 +909,10 @@ out:
 
 			l.bestHeight = uint32(blockEpoch.Height)
 
+			// With the new height known, we'll cancel any held
+			// HTLCs that are getting too close to their expiry.
+			l.cancelExpiringHodlHtlcs()
+
 			// If we're not the initiator of the channel, don't we
 			// don't control the fees, so we can ignore this.
 			if !l.channel.IsInitiator() {
@@ -822,6 +935,19 @@ out:
 				continue
 			}
 
+			// Before updating, we'll bound the increase of the fee
+			// rate, in case the sampled fee is far off from the
+			// current one.
+			boundedFee := boundCommitFeeUpdate(feePerKw, commitFee)
+			if boundedFee != feePerKw {
+				log.Warnf("ChannelLink(%v): sampled fee "+
+					"rate of %v sat/kw exceeds bound, "+
+					"using %v sat/kw", l, int64(feePerKw),
+					int64(boundedFee))
+
+				feePerKw = boundedFee
+			}
+
 			// If we do, then we'll send a new UpdateFee message to
 			// the remote party, to be locked in with a new update.
 			if err := l.updateChannelFee(feePerKw); err != nil {
@@ -892,6 +1018,16 @@ out:
 
 			l.handleDownStreamPkt(packet, true)
 
+		// A hold invoice that we're holding HTLCs for has been
+		// resolved, so we'll either settle or fail the HTLCs back to
+		// the remote party.
+		case item := <-l.hodlQueue.ChanOut():
+			event := item.(*HodlEvent)
+			if err := l.processHodlEvent(event); err != nil {
+				l.fail("unable to process hodl event: %v", err)
+				break out
+			}
+
 		// A message from the switch was just received. This indicates
 		// that the link is an intermediate hop in a multi-hop HTLC
 		// circuit.
@@ -995,6 +1131,12 @@ func (l *channelLink) handleDownStreamPkt(pkt *htlcPacket, isReProcess bool) {
 
 				failure := lnwire.NewTemporaryChannelFailure(nil)
 
+				l.cfg.HtlcNotifier.NotifyLinkFailEvent(
+					newHtlcKey(pkt), newHtlcInfo(pkt, htlc),
+					getEventType(pkt), failure,
+					FailureDetailAddRejected, false,
+				)
+
 				// Encrypt the error back to the source unless the payment was
 				// generated locally.
 				if pkt.obfuscator == nil {
@@ -1060,6 +1202,11 @@ func (l *channelLink) handleDownStreamPkt(pkt *htlcPacket, isReProcess bool) {
 
 		l.cfg.Peer.SendMessage(htlc)
 
+		l.cfg.HtlcNotifier.NotifyForwardingEvent(
+			newHtlcKey(pkt), newHtlcInfo(pkt, htlc),
+			getEventType(pkt),
+		)
+
 	case *lnwire.UpdateFulfillHTLC:
 		// An HTLC we forward to the switch has just settled somewhere
 		// upstream. Therefore we settle the HTLC within the our local
@@ -1311,6 +1458,15 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 			return
 		}
 
+		// If we have a tower client, we'll proceed in backing up the
+		// state that was just revoked.
+		if l.cfg.TowerClient != nil {
+			if err := l.backupRevokedState(); err != nil {
+				l.fail("unable to queue breach backup: %v", err)
+				return
+			}
+		}
+
 		l.processRemoteSettleFails(fwdPkg, settleFails)
 
 		needUpdate := l.processRemoteAdds(fwdPkg, adds)
@@ -1422,6 +1578,32 @@ func (l *channelLink) ackDownStreamPackets(forgive bool) error {
 	return nil
 }
 
+// backupRevokedState constructs the breach retribution for the remote
+// commitment that was just revoked, and hands it off to the tower client so
+// that the justice transaction can be backed up to a watchtower.
+func (l *channelLink) backupRevokedState() error {
+	state := l.channel.State()
+
+	// Since the remote commitment chain's tail was just advanced, the
+	// revoked state is the one directly preceding the current remote
+	// commitment.
+	revokedHeight := state.RemoteCommitment.CommitHeight - 1
+	revokedCommit, err := state.FindPreviousState(revokedHeight)
+	if err != nil {
+		return err
+	}
+
+	breachInfo, err := lnwallet.NewBreachRetribution(
+		state, revokedHeight, revokedCommit.CommitTx, 0,
+	)
+	if err != nil {
+		return err
+	}
+
+	chanID := l.ChanID()
+	return l.cfg.TowerClient.BackupState(&chanID, breachInfo)
+}
+
 // updateCommitTx signs, then sends an update to the remote peer adding a new
 // commitment to their commitment chain which includes all the latest updates
 // we've received+processed up to this point.
@@ -1831,8 +2013,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// If we're unable to process the onion blob than we
 			// should send the malformed htlc error to payment
 			// sender.
-			l.sendMalformedHTLCError(pd.HtlcIndex, failureCode,
-				onionBlob[:], pd.SourceRef)
+			l.sendMalformedHTLCError(
+				pd, failureCode, onionBlob[:],
+			)
 			needUpdate = true
 
 			log.Errorf("unable to decode onion hop "+
@@ -1849,8 +2032,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// If we're unable to process the onion blob than we
 			// should send the malformed htlc error to payment
 			// sender.
-			l.sendMalformedHTLCError(pd.HtlcIndex, failureCode,
-				onionBlob[:], pd.SourceRef)
+			l.sendMalformedHTLCError(
+				pd, failureCode, onionBlob[:],
+			)
 			needUpdate = true
 
 			log.Errorf("unable to decode onion "+
@@ -1879,7 +2063,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 
 				failure := lnwire.FailFinalIncorrectCltvExpiry{}
 				l.sendHTLCError(
-					pd.HtlcIndex, &failure, obfuscator, pd.SourceRef,
+					pd, &failure, obfuscator,
+					HtlcEventTypeReceive,
+					FailureDetailExpiryTooSoon,
 				)
 				needUpdate = true
 				continue
@@ -1889,13 +2075,42 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// we attempt to see if we have an invoice locally
 			// which'll allow us to settle this htlc.
 			invoiceHash := chainhash.Hash(pd.RHash)
+
+			// If this is a spontaneous payment, the sender has
+			// handed us the preimage within the onion, so we'll
+			// first create an invoice for it, which is then
+			// settled like any other.
+			if fwdInfo.KeySendPreimage != nil {
+				err := l.addKeySendInvoice(
+					invoiceHash, *fwdInfo.KeySendPreimage,
+					pd.Amount,
+				)
+				if err != nil {
+					log.Errorf("rejecting keysend "+
+						"htlc(%x): %v", pd.RHash[:],
+						err)
+
+					failure := lnwire.FailUnknownPaymentHash{}
+					l.sendHTLCError(
+						pd, failure, obfuscator,
+						HtlcEventTypeReceive,
+						FailureDetailInvalidKeySend,
+					)
+
+					needUpdate = true
+					continue
+				}
+			}
+
 			invoice, err := l.cfg.Registry.LookupInvoice(invoiceHash)
 			if err != nil {
 				log.Errorf("unable to query invoice registry: "+
 					" %v", err)
 				failure := lnwire.FailUnknownPaymentHash{}
 				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+					pd, failure, obfuscator,
+					HtlcEventTypeReceive,
+					FailureDetailUnknownInvoice,
 				)
 
 				needUpdate = true
@@ -1919,32 +2134,67 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// TODO(conner): track ownership of settlements to
 			// properly recover from failures? or add batch invoice
 			// settlement
-			if invoice.Terms.Settled {
+			if invoice.Terms.State == channeldb.ContractSettled {
 				log.Warnf("Accepting duplicate payment for "+
 					"hash=%x", pd.RHash[:])
 			}
 
+			// If the invoice has been canceled or has expired,
+			// we'll reject the HTLC as if we had never heard of
+			// the payment hash.
+			if invoice.Terms.State == channeldb.ContractCanceled ||
+				invoice.Terms.State == channeldb.ContractExpired {
+
+				log.Errorf("rejecting htlc(%x) paying to "+
+					"%v invoice", pd.RHash[:],
+					invoice.Terms.State)
+
+				failure := lnwire.FailUnknownPaymentHash{}
+				l.sendHTLCError(
+					pd, failure, obfuscator,
+					HtlcEventTypeReceive,
+					FailureDetailInvoiceCanceled,
+				)
+
+				needUpdate = true
+				continue
+			}
+
 			// If we're not currently in debug mode, and the
 			// extended htlc doesn't meet the value requested, then
-			// we'll fail the htlc.  Otherwise, we settle this htlc
-			// within our local state update log, then send the
-			// update entry to the remote party.
+			// it's either a shard of a multi-path payment, or
+			// we'll fail the htlc. An htlc is only considered a
+			// shard if the sender signalled the total amount of
+			// the payment within the onion, and that total covers
+			// the invoice. Shards are held until enough of them
+			// have arrived to pay the invoice in full. Otherwise,
+			// we settle this htlc within our local state update
+			// log, then send the update entry to the remote party.
 			//
 			// NOTE: We make an exception when the value requested
 			// by the invoice is zero. This means the invoice
 			// allows the payee to specify the amount of satoshis
 			// they wish to send.  So since we expect the htlc to
 			// have a different amount, we should not fail.
-			if !l.cfg.DebugHTLC && invoice.Terms.Value > 0 &&
-				pd.Amount < invoice.Terms.Value {
+			underpaid := !l.cfg.DebugHTLC &&
+				invoice.Terms.Value > 0 &&
+				pd.Amount < invoice.Terms.Value
 
+			// Once the invoice has been settled, there's no set
+			// of shards left to add to.
+			isShard := underpaid &&
+				fwdInfo.MultiPathTotal >= invoice.Terms.Value &&
+				invoice.Terms.State != channeldb.ContractSettled
+
+			if underpaid && !isShard {
 				log.Errorf("rejecting htlc due to incorrect "+
 					"amount: expected %v, received %v",
 					invoice.Terms.Value, pd.Amount)
 
 				failure := lnwire.FailIncorrectPaymentAmount{}
-				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+				l.failExitHopHtlc(
+					pd, fwdInfo, failure, obfuscator,
+					FailureDetailIncorrectAmount,
 				)
 
 				needUpdate = true
@@ -1961,17 +2211,25 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// allows the payee to specify the amount of satoshis
 			// they wish to send.  So since we expect the htlc to
 			// have a different amount, we should not fail.
+			//
+			// The onion payload of a shard must match the amount
+			// of the HTLC itself, rather than that of the invoice.
+			expectedAmt := invoice.Terms.Value
+			if isShard {
+				expectedAmt = pd.Amount
+			}
 			if !l.cfg.DebugHTLC && invoice.Terms.Value > 0 &&
-				fwdInfo.AmountToForward != invoice.Terms.Value {
+				fwdInfo.AmountToForward != expectedAmt {
 
 				log.Errorf("Onion payload of incoming htlc(%x) "+
 					"has incorrect value: expected %v, "+
-					"got %v", pd.RHash, invoice.Terms.Value,
+					"got %v", pd.RHash, expectedAmt,
 					fwdInfo.AmountToForward)
 
 				failure := lnwire.FailIncorrectPaymentAmount{}
-				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+				l.failExitHopHtlc(
+					pd, fwdInfo, failure, obfuscator,
+					FailureDetailIncorrectAmount,
 				)
 
 				needUpdate = true
@@ -1995,8 +2253,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				failure := lnwire.NewFinalIncorrectCltvExpiry(
 					fwdInfo.OutgoingCTLV,
 				)
-				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+				l.failExitHopHtlc(
+					pd, fwdInfo, failure, obfuscator,
+					FailureDetailIncorrectCltvExpiry,
 				)
 
 				needUpdate = true
@@ -2011,15 +2270,70 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				failure := lnwire.NewFinalIncorrectCltvExpiry(
 					fwdInfo.OutgoingCTLV,
 				)
-				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+				l.failExitHopHtlc(
+					pd, fwdInfo, failure, obfuscator,
+					FailureDetailIncorrectCltvExpiry,
 				)
 
 				needUpdate = true
 				continue
 			}
 
+			// If this HTLC is a shard of a multi-path payment,
+			// we'll hand it to the registry, and hold on to it
+			// until the shards received add up to the invoice
+			// amount, or the payment times out.
+			if isShard {
+				err := l.cfg.Registry.AcceptInvoiceShard(
+					invoiceHash, channeldb.CircuitKey{
+						ChanID: l.ShortChanID(),
+						HtlcID: pd.HtlcIndex,
+					}, pd.Amount, l.hodlQueue.ChanIn(),
+				)
+				if err != nil {
+					l.fail("unable to accept invoice "+
+						"shard: %v", err)
+					return false
+				}
+
+				l.hodlMap[invoiceHash] = append(
+					l.hodlMap[invoiceHash], hodlHtlc{
+						pd:         pd,
+						obfuscator: obfuscator,
+					},
+				)
+
+				l.infof("holding shard %x of %v as exit hop",
+					pd.RHash, pd.Amount)
+				continue
+			}
+
+			// If the preimage of the invoice isn't yet known, then
+			// this is a hold invoice. We'll mark the invoice as
+			// accepted and hold on to the HTLC until the invoice
+			// is either settled or canceled.
 			preimage := invoice.Terms.PaymentPreimage
+			if preimage == channeldb.UnknownPreimage {
+				err := l.cfg.Registry.AcceptInvoice(
+					invoiceHash, l.hodlQueue.ChanIn(),
+				)
+				if err != nil {
+					l.fail("unable to accept invoice: %v",
+						err)
+					return false
+				}
+
+				l.hodlMap[invoiceHash] = append(
+					l.hodlMap[invoiceHash], hodlHtlc{
+						pd:         pd,
+						obfuscator: obfuscator,
+					},
+				)
+
+				l.infof("holding %x as exit hop", pd.RHash)
+				continue
+			}
+
 			err = l.channel.SettleHTLC(preimage,
 				pd.HtlcIndex, pd.SourceRef, nil, nil)
 			if err != nil {
@@ -2046,6 +2360,10 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			})
 			needUpdate = true
 
+			l.cfg.HtlcNotifier.NotifySettleEvent(
+				l.incomingHtlcKey(pd), HtlcEventTypeReceive,
+			)
+
 		// There are additional channels left within this route. So
 		// we'll verify that our forwarding constraints have been
 		// properly met by by this incoming HTLC.
@@ -2081,14 +2399,15 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				chanIterator.EncodeNextHop(buf)
 
 				updatePacket := &htlcPacket{
-					incomingChanID: l.ShortChanID(),
-					incomingHTLCID: pd.HtlcIndex,
-					outgoingChanID: fwdInfo.NextHop,
-					sourceRef:      pd.SourceRef,
-					incomingAmount: pd.Amount,
-					amount:         addMsg.Amount,
-					htlc:           addMsg,
-					obfuscator:     obfuscator,
+					incomingChanID:  l.ShortChanID(),
+					incomingHTLCID:  pd.HtlcIndex,
+					outgoingChanID:  fwdInfo.NextHop,
+					sourceRef:       pd.SourceRef,
+					incomingAmount:  pd.Amount,
+					incomingTimeout: pd.Timeout,
+					amount:          addMsg.Amount,
+					htlc:            addMsg,
+					obfuscator:      obfuscator,
 				}
 				switchPackets = append(switchPackets,
 					updatePacket)
@@ -2116,7 +2435,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				}
 
 				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+					pd, failure, obfuscator,
+					HtlcEventTypeForward,
+					FailureDetailExpiryTooSoon,
 				)
 				needUpdate = true
 				continue
@@ -2144,7 +2465,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				}
 
 				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+					pd, failure, obfuscator,
+					HtlcEventTypeForward,
+					FailureDetailAmountBelowMinimum,
 				)
 				needUpdate = true
 				continue
@@ -2187,7 +2510,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				}
 
 				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+					pd, failure, obfuscator,
+					HtlcEventTypeForward,
+					FailureDetailFeeInsufficient,
 				)
 				needUpdate = true
 				continue
@@ -2219,7 +2544,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				failure := lnwire.NewIncorrectCltvExpiry(
 					pd.Timeout, *update)
 				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+					pd, failure, obfuscator,
+					HtlcEventTypeForward,
+					FailureDetailIncorrectCltvExpiry,
 				)
 
 				needUpdate = true
@@ -2249,7 +2576,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				failure := lnwire.NewTemporaryChannelFailure(nil)
 
 				l.sendHTLCError(
-					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+					pd, failure, obfuscator,
+					HtlcEventTypeForward,
+					FailureDetailOnionEncode,
 				)
 				needUpdate = true
 				continue
@@ -2265,14 +2594,15 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// section.
 			if fwdPkg.State == channeldb.FwdStateLockedIn {
 				updatePacket := &htlcPacket{
-					incomingChanID: l.ShortChanID(),
-					incomingHTLCID: pd.HtlcIndex,
-					outgoingChanID: fwdInfo.NextHop,
-					sourceRef:      pd.SourceRef,
-					incomingAmount: pd.Amount,
-					amount:         addMsg.Amount,
-					htlc:           addMsg,
-					obfuscator:     obfuscator,
+					incomingChanID:  l.ShortChanID(),
+					incomingHTLCID:  pd.HtlcIndex,
+					outgoingChanID:  fwdInfo.NextHop,
+					sourceRef:       pd.SourceRef,
+					incomingAmount:  pd.Amount,
+					incomingTimeout: pd.Timeout,
+					amount:          addMsg.Amount,
+					htlc:            addMsg,
+					obfuscator:      obfuscator,
 				}
 
 				fwdPkg.FwdFilter.Set(idx)
@@ -2343,10 +2673,131 @@ func (l *channelLink) handleBatchFwdErrs(errChan chan error) {
 	}
 }
 
+// addKeySendInvoice creates an invoice for a spontaneous payment paying the
+// passed amount to the given payment hash, after verifying that the preimage
+// handed to us by the sender actually matches the payment hash.
+func (l *channelLink) addKeySendInvoice(paymentHash chainhash.Hash,
+	preimage [32]byte, amt lnwire.MilliSatoshi) error {
+
+	if sha256.Sum256(preimage[:]) != paymentHash {
+		return fmt.Errorf("keysend preimage doesn't match payment " +
+			"hash")
+	}
+
+	return l.cfg.Registry.AddKeySendInvoice(preimage, amt)
+}
+
+// cancelExpiringHodlHtlcs cancels the invoices for which the link holds HTLCs
+// that expire within HodlExpiryDelta blocks of the best known height. Once such
+// an HTLC is failed back, the invoice can no longer be paid in full, so we
+// cancel it altogether. The registry then notifies all links holding HTLCs for
+// the invoice, ourselves included, which fail them back to the sender.
+func (l *channelLink) cancelExpiringHodlHtlcs() {
+	cancelHeight := l.bestHeight + l.cfg.HodlExpiryDelta
+	for hash, htlcs := range l.hodlMap {
+		for _, htlc := range htlcs {
+			if htlc.pd.Timeout > cancelHeight {
+				continue
+			}
+
+			l.infof("canceling invoice %x as held htlc expires at "+
+				"height %v", hash[:], htlc.pd.Timeout)
+
+			if err := l.cfg.Registry.CancelInvoice(hash); err != nil {
+				l.errorf("unable to cancel invoice %x: %v",
+					hash[:], err)
+			}
+			break
+		}
+	}
+}
+
+// processHodlEvent applies the resolution of a hold invoice to all HTLCs that
+// are being held for it. If the invoice was settled, the HTLCs are settled
+// with the revealed preimage, otherwise they're failed back to the sender.
+func (l *channelLink) processHodlEvent(event *HodlEvent) error {
+	htlcs, ok := l.hodlMap[event.Hash]
+	if !ok {
+		return nil
+	}
+	delete(l.hodlMap, event.Hash)
+
+	for _, htlc := range htlcs {
+		pd := htlc.pd
+
+		if event.MPPTimeout {
+			l.infof("failing held shard %x of incomplete "+
+				"multi-path payment", pd.RHash)
+
+			failure := lnwire.FailMPPTimeout{}
+			l.sendHTLCError(
+				pd, failure, htlc.obfuscator,
+				HtlcEventTypeReceive, FailureDetailMPPTimeout,
+			)
+			continue
+		}
+
+		if event.Preimage == nil {
+			l.infof("failing held htlc %x of canceled invoice",
+				pd.RHash)
+
+			failure := lnwire.FailUnknownPaymentHash{}
+			l.sendHTLCError(
+				pd, failure, htlc.obfuscator,
+				HtlcEventTypeReceive,
+				FailureDetailInvoiceCanceled,
+			)
+			continue
+		}
+
+		preimage := *event.Preimage
+		err := l.channel.SettleHTLC(
+			preimage, pd.HtlcIndex, pd.SourceRef, nil, nil,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to settle htlc: %v", err)
+		}
+
+		l.infof("settling held htlc %x as exit hop", pd.RHash)
+
+		l.cfg.Peer.SendMessage(&lnwire.UpdateFulfillHTLC{
+			ChanID:          l.ChanID(),
+			ID:              pd.HtlcIndex,
+			PaymentPreimage: preimage,
+		})
+
+		l.cfg.HtlcNotifier.NotifySettleEvent(
+			l.incomingHtlcKey(pd), HtlcEventTypeReceive,
+		)
+	}
+
+	return l.updateCommitTx()
+}
+
+// failExitHopHtlc fails an HTLC paying to one of our invoices that doesn't
+// satisfy the invoice or its onion payload. If the HTLC is a shard of a
+// multi-path payment, the payment can no longer complete, so we'll also fail
+// back any other shards held for the invoice, rather than holding on to them
+// until the payment times out.
+func (l *channelLink) failExitHopHtlc(pd *lnwallet.PaymentDescriptor,
+	fwdInfo ForwardingInfo, failure lnwire.FailureMessage,
+	e ErrorEncrypter, detail FailureDetail) {
+
+	l.sendHTLCError(pd, failure, e, HtlcEventTypeReceive, detail)
+
+	if fwdInfo.MultiPathTotal == 0 {
+		return
+	}
+
+	l.cfg.Registry.FailInvoiceShards(chainhash.Hash(pd.RHash))
+}
+
 // sendHTLCError functions cancels HTLC and send cancel message back to the
-// peer from which HTLC was received.
-func (l *channelLink) sendHTLCError(htlcIndex uint64, failure lnwire.FailureMessage,
-	e ErrorEncrypter, sourceRef *channeldb.AddRef) {
+// peer from which HTLC was received. The passed event type and failure detail
+// are used to notify subscribers of the failure.
+func (l *channelLink) sendHTLCError(pd *lnwallet.PaymentDescriptor,
+	failure lnwire.FailureMessage, e ErrorEncrypter,
+	eventType HtlcEventType, detail FailureDetail) {
 
 	reason, err := e.EncryptFirstHop(failure)
 	if err != nil {
@@ -2354,7 +2805,7 @@ func (l *channelLink) sendHTLCError(htlcIndex uint64, failure lnwire.FailureMess
 		return
 	}
 
-	err = l.channel.FailHTLC(htlcIndex, reason, sourceRef, nil, nil)
+	err = l.channel.FailHTLC(pd.HtlcIndex, reason, pd.SourceRef, nil, nil)
 	if err != nil {
 		log.Errorf("unable cancel htlc: %v", err)
 		return
@@ -2362,18 +2813,29 @@ func (l *channelLink) sendHTLCError(htlcIndex uint64, failure lnwire.FailureMess
 
 	l.cfg.Peer.SendMessage(&lnwire.UpdateFailHTLC{
 		ChanID: l.ChanID(),
-		ID:     htlcIndex,
+		ID:     pd.HtlcIndex,
 		Reason: reason,
 	})
+
+	if l.cfg.NotifyHtlcFailure != nil {
+		l.cfg.NotifyHtlcFailure(failure.Code())
+	}
+
+	l.cfg.HtlcNotifier.NotifyLinkFailEvent(
+		l.incomingHtlcKey(pd), l.incomingHtlcInfo(pd), eventType,
+		failure, detail, true,
+	)
 }
 
 // sendMalformedHTLCError helper function which sends the malformed HTLC update
 // to the payment sender.
-func (l *channelLink) sendMalformedHTLCError(htlcIndex uint64,
-	code lnwire.FailCode, onionBlob []byte, sourceRef *channeldb.AddRef) {
+func (l *channelLink) sendMalformedHTLCError(pd *lnwallet.PaymentDescriptor,
+	code lnwire.FailCode, onionBlob []byte) {
 
 	shaOnionBlob := sha256.Sum256(onionBlob)
-	err := l.channel.MalformedFailHTLC(htlcIndex, code, shaOnionBlob, sourceRef)
+	err := l.channel.MalformedFailHTLC(
+		pd.HtlcIndex, code, shaOnionBlob, pd.SourceRef,
+	)
 	if err != nil {
 		log.Errorf("unable cancel htlc: %v", err)
 		return
@@ -2381,10 +2843,59 @@ func (l *channelLink) sendMalformedHTLCError(htlcIndex uint64,
 
 	l.cfg.Peer.SendMessage(&lnwire.UpdateFailMalformedHTLC{
 		ChanID:       l.ChanID(),
-		ID:           htlcIndex,
+		ID:           pd.HtlcIndex,
 		ShaOnionBlob: shaOnionBlob,
 		FailureCode:  code,
 	})
+
+	if l.cfg.NotifyHtlcFailure != nil {
+		l.cfg.NotifyHtlcFailure(code)
+	}
+
+	var failure lnwire.FailureMessage
+	switch code {
+	case lnwire.CodeInvalidOnionVersion:
+		failure = &lnwire.FailInvalidOnionVersion{
+			OnionSHA256: shaOnionBlob,
+		}
+	case lnwire.CodeInvalidOnionHmac:
+		failure = &lnwire.FailInvalidOnionHmac{
+			OnionSHA256: shaOnionBlob,
+		}
+	default:
+		failure = &lnwire.FailInvalidOnionKey{
+			OnionSHA256: shaOnionBlob,
+		}
+	}
+
+	// As the onion couldn't be decoded, we can't tell whether we were
+	// meant to be the final hop, so the HTLC is reported as a forward.
+	l.cfg.HtlcNotifier.NotifyLinkFailEvent(
+		l.incomingHtlcKey(pd), l.incomingHtlcInfo(pd),
+		HtlcEventTypeForward, failure, FailureDetailOnionDecode, true,
+	)
+}
+
+// incomingHtlcKey returns the key of the passed HTLC, which was offered to us
+// by the remote peer.
+func (l *channelLink) incomingHtlcKey(pd *lnwallet.PaymentDescriptor) HtlcKey {
+	return HtlcKey{
+		IncomingCircuit: CircuitKey{
+			ChanID: l.ShortChanID(),
+			HtlcID: pd.HtlcIndex,
+		},
+	}
+}
+
+// incomingHtlcInfo returns the amount and time-lock of the passed HTLC, which
+// was offered to us by the remote peer.
+func (l *channelLink) incomingHtlcInfo(
+	pd *lnwallet.PaymentDescriptor) HtlcInfo {
+
+	return HtlcInfo{
+		IncomingTimeLock: pd.Timeout,
+		IncomingAmt:      pd.Amount,
+	}
 }
 
 // fail helper function which is used to encapsulate the action necessary for
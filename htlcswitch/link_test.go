@@ -229,7 +229,7 @@ func TestChannelLinkSingleHopPayment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("alice invoice wasn't settled")
 	}
 
@@ -454,7 +454,7 @@ func TestChannelLinkMultiHopPayment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("carol invoice haven't been settled")
 	}
 
@@ -580,6 +580,69 @@ func TestExitNodeAmountPayloadMismatch(t *testing.T) {
 	}
 }
 
+// TestExitNodeUnderpaymentWithoutMultiPath tests that an exit node rejects an
+// HTLC paying less than the invoice amount, if the sender didn't signal that
+// it's a shard of a multi-path payment.
+func TestExitNodeUnderpaymentWithoutMultiPath(t *testing.T) {
+	t.Parallel()
+
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*5,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	// Only half of the invoice amount is sent, without a multi-path total
+	// within the onion.
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(
+		amount/2, testStartingHeight, n.firstBobChannelLink,
+	)
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.bobServer.registry.AddInvoice(*invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	paymentErr := make(chan error, 1)
+	go func() {
+		_, err := n.aliceServer.htlcSwitch.SendHTLC(
+			n.bobServer.PubKey(), htlc, newMockDeobfuscator(),
+		)
+		paymentErr <- err
+	}()
+
+	select {
+	case err := <-paymentErr:
+		if err == nil ||
+			err.Error() != lnwire.CodeIncorrectPaymentAmount.String() {
+
+			t.Fatalf("expected incorrect payment amount failure, "+
+				"got: %v", err)
+		}
+
+	case <-time.After(30 * time.Second):
+		t.Fatalf("payment was not resolved in time")
+	}
+}
+
 // TestLinkForwardTimelockPolicyMismatch tests that if a node is an
 // intermediate node in a multi-hop payment, and receives an HTLC which
 // violates its specified multi-hop policy, then the HTLC is rejected.
@@ -794,7 +857,7 @@ func TestUpdateForwardingPolicy(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("carol invoice haven't been settled")
 	}
 
@@ -910,7 +973,7 @@ func TestChannelLinkMultiHopInsufficientPayment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("carol invoice have been settled")
 	}
 
@@ -997,7 +1060,7 @@ func TestChannelLinkMultiHopUnknownPaymentHash(t *testing.T) {
 
 	// Check that alice invoice wasn't settled and bandwidth of htlc
 	// links hasn't been changed.
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("alice invoice was settled")
 	}
 
@@ -1022,6 +1085,553 @@ func TestChannelLinkMultiHopUnknownPaymentHash(t *testing.T) {
 	}
 }
 
+// TestChannelLinkRejectResolvedInvoice asserts that an exit hop rejects HTLCs
+// paying to invoices that have been canceled or have expired.
+func TestChannelLinkRejectResolvedInvoice(t *testing.T) {
+	t.Parallel()
+
+	states := []channeldb.ContractState{
+		channeldb.ContractCanceled,
+		channeldb.ContractExpired,
+	}
+	for _, state := range states {
+		state := state
+		t.Run(state.String(), func(t *testing.T) {
+			testChannelLinkRejectResolvedInvoice(t, state)
+		})
+	}
+}
+
+func testChannelLinkRejectResolvedInvoice(t *testing.T,
+	state channeldb.ContractState) {
+
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	aliceBandwidthBefore := n.aliceChannelLink.Bandwidth()
+
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(amount, testStartingHeight,
+		n.firstBobChannelLink)
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invoice.Terms.State = state
+	if err := n.bobServer.registry.AddInvoice(*invoice); err != nil {
+		t.Fatalf("unable to add invoice in bob registry: %v", err)
+	}
+
+	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(), htlc,
+		newMockDeobfuscator())
+	if err == nil || err.Error() != lnwire.CodeUnknownPaymentHash.String() {
+		t.Fatalf("expected unknown payment hash failure, got: %v", err)
+	}
+
+	// Wait for Alice to receive the revocation.
+	time.Sleep(100 * time.Millisecond)
+
+	if n.aliceChannelLink.Bandwidth() != aliceBandwidthBefore {
+		t.Fatal("the bandwidth of alice channel link which handles " +
+			"alice->bob channel should be the same")
+	}
+}
+
+// TestChannelLinkHoldInvoice asserts that an exit hop holds on to an HTLC
+// paying to a hold invoice until the invoice is resolved, after which the HTLC
+// is either settled with the revealed preimage, or failed back to the sender.
+func TestChannelLinkHoldInvoice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("settle", func(t *testing.T) {
+		testChannelLinkHoldInvoice(t, true)
+	})
+	t.Run("cancel", func(t *testing.T) {
+		testChannelLinkHoldInvoice(t, false)
+	})
+}
+
+func testChannelLinkHoldInvoice(t *testing.T, settle bool) {
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(amount, testStartingHeight,
+		n.firstBobChannelLink)
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Strip the preimage from the invoice, turning it into a hold invoice
+	// that is only known by its payment hash.
+	preimage := invoice.Terms.PaymentPreimage
+	rhash := chainhash.Hash(htlc.PaymentHash)
+	invoice.Terms.PaymentPreimage = channeldb.UnknownPreimage
+
+	registry := n.bobServer.registry
+	registry.addHodlInvoice(*invoice, rhash)
+
+	paymentErr := make(chan error, 1)
+	go func() {
+		_, err := n.aliceServer.htlcSwitch.SendHTLC(
+			n.bobServer.PubKey(), htlc, newMockDeobfuscator(),
+		)
+		paymentErr <- err
+	}()
+
+	// Wait for Bob to accept the invoice, at which point the HTLC should
+	// be held rather than settled.
+	var state channeldb.ContractState
+	for i := 0; i < 100; i++ {
+		invoice, err := registry.LookupInvoice(rhash)
+		if err != nil {
+			t.Fatalf("unable to get invoice: %v", err)
+		}
+		state = invoice.Terms.State
+		if state == channeldb.ContractAccepted {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if state != channeldb.ContractAccepted {
+		t.Fatalf("invoice not accepted, state: %v", state)
+	}
+
+	select {
+	case err := <-paymentErr:
+		t.Fatalf("payment completed before invoice was resolved: %v",
+			err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// Resolve the invoice, which should either settle or fail the held
+	// HTLC.
+	var resolvePreimage *[32]byte
+	if settle {
+		resolvePreimage = &preimage
+	}
+	if err := registry.resolveHodlInvoice(rhash, resolvePreimage); err != nil {
+		t.Fatalf("unable to resolve invoice: %v", err)
+	}
+
+	select {
+	case err := <-paymentErr:
+		switch {
+		case settle && err != nil:
+			t.Fatalf("unable to make the payment: %v", err)
+
+		case !settle && (err == nil ||
+			err.Error() != lnwire.CodeUnknownPaymentHash.String()):
+
+			t.Fatalf("expected unknown payment hash failure, got: %v",
+				err)
+		}
+
+	case <-time.After(30 * time.Second):
+		t.Fatalf("payment was not resolved in time")
+	}
+}
+
+// TestChannelLinkHoldInvoiceExpiry asserts that an exit hop cancels a hold
+// invoice, failing back the HTLC it holds for it, once the HTLC gets within the
+// configured delta of its expiry.
+func TestChannelLinkHoldInvoiceExpiry(t *testing.T) {
+	t.Parallel()
+
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+
+	const hodlExpiryDelta = 10
+	n.firstBobChannelLink.cfg.HodlExpiryDelta = hodlExpiryDelta
+
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(amount, testStartingHeight,
+		n.firstBobChannelLink)
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Strip the preimage from the invoice, turning it into a hold invoice
+	// that is only known by its payment hash.
+	rhash := chainhash.Hash(htlc.PaymentHash)
+	invoice.Terms.PaymentPreimage = channeldb.UnknownPreimage
+
+	registry := n.bobServer.registry
+	registry.addHodlInvoice(*invoice, rhash)
+
+	paymentErr := make(chan error, 1)
+	go func() {
+		_, err := n.aliceServer.htlcSwitch.SendHTLC(
+			n.bobServer.PubKey(), htlc, newMockDeobfuscator(),
+		)
+		paymentErr <- err
+	}()
+
+	// Wait for Bob to accept the invoice, at which point the HTLC is being
+	// held.
+	assertInvoiceState := func(expected channeldb.ContractState) {
+		t.Helper()
+
+		var state channeldb.ContractState
+		for i := 0; i < 100; i++ {
+			invoice, err := registry.LookupInvoice(rhash)
+			if err != nil {
+				t.Fatalf("unable to get invoice: %v", err)
+			}
+			state = invoice.Terms.State
+			if state == expected {
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		t.Fatalf("expected invoice state %v, got %v", expected, state)
+	}
+	assertInvoiceState(channeldb.ContractAccepted)
+
+	notifyHeight := func(height uint32) {
+		select {
+		case n.bobFirstBlockEpoch <- &chainntnfs.BlockEpoch{
+			Height: int32(height),
+		}:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("block not consumed by link")
+		}
+	}
+
+	// A block that leaves the HTLC more than the delta away from its
+	// expiry shouldn't affect it.
+	notifyHeight(totalTimelock - hodlExpiryDelta - 1)
+
+	select {
+	case err := <-paymentErr:
+		t.Fatalf("payment completed before htlc was about to "+
+			"expire: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	assertInvoiceState(channeldb.ContractAccepted)
+
+	// Once the HTLC gets within the delta of its expiry, the invoice
+	// should be canceled and the HTLC failed back to Alice.
+	notifyHeight(totalTimelock - hodlExpiryDelta)
+
+	select {
+	case err := <-paymentErr:
+		if err == nil ||
+			err.Error() != lnwire.CodeUnknownPaymentHash.String() {
+
+			t.Fatalf("expected unknown payment hash failure, got: %v",
+				err)
+		}
+
+	case <-time.After(30 * time.Second):
+		t.Fatalf("payment was not failed in time")
+	}
+	assertInvoiceState(channeldb.ContractCanceled)
+}
+
+// TestChannelLinkMultiPathPayment tests that an exit hop holds the shards of a
+// multi-path payment until they add up to the invoice amount, at which point
+// all of them are settled, and that the shards are failed back if the payment
+// times out before then, or once another shard of the payment is rejected.
+func TestChannelLinkMultiPathPayment(t *testing.T) {
+	t.Parallel()
+
+	for _, outcome := range []string{"settle", "timeout", "reject"} {
+		outcome := outcome
+		t.Run(outcome, func(t *testing.T) {
+			testChannelLinkMultiPathPayment(t, outcome)
+		})
+	}
+}
+
+func testChannelLinkMultiPathPayment(t *testing.T, outcome string) {
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	// The invoice is paid using two shards, each carrying half of the
+	// invoice amount, and signalling the total amount to the exit hop.
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	shardAmt := amount / 2
+	htlcAmt, totalTimelock, hops := generateHops(
+		shardAmt, testStartingHeight, n.firstBobChannelLink,
+	)
+	hops[len(hops)-1].MultiPathTotal = amount
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rhash := chainhash.Hash(htlc.PaymentHash)
+
+	registry := n.bobServer.registry
+	if err := registry.AddInvoice(*invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	sendShard := func(expiry uint32) <-chan error {
+		paymentErr := make(chan error, 1)
+		shard := *htlc
+		shard.Expiry = expiry
+		go func() {
+			_, err := n.aliceServer.htlcSwitch.SendHTLC(
+				n.bobServer.PubKey(), &shard,
+				newMockDeobfuscator(),
+			)
+			paymentErr <- err
+		}()
+		return paymentErr
+	}
+
+	// Send the first shard. As it only pays half of the invoice, Bob
+	// should hold on to it.
+	firstShardErr := sendShard(totalTimelock)
+
+	select {
+	case err := <-firstShardErr:
+		t.Fatalf("shard completed before invoice was paid: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	dbInvoice, err := registry.LookupInvoice(rhash)
+	if err != nil {
+		t.Fatalf("unable to get invoice: %v", err)
+	}
+	if dbInvoice.Terms.State != channeldb.ContractOpen {
+		t.Fatalf("expected invoice to be open, is %v",
+			dbInvoice.Terms.State)
+	}
+
+	// Either complete the payment by sending the second shard, let the
+	// payment time out, or send a second shard whose time-lock doesn't
+	// match its onion payload, which Bob should reject.
+	assertShardResult := func(shardErr <-chan error,
+		expected lnwire.FailCode) {
+
+		t.Helper()
+
+		select {
+		case err := <-shardErr:
+			if expected == lnwire.CodeNone {
+				if err != nil {
+					t.Fatalf("unable to send shard: %v",
+						err)
+				}
+				return
+			}
+
+			ferr, ok := err.(*ForwardingError)
+			if !ok || ferr.FailureMessage.Code() != expected {
+				t.Fatalf("expected %v failure, got: %v",
+					expected, err)
+			}
+
+		case <-time.After(30 * time.Second):
+			t.Fatalf("shard was not resolved in time")
+		}
+	}
+
+	switch outcome {
+	case "settle":
+		secondShardErr := sendShard(totalTimelock)
+		assertShardResult(firstShardErr, lnwire.CodeNone)
+		assertShardResult(secondShardErr, lnwire.CodeNone)
+
+	case "timeout":
+		registry.timeoutShards(rhash)
+		assertShardResult(firstShardErr, lnwire.CodeMPPTimeout)
+
+	// As the rejected shard prevents the payment from completing, the
+	// shard Bob is holding should be failed back right away, rather than
+	// once the payment times out.
+	case "reject":
+		secondShardErr := sendShard(totalTimelock + 1)
+		assertShardResult(
+			secondShardErr, lnwire.CodeFinalIncorrectCltvExpiry,
+		)
+		assertShardResult(firstShardErr, lnwire.CodeMPPTimeout)
+	}
+
+	dbInvoice, err = registry.LookupInvoice(rhash)
+	if err != nil {
+		t.Fatalf("unable to get invoice: %v", err)
+	}
+
+	expectedState := channeldb.ContractOpen
+	if outcome == "settle" {
+		expectedState = channeldb.ContractSettled
+	}
+	if dbInvoice.Terms.State != expectedState {
+		t.Fatalf("expected invoice state %v, got %v", expectedState,
+			dbInvoice.Terms.State)
+	}
+}
+
+// TestChannelLinkKeySendPayment checks that a spontaneous payment, carrying its
+// preimage within the onion, is settled by the receiver if it accepts such
+// payments, and rejected otherwise.
+func TestChannelLinkKeySendPayment(t *testing.T) {
+	t.Parallel()
+
+	t.Run("accept", func(t *testing.T) {
+		testChannelLinkKeySendPayment(t, true)
+	})
+	t.Run("reject", func(t *testing.T) {
+		testChannelLinkKeySendPayment(t, false)
+	})
+}
+
+func testChannelLinkKeySendPayment(t *testing.T, acceptKeySend bool) {
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	registry := n.bobServer.registry
+	registry.Lock()
+	registry.acceptKeySend = acceptKeySend
+	registry.Unlock()
+
+	// The preimage of the payment is chosen by Alice, and handed to Bob
+	// within the onion. Bob has no invoice for it.
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(
+		amount, testStartingHeight, n.firstBobChannelLink,
+	)
+
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		[lnwire.OnionPacketSize]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	preimage := invoice.Terms.PaymentPreimage
+	hops[len(hops)-1].KeySendPreimage = &preimage
+
+	htlc.OnionBlob, err = generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = n.aliceServer.htlcSwitch.SendHTLC(
+		n.bobServer.PubKey(), htlc, newMockDeobfuscator(),
+	)
+	switch {
+	case acceptKeySend && err != nil:
+		t.Fatalf("unable to send keysend payment: %v", err)
+
+	case !acceptKeySend && (err == nil ||
+		err.Error() != lnwire.CodeUnknownPaymentHash.String()):
+
+		t.Fatalf("expected unknown payment hash failure, got: %v", err)
+	}
+
+	// If the payment was accepted, Bob should have created and settled an
+	// invoice for it.
+	rhash := chainhash.Hash(htlc.PaymentHash)
+	dbInvoice, err := registry.LookupInvoice(rhash)
+	if !acceptKeySend {
+		if err == nil {
+			t.Fatalf("invoice created for rejected keysend payment")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("unable to get invoice: %v", err)
+	}
+	if dbInvoice.Terms.State != channeldb.ContractSettled {
+		t.Fatalf("expected invoice to be settled, is %v",
+			dbInvoice.Terms.State)
+	}
+	if dbInvoice.Terms.Value != amount {
+		t.Fatalf("expected invoice value %v, got %v", amount,
+			dbInvoice.Terms.Value)
+	}
+}
+
 // TestChannelLinkMultiHopUnknownNextHop construct the chain of hops
 // Carol<->Bob<->Alice and checks that we receive remote error from Bob if he
 // has no idea about next hop (hop might goes down and routing info not updated
@@ -1078,7 +1688,7 @@ func TestChannelLinkMultiHopUnknownNextHop(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("carol invoice have been settled")
 	}
 
@@ -1166,7 +1776,7 @@ func TestChannelLinkMultiHopDecodeError(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("carol invoice have been settled")
 	}
 
@@ -1458,7 +2068,10 @@ func newSingleLinkTestHarness(chanAmt, chanReserve btcutil.Amount) (
 
 	aliceDb := aliceChannel.State().Db
 
-	aliceSwitch, err := New(Config{DB: aliceDb})
+	aliceSwitch, err := New(Config{
+		DB:           aliceDb,
+		HtlcNotifier: NewHtlcNotifier(time.Now),
+	})
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -1486,6 +2099,7 @@ func newSingleLinkTestHarness(chanAmt, chanReserve btcutil.Amount) (
 		BlockEpochs:    globalEpoch,
 		BatchTicker:    ticker,
 		FwdPkgGCTicker: NewBatchTicker(time.NewTicker(5 * time.Second)),
+		HtlcNotifier:   aliceSwitch.cfg.HtlcNotifier,
 		// Make the BatchSize large enough to not
 		// trigger commit update automatically during tests.
 		BatchSize: 10000,
@@ -2680,7 +3294,7 @@ func TestChannelRetransmission(t *testing.T) {
 				err = errors.Errorf("unable to get invoice: %v", err)
 				continue
 			}
-			if !invoice.Terms.Settled {
+			if invoice.Terms.State != channeldb.ContractSettled {
 				err = errors.Errorf("alice invoice haven't been settled")
 				continue
 			}
@@ -2817,6 +3431,58 @@ func TestShouldAdjustCommitFee(t *testing.T) {
 	}
 }
 
+// TestBoundCommitFeeUpdate tests that increases of the commitment fee are
+// bounded to maxCommitFeeIncrease times the current commitment fee, while
+// decreases are left untouched.
+func TestBoundCommitFeeUpdate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		netFee      lnwallet.SatPerKWeight
+		chanFee     lnwallet.SatPerKWeight
+		expectedFee lnwallet.SatPerKWeight
+	}{
+		// The network fee is lower than our commitment fee, so it
+		// should be used as is.
+		{
+			netFee:      100,
+			chanFee:     3000,
+			expectedFee: 100,
+		},
+
+		// The network fee is higher than our commitment fee, but within
+		// the bound, so it should be used as is.
+		{
+			netFee:      3000,
+			chanFee:     1000,
+			expectedFee: 3000,
+		},
+
+		// The network fee is exactly at the bound.
+		{
+			netFee:      1000 * maxCommitFeeIncrease,
+			chanFee:     1000,
+			expectedFee: 1000 * maxCommitFeeIncrease,
+		},
+
+		// The network fee exceeds the bound, so it should be capped.
+		{
+			netFee:      1000000,
+			chanFee:     1000,
+			expectedFee: 1000 * maxCommitFeeIncrease,
+		},
+	}
+
+	for i, test := range tests {
+		fee := boundCommitFeeUpdate(test.netFee, test.chanFee)
+		if fee != test.expectedFee {
+			t.Fatalf("test #%v failed: net_fee=%v, chan_fee=%v, "+
+				"expected %v, got %v", i, test.netFee,
+				test.chanFee, test.expectedFee, fee)
+		}
+	}
+}
+
 // TestChannelLinkUpdateCommitFee tests that when a new block comes in, the
 // channel link properly checks to see if it should update the commitment fee.
 func TestChannelLinkUpdateCommitFee(t *testing.T) {
@@ -140,6 +140,7 @@ func initSwitchWithDB(db *channeldb.DB) (*Switch, error) {
 		FwdingLog: &mockForwardingLog{
 			events: make(map[time.Time]channeldb.ForwardingEvent),
 		},
+		HtlcNotifier: NewHtlcNotifier(time.Now),
 	})
 }
 
@@ -270,7 +271,20 @@ func (f *ForwardingInfo) encode(w io.Writer) error {
 		return err
 	}
 
-	return nil
+	if err := binary.Write(w, binary.BigEndian, f.MultiPathTotal); err != nil {
+		return err
+	}
+
+	if f.KeySendPreimage == nil {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+
+	if _, err := w.Write([]byte{1}); err != nil {
+		return err
+	}
+	_, err := w.Write(f.KeySendPreimage[:])
+	return err
 }
 
 var _ HopIterator = (*mockHopIterator)(nil)
@@ -434,6 +448,24 @@ func (f *ForwardingInfo) decode(r io.Reader) error {
 		return err
 	}
 
+	if err := binary.Read(r, binary.BigEndian, &f.MultiPathTotal); err != nil {
+		return err
+	}
+
+	var keySend [1]byte
+	if _, err := r.Read(keySend[:]); err != nil {
+		return err
+	}
+	if keySend[0] == 0 {
+		return nil
+	}
+
+	var preimage [32]byte
+	if _, err := io.ReadFull(r, preimage[:]); err != nil {
+		return err
+	}
+	f.KeySendPreimage = &preimage
+
 	return nil
 }
 
@@ -632,12 +664,21 @@ var _ ChannelLink = (*mockChannelLink)(nil)
 
 type mockInvoiceRegistry struct {
 	sync.Mutex
-	invoices map[chainhash.Hash]channeldb.Invoice
+	invoices  map[chainhash.Hash]channeldb.Invoice
+	hodlChans map[chainhash.Hash]chan<- interface{}
+	shards    map[chainhash.Hash]map[channeldb.CircuitKey]lnwire.MilliSatoshi
+
+	// acceptKeySend indicates whether spontaneous payments are accepted.
+	acceptKeySend bool
 }
 
 func newMockRegistry() *mockInvoiceRegistry {
 	return &mockInvoiceRegistry{
-		invoices: make(map[chainhash.Hash]channeldb.Invoice),
+		invoices:  make(map[chainhash.Hash]channeldb.Invoice),
+		hodlChans: make(map[chainhash.Hash]chan<- interface{}),
+		shards: make(
+			map[chainhash.Hash]map[channeldb.CircuitKey]lnwire.MilliSatoshi,
+		),
 	}
 }
 
@@ -662,13 +703,182 @@ func (i *mockInvoiceRegistry) SettleInvoice(rhash chainhash.Hash) error {
 		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
 	}
 
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
+		return nil
+	}
+
+	invoice.Terms.State = channeldb.ContractSettled
+	i.invoices[rhash] = invoice
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) AcceptInvoice(rhash chainhash.Hash,
+	hodlChan chan<- interface{}) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+
+	invoice.Terms.State = channeldb.ContractAccepted
+	i.invoices[rhash] = invoice
+	i.hodlChans[rhash] = hodlChan
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) AcceptInvoiceShard(rhash chainhash.Hash,
+	htlcKey channeldb.CircuitKey, amt lnwire.MilliSatoshi,
+	hodlChan chan<- interface{}) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+
+	shards, ok := i.shards[rhash]
+	if !ok {
+		shards = make(map[channeldb.CircuitKey]lnwire.MilliSatoshi)
+		i.shards[rhash] = shards
+	}
+	shards[htlcKey] = amt
+	i.hodlChans[rhash] = hodlChan
+
+	var total lnwire.MilliSatoshi
+	for _, shardAmt := range shards {
+		total += shardAmt
+	}
+	if total < invoice.Terms.Value {
 		return nil
 	}
 
-	invoice.Terms.Settled = true
+	// The shards add up to the invoice amount, so we'll settle the
+	// invoice and notify the link holding them.
+	delete(i.shards, rhash)
+	delete(i.hodlChans, rhash)
+
+	invoice.Terms.State = channeldb.ContractSettled
 	i.invoices[rhash] = invoice
 
+	preimage := invoice.Terms.PaymentPreimage
+	hodlChan <- &HodlEvent{
+		Hash:     rhash,
+		Preimage: &preimage,
+	}
+
+	return nil
+}
+
+// timeoutShards times out the set of shards received for the invoice,
+// notifying the link holding them.
+func (i *mockInvoiceRegistry) timeoutShards(rhash chainhash.Hash) {
+	i.Lock()
+	hodlChan, ok := i.hodlChans[rhash]
+	delete(i.shards, rhash)
+	delete(i.hodlChans, rhash)
+	i.Unlock()
+
+	if ok {
+		hodlChan <- &HodlEvent{
+			Hash:       rhash,
+			MPPTimeout: true,
+		}
+	}
+}
+
+func (i *mockInvoiceRegistry) AddKeySendInvoice(preimage [32]byte,
+	amt lnwire.MilliSatoshi) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	if !i.acceptKeySend {
+		return fmt.Errorf("keysend payments not accepted")
+	}
+
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+	if _, ok := i.invoices[rhash]; ok {
+		return nil
+	}
+
+	i.invoices[rhash] = channeldb.Invoice{
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			Value:           amt,
+			PaymentPreimage: preimage,
+		},
+	}
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) FailInvoiceShards(rhash chainhash.Hash) {
+	i.Lock()
+	_, ok := i.shards[rhash]
+	i.Unlock()
+
+	if ok {
+		i.timeoutShards(rhash)
+	}
+}
+
+func (i *mockInvoiceRegistry) CancelInvoice(rhash chainhash.Hash) error {
+	i.Lock()
+	delete(i.shards, rhash)
+	i.Unlock()
+
+	return i.resolveHodlInvoice(rhash, nil)
+}
+
+func (i *mockInvoiceRegistry) HodlUnsubscribeAll(hodlChan chan<- interface{}) {
+	i.Lock()
+	defer i.Unlock()
+
+	for hash, c := range i.hodlChans {
+		if c == hodlChan {
+			delete(i.hodlChans, hash)
+		}
+	}
+}
+
+// resolveHodlInvoice resolves the accepted hold invoice, notifying the link
+// holding its HTLCs. A nil preimage cancels the invoice.
+func (i *mockInvoiceRegistry) resolveHodlInvoice(rhash chainhash.Hash,
+	preimage *[32]byte) error {
+
+	i.Lock()
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		i.Unlock()
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+
+	if preimage != nil {
+		invoice.Terms.PaymentPreimage = *preimage
+		invoice.Terms.State = channeldb.ContractSettled
+	} else {
+		invoice.Terms.State = channeldb.ContractCanceled
+	}
+	i.invoices[rhash] = invoice
+
+	hodlChan, ok := i.hodlChans[rhash]
+	delete(i.hodlChans, rhash)
+	i.Unlock()
+
+	if ok {
+		hodlChan <- &HodlEvent{
+			Hash:     rhash,
+			Preimage: preimage,
+		}
+	}
+
 	return nil
 }
 
@@ -682,6 +892,17 @@ func (i *mockInvoiceRegistry) AddInvoice(invoice channeldb.Invoice) error {
 	return nil
 }
 
+// addHodlInvoice adds a hold invoice, whose preimage isn't known yet, under
+// the given payment hash.
+func (i *mockInvoiceRegistry) addHodlInvoice(invoice channeldb.Invoice,
+	rhash chainhash.Hash) {
+
+	i.Lock()
+	defer i.Unlock()
+
+	i.invoices[rhash] = invoice
+}
+
 var _ InvoiceDatabase = (*mockInvoiceRegistry)(nil)
 
 type mockSigner struct {
@@ -50,6 +50,10 @@ type htlcPacket struct {
 	// incoming link.
 	incomingAmount lnwire.MilliSatoshi
 
+	// incomingTimeout is the absolute block height at which the incoming
+	// HTLC expires.
+	incomingTimeout uint32
+
 	// amount is the value of the HTLC that is being created or modified.
 	amount lnwire.MilliSatoshi
 
@@ -80,6 +84,11 @@ type htlcPacket struct {
 	// circuit holds a reference to an Add's circuit which is persisted in
 	// the switch during successful forwarding.
 	circuit *PaymentCircuit
+
+	// intercepted is set to true once a forwarded Add has been handed to
+	// the switch's forward interceptor, such that it isn't intercepted
+	// again once resumed.
+	intercepted bool
 }
 
 // inKey returns the circuit key used to identify the incoming htlc.
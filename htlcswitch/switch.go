@@ -95,6 +95,12 @@ type ChanClose struct {
 	// process for the cooperative closure transaction kicks off.
 	TargetFeePerKw lnwallet.SatPerKWeight
 
+	// MaxFeePerKw is the maximum fee that was specified by the caller.
+	// This value is only utilized if the closure type is CloseRegular. The
+	// fee negotiated for the cooperative closure transaction will never
+	// exceed it. If zero, then the negotiated fee isn't constrained.
+	MaxFeePerKw lnwallet.SatPerKWeight
+
 	// Updates is used by request creator to receive the notifications about
 	// execution of the close channel request.
 	Updates chan *lnrpc.CloseStatusUpdate
@@ -135,6 +141,10 @@ type Config struct {
 	// error encrypters stored in the circuit map on restarts, since they
 	// are not stored directly within the database.
 	ExtractErrorEncrypter ErrorEncrypterExtracter
+
+	// HtlcNotifier is used to notify subscribers of the HTLCs that are
+	// forwarded, failed and settled by the switch.
+	HtlcNotifier *HtlcNotifier
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -215,6 +225,17 @@ type Switch struct {
 	// to the forwarding log.
 	fwdEventMtx         sync.Mutex
 	pendingFwdingEvents []channeldb.ForwardingEvent
+
+	// fwdCounters tracks the number of HTLCs forwarded through the
+	// switch, along with the number of HTLCs failed back by each failure
+	// code.
+	fwdCounters *fwdCounters
+
+	// interceptor, if set, is handed every HTLC forward passing through
+	// the switch, which is only continued once the interceptor resolves
+	// it.
+	interceptor    ForwardInterceptor
+	interceptorMtx sync.RWMutex
 }
 
 // New creates the new instance of htlc switch.
@@ -245,6 +266,7 @@ func New(cfg Config) (*Switch, error) {
 		chanCloseRequests: make(chan *ChanClose),
 		resolutionMsgs:    make(chan *resolutionMsg),
 		linkControl:       make(chan interface{}),
+		fwdCounters:       newFwdCounters(),
 		quit:              make(chan struct{}),
 	}, nil
 }
@@ -474,7 +496,10 @@ func (s *Switch) forward(packet *htlcPacket) error {
 			failure := lnwire.NewTemporaryChannelFailure(nil)
 			addErr := ErrIncompleteForward
 
-			return s.failAddPacket(packet, failure, addErr)
+			return s.failAddPacket(
+				packet, failure, FailureDetailIncompleteForward,
+				addErr,
+			)
 		}
 
 		packet.circuit = circuit
@@ -608,7 +633,9 @@ func (s *Switch) ForwardPackets(packets ...*htlcPacket) chan error {
 
 		// We don't handle the error here since this method always
 		// returns an error.
-		s.failAddPacket(packet, failure, addErr)
+		s.failAddPacket(
+			packet, failure, FailureDetailIncompleteForward, addErr,
+		)
 	}
 
 	return errChan
@@ -718,9 +745,17 @@ func (s *Switch) handleLocalDispatch(pkt *htlcPacket) error {
 		links, err := s.getLinks(pkt.destNode)
 		if err != nil {
 			log.Errorf("unable to find links by destination %v", err)
+
+			failure := &lnwire.FailUnknownNextPeer{}
+			s.cfg.HtlcNotifier.NotifyLinkFailEvent(
+				newHtlcKey(pkt), newHtlcInfo(pkt, htlc),
+				HtlcEventTypeSend, failure,
+				FailureDetailUnknownNextPeer, false,
+			)
+
 			return &ForwardingError{
 				ErrorSource:    s.cfg.SelfKey,
-				FailureMessage: &lnwire.FailUnknownNextPeer{},
+				FailureMessage: failure,
 			}
 		}
 
@@ -759,6 +794,12 @@ func (s *Switch) handleLocalDispatch(pkt *htlcPacket) error {
 			log.Error(err)
 
 			htlcErr := lnwire.NewTemporaryChannelFailure(nil)
+			s.cfg.HtlcNotifier.NotifyLinkFailEvent(
+				newHtlcKey(pkt), newHtlcInfo(pkt, htlc),
+				HtlcEventTypeSend, htlcErr,
+				FailureDetailInsufficientBalance, false,
+			)
+
 			return &ForwardingError{
 				ErrorSource:    s.cfg.SelfKey,
 				ExtraMsg:       err.Error(),
@@ -785,7 +826,18 @@ func (s *Switch) handleLocalDispatch(pkt *htlcPacket) error {
 	// We've just received a fail update which means we can finalize the
 	// user payment and return fail response.
 	case *lnwire.UpdateFailHTLC:
-		payment.err <- s.parseFailedPayment(payment, pkt, htlc)
+		failure := s.parseFailedPayment(payment, pkt, htlc)
+
+		// Failures that originate from our own outgoing link have
+		// already been notified by the link itself.
+		if !pkt.hasSource {
+			s.cfg.HtlcNotifier.NotifyForwardingFailEvent(
+				newHtlcKey(pkt), HtlcEventTypeSend,
+				failure.FailureMessage, failure.ErrorSource,
+			)
+		}
+
+		payment.err <- failure
 		payment.response <- pkt
 		payment.preimage <- zeroPreimage
 		s.removePendingPayment(pkt.incomingHTLCID)
@@ -880,6 +932,26 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			return s.handleLocalDispatch(packet)
 		}
 
+		// If a forward interceptor is registered, we'll hand the
+		// forward over to it. It'll be routed through the switch once
+		// again if the interceptor resumes it.
+		if !packet.intercepted {
+			s.interceptorMtx.RLock()
+			interceptor := s.interceptor
+			s.interceptorMtx.RUnlock()
+
+			if interceptor != nil {
+				packet.intercepted = true
+				go interceptor(&interceptedForward{
+					packet:     packet,
+					htlc:       htlc,
+					htlcSwitch: s,
+				})
+
+				return nil
+			}
+		}
+
 		targetLink, err := s.getLinkByShortID(packet.outgoingChanID)
 		if err != nil {
 			// If packet was forwarded from another channel link
@@ -889,7 +961,10 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			addErr := errors.Errorf("unable to find link with "+
 				"destination %v", packet.outgoingChanID)
 
-			return s.failAddPacket(packet, failure, addErr)
+			return s.failAddPacket(
+				packet, failure, FailureDetailUnknownNextPeer,
+				addErr,
+			)
 		}
 		interfaceLinks, _ := s.getLinks(targetLink.Peer().PubKey())
 
@@ -922,7 +997,10 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 				"channel link insufficient capacity, need "+
 				"%v", htlc.Amount)
 
-			return s.failAddPacket(packet, failure, addErr)
+			return s.failAddPacket(
+				packet, failure,
+				FailureDetailInsufficientBalance, addErr,
+			)
 		}
 
 		// Send the packet to the destination channel link which
@@ -960,12 +1038,27 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 					log.Error(err)
 				}
 
+				s.fwdCounters.recordFailure(failure.Code())
+
+				s.cfg.HtlcNotifier.NotifyForwardingFailEvent(
+					newHtlcKey(packet), HtlcEventTypeForward,
+					failure, s.cfg.SelfKey,
+				)
+
 			default:
 				// Otherwise, it's a forwarded error, so we'll perform a
 				// wrapper encryption as normal.
 				fail.Reason = circuit.ErrorEncrypter.IntermediateEncrypt(
 					fail.Reason,
 				)
+
+				// As the failure is encrypted for the sender,
+				// neither it nor the failing hop are known to
+				// us.
+				s.cfg.HtlcNotifier.NotifyForwardingFailEvent(
+					newHtlcKey(packet), HtlcEventTypeForward,
+					nil, nil,
+				)
 			}
 		} else {
 			// If this is an HTLC settle, and it wasn't from a
@@ -988,6 +1081,18 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 					},
 				)
 				s.fwdEventMtx.Unlock()
+
+				// Only settled HTLCs count as successful
+				// forwards.
+				if !isFail {
+					s.fwdCounters.recordForward()
+				}
+			}
+
+			if !isFail {
+				s.cfg.HtlcNotifier.NotifySettleEvent(
+					newHtlcKey(packet), getEventType(packet),
+				)
 			}
 		}
 
@@ -1011,7 +1116,8 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 // The ciphertext will be derived from the failure message proivded by context.
 // This method returns the failErr if all other steps complete successfully.
 func (s *Switch) failAddPacket(packet *htlcPacket,
-	failure lnwire.FailureMessage, failErr error) error {
+	failure lnwire.FailureMessage, detail FailureDetail,
+	failErr error) error {
 
 	// Encrypt the failure so that the sender will be able to read the error
 	// message. Since we failed this packet, we use EncryptFirstHop to
@@ -1026,6 +1132,14 @@ func (s *Switch) failAddPacket(packet *htlcPacket,
 
 	log.Error(failErr)
 
+	s.fwdCounters.recordFailure(failure.Code())
+
+	htlc := packet.htlc.(*lnwire.UpdateAddHTLC)
+	s.cfg.HtlcNotifier.NotifyLinkFailEvent(
+		newHtlcKey(packet), newHtlcInfo(packet, htlc),
+		HtlcEventTypeForward, failure, detail, false,
+	)
+
 	// Route a fail packet back to the source link.
 	sourceMailbox := s.getOrCreateMailBox(packet.incomingChanID)
 	if err = sourceMailbox.AddPacket(&htlcPacket{
@@ -1046,6 +1160,31 @@ func (s *Switch) failAddPacket(packet *htlcPacket,
 	return failErr
 }
 
+// settleAddPacket settles an add packet back to its source using the passed
+// preimage, without forwarding it.
+func (s *Switch) settleAddPacket(packet *htlcPacket,
+	preimage [sha256.Size]byte) error {
+
+	// Route a settle packet back to the source link.
+	sourceMailbox := s.getOrCreateMailBox(packet.incomingChanID)
+	if err := sourceMailbox.AddPacket(&htlcPacket{
+		incomingChanID: packet.incomingChanID,
+		incomingHTLCID: packet.incomingHTLCID,
+		circuit:        packet.circuit,
+		htlc: &lnwire.UpdateFulfillHTLC{
+			PaymentPreimage: preimage,
+		},
+	}); err != nil {
+		err = errors.Errorf("source chanid=%v unable to "+
+			"handle switch packet: %v",
+			packet.incomingChanID, err)
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 // closeCircuit accepts a settle or fail htlc and the associated htlc packet and
 // attempts to determine the source that forwarded this htlc. This method will
 // set the incoming chan and htlc ID of the given packet if the source was
@@ -1201,11 +1340,12 @@ func (s *Switch) teardownCircuit(pkt *htlcPacket) error {
 
 // CloseLink creates and sends the close channel command to the target link
 // directing the specified closure type. If the closure type if CloseRegular,
-// then the last parameter should be the ideal fee-per-kw that will be used as
-// a starting point for close negotiation.
+// then targetFeePerKw should be the ideal fee-per-kw that will be used as a
+// starting point for close negotiation, and maxFeePerKw the maximum fee-per-kw
+// that the negotiation may settle on, or zero if it isn't constrained.
 func (s *Switch) CloseLink(chanPoint *wire.OutPoint, closeType ChannelCloseType,
-	targetFeePerKw lnwallet.SatPerKWeight) (chan *lnrpc.CloseStatusUpdate,
-	chan error) {
+	targetFeePerKw, maxFeePerKw lnwallet.SatPerKWeight) (
+	chan *lnrpc.CloseStatusUpdate, chan error) {
 
 	// TODO(roasbeef) abstract out the close updates.
 	updateChan := make(chan *lnrpc.CloseStatusUpdate, 2)
@@ -1216,6 +1356,7 @@ func (s *Switch) CloseLink(chanPoint *wire.OutPoint, closeType ChannelCloseType,
 		ChanPoint:      chanPoint,
 		Updates:        updateChan,
 		TargetFeePerKw: targetFeePerKw,
+		MaxFeePerKw:    maxFeePerKw,
 		Err:            errChan,
 	}
 
@@ -1970,6 +2111,14 @@ func (s *Switch) findPayment(paymentID uint64) (*pendingPayment, error) {
 	return payment, nil
 }
 
+// SetInterceptor registers the interceptor that is handed every HTLC forward
+// passing through the switch. Passing nil removes the current interceptor.
+func (s *Switch) SetInterceptor(interceptor ForwardInterceptor) {
+	s.interceptorMtx.Lock()
+	s.interceptor = interceptor
+	s.interceptorMtx.Unlock()
+}
+
 // CircuitModifier returns a reference to subset of the interfaces provided by
 // the circuit map, to allow links to open and close circuits.
 func (s *Switch) CircuitModifier() CircuitModifier {
@@ -2013,6 +2162,20 @@ func (s *Switch) lookupOpenCircuit(outKey CircuitKey) *PaymentCircuit {
 	return s.circuits.LookupOpenCircuit(outKey)
 }
 
+// RecordHtlcFailure records that an HTLC has been failed back to its sender
+// with the given onion failure code. This is used by the channel links to
+// report the HTLCs they fail themselves.
+func (s *Switch) RecordHtlcFailure(code lnwire.FailCode) {
+	s.fwdCounters.recordFailure(code)
+}
+
+// FwdStats returns a snapshot of the number of HTLCs that have been forwarded
+// through the switch, along with the number of HTLCs that have been failed
+// back by each onion failure code.
+func (s *Switch) FwdStats() FwdStats {
+	return s.fwdCounters.snapshot()
+}
+
 // FlushForwardingEvents flushes out the set of pending forwarding events to
 // the persistent log. This will be used by the switch to periodically flush
 // out the set of forwarding events to disk. External callers can also use this
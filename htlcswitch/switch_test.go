@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"io"
 	"io/ioutil"
+	"reflect"
 	"testing"
 	"time"
 
@@ -127,6 +128,411 @@ func TestSwitchForward(t *testing.T) {
 	if s.circuits.NumOpen() != 0 {
 		t.Fatal("wrong amount of circuits")
 	}
+
+	// The settled HTLC should be counted as a successful forward.
+	if stats := s.FwdStats(); stats.NumForwards != 1 {
+		t.Fatalf("expected 1 forward, got %v", stats.NumForwards)
+	}
+}
+
+// TestSwitchForwardInterceptor tests that forwards are handed to a registered
+// interceptor, and are only continued, settled or failed once the interceptor
+// resolves them.
+func TestSwitchForwardInterceptor(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(t, "alice", nil)
+	if err != nil {
+		t.Fatalf("unable to create alice server: %v", err)
+	}
+	bobPeer, err := newMockServer(t, "bob", nil)
+	if err != nil {
+		t.Fatalf("unable to create bob server: %v", err)
+	}
+
+	s, err := initSwitchWithDB(nil)
+	if err != nil {
+		t.Fatalf("unable to init switch: %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer s.Stop()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add alice link: %v", err)
+	}
+	if err := s.AddLink(bobChannelLink); err != nil {
+		t.Fatalf("unable to add bob link: %v", err)
+	}
+
+	forwards := make(chan InterceptedForward, 1)
+	s.SetInterceptor(func(fwd InterceptedForward) {
+		forwards <- fwd
+	})
+
+	preimage, err := genPreimage()
+	if err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	rhash := fastsha256.Sum256(preimage[:])
+
+	// interceptAdd forwards a new add from Alice to Bob, and returns the
+	// forward handed to the interceptor.
+	interceptAdd := func(htlcID uint64) InterceptedForward {
+		packet := &htlcPacket{
+			incomingChanID:  aliceChannelLink.ShortChanID(),
+			incomingHTLCID:  htlcID,
+			outgoingChanID:  bobChannelLink.ShortChanID(),
+			incomingAmount:  2,
+			incomingTimeout: 200,
+			obfuscator:      NewMockObfuscator(),
+			htlc: &lnwire.UpdateAddHTLC{
+				PaymentHash: rhash,
+				Amount:      1,
+				Expiry:      100,
+			},
+		}
+		if err := s.forward(packet); err != nil {
+			t.Fatal(err)
+		}
+
+		var fwd InterceptedForward
+		select {
+		case fwd = <-forwards:
+		case <-time.After(time.Second):
+			t.Fatal("forward was not intercepted")
+		}
+
+		// The forward shouldn't reach Bob until it's resolved.
+		select {
+		case <-bobChannelLink.packets:
+			t.Fatal("intercepted forward reached destination")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		expected := InterceptedPacket{
+			IncomingCircuit: CircuitKey{
+				ChanID: aliceChannelLink.ShortChanID(),
+				HtlcID: htlcID,
+			},
+			OutgoingChanID: bobChannelLink.ShortChanID(),
+			Hash:           rhash,
+			IncomingAmount: 2,
+			IncomingExpiry: 200,
+			OutgoingAmount: 1,
+			OutgoingExpiry: 100,
+		}
+		if !reflect.DeepEqual(fwd.Packet(), expected) {
+			t.Fatalf("expected packet %v, got %v",
+				spew.Sdump(expected), spew.Sdump(fwd.Packet()))
+		}
+
+		return fwd
+	}
+
+	// First, we'll resume a forward, which should then reach Bob.
+	fwd := interceptAdd(0)
+	if err := fwd.Resume(); err != nil {
+		t.Fatalf("unable to resume forward: %v", err)
+	}
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("resumed forward was not propagated to destination")
+	}
+
+	// A forward can only be resolved once.
+	if err := fwd.Resume(); err != ErrFwdAlreadyResolved {
+		t.Fatalf("expected ErrFwdAlreadyResolved, got %v", err)
+	}
+
+	// Next, we'll settle a forward, which should send a settle back to
+	// Alice, but only if the preimage matches.
+	fwd = interceptAdd(1)
+	if err := fwd.Settle([32]byte{}); err != ErrInvalidPreimage {
+		t.Fatalf("expected ErrInvalidPreimage, got %v", err)
+	}
+	if err := fwd.Settle(preimage); err != nil {
+		t.Fatalf("unable to settle forward: %v", err)
+	}
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		if _, ok := pkt.htlc.(*lnwire.UpdateFulfillHTLC); !ok {
+			t.Fatalf("expected settle, got %T", pkt.htlc)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("settle was not propagated to source")
+	}
+
+	// Finally, we'll fail a forward, which should send a fail back to
+	// Alice.
+	fwd = interceptAdd(2)
+	if err := fwd.Fail(&lnwire.FailUnknownNextPeer{}); err != nil {
+		t.Fatalf("unable to fail forward: %v", err)
+	}
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		if _, ok := pkt.htlc.(*lnwire.UpdateFailHTLC); !ok {
+			t.Fatalf("expected fail, got %T", pkt.htlc)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("fail was not propagated to source")
+	}
+
+	// Once the interceptor is removed, forwards should reach Bob directly.
+	s.SetInterceptor(nil)
+	packet := &htlcPacket{
+		incomingChanID: aliceChannelLink.ShortChanID(),
+		incomingHTLCID: 3,
+		outgoingChanID: bobChannelLink.ShortChanID(),
+		obfuscator:     NewMockObfuscator(),
+		htlc: &lnwire.UpdateAddHTLC{
+			PaymentHash: rhash,
+			Amount:      1,
+		},
+	}
+	if err := s.forward(packet); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+}
+
+// TestSwitchHtlcNotifier tests that the switch notifies subscribers of the
+// HTLCs it fails, and of the failures and settles of the HTLCs it forwards.
+func TestSwitchHtlcNotifier(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(t, "alice", nil)
+	if err != nil {
+		t.Fatalf("unable to create alice server: %v", err)
+	}
+	bobPeer, err := newMockServer(t, "bob", nil)
+	if err != nil {
+		t.Fatalf("unable to create bob server: %v", err)
+	}
+
+	s, err := initSwitchWithDB(nil)
+	if err != nil {
+		t.Fatalf("unable to init switch: %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer s.Stop()
+	defer s.cfg.HtlcNotifier.Stop()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add alice link: %v", err)
+	}
+	if err := s.AddLink(bobChannelLink); err != nil {
+		t.Fatalf("unable to add bob link: %v", err)
+	}
+
+	sub, err := s.cfg.HtlcNotifier.SubscribeHtlcEvents()
+	if err != nil {
+		t.Fatalf("unable to subscribe to htlc events: %v", err)
+	}
+	defer sub.Cancel()
+
+	nextEvent := func() interface{} {
+		select {
+		case event := <-sub.Events:
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("htlc event not received")
+		}
+		return nil
+	}
+
+	preimage, err := genPreimage()
+	if err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	rhash := fastsha256.Sum256(preimage[:])
+
+	// newAdd returns a new add from Alice to be forwarded over the given
+	// outgoing channel.
+	newAdd := func(htlcID uint64,
+		outgoingChanID lnwire.ShortChannelID) *htlcPacket {
+
+		return &htlcPacket{
+			incomingChanID:  aliceChannelLink.ShortChanID(),
+			incomingHTLCID:  htlcID,
+			outgoingChanID:  outgoingChanID,
+			incomingAmount:  2,
+			incomingTimeout: 200,
+			obfuscator:      NewMockObfuscator(),
+			htlc: &lnwire.UpdateAddHTLC{
+				PaymentHash: rhash,
+				Amount:      1,
+				Expiry:      100,
+			},
+		}
+	}
+
+	// First, we'll forward an add over a channel we don't have a link
+	// for, which should be failed by the switch itself.
+	unknownChanID := lnwire.NewShortChanIDFromInt(100)
+	if err := s.forward(newAdd(0, unknownChanID)); err == nil {
+		t.Fatal("expected forward to fail")
+	}
+	select {
+	case <-aliceChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("fail was not propagated to source")
+	}
+
+	expectedInfo := HtlcInfo{
+		IncomingTimeLock: 200,
+		OutgoingTimeLock: 100,
+		IncomingAmt:      2,
+		OutgoingAmt:      1,
+	}
+	linkFail, ok := nextEvent().(*LinkFailEvent)
+	switch {
+	case !ok:
+		t.Fatalf("expected link fail event")
+
+	case linkFail.HtlcKey != (HtlcKey{
+		IncomingCircuit: CircuitKey{
+			ChanID: aliceChannelLink.ShortChanID(),
+			HtlcID: 0,
+		},
+		OutgoingCircuit: CircuitKey{
+			ChanID: unknownChanID,
+		},
+	}):
+		t.Fatalf("unexpected htlc key: %v", linkFail.HtlcKey)
+
+	case linkFail.HtlcInfo != expectedInfo:
+		t.Fatalf("expected htlc info %v, got %v", expectedInfo,
+			linkFail.HtlcInfo)
+
+	case linkFail.HtlcEventType != HtlcEventTypeForward:
+		t.Fatalf("expected forward, got %v", linkFail.HtlcEventType)
+
+	case linkFail.FailureDetail != FailureDetailUnknownNextPeer:
+		t.Fatalf("expected unknown next peer, got %v",
+			linkFail.FailureDetail)
+
+	case linkFail.Incoming:
+		t.Fatalf("expected outgoing failure")
+	}
+
+	// Next, we'll forward two adds to Bob, the first of which is failed
+	// back by a node further down the route, while the second is
+	// settled.
+	for htlcID := uint64(1); htlcID <= 2; htlcID++ {
+		packet := newAdd(htlcID, bobChannelLink.ShortChanID())
+		if err := s.forward(packet); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-bobChannelLink.packets:
+			err := bobChannelLink.completeCircuit(packet)
+			if err != nil {
+				t.Fatalf("unable to complete payment "+
+					"circuit: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("request was not propagated to destination")
+		}
+	}
+
+	failPacket := &htlcPacket{
+		outgoingChanID: bobChannelLink.ShortChanID(),
+		outgoingHTLCID: 0,
+		amount:         1,
+		htlc: &lnwire.UpdateFailHTLC{
+			Reason: []byte("fail"),
+		},
+	}
+	settlePacket := &htlcPacket{
+		outgoingChanID: bobChannelLink.ShortChanID(),
+		outgoingHTLCID: 1,
+		amount:         1,
+		htlc: &lnwire.UpdateFulfillHTLC{
+			PaymentPreimage: preimage,
+		},
+	}
+	for _, packet := range []*htlcPacket{failPacket, settlePacket} {
+		if err := s.forward(packet); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-aliceChannelLink.packets:
+		case <-time.After(time.Second):
+			t.Fatal("response was not propagated to source")
+		}
+	}
+
+	// As the failure is encrypted for the sender, neither it nor the
+	// failing hop should be known to us.
+	fwdFail, ok := nextEvent().(*ForwardingFailEvent)
+	switch {
+	case !ok:
+		t.Fatalf("expected forwarding fail event")
+
+	case fwdFail.HtlcKey != (HtlcKey{
+		IncomingCircuit: CircuitKey{
+			ChanID: aliceChannelLink.ShortChanID(),
+			HtlcID: 1,
+		},
+		OutgoingCircuit: CircuitKey{
+			ChanID: bobChannelLink.ShortChanID(),
+			HtlcID: 0,
+		},
+	}):
+		t.Fatalf("unexpected htlc key: %v", fwdFail.HtlcKey)
+
+	case fwdFail.HtlcEventType != HtlcEventTypeForward:
+		t.Fatalf("expected forward, got %v", fwdFail.HtlcEventType)
+
+	case fwdFail.FailureMessage != nil || fwdFail.FailingHop != nil:
+		t.Fatalf("expected unknown failure")
+	}
+
+	settle, ok := nextEvent().(*SettleEvent)
+	switch {
+	case !ok:
+		t.Fatalf("expected settle event")
+
+	case settle.HtlcKey != (HtlcKey{
+		IncomingCircuit: CircuitKey{
+			ChanID: aliceChannelLink.ShortChanID(),
+			HtlcID: 2,
+		},
+		OutgoingCircuit: CircuitKey{
+			ChanID: bobChannelLink.ShortChanID(),
+			HtlcID: 1,
+		},
+	}):
+		t.Fatalf("unexpected htlc key: %v", settle.HtlcKey)
+
+	case settle.HtlcEventType != HtlcEventTypeForward:
+		t.Fatalf("expected forward, got %v", settle.HtlcEventType)
+	}
 }
 
 func TestSwitchForwardFailAfterFullAdd(t *testing.T) {
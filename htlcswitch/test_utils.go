@@ -252,9 +252,10 @@ func createTestChannel(alicePrivKey, bobPrivKey []byte,
 	}
 	aliceCommitPoint := lnwallet.ComputeCommitmentPoint(aliceFirstRevoke[:])
 
-	aliceCommitTx, bobCommitTx, err := lnwallet.CreateCommitmentTxns(aliceAmount,
-		bobAmount, &aliceCfg, &bobCfg, aliceCommitPoint, bobCommitPoint,
-		*fundingTxIn)
+	aliceCommitTx, bobCommitTx, err := lnwallet.CreateCommitmentTxns(
+		channeldb.SingleFunder, aliceAmount, bobAmount, &aliceCfg,
+		&bobCfg, aliceCommitPoint, bobCommitPoint, *fundingTxIn,
+	)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -924,6 +925,7 @@ func newThreeHopNetwork(t testing.TB, aliceChannel, firstBobChannel,
 			BatchTicker:    &mockTicker{aliceTicker.C},
 			FwdPkgGCTicker: &mockTicker{time.NewTicker(5 * time.Second).C},
 			BatchSize:      10,
+			HtlcNotifier:   aliceServer.htlcSwitch.cfg.HtlcNotifier,
 		},
 		aliceChannel,
 		startingHeight,
@@ -972,6 +974,7 @@ func newThreeHopNetwork(t testing.TB, aliceChannel, firstBobChannel,
 			BatchTicker:    &mockTicker{firstBobTicker.C},
 			FwdPkgGCTicker: &mockTicker{time.NewTicker(5 * time.Second).C},
 			BatchSize:      10,
+			HtlcNotifier:   bobServer.htlcSwitch.cfg.HtlcNotifier,
 		},
 		firstBobChannel,
 		startingHeight,
@@ -1020,6 +1023,7 @@ func newThreeHopNetwork(t testing.TB, aliceChannel, firstBobChannel,
 			BatchTicker:    &mockTicker{secondBobTicker.C},
 			FwdPkgGCTicker: &mockTicker{time.NewTicker(5 * time.Second).C},
 			BatchSize:      10,
+			HtlcNotifier:   bobServer.htlcSwitch.cfg.HtlcNotifier,
 		},
 		secondBobChannel,
 		startingHeight,
@@ -1068,6 +1072,7 @@ func newThreeHopNetwork(t testing.TB, aliceChannel, firstBobChannel,
 			BatchTicker:    &mockTicker{carolTicker.C},
 			FwdPkgGCTicker: &mockTicker{time.NewTicker(5 * time.Second).C},
 			BatchSize:      10,
+			HtlcNotifier:   carolServer.htlcSwitch.cfg.HtlcNotifier,
 		},
 		carolChannel,
 		startingHeight,
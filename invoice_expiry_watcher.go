@@ -0,0 +1,191 @@
+package main
+
+import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// invoiceExpiry holds the payment hash of an invoice along with the time at
+// which the invoice expires.
+type invoiceExpiry struct {
+	paymentHash chainhash.Hash
+	expiry      time.Time
+}
+
+// invoiceExpiryQueue is a min-heap of invoice expiries, ordered such that the
+// invoice that expires first is at the top of the heap.
+type invoiceExpiryQueue []*invoiceExpiry
+
+// Len returns the number of invoices in the queue.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q invoiceExpiryQueue) Len() int {
+	return len(q)
+}
+
+// Less returns true if the invoice at index i expires before the invoice at
+// index j.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q invoiceExpiryQueue) Less(i, j int) bool {
+	return q[i].expiry.Before(q[j].expiry)
+}
+
+// Swap swaps the invoices at the passed indices.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q invoiceExpiryQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+// Push adds a new invoice expiry to the end of the queue.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q *invoiceExpiryQueue) Push(x interface{}) {
+	*q = append(*q, x.(*invoiceExpiry))
+}
+
+// Pop removes the last invoice expiry from the queue.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q *invoiceExpiryQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
+
+// invoiceExpiryWatcher tracks the expiry of all open invoices, and invokes a
+// callback for each invoice once its expiry has passed. The callback is
+// expected to transition the invoice to the expired state, after which
+// incoming HTLCs paying to it will be rejected.
+type invoiceExpiryWatcher struct {
+	started uint32
+	stopped uint32
+
+	// expireInvoice is called for each invoice once it has expired.
+	expireInvoice func(chainhash.Hash) error
+
+	// newInvoices is used to hand newly tracked invoices to the main
+	// loop of the watcher.
+	newInvoices chan *invoiceExpiry
+
+	// expiryQueue holds all tracked invoices, ordered by their expiry.
+	expiryQueue invoiceExpiryQueue
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// newInvoiceExpiryWatcher creates a new invoice expiry watcher that calls the
+// passed function for each tracked invoice once it expires.
+func newInvoiceExpiryWatcher(
+	expireInvoice func(chainhash.Hash) error) *invoiceExpiryWatcher {
+
+	return &invoiceExpiryWatcher{
+		expireInvoice: expireInvoice,
+		newInvoices:   make(chan *invoiceExpiry),
+		quit:          make(chan struct{}),
+	}
+}
+
+// Start launches the main loop of the expiry watcher.
+func (w *invoiceExpiryWatcher) Start() error {
+	if !atomic.CompareAndSwapUint32(&w.started, 0, 1) {
+		return nil
+	}
+
+	w.wg.Add(1)
+	go w.expiryLoop()
+
+	return nil
+}
+
+// Stop signals the expiry watcher to exit, and waits for it to do so.
+func (w *invoiceExpiryWatcher) Stop() error {
+	if !atomic.CompareAndSwapUint32(&w.stopped, 0, 1) {
+		return nil
+	}
+
+	close(w.quit)
+	w.wg.Wait()
+
+	return nil
+}
+
+// AddInvoice starts tracking the expiry of the invoice with the passed payment
+// hash. If the expiry has already passed, the invoice is expired right away.
+func (w *invoiceExpiryWatcher) AddInvoice(paymentHash chainhash.Hash,
+	expiry time.Time) {
+
+	select {
+	case w.newInvoices <- &invoiceExpiry{
+		paymentHash: paymentHash,
+		expiry:      expiry,
+	}:
+	case <-w.quit:
+	}
+}
+
+// expireInvoices expires all tracked invoices whose expiry has passed.
+func (w *invoiceExpiryWatcher) expireInvoices() {
+	now := time.Now()
+	for w.expiryQueue.Len() > 0 {
+		next := w.expiryQueue[0]
+		if next.expiry.After(now) {
+			return
+		}
+		heap.Pop(&w.expiryQueue)
+
+		if err := w.expireInvoice(next.paymentHash); err != nil {
+			ltndLog.Errorf("Unable to expire invoice %v: %v",
+				next.paymentHash, err)
+		}
+	}
+}
+
+// expiryLoop is the main loop of the expiry watcher. It waits until the next
+// tracked invoice expires, while accepting new invoices to track.
+//
+// NOTE: This MUST be run as a goroutine.
+func (w *invoiceExpiryWatcher) expiryLoop() {
+	defer w.wg.Done()
+
+	for {
+		// Expire any invoices that have passed their expiry, then arm
+		// a timer for the next invoice in line.
+		w.expireInvoices()
+
+		var (
+			timer      *time.Timer
+			nextExpiry <-chan time.Time
+		)
+		if w.expiryQueue.Len() > 0 {
+			timeout := w.expiryQueue[0].expiry.Sub(time.Now())
+			timer = time.NewTimer(timeout)
+			nextExpiry = timer.C
+		}
+
+		select {
+		case <-nextExpiry:
+
+		case invoice := <-w.newInvoices:
+			heap.Push(&w.expiryQueue, invoice)
+
+		case <-w.quit:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
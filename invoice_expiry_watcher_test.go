@@ -0,0 +1,59 @@
+// +build !rpctest
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// TestInvoiceExpiryWatcher asserts that the expiry watcher expires tracked
+// invoices in the order of their expiry, and only once their expiry has
+// passed.
+func TestInvoiceExpiryWatcher(t *testing.T) {
+	t.Parallel()
+
+	expired := make(chan chainhash.Hash, 10)
+	watcher := newInvoiceExpiryWatcher(func(hash chainhash.Hash) error {
+		expired <- hash
+		return nil
+	})
+	if err := watcher.Start(); err != nil {
+		t.Fatalf("unable to start watcher: %v", err)
+	}
+	defer watcher.Stop()
+
+	now := time.Now()
+	late := chainhash.Hash{1}
+	early := chainhash.Hash{2}
+	past := chainhash.Hash{3}
+	never := chainhash.Hash{4}
+
+	// Add the invoices out of order. The invoice whose expiry has already
+	// passed should be expired right away.
+	watcher.AddInvoice(late, now.Add(400*time.Millisecond))
+	watcher.AddInvoice(never, now.Add(time.Hour))
+	watcher.AddInvoice(early, now.Add(200*time.Millisecond))
+	watcher.AddInvoice(past, now.Add(-time.Minute))
+
+	for _, expectedHash := range []chainhash.Hash{past, early, late} {
+		select {
+		case hash := <-expired:
+			if hash != expectedHash {
+				t.Fatalf("expected invoice %v to expire, "+
+					"got %v", expectedHash, hash)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("invoice %v not expired", expectedHash)
+		}
+	}
+
+	// The invoice with the far away expiry should not have been expired.
+	select {
+	case hash := <-expired:
+		t.Fatalf("unexpected expiry of invoice %v", hash)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
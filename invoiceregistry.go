@@ -3,12 +3,15 @@ package main
 import (
 	"bytes"
 	"crypto/sha256"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcutil"
 )
@@ -24,6 +27,27 @@ var (
 	debugHash = chainhash.Hash(sha256.Sum256(debugPre[:]))
 )
 
+const (
+	// defaultMPPTimeout is the time we'll wait for the shards of a
+	// multi-path payment to add up to the invoice amount, counted from
+	// the arrival of the first shard. Once it passes, all shards received
+	// so far are failed back to the sender.
+	defaultMPPTimeout = time.Minute
+)
+
+// invoiceShardSet tracks the HTLCs received so far for an invoice that is
+// being paid by a multi-path payment.
+type invoiceShardSet struct {
+	// htlcs maps each received shard to its amount.
+	htlcs map[channeldb.CircuitKey]lnwire.MilliSatoshi
+
+	// total is the sum of the amounts of all received shards.
+	total lnwire.MilliSatoshi
+
+	// timeout fails the set back once the MPP timeout has passed.
+	timeout *time.Timer
+}
+
 // invoiceRegistry is a central registry of all the outstanding invoices
 // created by the daemon. The registry is a thin wrapper around a map in order
 // to ensure that all updates/reads are thread safe.
@@ -40,18 +64,139 @@ type invoiceRegistry struct {
 	// should be only created/used when manual tests require an invoice
 	// that *all* nodes are able to fully settle.
 	debugInvoices map[chainhash.Hash]*channeldb.Invoice
+
+	// hodlSubscriptions maps the payment hash of each accepted hold
+	// invoice, or invoice being paid by a multi-path payment, to the set
+	// of link channels that are holding HTLCs paying to it. Once the
+	// HTLCs are resolved, a HodlEvent is sent to each of these channels.
+	hodlSubscriptions map[chainhash.Hash]map[chan<- interface{}]struct{}
+
+	// invoiceShards maps the payment hash of each invoice that is being
+	// paid by a multi-path payment to the set of shards received for it,
+	// until the shards add up to the invoice amount.
+	invoiceShards map[chainhash.Hash]*invoiceShardSet
+
+	// mppTimeout is the time we'll wait for the shards of a multi-path
+	// payment to add up to the invoice amount.
+	mppTimeout time.Duration
+
+	// expiryWatcher expires open invoices once the expiry encoded within
+	// their payment request has passed.
+	expiryWatcher *invoiceExpiryWatcher
+
+	// acceptKeySend indicates whether spontaneous payments, for which an
+	// invoice is created on the fly, are accepted.
+	acceptKeySend bool
 }
 
 // newInvoiceRegistry creates a new invoice registry. The invoice registry
 // wraps the persistent on-disk invoice storage with an additional in-memory
 // layer. The in-memory layer is in place such that debug invoices can be added
-// which are volatile yet available system wide within the daemon.
-func newInvoiceRegistry(cdb *channeldb.DB) *invoiceRegistry {
-	return &invoiceRegistry{
+// which are volatile yet available system wide within the daemon. If
+// acceptKeySend is true, invoices are created for spontaneous payments made
+// without one.
+func newInvoiceRegistry(cdb *channeldb.DB,
+	acceptKeySend bool) *invoiceRegistry {
+
+	i := &invoiceRegistry{
 		cdb:                 cdb,
+		acceptKeySend:       acceptKeySend,
 		debugInvoices:       make(map[chainhash.Hash]*channeldb.Invoice),
 		notificationClients: make(map[uint32]*invoiceSubscription),
+		hodlSubscriptions: make(
+			map[chainhash.Hash]map[chan<- interface{}]struct{},
+		),
+		invoiceShards: make(map[chainhash.Hash]*invoiceShardSet),
+		mppTimeout:    defaultMPPTimeout,
+	}
+	i.expiryWatcher = newInvoiceExpiryWatcher(i.expireInvoice)
+
+	return i
+}
+
+// Start starts the registry's invoice expiry watcher, and begins tracking the
+// expiry of all open invoices within the database.
+func (i *invoiceRegistry) Start() error {
+	if err := i.expiryWatcher.Start(); err != nil {
+		return err
+	}
+
+	pendingInvoices, err := i.cdb.FetchAllInvoices(true)
+	switch {
+	case err == channeldb.ErrNoInvoicesCreated:
+		return nil
+	case err != nil:
+		return err
+	}
+
+	for _, invoice := range pendingInvoices {
+		// Accepted hold invoices have HTLCs being held for them, so
+		// they can only be resolved by settling or canceling them.
+		if invoice.Terms.State != channeldb.ContractOpen {
+			continue
+		}
+
+		i.trackExpiry(invoice)
+	}
+
+	return nil
+}
+
+// Stop stops the registry's invoice expiry watcher.
+func (i *invoiceRegistry) Stop() error {
+	return i.expiryWatcher.Stop()
+}
+
+// trackExpiry hands the passed invoice to the expiry watcher, such that it's
+// expired once the expiry encoded within its payment request has passed.
+//
+// NOTE: This method MUST NOT be called with the registry's lock held, as the
+// expiry watcher acquires it when expiring invoices.
+func (i *invoiceRegistry) trackExpiry(invoice *channeldb.Invoice) {
+	// Invoices without a payment request have no encoded expiry.
+	if len(invoice.PaymentRequest) == 0 {
+		return
+	}
+
+	payReq, err := zpay32.Decode(
+		string(invoice.PaymentRequest), activeNetParams.Params,
+	)
+	if err != nil {
+		ltndLog.Errorf("Unable to decode payment request of "+
+			"invoice: %v", err)
+		return
 	}
+
+	expiry := payReq.Timestamp.Add(payReq.Expiry())
+	i.expiryWatcher.AddInvoice(*payReq.PaymentHash, expiry)
+}
+
+// expireInvoice marks the invoice matching the passed payment hash as expired,
+// if it is still open. This method is called by the expiry watcher.
+func (i *invoiceRegistry) expireInvoice(rHash chainhash.Hash) error {
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, err := i.cdb.ExpireInvoice(rHash)
+	if err != nil {
+		return err
+	}
+
+	if invoice.Terms.State != channeldb.ContractExpired {
+		return nil
+	}
+
+	ltndLog.Infof("Invoice %x expired", rHash[:])
+
+	// Any shards of a multi-path payment received for the invoice can no
+	// longer complete it, so we'll fail them back.
+	i.removeShards(rHash)
+	i.notifyHodlSubscribers(&htlcswitch.HodlEvent{
+		Hash: rHash,
+	})
+	go i.notifyClients(invoice, false)
+
+	return nil
 }
 
 // addDebugInvoice adds a debug invoice for the specified amount, identified
@@ -89,10 +234,77 @@ func (i *invoiceRegistry) AddInvoice(invoice *channeldb.Invoice) error {
 	}))
 
 	// TODO(roasbeef): also check in memory for quick lookups/settles?
-	return i.cdb.AddInvoice(invoice)
+	if err := i.cdb.AddInvoice(invoice); err != nil {
+		return err
+	}
+
+	i.trackExpiry(invoice)
 
 	// TODO(roasbeef): re-enable?
 	//go i.notifyClients(invoice, false)
+
+	return nil
+}
+
+// AddHoldInvoice adds a hold invoice for the specified amount, identified only
+// by the passed payment hash. As the preimage isn't known, incoming HTLCs
+// paying to this invoice are held by the link until the invoice is either
+// settled with SettleHoldInvoice, or canceled with CancelInvoice.
+func (i *invoiceRegistry) AddHoldInvoice(invoice *channeldb.Invoice,
+	paymentHash chainhash.Hash) error {
+
+	ltndLog.Debugf("Adding hold invoice %v", newLogClosure(func() string {
+		return spew.Sdump(invoice)
+	}))
+
+	if err := i.cdb.AddHoldInvoice(invoice, paymentHash); err != nil {
+		return err
+	}
+
+	i.trackExpiry(invoice)
+
+	return nil
+}
+
+// AddKeySendInvoice adds an invoice for a spontaneous payment of the passed
+// amount, which the sender made without requesting an invoice first. The
+// preimage of the payment was chosen by the sender and delivered within the
+// onion, so the invoice can be settled right away. If an invoice for the
+// payment already exists, as the HTLC carrying it is being processed again,
+// it's left as is. An error is returned if spontaneous payments aren't
+// accepted.
+func (i *invoiceRegistry) AddKeySendInvoice(preimage [32]byte,
+	amt lnwire.MilliSatoshi) error {
+
+	if !i.acceptKeySend {
+		return fmt.Errorf("keysend payments not accepted")
+	}
+
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Memo:         []byte("keysend"),
+		Terms: channeldb.ContractTerm{
+			Value:           amt,
+			PaymentPreimage: preimage,
+		},
+	}
+
+	ltndLog.Debugf("Adding keysend invoice %v", newLogClosure(
+		func() string {
+			return spew.Sdump(invoice)
+		}),
+	)
+
+	err := i.cdb.AddInvoice(invoice)
+	switch {
+	case err == channeldb.ErrDuplicateInvoice:
+		return nil
+
+	case err != nil:
+		return err
+	}
+
+	return nil
 }
 
 // lookupInvoice looks up an invoice by its payment hash (R-Hash), if found
@@ -155,24 +367,345 @@ func (i *invoiceRegistry) SettleInvoice(rHash chainhash.Hash) error {
 
 		ltndLog.Infof("Payment received: %v", spew.Sdump(invoice))
 
-		i.notifyClients(invoice, true)
+		i.notifyClients(invoice, false)
 	}()
 
 	return nil
 }
 
+// AcceptInvoice marks the hold invoice matching the passed payment hash as
+// accepted, and subscribes the passed channel to its resolution. If the
+// invoice has already been resolved, the resolution is delivered immediately.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) AcceptInvoice(rHash chainhash.Hash,
+	hodlChan chan<- interface{}) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	ltndLog.Debugf("Accepting invoice %x", rHash[:])
+
+	invoice, err := i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		return err
+	}
+
+	switch invoice.Terms.State {
+
+	// The invoice was resolved in the meantime, so there's nothing left
+	// to wait for.
+	case channeldb.ContractSettled:
+		preimage := invoice.Terms.PaymentPreimage
+		hodlChan <- &htlcswitch.HodlEvent{
+			Hash:     rHash,
+			Preimage: &preimage,
+		}
+		return nil
+
+	case channeldb.ContractCanceled, channeldb.ContractExpired:
+		hodlChan <- &htlcswitch.HodlEvent{
+			Hash: rHash,
+		}
+		return nil
+
+	case channeldb.ContractOpen:
+		invoice, err = i.cdb.AcceptHoldInvoice(rHash)
+		if err != nil {
+			return err
+		}
+
+		go i.notifyClients(invoice, false)
+	}
+
+	i.hodlSubscribe(rHash, hodlChan)
+
+	return nil
+}
+
+// AcceptInvoiceShard adds an HTLC paying part of the invoice matching the
+// passed payment hash to the set of shards received for it, and subscribes the
+// passed channel to the resolution of the set. Once the shards add up to the
+// invoice amount, the invoice is settled, or accepted in the case of a hold
+// invoice. If they don't do so within the MPP timeout, all shards are failed
+// back.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) AcceptInvoiceShard(rHash chainhash.Hash,
+	htlcKey channeldb.CircuitKey, amt lnwire.MilliSatoshi,
+	hodlChan chan<- interface{}) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	ltndLog.Debugf("Accepting shard %v of %v for invoice %x", htlcKey,
+		amt, rHash[:])
+
+	invoice, err := i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		return err
+	}
+
+	switch invoice.Terms.State {
+
+	// The invoice was resolved in the meantime, so there's nothing left
+	// to wait for.
+	case channeldb.ContractSettled:
+		preimage := invoice.Terms.PaymentPreimage
+		hodlChan <- &htlcswitch.HodlEvent{
+			Hash:     rHash,
+			Preimage: &preimage,
+		}
+		return nil
+
+	case channeldb.ContractCanceled, channeldb.ContractExpired:
+		hodlChan <- &htlcswitch.HodlEvent{
+			Hash: rHash,
+		}
+		return nil
+
+	// The hold invoice has already been paid in full, so we'll hold on to
+	// this shard along with the others until the invoice is resolved.
+	case channeldb.ContractAccepted:
+		i.hodlSubscribe(rHash, hodlChan)
+		return nil
+	}
+
+	shards, ok := i.invoiceShards[rHash]
+	if !ok {
+		shards = &invoiceShardSet{
+			htlcs: make(map[channeldb.CircuitKey]lnwire.MilliSatoshi),
+		}
+		shards.timeout = time.AfterFunc(i.mppTimeout, func() {
+			i.timeoutShards(rHash, shards)
+		})
+		i.invoiceShards[rHash] = shards
+	}
+
+	// The same HTLC may be handed to us again if the link processing it
+	// is restarted, so we'll make sure to only count it once.
+	if _, ok := shards.htlcs[htlcKey]; !ok {
+		shards.htlcs[htlcKey] = amt
+		shards.total += amt
+	}
+	i.hodlSubscribe(rHash, hodlChan)
+
+	if shards.total < invoice.Terms.Value {
+		return nil
+	}
+
+	// The shards now add up to the invoice amount, so the set is
+	// complete.
+	i.removeShards(rHash)
+
+	ltndLog.Infof("Received %v shards paying %v for invoice %x",
+		len(shards.htlcs), shards.total, rHash[:])
+
+	// If this is a hold invoice, we'll mark it as accepted and keep on
+	// holding the shards until the invoice is either settled or canceled.
+	if invoice.Terms.PaymentPreimage == channeldb.UnknownPreimage {
+		invoice, err = i.cdb.AcceptHoldInvoice(rHash)
+		if err != nil {
+			return err
+		}
+
+		go i.notifyClients(invoice, false)
+
+		return nil
+	}
+
+	// Otherwise, we'll settle the invoice and all of its shards.
+	if err := i.cdb.SettleInvoice(rHash); err != nil {
+		return err
+	}
+
+	invoice, err = i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		return err
+	}
+
+	ltndLog.Infof("Payment received: %v", spew.Sdump(invoice))
+
+	preimage := invoice.Terms.PaymentPreimage
+	i.notifyHodlSubscribers(&htlcswitch.HodlEvent{
+		Hash:     rHash,
+		Preimage: &preimage,
+	})
+	go i.notifyClients(invoice, false)
+
+	return nil
+}
+
+// timeoutShards fails back all shards of the passed set, if the set still
+// hasn't added up to the invoice amount.
+func (i *invoiceRegistry) timeoutShards(rHash chainhash.Hash,
+	shards *invoiceShardSet) {
+
+	i.Lock()
+	defer i.Unlock()
+
+	// If the set was completed or removed in the meantime, there's
+	// nothing left to do.
+	if i.invoiceShards[rHash] != shards {
+		return
+	}
+	delete(i.invoiceShards, rHash)
+
+	ltndLog.Infof("Multi-path payment for invoice %x timed out after "+
+		"receiving %v", rHash[:], shards.total)
+
+	i.notifyHodlSubscribers(&htlcswitch.HodlEvent{
+		Hash:       rHash,
+		MPPTimeout: true,
+	})
+}
+
+// FailInvoiceShards fails back all shards received so far for the invoice
+// matching the passed payment hash, if they haven't added up to the invoice
+// amount yet. It's used once a shard of the payment is rejected, as the sender
+// then abandons the payment, and the shards would otherwise be held until the
+// MPP timeout.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) FailInvoiceShards(rHash chainhash.Hash) {
+	i.Lock()
+	defer i.Unlock()
+
+	shards, ok := i.invoiceShards[rHash]
+	if !ok {
+		return
+	}
+	i.removeShards(rHash)
+
+	ltndLog.Infof("Failing %v shards of multi-path payment for invoice "+
+		"%x after a shard was rejected", len(shards.htlcs), rHash[:])
+
+	i.notifyHodlSubscribers(&htlcswitch.HodlEvent{
+		Hash:       rHash,
+		MPPTimeout: true,
+	})
+}
+
+// removeShards stops tracking the set of shards received for the invoice
+// matching the passed payment hash.
+//
+// NOTE: This method MUST be called with the registry's lock held.
+func (i *invoiceRegistry) removeShards(rHash chainhash.Hash) {
+	shards, ok := i.invoiceShards[rHash]
+	if !ok {
+		return
+	}
+
+	shards.timeout.Stop()
+	delete(i.invoiceShards, rHash)
+}
+
+// SettleHoldInvoice settles the accepted hold invoice whose payment hash
+// matches the passed preimage, and instructs the links holding HTLCs paying to
+// it to settle them.
+func (i *invoiceRegistry) SettleHoldInvoice(preimage [32]byte) error {
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, err := i.cdb.SettleHoldInvoice(preimage)
+	if err != nil {
+		return err
+	}
+
+	rHash := chainhash.Hash(sha256.Sum256(preimage[:]))
+	ltndLog.Infof("Settled hold invoice %x", rHash[:])
+
+	i.notifyHodlSubscribers(&htlcswitch.HodlEvent{
+		Hash:     rHash,
+		Preimage: &preimage,
+	})
+	go i.notifyClients(invoice, false)
+
+	return nil
+}
+
+// CancelInvoice cancels the invoice matching the passed payment hash. Any
+// HTLCs being held for the invoice are failed back to the sender, and future
+// HTLCs paying to it will be rejected.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) CancelInvoice(rHash chainhash.Hash) error {
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, err := i.cdb.CancelInvoice(rHash)
+	if err != nil {
+		return err
+	}
+
+	ltndLog.Infof("Canceled invoice %x", rHash[:])
+
+	i.removeShards(rHash)
+	i.notifyHodlSubscribers(&htlcswitch.HodlEvent{
+		Hash: rHash,
+	})
+	go i.notifyClients(invoice, false)
+
+	return nil
+}
+
+// HodlUnsubscribeAll removes the passed channel from all hold invoice
+// subscriptions.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) HodlUnsubscribeAll(hodlChan chan<- interface{}) {
+	i.Lock()
+	defer i.Unlock()
+
+	for hash, subscribers := range i.hodlSubscriptions {
+		delete(subscribers, hodlChan)
+		if len(subscribers) == 0 {
+			delete(i.hodlSubscriptions, hash)
+		}
+	}
+}
+
+// hodlSubscribe subscribes the passed channel to the resolution of the HTLCs
+// held for the invoice matching the passed payment hash.
+//
+// NOTE: This method MUST be called with the registry's lock held.
+func (i *invoiceRegistry) hodlSubscribe(rHash chainhash.Hash,
+	hodlChan chan<- interface{}) {
+
+	subscribers, ok := i.hodlSubscriptions[rHash]
+	if !ok {
+		subscribers = make(map[chan<- interface{}]struct{})
+		i.hodlSubscriptions[rHash] = subscribers
+	}
+	subscribers[hodlChan] = struct{}{}
+}
+
+// notifyHodlSubscribers delivers the resolution of a hold invoice to all links
+// holding HTLCs paying to it, and removes their subscriptions.
+//
+// NOTE: This method MUST be called with the registry's lock held.
+func (i *invoiceRegistry) notifyHodlSubscribers(event *htlcswitch.HodlEvent) {
+	for hodlChan := range i.hodlSubscriptions[event.Hash] {
+		hodlChan <- event
+	}
+
+	delete(i.hodlSubscriptions, event.Hash)
+}
+
 // notifyClients notifies all currently registered invoice notification clients
-// of a newly added/settled invoice.
-func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice, settle bool) {
+// of a newly added invoice, or of a state change of an existing one.
+func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice,
+	newInvoice bool) {
+
 	i.clientMtx.Lock()
 	defer i.clientMtx.Unlock()
 
 	for _, client := range i.notificationClients {
 		var eventChan chan *channeldb.Invoice
-		if settle {
-			eventChan = client.SettledInvoices
-		} else {
+		if newInvoice {
 			eventChan = client.NewInvoices
+		} else {
+			eventChan = client.InvoiceUpdates
 		}
 
 		go func() {
@@ -182,13 +715,13 @@ func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice, settle bool)
 }
 
 // invoiceSubscription represents an intent to receive updates for newly added
-// or settled invoices. For each newly added invoice, a copy of the invoice
-// will be sent over the NewInvoices channel. Similarly, for each newly settled
-// invoice, a copy of the invoice will be sent over the SettledInvoices
-// channel.
+// or updated invoices. For each newly added invoice, a copy of the invoice
+// will be sent over the NewInvoices channel. Similarly, each time an invoice
+// is accepted, settled or canceled, a copy of the invoice will be sent over
+// the InvoiceUpdates channel.
 type invoiceSubscription struct {
-	NewInvoices     chan *channeldb.Invoice
-	SettledInvoices chan *channeldb.Invoice
+	NewInvoices    chan *channeldb.Invoice
+	InvoiceUpdates chan *channeldb.Invoice
 
 	inv *invoiceRegistry
 	id  uint32
@@ -203,13 +736,13 @@ func (i *invoiceSubscription) Cancel() {
 }
 
 // SubscribeNotifications returns an invoiceSubscription which allows the
-// caller to receive async notifications when any invoices are settled or
-// added.
+// caller to receive async notifications when any invoices are added or change
+// state.
 func (i *invoiceRegistry) SubscribeNotifications() *invoiceSubscription {
 	client := &invoiceSubscription{
-		NewInvoices:     make(chan *channeldb.Invoice),
-		SettledInvoices: make(chan *channeldb.Invoice),
-		inv:             i,
+		NewInvoices:    make(chan *channeldb.Invoice),
+		InvoiceUpdates: make(chan *channeldb.Invoice),
+		inv:            i,
 	}
 
 	i.clientMtx.Lock()
@@ -37,6 +37,7 @@ import (
 	proxy "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lightningnetwork/lnd/autopilot"
+	"github.com/lightningnetwork/lnd/chanacceptor"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
@@ -45,7 +46,10 @@ import (
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/walletunlocker"
+	"github.com/lightningnetwork/lnd/watchtower"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 	"github.com/roasbeef/btcwallet/wallet"
@@ -308,6 +312,65 @@ func lndMain() error {
 		return err
 	}
 
+	// If the watchtower is active, we'll initialize a standalone tower that
+	// accepts state updates from clients and monitors the chain for
+	// breaches on their behalf.
+	var tower *watchtower.Standalone
+	if cfg.Watchtower.Active {
+		// Segment the watchtower directory by chain and network.
+		towerDBDir := filepath.Join(
+			cfg.DataDir, defaultTowerSubDirname,
+			registeredChains.PrimaryChain().String(),
+			normalizeNetwork(activeNetParams.Name),
+		)
+
+		towerDB, err := wtdb.OpenTowerDB(towerDBDir)
+		if err != nil {
+			ltndLog.Errorf("unable to open watchtower db: %v", err)
+			return err
+		}
+		defer towerDB.Close()
+
+		towerPrivKey, err := activeChainControl.wallet.DerivePrivKey(
+			keychain.KeyDescriptor{
+				KeyLocator: keychain.KeyLocator{
+					Family: keychain.KeyFamilyTowerID,
+					Index:  0,
+				},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		towerPrivKey.Curve = btcec.S256()
+
+		listenAddrs := make(
+			[]net.Addr, 0, len(cfg.Watchtower.RawListeners),
+		)
+		for _, rawAddr := range cfg.Watchtower.RawListeners {
+			addr, err := cfg.net.ResolveTCPAddr("tcp", rawAddr)
+			if err != nil {
+				return err
+			}
+			listenAddrs = append(listenAddrs, addr)
+		}
+
+		wtConfig := cfg.Watchtower.Apply(&watchtower.Config{
+			BlockFetcher:   activeChainControl.chainIO,
+			DB:             towerDB,
+			EpochRegistrar: activeChainControl.chainNotifier,
+			NodePrivKey:    towerPrivKey,
+			PublishTx:      activeChainControl.wallet.PublishTransaction,
+			ListenAddrs:    listenAddrs,
+		})
+
+		tower, err = watchtower.New(wtConfig)
+		if err != nil {
+			ltndLog.Errorf("unable to create watchtower: %v", err)
+			return err
+		}
+	}
+
 	// Next, we'll initialize the funding manager itself so it can answer
 	// queries while the wallet+chain are still syncing.
 	nodeSigner := newNodeSigner(idPrivKey)
@@ -315,6 +378,26 @@ func lndMain() error {
 	if _, err := rand.Read(chanIDSeed[:]); err != nil {
 		return err
 	}
+	// The channel acceptors of all clients of the ChannelAcceptor RPC are
+	// chained together, and consulted for every inbound channel request.
+	chanPredicate := chanacceptor.NewChainedAcceptor()
+
+	// If requested, we'll commit to a fresh wallet address as our upfront
+	// shutdown script for each inbound channel.
+	var upfrontShutdownScript func() (lnwire.DeliveryAddress, error)
+	if cfg.UpfrontShutdown {
+		upfrontShutdownScript = func() (lnwire.DeliveryAddress, error) {
+			addr, err := activeChainControl.wallet.NewAddress(
+				lnwallet.WitnessPubKey, false,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return txscript.PayToAddrScript(addr)
+		}
+	}
+
 	fundingMgr, err := newFundingManager(fundingConfig{
 		IDKey:              idPrivKey.PubKey(),
 		Wallet:             activeChainControl.wallet,
@@ -388,20 +471,7 @@ func lndMain() error {
 
 			// If not we return a value scaled linearly
 			// between 3 and 6, depending on channel size.
-			// TODO(halseth): Use 1 as minimum?
-			minConf := uint64(3)
-			maxConf := uint64(6)
-			maxChannelSize := uint64(
-				lnwire.NewMSatFromSatoshis(maxFundingAmount))
-			stake := lnwire.NewMSatFromSatoshis(chanAmt) + pushAmt
-			conf := maxConf * uint64(stake) / maxChannelSize
-			if conf < minConf {
-				conf = minConf
-			}
-			if conf > maxConf {
-				conf = maxConf
-			}
-			return uint16(conf)
+			return scaledNumConfs(chanAmt, pushAmt)
 		},
 		RequiredRemoteDelay: func(chanAmt btcutil.Amount) uint16 {
 			// We scale the remote CSV delay (the time the
@@ -420,15 +490,9 @@ func lndMain() error {
 			}
 
 			// If not we scale according to channel size.
-			delay := uint16(btcutil.Amount(maxRemoteDelay) *
-				chanAmt / maxFundingAmount)
-			if delay < minRemoteDelay {
-				delay = minRemoteDelay
-			}
-			if delay > maxRemoteDelay {
-				delay = maxRemoteDelay
-			}
-			return delay
+			return scaledRemoteDelay(
+				chanAmt, minRemoteDelay, maxRemoteDelay,
+			)
 		},
 		WatchNewChannel: func(channel *channeldb.OpenChannel,
 			addr *lnwire.NetAddress) error {
@@ -442,7 +506,18 @@ func lndMain() error {
 
 			// With that taken care of, we'll send this channel to
 			// the chain arb so it can react to on-chain events.
-			return server.chainArb.WatchNewChannel(channel)
+			if err := server.chainArb.WatchNewChannel(channel); err != nil {
+				return err
+			}
+
+			// Finally, we'll notify the rest of the daemon of the
+			// new channel, so the static channel backup can be
+			// updated to include it.
+			server.chanNotifier.NotifyNewChannel(
+				channel, []net.Addr{addr.Address},
+			)
+
+			return nil
 		},
 		ReportShortChanID: func(chanPoint wire.OutPoint,
 			sid lnwire.ShortChannelID) error {
@@ -470,6 +545,10 @@ func lndMain() error {
 		},
 		ZombieSweeperInterval: 1 * time.Minute,
 		ReservationTimeout:    10 * time.Minute,
+		MaxChanSize:           btcutil.Amount(cfg.MaxChanSize),
+		AnchorCommitments:     cfg.AnchorCommitments,
+		OpenChannelPredicate:  chanPredicate,
+		UpfrontShutdownScript: upfrontShutdownScript,
 	})
 	if err != nil {
 		return err
@@ -491,7 +570,7 @@ func lndMain() error {
 
 	// Initialize, and register our implementation of the gRPC interface
 	// exported by the rpcServer.
-	rpcServer := newRPCServer(server)
+	rpcServer := newRPCServer(server, chanPredicate)
 	if err := rpcServer.Start(); err != nil {
 		return err
 	}
@@ -575,6 +654,15 @@ func lndMain() error {
 		return err
 	}
 
+	// Once the server is running, start the watchtower if one was
+	// configured.
+	if tower != nil {
+		if err := tower.Start(); err != nil {
+			ltndLog.Errorf("unable to start watchtower: %v", err)
+			return err
+		}
+	}
+
 	// Now that the server has started, if the autopilot mode is currently
 	// active, then we'll initialize a fresh instance of it and start it.
 	var pilot *autopilot.Agent
@@ -598,6 +686,10 @@ func lndMain() error {
 		fundingMgr.Stop()
 		server.Stop()
 
+		if tower != nil {
+			tower.Stop()
+		}
+
 		if pilot != nil {
 			pilot.Stop()
 		}
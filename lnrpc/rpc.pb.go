@@ -105,6 +105,51 @@ It has these top-level messages:
 	ForwardingHistoryRequest
 	ForwardingEvent
 	ForwardingHistoryResponse
+	ExportChannelBackupRequest
+	ChannelBackup
+	MultiChanBackup
+	ChanBackupExportRequest
+	ChanBackupSnapshot
+	ChannelBackups
+	RestoreChanBackupRequest
+	RestoreBackupResponse
+	VerifyChanBackupResponse
+	AddHoldInvoiceRequest
+	SettleInvoiceMsg
+	SettleInvoiceResp
+	CancelInvoiceMsg
+	CancelInvoiceResp
+	DeleteCanceledInvoicesRequest
+	DeleteCanceledInvoicesResponse
+	ChannelAcceptRequest
+	ChannelAcceptResponse
+	BatchOpenChannelRequest
+	BatchOpenChannel
+	BatchOpenChannelResponse
+	ReadyForPsbtFunding
+	FinalizePsbtFundingRequest
+	FinalizePsbtFundingResponse
+	CircuitKey
+	ForwardHtlcInterceptRequest
+	ForwardHtlcInterceptResponse
+	SubscribeHtlcEventsRequest
+	HtlcEvent
+	HtlcInfo
+	ForwardEvent
+	ForwardFailEvent
+	SettleEvent
+	LinkFailEvent
+	QueryMissionControlRequest
+	QueryMissionControlResponse
+	PairHistory
+	ImportMissionControlRequest
+	ImportMissionControlResponse
+	ResetMissionControlRequest
+	ResetMissionControlResponse
+	SendToRouteRequest
+	BuildRouteRequest
+	BuildRouteResponse
+	RebalanceRequest
 */
 package lnrpc
 
@@ -152,6 +197,157 @@ func (NewAddressRequest_AddressType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor0, []int{17, 0}
 }
 
+type Invoice_InvoiceState int32
+
+const (
+	Invoice_OPEN     Invoice_InvoiceState = 0
+	Invoice_SETTLED  Invoice_InvoiceState = 1
+	Invoice_CANCELED Invoice_InvoiceState = 2
+	Invoice_ACCEPTED Invoice_InvoiceState = 3
+	Invoice_EXPIRED  Invoice_InvoiceState = 4
+)
+
+var Invoice_InvoiceState_name = map[int32]string{
+	0: "OPEN",
+	1: "SETTLED",
+	2: "CANCELED",
+	3: "ACCEPTED",
+	4: "EXPIRED",
+}
+var Invoice_InvoiceState_value = map[string]int32{
+	"OPEN":     0,
+	"SETTLED":  1,
+	"CANCELED": 2,
+	"ACCEPTED": 3,
+	"EXPIRED":  4,
+}
+
+func (x Invoice_InvoiceState) String() string {
+	return proto.EnumName(Invoice_InvoiceState_name, int32(x))
+}
+func (Invoice_InvoiceState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{74, 0} }
+
+type ForwardHtlcInterceptResponse_ResolveAction int32
+
+const (
+	ForwardHtlcInterceptResponse_RESUME ForwardHtlcInterceptResponse_ResolveAction = 0
+	ForwardHtlcInterceptResponse_FAIL   ForwardHtlcInterceptResponse_ResolveAction = 1
+	ForwardHtlcInterceptResponse_SETTLE ForwardHtlcInterceptResponse_ResolveAction = 2
+)
+
+var ForwardHtlcInterceptResponse_ResolveAction_name = map[int32]string{
+	0: "RESUME",
+	1: "FAIL",
+	2: "SETTLE",
+}
+var ForwardHtlcInterceptResponse_ResolveAction_value = map[string]int32{
+	"RESUME": 0,
+	"FAIL":   1,
+	"SETTLE": 2,
+}
+
+func (x ForwardHtlcInterceptResponse_ResolveAction) String() string {
+	return proto.EnumName(ForwardHtlcInterceptResponse_ResolveAction_name, int32(x))
+}
+func (ForwardHtlcInterceptResponse_ResolveAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{123, 0}
+}
+
+type HtlcEvent_EventType int32
+
+const (
+	HtlcEvent_UNKNOWN HtlcEvent_EventType = 0
+	HtlcEvent_SEND    HtlcEvent_EventType = 1
+	HtlcEvent_RECEIVE HtlcEvent_EventType = 2
+	HtlcEvent_FORWARD HtlcEvent_EventType = 3
+)
+
+var HtlcEvent_EventType_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "SEND",
+	2: "RECEIVE",
+	3: "FORWARD",
+}
+var HtlcEvent_EventType_value = map[string]int32{
+	"UNKNOWN": 0,
+	"SEND":    1,
+	"RECEIVE": 2,
+	"FORWARD": 3,
+}
+
+func (x HtlcEvent_EventType) String() string {
+	return proto.EnumName(HtlcEvent_EventType_name, int32(x))
+}
+func (HtlcEvent_EventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{125, 0} }
+
+type LinkFailEvent_FailureDetail int32
+
+const (
+	LinkFailEvent_NO_DETAIL             LinkFailEvent_FailureDetail = 0
+	LinkFailEvent_ONION_DECODE          LinkFailEvent_FailureDetail = 1
+	LinkFailEvent_ONION_ENCODE          LinkFailEvent_FailureDetail = 2
+	LinkFailEvent_EXPIRY_TOO_SOON       LinkFailEvent_FailureDetail = 3
+	LinkFailEvent_AMOUNT_BELOW_MINIMUM  LinkFailEvent_FailureDetail = 4
+	LinkFailEvent_FEE_INSUFFICIENT      LinkFailEvent_FailureDetail = 5
+	LinkFailEvent_INCORRECT_CLTV_EXPIRY LinkFailEvent_FailureDetail = 6
+	LinkFailEvent_INCORRECT_AMOUNT      LinkFailEvent_FailureDetail = 7
+	LinkFailEvent_UNKNOWN_INVOICE       LinkFailEvent_FailureDetail = 8
+	LinkFailEvent_INVOICE_CANCELED      LinkFailEvent_FailureDetail = 9
+	LinkFailEvent_INVALID_KEYSEND       LinkFailEvent_FailureDetail = 10
+	LinkFailEvent_MPP_TIMEOUT           LinkFailEvent_FailureDetail = 11
+	LinkFailEvent_UNKNOWN_NEXT_PEER     LinkFailEvent_FailureDetail = 12
+	LinkFailEvent_INSUFFICIENT_BALANCE  LinkFailEvent_FailureDetail = 13
+	LinkFailEvent_ADD_REJECTED          LinkFailEvent_FailureDetail = 14
+	LinkFailEvent_INCOMPLETE_FORWARD    LinkFailEvent_FailureDetail = 15
+	LinkFailEvent_INTERCEPTED           LinkFailEvent_FailureDetail = 16
+)
+
+var LinkFailEvent_FailureDetail_name = map[int32]string{
+	0:  "NO_DETAIL",
+	1:  "ONION_DECODE",
+	2:  "ONION_ENCODE",
+	3:  "EXPIRY_TOO_SOON",
+	4:  "AMOUNT_BELOW_MINIMUM",
+	5:  "FEE_INSUFFICIENT",
+	6:  "INCORRECT_CLTV_EXPIRY",
+	7:  "INCORRECT_AMOUNT",
+	8:  "UNKNOWN_INVOICE",
+	9:  "INVOICE_CANCELED",
+	10: "INVALID_KEYSEND",
+	11: "MPP_TIMEOUT",
+	12: "UNKNOWN_NEXT_PEER",
+	13: "INSUFFICIENT_BALANCE",
+	14: "ADD_REJECTED",
+	15: "INCOMPLETE_FORWARD",
+	16: "INTERCEPTED",
+}
+var LinkFailEvent_FailureDetail_value = map[string]int32{
+	"NO_DETAIL":             0,
+	"ONION_DECODE":          1,
+	"ONION_ENCODE":          2,
+	"EXPIRY_TOO_SOON":       3,
+	"AMOUNT_BELOW_MINIMUM":  4,
+	"FEE_INSUFFICIENT":      5,
+	"INCORRECT_CLTV_EXPIRY": 6,
+	"INCORRECT_AMOUNT":      7,
+	"UNKNOWN_INVOICE":       8,
+	"INVOICE_CANCELED":      9,
+	"INVALID_KEYSEND":       10,
+	"MPP_TIMEOUT":           11,
+	"UNKNOWN_NEXT_PEER":     12,
+	"INSUFFICIENT_BALANCE":  13,
+	"ADD_REJECTED":          14,
+	"INCOMPLETE_FORWARD":    15,
+	"INTERCEPTED":           16,
+}
+
+func (x LinkFailEvent_FailureDetail) String() string {
+	return proto.EnumName(LinkFailEvent_FailureDetail_name, int32(x))
+}
+func (LinkFailEvent_FailureDetail) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{130, 0}
+}
+
 type GenSeedRequest struct {
 	// *
 	// aezeed_passphrase is an optional user provided passphrase that will be used
@@ -417,6 +613,23 @@ type SendRequest struct {
 	PaymentRequest string `protobuf:"bytes,6,opt,name=payment_request,json=paymentRequest" json:"payment_request,omitempty"`
 	// / The CLTV delta from the current height that should be used to set the timelock for the final hop.
 	FinalCltvDelta int32 `protobuf:"varint,7,opt,name=final_cltv_delta,json=finalCltvDelta" json:"final_cltv_delta,omitempty"`
+	// *
+	// The maximum number of shards the payment may be split into, each of which
+	// is sent along a different path. Payments are only split if the destination
+	// supports multi-path payments. If zero or one, the payment is sent in full
+	// along a single path. Once a shard fails permanently, the payment is
+	// abandoned. Shards that already reached the destination are locked up until
+	// it fails them back, which happens as soon as the destination rejects a
+	// shard itself, but otherwise only once its multi-path payment timeout
+	// expires.
+	MaxShards uint32 `protobuf:"varint,8,opt,name=max_shards,json=maxShards" json:"max_shards,omitempty"`
+	// *
+	// If set, a spontaneous payment is made to the destination without an
+	// invoice. The preimage of the payment is generated by the sender, and
+	// delivered to the destination within the onion. No payment hash or payment
+	// request may be set, and the payment isn't split. The destination must have
+	// enabled the acceptance of such payments.
+	KeySend bool `protobuf:"varint,9,opt,name=key_send,json=keySend" json:"key_send,omitempty"`
 }
 
 func (m *SendRequest) Reset()                    { *m = SendRequest{} }
@@ -473,10 +686,26 @@ func (m *SendRequest) GetFinalCltvDelta() int32 {
 	return 0
 }
 
+func (m *SendRequest) GetMaxShards() uint32 {
+	if m != nil {
+		return m.MaxShards
+	}
+	return 0
+}
+
+func (m *SendRequest) GetKeySend() bool {
+	if m != nil {
+		return m.KeySend
+	}
+	return false
+}
+
 type SendResponse struct {
 	PaymentError    string `protobuf:"bytes,1,opt,name=payment_error" json:"payment_error,omitempty"`
 	PaymentPreimage []byte `protobuf:"bytes,2,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
 	PaymentRoute    *Route `protobuf:"bytes,3,opt,name=payment_route" json:"payment_route,omitempty"`
+	// / If the payment was split, the routes taken by each of its shards.
+	ShardRoutes []*Route `protobuf:"bytes,4,rep,name=shard_routes" json:"shard_routes,omitempty"`
 }
 
 func (m *SendResponse) Reset()                    { *m = SendResponse{} }
@@ -505,6 +734,13 @@ func (m *SendResponse) GetPaymentRoute() *Route {
 	return nil
 }
 
+func (m *SendResponse) GetShardRoutes() []*Route {
+	if m != nil {
+		return m.ShardRoutes
+	}
+	return nil
+}
+
 type ChannelPoint struct {
 	// Types that are valid to be assigned to FundingTxid:
 	//	*ChannelPoint_FundingTxidBytes
@@ -1551,6 +1787,13 @@ type CloseChannelRequest struct {
 	TargetConf int32 `protobuf:"varint,3,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
 	// / A manual fee rate set in sat/byte that should be used when crafting the closure transaction.
 	SatPerByte int64 `protobuf:"varint,4,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	// *
+	// The maximum fee rate in sat/byte that we're willing to pay for a
+	// cooperative closure transaction. The fee negotiated with the remote party
+	// will never exceed this rate, and the closure will fail if the remote party
+	// insists on a higher fee. If this is not set, then the negotiated fee is not
+	// constrained.
+	MaxSatPerByte int64 `protobuf:"varint,5,opt,name=max_sat_per_byte" json:"max_sat_per_byte,omitempty"`
 }
 
 func (m *CloseChannelRequest) Reset()                    { *m = CloseChannelRequest{} }
@@ -1586,6 +1829,13 @@ func (m *CloseChannelRequest) GetSatPerByte() int64 {
 	return 0
 }
 
+func (m *CloseChannelRequest) GetMaxSatPerByte() int64 {
+	if m != nil {
+		return m.MaxSatPerByte
+	}
+	return 0
+}
+
 type CloseStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*CloseStatusUpdate_ClosePending
@@ -1781,6 +2031,19 @@ type OpenChannelRequest struct {
 	MinHtlcMsat int64 `protobuf:"varint,9,opt,name=min_htlc_msat" json:"min_htlc_msat,omitempty"`
 	// / The delay we require on the remote's commitment transaction. If this is not set, it will be scaled automatically with the channel size.
 	RemoteCsvDelay uint32 `protobuf:"varint,10,opt,name=remote_csv_delay" json:"remote_csv_delay,omitempty"`
+	// *
+	// An optional address to which our funds will be paid upon a cooperative
+	// close of the channel. The address is committed to when the channel is
+	// opened, so it can't later be changed, even if this node's wallet is
+	// compromised. The remote peer must support upfront shutdown scripts.
+	CloseAddress string `protobuf:"bytes,11,opt,name=close_address" json:"close_address,omitempty"`
+	// *
+	// Whether the channel should be funded by an external wallet through a PSBT
+	// rather than by the internal wallet. If set, the funding flow pauses once
+	// the funding output has been negotiated with the remote peer, and a
+	// ReadyForPsbtFunding update is sent, after which FinalizePsbtFunding must
+	// be called to resume the flow.
+	PsbtFunding bool `protobuf:"varint,12,opt,name=psbt_funding" json:"psbt_funding,omitempty"`
 }
 
 func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
@@ -1851,11 +2114,26 @@ func (m *OpenChannelRequest) GetRemoteCsvDelay() uint32 {
 	return 0
 }
 
+func (m *OpenChannelRequest) GetCloseAddress() string {
+	if m != nil {
+		return m.CloseAddress
+	}
+	return ""
+}
+
+func (m *OpenChannelRequest) GetPsbtFunding() bool {
+	if m != nil {
+		return m.PsbtFunding
+	}
+	return false
+}
+
 type OpenStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*OpenStatusUpdate_ChanPending
 	//	*OpenStatusUpdate_Confirmation
 	//	*OpenStatusUpdate_ChanOpen
+	//	*OpenStatusUpdate_PsbtFund
 	Update isOpenStatusUpdate_Update `protobuf_oneof:"update"`
 }
 
@@ -1877,10 +2155,14 @@ type OpenStatusUpdate_Confirmation struct {
 type OpenStatusUpdate_ChanOpen struct {
 	ChanOpen *ChannelOpenUpdate `protobuf:"bytes,3,opt,name=chan_open,oneof"`
 }
+type OpenStatusUpdate_PsbtFund struct {
+	PsbtFund *ReadyForPsbtFunding `protobuf:"bytes,4,opt,name=psbt_fund,oneof"`
+}
 
 func (*OpenStatusUpdate_ChanPending) isOpenStatusUpdate_Update()  {}
 func (*OpenStatusUpdate_Confirmation) isOpenStatusUpdate_Update() {}
 func (*OpenStatusUpdate_ChanOpen) isOpenStatusUpdate_Update()     {}
+func (*OpenStatusUpdate_PsbtFund) isOpenStatusUpdate_Update()     {}
 
 func (m *OpenStatusUpdate) GetUpdate() isOpenStatusUpdate_Update {
 	if m != nil {
@@ -1910,12 +2192,20 @@ func (m *OpenStatusUpdate) GetChanOpen() *ChannelOpenUpdate {
 	return nil
 }
 
+func (m *OpenStatusUpdate) GetPsbtFund() *ReadyForPsbtFunding {
+	if x, ok := m.GetUpdate().(*OpenStatusUpdate_PsbtFund); ok {
+		return x.PsbtFund
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*OpenStatusUpdate) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _OpenStatusUpdate_OneofMarshaler, _OpenStatusUpdate_OneofUnmarshaler, _OpenStatusUpdate_OneofSizer, []interface{}{
 		(*OpenStatusUpdate_ChanPending)(nil),
 		(*OpenStatusUpdate_Confirmation)(nil),
 		(*OpenStatusUpdate_ChanOpen)(nil),
+		(*OpenStatusUpdate_PsbtFund)(nil),
 	}
 }
 
@@ -1938,6 +2228,11 @@ func _OpenStatusUpdate_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.ChanOpen); err != nil {
 			return err
 		}
+	case *OpenStatusUpdate_PsbtFund:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PsbtFund); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("OpenStatusUpdate.Update has unexpected type %T", x)
@@ -1972,6 +2267,14 @@ func _OpenStatusUpdate_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Update = &OpenStatusUpdate_ChanOpen{msg}
 		return true, err
+	case 4: // update.psbt_fund
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ReadyForPsbtFunding)
+		err := b.DecodeMessage(msg)
+		m.Update = &OpenStatusUpdate_PsbtFund{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1996,6 +2299,11 @@ func _OpenStatusUpdate_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(3<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *OpenStatusUpdate_PsbtFund:
+		s := proto.Size(x.PsbtFund)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -2475,6 +2783,12 @@ type Hop struct {
 	AmtToForward int64  `protobuf:"varint,3,opt,name=amt_to_forward" json:"amt_to_forward,omitempty"`
 	Fee          int64  `protobuf:"varint,4,opt,name=fee" json:"fee,omitempty"`
 	Expiry       uint32 `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
+	// / The amount to forward to the next hop, in millisatoshis.
+	AmtToForwardMsat int64 `protobuf:"varint,6,opt,name=amt_to_forward_msat" json:"amt_to_forward_msat,omitempty"`
+	// / The fee charged by the node of this hop, in millisatoshis.
+	FeeMsat int64 `protobuf:"varint,7,opt,name=fee_msat" json:"fee_msat,omitempty"`
+	// / The public key of the node this hop leads to, in hex.
+	PubKey string `protobuf:"bytes,8,opt,name=pub_key" json:"pub_key,omitempty"`
 }
 
 func (m *Hop) Reset()                    { *m = Hop{} }
@@ -2517,6 +2831,27 @@ func (m *Hop) GetExpiry() uint32 {
 	return 0
 }
 
+func (m *Hop) GetAmtToForwardMsat() int64 {
+	if m != nil {
+		return m.AmtToForwardMsat
+	}
+	return 0
+}
+
+func (m *Hop) GetFeeMsat() int64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
+
+func (m *Hop) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
 // *
 // A path through the channel graph which runs over one or more channels in
 // succession. This struct carries all the information required to craft the
@@ -2545,6 +2880,12 @@ type Route struct {
 	// *
 	// Contains details concerning the specific forwarding details at each hop.
 	Hops []*Hop `protobuf:"bytes,4,rep,name=hops" json:"hops,omitempty"`
+	// / The sum of the fees paid at each hop, in millisatoshis.
+	TotalFeesMsat int64 `protobuf:"varint,5,opt,name=total_fees_msat" json:"total_fees_msat,omitempty"`
+	// *
+	// The total amount required to complete a payment over this route,
+	// including fees, in millisatoshis.
+	TotalAmtMsat int64 `protobuf:"varint,6,opt,name=total_amt_msat" json:"total_amt_msat,omitempty"`
 }
 
 func (m *Route) Reset()                    { *m = Route{} }
@@ -2580,6 +2921,20 @@ func (m *Route) GetHops() []*Hop {
 	return nil
 }
 
+func (m *Route) GetTotalFeesMsat() int64 {
+	if m != nil {
+		return m.TotalFeesMsat
+	}
+	return 0
+}
+
+func (m *Route) GetTotalAmtMsat() int64 {
+	if m != nil {
+		return m.TotalAmtMsat
+	}
+	return 0
+}
+
 type NodeInfoRequest struct {
 	// / The 33-byte hex-encoded compressed public of the target node
 	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
@@ -3215,6 +3570,14 @@ type Invoice struct {
 	FallbackAddr string `protobuf:"bytes,12,opt,name=fallback_addr" json:"fallback_addr,omitempty"`
 	// / Delta to use for the time-lock of the CLTV extended to the final hop.
 	CltvExpiry uint64 `protobuf:"varint,13,opt,name=cltv_expiry" json:"cltv_expiry,omitempty"`
+	// / The state the invoice is in.
+	State Invoice_InvoiceState `protobuf:"varint,14,opt,name=state,enum=lnrpc.Invoice_InvoiceState" json:"state,omitempty"`
+	// *
+	// Whether this invoice should include routing hints for private channels.
+	// The hints are selected among our active private channels with a publicly
+	// advertised peer, and enough inbound capacity to receive the invoice
+	// amount.
+	Private bool `protobuf:"varint,15,opt,name=private" json:"private,omitempty"`
 }
 
 func (m *Invoice) Reset()                    { *m = Invoice{} }
@@ -3313,6 +3676,20 @@ func (m *Invoice) GetCltvExpiry() uint64 {
 	return 0
 }
 
+func (m *Invoice) GetState() Invoice_InvoiceState {
+	if m != nil {
+		return m.State
+	}
+	return Invoice_OPEN
+}
+
+func (m *Invoice) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
 type AddInvoiceResponse struct {
 	RHash []byte `protobuf:"bytes,1,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
 	// *
@@ -4033,1942 +4410,4352 @@ func (m *ForwardingHistoryResponse) GetLastOffsetIndex() uint32 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*GenSeedRequest)(nil), "lnrpc.GenSeedRequest")
-	proto.RegisterType((*GenSeedResponse)(nil), "lnrpc.GenSeedResponse")
-	proto.RegisterType((*InitWalletRequest)(nil), "lnrpc.InitWalletRequest")
-	proto.RegisterType((*InitWalletResponse)(nil), "lnrpc.InitWalletResponse")
-	proto.RegisterType((*UnlockWalletRequest)(nil), "lnrpc.UnlockWalletRequest")
-	proto.RegisterType((*UnlockWalletResponse)(nil), "lnrpc.UnlockWalletResponse")
-	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
-	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
-	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
-	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
-	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
-	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
-	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
-	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
-	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
-	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
-	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
-	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
-	proto.RegisterType((*NewWitnessAddressRequest)(nil), "lnrpc.NewWitnessAddressRequest")
-	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
-	proto.RegisterType((*SignMessageRequest)(nil), "lnrpc.SignMessageRequest")
-	proto.RegisterType((*SignMessageResponse)(nil), "lnrpc.SignMessageResponse")
-	proto.RegisterType((*VerifyMessageRequest)(nil), "lnrpc.VerifyMessageRequest")
-	proto.RegisterType((*VerifyMessageResponse)(nil), "lnrpc.VerifyMessageResponse")
-	proto.RegisterType((*ConnectPeerRequest)(nil), "lnrpc.ConnectPeerRequest")
-	proto.RegisterType((*ConnectPeerResponse)(nil), "lnrpc.ConnectPeerResponse")
-	proto.RegisterType((*DisconnectPeerRequest)(nil), "lnrpc.DisconnectPeerRequest")
-	proto.RegisterType((*DisconnectPeerResponse)(nil), "lnrpc.DisconnectPeerResponse")
-	proto.RegisterType((*HTLC)(nil), "lnrpc.HTLC")
-	proto.RegisterType((*Channel)(nil), "lnrpc.Channel")
-	proto.RegisterType((*ListChannelsRequest)(nil), "lnrpc.ListChannelsRequest")
-	proto.RegisterType((*ListChannelsResponse)(nil), "lnrpc.ListChannelsResponse")
-	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
-	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
-	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
-	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
-	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
-	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
-	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
-	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
-	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
-	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
-	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
-	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
-	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
-	proto.RegisterType((*PendingHTLC)(nil), "lnrpc.PendingHTLC")
-	proto.RegisterType((*PendingChannelsRequest)(nil), "lnrpc.PendingChannelsRequest")
-	proto.RegisterType((*PendingChannelsResponse)(nil), "lnrpc.PendingChannelsResponse")
-	proto.RegisterType((*PendingChannelsResponse_PendingChannel)(nil), "lnrpc.PendingChannelsResponse.PendingChannel")
-	proto.RegisterType((*PendingChannelsResponse_PendingOpenChannel)(nil), "lnrpc.PendingChannelsResponse.PendingOpenChannel")
-	proto.RegisterType((*PendingChannelsResponse_ClosedChannel)(nil), "lnrpc.PendingChannelsResponse.ClosedChannel")
-	proto.RegisterType((*PendingChannelsResponse_ForceClosedChannel)(nil), "lnrpc.PendingChannelsResponse.ForceClosedChannel")
-	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
-	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
-	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
-	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
-	proto.RegisterType((*QueryRoutesRequest)(nil), "lnrpc.QueryRoutesRequest")
-	proto.RegisterType((*QueryRoutesResponse)(nil), "lnrpc.QueryRoutesResponse")
-	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
-	proto.RegisterType((*Route)(nil), "lnrpc.Route")
-	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
-	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
-	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
-	proto.RegisterType((*NodeAddress)(nil), "lnrpc.NodeAddress")
-	proto.RegisterType((*RoutingPolicy)(nil), "lnrpc.RoutingPolicy")
-	proto.RegisterType((*ChannelEdge)(nil), "lnrpc.ChannelEdge")
-	proto.RegisterType((*ChannelGraphRequest)(nil), "lnrpc.ChannelGraphRequest")
-	proto.RegisterType((*ChannelGraph)(nil), "lnrpc.ChannelGraph")
-	proto.RegisterType((*ChanInfoRequest)(nil), "lnrpc.ChanInfoRequest")
-	proto.RegisterType((*NetworkInfoRequest)(nil), "lnrpc.NetworkInfoRequest")
-	proto.RegisterType((*NetworkInfo)(nil), "lnrpc.NetworkInfo")
-	proto.RegisterType((*StopRequest)(nil), "lnrpc.StopRequest")
-	proto.RegisterType((*StopResponse)(nil), "lnrpc.StopResponse")
-	proto.RegisterType((*GraphTopologySubscription)(nil), "lnrpc.GraphTopologySubscription")
-	proto.RegisterType((*GraphTopologyUpdate)(nil), "lnrpc.GraphTopologyUpdate")
-	proto.RegisterType((*NodeUpdate)(nil), "lnrpc.NodeUpdate")
-	proto.RegisterType((*ChannelEdgeUpdate)(nil), "lnrpc.ChannelEdgeUpdate")
-	proto.RegisterType((*ClosedChannelUpdate)(nil), "lnrpc.ClosedChannelUpdate")
-	proto.RegisterType((*Invoice)(nil), "lnrpc.Invoice")
-	proto.RegisterType((*AddInvoiceResponse)(nil), "lnrpc.AddInvoiceResponse")
-	proto.RegisterType((*PaymentHash)(nil), "lnrpc.PaymentHash")
-	proto.RegisterType((*ListInvoiceRequest)(nil), "lnrpc.ListInvoiceRequest")
-	proto.RegisterType((*ListInvoiceResponse)(nil), "lnrpc.ListInvoiceResponse")
-	proto.RegisterType((*InvoiceSubscription)(nil), "lnrpc.InvoiceSubscription")
-	proto.RegisterType((*Payment)(nil), "lnrpc.Payment")
-	proto.RegisterType((*ListPaymentsRequest)(nil), "lnrpc.ListPaymentsRequest")
-	proto.RegisterType((*ListPaymentsResponse)(nil), "lnrpc.ListPaymentsResponse")
-	proto.RegisterType((*DeleteAllPaymentsRequest)(nil), "lnrpc.DeleteAllPaymentsRequest")
-	proto.RegisterType((*DeleteAllPaymentsResponse)(nil), "lnrpc.DeleteAllPaymentsResponse")
-	proto.RegisterType((*DebugLevelRequest)(nil), "lnrpc.DebugLevelRequest")
-	proto.RegisterType((*DebugLevelResponse)(nil), "lnrpc.DebugLevelResponse")
-	proto.RegisterType((*PayReqString)(nil), "lnrpc.PayReqString")
-	proto.RegisterType((*PayReq)(nil), "lnrpc.PayReq")
-	proto.RegisterType((*FeeReportRequest)(nil), "lnrpc.FeeReportRequest")
-	proto.RegisterType((*ChannelFeeReport)(nil), "lnrpc.ChannelFeeReport")
-	proto.RegisterType((*FeeReportResponse)(nil), "lnrpc.FeeReportResponse")
-	proto.RegisterType((*PolicyUpdateRequest)(nil), "lnrpc.PolicyUpdateRequest")
-	proto.RegisterType((*PolicyUpdateResponse)(nil), "lnrpc.PolicyUpdateResponse")
-	proto.RegisterType((*ForwardingHistoryRequest)(nil), "lnrpc.ForwardingHistoryRequest")
-	proto.RegisterType((*ForwardingEvent)(nil), "lnrpc.ForwardingEvent")
-	proto.RegisterType((*ForwardingHistoryResponse)(nil), "lnrpc.ForwardingHistoryResponse")
-	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+type ExportChannelBackupRequest struct {
+	// / The target channel point to obtain a back up for.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *ExportChannelBackupRequest) Reset()                    { *m = ExportChannelBackupRequest{} }
+func (m *ExportChannelBackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportChannelBackupRequest) ProtoMessage()               {}
+func (*ExportChannelBackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
 
-// Client API for WalletUnlocker service
+func (m *ExportChannelBackupRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
 
-type WalletUnlockerClient interface {
+type ChannelBackup struct {
 	// *
-	// GenSeed is the first method that should be used to instantiate a new lnd
-	// instance. This method allows a caller to generate a new aezeed cipher seed
-	// given an optional passphrase. If provided, the passphrase will be necessary
-	// to decrypt the cipherseed to expose the internal wallet seed.
-	//
-	// Once the cipherseed is obtained and verified by the user, the InitWallet
-	// method should be used to commit the newly generated seed, and create the
-	// wallet.
-	GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error)
+	// Identifies the channel that this backup belongs to.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
 	// *
-	// InitWallet is used when lnd is starting up for the first time to fully
-	// initialize the daemon and its internal wallet. At the very least a wallet
-	// password must be provided. This will be used to encrypt sensitive material
-	// on disk.
-	//
-	// In the case of a recovery scenario, the user can also specify their aezeed
-	// mnemonic and passphrase. If set, then the daemon will use this prior state
-	// to initialize its internal wallet.
-	//
-	// Alternatively, this can be used along with the GenSeed RPC to obtain a
-	// seed, then present it to the user. Once it has been verified by the user,
-	// the seed can be fed into this RPC in order to commit the new wallet.
-	InitWallet(ctx context.Context, in *InitWalletRequest, opts ...grpc.CallOption) (*InitWalletResponse, error)
-	// * lncli: `unlock`
-	// UnlockWallet is used at startup of lnd to provide a password to unlock
-	// the wallet database.
-	UnlockWallet(ctx context.Context, in *UnlockWalletRequest, opts ...grpc.CallOption) (*UnlockWalletResponse, error)
+	// Is an encrypted single-chan backup. This can be passed to
+	// RestoreChannelBackups in order to trigger the recovery protocol.
+	ChanBackup []byte `protobuf:"bytes,2,opt,name=chan_backup,proto3" json:"chan_backup,omitempty"`
 }
 
-type walletUnlockerClient struct {
-	cc *grpc.ClientConn
-}
+func (m *ChannelBackup) Reset()                    { *m = ChannelBackup{} }
+func (m *ChannelBackup) String() string            { return proto.CompactTextString(m) }
+func (*ChannelBackup) ProtoMessage()               {}
+func (*ChannelBackup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
 
-func NewWalletUnlockerClient(cc *grpc.ClientConn) WalletUnlockerClient {
-	return &walletUnlockerClient{cc}
+func (m *ChannelBackup) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
 }
 
-func (c *walletUnlockerClient) GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error) {
-	out := new(GenSeedResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/GenSeed", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelBackup) GetChanBackup() []byte {
+	if m != nil {
+		return m.ChanBackup
 	}
-	return out, nil
+	return nil
 }
 
-func (c *walletUnlockerClient) InitWallet(ctx context.Context, in *InitWalletRequest, opts ...grpc.CallOption) (*InitWalletResponse, error) {
-	out := new(InitWalletResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/InitWallet", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type MultiChanBackup struct {
+	// *
+	// Is the set of all channels that are included in this multi-channel backup.
+	ChanPoints []*ChannelPoint `protobuf:"bytes,1,rep,name=chan_points" json:"chan_points,omitempty"`
+	// *
+	// A single encrypted blob containing all the static channel backups of the
+	// channels listed above. This can be stored as a single file or blob, and
+	// safely be replaced with any prior/future versions.
+	MultiChanBackup []byte `protobuf:"bytes,2,opt,name=multi_chan_backup,proto3" json:"multi_chan_backup,omitempty"`
+}
+
+func (m *MultiChanBackup) Reset()                    { *m = MultiChanBackup{} }
+func (m *MultiChanBackup) String() string            { return proto.CompactTextString(m) }
+func (*MultiChanBackup) ProtoMessage()               {}
+func (*MultiChanBackup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
+
+func (m *MultiChanBackup) GetChanPoints() []*ChannelPoint {
+	if m != nil {
+		return m.ChanPoints
 	}
-	return out, nil
+	return nil
 }
 
-func (c *walletUnlockerClient) UnlockWallet(ctx context.Context, in *UnlockWalletRequest, opts ...grpc.CallOption) (*UnlockWalletResponse, error) {
-	out := new(UnlockWalletResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/UnlockWallet", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *MultiChanBackup) GetMultiChanBackup() []byte {
+	if m != nil {
+		return m.MultiChanBackup
 	}
-	return out, nil
+	return nil
 }
 
-// Server API for WalletUnlocker service
+type ChanBackupExportRequest struct {
+}
 
-type WalletUnlockerServer interface {
+func (m *ChanBackupExportRequest) Reset()                    { *m = ChanBackupExportRequest{} }
+func (m *ChanBackupExportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChanBackupExportRequest) ProtoMessage()               {}
+func (*ChanBackupExportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+
+type ChanBackupSnapshot struct {
 	// *
-	// GenSeed is the first method that should be used to instantiate a new lnd
-	// instance. This method allows a caller to generate a new aezeed cipher seed
-	// given an optional passphrase. If provided, the passphrase will be necessary
-	// to decrypt the cipherseed to expose the internal wallet seed.
-	//
-	// Once the cipherseed is obtained and verified by the user, the InitWallet
-	// method should be used to commit the newly generated seed, and create the
-	// wallet.
-	GenSeed(context.Context, *GenSeedRequest) (*GenSeedResponse, error)
+	// The set of single-chan backups for all open channels currently known to
+	// lnd.
+	SingleChanBackups *ChannelBackups `protobuf:"bytes,1,opt,name=single_chan_backups" json:"single_chan_backups,omitempty"`
 	// *
-	// InitWallet is used when lnd is starting up for the first time to fully
-	// initialize the daemon and its internal wallet. At the very least a wallet
-	// password must be provided. This will be used to encrypt sensitive material
-	// on disk.
-	//
-	// In the case of a recovery scenario, the user can also specify their aezeed
-	// mnemonic and passphrase. If set, then the daemon will use this prior state
-	// to initialize its internal wallet.
-	//
-	// Alternatively, this can be used along with the GenSeed RPC to obtain a
-	// seed, then present it to the user. Once it has been verified by the user,
-	// the seed can be fed into this RPC in order to commit the new wallet.
-	InitWallet(context.Context, *InitWalletRequest) (*InitWalletResponse, error)
-	// * lncli: `unlock`
-	// UnlockWallet is used at startup of lnd to provide a password to unlock
-	// the wallet database.
-	UnlockWallet(context.Context, *UnlockWalletRequest) (*UnlockWalletResponse, error)
+	// A multi-channel backup that covers all open channels currently known to
+	// lnd.
+	MultiChanBackup *MultiChanBackup `protobuf:"bytes,2,opt,name=multi_chan_backup" json:"multi_chan_backup,omitempty"`
 }
 
-func RegisterWalletUnlockerServer(s *grpc.Server, srv WalletUnlockerServer) {
-	s.RegisterService(&_WalletUnlocker_serviceDesc, srv)
+func (m *ChanBackupSnapshot) Reset()                    { *m = ChanBackupSnapshot{} }
+func (m *ChanBackupSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*ChanBackupSnapshot) ProtoMessage()               {}
+func (*ChanBackupSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
+
+func (m *ChanBackupSnapshot) GetSingleChanBackups() *ChannelBackups {
+	if m != nil {
+		return m.SingleChanBackups
+	}
+	return nil
 }
 
-func _WalletUnlocker_GenSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GenSeedRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ChanBackupSnapshot) GetMultiChanBackup() *MultiChanBackup {
+	if m != nil {
+		return m.MultiChanBackup
 	}
-	if interceptor == nil {
-		return srv.(WalletUnlockerServer).GenSeed(ctx, in)
+	return nil
+}
+
+type ChannelBackups struct {
+	// *
+	// A set of single-chan static channel backups.
+	ChanBackups []*ChannelBackup `protobuf:"bytes,1,rep,name=chan_backups" json:"chan_backups,omitempty"`
+}
+
+func (m *ChannelBackups) Reset()                    { *m = ChannelBackups{} }
+func (m *ChannelBackups) String() string            { return proto.CompactTextString(m) }
+func (*ChannelBackups) ProtoMessage()               {}
+func (*ChannelBackups) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
+
+func (m *ChannelBackups) GetChanBackups() []*ChannelBackup {
+	if m != nil {
+		return m.ChanBackups
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.WalletUnlocker/GenSeed",
+	return nil
+}
+
+type RestoreChanBackupRequest struct {
+	// Types that are valid to be assigned to Backup:
+	//	*RestoreChanBackupRequest_ChanBackups
+	//	*RestoreChanBackupRequest_MultiChanBackup
+	Backup isRestoreChanBackupRequest_Backup `protobuf_oneof:"backup"`
+}
+
+func (m *RestoreChanBackupRequest) Reset()                    { *m = RestoreChanBackupRequest{} }
+func (m *RestoreChanBackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*RestoreChanBackupRequest) ProtoMessage()               {}
+func (*RestoreChanBackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
+
+type isRestoreChanBackupRequest_Backup interface {
+	isRestoreChanBackupRequest_Backup()
+}
+
+type RestoreChanBackupRequest_ChanBackups struct {
+	ChanBackups *ChannelBackups `protobuf:"bytes,1,opt,name=chan_backups,oneof"`
+}
+type RestoreChanBackupRequest_MultiChanBackup struct {
+	MultiChanBackup []byte `protobuf:"bytes,2,opt,name=multi_chan_backup,proto3,oneof"`
+}
+
+func (*RestoreChanBackupRequest_ChanBackups) isRestoreChanBackupRequest_Backup()     {}
+func (*RestoreChanBackupRequest_MultiChanBackup) isRestoreChanBackupRequest_Backup() {}
+
+func (m *RestoreChanBackupRequest) GetBackup() isRestoreChanBackupRequest_Backup {
+	if m != nil {
+		return m.Backup
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletUnlockerServer).GenSeed(ctx, req.(*GenSeedRequest))
+	return nil
+}
+
+func (m *RestoreChanBackupRequest) GetChanBackups() *ChannelBackups {
+	if x, ok := m.GetBackup().(*RestoreChanBackupRequest_ChanBackups); ok {
+		return x.ChanBackups
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _WalletUnlocker_InitWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InitWalletRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *RestoreChanBackupRequest) GetMultiChanBackup() []byte {
+	if x, ok := m.GetBackup().(*RestoreChanBackupRequest_MultiChanBackup); ok {
+		return x.MultiChanBackup
 	}
-	if interceptor == nil {
-		return srv.(WalletUnlockerServer).InitWallet(ctx, in)
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RestoreChanBackupRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RestoreChanBackupRequest_OneofMarshaler, _RestoreChanBackupRequest_OneofUnmarshaler, _RestoreChanBackupRequest_OneofSizer, []interface{}{
+		(*RestoreChanBackupRequest_ChanBackups)(nil),
+		(*RestoreChanBackupRequest_MultiChanBackup)(nil),
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.WalletUnlocker/InitWallet",
+}
+
+func _RestoreChanBackupRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RestoreChanBackupRequest)
+	// backup
+	switch x := m.Backup.(type) {
+	case *RestoreChanBackupRequest_ChanBackups:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChanBackups); err != nil {
+			return err
+		}
+	case *RestoreChanBackupRequest_MultiChanBackup:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeRawBytes(x.MultiChanBackup)
+	case nil:
+	default:
+		return fmt.Errorf("RestoreChanBackupRequest.Backup has unexpected type %T", x)
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletUnlockerServer).InitWallet(ctx, req.(*InitWalletRequest))
+	return nil
+}
+
+func _RestoreChanBackupRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RestoreChanBackupRequest)
+	switch tag {
+	case 1: // backup.chan_backups
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelBackups)
+		err := b.DecodeMessage(msg)
+		m.Backup = &RestoreChanBackupRequest_ChanBackups{msg}
+		return true, err
+	case 2: // backup.multi_chan_backup
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeRawBytes(true)
+		m.Backup = &RestoreChanBackupRequest_MultiChanBackup{x}
+		return true, err
+	default:
+		return false, nil
 	}
-	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletUnlocker_UnlockWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnlockWalletRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func _RestoreChanBackupRequest_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RestoreChanBackupRequest)
+	// backup
+	switch x := m.Backup.(type) {
+	case *RestoreChanBackupRequest_ChanBackups:
+		s := proto.Size(x.ChanBackups)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RestoreChanBackupRequest_MultiChanBackup:
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.MultiChanBackup)))
+		n += len(x.MultiChanBackup)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
 	}
-	if interceptor == nil {
-		return srv.(WalletUnlockerServer).UnlockWallet(ctx, in)
+	return n
+}
+
+type RestoreBackupResponse struct {
+}
+
+func (m *RestoreBackupResponse) Reset()                    { *m = RestoreBackupResponse{} }
+func (m *RestoreBackupResponse) String() string            { return proto.CompactTextString(m) }
+func (*RestoreBackupResponse) ProtoMessage()               {}
+func (*RestoreBackupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
+
+type VerifyChanBackupResponse struct {
+}
+
+func (m *VerifyChanBackupResponse) Reset()                    { *m = VerifyChanBackupResponse{} }
+func (m *VerifyChanBackupResponse) String() string            { return proto.CompactTextString(m) }
+func (*VerifyChanBackupResponse) ProtoMessage()               {}
+func (*VerifyChanBackupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
+
+type AddHoldInvoiceRequest struct {
+	// *
+	// An optional memo to attach along with the invoice. Used for record keeping
+	// purposes for the invoice's creator, and will also be set in the description
+	// field of the encoded payment request if the description_hash field is not
+	// being used.
+	Memo string `protobuf:"bytes,1,opt,name=memo" json:"memo,omitempty"`
+	// / The hash of the preimage
+	Hash []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// / The value of this invoice in satoshis
+	Value int64 `protobuf:"varint,3,opt,name=value" json:"value,omitempty"`
+	// *
+	// Hash (SHA-256) of a description of the payment. Used if the description of
+	// payment (memo) is too long to naturally fit within the description field
+	// of an encoded payment request.
+	DescriptionHash []byte `protobuf:"bytes,4,opt,name=description_hash,proto3" json:"description_hash,omitempty"`
+	// / Payment request expiry time in seconds. Default is 3600 (1 hour).
+	Expiry int64 `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
+	// / Fallback on-chain address.
+	FallbackAddr string `protobuf:"bytes,6,opt,name=fallback_addr" json:"fallback_addr,omitempty"`
+	// / Delta to use for the time-lock of the CLTV extended to the final hop.
+	CltvExpiry uint64 `protobuf:"varint,7,opt,name=cltv_expiry" json:"cltv_expiry,omitempty"`
+}
+
+func (m *AddHoldInvoiceRequest) Reset()                    { *m = AddHoldInvoiceRequest{} }
+func (m *AddHoldInvoiceRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddHoldInvoiceRequest) ProtoMessage()               {}
+func (*AddHoldInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
+
+func (m *AddHoldInvoiceRequest) GetMemo() string {
+	if m != nil {
+		return m.Memo
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.WalletUnlocker/UnlockWallet",
+	return ""
+}
+
+func (m *AddHoldInvoiceRequest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletUnlockerServer).UnlockWallet(ctx, req.(*UnlockWalletRequest))
+	return nil
+}
+
+func (m *AddHoldInvoiceRequest) GetValue() int64 {
+	if m != nil {
+		return m.Value
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-var _WalletUnlocker_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "lnrpc.WalletUnlocker",
-	HandlerType: (*WalletUnlockerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GenSeed",
-			Handler:    _WalletUnlocker_GenSeed_Handler,
-		},
-		{
-			MethodName: "InitWallet",
-			Handler:    _WalletUnlocker_InitWallet_Handler,
-		},
-		{
-			MethodName: "UnlockWallet",
-			Handler:    _WalletUnlocker_UnlockWallet_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "rpc.proto",
+func (m *AddHoldInvoiceRequest) GetDescriptionHash() []byte {
+	if m != nil {
+		return m.DescriptionHash
+	}
+	return nil
 }
 
-// Client API for Lightning service
+func (m *AddHoldInvoiceRequest) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
 
-type LightningClient interface {
-	// * lncli: `walletbalance`
-	// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
-	// confirmed unspent outputs and all unconfirmed unspent outputs under control
-	// of the wallet.
-	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
-	// * lncli: `channelbalance`
-	// ChannelBalance returns the total funds available across all open channels
-	// in satoshis.
-	ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error)
-	// * lncli: `listchaintxns`
-	// GetTransactions returns a list describing all the known transactions
-	// relevant to the wallet.
-	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error)
-	// * lncli: `sendcoins`
-	// SendCoins executes a request to send coins to a particular address. Unlike
-	// SendMany, this RPC call only allows creating a single output at a time. If
-	// neither target_conf, or sat_per_byte are set, then the internal wallet will
-	// consult its fee model to determine a fee for the default confirmation
-	// target.
-	SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error)
-	// *
-	// SubscribeTransactions creates a uni-directional stream from the server to
-	// the client in which any newly discovered transactions relevant to the
-	// wallet are sent over.
-	SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error)
-	// * lncli: `sendmany`
-	// SendMany handles a request for a transaction that creates multiple specified
-	// outputs in parallel. If neither target_conf, or sat_per_byte are set, then
-	// the internal wallet will consult its fee model to determine a fee for the
-	// default confirmation target.
-	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
-	// * lncli: `newaddress`
-	// NewAddress creates a new address under control of the local wallet.
-	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
-	// *
-	// NewWitnessAddress creates a new witness address under control of the local wallet.
-	NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
-	// * lncli: `signmessage`
-	// SignMessage signs a message with this node's private key. The returned
-	// signature string is `zbase32` encoded and pubkey recoverable, meaning that
-	// only the message digest and signature are needed for verification.
-	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
-	// * lncli: `verifymessage`
-	// VerifyMessage verifies a signature over a msg. The signature must be
-	// zbase32 encoded and signed by an active node in the resident node's
-	// channel database. In addition to returning the validity of the signature,
-	// VerifyMessage also returns the recovered pubkey from the signature.
-	VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error)
-	// * lncli: `connect`
-	// ConnectPeer attempts to establish a connection to a remote peer. This is at
-	// the networking level, and is used for communication between nodes. This is
-	// distinct from establishing a channel with a peer.
-	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error)
-	// * lncli: `disconnect`
-	// DisconnectPeer attempts to disconnect one peer from another identified by a
-	// given pubKey. In the case that we currently have a pending or active channel
-	// with the target peer, then this action will be not be allowed.
-	DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error)
-	// * lncli: `listpeers`
-	// ListPeers returns a verbose listing of all currently active peers.
-	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
-	// * lncli: `getinfo`
-	// GetInfo returns general information concerning the lightning node including
-	// it's identity pubkey, alias, the chains it is connected to, and information
-	// concerning the number of open+pending channels.
-	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
-	// * lncli: `pendingchannels`
-	// PendingChannels returns a list of all the channels that are currently
-	// considered "pending". A channel is pending if it has finished the funding
-	// workflow and is waiting for confirmations for the funding txn, or is in the
-	// process of closure, either initiated cooperatively or non-cooperatively.
-	PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error)
-	// * lncli: `listchannels`
-	// ListChannels returns a description of all the open channels that this node
-	// is a participant in.
-	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
-	// *
-	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
-	// call is meant to be consumed by clients to the REST proxy. As with all
-	// other sync calls, all byte slices are intended to be populated as hex
-	// encoded strings.
-	OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error)
-	// * lncli: `openchannel`
-	// OpenChannel attempts to open a singly funded channel specified in the
-	// request to a remote peer. Users are able to specify a target number of
-	// blocks that the funding transaction should be confirmed in, or a manual fee
-	// rate to us for the funding transaction. If neither are specified, then a
-	// lax block confirmation target is used.
-	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
-	// * lncli: `closechannel`
-	// CloseChannel attempts to close an active channel identified by its channel
-	// outpoint (ChannelPoint). The actions of this method can additionally be
-	// augmented to attempt a force close after a timeout period in the case of an
-	// inactive peer. If a non-force close (cooperative closure) is requested,
-	// then the user can specify either a target number of blocks until the
-	// closure transaction is confirmed, or a manual fee rate. If neither are
-	// specified, then a default lax, block confirmation target is used.
-	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
-	// * lncli: `sendpayment`
-	// SendPayment dispatches a bi-directional streaming RPC for sending payments
-	// through the Lightning Network. A single RPC invocation creates a persistent
-	// bi-directional stream allowing clients to rapidly send payments through the
-	// Lightning Network with a single persistent connection.
-	SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error)
-	// *
-	// SendPaymentSync is the synchronous non-streaming version of SendPayment.
-	// This RPC is intended to be consumed by clients of the REST proxy.
-	// Additionally, this RPC expects the destination's public key and the payment
-	// hash (if any) to be encoded as hex strings.
-	SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
-	// * lncli: `addinvoice`
-	// AddInvoice attempts to add a new invoice to the invoice database. Any
-	// duplicated invoices are rejected, therefore all invoices *must* have a
-	// unique payment preimage.
-	AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
-	// * lncli: `listinvoices`
-	// ListInvoices returns a list of all the invoices currently stored within the
-	// database. Any active debug invoices are ignored.
-	ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error)
-	// * lncli: `lookupinvoice`
-	// LookupInvoice attempts to look up an invoice according to its payment hash.
-	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
-	// returned.
-	LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error)
-	// *
-	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
-	// notifying the client of newly added/settled invoices.
-	SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error)
-	// * lncli: `decodepayreq`
-	// DecodePayReq takes an encoded payment request string and attempts to decode
-	// it, returning a full description of the conditions encoded within the
-	// payment request.
-	DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error)
-	// * lncli: `listpayments`
-	// ListPayments returns a list of all outgoing payments.
-	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
-	// *
-	// DeleteAllPayments deletes all outgoing payments from DB.
-	DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error)
-	// * lncli: `describegraph`
-	// DescribeGraph returns a description of the latest graph state from the
-	// point of view of the node. The graph information is partitioned into two
-	// components: all the nodes/vertexes, and all the edges that connect the
-	// vertexes themselves.  As this is a directed graph, the edges also contain
-	// the node directional specific routing policy which includes: the time lock
-	// delta, fee information, etc.
-	DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error)
-	// * lncli: `getchaninfo`
-	// GetChanInfo returns the latest authenticated network announcement for the
-	// given channel identified by its channel ID: an 8-byte integer which
-	// uniquely identifies the location of transaction's funding output within the
-	// blockchain.
-	GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error)
-	// * lncli: `getnodeinfo`
-	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
-	// channel information for the specified node identified by its public key.
-	GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error)
-	// * lncli: `queryroutes`
-	// QueryRoutes attempts to query the daemon's Channel Router for a possible
-	// route to a target destination capable of carrying a specific amount of
-	// satoshis. The retuned route contains the full details required to craft and
-	// send an HTLC, also including the necessary information that should be
-	// present within the Sphinx packet encapsulated within the HTLC.
-	QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error)
-	// * lncli: `getnetworkinfo`
-	// GetNetworkInfo returns some basic stats about the known channel graph from
-	// the point of view of the node.
-	GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error)
-	// * lncli: `stop`
-	// StopDaemon will send a shutdown request to the interrupt handler, triggering
-	// a graceful shutdown of the daemon.
-	StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
-	// *
-	// SubscribeChannelGraph launches a streaming RPC that allows the caller to
-	// receive notifications upon any changes to the channel graph topology from
-	// the point of view of the responding node. Events notified include: new
-	// nodes coming online, nodes updating their authenticated attributes, new
-	// channels being advertised, updates in the routing policy for a directional
-	// channel edge, and when channels are closed on-chain.
-	SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error)
-	// * lncli: `debuglevel`
-	// DebugLevel allows a caller to programmatically set the logging verbosity of
-	// lnd. The logging can be targeted according to a coarse daemon-wide logging
-	// level, or in a granular fashion to specify the logging for a target
-	// sub-system.
-	DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error)
-	// * lncli: `feereport`
-	// FeeReport allows the caller to obtain a report detailing the current fee
-	// schedule enforced by the node globally for each channel.
-	FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error)
-	// * lncli: `updatechanpolicy`
-	// UpdateChannelPolicy allows the caller to update the fee schedule and
-	// channel policies for all channels globally, or a particular channel.
-	UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error)
-	// * lncli: `fwdinghistory`
-	// ForwardingHistory allows the caller to query the htlcswitch for a record of
-	// all HTLC's forwarded within the target time range, and integer offset
-	// within that time range. If no time-range is specified, then the first chunk
-	// of the past 24 hrs of forwarding history are returned.
-	//
-	// A list of forwarding events are returned. The size of each forwarding event
-	// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
-	// As a result each message can only contain 50k entries.  Each response has
-	// the index offset of the last entry. The index offset can be provided to the
-	// request to allow the caller to skip a series of records.
-	ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error)
+func (m *AddHoldInvoiceRequest) GetFallbackAddr() string {
+	if m != nil {
+		return m.FallbackAddr
+	}
+	return ""
 }
 
-type lightningClient struct {
-	cc *grpc.ClientConn
+func (m *AddHoldInvoiceRequest) GetCltvExpiry() uint64 {
+	if m != nil {
+		return m.CltvExpiry
+	}
+	return 0
 }
 
-func NewLightningClient(cc *grpc.ClientConn) LightningClient {
-	return &lightningClient{cc}
+type SettleInvoiceMsg struct {
+	// / Externally discovered pre-image that should be used to settle the hold invoice.
+	Preimage []byte `protobuf:"bytes,1,opt,name=preimage,proto3" json:"preimage,omitempty"`
 }
 
-func (c *lightningClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
-	out := new(WalletBalanceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/WalletBalance", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SettleInvoiceMsg) Reset()                    { *m = SettleInvoiceMsg{} }
+func (m *SettleInvoiceMsg) String() string            { return proto.CompactTextString(m) }
+func (*SettleInvoiceMsg) ProtoMessage()               {}
+func (*SettleInvoiceMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
+
+func (m *SettleInvoiceMsg) GetPreimage() []byte {
+	if m != nil {
+		return m.Preimage
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error) {
-	out := new(ChannelBalanceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ChannelBalance", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type SettleInvoiceResp struct {
 }
 
-func (c *lightningClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error) {
-	out := new(TransactionDetails)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetTransactions", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *SettleInvoiceResp) Reset()                    { *m = SettleInvoiceResp{} }
+func (m *SettleInvoiceResp) String() string            { return proto.CompactTextString(m) }
+func (*SettleInvoiceResp) ProtoMessage()               {}
+func (*SettleInvoiceResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
+
+type CancelInvoiceMsg struct {
+	// / Hash corresponding to the (hold) invoice to cancel.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
 }
 
-func (c *lightningClient) SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error) {
-	out := new(SendCoinsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCoins", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CancelInvoiceMsg) Reset()                    { *m = CancelInvoiceMsg{} }
+func (m *CancelInvoiceMsg) String() string            { return proto.CompactTextString(m) }
+func (*CancelInvoiceMsg) ProtoMessage()               {}
+func (*CancelInvoiceMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
+
+func (m *CancelInvoiceMsg) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[0], c.cc, "/lnrpc.Lightning/SubscribeTransactions", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningSubscribeTransactionsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+type CancelInvoiceResp struct {
 }
 
-type Lightning_SubscribeTransactionsClient interface {
-	Recv() (*Transaction, error)
-	grpc.ClientStream
+func (m *CancelInvoiceResp) Reset()                    { *m = CancelInvoiceResp{} }
+func (m *CancelInvoiceResp) String() string            { return proto.CompactTextString(m) }
+func (*CancelInvoiceResp) ProtoMessage()               {}
+func (*CancelInvoiceResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
+
+type DeleteCanceledInvoicesRequest struct {
+	// *
+	// Only canceled and expired invoices created before this unix timestamp are
+	// deleted. If zero, all canceled and expired invoices are deleted.
+	CreatedBefore int64 `protobuf:"varint,1,opt,name=created_before" json:"created_before,omitempty"`
 }
 
-type lightningSubscribeTransactionsClient struct {
-	grpc.ClientStream
+func (m *DeleteCanceledInvoicesRequest) Reset()         { *m = DeleteCanceledInvoicesRequest{} }
+func (m *DeleteCanceledInvoicesRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCanceledInvoicesRequest) ProtoMessage()    {}
+func (*DeleteCanceledInvoicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{111}
 }
 
-func (x *lightningSubscribeTransactionsClient) Recv() (*Transaction, error) {
-	m := new(Transaction)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *DeleteCanceledInvoicesRequest) GetCreatedBefore() int64 {
+	if m != nil {
+		return m.CreatedBefore
 	}
-	return m, nil
+	return 0
 }
 
-func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error) {
-	out := new(SendManyResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendMany", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type DeleteCanceledInvoicesResponse struct {
+	// / The number of invoices that were deleted.
+	NumDeleted uint32 `protobuf:"varint,1,opt,name=num_deleted" json:"num_deleted,omitempty"`
 }
 
-func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
-	out := new(NewAddressResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *DeleteCanceledInvoicesResponse) Reset()         { *m = DeleteCanceledInvoicesResponse{} }
+func (m *DeleteCanceledInvoicesResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteCanceledInvoicesResponse) ProtoMessage()    {}
+func (*DeleteCanceledInvoicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{112}
 }
 
-func (c *lightningClient) NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
-	out := new(NewAddressResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewWitnessAddress", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeleteCanceledInvoicesResponse) GetNumDeleted() uint32 {
+	if m != nil {
+		return m.NumDeleted
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
-	out := new(SignMessageResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SignMessage", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type ChannelAcceptRequest struct {
+	// / The pubkey of the node that wishes to open an inbound channel.
+	NodePubkey []byte `protobuf:"bytes,1,opt,name=node_pubkey,proto3" json:"node_pubkey,omitempty"`
+	// / The hash of the genesis block that the proposed channel resides in.
+	ChainHash []byte `protobuf:"bytes,2,opt,name=chain_hash,proto3" json:"chain_hash,omitempty"`
+	// / The pending channel id, which must be given in the response.
+	PendingChanId []byte `protobuf:"bytes,3,opt,name=pending_chan_id,proto3" json:"pending_chan_id,omitempty"`
+	// / The funding amount in satoshis that the initiator wishes to use in the channel.
+	FundingAmt uint64 `protobuf:"varint,4,opt,name=funding_amt" json:"funding_amt,omitempty"`
+	// / The push amount of the proposed channel in millisatoshis.
+	PushAmt uint64 `protobuf:"varint,5,opt,name=push_amt" json:"push_amt,omitempty"`
+	// / The dust limit of the initiator's commitment tx.
+	DustLimit uint64 `protobuf:"varint,6,opt,name=dust_limit" json:"dust_limit,omitempty"`
+	// / The maximum amount of coins in millisatoshis that can be pending in this channel.
+	MaxValueInFlight uint64 `protobuf:"varint,7,opt,name=max_value_in_flight" json:"max_value_in_flight,omitempty"`
+	// / The minimum amount of satoshis the initiator requires us to have at all times.
+	ChannelReserve uint64 `protobuf:"varint,8,opt,name=channel_reserve" json:"channel_reserve,omitempty"`
+	// / The smallest HTLC in millisatoshis that the initiator will accept.
+	MinHtlc uint64 `protobuf:"varint,9,opt,name=min_htlc" json:"min_htlc,omitempty"`
+	// / The initial fee rate that the initiator suggests for both commitment transactions.
+	FeePerKw uint64 `protobuf:"varint,10,opt,name=fee_per_kw" json:"fee_per_kw,omitempty"`
+	// *
+	// The number of blocks to use for the relative time lock in the pay-to-self
+	// output of both commitment transactions.
+	CsvDelay uint32 `protobuf:"varint,11,opt,name=csv_delay" json:"csv_delay,omitempty"`
+	// / The total number of incoming HTLC's that the initiator will accept.
+	MaxAcceptedHtlcs uint32 `protobuf:"varint,12,opt,name=max_accepted_htlcs" json:"max_accepted_htlcs,omitempty"`
+	// / A bit-field which the initiator uses to specify proposed channel behavior.
+	ChannelFlags uint32 `protobuf:"varint,13,opt,name=channel_flags" json:"channel_flags,omitempty"`
+}
+
+func (m *ChannelAcceptRequest) Reset()                    { *m = ChannelAcceptRequest{} }
+func (m *ChannelAcceptRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChannelAcceptRequest) ProtoMessage()               {}
+func (*ChannelAcceptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
+
+func (m *ChannelAcceptRequest) GetNodePubkey() []byte {
+	if m != nil {
+		return m.NodePubkey
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error) {
-	out := new(VerifyMessageResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/VerifyMessage", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetChainHash() []byte {
+	if m != nil {
+		return m.ChainHash
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error) {
-	out := new(ConnectPeerResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ConnectPeer", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetPendingChanId() []byte {
+	if m != nil {
+		return m.PendingChanId
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error) {
-	out := new(DisconnectPeerResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DisconnectPeer", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetFundingAmt() uint64 {
+	if m != nil {
+		return m.FundingAmt
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
-	out := new(ListPeersResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPeers", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetPushAmt() uint64 {
+	if m != nil {
+		return m.PushAmt
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
-	out := new(GetInfoResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetDustLimit() uint64 {
+	if m != nil {
+		return m.DustLimit
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error) {
-	out := new(PendingChannelsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetMaxValueInFlight() uint64 {
+	if m != nil {
+		return m.MaxValueInFlight
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
-	out := new(ListChannelsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetChannelReserve() uint64 {
+	if m != nil {
+		return m.ChannelReserve
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error) {
-	out := new(ChannelPoint)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/OpenChannelSync", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetMinHtlc() uint64 {
+	if m != nil {
+		return m.MinHtlc
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetFeePerKw() uint64 {
+	if m != nil {
+		return m.FeePerKw
 	}
-	x := &lightningOpenChannelClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *ChannelAcceptRequest) GetCsvDelay() uint32 {
+	if m != nil {
+		return m.CsvDelay
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *ChannelAcceptRequest) GetMaxAcceptedHtlcs() uint32 {
+	if m != nil {
+		return m.MaxAcceptedHtlcs
 	}
-	return x, nil
+	return 0
 }
 
-type Lightning_OpenChannelClient interface {
-	Recv() (*OpenStatusUpdate, error)
-	grpc.ClientStream
+func (m *ChannelAcceptRequest) GetChannelFlags() uint32 {
+	if m != nil {
+		return m.ChannelFlags
+	}
+	return 0
 }
 
-type lightningOpenChannelClient struct {
-	grpc.ClientStream
+type ChannelAcceptResponse struct {
+	// / Whether or not the client accepts the channel.
+	Accept bool `protobuf:"varint,1,opt,name=accept" json:"accept,omitempty"`
+	// / The pending channel id to which this response applies.
+	PendingChanId []byte `protobuf:"bytes,2,opt,name=pending_chan_id,proto3" json:"pending_chan_id,omitempty"`
+	// *
+	// The reason for rejecting the channel, which is sent to the remote peer. It
+	// must not contain any private information.
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
 }
 
-func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
-	m := new(OpenStatusUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ChannelAcceptResponse) Reset()                    { *m = ChannelAcceptResponse{} }
+func (m *ChannelAcceptResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChannelAcceptResponse) ProtoMessage()               {}
+func (*ChannelAcceptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
+
+func (m *ChannelAcceptResponse) GetAccept() bool {
+	if m != nil {
+		return m.Accept
 	}
-	return m, nil
+	return false
 }
 
-func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningCloseChannelClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *ChannelAcceptResponse) GetPendingChanId() []byte {
+	if m != nil {
+		return m.PendingChanId
 	}
-	return x, nil
+	return nil
 }
 
-type Lightning_CloseChannelClient interface {
-	Recv() (*CloseStatusUpdate, error)
-	grpc.ClientStream
+func (m *ChannelAcceptResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
 }
 
-type lightningCloseChannelClient struct {
-	grpc.ClientStream
+type BatchOpenChannelRequest struct {
+	// / The list of channels to open.
+	Channels []*BatchOpenChannel `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+	// / The target number of blocks that the funding transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,2,opt,name=target_conf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that should be used when crafting the funding transaction.
+	SatPerByte int64 `protobuf:"varint,3,opt,name=sat_per_byte" json:"sat_per_byte,omitempty"`
 }
 
-func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
-	m := new(CloseStatusUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *BatchOpenChannelRequest) Reset()                    { *m = BatchOpenChannelRequest{} }
+func (m *BatchOpenChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*BatchOpenChannelRequest) ProtoMessage()               {}
+func (*BatchOpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
+
+func (m *BatchOpenChannelRequest) GetChannels() []*BatchOpenChannel {
+	if m != nil {
+		return m.Channels
 	}
-	return m, nil
+	return nil
 }
 
-func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
-	if err != nil {
-		return nil, err
+func (m *BatchOpenChannelRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
 	}
-	x := &lightningSendPaymentClient{stream}
-	return x, nil
+	return 0
 }
 
-type Lightning_SendPaymentClient interface {
-	Send(*SendRequest) error
-	Recv() (*SendResponse, error)
-	grpc.ClientStream
+func (m *BatchOpenChannelRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
 }
 
-type lightningSendPaymentClient struct {
-	grpc.ClientStream
+type BatchOpenChannel struct {
+	// / The pubkey of the node to open a channel with.
+	NodePubkey []byte `protobuf:"bytes,1,opt,name=node_pubkey,proto3" json:"node_pubkey,omitempty"`
+	// / The number of satoshis the wallet should commit to the channel.
+	LocalFundingAmount int64 `protobuf:"varint,2,opt,name=local_funding_amount" json:"local_funding_amount,omitempty"`
+	// / The number of satoshis to push to the remote side as part of the initial commitment state.
+	PushSat int64 `protobuf:"varint,3,opt,name=push_sat" json:"push_sat,omitempty"`
+	// / Whether this channel should be private, not announced to the greater network.
+	Private bool `protobuf:"varint,4,opt,name=private" json:"private,omitempty"`
+	// / The minimum value in millisatoshi we will require for incoming HTLCs on the channel.
+	MinHtlcMsat int64 `protobuf:"varint,5,opt,name=min_htlc_msat" json:"min_htlc_msat,omitempty"`
+	// / The delay we require on the remote's commitment transaction. If this is not set, it will be scaled automatically with the channel size.
+	RemoteCsvDelay uint32 `protobuf:"varint,6,opt,name=remote_csv_delay" json:"remote_csv_delay,omitempty"`
+	// *
+	// An optional address to which our funds will be paid upon a cooperative
+	// close of the channel. The remote peer must support upfront shutdown
+	// scripts.
+	CloseAddress string `protobuf:"bytes,7,opt,name=close_address" json:"close_address,omitempty"`
 }
 
-func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
+func (m *BatchOpenChannel) Reset()                    { *m = BatchOpenChannel{} }
+func (m *BatchOpenChannel) String() string            { return proto.CompactTextString(m) }
+func (*BatchOpenChannel) ProtoMessage()               {}
+func (*BatchOpenChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
 
-func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
-	m := new(SendResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *BatchOpenChannel) GetNodePubkey() []byte {
+	if m != nil {
+		return m.NodePubkey
 	}
-	return m, nil
+	return nil
 }
 
-func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
-	out := new(SendResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendPaymentSync", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *BatchOpenChannel) GetLocalFundingAmount() int64 {
+	if m != nil {
+		return m.LocalFundingAmount
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
-	out := new(AddInvoiceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddInvoice", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *BatchOpenChannel) GetPushSat() int64 {
+	if m != nil {
+		return m.PushSat
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error) {
-	out := new(ListInvoiceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListInvoices", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *BatchOpenChannel) GetPrivate() bool {
+	if m != nil {
+		return m.Private
 	}
-	return out, nil
+	return false
 }
 
-func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error) {
-	out := new(Invoice)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LookupInvoice", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *BatchOpenChannel) GetMinHtlcMsat() int64 {
+	if m != nil {
+		return m.MinHtlcMsat
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
-	if err != nil {
-		return nil, err
+func (m *BatchOpenChannel) GetRemoteCsvDelay() uint32 {
+	if m != nil {
+		return m.RemoteCsvDelay
 	}
-	x := &lightningSubscribeInvoicesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *BatchOpenChannel) GetCloseAddress() string {
+	if m != nil {
+		return m.CloseAddress
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return ""
+}
+
+type BatchOpenChannelResponse struct {
+	// *
+	// The pending channels, in the order of their requests, which all share the
+	// same funding transaction.
+	PendingChannels []*PendingUpdate `protobuf:"bytes,1,rep,name=pending_channels" json:"pending_channels,omitempty"`
+}
+
+func (m *BatchOpenChannelResponse) Reset()                    { *m = BatchOpenChannelResponse{} }
+func (m *BatchOpenChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*BatchOpenChannelResponse) ProtoMessage()               {}
+func (*BatchOpenChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
+
+func (m *BatchOpenChannelResponse) GetPendingChannels() []*PendingUpdate {
+	if m != nil {
+		return m.PendingChannels
 	}
-	return x, nil
+	return nil
 }
 
-type Lightning_SubscribeInvoicesClient interface {
-	Recv() (*Invoice, error)
-	grpc.ClientStream
+type ReadyForPsbtFunding struct {
+	// *
+	// The pending channel ID of the channel awaiting funding. This must be passed
+	// to FinalizePsbtFunding in order to resume the funding flow.
+	PendingChanId []byte `protobuf:"bytes,1,opt,name=pending_chan_id,proto3" json:"pending_chan_id,omitempty"`
+	// / The P2WSH address of the 2-of-2 multi-sig funding output of the channel.
+	FundingAddress string `protobuf:"bytes,2,opt,name=funding_address" json:"funding_address,omitempty"`
+	// / The value in satoshis that must be sent to the funding address.
+	FundingAmount int64 `protobuf:"varint,3,opt,name=funding_amount" json:"funding_amount,omitempty"`
+	// *
+	// A serialized BIP 174 PSBT template containing the funding output, which
+	// can be passed to an external wallet to be funded and signed.
+	Psbt []byte `protobuf:"bytes,4,opt,name=psbt,proto3" json:"psbt,omitempty"`
 }
 
-type lightningSubscribeInvoicesClient struct {
-	grpc.ClientStream
+func (m *ReadyForPsbtFunding) Reset()                    { *m = ReadyForPsbtFunding{} }
+func (m *ReadyForPsbtFunding) String() string            { return proto.CompactTextString(m) }
+func (*ReadyForPsbtFunding) ProtoMessage()               {}
+func (*ReadyForPsbtFunding) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
+
+func (m *ReadyForPsbtFunding) GetPendingChanId() []byte {
+	if m != nil {
+		return m.PendingChanId
+	}
+	return nil
 }
 
-func (x *lightningSubscribeInvoicesClient) Recv() (*Invoice, error) {
-	m := new(Invoice)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ReadyForPsbtFunding) GetFundingAddress() string {
+	if m != nil {
+		return m.FundingAddress
 	}
-	return m, nil
+	return ""
 }
 
-func (c *lightningClient) DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error) {
-	out := new(PayReq)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DecodePayReq", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ReadyForPsbtFunding) GetFundingAmount() int64 {
+	if m != nil {
+		return m.FundingAmount
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
-	out := new(ListPaymentsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPayments", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ReadyForPsbtFunding) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error) {
-	out := new(DeleteAllPaymentsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteAllPayments", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type FinalizePsbtFundingRequest struct {
+	// / The pending channel ID of the channel awaiting funding.
+	PendingChanId []byte `protobuf:"bytes,1,opt,name=pending_chan_id,proto3" json:"pending_chan_id,omitempty"`
+	// *
+	// The serialized, fully signed and finalized BIP 174 PSBT of the funding
+	// transaction. Either this or final_raw_tx must be set.
+	SignedPsbt []byte `protobuf:"bytes,2,opt,name=signed_psbt,proto3" json:"signed_psbt,omitempty"`
+	// *
+	// The serialized, fully signed funding transaction. Either this or
+	// signed_psbt must be set.
+	FinalRawTx []byte `protobuf:"bytes,3,opt,name=final_raw_tx,proto3" json:"final_raw_tx,omitempty"`
+}
+
+func (m *FinalizePsbtFundingRequest) Reset()                    { *m = FinalizePsbtFundingRequest{} }
+func (m *FinalizePsbtFundingRequest) String() string            { return proto.CompactTextString(m) }
+func (*FinalizePsbtFundingRequest) ProtoMessage()               {}
+func (*FinalizePsbtFundingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
+
+func (m *FinalizePsbtFundingRequest) GetPendingChanId() []byte {
+	if m != nil {
+		return m.PendingChanId
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error) {
-	out := new(ChannelGraph)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DescribeGraph", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FinalizePsbtFundingRequest) GetSignedPsbt() []byte {
+	if m != nil {
+		return m.SignedPsbt
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error) {
-	out := new(ChannelEdge)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetChanInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FinalizePsbtFundingRequest) GetFinalRawTx() []byte {
+	if m != nil {
+		return m.FinalRawTx
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error) {
-	out := new(NodeInfo)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type FinalizePsbtFundingResponse struct {
+}
+
+func (m *FinalizePsbtFundingResponse) Reset()                    { *m = FinalizePsbtFundingResponse{} }
+func (m *FinalizePsbtFundingResponse) String() string            { return proto.CompactTextString(m) }
+func (*FinalizePsbtFundingResponse) ProtoMessage()               {}
+func (*FinalizePsbtFundingResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
+
+type CircuitKey struct {
+	// / The id of the channel that is part of this circuit.
+	ChanId uint64 `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	// / The index of the incoming htlc in the incoming channel.
+	HtlcId uint64 `protobuf:"varint,2,opt,name=htlc_id" json:"htlc_id,omitempty"`
+}
+
+func (m *CircuitKey) Reset()                    { *m = CircuitKey{} }
+func (m *CircuitKey) String() string            { return proto.CompactTextString(m) }
+func (*CircuitKey) ProtoMessage()               {}
+func (*CircuitKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
+
+func (m *CircuitKey) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error) {
-	out := new(QueryRoutesResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryRoutes", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CircuitKey) GetHtlcId() uint64 {
+	if m != nil {
+		return m.HtlcId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error) {
-	out := new(NetworkInfo)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNetworkInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type ForwardHtlcInterceptRequest struct {
+	// *
+	// The key of this forwarded htlc. It defines the incoming channel id and
+	// the index in this channel.
+	IncomingCircuitKey *CircuitKey `protobuf:"bytes,1,opt,name=incoming_circuit_key" json:"incoming_circuit_key,omitempty"`
+	// / The incoming htlc amount in millisatoshis.
+	IncomingAmountMsat uint64 `protobuf:"varint,2,opt,name=incoming_amount_msat" json:"incoming_amount_msat,omitempty"`
+	// / The incoming htlc expiry as an absolute block height.
+	IncomingExpiry uint32 `protobuf:"varint,3,opt,name=incoming_expiry" json:"incoming_expiry,omitempty"`
+	// / The payment hash of the htlc.
+	PaymentHash []byte `protobuf:"bytes,4,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// *
+	// The requested outgoing channel id for this forwarded htlc. Because of
+	// non-strict forwarding, this isn't necessarily the channel over which the
+	// packet will be forwarded eventually.
+	OutgoingRequestedChanId uint64 `protobuf:"varint,5,opt,name=outgoing_requested_chan_id" json:"outgoing_requested_chan_id,omitempty"`
+	// / The outgoing htlc amount in millisatoshis.
+	OutgoingAmountMsat uint64 `protobuf:"varint,6,opt,name=outgoing_amount_msat" json:"outgoing_amount_msat,omitempty"`
+	// / The outgoing htlc expiry as an absolute block height.
+	OutgoingExpiry uint32 `protobuf:"varint,7,opt,name=outgoing_expiry" json:"outgoing_expiry,omitempty"`
+	// / The onion blob for the next hop.
+	OnionBlob []byte `protobuf:"bytes,8,opt,name=onion_blob,proto3" json:"onion_blob,omitempty"`
+}
+
+func (m *ForwardHtlcInterceptRequest) Reset()                    { *m = ForwardHtlcInterceptRequest{} }
+func (m *ForwardHtlcInterceptRequest) String() string            { return proto.CompactTextString(m) }
+func (*ForwardHtlcInterceptRequest) ProtoMessage()               {}
+func (*ForwardHtlcInterceptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
+
+func (m *ForwardHtlcInterceptRequest) GetIncomingCircuitKey() *CircuitKey {
+	if m != nil {
+		return m.IncomingCircuitKey
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
-	out := new(StopResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/StopDaemon", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptRequest) GetIncomingAmountMsat() uint64 {
+	if m != nil {
+		return m.IncomingAmountMsat
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptRequest) GetIncomingExpiry() uint32 {
+	if m != nil {
+		return m.IncomingExpiry
 	}
-	x := &lightningSubscribeChannelGraphClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return nil
+}
+
+func (m *ForwardHtlcInterceptRequest) GetOutgoingRequestedChanId() uint64 {
+	if m != nil {
+		return m.OutgoingRequestedChanId
 	}
-	return x, nil
+	return 0
 }
 
-type Lightning_SubscribeChannelGraphClient interface {
-	Recv() (*GraphTopologyUpdate, error)
-	grpc.ClientStream
+func (m *ForwardHtlcInterceptRequest) GetOutgoingAmountMsat() uint64 {
+	if m != nil {
+		return m.OutgoingAmountMsat
+	}
+	return 0
 }
 
-type lightningSubscribeChannelGraphClient struct {
-	grpc.ClientStream
+func (m *ForwardHtlcInterceptRequest) GetOutgoingExpiry() uint32 {
+	if m != nil {
+		return m.OutgoingExpiry
+	}
+	return 0
 }
 
-func (x *lightningSubscribeChannelGraphClient) Recv() (*GraphTopologyUpdate, error) {
-	m := new(GraphTopologyUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptRequest) GetOnionBlob() []byte {
+	if m != nil {
+		return m.OnionBlob
 	}
-	return m, nil
+	return nil
 }
 
-func (c *lightningClient) DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error) {
-	out := new(DebugLevelResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DebugLevel", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type ForwardHtlcInterceptResponse struct {
+	// *
+	// The key of this forwarded htlc. It defines the incoming channel id and
+	// the index in this channel.
+	IncomingCircuitKey *CircuitKey `protobuf:"bytes,1,opt,name=incoming_circuit_key" json:"incoming_circuit_key,omitempty"`
+	// / The resolution of the forwarded htlc.
+	Action ForwardHtlcInterceptResponse_ResolveAction `protobuf:"varint,2,opt,name=action,enum=lnrpc.ForwardHtlcInterceptResponse_ResolveAction" json:"action,omitempty"`
+	// / The preimage in case the resolve action is SETTLE.
+	Preimage []byte `protobuf:"bytes,3,opt,name=preimage,proto3" json:"preimage,omitempty"`
+	// *
+	// The BOLT #4 failure code to fail the htlc back with in case the resolve
+	// action is FAIL. If not set, a temporary channel failure is used.
+	FailureCode uint32 `protobuf:"varint,4,opt,name=failure_code" json:"failure_code,omitempty"`
+}
+
+func (m *ForwardHtlcInterceptResponse) Reset()                    { *m = ForwardHtlcInterceptResponse{} }
+func (m *ForwardHtlcInterceptResponse) String() string            { return proto.CompactTextString(m) }
+func (*ForwardHtlcInterceptResponse) ProtoMessage()               {}
+func (*ForwardHtlcInterceptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
+
+func (m *ForwardHtlcInterceptResponse) GetIncomingCircuitKey() *CircuitKey {
+	if m != nil {
+		return m.IncomingCircuitKey
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error) {
-	out := new(FeeReportResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FeeReport", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptResponse) GetAction() ForwardHtlcInterceptResponse_ResolveAction {
+	if m != nil {
+		return m.Action
 	}
-	return out, nil
+	return ForwardHtlcInterceptResponse_RESUME
 }
 
-func (c *lightningClient) UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error) {
-	out := new(PolicyUpdateResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateChannelPolicy", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptResponse) GetPreimage() []byte {
+	if m != nil {
+		return m.Preimage
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error) {
-	out := new(ForwardingHistoryResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ForwardingHistory", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptResponse) GetFailureCode() uint32 {
+	if m != nil {
+		return m.FailureCode
 	}
-	return out, nil
+	return 0
 }
 
-// Server API for Lightning service
+type SubscribeHtlcEventsRequest struct {
+}
 
-type LightningServer interface {
-	// * lncli: `walletbalance`
-	// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
-	// confirmed unspent outputs and all unconfirmed unspent outputs under control
-	// of the wallet.
-	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
-	// * lncli: `channelbalance`
-	// ChannelBalance returns the total funds available across all open channels
-	// in satoshis.
-	ChannelBalance(context.Context, *ChannelBalanceRequest) (*ChannelBalanceResponse, error)
-	// * lncli: `listchaintxns`
-	// GetTransactions returns a list describing all the known transactions
-	// relevant to the wallet.
-	GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionDetails, error)
-	// * lncli: `sendcoins`
-	// SendCoins executes a request to send coins to a particular address. Unlike
-	// SendMany, this RPC call only allows creating a single output at a time. If
-	// neither target_conf, or sat_per_byte are set, then the internal wallet will
-	// consult its fee model to determine a fee for the default confirmation
-	// target.
-	SendCoins(context.Context, *SendCoinsRequest) (*SendCoinsResponse, error)
+func (m *SubscribeHtlcEventsRequest) Reset()                    { *m = SubscribeHtlcEventsRequest{} }
+func (m *SubscribeHtlcEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeHtlcEventsRequest) ProtoMessage()               {}
+func (*SubscribeHtlcEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{124} }
+
+type HtlcEvent struct {
 	// *
-	// SubscribeTransactions creates a uni-directional stream from the server to
-	// the client in which any newly discovered transactions relevant to the
-	// wallet are sent over.
-	SubscribeTransactions(*GetTransactionsRequest, Lightning_SubscribeTransactionsServer) error
-	// * lncli: `sendmany`
-	// SendMany handles a request for a transaction that creates multiple specified
-	// outputs in parallel. If neither target_conf, or sat_per_byte are set, then
-	// the internal wallet will consult its fee model to determine a fee for the
-	// default confirmation target.
-	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
-	// * lncli: `newaddress`
-	// NewAddress creates a new address under control of the local wallet.
-	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
+	// The short channel id that the incoming htlc arrived at our node on. This
+	// value is zero for sends.
+	IncomingChannelId uint64 `protobuf:"varint,1,opt,name=incoming_channel_id" json:"incoming_channel_id,omitempty"`
 	// *
-	// NewWitnessAddress creates a new witness address under control of the local wallet.
-	NewWitnessAddress(context.Context, *NewWitnessAddressRequest) (*NewAddressResponse, error)
-	// * lncli: `signmessage`
-	// SignMessage signs a message with this node's private key. The returned
-	// signature string is `zbase32` encoded and pubkey recoverable, meaning that
-	// only the message digest and signature are needed for verification.
-	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
-	// * lncli: `verifymessage`
-	// VerifyMessage verifies a signature over a msg. The signature must be
-	// zbase32 encoded and signed by an active node in the resident node's
-	// channel database. In addition to returning the validity of the signature,
-	// VerifyMessage also returns the recovered pubkey from the signature.
-	VerifyMessage(context.Context, *VerifyMessageRequest) (*VerifyMessageResponse, error)
-	// * lncli: `connect`
-	// ConnectPeer attempts to establish a connection to a remote peer. This is at
-	// the networking level, and is used for communication between nodes. This is
-	// distinct from establishing a channel with a peer.
-	ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error)
-	// * lncli: `disconnect`
-	// DisconnectPeer attempts to disconnect one peer from another identified by a
-	// given pubKey. In the case that we currently have a pending or active channel
-	// with the target peer, then this action will be not be allowed.
-	DisconnectPeer(context.Context, *DisconnectPeerRequest) (*DisconnectPeerResponse, error)
-	// * lncli: `listpeers`
-	// ListPeers returns a verbose listing of all currently active peers.
-	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
-	// * lncli: `getinfo`
-	// GetInfo returns general information concerning the lightning node including
-	// it's identity pubkey, alias, the chains it is connected to, and information
-	// concerning the number of open+pending channels.
-	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
-	// * lncli: `pendingchannels`
-	// PendingChannels returns a list of all the channels that are currently
-	// considered "pending". A channel is pending if it has finished the funding
-	// workflow and is waiting for confirmations for the funding txn, or is in the
-	// process of closure, either initiated cooperatively or non-cooperatively.
-	PendingChannels(context.Context, *PendingChannelsRequest) (*PendingChannelsResponse, error)
-	// * lncli: `listchannels`
-	// ListChannels returns a description of all the open channels that this node
-	// is a participant in.
-	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
-	// *
-	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
-	// call is meant to be consumed by clients to the REST proxy. As with all
-	// other sync calls, all byte slices are intended to be populated as hex
-	// encoded strings.
-	OpenChannelSync(context.Context, *OpenChannelRequest) (*ChannelPoint, error)
-	// * lncli: `openchannel`
-	// OpenChannel attempts to open a singly funded channel specified in the
-	// request to a remote peer. Users are able to specify a target number of
-	// blocks that the funding transaction should be confirmed in, or a manual fee
-	// rate to us for the funding transaction. If neither are specified, then a
-	// lax block confirmation target is used.
-	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
-	// * lncli: `closechannel`
-	// CloseChannel attempts to close an active channel identified by its channel
-	// outpoint (ChannelPoint). The actions of this method can additionally be
-	// augmented to attempt a force close after a timeout period in the case of an
-	// inactive peer. If a non-force close (cooperative closure) is requested,
-	// then the user can specify either a target number of blocks until the
-	// closure transaction is confirmed, or a manual fee rate. If neither are
-	// specified, then a default lax, block confirmation target is used.
-	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
-	// * lncli: `sendpayment`
-	// SendPayment dispatches a bi-directional streaming RPC for sending payments
-	// through the Lightning Network. A single RPC invocation creates a persistent
-	// bi-directional stream allowing clients to rapidly send payments through the
-	// Lightning Network with a single persistent connection.
-	SendPayment(Lightning_SendPaymentServer) error
+	// The short channel id that the outgoing htlc left our node on. This value
+	// is zero for receives.
+	OutgoingChannelId uint64 `protobuf:"varint,2,opt,name=outgoing_channel_id" json:"outgoing_channel_id,omitempty"`
 	// *
-	// SendPaymentSync is the synchronous non-streaming version of SendPayment.
-	// This RPC is intended to be consumed by clients of the REST proxy.
-	// Additionally, this RPC expects the destination's public key and the payment
-	// hash (if any) to be encoded as hex strings.
-	SendPaymentSync(context.Context, *SendRequest) (*SendResponse, error)
-	// * lncli: `addinvoice`
-	// AddInvoice attempts to add a new invoice to the invoice database. Any
-	// duplicated invoices are rejected, therefore all invoices *must* have a
-	// unique payment preimage.
-	AddInvoice(context.Context, *Invoice) (*AddInvoiceResponse, error)
-	// * lncli: `listinvoices`
-	// ListInvoices returns a list of all the invoices currently stored within the
-	// database. Any active debug invoices are ignored.
-	ListInvoices(context.Context, *ListInvoiceRequest) (*ListInvoiceResponse, error)
-	// * lncli: `lookupinvoice`
-	// LookupInvoice attempts to look up an invoice according to its payment hash.
-	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
-	// returned.
-	LookupInvoice(context.Context, *PaymentHash) (*Invoice, error)
+	// Incoming id is the index of the incoming htlc in the incoming channel.
+	// This value is zero for sends.
+	IncomingHtlcId uint64 `protobuf:"varint,3,opt,name=incoming_htlc_id" json:"incoming_htlc_id,omitempty"`
 	// *
-	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
-	// notifying the client of newly added/settled invoices.
-	SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error
-	// * lncli: `decodepayreq`
-	// DecodePayReq takes an encoded payment request string and attempts to decode
-	// it, returning a full description of the conditions encoded within the
-	// payment request.
-	DecodePayReq(context.Context, *PayReqString) (*PayReq, error)
-	// * lncli: `listpayments`
-	// ListPayments returns a list of all outgoing payments.
-	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	// Outgoing id is the index of the outgoing htlc in the outgoing channel.
+	// This value is zero for receives, and for htlcs that never made it onto
+	// the outgoing channel.
+	OutgoingHtlcId uint64 `protobuf:"varint,4,opt,name=outgoing_htlc_id" json:"outgoing_htlc_id,omitempty"`
+	// / The time in unix nanoseconds that the event occurred.
+	TimestampNs uint64 `protobuf:"varint,5,opt,name=timestamp_ns" json:"timestamp_ns,omitempty"`
 	// *
-	// DeleteAllPayments deletes all outgoing payments from DB.
-	DeleteAllPayments(context.Context, *DeleteAllPaymentsRequest) (*DeleteAllPaymentsResponse, error)
-	// * lncli: `describegraph`
-	// DescribeGraph returns a description of the latest graph state from the
-	// point of view of the node. The graph information is partitioned into two
-	// components: all the nodes/vertexes, and all the edges that connect the
-	// vertexes themselves.  As this is a directed graph, the edges also contain
-	// the node directional specific routing policy which includes: the time lock
-	// delta, fee information, etc.
-	DescribeGraph(context.Context, *ChannelGraphRequest) (*ChannelGraph, error)
-	// * lncli: `getchaninfo`
-	// GetChanInfo returns the latest authenticated network announcement for the
-	// given channel identified by its channel ID: an 8-byte integer which
-	// uniquely identifies the location of transaction's funding output within the
-	// blockchain.
-	GetChanInfo(context.Context, *ChanInfoRequest) (*ChannelEdge, error)
-	// * lncli: `getnodeinfo`
-	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
-	// channel information for the specified node identified by its public key.
-	GetNodeInfo(context.Context, *NodeInfoRequest) (*NodeInfo, error)
-	// * lncli: `queryroutes`
-	// QueryRoutes attempts to query the daemon's Channel Router for a possible
-	// route to a target destination capable of carrying a specific amount of
-	// satoshis. The retuned route contains the full details required to craft and
-	// send an HTLC, also including the necessary information that should be
-	// present within the Sphinx packet encapsulated within the HTLC.
-	QueryRoutes(context.Context, *QueryRoutesRequest) (*QueryRoutesResponse, error)
-	// * lncli: `getnetworkinfo`
-	// GetNetworkInfo returns some basic stats about the known channel graph from
-	// the point of view of the node.
-	GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfo, error)
-	// * lncli: `stop`
-	// StopDaemon will send a shutdown request to the interrupt handler, triggering
-	// a graceful shutdown of the daemon.
-	StopDaemon(context.Context, *StopRequest) (*StopResponse, error)
+	// The event type indicates whether the htlc was part of a send, receive or
+	// forward.
+	EventType HtlcEvent_EventType `protobuf:"varint,6,opt,name=event_type,enum=lnrpc.HtlcEvent_EventType" json:"event_type,omitempty"`
+	// / Set if the htlc was added to the outgoing channel.
+	ForwardEvent *ForwardEvent `protobuf:"bytes,7,opt,name=forward_event" json:"forward_event,omitempty"`
+	// / Set if the htlc was failed by a node further down the route.
+	ForwardFailEvent *ForwardFailEvent `protobuf:"bytes,8,opt,name=forward_fail_event" json:"forward_fail_event,omitempty"`
+	// / Set if the htlc was settled.
+	SettleEvent *SettleEvent `protobuf:"bytes,9,opt,name=settle_event" json:"settle_event,omitempty"`
+	// / Set if the htlc was failed by our node.
+	LinkFailEvent *LinkFailEvent `protobuf:"bytes,10,opt,name=link_fail_event" json:"link_fail_event,omitempty"`
+}
+
+func (m *HtlcEvent) Reset()                    { *m = HtlcEvent{} }
+func (m *HtlcEvent) String() string            { return proto.CompactTextString(m) }
+func (*HtlcEvent) ProtoMessage()               {}
+func (*HtlcEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{125} }
+
+func (m *HtlcEvent) GetIncomingChannelId() uint64 {
+	if m != nil {
+		return m.IncomingChannelId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetOutgoingChannelId() uint64 {
+	if m != nil {
+		return m.OutgoingChannelId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetIncomingHtlcId() uint64 {
+	if m != nil {
+		return m.IncomingHtlcId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetOutgoingHtlcId() uint64 {
+	if m != nil {
+		return m.OutgoingHtlcId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetTimestampNs() uint64 {
+	if m != nil {
+		return m.TimestampNs
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetEventType() HtlcEvent_EventType {
+	if m != nil {
+		return m.EventType
+	}
+	return HtlcEvent_UNKNOWN
+}
+
+func (m *HtlcEvent) GetForwardEvent() *ForwardEvent {
+	if m != nil {
+		return m.ForwardEvent
+	}
+	return nil
+}
+
+func (m *HtlcEvent) GetForwardFailEvent() *ForwardFailEvent {
+	if m != nil {
+		return m.ForwardFailEvent
+	}
+	return nil
+}
+
+func (m *HtlcEvent) GetSettleEvent() *SettleEvent {
+	if m != nil {
+		return m.SettleEvent
+	}
+	return nil
+}
+
+func (m *HtlcEvent) GetLinkFailEvent() *LinkFailEvent {
+	if m != nil {
+		return m.LinkFailEvent
+	}
+	return nil
+}
+
+type HtlcInfo struct {
+	// / The timelock on the incoming htlc.
+	IncomingTimelock uint32 `protobuf:"varint,1,opt,name=incoming_timelock" json:"incoming_timelock,omitempty"`
+	// / The timelock on the outgoing htlc.
+	OutgoingTimelock uint32 `protobuf:"varint,2,opt,name=outgoing_timelock" json:"outgoing_timelock,omitempty"`
+	// / The amount of the incoming htlc.
+	IncomingAmtMsat uint64 `protobuf:"varint,3,opt,name=incoming_amt_msat" json:"incoming_amt_msat,omitempty"`
+	// / The amount of the outgoing htlc.
+	OutgoingAmtMsat uint64 `protobuf:"varint,4,opt,name=outgoing_amt_msat" json:"outgoing_amt_msat,omitempty"`
+}
+
+func (m *HtlcInfo) Reset()                    { *m = HtlcInfo{} }
+func (m *HtlcInfo) String() string            { return proto.CompactTextString(m) }
+func (*HtlcInfo) ProtoMessage()               {}
+func (*HtlcInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{126} }
+
+func (m *HtlcInfo) GetIncomingTimelock() uint32 {
+	if m != nil {
+		return m.IncomingTimelock
+	}
+	return 0
+}
+
+func (m *HtlcInfo) GetOutgoingTimelock() uint32 {
+	if m != nil {
+		return m.OutgoingTimelock
+	}
+	return 0
+}
+
+func (m *HtlcInfo) GetIncomingAmtMsat() uint64 {
+	if m != nil {
+		return m.IncomingAmtMsat
+	}
+	return 0
+}
+
+func (m *HtlcInfo) GetOutgoingAmtMsat() uint64 {
+	if m != nil {
+		return m.OutgoingAmtMsat
+	}
+	return 0
+}
+
+type ForwardEvent struct {
+	// / Info contains details about the htlc that was forwarded.
+	Info *HtlcInfo `protobuf:"bytes,1,opt,name=info" json:"info,omitempty"`
+}
+
+func (m *ForwardEvent) Reset()                    { *m = ForwardEvent{} }
+func (m *ForwardEvent) String() string            { return proto.CompactTextString(m) }
+func (*ForwardEvent) ProtoMessage()               {}
+func (*ForwardEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{127} }
+
+func (m *ForwardEvent) GetInfo() *HtlcInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+type ForwardFailEvent struct {
 	// *
-	// SubscribeChannelGraph launches a streaming RPC that allows the caller to
-	// receive notifications upon any changes to the channel graph topology from
-	// the point of view of the responding node. Events notified include: new
-	// nodes coming online, nodes updating their authenticated attributes, new
-	// channels being advertised, updates in the routing policy for a directional
-	// channel edge, and when channels are closed on-chain.
-	SubscribeChannelGraph(*GraphTopologySubscription, Lightning_SubscribeChannelGraphServer) error
-	// * lncli: `debuglevel`
-	// DebugLevel allows a caller to programmatically set the logging verbosity of
-	// lnd. The logging can be targeted according to a coarse daemon-wide logging
-	// level, or in a granular fashion to specify the logging for a target
-	// sub-system.
-	DebugLevel(context.Context, *DebugLevelRequest) (*DebugLevelResponse, error)
-	// * lncli: `feereport`
-	// FeeReport allows the caller to obtain a report detailing the current fee
-	// schedule enforced by the node globally for each channel.
-	FeeReport(context.Context, *FeeReportRequest) (*FeeReportResponse, error)
-	// * lncli: `updatechanpolicy`
-	// UpdateChannelPolicy allows the caller to update the fee schedule and
-	// channel policies for all channels globally, or a particular channel.
-	UpdateChannelPolicy(context.Context, *PolicyUpdateRequest) (*PolicyUpdateResponse, error)
-	// * lncli: `fwdinghistory`
-	// ForwardingHistory allows the caller to query the htlcswitch for a record of
-	// all HTLC's forwarded within the target time range, and integer offset
-	// within that time range. If no time-range is specified, then the first chunk
-	// of the past 24 hrs of forwarding history are returned.
-	//
-	// A list of forwarding events are returned. The size of each forwarding event
-	// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
-	// As a result each message can only contain 50k entries.  Each response has
-	// the index offset of the last entry. The index offset can be provided to the
-	// request to allow the caller to skip a series of records.
-	ForwardingHistory(context.Context, *ForwardingHistoryRequest) (*ForwardingHistoryResponse, error)
+	// The BOLT #4 failure code returned by the failing hop. As failures of
+	// forwarded htlcs are encrypted for their sender, it is only known for
+	// sends, and zero otherwise.
+	FailureCode uint32 `protobuf:"varint,1,opt,name=failure_code" json:"failure_code,omitempty"`
+	// / The public key of the node that failed the htlc, if known.
+	FailureSourcePubkey []byte `protobuf:"bytes,2,opt,name=failure_source_pubkey,proto3" json:"failure_source_pubkey,omitempty"`
+}
+
+func (m *ForwardFailEvent) Reset()                    { *m = ForwardFailEvent{} }
+func (m *ForwardFailEvent) String() string            { return proto.CompactTextString(m) }
+func (*ForwardFailEvent) ProtoMessage()               {}
+func (*ForwardFailEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{128} }
+
+func (m *ForwardFailEvent) GetFailureCode() uint32 {
+	if m != nil {
+		return m.FailureCode
+	}
+	return 0
+}
+
+func (m *ForwardFailEvent) GetFailureSourcePubkey() []byte {
+	if m != nil {
+		return m.FailureSourcePubkey
+	}
+	return nil
+}
+
+type SettleEvent struct {
+}
+
+func (m *SettleEvent) Reset()                    { *m = SettleEvent{} }
+func (m *SettleEvent) String() string            { return proto.CompactTextString(m) }
+func (*SettleEvent) ProtoMessage()               {}
+func (*SettleEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{129} }
+
+type LinkFailEvent struct {
+	// / Info contains details about the htlc that we failed.
+	Info *HtlcInfo `protobuf:"bytes,1,opt,name=info" json:"info,omitempty"`
+	// / The BOLT #4 failure code sent back to the sender of the htlc.
+	FailureCode uint32 `protobuf:"varint,2,opt,name=failure_code" json:"failure_code,omitempty"`
+	// / The reason for which our node failed the htlc.
+	FailureDetail LinkFailEvent_FailureDetail `protobuf:"varint,3,opt,name=failure_detail,enum=lnrpc.LinkFailEvent_FailureDetail" json:"failure_detail,omitempty"`
+	// / A human readable version of the failure detail.
+	FailureString string `protobuf:"bytes,4,opt,name=failure_string" json:"failure_string,omitempty"`
+	// / Whether the htlc was failed by the incoming link, or on its way out.
+	Incoming bool `protobuf:"varint,5,opt,name=incoming" json:"incoming,omitempty"`
+}
+
+func (m *LinkFailEvent) Reset()                    { *m = LinkFailEvent{} }
+func (m *LinkFailEvent) String() string            { return proto.CompactTextString(m) }
+func (*LinkFailEvent) ProtoMessage()               {}
+func (*LinkFailEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{130} }
+
+func (m *LinkFailEvent) GetInfo() *HtlcInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *LinkFailEvent) GetFailureCode() uint32 {
+	if m != nil {
+		return m.FailureCode
+	}
+	return 0
+}
+
+func (m *LinkFailEvent) GetFailureDetail() LinkFailEvent_FailureDetail {
+	if m != nil {
+		return m.FailureDetail
+	}
+	return LinkFailEvent_NO_DETAIL
+}
+
+func (m *LinkFailEvent) GetFailureString() string {
+	if m != nil {
+		return m.FailureString
+	}
+	return ""
+}
+
+func (m *LinkFailEvent) GetIncoming() bool {
+	if m != nil {
+		return m.Incoming
+	}
+	return false
+}
+
+type QueryMissionControlRequest struct {
+}
+
+func (m *QueryMissionControlRequest) Reset()                    { *m = QueryMissionControlRequest{} }
+func (m *QueryMissionControlRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryMissionControlRequest) ProtoMessage()               {}
+func (*QueryMissionControlRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{131} }
+
+type QueryMissionControlResponse struct {
+	// / The outcomes of past payment attempts, for each node pair.
+	Pairs []*PairHistory `protobuf:"bytes,1,rep,name=pairs" json:"pairs,omitempty"`
+}
+
+func (m *QueryMissionControlResponse) Reset()                    { *m = QueryMissionControlResponse{} }
+func (m *QueryMissionControlResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueryMissionControlResponse) ProtoMessage()               {}
+func (*QueryMissionControlResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{132} }
+
+func (m *QueryMissionControlResponse) GetPairs() []*PairHistory {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
+}
+
+type PairHistory struct {
+	// / The source node of the pair.
+	NodeFrom []byte `protobuf:"bytes,1,opt,name=node_from,proto3" json:"node_from,omitempty"`
+	// / The destination node of the pair.
+	NodeTo []byte `protobuf:"bytes,2,opt,name=node_to,proto3" json:"node_to,omitempty"`
+	// *
+	// The time of the last failed attempt, in unix seconds. Zero if no failure
+	// has been recorded.
+	FailTime int64 `protobuf:"varint,3,opt,name=fail_time" json:"fail_time,omitempty"`
+	// *
+	// The amount of the last failed attempt. A zero amount denotes a failure for
+	// any amount.
+	FailAmtMsat int64 `protobuf:"varint,4,opt,name=fail_amt_msat" json:"fail_amt_msat,omitempty"`
+	// *
+	// The time of the last successful attempt, in unix seconds. Zero if no
+	// success has been recorded.
+	SuccessTime int64 `protobuf:"varint,5,opt,name=success_time" json:"success_time,omitempty"`
+	// / The amount of the last successful attempt.
+	SuccessAmtMsat int64 `protobuf:"varint,6,opt,name=success_amt_msat" json:"success_amt_msat,omitempty"`
+}
+
+func (m *PairHistory) Reset()                    { *m = PairHistory{} }
+func (m *PairHistory) String() string            { return proto.CompactTextString(m) }
+func (*PairHistory) ProtoMessage()               {}
+func (*PairHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{133} }
+
+func (m *PairHistory) GetNodeFrom() []byte {
+	if m != nil {
+		return m.NodeFrom
+	}
+	return nil
+}
+
+func (m *PairHistory) GetNodeTo() []byte {
+	if m != nil {
+		return m.NodeTo
+	}
+	return nil
+}
+
+func (m *PairHistory) GetFailTime() int64 {
+	if m != nil {
+		return m.FailTime
+	}
+	return 0
+}
+
+func (m *PairHistory) GetFailAmtMsat() int64 {
+	if m != nil {
+		return m.FailAmtMsat
+	}
+	return 0
+}
+
+func (m *PairHistory) GetSuccessTime() int64 {
+	if m != nil {
+		return m.SuccessTime
+	}
+	return 0
+}
+
+func (m *PairHistory) GetSuccessAmtMsat() int64 {
+	if m != nil {
+		return m.SuccessAmtMsat
+	}
+	return 0
+}
+
+type ImportMissionControlRequest struct {
+	// / The node pair results to import.
+	Pairs []*PairHistory `protobuf:"bytes,1,rep,name=pairs" json:"pairs,omitempty"`
+}
+
+func (m *ImportMissionControlRequest) Reset()                    { *m = ImportMissionControlRequest{} }
+func (m *ImportMissionControlRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportMissionControlRequest) ProtoMessage()               {}
+func (*ImportMissionControlRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{134} }
+
+func (m *ImportMissionControlRequest) GetPairs() []*PairHistory {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
+}
+
+type ImportMissionControlResponse struct {
+}
+
+func (m *ImportMissionControlResponse) Reset()                    { *m = ImportMissionControlResponse{} }
+func (m *ImportMissionControlResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportMissionControlResponse) ProtoMessage()               {}
+func (*ImportMissionControlResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{135} }
+
+type ResetMissionControlRequest struct {
+}
+
+func (m *ResetMissionControlRequest) Reset()                    { *m = ResetMissionControlRequest{} }
+func (m *ResetMissionControlRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResetMissionControlRequest) ProtoMessage()               {}
+func (*ResetMissionControlRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{136} }
+
+type ResetMissionControlResponse struct {
+}
+
+func (m *ResetMissionControlResponse) Reset()                    { *m = ResetMissionControlResponse{} }
+func (m *ResetMissionControlResponse) String() string            { return proto.CompactTextString(m) }
+func (*ResetMissionControlResponse) ProtoMessage()               {}
+func (*ResetMissionControlResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{137} }
+
+type SendToRouteRequest struct {
+	// / The payment hash to use for the HTLC.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// / An optional hex-encoded payment hash to be used for the HTLC.
+	PaymentHashString string `protobuf:"bytes,2,opt,name=payment_hash_string" json:"payment_hash_string,omitempty"`
+	// / The routes to attempt, in order, until the payment succeeds.
+	Routes []*Route `protobuf:"bytes,3,rep,name=routes" json:"routes,omitempty"`
+}
+
+func (m *SendToRouteRequest) Reset()                    { *m = SendToRouteRequest{} }
+func (m *SendToRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendToRouteRequest) ProtoMessage()               {}
+func (*SendToRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{138} }
+
+func (m *SendToRouteRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *SendToRouteRequest) GetPaymentHashString() string {
+	if m != nil {
+		return m.PaymentHashString
+	}
+	return ""
+}
+
+func (m *SendToRouteRequest) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+type BuildRouteRequest struct {
+	// / The amount to deliver to the final hop, in millisatoshis.
+	AmtMsat int64 `protobuf:"varint,1,opt,name=amt_msat" json:"amt_msat,omitempty"`
+	// / The CLTV delta of the final hop. If zero, the default value is used.
+	FinalCltvDelta int32 `protobuf:"varint,2,opt,name=final_cltv_delta" json:"final_cltv_delta,omitempty"`
+	// *
+	// The hex-encoded public keys of the nodes along the route, excluding our
+	// own node. May be omitted if chan_ids is set.
+	HopPubkeys []string `protobuf:"bytes,3,rep,name=hop_pubkeys" json:"hop_pubkeys,omitempty"`
+	// *
+	// The channels along the route. If hop_pubkeys is set as well, both must
+	// have the same length, and a zero channel id leaves it to the node to pick
+	// the cheapest channel to the node of the hop.
+	ChanIds []uint64 `protobuf:"varint,4,rep,packed,name=chan_ids" json:"chan_ids,omitempty"`
+}
+
+func (m *BuildRouteRequest) Reset()                    { *m = BuildRouteRequest{} }
+func (m *BuildRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*BuildRouteRequest) ProtoMessage()               {}
+func (*BuildRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{139} }
+
+func (m *BuildRouteRequest) GetAmtMsat() int64 {
+	if m != nil {
+		return m.AmtMsat
+	}
+	return 0
+}
+
+func (m *BuildRouteRequest) GetFinalCltvDelta() int32 {
+	if m != nil {
+		return m.FinalCltvDelta
+	}
+	return 0
+}
+
+func (m *BuildRouteRequest) GetHopPubkeys() []string {
+	if m != nil {
+		return m.HopPubkeys
+	}
+	return nil
+}
+
+func (m *BuildRouteRequest) GetChanIds() []uint64 {
+	if m != nil {
+		return m.ChanIds
+	}
+	return nil
+}
+
+type BuildRouteResponse struct {
+	// / The route that was built.
+	Route *Route `protobuf:"bytes,1,opt,name=route" json:"route,omitempty"`
+}
+
+func (m *BuildRouteResponse) Reset()                    { *m = BuildRouteResponse{} }
+func (m *BuildRouteResponse) String() string            { return proto.CompactTextString(m) }
+func (*BuildRouteResponse) ProtoMessage()               {}
+func (*BuildRouteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{140} }
+
+func (m *BuildRouteResponse) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+type RebalanceRequest struct {
+	// / The channel through which the payment leaves our node.
+	OutgoingChanId uint64 `protobuf:"varint,1,opt,name=outgoing_chan_id" json:"outgoing_chan_id,omitempty"`
+	// / The channel through which the payment returns to our node.
+	LastHopChanId uint64 `protobuf:"varint,2,opt,name=last_hop_chan_id" json:"last_hop_chan_id,omitempty"`
+	// *
+	// The amount to move from the outgoing to the last hop channel, in
+	// satoshis.
+	Amt int64 `protobuf:"varint,3,opt,name=amt" json:"amt,omitempty"`
+	// / The maximum total fee to pay to route the payment, in satoshis.
+	FeeLimit int64 `protobuf:"varint,4,opt,name=fee_limit" json:"fee_limit,omitempty"`
+}
+
+func (m *RebalanceRequest) Reset()                    { *m = RebalanceRequest{} }
+func (m *RebalanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*RebalanceRequest) ProtoMessage()               {}
+func (*RebalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{141} }
+
+func (m *RebalanceRequest) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *RebalanceRequest) GetLastHopChanId() uint64 {
+	if m != nil {
+		return m.LastHopChanId
+	}
+	return 0
+}
+
+func (m *RebalanceRequest) GetAmt() int64 {
+	if m != nil {
+		return m.Amt
+	}
+	return 0
+}
+
+func (m *RebalanceRequest) GetFeeLimit() int64 {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*GenSeedRequest)(nil), "lnrpc.GenSeedRequest")
+	proto.RegisterType((*GenSeedResponse)(nil), "lnrpc.GenSeedResponse")
+	proto.RegisterType((*InitWalletRequest)(nil), "lnrpc.InitWalletRequest")
+	proto.RegisterType((*InitWalletResponse)(nil), "lnrpc.InitWalletResponse")
+	proto.RegisterType((*UnlockWalletRequest)(nil), "lnrpc.UnlockWalletRequest")
+	proto.RegisterType((*UnlockWalletResponse)(nil), "lnrpc.UnlockWalletResponse")
+	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
+	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
+	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
+	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
+	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
+	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
+	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
+	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
+	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
+	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
+	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
+	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
+	proto.RegisterType((*NewWitnessAddressRequest)(nil), "lnrpc.NewWitnessAddressRequest")
+	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
+	proto.RegisterType((*SignMessageRequest)(nil), "lnrpc.SignMessageRequest")
+	proto.RegisterType((*SignMessageResponse)(nil), "lnrpc.SignMessageResponse")
+	proto.RegisterType((*VerifyMessageRequest)(nil), "lnrpc.VerifyMessageRequest")
+	proto.RegisterType((*VerifyMessageResponse)(nil), "lnrpc.VerifyMessageResponse")
+	proto.RegisterType((*ConnectPeerRequest)(nil), "lnrpc.ConnectPeerRequest")
+	proto.RegisterType((*ConnectPeerResponse)(nil), "lnrpc.ConnectPeerResponse")
+	proto.RegisterType((*DisconnectPeerRequest)(nil), "lnrpc.DisconnectPeerRequest")
+	proto.RegisterType((*DisconnectPeerResponse)(nil), "lnrpc.DisconnectPeerResponse")
+	proto.RegisterType((*HTLC)(nil), "lnrpc.HTLC")
+	proto.RegisterType((*Channel)(nil), "lnrpc.Channel")
+	proto.RegisterType((*ListChannelsRequest)(nil), "lnrpc.ListChannelsRequest")
+	proto.RegisterType((*ListChannelsResponse)(nil), "lnrpc.ListChannelsResponse")
+	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
+	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
+	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
+	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
+	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
+	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
+	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
+	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
+	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
+	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
+	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
+	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
+	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
+	proto.RegisterType((*PendingHTLC)(nil), "lnrpc.PendingHTLC")
+	proto.RegisterType((*PendingChannelsRequest)(nil), "lnrpc.PendingChannelsRequest")
+	proto.RegisterType((*PendingChannelsResponse)(nil), "lnrpc.PendingChannelsResponse")
+	proto.RegisterType((*PendingChannelsResponse_PendingChannel)(nil), "lnrpc.PendingChannelsResponse.PendingChannel")
+	proto.RegisterType((*PendingChannelsResponse_PendingOpenChannel)(nil), "lnrpc.PendingChannelsResponse.PendingOpenChannel")
+	proto.RegisterType((*PendingChannelsResponse_ClosedChannel)(nil), "lnrpc.PendingChannelsResponse.ClosedChannel")
+	proto.RegisterType((*PendingChannelsResponse_ForceClosedChannel)(nil), "lnrpc.PendingChannelsResponse.ForceClosedChannel")
+	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
+	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
+	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
+	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
+	proto.RegisterType((*QueryRoutesRequest)(nil), "lnrpc.QueryRoutesRequest")
+	proto.RegisterType((*QueryRoutesResponse)(nil), "lnrpc.QueryRoutesResponse")
+	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
+	proto.RegisterType((*Route)(nil), "lnrpc.Route")
+	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
+	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
+	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
+	proto.RegisterType((*NodeAddress)(nil), "lnrpc.NodeAddress")
+	proto.RegisterType((*RoutingPolicy)(nil), "lnrpc.RoutingPolicy")
+	proto.RegisterType((*ChannelEdge)(nil), "lnrpc.ChannelEdge")
+	proto.RegisterType((*ChannelGraphRequest)(nil), "lnrpc.ChannelGraphRequest")
+	proto.RegisterType((*ChannelGraph)(nil), "lnrpc.ChannelGraph")
+	proto.RegisterType((*ChanInfoRequest)(nil), "lnrpc.ChanInfoRequest")
+	proto.RegisterType((*NetworkInfoRequest)(nil), "lnrpc.NetworkInfoRequest")
+	proto.RegisterType((*NetworkInfo)(nil), "lnrpc.NetworkInfo")
+	proto.RegisterType((*StopRequest)(nil), "lnrpc.StopRequest")
+	proto.RegisterType((*StopResponse)(nil), "lnrpc.StopResponse")
+	proto.RegisterType((*GraphTopologySubscription)(nil), "lnrpc.GraphTopologySubscription")
+	proto.RegisterType((*GraphTopologyUpdate)(nil), "lnrpc.GraphTopologyUpdate")
+	proto.RegisterType((*NodeUpdate)(nil), "lnrpc.NodeUpdate")
+	proto.RegisterType((*ChannelEdgeUpdate)(nil), "lnrpc.ChannelEdgeUpdate")
+	proto.RegisterType((*ClosedChannelUpdate)(nil), "lnrpc.ClosedChannelUpdate")
+	proto.RegisterType((*Invoice)(nil), "lnrpc.Invoice")
+	proto.RegisterType((*AddInvoiceResponse)(nil), "lnrpc.AddInvoiceResponse")
+	proto.RegisterType((*PaymentHash)(nil), "lnrpc.PaymentHash")
+	proto.RegisterType((*ListInvoiceRequest)(nil), "lnrpc.ListInvoiceRequest")
+	proto.RegisterType((*ListInvoiceResponse)(nil), "lnrpc.ListInvoiceResponse")
+	proto.RegisterType((*InvoiceSubscription)(nil), "lnrpc.InvoiceSubscription")
+	proto.RegisterType((*Payment)(nil), "lnrpc.Payment")
+	proto.RegisterType((*ListPaymentsRequest)(nil), "lnrpc.ListPaymentsRequest")
+	proto.RegisterType((*ListPaymentsResponse)(nil), "lnrpc.ListPaymentsResponse")
+	proto.RegisterType((*DeleteAllPaymentsRequest)(nil), "lnrpc.DeleteAllPaymentsRequest")
+	proto.RegisterType((*DeleteAllPaymentsResponse)(nil), "lnrpc.DeleteAllPaymentsResponse")
+	proto.RegisterType((*DebugLevelRequest)(nil), "lnrpc.DebugLevelRequest")
+	proto.RegisterType((*DebugLevelResponse)(nil), "lnrpc.DebugLevelResponse")
+	proto.RegisterType((*PayReqString)(nil), "lnrpc.PayReqString")
+	proto.RegisterType((*PayReq)(nil), "lnrpc.PayReq")
+	proto.RegisterType((*FeeReportRequest)(nil), "lnrpc.FeeReportRequest")
+	proto.RegisterType((*ChannelFeeReport)(nil), "lnrpc.ChannelFeeReport")
+	proto.RegisterType((*FeeReportResponse)(nil), "lnrpc.FeeReportResponse")
+	proto.RegisterType((*PolicyUpdateRequest)(nil), "lnrpc.PolicyUpdateRequest")
+	proto.RegisterType((*PolicyUpdateResponse)(nil), "lnrpc.PolicyUpdateResponse")
+	proto.RegisterType((*ForwardingHistoryRequest)(nil), "lnrpc.ForwardingHistoryRequest")
+	proto.RegisterType((*ForwardingEvent)(nil), "lnrpc.ForwardingEvent")
+	proto.RegisterType((*ForwardingHistoryResponse)(nil), "lnrpc.ForwardingHistoryResponse")
+	proto.RegisterType((*ExportChannelBackupRequest)(nil), "lnrpc.ExportChannelBackupRequest")
+	proto.RegisterType((*ChannelBackup)(nil), "lnrpc.ChannelBackup")
+	proto.RegisterType((*MultiChanBackup)(nil), "lnrpc.MultiChanBackup")
+	proto.RegisterType((*ChanBackupExportRequest)(nil), "lnrpc.ChanBackupExportRequest")
+	proto.RegisterType((*ChanBackupSnapshot)(nil), "lnrpc.ChanBackupSnapshot")
+	proto.RegisterType((*ChannelBackups)(nil), "lnrpc.ChannelBackups")
+	proto.RegisterType((*RestoreChanBackupRequest)(nil), "lnrpc.RestoreChanBackupRequest")
+	proto.RegisterType((*RestoreBackupResponse)(nil), "lnrpc.RestoreBackupResponse")
+	proto.RegisterType((*VerifyChanBackupResponse)(nil), "lnrpc.VerifyChanBackupResponse")
+	proto.RegisterType((*AddHoldInvoiceRequest)(nil), "lnrpc.AddHoldInvoiceRequest")
+	proto.RegisterType((*SettleInvoiceMsg)(nil), "lnrpc.SettleInvoiceMsg")
+	proto.RegisterType((*SettleInvoiceResp)(nil), "lnrpc.SettleInvoiceResp")
+	proto.RegisterType((*CancelInvoiceMsg)(nil), "lnrpc.CancelInvoiceMsg")
+	proto.RegisterType((*CancelInvoiceResp)(nil), "lnrpc.CancelInvoiceResp")
+	proto.RegisterType((*DeleteCanceledInvoicesRequest)(nil), "lnrpc.DeleteCanceledInvoicesRequest")
+	proto.RegisterType((*DeleteCanceledInvoicesResponse)(nil), "lnrpc.DeleteCanceledInvoicesResponse")
+	proto.RegisterType((*ChannelAcceptRequest)(nil), "lnrpc.ChannelAcceptRequest")
+	proto.RegisterType((*ChannelAcceptResponse)(nil), "lnrpc.ChannelAcceptResponse")
+	proto.RegisterType((*BatchOpenChannelRequest)(nil), "lnrpc.BatchOpenChannelRequest")
+	proto.RegisterType((*BatchOpenChannel)(nil), "lnrpc.BatchOpenChannel")
+	proto.RegisterType((*BatchOpenChannelResponse)(nil), "lnrpc.BatchOpenChannelResponse")
+	proto.RegisterType((*ReadyForPsbtFunding)(nil), "lnrpc.ReadyForPsbtFunding")
+	proto.RegisterType((*FinalizePsbtFundingRequest)(nil), "lnrpc.FinalizePsbtFundingRequest")
+	proto.RegisterType((*FinalizePsbtFundingResponse)(nil), "lnrpc.FinalizePsbtFundingResponse")
+	proto.RegisterType((*CircuitKey)(nil), "lnrpc.CircuitKey")
+	proto.RegisterType((*ForwardHtlcInterceptRequest)(nil), "lnrpc.ForwardHtlcInterceptRequest")
+	proto.RegisterType((*ForwardHtlcInterceptResponse)(nil), "lnrpc.ForwardHtlcInterceptResponse")
+	proto.RegisterType((*SubscribeHtlcEventsRequest)(nil), "lnrpc.SubscribeHtlcEventsRequest")
+	proto.RegisterType((*HtlcEvent)(nil), "lnrpc.HtlcEvent")
+	proto.RegisterType((*HtlcInfo)(nil), "lnrpc.HtlcInfo")
+	proto.RegisterType((*ForwardEvent)(nil), "lnrpc.ForwardEvent")
+	proto.RegisterType((*ForwardFailEvent)(nil), "lnrpc.ForwardFailEvent")
+	proto.RegisterType((*SettleEvent)(nil), "lnrpc.SettleEvent")
+	proto.RegisterType((*LinkFailEvent)(nil), "lnrpc.LinkFailEvent")
+	proto.RegisterType((*QueryMissionControlRequest)(nil), "lnrpc.QueryMissionControlRequest")
+	proto.RegisterType((*QueryMissionControlResponse)(nil), "lnrpc.QueryMissionControlResponse")
+	proto.RegisterType((*PairHistory)(nil), "lnrpc.PairHistory")
+	proto.RegisterType((*ImportMissionControlRequest)(nil), "lnrpc.ImportMissionControlRequest")
+	proto.RegisterType((*ImportMissionControlResponse)(nil), "lnrpc.ImportMissionControlResponse")
+	proto.RegisterType((*ResetMissionControlRequest)(nil), "lnrpc.ResetMissionControlRequest")
+	proto.RegisterType((*ResetMissionControlResponse)(nil), "lnrpc.ResetMissionControlResponse")
+	proto.RegisterType((*SendToRouteRequest)(nil), "lnrpc.SendToRouteRequest")
+	proto.RegisterType((*BuildRouteRequest)(nil), "lnrpc.BuildRouteRequest")
+	proto.RegisterType((*BuildRouteResponse)(nil), "lnrpc.BuildRouteResponse")
+	proto.RegisterType((*RebalanceRequest)(nil), "lnrpc.RebalanceRequest")
+	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+	proto.RegisterEnum("lnrpc.Invoice_InvoiceState", Invoice_InvoiceState_name, Invoice_InvoiceState_value)
+	proto.RegisterEnum("lnrpc.ForwardHtlcInterceptResponse_ResolveAction", ForwardHtlcInterceptResponse_ResolveAction_name, ForwardHtlcInterceptResponse_ResolveAction_value)
+	proto.RegisterEnum("lnrpc.HtlcEvent_EventType", HtlcEvent_EventType_name, HtlcEvent_EventType_value)
+	proto.RegisterEnum("lnrpc.LinkFailEvent_FailureDetail", LinkFailEvent_FailureDetail_name, LinkFailEvent_FailureDetail_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for WalletUnlocker service
+
+type WalletUnlockerClient interface {
+	// *
+	// GenSeed is the first method that should be used to instantiate a new lnd
+	// instance. This method allows a caller to generate a new aezeed cipher seed
+	// given an optional passphrase. If provided, the passphrase will be necessary
+	// to decrypt the cipherseed to expose the internal wallet seed.
+	//
+	// Once the cipherseed is obtained and verified by the user, the InitWallet
+	// method should be used to commit the newly generated seed, and create the
+	// wallet.
+	GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error)
+	// *
+	// InitWallet is used when lnd is starting up for the first time to fully
+	// initialize the daemon and its internal wallet. At the very least a wallet
+	// password must be provided. This will be used to encrypt sensitive material
+	// on disk.
+	//
+	// In the case of a recovery scenario, the user can also specify their aezeed
+	// mnemonic and passphrase. If set, then the daemon will use this prior state
+	// to initialize its internal wallet.
+	//
+	// Alternatively, this can be used along with the GenSeed RPC to obtain a
+	// seed, then present it to the user. Once it has been verified by the user,
+	// the seed can be fed into this RPC in order to commit the new wallet.
+	InitWallet(ctx context.Context, in *InitWalletRequest, opts ...grpc.CallOption) (*InitWalletResponse, error)
+	// * lncli: `unlock`
+	// UnlockWallet is used at startup of lnd to provide a password to unlock
+	// the wallet database.
+	UnlockWallet(ctx context.Context, in *UnlockWalletRequest, opts ...grpc.CallOption) (*UnlockWalletResponse, error)
+}
+
+type walletUnlockerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWalletUnlockerClient(cc *grpc.ClientConn) WalletUnlockerClient {
+	return &walletUnlockerClient{cc}
+}
+
+func (c *walletUnlockerClient) GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error) {
+	out := new(GenSeedResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/GenSeed", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletUnlockerClient) InitWallet(ctx context.Context, in *InitWalletRequest, opts ...grpc.CallOption) (*InitWalletResponse, error) {
+	out := new(InitWalletResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/InitWallet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletUnlockerClient) UnlockWallet(ctx context.Context, in *UnlockWalletRequest, opts ...grpc.CallOption) (*UnlockWalletResponse, error) {
+	out := new(UnlockWalletResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/UnlockWallet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for WalletUnlocker service
+
+type WalletUnlockerServer interface {
+	// *
+	// GenSeed is the first method that should be used to instantiate a new lnd
+	// instance. This method allows a caller to generate a new aezeed cipher seed
+	// given an optional passphrase. If provided, the passphrase will be necessary
+	// to decrypt the cipherseed to expose the internal wallet seed.
+	//
+	// Once the cipherseed is obtained and verified by the user, the InitWallet
+	// method should be used to commit the newly generated seed, and create the
+	// wallet.
+	GenSeed(context.Context, *GenSeedRequest) (*GenSeedResponse, error)
+	// *
+	// InitWallet is used when lnd is starting up for the first time to fully
+	// initialize the daemon and its internal wallet. At the very least a wallet
+	// password must be provided. This will be used to encrypt sensitive material
+	// on disk.
+	//
+	// In the case of a recovery scenario, the user can also specify their aezeed
+	// mnemonic and passphrase. If set, then the daemon will use this prior state
+	// to initialize its internal wallet.
+	//
+	// Alternatively, this can be used along with the GenSeed RPC to obtain a
+	// seed, then present it to the user. Once it has been verified by the user,
+	// the seed can be fed into this RPC in order to commit the new wallet.
+	InitWallet(context.Context, *InitWalletRequest) (*InitWalletResponse, error)
+	// * lncli: `unlock`
+	// UnlockWallet is used at startup of lnd to provide a password to unlock
+	// the wallet database.
+	UnlockWallet(context.Context, *UnlockWalletRequest) (*UnlockWalletResponse, error)
+}
+
+func RegisterWalletUnlockerServer(s *grpc.Server, srv WalletUnlockerServer) {
+	s.RegisterService(&_WalletUnlocker_serviceDesc, srv)
+}
+
+func _WalletUnlocker_GenSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenSeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletUnlockerServer).GenSeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.WalletUnlocker/GenSeed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletUnlockerServer).GenSeed(ctx, req.(*GenSeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletUnlocker_InitWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletUnlockerServer).InitWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.WalletUnlocker/InitWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletUnlockerServer).InitWallet(ctx, req.(*InitWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletUnlocker_UnlockWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletUnlockerServer).UnlockWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.WalletUnlocker/UnlockWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletUnlockerServer).UnlockWallet(ctx, req.(*UnlockWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WalletUnlocker_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.WalletUnlocker",
+	HandlerType: (*WalletUnlockerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenSeed",
+			Handler:    _WalletUnlocker_GenSeed_Handler,
+		},
+		{
+			MethodName: "InitWallet",
+			Handler:    _WalletUnlocker_InitWallet_Handler,
+		},
+		{
+			MethodName: "UnlockWallet",
+			Handler:    _WalletUnlocker_UnlockWallet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc.proto",
+}
+
+// Client API for Lightning service
+
+type LightningClient interface {
+	// * lncli: `walletbalance`
+	// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
+	// confirmed unspent outputs and all unconfirmed unspent outputs under control
+	// of the wallet.
+	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
+	// * lncli: `channelbalance`
+	// ChannelBalance returns the total funds available across all open channels
+	// in satoshis.
+	ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error)
+	// * lncli: `listchaintxns`
+	// GetTransactions returns a list describing all the known transactions
+	// relevant to the wallet.
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error)
+	// * lncli: `sendcoins`
+	// SendCoins executes a request to send coins to a particular address. Unlike
+	// SendMany, this RPC call only allows creating a single output at a time. If
+	// neither target_conf, or sat_per_byte are set, then the internal wallet will
+	// consult its fee model to determine a fee for the default confirmation
+	// target.
+	SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error)
+	// *
+	// SubscribeTransactions creates a uni-directional stream from the server to
+	// the client in which any newly discovered transactions relevant to the
+	// wallet are sent over.
+	SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error)
+	// * lncli: `sendmany`
+	// SendMany handles a request for a transaction that creates multiple specified
+	// outputs in parallel. If neither target_conf, or sat_per_byte are set, then
+	// the internal wallet will consult its fee model to determine a fee for the
+	// default confirmation target.
+	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
+	// * lncli: `newaddress`
+	// NewAddress creates a new address under control of the local wallet.
+	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
+	// *
+	// NewWitnessAddress creates a new witness address under control of the local wallet.
+	NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
+	// * lncli: `signmessage`
+	// SignMessage signs a message with this node's private key. The returned
+	// signature string is `zbase32` encoded and pubkey recoverable, meaning that
+	// only the message digest and signature are needed for verification.
+	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
+	// * lncli: `verifymessage`
+	// VerifyMessage verifies a signature over a msg. The signature must be
+	// zbase32 encoded and signed by an active node in the resident node's
+	// channel database. In addition to returning the validity of the signature,
+	// VerifyMessage also returns the recovered pubkey from the signature.
+	VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error)
+	// * lncli: `connect`
+	// ConnectPeer attempts to establish a connection to a remote peer. This is at
+	// the networking level, and is used for communication between nodes. This is
+	// distinct from establishing a channel with a peer.
+	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error)
+	// * lncli: `disconnect`
+	// DisconnectPeer attempts to disconnect one peer from another identified by a
+	// given pubKey. In the case that we currently have a pending or active channel
+	// with the target peer, then this action will be not be allowed.
+	DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error)
+	// * lncli: `listpeers`
+	// ListPeers returns a verbose listing of all currently active peers.
+	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	// * lncli: `getinfo`
+	// GetInfo returns general information concerning the lightning node including
+	// it's identity pubkey, alias, the chains it is connected to, and information
+	// concerning the number of open+pending channels.
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// * lncli: `pendingchannels`
+	// PendingChannels returns a list of all the channels that are currently
+	// considered "pending". A channel is pending if it has finished the funding
+	// workflow and is waiting for confirmations for the funding txn, or is in the
+	// process of closure, either initiated cooperatively or non-cooperatively.
+	PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error)
+	// * lncli: `listchannels`
+	// ListChannels returns a description of all the open channels that this node
+	// is a participant in.
+	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
+	// *
+	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
+	// call is meant to be consumed by clients to the REST proxy. As with all
+	// other sync calls, all byte slices are intended to be populated as hex
+	// encoded strings.
+	OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error)
+	// * lncli: `openchannel`
+	// OpenChannel attempts to open a singly funded channel specified in the
+	// request to a remote peer. Users are able to specify a target number of
+	// blocks that the funding transaction should be confirmed in, or a manual fee
+	// rate to us for the funding transaction. If neither are specified, then a
+	// lax block confirmation target is used.
+	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
+	// * lncli: `closechannel`
+	// CloseChannel attempts to close an active channel identified by its channel
+	// outpoint (ChannelPoint). The actions of this method can additionally be
+	// augmented to attempt a force close after a timeout period in the case of an
+	// inactive peer. If a non-force close (cooperative closure) is requested,
+	// then the user can specify either a target number of blocks until the
+	// closure transaction is confirmed, or a manual fee rate. If neither are
+	// specified, then a default lax, block confirmation target is used.
+	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
+	// * lncli: `sendpayment`
+	// SendPayment dispatches a bi-directional streaming RPC for sending payments
+	// through the Lightning Network. A single RPC invocation creates a persistent
+	// bi-directional stream allowing clients to rapidly send payments through the
+	// Lightning Network with a single persistent connection.
+	SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error)
+	// *
+	// SendPaymentSync is the synchronous non-streaming version of SendPayment.
+	// This RPC is intended to be consumed by clients of the REST proxy.
+	// Additionally, this RPC expects the destination's public key and the payment
+	// hash (if any) to be encoded as hex strings.
+	SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// * lncli: `addinvoice`
+	// AddInvoice attempts to add a new invoice to the invoice database. Any
+	// duplicated invoices are rejected, therefore all invoices *must* have a
+	// unique payment preimage.
+	AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
+	// * lncli: `listinvoices`
+	// ListInvoices returns a list of all the invoices currently stored within the
+	// database. Any active debug invoices are ignored.
+	ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error)
+	// * lncli: `lookupinvoice`
+	// LookupInvoice attempts to look up an invoice according to its payment hash.
+	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
+	// returned.
+	LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error)
+	// *
+	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
+	// notifying the client of invoices that were accepted, settled or canceled.
+	SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error)
+	// * lncli: `decodepayreq`
+	// DecodePayReq takes an encoded payment request string and attempts to decode
+	// it, returning a full description of the conditions encoded within the
+	// payment request.
+	DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error)
+	// * lncli: `listpayments`
+	// ListPayments returns a list of all outgoing payments.
+	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	// *
+	// DeleteAllPayments deletes all outgoing payments from DB.
+	DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error)
+	// * lncli: `describegraph`
+	// DescribeGraph returns a description of the latest graph state from the
+	// point of view of the node. The graph information is partitioned into two
+	// components: all the nodes/vertexes, and all the edges that connect the
+	// vertexes themselves.  As this is a directed graph, the edges also contain
+	// the node directional specific routing policy which includes: the time lock
+	// delta, fee information, etc.
+	DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error)
+	// * lncli: `getchaninfo`
+	// GetChanInfo returns the latest authenticated network announcement for the
+	// given channel identified by its channel ID: an 8-byte integer which
+	// uniquely identifies the location of transaction's funding output within the
+	// blockchain.
+	GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error)
+	// * lncli: `getnodeinfo`
+	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
+	// channel information for the specified node identified by its public key.
+	GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error)
+	// * lncli: `queryroutes`
+	// QueryRoutes attempts to query the daemon's Channel Router for a possible
+	// route to a target destination capable of carrying a specific amount of
+	// satoshis. The retuned route contains the full details required to craft and
+	// send an HTLC, also including the necessary information that should be
+	// present within the Sphinx packet encapsulated within the HTLC.
+	QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error)
+	// * lncli: `getnetworkinfo`
+	// GetNetworkInfo returns some basic stats about the known channel graph from
+	// the point of view of the node.
+	GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error)
+	// * lncli: `stop`
+	// StopDaemon will send a shutdown request to the interrupt handler, triggering
+	// a graceful shutdown of the daemon.
+	StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	// *
+	// SubscribeChannelGraph launches a streaming RPC that allows the caller to
+	// receive notifications upon any changes to the channel graph topology from
+	// the point of view of the responding node. Events notified include: new
+	// nodes coming online, nodes updating their authenticated attributes, new
+	// channels being advertised, updates in the routing policy for a directional
+	// channel edge, and when channels are closed on-chain.
+	SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error)
+	// * lncli: `debuglevel`
+	// DebugLevel allows a caller to programmatically set the logging verbosity of
+	// lnd. The logging can be targeted according to a coarse daemon-wide logging
+	// level, or in a granular fashion to specify the logging for a target
+	// sub-system.
+	DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error)
+	// * lncli: `feereport`
+	// FeeReport allows the caller to obtain a report detailing the current fee
+	// schedule enforced by the node globally for each channel.
+	FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error)
+	// * lncli: `updatechanpolicy`
+	// UpdateChannelPolicy allows the caller to update the fee schedule and
+	// channel policies for all channels globally, or a particular channel.
+	UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error)
+	// * lncli: `fwdinghistory`
+	// ForwardingHistory allows the caller to query the htlcswitch for a record of
+	// all HTLC's forwarded within the target time range, and integer offset
+	// within that time range. If no time-range is specified, then the first chunk
+	// of the past 24 hrs of forwarding history are returned.
+	//
+	// A list of forwarding events are returned. The size of each forwarding event
+	// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
+	// As a result each message can only contain 50k entries.  Each response has
+	// the index offset of the last entry. The index offset can be provided to the
+	// request to allow the caller to skip a series of records.
+	ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error)
+	// * lncli: `exportchanbackup`
+	// ExportChannelBackup attempts to return an encrypted static channel backup
+	// for the target channel identified by its channel point. The backup is
+	// encrypted with a key derived from the wallet's keychain. The returned
+	// backup can later be restored using the RestoreChannelBackups method.
+	ExportChannelBackup(ctx context.Context, in *ExportChannelBackupRequest, opts ...grpc.CallOption) (*ChannelBackup, error)
+	// *
+	// ExportAllChannelBackups returns static channel backups for all existing
+	// channels known to lnd. A set of regular singular static channel backups for
+	// each channel are returned. Additionally, a multi-channel backup is returned
+	// as well, which contains a single encrypted blob containing the backups of
+	// each channel.
+	ExportAllChannelBackups(ctx context.Context, in *ChanBackupExportRequest, opts ...grpc.CallOption) (*ChanBackupSnapshot, error)
+	// * lncli: `verifychanbackup`
+	// VerifyChanBackup allows a caller to verify the integrity of a channel
+	// backup snapshot. This method will accept both a set of packed Singles, and
+	// also a packed Multi. If either of the backups present within the snapshot
+	// can't be decrypted and parsed, then an error is returned.
+	VerifyChanBackup(ctx context.Context, in *ChanBackupSnapshot, opts ...grpc.CallOption) (*VerifyChanBackupResponse, error)
+	// * lncli: `restorechanbackup`
+	// RestoreChannelBackups accepts a set of singular channel backups, or a
+	// single encrypted multi-chan backup and attempts to recover any funds
+	// remaining within the channel. If we are able to unpack the backup, then the
+	// new channel will be shown under listchannels, as well as pending channels.
+	RestoreChannelBackups(ctx context.Context, in *RestoreChanBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
+	// * lncli: `addholdinvoice`
+	// AddHoldInvoice creates a hold invoice. It ties the invoice to the hash
+	// supplied in the request. Incoming HTLCs paying to the invoice are held
+	// until the invoice is either settled with SettleInvoice, or canceled with
+	// CancelInvoice.
+	AddHoldInvoice(ctx context.Context, in *AddHoldInvoiceRequest, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
+	// * lncli: `settleinvoice`
+	// SettleInvoice settles an accepted hold invoice using the preimage of its
+	// payment hash. The HTLCs being held for the invoice are settled as a result.
+	SettleInvoice(ctx context.Context, in *SettleInvoiceMsg, opts ...grpc.CallOption) (*SettleInvoiceResp, error)
+	// * lncli: `cancelinvoice`
+	// CancelInvoice cancels a currently open invoice. If the invoice is already
+	// canceled, this call will succeed. If the invoice is already settled, it
+	// will fail. Any HTLCs being held for the invoice are failed back to the
+	// sender.
+	CancelInvoice(ctx context.Context, in *CancelInvoiceMsg, opts ...grpc.CallOption) (*CancelInvoiceResp, error)
+	// * lncli: `deletecanceledinvoices`
+	// DeleteCanceledInvoices removes all canceled and expired invoices created
+	// before the given time from the invoice database. The payment hashes of the
+	// removed invoices can be reused by new invoices.
+	DeleteCanceledInvoices(ctx context.Context, in *DeleteCanceledInvoicesRequest, opts ...grpc.CallOption) (*DeleteCanceledInvoicesResponse, error)
+	// *
+	// ChannelAcceptor dispatches a bi-directional streaming RPC in which each
+	// inbound OpenChannel request is sent to the client, which responds with
+	// whether the channel should be accepted or not. The reason for rejecting a
+	// channel is sent to the remote peer. This allows node operators to enforce
+	// their own criteria for accepting inbound channels through a single
+	// persistent connection. If several clients are connected, a channel is only
+	// accepted if all of them accept it. Requests that aren't responded to in
+	// time are rejected.
+	ChannelAcceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_ChannelAcceptorClient, error)
+	// * lncli: `batchopenchannel`
+	// BatchOpenChannel attempts to open multiple singly funded channels with
+	// different remote peers, all funded by a single transaction. If the funding
+	// workflow with any of the peers fails, then none of the channels are opened,
+	// and the funding transaction is never broadcast. The pending channels are
+	// returned once the funding transaction has been broadcast.
+	BatchOpenChannel(ctx context.Context, in *BatchOpenChannelRequest, opts ...grpc.CallOption) (*BatchOpenChannelResponse, error)
+	// * lncli: `finalizepsbt`
+	// FinalizePsbtFunding resumes the funding flow of a channel opened with
+	// psbt_funding set, once the external wallet has funded and signed the funding
+	// transaction. The funding transaction must contain the funding output
+	// described by the ReadyForPsbtFunding update, and all of its inputs must
+	// spend segwit outputs.
+	FinalizePsbtFunding(ctx context.Context, in *FinalizePsbtFundingRequest, opts ...grpc.CallOption) (*FinalizePsbtFundingResponse, error)
+	// *
+	// HtlcInterceptor dispatches a bi-directional streaming RPC in which each
+	// forwarded htlc is sent to the client, which decides whether to resume the
+	// forward, to settle it using a preimage, or to fail it back with a chosen
+	// failure. Forwards that aren't resolved in time are resumed. Only a single
+	// client can intercept forwards at a time.
+	HtlcInterceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_HtlcInterceptorClient, error)
+	// *
+	// SubscribeHtlcEvents creates a uni-directional stream from the server to
+	// the client which delivers a stream of htlc events as they occur. Events
+	// are sent when htlcs are forwarded, sent, settled, or failed, either by
+	// our node or a node further down the route.
+	SubscribeHtlcEvents(ctx context.Context, in *SubscribeHtlcEventsRequest, opts ...grpc.CallOption) (Lightning_SubscribeHtlcEventsClient, error)
+	// * lncli: `querymc`
+	// QueryMissionControl returns the outcomes of past payment attempts recorded
+	// by mission control, for each pair of nodes that payments were attempted
+	// to be forwarded between. The response can be used to export the history,
+	// for instance to import it into another node.
+	QueryMissionControl(ctx context.Context, in *QueryMissionControlRequest, opts ...grpc.CallOption) (*QueryMissionControlResponse, error)
+	// * lncli: `importmc`
+	// ImportMissionControl merges the passed node pair results into the history
+	// of mission control. For each pair, results are only applied if they are
+	// more recent than those already known.
+	ImportMissionControl(ctx context.Context, in *ImportMissionControlRequest, opts ...grpc.CallOption) (*ImportMissionControlResponse, error)
+	// * lncli: `resetmc`
+	// ResetMissionControl clears all outcomes of past payment attempts recorded
+	// by mission control.
+	ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error)
+	// * lncli: `sendtoroute`
+	// SendToRoute sends a payment along the passed routes, rather than along
+	// routes found by the node itself. The routes are attempted in order, until
+	// either the payment succeeds or all routes have failed. The routes may be
+	// obtained using QueryRoutes or BuildRoute.
+	SendToRoute(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// * lncli: `buildroute`
+	// BuildRoute builds a route that delivers the given amount along the passed
+	// hops, starting at our own node. The fees and time locks of the route are
+	// computed from the channel policies known to the node. The route can be
+	// used to pay along it using SendToRoute.
+	BuildRoute(ctx context.Context, in *BuildRouteRequest, opts ...grpc.CallOption) (*BuildRouteResponse, error)
+	// * lncli: `rebalance`
+	// Rebalance moves funds from one of our channels to another by paying
+	// ourselves. The payment leaves our node through the outgoing channel, and
+	// returns through the last hop channel, along a route found by the node
+	// that doesn't exceed the fee limit. It settles an invoice generated by the
+	// node for this purpose.
+	Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*SendResponse, error)
+}
+
+type lightningClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLightningClient(cc *grpc.ClientConn) LightningClient {
+	return &lightningClient{cc}
+}
+
+func (c *lightningClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
+	out := new(WalletBalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/WalletBalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error) {
+	out := new(ChannelBalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ChannelBalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error) {
+	out := new(TransactionDetails)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetTransactions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error) {
+	out := new(SendCoinsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCoins", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[0], c.cc, "/lnrpc.Lightning/SubscribeTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeTransactionsClient interface {
+	Recv() (*Transaction, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeTransactionsClient) Recv() (*Transaction, error) {
+	m := new(Transaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error) {
+	out := new(SendManyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendMany", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
+	out := new(NewAddressResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
+	out := new(NewAddressResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewWitnessAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
+	out := new(SignMessageResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SignMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error) {
+	out := new(VerifyMessageResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/VerifyMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error) {
+	out := new(ConnectPeerResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ConnectPeer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error) {
+	out := new(DisconnectPeerResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DisconnectPeer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
+	out := new(ListPeersResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPeers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	out := new(GetInfoResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error) {
+	out := new(PendingChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
+	out := new(ListChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error) {
+	out := new(ChannelPoint)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/OpenChannelSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningOpenChannelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_OpenChannelClient interface {
+	Recv() (*OpenStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningOpenChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
+	m := new(OpenStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningCloseChannelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_CloseChannelClient interface {
+	Recv() (*CloseStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningCloseChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
+	m := new(CloseStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSendPaymentClient{stream}
+	return x, nil
+}
+
+type Lightning_SendPaymentClient interface {
+	Send(*SendRequest) error
+	Recv() (*SendResponse, error)
+	grpc.ClientStream
+}
+
+type lightningSendPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
+	m := new(SendResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendPaymentSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
+	out := new(AddInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error) {
+	out := new(ListInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListInvoices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error) {
+	out := new(Invoice)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LookupInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeInvoicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeInvoicesClient interface {
+	Recv() (*Invoice, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeInvoicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeInvoicesClient) Recv() (*Invoice, error) {
+	m := new(Invoice)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error) {
+	out := new(PayReq)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DecodePayReq", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	out := new(ListPaymentsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPayments", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error) {
+	out := new(DeleteAllPaymentsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteAllPayments", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error) {
+	out := new(ChannelGraph)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DescribeGraph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error) {
+	out := new(ChannelEdge)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetChanInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error) {
+	out := new(NodeInfo)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error) {
+	out := new(QueryRoutesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryRoutes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error) {
+	out := new(NetworkInfo)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNetworkInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	out := new(StopResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/StopDaemon", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeChannelGraphClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeChannelGraphClient interface {
+	Recv() (*GraphTopologyUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeChannelGraphClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeChannelGraphClient) Recv() (*GraphTopologyUpdate, error) {
+	m := new(GraphTopologyUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error) {
+	out := new(DebugLevelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DebugLevel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error) {
+	out := new(FeeReportResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FeeReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error) {
+	out := new(PolicyUpdateResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateChannelPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error) {
+	out := new(ForwardingHistoryResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ForwardingHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ExportChannelBackup(ctx context.Context, in *ExportChannelBackupRequest, opts ...grpc.CallOption) (*ChannelBackup, error) {
+	out := new(ChannelBackup)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportChannelBackup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ExportAllChannelBackups(ctx context.Context, in *ChanBackupExportRequest, opts ...grpc.CallOption) (*ChanBackupSnapshot, error) {
+	out := new(ChanBackupSnapshot)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportAllChannelBackups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) VerifyChanBackup(ctx context.Context, in *ChanBackupSnapshot, opts ...grpc.CallOption) (*VerifyChanBackupResponse, error) {
+	out := new(VerifyChanBackupResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/VerifyChanBackup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) RestoreChannelBackups(ctx context.Context, in *RestoreChanBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
+	out := new(RestoreBackupResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/RestoreChannelBackups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) AddHoldInvoice(ctx context.Context, in *AddHoldInvoiceRequest, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
+	out := new(AddInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddHoldInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SettleInvoice(ctx context.Context, in *SettleInvoiceMsg, opts ...grpc.CallOption) (*SettleInvoiceResp, error) {
+	out := new(SettleInvoiceResp)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SettleInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) CancelInvoice(ctx context.Context, in *CancelInvoiceMsg, opts ...grpc.CallOption) (*CancelInvoiceResp, error) {
+	out := new(CancelInvoiceResp)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/CancelInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeleteCanceledInvoices(ctx context.Context, in *DeleteCanceledInvoicesRequest, opts ...grpc.CallOption) (*DeleteCanceledInvoicesResponse, error) {
+	out := new(DeleteCanceledInvoicesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteCanceledInvoices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ChannelAcceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_ChannelAcceptorClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/ChannelAcceptor", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningChannelAcceptorClient{stream}
+	return x, nil
+}
+
+type Lightning_ChannelAcceptorClient interface {
+	Send(*ChannelAcceptResponse) error
+	Recv() (*ChannelAcceptRequest, error)
+	grpc.ClientStream
+}
+
+type lightningChannelAcceptorClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningChannelAcceptorClient) Send(m *ChannelAcceptResponse) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningChannelAcceptorClient) Recv() (*ChannelAcceptRequest, error) {
+	m := new(ChannelAcceptRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) BatchOpenChannel(ctx context.Context, in *BatchOpenChannelRequest, opts ...grpc.CallOption) (*BatchOpenChannelResponse, error) {
+	out := new(BatchOpenChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BatchOpenChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) FinalizePsbtFunding(ctx context.Context, in *FinalizePsbtFundingRequest, opts ...grpc.CallOption) (*FinalizePsbtFundingResponse, error) {
+	out := new(FinalizePsbtFundingResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FinalizePsbtFunding", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) HtlcInterceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_HtlcInterceptorClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[7], c.cc, "/lnrpc.Lightning/HtlcInterceptor", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningHtlcInterceptorClient{stream}
+	return x, nil
+}
+
+type Lightning_HtlcInterceptorClient interface {
+	Send(*ForwardHtlcInterceptResponse) error
+	Recv() (*ForwardHtlcInterceptRequest, error)
+	grpc.ClientStream
+}
+
+type lightningHtlcInterceptorClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningHtlcInterceptorClient) Send(m *ForwardHtlcInterceptResponse) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningHtlcInterceptorClient) Recv() (*ForwardHtlcInterceptRequest, error) {
+	m := new(ForwardHtlcInterceptRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SubscribeHtlcEvents(ctx context.Context, in *SubscribeHtlcEventsRequest, opts ...grpc.CallOption) (Lightning_SubscribeHtlcEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[8], c.cc, "/lnrpc.Lightning/SubscribeHtlcEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeHtlcEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeHtlcEventsClient interface {
+	Recv() (*HtlcEvent, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeHtlcEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeHtlcEventsClient) Recv() (*HtlcEvent, error) {
+	m := new(HtlcEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) QueryMissionControl(ctx context.Context, in *QueryMissionControlRequest, opts ...grpc.CallOption) (*QueryMissionControlResponse, error) {
+	out := new(QueryMissionControlResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryMissionControl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ImportMissionControl(ctx context.Context, in *ImportMissionControlRequest, opts ...grpc.CallOption) (*ImportMissionControlResponse, error) {
+	out := new(ImportMissionControlResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ImportMissionControl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error) {
+	out := new(ResetMissionControlResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ResetMissionControl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendToRoute(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendToRoute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) BuildRoute(ctx context.Context, in *BuildRouteRequest, opts ...grpc.CallOption) (*BuildRouteResponse, error) {
+	out := new(BuildRouteResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BuildRoute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/Rebalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Lightning service
+
+type LightningServer interface {
+	// * lncli: `walletbalance`
+	// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
+	// confirmed unspent outputs and all unconfirmed unspent outputs under control
+	// of the wallet.
+	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
+	// * lncli: `channelbalance`
+	// ChannelBalance returns the total funds available across all open channels
+	// in satoshis.
+	ChannelBalance(context.Context, *ChannelBalanceRequest) (*ChannelBalanceResponse, error)
+	// * lncli: `listchaintxns`
+	// GetTransactions returns a list describing all the known transactions
+	// relevant to the wallet.
+	GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionDetails, error)
+	// * lncli: `sendcoins`
+	// SendCoins executes a request to send coins to a particular address. Unlike
+	// SendMany, this RPC call only allows creating a single output at a time. If
+	// neither target_conf, or sat_per_byte are set, then the internal wallet will
+	// consult its fee model to determine a fee for the default confirmation
+	// target.
+	SendCoins(context.Context, *SendCoinsRequest) (*SendCoinsResponse, error)
+	// *
+	// SubscribeTransactions creates a uni-directional stream from the server to
+	// the client in which any newly discovered transactions relevant to the
+	// wallet are sent over.
+	SubscribeTransactions(*GetTransactionsRequest, Lightning_SubscribeTransactionsServer) error
+	// * lncli: `sendmany`
+	// SendMany handles a request for a transaction that creates multiple specified
+	// outputs in parallel. If neither target_conf, or sat_per_byte are set, then
+	// the internal wallet will consult its fee model to determine a fee for the
+	// default confirmation target.
+	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
+	// * lncli: `newaddress`
+	// NewAddress creates a new address under control of the local wallet.
+	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
+	// *
+	// NewWitnessAddress creates a new witness address under control of the local wallet.
+	NewWitnessAddress(context.Context, *NewWitnessAddressRequest) (*NewAddressResponse, error)
+	// * lncli: `signmessage`
+	// SignMessage signs a message with this node's private key. The returned
+	// signature string is `zbase32` encoded and pubkey recoverable, meaning that
+	// only the message digest and signature are needed for verification.
+	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
+	// * lncli: `verifymessage`
+	// VerifyMessage verifies a signature over a msg. The signature must be
+	// zbase32 encoded and signed by an active node in the resident node's
+	// channel database. In addition to returning the validity of the signature,
+	// VerifyMessage also returns the recovered pubkey from the signature.
+	VerifyMessage(context.Context, *VerifyMessageRequest) (*VerifyMessageResponse, error)
+	// * lncli: `connect`
+	// ConnectPeer attempts to establish a connection to a remote peer. This is at
+	// the networking level, and is used for communication between nodes. This is
+	// distinct from establishing a channel with a peer.
+	ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error)
+	// * lncli: `disconnect`
+	// DisconnectPeer attempts to disconnect one peer from another identified by a
+	// given pubKey. In the case that we currently have a pending or active channel
+	// with the target peer, then this action will be not be allowed.
+	DisconnectPeer(context.Context, *DisconnectPeerRequest) (*DisconnectPeerResponse, error)
+	// * lncli: `listpeers`
+	// ListPeers returns a verbose listing of all currently active peers.
+	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	// * lncli: `getinfo`
+	// GetInfo returns general information concerning the lightning node including
+	// it's identity pubkey, alias, the chains it is connected to, and information
+	// concerning the number of open+pending channels.
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// * lncli: `pendingchannels`
+	// PendingChannels returns a list of all the channels that are currently
+	// considered "pending". A channel is pending if it has finished the funding
+	// workflow and is waiting for confirmations for the funding txn, or is in the
+	// process of closure, either initiated cooperatively or non-cooperatively.
+	PendingChannels(context.Context, *PendingChannelsRequest) (*PendingChannelsResponse, error)
+	// * lncli: `listchannels`
+	// ListChannels returns a description of all the open channels that this node
+	// is a participant in.
+	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
+	// *
+	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
+	// call is meant to be consumed by clients to the REST proxy. As with all
+	// other sync calls, all byte slices are intended to be populated as hex
+	// encoded strings.
+	OpenChannelSync(context.Context, *OpenChannelRequest) (*ChannelPoint, error)
+	// * lncli: `openchannel`
+	// OpenChannel attempts to open a singly funded channel specified in the
+	// request to a remote peer. Users are able to specify a target number of
+	// blocks that the funding transaction should be confirmed in, or a manual fee
+	// rate to us for the funding transaction. If neither are specified, then a
+	// lax block confirmation target is used.
+	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
+	// * lncli: `closechannel`
+	// CloseChannel attempts to close an active channel identified by its channel
+	// outpoint (ChannelPoint). The actions of this method can additionally be
+	// augmented to attempt a force close after a timeout period in the case of an
+	// inactive peer. If a non-force close (cooperative closure) is requested,
+	// then the user can specify either a target number of blocks until the
+	// closure transaction is confirmed, or a manual fee rate. If neither are
+	// specified, then a default lax, block confirmation target is used.
+	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
+	// * lncli: `sendpayment`
+	// SendPayment dispatches a bi-directional streaming RPC for sending payments
+	// through the Lightning Network. A single RPC invocation creates a persistent
+	// bi-directional stream allowing clients to rapidly send payments through the
+	// Lightning Network with a single persistent connection.
+	SendPayment(Lightning_SendPaymentServer) error
+	// *
+	// SendPaymentSync is the synchronous non-streaming version of SendPayment.
+	// This RPC is intended to be consumed by clients of the REST proxy.
+	// Additionally, this RPC expects the destination's public key and the payment
+	// hash (if any) to be encoded as hex strings.
+	SendPaymentSync(context.Context, *SendRequest) (*SendResponse, error)
+	// * lncli: `addinvoice`
+	// AddInvoice attempts to add a new invoice to the invoice database. Any
+	// duplicated invoices are rejected, therefore all invoices *must* have a
+	// unique payment preimage.
+	AddInvoice(context.Context, *Invoice) (*AddInvoiceResponse, error)
+	// * lncli: `listinvoices`
+	// ListInvoices returns a list of all the invoices currently stored within the
+	// database. Any active debug invoices are ignored.
+	ListInvoices(context.Context, *ListInvoiceRequest) (*ListInvoiceResponse, error)
+	// * lncli: `lookupinvoice`
+	// LookupInvoice attempts to look up an invoice according to its payment hash.
+	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
+	// returned.
+	LookupInvoice(context.Context, *PaymentHash) (*Invoice, error)
+	// *
+	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
+	// notifying the client of invoices that were accepted, settled or canceled.
+	SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error
+	// * lncli: `decodepayreq`
+	// DecodePayReq takes an encoded payment request string and attempts to decode
+	// it, returning a full description of the conditions encoded within the
+	// payment request.
+	DecodePayReq(context.Context, *PayReqString) (*PayReq, error)
+	// * lncli: `listpayments`
+	// ListPayments returns a list of all outgoing payments.
+	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	// *
+	// DeleteAllPayments deletes all outgoing payments from DB.
+	DeleteAllPayments(context.Context, *DeleteAllPaymentsRequest) (*DeleteAllPaymentsResponse, error)
+	// * lncli: `describegraph`
+	// DescribeGraph returns a description of the latest graph state from the
+	// point of view of the node. The graph information is partitioned into two
+	// components: all the nodes/vertexes, and all the edges that connect the
+	// vertexes themselves.  As this is a directed graph, the edges also contain
+	// the node directional specific routing policy which includes: the time lock
+	// delta, fee information, etc.
+	DescribeGraph(context.Context, *ChannelGraphRequest) (*ChannelGraph, error)
+	// * lncli: `getchaninfo`
+	// GetChanInfo returns the latest authenticated network announcement for the
+	// given channel identified by its channel ID: an 8-byte integer which
+	// uniquely identifies the location of transaction's funding output within the
+	// blockchain.
+	GetChanInfo(context.Context, *ChanInfoRequest) (*ChannelEdge, error)
+	// * lncli: `getnodeinfo`
+	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
+	// channel information for the specified node identified by its public key.
+	GetNodeInfo(context.Context, *NodeInfoRequest) (*NodeInfo, error)
+	// * lncli: `queryroutes`
+	// QueryRoutes attempts to query the daemon's Channel Router for a possible
+	// route to a target destination capable of carrying a specific amount of
+	// satoshis. The retuned route contains the full details required to craft and
+	// send an HTLC, also including the necessary information that should be
+	// present within the Sphinx packet encapsulated within the HTLC.
+	QueryRoutes(context.Context, *QueryRoutesRequest) (*QueryRoutesResponse, error)
+	// * lncli: `getnetworkinfo`
+	// GetNetworkInfo returns some basic stats about the known channel graph from
+	// the point of view of the node.
+	GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfo, error)
+	// * lncli: `stop`
+	// StopDaemon will send a shutdown request to the interrupt handler, triggering
+	// a graceful shutdown of the daemon.
+	StopDaemon(context.Context, *StopRequest) (*StopResponse, error)
+	// *
+	// SubscribeChannelGraph launches a streaming RPC that allows the caller to
+	// receive notifications upon any changes to the channel graph topology from
+	// the point of view of the responding node. Events notified include: new
+	// nodes coming online, nodes updating their authenticated attributes, new
+	// channels being advertised, updates in the routing policy for a directional
+	// channel edge, and when channels are closed on-chain.
+	SubscribeChannelGraph(*GraphTopologySubscription, Lightning_SubscribeChannelGraphServer) error
+	// * lncli: `debuglevel`
+	// DebugLevel allows a caller to programmatically set the logging verbosity of
+	// lnd. The logging can be targeted according to a coarse daemon-wide logging
+	// level, or in a granular fashion to specify the logging for a target
+	// sub-system.
+	DebugLevel(context.Context, *DebugLevelRequest) (*DebugLevelResponse, error)
+	// * lncli: `feereport`
+	// FeeReport allows the caller to obtain a report detailing the current fee
+	// schedule enforced by the node globally for each channel.
+	FeeReport(context.Context, *FeeReportRequest) (*FeeReportResponse, error)
+	// * lncli: `updatechanpolicy`
+	// UpdateChannelPolicy allows the caller to update the fee schedule and
+	// channel policies for all channels globally, or a particular channel.
+	UpdateChannelPolicy(context.Context, *PolicyUpdateRequest) (*PolicyUpdateResponse, error)
+	// * lncli: `fwdinghistory`
+	// ForwardingHistory allows the caller to query the htlcswitch for a record of
+	// all HTLC's forwarded within the target time range, and integer offset
+	// within that time range. If no time-range is specified, then the first chunk
+	// of the past 24 hrs of forwarding history are returned.
+	//
+	// A list of forwarding events are returned. The size of each forwarding event
+	// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
+	// As a result each message can only contain 50k entries.  Each response has
+	// the index offset of the last entry. The index offset can be provided to the
+	// request to allow the caller to skip a series of records.
+	ForwardingHistory(context.Context, *ForwardingHistoryRequest) (*ForwardingHistoryResponse, error)
+	// * lncli: `exportchanbackup`
+	// ExportChannelBackup attempts to return an encrypted static channel backup
+	// for the target channel identified by its channel point. The backup is
+	// encrypted with a key derived from the wallet's keychain. The returned
+	// backup can later be restored using the RestoreChannelBackups method.
+	ExportChannelBackup(context.Context, *ExportChannelBackupRequest) (*ChannelBackup, error)
+	// *
+	// ExportAllChannelBackups returns static channel backups for all existing
+	// channels known to lnd. A set of regular singular static channel backups for
+	// each channel are returned. Additionally, a multi-channel backup is returned
+	// as well, which contains a single encrypted blob containing the backups of
+	// each channel.
+	ExportAllChannelBackups(context.Context, *ChanBackupExportRequest) (*ChanBackupSnapshot, error)
+	// * lncli: `verifychanbackup`
+	// VerifyChanBackup allows a caller to verify the integrity of a channel
+	// backup snapshot. This method will accept both a set of packed Singles, and
+	// also a packed Multi. If either of the backups present within the snapshot
+	// can't be decrypted and parsed, then an error is returned.
+	VerifyChanBackup(context.Context, *ChanBackupSnapshot) (*VerifyChanBackupResponse, error)
+	// * lncli: `restorechanbackup`
+	// RestoreChannelBackups accepts a set of singular channel backups, or a
+	// single encrypted multi-chan backup and attempts to recover any funds
+	// remaining within the channel. If we are able to unpack the backup, then the
+	// new channel will be shown under listchannels, as well as pending channels.
+	RestoreChannelBackups(context.Context, *RestoreChanBackupRequest) (*RestoreBackupResponse, error)
+	// * lncli: `addholdinvoice`
+	// AddHoldInvoice creates a hold invoice. It ties the invoice to the hash
+	// supplied in the request. Incoming HTLCs paying to the invoice are held
+	// until the invoice is either settled with SettleInvoice, or canceled with
+	// CancelInvoice.
+	AddHoldInvoice(context.Context, *AddHoldInvoiceRequest) (*AddInvoiceResponse, error)
+	// * lncli: `settleinvoice`
+	// SettleInvoice settles an accepted hold invoice using the preimage of its
+	// payment hash. The HTLCs being held for the invoice are settled as a result.
+	SettleInvoice(context.Context, *SettleInvoiceMsg) (*SettleInvoiceResp, error)
+	// * lncli: `cancelinvoice`
+	// CancelInvoice cancels a currently open invoice. If the invoice is already
+	// canceled, this call will succeed. If the invoice is already settled, it
+	// will fail. Any HTLCs being held for the invoice are failed back to the
+	// sender.
+	CancelInvoice(context.Context, *CancelInvoiceMsg) (*CancelInvoiceResp, error)
+	// * lncli: `deletecanceledinvoices`
+	// DeleteCanceledInvoices removes all canceled and expired invoices created
+	// before the given time from the invoice database. The payment hashes of the
+	// removed invoices can be reused by new invoices.
+	DeleteCanceledInvoices(context.Context, *DeleteCanceledInvoicesRequest) (*DeleteCanceledInvoicesResponse, error)
+	// *
+	// ChannelAcceptor dispatches a bi-directional streaming RPC in which each
+	// inbound OpenChannel request is sent to the client, which responds with
+	// whether the channel should be accepted or not. The reason for rejecting a
+	// channel is sent to the remote peer. This allows node operators to enforce
+	// their own criteria for accepting inbound channels through a single
+	// persistent connection. If several clients are connected, a channel is only
+	// accepted if all of them accept it. Requests that aren't responded to in
+	// time are rejected.
+	ChannelAcceptor(Lightning_ChannelAcceptorServer) error
+	// * lncli: `batchopenchannel`
+	// BatchOpenChannel attempts to open multiple singly funded channels with
+	// different remote peers, all funded by a single transaction. If the funding
+	// workflow with any of the peers fails, then none of the channels are opened,
+	// and the funding transaction is never broadcast. The pending channels are
+	// returned once the funding transaction has been broadcast.
+	BatchOpenChannel(context.Context, *BatchOpenChannelRequest) (*BatchOpenChannelResponse, error)
+	// * lncli: `finalizepsbt`
+	// FinalizePsbtFunding resumes the funding flow of a channel opened with
+	// psbt_funding set, once the external wallet has funded and signed the funding
+	// transaction. The funding transaction must contain the funding output
+	// described by the ReadyForPsbtFunding update, and all of its inputs must
+	// spend segwit outputs.
+	FinalizePsbtFunding(context.Context, *FinalizePsbtFundingRequest) (*FinalizePsbtFundingResponse, error)
+	// *
+	// HtlcInterceptor dispatches a bi-directional streaming RPC in which each
+	// forwarded htlc is sent to the client, which decides whether to resume the
+	// forward, to settle it using a preimage, or to fail it back with a chosen
+	// failure. Forwards that aren't resolved in time are resumed. Only a single
+	// client can intercept forwards at a time.
+	HtlcInterceptor(Lightning_HtlcInterceptorServer) error
+	// *
+	// SubscribeHtlcEvents creates a uni-directional stream from the server to
+	// the client which delivers a stream of htlc events as they occur. Events
+	// are sent when htlcs are forwarded, sent, settled, or failed, either by
+	// our node or a node further down the route.
+	SubscribeHtlcEvents(*SubscribeHtlcEventsRequest, Lightning_SubscribeHtlcEventsServer) error
+	// * lncli: `querymc`
+	// QueryMissionControl returns the outcomes of past payment attempts recorded
+	// by mission control, for each pair of nodes that payments were attempted
+	// to be forwarded between. The response can be used to export the history,
+	// for instance to import it into another node.
+	QueryMissionControl(context.Context, *QueryMissionControlRequest) (*QueryMissionControlResponse, error)
+	// * lncli: `importmc`
+	// ImportMissionControl merges the passed node pair results into the history
+	// of mission control. For each pair, results are only applied if they are
+	// more recent than those already known.
+	ImportMissionControl(context.Context, *ImportMissionControlRequest) (*ImportMissionControlResponse, error)
+	// * lncli: `resetmc`
+	// ResetMissionControl clears all outcomes of past payment attempts recorded
+	// by mission control.
+	ResetMissionControl(context.Context, *ResetMissionControlRequest) (*ResetMissionControlResponse, error)
+	// * lncli: `sendtoroute`
+	// SendToRoute sends a payment along the passed routes, rather than along
+	// routes found by the node itself. The routes are attempted in order, until
+	// either the payment succeeds or all routes have failed. The routes may be
+	// obtained using QueryRoutes or BuildRoute.
+	SendToRoute(context.Context, *SendToRouteRequest) (*SendResponse, error)
+	// * lncli: `buildroute`
+	// BuildRoute builds a route that delivers the given amount along the passed
+	// hops, starting at our own node. The fees and time locks of the route are
+	// computed from the channel policies known to the node. The route can be
+	// used to pay along it using SendToRoute.
+	BuildRoute(context.Context, *BuildRouteRequest) (*BuildRouteResponse, error)
+	// * lncli: `rebalance`
+	// Rebalance moves funds from one of our channels to another by paying
+	// ourselves. The payment leaves our node through the outgoing channel, and
+	// returns through the last hop channel, along a route found by the node
+	// that doesn't exceed the fee limit. It settles an invoice generated by the
+	// node for this purpose.
+	Rebalance(context.Context, *RebalanceRequest) (*SendResponse, error)
+}
+
+func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
+	s.RegisterService(&_Lightning_serviceDesc, srv)
+}
+
+func _Lightning_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).WalletBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/WalletBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ChannelBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ChannelBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ChannelBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ChannelBalance(ctx, req.(*ChannelBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SendCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendCoinsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendCoins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendCoins",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendCoins(ctx, req.(*SendCoinsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeTransactions(m, &lightningSubscribeTransactionsServer{stream})
+}
+
+type Lightning_SubscribeTransactionsServer interface {
+	Send(*Transaction) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeTransactionsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).NewAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/NewAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).NewAddress(ctx, req.(*NewAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_NewWitnessAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewWitnessAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).NewWitnessAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/NewWitnessAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).NewWitnessAddress(ctx, req.(*NewWitnessAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SignMessage(ctx, req.(*SignMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_VerifyMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).VerifyMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/VerifyMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).VerifyMessage(ctx, req.(*VerifyMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ConnectPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ConnectPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DisconnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DisconnectPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DisconnectPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DisconnectPeer(ctx, req.(*DisconnectPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListPeers(ctx, req.(*ListPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).PendingChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/PendingChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).PendingChannels(ctx, req.(*PendingChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListChannels(ctx, req.(*ListChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_OpenChannelSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).OpenChannelSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/OpenChannelSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).OpenChannelSync(ctx, req.(*OpenChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_OpenChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OpenChannelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).OpenChannel(m, &lightningOpenChannelServer{stream})
+}
+
+type Lightning_OpenChannelServer interface {
+	Send(*OpenStatusUpdate) error
+	grpc.ServerStream
+}
+
+type lightningOpenChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CloseChannelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
+}
+
+type Lightning_CloseChannelServer interface {
+	Send(*CloseStatusUpdate) error
+	grpc.ServerStream
+}
+
+type lightningCloseChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
+}
+
+type Lightning_SendPaymentServer interface {
+	Send(*SendResponse) error
+	Recv() (*SendRequest, error)
+	grpc.ServerStream
+}
+
+type lightningSendPaymentServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSendPaymentServer) Send(m *SendResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
-	s.RegisterService(&_Lightning_serviceDesc, srv)
+func (x *lightningSendPaymentServer) Recv() (*SendRequest, error) {
+	m := new(SendRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func _Lightning_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WalletBalanceRequest)
+func _Lightning_SendPaymentSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).WalletBalance(ctx, in)
+		return srv.(LightningServer).SendPaymentSync(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/WalletBalance",
+		FullMethod: "/lnrpc.Lightning/SendPaymentSync",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+		return srv.(LightningServer).SendPaymentSync(ctx, req.(*SendRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ChannelBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelBalanceRequest)
+func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Invoice)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ChannelBalance(ctx, in)
+		return srv.(LightningServer).AddInvoice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ChannelBalance",
+		FullMethod: "/lnrpc.Lightning/AddInvoice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ChannelBalance(ctx, req.(*ChannelBalanceRequest))
+		return srv.(LightningServer).AddInvoice(ctx, req.(*Invoice))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionsRequest)
+func _Lightning_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvoiceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetTransactions(ctx, in)
+		return srv.(LightningServer).ListInvoices(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetTransactions",
+		FullMethod: "/lnrpc.Lightning/ListInvoices",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+		return srv.(LightningServer).ListInvoices(ctx, req.(*ListInvoiceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SendCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendCoinsRequest)
+func _Lightning_LookupInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentHash)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SendCoins(ctx, in)
+		return srv.(LightningServer).LookupInvoice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendCoins",
+		FullMethod: "/lnrpc.Lightning/LookupInvoice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendCoins(ctx, req.(*SendCoinsRequest))
+		return srv.(LightningServer).LookupInvoice(ctx, req.(*PaymentHash))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetTransactionsRequest)
+func _Lightning_SubscribeInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InvoiceSubscription)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(LightningServer).SubscribeTransactions(m, &lightningSubscribeTransactionsServer{stream})
+	return srv.(LightningServer).SubscribeInvoices(m, &lightningSubscribeInvoicesServer{stream})
 }
 
-type Lightning_SubscribeTransactionsServer interface {
-	Send(*Transaction) error
+type Lightning_SubscribeInvoicesServer interface {
+	Send(*Invoice) error
 	grpc.ServerStream
 }
 
-type lightningSubscribeTransactionsServer struct {
+type lightningSubscribeInvoicesServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningSubscribeTransactionsServer) Send(m *Transaction) error {
+func (x *lightningSubscribeInvoicesServer) Send(m *Invoice) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendManyRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).SendMany(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendMany",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewAddressRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).NewAddress(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/NewAddress",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).NewAddress(ctx, req.(*NewAddressRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Lightning_NewWitnessAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewWitnessAddressRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).NewWitnessAddress(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/NewWitnessAddress",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).NewWitnessAddress(ctx, req.(*NewWitnessAddressRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Lightning_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SignMessageRequest)
+func _Lightning_DecodePayReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayReqString)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SignMessage(ctx, in)
+		return srv.(LightningServer).DecodePayReq(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SignMessage",
+		FullMethod: "/lnrpc.Lightning/DecodePayReq",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SignMessage(ctx, req.(*SignMessageRequest))
+		return srv.(LightningServer).DecodePayReq(ctx, req.(*PayReqString))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_VerifyMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifyMessageRequest)
+func _Lightning_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).VerifyMessage(ctx, in)
+		return srv.(LightningServer).ListPayments(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/VerifyMessage",
+		FullMethod: "/lnrpc.Lightning/ListPayments",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).VerifyMessage(ctx, req.(*VerifyMessageRequest))
+		return srv.(LightningServer).ListPayments(ctx, req.(*ListPaymentsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConnectPeerRequest)
+func _Lightning_DeleteAllPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAllPaymentsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ConnectPeer(ctx, in)
+		return srv.(LightningServer).DeleteAllPayments(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ConnectPeer",
+		FullMethod: "/lnrpc.Lightning/DeleteAllPayments",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
+		return srv.(LightningServer).DeleteAllPayments(ctx, req.(*DeleteAllPaymentsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_DisconnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DisconnectPeerRequest)
+func _Lightning_DescribeGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelGraphRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).DisconnectPeer(ctx, in)
+		return srv.(LightningServer).DescribeGraph(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DisconnectPeer",
+		FullMethod: "/lnrpc.Lightning/DescribeGraph",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DisconnectPeer(ctx, req.(*DisconnectPeerRequest))
+		return srv.(LightningServer).DescribeGraph(ctx, req.(*ChannelGraphRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPeersRequest)
+func _Lightning_GetChanInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ListPeers(ctx, in)
+		return srv.(LightningServer).GetChanInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListPeers",
+		FullMethod: "/lnrpc.Lightning/GetChanInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListPeers(ctx, req.(*ListPeersRequest))
+		return srv.(LightningServer).GetChanInfo(ctx, req.(*ChanInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInfoRequest)
+func _Lightning_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetInfo(ctx, in)
+		return srv.(LightningServer).GetNodeInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetInfo",
+		FullMethod: "/lnrpc.Lightning/GetNodeInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetInfo(ctx, req.(*GetInfoRequest))
+		return srv.(LightningServer).GetNodeInfo(ctx, req.(*NodeInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PendingChannelsRequest)
+func _Lightning_QueryRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRoutesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).PendingChannels(ctx, in)
+		return srv.(LightningServer).QueryRoutes(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/PendingChannels",
+		FullMethod: "/lnrpc.Lightning/QueryRoutes",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).PendingChannels(ctx, req.(*PendingChannelsRequest))
+		return srv.(LightningServer).QueryRoutes(ctx, req.(*QueryRoutesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListChannelsRequest)
+func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ListChannels(ctx, in)
+		return srv.(LightningServer).GetNetworkInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListChannels",
+		FullMethod: "/lnrpc.Lightning/GetNetworkInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListChannels(ctx, req.(*ListChannelsRequest))
+		return srv.(LightningServer).GetNetworkInfo(ctx, req.(*NetworkInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_OpenChannelSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OpenChannelRequest)
+func _Lightning_StopDaemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).OpenChannelSync(ctx, in)
+		return srv.(LightningServer).StopDaemon(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/OpenChannelSync",
+		FullMethod: "/lnrpc.Lightning/StopDaemon",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).OpenChannelSync(ctx, req.(*OpenChannelRequest))
+		return srv.(LightningServer).StopDaemon(ctx, req.(*StopRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_OpenChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(OpenChannelRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(LightningServer).OpenChannel(m, &lightningOpenChannelServer{stream})
-}
-
-type Lightning_OpenChannelServer interface {
-	Send(*OpenStatusUpdate) error
-	grpc.ServerStream
-}
-
-type lightningOpenChannelServer struct {
-	grpc.ServerStream
-}
-
-func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(CloseChannelRequest)
+func _Lightning_SubscribeChannelGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GraphTopologySubscription)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
-}
-
-type Lightning_CloseChannelServer interface {
-	Send(*CloseStatusUpdate) error
-	grpc.ServerStream
-}
-
-type lightningCloseChannelServer struct {
-	grpc.ServerStream
-}
-
-func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
+	return srv.(LightningServer).SubscribeChannelGraph(m, &lightningSubscribeChannelGraphServer{stream})
 }
-
-type Lightning_SendPaymentServer interface {
-	Send(*SendResponse) error
-	Recv() (*SendRequest, error)
+
+type Lightning_SubscribeChannelGraphServer interface {
+	Send(*GraphTopologyUpdate) error
 	grpc.ServerStream
 }
 
-type lightningSendPaymentServer struct {
+type lightningSubscribeChannelGraphServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningSendPaymentServer) Send(m *SendResponse) error {
+func (x *lightningSubscribeChannelGraphServer) Send(m *GraphTopologyUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *lightningSendPaymentServer) Recv() (*SendRequest, error) {
-	m := new(SendRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _Lightning_DebugLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugLevelRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(LightningServer).DebugLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DebugLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DebugLevel(ctx, req.(*DebugLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SendPaymentSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendRequest)
+func _Lightning_FeeReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeeReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SendPaymentSync(ctx, in)
+		return srv.(LightningServer).FeeReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendPaymentSync",
+		FullMethod: "/lnrpc.Lightning/FeeReport",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendPaymentSync(ctx, req.(*SendRequest))
+		return srv.(LightningServer).FeeReport(ctx, req.(*FeeReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Invoice)
+func _Lightning_UpdateChannelPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyUpdateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).AddInvoice(ctx, in)
+		return srv.(LightningServer).UpdateChannelPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/AddInvoice",
+		FullMethod: "/lnrpc.Lightning/UpdateChannelPolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).AddInvoice(ctx, req.(*Invoice))
+		return srv.(LightningServer).UpdateChannelPolicy(ctx, req.(*PolicyUpdateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListInvoiceRequest)
+func _Lightning_ForwardingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForwardingHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ListInvoices(ctx, in)
+		return srv.(LightningServer).ForwardingHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListInvoices",
+		FullMethod: "/lnrpc.Lightning/ForwardingHistory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListInvoices(ctx, req.(*ListInvoiceRequest))
+		return srv.(LightningServer).ForwardingHistory(ctx, req.(*ForwardingHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_LookupInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PaymentHash)
+func _Lightning_ExportChannelBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportChannelBackupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).LookupInvoice(ctx, in)
+		return srv.(LightningServer).ExportChannelBackup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/LookupInvoice",
+		FullMethod: "/lnrpc.Lightning/ExportChannelBackup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).LookupInvoice(ctx, req.(*PaymentHash))
+		return srv.(LightningServer).ExportChannelBackup(ctx, req.(*ExportChannelBackupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SubscribeInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(InvoiceSubscription)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(LightningServer).SubscribeInvoices(m, &lightningSubscribeInvoicesServer{stream})
-}
-
-type Lightning_SubscribeInvoicesServer interface {
-	Send(*Invoice) error
-	grpc.ServerStream
-}
-
-type lightningSubscribeInvoicesServer struct {
-	grpc.ServerStream
-}
-
-func (x *lightningSubscribeInvoicesServer) Send(m *Invoice) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _Lightning_DecodePayReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PayReqString)
+func _Lightning_ExportAllChannelBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanBackupExportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).DecodePayReq(ctx, in)
+		return srv.(LightningServer).ExportAllChannelBackups(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DecodePayReq",
+		FullMethod: "/lnrpc.Lightning/ExportAllChannelBackups",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DecodePayReq(ctx, req.(*PayReqString))
+		return srv.(LightningServer).ExportAllChannelBackups(ctx, req.(*ChanBackupExportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPaymentsRequest)
+func _Lightning_VerifyChanBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanBackupSnapshot)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ListPayments(ctx, in)
+		return srv.(LightningServer).VerifyChanBackup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListPayments",
+		FullMethod: "/lnrpc.Lightning/VerifyChanBackup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListPayments(ctx, req.(*ListPaymentsRequest))
+		return srv.(LightningServer).VerifyChanBackup(ctx, req.(*ChanBackupSnapshot))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_DeleteAllPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteAllPaymentsRequest)
+func _Lightning_RestoreChannelBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreChanBackupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).DeleteAllPayments(ctx, in)
+		return srv.(LightningServer).RestoreChannelBackups(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DeleteAllPayments",
+		FullMethod: "/lnrpc.Lightning/RestoreChannelBackups",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DeleteAllPayments(ctx, req.(*DeleteAllPaymentsRequest))
+		return srv.(LightningServer).RestoreChannelBackups(ctx, req.(*RestoreChanBackupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_DescribeGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelGraphRequest)
+func _Lightning_AddHoldInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddHoldInvoiceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).DescribeGraph(ctx, in)
+		return srv.(LightningServer).AddHoldInvoice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DescribeGraph",
+		FullMethod: "/lnrpc.Lightning/AddHoldInvoice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DescribeGraph(ctx, req.(*ChannelGraphRequest))
+		return srv.(LightningServer).AddHoldInvoice(ctx, req.(*AddHoldInvoiceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetChanInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChanInfoRequest)
+func _Lightning_SettleInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettleInvoiceMsg)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetChanInfo(ctx, in)
+		return srv.(LightningServer).SettleInvoice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetChanInfo",
+		FullMethod: "/lnrpc.Lightning/SettleInvoice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetChanInfo(ctx, req.(*ChanInfoRequest))
+		return srv.(LightningServer).SettleInvoice(ctx, req.(*SettleInvoiceMsg))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NodeInfoRequest)
+func _Lightning_CancelInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelInvoiceMsg)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetNodeInfo(ctx, in)
+		return srv.(LightningServer).CancelInvoice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetNodeInfo",
+		FullMethod: "/lnrpc.Lightning/CancelInvoice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetNodeInfo(ctx, req.(*NodeInfoRequest))
+		return srv.(LightningServer).CancelInvoice(ctx, req.(*CancelInvoiceMsg))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_QueryRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryRoutesRequest)
+func _Lightning_DeleteCanceledInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCanceledInvoicesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).QueryRoutes(ctx, in)
+		return srv.(LightningServer).DeleteCanceledInvoices(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/QueryRoutes",
+		FullMethod: "/lnrpc.Lightning/DeleteCanceledInvoices",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).QueryRoutes(ctx, req.(*QueryRoutesRequest))
+		return srv.(LightningServer).DeleteCanceledInvoices(ctx, req.(*DeleteCanceledInvoicesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NetworkInfoRequest)
+func _Lightning_ChannelAcceptor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).ChannelAcceptor(&lightningChannelAcceptorServer{stream})
+}
+
+type Lightning_ChannelAcceptorServer interface {
+	Send(*ChannelAcceptRequest) error
+	Recv() (*ChannelAcceptResponse, error)
+	grpc.ServerStream
+}
+
+type lightningChannelAcceptorServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningChannelAcceptorServer) Send(m *ChannelAcceptRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningChannelAcceptorServer) Recv() (*ChannelAcceptResponse, error) {
+	m := new(ChannelAcceptResponse)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Lightning_BatchOpenChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchOpenChannelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetNetworkInfo(ctx, in)
+		return srv.(LightningServer).BatchOpenChannel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetNetworkInfo",
+		FullMethod: "/lnrpc.Lightning/BatchOpenChannel",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetNetworkInfo(ctx, req.(*NetworkInfoRequest))
+		return srv.(LightningServer).BatchOpenChannel(ctx, req.(*BatchOpenChannelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_StopDaemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StopRequest)
+func _Lightning_FinalizePsbtFunding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizePsbtFundingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).StopDaemon(ctx, in)
+		return srv.(LightningServer).FinalizePsbtFunding(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/StopDaemon",
+		FullMethod: "/lnrpc.Lightning/FinalizePsbtFunding",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).StopDaemon(ctx, req.(*StopRequest))
+		return srv.(LightningServer).FinalizePsbtFunding(ctx, req.(*FinalizePsbtFundingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SubscribeChannelGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GraphTopologySubscription)
+func _Lightning_HtlcInterceptor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).HtlcInterceptor(&lightningHtlcInterceptorServer{stream})
+}
+
+type Lightning_HtlcInterceptorServer interface {
+	Send(*ForwardHtlcInterceptRequest) error
+	Recv() (*ForwardHtlcInterceptResponse, error)
+	grpc.ServerStream
+}
+
+type lightningHtlcInterceptorServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningHtlcInterceptorServer) Send(m *ForwardHtlcInterceptRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningHtlcInterceptorServer) Recv() (*ForwardHtlcInterceptResponse, error) {
+	m := new(ForwardHtlcInterceptResponse)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Lightning_SubscribeHtlcEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeHtlcEventsRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(LightningServer).SubscribeChannelGraph(m, &lightningSubscribeChannelGraphServer{stream})
+	return srv.(LightningServer).SubscribeHtlcEvents(m, &lightningSubscribeHtlcEventsServer{stream})
 }
 
-type Lightning_SubscribeChannelGraphServer interface {
-	Send(*GraphTopologyUpdate) error
+type Lightning_SubscribeHtlcEventsServer interface {
+	Send(*HtlcEvent) error
 	grpc.ServerStream
 }
 
-type lightningSubscribeChannelGraphServer struct {
+type lightningSubscribeHtlcEventsServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningSubscribeChannelGraphServer) Send(m *GraphTopologyUpdate) error {
+func (x *lightningSubscribeHtlcEventsServer) Send(m *HtlcEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_DebugLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DebugLevelRequest)
+func _Lightning_QueryMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMissionControlRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).DebugLevel(ctx, in)
+		return srv.(LightningServer).QueryMissionControl(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DebugLevel",
+		FullMethod: "/lnrpc.Lightning/QueryMissionControl",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DebugLevel(ctx, req.(*DebugLevelRequest))
+		return srv.(LightningServer).QueryMissionControl(ctx, req.(*QueryMissionControlRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_FeeReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FeeReportRequest)
+func _Lightning_ImportMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportMissionControlRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).FeeReport(ctx, in)
+		return srv.(LightningServer).ImportMissionControl(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/FeeReport",
+		FullMethod: "/lnrpc.Lightning/ImportMissionControl",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).FeeReport(ctx, req.(*FeeReportRequest))
+		return srv.(LightningServer).ImportMissionControl(ctx, req.(*ImportMissionControlRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_UpdateChannelPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PolicyUpdateRequest)
+func _Lightning_ResetMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetMissionControlRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).UpdateChannelPolicy(ctx, in)
+		return srv.(LightningServer).ResetMissionControl(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/UpdateChannelPolicy",
+		FullMethod: "/lnrpc.Lightning/ResetMissionControl",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).UpdateChannelPolicy(ctx, req.(*PolicyUpdateRequest))
+		return srv.(LightningServer).ResetMissionControl(ctx, req.(*ResetMissionControlRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ForwardingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ForwardingHistoryRequest)
+func _Lightning_SendToRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendToRouteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ForwardingHistory(ctx, in)
+		return srv.(LightningServer).SendToRoute(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ForwardingHistory",
+		FullMethod: "/lnrpc.Lightning/SendToRoute",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ForwardingHistory(ctx, req.(*ForwardingHistoryRequest))
+		return srv.(LightningServer).SendToRoute(ctx, req.(*SendToRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_BuildRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BuildRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BuildRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BuildRoute(ctx, req.(*BuildRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_Rebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).Rebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/Rebalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).Rebalance(ctx, req.(*RebalanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -6109,6 +8896,70 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ForwardingHistory",
 			Handler:    _Lightning_ForwardingHistory_Handler,
 		},
+		{
+			MethodName: "ExportChannelBackup",
+			Handler:    _Lightning_ExportChannelBackup_Handler,
+		},
+		{
+			MethodName: "ExportAllChannelBackups",
+			Handler:    _Lightning_ExportAllChannelBackups_Handler,
+		},
+		{
+			MethodName: "VerifyChanBackup",
+			Handler:    _Lightning_VerifyChanBackup_Handler,
+		},
+		{
+			MethodName: "RestoreChannelBackups",
+			Handler:    _Lightning_RestoreChannelBackups_Handler,
+		},
+		{
+			MethodName: "AddHoldInvoice",
+			Handler:    _Lightning_AddHoldInvoice_Handler,
+		},
+		{
+			MethodName: "SettleInvoice",
+			Handler:    _Lightning_SettleInvoice_Handler,
+		},
+		{
+			MethodName: "CancelInvoice",
+			Handler:    _Lightning_CancelInvoice_Handler,
+		},
+		{
+			MethodName: "DeleteCanceledInvoices",
+			Handler:    _Lightning_DeleteCanceledInvoices_Handler,
+		},
+		{
+			MethodName: "BatchOpenChannel",
+			Handler:    _Lightning_BatchOpenChannel_Handler,
+		},
+		{
+			MethodName: "FinalizePsbtFunding",
+			Handler:    _Lightning_FinalizePsbtFunding_Handler,
+		},
+		{
+			MethodName: "QueryMissionControl",
+			Handler:    _Lightning_QueryMissionControl_Handler,
+		},
+		{
+			MethodName: "ImportMissionControl",
+			Handler:    _Lightning_ImportMissionControl_Handler,
+		},
+		{
+			MethodName: "ResetMissionControl",
+			Handler:    _Lightning_ResetMissionControl_Handler,
+		},
+		{
+			MethodName: "SendToRoute",
+			Handler:    _Lightning_SendToRoute_Handler,
+		},
+		{
+			MethodName: "BuildRoute",
+			Handler:    _Lightning_BuildRoute_Handler,
+		},
+		{
+			MethodName: "Rebalance",
+			Handler:    _Lightning_Rebalance_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -6142,6 +8993,23 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Lightning_SubscribeChannelGraph_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ChannelAcceptor",
+			Handler:       _Lightning_ChannelAcceptor_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "HtlcInterceptor",
+			Handler:       _Lightning_HtlcInterceptor_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeHtlcEvents",
+			Handler:       _Lightning_SubscribeHtlcEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc.proto",
 }
@@ -6149,341 +9017,496 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 5373 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5c, 0x4d, 0x90, 0x1c, 0xd9,
-	0x51, 0x56, 0xf5, 0xf4, 0xfc, 0x74, 0x76, 0xcf, 0xdf, 0x9b, 0xd1, 0xa8, 0xd5, 0xab, 0xd5, 0x6a,
-	0xcb, 0x1b, 0x2b, 0x21, 0x16, 0x8d, 0x76, 0x6c, 0x2f, 0xcb, 0x0a, 0xd6, 0xa1, 0xff, 0x59, 0x5b,
-	0x2b, 0x8f, 0x6b, 0x24, 0x2f, 0x78, 0x81, 0x76, 0x4d, 0xf7, 0x9b, 0x9e, 0xb2, 0xba, 0xab, 0x6a,
-	0xab, 0x5e, 0xcf, 0xa8, 0x77, 0x51, 0x04, 0x3f, 0x11, 0x9c, 0x70, 0xf8, 0x00, 0x11, 0x84, 0x21,
-	0x1c, 0x44, 0xd8, 0x17, 0x38, 0x70, 0xe4, 0x64, 0x02, 0xee, 0x8e, 0x20, 0x38, 0xf8, 0x44, 0x70,
-	0xe3, 0xe7, 0x02, 0x67, 0x2e, 0x1c, 0x08, 0x22, 0xf3, 0xfd, 0xd4, 0x7b, 0x55, 0x35, 0x92, 0x6c,
-	0x83, 0x6f, 0xf3, 0xbe, 0x97, 0x95, 0xef, 0x2f, 0x33, 0x5f, 0x66, 0xbe, 0xec, 0x81, 0x56, 0x96,
-	0x0e, 0xae, 0xa5, 0x59, 0x22, 0x12, 0x36, 0x3f, 0x8e, 0xb3, 0x74, 0xd0, 0xbb, 0x30, 0x4a, 0x92,
-	0xd1, 0x98, 0x6f, 0x87, 0x69, 0xb4, 0x1d, 0xc6, 0x71, 0x22, 0x42, 0x11, 0x25, 0x71, 0x2e, 0x89,
-	0xfc, 0x6f, 0xc2, 0xca, 0x7d, 0x1e, 0xef, 0x73, 0x3e, 0x0c, 0xf8, 0x27, 0x53, 0x9e, 0x0b, 0xf6,
-	0x8b, 0xb0, 0x1e, 0xf2, 0x4f, 0x39, 0x1f, 0xf6, 0xd3, 0x30, 0xcf, 0xd3, 0xa3, 0x2c, 0xcc, 0x79,
-	0xd7, 0xbb, 0xe4, 0x5d, 0xe9, 0x04, 0x6b, 0xb2, 0x63, 0xcf, 0xe0, 0xec, 0x75, 0xe8, 0xe4, 0x48,
-	0xca, 0x63, 0x91, 0x25, 0xe9, 0xac, 0xdb, 0x20, 0xba, 0x36, 0x62, 0x77, 0x25, 0xe4, 0x8f, 0x61,
-	0xd5, 0x8c, 0x90, 0xa7, 0x49, 0x9c, 0x73, 0x76, 0x1d, 0x36, 0x07, 0x51, 0x7a, 0xc4, 0xb3, 0x3e,
-	0x7d, 0x3c, 0x89, 0xf9, 0x24, 0x89, 0xa3, 0x41, 0xd7, 0xbb, 0x34, 0x77, 0xa5, 0x15, 0x30, 0xd9,
-	0x87, 0x5f, 0x7c, 0xa8, 0x7a, 0xd8, 0x65, 0x58, 0xe5, 0xb1, 0xc4, 0xf9, 0x90, 0xbe, 0x52, 0x43,
-	0xad, 0x14, 0x30, 0x7e, 0xe0, 0xff, 0xb9, 0x07, 0xeb, 0x1f, 0xc4, 0x91, 0xf8, 0x28, 0x1c, 0x8f,
-	0xb9, 0xd0, 0x6b, 0xba, 0x0c, 0xab, 0x27, 0x04, 0xd0, 0x9a, 0x4e, 0x92, 0x6c, 0xa8, 0x56, 0xb4,
-	0x22, 0xe1, 0x3d, 0x85, 0x9e, 0x3a, 0xb3, 0xc6, 0xa9, 0x33, 0xab, 0xdd, 0xae, 0xb9, 0xfa, 0xed,
-	0xf2, 0x37, 0x81, 0xd9, 0x93, 0x93, 0xdb, 0xe1, 0xbf, 0x0f, 0x1b, 0x8f, 0xe3, 0x71, 0x32, 0x78,
-	0xf2, 0xd3, 0x4d, 0xda, 0xdf, 0x82, 0x4d, 0xf7, 0x7b, 0xc5, 0xf7, 0xbb, 0x0d, 0x68, 0x3f, 0xca,
-	0xc2, 0x38, 0x0f, 0x07, 0x78, 0xe4, 0xac, 0x0b, 0x8b, 0xe2, 0x69, 0xff, 0x28, 0xcc, 0x8f, 0x88,
-	0x51, 0x2b, 0xd0, 0x4d, 0xb6, 0x05, 0x0b, 0xe1, 0x24, 0x99, 0xc6, 0x82, 0x76, 0x75, 0x2e, 0x50,
-	0x2d, 0xf6, 0x16, 0xac, 0xc7, 0xd3, 0x49, 0x7f, 0x90, 0xc4, 0x87, 0x51, 0x36, 0x91, 0x82, 0x43,
-	0x8b, 0x9b, 0x0f, 0xaa, 0x1d, 0xec, 0x22, 0xc0, 0x01, 0x4e, 0x43, 0x0e, 0xd1, 0xa4, 0x21, 0x2c,
-	0x84, 0xf9, 0xd0, 0x51, 0x2d, 0x1e, 0x8d, 0x8e, 0x44, 0x77, 0x9e, 0x18, 0x39, 0x18, 0xf2, 0x10,
-	0xd1, 0x84, 0xf7, 0x73, 0x11, 0x4e, 0xd2, 0xee, 0x02, 0xcd, 0xc6, 0x42, 0xa8, 0x3f, 0x11, 0xe1,
-	0xb8, 0x7f, 0xc8, 0x79, 0xde, 0x5d, 0x54, 0xfd, 0x06, 0x61, 0x6f, 0xc2, 0xca, 0x90, 0xe7, 0xa2,
-	0x1f, 0x0e, 0x87, 0x19, 0xcf, 0x73, 0x9e, 0x77, 0x97, 0xe8, 0xe8, 0x4a, 0xa8, 0xdf, 0x85, 0xad,
-	0xfb, 0x5c, 0x58, 0xbb, 0x93, 0xab, 0x6d, 0xf7, 0x1f, 0x00, 0xb3, 0xe0, 0x3b, 0x5c, 0x84, 0xd1,
-	0x38, 0x67, 0xef, 0x40, 0x47, 0x58, 0xc4, 0x24, 0xaa, 0xed, 0x1d, 0x76, 0x8d, 0x74, 0xec, 0x9a,
-	0xf5, 0x41, 0xe0, 0xd0, 0xf9, 0xff, 0xed, 0x41, 0x7b, 0x9f, 0xc7, 0x46, 0xbb, 0x18, 0x34, 0x71,
-	0x26, 0xea, 0x24, 0xe9, 0x6f, 0xf6, 0x1a, 0xb4, 0x69, 0x76, 0xb9, 0xc8, 0xa2, 0x78, 0x44, 0x47,
-	0xd0, 0x0a, 0x00, 0xa1, 0x7d, 0x42, 0xd8, 0x1a, 0xcc, 0x85, 0x13, 0x41, 0x1b, 0x3f, 0x17, 0xe0,
-	0x9f, 0xa8, 0x77, 0x69, 0x38, 0x9b, 0xf0, 0x58, 0x14, 0x9b, 0xdd, 0x09, 0xda, 0x0a, 0xdb, 0xc5,
-	0xdd, 0xbe, 0x06, 0x1b, 0x36, 0x89, 0xe6, 0x3e, 0x4f, 0xdc, 0xd7, 0x2d, 0x4a, 0x35, 0xc8, 0x65,
-	0x58, 0xd5, 0xf4, 0x99, 0x9c, 0x2c, 0x6d, 0x7f, 0x2b, 0x58, 0x51, 0xb0, 0x5e, 0xc2, 0x15, 0x58,
-	0x3b, 0x8c, 0xe2, 0x70, 0xdc, 0x1f, 0x8c, 0xc5, 0x71, 0x7f, 0xc8, 0xc7, 0x22, 0xa4, 0x83, 0x98,
-	0x0f, 0x56, 0x08, 0xbf, 0x3d, 0x16, 0xc7, 0x77, 0x10, 0xf5, 0xff, 0xc4, 0x83, 0x8e, 0x5c, 0xbc,
-	0x52, 0xfc, 0x37, 0x60, 0x59, 0x8f, 0xc1, 0xb3, 0x2c, 0xc9, 0x94, 0x1c, 0xba, 0x20, 0xbb, 0x0a,
-	0x6b, 0x1a, 0x48, 0x33, 0x1e, 0x4d, 0xc2, 0x11, 0x57, 0xda, 0x5e, 0xc1, 0xd9, 0x4e, 0xc1, 0x31,
-	0x4b, 0xa6, 0x42, 0xaa, 0x5e, 0x7b, 0xa7, 0xa3, 0x0e, 0x26, 0x40, 0x2c, 0x70, 0x49, 0xfc, 0xef,
-	0x7b, 0xd0, 0xb9, 0x7d, 0x14, 0xc6, 0x31, 0x1f, 0xef, 0x25, 0x51, 0x2c, 0xd8, 0x75, 0x60, 0x87,
-	0xd3, 0x78, 0x18, 0xc5, 0xa3, 0xbe, 0x78, 0x1a, 0x0d, 0xfb, 0x07, 0x33, 0xc1, 0x73, 0x79, 0x44,
-	0xbb, 0x67, 0x82, 0x9a, 0x3e, 0xf6, 0x16, 0xac, 0x39, 0x68, 0x2e, 0x32, 0x79, 0x6e, 0xbb, 0x67,
-	0x82, 0x4a, 0x0f, 0x0a, 0x7e, 0x32, 0x15, 0xe9, 0x54, 0xf4, 0xa3, 0x78, 0xc8, 0x9f, 0xd2, 0x1c,
-	0x97, 0x03, 0x07, 0xbb, 0xb5, 0x02, 0x1d, 0xfb, 0x3b, 0xff, 0x7d, 0x58, 0x7b, 0x80, 0x1a, 0x11,
-	0x47, 0xf1, 0xe8, 0xa6, 0x14, 0x5b, 0x54, 0xd3, 0x74, 0x7a, 0xf0, 0x84, 0xcf, 0xd4, 0xbe, 0xa9,
-	0x16, 0x0a, 0xd5, 0x51, 0x92, 0x0b, 0x25, 0x39, 0xf4, 0xb7, 0xff, 0xaf, 0x1e, 0xac, 0xe2, 0xde,
-	0x7f, 0x18, 0xc6, 0x33, 0x7d, 0x72, 0x0f, 0xa0, 0x83, 0xac, 0x1e, 0x25, 0x37, 0xa5, 0xb2, 0x4b,
-	0x21, 0xbe, 0xa2, 0xf6, 0xaa, 0x44, 0x7d, 0xcd, 0x26, 0x45, 0x63, 0x3e, 0x0b, 0x9c, 0xaf, 0x51,
-	0x6c, 0x45, 0x98, 0x8d, 0xb8, 0x20, 0x33, 0xa0, 0xcc, 0x02, 0x48, 0xe8, 0x76, 0x12, 0x1f, 0xb2,
-	0x4b, 0xd0, 0xc9, 0x43, 0xd1, 0x4f, 0x79, 0x46, 0xbb, 0x46, 0xa2, 0x37, 0x17, 0x40, 0x1e, 0x8a,
-	0x3d, 0x9e, 0xdd, 0x9a, 0x09, 0xde, 0xfb, 0x12, 0xac, 0x57, 0x46, 0x41, 0x69, 0x2f, 0x96, 0x88,
-	0x7f, 0xb2, 0x4d, 0x98, 0x3f, 0x0e, 0xc7, 0x53, 0xae, 0xac, 0x93, 0x6c, 0xbc, 0xd7, 0x78, 0xd7,
-	0xf3, 0xdf, 0x84, 0xb5, 0x62, 0xda, 0x4a, 0xc8, 0x18, 0x34, 0x71, 0x07, 0x15, 0x03, 0xfa, 0xdb,
-	0xff, 0x3d, 0x4f, 0x12, 0xde, 0x4e, 0x22, 0xa3, 0xe9, 0x48, 0x88, 0x06, 0x41, 0x13, 0xe2, 0xdf,
-	0xa7, 0x5a, 0xc2, 0x9f, 0x7d, 0xb1, 0xfe, 0x65, 0x58, 0xb7, 0xa6, 0xf0, 0x9c, 0xc9, 0x7e, 0xdb,
-	0x83, 0xf5, 0x87, 0xfc, 0x44, 0x9d, 0xba, 0x9e, 0xed, 0xbb, 0xd0, 0x14, 0xb3, 0x54, 0x5e, 0xc5,
-	0x2b, 0x3b, 0x6f, 0xa8, 0x43, 0xab, 0xd0, 0x5d, 0x53, 0xcd, 0x47, 0xb3, 0x94, 0x07, 0xf4, 0x85,
-	0xff, 0x3e, 0xb4, 0x2d, 0x90, 0x9d, 0x83, 0x8d, 0x8f, 0x3e, 0x78, 0xf4, 0xf0, 0xee, 0xfe, 0x7e,
-	0x7f, 0xef, 0xf1, 0xad, 0xaf, 0xdc, 0xfd, 0x8d, 0xfe, 0xee, 0xcd, 0xfd, 0xdd, 0xb5, 0x33, 0x6c,
-	0x0b, 0xd8, 0xc3, 0xbb, 0xfb, 0x8f, 0xee, 0xde, 0x71, 0x70, 0xcf, 0xef, 0x41, 0xf7, 0x21, 0x3f,
-	0xf9, 0x28, 0x12, 0x31, 0xcf, 0x73, 0x77, 0x34, 0xff, 0x1a, 0x30, 0x7b, 0x0a, 0x6a, 0x55, 0x5d,
-	0x58, 0x54, 0xa6, 0x56, 0xdf, 0x34, 0xaa, 0xe9, 0xbf, 0x09, 0x6c, 0x3f, 0x1a, 0xc5, 0x1f, 0xf2,
-	0x3c, 0x0f, 0x47, 0x5c, 0xaf, 0x6d, 0x0d, 0xe6, 0x26, 0xf9, 0x48, 0x19, 0x45, 0xfc, 0xd3, 0xff,
-	0x3c, 0x6c, 0x38, 0x74, 0x8a, 0xf1, 0x05, 0x68, 0xe5, 0xd1, 0x28, 0x0e, 0xc5, 0x34, 0xe3, 0x8a,
-	0x75, 0x01, 0xf8, 0xf7, 0x60, 0xf3, 0xeb, 0x3c, 0x8b, 0x0e, 0x67, 0x2f, 0x62, 0xef, 0xf2, 0x69,
-	0x94, 0xf9, 0xdc, 0x85, 0xb3, 0x25, 0x3e, 0x6a, 0x78, 0x29, 0x88, 0xea, 0xb8, 0x96, 0x02, 0xd9,
-	0xb0, 0xd4, 0xb2, 0x61, 0xab, 0xa5, 0xff, 0x18, 0xd8, 0xed, 0x24, 0x8e, 0xf9, 0x40, 0xec, 0x71,
-	0x9e, 0x15, 0xfe, 0x55, 0x21, 0x75, 0xed, 0x9d, 0x73, 0xea, 0x1c, 0xcb, 0xba, 0xae, 0xc4, 0x91,
-	0x41, 0x33, 0xe5, 0xd9, 0x84, 0x18, 0x2f, 0x05, 0xf4, 0xb7, 0x7f, 0x16, 0x36, 0x1c, 0xb6, 0xea,
-	0xb6, 0x7f, 0x1b, 0xce, 0xde, 0x89, 0xf2, 0x41, 0x75, 0xc0, 0x2e, 0x2c, 0xa6, 0xd3, 0x83, 0x7e,
-	0xa1, 0x53, 0xba, 0x89, 0x97, 0x60, 0xf9, 0x13, 0xc5, 0xec, 0x0f, 0x3d, 0x68, 0xee, 0x3e, 0x7a,
-	0x70, 0x9b, 0xf5, 0x60, 0x29, 0x8a, 0x07, 0xc9, 0x04, 0xaf, 0x0e, 0xb9, 0x68, 0xd3, 0x3e, 0x55,
-	0x57, 0x2e, 0x40, 0x8b, 0x6e, 0x1c, 0xbc, 0xd7, 0x95, 0x2b, 0x54, 0x00, 0xe8, 0x53, 0xf0, 0xa7,
-	0x69, 0x94, 0x91, 0xd3, 0xa0, 0x5d, 0x81, 0x26, 0x59, 0xc4, 0x6a, 0x87, 0xff, 0x3f, 0x4d, 0x58,
-	0x54, 0xb6, 0x9a, 0xc6, 0x1b, 0x88, 0xe8, 0x98, 0xab, 0x99, 0xa8, 0x16, 0xde, 0x2a, 0x19, 0x9f,
-	0x24, 0x82, 0xf7, 0x9d, 0x63, 0x70, 0x41, 0xa4, 0x1a, 0x48, 0x46, 0xfd, 0x14, 0xad, 0x3e, 0xcd,
-	0xac, 0x15, 0xb8, 0x20, 0x6e, 0x16, 0x02, 0xfd, 0x68, 0x48, 0x73, 0x6a, 0x06, 0xba, 0x89, 0x3b,
-	0x31, 0x08, 0xd3, 0x70, 0x10, 0x89, 0x99, 0x52, 0x6e, 0xd3, 0x46, 0xde, 0xe3, 0x64, 0x10, 0x8e,
-	0xfb, 0x07, 0xe1, 0x38, 0x8c, 0x07, 0x5c, 0x39, 0x2e, 0x2e, 0x88, 0xbe, 0x89, 0x9a, 0x92, 0x26,
-	0x93, 0xfe, 0x4b, 0x09, 0x45, 0x1f, 0x67, 0x90, 0x4c, 0x26, 0x91, 0x40, 0x97, 0xa6, 0xbb, 0x24,
-	0x0d, 0x49, 0x81, 0xd0, 0x4a, 0x64, 0xeb, 0x44, 0xee, 0x5e, 0x4b, 0x8e, 0xe6, 0x80, 0xc8, 0xe5,
-	0x90, 0x73, 0x32, 0x48, 0x4f, 0x4e, 0xba, 0x20, 0xb9, 0x14, 0x08, 0x9e, 0xc3, 0x34, 0xce, 0xb9,
-	0x10, 0x63, 0x3e, 0x34, 0x13, 0x6a, 0x13, 0x59, 0xb5, 0x83, 0x5d, 0x87, 0x0d, 0xe9, 0x65, 0xe5,
-	0xa1, 0x48, 0xf2, 0xa3, 0x28, 0xef, 0xe7, 0x3c, 0x16, 0xdd, 0x0e, 0xd1, 0xd7, 0x75, 0xb1, 0x77,
-	0xe1, 0x5c, 0x09, 0xce, 0xf8, 0x80, 0x47, 0xc7, 0x7c, 0xd8, 0x5d, 0xa6, 0xaf, 0x4e, 0xeb, 0x66,
-	0x97, 0xa0, 0x8d, 0xce, 0xe5, 0x34, 0x1d, 0x86, 0x78, 0x0f, 0xaf, 0xd0, 0x39, 0xd8, 0x10, 0x7b,
-	0x1b, 0x96, 0x53, 0x2e, 0x2f, 0xcb, 0x23, 0x31, 0x1e, 0xe4, 0xdd, 0x55, 0xba, 0xc9, 0xda, 0x4a,
-	0x99, 0x50, 0x72, 0x03, 0x97, 0x02, 0x85, 0x72, 0x90, 0x93, 0xbb, 0x12, 0xce, 0xba, 0x6b, 0x24,
-	0x6e, 0x05, 0x40, 0x3a, 0x92, 0x45, 0xc7, 0xa1, 0xe0, 0xdd, 0x75, 0x92, 0x2d, 0xdd, 0xf4, 0xff,
-	0xc2, 0x83, 0x8d, 0x07, 0x51, 0x2e, 0x94, 0x10, 0x1a, 0x73, 0xfc, 0x1a, 0xb4, 0xa5, 0xf8, 0xf5,
-	0x93, 0x78, 0x3c, 0x53, 0x12, 0x09, 0x12, 0xfa, 0x6a, 0x3c, 0x9e, 0xb1, 0xcf, 0xc1, 0x72, 0x14,
-	0xdb, 0x24, 0x52, 0x87, 0x3b, 0x1a, 0x24, 0xa2, 0xd7, 0xa0, 0x9d, 0x4e, 0x0f, 0xc6, 0xd1, 0x40,
-	0x92, 0xcc, 0x49, 0x2e, 0x12, 0x22, 0x02, 0x74, 0xf4, 0xe4, 0x4c, 0x24, 0x45, 0x93, 0x28, 0xda,
-	0x0a, 0x43, 0x12, 0xff, 0x16, 0x6c, 0xba, 0x13, 0x54, 0xc6, 0xea, 0x2a, 0x2c, 0x29, 0xd9, 0xce,
-	0xbb, 0x6d, 0xda, 0x9f, 0x15, 0xb5, 0x3f, 0x8a, 0x34, 0x30, 0xfd, 0xfe, 0x7f, 0x78, 0xd0, 0x44,
-	0x03, 0x70, 0xba, 0xb1, 0xb0, 0x6d, 0xfa, 0x9c, 0x63, 0xd3, 0xc9, 0xef, 0x47, 0xaf, 0x48, 0x8a,
-	0x84, 0x54, 0x1b, 0x0b, 0x29, 0xfa, 0x33, 0x3e, 0x38, 0x26, 0xdd, 0x31, 0xfd, 0x88, 0xa0, 0x66,
-	0xe1, 0xd5, 0x49, 0x5f, 0x4b, 0xc5, 0x31, 0x6d, 0xdd, 0x47, 0x5f, 0x2e, 0x16, 0x7d, 0xf4, 0x5d,
-	0x17, 0x16, 0xa3, 0xf8, 0x20, 0x99, 0xc6, 0x43, 0x52, 0x92, 0xa5, 0x40, 0x37, 0xf1, 0xb0, 0x53,
-	0xf2, 0xa4, 0xa2, 0x09, 0x57, 0xda, 0x51, 0x00, 0x3e, 0x43, 0xd7, 0x2a, 0x27, 0x83, 0x67, 0xee,
-	0xb1, 0x77, 0x60, 0xdd, 0xc2, 0xd4, 0x0e, 0xbe, 0x0e, 0xf3, 0x29, 0x02, 0xca, 0x51, 0xd2, 0xe2,
-	0x45, 0x96, 0x52, 0xf6, 0xf8, 0x6b, 0x18, 0x3f, 0x8b, 0x0f, 0xe2, 0xc3, 0x44, 0x73, 0xfa, 0xfb,
-	0x39, 0x0c, 0x78, 0x15, 0xa4, 0x18, 0x5d, 0x81, 0xd5, 0x68, 0xc8, 0x63, 0x11, 0x89, 0x59, 0xdf,
-	0xf1, 0xe0, 0xca, 0x30, 0xde, 0x30, 0xe1, 0x38, 0x0a, 0x73, 0x65, 0xc3, 0x64, 0x83, 0xed, 0xc0,
-	0x26, 0x8a, 0xbf, 0x96, 0x68, 0x73, 0xac, 0xd2, 0x91, 0xac, 0xed, 0x43, 0x8d, 0x45, 0x5c, 0x49,
-	0xa0, 0xf9, 0x44, 0x5a, 0xda, 0xba, 0x2e, 0xdc, 0x35, 0xc9, 0x09, 0x97, 0x3c, 0x2f, 0x55, 0xc4,
-	0x00, 0x95, 0xe8, 0x6d, 0x41, 0x3a, 0xb1, 0xe5, 0xe8, 0xcd, 0x8a, 0x00, 0x97, 0x2a, 0x11, 0xe0,
-	0x15, 0x58, 0xcd, 0x67, 0xf1, 0x80, 0x0f, 0xfb, 0x22, 0xc1, 0x71, 0xa3, 0x98, 0x4e, 0x67, 0x29,
-	0x28, 0xc3, 0x14, 0xab, 0xf2, 0x5c, 0xc4, 0x5c, 0x90, 0xe9, 0x5a, 0x0a, 0x74, 0x13, 0x6f, 0x01,
-	0x22, 0x91, 0x42, 0xdd, 0x0a, 0x54, 0x0b, 0xaf, 0xca, 0x69, 0x16, 0xe5, 0xdd, 0x0e, 0xa1, 0xf4,
-	0x37, 0xfb, 0x02, 0x9c, 0x3d, 0xc0, 0xc8, 0xea, 0x88, 0x87, 0x43, 0x9e, 0xd1, 0xe9, 0xcb, 0xc0,
-	0x52, 0x5a, 0xa0, 0xfa, 0x4e, 0xff, 0x53, 0xba, 0xb7, 0x4d, 0x60, 0xfb, 0x98, 0x8c, 0x0e, 0x7b,
-	0x05, 0x5a, 0x72, 0x25, 0xf9, 0x51, 0xa8, 0x5c, 0x89, 0x25, 0x02, 0xf6, 0x8f, 0x42, 0x54, 0x53,
-	0x67, 0x73, 0x1a, 0xe4, 0x1f, 0xb6, 0x09, 0xdb, 0x95, 0x7b, 0xf3, 0x06, 0xac, 0xe8, 0x90, 0x39,
-	0xef, 0x8f, 0xf9, 0xa1, 0xd0, 0x61, 0x40, 0x3c, 0x9d, 0xe0, 0x70, 0xf9, 0x03, 0x7e, 0x28, 0xfc,
-	0x87, 0xb0, 0xae, 0xb4, 0xf3, 0xab, 0x29, 0xd7, 0x43, 0xff, 0x4a, 0xf9, 0xea, 0x92, 0xbe, 0xc3,
-	0x86, 0xab, 0xce, 0x14, 0xcb, 0x94, 0xee, 0x33, 0x3f, 0x00, 0xa6, 0xba, 0x6f, 0x8f, 0x93, 0x9c,
-	0x2b, 0x86, 0x3e, 0x74, 0x06, 0xe3, 0x24, 0xd7, 0xc1, 0x86, 0x5a, 0x8e, 0x83, 0xe1, 0x09, 0xe4,
-	0xd3, 0xc1, 0x00, 0xf5, 0x5d, 0x5a, 0x2e, 0xdd, 0xf4, 0xff, 0xd2, 0x83, 0x0d, 0xe2, 0xa6, 0xed,
-	0x88, 0xf1, 0x50, 0x5f, 0x7e, 0x9a, 0x9d, 0x81, 0x1d, 0x80, 0x6d, 0xc2, 0xfc, 0x61, 0x92, 0x0d,
-	0xb8, 0x1a, 0x49, 0x36, 0x7e, 0x72, 0x9f, 0xbb, 0x59, 0xf1, 0xb9, 0xff, 0xc9, 0x83, 0x75, 0x9a,
-	0xea, 0xbe, 0x08, 0xc5, 0x34, 0x57, 0xcb, 0xff, 0x55, 0x58, 0xc6, 0xa5, 0x72, 0xad, 0x34, 0x6a,
-	0xa2, 0x9b, 0x46, 0xbf, 0x09, 0x95, 0xc4, 0xbb, 0x67, 0x02, 0x97, 0x98, 0x7d, 0x09, 0x3a, 0x76,
-	0xde, 0x83, 0xe6, 0xdc, 0xde, 0x39, 0xaf, 0x57, 0x59, 0x91, 0x9c, 0xdd, 0x33, 0x81, 0xf3, 0x01,
-	0xbb, 0x01, 0x40, 0x4e, 0x05, 0xb1, 0x55, 0x01, 0xeb, 0x79, 0x77, 0x93, 0xac, 0xc3, 0xda, 0x3d,
-	0x13, 0x58, 0xe4, 0xb7, 0x96, 0x60, 0x41, 0xde, 0x82, 0xfe, 0x7d, 0x58, 0x76, 0x66, 0xea, 0xc4,
-	0x12, 0x1d, 0x19, 0x4b, 0x54, 0x42, 0xcf, 0x46, 0x35, 0xf4, 0xf4, 0xff, 0xbd, 0x01, 0x0c, 0xa5,
-	0xad, 0x74, 0x9c, 0x78, 0x0d, 0x27, 0x43, 0xc7, 0xa9, 0xea, 0x04, 0x36, 0xc4, 0xae, 0x01, 0xb3,
-	0x9a, 0x3a, 0xc3, 0x20, 0x6f, 0x87, 0x9a, 0x1e, 0x34, 0x63, 0xd2, 0x23, 0xd2, 0x91, 0xae, 0x72,
-	0x1f, 0xe5, 0xb9, 0xd5, 0xf6, 0xe1, 0x05, 0x90, 0x4e, 0xf3, 0x23, 0x74, 0x13, 0xb4, 0xdb, 0xa5,
-	0xdb, 0x65, 0x01, 0x59, 0x78, 0xa1, 0x80, 0x2c, 0x96, 0x05, 0xc4, 0xbe, 0xf8, 0x97, 0x9c, 0x8b,
-	0x1f, 0xbd, 0xac, 0x49, 0x14, 0x93, 0xf7, 0xd0, 0x9f, 0xe0, 0xe8, 0xca, 0xcb, 0x72, 0x40, 0x76,
-	0x15, 0xd6, 0x94, 0xf7, 0x56, 0x78, 0x17, 0x40, 0x7b, 0x5c, 0xc1, 0xfd, 0x1f, 0x7b, 0xb0, 0x86,
-	0xfb, 0xec, 0xc8, 0xe2, 0x7b, 0x40, 0xaa, 0xf0, 0x92, 0xa2, 0xe8, 0xd0, 0xfe, 0xec, 0x92, 0xf8,
-	0x2e, 0xb4, 0x88, 0x61, 0x92, 0xf2, 0x58, 0x09, 0x62, 0xd7, 0x15, 0xc4, 0xc2, 0x0a, 0xed, 0x9e,
-	0x09, 0x0a, 0x62, 0x4b, 0x0c, 0xff, 0xd1, 0x83, 0xb6, 0x9a, 0xe6, 0x4f, 0x1d, 0x31, 0xf4, 0x60,
-	0x09, 0x25, 0xd2, 0x72, 0xcb, 0x4d, 0x1b, 0xef, 0x8c, 0x09, 0x86, 0x65, 0x78, 0x49, 0x3a, 0xd1,
-	0x42, 0x19, 0xc6, 0x1b, 0x8f, 0x0c, 0x6e, 0xde, 0x17, 0xd1, 0xb8, 0xaf, 0x7b, 0x55, 0x9a, 0xb1,
-	0xae, 0x0b, 0xed, 0x4e, 0x2e, 0xc2, 0x11, 0x57, 0x97, 0x99, 0x6c, 0x60, 0x58, 0xa4, 0x16, 0x54,
-	0x72, 0xfa, 0xfc, 0x1f, 0x01, 0x9c, 0xab, 0x74, 0x99, 0xa4, 0xb6, 0x72, 0x83, 0xc7, 0xd1, 0xe4,
-	0x20, 0x31, 0x1e, 0xb5, 0x67, 0x7b, 0xc8, 0x4e, 0x17, 0x1b, 0xc1, 0x59, 0x7d, 0x6b, 0xe3, 0x9e,
-	0x16, 0x77, 0x74, 0x83, 0xdc, 0x8d, 0xb7, 0x5d, 0x19, 0x28, 0x0f, 0xa8, 0x71, 0x5b, 0x73, 0xeb,
-	0xf9, 0xb1, 0x23, 0xe8, 0x1a, 0xf7, 0x40, 0x99, 0x78, 0xcb, 0x85, 0xc0, 0xb1, 0xde, 0x7a, 0xc1,
-	0x58, 0x64, 0x8f, 0x86, 0x7a, 0x98, 0x53, 0xb9, 0xb1, 0x19, 0x5c, 0xd4, 0x7d, 0x64, 0xc3, 0xab,
-	0xe3, 0x35, 0x5f, 0x6a, 0x6d, 0xf7, 0xf0, 0x63, 0x77, 0xd0, 0x17, 0x30, 0xee, 0xfd, 0xc8, 0x83,
-	0x15, 0x97, 0x1d, 0x8a, 0x8e, 0x52, 0x42, 0x6d, 0x8c, 0xb4, 0xdb, 0x55, 0x82, 0xab, 0xc1, 0x61,
-	0xa3, 0x2e, 0x38, 0xb4, 0x43, 0xc0, 0xb9, 0x17, 0x85, 0x80, 0xcd, 0x97, 0x0b, 0x01, 0xe7, 0xeb,
-	0x42, 0xc0, 0xde, 0x7f, 0x79, 0xc0, 0xaa, 0xe7, 0xcb, 0xee, 0xcb, 0xe8, 0x34, 0xe6, 0x63, 0x65,
-	0x27, 0x7e, 0xe9, 0xe5, 0x64, 0x44, 0xef, 0xa1, 0xfe, 0x1a, 0x85, 0xd5, 0x36, 0x04, 0xb6, 0xdb,
-	0xb2, 0x1c, 0xd4, 0x75, 0x95, 0x82, 0xd2, 0xe6, 0x8b, 0x83, 0xd2, 0xf9, 0x17, 0x07, 0xa5, 0x0b,
-	0xe5, 0xa0, 0xb4, 0xf7, 0x3b, 0xb0, 0xec, 0x9c, 0xfa, 0xff, 0xdd, 0x8a, 0xcb, 0x2e, 0x8f, 0x3c,
-	0x60, 0x07, 0xeb, 0xfd, 0x67, 0x03, 0x58, 0x55, 0xf2, 0x7e, 0xae, 0x73, 0x20, 0x39, 0x72, 0x0c,
-	0xc8, 0x9c, 0x92, 0x23, 0xc7, 0x74, 0xfc, 0x7f, 0x1a, 0xc5, 0xb7, 0x60, 0x3d, 0xe3, 0x83, 0xe4,
-	0x98, 0x9e, 0xda, 0xdc, 0x84, 0x46, 0xb5, 0x03, 0x9d, 0x3e, 0x37, 0x14, 0x5f, 0x72, 0x5e, 0x46,
-	0xac, 0x9b, 0xa1, 0x14, 0x91, 0xfb, 0x5b, 0xb0, 0x29, 0x1f, 0xac, 0x6e, 0x49, 0x56, 0xda, 0xc8,
-	0x7e, 0xcf, 0x83, 0xb3, 0xa5, 0x8e, 0xe2, 0xf9, 0x40, 0xda, 0x51, 0xd7, 0xb8, 0xba, 0x20, 0xce,
-	0x5f, 0x09, 0xb0, 0x35, 0x7f, 0x79, 0xdf, 0x54, 0x3b, 0x70, 0x7f, 0xa6, 0x71, 0x95, 0x5e, 0xee,
-	0x7a, 0x5d, 0x97, 0x7f, 0x0e, 0xce, 0xaa, 0x93, 0x2d, 0x4d, 0x7c, 0x07, 0xb6, 0xca, 0x1d, 0x45,
-	0x3e, 0xd4, 0x9d, 0xb2, 0x6e, 0xfa, 0xbf, 0x0d, 0xec, 0x6b, 0x53, 0x9e, 0xcd, 0xe8, 0xa1, 0xc2,
-	0x24, 0x17, 0xce, 0x95, 0xa3, 0xf0, 0x85, 0x74, 0x7a, 0xf0, 0x15, 0x3e, 0xd3, 0x2f, 0x41, 0x8d,
-	0xe2, 0x25, 0xe8, 0x55, 0x00, 0x0c, 0x2b, 0xe8, 0x65, 0x43, 0xbf, 0xcd, 0x61, 0xd4, 0x26, 0x19,
-	0xfa, 0x37, 0x60, 0xc3, 0xe1, 0x6f, 0x76, 0x72, 0x41, 0x7d, 0x21, 0x43, 0x5b, 0xf7, 0xbd, 0x44,
-	0xf5, 0xf9, 0x7f, 0xea, 0xc1, 0xdc, 0x6e, 0x92, 0xda, 0x49, 0x31, 0xcf, 0x4d, 0x8a, 0x29, 0xbb,
-	0xd9, 0x37, 0x66, 0xb1, 0xa1, 0xb4, 0xde, 0x06, 0xd1, 0xea, 0x85, 0x13, 0x81, 0xc1, 0xdd, 0x61,
-	0x92, 0x9d, 0x84, 0xd9, 0x50, 0x6d, 0x6f, 0x09, 0xc5, 0xd5, 0x15, 0xc6, 0x05, 0xff, 0x44, 0x87,
-	0x81, 0x72, 0x82, 0x33, 0x15, 0x8f, 0xaa, 0x96, 0xff, 0x1d, 0x0f, 0xe6, 0x69, 0xae, 0xa8, 0x09,
-	0xf2, 0xf8, 0xe9, 0x91, 0x90, 0x52, 0x8e, 0x9e, 0xd4, 0x84, 0x12, 0x5c, 0x7a, 0x3a, 0x6c, 0x54,
-	0x9e, 0x0e, 0x2f, 0x40, 0x4b, 0xb6, 0x8a, 0xb7, 0xb6, 0x02, 0x60, 0x17, 0xa1, 0x79, 0x94, 0xa4,
-	0xfa, 0xfe, 0x02, 0x9d, 0x69, 0x4a, 0xd2, 0x80, 0x70, 0xff, 0x2a, 0xac, 0x3e, 0x4c, 0x86, 0xdc,
-	0xca, 0x04, 0x9c, 0x7a, 0x8a, 0xfe, 0xef, 0x7a, 0xb0, 0xa4, 0x89, 0xd9, 0x15, 0x68, 0xe2, 0x35,
-	0x54, 0x72, 0xfc, 0x4c, 0x3e, 0x18, 0xe9, 0x02, 0xa2, 0x40, 0xf3, 0x41, 0x11, 0x64, 0xe1, 0x26,
-	0xe8, 0xf8, 0xb1, 0xb8, 0x80, 0xdf, 0x84, 0x15, 0x39, 0xe7, 0xd2, 0x45, 0x55, 0x42, 0xfd, 0xbf,
-	0xf2, 0x60, 0xd9, 0x19, 0x03, 0xdd, 0xfd, 0x71, 0x98, 0x0b, 0x95, 0x63, 0x53, 0x9b, 0x68, 0x43,
-	0x76, 0x6e, 0xa8, 0xe1, 0xe6, 0x86, 0x4c, 0xd6, 0x62, 0xce, 0xce, 0x5a, 0x5c, 0x87, 0x56, 0xf1,
-	0x0c, 0xdb, 0x74, 0xcc, 0x02, 0x8e, 0xa8, 0x33, 0xdd, 0x05, 0x11, 0xf2, 0x19, 0x24, 0xe3, 0x24,
-	0x53, 0xaf, 0x94, 0xb2, 0xe1, 0xdf, 0x80, 0xb6, 0x45, 0x8f, 0xd3, 0x88, 0xb9, 0x38, 0x49, 0xb2,
-	0x27, 0x3a, 0x45, 0xa5, 0x9a, 0xe6, 0x41, 0xa7, 0x51, 0x3c, 0xe8, 0xf8, 0x7f, 0xed, 0xc1, 0x32,
-	0x4a, 0x4a, 0x14, 0x8f, 0xf6, 0x92, 0x71, 0x34, 0x98, 0x91, 0xc4, 0x68, 0xa1, 0x50, 0xcf, 0x97,
-	0x5a, 0x62, 0x5c, 0x18, 0xef, 0x7b, 0xed, 0xed, 0x2b, 0x79, 0x31, 0x6d, 0x94, 0x7c, 0xbc, 0xb7,
-	0x0e, 0xc2, 0x9c, 0xcb, 0xf0, 0x40, 0xd9, 0x69, 0x07, 0x44, 0xeb, 0x82, 0x40, 0x16, 0x0a, 0xde,
-	0x9f, 0x44, 0xe3, 0x71, 0x24, 0x69, 0xa5, 0x84, 0xd7, 0x75, 0xf9, 0x3f, 0x6c, 0x40, 0x5b, 0x59,
-	0x91, 0xbb, 0xc3, 0x91, 0x4c, 0x06, 0x2b, 0x27, 0xc4, 0xa8, 0x9f, 0x85, 0xe8, 0x7e, 0xc7, 0x6d,
-	0xb1, 0x90, 0xf2, 0xb1, 0xce, 0x55, 0x8f, 0xf5, 0x02, 0xb4, 0x50, 0xbc, 0xde, 0x26, 0xff, 0x48,
-	0xbe, 0xda, 0x17, 0x80, 0xee, 0xdd, 0xa1, 0xde, 0xf9, 0xa2, 0x97, 0x00, 0xc7, 0x23, 0x5a, 0x28,
-	0x79, 0x44, 0xef, 0x42, 0x47, 0xb1, 0xa1, 0x7d, 0xa7, 0xe0, 0xab, 0x10, 0x70, 0xe7, 0x4c, 0x02,
-	0x87, 0x52, 0x7f, 0xb9, 0xa3, 0xbf, 0x5c, 0x7a, 0xd1, 0x97, 0x9a, 0x92, 0xde, 0x46, 0xe4, 0xde,
-	0xdc, 0xcf, 0xc2, 0xf4, 0x48, 0x5b, 0xe6, 0xa1, 0x79, 0xf0, 0x25, 0x98, 0x5d, 0x85, 0x79, 0xfc,
-	0x4c, 0x5b, 0xbf, 0x7a, 0xa5, 0x93, 0x24, 0xec, 0x0a, 0xcc, 0xf3, 0xe1, 0x88, 0x6b, 0xaf, 0x9c,
-	0xb9, 0xf1, 0x11, 0x9e, 0x51, 0x20, 0x09, 0xd0, 0x04, 0x20, 0x5a, 0x32, 0x01, 0xae, 0xe5, 0x5c,
-	0xc0, 0xe6, 0x07, 0x43, 0x7f, 0x13, 0xd8, 0x43, 0x29, 0xb5, 0x76, 0xee, 0xf0, 0x0f, 0xe6, 0xa0,
-	0x6d, 0xc1, 0xa8, 0xcd, 0x23, 0x9c, 0x70, 0x7f, 0x18, 0x85, 0x13, 0x2e, 0x78, 0xa6, 0x24, 0xb5,
-	0x84, 0x92, 0x81, 0x3d, 0x1e, 0xf5, 0x93, 0xa9, 0xe8, 0x0f, 0xf9, 0x28, 0xe3, 0xf2, 0xbe, 0xf3,
-	0x82, 0x12, 0x8a, 0x74, 0x93, 0xf0, 0xa9, 0x4d, 0x27, 0xe5, 0xa1, 0x84, 0xea, 0x4c, 0xa0, 0xdc,
-	0xa3, 0x66, 0x91, 0x09, 0x94, 0x3b, 0x52, 0xb6, 0x43, 0xf3, 0x35, 0x76, 0xe8, 0x1d, 0xd8, 0x92,
-	0x16, 0x47, 0xe9, 0x66, 0xbf, 0x24, 0x26, 0xa7, 0xf4, 0x62, 0x3c, 0x8d, 0x73, 0xd6, 0x02, 0x9e,
-	0x47, 0x9f, 0xca, 0xa8, 0xdd, 0x0b, 0x2a, 0x38, 0xd2, 0xa2, 0x3a, 0x3a, 0xb4, 0xf2, 0xb5, 0xa4,
-	0x82, 0x13, 0x6d, 0xf8, 0xd4, 0xa5, 0x6d, 0x29, 0xda, 0x12, 0xee, 0x2f, 0x43, 0x7b, 0x5f, 0x24,
-	0xa9, 0x3e, 0x94, 0x15, 0xe8, 0xc8, 0xa6, 0x7a, 0x1b, 0x7b, 0x05, 0xce, 0x93, 0x14, 0x3d, 0x4a,
-	0xd2, 0x64, 0x9c, 0x8c, 0x66, 0xfb, 0xd3, 0x83, 0x7c, 0x90, 0x45, 0x29, 0x7a, 0xcb, 0xfe, 0x3f,
-	0x78, 0xb0, 0xe1, 0xf4, 0xaa, 0x30, 0xff, 0x0b, 0x52, 0xa4, 0xcd, 0xa3, 0x86, 0x14, 0xbc, 0x75,
-	0xcb, 0x1c, 0x4a, 0x42, 0x99, 0x60, 0x79, 0xac, 0xde, 0x39, 0x6e, 0xc2, 0xaa, 0x9e, 0x99, 0xfe,
-	0x50, 0x4a, 0x61, 0xb7, 0x2a, 0x85, 0xea, 0xfb, 0x15, 0xf5, 0x81, 0x66, 0xf1, 0x6b, 0xd2, 0xe7,
-	0xe4, 0x43, 0x5a, 0xa3, 0x8e, 0xf7, 0x7a, 0xfa, 0x7b, 0xdb, 0xd1, 0xd5, 0x33, 0x18, 0x18, 0x30,
-	0xf7, 0xff, 0xc8, 0x03, 0x28, 0x66, 0x87, 0x82, 0x51, 0x98, 0x74, 0x59, 0xae, 0x65, 0x99, 0xef,
-	0xd7, 0xa1, 0x63, 0xf2, 0xd9, 0xc5, 0x2d, 0xd1, 0xd6, 0x18, 0x3a, 0x30, 0x97, 0x61, 0x75, 0x34,
-	0x4e, 0x0e, 0xe8, 0xce, 0xa5, 0xc7, 0xd6, 0x5c, 0xbd, 0x10, 0xae, 0x48, 0xf8, 0x9e, 0x42, 0x8b,
-	0x2b, 0xa5, 0x69, 0x5d, 0x29, 0xfe, 0xb7, 0x1b, 0x26, 0x3f, 0x5a, 0xac, 0xf9, 0x54, 0x2d, 0x63,
-	0x3b, 0x15, 0xe3, 0x78, 0x4a, 0x3a, 0x92, 0x32, 0x1b, 0x7b, 0x2f, 0x0c, 0xf2, 0x6e, 0xc0, 0x4a,
-	0x26, 0xad, 0x8f, 0x36, 0x4d, 0xcd, 0xe7, 0x98, 0xa6, 0xe5, 0xcc, 0xb9, 0x77, 0x7e, 0x01, 0xd6,
-	0xc2, 0xe1, 0x31, 0xcf, 0x44, 0x44, 0xde, 0x3e, 0x5d, 0xfa, 0xd2, 0xa0, 0xae, 0x5a, 0x38, 0xdd,
-	0xc5, 0x97, 0x61, 0x55, 0xbd, 0xca, 0x1a, 0x4a, 0x55, 0x8b, 0x53, 0xc0, 0x48, 0xe8, 0xff, 0x40,
-	0xa7, 0x62, 0xdd, 0x33, 0x3c, 0x7d, 0x47, 0xec, 0xd5, 0x35, 0x4a, 0xab, 0xfb, 0x9c, 0x4a, 0x8b,
-	0x0e, 0x75, 0x48, 0xa1, 0x12, 0xd4, 0x12, 0x54, 0x69, 0x6c, 0x77, 0x4b, 0x9b, 0x2f, 0xb3, 0xa5,
-	0xfe, 0xf7, 0xe6, 0x60, 0xf1, 0x83, 0xf8, 0x38, 0x89, 0x06, 0x94, 0xa4, 0x9c, 0xf0, 0x49, 0xa2,
-	0x0b, 0x1e, 0xf0, 0x6f, 0xbc, 0xd1, 0xe9, 0xf1, 0x2f, 0x15, 0x2a, 0xcb, 0xa8, 0x9b, 0x78, 0xbb,
-	0x65, 0x45, 0x11, 0x90, 0x94, 0x14, 0x0b, 0x41, 0xff, 0x30, 0xb3, 0x2b, 0xa0, 0x54, 0xab, 0xa8,
-	0x18, 0x99, 0xb7, 0x2a, 0x46, 0x28, 0xa5, 0x2d, 0xdf, 0x35, 0x69, 0x3b, 0x97, 0x02, 0xdd, 0x24,
-	0x3f, 0x36, 0xe3, 0x32, 0xe0, 0xa5, 0x7b, 0x72, 0x51, 0xf9, 0xb1, 0x36, 0x88, 0x77, 0xa9, 0xfc,
-	0x40, 0xd2, 0x48, 0x5b, 0x63, 0x43, 0xe8, 0x5b, 0x94, 0x8b, 0xa8, 0x5a, 0xf2, 0x88, 0x4b, 0x30,
-	0x1a, 0xa4, 0x21, 0x37, 0x76, 0x43, 0xae, 0x01, 0x64, 0x91, 0x53, 0x19, 0xb7, 0xbc, 0x60, 0xf9,
-	0x3e, 0xab, 0x5a, 0xe4, 0x83, 0x84, 0xe3, 0xf1, 0x41, 0x38, 0x78, 0x42, 0xa5, 0x6d, 0xf4, 0x1c,
-	0xdb, 0x0a, 0x5c, 0x10, 0x67, 0x4d, 0x95, 0x5a, 0x8a, 0xc5, 0xb2, 0x7c, 0x4e, 0xb5, 0x20, 0xff,
-	0xeb, 0xc0, 0x6e, 0x0e, 0x87, 0xea, 0x84, 0x4c, 0x8c, 0x50, 0xec, 0xad, 0xe7, 0xec, 0x6d, 0xcd,
-	0x1a, 0x1b, 0xb5, 0x6b, 0xf4, 0xef, 0x42, 0x7b, 0xcf, 0xaa, 0x48, 0xa3, 0xc3, 0xd4, 0xb5, 0x68,
-	0x4a, 0x00, 0x2c, 0xc4, 0x1a, 0xb0, 0x61, 0x0f, 0xe8, 0xff, 0x32, 0xb0, 0x07, 0x51, 0x2e, 0xcc,
-	0xfc, 0xe4, 0x06, 0xbe, 0x0e, 0x1d, 0x93, 0xed, 0x2a, 0x5e, 0x60, 0xdb, 0x0a, 0xa3, 0x97, 0xd1,
-	0x9b, 0xf2, 0xe9, 0xb6, 0xbc, 0xb0, 0xab, 0xb0, 0x14, 0x49, 0x48, 0xdb, 0x61, 0xfd, 0x30, 0xaa,
-	0x29, 0x4d, 0x3f, 0x3a, 0x14, 0x0a, 0x74, 0xcc, 0xfc, 0x0f, 0x3d, 0x58, 0x54, 0x4b, 0xc3, 0xeb,
-	0xd0, 0xa9, 0xc5, 0x93, 0x0b, 0x73, 0xb0, 0xfa, 0x0a, 0xa6, 0xaa, 0xd4, 0xcd, 0xd5, 0x49, 0x1d,
-	0x83, 0x66, 0x1a, 0x8a, 0x23, 0xf2, 0xa0, 0x5b, 0x01, 0xfd, 0xad, 0x23, 0xa5, 0xf9, 0x22, 0x52,
-	0xaa, 0x2b, 0x9a, 0x93, 0x36, 0xa3, 0x82, 0xe3, 0xa2, 0xe8, 0xb1, 0x53, 0xe2, 0x26, 0xbb, 0xa9,
-	0x1e, 0x92, 0x0b, 0xb8, 0xd8, 0x2f, 0xc5, 0xa2, 0xbc, 0x5f, 0x8a, 0x34, 0x30, 0xfd, 0x7e, 0x0f,
-	0xba, 0x77, 0xf8, 0x98, 0x0b, 0x7e, 0x73, 0x3c, 0x2e, 0xf3, 0x7f, 0x05, 0xce, 0xd7, 0xf4, 0xa9,
-	0x5b, 0xf5, 0x1e, 0xac, 0xdf, 0xe1, 0x07, 0xd3, 0xd1, 0x03, 0x7e, 0x5c, 0x3c, 0x41, 0x30, 0x68,
-	0xe6, 0x47, 0xc9, 0x89, 0x3a, 0x5b, 0xfa, 0x1b, 0x03, 0xde, 0x31, 0xd2, 0xf4, 0xf3, 0x94, 0x0f,
-	0x74, 0xed, 0x0e, 0x21, 0xfb, 0x29, 0x1f, 0xf8, 0xef, 0x00, 0xb3, 0xf9, 0xa8, 0x25, 0xa0, 0xe6,
-	0x4e, 0x0f, 0xfa, 0xf9, 0x2c, 0x17, 0x7c, 0xa2, 0x8b, 0x92, 0x6c, 0xc8, 0xbf, 0x0c, 0x9d, 0xbd,
-	0x70, 0x16, 0xf0, 0x4f, 0x54, 0x39, 0x24, 0x06, 0x6f, 0xe1, 0x0c, 0x45, 0xd9, 0x04, 0x6f, 0xd4,
-	0xed, 0xff, 0x5d, 0x03, 0x16, 0x24, 0x25, 0x72, 0x1d, 0xf2, 0x5c, 0x44, 0xb1, 0x4c, 0xbf, 0x2b,
-	0xae, 0x16, 0x54, 0x91, 0x8d, 0x46, 0x8d, 0x6c, 0x28, 0x77, 0x4a, 0xd7, 0x41, 0x28, 0x21, 0x70,
-	0x30, 0x8a, 0x4d, 0xcd, 0xe3, 0x65, 0x53, 0xc5, 0xa6, 0x1a, 0x28, 0x45, 0xc9, 0x85, 0x7d, 0x90,
-	0xf3, 0xd3, 0x42, 0xab, 0xc4, 0xc1, 0x86, 0x6a, 0xad, 0xd0, 0xa2, 0x94, 0x9a, 0x8a, 0x15, 0xaa,
-	0x58, 0x9b, 0xa5, 0x97, 0xb0, 0x36, 0xd2, 0xc7, 0x72, 0xac, 0x0d, 0x83, 0xb5, 0x7b, 0x9c, 0x07,
-	0x3c, 0x4d, 0x32, 0x5d, 0x53, 0xea, 0x7f, 0xd7, 0x83, 0x35, 0x75, 0x7b, 0x98, 0x3e, 0xf6, 0xba,
-	0x73, 0xd5, 0x78, 0x75, 0x19, 0xd9, 0x37, 0x60, 0x99, 0x82, 0x2d, 0x8c, 0xa4, 0x28, 0xb2, 0x52,
-	0xf9, 0x07, 0x07, 0xc4, 0x39, 0xe9, 0x1c, 0xe3, 0x24, 0x1a, 0xab, 0x0d, 0xb6, 0x21, 0xbc, 0x16,
-	0x75, 0x30, 0x46, 0xdb, 0xeb, 0x05, 0xa6, 0xed, 0xff, 0xad, 0x07, 0xeb, 0xd6, 0x84, 0x95, 0x44,
-	0xdd, 0x00, 0xfd, 0x84, 0x29, 0xf3, 0x09, 0x52, 0x31, 0xce, 0xb9, 0x37, 0x61, 0xf1, 0x99, 0x43,
-	0x4c, 0x07, 0x13, 0xce, 0x68, 0x82, 0xf9, 0x54, 0x56, 0x77, 0x35, 0x03, 0x1b, 0x42, 0xa1, 0x38,
-	0xe1, 0xfc, 0x89, 0x21, 0x99, 0x23, 0x12, 0x07, 0xa3, 0x17, 0xaa, 0x24, 0x16, 0x47, 0x86, 0x48,
-	0x96, 0x5e, 0xb8, 0xa0, 0xff, 0xcf, 0x1e, 0x6c, 0x48, 0x0f, 0x44, 0xf9, 0x77, 0xa6, 0x2c, 0x6c,
-	0x41, 0xba, 0x5c, 0x52, 0xbb, 0x76, 0xcf, 0x04, 0xaa, 0xcd, 0xbe, 0xf8, 0x92, 0x5e, 0x93, 0x79,
-	0x99, 0x3c, 0xe5, 0x2c, 0xe6, 0xea, 0xce, 0xe2, 0x39, 0x3b, 0x5d, 0x17, 0x99, 0xcf, 0xd7, 0x46,
-	0xe6, 0xb7, 0x16, 0x61, 0x3e, 0x1f, 0x24, 0x29, 0xf7, 0xb7, 0x60, 0xd3, 0x5d, 0x9c, 0x32, 0x27,
-	0xdf, 0xf7, 0xa0, 0x7b, 0x4f, 0xa6, 0x95, 0xa2, 0x78, 0xb4, 0x1b, 0xe5, 0x22, 0xc9, 0x4c, 0x1d,
-	0xec, 0x45, 0x80, 0x5c, 0x84, 0x99, 0x90, 0xf5, 0x21, 0x2a, 0xa6, 0x2e, 0x10, 0x9c, 0x23, 0x8f,
-	0x87, 0xb2, 0x57, 0x9e, 0x8d, 0x69, 0xe3, 0xc1, 0xd0, 0xab, 0x69, 0x3f, 0x39, 0x3c, 0xcc, 0xb9,
-	0xf1, 0x91, 0x6c, 0x0c, 0xc3, 0x2c, 0xd4, 0x5e, 0x0c, 0x2c, 0xf8, 0x31, 0x99, 0x4d, 0x19, 0x43,
-	0x95, 0x50, 0xff, 0x6f, 0x3c, 0x58, 0x2d, 0x26, 0x79, 0x17, 0x41, 0x57, 0xd3, 0xe5, 0xd4, 0x2c,
-	0x4d, 0xd7, 0xd1, 0x7e, 0x34, 0xec, 0x47, 0xb1, 0x9a, 0x9b, 0x85, 0x90, 0xf6, 0xa9, 0x56, 0x32,
-	0xd5, 0xb5, 0x38, 0x36, 0x24, 0x9f, 0xe0, 0x04, 0x7e, 0x2d, 0x0b, 0x71, 0x54, 0x8b, 0xca, 0x7b,
-	0x26, 0x82, 0xbe, 0x5a, 0x90, 0x39, 0x3e, 0xd5, 0xd4, 0x77, 0xcd, 0x22, 0xa1, 0xf8, 0xa7, 0xff,
-	0x1d, 0x0f, 0xce, 0xd7, 0x6c, 0xae, 0xd2, 0x8c, 0x3b, 0xb0, 0x7e, 0x68, 0x3a, 0xf5, 0x06, 0x48,
-	0xf5, 0xd8, 0x52, 0x52, 0x54, 0x5a, 0x74, 0x50, 0xfd, 0x80, 0xbd, 0x05, 0xeb, 0x94, 0xa4, 0x90,
-	0x5b, 0xea, 0xbc, 0x5e, 0x57, 0x3b, 0x76, 0x7e, 0xd0, 0x80, 0x15, 0x99, 0x33, 0x96, 0xbf, 0x84,
-	0xe0, 0x19, 0xfb, 0x10, 0x16, 0xd5, 0xef, 0x4e, 0xd8, 0x59, 0x35, 0xac, 0xfb, 0x4b, 0x97, 0xde,
-	0x56, 0x19, 0x56, 0xb2, 0xb3, 0xf1, 0xfb, 0x3f, 0xfe, 0xb7, 0x3f, 0x6e, 0x2c, 0xb3, 0xf6, 0xf6,
-	0xf1, 0xdb, 0xdb, 0x23, 0x1e, 0xe7, 0xc8, 0xe3, 0x37, 0x01, 0x8a, 0x9f, 0x6e, 0xb0, 0xae, 0x71,
-	0x18, 0x4a, 0x3f, 0x35, 0xe9, 0x9d, 0xaf, 0xe9, 0x51, 0x7c, 0xcf, 0x13, 0xdf, 0x0d, 0x7f, 0x05,
-	0xf9, 0x46, 0x71, 0x24, 0xe4, 0xef, 0x38, 0xde, 0xf3, 0xae, 0xb2, 0x21, 0x74, 0xec, 0x9f, 0x70,
-	0x30, 0x1d, 0x9f, 0xd5, 0xfc, 0x2e, 0xa4, 0xf7, 0x4a, 0x6d, 0x9f, 0x0e, 0x4e, 0x69, 0x8c, 0xb3,
-	0xfe, 0x1a, 0x8e, 0x31, 0x25, 0x0a, 0x33, 0xca, 0xce, 0xbf, 0xbc, 0x02, 0x2d, 0x93, 0xe3, 0x60,
-	0xdf, 0x82, 0x65, 0x27, 0xcd, 0xce, 0x34, 0xe3, 0xba, 0xac, 0x7c, 0xef, 0x42, 0x7d, 0xa7, 0x1a,
-	0xf6, 0x22, 0x0d, 0xdb, 0x65, 0x5b, 0x38, 0xac, 0xca, 0x6d, 0x6f, 0xd3, 0xe3, 0x82, 0x2c, 0xe7,
-	0x79, 0x02, 0x2b, 0x6e, 0x6a, 0x9c, 0x5d, 0x70, 0x0d, 0x4a, 0x69, 0xb4, 0x57, 0x4f, 0xe9, 0x55,
-	0xc3, 0x5d, 0xa0, 0xe1, 0xb6, 0xd8, 0xa6, 0x3d, 0x9c, 0xc9, 0x3d, 0x70, 0x2a, 0xc0, 0xb2, 0x7f,
-	0xdb, 0xc1, 0x5e, 0x35, 0x47, 0x5d, 0xf7, 0x9b, 0x0f, 0x73, 0x68, 0xd5, 0x1f, 0x7e, 0xf8, 0x5d,
-	0x1a, 0x8a, 0x31, 0xda, 0x50, 0xfb, 0xa7, 0x1d, 0xec, 0x63, 0x68, 0x99, 0x7a, 0x6e, 0x76, 0xce,
-	0x2a, 0xa2, 0xb7, 0x8b, 0xcc, 0x7b, 0xdd, 0x6a, 0x47, 0xdd, 0x51, 0xd9, 0x9c, 0x51, 0x20, 0x1e,
-	0xc0, 0x59, 0xe5, 0x70, 0x1e, 0xf0, 0x9f, 0x64, 0x25, 0x35, 0xbf, 0x48, 0xb9, 0xee, 0xb1, 0x1b,
-	0xb0, 0xa4, 0xcb, 0xe4, 0xd9, 0x56, 0x7d, 0xb9, 0x7f, 0xef, 0x5c, 0x05, 0x57, 0xfa, 0x7c, 0x13,
-	0xa0, 0x28, 0xf1, 0x36, 0x92, 0x5f, 0x29, 0x3c, 0x37, 0x9b, 0x58, 0x53, 0x0f, 0x3e, 0xa2, 0x82,
-	0x76, 0xb7, 0x82, 0x9c, 0xbd, 0x56, 0xd0, 0xd7, 0xd6, 0x96, 0x3f, 0x87, 0xa1, 0xbf, 0x45, 0x7b,
-	0xb7, 0xc6, 0x48, 0x95, 0x62, 0x7e, 0xa2, 0x4b, 0x11, 0xef, 0x40, 0xdb, 0x2a, 0x1b, 0x67, 0x9a,
-	0x43, 0xb5, 0xe4, 0xbc, 0xd7, 0xab, 0xeb, 0x52, 0xd3, 0xfd, 0x32, 0x2c, 0x3b, 0xf5, 0xdf, 0x46,
-	0x33, 0xea, 0xaa, 0xcb, 0x8d, 0x66, 0xd4, 0x97, 0x8c, 0x7f, 0x03, 0xda, 0x56, 0xb5, 0x36, 0xb3,
-	0x8a, 0x33, 0x4a, 0x75, 0xda, 0x66, 0x46, 0x75, 0xc5, 0xdd, 0x9b, 0xb4, 0xde, 0x15, 0xbf, 0x85,
-	0xeb, 0xa5, 0x7a, 0x3c, 0x14, 0x92, 0x6f, 0xc1, 0x8a, 0x5b, 0xbf, 0x6d, 0xb4, 0xaa, 0xb6, 0x12,
-	0xdc, 0x68, 0xd5, 0x29, 0x45, 0xdf, 0x4a, 0x20, 0xaf, 0x6e, 0x98, 0x41, 0xb6, 0x3f, 0x53, 0x19,
-	0xfe, 0x67, 0xec, 0x6b, 0x68, 0x3a, 0x54, 0x81, 0x24, 0x2b, 0xaa, 0xd6, 0xdd, 0x32, 0x4a, 0x23,
-	0xed, 0x95, 0x5a, 0x4a, 0x7f, 0x9d, 0x98, 0xb7, 0x59, 0xb1, 0x02, 0x69, 0xa1, 0xa9, 0x50, 0xd2,
-	0xb2, 0xd0, 0x76, 0x2d, 0xa5, 0x65, 0xa1, 0x9d, 0x7a, 0xca, 0xb2, 0x85, 0x16, 0x11, 0xf2, 0x88,
-	0x61, 0xb5, 0xf4, 0x20, 0x6b, 0x94, 0xa5, 0xbe, 0x9c, 0xa3, 0x77, 0xf1, 0xf9, 0xef, 0xb8, 0xae,
-	0x99, 0xd1, 0xe6, 0x65, 0x5b, 0x57, 0xdf, 0xfc, 0x16, 0x74, 0xec, 0xba, 0x5b, 0x63, 0xb3, 0x6b,
-	0xaa, 0x85, 0x8d, 0xcd, 0xae, 0x2b, 0xd4, 0xd5, 0x87, 0xcb, 0x3a, 0xf6, 0x30, 0xec, 0x1b, 0xb0,
-	0x6a, 0x3d, 0xfd, 0xef, 0xcf, 0xe2, 0x81, 0x11, 0x9e, 0x6a, 0xb1, 0x56, 0xaf, 0xce, 0x3f, 0xf3,
-	0xcf, 0x11, 0xe3, 0x75, 0xdf, 0x61, 0x8c, 0x82, 0x73, 0x1b, 0xda, 0x76, 0x59, 0xc1, 0x73, 0xf8,
-	0x9e, 0xb3, 0xba, 0xec, 0xba, 0xa5, 0xeb, 0x1e, 0xfb, 0x33, 0x0f, 0x3a, 0x76, 0x19, 0x20, 0x73,
-	0x92, 0x8a, 0x25, 0x3e, 0x5d, 0xbb, 0xcf, 0x66, 0xe4, 0x07, 0x34, 0xc9, 0x07, 0x57, 0xbf, 0xec,
-	0x6c, 0xf2, 0x67, 0x8e, 0x9f, 0x7f, 0xad, 0xfc, 0x93, 0xaa, 0x67, 0x65, 0x02, 0xbb, 0xa0, 0xed,
-	0xd9, 0x75, 0x8f, 0xbd, 0x27, 0x7f, 0x76, 0xa7, 0x63, 0x74, 0x66, 0x19, 0xb7, 0xf2, 0x96, 0xd9,
-	0xbf, 0x50, 0xbb, 0xe2, 0x5d, 0xf7, 0xd8, 0x37, 0xe5, 0x2f, 0xa7, 0xd4, 0xb7, 0xb4, 0xf3, 0x2f,
-	0xfb, 0xbd, 0xff, 0x06, 0xad, 0xe6, 0xa2, 0x7f, 0xde, 0x59, 0x4d, 0xd9, 0xba, 0xef, 0x01, 0x14,
-	0x09, 0x17, 0x56, 0xca, 0x3e, 0x18, 0xbb, 0x57, 0xcd, 0xc9, 0xb8, 0x27, 0xaa, 0x93, 0x14, 0xc8,
-	0xf1, 0x63, 0x29, 0x8c, 0x8a, 0x3e, 0x37, 0x47, 0x5a, 0x4d, 0x9c, 0xf4, 0x7a, 0x75, 0x5d, 0x75,
-	0xa2, 0xa8, 0xf9, 0xb3, 0xc7, 0xb0, 0xfc, 0x20, 0x49, 0x9e, 0x4c, 0x53, 0x93, 0xc4, 0x73, 0xe3,
-	0xff, 0xdd, 0x30, 0x3f, 0xea, 0x95, 0x56, 0xe1, 0x5f, 0x22, 0x56, 0x3d, 0xd6, 0xb5, 0x58, 0x6d,
-	0x7f, 0x56, 0xa4, 0x7b, 0x9e, 0xb1, 0x10, 0xd6, 0xcd, 0x1d, 0x67, 0x26, 0xde, 0x73, 0xd9, 0xd8,
-	0x59, 0x97, 0xca, 0x10, 0x8e, 0xd7, 0xa1, 0x67, 0xbb, 0x9d, 0x6b, 0x9e, 0xd7, 0x3d, 0xb6, 0x07,
-	0x9d, 0x3b, 0x7c, 0x90, 0x0c, 0xb9, 0x8a, 0xd8, 0x37, 0x8a, 0x89, 0x9b, 0x50, 0xbf, 0xb7, 0xec,
-	0x80, 0xae, 0xd6, 0xa7, 0xe1, 0x2c, 0xe3, 0x9f, 0x6c, 0x7f, 0xa6, 0x72, 0x01, 0xcf, 0xb4, 0xd6,
-	0xeb, 0xfc, 0x85, 0xa3, 0xf5, 0xa5, 0x84, 0x87, 0xa3, 0xf5, 0x95, 0x84, 0x87, 0xb3, 0xd5, 0x3a,
-	0x7f, 0xc2, 0xc6, 0xb0, 0x5e, 0xc9, 0x91, 0x98, 0x9b, 0xf2, 0xb4, 0xcc, 0x4a, 0xef, 0xd2, 0xe9,
-	0x04, 0xee, 0x68, 0x57, 0xdd, 0xd1, 0xf6, 0x61, 0xf9, 0x0e, 0x97, 0x9b, 0x25, 0x1f, 0xc6, 0x7a,
-	0xae, 0x19, 0xb1, 0x1f, 0xd1, 0xca, 0x26, 0x86, 0xfa, 0x5c, 0xb3, 0x4e, 0xaf, 0x52, 0xec, 0x63,
-	0x68, 0xdf, 0xe7, 0x42, 0xbf, 0x84, 0x19, 0x7f, 0xa3, 0xf4, 0x34, 0xd6, 0xab, 0x79, 0x48, 0x73,
-	0x65, 0x86, 0xb8, 0x6d, 0xf3, 0xe1, 0x88, 0x4b, 0x65, 0xef, 0x47, 0xc3, 0x67, 0xec, 0xd7, 0x89,
-	0xb9, 0x79, 0x3c, 0xdf, 0xb2, 0x1e, 0x50, 0x6c, 0xe6, 0xab, 0x25, 0xbc, 0x8e, 0x73, 0x9c, 0x0c,
-	0xb9, 0x75, 0xc1, 0xc5, 0xd0, 0xb6, 0x2a, 0x25, 0x8c, 0x02, 0x55, 0xab, 0x33, 0x8c, 0x02, 0xd5,
-	0x14, 0x56, 0xf8, 0x57, 0x68, 0x1c, 0x9f, 0x5d, 0x2a, 0xc6, 0x91, 0xc5, 0x14, 0xc5, 0x48, 0xdb,
-	0x9f, 0x85, 0x13, 0xf1, 0x8c, 0x7d, 0x44, 0xbf, 0x1c, 0xb0, 0x5f, 0xfb, 0x0a, 0x7f, 0xa7, 0xfc,
-	0x30, 0x68, 0x36, 0xcb, 0xea, 0x72, 0x7d, 0x20, 0x39, 0x14, 0xdd, 0x83, 0x5f, 0x04, 0xd8, 0x17,
-	0x49, 0x7a, 0x27, 0xe4, 0x93, 0x24, 0x2e, 0x2c, 0x57, 0xf1, 0xa2, 0x55, 0x58, 0x2e, 0xeb, 0x59,
-	0x8b, 0x7d, 0x64, 0x79, 0x9c, 0xce, 0x63, 0xa9, 0x16, 0xae, 0x53, 0x1f, 0xbd, 0xcc, 0x86, 0xd4,
-	0x3c, 0x7c, 0x5d, 0xf7, 0xd0, 0x7f, 0x2c, 0x32, 0x72, 0xc6, 0x7f, 0xac, 0x24, 0xfb, 0x8c, 0xd9,
-	0xab, 0x49, 0xdf, 0xed, 0x41, 0xab, 0x48, 0x0b, 0xe9, 0x2b, 0xa9, 0x9c, 0x44, 0x32, 0x77, 0x4c,
-	0x25, 0x59, 0xe3, 0xaf, 0xd1, 0x56, 0x01, 0x5b, 0xc2, 0xad, 0xa2, 0x0c, 0x4c, 0x04, 0x1b, 0x72,
-	0x82, 0xe6, 0xc2, 0xa4, 0x37, 0x1a, 0xbd, 0x92, 0x9a, 0x84, 0x89, 0xd1, 0xe6, 0xda, 0x7c, 0x83,
-	0x13, 0xdb, 0xa1, 0xb4, 0xca, 0xf7, 0x21, 0x34, 0xcd, 0x13, 0x58, 0xaf, 0x04, 0xcb, 0x46, 0xa5,
-	0x4f, 0xcb, 0x51, 0x18, 0x95, 0x3e, 0x35, 0xce, 0xf6, 0xcf, 0xd2, 0x90, 0xab, 0x3e, 0xe0, 0x90,
-	0xf9, 0x49, 0x24, 0x06, 0x47, 0xef, 0x79, 0x57, 0x0f, 0x16, 0xe8, 0x1f, 0x3b, 0x7c, 0xfe, 0x7f,
-	0x03, 0x00, 0x00, 0xff, 0xff, 0x05, 0xff, 0x3b, 0xf8, 0x0a, 0x42, 0x00, 0x00,
+	// 7842 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7d, 0x4d, 0x6c, 0x1c, 0xc9,
+	0xd5, 0x98, 0x7a, 0x38, 0x24, 0x67, 0xde, 0xcc, 0x90, 0xc3, 0xe2, 0x8f, 0x46, 0x23, 0xad, 0x56,
+	0xdb, 0xbb, 0x59, 0x29, 0xca, 0x46, 0xd2, 0x72, 0xed, 0xcd, 0x7a, 0xd7, 0x5e, 0x9b, 0x22, 0x87,
+	0x22, 0x77, 0x29, 0x92, 0x6e, 0x52, 0x2b, 0xff, 0xc5, 0xed, 0xe6, 0x4c, 0x91, 0x6c, 0xab, 0xa7,
+	0x7b, 0xdc, 0xdd, 0x43, 0x89, 0xbb, 0x59, 0x20, 0x3f, 0x40, 0x4e, 0x36, 0x9c, 0x20, 0x41, 0x00,
+	0x3b, 0x08, 0x02, 0x78, 0x93, 0x43, 0x0e, 0x41, 0x2e, 0xc9, 0x25, 0x0e, 0x12, 0x20, 0x87, 0x1c,
+	0x0c, 0x04, 0x39, 0x24, 0x17, 0x23, 0x97, 0x18, 0xc9, 0x29, 0xc9, 0xd5, 0xf8, 0x6e, 0xdf, 0xf7,
+	0xe1, 0xd5, 0x5f, 0x57, 0x75, 0xf7, 0x50, 0xf4, 0xcf, 0xf7, 0x5d, 0xa4, 0xa9, 0x57, 0xaf, 0x5e,
+	0x55, 0xbd, 0x7a, 0xf5, 0xea, 0xbd, 0x57, 0xaf, 0x8b, 0x50, 0x8f, 0x47, 0xfd, 0x7b, 0xa3, 0x38,
+	0x4a, 0x23, 0x32, 0x1d, 0x84, 0xf1, 0xa8, 0xdf, 0xbd, 0x71, 0x12, 0x45, 0x27, 0x01, 0xbd, 0xef,
+	0x8d, 0xfc, 0xfb, 0x5e, 0x18, 0x46, 0xa9, 0x97, 0xfa, 0x51, 0x98, 0x70, 0x24, 0xfb, 0x07, 0x30,
+	0xf7, 0x88, 0x86, 0x07, 0x94, 0x0e, 0x1c, 0xfa, 0xa3, 0x31, 0x4d, 0x52, 0xf2, 0xd7, 0x60, 0xc1,
+	0xa3, 0x9f, 0x52, 0x3a, 0x70, 0x47, 0x5e, 0x92, 0x8c, 0x4e, 0x63, 0x2f, 0xa1, 0x1d, 0xeb, 0x96,
+	0x75, 0xa7, 0xe9, 0xb4, 0x79, 0xc5, 0xbe, 0x82, 0x93, 0xd7, 0xa0, 0x99, 0x20, 0x2a, 0x0d, 0xd3,
+	0x38, 0x1a, 0x9d, 0x77, 0x2a, 0x0c, 0xaf, 0x81, 0xb0, 0x1e, 0x07, 0xd9, 0x01, 0xcc, 0xab, 0x1e,
+	0x92, 0x51, 0x14, 0x26, 0x94, 0x3c, 0x80, 0xa5, 0xbe, 0x3f, 0x3a, 0xa5, 0xb1, 0xcb, 0x1a, 0x0f,
+	0x43, 0x3a, 0x8c, 0x42, 0xbf, 0xdf, 0xb1, 0x6e, 0x4d, 0xdd, 0xa9, 0x3b, 0x84, 0xd7, 0x61, 0x8b,
+	0xc7, 0xa2, 0x86, 0xdc, 0x86, 0x79, 0x1a, 0x72, 0x38, 0x1d, 0xb0, 0x56, 0xa2, 0xab, 0xb9, 0x0c,
+	0x8c, 0x0d, 0xec, 0x7f, 0x6a, 0xc1, 0xc2, 0x76, 0xe8, 0xa7, 0x4f, 0xbd, 0x20, 0xa0, 0xa9, 0x9c,
+	0xd3, 0x6d, 0x98, 0x7f, 0xce, 0x00, 0x6c, 0x4e, 0xcf, 0xa3, 0x78, 0x20, 0x66, 0x34, 0xc7, 0xc1,
+	0xfb, 0x02, 0x3a, 0x71, 0x64, 0x95, 0x89, 0x23, 0x2b, 0x65, 0xd7, 0x54, 0x39, 0xbb, 0xec, 0x25,
+	0x20, 0xfa, 0xe0, 0x38, 0x3b, 0xec, 0x0f, 0x61, 0xf1, 0x49, 0x18, 0x44, 0xfd, 0x67, 0xbf, 0xdf,
+	0xa0, 0xed, 0x15, 0x58, 0x32, 0xdb, 0x0b, 0xba, 0x3f, 0xab, 0x40, 0xe3, 0x30, 0xf6, 0xc2, 0xc4,
+	0xeb, 0xe3, 0x92, 0x93, 0x0e, 0xcc, 0xa6, 0x2f, 0xdc, 0x53, 0x2f, 0x39, 0x65, 0x84, 0xea, 0x8e,
+	0x2c, 0x92, 0x15, 0x98, 0xf1, 0x86, 0xd1, 0x38, 0x4c, 0x19, 0x57, 0xa7, 0x1c, 0x51, 0x22, 0x6f,
+	0xc1, 0x42, 0x38, 0x1e, 0xba, 0xfd, 0x28, 0x3c, 0xf6, 0xe3, 0x21, 0x17, 0x1c, 0x36, 0xb9, 0x69,
+	0xa7, 0x58, 0x41, 0x6e, 0x02, 0x1c, 0xe1, 0x30, 0x78, 0x17, 0x55, 0xd6, 0x85, 0x06, 0x21, 0x36,
+	0x34, 0x45, 0x89, 0xfa, 0x27, 0xa7, 0x69, 0x67, 0x9a, 0x11, 0x32, 0x60, 0x48, 0x23, 0xf5, 0x87,
+	0xd4, 0x4d, 0x52, 0x6f, 0x38, 0xea, 0xcc, 0xb0, 0xd1, 0x68, 0x10, 0x56, 0x1f, 0xa5, 0x5e, 0xe0,
+	0x1e, 0x53, 0x9a, 0x74, 0x66, 0x45, 0xbd, 0x82, 0x90, 0x37, 0x61, 0x6e, 0x40, 0x93, 0xd4, 0xf5,
+	0x06, 0x83, 0x98, 0x26, 0x09, 0x4d, 0x3a, 0x35, 0xb6, 0x74, 0x39, 0xa8, 0xdd, 0x81, 0x95, 0x47,
+	0x34, 0xd5, 0xb8, 0x93, 0x08, 0xb6, 0xdb, 0x3b, 0x40, 0x34, 0xf0, 0x06, 0x4d, 0x3d, 0x3f, 0x48,
+	0xc8, 0xbb, 0xd0, 0x4c, 0x35, 0x64, 0x26, 0xaa, 0x8d, 0x55, 0x72, 0x8f, 0xed, 0xb1, 0x7b, 0x5a,
+	0x03, 0xc7, 0xc0, 0xb3, 0xff, 0x6d, 0x05, 0x1a, 0x07, 0x34, 0x54, 0xbb, 0x8b, 0x40, 0x15, 0x47,
+	0x22, 0x56, 0x92, 0xfd, 0x26, 0xaf, 0x42, 0x83, 0x8d, 0x2e, 0x49, 0x63, 0x3f, 0x3c, 0x61, 0x4b,
+	0x50, 0x77, 0x00, 0x41, 0x07, 0x0c, 0x42, 0xda, 0x30, 0xe5, 0x0d, 0x53, 0xc6, 0xf8, 0x29, 0x07,
+	0x7f, 0xe2, 0xbe, 0x1b, 0x79, 0xe7, 0x43, 0x1a, 0xa6, 0x19, 0xb3, 0x9b, 0x4e, 0x43, 0xc0, 0xb6,
+	0x90, 0xdb, 0xf7, 0x60, 0x51, 0x47, 0x91, 0xd4, 0xa7, 0x19, 0xf5, 0x05, 0x0d, 0x53, 0x74, 0x72,
+	0x1b, 0xe6, 0x25, 0x7e, 0xcc, 0x07, 0xcb, 0xd8, 0x5f, 0x77, 0xe6, 0x04, 0x58, 0x4e, 0xe1, 0x0e,
+	0xb4, 0x8f, 0xfd, 0xd0, 0x0b, 0xdc, 0x7e, 0x90, 0x9e, 0xb9, 0x03, 0x1a, 0xa4, 0x1e, 0x5b, 0x88,
+	0x69, 0x67, 0x8e, 0xc1, 0xd7, 0x83, 0xf4, 0x6c, 0x03, 0xa1, 0xe4, 0x15, 0x80, 0xa1, 0xf7, 0xc2,
+	0x4d, 0x4e, 0xbd, 0x78, 0x80, 0x0b, 0x61, 0xdd, 0x69, 0x39, 0xf5, 0xa1, 0xf7, 0xe2, 0x80, 0x01,
+	0xc8, 0x35, 0xa8, 0x3d, 0xa3, 0xe7, 0x6e, 0x42, 0xc3, 0x41, 0xa7, 0x7e, 0xcb, 0xba, 0x53, 0x73,
+	0x66, 0x9f, 0xd1, 0x73, 0xe4, 0x96, 0xfd, 0x5f, 0x2c, 0x68, 0x72, 0xb6, 0x09, 0x95, 0xf1, 0x06,
+	0xb4, 0xe4, 0xe8, 0x68, 0x1c, 0x47, 0xb1, 0x90, 0x60, 0x13, 0x48, 0xee, 0x42, 0x5b, 0x02, 0x46,
+	0x31, 0xf5, 0x87, 0xde, 0x09, 0x15, 0x7a, 0xa2, 0x00, 0x27, 0xab, 0x19, 0xc5, 0x38, 0x1a, 0xa7,
+	0x7c, 0xd3, 0x36, 0x56, 0x9b, 0x62, 0x49, 0x1d, 0x84, 0x39, 0x26, 0x0a, 0x79, 0x00, 0x4d, 0x36,
+	0x19, 0x5e, 0x4c, 0x3a, 0x55, 0x26, 0x05, 0x66, 0x13, 0x03, 0xc3, 0xfe, 0x85, 0x05, 0xcd, 0xf5,
+	0x53, 0x2f, 0x0c, 0x69, 0xb0, 0x1f, 0xf9, 0x61, 0x4a, 0x1e, 0x00, 0x39, 0x1e, 0x87, 0x03, 0x3f,
+	0x3c, 0x71, 0xd3, 0x17, 0xfe, 0xc0, 0x3d, 0x3a, 0x47, 0x42, 0x4c, 0x1c, 0xb6, 0xae, 0x38, 0x25,
+	0x75, 0xe4, 0x2d, 0x68, 0x1b, 0xd0, 0x24, 0x8d, 0xb9, 0x8c, 0x6c, 0x5d, 0x71, 0x0a, 0x35, 0xb8,
+	0xc9, 0xa2, 0x71, 0x3a, 0x1a, 0xa7, 0xae, 0x1f, 0x0e, 0xe8, 0x0b, 0x36, 0xab, 0x96, 0x63, 0xc0,
+	0x1e, 0xce, 0x41, 0x53, 0x6f, 0x67, 0x7f, 0x08, 0xed, 0x1d, 0xdc, 0x7d, 0xa1, 0x1f, 0x9e, 0xac,
+	0xf1, 0x2d, 0x82, 0x2a, 0x61, 0x34, 0x3e, 0x7a, 0x46, 0xcf, 0x05, 0xa7, 0x45, 0x09, 0x05, 0xf8,
+	0x34, 0x4a, 0x52, 0x21, 0xa5, 0xec, 0xb7, 0xfd, 0xbf, 0x2d, 0x98, 0xc7, 0xd5, 0x7a, 0xec, 0x85,
+	0xe7, 0x52, 0x4a, 0x76, 0xa0, 0x89, 0xa4, 0x0e, 0xa3, 0x35, 0xae, 0x58, 0xf8, 0x86, 0xb9, 0x23,
+	0x58, 0x95, 0xc3, 0xbe, 0xa7, 0xa3, 0xe2, 0xc1, 0x71, 0xee, 0x18, 0xad, 0x71, 0x8b, 0xa4, 0x5e,
+	0x7c, 0x42, 0x53, 0xa6, 0x72, 0x84, 0x0a, 0x02, 0x0e, 0x5a, 0x8f, 0xc2, 0x63, 0x72, 0x0b, 0x9a,
+	0x89, 0x97, 0xba, 0x23, 0x1a, 0x33, 0xae, 0x31, 0x31, 0x9f, 0x72, 0x20, 0xf1, 0xd2, 0x7d, 0x1a,
+	0x3f, 0x3c, 0x4f, 0x69, 0xf7, 0xeb, 0xb0, 0x50, 0xe8, 0x05, 0x77, 0x56, 0x36, 0x45, 0xfc, 0x49,
+	0x96, 0x60, 0xfa, 0xcc, 0x0b, 0xc6, 0x54, 0x68, 0x42, 0x5e, 0x78, 0xbf, 0xf2, 0x9e, 0x65, 0xbf,
+	0x09, 0xed, 0x6c, 0xd8, 0x42, 0x2c, 0x09, 0x54, 0x91, 0x83, 0x82, 0x00, 0xfb, 0x6d, 0xff, 0x1d,
+	0x8b, 0x23, 0xae, 0x47, 0xbe, 0xd2, 0x2a, 0x88, 0x88, 0xca, 0x47, 0x22, 0xe2, 0xef, 0x89, 0x5a,
+	0xf7, 0x0f, 0x9f, 0xac, 0x7d, 0x1b, 0x16, 0xb4, 0x21, 0x5c, 0x30, 0xd8, 0x9f, 0x58, 0xb0, 0xb0,
+	0x4b, 0x9f, 0x8b, 0x55, 0x97, 0xa3, 0x7d, 0x0f, 0xaa, 0xe9, 0xf9, 0x88, 0x1f, 0xfb, 0x73, 0xab,
+	0x6f, 0x88, 0x45, 0x2b, 0xe0, 0xdd, 0x13, 0xc5, 0xc3, 0xf3, 0x11, 0x75, 0x58, 0x0b, 0xfb, 0x43,
+	0x68, 0x68, 0x40, 0x72, 0x15, 0x16, 0x9f, 0x6e, 0x1f, 0xee, 0xf6, 0x0e, 0x0e, 0xdc, 0xfd, 0x27,
+	0x0f, 0x3f, 0xee, 0x7d, 0xdb, 0xdd, 0x5a, 0x3b, 0xd8, 0x6a, 0x5f, 0x21, 0x2b, 0x40, 0x76, 0x7b,
+	0x07, 0x87, 0xbd, 0x0d, 0x03, 0x6e, 0xd9, 0x5d, 0xe8, 0xec, 0xd2, 0xe7, 0x4f, 0xfd, 0x34, 0xa4,
+	0x49, 0x62, 0xf6, 0x66, 0xdf, 0x03, 0xa2, 0x0f, 0x41, 0xcc, 0xaa, 0x03, 0xb3, 0x42, 0xad, 0xcb,
+	0x53, 0x4d, 0x14, 0xed, 0x37, 0x81, 0x1c, 0xf8, 0x27, 0xe1, 0x63, 0x9a, 0x24, 0xde, 0x09, 0x95,
+	0x73, 0x6b, 0xc3, 0xd4, 0x30, 0x39, 0x11, 0x0a, 0x18, 0x7f, 0xda, 0xef, 0xc0, 0xa2, 0x81, 0x27,
+	0x08, 0xdf, 0x80, 0x7a, 0xe2, 0x9f, 0x84, 0x5e, 0x3a, 0x8e, 0xa9, 0x20, 0x9d, 0x01, 0xec, 0x4d,
+	0x58, 0xfa, 0x84, 0xc6, 0xfe, 0xf1, 0xf9, 0xcb, 0xc8, 0x9b, 0x74, 0x2a, 0x79, 0x3a, 0x3d, 0x58,
+	0xce, 0xd1, 0x11, 0xdd, 0x73, 0x41, 0x14, 0xcb, 0x55, 0x73, 0x78, 0x41, 0xdb, 0x96, 0x15, 0x7d,
+	0x5b, 0xda, 0x4f, 0x80, 0xac, 0x47, 0x61, 0x48, 0xfb, 0xe9, 0x3e, 0xa5, 0x71, 0x66, 0xcb, 0x65,
+	0x52, 0xd7, 0x58, 0xbd, 0x2a, 0xd6, 0x31, 0xbf, 0xd7, 0x85, 0x38, 0x12, 0xa8, 0x8e, 0x68, 0x3c,
+	0x64, 0x84, 0x6b, 0x0e, 0xfb, 0x6d, 0x2f, 0xc3, 0xa2, 0x41, 0x56, 0x58, 0x16, 0x6f, 0xc3, 0xf2,
+	0x86, 0x9f, 0xf4, 0x8b, 0x1d, 0x76, 0x60, 0x76, 0x34, 0x3e, 0x72, 0xb3, 0x3d, 0x25, 0x8b, 0x78,
+	0xe0, 0xe6, 0x9b, 0x08, 0x62, 0x7f, 0xdf, 0x82, 0xea, 0xd6, 0xe1, 0xce, 0x3a, 0xe9, 0x42, 0xcd,
+	0x0f, 0xfb, 0xd1, 0x10, 0x8f, 0x29, 0x3e, 0x69, 0x55, 0x9e, 0xb8, 0x57, 0x6e, 0x40, 0x9d, 0x9d,
+	0x6e, 0x68, 0x43, 0x08, 0xb3, 0x2b, 0x03, 0xa0, 0xfd, 0x42, 0x5f, 0x8c, 0xfc, 0x98, 0x19, 0x28,
+	0xd2, 0xec, 0xa8, 0x32, 0x8d, 0x58, 0xac, 0xb0, 0xff, 0xb4, 0x0a, 0xb3, 0x42, 0x57, 0xb3, 0xfe,
+	0xfa, 0xa9, 0x7f, 0x46, 0xc5, 0x48, 0x44, 0x09, 0xcf, 0xa1, 0x98, 0x0e, 0xa3, 0x94, 0xba, 0xc6,
+	0x32, 0x98, 0x40, 0xc4, 0xea, 0x73, 0x42, 0xee, 0x08, 0xb5, 0x3e, 0x1b, 0x59, 0xdd, 0x31, 0x81,
+	0xc8, 0x2c, 0x04, 0xb8, 0xfe, 0x80, 0x8d, 0xa9, 0xea, 0xc8, 0x22, 0x72, 0xa2, 0xef, 0x8d, 0xbc,
+	0xbe, 0x9f, 0x9e, 0x8b, 0xcd, 0xad, 0xca, 0x48, 0x3b, 0x88, 0xfa, 0x5e, 0xe0, 0x1e, 0x79, 0x81,
+	0x17, 0xf6, 0xa9, 0x30, 0x92, 0x4c, 0x20, 0xda, 0x41, 0x62, 0x48, 0x12, 0x8d, 0xdb, 0x4a, 0x39,
+	0x28, 0xda, 0x53, 0xfd, 0x68, 0x38, 0xf4, 0x53, 0x34, 0x9f, 0xd8, 0x11, 0x3d, 0xe5, 0x68, 0x10,
+	0x36, 0x13, 0x5e, 0x7a, 0xce, 0xb9, 0x57, 0xe7, 0xbd, 0x19, 0x40, 0xa4, 0x72, 0x4c, 0x29, 0x53,
+	0x48, 0xcf, 0x9e, 0x77, 0x80, 0x53, 0xc9, 0x20, 0xb8, 0x0e, 0xe3, 0x30, 0xa1, 0x69, 0x1a, 0xd0,
+	0x81, 0x1a, 0x50, 0x83, 0xa1, 0x15, 0x2b, 0xc8, 0x03, 0x58, 0xe4, 0x16, 0x5d, 0xe2, 0xa5, 0x51,
+	0x72, 0xea, 0x27, 0x68, 0x22, 0xa4, 0x9d, 0x26, 0xc3, 0x2f, 0xab, 0x22, 0xef, 0xc1, 0xd5, 0x1c,
+	0x38, 0xa6, 0x7d, 0xea, 0x9f, 0xd1, 0x41, 0xa7, 0xc5, 0x5a, 0x4d, 0xaa, 0x26, 0xb7, 0xa0, 0x81,
+	0x86, 0xec, 0x78, 0x34, 0xf0, 0xf0, 0x1c, 0x9e, 0x63, 0xeb, 0xa0, 0x83, 0xc8, 0xdb, 0xd0, 0x1a,
+	0x51, 0x7e, 0x58, 0x9e, 0xa6, 0x41, 0x3f, 0xe9, 0xcc, 0xb3, 0x93, 0xac, 0x21, 0x36, 0x13, 0x4a,
+	0xae, 0x63, 0x62, 0xa0, 0x50, 0xf6, 0x13, 0x66, 0x1a, 0x79, 0xe7, 0x9d, 0x36, 0x37, 0x7b, 0x14,
+	0x80, 0xed, 0x91, 0xd8, 0x3f, 0xf3, 0x52, 0xda, 0x59, 0xe0, 0x56, 0x8f, 0x28, 0xda, 0xff, 0xdc,
+	0x82, 0xc5, 0x1d, 0x3f, 0x49, 0x85, 0x10, 0x2a, 0x75, 0xfc, 0x2a, 0x34, 0xb8, 0xf8, 0xb9, 0x51,
+	0x18, 0x9c, 0x0b, 0x89, 0x04, 0x0e, 0xda, 0x0b, 0x83, 0x73, 0xf2, 0x3a, 0xb4, 0xfc, 0x50, 0x47,
+	0xe1, 0x7b, 0xb8, 0x29, 0x81, 0x0c, 0xe9, 0x55, 0x68, 0x8c, 0xc6, 0x47, 0x81, 0xdf, 0xe7, 0x28,
+	0x53, 0x9c, 0x0a, 0x07, 0x31, 0x04, 0x34, 0x2a, 0xf9, 0x48, 0x38, 0x46, 0x95, 0x61, 0x34, 0x04,
+	0x0c, 0x51, 0xec, 0x87, 0xb0, 0x64, 0x0e, 0x50, 0x28, 0xab, 0xbb, 0x50, 0x13, 0xb2, 0x9d, 0x74,
+	0x1a, 0x8c, 0x3f, 0x73, 0x82, 0x3f, 0x02, 0xd5, 0x51, 0xf5, 0xf6, 0xff, 0xb5, 0xa0, 0x8a, 0x0a,
+	0x60, 0xb2, 0xb2, 0xd0, 0x75, 0xfa, 0x94, 0xa1, 0xd3, 0x99, 0x8f, 0x81, 0x56, 0x11, 0x17, 0x09,
+	0xbe, 0x6d, 0x34, 0x48, 0x56, 0x1f, 0xd3, 0xfe, 0x19, 0xdb, 0x3b, 0xaa, 0x1e, 0x21, 0xb8, 0xb3,
+	0xf0, 0xe8, 0x64, 0xad, 0xf9, 0xc6, 0x51, 0x65, 0x59, 0xc7, 0x5a, 0xce, 0x66, 0x75, 0xac, 0x5d,
+	0x07, 0x66, 0xfd, 0xf0, 0x28, 0x1a, 0x87, 0x03, 0xb6, 0x49, 0x6a, 0x8e, 0x2c, 0xe2, 0x62, 0x8f,
+	0x98, 0x25, 0xe5, 0x0f, 0xa9, 0xd8, 0x1d, 0x19, 0xc0, 0x26, 0x68, 0x5a, 0x25, 0x4c, 0xe1, 0xa9,
+	0x73, 0xec, 0x5d, 0x58, 0xd0, 0x60, 0x82, 0x83, 0xaf, 0xc1, 0xf4, 0x08, 0x01, 0xc2, 0x50, 0x92,
+	0xe2, 0xc5, 0x34, 0x25, 0xaf, 0xb1, 0xdb, 0xe8, 0xab, 0xa7, 0xdb, 0xe1, 0x71, 0x24, 0x29, 0xfd,
+	0xa7, 0x29, 0x74, 0xae, 0x05, 0x48, 0x10, 0xba, 0x03, 0xf3, 0xfe, 0x80, 0x86, 0xa9, 0x9f, 0x9e,
+	0xbb, 0x86, 0x05, 0x97, 0x07, 0xe3, 0x09, 0xe3, 0x05, 0xbe, 0x97, 0x08, 0x1d, 0xc6, 0x0b, 0x64,
+	0x15, 0x96, 0x50, 0xfc, 0xa5, 0x44, 0xab, 0x65, 0xe5, 0x86, 0x64, 0x69, 0x1d, 0xee, 0x58, 0x84,
+	0x0b, 0x09, 0x54, 0x4d, 0xb8, 0xa6, 0x2d, 0xab, 0x42, 0xae, 0x71, 0x4a, 0x38, 0xe5, 0x69, 0xbe,
+	0x45, 0x14, 0xa0, 0xe0, 0x29, 0xce, 0x70, 0x23, 0x36, 0xef, 0x29, 0x6a, 0xde, 0x66, 0xad, 0xe0,
+	0x6d, 0xde, 0x81, 0xf9, 0xe4, 0x3c, 0xec, 0xd3, 0x81, 0x9b, 0x46, 0xd8, 0xaf, 0x1f, 0x0a, 0x27,
+	0x23, 0x0f, 0x66, 0x7e, 0x31, 0x4d, 0xd2, 0x90, 0xa6, 0x4c, 0x75, 0xd5, 0x1c, 0x59, 0xc4, 0x53,
+	0x80, 0xa1, 0x70, 0xa1, 0xae, 0x3b, 0xa2, 0x84, 0x47, 0xe5, 0x38, 0xf6, 0x93, 0x4e, 0x93, 0x41,
+	0xd9, 0x6f, 0xf2, 0x25, 0x58, 0x3e, 0x42, 0x2f, 0xee, 0x94, 0x7a, 0x03, 0x1a, 0xb3, 0xd5, 0xe7,
+	0x4e, 0x2c, 0xd7, 0x40, 0xe5, 0x95, 0xf6, 0xa7, 0xec, 0xdc, 0x56, 0x4e, 0xf4, 0x13, 0xa6, 0x74,
+	0xc8, 0x75, 0xa8, 0xf3, 0x99, 0x24, 0xa7, 0x9e, 0x30, 0x25, 0x6a, 0x0c, 0x70, 0x70, 0xea, 0xe1,
+	0x36, 0x35, 0x98, 0x53, 0x61, 0xf6, 0x61, 0x83, 0xc1, 0xb6, 0x38, 0x6f, 0xde, 0x80, 0x39, 0xe9,
+	0x9e, 0x27, 0x6e, 0x40, 0x8f, 0x53, 0xe9, 0x06, 0x84, 0xe3, 0x21, 0x76, 0x97, 0xec, 0xd0, 0xe3,
+	0xd4, 0xde, 0x85, 0x05, 0xb1, 0x3b, 0xf7, 0x46, 0x54, 0x76, 0xfd, 0x95, 0xfc, 0xd1, 0xc5, 0x6d,
+	0x87, 0x45, 0x73, 0x3b, 0x33, 0x5f, 0x26, 0x77, 0x9e, 0xd9, 0x0e, 0x10, 0x51, 0xbd, 0x1e, 0x44,
+	0x09, 0x15, 0x04, 0x6d, 0x68, 0xf6, 0x83, 0x28, 0x91, 0xce, 0x86, 0x98, 0x8e, 0x01, 0xc3, 0x15,
+	0x48, 0xc6, 0xfd, 0x3e, 0xee, 0x77, 0xae, 0xb9, 0x64, 0xd1, 0xfe, 0x1f, 0x16, 0x2c, 0x32, 0x6a,
+	0x52, 0x8f, 0x28, 0x0b, 0xf5, 0xf2, 0xc3, 0x6c, 0xf6, 0x75, 0x07, 0x6c, 0x09, 0xa6, 0x8f, 0xa3,
+	0xb8, 0x4f, 0x45, 0x4f, 0xbc, 0xf0, 0xbb, 0xdb, 0xdc, 0xd5, 0xbc, 0xcd, 0x8d, 0xce, 0x27, 0xf3,
+	0x76, 0x8b, 0x96, 0x79, 0x01, 0x6e, 0xff, 0xda, 0x82, 0x05, 0x36, 0xad, 0x83, 0xd4, 0x4b, 0xc7,
+	0x89, 0x60, 0xd5, 0x57, 0xa1, 0x85, 0x6c, 0xa1, 0x72, 0x83, 0x89, 0x49, 0x2d, 0x29, 0x5d, 0xc0,
+	0xa0, 0x1c, 0x79, 0xeb, 0x8a, 0x63, 0x22, 0x93, 0xaf, 0x43, 0x53, 0x8f, 0xc7, 0xb0, 0xf9, 0x35,
+	0x56, 0xaf, 0x49, 0x8e, 0x14, 0xa4, 0x6c, 0xeb, 0x8a, 0x63, 0x34, 0x20, 0x1f, 0x00, 0x30, 0x03,
+	0x84, 0x91, 0x15, 0xee, 0xf0, 0x35, 0x93, 0xa1, 0xda, 0xc2, 0x6e, 0x5d, 0x71, 0x34, 0xf4, 0x87,
+	0x35, 0x98, 0xe1, 0x27, 0xa6, 0xfd, 0x08, 0x5a, 0xc6, 0x48, 0x0d, 0xbf, 0xa3, 0xc9, 0xfd, 0x8e,
+	0x82, 0x9b, 0x5a, 0x29, 0xba, 0xa9, 0xf6, 0xbf, 0x99, 0x02, 0x82, 0x92, 0x99, 0x5b, 0x7a, 0x3c,
+	0xb2, 0xa3, 0x81, 0x61, 0x80, 0x35, 0x1d, 0x1d, 0x44, 0xee, 0x01, 0xd1, 0x8a, 0x32, 0xf2, 0xc1,
+	0x4f, 0x92, 0x92, 0x1a, 0x54, 0x79, 0xdc, 0x7a, 0x92, 0x5e, 0xb1, 0x30, 0x35, 0xf9, 0x1a, 0x97,
+	0xd6, 0xe1, 0x61, 0x31, 0x1a, 0x27, 0xa7, 0xb8, 0xac, 0xd2, 0x44, 0x93, 0xe5, 0xbc, 0x30, 0xcd,
+	0xbc, 0x54, 0x98, 0x66, 0x0b, 0xc2, 0xa4, 0x19, 0x09, 0x35, 0xc3, 0x48, 0x40, 0x8b, 0x6c, 0xe8,
+	0x87, 0xcc, 0xd2, 0x70, 0x87, 0xd8, 0xbb, 0xb0, 0xc8, 0x0c, 0x20, 0x0a, 0xa3, 0xb0, 0xf4, 0x32,
+	0x4b, 0x04, 0x18, 0x8f, 0x0b, 0x70, 0x66, 0xe3, 0x31, 0x49, 0x92, 0x67, 0x6e, 0x43, 0x58, 0xab,
+	0x3a, 0x10, 0x57, 0x6c, 0x94, 0x1c, 0xa5, 0x92, 0x0f, 0xcc, 0x1c, 0xab, 0x39, 0x06, 0xcc, 0xfe,
+	0x69, 0x05, 0xda, 0xb8, 0x62, 0x86, 0x54, 0xbf, 0x0f, 0x6c, 0x03, 0x5e, 0x52, 0xa8, 0x0d, 0xdc,
+	0x3f, 0x5c, 0xa6, 0xdf, 0x83, 0x3a, 0x23, 0x18, 0x8d, 0x68, 0x28, 0x44, 0xba, 0x63, 0x8a, 0x74,
+	0xa6, 0xfb, 0xb6, 0xae, 0x38, 0x19, 0x32, 0x79, 0x1f, 0xea, 0x6a, 0x6e, 0x4c, 0x12, 0x1a, 0xab,
+	0x5d, 0x19, 0xe8, 0xa1, 0xde, 0xe0, 0x7c, 0x33, 0x8a, 0xf7, 0x93, 0xa3, 0x74, 0x93, 0x4f, 0x1d,
+	0xdb, 0x2a, 0x74, 0x6d, 0x33, 0xfc, 0x37, 0x0b, 0x1a, 0x62, 0x8a, 0xbf, 0xb7, 0x8f, 0xd3, 0x85,
+	0x1a, 0xee, 0x0b, 0xcd, 0x91, 0x50, 0x65, 0x3c, 0xe5, 0x86, 0xe8, 0x48, 0xe2, 0xb1, 0x6e, 0xf8,
+	0x37, 0x79, 0x30, 0x9e, 0xd1, 0xec, 0x88, 0x48, 0xdc, 0xd4, 0x0f, 0x5c, 0x59, 0x2b, 0x82, 0xb0,
+	0x65, 0x55, 0xa8, 0x29, 0x93, 0xd4, 0x3b, 0xa1, 0xe2, 0xf8, 0xe5, 0x05, 0x74, 0xe4, 0xc4, 0x84,
+	0x72, 0x66, 0xaa, 0xfd, 0x2b, 0x80, 0xab, 0x85, 0x2a, 0x15, 0xf2, 0x17, 0x86, 0x7b, 0xe0, 0x0f,
+	0x8f, 0x22, 0xe5, 0x03, 0x58, 0xba, 0x4d, 0x6f, 0x54, 0x91, 0x13, 0x58, 0x96, 0x76, 0x06, 0xae,
+	0x47, 0x66, 0x55, 0x54, 0x98, 0x81, 0xf4, 0xb6, 0x29, 0x3f, 0xf9, 0x0e, 0x25, 0x5c, 0xd7, 0x1f,
+	0xe5, 0xf4, 0xc8, 0x29, 0x74, 0x94, 0x41, 0x23, 0x0e, 0x25, 0xcd, 0xe8, 0xc1, 0xbe, 0xde, 0x7a,
+	0x49, 0x5f, 0x4c, 0x2b, 0x0e, 0x64, 0x37, 0x13, 0xa9, 0x91, 0x73, 0xb8, 0x29, 0xeb, 0xd8, 0xa9,
+	0x53, 0xec, 0xaf, 0x7a, 0xa9, 0xb9, 0x6d, 0x62, 0x63, 0xb3, 0xd3, 0x97, 0x10, 0xee, 0xfe, 0xca,
+	0x82, 0x39, 0x93, 0x1c, 0x8a, 0x8e, 0x50, 0x05, 0x52, 0x25, 0x4a, 0x43, 0x31, 0x07, 0x2e, 0xba,
+	0xb3, 0x95, 0x32, 0x77, 0x56, 0x77, 0x5a, 0xa7, 0x5e, 0xe6, 0xb4, 0x56, 0x2f, 0xe7, 0xb4, 0x4e,
+	0x97, 0x39, 0xad, 0xdd, 0xdf, 0x5a, 0x40, 0x8a, 0xeb, 0x4b, 0x1e, 0x71, 0x7f, 0x3a, 0xa4, 0x81,
+	0xd0, 0x31, 0x7f, 0xfd, 0x72, 0x32, 0x22, 0x79, 0x28, 0x5b, 0xa3, 0xb0, 0xea, 0x4a, 0x44, 0x37,
+	0xb4, 0x5a, 0x4e, 0x59, 0x55, 0xce, 0x8d, 0xae, 0xbe, 0xdc, 0x8d, 0x9e, 0x7e, 0xb9, 0x1b, 0x3d,
+	0x93, 0x77, 0xa3, 0xbb, 0x7f, 0x0b, 0x5a, 0xc6, 0xaa, 0xff, 0xf1, 0x66, 0x9c, 0x37, 0xd2, 0xf8,
+	0x02, 0x1b, 0xb0, 0xee, 0xff, 0xab, 0x00, 0x29, 0x4a, 0xde, 0x5f, 0xea, 0x18, 0x98, 0x1c, 0x19,
+	0x0a, 0x64, 0x4a, 0xc8, 0x91, 0xa1, 0x3a, 0xfe, 0x22, 0x95, 0xe2, 0x5b, 0xb0, 0x10, 0xd3, 0x7e,
+	0x74, 0xc6, 0x2e, 0x22, 0xcd, 0x10, 0x4c, 0xb1, 0x02, 0xcd, 0x54, 0x33, 0x78, 0x50, 0x33, 0xee,
+	0x8d, 0xb4, 0x93, 0x21, 0x17, 0x43, 0xb0, 0x57, 0x60, 0x89, 0x5f, 0xe7, 0x3d, 0xe4, 0xa4, 0xa4,
+	0x92, 0xfd, 0x67, 0x16, 0x2c, 0xe7, 0x2a, 0xb2, 0x2b, 0x12, 0xae, 0x47, 0x4d, 0xe5, 0x6a, 0x02,
+	0x71, 0xfc, 0x42, 0x80, 0xb5, 0xf1, 0xf3, 0xf3, 0xa6, 0x58, 0x81, 0xfc, 0x19, 0x87, 0x45, 0x7c,
+	0xce, 0xf5, 0xb2, 0x2a, 0xfb, 0x2a, 0x2c, 0x8b, 0x95, 0xcd, 0x0d, 0x7c, 0x15, 0x56, 0xf2, 0x15,
+	0x59, 0x04, 0xd7, 0x1c, 0xb2, 0x2c, 0xda, 0xdf, 0x07, 0xf2, 0xcd, 0x31, 0x8d, 0xcf, 0xd9, 0xcd,
+	0x8a, 0x0a, 0x87, 0x5c, 0xcd, 0xc7, 0x0d, 0x66, 0x46, 0xe3, 0xa3, 0x8f, 0xe9, 0xb9, 0xbc, 0x27,
+	0xab, 0x64, 0xf7, 0x64, 0xaf, 0x00, 0xa0, 0x23, 0x24, 0xae, 0x6b, 0xb8, 0x55, 0x8f, 0x7e, 0x26,
+	0x27, 0x68, 0x7f, 0x00, 0x8b, 0x06, 0x7d, 0xc5, 0xc9, 0x19, 0xd1, 0xc2, 0x2a, 0xb9, 0xe0, 0x11,
+	0x75, 0xf6, 0x9f, 0x59, 0x30, 0xb5, 0x15, 0x8d, 0xf4, 0x30, 0x9e, 0x65, 0x86, 0xf1, 0x84, 0xde,
+	0x74, 0x95, 0x5a, 0xac, 0x88, 0x5d, 0xaf, 0x03, 0x51, 0xeb, 0x79, 0xc3, 0x14, 0xdd, 0xd1, 0xe3,
+	0x28, 0x7e, 0xee, 0xc5, 0x03, 0xc1, 0xde, 0x1c, 0x14, 0x67, 0x97, 0x29, 0x17, 0xfc, 0x89, 0x06,
+	0x03, 0x8b, 0x62, 0x9e, 0x0b, 0x0f, 0x5a, 0x94, 0x70, 0xd5, 0xcc, 0xb6, 0xdc, 0x50, 0xe4, 0x52,
+	0x5a, 0x56, 0x85, 0xba, 0x1b, 0xf5, 0x0c, 0x43, 0x13, 0xa1, 0x0f, 0x59, 0xd6, 0xc3, 0x34, 0x35,
+	0x33, 0xa6, 0xfb, 0x1b, 0x0b, 0xa6, 0x19, 0x4f, 0x70, 0xc7, 0x71, 0x31, 0x63, 0x57, 0xb5, 0x2c,
+	0x18, 0x6b, 0xf1, 0x1d, 0x97, 0x03, 0xe7, 0x2e, 0x70, 0x2b, 0x85, 0x0b, 0xdc, 0x1b, 0x50, 0xe7,
+	0xa5, 0xec, 0xc6, 0x33, 0x03, 0x90, 0x9b, 0x50, 0x3d, 0x8d, 0x46, 0xf2, 0x9c, 0x04, 0x19, 0x83,
+	0x8b, 0x46, 0x0e, 0x83, 0x67, 0xe3, 0x40, 0x5a, 0x7c, 0x3a, 0x5c, 0xd3, 0xe6, 0xc1, 0xc8, 0x75,
+	0x45, 0x56, 0x67, 0x4f, 0x0e, 0x6a, 0xdf, 0x85, 0xf9, 0xdd, 0x68, 0x40, 0xb5, 0xa8, 0xcb, 0x44,
+	0xf9, 0xb3, 0xff, 0xb6, 0x05, 0x35, 0x89, 0x4c, 0xee, 0x40, 0x15, 0x0f, 0xd0, 0x9c, 0xb9, 0xab,
+	0x62, 0xef, 0x88, 0xe7, 0x30, 0x0c, 0x54, 0x7c, 0xcc, 0x5b, 0xcf, 0x0c, 0x1c, 0xe9, 0xab, 0x67,
+	0xa6, 0x83, 0x1a, 0x6e, 0xee, 0x88, 0xcd, 0x41, 0xed, 0x7f, 0x65, 0x41, 0xcb, 0xe8, 0x03, 0xdd,
+	0xa5, 0xc0, 0x4b, 0x52, 0x11, 0xcf, 0x14, 0xcb, 0xa2, 0x83, 0xf4, 0x05, 0xae, 0x98, 0x71, 0x38,
+	0x15, 0x21, 0x9a, 0xd2, 0x23, 0x44, 0x0f, 0xa0, 0x9e, 0x5d, 0xaf, 0x57, 0x0d, 0x85, 0x86, 0x3d,
+	0xca, 0x5b, 0x85, 0x0c, 0x09, 0xe9, 0xf4, 0xa3, 0x20, 0x8a, 0xc5, 0xed, 0x33, 0x2f, 0xd8, 0x1f,
+	0x40, 0x43, 0xc3, 0xc7, 0x61, 0x84, 0x34, 0x7d, 0x1e, 0xc5, 0xcf, 0x64, 0x38, 0x50, 0x14, 0xd5,
+	0xe5, 0x59, 0x25, 0xbb, 0x3c, 0xb3, 0xff, 0xb5, 0x05, 0x2d, 0x94, 0x3d, 0x3f, 0x3c, 0xd9, 0x8f,
+	0x02, 0xbf, 0x7f, 0xce, 0xd6, 0x5e, 0x8a, 0x99, 0xb8, 0x96, 0x96, 0x32, 0x68, 0x82, 0x51, 0xda,
+	0xa5, 0xb7, 0x24, 0x24, 0x50, 0x95, 0x71, 0xcf, 0xa2, 0xe4, 0x1f, 0x79, 0x89, 0xd8, 0x0e, 0xe2,
+	0x84, 0x31, 0x80, 0xb8, 0xc3, 0x10, 0x10, 0x7b, 0x29, 0x75, 0x87, 0x7e, 0x10, 0xf8, 0x1c, 0x97,
+	0xef, 0xcd, 0xb2, 0x2a, 0xfb, 0x97, 0x15, 0x68, 0x08, 0xfd, 0xd7, 0x1b, 0x9c, 0xf0, 0xc0, 0xbb,
+	0x30, 0x9f, 0x94, 0xe2, 0xd0, 0x20, 0xb2, 0xde, 0x30, 0xb8, 0x34, 0x48, 0x7e, 0x59, 0xa7, 0x8a,
+	0xcb, 0x7a, 0x03, 0xea, 0x28, 0x5e, 0x6f, 0x33, 0xcb, 0x8e, 0x67, 0x63, 0x64, 0x00, 0x59, 0xbb,
+	0xca, 0x6a, 0xa7, 0xb3, 0x5a, 0x06, 0x30, 0x6c, 0xb9, 0x99, 0x9c, 0x2d, 0xf7, 0x1e, 0x34, 0x05,
+	0x19, 0xc6, 0x77, 0xa6, 0x2f, 0x32, 0x01, 0x37, 0xd6, 0xc4, 0x31, 0x30, 0x65, 0xcb, 0x55, 0xd9,
+	0xb2, 0xf6, 0xb2, 0x96, 0x12, 0x93, 0xdd, 0x43, 0x71, 0xde, 0x3c, 0x8a, 0xbd, 0xd1, 0xa9, 0x3c,
+	0x53, 0x06, 0xea, 0x72, 0x9d, 0x81, 0xc9, 0x5d, 0x98, 0xc6, 0x66, 0x52, 0x6f, 0x97, 0x6f, 0x3a,
+	0x8e, 0x42, 0xee, 0xc0, 0x34, 0x1d, 0x9c, 0x50, 0xe9, 0x4f, 0x10, 0xd3, 0x2b, 0xc4, 0x35, 0x72,
+	0x38, 0x02, 0xaa, 0x00, 0x84, 0xe6, 0x54, 0x80, 0xa9, 0xf3, 0x67, 0xb0, 0xb8, 0x3d, 0xb0, 0x97,
+	0x80, 0xec, 0x72, 0xa9, 0xd5, 0xe3, 0xb4, 0x7f, 0x6f, 0x0a, 0x1a, 0x1a, 0x18, 0x77, 0xf3, 0x09,
+	0x0e, 0xd8, 0x1d, 0xf8, 0xde, 0x90, 0xa6, 0x34, 0x16, 0x92, 0x9a, 0x83, 0xb2, 0xa3, 0xe1, 0xec,
+	0xc4, 0x8d, 0xc6, 0xa9, 0x3b, 0xa0, 0x27, 0x31, 0xe5, 0x27, 0xb5, 0xe5, 0xe4, 0xa0, 0x88, 0x37,
+	0xf4, 0x5e, 0xe8, 0x78, 0x5c, 0x1e, 0x72, 0x50, 0x19, 0x75, 0xe5, 0x3c, 0xaa, 0x66, 0x51, 0x57,
+	0xce, 0x91, 0xbc, 0x1e, 0x9a, 0x2e, 0xd1, 0x43, 0xef, 0xc2, 0x0a, 0xd7, 0x38, 0x62, 0x6f, 0xba,
+	0x39, 0x31, 0x99, 0x50, 0x4b, 0xee, 0x42, 0x1b, 0xc7, 0x2c, 0x05, 0x3c, 0xf1, 0x3f, 0xe5, 0x51,
+	0x0f, 0xcb, 0x29, 0xc0, 0x59, 0x20, 0xcd, 0x0f, 0x4d, 0xdc, 0x9a, 0x08, 0xa4, 0xe5, 0xe0, 0x32,
+	0xe8, 0x66, 0xe0, 0xd6, 0xb3, 0xa0, 0x9b, 0x0e, 0xb7, 0x5b, 0xd0, 0x38, 0x48, 0xa3, 0x91, 0x5c,
+	0x94, 0x39, 0x68, 0xf2, 0xa2, 0xb8, 0x87, 0xbc, 0x0e, 0xd7, 0x98, 0x14, 0x1d, 0x46, 0xa3, 0x28,
+	0x88, 0x4e, 0xce, 0x0f, 0xc6, 0x47, 0x49, 0x3f, 0xf6, 0x47, 0x68, 0xe7, 0xdb, 0xff, 0xd5, 0x82,
+	0x45, 0xa3, 0x56, 0x04, 0x37, 0xbe, 0xc4, 0x45, 0x5a, 0x5d, 0x20, 0x71, 0xc1, 0x5b, 0xd0, 0xd4,
+	0x21, 0x47, 0xe4, 0x01, 0xaa, 0x27, 0xe2, 0x4e, 0x69, 0x0d, 0xe6, 0xe5, 0xc8, 0x64, 0x43, 0x2e,
+	0x85, 0x9d, 0xa2, 0x14, 0x8a, 0xf6, 0x73, 0xa2, 0x81, 0x24, 0xf1, 0x35, 0x6e, 0x2d, 0xd3, 0x01,
+	0x9b, 0xa3, 0xf4, 0x54, 0x65, 0x84, 0xc2, 0x30, 0xd1, 0xe5, 0x08, 0xfa, 0x0a, 0x98, 0xd8, 0x3f,
+	0xb6, 0x00, 0xb2, 0xd1, 0xa1, 0x60, 0x64, 0x2a, 0x9d, 0xa7, 0xe1, 0x69, 0xea, 0xfb, 0x35, 0x68,
+	0xaa, 0xbb, 0x83, 0xec, 0x94, 0x68, 0x48, 0x18, 0x9a, 0x5e, 0xb7, 0x61, 0xfe, 0x24, 0x88, 0x8e,
+	0xd8, 0x11, 0xcb, 0x2e, 0xb6, 0x13, 0x71, 0x1b, 0x3b, 0xc7, 0xc1, 0x9b, 0x02, 0x9a, 0x1d, 0x29,
+	0x55, 0xed, 0x48, 0xb1, 0x7f, 0x52, 0x51, 0xb1, 0xe8, 0x6c, 0xce, 0x13, 0x77, 0x19, 0x59, 0x2d,
+	0x28, 0xc7, 0x09, 0xa1, 0x5f, 0x16, 0xcf, 0xd9, 0x7f, 0xa9, 0x7b, 0xfa, 0x01, 0xcc, 0xc5, 0x5c,
+	0xfb, 0x48, 0xd5, 0x54, 0xbd, 0x40, 0x35, 0xb5, 0x62, 0xe3, 0xdc, 0xf9, 0xab, 0xd0, 0xf6, 0x06,
+	0x67, 0x34, 0x4e, 0x7d, 0xe6, 0xa7, 0xb0, 0x43, 0x9f, 0x2b, 0xd4, 0x79, 0x0d, 0xce, 0xce, 0xe2,
+	0xdb, 0x30, 0x2f, 0x6e, 0xc0, 0x15, 0xa6, 0xc8, 0xb1, 0xca, 0xc0, 0x88, 0x68, 0x7f, 0x21, 0xc3,
+	0xde, 0xe6, 0x1a, 0x4e, 0xe6, 0x88, 0x3e, 0xbb, 0x4a, 0x6e, 0x76, 0xaf, 0x8b, 0xf8, 0xde, 0x40,
+	0x3a, 0x43, 0xe2, 0x32, 0x80, 0x03, 0xc5, 0x95, 0x81, 0xc9, 0xd2, 0xea, 0x65, 0x58, 0x6a, 0xff,
+	0xfb, 0x2a, 0xcc, 0x6e, 0x87, 0x67, 0x91, 0xdf, 0x67, 0x41, 0xde, 0x21, 0x1d, 0x46, 0x32, 0xb9,
+	0x04, 0x7f, 0xe3, 0x89, 0xce, 0x2e, 0x5a, 0x47, 0xa9, 0x88, 0xd2, 0xca, 0x22, 0x9e, 0x6e, 0x71,
+	0x96, 0xa2, 0xc5, 0x25, 0x45, 0x83, 0xa0, 0x65, 0x1b, 0xeb, 0x99, 0x6d, 0xa2, 0x94, 0x65, 0xe7,
+	0x4c, 0x6b, 0xd9, 0x39, 0xec, 0xfa, 0x80, 0xdf, 0x21, 0x33, 0x76, 0xd6, 0x1c, 0x59, 0x64, 0x16,
+	0x78, 0x4c, 0xb9, 0xab, 0xce, 0xce, 0xc9, 0x59, 0x61, 0x81, 0xeb, 0x40, 0x3c, 0x4b, 0x79, 0x03,
+	0x8e, 0xc3, 0x75, 0x8d, 0x0e, 0x42, 0xdb, 0x22, 0x9f, 0x1c, 0x57, 0xe7, 0x4b, 0x9c, 0x03, 0xa3,
+	0x42, 0x1a, 0x50, 0xa5, 0x37, 0xf8, 0x1c, 0x80, 0xa7, 0xa0, 0xe5, 0xe1, 0x9a, 0xfd, 0xce, 0xef,
+	0xc2, 0xa5, 0xfd, 0x8e, 0x36, 0x88, 0x17, 0x04, 0x47, 0x5e, 0xff, 0x19, 0x0b, 0xbf, 0xb2, 0x58,
+	0x6b, 0xdd, 0x31, 0x81, 0x38, 0x6a, 0x96, 0x81, 0x27, 0x48, 0xb4, 0xf8, 0xd5, 0xb5, 0x06, 0x22,
+	0x6f, 0xb3, 0x00, 0x5e, 0x4a, 0xd9, 0xb5, 0xf6, 0xdc, 0xea, 0x75, 0xb1, 0x9c, 0x62, 0xc9, 0xe4,
+	0xff, 0x07, 0x88, 0xe2, 0x70, 0x4c, 0x3d, 0xee, 0x3c, 0x6f, 0x5e, 0x4e, 0xef, 0x42, 0x53, 0x6f,
+	0x40, 0x6a, 0x50, 0xdd, 0xdb, 0xef, 0xed, 0xb6, 0xaf, 0x90, 0x06, 0xcc, 0x1e, 0xf4, 0x0e, 0x0f,
+	0x77, 0x7a, 0x1b, 0x6d, 0x8b, 0x34, 0xa1, 0xb6, 0xbe, 0xb6, 0xbb, 0xde, 0xc3, 0x52, 0x05, 0x4b,
+	0x6b, 0xeb, 0xeb, 0xbd, 0xfd, 0xc3, 0xde, 0x46, 0x7b, 0x0a, 0x11, 0x7b, 0xdf, 0xda, 0xdf, 0x76,
+	0x7a, 0x1b, 0xed, 0xaa, 0xfd, 0x09, 0x90, 0xb5, 0xc1, 0x40, 0x90, 0x54, 0xae, 0x57, 0xb6, 0xf0,
+	0x96, 0xb1, 0xf0, 0x25, 0x0b, 0x50, 0x29, 0x5d, 0x00, 0xbb, 0x07, 0x8d, 0x7d, 0x2d, 0x0d, 0x92,
+	0x49, 0x9a, 0x4c, 0x80, 0x14, 0xd2, 0xa9, 0x41, 0xb4, 0x0e, 0x2b, 0x7a, 0x87, 0xf6, 0xdf, 0x00,
+	0xb2, 0xe3, 0x27, 0xa9, 0x1a, 0x1f, 0x5f, 0xdd, 0xd7, 0xa0, 0xa9, 0x82, 0x88, 0xd9, 0x55, 0x7c,
+	0x43, 0xc0, 0xd8, 0x15, 0xf9, 0x1a, 0xbf, 0xc3, 0xcf, 0x4f, 0xec, 0x2e, 0xd4, 0x7c, 0x0e, 0x92,
+	0x87, 0xc4, 0x9c, 0xb9, 0x1c, 0x8e, 0xaa, 0x47, 0x6b, 0x47, 0xb2, 0x5a, 0x3f, 0x83, 0x7e, 0x69,
+	0xc1, 0xac, 0x98, 0x1a, 0x8b, 0xc6, 0xeb, 0x09, 0xa0, 0x7c, 0x62, 0x06, 0xac, 0x3c, 0x95, 0xad,
+	0xb8, 0x25, 0xa6, 0xca, 0xb6, 0x04, 0x81, 0xea, 0xc8, 0x4b, 0x4f, 0x99, 0x79, 0x5f, 0x77, 0xd8,
+	0x6f, 0xe9, 0x80, 0x4e, 0x67, 0x0e, 0x68, 0x59, 0xbe, 0x25, 0x57, 0x68, 0x05, 0x38, 0x4e, 0x8a,
+	0xdd, 0x7a, 0x73, 0xb8, 0x0a, 0x1a, 0x8b, 0x8c, 0x82, 0x0c, 0x9c, 0xf1, 0x4b, 0x90, 0xc8, 0xf3,
+	0x4b, 0xa0, 0x3a, 0xaa, 0xde, 0xee, 0x42, 0x67, 0x83, 0x06, 0x34, 0xa5, 0x6b, 0x41, 0x90, 0xa7,
+	0x7f, 0x1d, 0xae, 0x95, 0xd4, 0x89, 0x23, 0x7f, 0x13, 0x16, 0x36, 0xe8, 0xd1, 0xf8, 0x64, 0x87,
+	0x9e, 0x65, 0xf7, 0x4b, 0x04, 0xaa, 0xc9, 0x69, 0xf4, 0x5c, 0xac, 0x2d, 0xfb, 0x4d, 0x5e, 0x01,
+	0x08, 0x10, 0xc7, 0x4d, 0x46, 0xb4, 0x2f, 0x93, 0xb8, 0x18, 0xe4, 0x60, 0x44, 0xfb, 0xf6, 0xbb,
+	0x40, 0x74, 0x3a, 0x62, 0x0a, 0xa8, 0x56, 0xc6, 0x47, 0x6e, 0x72, 0x9e, 0xa4, 0x74, 0x28, 0xb3,
+	0xd3, 0x74, 0x90, 0x7d, 0x1b, 0x9a, 0xfb, 0xde, 0xb9, 0x43, 0x7f, 0x24, 0x72, 0x70, 0xd1, 0xb3,
+	0xf4, 0xce, 0x51, 0x94, 0x95, 0x67, 0xc9, 0xaa, 0xed, 0xff, 0x58, 0x81, 0x19, 0x8e, 0x89, 0x54,
+	0x07, 0x34, 0x49, 0xfd, 0x90, 0xdf, 0x88, 0x08, 0xaa, 0x1a, 0xa8, 0x20, 0x1b, 0x95, 0x12, 0xd9,
+	0x10, 0xb6, 0x9e, 0x4c, 0x88, 0x11, 0x42, 0x60, 0xc0, 0x98, 0x2b, 0xae, 0x6e, 0xb1, 0xab, 0xc2,
+	0x15, 0x97, 0x80, 0x5c, 0xf0, 0x21, 0x53, 0x5e, 0x7c, 0x7c, 0x52, 0x68, 0x85, 0x38, 0xe8, 0xa0,
+	0x52, 0x15, 0x39, 0xcb, 0xa5, 0xa6, 0xa0, 0x22, 0x0b, 0xaa, 0xb0, 0x76, 0x09, 0x55, 0xc8, 0x0d,
+	0x40, 0x1d, 0x64, 0x13, 0x68, 0x6f, 0x52, 0xea, 0xd0, 0x51, 0x14, 0xcb, 0x44, 0x66, 0xfb, 0x67,
+	0x16, 0xb4, 0xc5, 0xd1, 0xa6, 0xea, 0xc8, 0x6b, 0xc6, 0x39, 0x68, 0x95, 0x05, 0xba, 0xdf, 0x80,
+	0x16, 0xf3, 0x04, 0x55, 0xc4, 0x44, 0x84, 0x75, 0x0c, 0x20, 0x8e, 0x49, 0x86, 0x6e, 0x87, 0x7e,
+	0x20, 0x18, 0xac, 0x83, 0x64, 0xd0, 0x05, 0x3d, 0x45, 0xc6, 0x5e, 0xcb, 0x51, 0x65, 0xfb, 0x3f,
+	0x58, 0xb0, 0xa0, 0x0d, 0x58, 0x48, 0xd4, 0x07, 0x20, 0xef, 0xb2, 0x79, 0xf8, 0x84, 0x6f, 0x8c,
+	0xab, 0xe6, 0x31, 0x9d, 0x35, 0x33, 0x90, 0xd9, 0xc2, 0x78, 0xe7, 0x6c, 0x80, 0xc9, 0x98, 0xa7,
+	0xf9, 0x55, 0x1d, 0x1d, 0x84, 0x42, 0xf1, 0x9c, 0xd2, 0x67, 0x0a, 0x65, 0x8a, 0xa1, 0x18, 0x30,
+	0x76, 0xfd, 0x18, 0x85, 0xe9, 0xa9, 0x42, 0xe2, 0x39, 0x38, 0x26, 0xd0, 0xfe, 0x9f, 0x16, 0x2c,
+	0x72, 0xf3, 0x48, 0x18, 0x9f, 0x2a, 0x3f, 0x70, 0x86, 0xdb, 0x83, 0x7c, 0x77, 0x6d, 0x5d, 0x71,
+	0x44, 0x99, 0x7c, 0xf9, 0x92, 0x26, 0x9d, 0xba, 0x76, 0x9e, 0xb0, 0x16, 0x53, 0x65, 0x6b, 0x71,
+	0x01, 0xa7, 0xcb, 0xc2, 0x06, 0xd3, 0xa5, 0x61, 0x83, 0x87, 0xb3, 0x30, 0x9d, 0xf4, 0xa3, 0x11,
+	0xb5, 0x57, 0x60, 0xc9, 0x9c, 0x9c, 0x50, 0x27, 0xbf, 0xb0, 0xa0, 0xb3, 0xc9, 0xc3, 0x6a, 0x7e,
+	0x78, 0xb2, 0xe5, 0x27, 0x69, 0x14, 0xab, 0x84, 0xe8, 0x9b, 0x00, 0x49, 0xea, 0xc5, 0x29, 0x4f,
+	0x14, 0x12, 0x0e, 0x7f, 0x06, 0xc1, 0x31, 0xd2, 0x70, 0xc0, 0x6b, 0xf9, 0xda, 0xa8, 0x32, 0x2e,
+	0x0c, 0xbb, 0x12, 0x77, 0xa3, 0xe3, 0xe3, 0x84, 0x2a, 0x03, 0x4e, 0x87, 0xa1, 0x0f, 0x88, 0xbb,
+	0x17, 0xbd, 0x1e, 0x7a, 0xc6, 0xd4, 0x26, 0x77, 0xf0, 0x72, 0x50, 0xfb, 0xdf, 0x59, 0x30, 0x9f,
+	0x0d, 0xb2, 0x87, 0x40, 0x73, 0xa7, 0xf3, 0xa1, 0x69, 0x3b, 0x5d, 0x86, 0x22, 0xfc, 0x81, 0xeb,
+	0x87, 0x62, 0x6c, 0x1a, 0x84, 0xed, 0x3e, 0x51, 0x8a, 0xc6, 0x32, 0x29, 0x4b, 0x07, 0xf1, 0x9b,
+	0xcd, 0x14, 0x5b, 0xf3, 0x8c, 0x2c, 0x51, 0x62, 0x79, 0x5e, 0xc3, 0x94, 0xb5, 0x9a, 0xe1, 0xa1,
+	0x53, 0x51, 0x94, 0x67, 0xcd, 0x2c, 0x83, 0xe2, 0x4f, 0xfb, 0xa7, 0x16, 0x5c, 0x2b, 0x61, 0xae,
+	0xd8, 0x19, 0x1b, 0xb0, 0x70, 0xac, 0x2a, 0x25, 0x03, 0xf8, 0xf6, 0x58, 0x11, 0x52, 0x94, 0x9b,
+	0xb4, 0x53, 0x6c, 0x40, 0xde, 0x82, 0x05, 0x16, 0x41, 0xe1, 0x2c, 0x35, 0x52, 0x13, 0x8a, 0x15,
+	0xf6, 0x37, 0xa1, 0xdb, 0x7b, 0x81, 0x1b, 0x4d, 0xc5, 0xb5, 0xfb, 0xcf, 0xc6, 0xd2, 0xbd, 0x24,
+	0xef, 0x14, 0x14, 0xc9, 0x04, 0x83, 0x5a, 0x43, 0xb3, 0x8f, 0xa1, 0x65, 0x10, 0xfb, 0xbd, 0xa8,
+	0xa8, 0x05, 0x39, 0x62, 0x34, 0x64, 0x86, 0x84, 0x06, 0xb2, 0xcf, 0x60, 0xfe, 0xf1, 0x38, 0x48,
+	0x7d, 0x24, 0x21, 0x7a, 0xfa, 0xb2, 0x68, 0xc4, 0x48, 0x48, 0xde, 0x95, 0x76, 0xa5, 0xe3, 0x21,
+	0xcb, 0x86, 0x48, 0xc9, 0x2d, 0xf6, 0x58, 0xac, 0xb0, 0xaf, 0xc1, 0xd5, 0xac, 0x4b, 0xce, 0x3c,
+	0xa9, 0x8d, 0xbf, 0xb0, 0x78, 0xfa, 0x10, 0xaf, 0x3b, 0x08, 0xbd, 0x51, 0x72, 0x1a, 0xa5, 0xe4,
+	0x11, 0x2c, 0xa2, 0xfb, 0x14, 0x50, 0x9d, 0x4e, 0x22, 0x38, 0xb1, 0x6c, 0x0e, 0x8f, 0x37, 0x4d,
+	0x9c, 0xb2, 0x16, 0x28, 0x21, 0xe5, 0x03, 0xcd, 0x24, 0x24, 0xc7, 0x92, 0xb2, 0x09, 0x7c, 0x04,
+	0x73, 0x66, 0x67, 0xe4, 0x3d, 0x91, 0xde, 0x90, 0x8d, 0x4c, 0x0f, 0x3d, 0x99, 0xa2, 0x61, 0x60,
+	0xda, 0xff, 0xd0, 0x82, 0x8e, 0x43, 0x51, 0x8e, 0xa9, 0xd6, 0xa9, 0x10, 0x9f, 0x0f, 0x0a, 0x64,
+	0x27, 0x4f, 0x58, 0xa5, 0x4d, 0xc8, 0xb9, 0xde, 0x9b, 0xb8, 0x28, 0x5b, 0x57, 0x4a, 0x66, 0xf5,
+	0xb0, 0x06, 0x33, 0x62, 0x7e, 0x57, 0x61, 0x59, 0x0c, 0x49, 0x0e, 0x47, 0xe8, 0xb6, 0x2e, 0x74,
+	0x78, 0x9e, 0xba, 0x3e, 0x54, 0x51, 0xf7, 0x1b, 0x0b, 0x96, 0xd7, 0x06, 0x83, 0xad, 0x28, 0x18,
+	0xe4, 0xec, 0xe5, 0x32, 0xaf, 0x90, 0x40, 0x55, 0xb3, 0xb7, 0xab, 0xa6, 0xa9, 0x3a, 0xa5, 0x9b,
+	0xaa, 0x65, 0x86, 0x42, 0xf5, 0xa5, 0xbe, 0xd4, 0xf4, 0xc5, 0xbe, 0xd4, 0xcc, 0x25, 0x0c, 0x88,
+	0xd9, 0x82, 0x2f, 0x65, 0xdf, 0x83, 0xf6, 0x01, 0x73, 0x08, 0xc5, 0x0c, 0x1f, 0x27, 0x27, 0x2c,
+	0x07, 0x48, 0x9a, 0xbd, 0x22, 0x4b, 0x4f, 0x99, 0xbb, 0x8b, 0xb0, 0x60, 0xe0, 0x23, 0xb3, 0xec,
+	0x77, 0xa1, 0xbd, 0xee, 0x85, 0x7d, 0x1a, 0x68, 0x44, 0xca, 0x2c, 0xf9, 0xa6, 0x69, 0xad, 0x21,
+	0x31, 0xa3, 0x1d, 0x23, 0xf6, 0x08, 0x5e, 0xe1, 0x96, 0x2d, 0xaf, 0xa2, 0x92, 0xf7, 0xea, 0x9e,
+	0xec, 0x4d, 0x98, 0x63, 0x46, 0x3d, 0x1d, 0xb8, 0x47, 0xf4, 0x38, 0x8a, 0xe5, 0xf5, 0x5a, 0x0e,
+	0x6a, 0x3f, 0x84, 0x9b, 0x93, 0x08, 0x65, 0x96, 0x2c, 0x9e, 0x22, 0x03, 0x86, 0x35, 0x90, 0x77,
+	0x08, 0x1a, 0xc8, 0xfe, 0xff, 0x53, 0xb0, 0x24, 0x84, 0x72, 0xad, 0xdf, 0xa7, 0xa3, 0x74, 0x42,
+	0xb6, 0x96, 0x55, 0xcc, 0xd6, 0xe2, 0xc7, 0x8b, 0x1f, 0xea, 0x5e, 0x98, 0x06, 0x61, 0xae, 0x9f,
+	0x96, 0x70, 0xea, 0xfa, 0x03, 0x11, 0x30, 0xc8, 0x83, 0x99, 0xc9, 0xa5, 0xb2, 0xb4, 0xd4, 0x41,
+	0xa4, 0x81, 0x54, 0xd6, 0x16, 0x56, 0xf3, 0xa3, 0x48, 0x95, 0x71, 0x1c, 0x83, 0x71, 0x92, 0xba,
+	0x81, 0x3f, 0xf4, 0xe5, 0x79, 0xa4, 0x41, 0xc8, 0x03, 0x58, 0xc4, 0x63, 0x94, 0x89, 0xa6, 0xeb,
+	0x87, 0xee, 0x71, 0xc0, 0x82, 0x29, 0x5c, 0x56, 0xca, 0xaa, 0x70, 0xe4, 0xd2, 0x02, 0x8b, 0x69,
+	0x42, 0xe3, 0x33, 0x1e, 0x5b, 0xa8, 0x3a, 0x79, 0xb0, 0x71, 0x23, 0x51, 0xe7, 0xe3, 0x52, 0x37,
+	0x12, 0xc5, 0xe4, 0xfa, 0xaa, 0x91, 0x5c, 0x6f, 0x64, 0x9b, 0x37, 0xf2, 0xd9, 0xe6, 0xf7, 0x80,
+	0xe0, 0xd0, 0x3c, 0xb6, 0x28, 0x74, 0x20, 0xae, 0xa1, 0x9b, 0x0c, 0xad, 0xa4, 0x46, 0xcf, 0xf5,
+	0x38, 0x0e, 0xbc, 0x93, 0x84, 0xc5, 0x15, 0x5a, 0x8e, 0x09, 0xb4, 0x23, 0x75, 0xcb, 0x2b, 0x57,
+	0x3b, 0xf3, 0xdf, 0x39, 0xc1, 0xec, 0xbb, 0x09, 0x2c, 0x95, 0x2d, 0x62, 0xa5, 0x7c, 0x11, 0x97,
+	0x60, 0x9a, 0x7f, 0xe1, 0x27, 0xee, 0x9c, 0x58, 0xc1, 0xfe, 0xc7, 0x16, 0x5c, 0x7d, 0xe8, 0xa5,
+	0xfd, 0xd3, 0x92, 0x84, 0xc0, 0x77, 0xb4, 0xe4, 0x73, 0xd3, 0x22, 0x2e, 0xb4, 0x50, 0x88, 0x28,
+	0x2b, 0x7a, 0x8e, 0x9e, 0x48, 0xa2, 0xd5, 0x40, 0xb8, 0x31, 0x8d, 0x24, 0x3d, 0xe1, 0x22, 0x19,
+	0x79, 0x9c, 0xff, 0xa4, 0x02, 0xed, 0x7c, 0x27, 0x97, 0x10, 0xf9, 0x49, 0x09, 0x87, 0x95, 0x4b,
+	0x26, 0x1c, 0x4e, 0xe5, 0x12, 0x0e, 0xb5, 0xa8, 0x4d, 0xf5, 0x25, 0xd9, 0x82, 0xd3, 0x97, 0xcd,
+	0x16, 0x9c, 0xb9, 0x6c, 0xb6, 0xe0, 0x6c, 0x49, 0xb6, 0xa0, 0xfd, 0x3d, 0xe8, 0x14, 0x97, 0x4b,
+	0xc8, 0xc8, 0x37, 0xa0, 0x5d, 0xc8, 0x2e, 0x37, 0x4f, 0x4d, 0x23, 0x29, 0xd0, 0x29, 0x60, 0xdb,
+	0xff, 0xd2, 0x82, 0xc5, 0x92, 0x24, 0xbc, 0x32, 0x29, 0xb3, 0xca, 0xa5, 0xec, 0x0e, 0xcc, 0x2b,
+	0xfe, 0x8a, 0x79, 0x88, 0x78, 0x52, 0x0e, 0x8c, 0x5a, 0x34, 0xb7, 0x4a, 0xe2, 0xe6, 0x35, 0xb7,
+	0x3e, 0x04, 0xaa, 0xa3, 0xe4, 0x28, 0x15, 0x07, 0x14, 0xfb, 0x6d, 0xff, 0xd8, 0x82, 0xee, 0xa6,
+	0x1f, 0x7a, 0x81, 0xff, 0x29, 0xd5, 0xc6, 0x99, 0x7d, 0x49, 0x7b, 0xd9, 0xe1, 0xde, 0x82, 0x46,
+	0xe2, 0x9f, 0x84, 0x74, 0xe0, 0xb2, 0x3e, 0xe4, 0x67, 0xf6, 0x19, 0x08, 0xa5, 0x95, 0x7f, 0x95,
+	0x1b, 0x7b, 0xcf, 0xdd, 0xf4, 0x85, 0x50, 0x91, 0x06, 0xcc, 0x7e, 0x05, 0xae, 0x97, 0x8e, 0x46,
+	0x1c, 0xe3, 0xdf, 0x00, 0x58, 0xf7, 0xe3, 0xfe, 0xd8, 0x4f, 0x3f, 0xe6, 0xdf, 0x60, 0x4c, 0x48,
+	0x6b, 0xe8, 0xc0, 0x2c, 0x13, 0x1d, 0xb1, 0x87, 0xab, 0x8e, 0x2c, 0xda, 0xff, 0x62, 0x0a, 0xae,
+	0x0b, 0x33, 0x7b, 0x2b, 0x0d, 0xfa, 0xdb, 0x61, 0x4a, 0x63, 0xfd, 0x30, 0xe8, 0xc1, 0x92, 0xcc,
+	0x76, 0x74, 0xfb, 0xbc, 0x2b, 0x75, 0x8d, 0x9e, 0xdd, 0x9a, 0x64, 0x83, 0x70, 0x4a, 0xd1, 0x71,
+	0xfb, 0x28, 0x38, 0xe7, 0x7e, 0xe6, 0x87, 0x57, 0x9d, 0xd2, 0x3a, 0xf6, 0x59, 0x84, 0x84, 0x8b,
+	0x53, 0x9e, 0x7b, 0x51, 0x79, 0x70, 0xe1, 0x40, 0xae, 0x16, 0x0f, 0x64, 0xf2, 0x21, 0x74, 0xa3,
+	0x71, 0x7a, 0x12, 0x61, 0x33, 0x11, 0x78, 0x14, 0x37, 0x31, 0xc8, 0x15, 0x7e, 0xb2, 0x5c, 0x80,
+	0x81, 0x33, 0x50, 0xb5, 0xfa, 0x0c, 0xf8, 0xa9, 0x53, 0x5a, 0x87, 0x33, 0x50, 0x70, 0xcd, 0x4e,
+	0x69, 0x39, 0x79, 0x30, 0x9e, 0x18, 0x51, 0x88, 0x96, 0xd1, 0x51, 0x10, 0x1d, 0xb1, 0x23, 0xa7,
+	0xe9, 0x68, 0x10, 0xfb, 0x8b, 0x0a, 0xdc, 0x28, 0x5f, 0x26, 0xb1, 0x43, 0xff, 0x48, 0xeb, 0xb4,
+	0xcd, 0x3f, 0xa2, 0x13, 0x79, 0xbb, 0x73, 0x2a, 0xaf, 0xf1, 0xa2, 0xbe, 0xef, 0x39, 0x34, 0x89,
+	0x82, 0x33, 0xba, 0xc6, 0xbf, 0xa6, 0x17, 0x04, 0x0c, 0x53, 0x6b, 0xca, 0x34, 0xb5, 0x98, 0xe8,
+	0x7b, 0x7e, 0x30, 0x8e, 0xa9, 0xdb, 0x8f, 0x06, 0x54, 0xf8, 0xbd, 0x06, 0xcc, 0x7e, 0x1b, 0x5a,
+	0x06, 0x61, 0x02, 0x30, 0xe3, 0xf4, 0x0e, 0x9e, 0x3c, 0xee, 0xb5, 0xaf, 0x90, 0x1a, 0x54, 0x37,
+	0xd7, 0xb6, 0x77, 0xda, 0x16, 0x42, 0x79, 0x28, 0xbb, 0x5d, 0xb1, 0x6f, 0x40, 0x57, 0x84, 0x5f,
+	0x8f, 0x28, 0x0e, 0x95, 0x79, 0x8d, 0x2a, 0xae, 0xf8, 0x9b, 0x2a, 0xd4, 0x15, 0x14, 0x6d, 0x83,
+	0x8c, 0x03, 0xf9, 0x6b, 0xfd, 0xb2, 0x2a, 0x6c, 0xa1, 0x96, 0x4d, 0x6b, 0xc1, 0x45, 0xb8, 0xac,
+	0x0a, 0x95, 0xb4, 0x22, 0x24, 0xf7, 0x1f, 0x8f, 0xd0, 0x14, 0xe0, 0x88, 0xab, 0x48, 0x48, 0x5c,
+	0x6e, 0x0e, 0x15, 0xe0, 0xc8, 0x3e, 0xe5, 0xeb, 0xbb, 0x61, 0x22, 0xa4, 0xd7, 0x80, 0x91, 0xf7,
+	0x01, 0x98, 0x8b, 0xec, 0xb2, 0xcf, 0x82, 0x67, 0xd8, 0x6a, 0xca, 0xbb, 0x46, 0xc5, 0x85, 0x7b,
+	0xec, 0x5f, 0xf6, 0x31, 0xb0, 0x86, 0x4d, 0xbe, 0x02, 0x2d, 0x99, 0x6b, 0xc4, 0xa0, 0x22, 0x61,
+	0x60, 0xd1, 0x14, 0x06, 0xee, 0x93, 0x9b, 0x98, 0xe4, 0x11, 0x10, 0x09, 0xc0, 0xd5, 0x14, 0xed,
+	0x6b, 0xc6, 0xd7, 0xac, 0xa2, 0xfd, 0xa6, 0xe7, 0x07, 0x9c, 0x46, 0x49, 0x13, 0xf2, 0x2e, 0x34,
+	0xc5, 0x75, 0x0e, 0x27, 0x51, 0x67, 0x24, 0x88, 0xfa, 0x1a, 0x1d, 0xab, 0x78, 0x6b, 0x03, 0x8f,
+	0x7c, 0x08, 0xf3, 0x81, 0x1f, 0x3e, 0xd3, 0x7b, 0x87, 0x5c, 0x3e, 0x4f, 0xf8, 0x2c, 0xeb, 0x3a,
+	0x8f, 0x6c, 0x7f, 0x15, 0xea, 0x8a, 0x29, 0xa4, 0x01, 0xb3, 0x4f, 0x76, 0x3f, 0xde, 0xdd, 0x7b,
+	0xba, 0xcb, 0x65, 0xee, 0xa0, 0xb7, 0xbb, 0xd1, 0xb6, 0x10, 0xec, 0xf4, 0xd6, 0x7b, 0xdb, 0x9f,
+	0xf4, 0xda, 0x15, 0x2c, 0x6c, 0xee, 0x39, 0x4f, 0xd7, 0x9c, 0x8d, 0xf6, 0x94, 0xfd, 0x9f, 0x2d,
+	0xa8, 0xf1, 0x4d, 0x72, 0x1c, 0xa1, 0xa3, 0xad, 0x96, 0x19, 0xd7, 0x46, 0x4b, 0xb2, 0x2a, 0x56,
+	0x20, 0xb6, 0x5a, 0x68, 0x85, 0x2d, 0x22, 0x19, 0x85, 0x0a, 0x83, 0xb6, 0xca, 0x87, 0xe2, 0xb2,
+	0x55, 0xac, 0x30, 0x68, 0x2b, 0x6c, 0x2e, 0x5d, 0xc5, 0x0a, 0xfb, 0x1d, 0x68, 0xea, 0x4b, 0x4c,
+	0x5e, 0x87, 0xaa, 0x1f, 0x1e, 0x47, 0x42, 0x97, 0xcc, 0x6b, 0x42, 0xc4, 0x32, 0x26, 0x58, 0xa5,
+	0x1d, 0x40, 0x3b, 0xbf, 0xae, 0x85, 0x6d, 0x6e, 0x15, 0xb7, 0x39, 0xf9, 0x12, 0x2c, 0xcb, 0x72,
+	0x12, 0x8d, 0xe3, 0x7e, 0xee, 0x2b, 0x91, 0xf2, 0x4a, 0x96, 0x18, 0x90, 0x89, 0x80, 0xfd, 0xbf,
+	0xaa, 0xd0, 0x32, 0xd6, 0xf5, 0x52, 0x63, 0x2e, 0x8c, 0xaf, 0x52, 0x32, 0xbe, 0x8f, 0x60, 0x4e,
+	0x96, 0x07, 0xec, 0x65, 0x11, 0xc6, 0xe5, 0xb9, 0x55, 0xbb, 0x4c, 0x9c, 0xee, 0x6d, 0x72, 0x54,
+	0xfe, 0x06, 0x89, 0x93, 0x6b, 0xc9, 0x0c, 0x13, 0x39, 0x1d, 0xfe, 0x85, 0x0b, 0xbf, 0x52, 0xcf,
+	0x41, 0x8d, 0x4f, 0x0e, 0xa6, 0xcd, 0x4f, 0x0e, 0xec, 0xdf, 0x56, 0xa0, 0x65, 0xf4, 0x42, 0x5a,
+	0x50, 0xdf, 0xdd, 0x73, 0x37, 0x7a, 0x87, 0xa8, 0x10, 0xaf, 0x90, 0x36, 0x34, 0xf7, 0x76, 0xb7,
+	0xf7, 0x76, 0xdd, 0x8d, 0xde, 0xfa, 0xde, 0x46, 0xaf, 0x6d, 0x65, 0x90, 0xde, 0x2e, 0x83, 0x54,
+	0xc8, 0x22, 0xcc, 0xb3, 0x6b, 0xbd, 0x6f, 0xbb, 0x87, 0x7b, 0x7b, 0xee, 0xc1, 0xde, 0xde, 0x6e,
+	0x7b, 0x8a, 0x74, 0x60, 0x69, 0xed, 0xf1, 0xde, 0x93, 0xdd, 0x43, 0xf7, 0x61, 0x6f, 0x67, 0xef,
+	0xa9, 0xfb, 0x78, 0x7b, 0x77, 0xfb, 0xf1, 0x93, 0xc7, 0xed, 0x2a, 0x59, 0x82, 0xf6, 0x66, 0xaf,
+	0xe7, 0x6e, 0xef, 0x1e, 0x3c, 0xd9, 0xdc, 0xdc, 0x5e, 0xdf, 0xee, 0xed, 0x1e, 0xb6, 0xa7, 0xc9,
+	0x35, 0x58, 0xde, 0xde, 0x5d, 0xdf, 0x73, 0x9c, 0xde, 0xfa, 0xa1, 0xbb, 0xbe, 0x73, 0xf8, 0x89,
+	0xcb, 0x69, 0xb6, 0x67, 0xb0, 0x41, 0x56, 0xc5, 0x89, 0xb6, 0x67, 0xb1, 0x57, 0xb1, 0x9b, 0xdc,
+	0xed, 0xdd, 0x4f, 0xf6, 0xb6, 0xd7, 0x7b, 0xed, 0x1a, 0x47, 0x65, 0x05, 0x57, 0xdd, 0x42, 0xd6,
+	0x11, 0x75, 0x7b, 0xf7, 0x93, 0xb5, 0x9d, 0xed, 0x0d, 0xf7, 0xe3, 0xde, 0xb7, 0xd9, 0xb6, 0x03,
+	0x32, 0x0f, 0x8d, 0xc7, 0xfb, 0xfb, 0xee, 0xe1, 0xf6, 0xe3, 0xde, 0xde, 0x93, 0xc3, 0x76, 0x83,
+	0x2c, 0xc3, 0x82, 0x24, 0xb8, 0xdb, 0xfb, 0xd6, 0xa1, 0xbb, 0xdf, 0xeb, 0x39, 0xed, 0x26, 0x4e,
+	0x44, 0x1f, 0xaa, 0xfb, 0x70, 0x6d, 0x07, 0x29, 0xb7, 0x5b, 0xc8, 0x89, 0xb5, 0x8d, 0x0d, 0xd7,
+	0xe9, 0x7d, 0xd4, 0x5b, 0x3f, 0xec, 0x6d, 0xb4, 0xe7, 0xc8, 0x0a, 0x10, 0x1c, 0xe9, 0xe3, 0xfd,
+	0x9d, 0xde, 0x61, 0xcf, 0x95, 0x1b, 0x79, 0x1e, 0xfb, 0xda, 0xde, 0x3d, 0xec, 0x39, 0xe2, 0x26,
+	0xb4, 0x8d, 0x67, 0x0b, 0x4b, 0x3c, 0x7d, 0xec, 0x27, 0x89, 0x1f, 0x85, 0xeb, 0x51, 0x98, 0xc6,
+	0x91, 0x74, 0x68, 0xec, 0x47, 0x70, 0xbd, 0xb4, 0x56, 0x7d, 0xe1, 0x39, 0x3d, 0xf2, 0xfc, 0x38,
+	0xff, 0x08, 0xcd, 0xbe, 0xe7, 0xc7, 0x32, 0x2c, 0xca, 0x11, 0xec, 0x5f, 0x5b, 0xd0, 0xd0, 0xc0,
+	0x32, 0xe9, 0xcb, 0x3d, 0x8e, 0xa3, 0xa1, 0x30, 0x35, 0x33, 0x00, 0x4b, 0xc0, 0xc3, 0x42, 0x1a,
+	0xc9, 0xeb, 0x7a, 0x51, 0xc4, 0x76, 0x4c, 0xa9, 0xb1, 0xe0, 0xb4, 0x48, 0xca, 0x54, 0x00, 0x1e,
+	0x62, 0xf1, 0x03, 0x53, 0x17, 0x4c, 0x39, 0x26, 0x90, 0xb9, 0x53, 0xfc, 0xa3, 0x3e, 0x4e, 0x66,
+	0x5a, 0xb8, 0x53, 0x1a, 0x0c, 0x8f, 0x2d, 0x59, 0xce, 0xa5, 0x65, 0x16, 0xe0, 0xc8, 0xa4, 0xed,
+	0xe1, 0x28, 0x8a, 0xd3, 0x52, 0x1e, 0xfe, 0x0e, 0x4c, 0xba, 0x09, 0x37, 0xca, 0x09, 0x09, 0xb3,
+	0xf8, 0x06, 0x74, 0x1d, 0x9a, 0xd0, 0xf2, 0x7e, 0xd0, 0xa6, 0x2e, 0xad, 0x15, 0x8d, 0xff, 0x81,
+	0x05, 0xe4, 0x80, 0x86, 0x83, 0xc3, 0x88, 0x27, 0x0f, 0x8b, 0xd1, 0x5d, 0x22, 0xe8, 0x83, 0x16,
+	0x42, 0xd9, 0x03, 0x3e, 0xdc, 0x4d, 0x29, 0xab, 0xd2, 0xf2, 0x96, 0xa7, 0x2e, 0xc8, 0x5b, 0xfe,
+	0x99, 0x05, 0x0b, 0x0f, 0xc7, 0x7e, 0x30, 0x30, 0x46, 0xd4, 0x85, 0x9a, 0x62, 0x39, 0x0f, 0x13,
+	0xa9, 0x32, 0x2e, 0x4b, 0xe1, 0xc5, 0x1f, 0xee, 0x30, 0x17, 0xe0, 0xe8, 0xa9, 0x9c, 0x46, 0x23,
+	0xa1, 0x59, 0xf9, 0x40, 0xea, 0x8e, 0x0e, 0x62, 0xa9, 0x2a, 0xdc, 0x0c, 0xe6, 0xd9, 0xa3, 0x55,
+	0x47, 0x95, 0xed, 0xf7, 0x80, 0xe8, 0x43, 0x13, 0x02, 0x6f, 0xc3, 0x34, 0x7f, 0xa2, 0xc7, 0x2a,
+	0x79, 0xa2, 0x87, 0x57, 0xd9, 0x3f, 0xb7, 0xa0, 0xed, 0xd0, 0x23, 0x23, 0xe7, 0xdc, 0x30, 0x83,
+	0x4c, 0x67, 0xa6, 0x00, 0x47, 0x5c, 0x16, 0xe2, 0xc7, 0xa1, 0xea, 0x21, 0x8a, 0xaa, 0x53, 0x80,
+	0x97, 0x3c, 0xc8, 0x84, 0x3b, 0x84, 0x52, 0x11, 0x3b, 0x12, 0x77, 0xa5, 0x0a, 0xb0, 0xfa, 0x45,
+	0x05, 0xe6, 0x78, 0xd2, 0x3e, 0x7f, 0xa8, 0x8b, 0xc6, 0xe4, 0x31, 0xcc, 0x8a, 0x67, 0xd1, 0x88,
+	0x0c, 0xea, 0x9a, 0x0f, 0xb1, 0x75, 0x57, 0xf2, 0x60, 0x21, 0x52, 0x8b, 0x7f, 0xf7, 0xbf, 0xff,
+	0x9f, 0x7f, 0x54, 0x69, 0x91, 0xc6, 0xfd, 0xb3, 0xb7, 0xef, 0x9f, 0xd0, 0x30, 0x41, 0x1a, 0xdf,
+	0x03, 0xc8, 0x5e, 0x16, 0x23, 0x1d, 0x95, 0x5a, 0x90, 0x7b, 0x09, 0xad, 0x7b, 0xad, 0xa4, 0x46,
+	0xd0, 0xbd, 0xc6, 0xe8, 0x2e, 0xda, 0x73, 0x48, 0xd7, 0x0f, 0xfd, 0x94, 0x3f, 0x33, 0xf6, 0xbe,
+	0x75, 0x97, 0x0c, 0xa0, 0xa9, 0xbf, 0x30, 0x46, 0xa4, 0xe9, 0x57, 0xf2, 0x6c, 0x59, 0xf7, 0x7a,
+	0x69, 0x9d, 0xcc, 0xb1, 0x63, 0x7d, 0x2c, 0xdb, 0x6d, 0xec, 0x63, 0xcc, 0x30, 0x54, 0x2f, 0xab,
+	0x7f, 0x72, 0x1b, 0xea, 0x2a, 0x55, 0x93, 0xfc, 0x10, 0x5a, 0xc6, 0x77, 0x0e, 0x44, 0x12, 0x2e,
+	0xfb, 0x2c, 0xa2, 0x7b, 0xa3, 0xbc, 0x52, 0x74, 0x7b, 0x93, 0x75, 0xdb, 0x21, 0x2b, 0xd8, 0xad,
+	0x90, 0x91, 0xfb, 0xec, 0xeb, 0x0e, 0xfe, 0x05, 0xf8, 0x33, 0x2d, 0xaa, 0xcf, 0x3b, 0xbb, 0x91,
+	0x0f, 0xb4, 0x1b, 0xbd, 0xbd, 0x32, 0xa1, 0x56, 0x6a, 0x0c, 0xd6, 0xdd, 0x0a, 0x59, 0xd2, 0xbb,
+	0x53, 0x91, 0x27, 0xca, 0xbe, 0xd9, 0xd7, 0x9f, 0x1e, 0x23, 0xaf, 0xa8, 0xa5, 0x2e, 0x7b, 0x92,
+	0x4c, 0x2d, 0x5a, 0xf1, 0x5d, 0x32, 0xbb, 0xc3, 0xba, 0x22, 0x84, 0x31, 0x54, 0x7f, 0x79, 0x8c,
+	0x7c, 0x17, 0xea, 0xea, 0x09, 0x20, 0x72, 0x55, 0x7b, 0x77, 0x49, 0x7f, 0x97, 0xa8, 0xdb, 0x29,
+	0x56, 0x94, 0x2d, 0x95, 0x4e, 0x19, 0x05, 0x62, 0x07, 0x96, 0x95, 0x6f, 0xf4, 0xbb, 0xcc, 0xa4,
+	0xe4, 0xc1, 0xb4, 0x07, 0x16, 0xf9, 0x00, 0x6a, 0xf2, 0x65, 0x25, 0xb2, 0x52, 0xfe, 0x42, 0x54,
+	0xf7, 0x6a, 0x01, 0x2e, 0x94, 0xc3, 0x1a, 0x40, 0xf6, 0x2a, 0x90, 0x92, 0xfc, 0xc2, 0x5b, 0x45,
+	0x8a, 0x89, 0x25, 0x4f, 0x08, 0x9d, 0xb0, 0x37, 0x90, 0xcc, 0x47, 0x87, 0xc8, 0xab, 0x19, 0x7e,
+	0xe9, 0x73, 0x44, 0x17, 0x10, 0xb4, 0x57, 0x18, 0xef, 0xda, 0x84, 0x6d, 0xa5, 0x90, 0x3e, 0x97,
+	0xb1, 0xa4, 0x0d, 0x68, 0x68, 0x2f, 0x0d, 0x11, 0x49, 0xa1, 0xf8, 0x4a, 0x51, 0xb7, 0x5b, 0x56,
+	0x25, 0x86, 0xfb, 0x11, 0xb4, 0x8c, 0x27, 0x83, 0xd4, 0xce, 0x28, 0x7b, 0x90, 0x48, 0xed, 0x8c,
+	0xf2, 0x57, 0x86, 0xbe, 0x03, 0x0d, 0xed, 0x81, 0x1f, 0xa2, 0x7d, 0x59, 0x9b, 0x7b, 0xda, 0x47,
+	0x8d, 0xa8, 0xec, 0x3d, 0xa0, 0x25, 0x36, 0xdf, 0x39, 0xbb, 0x8e, 0xf3, 0x65, 0x4f, 0x38, 0xa0,
+	0x90, 0xfc, 0x10, 0xe6, 0xcc, 0x27, 0x7f, 0xd4, 0xae, 0x2a, 0x7d, 0x3c, 0x48, 0xed, 0xaa, 0x09,
+	0xef, 0x04, 0x09, 0x81, 0xbc, 0xbb, 0xa8, 0x3a, 0xb9, 0xff, 0x99, 0xf8, 0x50, 0xe1, 0x73, 0xf2,
+	0x4d, 0x54, 0x1d, 0xe2, 0x4d, 0x0d, 0x92, 0x3d, 0x74, 0x64, 0xbe, 0xbc, 0xa1, 0xa4, 0xbd, 0xf0,
+	0xfc, 0x86, 0xbd, 0xc0, 0x88, 0x37, 0x48, 0x36, 0x03, 0xae, 0xa1, 0xd9, 0xdb, 0x1a, 0x9a, 0x86,
+	0xd6, 0x9f, 0xdf, 0xd0, 0x34, 0xb4, 0xf1, 0x04, 0x47, 0x5e, 0x43, 0xa7, 0xcc, 0x3d, 0x08, 0x61,
+	0x3e, 0xf7, 0x45, 0x9c, 0xda, 0x2c, 0xe5, 0xdf, 0xd3, 0x76, 0x6f, 0x5e, 0xfc, 0x21, 0x9d, 0xa9,
+	0x66, 0xa4, 0x7a, 0xb9, 0x2f, 0x3f, 0x9d, 0xfe, 0x9b, 0xd0, 0xd4, 0x9f, 0x6a, 0x51, 0x3a, 0xbb,
+	0xe4, 0x81, 0x19, 0xa5, 0xb3, 0xcb, 0xde, 0x76, 0x91, 0x8b, 0x4b, 0x9a, 0x7a, 0x37, 0xe4, 0x3b,
+	0x30, 0xaf, 0xc5, 0x76, 0x0f, 0xce, 0xc3, 0xbe, 0x12, 0x9e, 0x62, 0x88, 0xbe, 0x5b, 0x76, 0x8f,
+	0x6c, 0x5f, 0x65, 0x84, 0x17, 0x6c, 0x83, 0x30, 0x0a, 0xce, 0x3a, 0x34, 0xf4, 0x78, 0xfa, 0x05,
+	0x74, 0xaf, 0x6a, 0x55, 0xfa, 0x47, 0xe7, 0x0f, 0x2c, 0xf2, 0x73, 0x0b, 0x9a, 0xfa, 0xcb, 0x11,
+	0xc4, 0xc8, 0x8d, 0xce, 0xd1, 0xe9, 0xe8, 0x75, 0x3a, 0x21, 0xdb, 0x61, 0x83, 0xdc, 0xb9, 0xfb,
+	0x91, 0xc1, 0xe4, 0xcf, 0x8c, 0x8c, 0xa0, 0x7b, 0xf9, 0x57, 0xf8, 0x3e, 0xcf, 0x23, 0xe8, 0xef,
+	0x1a, 0x7c, 0xfe, 0xc0, 0x22, 0xef, 0xf3, 0x57, 0x21, 0x65, 0x36, 0x1f, 0xd1, 0x94, 0x5b, 0x9e,
+	0x65, 0xfa, 0x33, 0x88, 0x77, 0xac, 0x07, 0x16, 0xf9, 0x01, 0x7f, 0x6c, 0x4f, 0xb4, 0x65, 0x9c,
+	0xbf, 0x6c, 0x7b, 0xfb, 0x0d, 0x36, 0x9b, 0x9b, 0xf6, 0x35, 0x63, 0x36, 0x79, 0xed, 0xbe, 0x0f,
+	0x90, 0xa5, 0x66, 0x92, 0x5c, 0x9e, 0xa2, 0xd2, 0x7b, 0xc5, 0xec, 0x4d, 0x73, 0x45, 0x65, 0x3a,
+	0x23, 0x52, 0xfc, 0x2e, 0x17, 0x46, 0x79, 0xb1, 0xa8, 0x96, 0xb4, 0x98, 0x62, 0xd9, 0xed, 0x96,
+	0x55, 0x95, 0x89, 0xa2, 0xa4, 0x4f, 0x9e, 0x40, 0x6b, 0x27, 0x8a, 0x9e, 0x8d, 0x47, 0x2a, 0x17,
+	0xd9, 0xcc, 0x14, 0xdc, 0xf2, 0x92, 0xd3, 0x6e, 0x6e, 0x16, 0xf6, 0x2d, 0x46, 0xaa, 0x4b, 0x3a,
+	0x1a, 0xa9, 0xfb, 0x9f, 0x65, 0x89, 0xa1, 0x9f, 0x13, 0x0f, 0x16, 0xd4, 0x19, 0xa7, 0x06, 0xde,
+	0x35, 0xc9, 0xe8, 0xf9, 0x99, 0x85, 0x2e, 0x0c, 0xab, 0x43, 0x8e, 0xf6, 0x7e, 0x22, 0x69, 0x3e,
+	0xb0, 0xc8, 0x3e, 0x34, 0x37, 0x68, 0x3f, 0x1a, 0x50, 0x91, 0xdb, 0xb7, 0x98, 0x0d, 0x5c, 0x25,
+	0x05, 0x76, 0x5b, 0x06, 0xd0, 0xdc, 0xf5, 0x23, 0xef, 0x3c, 0xa6, 0x3f, 0xba, 0xff, 0x99, 0xc8,
+	0x1a, 0xfc, 0x5c, 0xee, 0x7a, 0x99, 0xe9, 0x68, 0xec, 0xfa, 0x5c, 0x6a, 0xa4, 0xb1, 0xeb, 0x0b,
+	0xa9, 0x91, 0x06, 0xab, 0x65, 0xa6, 0x25, 0x09, 0x60, 0xa1, 0x90, 0x4d, 0xa9, 0x4e, 0xca, 0x49,
+	0x39, 0x98, 0xdd, 0x5b, 0x93, 0x11, 0xcc, 0xde, 0xee, 0x9a, 0xbd, 0x1d, 0x40, 0x6b, 0x83, 0x72,
+	0x66, 0xf1, 0xef, 0x7b, 0xba, 0xa6, 0x1a, 0xd1, 0xbf, 0x05, 0xca, 0xab, 0x18, 0x56, 0x67, 0xaa,
+	0x75, 0xf6, 0x71, 0x0d, 0xf9, 0x2e, 0x34, 0x1e, 0xd1, 0x54, 0x7e, 0xd0, 0xa3, 0xec, 0x8d, 0xdc,
+	0x17, 0x3e, 0xdd, 0x92, 0xef, 0x81, 0x4c, 0x99, 0x61, 0xd4, 0xee, 0xd3, 0xc1, 0x09, 0xe5, 0x9b,
+	0xdd, 0xf5, 0x07, 0x9f, 0x93, 0x6f, 0x31, 0xe2, 0xea, 0x1b, 0xc0, 0x15, 0xed, 0x3b, 0x10, 0x9d,
+	0xf8, 0x7c, 0x0e, 0x5e, 0x46, 0x19, 0x7d, 0x6f, 0xed, 0x80, 0x0b, 0xa1, 0xa1, 0x7d, 0xaa, 0xaa,
+	0x36, 0x50, 0xf1, 0xf3, 0x58, 0xb5, 0x81, 0x4a, 0xbe, 0x6c, 0xb5, 0xef, 0xb0, 0x7e, 0x6c, 0x72,
+	0x2b, 0xeb, 0x87, 0x7b, 0x85, 0x59, 0x4f, 0xf7, 0x3f, 0xf3, 0x86, 0xe9, 0xe7, 0xe4, 0x29, 0x7b,
+	0x6c, 0x4a, 0xff, 0x68, 0x29, 0xb3, 0x77, 0xf2, 0xdf, 0x37, 0x29, 0x66, 0x69, 0x55, 0xa6, 0x0d,
+	0xc4, 0xbb, 0x62, 0xe7, 0xe0, 0x97, 0x01, 0x0e, 0xd2, 0x68, 0xb4, 0xe1, 0xd1, 0x61, 0x14, 0x66,
+	0x9a, 0x2b, 0xfb, 0x30, 0x27, 0xd3, 0x5c, 0xda, 0xd7, 0x39, 0xe4, 0xa9, 0x66, 0x71, 0x1a, 0xdf,
+	0x7c, 0x49, 0xe1, 0x9a, 0xf8, 0xed, 0x8e, 0x62, 0x48, 0xc9, 0xf7, 0x3b, 0x0f, 0x2c, 0xb4, 0x1f,
+	0xb3, 0xdc, 0x5d, 0x65, 0x3f, 0x16, 0xd2, 0x82, 0x95, 0xda, 0x2b, 0x49, 0xf4, 0xdd, 0x87, 0x7a,
+	0x96, 0x40, 0xaa, 0xe2, 0xd2, 0xb9, 0x74, 0x53, 0x75, 0xc6, 0x14, 0xd2, 0x3a, 0xed, 0x36, 0x63,
+	0x15, 0x90, 0x1a, 0xb2, 0x8a, 0xe5, 0x6a, 0xfa, 0xb0, 0xc8, 0x07, 0xa8, 0x0e, 0x4c, 0xf6, 0xa9,
+	0x89, 0x9c, 0x49, 0x49, 0x6a, 0xa5, 0xda, 0xcd, 0xa5, 0x99, 0x89, 0x86, 0x6f, 0x87, 0xd2, 0xca,
+	0x3f, 0x73, 0x41, 0xd5, 0x3c, 0x84, 0x85, 0x42, 0x5a, 0x9d, 0xda, 0xd2, 0x93, 0xb2, 0x19, 0xd5,
+	0x96, 0x9e, 0x98, 0x91, 0x67, 0x2f, 0xb3, 0x2e, 0xe7, 0x6d, 0xc0, 0x2e, 0x93, 0xe7, 0x7e, 0xda,
+	0x3f, 0xc5, 0xee, 0x76, 0x61, 0xb1, 0x24, 0x69, 0x8e, 0xbc, 0x26, 0xe8, 0x4d, 0x4e, 0xa8, 0xeb,
+	0x96, 0xa6, 0x54, 0x91, 0x43, 0xb8, 0xca, 0xdb, 0xac, 0x05, 0x41, 0x2e, 0x33, 0xeb, 0xa6, 0xd6,
+	0xa0, 0x24, 0xe3, 0xac, 0x7b, 0xad, 0x50, 0xaf, 0xb2, 0xce, 0x76, 0xa1, 0x9d, 0xcf, 0x76, 0x22,
+	0x93, 0xd1, 0xbb, 0xaf, 0x1a, 0x36, 0x76, 0x31, 0x43, 0x8a, 0x7c, 0xa2, 0xd2, 0xaa, 0x72, 0x63,
+	0x7c, 0x55, 0x3d, 0x29, 0x53, 0x9e, 0x07, 0xa6, 0xcc, 0xf7, 0xd2, 0xac, 0x2c, 0xb2, 0x0d, 0x73,
+	0x66, 0xe2, 0x95, 0x32, 0xb1, 0x4b, 0xf3, 0xb1, 0x2e, 0x38, 0xbb, 0xc9, 0x43, 0x68, 0x19, 0x09,
+	0x4b, 0x9a, 0xcf, 0x68, 0xa6, 0x3d, 0x69, 0x3e, 0x63, 0x2e, 0xbf, 0x09, 0x69, 0x18, 0x79, 0x4a,
+	0x8a, 0x46, 0x3e, 0xeb, 0x29, 0x33, 0xb8, 0xf2, 0x69, 0x4d, 0x84, 0xc2, 0x4a, 0x79, 0x36, 0x12,
+	0x79, 0xc3, 0x38, 0x46, 0x26, 0x64, 0x3d, 0x75, 0xff, 0xca, 0x4b, 0xb0, 0xd4, 0x9e, 0x9d, 0x37,
+	0x32, 0x58, 0xa2, 0x38, 0xef, 0xf3, 0x9b, 0x99, 0x2d, 0x6a, 0x7f, 0x95, 0x65, 0x39, 0x31, 0xbb,
+	0xec, 0xa0, 0x24, 0x15, 0xe4, 0xe6, 0xa4, 0x44, 0x14, 0x31, 0xd8, 0x57, 0x27, 0xd6, 0x8b, 0x61,
+	0x7e, 0x0f, 0x16, 0x4b, 0xae, 0xec, 0xd5, 0x76, 0x99, 0x9c, 0x5c, 0xd0, 0xb5, 0x2f, 0x42, 0x11,
+	0xd4, 0xbf, 0x0f, 0xf3, 0xc6, 0x25, 0x6c, 0x14, 0x93, 0xd7, 0x2f, 0x71, 0x47, 0x9b, 0xd1, 0x9e,
+	0x7c, 0xd7, 0xcf, 0x58, 0xb2, 0x03, 0x8b, 0x25, 0x57, 0xa8, 0x6a, 0xf4, 0x93, 0xaf, 0x57, 0xbb,
+	0xed, 0xfc, 0xe5, 0xe2, 0x03, 0x0b, 0x79, 0x51, 0x12, 0x16, 0x57, 0xd4, 0x26, 0x07, 0xd4, 0xd5,
+	0x78, 0x2f, 0x8a, 0xaa, 0xbb, 0xb0, 0x54, 0x16, 0x06, 0x26, 0xb2, 0xed, 0x05, 0xc1, 0xe6, 0xee,
+	0xeb, 0x17, 0xe2, 0x64, 0x4b, 0x59, 0x12, 0x29, 0x56, 0xc3, 0x9f, 0x1c, 0x63, 0x56, 0xc3, 0xbf,
+	0x20, 0xd0, 0x4c, 0xbe, 0xc6, 0x3d, 0x0a, 0x11, 0x67, 0xce, 0x42, 0x0b, 0x85, 0xd8, 0x73, 0xa9,
+	0x63, 0x80, 0xa7, 0x60, 0x16, 0x78, 0x55, 0xa7, 0x60, 0x21, 0x4c, 0xac, 0x14, 0x48, 0x49, 0x94,
+	0xf6, 0x2b, 0x50, 0x57, 0x01, 0x58, 0xb5, 0xf1, 0xf3, 0x21, 0xd9, 0xd2, 0xde, 0x8f, 0x66, 0xd8,
+	0x1f, 0xa3, 0x78, 0xe7, 0xcf, 0x03, 0x00, 0x00, 0xff, 0xff, 0xc9, 0x14, 0x1c, 0x40, 0xbe, 0x62,
+	0x00, 0x00,
 }
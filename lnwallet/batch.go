@@ -0,0 +1,357 @@
+package lnwallet
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/txsort"
+)
+
+var (
+	// ErrBatchFunded is returned when attempting to add a reservation to,
+	// or fund, a batch which has already been funded.
+	ErrBatchFunded = errors.New("funding batch has already been funded")
+
+	// ErrBatchNotFunded is returned when attempting to publish a batch
+	// which hasn't been funded yet.
+	ErrBatchNotFunded = errors.New("funding batch hasn't been funded")
+)
+
+// FundingBatch coordinates the funding of several single funder channel
+// reservations, for which we're the initiator, within a single funding
+// transaction. Rather than performing coin selection for each reservation,
+// coin selection is performed once for the entire batch, and a funding output
+// for each reservation is added to the shared funding transaction.
+//
+// The batch workflow consists of the following steps:
+//  1. FundingBatch.InitChannelReservation creates a reservation for each
+//     channel within the batch, without performing any coin selection.
+//  2. ChannelReservation.ProcessContribution records the contribution of
+//     each remote party.
+//  3. FundingBatch.Fund assembles and signs the shared funding transaction
+//     once every remote party has contributed, along with the commitment
+//     transactions of each reservation.
+//  4. ChannelReservation.CompleteReservation verifies the signature of each
+//     remote party for our version of the commitment transaction.
+//  5. FundingBatch.Publish commits the channels to disk once every
+//     reservation has been completed, and broadcasts the funding
+//     transaction. If any channel fails to be committed, or the funding
+//     transaction fails to be broadcast, then the channels committed so far
+//     are removed from disk.
+//
+// If any of the reservations fail before the batch has been published, then
+// every reservation must be cancelled, along with the batch itself, ensuring
+// none of the channels are opened.
+type FundingBatch struct {
+	// feeRate is the fee rate in sat/vbyte used for the shared funding
+	// transaction.
+	feeRate SatPerVByte
+
+	wallet *LightningWallet
+
+	// This mutex MUST be held when either reading or modifying any of the
+	// fields below.
+	sync.Mutex
+
+	// reservations is the set of reservations that will be funded by the
+	// batch, in the order they were created.
+	reservations []*ChannelReservation
+
+	// inputs are the inputs of the shared funding transaction, which are
+	// locked once the batch is funded.
+	inputs []*wire.TxIn
+
+	// fundingTx is the shared funding transaction. This will only be set
+	// once the batch has been funded.
+	fundingTx *wire.MsgTx
+
+	// persisted is the set of channels which remain committed to disk
+	// after failing to publish the batch, as they couldn't be removed.
+	persisted []*channeldb.OpenChannel
+}
+
+// NewFundingBatch creates a new, empty funding batch whose funding
+// transaction will pay the passed fee rate.
+func (l *LightningWallet) NewFundingBatch(feeRate SatPerVByte) *FundingBatch {
+	return &FundingBatch{
+		feeRate: feeRate,
+		wallet:  l,
+	}
+}
+
+// InitChannelReservation creates a new channel reservation which will be
+// funded as part of the batch. The parameters are the same as those of
+// LightningWallet.InitChannelReservation, except that the funding fee rate is
+// that of the batch. As we must be the sole funder of each channel within the
+// batch, the capacity of the channel must match our funding amount.
+func (b *FundingBatch) InitChannelReservation(
+	capacity, ourFundAmt btcutil.Amount, pushMSat lnwire.MilliSatoshi,
+	commitFeePerKw SatPerKWeight, theirID *btcec.PublicKey,
+	theirAddr net.Addr, chainHash *chainhash.Hash,
+	flags lnwire.FundingFlag, anchors bool) (*ChannelReservation, error) {
+
+	if capacity != ourFundAmt {
+		return nil, fmt.Errorf("batched channels must be funded " +
+			"solely by us")
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	if b.fundingTx != nil {
+		return nil, ErrBatchFunded
+	}
+
+	errChan := make(chan error, 1)
+	respChan := make(chan *ChannelReservation, 1)
+
+	b.wallet.msgChan <- &initFundingReserveMsg{
+		chainHash:          chainHash,
+		nodeID:             theirID,
+		nodeAddr:           theirAddr,
+		fundingAmount:      ourFundAmt,
+		capacity:           capacity,
+		commitFeePerKw:     commitFeePerKw,
+		fundingFeePerVSize: b.feeRate,
+		pushMSat:           pushMSat,
+		flags:              flags,
+		anchors:            anchors,
+		batch:              b,
+		err:                errChan,
+		resp:               respChan,
+	}
+
+	reservation, err := <-respChan, <-errChan
+	if err != nil {
+		return nil, err
+	}
+
+	b.reservations = append(b.reservations, reservation)
+
+	return reservation, nil
+}
+
+// Fund performs coin selection for the entire batch, and assembles the shared
+// funding transaction with a funding output for each of the batch's
+// reservations. Afterwards, the commitment transactions of each reservation
+// are created, along with our signature for the remote party's version. This
+// method MUST only be called once the contribution of every remote party has
+// been processed.
+func (b *FundingBatch) Fund() (*wire.MsgTx, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.fundingTx != nil {
+		return nil, ErrBatchFunded
+	}
+	if len(b.reservations) == 0 {
+		return nil, fmt.Errorf("funding batch has no reservations")
+	}
+
+	// We'll hold the mutex of each reservation for the remainder of the
+	// funding process, as we'll be modifying their state.
+	var totalAmt btcutil.Amount
+	for _, res := range b.reservations {
+		res.Lock()
+		defer res.Unlock()
+
+		if res.theirContribution == nil {
+			return nil, fmt.Errorf("remote party hasn't "+
+				"contributed to reservation %v yet",
+				res.reservationID)
+		}
+
+		totalAmt += res.partialState.Capacity
+	}
+
+	// With the total amount known, we'll perform coin selection for the
+	// entire batch at once, accounting for a funding output per
+	// reservation.
+	contribution := &ChannelContribution{}
+	err := b.wallet.selectCoinsAndChange(
+		b.feeRate, totalAmt, len(b.reservations), contribution,
+	)
+	if err != nil {
+		return nil, err
+	}
+	b.inputs = contribution.Inputs
+
+	fundingTx := wire.NewMsgTx(1)
+	for _, input := range contribution.Inputs {
+		fundingTx.AddTxIn(input)
+	}
+	for _, changeOutput := range contribution.ChangeOutputs {
+		fundingTx.AddTxOut(changeOutput)
+	}
+
+	// Next, we'll add the 2-of-2 multi-sig output of each reservation,
+	// then sort the transaction into its canonical ordering.
+	witnessScripts := make([][]byte, len(b.reservations))
+	fundingOutputs := make([]*wire.TxOut, len(b.reservations))
+	for i, res := range b.reservations {
+		witnessScript, multiSigOut, err := res.fundingOutput()
+		if err != nil {
+			return nil, err
+		}
+
+		witnessScripts[i] = witnessScript
+		fundingOutputs[i] = multiSigOut
+		fundingTx.AddTxOut(multiSigOut)
+	}
+	txsort.InPlaceSort(fundingTx)
+
+	inputScripts, err := b.wallet.signFundingInputs(fundingTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally, with the funding transaction complete, we can create the
+	// commitment transactions of each reservation.
+	for i, res := range b.reservations {
+		res.fundingTx = fundingTx
+		res.ourFundingInputScripts = inputScripts
+
+		err := b.wallet.initCommitments(
+			res, fundingOutputs[i], witnessScripts[i],
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b.fundingTx = fundingTx
+
+	walletLog.Infof("Funded batch of %v channels with funding tx %v",
+		len(b.reservations), fundingTx.TxHash())
+
+	return fundingTx, nil
+}
+
+// Publish commits the channel of each of the batch's reservations to disk,
+// then broadcasts the shared funding transaction. The funding transaction is
+// only broadcast once every channel has been committed, and on failure, none
+// of the batch's channels remain on disk. This method MUST only be called
+// once every reservation within the batch has been completed.
+func (b *FundingBatch) Publish() ([]*channeldb.OpenChannel, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.fundingTx == nil {
+		return nil, ErrBatchNotFunded
+	}
+
+	// Before committing anything to disk, we'll ensure that we've received
+	// a valid signature for each of our commitment transactions.
+	for _, res := range b.reservations {
+		res.RLock()
+		completed := res.partialState.LocalCommitment.CommitSig != nil
+		res.RUnlock()
+
+		if !completed {
+			return nil, fmt.Errorf("reservation %v hasn't been "+
+				"completed yet", res.reservationID)
+		}
+	}
+
+	// As we're about to broadcast the funding transaction, we'll take note
+	// of the current height for record keeping purposes.
+	_, bestHeight, err := b.wallet.Cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll commit every channel to disk before broadcasting the funding
+	// transaction. If any of them fail to be persisted, then the channels
+	// persisted so far are removed, ensuring none of the batch's channels
+	// are opened.
+	channels := make([]*channeldb.OpenChannel, 0, len(b.reservations))
+	for _, res := range b.reservations {
+		res.Lock()
+		err := res.partialState.SyncPending(
+			res.nodeAddr, uint32(bestHeight),
+		)
+		res.Unlock()
+		if err != nil {
+			b.rollback(channels)
+			return nil, err
+		}
+
+		channels = append(channels, res.partialState)
+	}
+
+	walletLog.Infof("Broadcasting batch funding tx %v for %v channels",
+		b.fundingTx.TxHash(), len(b.reservations))
+
+	if err := b.wallet.PublishTransaction(b.fundingTx); err != nil {
+		b.rollback(channels)
+		return nil, err
+	}
+
+	// Funding complete, the entries of the batch can be removed from
+	// limbo.
+	b.wallet.limboMtx.Lock()
+	for _, res := range b.reservations {
+		delete(b.wallet.fundingLimbo, res.reservationID)
+	}
+	b.wallet.limboMtx.Unlock()
+
+	return channels, nil
+}
+
+// rollback removes the passed channels, which were committed to disk while
+// publishing the batch, from the database. Any channel which can't be removed
+// is recorded as persisted, preventing the batch's inputs from being released
+// on cancellation, as they're still referenced by the channel's funding
+// transaction.
+//
+// NOTE: This method MUST be called with the batch's mutex held.
+func (b *FundingBatch) rollback(channels []*channeldb.OpenChannel) {
+	for _, channel := range channels {
+		closeInfo := &channeldb.ChannelCloseSummary{
+			ChanPoint: channel.FundingOutpoint,
+			ChainHash: channel.ChainHash,
+			RemotePub: channel.IdentityPub,
+			CloseType: channeldb.FundingCanceled,
+		}
+		if err := channel.CloseChannel(closeInfo); err != nil {
+			walletLog.Errorf("Unable to remove batch channel %v: %v",
+				channel.FundingOutpoint, err)
+
+			b.persisted = append(b.persisted, channel)
+		}
+	}
+}
+
+// Cancel releases the inputs locked by the batch, allowing them to be used by
+// subsequent reservations. Each of the batch's reservations must be cancelled
+// individually. If any of the batch's channels remain committed to disk, then
+// the inputs are kept locked, as they're spent by the channel's funding
+// transaction.
+func (b *FundingBatch) Cancel() {
+	b.Lock()
+	defer b.Unlock()
+
+	if len(b.persisted) != 0 {
+		walletLog.Warnf("Not releasing inputs of batch funding tx %v, "+
+			"%v channels remain persisted", b.fundingTx.TxHash(),
+			len(b.persisted))
+		return
+	}
+
+	b.wallet.coinSelectMtx.Lock()
+	defer b.wallet.coinSelectMtx.Unlock()
+
+	for _, input := range b.inputs {
+		delete(b.wallet.lockedOutPoints, input.PreviousOutPoint)
+		b.wallet.UnlockOutpoint(input.PreviousOutPoint)
+	}
+	b.inputs = nil
+}
@@ -80,6 +80,15 @@ var (
 	// our current known height.
 	ErrCommitSyncDataLoss = fmt.Errorf("possible commitment state data " +
 		"loss")
+
+	// ErrCommitSyncRemoteDataLoss is returned in the case that we receive
+	// a ChannelReestablish message from the remote node that includes the
+	// data loss protection fields AND they advertise a
+	// RemoteCommitTailHeight lower than our current known height. This
+	// indicates that the remote party has lost state, so we should force
+	// close the channel in order to allow them to sweep their funds.
+	ErrCommitSyncRemoteDataLoss = fmt.Errorf("remote party lost " +
+		"commitment state data")
 )
 
 // channelState is an enum like type which represents the current state of a
@@ -1406,7 +1415,7 @@ func (lc *LightningChannel) createSignDesc() error {
 		return err
 	}
 
-	fundingPkScript, err := witnessScriptHash(multiSigScript)
+	fundingPkScript, err := WitnessScriptHash(multiSigScript)
 	if err != nil {
 		return err
 	}
@@ -1856,6 +1865,15 @@ type BreachRetribution struct {
 	// HtlcRetributions is a slice of HTLC retributions for each output
 	// active HTLC output within the breached commitment transaction.
 	HtlcRetributions []HtlcRetribution
+
+	// KeyRing contains the derived public keys used to construct the
+	// breaching commitment transaction. This allows downstream clients to
+	// have access to the public keys used in the scripts.
+	KeyRing *CommitmentKeyRing
+
+	// RemoteDelay specifies the CSV delay applied to to-local scripts on
+	// the breaching commitment transaction.
+	RemoteDelay uint32
 }
 
 // NewBreachRetribution creates a new fully populated BreachRetribution for the
@@ -1893,16 +1911,16 @@ func NewBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 	// number so we can have the proper witness script to sign and include
 	// within the final witness.
 	remoteDelay := uint32(chanState.RemoteChanCfg.CsvDelay)
-	remotePkScript, err := commitScriptToSelf(remoteDelay, keyRing.DelayKey,
+	remotePkScript, err := CommitScriptToSelf(remoteDelay, keyRing.DelayKey,
 		keyRing.RevocationKey)
 	if err != nil {
 		return nil, err
 	}
-	remoteWitnessHash, err := witnessScriptHash(remotePkScript)
+	remoteWitnessHash, err := WitnessScriptHash(remotePkScript)
 	if err != nil {
 		return nil, err
 	}
-	localPkScript, err := commitScriptUnencumbered(keyRing.NoDelayKey)
+	localPkScript, err := CommitScriptUnencumbered(keyRing.NoDelayKey)
 	if err != nil {
 		return nil, err
 	}
@@ -2046,6 +2064,8 @@ func NewBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 		RemoteOutpoint:       remoteOutpoint,
 		RemoteOutputSignDesc: remoteSignDesc,
 		HtlcRetributions:     htlcRetributions,
+		KeyRing:              keyRing,
+		RemoteDelay:          remoteDelay,
 	}, nil
 }
 
@@ -2252,29 +2272,32 @@ func (lc *LightningChannel) createCommitmentTx(c *commitment,
 	// on its total weight. Once we have the total weight, we'll multiply
 	// by the current fee-per-kw, then divide by 1000 to get the proper
 	// fee.
-	totalCommitWeight := CommitWeight + (HtlcWeight * numHTLCs)
+	chanType := lc.channelState.ChanType
+	totalCommitWeight := baseCommitWeight(chanType) + (HtlcWeight * numHTLCs)
 
 	// With the weight known, we can now calculate the commitment fee,
 	// ensuring that we account for any dust outputs trimmed above.
 	commitFee := c.feePerKw.FeeForWeight(totalCommitWeight)
-	commitFeeMSat := lnwire.NewMSatFromSatoshis(commitFee)
 
-	// Currently, within the protocol, the initiator always pays the fees.
-	// So we'll subtract the fee amount from the balance of the current
-	// initiator. If the initiator is unable to pay the fee fully, then
-	// their entire output is consumed.
+	// Currently, within the protocol, the initiator always pays the fees,
+	// as well as the value of the anchor outputs if the channel makes use
+	// of them. So we'll subtract the total cost from the balance of the
+	// current initiator. If the initiator is unable to pay the cost fully,
+	// then their entire output is consumed.
+	commitCost := commitFee + anchorsValue(chanType)
+	commitCostMSat := lnwire.NewMSatFromSatoshis(commitCost)
 	switch {
-	case lc.channelState.IsInitiator && commitFee > ourBalance.ToSatoshis():
+	case lc.channelState.IsInitiator && commitCost > ourBalance.ToSatoshis():
 		ourBalance = 0
 
 	case lc.channelState.IsInitiator:
-		ourBalance -= commitFeeMSat
+		ourBalance -= commitCostMSat
 
-	case !lc.channelState.IsInitiator && commitFee > theirBalance.ToSatoshis():
+	case !lc.channelState.IsInitiator && commitCost > theirBalance.ToSatoshis():
 		theirBalance = 0
 
 	case !lc.channelState.IsInitiator:
-		theirBalance -= commitFeeMSat
+		theirBalance -= commitCostMSat
 	}
 
 	var (
@@ -2326,6 +2349,24 @@ func (lc *LightningChannel) createCommitmentTx(c *commitment,
 		}
 	}
 
+	// If the channel makes use of the anchor commitment format, we'll add
+	// the anchor outputs of both parties as well.
+	if chanType.HasAnchors() {
+		ownerKey := lc.localChanCfg.MultiSigKey.PubKey
+		otherKey := lc.remoteChanCfg.MultiSigKey.PubKey
+		if !c.isOurs {
+			ownerKey, otherKey = otherKey, ownerKey
+		}
+
+		err := addAnchorOutputs(
+			commitTx, ownerKey, otherKey, delayBalance >= c.dustLimit,
+			p2wkhBalance >= c.dustLimit, numHTLCs > 0,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Set the state hint of the commitment transaction to facilitate
 	// quickly recovering the necessary penalty state in the case of an
 	// uncooperative broadcast.
@@ -3110,6 +3151,20 @@ func (lc *LightningChannel) ProcessChanSyncMsg(
 		// to signal to the caller the current state.
 		return nil, nil, nil, ErrCommitSyncDataLoss
 
+	// If we don't owe the remote party a revocation, but they report a
+	// lower value for our remote chain tail while also including the
+	// recovery options, then they've likely lost data. In this case, we
+	// should force close the channel using our latest commitment, so the
+	// remote party is able to sweep their funds.
+	case (!oweRevocation && hasRecoveryOptions &&
+		msg.RemoteCommitTailHeight < localChainTail.height):
+
+		if err := lc.channelState.MarkBorked(); err != nil {
+			return nil, nil, nil, err
+		}
+
+		return nil, nil, nil, ErrCommitSyncRemoteDataLoss
+
 	// If we don't owe them a revocation, and the height of our commitment
 	// chain reported by the remote party is not equal to our chain tail,
 	// then we cannot sync.
@@ -3250,13 +3305,14 @@ func (lc *LightningChannel) computeView(view *htlcView, remoteChain bool,
 	// Add the fee from the previous commitment state back to the
 	// initiator's balance, so that the fee can be recalculated and
 	// re-applied in case fee estimation parameters have changed or the
-	// number of outstanding HTLCs has changed.
+	// number of outstanding HTLCs has changed. The same goes for the
+	// value of the anchor outputs, if any.
+	chanType := lc.channelState.ChanType
+	commitCost := commitChain.tip().fee + anchorsValue(chanType)
 	if lc.channelState.IsInitiator {
-		ourBalance += lnwire.NewMSatFromSatoshis(
-			commitChain.tip().fee)
+		ourBalance += lnwire.NewMSatFromSatoshis(commitCost)
 	} else if !lc.channelState.IsInitiator {
-		theirBalance += lnwire.NewMSatFromSatoshis(
-			commitChain.tip().fee)
+		theirBalance += lnwire.NewMSatFromSatoshis(commitCost)
 	}
 	nextHeight := commitChain.tip().height + 1
 
@@ -3322,7 +3378,7 @@ func (lc *LightningChannel) computeView(view *htlcView, remoteChain bool,
 		totalHtlcWeight += HtlcWeight
 	}
 
-	totalCommitWeight := CommitWeight + totalHtlcWeight
+	totalCommitWeight := baseCommitWeight(chanType) + totalHtlcWeight
 	return ourBalance, theirBalance, totalCommitWeight, filteredHTLCView, feePerKw
 }
 
@@ -3359,8 +3415,9 @@ func (lc *LightningChannel) validateCommitmentSanity(theirLogCounter,
 	)
 
 	// Calculate the commitment fee, and subtract it from the initiator's
-	// balance.
-	commitFee := feePerKw.FeeForWeight(commitWeight)
+	// balance, along with the value of the anchor outputs if any.
+	commitFee := feePerKw.FeeForWeight(commitWeight) +
+		anchorsValue(lc.channelState.ChanType)
 	commitFeeMsat := lnwire.NewMSatFromSatoshis(commitFee)
 	if lc.channelState.IsInitiator {
 		ourBalance -= commitFeeMsat
@@ -4474,7 +4531,7 @@ func genHtlcScript(isIncoming, ourCommit bool, timeout uint32, rHash [32]byte,
 
 	// Now that we have the redeem scripts, create the P2WSH public key
 	// script for the output itself.
-	htlcP2WSH, err := witnessScriptHash(witnessScript)
+	htlcP2WSH, err := WitnessScriptHash(witnessScript)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4570,6 +4627,26 @@ type CommitOutputResolution struct {
 	MaturityDelay uint32
 }
 
+// AnchorResolution contains the information required to sweep our anchor
+// output within a commitment transaction. Sweeping the anchor allows us to
+// bump the fee of the commitment through CPFP if it doesn't confirm in time.
+type AnchorResolution struct {
+	// CommitAnchor is the outpoint of our anchor output within the
+	// commitment transaction.
+	CommitAnchor wire.OutPoint
+
+	// AnchorSignDescriptor is a fully populated sign descriptor capable
+	// of generating a valid signature to sweep our anchor output.
+	AnchorSignDescriptor SignDescriptor
+
+	// CommitFee is the fee paid by the commitment transaction, including
+	// the value of the anchor outputs.
+	CommitFee btcutil.Amount
+
+	// CommitWeight is the weight of the commitment transaction.
+	CommitWeight int64
+}
+
 // UnilateralCloseSummary describes the details of a detected unilateral
 // channel closure. This includes the information about with which
 // transactions, and block the channel was unilaterally closed, as well as
@@ -4608,14 +4685,19 @@ type UnilateralCloseSummary struct {
 
 // NewUnilateralCloseSummary creates a new summary that provides the caller
 // with all the information required to claim all funds on chain in the event
-// that the remote party broadcasts their commitment.
+// that the remote party broadcasts their commitment. The commitPoint argument
+// should be set to the per commitment point for the remote party's
+// commitment. This will typically be the RemoteCurrentRevocation, unless
+// we've lost state, in which case it'll be the point sent by the remote party
+// during channel reestablishment.
 func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 	pCache PreimageCache, commitSpend *chainntnfs.SpendDetail,
-	remoteCommit channeldb.ChannelCommitment) (*UnilateralCloseSummary, error) {
+	remoteCommit channeldb.ChannelCommitment,
+	commitPoint *btcec.PublicKey) (*UnilateralCloseSummary, error) {
 
-	// First, we'll generate the commitment point and the revocation point
-	// so we can re-construct the HTLC state and also our payment key.
-	commitPoint := chanState.RemoteCurrentRevocation
+	// First, we'll generate the commitment keys using the commitment
+	// point so we can re-construct the HTLC state and also our payment
+	// key.
 	keyRing := deriveCommitmentKeys(
 		commitPoint, false, &chanState.LocalChanCfg,
 		&chanState.RemoteChanCfg,
@@ -4637,28 +4719,35 @@ func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 	// Before we can generate the proper sign descriptor, we'll need to
 	// locate the output index of our non-delayed output on the commitment
 	// transaction.
-	selfP2WKH, err := commitScriptUnencumbered(keyRing.NoDelayKey)
+	selfP2WKH, err := CommitScriptUnencumbered(keyRing.NoDelayKey)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create self commit script: %v", err)
 	}
-	var selfPoint *wire.OutPoint
+	var (
+		selfPoint  *wire.OutPoint
+		selfAmount btcutil.Amount
+	)
 	for outputIndex, txOut := range commitTxBroadcast.TxOut {
 		if bytes.Equal(txOut.PkScript, selfP2WKH) {
 			selfPoint = &wire.OutPoint{
 				Hash:  *commitSpend.SpenderTxHash,
 				Index: uint32(outputIndex),
 			}
+			selfAmount = btcutil.Amount(txOut.Value)
 			break
 		}
 	}
 
 	// With the HTLC's taken care of, we'll generate the sign descriptor
 	// necessary to sweep our commitment output, but only if we had a
-	// non-trimmed balance.
+	// non-trimmed balance. We use the value of the output itself rather
+	// than our balance in the commitment, as the commitment may be
+	// unknown to us if we've lost state.
+	localBalance := remoteCommit.LocalBalance.ToSatoshis()
 	var commitResolution *CommitOutputResolution
 	if selfPoint != nil {
 		localPayBase := chanState.LocalChanCfg.PaymentBasePoint
-		localBalance := remoteCommit.LocalBalance.ToSatoshis()
+		localBalance = selfAmount
 		commitResolution = &CommitOutputResolution{
 			SelfOutPoint: *selfPoint,
 			SelfOutputSignDesc: SignDescriptor{
@@ -4675,7 +4764,6 @@ func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 		}
 	}
 
-	localBalance := remoteCommit.LocalBalance.ToSatoshis()
 	closeSummary := channeldb.ChannelCloseSummary{
 		ChanPoint:      chanState.FundingOutpoint,
 		ChainHash:      chanState.ChainHash,
@@ -4819,7 +4907,7 @@ func newOutgoingHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelCon
 		if err != nil {
 			return nil, err
 		}
-		htlcScriptHash, err := witnessScriptHash(htlcReceiverScript)
+		htlcScriptHash, err := WitnessScriptHash(htlcReceiverScript)
 		if err != nil {
 			return nil, err
 		}
@@ -4899,7 +4987,7 @@ func newOutgoingHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelCon
 	if err != nil {
 		return nil, err
 	}
-	htlcScriptHash, err := witnessScriptHash(htlcSweepScript)
+	htlcScriptHash, err := WitnessScriptHash(htlcSweepScript)
 	if err != nil {
 		return nil, err
 	}
@@ -4957,7 +5045,7 @@ func newIncomingHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelCon
 		if err != nil {
 			return nil, err
 		}
-		htlcScriptHash, err := witnessScriptHash(htlcSenderScript)
+		htlcScriptHash, err := WitnessScriptHash(htlcSenderScript)
 		if err != nil {
 			return nil, err
 		}
@@ -5035,7 +5123,7 @@ func newIncomingHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelCon
 	if err != nil {
 		return nil, err
 	}
-	htlcScriptHash, err := witnessScriptHash(htlcSweepScript)
+	htlcScriptHash, err := WitnessScriptHash(htlcSweepScript)
 	if err != nil {
 		return nil, err
 	}
@@ -5161,6 +5249,14 @@ type ForceCloseSummary struct {
 	// HTLC's, we'll need to go to the second level to sweep them fully.
 	HtlcResolutions *HtlcResolutions
 
+	// AnchorResolution contains the information required to sweep our
+	// anchor output, in order to bump the fee of the commitment
+	// transaction.
+	//
+	// NOTE: This will be nil if the channel doesn't use anchor outputs, or
+	// if our commitment doesn't contain an anchor for us.
+	AnchorResolution *AnchorResolution
+
 	// ChanSnapshot is a snapshot of the final state of the channel at the
 	// time it was closed.
 	ChanSnapshot channeldb.ChannelSnapshot
@@ -5203,12 +5299,12 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 	commitPoint := ComputeCommitmentPoint(unusedRevocation[:])
 	keyRing := deriveCommitmentKeys(commitPoint, true, lc.localChanCfg,
 		lc.remoteChanCfg)
-	selfScript, err := commitScriptToSelf(csvTimeout, keyRing.DelayKey,
+	selfScript, err := CommitScriptToSelf(csvTimeout, keyRing.DelayKey,
 		keyRing.RevocationKey)
 	if err != nil {
 		return nil, err
 	}
-	payToUsScriptHash, err := witnessScriptHash(selfScript)
+	payToUsScriptHash, err := WitnessScriptHash(selfScript)
 	if err != nil {
 		return nil, err
 	}
@@ -5275,15 +5371,77 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 		return nil, err
 	}
 
+	anchorResolution, err := newAnchorResolution(lc.channelState, commitTx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ForceCloseSummary{
 		ChanPoint:        lc.channelState.FundingOutpoint,
 		CloseTx:          commitTx,
 		CommitResolution: commitResolution,
 		HtlcResolutions:  htlcResolutions,
+		AnchorResolution: anchorResolution,
 		ChanSnapshot:     *lc.channelState.Snapshot(),
 	}, nil
 }
 
+// newAnchorResolution returns the information required to sweep our anchor
+// output within the passed commitment transaction. If the channel doesn't use
+// anchor outputs, or the commitment doesn't contain an anchor for us, then nil
+// is returned.
+func newAnchorResolution(chanState *channeldb.OpenChannel,
+	commitTx *wire.MsgTx) (*AnchorResolution, error) {
+
+	if !chanState.ChanType.HasAnchors() {
+		return nil, nil
+	}
+
+	localKey := chanState.LocalChanCfg.MultiSigKey
+	anchorScript, err := CommitScriptAnchor(localKey.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	anchorScriptHash, err := WitnessScriptHash(anchorScript)
+	if err != nil {
+		return nil, err
+	}
+
+	// Our anchor output may not be present if we have no stake within the
+	// commitment, in which case there's nothing for us to sweep.
+	found, index := FindScriptOutputIndex(commitTx, anchorScriptHash)
+	if !found {
+		return nil, nil
+	}
+
+	// As the commitment transaction spends the funding output, the fee it
+	// pays is the capacity of the channel less the value of its outputs.
+	var outputValue btcutil.Amount
+	for _, txOut := range commitTx.TxOut {
+		outputValue += btcutil.Amount(txOut.Value)
+	}
+
+	return &AnchorResolution{
+		CommitAnchor: wire.OutPoint{
+			Hash:  commitTx.TxHash(),
+			Index: index,
+		},
+		AnchorSignDescriptor: SignDescriptor{
+			KeyDesc:       localKey,
+			WitnessScript: anchorScript,
+			Output: &wire.TxOut{
+				PkScript: anchorScriptHash,
+				Value:    int64(AnchorSize),
+			},
+			HashType: txscript.SigHashAll,
+		},
+		CommitFee: chanState.Capacity - outputValue,
+		CommitWeight: blockchain.GetTransactionWeight(
+			btcutil.NewTx(commitTx),
+		),
+	}, nil
+}
+
 // CreateCloseProposal is used by both parties in a cooperative channel close
 // workflow to generate proposed close transactions and signatures. This method
 // should only be executed once all pending HTLCs (if any) on the channel have
@@ -5315,8 +5473,10 @@ func (lc *LightningChannel) CreateCloseProposal(proposedFee btcutil.Amount,
 	theirBalance := localCommit.RemoteBalance.ToSatoshis()
 
 	// We'll make sure we account for the complete balance by adding the
-	// current dangling commitment fee to the balance of the initiator.
-	commitFee := localCommit.CommitFee
+	// current dangling commitment fee, along with the value of the anchor
+	// outputs if any, to the balance of the initiator.
+	commitFee := localCommit.CommitFee +
+		anchorsValue(lc.channelState.ChanType)
 	if lc.channelState.IsInitiator {
 		ourBalance = ourBalance - proposedFee + commitFee
 	} else {
@@ -5382,8 +5542,10 @@ func (lc *LightningChannel) CompleteCooperativeClose(localSig, remoteSig []byte,
 	theirBalance := localCommit.RemoteBalance.ToSatoshis()
 
 	// We'll make sure we account for the complete balance by adding the
-	// current dangling commitment fee to the balance of the initiator.
-	commitFee := localCommit.CommitFee
+	// current dangling commitment fee, along with the value of the anchor
+	// outputs if any, to the balance of the initiator.
+	commitFee := localCommit.CommitFee +
+		anchorsValue(lc.channelState.ChanType)
 	if lc.channelState.IsInitiator {
 		ourBalance = ourBalance - proposedFee + commitFee
 	} else {
@@ -5471,8 +5633,10 @@ func (lc *LightningChannel) availableBalance() (lnwire.MilliSatoshi, int64) {
 		lc.computeView(htlcView, false, false)
 
 	// If we are the channel initiator, we must remember to subtract the
-	// commitment fee from our available balance.
-	commitFee := feePerKw.FeeForWeight(commitWeight)
+	// commitment fee, along with the value of the anchor outputs if any,
+	// from our available balance.
+	commitFee := feePerKw.FeeForWeight(commitWeight) +
+		anchorsValue(lc.channelState.ChanType)
 	if lc.channelState.IsInitiator {
 		ourBalance -= lnwire.NewMSatFromSatoshis(commitFee)
 	}
@@ -5626,19 +5790,19 @@ func CreateCommitTx(fundingOutput wire.TxIn,
 	// output after a relative block delay, or the remote node can claim
 	// the funds with the revocation key if we broadcast a revoked
 	// commitment transaction.
-	ourRedeemScript, err := commitScriptToSelf(csvTimeout, keyRing.DelayKey,
+	ourRedeemScript, err := CommitScriptToSelf(csvTimeout, keyRing.DelayKey,
 		keyRing.RevocationKey)
 	if err != nil {
 		return nil, err
 	}
-	payToUsScriptHash, err := witnessScriptHash(ourRedeemScript)
+	payToUsScriptHash, err := WitnessScriptHash(ourRedeemScript)
 	if err != nil {
 		return nil, err
 	}
 
 	// Next, we create the script paying to them. This is just a regular
 	// P2WPKH output, without any added CSV delay.
-	theirWitnessKeyHash, err := commitScriptUnencumbered(keyRing.NoDelayKey)
+	theirWitnessKeyHash, err := CommitScriptUnencumbered(keyRing.NoDelayKey)
 	if err != nil {
 		return nil, err
 	}
@@ -5673,6 +5837,58 @@ func CreateCommitTx(fundingOutput wire.TxIn,
 	return commitTx, nil
 }
 
+// anchorsValue returns the total value of the anchor outputs carried by the
+// commitment transactions of a channel of the passed type. This value is paid
+// for by the initiator of the channel, along with the commitment fee.
+func anchorsValue(chanType channeldb.ChannelType) btcutil.Amount {
+	if !chanType.HasAnchors() {
+		return 0
+	}
+
+	return 2 * AnchorSize
+}
+
+// addAnchorOutputs adds the anchor outputs of both parties to the passed
+// commitment transaction of a channel that makes use of the anchor commitment
+// format. Each anchor is locked to the funding key of its owner. The anchor of
+// a party is only added if they have an output on the commitment, or if the
+// commitment carries any HTLC outputs, as they otherwise have no stake in the
+// confirmation of the commitment.
+func addAnchorOutputs(commitTx *wire.MsgTx, ownerKey, otherKey *btcec.PublicKey,
+	ownerOutput, otherOutput, hasHtlcs bool) error {
+
+	addAnchor := func(key *btcec.PublicKey) error {
+		anchorScript, err := CommitScriptAnchor(key)
+		if err != nil {
+			return err
+		}
+		anchorPkScript, err := WitnessScriptHash(anchorScript)
+		if err != nil {
+			return err
+		}
+
+		commitTx.AddTxOut(&wire.TxOut{
+			PkScript: anchorPkScript,
+			Value:    int64(AnchorSize),
+		})
+
+		return nil
+	}
+
+	if ownerOutput || hasHtlcs {
+		if err := addAnchor(ownerKey); err != nil {
+			return err
+		}
+	}
+	if otherOutput || hasHtlcs {
+		if err := addAnchor(otherKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // CreateCooperativeCloseTx creates a transaction which if signed by both
 // parties, then broadcast cooperatively closes an active channel. The creation
 // of the closure transaction is modified by a boolean indicating if the party
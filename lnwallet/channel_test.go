@@ -163,6 +163,17 @@ func forceStateTransition(chanA, chanB *LightningChannel) error {
 func createTestChannels(revocationWindow int) (*LightningChannel,
 	*LightningChannel, func(), error) {
 
+	return createTestChannelsWithType(
+		revocationWindow, channeldb.SingleFunder,
+	)
+}
+
+// createTestChannelsWithType creates two test lightning channels of the passed
+// channel type, in the same manner as createTestChannels.
+func createTestChannelsWithType(revocationWindow int,
+	chanType channeldb.ChannelType) (*LightningChannel, *LightningChannel,
+	func(), error) {
+
 	channelCapacity, err := btcutil.NewAmount(10)
 	if err != nil {
 		return nil, nil, nil, err
@@ -275,9 +286,9 @@ func createTestChannels(revocationWindow int) (*LightningChannel,
 	}
 	aliceCommitPoint := ComputeCommitmentPoint(aliceFirstRevoke[:])
 
-	aliceCommitTx, bobCommitTx, err := CreateCommitmentTxns(channelBal,
-		channelBal, &aliceCfg, &bobCfg, aliceCommitPoint, bobCommitPoint,
-		*fundingTxIn)
+	aliceCommitTx, bobCommitTx, err := CreateCommitmentTxns(chanType,
+		channelBal, channelBal, &aliceCfg, &bobCfg, aliceCommitPoint,
+		bobCommitPoint, *fundingTxIn)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -302,9 +313,17 @@ func createTestChannels(revocationWindow int) (*LightningChannel,
 	feePerKw := feePerVSize.FeePerKWeight()
 	commitFee := calcStaticFee(0)
 
+	// If the channel makes use of anchors, Alice as the initiator also
+	// pays for the anchor outputs.
+	initiatorCost := commitFee
+	if chanType.HasAnchors() {
+		commitFee = feePerKw.FeeForWeight(AnchorCommitWeight)
+		initiatorCost = commitFee + 2*AnchorSize
+	}
+
 	aliceCommit := channeldb.ChannelCommitment{
 		CommitHeight:  0,
-		LocalBalance:  lnwire.NewMSatFromSatoshis(channelBal - commitFee),
+		LocalBalance:  lnwire.NewMSatFromSatoshis(channelBal - initiatorCost),
 		RemoteBalance: lnwire.NewMSatFromSatoshis(channelBal),
 		CommitFee:     commitFee,
 		FeePerKw:      btcutil.Amount(feePerKw),
@@ -314,7 +333,7 @@ func createTestChannels(revocationWindow int) (*LightningChannel,
 	bobCommit := channeldb.ChannelCommitment{
 		CommitHeight:  0,
 		LocalBalance:  lnwire.NewMSatFromSatoshis(channelBal),
-		RemoteBalance: lnwire.NewMSatFromSatoshis(channelBal - commitFee),
+		RemoteBalance: lnwire.NewMSatFromSatoshis(channelBal - initiatorCost),
 		CommitFee:     commitFee,
 		FeePerKw:      btcutil.Amount(feePerKw),
 		CommitTx:      bobCommitTx,
@@ -336,7 +355,7 @@ func createTestChannels(revocationWindow int) (*LightningChannel,
 		IdentityPub:             aliceKeys[0].PubKey(),
 		FundingOutpoint:         *prevOut,
 		ShortChanID:             shortChanID,
-		ChanType:                channeldb.SingleFunder,
+		ChanType:                chanType,
 		IsInitiator:             true,
 		Capacity:                channelCapacity,
 		RemoteCurrentRevocation: bobCommitPoint,
@@ -354,7 +373,7 @@ func createTestChannels(revocationWindow int) (*LightningChannel,
 		IdentityPub:             bobKeys[0].PubKey(),
 		FundingOutpoint:         *prevOut,
 		ShortChanID:             shortChanID,
-		ChanType:                channeldb.SingleFunder,
+		ChanType:                chanType,
 		IsInitiator:             false,
 		Capacity:                channelCapacity,
 		RemoteCurrentRevocation: aliceCommitPoint,
@@ -1307,6 +1326,102 @@ func TestForceCloseDustOutput(t *testing.T) {
 	}
 }
 
+// TestForceCloseAnchors tests that the commitment transactions of a channel
+// using anchor outputs carry an anchor for each party, and that the
+// ForceCloseSummary contains the information required to sweep our anchor in
+// order to bump the fee of the commitment.
+func TestForceCloseAnchors(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := createTestChannelsWithType(
+		1, channeldb.SingleFunder|channeldb.AnchorOutputsBit,
+	)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// We'll start by having Alice add an HTLC, and locking it into both
+	// commitments, to ensure commitments with anchors are properly signed
+	// and validated by the state machine.
+	htlc, _ := createHTLC(0, lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin))
+	if _, err := aliceChannel.AddHTLC(htlc, nil); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to complete state update: %v", err)
+	}
+
+	for _, channel := range []*LightningChannel{aliceChannel, bobChannel} {
+		localCommit := channel.channelState.LocalCommitment
+
+		// The commitment should carry two anchors in addition to the
+		// outputs of both parties and the HTLC.
+		var numAnchors int
+		for _, txOut := range localCommit.CommitTx.TxOut {
+			if txOut.Value == int64(AnchorSize) {
+				numAnchors++
+			}
+		}
+		if numAnchors != 2 {
+			t.Fatalf("expected 2 anchor outputs, found %v",
+				numAnchors)
+		}
+
+		closeSummary, err := channel.ForceClose()
+		if err != nil {
+			t.Fatalf("unable to force close channel: %v", err)
+		}
+
+		anchorRes := closeSummary.AnchorResolution
+		if anchorRes == nil {
+			t.Fatalf("expected anchor resolution")
+		}
+		if anchorRes.CommitAnchor.Hash != closeSummary.CloseTx.TxHash() {
+			t.Fatalf("anchor doesn't point to commitment")
+		}
+		if anchorRes.CommitFee != localCommit.CommitFee {
+			t.Fatalf("expected commit fee %v, got %v",
+				localCommit.CommitFee, anchorRes.CommitFee)
+		}
+
+		// Finally, we'll ensure the anchor can be swept using the
+		// returned sign descriptor.
+		sweepTx := wire.NewMsgTx(2)
+		sweepTx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: anchorRes.CommitAnchor,
+		})
+		sweepTx.AddTxOut(&wire.TxOut{
+			PkScript: anchorRes.AnchorSignDescriptor.Output.PkScript,
+			Value:    int64(AnchorSize),
+		})
+
+		signDesc := anchorRes.AnchorSignDescriptor
+		signDesc.SigHashes = txscript.NewTxSigHashes(sweepTx)
+		witness, err := CommitmentAnchor.GenWitnessFunc(
+			channel.signer, &signDesc,
+		)(sweepTx, signDesc.SigHashes, 0)
+		if err != nil {
+			t.Fatalf("unable to generate anchor witness: %v", err)
+		}
+		sweepTx.TxIn[0].Witness = witness
+
+		anchorOut := closeSummary.CloseTx.TxOut[anchorRes.CommitAnchor.Index]
+		vm, err := txscript.NewEngine(anchorOut.PkScript,
+			sweepTx, 0, txscript.StandardVerifyFlags, nil,
+			nil, anchorOut.Value)
+		if err != nil {
+			t.Fatalf("unable to create engine: %v", err)
+		}
+		if err := vm.Execute(); err != nil {
+			t.Fatalf("anchor spend is invalid: %v", err)
+		}
+	}
+}
+
 // TestDustHTLCFees checks that fees are calculated correctly when HTLCs fall
 // below the nodes' dust limit. In these cases, the amount of the dust HTLCs
 // should be applied to the commitment transaction fee.
@@ -4492,6 +4607,7 @@ func TestChannelUnilateralCloseHtlcResolution(t *testing.T) {
 	aliceCloseSummary, err := NewUnilateralCloseSummary(
 		aliceChannel.channelState, aliceChannel.signer, aliceChannel.pCache,
 		spendDetail, aliceChannel.channelState.RemoteCommitment,
+		aliceChannel.channelState.RemoteCurrentRevocation,
 	)
 	if err != nil {
 		t.Fatalf("unable to create alice close summary: %v", err)
@@ -2,6 +2,12 @@ package lnwallet
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/rpcclient"
@@ -315,3 +321,434 @@ func (b *BitcoindFeeEstimator) fetchEstimatePerVSize(
 // A compile-time assertion to ensure that BitcoindFeeEstimator implements the
 // FeeEstimator interface.
 var _ FeeEstimator = (*BitcoindFeeEstimator)(nil)
+
+// WebAPIFeeSource is an interface that allows the WebAPIFeeEstimator to query
+// an arbitrary HTTP-based fee estimation service.
+type WebAPIFeeSource interface {
+	// GenQueryURL generates the full query URL. The value returned by this
+	// method should be able to be used directly as a path for an HTTP GET
+	// request.
+	GenQueryURL() string
+
+	// ParseResponse attempts to parse the body of the response generated
+	// by the above query URL. Typically this will be JSON, but the
+	// specifics are left to the WebAPIFeeSource implementation. The
+	// returned map should be keyed by confirmation target, with fee rates
+	// expressed in sat/vbyte.
+	ParseResponse(r io.Reader) (map[uint32]uint32, error)
+}
+
+// SparseConfFeeSource is an implementation of the WebAPIFeeSource that
+// utilizes a user-specified fee estimation API that returns fee rates for a
+// sparse set of confirmation targets, in the form:
+//
+//	{"fee_by_block_target": {"2": 70, "6": 25, "144": 2}}
+//
+// Where each fee rate is expressed in sat/vbyte.
+type SparseConfFeeSource struct {
+	// URL is the fee estimation API specified by the user.
+	URL string
+}
+
+// GenQueryURL generates the full query URL. The value returned by this method
+// should be able to be used directly as a path for an HTTP GET request.
+//
+// NOTE: This method is part of the WebAPIFeeSource interface.
+func (s SparseConfFeeSource) GenQueryURL() string {
+	return s.URL
+}
+
+// ParseResponse attempts to parse the body of the response generated by the
+// above query URL.
+//
+// NOTE: This method is part of the WebAPIFeeSource interface.
+func (s SparseConfFeeSource) ParseResponse(r io.Reader) (map[uint32]uint32, error) {
+	type jsonResp struct {
+		FeeByBlockTarget map[uint32]uint32 `json:"fee_by_block_target"`
+	}
+
+	resp := jsonResp{
+		FeeByBlockTarget: make(map[uint32]uint32),
+	}
+	jsonReader := json.NewDecoder(r)
+	if err := jsonReader.Decode(&resp); err != nil {
+		return nil, err
+	}
+
+	return resp.FeeByBlockTarget, nil
+}
+
+// A compile-time assertion to ensure that SparseConfFeeSource implements the
+// WebAPIFeeSource interface.
+var _ WebAPIFeeSource = (*SparseConfFeeSource)(nil)
+
+const (
+	// webAPIUpdateInterval is the interval at which the WebAPIFeeEstimator
+	// will refresh its fee estimates from the fee estimation service.
+	webAPIUpdateInterval = 10 * time.Minute
+
+	// webAPITimeout is the maximum amount of time we'll wait for the fee
+	// estimation service to respond to a query.
+	webAPITimeout = 10 * time.Second
+)
+
+// WebAPIFeeEstimator is an implementation of the FeeEstimator interface that
+// queries an HTTP-based fee estimation service for its estimates. The
+// estimates are fetched when the estimator is started, and refreshed
+// periodically afterwards, such that fee estimation requests never block on
+// the network.
+type WebAPIFeeEstimator struct {
+	started sync.Once
+	stopped sync.Once
+
+	apiSource WebAPIFeeSource
+
+	// defaultFeePerVSize is the fee rate in sat/vbyte that is returned if
+	// the fee estimation service hasn't yet provided any estimates.
+	defaultFeePerVSize SatPerVByte
+
+	client *http.Client
+
+	feeByBlockTarget map[uint32]uint32
+	mtx              sync.RWMutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewWebAPIFeeEstimator creates a new WebAPIFeeEstimator from a given
+// WebAPIFeeSource and default fee rate. The optional dial function is used to
+// establish connections to the fee estimation service, allowing the queries to
+// be proxied, e.g. through Tor. If it is nil, a regular network connection is
+// used.
+func NewWebAPIFeeEstimator(api WebAPIFeeSource, defaultFee SatPerVByte,
+	dial func(network, addr string) (net.Conn, error)) *WebAPIFeeEstimator {
+
+	transport := &http.Transport{}
+	if dial != nil {
+		transport.Dial = dial
+	}
+
+	return &WebAPIFeeEstimator{
+		apiSource:          api,
+		defaultFeePerVSize: defaultFee,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   webAPITimeout,
+		},
+		feeByBlockTarget: make(map[uint32]uint32),
+		quit:             make(chan struct{}),
+	}
+}
+
+// Start signals the FeeEstimator to start any processes or goroutines
+// it needs to perform its duty.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (w *WebAPIFeeEstimator) Start() error {
+	w.started.Do(func() {
+		walletLog.Infof("Starting web API fee estimator")
+
+		// We'll populate our fee estimates before returning, failing
+		// to do so isn't fatal however, as the estimates will be
+		// fetched again on the next update.
+		w.updateFeeEstimates()
+
+		w.wg.Add(1)
+		go w.feeUpdateManager()
+	})
+
+	return nil
+}
+
+// Stop stops any spawned goroutines and cleans up the resources used
+// by the fee estimator.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (w *WebAPIFeeEstimator) Stop() error {
+	w.stopped.Do(func() {
+		walletLog.Infof("Stopping web API fee estimator")
+
+		close(w.quit)
+		w.wg.Wait()
+	})
+
+	return nil
+}
+
+// EstimateFeePerVSize takes in a target for the number of blocks until an
+// initial confirmation and returns the estimated fee expressed in
+// satoshis/vbyte. If the service doesn't report an estimate for the exact
+// confirmation target, the estimate of the closest faster target is used,
+// falling back to the fastest target known.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (w *WebAPIFeeEstimator) EstimateFeePerVSize(numBlocks uint32) (SatPerVByte, error) {
+	if numBlocks == 0 {
+		return 0, fmt.Errorf("conf target must be greater than 0")
+	}
+
+	w.mtx.RLock()
+	defer w.mtx.RUnlock()
+
+	if len(w.feeByBlockTarget) == 0 {
+		return w.defaultFeePerVSize, nil
+	}
+
+	if fee, ok := w.feeByBlockTarget[numBlocks]; ok {
+		return SatPerVByte(fee), nil
+	}
+
+	// As we don't have an estimate for the exact target, we'll use the
+	// estimate for the highest target below it, as that will confirm at
+	// least as fast as requested. If there is no such target, we'll use
+	// the estimate for the lowest target known.
+	var (
+		closest, lowest       uint32
+		closestFee, lowestFee uint32
+	)
+	for target, fee := range w.feeByBlockTarget {
+		if target < numBlocks && target > closest {
+			closest, closestFee = target, fee
+		}
+		if lowest == 0 || target < lowest {
+			lowest, lowestFee = target, fee
+		}
+	}
+
+	feeRate := SatPerVByte(lowestFee)
+	if closest != 0 {
+		feeRate = SatPerVByte(closestFee)
+	}
+
+	walletLog.Debugf("Web API returning %v sat/vbyte for conf target of "+
+		"%v", int64(feeRate), numBlocks)
+
+	return feeRate, nil
+}
+
+// feeUpdateManager periodically refreshes the fee estimates from the fee
+// estimation service.
+//
+// NOTE: This MUST be run as a goroutine.
+func (w *WebAPIFeeEstimator) feeUpdateManager() {
+	defer w.wg.Done()
+
+	updateTicker := time.NewTicker(webAPIUpdateInterval)
+	defer updateTicker.Stop()
+
+	for {
+		select {
+		case <-updateTicker.C:
+			w.updateFeeEstimates()
+
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+// updateFeeEstimates queries the fee estimation service and replaces the
+// cached set of estimates with the result. If the query fails, the previous
+// estimates are kept.
+func (w *WebAPIFeeEstimator) updateFeeEstimates() {
+	feeByBlockTarget, err := w.fetchFeeEstimates()
+	if err != nil {
+		walletLog.Errorf("unable to query web API fee estimator: %v",
+			err)
+		return
+	}
+
+	// Targets of zero, as well as estimates of zero, aren't meaningful, so
+	// we'll ignore them.
+	for target, fee := range feeByBlockTarget {
+		if target == 0 || fee == 0 {
+			delete(feeByBlockTarget, target)
+		}
+	}
+	if len(feeByBlockTarget) == 0 {
+		walletLog.Errorf("web API fee estimator returned no estimates")
+		return
+	}
+
+	w.mtx.Lock()
+	w.feeByBlockTarget = feeByBlockTarget
+	w.mtx.Unlock()
+}
+
+// fetchFeeEstimates queries the fee estimation service for the current set of
+// fee estimates.
+func (w *WebAPIFeeEstimator) fetchFeeEstimates() (map[uint32]uint32, error) {
+	targetURL := w.apiSource.GenQueryURL()
+
+	resp, err := w.client.Get(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status from %v: %v",
+			targetURL, resp.Status)
+	}
+
+	return w.apiSource.ParseResponse(resp.Body)
+}
+
+// A compile-time assertion to ensure that WebAPIFeeEstimator implements the
+// FeeEstimator interface.
+var _ FeeEstimator = (*WebAPIFeeEstimator)(nil)
+
+// FeePerVSizeFloor is the lowest fee rate in sat/vbyte that the
+// CompositeFeeEstimator will return, as transactions paying less than this
+// won't be relayed by the default policy of most nodes.
+const FeePerVSizeFloor = SatPerVByte(1)
+
+// cachedFeeEstimate is a fee estimate returned by the CompositeFeeEstimator,
+// along with the time at which it should be refreshed.
+type cachedFeeEstimate struct {
+	feeRate SatPerVByte
+	expiry  time.Time
+}
+
+// CompositeFeeEstimator is an implementation of the FeeEstimator interface
+// that consults a list of fee estimators in order of preference, returning
+// the estimate of the first one that is able to produce one. The estimates
+// are cached for a configurable amount of time, and bounded to sane values,
+// such that a single misbehaving source can't cause us to pay an outrageous
+// fee.
+//
+// As an estimator returning an error or a non-positive fee rate is considered
+// to have failed, estimators backed by a node's RPC interface should be
+// created with a zero fall back fee rate, allowing the next estimator to be
+// consulted instead.
+type CompositeFeeEstimator struct {
+	// estimators is the list of fee estimators, in order of preference.
+	estimators []FeeEstimator
+
+	// fallBackFeeRate is the fee rate in sat/vbyte that is returned if
+	// none of the estimators is able to produce an estimate.
+	fallBackFeeRate SatPerVByte
+
+	// maxFeeRate is the largest fee rate in sat/vbyte that will be
+	// returned. Any estimate above it is capped to this value.
+	maxFeeRate SatPerVByte
+
+	// cacheDuration is the amount of time an estimate for a particular
+	// confirmation target is reused before consulting the estimators
+	// again.
+	cacheDuration time.Duration
+
+	cache map[uint32]cachedFeeEstimate
+	mtx   sync.Mutex
+}
+
+// NewCompositeFeeEstimator creates a new CompositeFeeEstimator that consults
+// the passed estimators in order. The fall back fee rate is returned if none
+// of the estimators is able to produce an estimate, while every returned fee
+// rate is bounded between FeePerVSizeFloor and maxFeeRate.
+func NewCompositeFeeEstimator(estimators []FeeEstimator,
+	fallBackFeeRate, maxFeeRate SatPerVByte,
+	cacheDuration time.Duration) (*CompositeFeeEstimator, error) {
+
+	if len(estimators) == 0 {
+		return nil, fmt.Errorf("at least one fee estimator is required")
+	}
+	if maxFeeRate < FeePerVSizeFloor {
+		return nil, fmt.Errorf("max fee rate of %v sat/vbyte is below "+
+			"the floor of %v sat/vbyte", int64(maxFeeRate),
+			int64(FeePerVSizeFloor))
+	}
+
+	return &CompositeFeeEstimator{
+		estimators:      estimators,
+		fallBackFeeRate: fallBackFeeRate,
+		maxFeeRate:      maxFeeRate,
+		cacheDuration:   cacheDuration,
+		cache:           make(map[uint32]cachedFeeEstimate),
+	}, nil
+}
+
+// Start signals the FeeEstimator to start any processes or goroutines
+// it needs to perform its duty. All of the underlying estimators are started.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (c *CompositeFeeEstimator) Start() error {
+	for _, estimator := range c.estimators {
+		if err := estimator.Start(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop stops any spawned goroutines and cleans up the resources used
+// by the fee estimator. All of the underlying estimators are stopped.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (c *CompositeFeeEstimator) Stop() error {
+	for _, estimator := range c.estimators {
+		if err := estimator.Stop(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EstimateFeePerVSize takes in a target for the number of blocks until an
+// initial confirmation and returns the estimated fee expressed in
+// satoshis/vbyte.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (c *CompositeFeeEstimator) EstimateFeePerVSize(numBlocks uint32) (SatPerVByte, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	if cached, ok := c.cache[numBlocks]; ok && now.Before(cached.expiry) {
+		return cached.feeRate, nil
+	}
+
+	feeRate := c.fallBackFeeRate
+	for i, estimator := range c.estimators {
+		feeEstimate, err := estimator.EstimateFeePerVSize(numBlocks)
+		if err != nil {
+			walletLog.Debugf("Fee estimator %v failed for conf "+
+				"target of %v: %v", i, numBlocks, err)
+			continue
+		}
+		if feeEstimate <= 0 {
+			continue
+		}
+
+		feeRate = feeEstimate
+		break
+	}
+
+	// Finally, we'll make sure the estimate is within our bounds before
+	// caching and returning it.
+	switch {
+	case feeRate < FeePerVSizeFloor:
+		feeRate = FeePerVSizeFloor
+
+	case feeRate > c.maxFeeRate:
+		walletLog.Warnf("Fee estimate of %v sat/vbyte for conf target "+
+			"of %v exceeds maximum, using %v sat/vbyte",
+			int64(feeRate), numBlocks, int64(c.maxFeeRate))
+
+		feeRate = c.maxFeeRate
+	}
+
+	c.cache[numBlocks] = cachedFeeEstimate{
+		feeRate: feeRate,
+		expiry:  now.Add(c.cacheDuration),
+	}
+
+	return feeRate, nil
+}
+
+// A compile-time assertion to ensure that CompositeFeeEstimator implements the
+// FeeEstimator interface.
+var _ FeeEstimator = (*CompositeFeeEstimator)(nil)
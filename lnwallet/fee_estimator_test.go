@@ -1,7 +1,13 @@
 package lnwallet_test
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcutil"
@@ -92,3 +98,291 @@ func TestStaticFeeEstimator(t *testing.T) {
 		t.Fatalf("expected fee rate %v, got %v", feePerVSize, feeRate)
 	}
 }
+
+// TestSparseConfFeeSource checks that SparseConfFeeSource generates the
+// correct query URL and parses the fee estimation response.
+func TestSparseConfFeeSource(t *testing.T) {
+	t.Parallel()
+
+	const testURL = "https://example.com/fees"
+	feeSource := lnwallet.SparseConfFeeSource{
+		URL: testURL,
+	}
+
+	if queryURL := feeSource.GenQueryURL(); queryURL != testURL {
+		t.Fatalf("expected query URL %v, got %v", testURL, queryURL)
+	}
+
+	resp := `{"fee_by_block_target": {"2": 70, "6": 25, "144": 2}}`
+	feeByBlockTarget, err := feeSource.ParseResponse(
+		strings.NewReader(resp),
+	)
+	if err != nil {
+		t.Fatalf("unable to parse response: %v", err)
+	}
+
+	expected := map[uint32]uint32{2: 70, 6: 25, 144: 2}
+	if !reflect.DeepEqual(feeByBlockTarget, expected) {
+		t.Fatalf("expected %v, got %v", expected, feeByBlockTarget)
+	}
+
+	// A malformed response should be rejected.
+	_, err = feeSource.ParseResponse(strings.NewReader("not json"))
+	if err == nil {
+		t.Fatalf("expected malformed response to be rejected")
+	}
+}
+
+// TestWebAPIFeeEstimator checks that the WebAPIFeeEstimator returns the
+// estimates of a local fee estimation service, choosing the closest faster
+// confirmation target if no estimate exists for the one requested.
+func TestWebAPIFeeEstimator(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"fee_by_block_target": `+
+				`{"2": 70, "6": 25, "144": 2}}`)
+		},
+	))
+	defer server.Close()
+
+	const defaultFee = 50
+	feeEstimator := lnwallet.NewWebAPIFeeEstimator(
+		lnwallet.SparseConfFeeSource{URL: server.URL}, defaultFee, nil,
+	)
+	if err := feeEstimator.Start(); err != nil {
+		t.Fatalf("unable to start fee estimator: %v", err)
+	}
+	defer feeEstimator.Stop()
+
+	testCases := []struct {
+		confTarget uint32
+		expected   lnwallet.SatPerVByte
+	}{
+		// Exact targets should return their estimate.
+		{confTarget: 2, expected: 70},
+		{confTarget: 6, expected: 25},
+		{confTarget: 144, expected: 2},
+
+		// Targets in between known ones should use the closest faster
+		// target.
+		{confTarget: 3, expected: 70},
+		{confTarget: 100, expected: 25},
+		{confTarget: 1000, expected: 2},
+
+		// Targets below the fastest known one should use the fastest
+		// target's estimate.
+		{confTarget: 1, expected: 70},
+	}
+	for _, test := range testCases {
+		feeRate, err := feeEstimator.EstimateFeePerVSize(
+			test.confTarget,
+		)
+		if err != nil {
+			t.Fatalf("unable to get fee rate: %v", err)
+		}
+		if feeRate != test.expected {
+			t.Fatalf("expected fee rate %v for conf target %v, "+
+				"got %v", test.expected, test.confTarget,
+				feeRate)
+		}
+	}
+
+	// A conf target of zero is invalid.
+	if _, err := feeEstimator.EstimateFeePerVSize(0); err == nil {
+		t.Fatalf("expected conf target of zero to be rejected")
+	}
+}
+
+// TestWebAPIFeeEstimatorUnavailable checks that the WebAPIFeeEstimator returns
+// its default fee rate if the fee estimation service is unavailable.
+func TestWebAPIFeeEstimatorUnavailable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		},
+	))
+	defer server.Close()
+
+	const defaultFee = 50
+	feeEstimator := lnwallet.NewWebAPIFeeEstimator(
+		lnwallet.SparseConfFeeSource{URL: server.URL}, defaultFee, nil,
+	)
+	if err := feeEstimator.Start(); err != nil {
+		t.Fatalf("unable to start fee estimator: %v", err)
+	}
+	defer feeEstimator.Stop()
+
+	feeRate, err := feeEstimator.EstimateFeePerVSize(6)
+	if err != nil {
+		t.Fatalf("unable to get fee rate: %v", err)
+	}
+	if feeRate != defaultFee {
+		t.Fatalf("expected fee rate %v, got %v", defaultFee, feeRate)
+	}
+}
+
+// mockFeeEstimator is a FeeEstimator that returns a fixed fee rate or error,
+// and counts the number of estimates requested from it.
+type mockFeeEstimator struct {
+	feeRate lnwallet.SatPerVByte
+	err     error
+
+	numCalls int
+}
+
+func (m *mockFeeEstimator) EstimateFeePerVSize(
+	numBlocks uint32) (lnwallet.SatPerVByte, error) {
+
+	m.numCalls++
+	return m.feeRate, m.err
+}
+
+func (m *mockFeeEstimator) Start() error {
+	return nil
+}
+
+func (m *mockFeeEstimator) Stop() error {
+	return nil
+}
+
+// TestCompositeFeeEstimator checks that the CompositeFeeEstimator consults its
+// estimators in order, and that the returned estimates are bounded.
+func TestCompositeFeeEstimator(t *testing.T) {
+	t.Parallel()
+
+	const (
+		fallBackFee = 30
+		maxFee      = 500
+	)
+
+	testCases := []struct {
+		name       string
+		estimators []*mockFeeEstimator
+		expected   lnwallet.SatPerVByte
+	}{
+		{
+			name: "first estimator succeeds",
+			estimators: []*mockFeeEstimator{
+				{feeRate: 20},
+				{feeRate: 40},
+			},
+			expected: 20,
+		},
+		{
+			name: "first estimator errors",
+			estimators: []*mockFeeEstimator{
+				{err: fmt.Errorf("unavailable")},
+				{feeRate: 40},
+			},
+			expected: 40,
+		},
+		{
+			name: "first estimator has no estimate",
+			estimators: []*mockFeeEstimator{
+				{feeRate: 0},
+				{feeRate: 40},
+			},
+			expected: 40,
+		},
+		{
+			name: "all estimators fail",
+			estimators: []*mockFeeEstimator{
+				{err: fmt.Errorf("unavailable")},
+				{feeRate: 0},
+			},
+			expected: fallBackFee,
+		},
+		{
+			name: "estimate above maximum",
+			estimators: []*mockFeeEstimator{
+				{feeRate: 100000},
+				{feeRate: 40},
+			},
+			expected: maxFee,
+		},
+	}
+
+	for _, test := range testCases {
+		estimators := make([]lnwallet.FeeEstimator, len(test.estimators))
+		for i, estimator := range test.estimators {
+			estimators[i] = estimator
+		}
+
+		feeEstimator, err := lnwallet.NewCompositeFeeEstimator(
+			estimators, fallBackFee, maxFee, 0,
+		)
+		if err != nil {
+			t.Fatalf("%v: unable to create fee estimator: %v",
+				test.name, err)
+		}
+
+		feeRate, err := feeEstimator.EstimateFeePerVSize(6)
+		if err != nil {
+			t.Fatalf("%v: unable to get fee rate: %v", test.name,
+				err)
+		}
+		if feeRate != test.expected {
+			t.Fatalf("%v: expected fee rate %v, got %v", test.name,
+				test.expected, feeRate)
+		}
+	}
+
+	// An estimator without any sources, or with a maximum below the floor,
+	// is invalid.
+	_, err := lnwallet.NewCompositeFeeEstimator(nil, fallBackFee, maxFee, 0)
+	if err == nil {
+		t.Fatalf("expected estimator without sources to be rejected")
+	}
+	_, err = lnwallet.NewCompositeFeeEstimator(
+		[]lnwallet.FeeEstimator{&mockFeeEstimator{}}, fallBackFee, 0, 0,
+	)
+	if err == nil {
+		t.Fatalf("expected maximum below floor to be rejected")
+	}
+}
+
+// TestCompositeFeeEstimatorCache checks that the CompositeFeeEstimator caches
+// its estimates per confirmation target.
+func TestCompositeFeeEstimatorCache(t *testing.T) {
+	t.Parallel()
+
+	source := &mockFeeEstimator{feeRate: 20}
+	feeEstimator, err := lnwallet.NewCompositeFeeEstimator(
+		[]lnwallet.FeeEstimator{source}, 30, 500, time.Hour,
+	)
+	if err != nil {
+		t.Fatalf("unable to create fee estimator: %v", err)
+	}
+
+	assertFeeRate := func(confTarget uint32,
+		expected lnwallet.SatPerVByte, expectedCalls int) {
+
+		t.Helper()
+
+		feeRate, err := feeEstimator.EstimateFeePerVSize(confTarget)
+		if err != nil {
+			t.Fatalf("unable to get fee rate: %v", err)
+		}
+		if feeRate != expected {
+			t.Fatalf("expected fee rate %v, got %v", expected,
+				feeRate)
+		}
+		if source.numCalls != expectedCalls {
+			t.Fatalf("expected %v calls to source, got %v",
+				expectedCalls, source.numCalls)
+		}
+	}
+
+	assertFeeRate(6, 20, 1)
+
+	// Changing the source's estimate shouldn't affect the cached estimate
+	// for the same conf target, while a new conf target should query the
+	// source again.
+	source.feeRate = 40
+	assertFeeRate(6, 20, 1)
+	assertFeeRate(3, 40, 2)
+}
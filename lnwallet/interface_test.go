@@ -300,7 +300,7 @@ func testDualFundingReservationWorkflow(miner *rpctest.Harness,
 	feePerKw := feeRate.FeePerKWeight()
 	aliceChanReservation, err := alice.InitChannelReservation(
 		fundingAmount*2, fundingAmount, 0, feePerKw, feeRate,
-		bobPub, bobAddr, chainHash, lnwire.FFAnnounceChannel)
+		bobPub, bobAddr, chainHash, lnwire.FFAnnounceChannel, false)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -324,7 +324,7 @@ func testDualFundingReservationWorkflow(miner *rpctest.Harness,
 	// the funding process.
 	bobChanReservation, err := bob.InitChannelReservation(fundingAmount*2,
 		fundingAmount, 0, feePerKw, feeRate, alicePub, aliceAddr,
-		chainHash, lnwire.FFAnnounceChannel)
+		chainHash, lnwire.FFAnnounceChannel, false)
 	if err != nil {
 		t.Fatalf("bob unable to init channel reservation: %v", err)
 	}
@@ -469,7 +469,7 @@ func testFundingTransactionLockedOutputs(miner *rpctest.Harness,
 	feePerKw := feeRate.FeePerKWeight()
 	_, err = alice.InitChannelReservation(fundingAmount,
 		fundingAmount, 0, feePerKw, feeRate, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel,
+		lnwire.FFAnnounceChannel, false,
 	)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation 1: %v", err)
@@ -484,7 +484,7 @@ func testFundingTransactionLockedOutputs(miner *rpctest.Harness,
 	}
 	failedReservation, err := alice.InitChannelReservation(amt, amt, 0,
 		feePerKw, feeRate, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel)
+		lnwire.FFAnnounceChannel, false)
 	if err == nil {
 		t.Fatalf("not error returned, should fail on coin selection")
 	}
@@ -512,7 +512,7 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 	}
 	chanReservation, err := alice.InitChannelReservation(fundingAmount,
 		fundingAmount, 0, feePerKw, feeRate, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel)
+		lnwire.FFAnnounceChannel, false)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -520,7 +520,7 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 	// Attempt to create another channel with 44 BTC, this should fail.
 	_, err = alice.InitChannelReservation(fundingAmount,
 		fundingAmount, 0, feePerKw, feeRate, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel,
+		lnwire.FFAnnounceChannel, false,
 	)
 	if _, ok := err.(*lnwallet.ErrInsufficientFunds); !ok {
 		t.Fatalf("coin selection succeeded should have insufficient funds: %v",
@@ -552,7 +552,7 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 	// Request to fund a new channel should now succeed.
 	_, err = alice.InitChannelReservation(fundingAmount, fundingAmount,
 		0, feePerKw, feeRate, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel)
+		lnwire.FFAnnounceChannel, false)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -569,7 +569,7 @@ func testCancelNonExistentReservation(miner *rpctest.Harness,
 	// Create our own reservation, give it some ID.
 	res, err := lnwallet.NewChannelReservation(
 		10000, 10000, feeRate.FeePerKWeight(), alice,
-		22, 10, &testHdSeed, lnwire.FFAnnounceChannel,
+		22, 10, &testHdSeed, lnwire.FFAnnounceChannel, false,
 	)
 	if err != nil {
 		t.Fatalf("unable to create res: %v", err)
@@ -596,7 +596,7 @@ func testReservationInitiatorBalanceBelowDustCancel(miner *rpctest.Harness,
 	feePerKw := feePerVSize.FeePerKWeight()
 	_, err = alice.InitChannelReservation(
 		fundingAmount, fundingAmount, 0, feePerKw, feePerVSize, bobPub,
-		bobAddr, chainHash, lnwire.FFAnnounceChannel,
+		bobAddr, chainHash, lnwire.FFAnnounceChannel, false,
 	)
 	switch {
 	case err == nil:
@@ -670,7 +670,7 @@ func testSingleFunderReservationWorkflow(miner *rpctest.Harness,
 	feePerKw := feeRate.FeePerKWeight()
 	aliceChanReservation, err := alice.InitChannelReservation(fundingAmt,
 		fundingAmt, pushAmt, feePerKw, feeRate, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel)
+		lnwire.FFAnnounceChannel, false)
 	if err != nil {
 		t.Fatalf("unable to init channel reservation: %v", err)
 	}
@@ -694,7 +694,7 @@ func testSingleFunderReservationWorkflow(miner *rpctest.Harness,
 	// reservation initiation, then consume Alice's contribution.
 	bobChanReservation, err := bob.InitChannelReservation(fundingAmt, 0,
 		pushAmt, feePerKw, feeRate, alicePub, aliceAddr, chainHash,
-		lnwire.FFAnnounceChannel)
+		lnwire.FFAnnounceChannel, false)
 	if err != nil {
 		t.Fatalf("unable to create bob reservation: %v", err)
 	}
@@ -0,0 +1,171 @@
+package lnwallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/psbt"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// ErrNotPsbtFunded is returned when attempting to use the PSBT
+	// funding flow on a reservation which will be funded by the wallet.
+	ErrNotPsbtFunded = errors.New("reservation isn't funded through a psbt")
+
+	// ErrPsbtFunded is returned when attempting to supply the funding
+	// transaction of a reservation which has already been funded.
+	ErrPsbtFunded = errors.New("reservation has already been funded")
+)
+
+// InitPsbtChannelReservation creates a new channel reservation which will be
+// funded by an external wallet rather than by the wallet itself. The
+// parameters are the same as those of InitChannelReservation, except that no
+// funding fee rate is required, as no coin selection is performed. As the
+// funding transaction is crafted externally, we must be the sole funder of the
+// channel, so its capacity must match our funding amount.
+//
+// Once the remote party's contribution has been processed, the funding output
+// of the channel is known, and FundingPsbt can be used to obtain a PSBT
+// template which an external wallet can fund. The signed funding transaction
+// must then be supplied with ProcessPsbtFunding before the commitment
+// transactions of the channel can be created.
+func (l *LightningWallet) InitPsbtChannelReservation(
+	capacity, ourFundAmt btcutil.Amount, pushMSat lnwire.MilliSatoshi,
+	commitFeePerKw SatPerKWeight, theirID *btcec.PublicKey,
+	theirAddr net.Addr, chainHash *chainhash.Hash,
+	flags lnwire.FundingFlag, anchors bool) (*ChannelReservation, error) {
+
+	if capacity != ourFundAmt {
+		return nil, fmt.Errorf("psbt funded channels must be funded " +
+			"solely by us")
+	}
+
+	errChan := make(chan error, 1)
+	respChan := make(chan *ChannelReservation, 1)
+
+	l.msgChan <- &initFundingReserveMsg{
+		chainHash:      chainHash,
+		nodeID:         theirID,
+		nodeAddr:       theirAddr,
+		fundingAmount:  ourFundAmt,
+		capacity:       capacity,
+		commitFeePerKw: commitFeePerKw,
+		pushMSat:       pushMSat,
+		flags:          flags,
+		anchors:        anchors,
+		psbtFunding:    true,
+		err:            errChan,
+		resp:           respChan,
+	}
+
+	return <-respChan, <-errChan
+}
+
+// FundingPsbt returns a PSBT template containing the 2-of-2 multi-sig funding
+// output of the reservation, which an external wallet should add its inputs
+// to, and sign. This method MUST only be called once the contribution of the
+// remote party has been processed.
+func (r *ChannelReservation) FundingPsbt() (*psbt.Packet, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	if !r.psbtFunding {
+		return nil, ErrNotPsbtFunded
+	}
+	if r.theirContribution == nil {
+		return nil, fmt.Errorf("remote party hasn't contributed to " +
+			"reservation yet")
+	}
+
+	_, multiSigOut, err := r.fundingOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxOut(multiSigOut)
+
+	return psbt.NewFromUnsignedTx(tx)
+}
+
+// ProcessPsbtFunding records the fully signed funding transaction of the
+// reservation crafted by an external wallet, then creates both commitment
+// transactions of the channel, along with our signature for the remote
+// party's version. The funding transaction must contain the funding output
+// described by FundingPsbt, and all of its inputs must spend segwit outputs,
+// as otherwise the funding transaction could be malleated, invalidating the
+// commitment transactions.
+//
+// Once this method returns, the reservation proceeds as any other single
+// funder reservation for which we're the initiator. The funding transaction is
+// only broadcast after CompleteReservation has verified the remote party's
+// signature for our version of the commitment transaction.
+func (r *ChannelReservation) ProcessPsbtFunding(fundingTx *wire.MsgTx) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if !r.psbtFunding {
+		return ErrNotPsbtFunded
+	}
+	if r.fundingTx != nil {
+		return ErrPsbtFunded
+	}
+	if r.theirContribution == nil {
+		return fmt.Errorf("remote party hasn't contributed to " +
+			"reservation yet")
+	}
+
+	witnessScript, multiSigOut, err := r.fundingOutput()
+	if err != nil {
+		return err
+	}
+
+	// The funding transaction must pay exactly the capacity of the channel
+	// to the funding output.
+	var foundOutput bool
+	for _, txOut := range fundingTx.TxOut {
+		if bytes.Equal(txOut.PkScript, multiSigOut.PkScript) &&
+			txOut.Value == multiSigOut.Value {
+
+			foundOutput = true
+			break
+		}
+	}
+	if !foundOutput {
+		return fmt.Errorf("funding transaction doesn't contain "+
+			"funding output of %v to %x",
+			btcutil.Amount(multiSigOut.Value), multiSigOut.PkScript)
+	}
+
+	// As we'll be signing the commitment transactions before the funding
+	// transaction is broadcast, we'll need to ensure that its txid can't
+	// change, so each of its inputs must spend a segwit output.
+	if len(fundingTx.TxIn) == 0 {
+		return fmt.Errorf("funding transaction has no inputs")
+	}
+	for i, txIn := range fundingTx.TxIn {
+		if len(txIn.Witness) == 0 {
+			return fmt.Errorf("input %v of funding transaction "+
+				"isn't a signed segwit input", i)
+		}
+	}
+
+	r.fundingTx = fundingTx
+	err = r.wallet.initCommitments(r, multiSigOut, witnessScript)
+	if err != nil {
+		r.fundingTx = nil
+		return err
+	}
+
+	walletLog.Infof("Processed psbt funding tx %v for reservation %v",
+		fundingTx.TxHash(), r.reservationID)
+
+	return nil
+}
@@ -0,0 +1,631 @@
+package lnwallet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// errReplaySigner is returned by the signer used to replay a channel, as no
+// signatures are ever generated while replaying.
+var errReplaySigner = errors.New("signing isn't possible while replaying " +
+	"a channel")
+
+// replaySigner is a Signer which is unable to generate any signatures. It's
+// used to reconstruct a channel for replaying, without requiring access to the
+// keys of the wallet the channel originated from.
+type replaySigner struct{}
+
+// SignOutputRaw always returns errReplaySigner.
+//
+// NOTE: This is part of the Signer interface.
+func (replaySigner) SignOutputRaw(*wire.MsgTx, *SignDescriptor) ([]byte, error) {
+	return nil, errReplaySigner
+}
+
+// ComputeInputScript always returns errReplaySigner.
+//
+// NOTE: This is part of the Signer interface.
+func (replaySigner) ComputeInputScript(*wire.MsgTx,
+	*SignDescriptor) (*InputScript, error) {
+
+	return nil, errReplaySigner
+}
+
+// A compile time check to ensure replaySigner implements the Signer interface.
+var _ Signer = (*replaySigner)(nil)
+
+// ReplayDivergence describes the point within the history of a channel at
+// which its persisted state diverges from the state derived by replaying it.
+type ReplayDivergence struct {
+	// CommitHeight is the height of the commitment at which the divergence
+	// was detected.
+	CommitHeight uint64
+
+	// LocalCommit is true if the divergence was detected within our
+	// commitment chain, and false if it was detected within the remote
+	// party's commitment chain.
+	LocalCommit bool
+
+	// Reason describes the divergence.
+	Reason error
+}
+
+// String returns a human readable description of the divergence.
+func (d *ReplayDivergence) String() string {
+	chain := "remote"
+	if d.LocalCommit {
+		chain = "local"
+	}
+
+	return fmt.Sprintf("%v commitment at height %v: %v", chain,
+		d.CommitHeight, d.Reason)
+}
+
+// ChannelReplayReport summarizes the result of replaying the history of a
+// channel.
+type ChannelReplayReport struct {
+	// LocalCommitHeight is the height of our current commitment.
+	LocalCommitHeight uint64
+
+	// RemoteCommitHeight is the height of the remote party's current
+	// commitment, excluding any pending commitment we've extended to them.
+	RemoteCommitHeight uint64
+
+	// NumRemoteCommits is the number of remote commitments that were
+	// replayed, including the revoked and pending ones.
+	NumRemoteCommits int
+
+	// NumFwdPkgs is the number of forwarding packages whose log updates
+	// were replayed against the remote commitments.
+	NumFwdPkgs int
+
+	// Divergence is the first divergence encountered while replaying the
+	// channel. This is nil if the history of the channel is consistent.
+	Divergence *ReplayDivergence
+}
+
+// ReplayChannel reconstructs a LightningChannel from the passed channel state,
+// then replays the full history of the channel in order to pinpoint where its
+// persisted state diverges from the state derived by the channel state
+// machine. This is intended to be used for debugging channels which failed due
+// to a commitment signature mismatch, using a snapshot of their state restored
+// through channeldb.RestoreChannelDump.
+//
+// For each commitment of the remote party, starting from the earliest one
+// within the revocation log, the commitment transaction is re-created from the
+// stored balances and HTLCs, then compared against the stored transaction. The
+// log updates locked in at each height, as recorded within the forwarding
+// packages and the pending commitment diff, are then re-executed against the
+// commitments. Finally, our current commitment is re-created, and the
+// signatures of the remote party for it are verified. Only the first
+// divergence found is reported.
+//
+// NOTE: The channel's state is never modified, and no signatures are
+// generated, so the keys of the wallet the channel originated from aren't
+// required.
+func ReplayChannel(chanState *channeldb.OpenChannel) (*ChannelReplayReport,
+	error) {
+
+	lc, err := NewLightningChannel(replaySigner{}, nil, chanState)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reconstruct channel: %v", err)
+	}
+	defer lc.Stop()
+
+	report := &ChannelReplayReport{
+		LocalCommitHeight:  chanState.LocalCommitment.CommitHeight,
+		RemoteCommitHeight: chanState.RemoteCommitment.CommitHeight,
+	}
+
+	// The forwarding packages record the log updates of the remote party
+	// which were locked in at each height of their commitment chain. As
+	// packages are removed once fully processed, there may not be a
+	// package for every height.
+	fwdPkgs, err := chanState.LoadFwdPkgs()
+	if err != nil {
+		return nil, err
+	}
+	pkgsByHeight := make(map[uint64]*channeldb.FwdPkg, len(fwdPkgs))
+	for _, fwdPkg := range fwdPkgs {
+		pkgsByHeight[fwdPkg.Height] = fwdPkg
+	}
+
+	remoteDivergence := func(height uint64, err error) *ChannelReplayReport {
+		report.Divergence = &ReplayDivergence{
+			CommitHeight: height,
+			Reason:       err,
+		}
+		return report
+	}
+
+	// We'll start by replaying every revoked commitment of the remote
+	// party, followed by their current commitment.
+	var prevCommit *channeldb.ChannelCommitment
+	for height := uint64(0); height <= report.RemoteCommitHeight; height++ {
+		var (
+			commit      *channeldb.ChannelCommitment
+			commitPoint *btcec.PublicKey
+		)
+		if height == report.RemoteCommitHeight {
+			commit = &chanState.RemoteCommitment
+			commitPoint = chanState.RemoteCurrentRevocation
+		} else {
+			commit, err = chanState.FindPreviousState(height)
+			if err != nil {
+				return remoteDivergence(height, fmt.Errorf(
+					"unable to find revoked commitment: "+
+						"%v", err),
+				), nil
+			}
+
+			secret, err := chanState.RevocationStore.LookUp(height)
+			if err != nil {
+				return remoteDivergence(height, fmt.Errorf(
+					"unable to find revocation: %v", err),
+				), nil
+			}
+			commitPoint = ComputeCommitmentPoint(secret[:])
+		}
+
+		err := lc.replayRemoteCommit(prevCommit, commit, commitPoint)
+		if err != nil {
+			return remoteDivergence(height, err), nil
+		}
+
+		if fwdPkg, ok := pkgsByHeight[height]; ok {
+			err := replayLogUpdates(commit, fwdPkg.Adds, true)
+			if err != nil {
+				return remoteDivergence(height, err), nil
+			}
+			err = replayLogUpdates(commit, fwdPkg.SettleFails, true)
+			if err != nil {
+				return remoteDivergence(height, err), nil
+			}
+
+			report.NumFwdPkgs++
+		}
+
+		report.NumRemoteCommits++
+		prevCommit = commit
+	}
+
+	// If we've extended a new commitment to the remote party which they
+	// haven't yet revoked their prior commitment for, then we'll replay it
+	// along with the log updates we've proposed within it.
+	commitDiff, err := chanState.RemoteCommitChainTip()
+	switch {
+	case err == channeldb.ErrNoPendingCommit:
+
+	case err != nil:
+		return nil, err
+
+	default:
+		commit := &commitDiff.Commitment
+		height := commit.CommitHeight
+
+		err := lc.replayRemoteCommit(
+			prevCommit, commit, chanState.RemoteNextRevocation,
+		)
+		if err != nil {
+			return remoteDivergence(height, err), nil
+		}
+
+		err = replayLogUpdates(commit, commitDiff.LogUpdates, false)
+		if err != nil {
+			return remoteDivergence(height, err), nil
+		}
+
+		report.NumRemoteCommits++
+	}
+
+	// Finally, we'll replay our current commitment, ensuring both the
+	// commitment and HTLC signatures of the remote party are valid.
+	if err := lc.replayLocalCommit(&chanState.LocalCommitment); err != nil {
+		report.Divergence = &ReplayDivergence{
+			CommitHeight: report.LocalCommitHeight,
+			LocalCommit:  true,
+			Reason:       err,
+		}
+	}
+
+	return report, nil
+}
+
+// replayRemoteCommit re-creates a commitment of the remote party, ensuring it
+// matches the stored commitment, and that it's a valid successor of the
+// previous commitment of the remote party, if any.
+func (lc *LightningChannel) replayRemoteCommit(prevCommit,
+	diskCommit *channeldb.ChannelCommitment,
+	commitPoint *btcec.PublicKey) error {
+
+	if prevCommit != nil {
+		err := checkCommitTransition(prevCommit, diskCommit)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := lc.replayCommit(false, diskCommit, commitPoint)
+	if err != nil {
+		return err
+	}
+
+	// If we have our signature for the remote commitment, we'll ensure
+	// that it's valid for the re-created commitment transaction.
+	if len(diskCommit.CommitSig) == 0 {
+		return nil
+	}
+
+	return lc.verifyReplayCommitSig(
+		diskCommit.CommitTx, diskCommit.CommitSig,
+		lc.localChanCfg.MultiSigKey.PubKey,
+	)
+}
+
+// replayLocalCommit re-creates our commitment, ensuring it matches the stored
+// commitment, and that the remote party's signatures for the commitment
+// transaction and each of its HTLC transactions are valid.
+func (lc *LightningChannel) replayLocalCommit(
+	diskCommit *channeldb.ChannelCommitment) error {
+
+	commitSecret, err := lc.channelState.RevocationProducer.AtIndex(
+		diskCommit.CommitHeight,
+	)
+	if err != nil {
+		return err
+	}
+	commitPoint := ComputeCommitmentPoint(commitSecret[:])
+
+	commit, err := lc.replayCommit(true, diskCommit, commitPoint)
+	if err != nil {
+		return err
+	}
+
+	err = lc.verifyReplayCommitSig(
+		diskCommit.CommitTx, diskCommit.CommitSig,
+		lc.remoteChanCfg.MultiSigKey.PubKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The HTLC signatures are expected in the order of the outputs they
+	// spend within the commitment transaction.
+	htlcs := make([]channeldb.HTLC, 0, len(diskCommit.Htlcs))
+	for _, htlc := range diskCommit.Htlcs {
+		if htlc.OutputIndex < 0 {
+			continue
+		}
+		htlcs = append(htlcs, htlc)
+	}
+	sort.Slice(htlcs, func(i, j int) bool {
+		return htlcs[i].OutputIndex < htlcs[j].OutputIndex
+	})
+
+	htlcSigs := make([]lnwire.Sig, 0, len(htlcs))
+	for _, htlc := range htlcs {
+		sig, err := lnwire.NewSigFromRawSignature(htlc.Signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature for htlc %v: %v",
+				htlc.HtlcIndex, err)
+		}
+		htlcSigs = append(htlcSigs, sig)
+	}
+
+	keyRing := deriveCommitmentKeys(
+		commitPoint, true, lc.localChanCfg, lc.remoteChanCfg,
+	)
+	verifyJobs, err := genHtlcSigValidationJobs(
+		commit, keyRing, htlcSigs, lc.localChanCfg, lc.remoteChanCfg,
+	)
+	if err != nil {
+		return err
+	}
+	for i, job := range verifyJobs {
+		sigHash, err := job.sigHash()
+		if err != nil {
+			return err
+		}
+		if !job.sig.Verify(sigHash, job.pubKey) {
+			return fmt.Errorf("invalid signature for htlc %v",
+				htlcs[i].HtlcIndex)
+		}
+	}
+
+	return nil
+}
+
+// replayCommit re-creates the commitment transaction of the passed commitment
+// from its balances and HTLCs, ensuring it matches the stored transaction, and
+// that the commitment allocates the full capacity of the channel. The
+// in-memory version of the stored commitment is returned.
+func (lc *LightningChannel) replayCommit(isLocal bool,
+	diskCommit *channeldb.ChannelCommitment,
+	commitPoint *btcec.PublicKey) (*commitment, error) {
+
+	var localCommitPoint, remoteCommitPoint *btcec.PublicKey
+	if isLocal {
+		localCommitPoint = commitPoint
+	} else {
+		remoteCommitPoint = commitPoint
+	}
+
+	err := checkCommitCapacity(lc.channelState, diskCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Converting the stored commitment into its in-memory form will also
+	// locate each of its HTLCs within the stored commitment transaction,
+	// failing if any of them can't be found.
+	commit, err := lc.diskCommitToMemCommit(
+		isLocal, false, diskCommit, localCommitPoint, remoteCommitPoint,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stored balances have already had the commitment fee, along with
+	// the value of any anchor outputs, deducted from the balance of the
+	// initiator, so we'll credit it back, as it'll be deducted once again
+	// when re-creating the commitment transaction.
+	replayed := &commitment{
+		height:       diskCommit.CommitHeight,
+		isOurs:       isLocal,
+		ourBalance:   diskCommit.LocalBalance,
+		theirBalance: diskCommit.RemoteBalance,
+		feePerKw:     SatPerKWeight(diskCommit.FeePerKw),
+		dustLimit:    commit.dustLimit,
+	}
+	commitFee := lnwire.NewMSatFromSatoshis(
+		diskCommit.CommitFee + anchorsValue(lc.channelState.ChanType),
+	)
+	if lc.channelState.IsInitiator {
+		replayed.ourBalance += commitFee
+	} else {
+		replayed.theirBalance += commitFee
+	}
+
+	view := &htlcView{}
+	for i := range commit.outgoingHTLCs {
+		view.ourUpdates = append(view.ourUpdates, &commit.outgoingHTLCs[i])
+	}
+	for i := range commit.incomingHTLCs {
+		view.theirUpdates = append(
+			view.theirUpdates, &commit.incomingHTLCs[i],
+		)
+	}
+
+	keyRing := deriveCommitmentKeys(
+		commitPoint, isLocal, lc.localChanCfg, lc.remoteChanCfg,
+	)
+	if err := lc.createCommitmentTx(replayed, view, keyRing); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case replayed.fee != diskCommit.CommitFee:
+		return nil, fmt.Errorf("commitment fee mismatch: stored %v, "+
+			"replayed %v", diskCommit.CommitFee, replayed.fee)
+
+	case replayed.ourBalance != diskCommit.LocalBalance:
+		return nil, fmt.Errorf("local balance mismatch: stored %v, "+
+			"replayed %v", diskCommit.LocalBalance,
+			replayed.ourBalance)
+
+	case replayed.theirBalance != diskCommit.RemoteBalance:
+		return nil, fmt.Errorf("remote balance mismatch: stored %v, "+
+			"replayed %v", diskCommit.RemoteBalance,
+			replayed.theirBalance)
+
+	case replayed.txn.TxHash() != diskCommit.CommitTx.TxHash():
+		return nil, fmt.Errorf("commitment transaction mismatch: "+
+			"stored %v, replayed %v", diskCommit.CommitTx.TxHash(),
+			replayed.txn.TxHash())
+	}
+
+	return commit, nil
+}
+
+// verifyReplayCommitSig ensures the passed signature for the commitment
+// transaction is valid under the passed multi-sig key.
+func (lc *LightningChannel) verifyReplayCommitSig(commitTx *wire.MsgTx,
+	rawSig []byte, multiSigKey *btcec.PublicKey) error {
+
+	sig, err := btcec.ParseDERSignature(rawSig, btcec.S256())
+	if err != nil {
+		return fmt.Errorf("invalid commitment signature: %v", err)
+	}
+
+	hashCache := txscript.NewTxSigHashes(commitTx)
+	sigHash, err := txscript.CalcWitnessSigHash(
+		lc.signDesc.WitnessScript, hashCache, txscript.SigHashAll,
+		commitTx, 0, int64(lc.channelState.Capacity),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !sig.Verify(sigHash, multiSigKey) {
+		return fmt.Errorf("commitment signature mismatch for "+
+			"transaction %v", commitTx.TxHash())
+	}
+
+	return nil
+}
+
+// checkCommitTransition ensures that a commitment is a valid successor of the
+// previous commitment within the same commitment chain: the height must be
+// incremented by one, the log indexes may never decrease, any newly added HTLC
+// must have been assigned an index within the range of indexes added by the
+// transition.
+func checkCommitTransition(prev, commit *channeldb.ChannelCommitment) error {
+	if commit.CommitHeight != prev.CommitHeight+1 {
+		return fmt.Errorf("commitment follows commitment at height %v",
+			prev.CommitHeight)
+	}
+
+	switch {
+	case commit.LocalLogIndex < prev.LocalLogIndex:
+		return fmt.Errorf("local log index decreased from %v to %v",
+			prev.LocalLogIndex, commit.LocalLogIndex)
+
+	case commit.LocalHtlcIndex < prev.LocalHtlcIndex:
+		return fmt.Errorf("local htlc index decreased from %v to %v",
+			prev.LocalHtlcIndex, commit.LocalHtlcIndex)
+
+	case commit.RemoteLogIndex < prev.RemoteLogIndex:
+		return fmt.Errorf("remote log index decreased from %v to %v",
+			prev.RemoteLogIndex, commit.RemoteLogIndex)
+
+	case commit.RemoteHtlcIndex < prev.RemoteHtlcIndex:
+		return fmt.Errorf("remote htlc index decreased from %v to %v",
+			prev.RemoteHtlcIndex, commit.RemoteHtlcIndex)
+	}
+
+	prevHtlcs := make(map[bool]map[uint64]struct{})
+	prevHtlcs[true] = make(map[uint64]struct{})
+	prevHtlcs[false] = make(map[uint64]struct{})
+	for _, htlc := range prev.Htlcs {
+		prevHtlcs[htlc.Incoming][htlc.HtlcIndex] = struct{}{}
+	}
+
+	for _, htlc := range commit.Htlcs {
+		if _, ok := prevHtlcs[htlc.Incoming][htlc.HtlcIndex]; ok {
+			continue
+		}
+
+		minIndex, maxIndex := prev.LocalHtlcIndex, commit.LocalHtlcIndex
+		if htlc.Incoming {
+			minIndex = prev.RemoteHtlcIndex
+			maxIndex = commit.RemoteHtlcIndex
+		}
+		if htlc.HtlcIndex < minIndex || htlc.HtlcIndex >= maxIndex {
+			return fmt.Errorf("htlc %v (incoming=%v) added outside "+
+				"of index range [%v, %v)", htlc.HtlcIndex,
+				htlc.Incoming, minIndex, maxIndex)
+		}
+	}
+
+	return nil
+}
+
+// checkCommitCapacity ensures that the balances, HTLCs, including those
+// trimmed as dust, and fee of the passed commitment add up to the capacity of
+// the channel. This can only be checked if the balance of the initiator wasn't
+// exhausted by the commitment fee, as the fee is then capped.
+func checkCommitCapacity(chanState *channeldb.OpenChannel,
+	commit *channeldb.ChannelCommitment) error {
+
+	initiatorBalance := commit.RemoteBalance
+	if chanState.IsInitiator {
+		initiatorBalance = commit.LocalBalance
+	}
+	if initiatorBalance == 0 {
+		return nil
+	}
+
+	// The value of the anchor outputs, if any, is paid for by the
+	// initiator on top of the commitment fee.
+	commitCost := commit.CommitFee + anchorsValue(chanState.ChanType)
+	total := commit.LocalBalance + commit.RemoteBalance +
+		lnwire.NewMSatFromSatoshis(commitCost)
+	for _, htlc := range commit.Htlcs {
+		total += htlc.Amt
+	}
+
+	capacity := lnwire.NewMSatFromSatoshis(chanState.Capacity)
+	if total != capacity {
+		return fmt.Errorf("commitment allocates %v of channel capacity "+
+			"%v", total, capacity)
+	}
+
+	return nil
+}
+
+// replayLogUpdates re-executes the passed log updates against the commitment
+// they were locked in at, ensuring each added HTLC is present within the
+// commitment with the same parameters, and each settled or failed HTLC was
+// added to the channel. The remoteUpdates boolean indicates whether the
+// updates were proposed by the remote party or by us.
+//
+// As we sign for all of our own updates, any HTLC we settled or failed must
+// also have been removed from the commitment. The remote party's settles and
+// fails on the other hand are forwarded as soon as they're locked into our
+// commitment, so they may still be present within theirs.
+func replayLogUpdates(commit *channeldb.ChannelCommitment,
+	updates []channeldb.LogUpdate, remoteUpdates bool) error {
+
+	htlcs := make(map[bool]map[uint64]*channeldb.HTLC)
+	htlcs[true] = make(map[uint64]*channeldb.HTLC)
+	htlcs[false] = make(map[uint64]*channeldb.HTLC)
+	for i, htlc := range commit.Htlcs {
+		htlcs[htlc.Incoming][htlc.HtlcIndex] = &commit.Htlcs[i]
+	}
+
+	// HTLCs added by the remote party are incoming to us, and may only be
+	// removed by us, and vice versa.
+	addedHtlcs, removedHtlcs := htlcs[false], htlcs[true]
+	removedHtlcIndex := commit.RemoteHtlcIndex
+	if remoteUpdates {
+		addedHtlcs, removedHtlcs = htlcs[true], htlcs[false]
+		removedHtlcIndex = commit.LocalHtlcIndex
+	}
+
+	checkRemoved := func(id uint64, logIndex uint64) error {
+		if id >= removedHtlcIndex {
+			return fmt.Errorf("htlc %v removed at log index %v "+
+				"was never added", id, logIndex)
+		}
+
+		if _, ok := removedHtlcs[id]; ok && !remoteUpdates {
+			return fmt.Errorf("htlc %v removed at log index %v "+
+				"still in commitment", id, logIndex)
+		}
+
+		return nil
+	}
+
+	for _, update := range updates {
+		var err error
+		switch msg := update.UpdateMsg.(type) {
+		case *lnwire.UpdateAddHTLC:
+			htlc, ok := addedHtlcs[msg.ID]
+			switch {
+			case !ok:
+				err = fmt.Errorf("htlc %v added at log index "+
+					"%v not found in commitment", msg.ID,
+					update.LogIndex)
+
+			case htlc.Amt != msg.Amount ||
+				htlc.RHash != msg.PaymentHash ||
+				htlc.RefundTimeout != msg.Expiry:
+
+				err = fmt.Errorf("htlc %v doesn't match "+
+					"update at log index %v", msg.ID,
+					update.LogIndex)
+			}
+
+		case *lnwire.UpdateFulfillHTLC:
+			err = checkRemoved(msg.ID, update.LogIndex)
+
+		case *lnwire.UpdateFailHTLC:
+			err = checkRemoved(msg.ID, update.LogIndex)
+
+		case *lnwire.UpdateFailMalformedHTLC:
+			err = checkRemoved(msg.ID, update.LogIndex)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
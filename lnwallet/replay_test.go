@@ -0,0 +1,225 @@
+package lnwallet
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcutil"
+)
+
+// initReplayCommitments replaces the initial commitments of the passed
+// channel, as created by createTestChannels, with commitments created by the
+// channel state machine, along with valid signatures for them, as would be the
+// case for a channel created through the funding flow.
+func initReplayCommitments(lc, remoteChan *LightningChannel) error {
+	chanState := lc.channelState
+
+	localSecret, err := chanState.RevocationProducer.AtIndex(0)
+	if err != nil {
+		return err
+	}
+	localCommitPoint := ComputeCommitmentPoint(localSecret[:])
+
+	initCommit := func(diskCommit *channeldb.ChannelCommitment,
+		isLocal bool, commitPoint *btcec.PublicKey,
+		signer *LightningChannel) error {
+
+		commit := &commitment{
+			height:       0,
+			isOurs:       isLocal,
+			ourBalance:   diskCommit.LocalBalance,
+			theirBalance: diskCommit.RemoteBalance,
+			feePerKw:     SatPerKWeight(diskCommit.FeePerKw),
+			dustLimit:    chanState.RemoteChanCfg.DustLimit,
+		}
+		if isLocal {
+			commit.dustLimit = chanState.LocalChanCfg.DustLimit
+		}
+
+		commitFee := lnwire.NewMSatFromSatoshis(diskCommit.CommitFee)
+		if chanState.IsInitiator {
+			commit.ourBalance += commitFee
+		} else {
+			commit.theirBalance += commitFee
+		}
+
+		keyRing := deriveCommitmentKeys(
+			commitPoint, isLocal, lc.localChanCfg, lc.remoteChanCfg,
+		)
+		err := lc.createCommitmentTx(commit, &htlcView{}, keyRing)
+		if err != nil {
+			return err
+		}
+
+		signer.signDesc.SigHashes = txscript.NewTxSigHashes(commit.txn)
+		sig, err := signer.signer.SignOutputRaw(
+			commit.txn, signer.signDesc,
+		)
+		if err != nil {
+			return err
+		}
+
+		diskCommit.CommitTx = commit.txn
+		diskCommit.CommitSig = sig
+
+		return nil
+	}
+
+	// Our commitment is signed by the remote party, while the remote
+	// party's commitment is signed by us.
+	localCommit := chanState.LocalCommitment
+	err = initCommit(&localCommit, true, localCommitPoint, remoteChan)
+	if err != nil {
+		return err
+	}
+	remoteCommit := chanState.RemoteCommitment
+	err = initCommit(
+		&remoteCommit, false, chanState.RemoteCurrentRevocation, lc,
+	)
+	if err != nil {
+		return err
+	}
+
+	chanState.LocalCommitment = localCommit
+	chanState.RemoteCommitment = remoteCommit
+
+	return chanState.FullSync()
+}
+
+// TestReplayChannel tests that replaying the history of a channel reports no
+// divergence for a channel whose state was created by the channel state
+// machine, and that tampering with the channel state is detected.
+func TestReplayChannel(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(1)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	if err := initReplayCommitments(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to init commitments: %v", err)
+	}
+
+	// We'll start by having Alice add two HTLCs, one of which is dust
+	// within Bob's commitment, while Bob adds an HTLC of his own.
+	htlcAmt := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	dustAmt := lnwire.NewMSatFromSatoshis(1000)
+
+	var alicePreimages [][32]byte
+	for i, amt := range []lnwire.MilliSatoshi{htlcAmt, dustAmt} {
+		htlc, preimage := createHTLC(i, amt)
+		if _, err := aliceChannel.AddHTLC(htlc, nil); err != nil {
+			t.Fatalf("unable to add htlc: %v", err)
+		}
+		if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+			t.Fatalf("unable to recv htlc: %v", err)
+		}
+		alicePreimages = append(alicePreimages, preimage)
+	}
+
+	bobHtlc, _ := createHTLC(0, htlcAmt)
+	if _, err := bobChannel.AddHTLC(bobHtlc, nil); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := aliceChannel.ReceiveHTLC(bobHtlc); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
+
+	// As Bob's HTLC is only locked into his commitment once Alice signs
+	// another commitment, we'll need two state transitions before all
+	// HTLCs can be removed.
+	for i := 0; i < 2; i++ {
+		err := forceStateTransition(aliceChannel, bobChannel)
+		if err != nil {
+			t.Fatalf("unable to complete state update: %v", err)
+		}
+	}
+
+	// Bob will now settle Alice's first HTLC, while Alice fails Bob's
+	// HTLC.
+	err = bobChannel.SettleHTLC(alicePreimages[0], 0, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unable to settle htlc: %v", err)
+	}
+	err = aliceChannel.ReceiveHTLCSettle(alicePreimages[0], 0)
+	if err != nil {
+		t.Fatalf("unable to settle htlc: %v", err)
+	}
+	if err := aliceChannel.FailHTLC(0, []byte("fail"), nil, nil, nil); err != nil {
+		t.Fatalf("unable to fail htlc: %v", err)
+	}
+	if err := bobChannel.ReceiveFailHTLC(0, []byte("fail")); err != nil {
+		t.Fatalf("unable to fail htlc: %v", err)
+	}
+
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to complete state update: %v", err)
+	}
+
+	// Finally, Alice will add another HTLC, and extend a new commitment
+	// to Bob, which will remain pending.
+	htlc, _ := createHTLC(2, htlcAmt)
+	if _, err := aliceChannel.AddHTLC(htlc, nil); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
+	if _, _, err := aliceChannel.SignNextCommitment(); err != nil {
+		t.Fatalf("unable to sign commitment: %v", err)
+	}
+
+	// Replaying Alice's channel should report no divergence.
+	aliceState := aliceChannel.channelState
+	report, err := ReplayChannel(aliceState)
+	if err != nil {
+		t.Fatalf("unable to replay channel: %v", err)
+	}
+	if report.Divergence != nil {
+		t.Fatalf("unexpected divergence: %v", report.Divergence)
+	}
+
+	// Each of Bob's commitments, including the pending one, should have
+	// been replayed.
+	expectedRemoteCommits := int(aliceState.RemoteCommitment.CommitHeight) + 2
+	if report.NumRemoteCommits != expectedRemoteCommits {
+		t.Fatalf("expected %v remote commits to be replayed, got %v",
+			expectedRemoteCommits, report.NumRemoteCommits)
+	}
+	if report.NumFwdPkgs == 0 {
+		t.Fatalf("expected fwd pkgs to be replayed")
+	}
+
+	// We'll now tamper with Alice's current commitment, shifting a
+	// portion of her balance to Bob, which should be detected.
+	localCommit := aliceState.LocalCommitment
+	localCommit.LocalBalance -= dustAmt
+	localCommit.RemoteBalance += dustAmt
+	aliceState.LocalCommitment = localCommit
+	if err := aliceState.FullSync(); err != nil {
+		t.Fatalf("unable to sync channel state: %v", err)
+	}
+
+	report, err = ReplayChannel(aliceState)
+	if err != nil {
+		t.Fatalf("unable to replay channel: %v", err)
+	}
+	switch {
+	case report.Divergence == nil:
+		t.Fatalf("expected divergence to be detected")
+
+	case !report.Divergence.LocalCommit:
+		t.Fatalf("expected divergence within local commitment, got %v",
+			report.Divergence)
+
+	case report.Divergence.CommitHeight != localCommit.CommitHeight:
+		t.Fatalf("expected divergence at height %v, got %v",
+			localCommit.CommitHeight,
+			report.Divergence.CommitHeight)
+	}
+}
@@ -127,6 +127,13 @@ type ChannelReservation struct {
 	chanOpen    chan *openChanDetails
 	chanOpenErr chan error
 
+	// batch is the funding batch this reservation is a part of, if any.
+	batch *FundingBatch
+
+	// psbtFunding denotes whether the reservation will be funded by an
+	// external wallet through a PSBT.
+	psbtFunding bool
+
 	wallet *LightningWallet
 }
 
@@ -137,7 +144,7 @@ type ChannelReservation struct {
 func NewChannelReservation(capacity, fundingAmt btcutil.Amount,
 	commitFeePerKw SatPerKWeight, wallet *LightningWallet,
 	id uint64, pushMSat lnwire.MilliSatoshi, chainHash *chainhash.Hash,
-	flags lnwire.FundingFlag) (*ChannelReservation, error) {
+	flags lnwire.FundingFlag, anchors bool) (*ChannelReservation, error) {
 
 	var (
 		ourBalance   lnwire.MilliSatoshi
@@ -145,10 +152,22 @@ func NewChannelReservation(capacity, fundingAmt btcutil.Amount,
 		initiator    bool
 	)
 
-	commitFee := commitFeePerKw.FeeForWeight(CommitWeight)
+	// If the channel will make use of the anchor commitment format, the
+	// commitment is heavier, and the value of the anchor outputs is paid
+	// for along with the commitment fee.
+	var anchorsChanType channeldb.ChannelType
+	if anchors {
+		anchorsChanType = channeldb.AnchorOutputsBit
+	}
+
+	commitFee := commitFeePerKw.FeeForWeight(
+		baseCommitWeight(anchorsChanType),
+	)
 	fundingMSat := lnwire.NewMSatFromSatoshis(fundingAmt)
 	capacityMSat := lnwire.NewMSatFromSatoshis(capacity)
-	feeMSat := lnwire.NewMSatFromSatoshis(commitFee)
+	feeMSat := lnwire.NewMSatFromSatoshis(
+		commitFee + anchorsValue(anchorsChanType),
+	)
 
 	// If we're the responder to a single-funder reservation, then we have
 	// no initial balance in the channel unless the remote party is pushing
@@ -225,6 +244,7 @@ func NewChannelReservation(capacity, fundingAmt btcutil.Amount,
 		initiator = false
 		chanType = channeldb.DualFunder
 	}
+	chanType |= anchorsChanType
 
 	return &ChannelReservation{
 		ourContribution: &ChannelContribution{
@@ -276,6 +296,28 @@ func (r *ChannelReservation) SetNumConfsRequired(numConfs uint16) {
 	r.partialState.NumConfsRequired = numConfs
 }
 
+// SetLocalShutdownScript sets the script to which we commit to pay our funds
+// upon a cooperative close of the channel.
+func (r *ChannelReservation) SetLocalShutdownScript(
+	script lnwire.DeliveryAddress) {
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.partialState.LocalShutdownScript = script
+}
+
+// SetRemoteShutdownScript sets the script to which the remote party has
+// committed to pay its funds upon a cooperative close of the channel.
+func (r *ChannelReservation) SetRemoteShutdownScript(
+	script lnwire.DeliveryAddress) {
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.partialState.RemoteShutdownScript = script
+}
+
 // RegisterMinHTLC registers our desired amount for the smallest acceptable
 // HTLC we'll accept within this channel. Any HTLC's that are extended which
 // are below this value will SHOULD be rejected.
@@ -370,6 +412,11 @@ func (r *ChannelReservation) OurContribution() *ChannelContribution {
 // transaction belonging to the wallet are available. Additionally, the wallet
 // will generate a signature to the counterparty's version of the commitment
 // transaction.
+//
+// NOTE: If the reservation is part of a FundingBatch, then the contribution is
+// only recorded, and the transactions are built once the batch is funded. The
+// same applies to reservations funded through a PSBT, whose transactions are
+// built once ProcessPsbtFunding is called.
 func (r *ChannelReservation) ProcessContribution(theirContribution *ChannelContribution) error {
 	errChan := make(chan error, 1)
 
@@ -437,6 +484,10 @@ func (r *ChannelReservation) OurSignatures() ([]*InputScript, []byte) {
 // the configured number of confirmations. Once the method unblocks, a
 // LightningChannel instance is returned, marking the channel available for
 // updates.
+//
+// NOTE: If the reservation is part of a FundingBatch, then the channel is only
+// committed to disk, and the funding transaction broadcast, once the batch is
+// published.
 func (r *ChannelReservation) CompleteReservation(fundingInputScripts []*InputScript,
 	commitmentSig []byte) (*channeldb.OpenChannel, error) {
 
@@ -518,6 +569,21 @@ func (r *ChannelReservation) FundingOutpoint() *wire.OutPoint {
 	return &r.partialState.FundingOutpoint
 }
 
+// fundingOutput returns the witness script and the 2-of-2 multi-sig output of
+// the funding transaction which will set up the channel.
+//
+// NOTE: The reservation's mutex MUST be held when calling this method.
+func (r *ChannelReservation) fundingOutput() ([]byte, *wire.TxOut, error) {
+	ourKey := r.ourContribution.MultiSigKey
+	theirKey := r.theirContribution.MultiSigKey
+
+	return GenFundingPkScript(
+		ourKey.PubKey.SerializeCompressed(),
+		theirKey.PubKey.SerializeCompressed(),
+		int64(r.partialState.Capacity),
+	)
+}
+
 // Cancel abandons this channel reservation. This method should be called in
 // the scenario that communications with the counterparty break down. Upon
 // cancellation, all resources previously reserved for this pending payment
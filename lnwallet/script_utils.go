@@ -44,11 +44,21 @@ const (
 	// StateHintSize bytes amongst the sequence number and locktime fields
 	// of the commitment transaction.
 	maxStateHint uint64 = (1 << 48) - 1
+
+	// AnchorSize is the value of each of the anchor outputs carried by
+	// the commitment transactions of channels that make use of the anchor
+	// commitment format.
+	AnchorSize = btcutil.Amount(330)
+
+	// AnchorCsvDelay is the number of blocks after confirmation of the
+	// commitment transaction after which anybody is able to sweep its
+	// anchor outputs.
+	AnchorCsvDelay = 16
 )
 
-// witnessScriptHash generates a pay-to-witness-script-hash public key script
+// WitnessScriptHash generates a pay-to-witness-script-hash public key script
 // paying to a version 0 witness program paying to the passed redeem script.
-func witnessScriptHash(witnessScript []byte) ([]byte, error) {
+func WitnessScriptHash(witnessScript []byte) ([]byte, error) {
 	bldr := txscript.NewScriptBuilder()
 
 	bldr.AddOp(txscript.OP_0)
@@ -98,7 +108,7 @@ func GenFundingPkScript(aPub, bPub []byte, amt int64) ([]byte, *wire.TxOut, erro
 
 	// With the 2-of-2 script in had, generate a p2wsh script which pays
 	// to the funding script.
-	pkScript, err := witnessScriptHash(witnessScript)
+	pkScript, err := WitnessScriptHash(witnessScript)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -662,7 +672,7 @@ func createHtlcTimeoutTx(htlcOutput wire.OutPoint, htlcAmt btcutil.Amount,
 	if err != nil {
 		return nil, err
 	}
-	pkScript, err := witnessScriptHash(witnessScript)
+	pkScript, err := WitnessScriptHash(witnessScript)
 	if err != nil {
 		return nil, err
 	}
@@ -709,7 +719,7 @@ func createHtlcSuccessTx(htlcOutput wire.OutPoint, htlcAmt btcutil.Amount,
 	if err != nil {
 		return nil, err
 	}
-	pkScript, err := witnessScriptHash(witnessScript)
+	pkScript, err := WitnessScriptHash(witnessScript)
 	if err != nil {
 		return nil, err
 	}
@@ -902,7 +912,7 @@ func lockTimeToSequence(isSeconds bool, locktime uint32) uint32 {
 	return SequenceLockTimeSeconds | (locktime >> 9)
 }
 
-// commitScriptToSelf constructs the public key script for the output on the
+// CommitScriptToSelf constructs the public key script for the output on the
 // commitment transaction paying to the "owner" of said commitment transaction.
 // If the other party learns of the preimage to the revocation hash, then they
 // can claim all the settled funds in the channel, plus the unsettled funds.
@@ -919,7 +929,7 @@ func lockTimeToSequence(isSeconds bool, locktime uint32) uint32 {
 //         <timeKey>
 //     OP_ENDIF
 //     OP_CHECKSIG
-func commitScriptToSelf(csvTimeout uint32, selfKey, revokeKey *btcec.PublicKey) ([]byte, error) {
+func CommitScriptToSelf(csvTimeout uint32, selfKey, revokeKey *btcec.PublicKey) ([]byte, error) {
 	// This script is spendable under two conditions: either the
 	// 'csvTimeout' has passed and we can redeem our funds, or they can
 	// produce a valid signature with the revocation public key. The
@@ -953,10 +963,10 @@ func commitScriptToSelf(csvTimeout uint32, selfKey, revokeKey *btcec.PublicKey)
 	return builder.Script()
 }
 
-// commitScriptUnencumbered constructs the public key script on the commitment
+// CommitScriptUnencumbered constructs the public key script on the commitment
 // transaction paying to the "other" party. The constructed output is a normal
 // p2wkh output spendable immediately, requiring no contestation period.
-func commitScriptUnencumbered(key *btcec.PublicKey) ([]byte, error) {
+func CommitScriptUnencumbered(key *btcec.PublicKey) ([]byte, error) {
 	// This script goes to the "other" party, and it spendable immediately.
 	builder := txscript.NewScriptBuilder()
 	builder.AddOp(txscript.OP_0)
@@ -1060,6 +1070,78 @@ func CommitSpendNoDelay(signer Signer, signDesc *SignDescriptor,
 	return witness, nil
 }
 
+// CommitScriptAnchor constructs the script for an anchor output of a
+// commitment transaction. Each party to the channel has their own anchor
+// output, which they can spend immediately in order to bump the fee of the
+// commitment transaction through CPFP. To prevent the anchor outputs from
+// bloating the UTXO set, anybody is able to sweep them once the commitment
+// transaction has been confirmed for AnchorCsvDelay blocks.
+//
+// Possible Input Scripts:
+//     By owner:                  <sig>
+//     By anyone (after 16 conf): <emptyvector>
+//
+// Output Script:
+//     <key> OP_CHECKSIG OP_IFDUP
+//     OP_NOTIF
+//         OP_16 OP_CHECKSEQUENCEVERIFY
+//     OP_ENDIF
+func CommitScriptAnchor(key *btcec.PublicKey) ([]byte, error) {
+	builder := txscript.NewScriptBuilder()
+
+	// The owner of the anchor is able to spend it immediately by
+	// providing a valid signature, in which case OP_IFDUP leaves the
+	// result of the signature check on the stack, and the remainder of
+	// the script is skipped.
+	builder.AddData(key.SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIG)
+	builder.AddOp(txscript.OP_IFDUP)
+
+	// Otherwise, anybody is able to spend the anchor once the relative
+	// timeout has passed.
+	builder.AddOp(txscript.OP_NOTIF)
+	builder.AddInt64(AnchorCsvDelay)
+	builder.AddOp(txscript.OP_CHECKSEQUENCEVERIFY)
+	builder.AddOp(txscript.OP_ENDIF)
+
+	return builder.Script()
+}
+
+// CommitSpendAnchor constructs a valid witness allowing the owner of an anchor
+// output to spend it. The passed SignDescriptor should include the anchor
+// script as its WitnessScript, along with the key the script commits to.
+func CommitSpendAnchor(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	// The witness for the anchor output is simply our signature, followed
+	// by the anchor script.
+	witnessStack := wire.TxWitness(make([][]byte, 2))
+	witnessStack[0] = append(sweepSig, byte(signDesc.HashType))
+	witnessStack[1] = signDesc.WitnessScript
+
+	return witnessStack, nil
+}
+
+// CommitSpendAnchorAnyone constructs a witness allowing anybody to spend an
+// anchor output once the commitment transaction has been confirmed for
+// AnchorCsvDelay blocks. The sequence number of the spending input should be
+// set accordingly, and the version of the spending transaction must be >= 2.
+func CommitSpendAnchorAnyone(script []byte) (wire.TxWitness, error) {
+	// Place an empty byte as the first item in the witness stack, which
+	// will fail the signature check, forcing script execution to the
+	// timeout clause.
+	witnessStack := wire.TxWitness(make([][]byte, 2))
+	witnessStack[0] = nil
+	witnessStack[1] = script
+
+	return witnessStack, nil
+}
+
 // SingleTweakBytes computes set of bytes we call the single tweak. The purpose
 // of the single tweak is to randomize all regular delay and payment base
 // points. To do this, we generate a hash that binds the commitment point to
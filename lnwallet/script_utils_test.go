@@ -90,7 +90,7 @@ func TestCommitmentSpendValidation(t *testing.T) {
 
 	// We're testing an uncooperative close, output sweep, so construct a
 	// transaction which sweeps the funds to a random address.
-	targetOutput, err := commitScriptUnencumbered(aliceKeyPub)
+	targetOutput, err := CommitScriptUnencumbered(aliceKeyPub)
 	if err != nil {
 		t.Fatalf("unable to create target output: %v", err)
 	}
@@ -105,7 +105,7 @@ func TestCommitmentSpendValidation(t *testing.T) {
 	})
 
 	// First, we'll test spending with Alice's key after the timeout.
-	delayScript, err := commitScriptToSelf(csvTimeout, aliceDelayKey,
+	delayScript, err := CommitScriptToSelf(csvTimeout, aliceDelayKey,
 		revokePubKey)
 	if err != nil {
 		t.Fatalf("unable to generate alice delay script: %v", err)
@@ -186,7 +186,7 @@ func TestCommitmentSpendValidation(t *testing.T) {
 
 	// Finally, we test bob sweeping his output as normal in the case that
 	// Alice broadcasts this commitment transaction.
-	bobScriptP2WKH, err := commitScriptUnencumbered(bobPayKey)
+	bobScriptP2WKH, err := CommitScriptUnencumbered(bobPayKey)
 	if err != nil {
 		t.Fatalf("unable to create bob p2wkh script: %v", err)
 	}
@@ -221,6 +221,99 @@ func TestCommitmentSpendValidation(t *testing.T) {
 	}
 }
 
+// TestAnchorSpendValidation tests that the anchor output of a commitment
+// transaction can be spent immediately by its owner, and by anybody else once
+// the commitment has been confirmed for AnchorCsvDelay blocks.
+func TestAnchorSpendValidation(t *testing.T) {
+	t.Parallel()
+
+	aliceKeyPriv, aliceKeyPub := btcec.PrivKeyFromBytes(btcec.S256(),
+		testWalletPrivKey)
+	aliceSigner := &mockSigner{
+		privkeys: []*btcec.PrivateKey{aliceKeyPriv},
+	}
+
+	anchorScript, err := CommitScriptAnchor(aliceKeyPub)
+	if err != nil {
+		t.Fatalf("unable to create anchor script: %v", err)
+	}
+	anchorScriptHash, err := WitnessScriptHash(anchorScript)
+	if err != nil {
+		t.Fatalf("unable to create anchor script hash: %v", err)
+	}
+
+	// We'll construct a transaction which sweeps the anchor output of a
+	// fake commitment transaction to a random address.
+	txid, err := chainhash.NewHash(testHdSeed.CloneBytes())
+	if err != nil {
+		t.Fatalf("unable to create txid: %v", err)
+	}
+	targetOutput, err := CommitScriptUnencumbered(aliceKeyPub)
+	if err != nil {
+		t.Fatalf("unable to create target output: %v", err)
+	}
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{
+		Hash:  *txid,
+		Index: 2,
+	}, nil, nil))
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: targetOutput,
+		Value:    int64(AnchorSize),
+	})
+
+	checkSpend := func(witness wire.TxWitness) error {
+		sweepTx.TxIn[0].Witness = witness
+		vm, err := txscript.NewEngine(anchorScriptHash,
+			sweepTx, 0, txscript.StandardVerifyFlags, nil,
+			nil, int64(AnchorSize))
+		if err != nil {
+			return err
+		}
+		return vm.Execute()
+	}
+
+	// First, we'll test that Alice is able to spend her anchor right
+	// away, which allows her to bump the fee of the commitment.
+	signDesc := &SignDescriptor{
+		KeyDesc: keychain.KeyDescriptor{
+			PubKey: aliceKeyPub,
+		},
+		WitnessScript: anchorScript,
+		SigHashes:     txscript.NewTxSigHashes(sweepTx),
+		Output: &wire.TxOut{
+			PkScript: anchorScriptHash,
+			Value:    int64(AnchorSize),
+		},
+		HashType:   txscript.SigHashAll,
+		InputIndex: 0,
+	}
+	aliceWitness, err := CommitSpendAnchor(aliceSigner, signDesc, sweepTx)
+	if err != nil {
+		t.Fatalf("unable to generate anchor spend witness: %v", err)
+	}
+	if err := checkSpend(aliceWitness); err != nil {
+		t.Fatalf("owner spend of anchor is invalid: %v", err)
+	}
+
+	// Anybody else should be unable to spend the anchor before the CSV
+	// delay has passed.
+	anyoneWitness, err := CommitSpendAnchorAnyone(anchorScript)
+	if err != nil {
+		t.Fatalf("unable to generate anchor spend witness: %v", err)
+	}
+	sweepTx.TxIn[0].Sequence = lockTimeToSequence(false, AnchorCsvDelay-1)
+	if err := checkSpend(anyoneWitness); err == nil {
+		t.Fatalf("spend of anchor before csv delay should be invalid")
+	}
+
+	// Once the delay has passed, anybody is able to sweep the anchor.
+	sweepTx.TxIn[0].Sequence = lockTimeToSequence(false, AnchorCsvDelay)
+	if err := checkSpend(anyoneWitness); err != nil {
+		t.Fatalf("spend of anchor after csv delay is invalid: %v", err)
+	}
+}
+
 // TestRevocationKeyDerivation tests that given a public key, and a revocation
 // hash, the homomorphic revocation public and private key derivation work
 // properly.
@@ -357,7 +450,7 @@ func TestHTLCSenderSpendValidation(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to create htlc sender script: %v", err)
 	}
-	htlcPkScript, err := witnessScriptHash(htlcWitnessScript)
+	htlcPkScript, err := WitnessScriptHash(htlcWitnessScript)
 	if err != nil {
 		t.Fatalf("unable to create p2wsh htlc script: %v", err)
 	}
@@ -612,7 +705,7 @@ func TestHTLCReceiverSpendValidation(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to create htlc sender script: %v", err)
 	}
-	htlcPkScript, err := witnessScriptHash(htlcWitnessScript)
+	htlcPkScript, err := WitnessScriptHash(htlcWitnessScript)
 	if err != nil {
 		t.Fatalf("unable to create p2wsh htlc script: %v", err)
 	}
@@ -885,7 +978,7 @@ func TestSecondLevelHtlcSpends(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to create htlc script: %v", err)
 	}
-	htlcPkScript, err := witnessScriptHash(htlcWitnessScript)
+	htlcPkScript, err := WitnessScriptHash(htlcWitnessScript)
 	if err != nil {
 		t.Fatalf("unable to create htlc output: %v", err)
 	}
@@ -1,6 +1,7 @@
 package lnwallet
 
 import (
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/wire"
 )
@@ -12,6 +13,11 @@ const (
 
 	// HtlcWeight is the weight of an HTLC output.
 	HtlcWeight int64 = 172
+
+	// AnchorCommitWeight is the weight of the base commitment transaction
+	// of a channel that makes use of the anchor commitment format, which
+	// additionally includes an anchor output for each party.
+	AnchorCommitWeight int64 = CommitWeight + 2*witnessScaleFactor*AnchorOutputSize
 )
 
 const (
@@ -186,6 +192,31 @@ const (
 	// weight limits.
 	MaxHTLCNumber = 966
 
+	// AnchorScriptSize 40 bytes
+	//      - OP_DATA: 1 byte (key length)
+	//      - key: 33 bytes
+	//      - OP_CHECKSIG: 1 byte
+	//      - OP_IFDUP: 1 byte
+	//      - OP_NOTIF: 1 byte
+	//              - OP_16: 1 byte
+	//              - OP_CHECKSEQUENCEVERIFY: 1 byte
+	//      - OP_ENDIF: 1 byte
+	AnchorScriptSize = 1 + 33 + 1 + 1 + 1 + 1 + 1 + 1
+
+	// AnchorOutputSize 43 bytes
+	//      - Value: 8 bytes
+	//      - VarInt: 1 byte (PkScript length)
+	//      - PkScript (P2WSH)
+	AnchorOutputSize = 8 + 1 + P2WSHSize
+
+	// AnchorWitnessSize 116 bytes
+	//      - number_of_witness_elements: 1 byte
+	//      - sig_length: 1 byte
+	//      - sig: 73 bytes
+	//      - witness_script_length: 1 byte
+	//      - witness_script (anchor_script)
+	AnchorWitnessSize = 1 + 1 + 73 + 1 + AnchorScriptSize
+
 	// ToLocalScriptSize 83 bytes
 	//      - OP_IF: 1 byte
 	//              - OP_DATA: 1 byte (revocationkey length)
@@ -383,6 +414,16 @@ const (
 	OfferedHtlcPenaltyWitnessSize = 1 + 1 + 73 + 1 + 1 + OfferedHtlcScriptSize
 )
 
+// baseCommitWeight returns the weight of the base commitment transaction,
+// without any HTLC outputs, for a channel of the passed type.
+func baseCommitWeight(chanType channeldb.ChannelType) int64 {
+	if chanType.HasAnchors() {
+		return AnchorCommitWeight
+	}
+
+	return CommitWeight
+}
+
 // estimateCommitTxWeight estimate commitment transaction weight depending on
 // the precalculated weight of base transaction, witness data, which is needed
 // for paying for funding tx, and htlc weight multiplied by their count.
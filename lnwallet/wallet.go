@@ -93,6 +93,21 @@ type initFundingReserveMsg struct {
 	// open_channel message.
 	flags lnwire.FundingFlag
 
+	// anchors denotes whether the channel should make use of the anchor
+	// commitment format.
+	anchors bool
+
+	// batch is the funding batch this reservation is a part of, if any.
+	// If set, then no coin selection will be performed for the
+	// reservation, as its funding output will be created within the
+	// batch's shared funding transaction.
+	batch *FundingBatch
+
+	// psbtFunding denotes whether the reservation will be funded by an
+	// external wallet through a PSBT. If set, then no coin selection will
+	// be performed for the reservation.
+	psbtFunding bool
+
 	// err is a channel in which all errors will be sent across. Will be
 	// nil if this initial set is successful.
 	//
@@ -413,7 +428,8 @@ func (l *LightningWallet) InitChannelReservation(
 	capacity, ourFundAmt btcutil.Amount, pushMSat lnwire.MilliSatoshi,
 	commitFeePerKw SatPerKWeight, fundingFeePerVSize SatPerVByte,
 	theirID *btcec.PublicKey, theirAddr net.Addr,
-	chainHash *chainhash.Hash, flags lnwire.FundingFlag) (*ChannelReservation, error) {
+	chainHash *chainhash.Hash, flags lnwire.FundingFlag,
+	anchors bool) (*ChannelReservation, error) {
 
 	errChan := make(chan error, 1)
 	respChan := make(chan *ChannelReservation, 1)
@@ -428,6 +444,7 @@ func (l *LightningWallet) InitChannelReservation(
 		fundingFeePerVSize: fundingFeePerVSize,
 		pushMSat:           pushMSat,
 		flags:              flags,
+		anchors:            anchors,
 		err:                errChan,
 		resp:               respChan,
 	}
@@ -459,7 +476,7 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 	id := atomic.AddUint64(&l.nextFundingID, 1)
 	reservation, err := NewChannelReservation(req.capacity, req.fundingAmount,
 		req.commitFeePerKw, l, id, req.pushMSat,
-		l.Cfg.NetParams.GenesisHash, req.flags)
+		l.Cfg.NetParams.GenesisHash, req.flags, req.anchors)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
@@ -472,15 +489,20 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 
 	reservation.nodeAddr = req.nodeAddr
 	reservation.partialState.IdentityPub = req.nodeID
+	reservation.batch = req.batch
+	reservation.psbtFunding = req.psbtFunding
 
 	// If we're on the receiving end of a single funder channel then we
-	// don't need to perform any coin selection. Otherwise, attempt to
-	// obtain enough coins to meet the required funding amount.
-	if req.fundingAmount != 0 {
+	// don't need to perform any coin selection. The same applies if the
+	// reservation is part of a batch, as coin selection will be performed
+	// once for the entire batch, or if it will be funded by an external
+	// wallet. Otherwise, attempt to obtain enough coins to meet the
+	// required funding amount.
+	if req.fundingAmount != 0 && req.batch == nil && !req.psbtFunding {
 		// Coin selection is done on the basis of sat-per-vbyte, we'll
 		// use the passed sat/vbyte passed in to perform coin selection.
 		err := l.selectCoinsAndChange(
-			req.fundingFeePerVSize, req.fundingAmount,
+			req.fundingFeePerVSize, req.fundingAmount, 1,
 			reservation.ourContribution,
 		)
 		if err != nil {
@@ -574,6 +596,8 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 	)
 
 	reservation.partialState.RevocationProducer = producer
+	reservation.partialState.RevocationKeyLocator =
+		nextRevocationKeyDesc.KeyLocator
 	reservation.ourContribution.ChannelConstraints = l.Cfg.DefaultConstraints
 
 	// TODO(roasbeef): turn above into: initContribution()
@@ -632,8 +656,11 @@ func (l *LightningWallet) handleFundingCancelRequest(req *fundingReserveCancelMs
 // commitment transaction for both parties. This function is used during the
 // initial funding workflow as both sides must generate a signature for the
 // remote party's commitment transaction, and verify the signature for their
-// version of the commitment transaction.
-func CreateCommitmentTxns(localBalance, remoteBalance btcutil.Amount,
+// version of the commitment transaction. If the passed channel type makes use
+// of the anchor commitment format, the anchor outputs of both parties are
+// added to the commitment transactions as well.
+func CreateCommitmentTxns(chanType channeldb.ChannelType,
+	localBalance, remoteBalance btcutil.Amount,
 	ourChanCfg, theirChanCfg *channeldb.ChannelConfig,
 	localCommitPoint, remoteCommitPoint *btcec.PublicKey,
 	fundingTxIn wire.TxIn) (*wire.MsgTx, *wire.MsgTx, error) {
@@ -650,6 +677,21 @@ func CreateCommitmentTxns(localBalance, remoteBalance btcutil.Amount,
 		return nil, nil, err
 	}
 
+	// The initial commitments don't carry any HTLCs, so each party's
+	// anchor is only present if they have an output on the commitment.
+	ourKey := ourChanCfg.MultiSigKey.PubKey
+	theirKey := theirChanCfg.MultiSigKey.PubKey
+	if chanType.HasAnchors() {
+		err := addAnchorOutputs(
+			ourCommitTx, ourKey, theirKey,
+			localBalance >= ourChanCfg.DustLimit,
+			remoteBalance >= ourChanCfg.DustLimit, false,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	otxn := btcutil.NewTx(ourCommitTx)
 	if err := blockchain.CheckTransactionSanity(otxn); err != nil {
 		return nil, nil, err
@@ -662,6 +704,17 @@ func CreateCommitmentTxns(localBalance, remoteBalance btcutil.Amount,
 		return nil, nil, err
 	}
 
+	if chanType.HasAnchors() {
+		err := addAnchorOutputs(
+			theirCommitTx, theirKey, ourKey,
+			remoteBalance >= theirChanCfg.DustLimit,
+			localBalance >= theirChanCfg.DustLimit, false,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	ttxn := btcutil.NewTx(theirCommitTx)
 	if err := blockchain.CheckTransactionSanity(ttxn); err != nil {
 		return nil, nil, err
@@ -689,16 +742,27 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	pendingReservation.Lock()
 	defer pendingReservation.Unlock()
 
-	// Create a blank, fresh transaction. Soon to be a complete funding
-	// transaction which will allow opening a lightning channel.
-	pendingReservation.fundingTx = wire.NewMsgTx(1)
-	fundingTx := pendingReservation.fundingTx
-
 	// Some temporary variables to cut down on the resolution verbosity.
 	pendingReservation.theirContribution = req.contribution
 	theirContribution := req.contribution
 	ourContribution := pendingReservation.ourContribution
 
+	// If this reservation is part of a batch, then we'll only be able to
+	// construct its funding transaction once every reservation within the
+	// batch has received the contribution of the remote party. Similarly,
+	// if the reservation is funded through a PSBT, then the funding
+	// transaction will only be known once the external wallet has signed
+	// it.
+	if pendingReservation.batch != nil || pendingReservation.psbtFunding {
+		req.err <- nil
+		return
+	}
+
+	// Create a blank, fresh transaction. Soon to be a complete funding
+	// transaction which will allow opening a lightning channel.
+	pendingReservation.fundingTx = wire.NewMsgTx(1)
+	fundingTx := pendingReservation.fundingTx
+
 	// Add all multi-party inputs and outputs to the transaction.
 	for _, ourInput := range ourContribution.Inputs {
 		fundingTx.AddTxIn(ourInput)
@@ -713,16 +777,9 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 		fundingTx.AddTxOut(theirChangeOutput)
 	}
 
-	ourKey := pendingReservation.ourContribution.MultiSigKey
-	theirKey := theirContribution.MultiSigKey
-
 	// Finally, add the 2-of-2 multi-sig output which will set up the lightning
 	// channel.
-	channelCapacity := int64(pendingReservation.partialState.Capacity)
-	witnessScript, multiSigOut, err := GenFundingPkScript(
-		ourKey.PubKey.SerializeCompressed(),
-		theirKey.PubKey.SerializeCompressed(), channelCapacity,
-	)
+	witnessScript, multiSigOut, err := pendingReservation.fundingOutput()
 	if err != nil {
 		req.err <- err
 		return
@@ -736,8 +793,28 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 
 	// Next, sign all inputs that are ours, collecting the signatures in
 	// order of the inputs.
-	pendingReservation.ourFundingInputScripts = make([]*InputScript, 0,
-		len(ourContribution.Inputs))
+	pendingReservation.ourFundingInputScripts, err = l.signFundingInputs(
+		fundingTx,
+	)
+	if err != nil {
+		req.err <- err
+		return
+	}
+
+	// With the funding transaction complete, we can now create both
+	// commitment transactions, and sign the remote party's version.
+	req.err <- l.initCommitments(
+		pendingReservation, multiSigOut, witnessScript,
+	)
+}
+
+// signFundingInputs signs all inputs of the passed funding transaction that
+// belong to the wallet, returning the input scripts in the order of the
+// inputs.
+func (l *LightningWallet) signFundingInputs(
+	fundingTx *wire.MsgTx) ([]*InputScript, error) {
+
+	var inputScripts []*InputScript
 	signDesc := SignDescriptor{
 		HashType:  txscript.SigHashAll,
 		SigHashes: txscript.NewTxSigHashes(fundingTx),
@@ -747,8 +824,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 		if err == ErrNotMine {
 			continue
 		} else if err != nil {
-			req.err <- err
-			return
+			return nil, err
 		}
 
 		signDesc.Output = info
@@ -757,18 +833,30 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 		inputScript, err := l.Cfg.Signer.ComputeInputScript(fundingTx,
 			&signDesc)
 		if err != nil {
-			req.err <- err
-			return
+			return nil, err
 		}
 
 		txIn.SignatureScript = inputScript.ScriptSig
 		txIn.Witness = inputScript.Witness
-		pendingReservation.ourFundingInputScripts = append(
-			pendingReservation.ourFundingInputScripts,
-			inputScript,
-		)
+		inputScripts = append(inputScripts, inputScript)
 	}
 
+	return inputScripts, nil
+}
+
+// initCommitments creates both commitment transactions of a reservation that
+// spend from the passed funding output of the reservation's funding
+// transaction, and generates our signature for the remote party's version.
+//
+// NOTE: The reservation's mutex MUST be held when calling this method.
+func (l *LightningWallet) initCommitments(pendingReservation *ChannelReservation,
+	multiSigOut *wire.TxOut, witnessScript []byte) error {
+
+	fundingTx := pendingReservation.fundingTx
+	theirContribution := pendingReservation.theirContribution
+	ourContribution := pendingReservation.ourContribution
+	ourKey := ourContribution.MultiSigKey
+
 	// Locate the index of the multi-sig outpoint in order to record it
 	// since the outputs are canonically sorted. If this is a single funder
 	// workflow, then we'll also need to send this to the remote node.
@@ -805,21 +893,21 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	localBalance := pendingReservation.partialState.LocalCommitment.LocalBalance.ToSatoshis()
 	remoteBalance := pendingReservation.partialState.LocalCommitment.RemoteBalance.ToSatoshis()
 	ourCommitTx, theirCommitTx, err := CreateCommitmentTxns(
-		localBalance, remoteBalance, ourContribution.ChannelConfig,
+		chanState.ChanType, localBalance, remoteBalance,
+		ourContribution.ChannelConfig,
 		theirContribution.ChannelConfig,
 		ourContribution.FirstCommitmentPoint,
 		theirContribution.FirstCommitmentPoint, fundingTxIn,
 	)
 	if err != nil {
-		req.err <- err
-		return
+		return err
 	}
 
 	// With both commitment transactions constructed, generate the state
 	// obfuscator then use it to encode the current state number within
 	// both commitment transactions.
 	var stateObfuscator [StateHintSize]byte
-	if chanState.ChanType == channeldb.SingleFunder {
+	if chanState.ChanType.IsSingleFunder() {
 		stateObfuscator = DeriveStateHintObfuscator(
 			ourContribution.PaymentBasePoint.PubKey,
 			theirContribution.PaymentBasePoint.PubKey,
@@ -842,8 +930,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 	err = initStateHints(ourCommitTx, theirCommitTx, stateObfuscator)
 	if err != nil {
-		req.err <- err
-		return
+		return err
 	}
 
 	// Sort both transactions according to the agreed upon canonical
@@ -864,7 +951,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 
 	// Generate a signature for their version of the initial commitment
 	// transaction.
-	signDesc = SignDescriptor{
+	signDesc := SignDescriptor{
 		WitnessScript: witnessScript,
 		KeyDesc:       ourKey,
 		Output:        multiSigOut,
@@ -874,12 +961,11 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 	sigTheirCommit, err := l.Cfg.Signer.SignOutputRaw(theirCommitTx, &signDesc)
 	if err != nil {
-		req.err <- err
-		return
+		return err
 	}
 	pendingReservation.ourCommitmentSig = sigTheirCommit
 
-	req.err <- nil
+	return nil
 }
 
 // handleSingleContribution is called as the second step to a single funder
@@ -1047,6 +1133,20 @@ func (l *LightningWallet) handleFundingCounterPartySigs(msg *addCounterPartySigs
 	}
 	res.partialState.LocalCommitment.CommitSig = theirCommitSig
 
+	// If this reservation is part of a batch, then we're done for now. The
+	// channel will only be committed to disk, and the shared funding
+	// transaction broadcast, once every reservation within the batch has
+	// been completed.
+	if res.batch != nil {
+		res.partialState.LocalChanCfg = res.ourContribution.toChanConfig()
+		res.partialState.RemoteChanCfg = res.theirContribution.toChanConfig()
+		res.partialState.FundingTxn = fundingTx
+
+		msg.completeChan <- res.partialState
+		msg.err <- nil
+		return
+	}
+
 	// Funding complete, this entry can be removed from limbo.
 	l.limboMtx.Lock()
 	delete(l.fundingLimbo, res.reservationID)
@@ -1127,7 +1227,7 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 	localBalance := pendingReservation.partialState.LocalCommitment.LocalBalance.ToSatoshis()
 	remoteBalance := pendingReservation.partialState.LocalCommitment.RemoteBalance.ToSatoshis()
 	ourCommitTx, theirCommitTx, err := CreateCommitmentTxns(
-		localBalance, remoteBalance,
+		chanState.ChanType, localBalance, remoteBalance,
 		pendingReservation.ourContribution.ChannelConfig,
 		pendingReservation.theirContribution.ChannelConfig,
 		pendingReservation.ourContribution.FirstCommitmentPoint,
@@ -1207,7 +1307,7 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 	// With their signature for our version of the commitment transactions
 	// verified, we can now generate a signature for their version,
 	// allowing the funding transaction to be safely broadcast.
-	p2wsh, err := witnessScriptHash(witnessScript)
+	p2wsh, err := WitnessScriptHash(witnessScript)
 	if err != nil {
 		req.err <- err
 		req.completeChan <- nil
@@ -1259,13 +1359,15 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 }
 
 // selectCoinsAndChange performs coin selection in order to obtain witness
-// outputs which sum to at least 'numCoins' amount of satoshis. If coin
-// selection is successful/possible, then the selected coins are available
-// within the passed contribution's inputs. If necessary, a change address will
-// also be generated.
+// outputs which sum to at least 'numCoins' amount of satoshis. The fee is
+// estimated for a funding transaction with numFundingOutputs channel outputs.
+// If coin selection is successful/possible, then the selected coins are
+// available within the passed contribution's inputs. If necessary, a change
+// address will also be generated.
 // TODO(roasbeef): remove hardcoded fees and req'd confs for outputs.
 func (l *LightningWallet) selectCoinsAndChange(feeRate SatPerVByte,
-	amt btcutil.Amount, contribution *ChannelContribution) error {
+	amt btcutil.Amount, numFundingOutputs int,
+	contribution *ChannelContribution) error {
 
 	// We hold the coin select mutex while querying for outputs, and
 	// performing coin selection in order to avoid inadvertent double
@@ -1287,7 +1389,9 @@ func (l *LightningWallet) selectCoinsAndChange(feeRate SatPerVByte,
 	// Perform coin selection over our available, unlocked unspent outputs
 	// in order to find enough coins to meet the funding amount
 	// requirements.
-	selectedCoins, changeAmt, err := coinSelect(feeRate, amt, coins)
+	selectedCoins, changeAmt, err := coinSelect(
+		feeRate, amt, numFundingOutputs, coins,
+	)
 	if err != nil {
 		return err
 	}
@@ -1382,10 +1486,10 @@ func selectInputs(amt btcutil.Amount, coins []*Utxo) (btcutil.Amount, []*Utxo, e
 }
 
 // coinSelect attempts to select a sufficient amount of coins, including a
-// change output to fund amt satoshis, adhering to the specified fee rate. The
-// specified fee rate should be expressed in sat/vbyte for coin selection to
-// function properly.
-func coinSelect(feeRate SatPerVByte, amt btcutil.Amount,
+// change output to fund amt satoshis spread across numFundingOutputs channel
+// outputs, adhering to the specified fee rate. The specified fee rate should
+// be expressed in sat/vbyte for coin selection to function properly.
+func coinSelect(feeRate SatPerVByte, amt btcutil.Amount, numFundingOutputs int,
 	coins []*Utxo) ([]*Utxo, btcutil.Amount, error) {
 
 	amtNeeded := amt
@@ -1411,8 +1515,10 @@ func coinSelect(feeRate SatPerVByte, amt btcutil.Amount,
 			}
 		}
 
-		// Channel funding multisig output is P2WSH.
-		weightEstimate.AddP2WSHOutput()
+		// Channel funding multisig outputs are P2WSH.
+		for i := 0; i < numFundingOutputs; i++ {
+			weightEstimate.AddP2WSHOutput()
+		}
 
 		// Assume that change output is a P2WKH output.
 		//
@@ -69,6 +69,16 @@ const (
 	// broadcast a revoked commitment, but then also immediately attempt to
 	// go to the second level to claim the HTLC.
 	HtlcSecondLevelRevoke WitnessType = 9
+
+	// CommitmentAnchor is a witness that allows us to spend our anchor
+	// output on a commitment transaction, in order to bump the fee of the
+	// commitment through CPFP.
+	CommitmentAnchor WitnessType = 10
+
+	// WitnessKeyHash is a witness that allows us to spend a regular p2wkh
+	// output controlled by the wallet, for example in order to attach
+	// additional funds to a sweep transaction.
+	WitnessKeyHash WitnessType = 11
 )
 
 // WitnessGenerator represents a function which is able to generate the final
@@ -120,6 +130,17 @@ func (wt WitnessType) GenWitnessFunc(signer Signer,
 		case HtlcSecondLevelRevoke:
 			return htlcSpendRevoke(signer, desc, tx)
 
+		case CommitmentAnchor:
+			return CommitSpendAnchor(signer, desc, tx)
+
+		case WitnessKeyHash:
+			inputScript, err := signer.ComputeInputScript(tx, desc)
+			if err != nil {
+				return nil, err
+			}
+
+			return inputScript.Witness, nil
+
 		default:
 			return nil, fmt.Errorf("unknown witness type: %v", wt)
 		}
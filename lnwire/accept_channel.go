@@ -86,6 +86,13 @@ type AcceptChannel struct {
 	// base point in order to derive the revocation keys that are placed
 	// within the commitment transaction of the sender.
 	FirstCommitmentPoint *btcec.PublicKey
+
+	// UpfrontShutdownScript is the script to which the responder commits
+	// to pay its funds upon a cooperative close of the channel. It is only
+	// sent if non-empty, to peers that signal the upfront shutdown script
+	// feature bit, and the remote peer must reject any Shutdown message
+	// from the responder that pays to a different script.
+	UpfrontShutdownScript DeliveryAddress
 }
 
 // A compile time check to ensure AcceptChannel implements the lnwire.Message
@@ -98,7 +105,7 @@ var _ Message = (*AcceptChannel)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (a *AcceptChannel) Encode(w io.Writer, pver uint32) error {
-	return writeElements(w,
+	err := writeElements(w,
 		a.PendingChannelID[:],
 		a.DustLimit,
 		a.MaxValueInFlight,
@@ -114,6 +121,17 @@ func (a *AcceptChannel) Encode(w io.Writer, pver uint32) error {
 		a.HtlcPoint,
 		a.FirstCommitmentPoint,
 	)
+	if err != nil {
+		return err
+	}
+
+	// The upfront shutdown script is optional, so we'll only include it
+	// if one was specified.
+	if len(a.UpfrontShutdownScript) == 0 {
+		return nil
+	}
+
+	return writeElement(w, a.UpfrontShutdownScript)
 }
 
 // Decode deserializes the serialized AcceptChannel stored in the passed
@@ -122,7 +140,7 @@ func (a *AcceptChannel) Encode(w io.Writer, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (a *AcceptChannel) Decode(r io.Reader, pver uint32) error {
-	return readElements(r,
+	err := readElements(r,
 		a.PendingChannelID[:],
 		&a.DustLimit,
 		&a.MaxValueInFlight,
@@ -138,6 +156,18 @@ func (a *AcceptChannel) Decode(r io.Reader, pver uint32) error {
 		&a.HtlcPoint,
 		&a.FirstCommitmentPoint,
 	)
+	if err != nil {
+		return err
+	}
+
+	// The upfront shutdown script is optional, so we'll only attempt to
+	// read it if the message hasn't ended yet.
+	err = readElement(r, &a.UpfrontShutdownScript)
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
 }
 
 // MsgType returns the MessageType code which uniquely identifies this message
@@ -153,6 +183,6 @@ func (a *AcceptChannel) MsgType() MessageType {
 //
 // This is part of the lnwire.Message interface.
 func (a *AcceptChannel) MaxPayloadLength(uint32) uint32 {
-	// 32 + (8 * 4) + (4 * 1) + (2 * 2) + (33 * 6)
-	return 270
+	// 32 + (8 * 4) + (4 * 1) + (2 * 2) + (33 * 6) + 2 + 34
+	return 306
 }
@@ -21,6 +21,54 @@ const (
 	// connection is established.
 	InitialRoutingSync FeatureBit = 3
 
+	// UpfrontShutdownScriptRequired is a local feature bit signalling
+	// that the node requires its peers to understand the upfront
+	// shutdown scripts committed to within the OpenChannel and
+	// AcceptChannel messages.
+	UpfrontShutdownScriptRequired FeatureBit = 4
+
+	// UpfrontShutdownScriptOptional is a local feature bit signalling
+	// that the node understands the upfront shutdown scripts committed to
+	// within the OpenChannel and AcceptChannel messages, and will enforce
+	// them upon a cooperative close.
+	UpfrontShutdownScriptOptional FeatureBit = 5
+
+	// GossipQueriesRequired is a local feature bit signalling that the
+	// node requires its peers to understand the gossip query messages
+	// defined in BOLT-07.
+	GossipQueriesRequired FeatureBit = 6
+
+	// GossipQueriesOptional is a local feature bit signalling that the
+	// node understands the gossip query messages defined in BOLT-07, and
+	// would rather sync its graph using them than receive a full dump of
+	// routing information on each connection.
+	GossipQueriesOptional FeatureBit = 7
+
+	// WumboChannelsRequired is a local feature bit signalling that the
+	// node requires its peers to support channels larger than 2^24
+	// satoshis.
+	WumboChannelsRequired FeatureBit = 18
+
+	// WumboChannelsOptional is a local feature bit signalling that the
+	// node is willing to open and accept channels larger than 2^24
+	// satoshis with peers that also support them.
+	WumboChannelsOptional FeatureBit = 19
+
+	// AnchorOutputsRequired is a local feature bit signalling that the
+	// node requires its peers to use the anchor commitment format, which
+	// adds an anchor output for each party to the commitment transaction.
+	AnchorOutputsRequired FeatureBit = 20
+
+	// AnchorOutputsOptional is a local feature bit signalling that the
+	// node is able to use the anchor commitment format, allowing either
+	// party to bump the fee of a force closed commitment through CPFP.
+	AnchorOutputsOptional FeatureBit = 21
+
+	// MultiPathPaymentsOptional is a global feature bit signalling that
+	// the node is able to receive payments that are split into several
+	// shards, each sent along a different path.
+	MultiPathPaymentsOptional FeatureBit = 17
+
 	// maxAllowedSize is a maximum allowed size of feature vector.
 	//
 	// NOTE: Within the protocol, the maximum allowed message size is 65535
@@ -42,14 +90,24 @@ const (
 // not advertised to the entire network. A full description of these feature
 // bits is provided in the BOLT-09 specification.
 var LocalFeatures = map[FeatureBit]string{
-	InitialRoutingSync: "initial-routing-sync",
+	InitialRoutingSync:            "initial-routing-sync",
+	UpfrontShutdownScriptRequired: "upfront-shutdown-script",
+	UpfrontShutdownScriptOptional: "upfront-shutdown-script",
+	GossipQueriesRequired:         "gossip-queries",
+	GossipQueriesOptional:         "gossip-queries",
+	WumboChannelsRequired:         "wumbo-channels",
+	WumboChannelsOptional:         "wumbo-channels",
+	AnchorOutputsRequired:         "anchor-commitments",
+	AnchorOutputsOptional:         "anchor-commitments",
 }
 
 // GlobalFeatures is a mapping of known global feature bits to a descriptive
 // name. All known global feature bits must be assigned a name in this mapping.
 // Global features are those which are advertised to the entire network. A full
 // description of these feature bits is provided in the BOLT-09 specification.
-var GlobalFeatures map[FeatureBit]string
+var GlobalFeatures = map[FeatureBit]string{
+	MultiPathPaymentsOptional: "multi-path-payments",
+}
 
 // RawFeatureVector represents a set of feature bits as defined in BOLT-09.  A
 // RawFeatureVector itself just stores a set of bit flags but can be used to
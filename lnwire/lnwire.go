@@ -11,6 +11,7 @@ import (
 	"net"
 
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/torsvc"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -295,7 +296,6 @@ func writeElement(w io.Writer, element interface{}) error {
 			return fmt.Errorf("cannot write nil TCPAddr")
 		}
 
-		// TODO(roasbeef): account for onion types too
 		if e.IP.To4() != nil {
 			var descriptor [1]byte
 			descriptor[0] = uint8(tcp4Addr)
@@ -326,6 +326,52 @@ func writeElement(w io.Writer, element interface{}) error {
 			return err
 		}
 
+	case *torsvc.OnionAddr:
+		if e == nil {
+			return fmt.Errorf("cannot write nil onion address")
+		}
+
+		// The type of the onion service is determined by the length of
+		// its address, which also tells us where its ".onion" suffix
+		// starts.
+		var (
+			descriptor  [1]byte
+			suffixIndex int
+		)
+		switch len(e.OnionService) {
+		case torsvc.V2Len:
+			descriptor[0] = uint8(v2OnionAddr)
+			suffixIndex = torsvc.V2Len - torsvc.OnionSuffixLen
+		case torsvc.V3Len:
+			descriptor[0] = uint8(v3OnionAddr)
+			suffixIndex = torsvc.V3Len - torsvc.OnionSuffixLen
+		default:
+			return fmt.Errorf("unknown onion service length: %v",
+				len(e.OnionService))
+		}
+
+		// With the suffix stripped, the onion service is decoded into
+		// its raw representation, which is what's sent over the wire.
+		host, err := torsvc.Base32Encoding.DecodeString(
+			e.OnionService[:suffixIndex],
+		)
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(descriptor[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(host); err != nil {
+			return err
+		}
+
+		var port [2]byte
+		binary.BigEndian.PutUint16(port[:], uint16(e.Port))
+		if _, err := w.Write(port[:]); err != nil {
+			return err
+		}
+
 	case []net.Addr:
 		// First, we'll encode all the addresses into an intermediate
 		// buffer. We need to do this in order to compute the total
@@ -629,7 +675,7 @@ func readElement(r io.Reader, element interface{}) error {
 
 			addrBytesRead++
 
-			address := &net.TCPAddr{}
+			var address net.Addr
 			aType := addressType(descriptor[0])
 			switch aType {
 
@@ -642,14 +688,16 @@ func readElement(r io.Reader, element interface{}) error {
 				if _, err = io.ReadFull(addrBuf, ip[:]); err != nil {
 					return err
 				}
-				address.IP = (net.IP)(ip[:])
 
 				var port [2]byte
 				if _, err = io.ReadFull(addrBuf, port[:]); err != nil {
 					return err
 				}
 
-				address.Port = int(binary.BigEndian.Uint16(port[:]))
+				address = &net.TCPAddr{
+					IP:   (net.IP)(ip[:]),
+					Port: int(binary.BigEndian.Uint16(port[:])),
+				}
 
 				addrBytesRead += aType.AddrLen()
 
@@ -658,23 +706,60 @@ func readElement(r io.Reader, element interface{}) error {
 				if _, err = io.ReadFull(addrBuf, ip[:]); err != nil {
 					return err
 				}
-				address.IP = (net.IP)(ip[:])
 
 				var port [2]byte
 				if _, err = io.ReadFull(addrBuf, port[:]); err != nil {
 					return err
 				}
-				address.Port = int(binary.BigEndian.Uint16(port[:]))
+
+				address = &net.TCPAddr{
+					IP:   (net.IP)(ip[:]),
+					Port: int(binary.BigEndian.Uint16(port[:])),
+				}
 
 				addrBytesRead += aType.AddrLen()
 
 			case v2OnionAddr:
+				var h [torsvc.V2DecodedLen]byte
+				if _, err = io.ReadFull(addrBuf, h[:]); err != nil {
+					return err
+				}
+
+				var port [2]byte
+				if _, err = io.ReadFull(addrBuf, port[:]); err != nil {
+					return err
+				}
+
+				onionService := torsvc.Base32Encoding.EncodeToString(
+					h[:],
+				) + torsvc.OnionSuffix
+				address = &torsvc.OnionAddr{
+					OnionService: onionService,
+					Port:         int(binary.BigEndian.Uint16(port[:])),
+				}
+
 				addrBytesRead += aType.AddrLen()
-				continue
 
 			case v3OnionAddr:
+				var h [torsvc.V3DecodedLen]byte
+				if _, err = io.ReadFull(addrBuf, h[:]); err != nil {
+					return err
+				}
+
+				var port [2]byte
+				if _, err = io.ReadFull(addrBuf, port[:]); err != nil {
+					return err
+				}
+
+				onionService := torsvc.Base32Encoding.EncodeToString(
+					h[:],
+				) + torsvc.OnionSuffix
+				address = &torsvc.OnionAddr{
+					OnionService: onionService,
+					Port:         int(binary.BigEndian.Uint16(port[:])),
+				}
+
 				addrBytesRead += aType.AddrLen()
-				continue
 
 			default:
 				return &ErrUnknownAddrType{aType}
@@ -727,3 +812,18 @@ func readElements(r io.Reader, elements ...interface{}) error {
 	}
 	return nil
 }
+
+// WriteElements writes each element in the elements slice to the passed
+// io.Writer using the serialization format of lnwire. This allows packages
+// outside of lnwire to re-use the wire encoding of common types such as
+// public keys, outpoints, and network addresses.
+func WriteElements(w io.Writer, elements ...interface{}) error {
+	return writeElements(w, elements...)
+}
+
+// ReadElements deserializes a variable number of elements from the passed
+// io.Reader using the serialization format of lnwire. It's the counterpart of
+// WriteElements.
+func ReadElements(r io.Reader, elements ...interface{}) error {
+	return readElements(r, elements...)
+}
@@ -9,10 +9,12 @@ import (
 	"math/rand"
 	"net"
 	"reflect"
+	"sort"
 	"testing"
 	"testing/quick"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/torsvc"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -38,9 +40,17 @@ var (
 	_, _ = testSig.S.SetString("18801056069249825825291287104931333862866033135609736119018462340006816851118", 10)
 
 	// TODO(roasbeef): randomly generate from three types of addrs
-	a1        = &net.TCPAddr{IP: (net.IP)([]byte{0x7f, 0x0, 0x0, 0x1}), Port: 8333}
-	a2, _     = net.ResolveTCPAddr("tcp", "[2001:db8:85a3:0:0:8a2e:370:7334]:80")
-	testAddrs = []net.Addr{a1, a2}
+	a1    = &net.TCPAddr{IP: (net.IP)([]byte{0x7f, 0x0, 0x0, 0x1}), Port: 8333}
+	a2, _ = net.ResolveTCPAddr("tcp", "[2001:db8:85a3:0:0:8a2e:370:7334]:80")
+	a3    = &torsvc.OnionAddr{
+		OnionService: "3g2upl4pq6kufc4m.onion",
+		Port:         9735,
+	}
+	a4 = &torsvc.OnionAddr{
+		OnionService: "vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3ah7ngmcopnpyyd.onion",
+		Port:         80,
+	}
+	testAddrs = []net.Addr{a1, a2, a3, a4}
 )
 
 func randPubKey() (*btcec.PublicKey, error) {
@@ -75,6 +85,15 @@ func randRawFeatureVector(r *rand.Rand) *RawFeatureVector {
 	return featureVec
 }
 
+func randDeliveryAddress(r *rand.Rand) (DeliveryAddress, error) {
+	addr := make(DeliveryAddress, r.Intn(34)+1)
+	if _, err := r.Read(addr); err != nil {
+		return nil, err
+	}
+
+	return addr, nil
+}
+
 func TestMaxOutPointIndex(t *testing.T) {
 	t.Parallel()
 
@@ -98,6 +117,35 @@ func TestEmptyMessageUnknownType(t *testing.T) {
 	}
 }
 
+// randSortedShortChanIDs generates a random, non-empty set of unique short
+// channel ID's, sorted in ascending order as required by the gossip query
+// messages.
+func randSortedShortChanIDs(r *rand.Rand) []ShortChannelID {
+	numChanIDs := r.Intn(1000) + 1
+
+	uniqueIDs := make(map[uint64]struct{})
+	for i := 0; i < numChanIDs; i++ {
+		uniqueIDs[uint64(r.Int63())] = struct{}{}
+	}
+
+	chanIDs := make([]uint64, 0, len(uniqueIDs))
+	for chanID := range uniqueIDs {
+		chanIDs = append(chanIDs, chanID)
+	}
+	sort.Slice(chanIDs, func(i, j int) bool {
+		return chanIDs[i] < chanIDs[j]
+	})
+
+	shortChanIDs := make([]ShortChannelID, 0, len(chanIDs))
+	for _, chanID := range chanIDs {
+		shortChanIDs = append(
+			shortChanIDs, NewShortChanIDFromInt(chanID),
+		)
+	}
+
+	return shortChanIDs
+}
+
 // TestLightningWireProtocol uses the testing/quick package to create a series
 // of fuzz tests to attempt to break a primary scenario which is implemented as
 // property based testing scenario.
@@ -213,6 +261,16 @@ func TestLightningWireProtocol(t *testing.T) {
 				return
 			}
 
+			// The upfront shutdown script is optional, so we'll
+			// only include one half of the time.
+			if r.Int31()%2 == 0 {
+				req.UpfrontShutdownScript, err = randDeliveryAddress(r)
+				if err != nil {
+					t.Fatalf("unable to generate script: %v", err)
+					return
+				}
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 		MsgAcceptChannel: func(v []reflect.Value, r *rand.Rand) {
@@ -263,6 +321,16 @@ func TestLightningWireProtocol(t *testing.T) {
 				return
 			}
 
+			// The upfront shutdown script is optional, so we'll
+			// only include one half of the time.
+			if r.Int31()%2 == 0 {
+				req.UpfrontShutdownScript, err = randDeliveryAddress(r)
+				if err != nil {
+					t.Fatalf("unable to generate script: %v", err)
+					return
+				}
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 		MsgFundingCreated: func(v []reflect.Value, r *rand.Rand) {
@@ -553,6 +621,37 @@ func TestLightningWireProtocol(t *testing.T) {
 				}
 			}
 
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgQueryShortChanIDs: func(v []reflect.Value, r *rand.Rand) {
+			req := QueryShortChanIDs{
+				EncodingType: ShortChanIDEncoding(r.Intn(2)),
+				ShortChanIDs: randSortedShortChanIDs(r),
+			}
+
+			if _, err := r.Read(req.ChainHash[:]); err != nil {
+				t.Fatalf("unable to read chain hash: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgReplyChannelRange: func(v []reflect.Value, r *rand.Rand) {
+			req := ReplyChannelRange{
+				QueryChannelRange: QueryChannelRange{
+					FirstBlockHeight: uint32(r.Int31()),
+					NumBlocks:        uint32(r.Int31()),
+				},
+				Complete:     uint8(r.Intn(2)),
+				EncodingType: ShortChanIDEncoding(r.Intn(2)),
+				ShortChanIDs: randSortedShortChanIDs(r),
+			}
+
+			if _, err := r.Read(req.ChainHash[:]); err != nil {
+				t.Fatalf("unable to read chain hash: %v", err)
+				return
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 	}
@@ -705,6 +804,36 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgQueryShortChanIDs,
+			scenario: func(m QueryShortChanIDs) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgReplyShortChanIDsEnd,
+			scenario: func(m ReplyShortChanIDsEnd) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgQueryChannelRange,
+			scenario: func(m QueryChannelRange) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgReplyChannelRange,
+			scenario: func(m ReplyChannelRange) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgGossipTimestampRange,
+			scenario: func(m GossipTimestampRange) bool {
+				return mainScenario(&m)
+			},
+		},
 	}
 	for _, test := range tests {
 		var config *quick.Config
@@ -76,6 +76,7 @@ const (
 	CodeFinalExpiryTooSoon            FailCode = 17
 	CodeFinalIncorrectCltvExpiry      FailCode = 18
 	CodeFinalIncorrectHtlcAmount      FailCode = 19
+	CodeMPPTimeout                    FailCode = 23
 )
 
 // String returns the string representation of the failure code.
@@ -144,6 +145,9 @@ func (c FailCode) String() string {
 	case CodeFinalIncorrectHtlcAmount:
 		return "FinalIncorrectHtlcAmount"
 
+	case CodeMPPTimeout:
+		return "MPPTimeout"
+
 	default:
 		return "<unknown>"
 	}
@@ -354,6 +358,26 @@ func (f FailFinalExpiryTooSoon) Error() string {
 	return f.Code().String()
 }
 
+// FailMPPTimeout is returned if the shards of a multi-path payment that were
+// received by the final node didn't add up to the invoice amount in time.
+//
+// NOTE: May only be returned by the final node in the path.
+type FailMPPTimeout struct{}
+
+// Code returns the failure unique code.
+//
+// NOTE: Part of the FailureMessage interface.
+func (f FailMPPTimeout) Code() FailCode {
+	return CodeMPPTimeout
+}
+
+// Returns a human readable string describing the target FailureMessage.
+//
+// NOTE: Implements the error interface.
+func (f FailMPPTimeout) Error() string {
+	return f.Code().String()
+}
+
 // FailInvalidOnionVersion is returned if the onion version byte is unknown.
 //
 // NOTE: May be returned only by intermediate nodes.
@@ -1151,6 +1175,9 @@ func makeEmptyOnionError(code FailCode) (FailureMessage, error) {
 
 	case CodeFinalIncorrectHtlcAmount:
 		return &FailFinalIncorrectHtlcAmount{}, nil
+
+	case CodeMPPTimeout:
+		return &FailMPPTimeout{}, nil
 	default:
 		return nil, errors.Errorf("unknown error code: %v", code)
 	}
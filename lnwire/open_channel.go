@@ -122,6 +122,13 @@ type OpenChannel struct {
 	// Currently, the least significant bit of this bit field indicates the
 	// initiator of the channel wishes to advertise this channel publicly.
 	ChannelFlags FundingFlag
+
+	// UpfrontShutdownScript is the script to which the initiator commits
+	// to pay its funds upon a cooperative close of the channel. It is only
+	// sent if non-empty, to peers that signal the upfront shutdown script
+	// feature bit, and the remote peer must reject any Shutdown message
+	// from the initiator that pays to a different script.
+	UpfrontShutdownScript DeliveryAddress
 }
 
 // A compile time check to ensure OpenChannel implements the lnwire.Message
@@ -134,7 +141,7 @@ var _ Message = (*OpenChannel)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (o *OpenChannel) Encode(w io.Writer, pver uint32) error {
-	return writeElements(w,
+	err := writeElements(w,
 		o.ChainHash[:],
 		o.PendingChannelID[:],
 		o.FundingAmount,
@@ -154,6 +161,17 @@ func (o *OpenChannel) Encode(w io.Writer, pver uint32) error {
 		o.FirstCommitmentPoint,
 		o.ChannelFlags,
 	)
+	if err != nil {
+		return err
+	}
+
+	// The upfront shutdown script is optional, so we'll only include it
+	// if one was specified.
+	if len(o.UpfrontShutdownScript) == 0 {
+		return nil
+	}
+
+	return writeElement(w, o.UpfrontShutdownScript)
 }
 
 // Decode deserializes the serialized OpenChannel stored in the passed
@@ -162,7 +180,7 @@ func (o *OpenChannel) Encode(w io.Writer, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (o *OpenChannel) Decode(r io.Reader, pver uint32) error {
-	return readElements(r,
+	err := readElements(r,
 		o.ChainHash[:],
 		o.PendingChannelID[:],
 		&o.FundingAmount,
@@ -182,6 +200,18 @@ func (o *OpenChannel) Decode(r io.Reader, pver uint32) error {
 		&o.FirstCommitmentPoint,
 		&o.ChannelFlags,
 	)
+	if err != nil {
+		return err
+	}
+
+	// The upfront shutdown script is optional, so we'll only attempt to
+	// read it if the message hasn't ended yet.
+	err = readElement(r, &o.UpfrontShutdownScript)
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
 }
 
 // MsgType returns the MessageType code which uniquely identifies this message
@@ -197,6 +227,6 @@ func (o *OpenChannel) MsgType() MessageType {
 //
 // This is part of the lnwire.Message interface.
 func (o *OpenChannel) MaxPayloadLength(uint32) uint32 {
-	// (32 * 2) + (8 * 6) + (4 * 1) + (2 * 2) + (33 * 6) + 1
-	return 319
+	// (32 * 2) + (8 * 6) + (4 * 1) + (2 * 2) + (33 * 6) + 1 + 2 + 34
+	return 355
 }
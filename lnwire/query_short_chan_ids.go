@@ -0,0 +1,338 @@
+package lnwire
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// ShortChanIDEncoding is an enum-like type that represents exactly how a set
+// of short channel ID's is encoded on the wire. The set of encodings allows
+// for compression of the set of ID's, which can greatly reduce the size of the
+// query and reply messages.
+type ShortChanIDEncoding uint8
+
+const (
+	// EncodingSortedPlain signals that the set of short channel ID's is
+	// encoded using the regular encoding, in a sorted order.
+	EncodingSortedPlain ShortChanIDEncoding = 0
+
+	// EncodingSortedZlib signals that the set of short channel ID's is
+	// encoded by first sorting the set of channel ID's, as then
+	// compressing them using zlib.
+	EncodingSortedZlib ShortChanIDEncoding = 1
+)
+
+const (
+	// maxZlibBufSize is the max number of bytes that we'll accept from a
+	// zlib decoding instance. We do this in order to limit the total
+	// amount of memory allocated during a decoding instance. This is the
+	// amount of memory needed to decode the largest possible set of plain
+	// encoded short channel ID's, as each ID is 8 bytes.
+	maxZlibBufSize = MaxMessagePayload * 8
+)
+
+// ErrUnknownShortChanIDEncoding is a parametrized error that indicates that
+// we came across an unknown short channel ID encoding, and therefore were
+// unable to continue parsing.
+func ErrUnknownShortChanIDEncoding(encoding ShortChanIDEncoding) error {
+	return fmt.Errorf("unknown short chan id encoding: %v", encoding)
+}
+
+// QueryShortChanIDs is a message that allows the sender to query a set of
+// channel announcement and channel update messages that correspond to the
+// set of encoded short channel ID's. The encoding of the short channel ID's
+// is detailed in the query message ensuring that the receiver knows how to
+// properly decode each encode short channel ID which may be encoded using a
+// compression format. The receiver should respond with a series of channel
+// announcement and channel updates, finally sending a ReplyShortChanIDsEnd
+// message.
+type QueryShortChanIDs struct {
+	// ChainHash denotes the target chain that we're querying for the
+	// channel ID's of.
+	ChainHash chainhash.Hash
+
+	// EncodingType is a signal to the receiver of the message that
+	// indicates exactly how the set of short channel ID's that follow have
+	// been encoded.
+	EncodingType ShortChanIDEncoding
+
+	// ShortChanIDs is a slice of decoded short channel ID's.
+	ShortChanIDs []ShortChannelID
+}
+
+// NewQueryShortChanIDs creates a new QueryShortChanIDs message.
+func NewQueryShortChanIDs(h chainhash.Hash, e ShortChanIDEncoding,
+	s []ShortChannelID) *QueryShortChanIDs {
+
+	return &QueryShortChanIDs{
+		ChainHash:    h,
+		EncodingType: e,
+		ShortChanIDs: s,
+	}
+}
+
+// A compile time check to ensure QueryShortChanIDs implements the
+// lnwire.Message interface.
+var _ Message = (*QueryShortChanIDs)(nil)
+
+// Decode deserializes a serialized QueryShortChanIDs message stored in the
+// passed io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) Decode(r io.Reader, pver uint32) error {
+	err := readElements(r, q.ChainHash[:])
+	if err != nil {
+		return err
+	}
+
+	q.EncodingType, q.ShortChanIDs, err = decodeShortChanIDs(r)
+
+	return err
+}
+
+// decodeShortChanIDs decodes a set of short channel ID's that have been
+// encoded. The first byte of the body details how the short chan ID's were
+// encoded. We'll use this type to govern exactly how we go about encoding the
+// set of short channel ID's.
+func decodeShortChanIDs(r io.Reader) (ShortChanIDEncoding, []ShortChannelID,
+	error) {
+
+	// First, we'll attempt to read the number of bytes in the body of the
+	// set of encoded short channel ID's.
+	var numBytesResp uint16
+	err := readElements(r, &numBytesResp)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if numBytesResp == 0 {
+		return 0, nil, fmt.Errorf("no encoding type specified")
+	}
+
+	queryBody := make([]byte, numBytesResp)
+	if _, err := io.ReadFull(r, queryBody); err != nil {
+		return 0, nil, err
+	}
+
+	// The first byte is the encoding type, so we'll extract that so we can
+	// continue our parsing.
+	encodingType := ShortChanIDEncoding(queryBody[0])
+
+	// Before continuing, we'll snip off the first byte of the query body
+	// as that was just the encoding type.
+	queryBody = queryBody[1:]
+
+	// Otherwise, depending on the encoding type, we'll decode the encode
+	// short channel ID's in a different manner.
+	switch encodingType {
+
+	// In this encoding, we'll simply read a sort array of encoded short
+	// channel ID's from the buffer.
+	case EncodingSortedPlain:
+		// If after extracting the encoding type, the number of
+		// remaining bytes isn't a whole multiple of the size of an
+		// encoded short channel ID (8 bytes), then we'll return a
+		// parsing error.
+		if len(queryBody)%8 != 0 {
+			return 0, nil, fmt.Errorf("whole number of short "+
+				"chan ID's cannot be encoded in len=%v",
+				len(queryBody))
+		}
+
+		shortChanIDs, err := readSortedShortChanIDs(
+			bytes.NewReader(queryBody), len(queryBody)/8,
+		)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		return encodingType, shortChanIDs, nil
+
+	// In this encoding, we'll use zlib to decode the compressed payload.
+	// However, we'll pay attention to ensure that we don't open our selves
+	// up to a memory exhaustion attack.
+	case EncodingSortedZlib:
+		// Before we start to decode, we'll create a limit reader over
+		// the current reader. This will ensure that we can control how
+		// much memory we're allocating during the decoding process.
+		zlibReader, err := zlib.NewReader(bytes.NewReader(queryBody))
+		if err != nil {
+			return 0, nil, fmt.Errorf("unable to create zlib "+
+				"reader: %v", err)
+		}
+		defer zlibReader.Close()
+
+		limitedDecompressor := &io.LimitedReader{
+			R: zlibReader,
+			N: maxZlibBufSize + 1,
+		}
+
+		var decompressed bytes.Buffer
+		if _, err := decompressed.ReadFrom(limitedDecompressor); err != nil {
+			return 0, nil, fmt.Errorf("unable to decompress "+
+				"short chan ID's: %v", err)
+		}
+
+		// If the decompressed payload exceeds our limit, then we'll
+		// bail out as the peer is attempting to make us allocate an
+		// excessive amount of memory.
+		if decompressed.Len() > maxZlibBufSize {
+			return 0, nil, fmt.Errorf("decompressed short chan "+
+				"ID's exceed max size of %v bytes",
+				maxZlibBufSize)
+		}
+
+		if decompressed.Len()%8 != 0 {
+			return 0, nil, fmt.Errorf("whole number of short "+
+				"chan ID's cannot be encoded in len=%v",
+				decompressed.Len())
+		}
+
+		shortChanIDs, err := readSortedShortChanIDs(
+			&decompressed, decompressed.Len()/8,
+		)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		return encodingType, shortChanIDs, nil
+
+	default:
+		// If we've been sent an encoding type that we don't know of,
+		// then we'll return a parsing error as we can't continue if
+		// we're unable to encode them.
+		return 0, nil, ErrUnknownShortChanIDEncoding(encodingType)
+	}
+}
+
+// readSortedShortChanIDs reads the given number of short channel ID's from the
+// passed reader, ensuring that they've been encoded in ascending order.
+func readSortedShortChanIDs(r io.Reader,
+	numShortChanIDs int) ([]ShortChannelID, error) {
+
+	shortChanIDs := make([]ShortChannelID, numShortChanIDs)
+	for i := 0; i < numShortChanIDs; i++ {
+		if err := readElements(r, &shortChanIDs[i]); err != nil {
+			return nil, fmt.Errorf("unable to parse short chan "+
+				"ID: %v", err)
+		}
+
+		// The set of short channel ID's must be sorted in ascending
+		// order, so we'll reject any set that violates this
+		// requirement.
+		if i > 0 && shortChanIDs[i].ToUint64() <=
+			shortChanIDs[i-1].ToUint64() {
+
+			return nil, fmt.Errorf("short chan ID's aren't " +
+				"sorted in ascending order")
+		}
+	}
+
+	return shortChanIDs, nil
+}
+
+// Encode serializes the target QueryShortChanIDs into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) Encode(w io.Writer, pver uint32) error {
+	// First, we'll write out the chain hash.
+	err := writeElements(w, q.ChainHash[:])
+	if err != nil {
+		return err
+	}
+
+	// Base on our encoding type, we'll write out the set of short channel
+	// ID's.
+	return encodeShortChanIDs(w, q.EncodingType, q.ShortChanIDs)
+}
+
+// encodeShortChanIDs encodes the passed short channel ID's into the passed
+// io.Writer, respecting the specified encoding type.
+func encodeShortChanIDs(w io.Writer, encodingType ShortChanIDEncoding,
+	shortChanIDs []ShortChannelID) error {
+
+	// For both of the current encoding types, the channel ID's are to be
+	// sorted in place, so we'll do that now.
+	sort.Slice(shortChanIDs, func(i, j int) bool {
+		return shortChanIDs[i].ToUint64() <
+			shortChanIDs[j].ToUint64()
+	})
+
+	// First, we'll serialize the body of the set of short channel ID's
+	// according to the encoding type. The body is then prefixed by its
+	// length and the encoding type itself.
+	var body bytes.Buffer
+	switch encodingType {
+
+	// In this encoding, we'll simply write a sorted array of encoded short
+	// channel ID's.
+	case EncodingSortedPlain:
+		for _, chanID := range shortChanIDs {
+			if err := writeElements(&body, chanID); err != nil {
+				return fmt.Errorf("unable to write short chan "+
+					"ID: %v", err)
+			}
+		}
+
+	// In this encoding, we'll compress the sorted array of encoded short
+	// channel ID's using zlib.
+	case EncodingSortedZlib:
+		zlibWriter := zlib.NewWriter(&body)
+		for _, chanID := range shortChanIDs {
+			if err := writeElements(zlibWriter, chanID); err != nil {
+				return fmt.Errorf("unable to write short chan "+
+					"ID: %v", err)
+			}
+		}
+
+		if err := zlibWriter.Close(); err != nil {
+			return fmt.Errorf("unable to finalize zlib "+
+				"compression: %v", err)
+		}
+
+	default:
+		// If we're trying to encode with an encoding type that we
+		// don't know of, then we'll return an error.
+		return ErrUnknownShortChanIDEncoding(encodingType)
+	}
+
+	// The length prefix covers the encoding type byte as well as the
+	// encoded body itself.
+	numBytesBody := body.Len() + 1
+	if numBytesBody > MaxSliceLength {
+		return fmt.Errorf("encoded short chan ID's of %v bytes "+
+			"exceed max length", numBytesBody)
+	}
+
+	err := writeElements(w, uint16(numBytesBody), uint8(encodingType))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(body.Bytes())
+
+	return err
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) MsgType() MessageType {
+	return MsgQueryShortChanIDs
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// QueryShortChanIDs complete message observing the specified protocol
+// version.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
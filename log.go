@@ -14,12 +14,16 @@ import (
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/discovery"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/sweep"
+	"github.com/lightningnetwork/lnd/watchtower"
+	"github.com/lightningnetwork/lnd/watchtower/wtclient"
 	"github.com/roasbeef/btcd/connmgr"
 )
 
@@ -74,6 +78,10 @@ var (
 	atplLog = backendLog.Logger("ATPL")
 	cnctLog = backendLog.Logger("CNCT")
 	sphxLog = backendLog.Logger("SPHX")
+	chbuLog = backendLog.Logger("CHBU")
+	wtwrLog = backendLog.Logger("WTWR")
+	wtclLog = backendLog.Logger("WTCL")
+	swprLog = backendLog.Logger("SWPR")
 )
 
 // Initialize package-global logger variables.
@@ -89,6 +97,10 @@ func init() {
 	autopilot.UseLogger(atplLog)
 	contractcourt.UseLogger(cnctLog)
 	sphinx.UseLogger(sphxLog)
+	chanbackup.UseLogger(chbuLog)
+	watchtower.UseLogger(wtwrLog)
+	wtclient.UseLogger(wtclLog)
+	sweep.UseLogger(swprLog)
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -111,6 +123,10 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"ATPL": atplLog,
 	"CNCT": cnctLog,
 	"SPHX": sphxLog,
+	"CHBU": chbuLog,
+	"WTWR": wtwrLog,
+	"WTCL": wtclLog,
+	"SWPR": swprLog,
 }
 
 // initLogRotator initializes the logging rotator to write logs to logFile and
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// metricsNamespace is the namespace under which all of lnd's metrics
+	// are exported.
+	metricsNamespace = "lnd"
+)
+
+var (
+	peersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "peers"),
+		"Number of currently connected peers.",
+		[]string{"direction"}, nil,
+	)
+
+	openChannelsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "channels", "open"),
+		"Number of open channels.",
+		nil, nil,
+	)
+
+	localBalanceDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(
+			metricsNamespace, "channels", "local_balance_msat",
+		),
+		"Sum of our settled balance across all open channels.",
+		nil, nil,
+	)
+
+	remoteBalanceDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(
+			metricsNamespace, "channels", "remote_balance_msat",
+		),
+		"Sum of the remote settled balance across all open channels.",
+		nil, nil,
+	)
+
+	forwardsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(
+			metricsNamespace, "htlcswitch", "forwards_total",
+		),
+		"Number of HTLCs successfully forwarded through the switch.",
+		nil, nil,
+	)
+
+	forwardFailuresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(
+			metricsNamespace, "htlcswitch", "failures_total",
+		),
+		"Number of HTLCs failed back to their sender, by onion error.",
+		[]string{"code"}, nil,
+	)
+
+	gossipMsgsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(
+			metricsNamespace, "gossip", "messages_received_total",
+		),
+		"Number of gossip messages received from remote peers, by "+
+			"message type.",
+		[]string{"type"}, nil,
+	)
+)
+
+// metricsExporter exposes the internal state of the daemon's sub-systems as
+// Prometheus metrics over HTTP. The values of most metrics are gathered from
+// the server at scrape time, while the latency of payment attempts is
+// observed by the router as each attempt completes.
+type metricsExporter struct {
+	started sync.Once
+	stopped sync.Once
+
+	server *server
+
+	listenAddr string
+
+	registry *prometheus.Registry
+
+	paymentAttempts *prometheus.HistogramVec
+
+	httpServer *http.Server
+
+	wg sync.WaitGroup
+}
+
+// A compile time check to ensure that metricsExporter implements the
+// prometheus.Collector interface.
+var _ prometheus.Collector = (*metricsExporter)(nil)
+
+// newMetricsExporter creates a new metrics exporter that will serve the
+// metrics of the passed server on the given address once started.
+func newMetricsExporter(s *server, listenAddr string) *metricsExporter {
+	m := &metricsExporter{
+		server:     s,
+		listenAddr: listenAddr,
+		registry:   prometheus.NewRegistry(),
+		paymentAttempts: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Subsystem: "router",
+				Name:      "payment_attempt_seconds",
+				Help: "Time taken by the switch to resolve " +
+					"payment attempts.",
+				Buckets: prometheus.ExponentialBuckets(
+					0.05, 2, 12,
+				),
+			},
+			[]string{"result"},
+		),
+	}
+
+	m.registry.MustRegister(m, m.paymentAttempts)
+
+	return m
+}
+
+// Start launches the HTTP server from which the metrics can be scraped.
+func (m *metricsExporter) Start() error {
+	var err error
+	m.started.Do(func() {
+		var listener net.Listener
+		listener, err = net.Listen("tcp", m.listenAddr)
+		if err != nil {
+			return
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(
+			m.registry, promhttp.HandlerOpts{},
+		))
+		m.httpServer = &http.Server{Handler: mux}
+
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+
+			err := m.httpServer.Serve(listener)
+			if err != nil && err != http.ErrServerClosed {
+				ltndLog.Errorf("Prometheus exporter failed: %v",
+					err)
+			}
+		}()
+
+		ltndLog.Infof("Prometheus exporter listening on %v",
+			listener.Addr())
+	})
+
+	return err
+}
+
+// Stop shuts down the HTTP server, and waits for it to exit.
+func (m *metricsExporter) Stop() {
+	m.stopped.Do(func() {
+		if m.httpServer == nil {
+			return
+		}
+
+		m.httpServer.Close()
+		m.wg.Wait()
+	})
+}
+
+// ObservePaymentAttempt records the latency of a single payment attempt along
+// with its outcome. It is meant to be used as the router's payment attempt
+// notification hook.
+func (m *metricsExporter) ObservePaymentAttempt(latency time.Duration,
+	success bool) {
+
+	result := "failure"
+	if success {
+		result = "success"
+	}
+
+	m.paymentAttempts.WithLabelValues(result).Observe(latency.Seconds())
+}
+
+// Describe sends the descriptors of all the metrics gathered at scrape time
+// to the passed channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (m *metricsExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- peersDesc
+	ch <- openChannelsDesc
+	ch <- localBalanceDesc
+	ch <- remoteBalanceDesc
+	ch <- forwardsDesc
+	ch <- forwardFailuresDesc
+	ch <- gossipMsgsDesc
+}
+
+// Collect gathers the current values of the metrics from the server's
+// sub-systems, and sends them to the passed channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (m *metricsExporter) Collect(ch chan<- prometheus.Metric) {
+	var numInbound, numOutbound float64
+	for _, p := range m.server.Peers() {
+		if p.inbound {
+			numInbound++
+		} else {
+			numOutbound++
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(
+		peersDesc, prometheus.GaugeValue, numInbound, "inbound",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		peersDesc, prometheus.GaugeValue, numOutbound, "outbound",
+	)
+
+	// If we're unable to fetch our channels, we'll skip the channel
+	// metrics for this scrape rather than report misleading values.
+	channels, err := m.server.chanDB.FetchAllChannels()
+	if err != nil {
+		ltndLog.Errorf("Unable to fetch channels for metrics: %v", err)
+	} else {
+		var localBalance, remoteBalance float64
+		for _, c := range channels {
+			localCommit := c.LocalCommitment
+			localBalance += float64(localCommit.LocalBalance)
+			remoteBalance += float64(localCommit.RemoteBalance)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			openChannelsDesc, prometheus.GaugeValue,
+			float64(len(channels)),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			localBalanceDesc, prometheus.GaugeValue, localBalance,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			remoteBalanceDesc, prometheus.GaugeValue, remoteBalance,
+		)
+	}
+
+	fwdStats := m.server.htlcSwitch.FwdStats()
+	ch <- prometheus.MustNewConstMetric(
+		forwardsDesc, prometheus.CounterValue,
+		float64(fwdStats.NumForwards),
+	)
+	for code, num := range fwdStats.NumFailures {
+		ch <- prometheus.MustNewConstMetric(
+			forwardFailuresDesc, prometheus.CounterValue,
+			float64(num), code.String(),
+		)
+	}
+
+	for msgType, num := range m.server.authGossiper.ReceivedMsgCounts() {
+		ch <- prometheus.MustNewConstMetric(
+			gossipMsgsDesc, prometheus.CounterValue, float64(num),
+			msgType.String(),
+		)
+	}
+}
@@ -127,6 +127,13 @@ type peer struct {
 	activeChanMtx  sync.RWMutex
 	activeChannels map[lnwire.ChannelID]*lnwallet.LightningChannel
 
+	// restoredChannels is the set of channels with this peer that have
+	// been restored from a static channel backup. No links are created
+	// for these channels. Instead, we'll only carry out the data loss
+	// recovery protocol in order to have the remote party force close
+	// them. This map is only written to when the peer is started.
+	restoredChannels map[lnwire.ChannelID]*channeldb.OpenChannel
+
 	// newChannels is used by the fundingManager to send fully opened
 	// channels to the source peer which handled the funding workflow.
 	newChannels chan *newChannelMsg
@@ -196,8 +203,9 @@ func newPeer(conn net.Conn, connReq *connmgr.ConnReq, server *server,
 		sendQueue:     make(chan outgoingMsg),
 		outgoingQueue: make(chan outgoingMsg),
 
-		activeChannels: make(map[lnwire.ChannelID]*lnwallet.LightningChannel),
-		newChannels:    make(chan *newChannelMsg, 1),
+		activeChannels:   make(map[lnwire.ChannelID]*lnwallet.LightningChannel),
+		restoredChannels: make(map[lnwire.ChannelID]*channeldb.OpenChannel),
+		newChannels:      make(chan *newChannelMsg, 1),
 
 		activeChanCloses:   make(map[lnwire.ChannelID]*channelCloser),
 		localCloseChanReqs: make(chan *htlcswitch.ChanClose),
@@ -284,7 +292,8 @@ func (p *peer) Start() error {
 	// goroutines required to operate them.
 	peerLog.Debugf("Loaded %v active channels from database with "+
 		"NodeKey(%x)", len(activeChans), p.PubKey())
-	if err := p.loadActiveChannels(activeChans); err != nil {
+	msgs, err := p.loadActiveChannels(activeChans)
+	if err != nil {
 		return fmt.Errorf("unable to load channels: %v", err)
 	}
 
@@ -295,19 +304,33 @@ func (p *peer) Start() error {
 	go p.channelManager()
 	go p.pingHandler()
 
+	// Now that the peer has started up, we send any channel sync messages
+	// that must be resent for restored channels.
+	for _, msg := range msgs {
+		if err := p.SendMessage(msg); err != nil {
+			return fmt.Errorf("unable to send channel sync "+
+				"message: %v", err)
+		}
+	}
+
 	return nil
 }
 
 // loadActiveChannels creates indexes within the peer for tracking all active
-// channels returned by the database.
-func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
+// channels returned by the database. It returns the set of channel
+// reestablishment messages that must be sent to the remote party for any
+// channels that have been restored from a static channel backup.
+func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) (
+	[]lnwire.Message, error) {
+
+	var msgs []lnwire.Message
 	for _, dbChan := range chans {
 		lnChan, err := lnwallet.NewLightningChannel(
 			p.server.cc.signer, p.server.witnessBeacon, dbChan,
 		)
 		if err != nil {
 			lnChan.Stop()
-			return err
+			return nil, err
 		}
 
 		chanPoint := &dbChan.FundingOutpoint
@@ -321,6 +344,27 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		peerLog.Infof("NodeKey(%x) loading ChannelPoint(%v)",
 			p.PubKey(), chanPoint)
 
+		// If the channel was restored from a static channel backup,
+		// then we won't create a link for it. Instead, we'll send the
+		// remote party our channel sync message, which should prompt
+		// them to force close the channel so we can sweep our funds.
+		if dbChan.IsRestored {
+			chanSync, err := lnChan.ChanSyncMsg()
+			if err != nil {
+				lnChan.Stop()
+				return nil, err
+			}
+
+			peerLog.Infof("ChannelPoint(%v) was restored from a "+
+				"backup, sending channel sync to initiate data "+
+				"loss recovery", chanPoint)
+
+			p.restoredChannels[chanID] = dbChan
+			msgs = append(msgs, chanSync)
+			lnChan.Stop()
+			continue
+		}
+
 		// Skip adding any permanently irreconcilable channels to the
 		// htlcswitch.
 		if dbChan.IsBorked {
@@ -342,12 +386,12 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		blockEpoch, err := p.server.cc.chainNotifier.RegisterBlockEpochNtfn()
 		if err != nil {
 			lnChan.Stop()
-			return err
+			return nil, err
 		}
 		_, currentHeight, err := p.server.cc.chainIO.GetBestBlock()
 		if err != nil {
 			lnChan.Stop()
-			return err
+			return nil, err
 		}
 
 		// Before we register this new link with the HTLC Switch, we'll
@@ -357,7 +401,7 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		info, p1, p2, err := graph.FetchChannelEdgesByOutpoint(chanPoint)
 		if err != nil && err != channeldb.ErrEdgeNotFound {
 			lnChan.Stop()
-			return err
+			return nil, err
 		}
 
 		// We'll filter out our policy from the directional channel
@@ -401,7 +445,7 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		)
 		if err != nil {
 			lnChan.Stop()
-			return err
+			return nil, err
 		}
 		linkCfg := htlcswitch.ChannelLinkConfig{
 			Peer:                  p,
@@ -409,23 +453,27 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 			ExtractErrorEncrypter: p.server.sphinx.ExtractErrorEncrypter,
 			GetLastChannelUpdate: createGetLastUpdate(p.server.chanRouter,
 				p.PubKey(), lnChan.ShortChanID()),
-			DebugHTLC:      cfg.DebugHTLC,
-			HodlHTLC:       cfg.HodlHTLC,
-			Registry:       p.server.invoices,
-			Switch:         p.server.htlcSwitch,
-			Circuits:       p.server.htlcSwitch.CircuitModifier(),
-			ForwardPackets: p.server.htlcSwitch.ForwardPackets,
-			FwrdingPolicy:  *forwardingPolicy,
-			FeeEstimator:   p.server.cc.feeEstimator,
-			BlockEpochs:    blockEpoch,
-			PreimageCache:  p.server.witnessBeacon,
-			ChainEvents:    chainEvents,
+			DebugHTLC:       cfg.DebugHTLC,
+			HodlHTLC:        cfg.HodlHTLC,
+			Registry:        p.server.invoices,
+			Switch:          p.server.htlcSwitch,
+			Circuits:        p.server.htlcSwitch.CircuitModifier(),
+			ForwardPackets:  p.server.htlcSwitch.ForwardPackets,
+			FwrdingPolicy:   *forwardingPolicy,
+			FeeEstimator:    p.server.cc.feeEstimator,
+			BlockEpochs:     blockEpoch,
+			HodlExpiryDelta: defaultBroadcastDelta,
+			PreimageCache:   p.server.witnessBeacon,
+			ChainEvents:     chainEvents,
 			UpdateContractSignals: func(signals *contractcourt.ContractSignals) error {
 				return p.server.chainArb.UpdateContractSignals(
 					*chanPoint, signals,
 				)
 			},
-			SyncStates: true,
+			ForceCloseChannel: p.forceCloseChannel,
+			NotifyHtlcFailure: p.server.htlcSwitch.RecordHtlcFailure,
+			HtlcNotifier:      p.server.htlcNotifier,
+			SyncStates:        true,
 			BatchTicker: htlcswitch.NewBatchTicker(
 				time.NewTicker(50 * time.Millisecond)),
 			FwdPkgGCTicker: htlcswitch.NewBatchTicker(
@@ -433,15 +481,61 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 			BatchSize:    10,
 			UnsafeReplay: cfg.UnsafeReplay,
 		}
+
+		// If a tower client is active, the link will back up every
+		// state revoked by the remote party.
+		if p.server.towerClient != nil {
+			linkCfg.TowerClient = p.server.towerClient
+		}
+
 		link := htlcswitch.NewChannelLink(linkCfg, lnChan,
 			uint32(currentHeight))
 
 		if err := p.server.htlcSwitch.AddLink(link); err != nil {
 			lnChan.Stop()
-			return err
+			return nil, err
 		}
 	}
 
+	return msgs, nil
+}
+
+// handleRestoredChanSync processes a channel reestablishment message sent by
+// the remote party for a channel that was restored from a static channel
+// backup. The commitment point within the message is stored within the
+// database, as it's required to sweep our output from the remote party's
+// commitment transaction.
+func (p *peer) handleRestoredChanSync(dbChan *channeldb.OpenChannel,
+	msg *lnwire.ChannelReestablish) {
+
+	chanPoint := dbChan.FundingOutpoint
+
+	if msg.LocalUnrevokedCommitPoint == nil {
+		peerLog.Errorf("ChannelPoint(%v) was restored, but remote "+
+			"party didn't send a commitment point", chanPoint)
+		return
+	}
+
+	peerLog.Infof("ChannelPoint(%v) was restored, storing commitment "+
+		"point received from NodeKey(%x)", chanPoint, p.PubKey())
+
+	if err := dbChan.MarkDataLoss(msg.LocalUnrevokedCommitPoint); err != nil {
+		peerLog.Errorf("unable to store commitment point for "+
+			"ChannelPoint(%v): %v", chanPoint, err)
+	}
+}
+
+// forceCloseChannel force closes the channel identified by the passed
+// channel point by broadcasting our latest commitment transaction.
+func (p *peer) forceCloseChannel(chanPoint wire.OutPoint) error {
+	closeTx, err := p.server.chainArb.ForceCloseContract(chanPoint)
+	if err != nil {
+		return err
+	}
+
+	peerLog.Infof("Force closed ChannelPoint(%v) with NodeKey(%x), "+
+		"closing_txid=%v", chanPoint, p.PubKey(), closeTx.TxHash())
+
 	return nil
 }
 
@@ -876,13 +970,28 @@ out:
 			isChanUpdate = true
 			targetChan = msg.ChanID
 		case *lnwire.ChannelReestablish:
+			// If this channel was restored from a static channel
+			// backup, then there's no link to deliver the message
+			// to. Instead, we'll store the commitment point sent
+			// by the remote party, as we'll need it to sweep our
+			// funds once they force close the channel.
+			if dbChan, ok := p.restoredChannels[msg.ChanID]; ok {
+				p.handleRestoredChanSync(dbChan, msg)
+				break
+			}
+
 			isChanUpdate = true
 			targetChan = msg.ChanID
 
 		case *lnwire.ChannelUpdate,
 			*lnwire.ChannelAnnouncement,
 			*lnwire.NodeAnnouncement,
-			*lnwire.AnnounceSignatures:
+			*lnwire.AnnounceSignatures,
+			*lnwire.GossipTimestampRange,
+			*lnwire.QueryShortChanIDs,
+			*lnwire.QueryChannelRange,
+			*lnwire.ReplyChannelRange,
+			*lnwire.ReplyShortChanIDsEnd:
 
 			discStream.AddMsg(msg)
 
@@ -1026,6 +1135,29 @@ func messageSummary(msg lnwire.Message) string {
 	case *lnwire.ChannelReestablish:
 		return fmt.Sprintf("next_local_height=%v, remote_tail_height=%v",
 			msg.NextLocalCommitHeight, msg.RemoteCommitTailHeight)
+
+	case *lnwire.ReplyShortChanIDsEnd:
+		return fmt.Sprintf("chain_hash=%v, complete=%v", msg.ChainHash,
+			msg.Complete)
+
+	case *lnwire.ReplyChannelRange:
+		return fmt.Sprintf("complete=%v, encoding=%v, num_chans=%v",
+			msg.Complete, msg.EncodingType, len(msg.ShortChanIDs))
+
+	case *lnwire.QueryShortChanIDs:
+		return fmt.Sprintf("chain_hash=%v, encoding=%v, num_chans=%v",
+			msg.ChainHash, msg.EncodingType, len(msg.ShortChanIDs))
+
+	case *lnwire.QueryChannelRange:
+		return fmt.Sprintf("chain_hash=%v, start_height=%v, "+
+			"num_blocks=%v", msg.ChainHash, msg.FirstBlockHeight,
+			msg.NumBlocks)
+
+	case *lnwire.GossipTimestampRange:
+		return fmt.Sprintf("chain_hash=%v, first_stamp=%v, "+
+			"stamp_range=%v", msg.ChainHash,
+			time.Unix(int64(msg.FirstTimestamp), 0),
+			msg.TimestampRange)
 	}
 
 	return ""
@@ -1296,6 +1428,23 @@ func (p *peer) genDeliveryScript() ([]byte, error) {
 	return txscript.PayToAddrScript(deliveryAddr)
 }
 
+// chooseDeliveryScript returns the script to which our funds should be paid
+// upon a cooperative close of the passed channel. If we committed to an
+// upfront shutdown script when the channel was opened, then we're bound to
+// it, otherwise a fresh script is generated.
+func (p *peer) chooseDeliveryScript(
+	channel *lnwallet.LightningChannel) ([]byte, error) {
+
+	if script := channel.State().LocalShutdownScript; len(script) > 0 {
+		peerLog.Infof("Using upfront shutdown script %x for "+
+			"ChannelPoint(%v)", script, channel.ChannelPoint())
+
+		return script, nil
+	}
+
+	return p.genDeliveryScript()
+}
+
 // channelManager is goroutine dedicated to handling all requests/signals
 // pertaining to the opening, cooperative closing, and force closing of all
 // channels maintained with the remote peer.
@@ -1385,23 +1534,27 @@ out:
 				ExtractErrorEncrypter: p.server.sphinx.ExtractErrorEncrypter,
 				GetLastChannelUpdate: createGetLastUpdate(p.server.chanRouter,
 					p.PubKey(), newChanReq.channel.ShortChanID()),
-				DebugHTLC:      cfg.DebugHTLC,
-				HodlHTLC:       cfg.HodlHTLC,
-				Registry:       p.server.invoices,
-				Switch:         p.server.htlcSwitch,
-				Circuits:       p.server.htlcSwitch.CircuitModifier(),
-				ForwardPackets: p.server.htlcSwitch.ForwardPackets,
-				FwrdingPolicy:  p.server.cc.routingPolicy,
-				FeeEstimator:   p.server.cc.feeEstimator,
-				BlockEpochs:    blockEpoch,
-				PreimageCache:  p.server.witnessBeacon,
-				ChainEvents:    chainEvents,
+				DebugHTLC:       cfg.DebugHTLC,
+				HodlHTLC:        cfg.HodlHTLC,
+				Registry:        p.server.invoices,
+				Switch:          p.server.htlcSwitch,
+				Circuits:        p.server.htlcSwitch.CircuitModifier(),
+				ForwardPackets:  p.server.htlcSwitch.ForwardPackets,
+				FwrdingPolicy:   p.server.cc.routingPolicy,
+				FeeEstimator:    p.server.cc.feeEstimator,
+				BlockEpochs:     blockEpoch,
+				HodlExpiryDelta: defaultBroadcastDelta,
+				PreimageCache:   p.server.witnessBeacon,
+				ChainEvents:     chainEvents,
 				UpdateContractSignals: func(signals *contractcourt.ContractSignals) error {
 					return p.server.chainArb.UpdateContractSignals(
 						*chanPoint, signals,
 					)
 				},
-				SyncStates: false,
+				ForceCloseChannel: p.forceCloseChannel,
+				NotifyHtlcFailure: p.server.htlcSwitch.RecordHtlcFailure,
+				HtlcNotifier:      p.server.htlcNotifier,
+				SyncStates:        false,
 				BatchTicker: htlcswitch.NewBatchTicker(
 					time.NewTicker(50 * time.Millisecond)),
 				FwdPkgGCTicker: htlcswitch.NewBatchTicker(
@@ -1409,6 +1562,13 @@ out:
 				BatchSize:    10,
 				UnsafeReplay: cfg.UnsafeReplay,
 			}
+
+			// If a tower client is active, the link will back up
+			// every state revoked by the remote party.
+			if p.server.towerClient != nil {
+				linkConfig.TowerClient = p.server.towerClient
+			}
+
 			link := htlcswitch.NewChannelLink(linkConfig, newChan,
 				uint32(currentHeight))
 
@@ -1535,7 +1695,7 @@ func (p *peer) fetchActiveChanCloser(chanID lnwire.ChannelID) (*channelCloser, e
 
 		// We'll create a valid closing state machine in order to
 		// respond to the initiated cooperative channel closure.
-		deliveryAddr, err := p.genDeliveryScript()
+		deliveryAddr, err := p.chooseDeliveryScript(channel)
 		if err != nil {
 			peerLog.Errorf("unable to gen delivery script: %v", err)
 
@@ -1619,10 +1779,11 @@ func (p *peer) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 	// out this channel on-chain, so we execute the cooperative channel
 	// closure workflow.
 	case htlcswitch.CloseRegular:
-		// First, we'll fetch a fresh delivery address that we'll use
-		// to send the funds to in the case of a successful
-		// negotiation.
-		deliveryAddr, err := p.genDeliveryScript()
+		// First, we'll fetch the delivery address that we'll use to
+		// send the funds to in the case of a successful negotiation.
+		// This will be our upfront shutdown script if we committed to
+		// one, otherwise a fresh address.
+		deliveryAddr, err := p.chooseDeliveryScript(channel)
 		if err != nil {
 			peerLog.Errorf(err.Error())
 			req.Err <- err
@@ -3,6 +3,7 @@
 package main
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -604,3 +605,188 @@ func TestPeerChannelClosureFeeNegotiationsInitiator(t *testing.T) {
 		t.Fatalf("closing tx not broadcast")
 	}
 }
+
+// TestPeerChannelClosureUpfrontShutdownScript tests that a shutdown message
+// paying out to a script other than the upfront shutdown script committed to
+// by the remote party is rejected, and that we respond with our own upfront
+// shutdown script if we committed to one.
+func TestPeerChannelClosureUpfrontShutdownScript(t *testing.T) {
+	t.Parallel()
+
+	notifier := &mockNotfier{
+		confChannel: make(chan *chainntnfs.TxConfirmation),
+	}
+	broadcastTxChan := make(chan *wire.MsgTx)
+
+	responder, responderChan, _, cleanUp, err := createTestPeer(
+		notifier, broadcastTxChan)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	chanID := lnwire.NewChanIDFromOutPoint(responderChan.ChannelPoint())
+
+	// We'll have both parties commit to an upfront shutdown script when
+	// the channel was opened.
+	localUpfrontScript := bobsPrivKey[:]
+	remoteUpfrontScript := dummyDeliveryScript
+	responderChan.State().LocalShutdownScript = localUpfrontScript
+	responderChan.State().RemoteShutdownScript = remoteUpfrontScript
+
+	// We'll first send a shutdown message to Alice that pays out to a
+	// script other than the one we committed to. She should reject it,
+	// without responding with a shutdown message of her own.
+	responder.chanCloseMsgs <- &closeMsg{
+		cid: chanID,
+		msg: lnwire.NewShutdown(chanID, localUpfrontScript),
+	}
+
+	select {
+	case outMsg := <-responder.outgoingQueue:
+		t.Fatalf("expected shutdown to be rejected, got %T",
+			outMsg.msg)
+	case <-time.After(time.Millisecond * 500):
+	}
+
+	// If we instead send a shutdown message paying out to our upfront
+	// shutdown script, then Alice should respond with a shutdown message
+	// paying out to her own upfront shutdown script.
+	responder.chanCloseMsgs <- &closeMsg{
+		cid: chanID,
+		msg: lnwire.NewShutdown(chanID, remoteUpfrontScript),
+	}
+
+	var msg lnwire.Message
+	select {
+	case outMsg := <-responder.outgoingQueue:
+		msg = outMsg.msg
+	case <-time.After(time.Second * 5):
+		t.Fatalf("did not receive shutdown message")
+	}
+
+	shutdownMsg, ok := msg.(*lnwire.Shutdown)
+	if !ok {
+		t.Fatalf("expected Shutdown message, got %T", msg)
+	}
+	if !bytes.Equal(shutdownMsg.Address, localUpfrontScript) {
+		t.Fatalf("expected delivery script %x, got %x",
+			localUpfrontScript, shutdownMsg.Address)
+	}
+}
+
+// TestPeerChannelClosureMaxFee tests that the shutdown initiator never
+// proposes a fee above the maximum fee specified within the close request,
+// and that the negotiation fails if the responder insists on a higher fee.
+func TestPeerChannelClosureMaxFee(t *testing.T) {
+	t.Parallel()
+
+	notifier := &mockNotfier{
+		confChannel: make(chan *chainntnfs.TxConfirmation),
+	}
+	broadcastTxChan := make(chan *wire.MsgTx)
+
+	initiator, initiatorChan, responderChan, cleanUp, err := createTestPeer(
+		notifier, broadcastTxChan)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// We make the initiator send a shutdown request, with a maximum fee
+	// below its target fee.
+	var maxFeePerKw lnwallet.SatPerKWeight = 6250
+	updateChan := make(chan *lnrpc.CloseStatusUpdate, 1)
+	errChan := make(chan error, 1)
+	closeCommand := &htlcswitch.ChanClose{
+		CloseType:      htlcswitch.CloseRegular,
+		ChanPoint:      initiatorChan.ChannelPoint(),
+		Updates:        updateChan,
+		TargetFeePerKw: 12500,
+		MaxFeePerKw:    maxFeePerKw,
+		Err:            errChan,
+	}
+
+	initiator.localCloseChanReqs <- closeCommand
+
+	// We should now be getting the shutdown request.
+	var msg lnwire.Message
+	select {
+	case outMsg := <-initiator.outgoingQueue:
+		msg = outMsg.msg
+	case <-time.After(time.Second * 5):
+		t.Fatalf("did not receive shutdown request")
+	}
+
+	shutdownMsg, ok := msg.(*lnwire.Shutdown)
+	if !ok {
+		t.Fatalf("expected Shutdown message, got %T", msg)
+	}
+
+	initiatorDeliveryScript := shutdownMsg.Address
+
+	// We'll answer the shutdown message with our own Shutdown, and then a
+	// ClosingSigned message with a fee above the initiator's maximum.
+	chanID := lnwire.NewChanIDFromOutPoint(initiatorChan.ChannelPoint())
+	respShutdown := lnwire.NewShutdown(chanID, dummyDeliveryScript)
+	initiator.chanCloseMsgs <- &closeMsg{
+		cid: chanID,
+		msg: respShutdown,
+	}
+
+	// The initiator should respond to our shutdown by proposing its
+	// maximum fee, as its ideal fee exceeds it.
+	maxFee := responderChan.CalcFee(maxFeePerKw)
+	select {
+	case outMsg := <-initiator.outgoingQueue:
+		msg = outMsg.msg
+	case <-time.After(time.Second * 5):
+		t.Fatalf("did not receive closing signed")
+	}
+	closingSignedMsg, ok := msg.(*lnwire.ClosingSigned)
+	if !ok {
+		t.Fatalf("expected ClosingSigned message, got %T", msg)
+	}
+	if closingSignedMsg.FeeSatoshis != maxFee {
+		t.Fatalf("expected ClosingSigned fee to be %v, instead got %v",
+			maxFee, closingSignedMsg.FeeSatoshis)
+	}
+
+	// We'll now insist on a fee above the initiator's maximum.
+	increasedFee := maxFee * 2
+	closeSig, _, _, err := responderChan.CreateCloseProposal(
+		increasedFee, dummyDeliveryScript, initiatorDeliveryScript,
+	)
+	if err != nil {
+		t.Fatalf("unable to create close proposal: %v", err)
+	}
+	parsedSig, err := lnwire.NewSigFromRawSignature(closeSig)
+	if err != nil {
+		t.Fatalf("unable to parse signature: %v", err)
+	}
+
+	closingSigned := lnwire.NewClosingSigned(
+		shutdownMsg.ChannelID, increasedFee, parsedSig,
+	)
+	initiator.chanCloseMsgs <- &closeMsg{
+		cid: chanID,
+		msg: closingSigned,
+	}
+
+	// As the initiator has already offered its maximum fee, the
+	// negotiation should fail, without any further proposals being sent.
+	select {
+	case err := <-errChan:
+		if err == nil {
+			t.Fatalf("expected close negotiation to fail")
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("close negotiation didn't fail")
+	}
+
+	select {
+	case outMsg := <-initiator.outgoingQueue:
+		t.Fatalf("expected no further messages, got %T", outMsg.msg)
+	default:
+	}
+}
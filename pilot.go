@@ -83,6 +83,14 @@ func (c *chanController) OpenChannel(target *btcec.PublicKey,
 		}
 	}
 
+	// Channels larger than the protocol's soft-limit may only be opened
+	// with peers that support them, so we'll cap the size of the channel
+	// to the maximum we're able to open with this peer.
+	maxChanSize := c.server.fundingMgr.maxChanSize(target)
+	if amt > maxChanSize {
+		amt = maxChanSize
+	}
+
 	// With the connection established, we'll now establish our connection
 	// to the target peer, waiting for the first update before we exit.
 	feePerVSize, err := c.server.cc.feeEstimator.EstimateFeePerVSize(3)
@@ -94,7 +102,7 @@ func (c *chanController) OpenChannel(target *btcec.PublicKey,
 	minHtlc := lnwire.NewMSatFromSatoshis(1)
 
 	updateStream, errChan := c.server.OpenChannel(target, amt, 0,
-		minHtlc, feePerVSize, false, 0)
+		minHtlc, feePerVSize, false, 0, nil, false)
 
 	select {
 	case err := <-errChan:
@@ -0,0 +1,442 @@
+// Package psbt implements the subset of BIP 174 partially signed bitcoin
+// transactions needed to have a transaction funded and signed by an external
+// wallet.
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// maxPsbtKeyValueSize is the maximum size of a single key or value
+	// within a PSBT that we'll read, guarding against allocating
+	// excessive amounts of memory for malformed packets.
+	maxPsbtKeyValueSize = 4000000
+
+	// The following key types are the ones understood by this package.
+	// Any other key-value pairs are retained as unknowns, so that they're
+	// preserved when the packet is serialized again.
+
+	// globalUnsignedTxType is the global key type of the unsigned
+	// transaction.
+	globalUnsignedTxType = 0x00
+
+	// inputFinalScriptSigType is the input key type of the finalized
+	// signature script of the input.
+	inputFinalScriptSigType = 0x07
+
+	// inputFinalScriptWitnessType is the input key type of the finalized
+	// witness of the input.
+	inputFinalScriptWitnessType = 0x08
+)
+
+var (
+	// psbtMagic is the separator used to identify a PSBT, "psbt" followed
+	// by 0xff.
+	psbtMagic = [5]byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+	// ErrInvalidMagic is returned when the packet doesn't start with the
+	// PSBT magic bytes.
+	ErrInvalidMagic = errors.New("invalid psbt magic bytes")
+
+	// ErrDuplicateKey is returned when a key appears more than once
+	// within the same map of a packet.
+	ErrDuplicateKey = errors.New("duplicate key within psbt map")
+
+	// ErrInvalidUnsignedTx is returned when the unsigned transaction of a
+	// packet is missing, or contains signature data.
+	ErrInvalidUnsignedTx = errors.New("invalid psbt unsigned transaction")
+
+	// ErrIncompletePsbt is returned when attempting to extract the final
+	// transaction of a packet which hasn't been finalized.
+	ErrIncompletePsbt = errors.New("psbt is not finalized")
+)
+
+// Unknown is a key-value pair within a PSBT map which isn't understood by
+// this package. Unknowns are retained so that a packet can be passed through
+// without losing any information.
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// PInput holds the fields of a PSBT input map understood by this package.
+type PInput struct {
+	// FinalScriptSig is the finalized signature script of the input, if
+	// any.
+	FinalScriptSig []byte
+
+	// FinalScriptWitness is the finalized witness of the input, if any.
+	FinalScriptWitness wire.TxWitness
+
+	// Unknowns holds all other key-value pairs of the input.
+	Unknowns []*Unknown
+}
+
+// isFinalized returns true if the input has either a finalized signature
+// script, or a finalized witness.
+func (p *PInput) isFinalized() bool {
+	return len(p.FinalScriptSig) != 0 || len(p.FinalScriptWitness) != 0
+}
+
+// POutput holds the fields of a PSBT output map. As none of the output fields
+// are needed by this package, they're all retained as unknowns.
+type POutput struct {
+	Unknowns []*Unknown
+}
+
+// Packet is a partially signed bitcoin transaction, as defined by BIP 174.
+// Only the fields needed to pass a transaction template to an external
+// wallet, and to extract the final transaction once it has been signed, are
+// parsed. All other fields are retained as is.
+type Packet struct {
+	// UnsignedTx is the transaction being signed, stripped of any
+	// signature scripts and witnesses.
+	UnsignedTx *wire.MsgTx
+
+	// Inputs holds an input map for each input of the unsigned
+	// transaction.
+	Inputs []PInput
+
+	// Outputs holds an output map for each output of the unsigned
+	// transaction.
+	Outputs []POutput
+
+	// Unknowns holds all global key-value pairs other than the unsigned
+	// transaction.
+	Unknowns []*Unknown
+}
+
+// NewFromUnsignedTx creates a new packet for the passed transaction, which
+// must not contain any signature data.
+func NewFromUnsignedTx(tx *wire.MsgTx) (*Packet, error) {
+	if !isUnsigned(tx) {
+		return nil, ErrInvalidUnsignedTx
+	}
+
+	return &Packet{
+		UnsignedTx: tx,
+		Inputs:     make([]PInput, len(tx.TxIn)),
+		Outputs:    make([]POutput, len(tx.TxOut)),
+	}, nil
+}
+
+// isUnsigned returns true if none of the inputs of the passed transaction
+// have a signature script or witness.
+func isUnsigned(tx *wire.MsgTx) bool {
+	for _, txIn := range tx.TxIn {
+		if len(txIn.SignatureScript) != 0 || len(txIn.Witness) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// kvPair is a single raw key-value pair of a PSBT map.
+type kvPair struct {
+	key   []byte
+	value []byte
+}
+
+// readMap reads the key-value pairs of a single PSBT map up to, and
+// including, its separator.
+func readMap(r io.Reader) ([]kvPair, error) {
+	var (
+		pairs []kvPair
+		seen  = make(map[string]struct{})
+	)
+	for {
+		key, err := wire.ReadVarBytes(
+			r, 0, maxPsbtKeyValueSize, "psbt key",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// A zero length key denotes the end of the map.
+		if len(key) == 0 {
+			return pairs, nil
+		}
+
+		if _, ok := seen[string(key)]; ok {
+			return nil, ErrDuplicateKey
+		}
+		seen[string(key)] = struct{}{}
+
+		value, err := wire.ReadVarBytes(
+			r, 0, maxPsbtKeyValueSize, "psbt value",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, kvPair{key: key, value: value})
+	}
+}
+
+// writePair writes a single key-value pair of a PSBT map.
+func writePair(w io.Writer, key, value []byte) error {
+	if err := wire.WriteVarBytes(w, 0, key); err != nil {
+		return err
+	}
+	return wire.WriteVarBytes(w, 0, value)
+}
+
+// writeUnknowns writes the passed unknown key-value pairs, followed by the
+// separator of the map they belong to.
+func writeUnknowns(w io.Writer, unknowns []*Unknown) error {
+	for _, unknown := range unknowns {
+		if err := writePair(w, unknown.Key, unknown.Value); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{0x00})
+	return err
+}
+
+// readWitness decodes a finalized witness, which is serialized as the number
+// of witness items followed by each length prefixed item.
+func readWitness(value []byte) (wire.TxWitness, error) {
+	r := bytes.NewReader(value)
+	numItems, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if numItems > uint64(len(value)) {
+		return nil, fmt.Errorf("invalid number of witness items: %v",
+			numItems)
+	}
+
+	witness := make(wire.TxWitness, 0, numItems)
+	for i := uint64(0); i < numItems; i++ {
+		item, err := wire.ReadVarBytes(
+			r, 0, maxPsbtKeyValueSize, "witness item",
+		)
+		if err != nil {
+			return nil, err
+		}
+		witness = append(witness, item)
+	}
+
+	return witness, nil
+}
+
+// writeWitness encodes a finalized witness in the same manner as
+// readWitness.
+func writeWitness(witness wire.TxWitness) ([]byte, error) {
+	var b bytes.Buffer
+	if err := wire.WriteVarInt(&b, 0, uint64(len(witness))); err != nil {
+		return nil, err
+	}
+	for _, item := range witness {
+		if err := wire.WriteVarBytes(&b, 0, item); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// NewFromRawBytes parses a packet from its binary serialization. If b64 is
+// true, then the serialization is expected to be base64 encoded.
+func NewFromRawBytes(r io.Reader, b64 bool) (*Packet, error) {
+	if b64 {
+		r = base64.NewDecoder(base64.StdEncoding, r)
+	}
+
+	var magic [5]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, err
+	}
+	if magic != psbtMagic {
+		return nil, ErrInvalidMagic
+	}
+
+	// First, we'll parse the global map, which must contain the unsigned
+	// transaction.
+	globals, err := readMap(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Packet{}
+	for _, pair := range globals {
+		if len(pair.key) == 1 && pair.key[0] == globalUnsignedTxType {
+			// As the unsigned transaction may not have any
+			// inputs, it must be decoded without witness data, as
+			// the input count would otherwise be mistaken for the
+			// segwit marker.
+			tx := wire.NewMsgTx(1)
+			err := tx.DeserializeNoWitness(bytes.NewReader(pair.value))
+			if err != nil {
+				return nil, err
+			}
+			p.UnsignedTx = tx
+			continue
+		}
+
+		p.Unknowns = append(p.Unknowns, &Unknown{
+			Key:   pair.key,
+			Value: pair.value,
+		})
+	}
+	if p.UnsignedTx == nil || !isUnsigned(p.UnsignedTx) {
+		return nil, ErrInvalidUnsignedTx
+	}
+
+	// Next, we'll parse a map for each of the inputs, followed by a map
+	// for each of the outputs of the unsigned transaction.
+	p.Inputs = make([]PInput, len(p.UnsignedTx.TxIn))
+	for i := range p.Inputs {
+		pairs, err := readMap(r)
+		if err != nil {
+			return nil, err
+		}
+
+		input := &p.Inputs[i]
+		for _, pair := range pairs {
+			switch {
+			case len(pair.key) == 1 &&
+				pair.key[0] == inputFinalScriptSigType:
+
+				input.FinalScriptSig = pair.value
+
+			case len(pair.key) == 1 &&
+				pair.key[0] == inputFinalScriptWitnessType:
+
+				witness, err := readWitness(pair.value)
+				if err != nil {
+					return nil, err
+				}
+				input.FinalScriptWitness = witness
+
+			default:
+				input.Unknowns = append(input.Unknowns, &Unknown{
+					Key:   pair.key,
+					Value: pair.value,
+				})
+			}
+		}
+	}
+
+	p.Outputs = make([]POutput, len(p.UnsignedTx.TxOut))
+	for i := range p.Outputs {
+		pairs, err := readMap(r)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pair := range pairs {
+			p.Outputs[i].Unknowns = append(
+				p.Outputs[i].Unknowns, &Unknown{
+					Key:   pair.key,
+					Value: pair.value,
+				},
+			)
+		}
+	}
+
+	return p, nil
+}
+
+// Serialize writes the binary serialization of the packet to the passed
+// writer.
+func (p *Packet) Serialize(w io.Writer) error {
+	if _, err := w.Write(psbtMagic[:]); err != nil {
+		return err
+	}
+
+	var tx bytes.Buffer
+	if err := p.UnsignedTx.SerializeNoWitness(&tx); err != nil {
+		return err
+	}
+	err := writePair(w, []byte{globalUnsignedTxType}, tx.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := writeUnknowns(w, p.Unknowns); err != nil {
+		return err
+	}
+
+	for _, input := range p.Inputs {
+		if len(input.FinalScriptSig) != 0 {
+			err := writePair(
+				w, []byte{inputFinalScriptSigType},
+				input.FinalScriptSig,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(input.FinalScriptWitness) != 0 {
+			witness, err := writeWitness(input.FinalScriptWitness)
+			if err != nil {
+				return err
+			}
+
+			err = writePair(
+				w, []byte{inputFinalScriptWitnessType}, witness,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := writeUnknowns(w, input.Unknowns); err != nil {
+			return err
+		}
+	}
+
+	for _, output := range p.Outputs {
+		if err := writeUnknowns(w, output.Unknowns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// B64Encode returns the base64 encoding of the packet's binary serialization.
+func (p *Packet) B64Encode() (string, error) {
+	var b bytes.Buffer
+	if err := p.Serialize(&b); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+// IsComplete returns true if every input of the packet has been finalized.
+func (p *Packet) IsComplete() bool {
+	for i := range p.Inputs {
+		if !p.Inputs[i].isFinalized() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Extract returns the fully signed transaction of a finalized packet.
+func Extract(p *Packet) (*wire.MsgTx, error) {
+	if !p.IsComplete() {
+		return nil, ErrIncompletePsbt
+	}
+
+	tx := p.UnsignedTx.Copy()
+	for i, txIn := range tx.TxIn {
+		txIn.SignatureScript = p.Inputs[i].FinalScriptSig
+		txIn.Witness = p.Inputs[i].FinalScriptWitness
+	}
+
+	return tx, nil
+}
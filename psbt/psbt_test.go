@@ -0,0 +1,159 @@
+package psbt
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestPacketTemplateRoundTrip tests that a packet without any inputs, as used
+// to hand a funding output to an external wallet, survives a round trip
+// through its base64 encoding.
+func TestPacketTemplateRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxOut(&wire.TxOut{
+		Value:    500000,
+		PkScript: bytes.Repeat([]byte{0x01}, 34),
+	})
+
+	packet, err := NewFromUnsignedTx(tx)
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+
+	b64, err := packet.B64Encode()
+	if err != nil {
+		t.Fatalf("unable to encode packet: %v", err)
+	}
+
+	decoded, err := NewFromRawBytes(bytes.NewReader([]byte(b64)), true)
+	if err != nil {
+		t.Fatalf("unable to decode packet: %v", err)
+	}
+
+	if decoded.UnsignedTx.TxHash() != tx.TxHash() {
+		t.Fatalf("expected unsigned tx %v, got %v", tx.TxHash(),
+			decoded.UnsignedTx.TxHash())
+	}
+	if len(decoded.Inputs) != 0 || len(decoded.Outputs) != 1 {
+		t.Fatalf("expected 0 inputs and 1 output, got %v and %v",
+			len(decoded.Inputs), len(decoded.Outputs))
+	}
+
+	// A packet without any inputs is trivially complete.
+	if !decoded.IsComplete() {
+		t.Fatalf("expected packet without inputs to be complete")
+	}
+}
+
+// TestPacketExtract tests that the final transaction can only be extracted
+// from a packet once all of its inputs have been finalized, and that unknown
+// key-value pairs are retained.
+func TestPacketExtract(t *testing.T) {
+	t.Parallel()
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash:  chainhash.Hash{0x01},
+			Index: 1,
+		},
+	})
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash:  chainhash.Hash{0x02},
+			Index: 0,
+		},
+	})
+	tx.AddTxOut(&wire.TxOut{
+		Value:    500000,
+		PkScript: bytes.Repeat([]byte{0x01}, 34),
+	})
+
+	packet, err := NewFromUnsignedTx(tx)
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+
+	// Only finalize the first input, and add an unknown pair to the
+	// second, which would typically hold the data needed to sign it.
+	witness := wire.TxWitness{{0x30, 0x44}, {0x02, 0x03}}
+	packet.Inputs[0].FinalScriptWitness = witness
+	unknown := &Unknown{Key: []byte{0x01}, Value: []byte{0xaa, 0xbb}}
+	packet.Inputs[1].Unknowns = []*Unknown{unknown}
+
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize packet: %v", err)
+	}
+	packet, err = NewFromRawBytes(&b, false)
+	if err != nil {
+		t.Fatalf("unable to parse packet: %v", err)
+	}
+
+	if !reflect.DeepEqual(packet.Inputs[1].Unknowns, []*Unknown{unknown}) {
+		t.Fatalf("unknown pair wasn't retained: %v",
+			packet.Inputs[1].Unknowns)
+	}
+
+	if _, err := Extract(packet); err != ErrIncompletePsbt {
+		t.Fatalf("expected ErrIncompletePsbt, got %v", err)
+	}
+
+	// Once the second input is finalized as well, we should be able to
+	// extract the signed transaction.
+	sigScript := []byte{0x16, 0x00, 0x14}
+	packet.Inputs[1].FinalScriptSig = sigScript
+	packet.Inputs[1].FinalScriptWitness = witness
+
+	finalTx, err := Extract(packet)
+	if err != nil {
+		t.Fatalf("unable to extract tx: %v", err)
+	}
+
+	// The signature script of the second input commits to the txid, so
+	// we'll account for it when comparing.
+	expectedTx := tx.Copy()
+	expectedTx.TxIn[1].SignatureScript = sigScript
+	if finalTx.TxHash() != expectedTx.TxHash() {
+		t.Fatalf("expected txid %v, got %v", expectedTx.TxHash(),
+			finalTx.TxHash())
+	}
+	if !reflect.DeepEqual(finalTx.TxIn[0].Witness, witness) {
+		t.Fatalf("expected witness %v, got %v", witness,
+			finalTx.TxIn[0].Witness)
+	}
+	if !bytes.Equal(finalTx.TxIn[1].SignatureScript, sigScript) {
+		t.Fatalf("expected sig script %x, got %x", sigScript,
+			finalTx.TxIn[1].SignatureScript)
+	}
+}
+
+// TestPacketInvalid tests that malformed packets are rejected.
+func TestPacketInvalid(t *testing.T) {
+	t.Parallel()
+
+	// A packet must start with the magic bytes.
+	_, err := NewFromRawBytes(bytes.NewReader([]byte("psbx\xff\x00")), false)
+	if err != ErrInvalidMagic {
+		t.Fatalf("expected ErrInvalidMagic, got %v", err)
+	}
+
+	// A packet must contain an unsigned transaction.
+	_, err = NewFromRawBytes(bytes.NewReader([]byte("psbt\xff\x00")), false)
+	if err != ErrInvalidUnsignedTx {
+		t.Fatalf("expected ErrInvalidUnsignedTx, got %v", err)
+	}
+
+	// A transaction with signature data can't be used to create a packet.
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{SignatureScript: []byte{0x01}})
+	if _, err := NewFromUnsignedTx(tx); err != ErrInvalidUnsignedTx {
+		t.Fatalf("expected ErrInvalidUnsignedTx, got %v", err)
+	}
+}
@@ -42,6 +42,10 @@ const (
 	// ErrPaymentAttemptTimeout is an error that indicates that a payment
 	// attempt timed out before we were able to successfully route an HTLC.
 	ErrPaymentAttemptTimeout
+
+	// ErrFeeLimitExceeded is returned when no route to the destination of
+	// a payment can be found without exceeding the payment's fee limit.
+	ErrFeeLimitExceeded
 )
 
 // routerError is a structure that represent the error inside the routing package,
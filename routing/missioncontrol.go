@@ -1,209 +1,534 @@
 package routing
 
 import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
 )
 
 const (
-	// vertexDecay is the decay period of colored vertexes added to
-	// missionControl. Once vertexDecay passes after an entry has been
-	// added to the prune view, it is garbage collected. This value is
-	// larger than edgeDecay as an edge failure typical indicates an
-	// unbalanced channel, while a vertex failure indicates a node is not
-	// online and active.
-	vertexDecay = time.Duration(time.Minute * 5)
-
-	// edgeDecay is the decay period of colored edges added to
-	// missionControl. Once edgeDecay passed after an entry has been added,
-	// it is garbage collected. This value is smaller than vertexDecay as
-	// an edge related failure during payment sending typically indicates
-	// that a channel was unbalanced, a condition which may quickly change.
-	//
-	// TODO(roasbeef): instead use random delay on each?
-	edgeDecay = time.Duration(time.Second * 5)
+	// DefaultAprioriHopProbability is the default probability of success
+	// assumed for a hop that we haven't attempted to route through yet.
+	DefaultAprioriHopProbability = 0.6
+
+	// DefaultPenaltyHalfLife is the default time after which half of the
+	// penalty applied to a hop after a failure has decayed.
+	DefaultPenaltyHalfLife = time.Hour
+
+	// DefaultAttemptCost is the default virtual cost of a payment attempt,
+	// which is weighed against the fees of a route during path finding.
+	DefaultAttemptCost = lnwire.MilliSatoshi(100000)
+
+	// prevSuccessProbability is the probability of success assumed for a
+	// hop that recently carried an HTLC of at least the amount in
+	// question. Over time, it decays back to the a priori probability.
+	prevSuccessProbability = 0.95
+
+	// minHopProbability is the probability of success below which a hop
+	// isn't considered during path finding.
+	minHopProbability = 0.01
 )
 
+// MissionControlStore persists the outcomes of past payment attempts
+// recorded by mission control, such that they aren't lost across restarts.
+type MissionControlStore interface {
+	// PutMissionControlPairs stores the passed node pair results,
+	// replacing any results previously stored for the same pairs.
+	PutMissionControlPairs(pairs ...*channeldb.MissionControlPair) error
+
+	// FetchMissionControlPairs returns all stored node pair results.
+	FetchMissionControlPairs() ([]*channeldb.MissionControlPair, error)
+
+	// ResetMissionControl deletes all stored node pair results.
+	ResetMissionControl() error
+}
+
+// MissionControlConfig holds the parameters of the probability model used by
+// mission control.
+type MissionControlConfig struct {
+	// AprioriHopProbability is the probability of success assumed for a
+	// hop that we haven't attempted to route through yet. If zero,
+	// DefaultAprioriHopProbability is used.
+	AprioriHopProbability float64
+
+	// PenaltyHalfLife is the time after which half of the penalty
+	// applied to a hop after a failure has decayed. If zero,
+	// DefaultPenaltyHalfLife is used.
+	PenaltyHalfLife time.Duration
+
+	// AttemptCost is the virtual cost of a payment attempt. During path
+	// finding, it is added to the fee of each hop, scaled by the inverse
+	// of the hop's probability of success, such that routes that are more
+	// likely to succeed are favored over cheaper ones.
+	AttemptCost lnwire.MilliSatoshi
+}
+
+// nodePair is a directed pair of nodes, between which an HTLC is forwarded.
+type nodePair struct {
+	from Vertex
+	to   Vertex
+}
+
 // missionControl contains state which summarizes the past attempts of HTLC
 // routing by external callers when sending payments throughout the network.
 // missionControl remembers the outcome of these past routing attempts (success
 // and failure), and is able to provide hints/guidance to future HTLC routing
-// attempts.  missionControl maintains a decaying network view of the
-// edges/vertexes that should be marked as "pruned" during path finding. This
-// graph view acts as a shared memory during HTLC payment routing attempts.
-// With each execution, if an error is encountered, based on the type of error
-// and the location of the error within the route, an edge or vertex is added
-// to the view. Later sending attempts will then query the view for all the
-// vertexes/edges that should be ignored. Items in the view decay after a set
-// period of time, allowing the view to be dynamic w.r.t network changes.
+// attempts. For each directed pair of nodes, the time and amount of the last
+// failed and successful attempts to forward an HTLC between them are
+// recorded. From these results, missionControl estimates the probability that
+// a new HTLC of a given amount is successfully forwarded. The penalty applied
+// after a failure decays over time, allowing the view to be dynamic w.r.t
+// network changes. The results are persisted, such that they survive
+// restarts.
 type missionControl struct {
-	// failedEdges maps a short channel ID to be pruned, to the time that
-	// it was added to the prune view. Edges are added to this map if a
-	// caller reports to missionControl a failure localized to that edge
-	// when sending a payment.
-	failedEdges map[uint64]time.Time
-
-	// failedVertexes maps a node's public key that should be pruned, to
-	// the time that it was added to the prune view. Vertexes are added to
-	// this map if a caller reports to missionControl a failure localized
-	// to that particular vertex.
-	failedVertexes map[Vertex]time.Time
+	// results maps each node pair that we attempted to route through to
+	// the outcomes of those attempts.
+	results map[nodePair]*channeldb.MissionControlPair
+
+	cfg MissionControlConfig
+
+	store MissionControlStore
 
 	graph *channeldb.ChannelGraph
 
 	selfNode *channeldb.LightningNode
 
+	// now returns the current time. It is used to timestamp results, and
+	// to compute the decay of penalties.
+	now func() time.Time
+
 	sync.Mutex
+}
+
+// newMissionControl returns a new instance of missionControl, populated with
+// the results previously persisted in the passed store.
+func newMissionControl(g *channeldb.ChannelGraph,
+	selfNode *channeldb.LightningNode, store MissionControlStore,
+	cfg MissionControlConfig) (*missionControl, error) {
+
+	if cfg.AprioriHopProbability == 0 {
+		cfg.AprioriHopProbability = DefaultAprioriHopProbability
+	}
+	if cfg.PenaltyHalfLife == 0 {
+		cfg.PenaltyHalfLife = DefaultPenaltyHalfLife
+	}
+
+	pairs, err := store.FetchMissionControlPairs()
+	if err != nil {
+		return nil, err
+	}
 
-	// TODO(roasbeef): further counters, if vertex continually unavailable,
-	// add to another generation
+	results := make(map[nodePair]*channeldb.MissionControlPair)
+	for _, pair := range pairs {
+		results[nodePair{from: pair.From, to: pair.To}] = pair
+	}
+
+	log.Debugf("Mission Control loaded results of %v node pairs",
+		len(results))
 
-	// TODO(roasbeef): also add favorable metrics for nodes
+	return &missionControl{
+		results:  results,
+		cfg:      cfg,
+		store:    store,
+		graph:    g,
+		selfNode: selfNode,
+		now:      time.Now,
+	}, nil
 }
 
-// newMissionControl returns a new instance of missionControl.
+// decayFactor returns the fraction of a penalty or bonus that remains after
+// the time elapsed since the passed time.
+func (m *missionControl) decayFactor(t time.Time) float64 {
+	elapsed := m.now().Sub(t)
+	if elapsed < 0 {
+		return 1
+	}
+
+	halfLives := float64(elapsed) / float64(m.cfg.PenaltyHalfLife)
+	return math.Pow(2, -halfLives)
+}
+
+// getProbability returns the estimated probability that an HTLC of the passed
+// amount is successfully forwarded from one node to the other. Without any
+// results, the a priori probability is returned. If the pair recently carried
+// at least the amount, the probability is raised, while a recent failure for
+// at most the amount lowers the probability down to zero. Both effects decay
+// over time.
 //
-// TODO(roasbeef): persist memory
-func newMissionControl(g *channeldb.ChannelGraph,
-	selfNode *channeldb.LightningNode) *missionControl {
+// NOTE: This function is safe for concurrent access.
+func (m *missionControl) getProbability(from, to Vertex,
+	amt lnwire.MilliSatoshi) float64 {
 
-	return &missionControl{
-		failedEdges:    make(map[uint64]time.Time),
-		failedVertexes: make(map[Vertex]time.Time),
-		selfNode:       selfNode,
-		graph:          g,
+	m.Lock()
+	defer m.Unlock()
+
+	apriori := m.cfg.AprioriHopProbability
+
+	result, ok := m.results[nodePair{from: from, to: to}]
+	if !ok {
+		return apriori
+	}
+
+	switch {
+	case !result.SuccessTime.IsZero() && amt <= result.SuccessAmt:
+		bonus := prevSuccessProbability - apriori
+		return apriori + bonus*m.decayFactor(result.SuccessTime)
+
+	case !result.FailTime.IsZero() && amt >= result.FailAmt:
+		return apriori * (1 - m.decayFactor(result.FailTime))
+
+	default:
+		return apriori
+	}
+}
+
+// recordSuccess records that the pair carried an HTLC of the passed amount at
+// the given time. Any earlier failure for at most this amount is cleared, as
+// it no longer reflects the state of the pair.
+func recordSuccess(result *channeldb.MissionControlPair, t time.Time,
+	amt lnwire.MilliSatoshi) {
+
+	result.SuccessTime = t
+	result.SuccessAmt = amt
+
+	if amt >= result.FailAmt {
+		result.FailTime = time.Time{}
+		result.FailAmt = 0
 	}
 }
 
-// graphPruneView is a filter of sorts that path finding routines should
-// consult during the execution. Any edges or vertexes within the view should
-// be ignored during path finding. The contents of the view reflect the current
-// state of the wider network from the PoV of mission control compiled via HTLC
-// routing attempts in the past.
-type graphPruneView struct {
-	edges map[uint64]struct{}
+// recordFailure records that the pair failed to carry an HTLC of the passed
+// amount at the given time. A zero amount denotes a failure for any amount.
+// Any earlier success for at least this amount is cleared, as it no longer
+// reflects the state of the pair.
+func recordFailure(result *channeldb.MissionControlPair, t time.Time,
+	amt lnwire.MilliSatoshi) {
+
+	result.FailTime = t
+	result.FailAmt = amt
 
-	vertexes map[Vertex]struct{}
+	if amt <= result.SuccessAmt {
+		result.SuccessTime = time.Time{}
+		result.SuccessAmt = 0
+	}
 }
 
-// GraphPruneView returns a new graphPruneView instance which is to be
-// consulted during path finding. If a vertex/edge is found within the returned
-// prune view, it is to be ignored as a goroutine has had issues routing
-// through it successfully. Within this method the main view of the
-// missionControl is garbage collected as entries are detected to be "stale".
-func (m *missionControl) GraphPruneView() graphPruneView {
-	// First, we'll grab the current time, this value will be used to
-	// determine if an entry is stale or not.
-	now := time.Now()
+// pairResult returns the results of the passed node pair, creating an empty
+// entry if none exist yet.
+//
+// NOTE: The mission control mutex MUST be held when calling this function.
+func (m *missionControl) pairResult(from,
+	to Vertex) *channeldb.MissionControlPair {
+
+	pair := nodePair{from: from, to: to}
+	result, ok := m.results[pair]
+	if !ok {
+		result = &channeldb.MissionControlPair{From: from, To: to}
+		m.results[pair] = result
+	}
+
+	return result
+}
+
+// recordRoute records the outcome of a payment attempt along the passed
+// route. All hops preceding failedHop are recorded as having successfully
+// carried the HTLC, and the hop at failedHop as having failed it. If
+// amtRelated is false, the failure is recorded to apply to any amount. A
+// failedHop beyond the last hop records a successful attempt.
+func (m *missionControl) recordRoute(rt *Route, failedHop int,
+	amtRelated bool) {
+
+	now := m.now()
 
 	m.Lock()
+	defer m.Unlock()
 
-	// For each of the vertexes that have been added to the prune view, if
-	// it is now "stale", then we'll ignore it and avoid adding it to the
-	// view we'll return.
-	vertexes := make(map[Vertex]struct{})
-	for vertex, pruneTime := range m.failedVertexes {
-		if now.Sub(pruneTime) >= vertexDecay {
-			log.Tracef("Pruning decayed failure report for vertex %v "+
-				"from Mission Control", vertex)
-
-			delete(m.failedVertexes, vertex)
-			continue
+	var updated []*channeldb.MissionControlPair
+	from := Vertex(m.selfNode.PubKeyBytes)
+	for i, hop := range rt.Hops {
+		if i > failedHop {
+			break
 		}
 
-		vertexes[vertex] = struct{}{}
+		// The amount carried from the previous node to this hop is the
+		// amount it forwards, plus the fee it charges for doing so.
+		to := Vertex(hop.Channel.Node.PubKeyBytes)
+		amt := hop.AmtToForward + hop.Fee
+
+		result := m.pairResult(from, to)
+		switch {
+		case i < failedHop:
+			recordSuccess(result, now, amt)
+
+		case amtRelated:
+			recordFailure(result, now, amt)
+
+		default:
+			recordFailure(result, now, 0)
+		}
+
+		resultCopy := *result
+		updated = append(updated, &resultCopy)
+
+		from = to
+	}
+
+	if err := m.store.PutMissionControlPairs(updated...); err != nil {
+		log.Errorf("Unable to persist mission control results: %v",
+			err)
+	}
+}
+
+// QueryHistory returns a copy of the results of all node pairs, sorted by
+// their source and destination nodes.
+//
+// NOTE: This function is safe for concurrent access.
+func (m *missionControl) QueryHistory() []*channeldb.MissionControlPair {
+	m.Lock()
+	defer m.Unlock()
+
+	pairs := make([]*channeldb.MissionControlPair, 0, len(m.results))
+	for _, result := range m.results {
+		resultCopy := *result
+		pairs = append(pairs, &resultCopy)
 	}
 
-	// We'll also do the same for edges, but use the edgeDecay this time
-	// rather than the decay for vertexes.
-	edges := make(map[uint64]struct{})
-	for edge, pruneTime := range m.failedEdges {
-		if now.Sub(pruneTime) >= edgeDecay {
-			log.Tracef("Pruning decayed failure report for edge %v "+
-				"from Mission Control", edge)
+	sort.Slice(pairs, func(i, j int) bool {
+		if cmp := bytes.Compare(pairs[i].From[:], pairs[j].From[:]); cmp != 0 {
+			return cmp < 0
+		}
+		return bytes.Compare(pairs[i].To[:], pairs[j].To[:]) < 0
+	})
+
+	return pairs
+}
+
+// ImportHistory merges the passed node pair results, for instance exported
+// from another node, into the history. The failure and success of each pair
+// are applied in chronological order, but only if they are more recent than
+// any result already known for the pair.
+//
+// NOTE: This function is safe for concurrent access.
+func (m *missionControl) ImportHistory(
+	pairs []*channeldb.MissionControlPair) error {
+
+	m.Lock()
+	defer m.Unlock()
 
-			delete(m.failedEdges, edge)
-			continue
+	updated := make([]*channeldb.MissionControlPair, 0, len(pairs))
+	for _, pair := range pairs {
+		result := m.pairResult(pair.From, pair.To)
+
+		isNewer := func(t time.Time) bool {
+			return t.After(result.FailTime) &&
+				t.After(result.SuccessTime)
+		}
+		applyFailure := func() {
+			if isNewer(pair.FailTime) {
+				recordFailure(result, pair.FailTime, pair.FailAmt)
+			}
+		}
+		applySuccess := func() {
+			if isNewer(pair.SuccessTime) {
+				recordSuccess(
+					result, pair.SuccessTime,
+					pair.SuccessAmt,
+				)
+			}
+		}
+
+		if pair.FailTime.Before(pair.SuccessTime) {
+			applyFailure()
+			applySuccess()
+		} else {
+			applySuccess()
+			applyFailure()
 		}
 
-		edges[edge] = struct{}{}
+		resultCopy := *result
+		updated = append(updated, &resultCopy)
 	}
 
-	m.Unlock()
+	log.Debugf("Mission Control imported results of %v node pairs",
+		len(updated))
 
-	log.Debugf("Mission Control returning prune view of %v edges, %v "+
-		"vertexes", len(edges), len(vertexes))
+	return m.store.PutMissionControlPairs(updated...)
+}
+
+// ResetHistory resets the history of missionControl returning it to a state as
+// if no payment attempts have been made.
+//
+// NOTE: This function is safe for concurrent access.
+func (m *missionControl) ResetHistory() error {
+	m.Lock()
+	defer m.Unlock()
 
-	return graphPruneView{
-		edges:    edges,
-		vertexes: vertexes,
+	if err := m.store.ResetMissionControl(); err != nil {
+		return err
 	}
+	m.results = make(map[nodePair]*channeldb.MissionControlPair)
+
+	log.Debugf("Mission Control history reset")
+
+	return nil
 }
 
 // paymentSession is used during an HTLC routings session to prune the local
 // chain view in response to failures, and also report those failures back to
-// missionControl. The snapshot copied for this session will only ever grow,
-// and will now be pruned after a decay like the main view within mission
-// control. We do this as we want to avoid the case where we continually try a
-// bad edge or route multiple times in a session. This can lead to an infinite
-// loop if payment attempts take long enough.
+// missionControl. The vertexes and edges that failed during this session will
+// be ignored for the remainder of the session, regardless of the probability
+// estimated by mission control. We do this as we want to avoid the case where
+// we continually try a bad edge or route multiple times in a session. This can
+// lead to an infinite loop if payment attempts take long enough.
 type paymentSession struct {
-	pruneViewSnapshot graphPruneView
+	// ignoredVertexes is the set of vertexes that failed during this
+	// session.
+	ignoredVertexes map[Vertex]struct{}
+
+	// ignoredEdges is the set of edges, identified by their short channel
+	// ID, that failed during this session.
+	ignoredEdges map[uint64]struct{}
+
+	// additionalEdges is a map of edges, keyed by the node they originate
+	// from, which are derived from the routing hints of the payment. These
+	// are considered alongside the edges of the graph during path
+	// finding.
+	additionalEdges map[Vertex][]*channeldb.ChannelEdgePolicy
+
+	// preBuiltRoutes is the queue of routes handed out by the session, if
+	// it was created with a set of pre-built routes rather than finding
+	// routes itself.
+	preBuiltRoutes []*Route
+
+	// haveRoutes is true if the session was created with a set of
+	// pre-built routes.
+	haveRoutes bool
 
 	mc *missionControl
 }
 
-// NewPaymentSession creates a new payment session backed by the latest prune
-// view from Mission Control.
-func (m *missionControl) NewPaymentSession() *paymentSession {
-	viewSnapshot := m.GraphPruneView()
+// NewPaymentSession creates a new payment session backed by Mission Control.
+// An optional set of routing hints can be provided in order to populate
+// additional edges to explore when finding a path to the payment's target.
+func (m *missionControl) NewPaymentSession(routeHints [][]HopHint,
+	target *btcec.PublicKey) *paymentSession {
+
+	edges := make(map[Vertex][]*channeldb.ChannelEdgePolicy)
+
+	// Traverse through all of the available hop hints and include them in
+	// our edges map, indexed by the public key of the channel's starting
+	// node.
+	for _, routeHint := range routeHints {
+		// If multiple hop hints are provided within a single route
+		// hint, we'll assume they must be chained together and sorted
+		// in forward order in order to reach the target successfully.
+		for i, hopHint := range routeHint {
+			// In order to determine the end node of this hint,
+			// we'll need to look at the next hint's start node. If
+			// we've reached the end of the hints list, we can
+			// assume we've reached the destination.
+			endNode := &channeldb.LightningNode{}
+			if i != len(routeHint)-1 {
+				endNode.AddPubKey(routeHint[i+1].NodeID)
+			} else {
+				endNode.AddPubKey(target)
+			}
+
+			// Finally, create the channel edge from the hop hint
+			// and add it to list of edges corresponding to the
+			// node at the start of the channel.
+			edge := &channeldb.ChannelEdgePolicy{
+				Node:      endNode,
+				ChannelID: hopHint.ChannelID,
+				FeeBaseMSat: lnwire.MilliSatoshi(
+					hopHint.FeeBaseMSat,
+				),
+				FeeProportionalMillionths: lnwire.MilliSatoshi(
+					hopHint.FeeProportionalMillionths,
+				),
+				TimeLockDelta: hopHint.CLTVExpiryDelta,
+			}
+
+			v := NewVertex(hopHint.NodeID)
+			edges[v] = append(edges[v], edge)
+		}
+	}
 
 	return &paymentSession{
-		pruneViewSnapshot: viewSnapshot,
-		mc:                m,
+		ignoredVertexes: make(map[Vertex]struct{}),
+		ignoredEdges:    make(map[uint64]struct{}),
+		additionalEdges: edges,
+		mc:              m,
 	}
 }
 
-// ReportVertexFailure adds a vertex to the graph prune view after a client
-// reports a routing failure localized to the vertex. The time the vertex was
-// added is noted, as it'll be pruned from the shared view after a period of
-// vertexDecay. However, the vertex will remain pruned for the *local* session.
-// This ensures we don't retry this vertex during the payment attempt.
-func (p *paymentSession) ReportVertexFailure(v Vertex) {
+// NewPaymentSessionFromRoutes creates a new payment session which, instead of
+// finding routes itself, hands out the passed pre-built routes in order, one
+// per payment attempt. The outcomes of the attempts are still reported to
+// mission control.
+func (m *missionControl) NewPaymentSessionFromRoutes(
+	routes []*Route) *paymentSession {
+
+	return &paymentSession{
+		ignoredVertexes: make(map[Vertex]struct{}),
+		ignoredEdges:    make(map[uint64]struct{}),
+		preBuiltRoutes:  routes,
+		haveRoutes:      true,
+		mc:              m,
+	}
+}
+
+// ReportVertexFailure adds a vertex to the set of vertexes ignored for the
+// remainder of the session, after a client reports a routing failure localized
+// to the vertex. The failure is also reported to mission control, as a failure
+// of the hop leading to the vertex in the passed route, regardless of the
+// amount.
+func (p *paymentSession) ReportVertexFailure(rt *Route, v Vertex) {
 	log.Debugf("Reporting vertex %v failure to Mission Control", v)
 
-	// First, we'll add the failed vertex to our local prune view snapshot.
-	p.pruneViewSnapshot.vertexes[v] = struct{}{}
+	p.ignoredVertexes[v] = struct{}{}
 
-	// With the vertex added, we'll now report back to the global prune
-	// view, with this new piece of information so it can be utilized for
-	// new payment sessions.
-	p.mc.Lock()
-	p.mc.failedVertexes[v] = time.Now()
-	p.mc.Unlock()
+	for i, hop := range rt.Hops {
+		if Vertex(hop.Channel.Node.PubKeyBytes) == v {
+			p.mc.recordRoute(rt, i, false)
+			return
+		}
+	}
 }
 
-// ReportChannelFailure adds a channel to the graph prune view. The time the
-// channel was added is noted, as it'll be pruned from the global view after a
-// period of edgeDecay. However, the edge will remain pruned for the duration
-// of the *local* session. This ensures that we don't flap by continually
-// retrying an edge after its pruning has expired.
-//
-// TODO(roasbeef): also add value attempted to send and capacity of channel
-func (p *paymentSession) ReportChannelFailure(e uint64) {
-	log.Debugf("Reporting edge %v failure to Mission Control", e)
-
-	// First, we'll add the failed edge to our local prune view snapshot.
-	p.pruneViewSnapshot.edges[e] = struct{}{}
-
-	// With the edge added, we'll now report back to the global prune view,
-	// with this new piece of information so it can be utilized for new
-	// payment sessions.
-	p.mc.Lock()
-	p.mc.failedEdges[e] = time.Now()
-	p.mc.Unlock()
+// ReportChannelFailure adds a channel to the set of edges ignored for the
+// remainder of the session, after a client reports a routing failure localized
+// to the channel within the passed route. The failure is also reported to
+// mission control. If amtRelated is true, the failure is only assumed to apply
+// to HTLCs of at least the amount that was attempted, such as when the channel
+// lacked the balance to forward it.
+func (p *paymentSession) ReportChannelFailure(rt *Route, chanID uint64,
+	amtRelated bool) {
+
+	log.Debugf("Reporting edge %v failure to Mission Control", chanID)
+
+	p.ignoredEdges[chanID] = struct{}{}
+
+	for i, hop := range rt.Hops {
+		if hop.Channel.ChannelID == chanID {
+			p.mc.recordRoute(rt, i, amtRelated)
+			return
+		}
+	}
+}
+
+// ReportSuccess reports to mission control that each hop of the passed route
+// successfully carried the HTLC of a payment attempt.
+func (p *paymentSession) ReportSuccess(rt *Route) {
+	p.mc.recordRoute(rt, len(rt.Hops), false)
 }
 
 // RequestRoute returns a route which is likely to be capable for successfully
@@ -217,29 +542,104 @@ func (p *paymentSession) ReportChannelFailure(e uint64) {
 func (p *paymentSession) RequestRoute(payment *LightningPayment,
 	height uint32, finalCltvDelta uint16) (*Route, error) {
 
-	// First, we'll obtain our current prune view snapshot. This view will
-	// only ever grow during the duration of this payment session, never
-	// shrinking.
-	pruneView := p.pruneViewSnapshot
+	switch {
+	// If we have a set of pre-built routes, then we'll just pop off the
+	// next route from the queue, and use it directly.
+	case p.haveRoutes && len(p.preBuiltRoutes) > 0:
+		route := p.preBuiltRoutes[0]
+		p.preBuiltRoutes[0] = nil
+		p.preBuiltRoutes = p.preBuiltRoutes[1:]
+
+		return route, nil
 
-	log.Debugf("Mission Control session using prune view of %v "+
-		"edges, %v vertexes", len(pruneView.edges),
-		len(pruneView.vertexes))
+	// If we were instantiated with a set of pre-built routes, and we've
+	// run out, then we'll return a terminal error.
+	case p.haveRoutes:
+		return nil, fmt.Errorf("pre-built routes exhausted")
+	}
+
+	log.Debugf("Mission Control session ignoring %v edges, %v vertexes",
+		len(p.ignoredEdges), len(p.ignoredVertexes))
 
 	// TODO(roasbeef): sync logic amongst dist sys
 
-	// Taking into account this prune view, we'll attempt to locate a path
-	// to our destination, respecting the recommendations from
-	// missionControl.
-	path, err := findPath(nil, p.mc.graph, p.mc.selfNode, payment.Target,
-		pruneView.vertexes, pruneView.edges, payment.Amount)
-	if err != nil {
-		return nil, err
+	sourceVertex := Vertex(p.mc.selfNode.PubKeyBytes)
+
+	// If the payment is restricted to a specific outgoing or last hop
+	// channel, we'll ignore the edges that may not be used for the
+	// remainder of the path.
+	ignoredEdges := p.ignoredEdges
+	if payment.OutgoingChannelID != nil || payment.LastHopChannelID != nil {
+		var err error
+		ignoredEdges, err = p.restrictedEdges(payment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// If the payment must reach its destination through a specific
+	// channel, we'll find a path to the node at the other end of that
+	// channel instead, and append the channel to it afterwards.
+	target := payment.Target
+	var lastHop *ChannelHop
+	if payment.LastHopChannelID != nil {
+		var err error
+		lastHop, target, err = p.lastHopChannel(
+			*payment.LastHopChannelID, NewVertex(payment.Target),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Paths that exceed the fee limit of the payment, if any, are pruned
+	// during path finding. The fee charged for the last hop channel is
+	// paid to the node preceding it, unless that's our own node, so we'll
+	// deduct it from the budget left for the remainder of the path.
+	feeLimit := noFeeLimit
+	if payment.FeeLimit != nil {
+		feeLimit = *payment.FeeLimit
+	}
+	if lastHop != nil && NewVertex(target) != sourceVertex {
+		lastHopFee := computeFee(
+			payment.Amount, lastHop.ChannelEdgePolicy,
+		)
+		if lastHopFee > feeLimit {
+			return nil, newErrf(ErrFeeLimitExceeded, "fee of %v "+
+				"for last hop channel %v exceeds fee limit "+
+				"of %v", lastHopFee, lastHop.ChannelID,
+				feeLimit)
+		}
+		feeLimit -= lastHopFee
+	}
+
+	// Taking into account the failures of this session, we'll attempt to
+	// locate a path to our destination, weighing each hop by its
+	// probability of success as estimated by missionControl. If the last
+	// hop starts at our own node, there's no path to be found.
+	var path []*ChannelHop
+	if NewVertex(target) != sourceVertex {
+		var err error
+		path, err = findPath(
+			nil, p.mc.graph, p.additionalEdges, p.mc.selfNode,
+			target, p.ignoredVertexes, ignoredEdges,
+			payment.Amount, p.mc.getProbability,
+			p.mc.cfg.AttemptCost, feeLimit,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if lastHop != nil {
+		path = append(path, lastHop)
+	}
+	if len(path) == 0 {
+		return nil, newErrf(ErrNoPathFound, "unable to find a path to "+
+			"destination")
 	}
 
 	// With the next candidate path found, we'll attempt to turn this into
 	// a route by applying the time-lock and fee requirements.
-	sourceVertex := Vertex(p.mc.selfNode.PubKeyBytes)
 	route, err := newRoute(payment.Amount, sourceVertex, path, height,
 		finalCltvDelta)
 	if err != nil {
@@ -248,14 +648,125 @@ func (p *paymentSession) RequestRoute(payment *LightningPayment,
 		return nil, err
 	}
 
+	// Finally, we'll make sure the route doesn't exceed the fee limit of
+	// the payment, if any, as path finding only accounts for a lower
+	// bound of the fees once they're applied to the actual amounts
+	// forwarded.
+	if payment.FeeLimit != nil && route.TotalFees > *payment.FeeLimit {
+		return nil, newErrf(ErrFeeLimitExceeded, "route fee of %v "+
+			"exceeds fee limit of %v", route.TotalFees,
+			*payment.FeeLimit)
+	}
+
 	return route, err
 }
 
-// ResetHistory resets the history of missionControl returning it to a state as
-// if no payment attempts have been made.
-func (m *missionControl) ResetHistory() {
-	m.Lock()
-	m.failedEdges = make(map[uint64]time.Time)
-	m.failedVertexes = make(map[Vertex]time.Time)
-	m.Unlock()
+// restrictedEdges returns the set of edges to ignore during path finding for
+// the passed payment, which is restricted to leave our node through a specific
+// channel, or to reach its destination through a specific channel. In
+// addition to the edges that failed during this session, all of our other
+// channels are ignored in the first case, while the last hop channel itself is
+// ignored in the latter, as it's appended to the path afterwards.
+func (p *paymentSession) restrictedEdges(
+	payment *LightningPayment) (map[uint64]struct{}, error) {
+
+	ignoredEdges := make(map[uint64]struct{}, len(p.ignoredEdges))
+	for chanID := range p.ignoredEdges {
+		ignoredEdges[chanID] = struct{}{}
+	}
+
+	if payment.LastHopChannelID != nil {
+		ignoredEdges[*payment.LastHopChannelID] = struct{}{}
+	}
+
+	if payment.OutgoingChannelID == nil {
+		return ignoredEdges, nil
+	}
+
+	outgoingChanID := *payment.OutgoingChannelID
+	if _, ok := p.ignoredEdges[outgoingChanID]; ok {
+		return nil, newErrf(ErrNoPathFound, "outgoing channel %v "+
+			"failed to forward the payment", outgoingChanID)
+	}
+
+	var found bool
+	err := p.mc.selfNode.ForEachChannel(nil, func(_ *bolt.Tx,
+		info *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		if info.ChannelID == outgoingChanID {
+			found = true
+			return nil
+		}
+
+		ignoredEdges[info.ChannelID] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("outgoing channel %v isn't one of our "+
+			"channels", outgoingChanID)
+	}
+
+	return ignoredEdges, nil
+}
+
+// lastHopChannel returns the passed channel, directed such that it leads to
+// the given target, along with the public key of the node at its other end.
+func (p *paymentSession) lastHopChannel(chanID uint64,
+	target Vertex) (*ChannelHop, *btcec.PublicKey, error) {
+
+	if _, ok := p.ignoredEdges[chanID]; ok {
+		return nil, nil, newErrf(ErrNoPathFound, "last hop channel "+
+			"%v failed to forward the payment", chanID)
+	}
+
+	info, policy1, policy2, err := p.mc.graph.FetchChannelEdgesByID(chanID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch last hop channel "+
+			"%v: %v", chanID, err)
+	}
+
+	// The first policy governs HTLCs forwarded by the first node of the
+	// channel towards the second one, and vice versa.
+	var (
+		policy   *channeldb.ChannelEdgePolicy
+		fromNode [33]byte
+	)
+	switch target {
+	case info.NodeKey2Bytes:
+		policy, fromNode = policy1, info.NodeKey1Bytes
+	case info.NodeKey1Bytes:
+		policy, fromNode = policy2, info.NodeKey2Bytes
+	default:
+		return nil, nil, fmt.Errorf("last hop channel %v isn't "+
+			"connected to the destination", chanID)
+	}
+	if policy == nil {
+		return nil, nil, fmt.Errorf("no policy known for last hop "+
+			"channel %v", chanID)
+	}
+
+	// If the node preceding the last hop channel failed to forward the
+	// payment before, there's no point in finding a path to it, as it
+	// would be ignored during path finding anyway.
+	if _, ok := p.ignoredVertexes[Vertex(fromNode)]; ok {
+		return nil, nil, newErrf(ErrNoPathFound, "node %x preceding "+
+			"last hop channel %v failed to forward the payment",
+			fromNode[:], chanID)
+	}
+
+	fromPub, err := btcec.ParsePubKey(fromNode[:], btcec.S256())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ChannelHop{
+		Capacity:          info.Capacity,
+		Chain:             info.ChainHash,
+		ChannelEdgePolicy: policy,
+	}, fromPub, nil
 }
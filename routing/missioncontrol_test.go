@@ -0,0 +1,226 @@
+package routing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	mcSelf  = Vertex{1}
+	mcNodeA = Vertex{2}
+	mcNodeB = Vertex{3}
+)
+
+// mcTestRoute returns a route from the mission control test's own node,
+// through node A, to node B. Node A charges the passed fee.
+func mcTestRoute(amt, fee lnwire.MilliSatoshi) *Route {
+	newHop := func(node Vertex, chanID uint64, amtToForward,
+		fee lnwire.MilliSatoshi) *Hop {
+
+		return &Hop{
+			Channel: &ChannelHop{
+				ChannelEdgePolicy: &channeldb.ChannelEdgePolicy{
+					ChannelID: chanID,
+					Node: &channeldb.LightningNode{
+						PubKeyBytes: node,
+					},
+				},
+			},
+			AmtToForward: amtToForward,
+			Fee:          fee,
+		}
+	}
+
+	return &Route{
+		Hops: []*Hop{
+			newHop(mcNodeA, 1, amt, fee),
+			newHop(mcNodeB, 2, amt, 0),
+		},
+	}
+}
+
+// TestMissionControlProbability tests that the probability estimated by
+// mission control reflects the outcomes of past payment attempts, that
+// penalties decay over time, and that the results survive a restart.
+func TestMissionControlProbability(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, err := makeTestGraph()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	selfNode := &channeldb.LightningNode{PubKeyBytes: mcSelf}
+	cfg := MissionControlConfig{
+		AprioriHopProbability: 0.6,
+		PenaltyHalfLife:       time.Hour,
+	}
+
+	now := time.Unix(1000000, 0)
+	newMC := func() *missionControl {
+		mc, err := newMissionControl(
+			graph, selfNode, graph.Database(), cfg,
+		)
+		if err != nil {
+			t.Fatalf("unable to create mission control: %v", err)
+		}
+		mc.now = func() time.Time {
+			return now
+		}
+
+		return mc
+	}
+	mc := newMC()
+
+	assertProbability := func(from, to Vertex, amt lnwire.MilliSatoshi,
+		expected float64) {
+
+		t.Helper()
+
+		probability := mc.getProbability(from, to, amt)
+		if math.Abs(probability-expected) > 1e-9 {
+			t.Fatalf("expected probability %v for %v sending %v "+
+				"to %v, got %v", expected, from, amt, to,
+				probability)
+		}
+	}
+
+	// Without any results, the a priori probability should be returned.
+	assertProbability(mcSelf, mcNodeA, 1000, 0.6)
+
+	// We'll now report a payment of 1000 msat that failed between node A
+	// and node B due to the amount. The hop from our node to node A, which
+	// carried 1000 msat plus node A's fee, should be considered successful.
+	session := mc.NewPaymentSession(nil, nil)
+	session.ReportChannelFailure(mcTestRoute(1000, 10), 2, true)
+
+	assertProbability(mcSelf, mcNodeA, 1010, prevSuccessProbability)
+	assertProbability(mcSelf, mcNodeA, 1011, 0.6)
+	assertProbability(mcNodeA, mcNodeB, 1000, 0)
+	assertProbability(mcNodeA, mcNodeB, 999, 0.6)
+
+	// After one half-life has passed, half of the penalty and half of the
+	// success bonus should have decayed.
+	now = now.Add(time.Hour)
+	assertProbability(mcNodeA, mcNodeB, 1000, 0.3)
+	assertProbability(
+		mcSelf, mcNodeA, 1010, 0.6+(prevSuccessProbability-0.6)/2,
+	)
+
+	// The results should have been persisted, such that a restarted
+	// mission control returns the same probabilities.
+	mc = newMC()
+	assertProbability(mcNodeA, mcNodeB, 1000, 0.3)
+
+	// A failure that isn't related to the amount should apply to any
+	// amount, and clear the earlier success.
+	session = mc.NewPaymentSession(nil, nil)
+	session.ReportVertexFailure(mcTestRoute(500, 10), mcNodeA)
+	assertProbability(mcSelf, mcNodeA, 1, 0)
+
+	// A subsequent success of the full route should clear all failures.
+	session.ReportSuccess(mcTestRoute(2000, 10))
+	assertProbability(mcSelf, mcNodeA, 2010, prevSuccessProbability)
+	assertProbability(mcNodeA, mcNodeB, 2000, prevSuccessProbability)
+
+	// Finally, once the history is reset, we should be back to the a
+	// priori probability, even after a restart.
+	if err := mc.ResetHistory(); err != nil {
+		t.Fatalf("unable to reset history: %v", err)
+	}
+	assertProbability(mcNodeA, mcNodeB, 2000, 0.6)
+
+	mc = newMC()
+	if len(mc.QueryHistory()) != 0 {
+		t.Fatalf("expected empty history after reset")
+	}
+}
+
+// TestMissionControlImport tests that imported results are only applied if
+// they are more recent than the results already known.
+func TestMissionControlImport(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, err := makeTestGraph()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	selfNode := &channeldb.LightningNode{PubKeyBytes: mcSelf}
+	mc, err := newMissionControl(
+		graph, selfNode, graph.Database(), MissionControlConfig{},
+	)
+	if err != nil {
+		t.Fatalf("unable to create mission control: %v", err)
+	}
+
+	now := time.Unix(1000000, 0)
+	mc.now = func() time.Time {
+		return now
+	}
+
+	// We'll record a successful attempt of 1000 msat between node A and
+	// node B.
+	session := mc.NewPaymentSession(nil, nil)
+	session.ReportSuccess(mcTestRoute(1000, 0))
+
+	// Importing an older failure of the same pair should have no effect,
+	// while a failure of another pair should be applied.
+	err = mc.ImportHistory([]*channeldb.MissionControlPair{
+		{
+			From:     mcNodeA,
+			To:       mcNodeB,
+			FailTime: now.Add(-time.Minute),
+			FailAmt:  500,
+		},
+		{
+			From:     mcNodeB,
+			To:       mcNodeA,
+			FailTime: now.Add(-time.Minute),
+			FailAmt:  500,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to import history: %v", err)
+	}
+
+	history := mc.QueryHistory()
+	if len(history) != 3 {
+		t.Fatalf("expected 3 pairs, got %v", len(history))
+	}
+	for _, pair := range history {
+		switch {
+		case pair.From == mcNodeA && !pair.FailTime.IsZero():
+			t.Fatalf("older failure shouldn't have been imported")
+
+		case pair.From == mcNodeB && pair.FailAmt != 500:
+			t.Fatalf("failure should have been imported")
+		}
+	}
+
+	// Importing a more recent failure for a lower amount should clear the
+	// earlier success.
+	err = mc.ImportHistory([]*channeldb.MissionControlPair{
+		{
+			From:     mcNodeA,
+			To:       mcNodeB,
+			FailTime: now.Add(time.Minute),
+			FailAmt:  500,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to import history: %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	probability := mc.getProbability(mcNodeA, mcNodeB, 800)
+	if probability != 0 {
+		t.Fatalf("expected zero probability, got %v", probability)
+	}
+}
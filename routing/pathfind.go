@@ -11,6 +11,7 @@ import (
 	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -26,6 +27,10 @@ const (
 
 	// infinity is used as a starting distance in our shortest path search.
 	infinity = math.MaxInt64
+
+	// noFeeLimit is used as the fee limit of a path finding attempt for a
+	// payment that doesn't restrict the fees it's willing to pay.
+	noFeeLimit = lnwire.MilliSatoshi(math.MaxUint64)
 )
 
 // ChannelHop is an intermediate hop within the network with a greater
@@ -186,8 +191,14 @@ func (r *Route) containsChannel(chanID uint64) bool {
 }
 
 // ToHopPayloads converts a complete route into the series of per-hop payloads
-// that is to be encoded within each HTLC using an opaque Sphinx packet.
-func (r *Route) ToHopPayloads() []sphinx.HopData {
+// that is to be encoded within each HTLC using an opaque Sphinx packet. If a
+// keysend preimage is passed, the final hop is signalled to expect it within
+// one additional payload, which is appended to the returned series. If a
+// non-zero multi-path total is passed, the route carries a shard of a payment
+// of that total amount, which is signalled to the final hop.
+func (r *Route) ToHopPayloads(keySendPreimage *[32]byte,
+	multiPathTotal lnwire.MilliSatoshi) []sphinx.HopData {
+
 	hopPayloads := make([]sphinx.HopData, len(r.Hops))
 
 	// For each hop encoded within the route, we'll convert the hop struct
@@ -216,6 +227,21 @@ func (r *Route) ToHopPayloads() []sphinx.HopData {
 			nextHop)
 	}
 
+	if multiPathTotal != 0 {
+		htlcswitch.SetMultiPathTotal(
+			&hopPayloads[len(hopPayloads)-1], multiPathTotal,
+		)
+	}
+
+	if keySendPreimage != nil {
+		hopPayloads[len(hopPayloads)-1].Realm = htlcswitch.KeySendRealm
+		hopPayloads = append(
+			hopPayloads, htlcswitch.NewKeySendHopData(
+				*keySendPreimage,
+			),
+		)
+	}
+
 	return hopPayloads
 }
 
@@ -374,6 +400,53 @@ func newRoute(amtToSend lnwire.MilliSatoshi, sourceVertex Vertex,
 	return route, nil
 }
 
+// NewRouteFromHops creates a new route from the passed hops, which MUST be
+// sorted in forward order, starting at the source node. Unlike newRoute, the
+// amounts, fees and time locks of the hops are taken as is, so routes that
+// were crafted elsewhere can be reconstructed. The amounts of consecutive hops
+// must be consistent with their fees, and the passed time lock is the one
+// extended to the first hop.
+func NewRouteFromHops(timeLock uint32, sourceVertex Vertex,
+	hops []*Hop) (*Route, error) {
+
+	if len(hops) == 0 {
+		return nil, fmt.Errorf("route must have at least one hop")
+	}
+
+	route := &Route{
+		Hops:          hops,
+		TotalTimeLock: timeLock,
+		TotalAmount:   hops[0].AmtToForward + hops[0].Fee,
+		nodeIndex:     make(map[Vertex]struct{}),
+		chanIndex:     make(map[uint64]struct{}),
+		nextHopMap:    make(map[Vertex]*ChannelHop),
+		prevHopMap:    make(map[Vertex]*ChannelHop),
+	}
+
+	route.nextHopMap[sourceVertex] = hops[0].Channel
+	for i, hop := range hops {
+		// Each node forwards the amount it received from the prior
+		// hop, minus its fee.
+		if i > 0 && hops[i-1].AmtToForward != hop.AmtToForward+hop.Fee {
+			return nil, fmt.Errorf("amount to forward of hop %v "+
+				"doesn't match the amount and fee of hop %v",
+				i-1, i)
+		}
+
+		v := Vertex(hop.Channel.Node.PubKeyBytes)
+		route.nodeIndex[v] = struct{}{}
+		route.chanIndex[hop.Channel.ChannelID] = struct{}{}
+		route.prevHopMap[v] = hop.Channel
+		if i != len(hops)-1 {
+			route.nextHopMap[v] = hops[i+1].Channel
+		}
+
+		route.TotalFees += hop.Fee
+	}
+
+	return route, nil
+}
+
 // Vertex is a simple alias for the serialization of a compressed Bitcoin
 // public key.
 type Vertex [33]byte
@@ -398,6 +471,10 @@ type edgeWithPrev struct {
 	prevNode [33]byte
 }
 
+// edgeProbability returns the estimated probability that an HTLC of the
+// passed amount is successfully forwarded from one node to the other.
+type edgeProbability func(from, to Vertex, amt lnwire.MilliSatoshi) float64
+
 // edgeWeight computes the weight of an edge. This value is used when searching
 // for the shortest path within the channel graph between two nodes. Currently
 // a component is just 1 + the cltv delta value required at this hop, this
@@ -405,17 +482,28 @@ type edgeWithPrev struct {
 // factor in the "pure fee" through this hop, using the square of this fee as
 // part of the weighting. The goal here is to bias more heavily towards fee
 // ranking, and fallback to a time-lock based value in the case of a fee tie.
+// The cost of a payment attempt is added to the fee as a virtual fee, scaled
+// by the inverse of the probability that the edge successfully forwards the
+// payment, such that edges that are unlikely to succeed are avoided.
 //
 // TODO(roasbeef): compute robust weight metric
-func edgeWeight(amt lnwire.MilliSatoshi, e *channeldb.ChannelEdgePolicy) int64 {
+func edgeWeight(amt lnwire.MilliSatoshi, e *channeldb.ChannelEdgePolicy,
+	probability float64, attemptCost lnwire.MilliSatoshi) int64 {
+
 	// First, we'll compute the "pure" fee through this hop. We say pure,
 	// as this may not be what's ultimately paid as fees are properly
 	// calculated backwards, while we're going in the reverse direction.
 	pureFee := computeFee(amt, e)
 
+	// Next, we'll add the virtual fee of the attempt, which grows as the
+	// probability of success decreases.
+	fee := pureFee + lnwire.MilliSatoshi(
+		float64(attemptCost)/probability,
+	)
+
 	// We'll then square the fee itself in order to more heavily weight our
 	// edge selection to bias towards lower fees.
-	feeWeight := int64(pureFee * pureFee)
+	feeWeight := int64(fee * fee)
 
 	// The final component is then 1 plus the timelock delta.
 	timeWeight := int64(1 + e.TimeLockDelta)
@@ -431,11 +519,19 @@ func edgeWeight(amt lnwire.MilliSatoshi, e *channeldb.ChannelEdgePolicy) int64 {
 // and the destination. The distance metric used for edges is related to the
 // time-lock+fee costs along a particular edge. If a path is found, this
 // function returns a slice of ChannelHop structs which encoded the chosen path
-// from the target to the source.
+// from the target to the source. Any additional edges passed, such as the
+// private channels found within the routing hints of an invoice, are
+// considered alongside the edges of the graph, keyed by the node they
+// originate from. If a probability source is passed, edges are additionally
+// weighted by their probability of success, using the passed attempt cost,
+// and edges that are very unlikely to succeed are skipped. Partial paths whose
+// fees already exceed the passed fee limit aren't explored any further.
 func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
+	additionalEdges map[Vertex][]*channeldb.ChannelEdgePolicy,
 	sourceNode *channeldb.LightningNode, target *btcec.PublicKey,
 	ignoredNodes map[Vertex]struct{}, ignoredEdges map[uint64]struct{},
-	amt lnwire.MilliSatoshi) ([]*ChannelHop, error) {
+	amt lnwire.MilliSatoshi, getProbability edgeProbability,
+	attemptCost, feeLimit lnwire.MilliSatoshi) ([]*ChannelHop, error) {
 
 	var err error
 	if tx == nil {
@@ -467,6 +563,27 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 		return nil, err
 	}
 
+	// We'll also include all the nodes found within the additional edges
+	// that are not known to us yet in the distance map, as the private
+	// channels they describe may lead to nodes that aren't part of the
+	// public graph.
+	addUnknownNode := func(node *channeldb.LightningNode) {
+		vertex := Vertex(node.PubKeyBytes)
+		if _, ok := distance[vertex]; ok {
+			return
+		}
+		distance[vertex] = nodeWithDist{
+			dist: infinity,
+			node: node,
+		}
+	}
+	for vertex, edges := range additionalEdges {
+		addUnknownNode(&channeldb.LightningNode{PubKeyBytes: vertex})
+		for _, edge := range edges {
+			addUnknownNode(edge.Node)
+		}
+	}
+
 	// TODO(roasbeef): also add path caching
 	//  * similar to route caching, but doesn't factor in the amount
 
@@ -483,6 +600,15 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// heap.
 	heap.Push(&nodeHeap, distance[sourceVertex])
 
+	// We'll keep track of the fees paid to reach each node along its best
+	// known path. As the amount to forward only grows towards the source,
+	// computing the fees based on the payment amount gives us a lower
+	// bound that we can prune partial paths with.
+	pathFees := map[Vertex]lnwire.MilliSatoshi{
+		sourceVertex: 0,
+	}
+	var feeLimitExceeded bool
+
 	targetBytes := target.SerializeCompressed()
 
 	// We'll use this map as a series of "previous" hop pointers. So to get
@@ -505,9 +631,8 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 		// examine all the outgoing edge (channels) from this node to
 		// further our graph traversal.
 		pivot := Vertex(bestNode.PubKeyBytes)
-		err := bestNode.ForEachChannel(tx, func(tx *bolt.Tx,
-			edgeInfo *channeldb.ChannelEdgeInfo,
-			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
+		processEdge := func(edgeInfo *channeldb.ChannelEdgeInfo,
+			outEdge *channeldb.ChannelEdgePolicy) {
 
 			v := Vertex(outEdge.Node.PubKeyBytes)
 
@@ -516,23 +641,47 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			// through it.
 			edgeFlags := lnwire.ChanUpdateFlag(outEdge.Flags)
 			if edgeFlags&lnwire.ChanUpdateDisabled == lnwire.ChanUpdateDisabled {
-				return nil
+				return
 			}
 
 			// If this Vertex or edge has been black listed, then
 			// we'll skip exploring this edge during this
 			// iteration.
 			if _, ok := ignoredNodes[v]; ok {
-				return nil
+				return
 			}
 			if _, ok := ignoredEdges[outEdge.ChannelID]; ok {
-				return nil
+				return
+			}
+
+			// If we have an estimate of the probability that this
+			// edge will successfully forward the payment, we'll
+			// skip it if it's unlikely to do so.
+			probability := 1.0
+			if getProbability != nil {
+				probability = getProbability(pivot, v, amt)
+			}
+			if probability < minHopProbability {
+				return
+			}
+
+			// We don't pay any fees to forward over our own
+			// channels. For any other edge, we'll skip it if the
+			// fee of the partial path would exceed our fee limit.
+			fee := pathFees[pivot]
+			if pivot != sourceVertex {
+				fee += computeFee(amt, outEdge)
+			}
+			if fee > feeLimit {
+				feeLimitExceeded = true
+				return
 			}
 
 			// Compute the tentative distance to this new
 			// channel/edge which is the distance to our current
 			// pivot node plus the weight of this edge.
-			tempDist := distance[pivot].dist + edgeWeight(amt, outEdge)
+			weight := edgeWeight(amt, outEdge, probability, attemptCost)
+			tempDist := distance[pivot].dist + weight
 
 			// If this new tentative distance is better than the
 			// current best known distance to this node, then we
@@ -550,6 +699,7 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 					dist: tempDist,
 					node: outEdge.Node,
 				}
+				pathFees[v] = fee
 				prev[v] = edgeWithPrev{
 					// We'll use the *incoming* edge here
 					// as we need to use the routing policy
@@ -568,17 +718,43 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			}
 
 			// TODO(roasbeef): return min HTLC as error in end?
+		}
+
+		err := bestNode.ForEachChannel(tx, func(tx *bolt.Tx,
+			edgeInfo *channeldb.ChannelEdgeInfo,
+			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
 
+			processEdge(edgeInfo, outEdge)
 			return nil
 		})
 		if err != nil {
 			return nil, err
 		}
+
+		// Then, we'll examine all the additional edges from the node
+		// we're currently visiting. Since we don't know the capacity
+		// of the private channel, we'll assume it was selected as a
+		// routing hint due to having enough capacity for the payment
+		// and use the payment amount as its capacity.
+		for _, edge := range additionalEdges[pivot] {
+			edgeInfo := &channeldb.ChannelEdgeInfo{
+				ChannelID: edge.ChannelID,
+				Capacity:  amt.ToSatoshis(),
+			}
+			processEdge(edgeInfo, edge)
+		}
 	}
 
 	// If the target node isn't found in the prev hop map, then a path
-	// doesn't exist, so we terminate in an error.
+	// doesn't exist, so we terminate in an error. If we had to skip any
+	// edges due to their fees, we'll let the caller know that the fee
+	// limit may be what's preventing the payment.
 	if _, ok := prev[NewVertex(target)]; !ok {
+		if feeLimitExceeded {
+			return nil, newErrf(ErrFeeLimitExceeded, "unable to "+
+				"find a path to destination within fee "+
+				"limit of %v", feeLimit)
+		}
 		return nil, newErrf(ErrNoPathFound, "unable to find a path to "+
 			"destination")
 	}
@@ -647,7 +823,8 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// selfNode) to the target destination that's capable of carrying amt
 	// satoshis along the path before fees are calculated.
 	startingPath, err := findPath(
-		tx, graph, source, target, ignoredVertexes, ignoredEdges, amt,
+		tx, graph, nil, source, target, ignoredVertexes, ignoredEdges,
+		amt, nil, 0, noFeeLimit,
 	)
 	if err != nil {
 		log.Errorf("Unable to find path: %v", err)
@@ -720,8 +897,9 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			// root path removed, we'll attempt to find another
 			// shortest path from the spur node to the destination.
 			spurPath, err := findPath(
-				tx, graph, spurNode, target, ignoredVertexes,
-				ignoredEdges, amt,
+				tx, graph, nil, spurNode, target,
+				ignoredVertexes, ignoredEdges, amt, nil, 0,
+				noFeeLimit,
 			)
 
 			// If we weren't able to find a path, we'll continue to
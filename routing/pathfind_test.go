@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -311,8 +312,8 @@ func TestBasicGraphPathFinding(t *testing.T) {
 
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := aliases["sophon"]
-	path, err := findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	path, err := findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, paymentAmt, nil, 0, noFeeLimit)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
 	}
@@ -355,7 +356,7 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	// Next, we'll assert that the "next hop" field in each route payload
 	// properly points to the channel ID that the HTLC should be forwarded
 	// along.
-	hopPayloads := route.ToHopPayloads()
+	hopPayloads := route.ToHopPayloads(nil, 0)
 	if len(hopPayloads) != 2 {
 		t.Fatalf("incorrect number of hop payloads: expected %v, got %v",
 			2, len(hopPayloads))
@@ -377,6 +378,43 @@ func TestBasicGraphPathFinding(t *testing.T) {
 			exitHop[:], hopPayloads[0].NextAddress)
 	}
 
+	// When making a spontaneous payment, an additional payload carrying
+	// the preimage should follow the one of the exit hop, which in turn
+	// should signal its presence.
+	keySendPreimage := [32]byte{1, 2, 3}
+	keySendPayloads := route.ToHopPayloads(&keySendPreimage, 0)
+	if len(keySendPayloads) != 3 {
+		t.Fatalf("incorrect number of keysend hop payloads: "+
+			"expected %v, got %v", 3, len(keySendPayloads))
+	}
+	if keySendPayloads[0].Realm != 0 {
+		t.Fatalf("first hop has incorrect realm: %v",
+			keySendPayloads[0].Realm)
+	}
+	if keySendPayloads[1].Realm != htlcswitch.KeySendRealm {
+		t.Fatalf("exit hop has incorrect realm: %v",
+			keySendPayloads[1].Realm)
+	}
+	if keySendPayloads[2] != htlcswitch.NewKeySendHopData(keySendPreimage) {
+		t.Fatalf("keysend payload doesn't carry preimage: %v",
+			keySendPayloads[2])
+	}
+
+	// When sending a shard of a multi-path payment, only the payload of
+	// the exit hop should carry the total amount of the payment.
+	const multiPathTotal = lnwire.MilliSatoshi(500000)
+	shardPayloads := route.ToHopPayloads(nil, multiPathTotal)
+	var noTotal [12]byte
+	if shardPayloads[0].ExtraBytes != noTotal {
+		t.Fatalf("first hop carries multi-path total: %x",
+			shardPayloads[0].ExtraBytes)
+	}
+	total := binary.BigEndian.Uint64(shardPayloads[1].ExtraBytes[:8])
+	if lnwire.MilliSatoshi(total) != multiPathTotal {
+		t.Fatalf("exit hop has incorrect multi-path total: "+
+			"expected %v, got %v", multiPathTotal, total)
+	}
+
 	// We'll also assert that the outgoing CLTV value for each hop was set
 	// accordingly.
 	if route.Hops[0].OutgoingTimeLock != 101 {
@@ -451,8 +489,8 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	// exist two possible paths in the graph, but the shorter (1 hop) path
 	// should be selected.
 	target = aliases["luoji"]
-	path, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	path, err = findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, paymentAmt, nil, 0, noFeeLimit)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
 	}
@@ -572,8 +610,8 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	// We start by confirming that routing a payment 20 hops away is possible.
 	// Alice should be able to find a valid route to ursula.
 	target := aliases["ursula"]
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	_, err = findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, paymentAmt, nil, 0, noFeeLimit)
 	if err != nil {
 		t.Fatalf("path should have been found")
 	}
@@ -581,8 +619,8 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	// Vincent is 21 hops away from Alice, and thus no valid route should be
 	// presented to Alice.
 	target = aliases["vincent"]
-	path, err := findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	path, err := findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, paymentAmt, nil, 0, noFeeLimit)
 	if err == nil {
 		t.Fatalf("should not have been able to find path, supposed to be "+
 			"greater than 20 hops, found route with %v hops",
@@ -621,13 +659,97 @@ func TestPathNotAvailable(t *testing.T) {
 		t.Fatalf("unable to parse pubkey: %v", err)
 	}
 
-	_, err = findPath(nil, graph, sourceNode, unknownNode, ignoredVertexes,
-		ignoredEdges, 100)
+	_, err = findPath(nil, graph, nil, sourceNode, unknownNode,
+		ignoredVertexes, ignoredEdges, 100, nil, 0, noFeeLimit)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("path shouldn't have been found: %v", err)
 	}
 }
 
+// TestRouteHintsPathFinding tests that a payment session is able to find a
+// route to a node that isn't part of the graph by making use of the routing
+// hints of the payment, which describe private channels leading to it.
+func TestRouteHintsPathFinding(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+
+	// The target of the payment is a node which only has a single private
+	// channel with sophon, so it can't be found within the graph.
+	privateNodeStr := "03dd46ff29a6941b4a2607525b043ec9b020b3f318a1bf281536fd7011ec59c882"
+	privateNodeBytes, err := hex.DecodeString(privateNodeStr)
+	if err != nil {
+		t.Fatalf("unable to parse bytes: %v", err)
+	}
+	privateNode, err := btcec.ParsePubKey(privateNodeBytes, btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	const (
+		startingHeight = 100
+		finalHopCLTV   = 1
+		privateChanID  = 1337
+	)
+	hopHint := HopHint{
+		NodeID:          aliases["sophon"],
+		ChannelID:       privateChanID,
+		FeeBaseMSat:     1000,
+		CLTVExpiryDelta: 10,
+	}
+	payment := &LightningPayment{
+		Target:     privateNode,
+		Amount:     lnwire.NewMSatFromSatoshis(100),
+		RouteHints: [][]HopHint{{hopHint}},
+	}
+
+	mc, err := newMissionControl(
+		graph, sourceNode, graph.Database(), MissionControlConfig{},
+	)
+	if err != nil {
+		t.Fatalf("unable to create mission control: %v", err)
+	}
+	paySession := mc.NewPaymentSession(payment.RouteHints, payment.Target)
+	route, err := paySession.RequestRoute(
+		payment, startingHeight, finalHopCLTV,
+	)
+	if err != nil {
+		t.Fatalf("unable to find route: %v", err)
+	}
+
+	// As sophon is two hops away from us, the route should consist of
+	// three hops, the last of which is the private channel.
+	if len(route.Hops) != 3 {
+		t.Fatalf("route is of incorrect length, expected %v got %v", 3,
+			len(route.Hops))
+	}
+	lastHop := route.Hops[2]
+	if lastHop.Channel.ChannelID != privateChanID {
+		t.Fatalf("expected last hop to use channel %v, got %v",
+			privateChanID, lastHop.Channel.ChannelID)
+	}
+	if !bytes.Equal(lastHop.Channel.Node.PubKeyBytes[:], privateNodeBytes) {
+		t.Fatalf("expected last hop to lead to private node %x, got %x",
+			privateNodeBytes, lastHop.Channel.Node.PubKeyBytes)
+	}
+
+	// Sophon should be paid the fee advertised within the hint in order to
+	// forward the payment over the private channel.
+	if route.Hops[1].Fee != lnwire.MilliSatoshi(hopHint.FeeBaseMSat) {
+		t.Fatalf("expected sophon to be paid fee of %v, got %v",
+			hopHint.FeeBaseMSat, route.Hops[1].Fee)
+	}
+}
+
 func TestPathInsufficientCapacity(t *testing.T) {
 	t.Parallel()
 
@@ -655,8 +777,8 @@ func TestPathInsufficientCapacity(t *testing.T) {
 	target := aliases["sophon"]
 
 	payAmt := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+	_, err = findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil, 0, noFeeLimit)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
@@ -683,8 +805,8 @@ func TestRouteFailMinHTLC(t *testing.T) {
 	// attempt should fail.
 	target := aliases["songoku"]
 	payAmt := lnwire.MilliSatoshi(10)
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+	_, err = findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil, 0, noFeeLimit)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
@@ -711,8 +833,8 @@ func TestRouteFailDisabledEdge(t *testing.T) {
 	// succeed without issue, and return a single path.
 	target := aliases["songoku"]
 	payAmt := lnwire.NewMSatFromSatoshis(10000)
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+	_, err = findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil, 0, noFeeLimit)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
 	}
@@ -730,13 +852,70 @@ func TestRouteFailDisabledEdge(t *testing.T) {
 
 	// Now, if we attempt to route through that edge, we should get a
 	// failure as it is no longer eligible.
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+	_, err = findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil, 0, noFeeLimit)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
 }
 
+// TestPathFindFeeLimit tests that path finding doesn't return paths whose fees
+// exceed the passed fee limit, while not counting any fees for our own
+// channels.
+func TestPathFindFeeLimit(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+	sourceVertex := Vertex(sourceNode.PubKeyBytes)
+	ignoredEdges := make(map[uint64]struct{})
+	ignoredVertexes := make(map[Vertex]struct{})
+
+	// The cheapest path from roasbeef to sophon goes through songoku, who
+	// charges 10 msat plus 1000 ppm to forward the payment.
+	target := aliases["sophon"]
+	payAmt := lnwire.NewMSatFromSatoshis(100)
+	expectedFee := lnwire.MilliSatoshi(10 + 100)
+
+	path, err := findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil, 0, expectedFee)
+	if err != nil {
+		t.Fatalf("unable to find path: %v", err)
+	}
+	route, err := newRoute(payAmt, sourceVertex, path, 100, 1)
+	if err != nil {
+		t.Fatalf("unable to create route: %v", err)
+	}
+	if route.TotalFees != expectedFee {
+		t.Fatalf("expected route fee of %v, got %v", expectedFee,
+			route.TotalFees)
+	}
+
+	// Lowering the fee limit below the fee of the cheapest path should
+	// cause path finding to fail, reporting the fee limit as the cause.
+	_, err = findPath(nil, graph, nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil, 0, expectedFee-1)
+	if !IsError(err, ErrFeeLimitExceeded) {
+		t.Fatalf("expected fee limit error, got %v", err)
+	}
+
+	// No fees are paid to reach our direct peers, so a path to songoku
+	// should be found even if we aren't willing to pay any fees.
+	_, err = findPath(nil, graph, nil, sourceNode, aliases["songoku"],
+		ignoredVertexes, ignoredEdges, payAmt, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("unable to find path: %v", err)
+	}
+}
+
 func TestPathInsufficientCapacityWithFee(t *testing.T) {
 	t.Parallel()
 
@@ -967,3 +1146,75 @@ func TestPathFindSpecExample(t *testing.T) {
 			startingHeight+DefaultFinalCLTVDelta)
 	}
 }
+
+// TestPathFindingProbability tests that path finding takes the probability of
+// success of each edge into account, avoiding edges that are unlikely to
+// forward the payment.
+func TestPathFindingProbability(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+
+	ignoredEdges := make(map[uint64]struct{})
+	ignoredVertexes := make(map[Vertex]struct{})
+
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := aliases["sophon"]
+	songoku := NewVertex(aliases["songoku"])
+	sophon := NewVertex(target)
+
+	// findPathWithProbability finds a path to sophon, where the edge from
+	// son goku to sophon has the passed probability of success, and all
+	// other edges are certain to succeed.
+	findPathWithProbability := func(probability float64,
+		attemptCost lnwire.MilliSatoshi) string {
+
+		getProbability := func(from, to Vertex,
+			amt lnwire.MilliSatoshi) float64 {
+
+			if from == songoku && to == sophon {
+				return probability
+			}
+			return 1
+		}
+
+		path, err := findPath(
+			nil, graph, nil, sourceNode, target, ignoredVertexes,
+			ignoredEdges, paymentAmt, getProbability, attemptCost,
+			noFeeLimit,
+		)
+		if err != nil {
+			t.Fatalf("unable to find path: %v", err)
+		}
+
+		return path[0].Node.Alias
+	}
+
+	// As long as both edges are certain to succeed, the path through son
+	// goku should be taken due to its lower fees.
+	if hop := findPathWithProbability(1, 100000); hop != "songoku" {
+		t.Fatalf("expected path through songoku, got %v", hop)
+	}
+
+	// Once the edge from son goku is unlikely to succeed, the virtual cost
+	// of the attempt should outweigh the fees of the path through pham
+	// nuwen.
+	if hop := findPathWithProbability(0.05, 100000); hop != "phamnuwen" {
+		t.Fatalf("expected path through phamnuwen, got %v", hop)
+	}
+
+	// Edges below the minimum probability should be skipped, even if
+	// attempts have no cost.
+	if hop := findPathWithProbability(0.005, 0); hop != "phamnuwen" {
+		t.Fatalf("expected path through phamnuwen, got %v", hop)
+	}
+}
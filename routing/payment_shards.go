@@ -0,0 +1,205 @@
+package routing
+
+import (
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// minShardAmount is the smallest amount we'll split a shard of a multi-path
+// payment into. Shards that can't be routed, and can't be split any further
+// without going below this amount, cause the payment to fail.
+const minShardAmount = lnwire.MilliSatoshi(10000)
+
+// ShardStore persists the shards sent for each payment, such that the progress
+// of payments split across multiple paths can be tracked.
+type ShardStore interface {
+	// AddPaymentShard records a newly sent shard of the payment with the
+	// passed payment hash, and assigns it a unique shard ID.
+	AddPaymentShard(paymentHash [32]byte,
+		shard *channeldb.PaymentShard) error
+
+	// UpdatePaymentShard sets the status of the shard with the given ID
+	// of the payment with the passed payment hash.
+	UpdatePaymentShard(paymentHash [32]byte, shardID uint64,
+		status channeldb.ShardStatus) error
+}
+
+// newPaymentShard creates a new in-flight shard delivering the passed amount
+// along the given route.
+func newPaymentShard(amt lnwire.MilliSatoshi,
+	route *Route) *channeldb.PaymentShard {
+
+	path := make([][33]byte, len(route.Hops))
+	for i, hop := range route.Hops {
+		path[i] = hop.Channel.Node.PubKeyBytes
+	}
+
+	return &channeldb.PaymentShard{
+		Amount:   amt,
+		Fee:      route.TotalFees,
+		TimeLock: route.TotalTimeLock,
+		Path:     path,
+		Status:   channeldb.ShardInFlight,
+	}
+}
+
+// shardResult is the outcome of sending a single shard of a multi-path
+// payment.
+type shardResult struct {
+	amt      lnwire.MilliSatoshi
+	preimage [32]byte
+	route    *Route
+	err      error
+}
+
+// SendMultiPathPayment attempts to send a payment as described within the
+// passed LightningPayment, splitting it into several shards if needed. The
+// payment is first attempted in full along a single route. If no route capable
+// of carrying the amount of a shard can be found, the shard is split in two,
+// and both halves are sent concurrently along their own routes. Splitting
+// continues until either all shards have been routed, or the MaxShards limit
+// of the payment is reached. Payments are only split if the destination
+// advertises support for multi-path payments.
+//
+// This function is blocking, and returns once all shards have been resolved.
+// If the payment succeeds, the payment preimage is returned along with the
+// routes taken by all settled shards.
+func (r *ChannelRouter) SendMultiPathPayment(payment *LightningPayment) (
+	[32]byte, []*Route, error) {
+
+	log.Tracef("Dispatching multi-path lightning payment: %v",
+		newLogClosure(func() string {
+			payment.Target.Curve = nil
+			return spew.Sdump(payment)
+		}),
+	)
+
+	params, err := r.newPaymentParams(payment)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	maxShards := payment.MaxShards
+	if maxShards > 1 && !r.supportsMultiPath(payment.Target) {
+		log.Debugf("Destination of payment %x doesn't support "+
+			"multi-path payments, sending along a single path",
+			payment.PaymentHash)
+
+		maxShards = 1
+	}
+
+	// Spontaneous payments create an invoice at the destination for the
+	// amount of the first HTLC carrying the preimage, so they can't be
+	// split.
+	if payment.KeySendPreimage != nil {
+		maxShards = 1
+	}
+
+	// abort is closed once a shard fails in a way that splitting can't
+	// recover from, signalling all other shards to stop their attempts.
+	abort := make(chan struct{})
+	results := make(chan *shardResult)
+
+	sendShard := func(amt lnwire.MilliSatoshi) {
+		shardPayment := *payment
+		shardPayment.Amount = amt
+
+		// Each shard is sent using its own payment session, such that
+		// the failures of one shard don't exclude edges from the
+		// routes of the others.
+		paySession := r.missionControl.NewPaymentSession(
+			payment.RouteHints, payment.Target,
+		)
+
+		go func() {
+			preimage, route, err := r.sendPaymentShard(
+				&shardPayment, paySession, params, abort,
+			)
+			results <- &shardResult{
+				amt:      amt,
+				preimage: preimage,
+				route:    route,
+				err:      err,
+			}
+		}()
+	}
+
+	// We'll start out by attempting to send the payment in full.
+	sendShard(payment.Amount)
+	numShards, inFlight := uint32(1), 1
+
+	var (
+		preimage   [32]byte
+		routes     []*Route
+		paymentErr error
+	)
+	for inFlight > 0 {
+		result := <-results
+		inFlight--
+
+		_, noRoute := result.err.(*noRouteError)
+		canSplit := noRoute && paymentErr == nil &&
+			numShards < maxShards &&
+			result.amt/2 >= minShardAmount
+
+		switch {
+		case result.err == nil:
+			preimage = result.preimage
+			routes = append(routes, result.route)
+
+		// No route could be found for the amount of the shard, so
+		// we'll split it in two and send both halves instead.
+		case canSplit:
+			half := result.amt / 2
+
+			log.Debugf("Splitting shard of %v of payment %x into "+
+				"%v and %v", result.amt, payment.PaymentHash,
+				half, result.amt-half)
+
+			sendShard(half)
+			sendShard(result.amt - half)
+			numShards++
+			inFlight += 2
+
+		// Otherwise, the shard failed for good. We'll note the first
+		// such failure, and signal the remaining shards to stop. Any
+		// shards already held by the destination are left for it to
+		// fail back, once it rejects a shard itself or times out the
+		// payment.
+		default:
+			if paymentErr != nil {
+				continue
+			}
+
+			paymentErr = result.err
+			if noRouteErr, ok := paymentErr.(*noRouteError); ok {
+				paymentErr = noRouteErr.err
+			}
+			close(abort)
+		}
+	}
+
+	// As the preimage proves that the payment was received, we'll
+	// consider the payment successful as soon as any shard was settled.
+	if len(routes) > 0 {
+		log.Debugf("Payment %x succeeded using %v shards",
+			payment.PaymentHash, len(routes))
+
+		return preimage, routes, nil
+	}
+
+	return [32]byte{}, nil, paymentErr
+}
+
+// supportsMultiPath returns whether the passed node advertises support for
+// receiving multi-path payments.
+func (r *ChannelRouter) supportsMultiPath(target *btcec.PublicKey) bool {
+	node, err := r.cfg.Graph.FetchLightningNode(target)
+	if err != nil {
+		return false
+	}
+
+	return node.Features.HasFeature(lnwire.MultiPathPaymentsOptional)
+}
@@ -154,6 +154,19 @@ type Config struct {
 	SendToSwitch func(firstHop [33]byte, htlcAdd *lnwire.UpdateAddHTLC,
 		circuit *sphinx.Circuit) ([sha256.Size]byte, error)
 
+	// ShardStore is used to persist each shard sent for a payment, along
+	// with its status.
+	ShardStore ShardStore
+
+	// MissionControlStore is used to persist the outcomes of past payment
+	// attempts, from which mission control estimates the probability of
+	// success of future attempts.
+	MissionControlStore MissionControlStore
+
+	// MissionControl holds the parameters of the probability model used by
+	// mission control.
+	MissionControl MissionControlConfig
+
 	// ChannelPruneExpiry is the duration used to determine if a channel
 	// should be pruned or not. If the delta between now and when the
 	// channel was last updated is greater than ChannelPruneExpiry, then
@@ -163,6 +176,11 @@ type Config struct {
 	// GraphPruneInterval is used as an interval to determine how often we
 	// should examine the channel graph to garbage collect zombie channels.
 	GraphPruneInterval time.Duration
+
+	// NotifyPaymentAttempt, if non-nil, is called once each payment
+	// attempt handed to the switch has been resolved, with the time it
+	// took to complete and whether the attempt succeeded.
+	NotifyPaymentAttempt func(latency time.Duration, success bool)
 }
 
 // routeTuple is an entry within the ChannelRouter's route cache. We cache
@@ -245,11 +263,12 @@ type ChannelRouter struct {
 	ntfnClientUpdates chan *topologyClientUpdate
 
 	// missionControl is a shared memory of sorts that executions of
-	// payment path finding use in order to remember which vertexes/edges
-	// were pruned from prior attempts. During SendPayment execution,
-	// errors sent by nodes are mapped into a vertex or edge to be pruned.
-	// Each run will then take into account this set of pruned
-	// vertexes/edges to reduce route failure and pass on graph information
+	// payment path finding use in order to remember the outcomes of prior
+	// attempts. During SendPayment execution, errors sent by nodes are
+	// mapped into a failure of a pair of nodes, while successful attempts
+	// are recorded for each pair along the route. Each run will then take
+	// into account the probability of success estimated from these
+	// results to reduce route failure and pass on graph information
 	// gained to the next execution.
 	missionControl *missionControl
 
@@ -283,12 +302,20 @@ func New(cfg Config) (*ChannelRouter, error) {
 		return nil, err
 	}
 
+	missionControl, err := newMissionControl(
+		cfg.Graph, selfNode, cfg.MissionControlStore,
+		cfg.MissionControl,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ChannelRouter{
 		cfg:               &cfg,
 		networkUpdates:    make(chan *routingMsg),
 		topologyClients:   make(map[uint64]*topologyClient),
 		ntfnClientUpdates: make(chan *topologyClientUpdate),
-		missionControl:    newMissionControl(cfg.Graph, selfNode),
+		missionControl:    missionControl,
 		channelEdgeMtx:    multimutex.NewMutex(),
 		selfNode:          selfNode,
 		routeCache:        make(map[routeTuple][]*Route),
@@ -1390,13 +1417,26 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 // generateSphinxPacket generates then encodes a sphinx packet which encodes
 // the onion route specified by the passed layer 3 route. The blob returned
 // from this function can immediately be included within an HTLC add packet to
-// be sent to the first hop within the route.
-func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
-	*sphinx.Circuit, error) {
+// be sent to the first hop within the route. If a keysend preimage is passed,
+// it's delivered to the final hop within an additional frame of the onion. A
+// non-zero multi-path total marks the HTLC as a shard of a payment of that
+// amount.
+func generateSphinxPacket(route *Route, paymentHash []byte,
+	keySendPreimage *[32]byte,
+	multiPathTotal lnwire.MilliSatoshi) ([]byte, *sphinx.Circuit, error) {
+
 	// First obtain all the public keys along the route which are contained
 	// in each hop.
-	nodes := make([]*btcec.PublicKey, len(route.Hops))
-	for i, hop := range route.Hops {
+	hops := route.Hops
+
+	// The additional frame carrying the preimage of a spontaneous payment
+	// is addressed to the final hop once more.
+	if keySendPreimage != nil {
+		hops = append(hops[:len(hops):len(hops)], hops[len(hops)-1])
+	}
+
+	nodes := make([]*btcec.PublicKey, len(hops))
+	for i, hop := range hops {
 		// We create a new instance of the public key to avoid possibly
 		// mutating the curve parameters, which are unset in a higher
 		// level in order to avoid spamming the logs.
@@ -1415,7 +1455,7 @@ func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
 	// Next we generate the per-hop payload which gives each node within
 	// the route the necessary information (fees, CLTV value, etc) to
 	// properly forward the payment.
-	hopPayloads := route.ToHopPayloads()
+	hopPayloads := route.ToHopPayloads(keySendPreimage, multiPathTotal)
 
 	log.Tracef("Constructed per-hop payloads for payment_hash=%x: %v",
 		paymentHash[:], spew.Sdump(hopPayloads))
@@ -1482,9 +1522,68 @@ type LightningPayment struct {
 	// indefinitely.
 	PayAttemptTimeout time.Duration
 
+	// MaxShards is the maximum number of shards the payment may be split
+	// into when sent using SendMultiPathPayment. Each shard is sent along
+	// its own route, carrying a part of the total amount. A value of zero
+	// or one disables splitting.
+	MaxShards uint32
+
+	// KeySendPreimage, if set, turns the payment into a spontaneous
+	// payment made without an invoice. The preimage is chosen by the
+	// sender, and delivered to the destination within the onion, allowing
+	// it to settle the payment. PaymentHash MUST be set to the hash of
+	// this preimage.
+	KeySendPreimage *[32]byte
+
+	// RouteHints represents the different routing hints that can be used
+	// to assist a payment in reaching its destination successfully. These
+	// hints will act as intermediate hops along the route.
+	//
+	// NOTE: This is optional unless required by the payment. When providing
+	// multiple routes, ensure the hop hints within each route are chained
+	// together and sorted in forward order in order to reach the
+	// destination successfully.
+	RouteHints [][]HopHint
+
+	// FeeLimit, if set, is the maximum total fee that may be paid to
+	// route the payment. Routes with higher fees aren't attempted.
+	FeeLimit *lnwire.MilliSatoshi
+
+	// OutgoingChannelID, if set, restricts the payment to leave our node
+	// through the given channel.
+	OutgoingChannelID *uint64
+
+	// LastHopChannelID, if set, restricts the payment to reach its
+	// destination through the given channel. Along with
+	// OutgoingChannelID, this allows a payment to be routed from our node
+	// back to itself, in order to rebalance our channels.
+	LastHopChannelID *uint64
+
 	// TODO(roasbeef): add e2e message?
 }
 
+// HopHint is a routing hint that contains the minimum information of a
+// channel required for an intermediate hop in a route to forward the payment
+// to the next. This should be ideally used for private channels, since they
+// are not publicly advertised to the network for routing.
+type HopHint struct {
+	// NodeID is the public key of the node at the start of the channel.
+	NodeID *btcec.PublicKey
+
+	// ChannelID is the unique identifier of the channel.
+	ChannelID uint64
+
+	// FeeBaseMSat is the base fee of the channel in millisatoshis.
+	FeeBaseMSat uint32
+
+	// FeeProportionalMillionths is the fee rate, in millionths of a
+	// satoshi, for every satoshi sent through the channel.
+	FeeProportionalMillionths uint32
+
+	// CLTVExpiryDelta is the time-lock delta of the channel.
+	CLTVExpiryDelta uint16
+}
+
 // SendPayment attempts to send a payment as described within the passed
 // LightningPayment. This function is blocking and will return either: when the
 // payment is successful, or all candidates routes have been attempted and
@@ -1492,6 +1591,9 @@ type LightningPayment struct {
 // will be returned which describes the path the successful payment traversed
 // within the network to reach the destination. Additionally, the payment
 // preimage will also be returned.
+//
+// NOTE: The payment is always sent in full along a single route. Use
+// SendMultiPathPayment to allow the payment to be split.
 func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route, error) {
 	log.Tracef("Dispatching route for lightning payment: %v",
 		newLogClosure(func() string {
@@ -1500,22 +1602,294 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		}),
 	)
 
+	params, err := r.newPaymentParams(payment)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	// Before starting the HTLC routing attempt, we'll create a fresh
+	// payment session which will report our errors back to mission
+	// control.
+	paySession := r.missionControl.NewPaymentSession(
+		payment.RouteHints, payment.Target,
+	)
+
+	preImage, route, err := r.sendPaymentShard(
+		payment, paySession, params, nil,
+	)
+	if noRouteErr, ok := err.(*noRouteError); ok {
+		err = noRouteErr.err
+	}
+
+	return preImage, route, err
+}
+
+// SendToRoute attempts to send a payment as described within the passed
+// LightningPayment along the passed routes, rather than along routes found by
+// the router itself. The routes are attempted in order, until either the
+// payment succeeds or all routes have failed. All routes must lead to the same
+// destination, delivering the same amount. The target and amount of the
+// payment are taken from the routes. If the payment succeeds, then the route
+// that the successful payment traversed is returned along with the payment
+// preimage.
+func (r *ChannelRouter) SendToRoute(routes []*Route,
+	payment *LightningPayment) ([32]byte, *Route, error) {
+
+	if len(routes) == 0 {
+		return [32]byte{}, nil, fmt.Errorf("no routes provided")
+	}
+
+	// We'll ensure that all routes pay the same amount to the same
+	// destination, as they're all meant to complete the same payment.
+	for _, route := range routes {
+		if len(route.Hops) == 0 {
+			return [32]byte{}, nil, fmt.Errorf("route has no hops")
+		}
+	}
+
+	lastHop := routes[0].Hops[len(routes[0].Hops)-1]
+	target := Vertex(lastHop.Channel.Node.PubKeyBytes)
+	amt := lastHop.AmtToForward
+	for _, route := range routes[1:] {
+		lastHop := route.Hops[len(route.Hops)-1]
+		switch {
+		case Vertex(lastHop.Channel.Node.PubKeyBytes) != target:
+			return [32]byte{}, nil, fmt.Errorf("routes lead to " +
+				"different destinations")
+
+		case lastHop.AmtToForward != amt:
+			return [32]byte{}, nil, fmt.Errorf("routes deliver " +
+				"different amounts")
+		}
+	}
+
+	targetPub, err := lastHop.Channel.Node.PubKey()
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	routePayment := *payment
+	routePayment.Target = targetPub
+	routePayment.Amount = amt
+
+	log.Tracef("Dispatching payment %x along %v pre-built routes",
+		payment.PaymentHash, len(routes))
+
+	params, err := r.newPaymentParams(&routePayment)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	// The payment session will hand out the passed routes in order, while
+	// still reporting the outcome of each attempt to mission control.
+	paySession := r.missionControl.NewPaymentSessionFromRoutes(routes)
+
+	preImage, route, err := r.sendPaymentShard(
+		&routePayment, paySession, params, nil,
+	)
+	if noRouteErr, ok := err.(*noRouteError); ok {
+		err = noRouteErr.err
+	}
+
+	return preImage, route, err
+}
+
+// HopSpec identifies a hop of a route to be built by BuildRoute. At least one
+// of its fields must be set.
+type HopSpec struct {
+	// Node is the node the hop leads to. If unset, it is derived from the
+	// channel.
+	Node Vertex
+
+	// ChannelID is the channel the hop travels along. If unset, the
+	// cheapest channel between the previous node and Node is selected.
+	ChannelID uint64
+}
+
+// BuildRoute returns a route that delivers the given amount along the passed
+// hops, starting at our own node. The fees and time locks of the route are
+// computed from the channel policies stored within the channel graph, as if
+// the route had been found by path finding. The final hop will use the passed
+// CLTV delta.
+func (r *ChannelRouter) BuildRoute(amt lnwire.MilliSatoshi, hops []HopSpec,
+	finalCLTVDelta uint16) (*Route, error) {
+
+	if len(hops) == 0 {
+		return nil, fmt.Errorf("no hops provided")
+	}
+
+	// We'll fetch the current block height so we can properly calculate
+	// the required HTLC time locks within the route.
+	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll now walk the hops in forward order, locating the policy of
+	// the channel that each hop travels along.
+	sourceVertex := Vertex(r.selfNode.PubKeyBytes)
+	prevNode := sourceVertex
+	pathEdges := make([]*ChannelHop, 0, len(hops))
+	for i, hop := range hops {
+		var (
+			edge *ChannelHop
+			err  error
+		)
+		if hop.ChannelID != 0 {
+			edge, err = r.fetchHopChannel(prevNode, hop.ChannelID)
+		} else {
+			edge, err = r.selectHopChannel(prevNode, hop.Node, amt)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to build hop %v: %v", i,
+				err)
+		}
+
+		nextNode := Vertex(edge.Node.PubKeyBytes)
+		if hop.Node != (Vertex{}) && hop.Node != nextNode {
+			return nil, fmt.Errorf("channel %v of hop %v doesn't "+
+				"lead to node %v", edge.ChannelID, i, hop.Node)
+		}
+
+		pathEdges = append(pathEdges, edge)
+		prevNode = nextNode
+	}
+
+	return newRoute(
+		amt, sourceVertex, pathEdges, uint32(currentHeight),
+		finalCLTVDelta,
+	)
+}
+
+// fetchHopChannel returns the passed channel, directed such that it leads away
+// from the given node.
+func (r *ChannelRouter) fetchHopChannel(from Vertex,
+	chanID uint64) (*ChannelHop, error) {
+
+	info, policy1, policy2, err := r.cfg.Graph.FetchChannelEdgesByID(chanID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch channel %v: %v", chanID,
+			err)
+	}
+
+	// The first policy governs HTLCs forwarded by the first node of the
+	// channel, and the second one those forwarded by the second node.
+	var policy *channeldb.ChannelEdgePolicy
+	switch from {
+	case info.NodeKey1Bytes:
+		policy = policy1
+	case info.NodeKey2Bytes:
+		policy = policy2
+	default:
+		return nil, fmt.Errorf("channel %v isn't connected to node %v",
+			chanID, from)
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("no policy known for channel %v from "+
+			"node %v", chanID, from)
+	}
+
+	return &ChannelHop{
+		Capacity:          info.Capacity,
+		Chain:             info.ChainHash,
+		ChannelEdgePolicy: policy,
+	}, nil
+}
+
+// selectHopChannel returns the channel with the lowest fee for the passed
+// amount out of the enabled channels leading from one node to another.
+func (r *ChannelRouter) selectHopChannel(from, to Vertex,
+	amt lnwire.MilliSatoshi) (*ChannelHop, error) {
+
+	if to == (Vertex{}) {
+		return nil, fmt.Errorf("either a node or a channel must be " +
+			"specified")
+	}
+
+	fromPub, err := btcec.ParsePubKey(from[:], btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	fromNode, err := r.cfg.Graph.FetchLightningNode(fromPub)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch node %v: %v", from, err)
+	}
+
 	var (
-		preImage  [32]byte
-		sendError error
+		bestEdge *ChannelHop
+		bestFee  lnwire.MilliSatoshi
 	)
+	err = fromNode.ForEachChannel(nil, func(_ *bolt.Tx,
+		info *channeldb.ChannelEdgeInfo,
+		outEdge, _ *channeldb.ChannelEdgePolicy) error {
 
-	// errFailedFeeChans is a map of the short channel ID's that were the
-	// source of fee related routing failures during this payment attempt.
-	// We'll use this map to prune out channels when the first error may
-	// not require pruning, but any subsequent ones do.
-	errFailedFeeChans := make(map[lnwire.ShortChannelID]struct{})
+		if Vertex(outEdge.Node.PubKeyBytes) != to {
+			return nil
+		}
+
+		edgeFlags := lnwire.ChanUpdateFlag(outEdge.Flags)
+		if edgeFlags&lnwire.ChanUpdateDisabled != 0 {
+			return nil
+		}
+
+		fee := computeFee(amt, outEdge)
+		if bestEdge != nil && fee >= bestFee {
+			return nil
+		}
+
+		bestFee = fee
+		bestEdge = &ChannelHop{
+			Capacity:          info.Capacity,
+			Chain:             info.ChainHash,
+			ChannelEdgePolicy: outEdge,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if bestEdge == nil {
+		return nil, fmt.Errorf("no enabled channel from node %v to "+
+			"node %v", from, to)
+	}
+
+	return bestEdge, nil
+}
+
+// paymentParams holds the parameters that are shared by all shards of a
+// payment.
+type paymentParams struct {
+	// currentHeight is the block height used to calculate the time locks
+	// of the routes.
+	currentHeight uint32
+
+	// finalCLTVDelta is the CLTV delta used for the final hop.
+	finalCLTVDelta uint16
+
+	// payAttemptTimeout is the duration after which the payment attempt
+	// is abandoned.
+	payAttemptTimeout time.Duration
+
+	// deadline is the time at which the payment attempt is abandoned.
+	deadline time.Time
+
+	// totalAmt is the full amount of the payment. Shards carrying less
+	// than this amount signal it to the destination, such that they're
+	// held until all of them have arrived.
+	totalAmt lnwire.MilliSatoshi
+}
+
+// newPaymentParams gathers the parameters that are shared by all shards of
+// the passed payment.
+func (r *ChannelRouter) newPaymentParams(
+	payment *LightningPayment) (*paymentParams, error) {
 
 	// We'll also fetch the current block height so we can properly
 	// calculate the required HTLC time locks within the route.
 	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
 	if err != nil {
-		return preImage, nil, err
+		return nil, err
 	}
 
 	var finalCLTVDelta uint16
@@ -1532,28 +1906,68 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		payAttemptTimeout = payment.PayAttemptTimeout
 	}
 
-	timeoutChan := time.After(payAttemptTimeout)
+	return &paymentParams{
+		currentHeight:     uint32(currentHeight),
+		finalCLTVDelta:    finalCLTVDelta,
+		payAttemptTimeout: payAttemptTimeout,
+		deadline:          time.Now().Add(payAttemptTimeout),
+		totalAmt:          payment.Amount,
+	}, nil
+}
+
+// noRouteError is returned when no route capable of carrying the amount of a
+// payment, or of a shard of it, could be found. Splitting the amount into
+// smaller shards may still allow it to be routed.
+type noRouteError struct {
+	err error
+}
 
-	// Before starting the HTLC routing attempt, we'll create a fresh
-	// payment session which will report our errors back to mission
-	// control.
-	paySession := r.missionControl.NewPaymentSession()
+// Error returns a human readable description of the error.
+//
+// NOTE: Part of the error interface.
+func (e *noRouteError) Error() string {
+	return e.err.Error()
+}
+
+// sendPaymentShard attempts to send the full amount of the passed payment
+// along a single route, retrying along alternative routes upon failure. Routes
+// are requested from the passed payment session. The attempt is abandoned once
+// the payment deadline passes, or the abort channel is closed. If no route
+// capable of carrying the amount can be found, a noRouteError is returned.
+func (r *ChannelRouter) sendPaymentShard(payment *LightningPayment,
+	paySession *paymentSession, params *paymentParams,
+	abort <-chan struct{}) ([32]byte, *Route, error) {
+
+	var (
+		preImage  [32]byte
+		sendError error
+	)
+
+	// errFailedFeeChans is a map of the short channel ID's that were the
+	// source of fee related routing failures during this payment attempt.
+	// We'll use this map to prune out channels when the first error may
+	// not require pruning, but any subsequent ones do.
+	errFailedFeeChans := make(map[lnwire.ShortChannelID]struct{})
 
 	// We'll continue until either our payment succeeds, or we encounter a
 	// critical error during path finding.
 	for {
 		// Before we attempt this next payment, we'll check to see if
-		// either we've gone past the payment attempt timeout, or the
-		// router is exiting. In either case, we'll stop this payment
-		// attempt short.
-		select {
-		case <-timeoutChan:
+		// either we've gone past the payment attempt timeout, the
+		// payment was aborted, or the router is exiting. In either
+		// case, we'll stop this payment attempt short.
+		if time.Now().After(params.deadline) {
 			errStr := fmt.Sprintf("payment attempt not completed "+
-				"before timeout of %v", payAttemptTimeout)
+				"before timeout of %v", params.payAttemptTimeout)
 
 			return preImage, nil, newErr(
 				ErrPaymentAttemptTimeout, errStr,
 			)
+		}
+
+		select {
+		case <-abort:
+			return preImage, nil, fmt.Errorf("payment aborted")
 
 		case <-r.quit:
 			return preImage, nil, fmt.Errorf("router shutting down")
@@ -1568,18 +1982,20 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		// of the channel graph and our past HTLC routing
 		// successes/failures.
 		route, err := paySession.RequestRoute(
-			payment, uint32(currentHeight), finalCLTVDelta,
+			payment, params.currentHeight, params.finalCLTVDelta,
 		)
 		if err != nil {
 			// If we're unable to successfully make a payment using
 			// any of the routes we've found, then return an error.
 			if sendError != nil {
-				return [32]byte{}, nil, fmt.Errorf("unable to "+
-					"route payment to destination: %v",
-					sendError)
+				return [32]byte{}, nil, &noRouteError{
+					err: fmt.Errorf("unable to route "+
+						"payment to destination: %v",
+						sendError),
+				}
 			}
 
-			return preImage, nil, err
+			return preImage, nil, &noRouteError{err: err}
 		}
 
 		log.Tracef("Attempting to send payment %x, using route: %v",
@@ -1588,11 +2004,19 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 			}),
 		)
 
+		// If this is only a shard of the payment, the destination is
+		// told the total amount to expect.
+		var multiPathTotal lnwire.MilliSatoshi
+		if payment.Amount < params.totalAmt {
+			multiPathTotal = params.totalAmt
+		}
+
 		// Generate the raw encoded sphinx packet to be included along
 		// with the htlcAdd message that we send directly to the
 		// switch.
 		onionBlob, circuit, err := generateSphinxPacket(
-			route, payment.PaymentHash[:],
+			route, payment.PaymentHash[:], payment.KeySendPreimage,
+			multiPathTotal,
 		)
 		if err != nil {
 			return preImage, nil, err
@@ -1608,13 +2032,41 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		}
 		copy(htlcAdd.OnionBlob[:], onionBlob)
 
+		// Before sending it, we'll record this attempt as a new shard
+		// of the payment.
+		shard := newPaymentShard(payment.Amount, route)
+		err = r.cfg.ShardStore.AddPaymentShard(payment.PaymentHash, shard)
+		if err != nil {
+			return preImage, nil, err
+		}
+
 		// Attempt to send this payment through the network to complete
 		// the payment. If this attempt fails, then we'll continue on
 		// to the next available route.
 		firstHop := route.Hops[0].Channel.Node.PubKeyBytes
+		attemptStart := time.Now()
 		preImage, sendError = r.cfg.SendToSwitch(
 			firstHop, htlcAdd, circuit,
 		)
+		if r.cfg.NotifyPaymentAttempt != nil {
+			r.cfg.NotifyPaymentAttempt(
+				time.Since(attemptStart), sendError == nil,
+			)
+		}
+
+		shardStatus := channeldb.ShardSucceeded
+		if sendError != nil {
+			shardStatus = channeldb.ShardFailed
+		}
+		err = r.cfg.ShardStore.UpdatePaymentShard(
+			payment.PaymentHash, shard.ShardID, shardStatus,
+		)
+		if err != nil {
+			log.Errorf("Unable to update status of shard %v of "+
+				"payment %x: %v", shard.ShardID,
+				payment.PaymentHash, err)
+		}
+
 		if sendError != nil {
 			// An error occurred when attempting to send the
 			// payment, depending on the error type, we'll either
@@ -1636,13 +2088,17 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 
 			switch onionErr := fErr.FailureMessage.(type) {
 			// If the end destination didn't know they payment
-			// hash, then we'll terminate immediately.
+			// hash, then we'll terminate immediately. As the HTLC
+			// did reach the destination, we'll report the route as
+			// successful to mission control.
 			case *lnwire.FailUnknownPaymentHash:
+				paySession.ReportSuccess(route)
 				return preImage, nil, sendError
 
 			// If we sent the wrong amount to the destination, then
 			// we'll exit early.
 			case *lnwire.FailIncorrectPaymentAmount:
+				paySession.ReportSuccess(route)
 				return preImage, nil, sendError
 
 			// If the time-lock that was extended to the final node
@@ -1718,6 +2174,7 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 
 					pruneEdgeFailure(
 						paySession, route, errSource,
+						false,
 					)
 				}
 
@@ -1730,6 +2187,7 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 				if ok {
 					pruneEdgeFailure(
 						paySession, route, errSource,
+						false,
 					)
 					continue
 				}
@@ -1765,12 +2223,16 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 						"update for onion error: %v", err)
 				}
 
-				pruneEdgeFailure(paySession, route, errSource)
+				pruneEdgeFailure(
+					paySession, route, errSource, false,
+				)
 				continue
 
 			// It's likely that the outgoing channel didn't have
 			// sufficient capacity, so we'll prune this edge for
 			// now, and continue onwards with our path finding.
+			// Mission control will only consider the channel to
+			// fail payments of at least this amount.
 			case *lnwire.FailTemporaryChannelFailure:
 				update := onionErr.Update
 				if err := r.applyChannelUpdate(update); err != nil {
@@ -1778,7 +2240,9 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 						"update for onion error: %v", err)
 				}
 
-				pruneEdgeFailure(paySession, route, errSource)
+				pruneEdgeFailure(
+					paySession, route, errSource, true,
+				)
 				continue
 
 			// If the send fail due to a node not having the
@@ -1827,7 +2291,9 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 			// we'll note this (exclude the vertex/edge), and
 			// continue with the rest of the routes.
 			case *lnwire.FailPermanentChannelFailure:
-				pruneEdgeFailure(paySession, route, errSource)
+				pruneEdgeFailure(
+					paySession, route, errSource, false,
+				)
 				continue
 
 			default:
@@ -1835,6 +2301,10 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 			}
 		}
 
+		// The payment succeeded, so we'll let mission control know
+		// that each hop along the route was able to carry it.
+		paySession.ReportSuccess(route)
+
 		return preImage, route, nil
 	}
 }
@@ -1862,14 +2332,15 @@ func pruneVertexFailure(paySession *paymentSession, route *Route,
 
 	// Once we've located the vertex, we'll report this failure to
 	// missionControl and restart path finding.
-	paySession.ReportVertexFailure(errNode)
+	paySession.ReportVertexFailure(route, errNode)
 }
 
 // pruneEdgeFailure will attempts to prune an edge from the current available
 // edges of the target payment session in response to an encountered routing
-// error.
+// error. If amtRelated is true, the error indicates that the edge is unable to
+// carry the amount of the HTLC, rather than being unusable altogether.
 func pruneEdgeFailure(paySession *paymentSession, route *Route,
-	errSource *btcec.PublicKey) {
+	errSource *btcec.PublicKey, amtRelated bool) {
 
 	// As this error indicates that the target channel was unable to carry
 	// this HTLC (for w/e reason), we'll query the index to find the
@@ -1892,7 +2363,28 @@ func pruneEdgeFailure(paySession *paymentSession, route *Route,
 
 	// If the channel was found, then we'll inform mission control of this
 	// failure so future attempts avoid this link temporarily.
-	paySession.ReportChannelFailure(badChan.ChannelID)
+	paySession.ReportChannelFailure(route, badChan.ChannelID, amtRelated)
+}
+
+// QueryMissionControl returns the outcomes of past payment attempts recorded by
+// mission control, for each pair of nodes that was attempted to route through.
+func (r *ChannelRouter) QueryMissionControl() []*channeldb.MissionControlPair {
+	return r.missionControl.QueryHistory()
+}
+
+// ImportMissionControl merges the passed node pair results into the history of
+// mission control. Results are only applied if they are more recent than those
+// already known for the pair.
+func (r *ChannelRouter) ImportMissionControl(
+	pairs []*channeldb.MissionControlPair) error {
+
+	return r.missionControl.ImportHistory(pairs)
+}
+
+// ResetMissionControl clears the history of mission control, returning it to
+// a state as if no payment attempts have been made.
+func (r *ChannelRouter) ResetMissionControl() error {
+	return r.missionControl.ResetHistory()
 }
 
 // applyChannelUpdate applies a channel update directly to the database,
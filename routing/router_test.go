@@ -6,6 +6,7 @@ import (
 	"image/color"
 	"math/rand"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -33,8 +34,53 @@ type testCtx struct {
 	chain *mockChain
 
 	chainView *mockChainView
+
+	shardStore *mockShardStore
+}
+
+// mockShardStore is an in-memory implementation of the ShardStore interface.
+type mockShardStore struct {
+	sync.Mutex
+	shards map[[32]byte][]*channeldb.PaymentShard
+}
+
+func newMockShardStore() *mockShardStore {
+	return &mockShardStore{
+		shards: make(map[[32]byte][]*channeldb.PaymentShard),
+	}
 }
 
+func (m *mockShardStore) AddPaymentShard(paymentHash [32]byte,
+	shard *channeldb.PaymentShard) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	shard.ShardID = uint64(len(m.shards[paymentHash]) + 1)
+	shardCopy := *shard
+	m.shards[paymentHash] = append(m.shards[paymentHash], &shardCopy)
+
+	return nil
+}
+
+func (m *mockShardStore) UpdatePaymentShard(paymentHash [32]byte,
+	shardID uint64, status channeldb.ShardStatus) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	for _, shard := range m.shards[paymentHash] {
+		if shard.ShardID == shardID {
+			shard.Status = status
+			return nil
+		}
+	}
+
+	return channeldb.ErrPaymentShardNotFound
+}
+
+var _ ShardStore = (*mockShardStore)(nil)
+
 func (c *testCtx) RestartRouter() error {
 	// First, we'll reset the chainView's state as it doesn't persist the
 	// filter between restarts.
@@ -50,8 +96,10 @@ func (c *testCtx) RestartRouter() error {
 			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 			return [32]byte{}, nil
 		},
-		ChannelPruneExpiry: time.Hour * 24,
-		GraphPruneInterval: time.Hour * 2,
+		ShardStore:          c.shardStore,
+		MissionControlStore: c.graph.Database(),
+		ChannelPruneExpiry:  time.Hour * 24,
+		GraphPruneInterval:  time.Hour * 2,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to create router %v", err)
@@ -117,6 +165,7 @@ func createTestCtx(startingHeight uint32, testGraph ...string) (*testCtx, func()
 	// be populated.
 	chain := newMockChain(startingHeight)
 	chainView := newMockChainView(chain)
+	shardStore := newMockShardStore()
 	router, err := New(Config{
 		Graph:     graph,
 		Chain:     chain,
@@ -126,8 +175,10 @@ func createTestCtx(startingHeight uint32, testGraph ...string) (*testCtx, func()
 
 			return [32]byte{}, nil
 		},
-		ChannelPruneExpiry: time.Hour * 24,
-		GraphPruneInterval: time.Hour * 2,
+		ShardStore:          shardStore,
+		MissionControlStore: graph.Database(),
+		ChannelPruneExpiry:  time.Hour * 24,
+		GraphPruneInterval:  time.Hour * 2,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to create router %v", err)
@@ -137,11 +188,12 @@ func createTestCtx(startingHeight uint32, testGraph ...string) (*testCtx, func()
 	}
 
 	ctx := &testCtx{
-		router:    router,
-		graph:     graph,
-		aliases:   aliasMap,
-		chain:     chain,
-		chainView: chainView,
+		router:     router,
+		graph:      graph,
+		aliases:    aliasMap,
+		chain:      chain,
+		chainView:  chainView,
+		shardStore: shardStore,
 	}
 
 	cleanUp := func() {
@@ -280,6 +332,130 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 	}
 }
 
+// TestSendMultiPathPayment asserts that a payment that can't be routed in full
+// due to the limited balance of our channels is split into several shards, but
+// only if the destination supports multi-path payments.
+func TestSendMultiPathPayment(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// We'll send 16k satoshis to satoshi, which can be reached either
+	// directly, or through luo ji. Neither of our channels has enough
+	// balance to carry the full amount.
+	var payHash [32]byte
+	payment := LightningPayment{
+		Target:      ctx.aliases["satoshi"],
+		Amount:      lnwire.NewMSatFromSatoshis(16000),
+		PaymentHash: payHash,
+		MaxShards:   4,
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	sourcePub, err := ctx.router.selfNode.PubKey()
+	if err != nil {
+		t.Fatalf("unable to get source pubkey: %v", err)
+	}
+
+	// The switch will only accept HTLCs that fit within the remaining
+	// balance of the channel to the first hop.
+	var (
+		balanceMtx sync.Mutex
+		balances   map[[33]byte]lnwire.MilliSatoshi
+	)
+	resetBalances := func() {
+		balanceMtx.Lock()
+		defer balanceMtx.Unlock()
+
+		balances = make(map[[33]byte]lnwire.MilliSatoshi)
+		for _, alias := range []string{"satoshi", "luoji"} {
+			var pub [33]byte
+			copy(pub[:], ctx.aliases[alias].SerializeCompressed())
+			balances[pub] = lnwire.NewMSatFromSatoshis(10000)
+		}
+	}
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		htlcAdd *lnwire.UpdateAddHTLC,
+		_ *sphinx.Circuit) ([32]byte, error) {
+
+		balanceMtx.Lock()
+		defer balanceMtx.Unlock()
+
+		if balances[n] < htlcAdd.Amount {
+			return [32]byte{}, &htlcswitch.ForwardingError{
+				ErrorSource:    sourcePub,
+				FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+			}
+		}
+		balances[n] -= htlcAdd.Amount
+
+		return preImage, nil
+	}
+
+	// As satoshi doesn't advertise support for multi-path payments yet,
+	// the payment should fail.
+	resetBalances()
+	_, _, err = ctx.router.SendMultiPathPayment(&payment)
+	if err == nil {
+		t.Fatalf("payment should have failed")
+	}
+
+	// Now we'll have satoshi advertise support for multi-path payments,
+	// after which the payment should be split and succeed.
+	satoshi, err := ctx.graph.FetchLightningNode(ctx.aliases["satoshi"])
+	if err != nil {
+		t.Fatalf("unable to fetch node: %v", err)
+	}
+	satoshi.Features.Set(lnwire.MultiPathPaymentsOptional)
+	if err := ctx.graph.AddLightningNode(satoshi); err != nil {
+		t.Fatalf("unable to update node: %v", err)
+	}
+
+	resetBalances()
+	paymentPreImage, routes, err := ctx.router.SendMultiPathPayment(
+		&payment,
+	)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+
+	if !bytes.Equal(paymentPreImage[:], preImage[:]) {
+		t.Fatalf("incorrect preimage used: expected %x got %x",
+			preImage[:], paymentPreImage[:])
+	}
+
+	// The payment should have been split in two, with the shards taking
+	// different first hops.
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes, got %v", len(routes))
+	}
+	firstHop0 := routes[0].Hops[0].Channel.Node.PubKeyBytes
+	firstHop1 := routes[1].Hops[0].Channel.Node.PubKeyBytes
+	if firstHop0 == firstHop1 {
+		t.Fatalf("expected shards to take different first hops")
+	}
+
+	// Finally, the settled shards recorded in the shard store should add
+	// up to the payment amount.
+	var settledAmt lnwire.MilliSatoshi
+	for _, shard := range ctx.shardStore.shards[payHash] {
+		if shard.Status == channeldb.ShardSucceeded {
+			settledAmt += shard.Amount
+		}
+	}
+	if settledAmt != payment.Amount {
+		t.Fatalf("expected settled shards to pay %v, got %v",
+			payment.Amount, settledAmt)
+	}
+}
+
 // TestSendPaymentErrorRepeatedFeeInsufficient tests that if we receive
 // multiple fee related errors from a channel that we're attempting to route
 // through, then we'll prune the channel after the second attempt.
@@ -577,7 +753,9 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 		return preImage, nil
 	}
 
-	ctx.router.missionControl.ResetHistory()
+	if err := ctx.router.missionControl.ResetHistory(); err != nil {
+		t.Fatalf("unable to reset mission control: %v", err)
+	}
 
 	// When we try to dispatch that payment, we should receive an error as
 	// both attempts should fail and cause both routes to be pruned.
@@ -592,7 +770,9 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 		t.Fatalf("expected UnknownNextPeer instead got: %v", err)
 	}
 
-	ctx.router.missionControl.ResetHistory()
+	if err := ctx.router.missionControl.ResetHistory(); err != nil {
+		t.Fatalf("unable to reset mission control: %v", err)
+	}
 
 	// Next, we'll modify the SendToSwitch method to indicate that luo ji
 	// wasn't originally online. This should also halt the send all
@@ -620,7 +800,9 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 		t.Fatalf("expected UnknownNextPeer instead got: %v", err)
 	}
 
-	ctx.router.missionControl.ResetHistory()
+	if err := ctx.router.missionControl.ResetHistory(); err != nil {
+		t.Fatalf("unable to reset mission control: %v", err)
+	}
 
 	// Finally, we'll modify the SendToSwitch function to indicate that the
 	// roasbeef -> luoji channel has insufficient capacity.
@@ -1195,8 +1377,10 @@ func TestWakeUpOnStaleBranch(t *testing.T) {
 			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 			return [32]byte{}, nil
 		},
-		ChannelPruneExpiry: time.Hour * 24,
-		GraphPruneInterval: time.Hour * 2,
+		ShardStore:          ctx.shardStore,
+		MissionControlStore: ctx.graph.Database(),
+		ChannelPruneExpiry:  time.Hour * 24,
+		GraphPruneInterval:  time.Hour * 2,
 	})
 	if err != nil {
 		t.Fatalf("unable to create router %v", err)
@@ -1605,8 +1789,8 @@ func TestFindPathFeeWeighting(t *testing.T) {
 	// the edge weighting, we should select the direct path over the 2 hop
 	// path even though the direct path has a higher potential time lock.
 	path, err := findPath(
-		nil, ctx.graph, sourceNode, target, ignoreVertex, ignoreEdge,
-		amt,
+		nil, ctx.graph, nil, sourceNode, target, ignoreVertex,
+		ignoreEdge, amt, nil, 0, noFeeLimit,
 	)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
@@ -1860,3 +2044,304 @@ func TestIsStaleEdgePolicy(t *testing.T) {
 		t.Fatalf("router failed to detect fresh edge policy")
 	}
 }
+
+// TestBuildRoute tests that routes built from a list of hops carry the fees
+// and time locks dictated by the stored channel policies, and that they can be
+// reconstructed from their hops.
+func TestBuildRoute(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	luoji := NewVertex(ctx.aliases["luoji"])
+	amt := lnwire.NewMSatFromSatoshis(1000)
+	const finalCLTVDelta = 40
+
+	// We'll build a route from roasbeef to luo ji through satoshi. The
+	// first hop is identified by its channel, while the channel of the
+	// second hop should be selected by the router.
+	hops := []HopSpec{
+		{ChannelID: 2340213491},
+		{Node: luoji},
+	}
+	route, err := ctx.router.BuildRoute(amt, hops, finalCLTVDelta)
+	if err != nil {
+		t.Fatalf("unable to build route: %v", err)
+	}
+
+	if len(route.Hops) != 2 {
+		t.Fatalf("expected 2 hops, got %v", len(route.Hops))
+	}
+	if route.Hops[0].Channel.Node.Alias != "satoshi" {
+		t.Fatalf("expected first hop to satoshi, got %v",
+			route.Hops[0].Channel.Node.Alias)
+	}
+	if route.Hops[1].Channel.ChannelID != 523452362 {
+		t.Fatalf("expected second hop along channel 523452362, got %v",
+			route.Hops[1].Channel.ChannelID)
+	}
+
+	// Satoshi charges a base fee of 10 msat and a fee rate of 1000 ppm for
+	// forwarding to luo ji.
+	expectedFee := lnwire.MilliSatoshi(10 + 1000)
+	if route.TotalFees != expectedFee {
+		t.Fatalf("expected fees of %v, got %v", expectedFee,
+			route.TotalFees)
+	}
+	if route.TotalAmount != amt+expectedFee {
+		t.Fatalf("expected total amount of %v, got %v",
+			amt+expectedFee, route.TotalAmount)
+	}
+	finalTimeLock := uint32(startingBlockHeight + finalCLTVDelta)
+	if route.Hops[1].OutgoingTimeLock != finalTimeLock {
+		t.Fatalf("expected final time lock of %v, got %v",
+			finalTimeLock, route.Hops[1].OutgoingTimeLock)
+	}
+
+	// Reconstructing the route from its hops should result in the same
+	// totals.
+	sourceVertex := Vertex(ctx.router.selfNode.PubKeyBytes)
+	rebuilt, err := NewRouteFromHops(
+		route.TotalTimeLock, sourceVertex, route.Hops,
+	)
+	if err != nil {
+		t.Fatalf("unable to create route from hops: %v", err)
+	}
+	if rebuilt.TotalAmount != route.TotalAmount ||
+		rebuilt.TotalFees != route.TotalFees {
+
+		t.Fatalf("rebuilt route mismatch: expected amount %v and "+
+			"fees %v, got %v and %v", route.TotalAmount,
+			route.TotalFees, rebuilt.TotalAmount, rebuilt.TotalFees)
+	}
+
+	// Hops with amounts that are inconsistent with their fees should be
+	// rejected.
+	badHops := []*Hop{
+		{
+			Channel:      route.Hops[0].Channel,
+			AmtToForward: route.Hops[0].AmtToForward + 1,
+			Fee:          route.Hops[0].Fee,
+		},
+		route.Hops[1],
+	}
+	_, err = NewRouteFromHops(route.TotalTimeLock, sourceVertex, badHops)
+	if err == nil {
+		t.Fatalf("expected inconsistent hops to be rejected")
+	}
+
+	// Building a route along a channel that isn't connected to the prior
+	// node should fail, as should specifying a channel that leads to
+	// another node than the one specified.
+	_, err = ctx.router.BuildRoute(
+		amt, []HopSpec{{ChannelID: 99999}}, finalCLTVDelta,
+	)
+	if err == nil {
+		t.Fatalf("expected route along unconnected channel to fail")
+	}
+	_, err = ctx.router.BuildRoute(
+		amt, []HopSpec{{Node: luoji, ChannelID: 2340213491}},
+		finalCLTVDelta,
+	)
+	if err == nil {
+		t.Fatalf("expected route with mismatched node to fail")
+	}
+}
+
+// TestSendToRoute tests that payments sent along a set of pre-built routes
+// attempt the routes in order, until one succeeds.
+func TestSendToRoute(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	luoji := NewVertex(ctx.aliases["luoji"])
+	amt := lnwire.NewMSatFromSatoshis(1000)
+
+	// We'll build two routes to luo ji, a direct one, and one through
+	// satoshi.
+	directRoute, err := ctx.router.BuildRoute(
+		amt, []HopSpec{{ChannelID: 689530843}}, DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to build route: %v", err)
+	}
+	satoshiRoute, err := ctx.router.BuildRoute(
+		amt, []HopSpec{{ChannelID: 2340213491}, {Node: luoji}},
+		DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to build route: %v", err)
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	sourceNode := ctx.router.selfNode
+
+	// We'll fail all attempts that have luo ji as the first hop, such that
+	// only the route through satoshi can succeed.
+	var numAttempts int
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		numAttempts++
+		if Vertex(n) == luoji {
+			pub, err := sourceNode.PubKey()
+			if err != nil {
+				return preImage, err
+			}
+			return [32]byte{}, &htlcswitch.ForwardingError{
+				ErrorSource:    pub,
+				FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+			}
+		}
+
+		return preImage, nil
+	}
+
+	var payHash [32]byte
+	payment := &LightningPayment{
+		PaymentHash: payHash,
+	}
+
+	// Sending along the direct route alone should fail once it has been
+	// attempted.
+	_, _, err = ctx.router.SendToRoute([]*Route{directRoute}, payment)
+	if err == nil {
+		t.Fatalf("expected payment along failing route to fail")
+	}
+	if numAttempts != 1 {
+		t.Fatalf("expected 1 attempt, got %v", numAttempts)
+	}
+
+	// When the route through satoshi is provided as a fallback, it should
+	// be used to complete the payment.
+	numAttempts = 0
+	paymentPreImage, route, err := ctx.router.SendToRoute(
+		[]*Route{directRoute, satoshiRoute}, payment,
+	)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if numAttempts != 2 {
+		t.Fatalf("expected 2 attempts, got %v", numAttempts)
+	}
+	if route != satoshiRoute {
+		t.Fatalf("expected payment to succeed along route through " +
+			"satoshi")
+	}
+	if paymentPreImage != preImage {
+		t.Fatalf("incorrect preimage used: expected %x got %x",
+			preImage[:], paymentPreImage[:])
+	}
+
+	// Routes that deliver different amounts can't complete the same
+	// payment.
+	smallRoute, err := ctx.router.BuildRoute(
+		amt/2, []HopSpec{{ChannelID: 689530843}}, DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to build route: %v", err)
+	}
+	_, _, err = ctx.router.SendToRoute(
+		[]*Route{directRoute, smallRoute}, payment,
+	)
+	if err == nil {
+		t.Fatalf("expected routes of different amounts to be rejected")
+	}
+}
+
+// TestSendCircularPayment tests that payments restricted to an outgoing and a
+// last hop channel are routed from our node back to itself along those
+// channels, that the fee limit of a payment is respected, and that the last
+// hop channel isn't used once the node preceding it failed.
+func TestSendCircularPayment(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	selfPub, err := ctx.router.selfNode.PubKey()
+	if err != nil {
+		t.Fatalf("unable to get self pubkey: %v", err)
+	}
+
+	// We'll send a payment to ourselves that leaves through our channel
+	// with luo ji, and returns through our channel with satoshi.
+	outgoingChanID := uint64(689530843)
+	lastHopChanID := uint64(2340213491)
+
+	var payHash [32]byte
+	payment := LightningPayment{
+		Target:            selfPub,
+		Amount:            lnwire.NewMSatFromSatoshis(1000),
+		PaymentHash:       payHash,
+		OutgoingChannelID: &outgoingChanID,
+		LastHopChannelID:  &lastHopChanID,
+	}
+
+	_, route, err := ctx.router.SendPayment(&payment)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+
+	expectedChans := []uint64{outgoingChanID, 523452362, lastHopChanID}
+	expectedNodes := []string{"luoji", "satoshi", "roasbeef"}
+	if len(route.Hops) != len(expectedChans) {
+		t.Fatalf("expected %v hops, got %v", len(expectedChans),
+			len(route.Hops))
+	}
+	for i, hop := range route.Hops {
+		if hop.Channel.ChannelID != expectedChans[i] {
+			t.Fatalf("expected hop %v along channel %v, got %v", i,
+				expectedChans[i], hop.Channel.ChannelID)
+		}
+		if hop.Channel.Node.Alias != expectedNodes[i] {
+			t.Fatalf("expected hop %v to %v, got %v", i,
+				expectedNodes[i], hop.Channel.Node.Alias)
+		}
+	}
+
+	// If the fee limit is lower than the fees of the route, the payment
+	// shouldn't be attempted.
+	feeLimit := route.TotalFees - 1
+	payment.FeeLimit = &feeLimit
+	ctx.router.cfg.SendToSwitch = func(_ [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		t.Fatalf("payment exceeding fee limit shouldn't be attempted")
+		return [32]byte{}, nil
+	}
+
+	_, _, err = ctx.router.SendPayment(&payment)
+	rErr, ok := err.(*routerError)
+	if !ok || rErr.code != ErrFeeLimitExceeded {
+		t.Fatalf("expected fee limit error, got %v", err)
+	}
+
+	// Once satoshi, the node preceding the last hop channel, failed to
+	// forward a payment, no route using that channel should be returned.
+	payment.FeeLimit = nil
+	session := ctx.router.missionControl.NewPaymentSession(nil, nil)
+	session.ReportVertexFailure(route, NewVertex(ctx.aliases["satoshi"]))
+
+	_, err = session.RequestRoute(&payment, startingBlockHeight, 0)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("expected no path to be found, got %v", err)
+	}
+}
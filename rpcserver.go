@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -20,11 +21,14 @@ import (
 
 	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chanacceptor"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/psbt"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/roasbeef/btcd/blockchain"
@@ -177,6 +181,27 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/BatchOpenChannel": {{
+			Entity: "onchain",
+			Action: "write",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/FinalizePsbtFunding": {{
+			Entity: "onchain",
+			Action: "write",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ChannelAcceptor": {{
+			Entity: "onchain",
+			Action: "write",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/CloseChannel": {{
 			Entity: "onchain",
 			Action: "write",
@@ -220,6 +245,22 @@ var (
 			Entity: "invoices",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/AddHoldInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/SettleInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/CancelInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/DeleteCanceledInvoices": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/LookupInvoice": {{
 			Entity: "invoices",
 			Action: "read",
@@ -296,6 +337,60 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ExportChannelBackup": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/ExportAllChannelBackups": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/VerifyChanBackup": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/RestoreChannelBackups": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/HtlcInterceptor": {{
+			Entity: "offchain",
+			Action: "read",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/SubscribeHtlcEvents": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/QueryMissionControl": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/ImportMissionControl": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ResetMissionControl": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/SendToRoute": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/BuildRoute": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/Rebalance": {{
+			Entity: "offchain",
+			Action: "write",
+		}, {
+			Entity: "invoices",
+			Action: "write",
+		}},
 	}
 )
 
@@ -313,6 +408,14 @@ type rpcServer struct {
 
 	server *server
 
+	// chanPredicate is used in the bidirectional ChannelAcceptor streaming
+	// method, to which the acceptor of each connected client is added.
+	chanPredicate *chanacceptor.ChainedAcceptor
+
+	// interceptorActive is set to 1 while a client of the HtlcInterceptor
+	// streaming method is connected. It must be used atomically.
+	interceptorActive int32
+
 	wg sync.WaitGroup
 
 	quit chan struct{}
@@ -323,10 +426,13 @@ type rpcServer struct {
 var _ lnrpc.LightningServer = (*rpcServer)(nil)
 
 // newRPCServer creates and returns a new instance of the rpcServer.
-func newRPCServer(s *server) *rpcServer {
+func newRPCServer(s *server,
+	chanPredicate *chanacceptor.ChainedAcceptor) *rpcServer {
+
 	return &rpcServer{
-		server: s,
-		quit:   make(chan struct{}, 1),
+		server:        s,
+		chanPredicate: chanPredicate,
+		quit:          make(chan struct{}, 1),
 	}
 }
 
@@ -387,6 +493,80 @@ func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
 	return r.server.cc.wallet.SendOutputs(outputs, feeRate)
 }
 
+// parseUpfrontShutdownAddress attempts to parse the passed address into the
+// delivery script that we'll commit to when opening a channel. If the address
+// is empty, then a nil script is returned, and no script will be committed to.
+func parseUpfrontShutdownAddress(address string) (lnwire.DeliveryAddress,
+	error) {
+
+	if address == "" {
+		return nil, nil
+	}
+
+	addr, err := btcutil.DecodeAddress(address, activeNetParams.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid close address: %v", err)
+	}
+	if !addr.IsForNet(activeNetParams.Params) {
+		return nil, fmt.Errorf("close address %v is not for the "+
+			"current network", address)
+	}
+
+	return txscript.PayToAddrScript(addr)
+}
+
+// determineCloseFeeRates determines the fee rate in sat/vbyte that the
+// negotiation of a cooperative channel closure should start at, along with the
+// maximum fee rate we'll accept, given an estimator and the fee related
+// parameters of the closure request. If the start fee rate was set explicitly
+// and exceeds the maximum fee rate, then an error is returned. Otherwise, an
+// estimated fee rate is clamped to the maximum fee rate.
+func determineCloseFeeRates(feeEstimator lnwallet.FeeEstimator,
+	targetConf int32, feePerByte, maxFeePerByte int64) (lnwallet.SatPerVByte,
+	lnwallet.SatPerVByte, error) {
+
+	if maxFeePerByte < 0 {
+		return 0, 0, fmt.Errorf("max fee rate must not be negative")
+	}
+
+	feeRate, err := determineFeePerVSize(feeEstimator, targetConf, feePerByte)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rpcsLog.Debugf("Target sat/vbyte for closing transaction: %v",
+		int64(feeRate))
+
+	if feeRate == 0 {
+		// If the fee rate returned isn't usable, then we'll fall back
+		// to an lax fee estimate.
+		feeRate, err = feeEstimator.EstimateFeePerVSize(6)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	// If the caller specified a maximum fee rate, then we'll ensure that
+	// it's sane with respect to the fee rate we'll start the negotiation
+	// at. A fee rate set by the caller is never overridden, while an
+	// estimated one is simply lowered to the maximum.
+	maxFeeRate := lnwallet.SatPerVByte(maxFeePerByte)
+	if maxFeeRate == 0 || feeRate <= maxFeeRate {
+		return feeRate, maxFeeRate, nil
+	}
+
+	if targetConf == 0 && feePerByte != 0 {
+		return 0, 0, fmt.Errorf("target fee rate of %v sat/vbyte "+
+			"exceeds max fee rate of %v sat/vbyte", int64(feeRate),
+			int64(maxFeeRate))
+	}
+
+	rpcsLog.Debugf("Clamping estimated sat/vbyte for closing transaction "+
+		"of %v to max of %v", int64(feeRate), int64(maxFeeRate))
+
+	return maxFeeRate, maxFeeRate, nil
+}
+
 // determineFeePerVSize will determine the fee in sat/vbyte that should be paid
 // given an estimator, a confirmation target, and a manual value for sat/byte.
 // A value is chosen based on the two free parameters as one, or both of them
@@ -723,12 +903,13 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 			"state must be below the local funding amount")
 	}
 
-	// Ensure that the user doesn't exceed the current soft-limit for
-	// channel size. If the funding amount is above the soft-limit, then
-	// we'll reject the request.
-	if localFundingAmt > maxFundingAmount {
+	// Ensure that the user doesn't exceed the configured maximum channel
+	// size. The funding manager will further restrict the size of the
+	// channel if the peer doesn't support large channels.
+	maxChanSize := btcutil.Amount(cfg.MaxChanSize)
+	if localFundingAmt > maxChanSize {
 		return fmt.Errorf("funding amount is too large, the max "+
-			"channel size is: %v", maxFundingAmount)
+			"channel size is: %v", maxChanSize)
 	}
 
 	// Restrict the size of the channel we'll actually open. At a later
@@ -779,13 +960,21 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 	rpcsLog.Debugf("[openchannel]: using fee of %v sat/vbyte for funding "+
 		"tx", int64(feeRate))
 
+	// If a close address was specified, then we'll commit to paying our
+	// funds to it upon a cooperative close of the channel.
+	shutdownScript, err := parseUpfrontShutdownAddress(in.CloseAddress)
+	if err != nil {
+		return err
+	}
+
 	// Instruct the server to trigger the necessary events to attempt to
 	// open a new channel. A stream is returned in place, this stream will
 	// be used to consume updates of the state of the pending channel.
 	updateChan, errChan := r.server.OpenChannel(
 		nodePubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		minHtlc, feeRate, in.Private, remoteCsvDelay,
+		minHtlc, feeRate, in.Private, remoteCsvDelay, shutdownScript,
+		in.PsbtFunding,
 	)
 
 	var outpoint wire.OutPoint
@@ -877,6 +1066,14 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 		return nil, err
 	}
 
+	// As the funding flow of a PSBT funded channel can only be resumed
+	// after the caller has received the PSBT, it requires the streaming
+	// version of this call.
+	if in.PsbtFunding {
+		return nil, fmt.Errorf("psbt funding is only supported by " +
+			"the streaming OpenChannel call")
+	}
+
 	localFundingAmt := btcutil.Amount(in.LocalFundingAmount)
 	remoteInitialBalance := btcutil.Amount(in.PushSat)
 	minHtlc := lnwire.MilliSatoshi(in.MinHtlcMsat)
@@ -910,10 +1107,16 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	rpcsLog.Tracef("[openchannel] target sat/vbyte for funding tx: %v",
 		int64(feeRate))
 
+	shutdownScript, err := parseUpfrontShutdownAddress(in.CloseAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	updateChan, errChan := r.server.OpenChannel(
 		nodepubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		minHtlc, feeRate, in.Private, remoteCsvDelay,
+		minHtlc, feeRate, in.Private, remoteCsvDelay, shutdownScript,
+		false,
 	)
 
 	select {
@@ -945,6 +1148,201 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	}
 }
 
+// BatchOpenChannel attempts to open multiple singly funded channels with
+// different remote peers, all funded by a single funding transaction. Either
+// all of the channels are opened, or none of them are. This call blocks until
+// the funding transaction has been broadcast.
+func (r *rpcServer) BatchOpenChannel(ctx context.Context,
+	in *lnrpc.BatchOpenChannelRequest) (*lnrpc.BatchOpenChannelResponse,
+	error) {
+
+	rpcsLog.Tracef("[batchopenchannel] request to open %v channels",
+		len(in.Channels))
+
+	// We don't allow new channels to be open while the server is still
+	// syncing, as otherwise we may not be able to obtain the relevant
+	// notifications.
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	// Creation of channels before the wallet syncs up is currently
+	// disallowed.
+	isSynced, _, err := r.server.cc.wallet.IsSynced()
+	if err != nil {
+		return nil, err
+	}
+	if !isSynced {
+		return nil, errors.New("channels cannot be created before the " +
+			"wallet is fully synced")
+	}
+
+	if len(in.Channels) == 0 {
+		return nil, fmt.Errorf("at least one channel must be specified")
+	}
+
+	// We'll validate each of the requested channels in the same manner as
+	// a regular channel opening, ensuring the entire batch is sane before
+	// contacting any of the peers.
+	reqs := make([]*openChanReq, 0, len(in.Channels))
+	for i, channel := range in.Channels {
+		nodePubKey, err := btcec.ParsePubKey(
+			channel.NodePubkey, btcec.S256(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pubkey for channel %v: "+
+				"%v", i, err)
+		}
+
+		localFundingAmt := btcutil.Amount(channel.LocalFundingAmount)
+		remoteInitialBalance := btcutil.Amount(channel.PushSat)
+
+		// Ensure that the initial balance of the remote party (if
+		// pushing satoshis) does not exceed the amount the local party
+		// has requested for funding.
+		if remoteInitialBalance >= localFundingAmt {
+			return nil, fmt.Errorf("amount pushed to remote peer "+
+				"for initial state must be below the local "+
+				"funding amount for channel %v", i)
+		}
+
+		// Restrict the size of the channel we'll actually open. At a
+		// later level, we'll ensure that the output we create after
+		// accounting for fees that a dust output isn't created.
+		if localFundingAmt < minChanFundingSize {
+			return nil, fmt.Errorf("channel %v is too small, the "+
+				"minimum channel size is: %v SAT", i,
+				int64(minChanFundingSize))
+		}
+
+		shutdownScript, err := parseUpfrontShutdownAddress(
+			channel.CloseAddress,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		reqs = append(reqs, &openChanReq{
+			targetPubkey:    nodePubKey,
+			localFundingAmt: localFundingAmt,
+			pushAmt: lnwire.NewMSatFromSatoshis(
+				remoteInitialBalance,
+			),
+			private:        channel.Private,
+			minHtlc:        lnwire.MilliSatoshi(channel.MinHtlcMsat),
+			remoteCsvDelay: uint16(channel.RemoteCsvDelay),
+			shutdownScript: shutdownScript,
+		})
+	}
+
+	// Based on the passed fee related parameters, we'll determine an
+	// appropriate fee rate for the shared funding transaction.
+	feeRate, err := determineFeePerVSize(
+		r.server.cc.feeEstimator, in.TargetConf, in.SatPerByte,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Tracef("[batchopenchannel] target sat/vbyte for funding tx: %v",
+		int64(feeRate))
+
+	chanPointsChan, errChan := r.server.BatchOpenChannel(reqs, feeRate)
+
+	select {
+	case err := <-errChan:
+		rpcsLog.Errorf("unable to batch open %v channels: %v",
+			len(reqs), err)
+		return nil, err
+
+	// Once the funding transaction has been broadcast, we'll return the
+	// pending channels to the caller, who can poll against the list of
+	// PendingChannels.
+	case chanPoints := <-chanPointsChan:
+		resp := &lnrpc.BatchOpenChannelResponse{
+			PendingChannels: make(
+				[]*lnrpc.PendingUpdate, 0, len(chanPoints),
+			),
+		}
+		for _, chanPoint := range chanPoints {
+			resp.PendingChannels = append(
+				resp.PendingChannels, &lnrpc.PendingUpdate{
+					Txid:        chanPoint.Hash[:],
+					OutputIndex: chanPoint.Index,
+				},
+			)
+		}
+
+		return resp, nil
+
+	case <-r.quit:
+		return nil, fmt.Errorf("server shutting down")
+	}
+}
+
+// FinalizePsbtFunding resumes the funding flow of a channel opened with
+// psbt_funding set, using the funding transaction crafted and signed by an
+// external wallet. The funding transaction can either be supplied as a
+// finalized PSBT, or as a raw transaction.
+func (r *rpcServer) FinalizePsbtFunding(ctx context.Context,
+	in *lnrpc.FinalizePsbtFundingRequest) (*lnrpc.FinalizePsbtFundingResponse,
+	error) {
+
+	var pendingChanID [32]byte
+	if len(in.PendingChanId) != len(pendingChanID) {
+		return nil, fmt.Errorf("pending channel ID must be %v bytes",
+			len(pendingChanID))
+	}
+	copy(pendingChanID[:], in.PendingChanId)
+
+	var (
+		fundingTx *wire.MsgTx
+		err       error
+	)
+	switch {
+	case len(in.SignedPsbt) != 0 && len(in.FinalRawTx) != 0:
+		return nil, fmt.Errorf("only one of signed_psbt and " +
+			"final_raw_tx can be set")
+
+	case len(in.SignedPsbt) != 0:
+		packet, err := psbt.NewFromRawBytes(
+			bytes.NewReader(in.SignedPsbt), false,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse psbt: %v", err)
+		}
+
+		fundingTx, err = psbt.Extract(packet)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract funding "+
+				"tx: %v", err)
+		}
+
+	case len(in.FinalRawTx) != 0:
+		fundingTx = &wire.MsgTx{}
+		err = fundingTx.Deserialize(bytes.NewReader(in.FinalRawTx))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse funding tx: %v",
+				err)
+		}
+
+	default:
+		return nil, fmt.Errorf("either signed_psbt or final_raw_tx " +
+			"must be set")
+	}
+
+	rpcsLog.Debugf("[finalizepsbtfunding] funding tx %v for "+
+		"pendingID(%x)", fundingTx.TxHash(), pendingChanID[:])
+
+	err = r.server.fundingMgr.processPsbtFunding(pendingChanID, fundingTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.FinalizePsbtFundingResponse{}, nil
+}
+
 // getChanPointFundingTxid returns the given channel point's funding txid in
 // raw bytes.
 func getChanPointFundingTxid(chanPoint *lnrpc.ChannelPoint) ([]byte, error) {
@@ -1073,26 +1471,15 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 	} else {
 		// Based on the passed fee related parameters, we'll determine
 		// an appropriate fee rate for the cooperative closure
-		// transaction.
-		feeRate, err := determineFeePerVSize(
+		// transaction, along with the maximum fee rate we'll accept.
+		feeRate, maxFeeRate, err := determineCloseFeeRates(
 			r.server.cc.feeEstimator, in.TargetConf, in.SatPerByte,
+			in.MaxSatPerByte,
 		)
 		if err != nil {
 			return err
 		}
 
-		rpcsLog.Debugf("Target sat/vbyte for closing transaction: %v",
-			int64(feeRate))
-
-		if feeRate == 0 {
-			// If the fee rate returned isn't usable, then we'll
-			// fall back to an lax fee estimate.
-			feeRate, err = r.server.cc.feeEstimator.EstimateFeePerVSize(6)
-			if err != nil {
-				return err
-			}
-		}
-
 		// Before we attempt the cooperative channel closure, we'll
 		// examine the channel to ensure that it doesn't have a
 		// lingering HTLC.
@@ -1106,8 +1493,9 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		// the htlc switch which will handle the negotiation and
 		// broadcast details.
 		feePerKw := feeRate.FeePerKWeight()
+		maxFeePerKw := maxFeeRate.FeePerKWeight()
 		updateChan, errChan = r.server.htlcSwitch.CloseLink(chanPoint,
-			htlcswitch.CloseRegular, feePerKw)
+			htlcswitch.CloseRegular, feePerKw, maxFeePerKw)
 	}
 out:
 	for {
@@ -1636,15 +2024,31 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 }
 
 // savePayment saves a successfully completed payment to the database for
-// historical record keeping.
-func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi, preImage []byte) error {
-
+// historical record keeping. If the payment was split into several shards, the
+// path of the first shard is recorded, along with the total fee paid and the
+// largest time lock of all shards. The details of each individual shard are
+// tracked separately by the router.
+func (r *rpcServer) savePayment(routes []*routing.Route,
+	amount lnwire.MilliSatoshi, preImage []byte) error {
+
+	route := routes[0]
 	paymentPath := make([][33]byte, len(route.Hops))
 	for i, hop := range route.Hops {
 		hopPub := hop.Channel.Node.PubKeyBytes
 		copy(paymentPath[i][:], hopPub[:])
 	}
 
+	var (
+		totalFees lnwire.MilliSatoshi
+		timeLock  uint32
+	)
+	for _, route := range routes {
+		totalFees += route.TotalFees
+		if route.TotalTimeLock > timeLock {
+			timeLock = route.TotalTimeLock
+		}
+	}
+
 	payment := &channeldb.OutgoingPayment{
 		Invoice: channeldb.Invoice{
 			Terms: channeldb.ContractTerm{
@@ -1653,8 +2057,8 @@ func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi
 			CreationDate: time.Now(),
 		},
 		Path:           paymentPath,
-		Fee:            route.TotalFees,
-		TimeLockLength: route.TotalTimeLock,
+		Fee:            totalFees,
+		TimeLockLength: timeLock,
 	}
 	copy(payment.PaymentPreimage[:], preImage)
 
@@ -1673,6 +2077,61 @@ func validatePayReqExpiry(payReq *zpay32.Invoice) error {
 	return nil
 }
 
+// unmarshallRouteHints converts the routing info found within a payment
+// request into the route hints used by the router to find a path to the
+// destination of the payment.
+func unmarshallRouteHints(
+	routingInfos [][]zpay32.ExtraRoutingInfo) [][]routing.HopHint {
+
+	routeHints := make([][]routing.HopHint, 0, len(routingInfos))
+	for _, routingInfo := range routingInfos {
+		routeHint := make([]routing.HopHint, 0, len(routingInfo))
+		for _, hop := range routingInfo {
+			routeHint = append(routeHint, routing.HopHint{
+				NodeID:                    hop.PubKey,
+				ChannelID:                 hop.ShortChanID,
+				FeeBaseMSat:               hop.FeeBaseMsat,
+				FeeProportionalMillionths: hop.FeeProportionalMillionths,
+				CLTVExpiryDelta:           hop.CltvExpDelta,
+			})
+		}
+		routeHints = append(routeHints, routeHint)
+	}
+
+	return routeHints
+}
+
+// newKeySendPreimage generates the preimage of a spontaneous payment requested
+// by the passed send request, after making sure the request is a valid
+// spontaneous payment. If the request isn't a spontaneous payment, nil is
+// returned.
+func newKeySendPreimage(req *lnrpc.SendRequest) (*[32]byte, error) {
+	if !req.KeySend {
+		return nil, nil
+	}
+
+	switch {
+	case req.PaymentRequest != "":
+		return nil, fmt.Errorf("keysend payments can't be made to a " +
+			"payment request")
+
+	case len(req.PaymentHash) != 0 || req.PaymentHashString != "":
+		return nil, fmt.Errorf("the payment hash of keysend payments " +
+			"is derived from a generated preimage, and can't be set")
+
+	case req.MaxShards > 1:
+		return nil, fmt.Errorf("keysend payments can't be split into " +
+			"multiple shards")
+	}
+
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		return nil, err
+	}
+
+	return &preimage, nil
+}
+
 // SendPayment dispatches a bi-directional streaming RPC for sending payments
 // through the Lightning Network. A single RPC invocation creates a persistent
 // bi-directional stream allowing clients to rapidly send payments through the
@@ -1681,10 +2140,13 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 	// For each payment we need to know the msat amount, the destination
 	// public key, and the payment hash.
 	type payment struct {
-		msat      lnwire.MilliSatoshi
-		dest      []byte
-		pHash     []byte
-		cltvDelta uint16
+		msat            lnwire.MilliSatoshi
+		dest            []byte
+		pHash           []byte
+		cltvDelta       uint16
+		maxShards       uint32
+		keySendPreimage *[32]byte
+		routeHints      [][]routing.HopHint
 	}
 	payChan := make(chan *payment)
 	errChan := make(chan error, 1)
@@ -1747,7 +2209,22 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				// Populate the next payment, either from the
 				// payment request, or from the explicitly set
 				// fields.
-				p := &payment{}
+				p := &payment{
+					maxShards: nextPayment.MaxShards,
+				}
+
+				// If this is a spontaneous payment, we'll
+				// generate its preimage ourselves.
+				p.keySendPreimage, err = newKeySendPreimage(
+					nextPayment,
+				)
+				if err != nil {
+					select {
+					case errChan <- err:
+					case <-reqQuit:
+					}
+					return
+				}
 
 				// If the payment request field isn't blank,
 				// then the details of the invoice are encoded
@@ -1796,6 +2273,9 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 
 					p.pHash = payReq.PaymentHash[:]
 					p.cltvDelta = uint16(payReq.MinFinalCLTVExpiry())
+					p.routeHints = unmarshallRouteHints(
+						payReq.RouteHints,
+					)
 				} else {
 					// If the payment request field was not
 					// specified, construct the payment from
@@ -1808,6 +2288,13 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 					p.cltvDelta = uint16(nextPayment.FinalCltvDelta)
 				}
 
+				// The payment hash of a spontaneous payment
+				// is derived from its preimage.
+				if p.keySendPreimage != nil {
+					hash := sha256.Sum256(p.keySendPreimage[:])
+					p.pHash = hash[:]
+				}
+
 				select {
 				case payChan <- p:
 				case <-reqQuit:
@@ -1876,14 +2363,19 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				// returned. Otherwise, we'll get a non-nil
 				// error.
 				payment := &routing.LightningPayment{
-					Target:      destNode,
-					Amount:      p.msat,
-					PaymentHash: rHash,
+					Target:          destNode,
+					Amount:          p.msat,
+					PaymentHash:     rHash,
+					MaxShards:       p.maxShards,
+					KeySendPreimage: p.keySendPreimage,
+					RouteHints:      p.routeHints,
 				}
 				if p.cltvDelta != 0 {
 					payment.FinalCLTVDelta = &p.cltvDelta
 				}
-				preImage, route, err := r.server.chanRouter.SendPayment(payment)
+				preImage, routes, err := r.server.chanRouter.SendMultiPathPayment(
+					payment,
+				)
 				if err != nil {
 					// If we receive payment error than,
 					// instead of terminating the stream,
@@ -1899,15 +2391,14 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 
 				// Save the completed payment to the database
 				// for record keeping purposes.
-				if err := r.savePayment(route, p.msat, preImage[:]); err != nil {
+				if err := r.savePayment(routes, p.msat, preImage[:]); err != nil {
 					errChan <- err
 					return
 				}
 
-				err = paymentStream.Send(&lnrpc.SendResponse{
-					PaymentPreimage: preImage[:],
-					PaymentRoute:    marshallRoute(route),
-				})
+				err = paymentStream.Send(
+					newSendResponse(preImage, routes),
+				)
 				if err != nil {
 					errChan <- err
 					return
@@ -1936,12 +2427,20 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	}
 
 	var (
-		destPub   *btcec.PublicKey
-		amtMSat   lnwire.MilliSatoshi
-		rHash     [32]byte
-		cltvDelta uint16
+		destPub    *btcec.PublicKey
+		amtMSat    lnwire.MilliSatoshi
+		rHash      [32]byte
+		cltvDelta  uint16
+		routeHints [][]routing.HopHint
 	)
 
+	// If this is a spontaneous payment, we'll generate its preimage
+	// ourselves.
+	keySendPreimage, err := newKeySendPreimage(nextPayment)
+	if err != nil {
+		return nil, err
+	}
+
 	// If the proto request has an encoded payment request, then we we'll
 	// use that solely to dispatch the payment.
 	if nextPayment.PaymentRequest != "" {
@@ -1972,16 +2471,24 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 
 		rHash = *payReq.PaymentHash
 		cltvDelta = uint16(payReq.MinFinalCLTVExpiry())
+		routeHints = unmarshallRouteHints(payReq.RouteHints)
 
 		// Otherwise, the payment conditions have been manually
 		// specified in the proto.
 	} else {
-		// If we're in debug HTLC mode, then all outgoing HTLCs will
-		// pay to the same debug rHash. Otherwise, we pay to the rHash
-		// specified within the RPC request.
-		if cfg.DebugHTLC && nextPayment.PaymentHashString == "" {
-			rHash = debugHash
-		} else {
+		// The payment hash of a spontaneous payment is derived from
+		// the preimage we generated. If we're in debug HTLC mode, then
+		// all outgoing HTLCs will pay to the same debug rHash.
+		// Otherwise, we pay to the rHash specified within the RPC
+		// request.
+		switch {
+		case keySendPreimage != nil:
+			rHash = sha256.Sum256(keySendPreimage[:])
+
+		case cfg.DebugHTLC && nextPayment.PaymentHashString == "":
+			rHash = debugHash
+
+		default:
 			paymentHash, err := hex.DecodeString(nextPayment.PaymentHashString)
 			if err != nil {
 				return nil, err
@@ -2019,14 +2526,17 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	// payment succeeds, then the returned route will be that was used
 	// successfully within the payment.
 	payment := &routing.LightningPayment{
-		Target:      destPub,
-		Amount:      amtMSat,
-		PaymentHash: rHash,
+		Target:          destPub,
+		Amount:          amtMSat,
+		PaymentHash:     rHash,
+		MaxShards:       nextPayment.MaxShards,
+		KeySendPreimage: keySendPreimage,
+		RouteHints:      routeHints,
 	}
 	if cltvDelta != 0 {
 		payment.FinalCLTVDelta = &cltvDelta
 	}
-	preImage, route, err := r.server.chanRouter.SendPayment(payment)
+	preImage, routes, err := r.server.chanRouter.SendMultiPathPayment(payment)
 	if err != nil {
 		return &lnrpc.SendResponse{
 			PaymentError: err.Error(),
@@ -2035,14 +2545,298 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 
 	// With the payment completed successfully, we now ave the details of
 	// the completed payment to the database for historical record keeping.
-	if err := r.savePayment(route, amtMSat, preImage[:]); err != nil {
+	if err := r.savePayment(routes, amtMSat, preImage[:]); err != nil {
 		return nil, err
 	}
 
-	return &lnrpc.SendResponse{
+	return newSendResponse(preImage, routes), nil
+}
+
+// newSendResponse creates the response to a successful payment, given the
+// payment preimage and the routes taken by the shards of the payment.
+func newSendResponse(preImage [32]byte,
+	routes []*routing.Route) *lnrpc.SendResponse {
+
+	resp := &lnrpc.SendResponse{
 		PaymentPreimage: preImage[:],
-		PaymentRoute:    marshallRoute(route),
-	}, nil
+		PaymentRoute:    marshallRoute(routes[0]),
+	}
+
+	// If the payment was split, we'll also include the routes taken by
+	// each of its shards.
+	if len(routes) > 1 {
+		for _, route := range routes {
+			resp.ShardRoutes = append(
+				resp.ShardRoutes, marshallRoute(route),
+			)
+		}
+	}
+
+	return resp
+}
+
+// SendToRoute sends a payment along the passed routes, rather than along
+// routes found by the router itself. The routes are attempted in order, until
+// either the payment succeeds or all routes have failed.
+func (r *rpcServer) SendToRoute(ctx context.Context,
+	in *lnrpc.SendToRouteRequest) (*lnrpc.SendResponse, error) {
+
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	if len(in.Routes) == 0 {
+		return nil, fmt.Errorf("unable to send, no routes provided")
+	}
+
+	// The payment hash may either be passed as raw bytes, or hex encoded.
+	paymentHash := in.PaymentHash
+	if in.PaymentHashString != "" {
+		var err error
+		paymentHash, err = hex.DecodeString(in.PaymentHashString)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(paymentHash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %v", len(paymentHash))
+	}
+
+	graph := r.server.chanDB.ChannelGraph()
+	routes := make([]*routing.Route, 0, len(in.Routes))
+	for _, rpcRoute := range in.Routes {
+		route, err := unmarshallRoute(rpcRoute, graph)
+		if err != nil {
+			return nil, err
+		}
+
+		routes = append(routes, route)
+	}
+
+	payment := &routing.LightningPayment{}
+	copy(payment.PaymentHash[:], paymentHash)
+
+	preImage, route, err := r.server.chanRouter.SendToRoute(
+		routes, payment,
+	)
+	if err != nil {
+		return &lnrpc.SendResponse{
+			PaymentError: err.Error(),
+		}, nil
+	}
+
+	// With the payment completed successfully, we now save the details of
+	// the completed payment to the database for historical record keeping.
+	amt := route.Hops[len(route.Hops)-1].AmtToForward
+	routes = []*routing.Route{route}
+	if err := r.savePayment(routes, amt, preImage[:]); err != nil {
+		return nil, err
+	}
+
+	return newSendResponse(preImage, routes), nil
+}
+
+// Rebalance moves funds from one of our channels to another by paying
+// ourselves. The payment leaves our node through the outgoing channel, and
+// returns through the last hop channel, settling an invoice generated for this
+// purpose.
+func (r *rpcServer) Rebalance(ctx context.Context,
+	in *lnrpc.RebalanceRequest) (*lnrpc.SendResponse, error) {
+
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	switch {
+	case in.OutgoingChanId == 0 || in.LastHopChanId == 0:
+		return nil, fmt.Errorf("both the outgoing and the last hop " +
+			"channel must be specified")
+
+	case in.OutgoingChanId == in.LastHopChanId:
+		return nil, fmt.Errorf("outgoing and last hop channel must " +
+			"differ")
+
+	case in.FeeLimit < 0:
+		return nil, fmt.Errorf("fee limit must not be negative")
+	}
+
+	amtMSat := lnwire.NewMSatFromSatoshis(btcutil.Amount(in.Amt))
+	if in.Amt <= 0 || amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("amount must be positive and at most "+
+			"%v", maxPaymentMSat.ToSatoshis())
+	}
+	feeLimit := lnwire.NewMSatFromSatoshis(btcutil.Amount(in.FeeLimit))
+
+	// We'll pay to an invoice of our own, which our node settles once the
+	// payment has made its way back to it.
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		return nil, err
+	}
+	rHash := sha256.Sum256(preimage[:])
+
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Memo:         []byte("rebalance"),
+		Terms: channeldb.ContractTerm{
+			Value:           amtMSat,
+			PaymentPreimage: preimage,
+		},
+	}
+	if err := r.server.invoices.AddInvoice(invoice); err != nil {
+		return nil, err
+	}
+
+	// We parse a copy of our own public key, as the router may modify the
+	// target of the payment.
+	selfPub, err := btcec.ParsePubKey(
+		r.server.identityPriv.PubKey().SerializeCompressed(),
+		btcec.S256(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &routing.LightningPayment{
+		Target:            selfPub,
+		Amount:            amtMSat,
+		PaymentHash:       rHash,
+		FeeLimit:          &feeLimit,
+		OutgoingChannelID: &in.OutgoingChanId,
+		LastHopChannelID:  &in.LastHopChanId,
+	}
+	preImage, route, err := r.server.chanRouter.SendPayment(payment)
+	if err != nil {
+		// As the payment failed, the invoice won't be paid anymore.
+		if err := r.server.invoices.CancelInvoice(rHash); err != nil {
+			rpcsLog.Errorf("Unable to cancel rebalance invoice %x: "+
+				"%v", rHash[:], err)
+		}
+
+		return &lnrpc.SendResponse{
+			PaymentError: err.Error(),
+		}, nil
+	}
+
+	rpcsLog.Infof("Rebalanced %v from channel %v to channel %v, paying "+
+		"%v in fees", amtMSat, in.OutgoingChanId, in.LastHopChanId,
+		route.TotalFees)
+
+	// With the payment completed successfully, we now save the details of
+	// the completed payment to the database for historical record keeping.
+	routes := []*routing.Route{route}
+	if err := r.savePayment(routes, amtMSat, preImage[:]); err != nil {
+		return nil, err
+	}
+
+	return newSendResponse(preImage, routes), nil
+}
+
+// unmarshallRoute converts an RPC route into a routing.Route, looking up the
+// policies of the channels it travels along within the passed graph.
+func unmarshallRoute(rpcRoute *lnrpc.Route,
+	graph *channeldb.ChannelGraph) (*routing.Route, error) {
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch source node from "+
+			"graph: %v", err)
+	}
+
+	prevNode := sourceNode.PubKeyBytes
+	hops := make([]*routing.Hop, len(rpcRoute.Hops))
+	for i, rpcHop := range rpcRoute.Hops {
+		info, policy1, policy2, err := graph.FetchChannelEdgesByID(
+			rpcHop.ChanId,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch channel %v: %v",
+				rpcHop.ChanId, err)
+		}
+
+		// We'll use the policy of the direction that leads away from
+		// the node of the prior hop.
+		var policy *channeldb.ChannelEdgePolicy
+		switch prevNode {
+		case info.NodeKey1Bytes:
+			policy = policy1
+		case info.NodeKey2Bytes:
+			policy = policy2
+		default:
+			return nil, fmt.Errorf("channel %v of hop %v isn't "+
+				"connected to the prior hop", rpcHop.ChanId, i)
+		}
+		if policy == nil {
+			return nil, fmt.Errorf("no policy known for channel %v",
+				rpcHop.ChanId)
+		}
+
+		// Routes marshalled by earlier versions only carry amounts in
+		// satoshis, so we'll only read the amounts of a hop in
+		// milli-satoshis if it carries any. Both amounts of a hop are
+		// always read in the same unit.
+		useMsat := rpcHop.AmtToForwardMsat != 0 || rpcHop.FeeMsat != 0
+		amtToForward, err := unmarshallHopAmount(
+			rpcHop.AmtToForwardMsat, rpcHop.AmtToForward, useMsat,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount to forward of "+
+				"hop %v: %v", i, err)
+		}
+		fee, err := unmarshallHopAmount(
+			rpcHop.FeeMsat, rpcHop.Fee, useMsat,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee of hop %v: %v",
+				i, err)
+		}
+
+		hops[i] = &routing.Hop{
+			Channel: &routing.ChannelHop{
+				Capacity:          info.Capacity,
+				Chain:             info.ChainHash,
+				ChannelEdgePolicy: policy,
+			},
+			OutgoingTimeLock: rpcHop.Expiry,
+			AmtToForward:     amtToForward,
+			Fee:              fee,
+		}
+
+		prevNode = policy.Node.PubKeyBytes
+	}
+
+	return routing.NewRouteFromHops(
+		rpcRoute.TotalTimeLock, sourceNode.PubKeyBytes, hops,
+	)
+}
+
+// unmarshallHopAmount returns an amount of a route hop, read from its
+// milli-satoshi variant if useMsat is set, and from its satoshi variant
+// otherwise. If both variants are set, they must describe the same amount, as
+// mixing units within a hop is rejected.
+func unmarshallHopAmount(msat, sat int64,
+	useMsat bool) (lnwire.MilliSatoshi, error) {
+
+	if !useMsat {
+		return lnwire.NewMSatFromSatoshis(btcutil.Amount(sat)), nil
+	}
+
+	amt := lnwire.MilliSatoshi(msat)
+	if sat != 0 && amt.ToSatoshis() != btcutil.Amount(sat) {
+		return 0, fmt.Errorf("amount of %v doesn't match amount of "+
+			"%v in satoshis", amt, sat)
+	}
+
+	return amt, nil
 }
 
 // AddInvoice attempts to add a new invoice to the invoice database. Any
@@ -2073,6 +2867,83 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		copy(paymentPreimage[:], invoice.RPreimage[:])
 	}
 
+	// Next, generate the payment hash itself from the preimage. This will
+	// be used by clients to query for the state of a particular invoice.
+	rHash := sha256.Sum256(paymentPreimage[:])
+
+	i, err := r.newInvoice(invoice, rHash)
+	if err != nil {
+		return nil, err
+	}
+	copy(i.Terms.PaymentPreimage[:], paymentPreimage[:])
+
+	rpcsLog.Tracef("[addinvoice] adding new invoice %v",
+		newLogClosure(func() string {
+			return spew.Sdump(i)
+		}),
+	)
+
+	// With all sanity checks passed, write the invoice to the database.
+	if err := r.server.invoices.AddInvoice(i); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.AddInvoiceResponse{
+		RHash:          rHash[:],
+		PaymentRequest: string(i.PaymentRequest),
+	}, nil
+}
+
+// AddHoldInvoice attempts to add a new hold invoice to the invoice database.
+// In contrast to regular invoices, a hold invoice is created from only a
+// payment hash. Incoming HTLCs paying to it are held until the invoice is
+// either settled with SettleInvoice once the preimage is known, or canceled
+// with CancelInvoice.
+func (r *rpcServer) AddHoldInvoice(ctx context.Context,
+	invoice *lnrpc.AddHoldInvoiceRequest) (*lnrpc.AddInvoiceResponse, error) {
+
+	if len(invoice.Hash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly "+
+			"32 bytes, is instead %v", len(invoice.Hash))
+	}
+
+	var rHash [32]byte
+	copy(rHash[:], invoice.Hash)
+
+	i, err := r.newInvoice(&lnrpc.Invoice{
+		Memo:            invoice.Memo,
+		Value:           invoice.Value,
+		DescriptionHash: invoice.DescriptionHash,
+		Expiry:          invoice.Expiry,
+		FallbackAddr:    invoice.FallbackAddr,
+		CltvExpiry:      invoice.CltvExpiry,
+	}, rHash)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Tracef("[addholdinvoice] adding new hold invoice %v",
+		newLogClosure(func() string {
+			return spew.Sdump(i)
+		}),
+	)
+
+	if err := r.server.invoices.AddHoldInvoice(i, rHash); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.AddInvoiceResponse{
+		RHash:          rHash[:],
+		PaymentRequest: string(i.PaymentRequest),
+	}, nil
+}
+
+// newInvoice validates the passed invoice parameters and creates a new
+// invoice paying to the given payment hash, along with its encoded payment
+// request. The preimage of the returned invoice is left unset.
+func (r *rpcServer) newInvoice(invoice *lnrpc.Invoice,
+	rHash [32]byte) (*channeldb.Invoice, error) {
+
 	// The size of the memo, receipt and description hash attached must not
 	// exceed the maximum values for either of the fields.
 	if len(invoice.Memo) > channeldb.MaxMemoSize {
@@ -2098,15 +2969,11 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 			"payment allowed is %v", amt, maxPaymentMSat.ToSatoshis())
 	}
 
-	// Next, generate the payment hash itself from the preimage. This will
-	// be used by clients to query for the state of a particular invoice.
-	rHash := sha256.Sum256(paymentPreimage[:])
-
 	// We also create an encoded payment request which allows the
 	// caller to compactly send the invoice to the payer. We'll create a
 	// list of options to be added to the encoded payment request. For now
 	// we only support the required fields description/description_hash,
-	// expiry, fallback address, and the amount field.
+	// expiry, fallback address, routing hints, and the amount field.
 	var options []func(*zpay32.Invoice)
 
 	// We only include the amount in the invoice if it is greater than 0.
@@ -2178,6 +3045,19 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		options = append(options, zpay32.CLTVExpiry(uint64(defaultDelta)))
 	}
 
+	// If we were requested to include routing hints in the invoice, then
+	// we'll select among our private channels those able to receive the
+	// payment, and add a routing hint for each of them.
+	if invoice.Private {
+		routeHints, err := r.selectRouteHints(amtMSat)
+		if err != nil {
+			return nil, err
+		}
+		for _, routeHint := range routeHints {
+			options = append(options, zpay32.RoutingInfo(routeHint))
+		}
+	}
+
 	// Create and encode the payment request as a bech32 (zpay32) string.
 	creationDate := time.Now()
 	payReq, err := zpay32.NewInvoice(
@@ -2199,7 +3079,7 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		return nil, err
 	}
 
-	i := &channeldb.Invoice{
+	return &channeldb.Invoice{
 		CreationDate:   creationDate,
 		Memo:           []byte(invoice.Memo),
 		Receipt:        invoice.Receipt,
@@ -2207,74 +3087,257 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		Terms: channeldb.ContractTerm{
 			Value: amtMSat,
 		},
-	}
-	copy(i.Terms.PaymentPreimage[:], paymentPreimage[:])
-
-	rpcsLog.Tracef("[addinvoice] adding new invoice %v",
-		newLogClosure(func() string {
-			return spew.Sdump(i)
-		}),
-	)
-
-	// With all sanity checks passed, write the invoice to the database.
-	if err := r.server.invoices.AddInvoice(i); err != nil {
-		return nil, err
-	}
-
-	return &lnrpc.AddInvoiceResponse{
-		RHash:          rHash[:],
-		PaymentRequest: payReqString,
 	}, nil
 }
 
-// createRPCInvoice creates an *lnrpc.Invoice from the *channeldb.Invoice.
-func createRPCInvoice(invoice *channeldb.Invoice) (*lnrpc.Invoice, error) {
-	paymentRequest := string(invoice.PaymentRequest)
-	decoded, err := zpay32.Decode(paymentRequest, activeNetParams.Params)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode payment request: %v",
-			err)
+// maxRouteHints is the maximum number of routing hints included within an
+// invoice, which avoids creating overly large invoices.
+const maxRouteHints = 20
+
+// selectRouteHints selects the private channels suitable to be used as
+// routing hints for an invoice of the given amount, and returns a routing hint
+// for each of them. A channel is only selected if it's active, the remote
+// party has enough balance to send us the amount, and the remote party is
+// publicly advertised within the network, as we'd otherwise leak the
+// existence of a node that intends to stay unadvertised.
+func (r *rpcServer) selectRouteHints(
+	amtMSat lnwire.MilliSatoshi) ([][]zpay32.ExtraRoutingInfo, error) {
+
+	openChannels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
 	}
 
-	descHash := []byte("")
-	if decoded.DescriptionHash != nil {
-		descHash = decoded.DescriptionHash[:]
-	}
+	graph := r.server.chanDB.ChannelGraph()
 
-	fallbackAddr := ""
-	if decoded.FallbackAddr != nil {
-		fallbackAddr = decoded.FallbackAddr.String()
-	}
+	var routeHints [][]zpay32.ExtraRoutingInfo
+	for _, channel := range openChannels {
+		if len(routeHints) >= maxRouteHints {
+			break
+		}
 
-	settleDate := int64(0)
-	if !invoice.SettleDate.IsZero() {
-		settleDate = invoice.SettleDate.Unix()
-	}
+		// Since we're only interested in our private channels, we'll
+		// skip the public ones.
+		isPublic := channel.ChannelFlags&lnwire.FFAnnounceChannel != 0
+		if isPublic {
+			continue
+		}
 
-	// Expiry time will default to 3600 seconds if not specified
-	// explicitly.
-	expiry := int64(decoded.Expiry().Seconds())
+		// Make sure the counterparty has enough balance in the
+		// channel for our amount. We do this in order to reduce
+		// payment errors when attempting to use this channel as a
+		// hint.
+		if amtMSat >= channel.LocalCommitment.RemoteBalance {
+			continue
+		}
 
-	// The expiry will default to 9 blocks if not specified explicitly.
-	cltvExpiry := decoded.MinFinalCLTVExpiry()
+		// Make sure the channel is active, which is the case if its
+		// link is eligible to forward HTLCs.
+		chanID := lnwire.NewChanIDFromOutPoint(&channel.FundingOutpoint)
+		link, err := r.server.htlcSwitch.GetLink(chanID)
+		if err != nil {
+			rpcsLog.Debugf("Unable to get link for channel %v: %v",
+				channel.FundingOutpoint, err)
+			continue
+		}
+		if !link.EligibleToForward() {
+			continue
+		}
 
-	preimage := invoice.Terms.PaymentPreimage
-	satAmt := invoice.Terms.Value.ToSatoshis()
+		// To ensure we don't leak unadvertised nodes, we'll make sure
+		// our counterparty is publicly advertised within the network.
+		var remotePub [33]byte
+		copy(remotePub[:], channel.IdentityPub.SerializeCompressed())
+		isRemotePublic, err := graph.IsPublicNode(remotePub)
+		if err != nil {
+			return nil, err
+		}
+		if !isRemotePublic {
+			continue
+		}
 
-	return &lnrpc.Invoice{
-		Memo:            string(invoice.Memo[:]),
+		// Fetch the policies for each end of the channel, so we can
+		// determine the policy of the remote party, which applies to
+		// HTLCs being sent by them to us.
+		shortChanID := channel.ShortChanID.ToUint64()
+		info, p1, p2, err := graph.FetchChannelEdgesByID(shortChanID)
+		if err != nil {
+			rpcsLog.Debugf("Unable to fetch edges of channel %v: "+
+				"%v", channel.FundingOutpoint, err)
+			continue
+		}
+
+		remotePolicy := p2
+		if bytes.Equal(remotePub[:], info.NodeKey1Bytes[:]) {
+			remotePolicy = p1
+		}
+
+		// If for some reason we don't yet have the policy of the
+		// remote party, then we'll skip adding this channel as a
+		// routing hint.
+		if remotePolicy == nil {
+			continue
+		}
+
+		routeHints = append(routeHints, []zpay32.ExtraRoutingInfo{{
+			PubKey:      channel.IdentityPub,
+			ShortChanID: shortChanID,
+			FeeBaseMsat: uint32(remotePolicy.FeeBaseMSat),
+			FeeProportionalMillionths: uint32(
+				remotePolicy.FeeProportionalMillionths,
+			),
+			CltvExpDelta: remotePolicy.TimeLockDelta,
+		}})
+	}
+
+	return routeHints, nil
+}
+
+// SettleInvoice settles an accepted hold invoice with the given preimage. The
+// HTLCs being held for the invoice are settled as a result.
+func (r *rpcServer) SettleInvoice(ctx context.Context,
+	in *lnrpc.SettleInvoiceMsg) (*lnrpc.SettleInvoiceResp, error) {
+
+	if len(in.Preimage) != 32 {
+		return nil, fmt.Errorf("payment preimage must be exactly "+
+			"32 bytes, is instead %v", len(in.Preimage))
+	}
+
+	var preimage [32]byte
+	copy(preimage[:], in.Preimage)
+
+	rpcsLog.Debugf("[settleinvoice] settling invoice with preimage %x",
+		sha256.Sum256(preimage[:]))
+
+	if err := r.server.invoices.SettleHoldInvoice(preimage); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SettleInvoiceResp{}, nil
+}
+
+// CancelInvoice cancels the invoice with the given payment hash. Any HTLCs
+// being held for the invoice are failed back to the sender, and future
+// payments to the invoice are rejected.
+func (r *rpcServer) CancelInvoice(ctx context.Context,
+	in *lnrpc.CancelInvoiceMsg) (*lnrpc.CancelInvoiceResp, error) {
+
+	if len(in.PaymentHash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly "+
+			"32 bytes, is instead %v", len(in.PaymentHash))
+	}
+
+	var rHash chainhash.Hash
+	copy(rHash[:], in.PaymentHash)
+
+	rpcsLog.Debugf("[cancelinvoice] canceling invoice %x", rHash[:])
+
+	if err := r.server.invoices.CancelInvoice(rHash); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.CancelInvoiceResp{}, nil
+}
+
+// DeleteCanceledInvoices removes all canceled and expired invoices that were
+// created before the passed unix timestamp from the invoice database. If no
+// timestamp is set, all canceled and expired invoices are removed.
+func (r *rpcServer) DeleteCanceledInvoices(ctx context.Context,
+	in *lnrpc.DeleteCanceledInvoicesRequest) (
+	*lnrpc.DeleteCanceledInvoicesResponse, error) {
+
+	if in.CreatedBefore < 0 {
+		return nil, fmt.Errorf("created_before must be positive, "+
+			"is instead %v", in.CreatedBefore)
+	}
+
+	createdBefore := time.Now()
+	if in.CreatedBefore != 0 {
+		createdBefore = time.Unix(in.CreatedBefore, 0)
+	}
+
+	rpcsLog.Debugf("[deletecanceledinvoices] deleting invoices created "+
+		"before %v", createdBefore)
+
+	numDeleted, err := r.server.chanDB.DeleteCanceledInvoices(createdBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("Deleted %v canceled or expired invoices", numDeleted)
+
+	return &lnrpc.DeleteCanceledInvoicesResponse{
+		NumDeleted: uint32(numDeleted),
+	}, nil
+}
+
+// createRPCInvoice creates an *lnrpc.Invoice from the *channeldb.Invoice.
+func createRPCInvoice(invoice *channeldb.Invoice) (*lnrpc.Invoice, error) {
+	paymentRequest := string(invoice.PaymentRequest)
+	decoded, err := zpay32.Decode(paymentRequest, activeNetParams.Params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode payment request: %v",
+			err)
+	}
+
+	descHash := []byte("")
+	if decoded.DescriptionHash != nil {
+		descHash = decoded.DescriptionHash[:]
+	}
+
+	fallbackAddr := ""
+	if decoded.FallbackAddr != nil {
+		fallbackAddr = decoded.FallbackAddr.String()
+	}
+
+	settleDate := int64(0)
+	if !invoice.SettleDate.IsZero() {
+		settleDate = invoice.SettleDate.Unix()
+	}
+
+	// Expiry time will default to 3600 seconds if not specified
+	// explicitly.
+	expiry := int64(decoded.Expiry().Seconds())
+
+	// The expiry will default to 9 blocks if not specified explicitly.
+	cltvExpiry := decoded.MinFinalCLTVExpiry()
+
+	preimage := invoice.Terms.PaymentPreimage
+	satAmt := invoice.Terms.Value.ToSatoshis()
+
+	var state lnrpc.Invoice_InvoiceState
+	switch invoice.Terms.State {
+	case channeldb.ContractOpen:
+		state = lnrpc.Invoice_OPEN
+	case channeldb.ContractSettled:
+		state = lnrpc.Invoice_SETTLED
+	case channeldb.ContractCanceled:
+		state = lnrpc.Invoice_CANCELED
+	case channeldb.ContractAccepted:
+		state = lnrpc.Invoice_ACCEPTED
+	case channeldb.ContractExpired:
+		state = lnrpc.Invoice_EXPIRED
+	default:
+		return nil, fmt.Errorf("unknown invoice state %v",
+			invoice.Terms.State)
+	}
+
+	return &lnrpc.Invoice{
+		Memo:            string(invoice.Memo[:]),
 		Receipt:         invoice.Receipt[:],
 		RHash:           decoded.PaymentHash[:],
 		RPreimage:       preimage[:],
 		Value:           int64(satAmt),
 		CreationDate:    invoice.CreationDate.Unix(),
 		SettleDate:      settleDate,
-		Settled:         invoice.Terms.Settled,
+		Settled:         invoice.Terms.State == channeldb.ContractSettled,
+		State:           state,
 		PaymentRequest:  paymentRequest,
 		DescriptionHash: descHash,
 		Expiry:          expiry,
 		CltvExpiry:      cltvExpiry,
 		FallbackAddr:    fallbackAddr,
+		Private:         len(decoded.RouteHints) > 0,
 	}, nil
 }
 
@@ -2355,7 +3418,7 @@ func (r *rpcServer) ListInvoices(ctx context.Context,
 }
 
 // SubscribeInvoices returns a uni-directional stream (server -> client) for
-// notifying the client of newly added/settled invoices.
+// notifying the client of invoices that were accepted, settled or canceled.
 func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
 	updateStream lnrpc.Lightning_SubscribeInvoicesServer) error {
 
@@ -2365,9 +3428,9 @@ func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
 	for {
 		select {
 		// TODO(roasbeef): include newly added invoices?
-		case settledInvoice := <-invoiceClient.SettledInvoices:
+		case updatedInvoice := <-invoiceClient.InvoiceUpdates:
 
-			rpcInvoice, err := createRPCInvoice(settledInvoice)
+			rpcInvoice, err := createRPCInvoice(updatedInvoice)
 			if err != nil {
 				return err
 			}
@@ -2723,20 +3786,90 @@ func marshallRoute(route *routing.Route) *lnrpc.Route {
 		TotalFees:     int64(route.TotalFees.ToSatoshis()),
 		TotalAmt:      int64(route.TotalAmount.ToSatoshis()),
 		Hops:          make([]*lnrpc.Hop, len(route.Hops)),
+		TotalFeesMsat: int64(route.TotalFees),
+		TotalAmtMsat:  int64(route.TotalAmount),
 	}
 	for i, hop := range route.Hops {
 		resp.Hops[i] = &lnrpc.Hop{
-			ChanId:       hop.Channel.ChannelID,
-			ChanCapacity: int64(hop.Channel.Capacity),
-			AmtToForward: int64(hop.AmtToForward.ToSatoshis()),
-			Fee:          int64(hop.Fee.ToSatoshis()),
-			Expiry:       uint32(hop.OutgoingTimeLock),
+			ChanId:           hop.Channel.ChannelID,
+			ChanCapacity:     int64(hop.Channel.Capacity),
+			AmtToForward:     int64(hop.AmtToForward.ToSatoshis()),
+			Fee:              int64(hop.Fee.ToSatoshis()),
+			Expiry:           uint32(hop.OutgoingTimeLock),
+			AmtToForwardMsat: int64(hop.AmtToForward),
+			FeeMsat:          int64(hop.Fee),
+			PubKey: hex.EncodeToString(
+				hop.Channel.Node.PubKeyBytes[:],
+			),
 		}
 	}
 
 	return resp
 }
 
+// BuildRoute builds a route that delivers the given amount along the passed
+// hops, starting at our own node. The fees and time locks of the route are
+// computed from the channel policies known to the router.
+func (r *rpcServer) BuildRoute(ctx context.Context,
+	in *lnrpc.BuildRouteRequest) (*lnrpc.BuildRouteResponse, error) {
+
+	amtMSat := lnwire.MilliSatoshi(in.AmtMsat)
+	if in.AmtMsat <= 0 || amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("amount must be positive and at most "+
+			"%v", maxPaymentMSat)
+	}
+
+	if in.FinalCltvDelta < 0 || in.FinalCltvDelta > math.MaxUint16 {
+		return nil, fmt.Errorf("invalid final cltv delta: %v",
+			in.FinalCltvDelta)
+	}
+	finalCLTVDelta := uint16(in.FinalCltvDelta)
+	if finalCLTVDelta == 0 {
+		finalCLTVDelta = routing.DefaultFinalCLTVDelta
+	}
+
+	// The hops may either be specified by their nodes, their channels, or
+	// both.
+	numHops := len(in.HopPubkeys)
+	if numHops == 0 {
+		numHops = len(in.ChanIds)
+	}
+	if len(in.HopPubkeys) != 0 && len(in.ChanIds) != 0 &&
+		len(in.HopPubkeys) != len(in.ChanIds) {
+
+		return nil, fmt.Errorf("number of hop pubkeys and channel " +
+			"ids must match")
+	}
+
+	hops := make([]routing.HopSpec, numHops)
+	for i, pubKeyStr := range in.HopPubkeys {
+		pubKeyBytes, err := hex.DecodeString(pubKeyStr)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		hops[i].Node = routing.NewVertex(pubKey)
+	}
+	for i, chanID := range in.ChanIds {
+		hops[i].ChannelID = chanID
+	}
+
+	route, err := r.server.chanRouter.BuildRoute(
+		amtMSat, hops, finalCLTVDelta,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.BuildRouteResponse{
+		Route: marshallRoute(route),
+	}, nil
+}
+
 // GetNetworkInfo returns some basic stats about the known channel graph from
 // the PoV of the node.
 func (r *rpcServer) GetNetworkInfo(ctx context.Context,
@@ -3440,3 +4573,919 @@ func (r *rpcServer) ForwardingHistory(ctx context.Context,
 
 	return resp, nil
 }
+
+// ExportChannelBackup attempts to return an encrypted static channel backup
+// for the target channel identified by its channel point. The backup is
+// encrypted with a key derived from the wallet's keychain. The returned
+// backup can later be restored using the RestoreChannelBackups method.
+func (r *rpcServer) ExportChannelBackup(ctx context.Context,
+	in *lnrpc.ExportChannelBackupRequest) (*lnrpc.ChannelBackup, error) {
+
+	if in.ChanPoint == nil {
+		return nil, fmt.Errorf("a channel point must be specified")
+	}
+
+	// First, we'll convert the lnrpc channel point into a wire.OutPoint
+	// that we can manipulate.
+	txidHash, err := getChanPointFundingTxid(in.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+	txid, err := chainhash.NewHash(txidHash)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.OutPoint{
+		Hash:  *txid,
+		Index: in.ChanPoint.OutputIndex,
+	}
+
+	rpcsLog.Debugf("[exportchanbackup] chan_point=%v", chanPoint)
+
+	// Next, we'll attempt to fetch a channel backup for this channel from
+	// the database. If this channel has been closed, or the outpoint is
+	// unknown, then we'll return an error.
+	unpackedBackup, err := fetchBackupForChan(chanPoint, r.server.chanDB)
+	if err != nil {
+		return nil, err
+	}
+
+	// At this point, we have an unpacked backup (plaintext) so we'll now
+	// attempt to serialize and encrypt it in order to create a packed
+	// backup.
+	var b bytes.Buffer
+	err = unpackedBackup.PackToWriter(&b, r.server.cc.wallet)
+	if err != nil {
+		return nil, fmt.Errorf("unable to export backup for %v: %v",
+			chanPoint, err)
+	}
+
+	return &lnrpc.ChannelBackup{
+		ChanPoint:  in.ChanPoint,
+		ChanBackup: b.Bytes(),
+	}, nil
+}
+
+// VerifyChanBackup allows a caller to verify the integrity of a channel
+// backup snapshot. This method will accept both a set of packed Singles, and
+// also a packed Multi. If either of the backups present within the snapshot
+// can't be decrypted and parsed, then an error is returned.
+func (r *rpcServer) VerifyChanBackup(ctx context.Context,
+	in *lnrpc.ChanBackupSnapshot) (*lnrpc.VerifyChanBackupResponse, error) {
+
+	// If neither a Single or Multi has been specified, then we have
+	// nothing to verify.
+	if in.GetSingleChanBackups() == nil && in.GetMultiChanBackup() == nil {
+		return nil, errors.New("either a Single or Multi channel " +
+			"backup must be specified")
+	}
+
+	// Either a single or multi might have been passed in, so we'll
+	// verify whichever is present.
+	if in.GetSingleChanBackups() != nil {
+		chanBackupsProtos := in.GetSingleChanBackups().ChanBackups
+
+		rpcsLog.Debugf("[verifychanbackup] num_single_chan_backups=%v",
+			len(chanBackupsProtos))
+
+		// First, we'll unpack all the channel backups into a packed
+		// single so we can attempt to decrypt them all.
+		packedBackups := make(
+			chanbackup.PackedSingles, 0, len(chanBackupsProtos),
+		)
+		for _, chanBackup := range chanBackupsProtos {
+			packedBackups = append(packedBackups, chanBackup.ChanBackup)
+		}
+
+		// With our PackedSingles created, we'll attempt to unpack the
+		// backup. If this fails, then we know the backup is invalid
+		// for some reason.
+		_, err := packedBackups.Unpack(r.server.cc.wallet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid single channel "+
+				"backup: %v", err)
+		}
+	}
+
+	if in.GetMultiChanBackup() != nil {
+		multiBackup := in.GetMultiChanBackup().MultiChanBackup
+
+		rpcsLog.Debugf("[verifychanbackup] multi_chan_backup_size=%v",
+			len(multiBackup))
+
+		// We'll convert the raw byte slice into a type we can work
+		// with a bit better.
+		packedMulti := chanbackup.PackedMulti(multiBackup)
+
+		// With our PackedMulti created, we'll attempt to unpack the
+		// backup. If this fails, then we know the backup is invalid
+		// for some reason.
+		_, err := packedMulti.Unpack(r.server.cc.wallet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid multi channel backup: "+
+				"%v", err)
+		}
+	}
+
+	return &lnrpc.VerifyChanBackupResponse{}, nil
+}
+
+// createBackupSnapshot converts the passed Single backup into a snapshot which
+// contains individual packed single backups, as well as a single packed multi
+// backup.
+func (r *rpcServer) createBackupSnapshot(backups []chanbackup.Single) (
+	*lnrpc.ChanBackupSnapshot, error) {
+
+	var (
+		keyRing       = r.server.cc.wallet
+		singleBackups = &lnrpc.ChannelBackups{}
+		multiBackup   = &lnrpc.MultiChanBackup{}
+	)
+
+	// First, we'll pack each of the singles into their encrypted form.
+	// We'll also need a map from each packed backup to its channel point
+	// so we can attach the channel point to each of them.
+	packedBackups, err := chanbackup.PackStaticChanBackups(
+		backups, keyRing,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to pack chan backups: %v", err)
+	}
+	for chanPoint, packedBackup := range packedBackups {
+		txid := chanPoint.Hash
+		rpcChanPoint := &lnrpc.ChannelPoint{
+			FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+				FundingTxidBytes: txid[:],
+			},
+			OutputIndex: chanPoint.Index,
+		}
+
+		singleBackups.ChanBackups = append(
+			singleBackups.ChanBackups,
+			&lnrpc.ChannelBackup{
+				ChanPoint:  rpcChanPoint,
+				ChanBackup: packedBackup,
+			},
+		)
+
+		multiBackup.ChanPoints = append(
+			multiBackup.ChanPoints, rpcChanPoint,
+		)
+	}
+
+	// With the single backups created, we'll now create a multi backup
+	// which contains all the channels within a single encrypted blob.
+	unpackedMulti := chanbackup.Multi{
+		StaticBackups: backups,
+	}
+
+	var b bytes.Buffer
+	if err := unpackedMulti.PackToWriter(&b, keyRing); err != nil {
+		return nil, fmt.Errorf("unable to multi-pack backups: %v", err)
+	}
+	multiBackup.MultiChanBackup = b.Bytes()
+
+	return &lnrpc.ChanBackupSnapshot{
+		SingleChanBackups: singleBackups,
+		MultiChanBackup:   multiBackup,
+	}, nil
+}
+
+// ExportAllChannelBackups returns static channel backups for all existing
+// channels known to lnd. A set of regular singular static channel backups
+// for each channel are returned. Additionally, a multi-channel backup is
+// returned as well, which contains a single encrypted blob containing the
+// backups of each channel.
+func (r *rpcServer) ExportAllChannelBackups(ctx context.Context,
+	in *lnrpc.ChanBackupExportRequest) (*lnrpc.ChanBackupSnapshot, error) {
+
+	// First, we'll attempt to read back ups for ALL currently opened
+	// channels from disk.
+	allUnpackedBackups, err := fetchStaticChanBackups(r.server.chanDB)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch all static chan "+
+			"backups: %v", err)
+	}
+
+	rpcsLog.Debugf("[exportallchanbackups] num_chans=%v",
+		len(allUnpackedBackups))
+
+	// With the backups assembled, we'll create a full snapshot.
+	return r.createBackupSnapshot(allUnpackedBackups)
+}
+
+// RestoreChannelBackups accepts a set of singular channel backups, or a single
+// encrypted multi-chan backup and attempts to recover any funds remaining
+// within the channel. If we're able to unpack the backup, then the new
+// channel will be shown under listchannels, as well as pending channels.
+func (r *rpcServer) RestoreChannelBackups(ctx context.Context,
+	in *lnrpc.RestoreChanBackupRequest) (*lnrpc.RestoreBackupResponse, error) {
+
+	// First, we'll make our implementation of the
+	// chanbackup.ChannelRestorer interface which we'll use to properly
+	// restore either a set of chanbackup.Single or chanbackup.Multi
+	// backups.
+	chanRestorer := &chanDBRestorer{
+		db:         r.server.chanDB,
+		secretKeys: r.server.cc.wallet,
+		chainArb:   r.server.chainArb,
+	}
+
+	// We'll accept either a list of Single backups, or a single Multi
+	// backup which contains several single backups.
+	switch {
+	case in.GetChanBackups() != nil:
+		chanBackupsProtos := in.GetChanBackups()
+
+		rpcsLog.Debugf("[restorechanbackup] num_single_chan_backups=%v",
+			len(chanBackupsProtos.ChanBackups))
+
+		// Now that we know what type of backup we're working with,
+		// we'll parse them all out into a more suitable format.
+		packedBackups := make(
+			chanbackup.PackedSingles, 0,
+			len(chanBackupsProtos.ChanBackups),
+		)
+		for _, chanBackup := range chanBackupsProtos.ChanBackups {
+			packedBackups = append(
+				packedBackups, chanBackup.ChanBackup,
+			)
+		}
+
+		// With our backups obtained, we'll now restore them which will
+		// write the new backups to disk, and then attempt to connect
+		// out to any peers that we know of which were our prior
+		// channel peers.
+		err := chanbackup.UnpackAndRecoverSingles(
+			packedBackups, r.server.cc.wallet, chanRestorer,
+			r.server,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unpack single "+
+				"backups: %v", err)
+		}
+
+	case in.GetMultiChanBackup() != nil:
+		packedMultiBackup := in.GetMultiChanBackup()
+
+		rpcsLog.Debugf("[restorechanbackup] multi_chan_backup_size=%v",
+			len(packedMultiBackup))
+
+		// With our backups obtained, we'll now restore them which will
+		// write the new backups to disk, and then attempt to connect
+		// out to any peers that we know of which were our prior
+		// channel peers.
+		packedMulti := chanbackup.PackedMulti(packedMultiBackup)
+		err := chanbackup.UnpackAndRecoverMulti(
+			packedMulti, r.server.cc.wallet, chanRestorer,
+			r.server,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unpack chan "+
+				"backup: %v", err)
+		}
+
+	default:
+		return nil, errors.New("either a set of single channel " +
+			"backups, or a multi channel backup must be specified")
+	}
+
+	return &lnrpc.RestoreBackupResponse{}, nil
+}
+
+// ChannelAcceptor dispatches a bi-directional streaming RPC in which each
+// inbound OpenChannel request is sent to the client, which responds with
+// whether the channel should be accepted or not. If several clients are
+// connected, a channel is only accepted if all of them accept it.
+func (r *rpcServer) ChannelAcceptor(
+	stream lnrpc.Lightning_ChannelAcceptorServer) error {
+
+	// chanAcceptInfo pairs a channel request with the channel over which
+	// the client's decision for it is delivered.
+	type chanAcceptInfo struct {
+		req  *chanacceptor.ChannelAcceptRequest
+		resp chan error
+	}
+
+	newRequests := make(chan *chanAcceptInfo)
+	quit := make(chan struct{})
+	defer close(quit)
+
+	// Our acceptor hands each request over to the main loop below, which
+	// sends it to the client, and then waits for the client's decision.
+	// If the client doesn't decide in time, the channel is rejected.
+	acceptor := func(req *chanacceptor.ChannelAcceptRequest) error {
+		info := &chanAcceptInfo{
+			req:  req,
+			resp: make(chan error, 1),
+		}
+		timeout := time.After(cfg.AcceptorTimeout)
+
+		select {
+		case newRequests <- info:
+		case <-timeout:
+			return errors.New("channel acceptor timed out")
+		case <-quit:
+			return errors.New("channel acceptor disconnected")
+		case <-r.quit:
+			return errors.New("rpc server shutting down")
+		}
+
+		select {
+		case err := <-info.resp:
+			return err
+		case <-timeout:
+			return errors.New("channel acceptor timed out")
+		case <-quit:
+			return errors.New("channel acceptor disconnected")
+		case <-r.quit:
+			return errors.New("rpc server shutting down")
+		}
+	}
+
+	acceptorID := r.chanPredicate.AddAcceptor(
+		chanacceptor.AcceptorFunc(acceptor),
+	)
+	defer r.chanPredicate.RemoveAcceptor(acceptorID)
+
+	// We'll read the client's responses within their own goroutine, so
+	// that we're able to send new requests while waiting for them.
+	responses := make(chan *lnrpc.ChannelAcceptResponse)
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case responses <- resp:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	// pendingRequests maps the pending channel ID of each request sent to
+	// the client to the channel over which its decision is delivered.
+	pendingRequests := make(map[[32]byte]chan error)
+	for {
+		select {
+		case info := <-newRequests:
+			msg := info.req.OpenChanMsg
+			pendingRequests[msg.PendingChannelID] = info.resp
+
+			err := stream.Send(&lnrpc.ChannelAcceptRequest{
+				NodePubkey:       info.req.Node.SerializeCompressed(),
+				ChainHash:        msg.ChainHash[:],
+				PendingChanId:    msg.PendingChannelID[:],
+				FundingAmt:       uint64(msg.FundingAmount),
+				PushAmt:          uint64(msg.PushAmount),
+				DustLimit:        uint64(msg.DustLimit),
+				MaxValueInFlight: uint64(msg.MaxValueInFlight),
+				ChannelReserve:   uint64(msg.ChannelReserve),
+				MinHtlc:          uint64(msg.HtlcMinimum),
+				FeePerKw:         uint64(msg.FeePerKiloWeight),
+				CsvDelay:         uint32(msg.CsvDelay),
+				MaxAcceptedHtlcs: uint32(msg.MaxAcceptedHTLCs),
+				ChannelFlags:     uint32(msg.ChannelFlags),
+			})
+			if err != nil {
+				return err
+			}
+
+		case resp := <-responses:
+			var pendingID [32]byte
+			copy(pendingID[:], resp.PendingChanId)
+
+			respChan, ok := pendingRequests[pendingID]
+			if !ok {
+				rpcsLog.Warnf("Received channel acceptor "+
+					"response for unknown pending channel "+
+					"%x", resp.PendingChanId)
+				continue
+			}
+			delete(pendingRequests, pendingID)
+
+			// If the client rejected the channel, we'll relay its
+			// reason to the remote peer.
+			var err error
+			if !resp.Accept {
+				reason := resp.Error
+				if reason == "" {
+					reason = "channel rejected"
+				}
+				err = &chanacceptor.RejectError{Reason: reason}
+			}
+			respChan <- err
+
+		case err := <-errChan:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+
+		case <-r.quit:
+			return errors.New("rpc server shutting down")
+		}
+	}
+}
+
+// unmarshallInterceptFailure returns the failure message corresponding to the
+// passed BOLT #4 failure code, which an intercepted forward is failed back
+// with. Only failures that don't carry any additional data are supported.
+func unmarshallInterceptFailure(code uint32) (lnwire.FailureMessage, error) {
+	switch lnwire.FailCode(code) {
+	case lnwire.CodeNone, lnwire.CodeTemporaryChannelFailure:
+		return lnwire.NewTemporaryChannelFailure(nil), nil
+
+	case lnwire.CodeTemporaryNodeFailure:
+		return &lnwire.FailTemporaryNodeFailure{}, nil
+
+	case lnwire.CodePermanentNodeFailure:
+		return &lnwire.FailPermanentNodeFailure{}, nil
+
+	case lnwire.CodeRequiredNodeFeatureMissing:
+		return &lnwire.FailRequiredNodeFeatureMissing{}, nil
+
+	case lnwire.CodePermanentChannelFailure:
+		return &lnwire.FailPermanentChannelFailure{}, nil
+
+	case lnwire.CodeRequiredChannelFeatureMissing:
+		return &lnwire.FailRequiredChannelFeatureMissing{}, nil
+
+	case lnwire.CodeUnknownNextPeer:
+		return &lnwire.FailUnknownNextPeer{}, nil
+
+	case lnwire.CodeUnknownPaymentHash:
+		return &lnwire.FailUnknownPaymentHash{}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported failure code: %v", code)
+	}
+}
+
+// HtlcInterceptor dispatches a bi-directional streaming RPC in which each
+// forwarded htlc is sent to the client, which decides whether to resume the
+// forward, to settle it using a preimage, or to fail it back. Forwards that
+// aren't resolved in time are resumed.
+func (r *rpcServer) HtlcInterceptor(
+	stream lnrpc.Lightning_HtlcInterceptorServer) error {
+
+	if !atomic.CompareAndSwapInt32(&r.interceptorActive, 0, 1) {
+		return errors.New("htlc interceptor already registered")
+	}
+	defer atomic.StoreInt32(&r.interceptorActive, 0)
+
+	newForwards := make(chan htlcswitch.InterceptedForward)
+	timedOut := make(chan htlcswitch.CircuitKey)
+	quit := make(chan struct{})
+	defer close(quit)
+
+	resumeForward := func(fwd htlcswitch.InterceptedForward) {
+		err := fwd.Resume()
+		if err != nil && err != htlcswitch.ErrFwdAlreadyResolved {
+			rpcsLog.Errorf("Unable to resume forward of %v: %v",
+				fwd.Packet().IncomingCircuit, err)
+		}
+	}
+
+	// Our interceptor hands each forward over to the main loop below,
+	// which sends it to the client. If the client disconnects in the
+	// meantime, the forward is resumed.
+	interceptor := func(fwd htlcswitch.InterceptedForward) {
+		select {
+		case newForwards <- fwd:
+		case <-quit:
+			resumeForward(fwd)
+		case <-r.quit:
+			resumeForward(fwd)
+		}
+	}
+
+	r.server.htlcSwitch.SetInterceptor(interceptor)
+	defer r.server.htlcSwitch.SetInterceptor(nil)
+
+	// We'll read the client's responses within their own goroutine, so
+	// that we're able to send new forwards while waiting for them.
+	responses := make(chan *lnrpc.ForwardHtlcInterceptResponse)
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case responses <- resp:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	// pendingForwards maps the incoming circuit key of each forward sent
+	// to the client to the forward itself. Any forwards still pending once
+	// the client disconnects are resumed.
+	pendingForwards := make(
+		map[htlcswitch.CircuitKey]htlcswitch.InterceptedForward,
+	)
+	defer func() {
+		for _, fwd := range pendingForwards {
+			resumeForward(fwd)
+		}
+	}()
+
+	for {
+		select {
+		case fwd := <-newForwards:
+			pkt := fwd.Packet()
+			pendingForwards[pkt.IncomingCircuit] = fwd
+
+			// If the client doesn't resolve the forward in time,
+			// we'll resume it.
+			key := pkt.IncomingCircuit
+			time.AfterFunc(cfg.InterceptorTimeout, func() {
+				select {
+				case timedOut <- key:
+				case <-quit:
+				}
+			})
+
+			err := stream.Send(&lnrpc.ForwardHtlcInterceptRequest{
+				IncomingCircuitKey: &lnrpc.CircuitKey{
+					ChanId: key.ChanID.ToUint64(),
+					HtlcId: key.HtlcID,
+				},
+				IncomingAmountMsat:      uint64(pkt.IncomingAmount),
+				IncomingExpiry:          pkt.IncomingExpiry,
+				PaymentHash:             pkt.Hash[:],
+				OutgoingRequestedChanId: pkt.OutgoingChanID.ToUint64(),
+				OutgoingAmountMsat:      uint64(pkt.OutgoingAmount),
+				OutgoingExpiry:          pkt.OutgoingExpiry,
+				OnionBlob:               pkt.OnionBlob[:],
+			})
+			if err != nil {
+				return err
+			}
+
+		case key := <-timedOut:
+			fwd, ok := pendingForwards[key]
+			if !ok {
+				continue
+			}
+			delete(pendingForwards, key)
+
+			rpcsLog.Warnf("Htlc interceptor didn't resolve forward "+
+				"of %v in time, resuming", key)
+
+			resumeForward(fwd)
+
+		case resp := <-responses:
+			if resp.IncomingCircuitKey == nil {
+				return errors.New("incoming circuit key not set")
+			}
+			key := htlcswitch.CircuitKey{
+				ChanID: lnwire.NewShortChanIDFromInt(
+					resp.IncomingCircuitKey.ChanId,
+				),
+				HtlcID: resp.IncomingCircuitKey.HtlcId,
+			}
+
+			fwd, ok := pendingForwards[key]
+			if !ok {
+				rpcsLog.Warnf("Received htlc interceptor "+
+					"response for unknown forward %v", key)
+				continue
+			}
+
+			var err error
+			switch resp.Action {
+			case lnrpc.ForwardHtlcInterceptResponse_RESUME:
+				err = fwd.Resume()
+
+			case lnrpc.ForwardHtlcInterceptResponse_FAIL:
+				failure, ferr := unmarshallInterceptFailure(
+					resp.FailureCode,
+				)
+				if ferr != nil {
+					return ferr
+				}
+				err = fwd.Fail(failure)
+
+			case lnrpc.ForwardHtlcInterceptResponse_SETTLE:
+				var preimage [32]byte
+				if len(resp.Preimage) != len(preimage) {
+					return fmt.Errorf("preimage must be "+
+						"%v bytes", len(preimage))
+				}
+				copy(preimage[:], resp.Preimage)
+
+				hash := sha256.Sum256(preimage[:])
+				if hash != fwd.Packet().Hash {
+					return htlcswitch.ErrInvalidPreimage
+				}
+
+				// We'll add the preimage to our preimage cache
+				// first, such that the incoming HTLC can still
+				// be claimed on-chain if needed.
+				err = r.server.witnessBeacon.AddPreimage(
+					preimage[:],
+				)
+				if err != nil {
+					return err
+				}
+				err = fwd.Settle(preimage)
+
+			default:
+				return fmt.Errorf("unknown resolve action: %v",
+					resp.Action)
+			}
+			delete(pendingForwards, key)
+
+			if err != nil && err != htlcswitch.ErrFwdAlreadyResolved {
+				rpcsLog.Errorf("Unable to resolve forward of "+
+					"%v: %v", key, err)
+			}
+
+		case err := <-errChan:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+
+		case <-r.quit:
+			return errors.New("rpc server shutting down")
+		}
+	}
+}
+
+// SubscribeHtlcEvents creates a uni-directional stream from the server to the
+// client which delivers a stream of htlc events as they occur, allowing
+// routing failures to be monitored as they happen.
+func (r *rpcServer) SubscribeHtlcEvents(req *lnrpc.SubscribeHtlcEventsRequest,
+	updateStream lnrpc.Lightning_SubscribeHtlcEventsServer) error {
+
+	htlcClient, err := r.server.htlcNotifier.SubscribeHtlcEvents()
+	if err != nil {
+		return err
+	}
+	defer htlcClient.Cancel()
+
+	for {
+		select {
+		case event := <-htlcClient.Events:
+			rpcEvent, err := marshallHtlcEvent(event)
+			if err != nil {
+				return err
+			}
+
+			if err := updateStream.Send(rpcEvent); err != nil {
+				return err
+			}
+
+		// The subscription was dropped by the htlc notifier, as the
+		// client didn't keep up with reading events.
+		case <-htlcClient.Quit:
+			return fmt.Errorf("htlc event subscription dropped, " +
+				"client too slow")
+
+		// The server is quitting, so we'll exit immediately. Returning
+		// nil will close the clients read end of the stream.
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// marshallHtlcEvent converts an event emitted by the htlc notifier into its
+// rpc representation.
+func marshallHtlcEvent(event interface{}) (*lnrpc.HtlcEvent, error) {
+	var (
+		key       htlcswitch.HtlcKey
+		eventType htlcswitch.HtlcEventType
+		timestamp time.Time
+		rpcEvent  = &lnrpc.HtlcEvent{}
+	)
+
+	switch e := event.(type) {
+	case *htlcswitch.ForwardingEvent:
+		key, eventType, timestamp = e.HtlcKey, e.HtlcEventType, e.Timestamp
+		rpcEvent.ForwardEvent = &lnrpc.ForwardEvent{
+			Info: marshallHtlcInfo(e.HtlcInfo),
+		}
+
+	case *htlcswitch.ForwardingFailEvent:
+		key, eventType, timestamp = e.HtlcKey, e.HtlcEventType, e.Timestamp
+		rpcEvent.ForwardFailEvent = &lnrpc.ForwardFailEvent{}
+		if e.FailureMessage != nil {
+			rpcEvent.ForwardFailEvent.FailureCode = uint32(
+				e.FailureMessage.Code(),
+			)
+		}
+		if e.FailingHop != nil {
+			rpcEvent.ForwardFailEvent.FailureSourcePubkey =
+				e.FailingHop.SerializeCompressed()
+		}
+
+	case *htlcswitch.LinkFailEvent:
+		key, eventType, timestamp = e.HtlcKey, e.HtlcEventType, e.Timestamp
+		detail, err := marshallFailureDetail(e.FailureDetail)
+		if err != nil {
+			return nil, err
+		}
+		rpcEvent.LinkFailEvent = &lnrpc.LinkFailEvent{
+			Info:          marshallHtlcInfo(e.HtlcInfo),
+			FailureCode:   uint32(e.FailureMessage.Code()),
+			FailureDetail: detail,
+			FailureString: e.FailureDetail.String(),
+			Incoming:      e.Incoming,
+		}
+
+	case *htlcswitch.SettleEvent:
+		key, eventType, timestamp = e.HtlcKey, e.HtlcEventType, e.Timestamp
+		rpcEvent.SettleEvent = &lnrpc.SettleEvent{}
+
+	default:
+		return nil, fmt.Errorf("unknown htlc event type: %T", event)
+	}
+
+	switch eventType {
+	case htlcswitch.HtlcEventTypeSend:
+		rpcEvent.EventType = lnrpc.HtlcEvent_SEND
+	case htlcswitch.HtlcEventTypeReceive:
+		rpcEvent.EventType = lnrpc.HtlcEvent_RECEIVE
+	case htlcswitch.HtlcEventTypeForward:
+		rpcEvent.EventType = lnrpc.HtlcEvent_FORWARD
+	default:
+		return nil, fmt.Errorf("unknown htlc event type: %v", eventType)
+	}
+
+	rpcEvent.IncomingChannelId = key.IncomingCircuit.ChanID.ToUint64()
+	rpcEvent.IncomingHtlcId = key.IncomingCircuit.HtlcID
+	rpcEvent.OutgoingChannelId = key.OutgoingCircuit.ChanID.ToUint64()
+	rpcEvent.OutgoingHtlcId = key.OutgoingCircuit.HtlcID
+	rpcEvent.TimestampNs = uint64(timestamp.UnixNano())
+
+	return rpcEvent, nil
+}
+
+// marshallHtlcInfo converts the amounts and time-locks of an htlc event into
+// their rpc representation.
+func marshallHtlcInfo(info htlcswitch.HtlcInfo) *lnrpc.HtlcInfo {
+	return &lnrpc.HtlcInfo{
+		IncomingTimelock: info.IncomingTimeLock,
+		OutgoingTimelock: info.OutgoingTimeLock,
+		IncomingAmtMsat:  uint64(info.IncomingAmt),
+		OutgoingAmtMsat:  uint64(info.OutgoingAmt),
+	}
+}
+
+// marshallFailureDetail converts the reason for which our node failed an htlc
+// into its rpc representation.
+func marshallFailureDetail(
+	detail htlcswitch.FailureDetail) (lnrpc.LinkFailEvent_FailureDetail, error) {
+
+	switch detail {
+	case htlcswitch.FailureDetailNone:
+		return lnrpc.LinkFailEvent_NO_DETAIL, nil
+	case htlcswitch.FailureDetailOnionDecode:
+		return lnrpc.LinkFailEvent_ONION_DECODE, nil
+	case htlcswitch.FailureDetailOnionEncode:
+		return lnrpc.LinkFailEvent_ONION_ENCODE, nil
+	case htlcswitch.FailureDetailExpiryTooSoon:
+		return lnrpc.LinkFailEvent_EXPIRY_TOO_SOON, nil
+	case htlcswitch.FailureDetailAmountBelowMinimum:
+		return lnrpc.LinkFailEvent_AMOUNT_BELOW_MINIMUM, nil
+	case htlcswitch.FailureDetailFeeInsufficient:
+		return lnrpc.LinkFailEvent_FEE_INSUFFICIENT, nil
+	case htlcswitch.FailureDetailIncorrectCltvExpiry:
+		return lnrpc.LinkFailEvent_INCORRECT_CLTV_EXPIRY, nil
+	case htlcswitch.FailureDetailIncorrectAmount:
+		return lnrpc.LinkFailEvent_INCORRECT_AMOUNT, nil
+	case htlcswitch.FailureDetailUnknownInvoice:
+		return lnrpc.LinkFailEvent_UNKNOWN_INVOICE, nil
+	case htlcswitch.FailureDetailInvoiceCanceled:
+		return lnrpc.LinkFailEvent_INVOICE_CANCELED, nil
+	case htlcswitch.FailureDetailInvalidKeySend:
+		return lnrpc.LinkFailEvent_INVALID_KEYSEND, nil
+	case htlcswitch.FailureDetailMPPTimeout:
+		return lnrpc.LinkFailEvent_MPP_TIMEOUT, nil
+	case htlcswitch.FailureDetailUnknownNextPeer:
+		return lnrpc.LinkFailEvent_UNKNOWN_NEXT_PEER, nil
+	case htlcswitch.FailureDetailInsufficientBalance:
+		return lnrpc.LinkFailEvent_INSUFFICIENT_BALANCE, nil
+	case htlcswitch.FailureDetailAddRejected:
+		return lnrpc.LinkFailEvent_ADD_REJECTED, nil
+	case htlcswitch.FailureDetailIncompleteForward:
+		return lnrpc.LinkFailEvent_INCOMPLETE_FORWARD, nil
+	case htlcswitch.FailureDetailIntercepted:
+		return lnrpc.LinkFailEvent_INTERCEPTED, nil
+	default:
+		return 0, fmt.Errorf("unknown failure detail: %v", detail)
+	}
+}
+
+// QueryMissionControl returns the outcomes of past payment attempts recorded
+// by mission control, for each pair of nodes that payments were attempted to
+// be forwarded between.
+func (r *rpcServer) QueryMissionControl(ctx context.Context,
+	in *lnrpc.QueryMissionControlRequest) (*lnrpc.QueryMissionControlResponse,
+	error) {
+
+	history := r.server.chanRouter.QueryMissionControl()
+
+	resp := &lnrpc.QueryMissionControlResponse{
+		Pairs: make([]*lnrpc.PairHistory, 0, len(history)),
+	}
+	for _, pair := range history {
+		resp.Pairs = append(resp.Pairs, &lnrpc.PairHistory{
+			NodeFrom:       pair.From[:],
+			NodeTo:         pair.To[:],
+			FailTime:       marshallUnixTime(pair.FailTime),
+			FailAmtMsat:    int64(pair.FailAmt),
+			SuccessTime:    marshallUnixTime(pair.SuccessTime),
+			SuccessAmtMsat: int64(pair.SuccessAmt),
+		})
+	}
+
+	return resp, nil
+}
+
+// ImportMissionControl merges the passed node pair results into the history
+// of mission control.
+func (r *rpcServer) ImportMissionControl(ctx context.Context,
+	in *lnrpc.ImportMissionControlRequest) (*lnrpc.ImportMissionControlResponse,
+	error) {
+
+	pairs := make([]*channeldb.MissionControlPair, 0, len(in.Pairs))
+	for _, rpcPair := range in.Pairs {
+		if len(rpcPair.NodeFrom) != 33 || len(rpcPair.NodeTo) != 33 {
+			return nil, fmt.Errorf("node pair keys must be 33 bytes")
+		}
+
+		if rpcPair.FailAmtMsat < 0 || rpcPair.SuccessAmtMsat < 0 {
+			return nil, fmt.Errorf("node pair amounts must not be " +
+				"negative")
+		}
+
+		pair := &channeldb.MissionControlPair{
+			FailTime:    unmarshallUnixTime(rpcPair.FailTime),
+			FailAmt:     lnwire.MilliSatoshi(rpcPair.FailAmtMsat),
+			SuccessTime: unmarshallUnixTime(rpcPair.SuccessTime),
+			SuccessAmt:  lnwire.MilliSatoshi(rpcPair.SuccessAmtMsat),
+		}
+		copy(pair.From[:], rpcPair.NodeFrom)
+		copy(pair.To[:], rpcPair.NodeTo)
+
+		pairs = append(pairs, pair)
+	}
+
+	err := r.server.chanRouter.ImportMissionControl(pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("Imported %v mission control pairs", len(pairs))
+
+	return &lnrpc.ImportMissionControlResponse{}, nil
+}
+
+// ResetMissionControl clears all outcomes of past payment attempts recorded by
+// mission control.
+func (r *rpcServer) ResetMissionControl(ctx context.Context,
+	in *lnrpc.ResetMissionControlRequest) (*lnrpc.ResetMissionControlResponse,
+	error) {
+
+	if err := r.server.chanRouter.ResetMissionControl(); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("Mission control history reset")
+
+	return &lnrpc.ResetMissionControlResponse{}, nil
+}
+
+// marshallUnixTime converts the passed time to unix seconds, using zero for
+// the zero time.
+func marshallUnixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+// unmarshallUnixTime converts the passed unix seconds to a time, returning the
+// zero time for zero.
+func unmarshallUnixTime(unix int64) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(unix, 0)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+// TestDetermineCloseFeeRates checks that the fee rate a cooperative closure
+// starts at is clamped to the maximum fee rate when it was estimated, while a
+// fee rate set explicitly above the maximum is rejected.
+func TestDetermineCloseFeeRates(t *testing.T) {
+	t.Parallel()
+
+	estimator := &lnwallet.StaticFeeEstimator{FeeRate: 50}
+
+	tests := []struct {
+		name          string
+		targetConf    int32
+		feePerByte    int64
+		maxFeePerByte int64
+		feeRate       lnwallet.SatPerVByte
+		maxFeeRate    lnwallet.SatPerVByte
+		expectErr     bool
+	}{
+		{
+			name:       "no max fee rate",
+			feeRate:    50,
+			maxFeeRate: 0,
+		},
+		{
+			name:          "estimate below max fee rate",
+			maxFeePerByte: 60,
+			feeRate:       50,
+			maxFeeRate:    60,
+		},
+		{
+			name:          "estimate clamped to max fee rate",
+			maxFeePerByte: 20,
+			feeRate:       20,
+			maxFeeRate:    20,
+		},
+		{
+			name:          "target conf estimate clamped to max fee rate",
+			targetConf:    2,
+			maxFeePerByte: 20,
+			feeRate:       20,
+			maxFeeRate:    20,
+		},
+		{
+			name:          "explicit fee rate below max fee rate",
+			feePerByte:    10,
+			maxFeePerByte: 20,
+			feeRate:       10,
+			maxFeeRate:    20,
+		},
+		{
+			name:          "explicit fee rate exceeds max fee rate",
+			feePerByte:    30,
+			maxFeePerByte: 20,
+			expectErr:     true,
+		},
+		{
+			name:          "negative max fee rate",
+			maxFeePerByte: -1,
+			expectErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		feeRate, maxFeeRate, err := determineCloseFeeRates(
+			estimator, test.targetConf, test.feePerByte,
+			test.maxFeePerByte,
+		)
+		switch {
+		case test.expectErr && err == nil:
+			t.Fatalf("%v: expected error", test.name)
+
+		case !test.expectErr && err != nil:
+			t.Fatalf("%v: unexpected error: %v", test.name, err)
+		}
+
+		if feeRate != test.feeRate {
+			t.Fatalf("%v: expected fee rate %v, got %v", test.name,
+				test.feeRate, feeRate)
+		}
+		if maxFeeRate != test.maxFeeRate {
+			t.Fatalf("%v: expected max fee rate %v, got %v",
+				test.name, test.maxFeeRate, maxFeeRate)
+		}
+	}
+}
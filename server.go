@@ -19,6 +19,7 @@ import (
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/discovery"
@@ -26,6 +27,12 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/sweep"
+	"github.com/lightningnetwork/lnd/torsvc"
+	"github.com/lightningnetwork/lnd/watchtower/wtclient"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/lightningnetwork/lnd/watchtower/wtserver"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/connmgr"
@@ -52,6 +59,21 @@ var (
 	// maximumBackoff is the largest backoff we will permit when
 	// reattempting connections to persistent peers.
 	maximumBackoff = time.Hour
+
+	// sweepBatchWindow is the time window during which inputs offered to
+	// the sweeper are collected, before a sweep transaction spending them
+	// is published.
+	sweepBatchWindow = 30 * time.Second
+)
+
+const (
+	// maxInputsPerSweepTx is the maximum number of inputs the sweeper
+	// includes in a single sweep transaction.
+	maxInputsPerSweepTx = 100
+
+	// maxSweepFeeRate is the maximum fee rate in sat/vbyte the sweeper
+	// will pay when bumping the fee of unconfirmed sweep transactions.
+	maxSweepFeeRate = lnwallet.SatPerVByte(500)
 )
 
 // server is the main server of the Lightning Network Daemon. The server houses
@@ -101,6 +123,8 @@ type server struct {
 
 	htlcSwitch *htlcswitch.Switch
 
+	htlcNotifier *htlcswitch.HtlcNotifier
+
 	invoices *invoiceRegistry
 
 	witnessBeacon contractcourt.WitnessBeacon
@@ -113,12 +137,39 @@ type server struct {
 
 	utxoNursery *utxoNursery
 
+	sweeper *sweep.UtxoSweeper
+
 	chainArb *contractcourt.ChainArbitrator
 
+	// chanNotifier dispatches notifications of newly opened and fully
+	// closed channels to any interested sub-systems.
+	chanNotifier *channelNotifier
+
+	// chanSubSwapper keeps the on-disk static channel backup up to date
+	// as the set of open channels changes.
+	chanSubSwapper *chanbackup.SubSwapper
+
+	// towerClient backs up the justice transaction for every revoked state
+	// to a private watchtower. It is nil if no tower has been configured.
+	towerClient *wtclient.Client
+
+	// towerClientDB persists the tower client's sessions and pending
+	// updates.
+	towerClientDB *wtdb.ClientDB
+
 	sphinx *htlcswitch.OnionProcessor
 
 	connMgr *connmgr.ConnManager
 
+	// torController is the controller used to create the onion service
+	// through which we accept inbound connections over Tor. It is nil if
+	// no onion service was requested.
+	torController *torsvc.Controller
+
+	// metrics exports the internal state of the server as Prometheus
+	// metrics. It is nil if the exporter hasn't been activated.
+	metrics *metricsExporter
+
 	// globalFeatures feature vector which affects HTLCs and thus are also
 	// advertised to other nodes.
 	globalFeatures *lnwire.FeatureVector
@@ -151,7 +202,11 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		}
 	}
 
-	globalFeatures := lnwire.NewRawFeatureVector()
+	// We're able to receive payments that are split across multiple
+	// paths, so we'll advertise this to the network.
+	globalFeatures := lnwire.NewRawFeatureVector(
+		lnwire.MultiPathPaymentsOptional,
+	)
 
 	serializedPubKey := privKey.PubKey().SerializeCompressed()
 
@@ -167,11 +222,13 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		chanDB: chanDB,
 		cc:     cc,
 
-		invoices: newInvoiceRegistry(chanDB),
+		invoices: newInvoiceRegistry(chanDB, cfg.AcceptKeySend),
 
 		identityPriv: privKey,
 		nodeSigner:   newNodeSigner(privKey),
 
+		chanNotifier: newChannelNotifier(chanDB),
+
 		// TODO(roasbeef): derive proper onion key based on rotation
 		// schedule
 		sphinx:      htlcswitch.NewOnionProcessor(sphinxRouter),
@@ -209,6 +266,8 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 			debugPre[:], debugHash[:])
 	}
 
+	s.htlcNotifier = htlcswitch.NewHtlcNotifier(time.Now)
+
 	htlcSwitch, err := htlcswitch.New(htlcswitch.Config{
 		DB:      chanDB,
 		SelfKey: s.identityPriv.PubKey(),
@@ -237,6 +296,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		FwdingLog:             chanDB.ForwardingLog(),
 		SwitchPackager:        channeldb.NewSwitchPackager(),
 		ExtractErrorEncrypter: s.sphinx.ExtractErrorEncrypter,
+		HtlcNotifier:          s.htlcNotifier,
 	})
 	if err != nil {
 		return nil, err
@@ -265,6 +325,28 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		selfAddrs = append(selfAddrs, lnAddr)
 	}
 
+	// If we were requested to automatically create an onion service for
+	// our listeners, then we'll do so now through the Tor control port,
+	// and advertise its address to the network along with any others.
+	if cfg.Tor.V2 || cfg.Tor.V3 {
+		s.torController = torsvc.NewController(
+			cfg.Tor.Control, cfg.Tor.Password,
+		)
+
+		onionAddr, err := s.initTorController(listenAddrs)
+		if err != nil {
+			return nil, err
+		}
+
+		selfAddrs = append(selfAddrs, onionAddr)
+	}
+
+	// If requested, we'll create the Prometheus exporter now so that the
+	// sub-systems created below are able to report to it.
+	if cfg.Prometheus.Active {
+		s.metrics = newMetricsExporter(s, cfg.Prometheus.Listen)
+	}
+
 	chanGraph := chanDB.ChannelGraph()
 
 	// Parse node color from configuration.
@@ -325,7 +407,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 	if err != nil {
 		return nil, err
 	}
-	s.chanRouter, err = routing.New(routing.Config{
+	routerCfg := routing.Config{
 		Graph:     chanGraph,
 		Chain:     cc.chainIO,
 		ChainView: cc.chainView,
@@ -342,9 +424,23 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 
 			return s.htlcSwitch.SendHTLC(firstHopPub, htlcAdd, errorDecryptor)
 		},
+		ShardStore:          chanDB,
+		MissionControlStore: chanDB,
+		MissionControl: routing.MissionControlConfig{
+			AprioriHopProbability: cfg.Routing.AprioriHopProb,
+			PenaltyHalfLife:       cfg.Routing.PenaltyHalfLife,
+			AttemptCost: lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(cfg.Routing.AttemptCost),
+			),
+		},
 		ChannelPruneExpiry: time.Duration(time.Hour * 24 * 14),
 		GraphPruneInterval: time.Duration(time.Hour),
-	})
+	}
+	if s.metrics != nil {
+		routerCfg.NotifyPaymentAttempt = s.metrics.ObservePaymentAttempt
+	}
+
+	s.chanRouter, err = routing.New(routerCfg)
 	if err != nil {
 		return nil, fmt.Errorf("can't create router: %v", err)
 	}
@@ -361,6 +457,9 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		RetransmitDelay:  time.Minute * 30,
 		DB:               chanDB,
 		AnnSigner:        s.nodeSigner,
+		ChannelSeries:    discovery.NewChanSeries(chanDB.ChannelGraph()),
+		Encoding:         lnwire.EncodingSortedPlain,
+		NumActiveSyncers: cfg.NumGraphSyncPeers,
 	},
 		s.identityPriv.PubKey(),
 	)
@@ -368,24 +467,44 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		return nil, err
 	}
 
-	utxnStore, err := newNurseryStore(activeNetParams.GenesisHash, chanDB)
+	sweeperStore, err := sweep.NewSweeperStore(chanDB)
 	if err != nil {
-		srvrLog.Errorf("unable to create nursery store: %v", err)
+		srvrLog.Errorf("unable to create sweeper store: %v", err)
 		return nil, err
 	}
 
-	s.utxoNursery = newUtxoNursery(&NurseryConfig{
-		ChainIO:   cc.chainIO,
-		ConfDepth: 1,
-		DB:        chanDB,
-		Estimator: cc.feeEstimator,
+	s.sweeper = sweep.New(&sweep.UtxoSweeperConfig{
 		GenSweepScript: func() ([]byte, error) {
 			return newSweepPkScript(cc.wallet)
 		},
+		FeeEstimator:       cc.feeEstimator,
+		PublishTransaction: cc.wallet.PublishTransaction,
+		NewBatchTimer: func() <-chan time.Time {
+			return time.NewTimer(sweepBatchWindow).C
+		},
+		Notifier:       cc.chainNotifier,
+		ChainIO:        cc.chainIO,
+		Store:          sweeperStore,
+		Signer:         cc.wallet.Cfg.Signer,
+		MaxInputsPerTx: maxInputsPerSweepTx,
+		MaxFeeRate:     maxSweepFeeRate,
+		Wallet:         cc.wallet,
+	})
+
+	utxnStore, err := newNurseryStore(activeNetParams.GenesisHash, chanDB)
+	if err != nil {
+		srvrLog.Errorf("unable to create nursery store: %v", err)
+		return nil, err
+	}
+
+	s.utxoNursery = newUtxoNursery(&NurseryConfig{
+		ChainIO:            cc.chainIO,
+		ConfDepth:          1,
+		DB:                 chanDB,
 		Notifier:           cc.chainNotifier,
 		PublishTransaction: cc.wallet.PublishTransaction,
-		Signer:             cc.wallet.Cfg.Signer,
 		Store:              utxnStore,
+		SweepInput:         s.sweeper.SweepInput,
 	})
 
 	// Construct a closure that wraps the htlcswitch's CloseLink method.
@@ -393,7 +512,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		closureType htlcswitch.ChannelCloseType) {
 		// TODO(conner): Properly respect the update and error channels
 		// returned by CloseLink.
-		s.htlcSwitch.CloseLink(chanPoint, closureType, 0)
+		s.htlcSwitch.CloseLink(chanPoint, closureType, 0, 0)
 	}
 
 	s.chainArb = contractcourt.NewChainArbitrator(contractcourt.ChainArbitratorConfig{
@@ -401,10 +520,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		// TODO(roasbeef): properly configure
 		//  * needs to be << or specified final hop time delta
 		BroadcastDelta: defaultBroadcastDelta,
-		NewSweepAddr: func() ([]byte, error) {
-			return newSweepPkScript(cc.wallet)
-		},
-		PublishTx: cc.wallet.PublishTransaction,
+		PublishTx:      cc.wallet.PublishTransaction,
 		DeliverResolutionMsg: func(msgs ...contractcourt.ResolutionMsg) error {
 			for _, msg := range msgs {
 				err := s.htlcSwitch.ProcessContractResolution(msg)
@@ -434,11 +550,11 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 				chanPoint, commitRes, outRes, inRes,
 			)
 		},
-		PreimageDB:   s.witnessBeacon,
-		Notifier:     cc.chainNotifier,
-		Signer:       cc.wallet.Cfg.Signer,
-		FeeEstimator: cc.feeEstimator,
-		ChainIO:      cc.chainIO,
+		PreimageDB: s.witnessBeacon,
+		Notifier:   cc.chainNotifier,
+		Signer:     cc.wallet.Cfg.Signer,
+		ChainIO:    cc.chainIO,
+		SweepInput: s.sweeper.SweepInput,
 		MarkLinkInactive: func(chanPoint wire.OutPoint) error {
 			chanID := lnwire.NewChanIDFromOutPoint(&chanPoint)
 			return s.htlcSwitch.RemoveLink(chanID)
@@ -447,27 +563,75 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 			_, err := cc.wallet.GetPrivKey(addr)
 			return err == nil
 		},
+		NotifyClosedChannel: s.chanNotifier.NotifyClosedChannel,
 	}, chanDB)
 
 	s.breachArbiter = newBreachArbiter(&BreachConfig{
 		CloseLink: closeLink,
 		DB:        chanDB,
-		Estimator: s.cc.feeEstimator,
-		GenSweepScript: func() ([]byte, error) {
-			return newSweepPkScript(cc.wallet)
-		},
-		Notifier:           cc.chainNotifier,
-		PublishTransaction: cc.wallet.PublishTransaction,
+		Notifier:  cc.chainNotifier,
 		SubscribeChannelEvents: func(chanPoint wire.OutPoint) (*contractcourt.ChainEventSubscription, error) {
 			// We'll request a sync dispatch to ensure that the channel
 			// is only marked as closed *after* we update our internal
 			// state.
 			return s.chainArb.SubscribeChannelEvents(chanPoint, true)
 		},
-		Signer: cc.wallet.Cfg.Signer,
-		Store:  newRetributionStore(chanDB),
+		Store:      newRetributionStore(chanDB),
+		SweepInput: s.sweeper.SweepInput,
 	})
 
+	// Assemble a static channel backup for each of our current channels,
+	// then create the sub-swapper which will keep the on-disk backup file
+	// up to date as channels are opened and closed.
+	startingChans, err := fetchStaticChanBackups(chanDB)
+	if err != nil {
+		return nil, err
+	}
+	s.chanSubSwapper, err = chanbackup.NewSubSwapper(
+		startingChans, s.chanNotifier, cc.wallet,
+		chanbackup.NewMultiFile(cfg.BackupFilePath),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// If a private watchtower has been configured, we'll create a tower
+	// client that will back up the justice transaction for each revoked
+	// state to the tower.
+	if len(cfg.WtClient.PrivateTowerURIs) > 0 {
+		towerAddr, err := parsePrivateTowerURI(
+			cfg.WtClient.PrivateTowerURIs[0],
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		s.towerClientDB, err = wtdb.OpenClientDB(chanDB.Path())
+		if err != nil {
+			return nil, err
+		}
+
+		s.towerClient, err = wtclient.New(&wtclient.Config{
+			Signer: cc.wallet.Cfg.Signer,
+			NewAddress: func() ([]byte, error) {
+				return newSweepPkScript(cc.wallet)
+			},
+			SecretKeyRing: cc.wallet,
+			Dial: func(localPriv *btcec.PrivateKey,
+				netAddr *lnwire.NetAddress) (wtserver.Peer, error) {
+
+				return brontide.Dial(localPriv, netAddr, cfg.net.Dial)
+			},
+			DB:           s.towerClientDB,
+			PrivateTower: towerAddr,
+			Policy:       wtpolicy.DefaultPolicy(),
+		})
+		if err != nil {
+			s.towerClientDB.Close()
+			return nil, err
+		}
+	}
+
 	// Create the connection manager which will be responsible for
 	// maintaining persistent outbound connections and also accepting new
 	// incoming connections
@@ -487,6 +651,61 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 	return s, nil
 }
 
+// initTorController establishes an authenticated connection to the Tor
+// server, and creates an onion service mapping to each of the ports the
+// server is listening on. The address of the onion service is returned, such
+// that it can be advertised to the network.
+func (s *server) initTorController(listenAddrs []string) (net.Addr, error) {
+	if err := s.torController.Start(); err != nil {
+		return nil, err
+	}
+
+	// Determine the different ports the server is listening on. The onion
+	// service's virtual port will map to these ports and one will be
+	// picked at random when the onion service is being accessed.
+	listenPorts := make([]int, 0, len(listenAddrs))
+	for _, listenAddr := range listenAddrs {
+		_, portStr, err := net.SplitHostPort(listenAddr)
+		if err != nil {
+			s.torController.Stop()
+			return nil, err
+		}
+
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			s.torController.Stop()
+			return nil, err
+		}
+
+		listenPorts = append(listenPorts, port)
+	}
+
+	onionType := torsvc.V2
+	if cfg.Tor.V3 {
+		onionType = torsvc.V3
+	}
+
+	// Once the port mapping has been set, we can go ahead and create our
+	// onion service. Its private key is stored on disk in order to regain
+	// access to the same onion service when restarting.
+	onionAddr, err := s.torController.AddOnion(torsvc.AddOnionConfig{
+		Type:           onionType,
+		VirtualPort:    defaultPeerPort,
+		TargetPorts:    listenPorts,
+		PrivateKeyPath: cfg.Tor.PrivateKeyPath,
+	})
+	if err != nil {
+		s.torController.Stop()
+		return nil, fmt.Errorf("unable to create %v onion service: %v",
+			onionType, err)
+	}
+
+	srvrLog.Infof("Accepting inbound connections through %v onion "+
+		"service %v", onionType, onionAddr)
+
+	return onionAddr, nil
+}
+
 // Started returns true if the server has been started, and false otherwise.
 // NOTE: This function is safe for concurrent access.
 func (s *server) Started() bool {
@@ -513,9 +732,15 @@ func (s *server) Start() error {
 	if err := s.sphinx.Start(); err != nil {
 		return err
 	}
+	if err := s.invoices.Start(); err != nil {
+		return err
+	}
 	if err := s.htlcSwitch.Start(); err != nil {
 		return err
 	}
+	if err := s.sweeper.Start(); err != nil {
+		return err
+	}
 	if err := s.utxoNursery.Start(); err != nil {
 		return err
 	}
@@ -531,6 +756,19 @@ func (s *server) Start() error {
 	if err := s.chanRouter.Start(); err != nil {
 		return err
 	}
+	if err := s.chanSubSwapper.Start(); err != nil {
+		return err
+	}
+	if s.towerClient != nil {
+		if err := s.towerClient.Start(); err != nil {
+			return err
+		}
+	}
+	if s.metrics != nil {
+		if err := s.metrics.Start(); err != nil {
+			return err
+		}
+	}
 
 	// With all the relevant sub-systems started, we'll now attempt to
 	// establish persistent connections to our direct channel collaborators
@@ -577,15 +815,30 @@ func (s *server) Stop() error {
 	s.cc.chainNotifier.Stop()
 	s.chanRouter.Stop()
 	s.htlcSwitch.Stop()
+	s.htlcNotifier.Stop()
+	s.invoices.Stop()
 	s.sphinx.Stop()
 	s.utxoNursery.Stop()
 	s.breachArbiter.Stop()
 	s.authGossiper.Stop()
 	s.chainArb.Stop()
+	s.sweeper.Stop()
+	s.chanSubSwapper.Stop()
+	s.chanNotifier.Stop()
+	if s.towerClient != nil {
+		s.towerClient.Stop()
+		s.towerClientDB.Close()
+	}
 	s.cc.wallet.Shutdown()
 	s.cc.chainView.Stop()
 	s.connMgr.Stop()
 	s.cc.feeEstimator.Stop()
+	if s.torController != nil {
+		s.torController.Stop()
+	}
+	if s.metrics != nil {
+		s.metrics.Stop()
+	}
 
 	// Disconnect from each active peers to ensure that
 	// peerTerminationWatchers signal completion to each peer.
@@ -1383,8 +1636,30 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 	// feature vector to advertise to the remote node.
 	localFeatures := lnwire.NewRawFeatureVector()
 
+	// We'll signal that we support the gossip query features, allowing
+	// the remote peer to only sync the parts of the graph it's missing.
+	localFeatures.Set(lnwire.GossipQueriesOptional)
+
+	// We'll also signal that we understand upfront shutdown scripts, so
+	// that the remote peer can commit to its delivery script when opening
+	// a channel with us.
+	localFeatures.Set(lnwire.UpfrontShutdownScriptOptional)
+
+	// If large channels have been enabled, we'll signal that we're
+	// willing to open and accept them with peers that support them too.
+	if cfg.WumboChans {
+		localFeatures.Set(lnwire.WumboChannelsOptional)
+	}
+
+	// If anchor commitments have been enabled, we'll signal that we're
+	// able to use them for new channels with peers that support them too.
+	if cfg.AnchorCommitments {
+		localFeatures.Set(lnwire.AnchorOutputsOptional)
+	}
+
 	// We'll only request a full channel graph sync if we detect that that
-	// we aren't fully synced yet.
+	// we aren't fully synced yet. Peers that understand gossip queries
+	// will ignore this bit in favor of syncing through queries.
 	if s.shouldRequestGraphSync() {
 		localFeatures.Set(lnwire.InitialRoutingSync)
 	}
@@ -1673,10 +1948,25 @@ func (s *server) addPeer(p *peer) {
 	s.wg.Add(1)
 	go s.peerTerminationWatcher(p)
 
-	// If the remote peer has the initial sync feature bit set, then we'll
-	// being the synchronization protocol to exchange authenticated channel
-	// graph edges/vertexes
-	if p.remoteLocalFeatures.HasFeature(lnwire.InitialRoutingSync) {
+	// If the remote peer understands gossip queries, then we'll create a
+	// gossip syncer for it, which will only sync the channels we're
+	// missing, and send them the updates they request.
+	//
+	// Otherwise, we'll fall back to the legacy behavior: if the remote
+	// peer has the initial sync feature bit set, then we'll begin the
+	// synchronization protocol to exchange authenticated channel graph
+	// edges/vertexes.
+	switch {
+	case p.remoteLocalFeatures.HasFeature(lnwire.GossipQueriesOptional),
+		p.remoteLocalFeatures.HasFeature(lnwire.GossipQueriesRequired):
+
+		err := s.authGossiper.InitSyncState(p.addr.IdentityKey)
+		if err != nil {
+			srvrLog.Errorf("unable to init gossip sync state for "+
+				"peer %v: %v", p, err)
+		}
+
+	case p.remoteLocalFeatures.HasFeature(lnwire.InitialRoutingSync):
 		go s.authGossiper.SynchronizeNode(p.addr.IdentityKey)
 	}
 
@@ -1719,6 +2009,9 @@ func (s *server) removePeer(p *peer) {
 	} else {
 		delete(s.outboundPeers, pubStr)
 	}
+
+	// Finally, we'll stop the gossip syncer for this peer, if it had one.
+	s.authGossiper.PruneSyncState(p.addr.IdentityKey)
 }
 
 // openChanReq is a message sent to the server in order to request the
@@ -1742,6 +2035,16 @@ type openChanReq struct {
 
 	remoteCsvDelay uint16
 
+	// shutdownScript is an optional script to which our funds will be
+	// paid upon a cooperative close. If set, it will be committed to
+	// within the OpenChannel message, preventing us from later paying out
+	// to any other script.
+	shutdownScript lnwire.DeliveryAddress
+
+	// psbtFunding denotes whether the funding transaction will be crafted
+	// by an external wallet through a PSBT, rather than by our wallet.
+	psbtFunding bool
+
 	// TODO(roasbeef): add ability to specify channel constraints as well
 
 	updates chan *lnrpc.OpenStatusUpdate
@@ -1860,13 +2163,18 @@ func (s *server) DisconnectPeer(pubKey *btcec.PublicKey) error {
 }
 
 // OpenChannel sends a request to the server to open a channel to the specified
-// peer identified by nodeKey with the passed channel funding parameters.
+// peer identified by nodeKey with the passed channel funding parameters. If a
+// non-empty shutdownScript is passed, then the channel's funds will only ever
+// be paid out to it upon a cooperative close. If psbtFunding is set, then the
+// funding transaction must be crafted by an external wallet, and supplied
+// through FinalizePsbtFunding once the funding output has been negotiated.
 //
 // NOTE: This function is safe for concurrent access.
 func (s *server) OpenChannel(nodeKey *btcec.PublicKey,
 	localAmt btcutil.Amount, pushAmt, minHtlc lnwire.MilliSatoshi,
 	fundingFeePerVSize lnwallet.SatPerVByte, private bool,
-	remoteCsvDelay uint16) (chan *lnrpc.OpenStatusUpdate, chan error) {
+	remoteCsvDelay uint16, shutdownScript lnwire.DeliveryAddress,
+	psbtFunding bool) (chan *lnrpc.OpenStatusUpdate, chan error) {
 
 	updateChan := make(chan *lnrpc.OpenStatusUpdate, 1)
 	errChan := make(chan error, 1)
@@ -1921,6 +2229,8 @@ func (s *server) OpenChannel(nodeKey *btcec.PublicKey,
 		private:            private,
 		minHtlc:            minHtlc,
 		remoteCsvDelay:     remoteCsvDelay,
+		shutdownScript:     shutdownScript,
+		psbtFunding:        psbtFunding,
 		updates:            updateChan,
 		err:                errChan,
 	}
@@ -1932,6 +2242,73 @@ func (s *server) OpenChannel(nodeKey *btcec.PublicKey,
 	return updateChan, errChan
 }
 
+// BatchOpenChannel sends a request to the server to open a channel with each
+// of the specified peers, all funded by a single funding transaction. If the
+// funding workflow with any of the peers fails, then none of the channels are
+// opened. Once the funding transaction has been broadcast, the channel points
+// of the pending channels are delivered, in the order of their requests.
+//
+// NOTE: The updates and err channels of the passed requests are ignored.
+func (s *server) BatchOpenChannel(reqs []*openChanReq,
+	fundingFeePerVSize lnwallet.SatPerVByte) (chan []*wire.OutPoint,
+	chan error) {
+
+	chanPointsChan := make(chan []*wire.OutPoint, 1)
+	errChan := make(chan error, 1)
+
+	if len(reqs) == 0 {
+		errChan <- fmt.Errorf("at least one channel must be specified")
+		return chanPointsChan, errChan
+	}
+
+	// First, we'll locate each of the peers we'll be opening channels
+	// with. If we're unable to locate any of them, then the entire
+	// request will fail.
+	channels := make([]*initFundingMsg, 0, len(reqs))
+	for _, req := range reqs {
+		pubKeyBytes := req.targetPubkey.SerializeCompressed()
+
+		s.mu.RLock()
+		targetPeer, ok := s.peersByPub[string(pubKeyBytes)]
+		s.mu.RUnlock()
+		if !ok {
+			errChan <- fmt.Errorf("unable to find peer NodeKey(%x)",
+				pubKeyBytes)
+			return chanPointsChan, errChan
+		}
+
+		req.chainHash = *activeNetParams.GenesisHash
+
+		channels = append(channels, &initFundingMsg{
+			peerAddress: targetPeer.addr,
+			openChanReq: req,
+		})
+	}
+
+	// If the fee rate wasn't specified, then we'll use a default
+	// confirmation target.
+	if fundingFeePerVSize == 0 {
+		var err error
+		estimator := s.cc.feeEstimator
+		fundingFeePerVSize, err = estimator.EstimateFeePerVSize(6)
+		if err != nil {
+			errChan <- err
+			return chanPointsChan, errChan
+		}
+	}
+
+	req := &initBatchFundingMsg{
+		channels:           channels,
+		fundingFeePerVSize: fundingFeePerVSize,
+		chanPoints:         chanPointsChan,
+		err:                errChan,
+	}
+
+	go s.fundingMgr.initBatchFundingWorkflow(req)
+
+	return chanPointsChan, errChan
+}
+
 // Peers returns a slice of all active peers.
 //
 // NOTE: This function is safe for concurrent access.
@@ -0,0 +1,210 @@
+package sweep
+
+import (
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TxInfo describes the properties of an unconfirmed parent tx that are
+// relevant when bumping its fee through CPFP.
+type TxInfo struct {
+	// Fee is the fee paid by the tx.
+	Fee btcutil.Amount
+
+	// Weight is the weight of the tx.
+	Weight int64
+}
+
+// Input contains all data needed to construct a sweep tx input.
+type Input interface {
+	// OutPoint returns the reference to the output being spent, used to
+	// construct the corresponding transaction input.
+	OutPoint() *wire.OutPoint
+
+	// WitnessType returns an enum specifying the type of witness that must
+	// be generated in order to spend this output.
+	WitnessType() lnwallet.WitnessType
+
+	// SignDesc returns a reference to a spendable output's sign
+	// descriptor, which is used during signing to compute a valid witness
+	// that spends this output.
+	SignDesc() *lnwallet.SignDescriptor
+
+	// BuildWitness returns a valid witness allowing this output to be
+	// spent, the witness should be attached to the transaction at the
+	// location determined by the given `txinIdx`.
+	BuildWitness(signer lnwallet.Signer, txn *wire.MsgTx,
+		hashCache *txscript.TxSigHashes,
+		txinIdx int) ([][]byte, error)
+
+	// BlocksToMaturity returns the relative timelock, as a number of
+	// blocks, that must be built on top of the confirmation height before
+	// the output can be spent. For non-CSV locked inputs this is always
+	// zero.
+	BlocksToMaturity() uint32
+
+	// HeightHint returns the minimum height at which a confirmed spending
+	// tx can occur.
+	HeightHint() uint32
+
+	// UnconfParent returns information about the unconfirmed parent tx of
+	// the input, whose fee should be bumped by the sweep tx through CPFP.
+	// Nil is returned for inputs that don't need to bump their parent.
+	UnconfParent() *TxInfo
+}
+
+// BaseInput contains all the information needed to sweep an output whose
+// witness can be generated using only its witness type and sign descriptor.
+type BaseInput struct {
+	outpoint         wire.OutPoint
+	witnessType      lnwallet.WitnessType
+	signDesc         lnwallet.SignDescriptor
+	heightHint       uint32
+	blocksToMaturity uint32
+	parent           *TxInfo
+}
+
+// NewBaseInput creates a new BaseInput spending the passed outpoint, which
+// doesn't carry a relative timelock.
+func NewBaseInput(outpoint *wire.OutPoint, witnessType lnwallet.WitnessType,
+	signDescriptor *lnwallet.SignDescriptor, heightHint uint32) *BaseInput {
+
+	return &BaseInput{
+		outpoint:    *outpoint,
+		witnessType: witnessType,
+		signDesc:    *signDescriptor,
+		heightHint:  heightHint,
+	}
+}
+
+// NewCsvInput creates a new BaseInput spending the passed outpoint, which
+// can only be spent once the given number of blocks have been built on top of
+// its confirmation.
+func NewCsvInput(outpoint *wire.OutPoint, witnessType lnwallet.WitnessType,
+	signDescriptor *lnwallet.SignDescriptor, heightHint uint32,
+	blocksToMaturity uint32) *BaseInput {
+
+	input := NewBaseInput(outpoint, witnessType, signDescriptor, heightHint)
+	input.blocksToMaturity = blocksToMaturity
+
+	return input
+}
+
+// NewCpfpInput creates a new BaseInput spending the passed outpoint of an
+// unconfirmed parent tx. The sweep tx spending the input will pay for the
+// parent, such that both confirm at the fee rate of the sweep.
+func NewCpfpInput(outpoint *wire.OutPoint, witnessType lnwallet.WitnessType,
+	signDescriptor *lnwallet.SignDescriptor, heightHint uint32,
+	parent *TxInfo) *BaseInput {
+
+	input := NewBaseInput(outpoint, witnessType, signDescriptor, heightHint)
+	input.parent = parent
+
+	return input
+}
+
+// OutPoint returns the breached output's identifier that is to be included as
+// a transaction input.
+//
+// NOTE: Part of the Input interface.
+func (bi *BaseInput) OutPoint() *wire.OutPoint {
+	return &bi.outpoint
+}
+
+// WitnessType returns the type of witness that must be generated to spend the
+// breached output.
+//
+// NOTE: Part of the Input interface.
+func (bi *BaseInput) WitnessType() lnwallet.WitnessType {
+	return bi.witnessType
+}
+
+// SignDesc returns the breached output's SignDescriptor, which is used during
+// signing to compute the witness.
+//
+// NOTE: Part of the Input interface.
+func (bi *BaseInput) SignDesc() *lnwallet.SignDescriptor {
+	return &bi.signDesc
+}
+
+// BuildWitness computes a valid witness that allows us to spend from the
+// output, using the witness generation function of its witness type.
+//
+// NOTE: Part of the Input interface.
+func (bi *BaseInput) BuildWitness(signer lnwallet.Signer, txn *wire.MsgTx,
+	hashCache *txscript.TxSigHashes, txinIdx int) ([][]byte, error) {
+
+	witnessFunc := bi.witnessType.GenWitnessFunc(signer, bi.SignDesc())
+
+	return witnessFunc(txn, hashCache, txinIdx)
+}
+
+// BlocksToMaturity returns the relative timelock, as a number of blocks, that
+// must be built on top of the confirmation height before the output can be
+// spent.
+//
+// NOTE: Part of the Input interface.
+func (bi *BaseInput) BlocksToMaturity() uint32 {
+	return bi.blocksToMaturity
+}
+
+// HeightHint returns the minimum height at which a confirmed spending tx can
+// occur.
+//
+// NOTE: Part of the Input interface.
+func (bi *BaseInput) HeightHint() uint32 {
+	return bi.heightHint
+}
+
+// UnconfParent returns information about the unconfirmed parent tx of the
+// input, if its fee should be bumped through CPFP.
+//
+// NOTE: Part of the Input interface.
+func (bi *BaseInput) UnconfParent() *TxInfo {
+	return bi.parent
+}
+
+// HtlcSucceedInput is an input that spends an HTLC offered to us by the remote
+// party on their commitment transaction, using the payment preimage.
+type HtlcSucceedInput struct {
+	BaseInput
+
+	preimage []byte
+}
+
+// NewHtlcSucceedInput creates a new HtlcSucceedInput, sweeping the HTLC output
+// with the passed outpoint using the given preimage.
+func NewHtlcSucceedInput(outpoint *wire.OutPoint,
+	signDescriptor *lnwallet.SignDescriptor, preimage []byte,
+	heightHint uint32) *HtlcSucceedInput {
+
+	return &HtlcSucceedInput{
+		BaseInput: *NewBaseInput(
+			outpoint, lnwallet.HtlcAcceptedRemoteSuccess,
+			signDescriptor, heightHint,
+		),
+		preimage: preimage,
+	}
+}
+
+// BuildWitness computes a valid witness that allows us to spend from the HTLC
+// output, revealing the payment preimage.
+//
+// NOTE: Part of the Input interface.
+func (h *HtlcSucceedInput) BuildWitness(signer lnwallet.Signer,
+	txn *wire.MsgTx, hashCache *txscript.TxSigHashes,
+	txinIdx int) ([][]byte, error) {
+
+	desc := h.signDesc
+	desc.SigHashes = hashCache
+	desc.InputIndex = txinIdx
+
+	return lnwallet.SenderHtlcSpendRedeem(signer, &desc, txn, h.preimage)
+}
+
+// Compile-time constraints to ensure each input struct implements the Input
+// interface.
+var _ Input = (*BaseInput)(nil)
+var _ Input = (*HtlcSucceedInput)(nil)
@@ -0,0 +1,93 @@
+package sweep
+
+import (
+	"errors"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// txHashesBucketKey is the key that points to a bucket containing the
+	// hashes of all sweep txes that were published successfully.
+	//
+	// maps: txHash -> empty slice
+	txHashesBucketKey = []byte("sweeper-tx-hashes")
+
+	// errNoTxHashesBucket is returned when the bucket holding the hashes
+	// of published sweep txes can't be found.
+	errNoTxHashesBucket = errors.New("tx hashes bucket does not exist")
+)
+
+// SweeperStore stores published txes.
+type SweeperStore interface {
+	// IsOurTx determines whether a tx is published by us, based on its
+	// hash.
+	IsOurTx(hash chainhash.Hash) (bool, error)
+
+	// NotifyPublishTx signals that we are about to publish a tx.
+	NotifyPublishTx(*wire.MsgTx) error
+}
+
+// sweeperStore is a SweeperStore backed by the channel database.
+type sweeperStore struct {
+	db *channeldb.DB
+}
+
+// NewSweeperStore returns a new store instance, creating its bucket within
+// the passed database if it doesn't exist yet.
+func NewSweeperStore(db *channeldb.DB) (SweeperStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(txHashesBucketKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sweeperStore{
+		db: db,
+	}, nil
+}
+
+// NotifyPublishTx signals that we are about to publish a tx. The hash of the
+// tx is stored, such that spends of our inputs by this tx can later be
+// recognized as our own.
+func (s *sweeperStore) NotifyPublishTx(sweepTx *wire.MsgTx) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		txHashesBucket := tx.Bucket(txHashesBucketKey)
+		if txHashesBucket == nil {
+			return errNoTxHashesBucket
+		}
+
+		hash := sweepTx.TxHash()
+
+		return txHashesBucket.Put(hash[:], []byte{})
+	})
+}
+
+// IsOurTx determines whether a tx is published by us, based on its hash.
+func (s *sweeperStore) IsOurTx(hash chainhash.Hash) (bool, error) {
+	var ours bool
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		txHashesBucket := tx.Bucket(txHashesBucketKey)
+		if txHashesBucket == nil {
+			return nil
+		}
+
+		ours = txHashesBucket.Get(hash[:]) != nil
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return ours, nil
+}
+
+// Compile-time constraint to ensure sweeperStore implements SweeperStore.
+var _ SweeperStore = (*sweeperStore)(nil)
@@ -0,0 +1,894 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// DefaultConfTarget is the confirmation target used to estimate the
+	// fee rate of sweep txes for inputs that were offered without a
+	// deadline.
+	DefaultConfTarget = 6
+
+	// minFeeRateIncrease is the minimal absolute increase of the fee rate
+	// of inputs that are swept again, after an earlier sweep tx didn't
+	// confirm.
+	minFeeRateIncrease = lnwallet.SatPerVByte(1)
+)
+
+var (
+	// ErrRemoteSpend is returned in case an output that we try to sweep is
+	// confirmed in a tx of the remote party.
+	ErrRemoteSpend = errors.New("remote party swept utxo")
+
+	// ErrSweeperShuttingDown is returned to callers of the sweeper if the
+	// sweeper is shut down before the offered input was resolved.
+	ErrSweeperShuttingDown = errors.New("utxo sweeper shutting down")
+
+	// ErrParentConfirmed is returned in case the unconfirmed parent of an
+	// input whose fee we're bumping through CPFP confirms before the input
+	// is swept.
+	ErrParentConfirmed = errors.New("parent tx of input confirmed")
+)
+
+// Wallet contains the wallet functionality required by the sweeper, in order
+// to add wallet inputs to sweep txes that bump the fee of their parent.
+type Wallet interface {
+	// ListUnspentWitness returns all unspent outputs which are version 0
+	// witness programs, and have at least the passed number of
+	// confirmations.
+	ListUnspentWitness(confirms int32) ([]*lnwallet.Utxo, error)
+}
+
+// UtxoSweeperConfig contains dependencies of UtxoSweeper.
+type UtxoSweeperConfig struct {
+	// GenSweepScript generates a P2WKH script belonging to the wallet where
+	// funds can be swept.
+	GenSweepScript func() ([]byte, error)
+
+	// FeeEstimator is used when crafting sweep transactions to estimate
+	// the necessary fee relative to the expected size of the sweep
+	// transaction.
+	FeeEstimator lnwallet.FeeEstimator
+
+	// PublishTransaction facilitates the process of broadcasting a signed
+	// transaction to the appropriate network.
+	PublishTransaction func(*wire.MsgTx) error
+
+	// NewBatchTimer creates a channel that will be sent on when a certain
+	// time window has passed. During this time window, new inputs can
+	// still be added to the sweep tx that is about to be generated.
+	NewBatchTimer func() <-chan time.Time
+
+	// Notifier is an instance of a chain notifier we'll use to watch for
+	// new blocks and spends of the inputs we're sweeping.
+	Notifier chainntnfs.ChainNotifier
+
+	// ChainIO is used to determine the current block height when the
+	// sweeper is started.
+	ChainIO lnwallet.BlockChainIO
+
+	// Store stores the hashes of the sweep txes we publish, such that
+	// spends of our inputs by those txes can be recognized.
+	Store SweeperStore
+
+	// Signer is used by the sweeper to generate valid witnesses at the
+	// time the incubated outputs need to be spent.
+	Signer lnwallet.Signer
+
+	// MaxInputsPerTx specifies the default maximum number of inputs
+	// allowed in a single sweep tx. If more need to be swept, multiple
+	// txes are created and published.
+	MaxInputsPerTx int
+
+	// MaxFeeRate is the maximum fee rate the sweeper is willing to pay
+	// when bumping the fee of inputs that didn't confirm in time.
+	MaxFeeRate lnwallet.SatPerVByte
+
+	// Wallet is used to source additional inputs for sweep txes that bump
+	// the fee of their parent through CPFP, as the inputs offered for
+	// this purpose usually don't carry enough value to pay for the fee.
+	Wallet Wallet
+}
+
+// Result is the struct that is pushed through the result channel. Callers
+// can use this to be informed of the final sweep result. In case of a remote
+// spend, Err will be ErrRemoteSpend.
+type Result struct {
+	// Err is the final result of the sweep. It is nil when the input is
+	// swept successfully by us. ErrRemoteSpend is returned when another
+	// tx swept the input. ErrParentConfirmed is returned when the parent
+	// of an input offered to bump its fee confirmed before the input was
+	// swept, in which case Tx is nil.
+	Err error
+
+	// Tx is the transaction that spent the input.
+	Tx *wire.MsgTx
+}
+
+// pendingInput is created when an input is offered to the sweeper. It tracks
+// all parties interested in the outcome of the sweep, along with the state
+// needed to bump the fee of the input if it doesn't confirm in time.
+type pendingInput struct {
+	// listeners is a list of channels over which the final outcome of the
+	// sweep needs to be broadcasted.
+	listeners []chan Result
+
+	// input is the original struct that contains the input and sign
+	// descriptor.
+	input Input
+
+	// deadline is the height by which the input should be swept. A zero
+	// value means that the default confirmation target applies.
+	deadline uint32
+
+	// ntfnRegCancel is populated with a function that cancels the chain
+	// notifier spend registration.
+	ntfnRegCancel func()
+
+	// parentConfCancel is populated with a function that stops waiting
+	// for the confirmation of the unconfirmed parent of the input, if any.
+	parentConfCancel func()
+
+	// walletInputs are the wallet inputs added to the last sweep tx that
+	// bumped the fee of the parent of this input. They're reused by the
+	// next sweep tx, as the wallet no longer reports them as unspent.
+	walletInputs []Input
+
+	// lastFeeRate is the fee rate of the last sweep tx that included this
+	// input. It is zero if the input hasn't been published yet.
+	lastFeeRate lnwallet.SatPerVByte
+}
+
+// sweepInputMessage structs are used in the internal channel between the
+// SweepInput call and the sweeper main loop.
+type sweepInputMessage struct {
+	input      Input
+	deadline   uint32
+	resultChan chan Result
+}
+
+// UtxoSweeper is responsible for sweeping outputs back into the wallet. It
+// accepts inputs from all subsystems that need to claim on-chain funds, and
+// batches them into as few transactions as possible. Inputs that aren't
+// confirmed by the next block are swept again at an increased fee rate, until
+// they are either confirmed or spent by another party.
+type UtxoSweeper struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	cfg *UtxoSweeperConfig
+
+	newInputs chan *sweepInputMessage
+	spendChan chan *chainntnfs.SpendDetail
+
+	// parentConfChan receives the outpoints of inputs whose unconfirmed
+	// parent has confirmed.
+	parentConfChan chan wire.OutPoint
+
+	// pendingInputs is the total list of inputs to be swept by the
+	// sweeper.
+	pendingInputs map[wire.OutPoint]*pendingInput
+
+	// timer is the channel that signals expiry of the sweep batch timer.
+	timer <-chan time.Time
+
+	currentHeight int32
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New returns a new Sweeper instance.
+func New(cfg *UtxoSweeperConfig) *UtxoSweeper {
+	return &UtxoSweeper{
+		cfg:            cfg,
+		newInputs:      make(chan *sweepInputMessage),
+		spendChan:      make(chan *chainntnfs.SpendDetail),
+		parentConfChan: make(chan wire.OutPoint),
+		quit:           make(chan struct{}),
+		pendingInputs:  make(map[wire.OutPoint]*pendingInput),
+	}
+}
+
+// Start starts the process of constructing and publishing sweep txes.
+func (s *UtxoSweeper) Start() error {
+	if !atomic.CompareAndSwapUint32(&s.started, 0, 1) {
+		return nil
+	}
+
+	log.Tracef("Sweeper starting")
+
+	// Register for block epochs to retry sweeping every block, and
+	// retrieve the current height to use as the lock time of our sweep
+	// txes.
+	blockEpochs, err := s.cfg.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return fmt.Errorf("register block epoch ntfn: %v", err)
+	}
+
+	_, bestHeight, err := s.cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		blockEpochs.Cancel()
+		return fmt.Errorf("get best block: %v", err)
+	}
+	s.currentHeight = bestHeight
+
+	// Start sweeper main loop.
+	s.wg.Add(1)
+	go func() {
+		defer blockEpochs.Cancel()
+		defer s.wg.Done()
+
+		s.collector(blockEpochs.Epochs)
+	}()
+
+	return nil
+}
+
+// Stop stops sweeper from listening to block epochs and constructing sweep
+// txes.
+func (s *UtxoSweeper) Stop() error {
+	if !atomic.CompareAndSwapUint32(&s.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Debugf("Sweeper shutting down")
+
+	close(s.quit)
+	s.wg.Wait()
+
+	log.Debugf("Sweeper shut down")
+
+	return nil
+}
+
+// SweepInput sweeps inputs back into the wallet. The inputs will be batched
+// and swept after the batch time window ends. The deadline is the block
+// height by which the input should be swept, and is used to pick the
+// confirmation target of the sweep tx. A zero deadline means that the default
+// confirmation target is used.
+//
+// The return value is a channel that will be sent on once the input has been
+// spent, either by a sweep tx of ours or by another party. Inputs that aren't
+// confirmed in time are swept again at an increased fee rate.
+//
+// NOTE: Extreme care needs to be taken that input isn't changed externally.
+// Because it is an interface and we don't know what is exactly behind it, we
+// cannot make a local copy in sweeper.
+func (s *UtxoSweeper) SweepInput(input Input,
+	deadline uint32) (chan Result, error) {
+
+	if input == nil || input.OutPoint() == nil || input.SignDesc() == nil {
+		return nil, errors.New("nil input received")
+	}
+
+	// Make sure we are able to estimate the size of the input's witness
+	// before accepting it.
+	if _, err := getInputWitnessSizeUpperBound(input); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Sweep request received: out_point=%v, witness_type=%v, "+
+		"time_lock=%v, deadline=%v, amount=%v", input.OutPoint(),
+		input.WitnessType(), input.BlocksToMaturity(), deadline,
+		input.SignDesc().Output.Value)
+
+	sweeperInput := &sweepInputMessage{
+		input:      input,
+		deadline:   deadline,
+		resultChan: make(chan Result, 1),
+	}
+
+	// Deliver input to main event loop.
+	select {
+	case s.newInputs <- sweeperInput:
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+
+	return sweeperInput.resultChan, nil
+}
+
+// collector is the sweeper main loop. It processes new inputs, spend
+// notifications and counts down to publication of the sweep tx.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
+	for {
+		select {
+
+		// A new inputs is offered to the sweeper. We check to see if
+		// we are already trying to sweep this input and if not, set up
+		// a listener for spend and schedule a sweep.
+		case input := <-s.newInputs:
+			outpoint := *input.input.OutPoint()
+			pendInput, pending := s.pendingInputs[outpoint]
+			if pending {
+				log.Debugf("Already pending input %v received",
+					outpoint)
+
+				// Add additional result channel to signal
+				// spend of this input.
+				pendInput.listeners = append(
+					pendInput.listeners, input.resultChan,
+				)
+
+				// Adopt the earliest deadline of all callers.
+				if input.deadline != 0 && (pendInput.deadline == 0 ||
+					input.deadline < pendInput.deadline) {
+
+					pendInput.deadline = input.deadline
+				}
+				continue
+			}
+
+			// Start watching for spend of this input, either by us
+			// or the remote party.
+			cancel, err := s.waitForSpend(
+				outpoint, input.input.HeightHint(),
+			)
+			if err != nil {
+				err := fmt.Errorf("wait for spend: %v", err)
+				input.resultChan <- Result{Err: err}
+				continue
+			}
+
+			pendInput = &pendingInput{
+				listeners:     []chan Result{input.resultChan},
+				input:         input.input,
+				deadline:      input.deadline,
+				ntfnRegCancel: cancel,
+			}
+
+			// If the input bumps the fee of its parent, we'll also
+			// watch for the parent to confirm, as there's no need
+			// to sweep the input anymore once it has.
+			if input.input.UnconfParent() != nil {
+				pendInput.parentConfCancel, err =
+					s.waitForParentConf(
+						outpoint,
+						input.input.HeightHint(),
+					)
+				if err != nil {
+					cancel()
+					err := fmt.Errorf("wait for parent "+
+						"conf: %v", err)
+					input.resultChan <- Result{Err: err}
+					continue
+				}
+			}
+
+			s.pendingInputs[outpoint] = pendInput
+
+			// Start sweep timer to create opportunity for more
+			// inputs to be added.
+			s.startTimer()
+
+		// A spend of one of our inputs is detected. Signal sweep
+		// results to the caller(s).
+		case spend := <-s.spendChan:
+			// For testing purposes.
+			if spend.SpendingTx == nil {
+				continue
+			}
+
+			// Query store to find out if we ever published this
+			// tx.
+			spendHash := *spend.SpenderTxHash
+			isOurTx, err := s.cfg.Store.IsOurTx(spendHash)
+			if err != nil {
+				log.Errorf("cannot determine if tx %v "+
+					"is ours: %v", spendHash, err)
+				continue
+			}
+
+			log.Debugf("Detected spend related to in flight inputs "+
+				"(is_ours=%v): %v", isOurTx,
+				newLogClosure(func() string {
+					return spew.Sdump(spend.SpendingTx)
+				}),
+			)
+
+			// Signal sweep results for inputs in this confirmed
+			// tx.
+			for _, txIn := range spend.SpendingTx.TxIn {
+				outpoint := txIn.PreviousOutPoint
+
+				// Check if this input is known to us. It could
+				// probably be unknown if we canceled the
+				// registration, deleted from pendingInputs but
+				// the ntfn was in-flight already. Or this
+				// could be not one of our inputs.
+				if _, ok := s.pendingInputs[outpoint]; !ok {
+					continue
+				}
+
+				// Return either a nil or a remote spend result.
+				var err error
+				if !isOurTx {
+					err = ErrRemoteSpend
+				}
+
+				// Signal result channels.
+				s.signalAndRemove(&outpoint, Result{
+					Tx:  spend.SpendingTx,
+					Err: err,
+				})
+			}
+
+		// The parent of an input whose fee we're bumping has
+		// confirmed, so there's no need to sweep the input anymore.
+		case outpoint := <-s.parentConfChan:
+			s.signalAndRemove(&outpoint, Result{
+				Err: ErrParentConfirmed,
+			})
+
+		// The timer expires and we are going to (re)sweep.
+		case <-s.timer:
+			log.Debugf("Sweep timer expired")
+
+			// Set timer to nil so we know that a new timer needs
+			// to be started when new inputs arrive.
+			s.timer = nil
+
+			s.sweepPendingInputs()
+
+		// A new block comes in. Things may have changed, so we retry
+		// sweeping the inputs that haven't confirmed yet, bumping
+		// their fee rate.
+		case epoch, ok := <-blockEpochs:
+			if !ok {
+				return
+			}
+
+			s.currentHeight = epoch.Height
+
+			log.Debugf("New blocks: height=%v, sha=%v",
+				epoch.Height, epoch.Hash)
+
+			if len(s.pendingInputs) > 0 {
+				s.startTimer()
+			}
+
+		case <-s.quit:
+			// Let all callers that are still waiting for a result
+			// know that their input won't be swept.
+			for outpoint := range s.pendingInputs {
+				outpoint := outpoint
+				s.signalAndRemove(&outpoint, Result{
+					Err: ErrSweeperShuttingDown,
+				})
+			}
+
+			return
+		}
+	}
+}
+
+// startTimer starts a new batch timer, unless one is already running.
+func (s *UtxoSweeper) startTimer() {
+	if s.timer != nil {
+		return
+	}
+
+	log.Debugf("Sweep timer started")
+	s.timer = s.cfg.NewBatchTimer()
+}
+
+// signalAndRemove notifies the listeners of the final result of the input
+// sweep. It cancels any pending spend notification and removes the input from
+// the list of pending inputs. When this function returns, the sweeper has
+// completely forgotten about the input.
+func (s *UtxoSweeper) signalAndRemove(outpoint *wire.OutPoint, result Result) {
+	pendInput := s.pendingInputs[*outpoint]
+	if pendInput == nil {
+		return
+	}
+
+	listeners := pendInput.listeners
+
+	if result.Err == nil {
+		log.Debugf("Dispatching sweep success for %v to %v listeners",
+			outpoint, len(listeners),
+		)
+	} else {
+		log.Debugf("Dispatching sweep error for %v to %v listeners: %v",
+			outpoint, len(listeners), result.Err,
+		)
+	}
+
+	// Signal all listeners. Channel is buffered. Because we only send once
+	// on every channel, it should never block.
+	for _, resultChan := range listeners {
+		resultChan <- result
+	}
+
+	// Cancel spend notification with chain notifier. This is not necessary
+	// in case of a success, except for that a reorg could happen.
+	if pendInput.ntfnRegCancel != nil {
+		log.Debugf("Canceling spend ntfn for %v", outpoint)
+
+		pendInput.ntfnRegCancel()
+	}
+	if pendInput.parentConfCancel != nil {
+		pendInput.parentConfCancel()
+	}
+
+	// Inputs are no longer pending after result has been sent.
+	delete(s.pendingInputs, *outpoint)
+}
+
+// confTarget returns the confirmation target to use for the passed pending
+// input, based on its deadline and the current height.
+func (s *UtxoSweeper) confTarget(input *pendingInput) uint32 {
+	if input.deadline == 0 {
+		return DefaultConfTarget
+	}
+
+	if input.deadline <= uint32(s.currentHeight)+1 {
+		return 1
+	}
+
+	return input.deadline - uint32(s.currentHeight)
+}
+
+// feeRate determines the fee rate at which the passed inputs are swept. The
+// fee rate is estimated using the most urgent confirmation target of the
+// inputs. Inputs that have been published before, but are still unconfirmed,
+// are swept at a fee rate that is higher than the one they were last
+// published at, such that the new sweep tx is able to replace the old one.
+func (s *UtxoSweeper) feeRate(inputs []*pendingInput) (lnwallet.SatPerVByte,
+	error) {
+
+	confTarget := uint32(DefaultConfTarget)
+	var lastFeeRate lnwallet.SatPerVByte
+	for _, input := range inputs {
+		target := s.confTarget(input)
+		if target < confTarget {
+			confTarget = target
+		}
+
+		if input.lastFeeRate > lastFeeRate {
+			lastFeeRate = input.lastFeeRate
+		}
+	}
+
+	feeRate, err := s.cfg.FeeEstimator.EstimateFeePerVSize(confTarget)
+	if err != nil {
+		return 0, err
+	}
+
+	// If the inputs were published before, bump the fee rate by at least
+	// 10%, such that the new tx is accepted as a replacement.
+	if lastFeeRate != 0 {
+		increase := lastFeeRate / 10
+		if increase < minFeeRateIncrease {
+			increase = minFeeRateIncrease
+		}
+
+		if feeRate < lastFeeRate+increase {
+			feeRate = lastFeeRate + increase
+		}
+	}
+
+	if s.cfg.MaxFeeRate != 0 && feeRate > s.cfg.MaxFeeRate {
+		log.Warnf("Fee rate %v sat/vbyte for sweep exceeds maximum, "+
+			"capping at %v sat/vbyte", int64(feeRate),
+			int64(s.cfg.MaxFeeRate))
+
+		feeRate = s.cfg.MaxFeeRate
+	}
+
+	return feeRate, nil
+}
+
+// sweepPendingInputs creates and publishes sweep txes for all inputs that are
+// still pending, splitting them up into chunks of at most MaxInputsPerTx
+// inputs each.
+func (s *UtxoSweeper) sweepPendingInputs() {
+	if len(s.pendingInputs) == 0 {
+		return
+	}
+
+	// Create a list of all pending inputs, sorted by confirmation target
+	// such that the most urgent inputs are grouped together. Inputs that
+	// bump the fee of their parent are each swept in a tx of their own,
+	// such that the fee rate of every parent is bumped independently.
+	inputs := make([]*pendingInput, 0, len(s.pendingInputs))
+	for _, input := range s.pendingInputs {
+		if input.input.UnconfParent() == nil {
+			inputs = append(inputs, input)
+			continue
+		}
+
+		if err := s.sweepCpfpInput(input); err != nil {
+			log.Errorf("Unable to bump fee of parent of %v: %v",
+				input.input.OutPoint(), err)
+		}
+	}
+	sort.Slice(inputs, func(i, j int) bool {
+		return s.confTarget(inputs[i]) < s.confTarget(inputs[j])
+	})
+
+	maxInputs := s.cfg.MaxInputsPerTx
+	if maxInputs <= 0 {
+		maxInputs = len(inputs)
+	}
+
+	for len(inputs) > 0 {
+		n := maxInputs
+		if n > len(inputs) {
+			n = len(inputs)
+		}
+		chunk := inputs[:n]
+		inputs = inputs[n:]
+
+		if err := s.sweep(chunk); err != nil {
+			log.Errorf("Unable to sweep %v inputs: %v", len(chunk),
+				err)
+		}
+	}
+}
+
+// sweepCpfpInput sweeps an input that bumps the fee of its unconfirmed parent
+// through CPFP, unless the parent pays a sufficient fee rate on its own.
+func (s *UtxoSweeper) sweepCpfpInput(input *pendingInput) error {
+	// If we haven't bumped the fee of the parent before, we'll first check
+	// whether it's needed at all. As the sweeper retries on every block,
+	// this is checked again once fee estimates change.
+	if input.lastFeeRate == 0 {
+		feeRate, err := s.cfg.FeeEstimator.EstimateFeePerVSize(
+			s.confTarget(input),
+		)
+		if err != nil {
+			return fmt.Errorf("estimate fee rate: %v", err)
+		}
+
+		parent := input.input.UnconfParent()
+		if parent.Fee >= feeRate.FeeForVSize(txVSize(parent.Weight)) {
+			log.Debugf("Parent of %v pays fee rate of at least %v "+
+				"sat/vbyte, not bumping", input.input.OutPoint(),
+				int64(feeRate))
+
+			return nil
+		}
+	}
+
+	return s.sweep([]*pendingInput{input})
+}
+
+// selectWalletInputs returns the wallet inputs that need to be added to the
+// passed inputs, in order for the sweep tx to pay its fee at the given fee
+// rate. The wallet inputs of an earlier sweep tx are selected first, followed
+// by the confirmed p2wkh outputs of the wallet, largest first.
+func (s *UtxoSweeper) selectWalletInputs(inputs, prevWalletInputs []Input,
+	feeRate lnwallet.SatPerVByte) ([]Input, error) {
+
+	if s.cfg.Wallet == nil {
+		return nil, errors.New("no wallet to select inputs from")
+	}
+
+	utxos, err := s.cfg.Wallet.ListUnspentWitness(1)
+	if err != nil {
+		return nil, fmt.Errorf("list unspent: %v", err)
+	}
+	sort.Slice(utxos, func(i, j int) bool {
+		return utxos[i].Value > utxos[j].Value
+	})
+
+	candidates := make([]Input, 0, len(prevWalletInputs)+len(utxos))
+	candidates = append(candidates, prevWalletInputs...)
+	for _, utxo := range utxos {
+		if utxo.AddressType != lnwallet.WitnessPubKey {
+			continue
+		}
+
+		candidates = append(candidates, NewBaseInput(
+			&utxo.OutPoint, lnwallet.WitnessKeyHash,
+			&lnwallet.SignDescriptor{
+				Output: &wire.TxOut{
+					PkScript: utxo.PkScript,
+					Value:    int64(utxo.Value),
+				},
+				HashType: txscript.SigHashAll,
+			}, 0,
+		))
+	}
+
+	selected := make([]Input, len(inputs), len(inputs)+len(candidates))
+	copy(selected, inputs)
+	seen := make(map[wire.OutPoint]struct{})
+	for _, candidate := range candidates {
+		if _, ok := seen[*candidate.OutPoint()]; ok {
+			continue
+		}
+		seen[*candidate.OutPoint()] = struct{}{}
+
+		selected = append(selected, candidate)
+		totalSum, fee, err := sweepTxFee(selected, feeRate)
+		if err != nil {
+			return nil, err
+		}
+		if totalSum-fee >= lnwallet.DefaultDustLimit() {
+			return selected[len(inputs):], nil
+		}
+	}
+
+	return nil, errors.New("insufficient wallet funds to pay for fee")
+}
+
+// sweep takes a set of preselected inputs, creates a sweep tx and publishes
+// the tx.
+func (s *UtxoSweeper) sweep(inputs []*pendingInput) error {
+	feeRate, err := s.feeRate(inputs)
+	if err != nil {
+		return fmt.Errorf("estimate fee rate: %v", err)
+	}
+
+	// Generate the receiving script to which the funds will be swept.
+	pkScript, err := s.cfg.GenSweepScript()
+	if err != nil {
+		return fmt.Errorf("gen sweep script: %v", err)
+	}
+
+	sweepInputs := make([]Input, len(inputs))
+	for i, input := range inputs {
+		sweepInputs[i] = input.input
+	}
+
+	// An input bumping the fee of its parent doesn't carry enough value
+	// to pay for the fee itself, so we'll add wallet inputs to cover it.
+	var walletInputs []Input
+	if len(inputs) == 1 && inputs[0].input.UnconfParent() != nil {
+		walletInputs, err = s.selectWalletInputs(
+			sweepInputs, inputs[0].walletInputs, feeRate,
+		)
+		if err != nil {
+			return fmt.Errorf("select wallet inputs: %v", err)
+		}
+		sweepInputs = append(sweepInputs, walletInputs...)
+	}
+
+	// Create sweep tx.
+	tx, err := createSweepTx(
+		sweepInputs, pkScript, uint32(s.currentHeight), feeRate,
+		s.cfg.Signer,
+	)
+	if err != nil {
+		return fmt.Errorf("create sweep tx: %v", err)
+	}
+
+	// Add tx before publication, so that we will always know that a spend
+	// by this tx is ours. Otherwise if the publish doesn't return, but did
+	// publish, we loose track of this tx. Even republication on startup
+	// doesn't prevent this, because that call returns a double spend error
+	// then and would also not add the hash to the store.
+	err = s.cfg.Store.NotifyPublishTx(tx)
+	if err != nil {
+		return fmt.Errorf("notify publish tx: %v", err)
+	}
+
+	// Publish sweep tx.
+	log.Debugf("Publishing sweep tx %v at fee rate %v sat/vbyte, "+
+		"tx_size=%v", tx.TxHash(), int64(feeRate),
+		tx.SerializeSize())
+
+	log.Tracef("Sweep tx at height=%v: %v", s.currentHeight,
+		newLogClosure(func() string {
+			return spew.Sdump(tx)
+		}),
+	)
+
+	err = s.cfg.PublishTransaction(tx)
+
+	// In case of an unexpected error, don't try to recover.
+	if err != nil && err != lnwallet.ErrDoubleSpend {
+		return fmt.Errorf("publish tx: %v", err)
+	}
+
+	// Remember the fee rate the inputs were published at, such that the
+	// next attempt to sweep them pays a higher fee, along with any wallet
+	// inputs the next attempt needs to spend as well.
+	if err == nil {
+		for _, input := range inputs {
+			input.lastFeeRate = feeRate
+			input.walletInputs = walletInputs
+		}
+	}
+
+	return nil
+}
+
+// waitForSpend registers a spend notification with the chain notifier. It
+// returns a cancel function that can be used to cancel the registration.
+func (s *UtxoSweeper) waitForSpend(outpoint wire.OutPoint,
+	heightHint uint32) (func(), error) {
+
+	log.Debugf("Wait for spend of %v", outpoint)
+
+	spendEvent, err := s.cfg.Notifier.RegisterSpendNtfn(
+		&outpoint, heightHint,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("register spend ntfn: %v", err)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		select {
+		case spend, ok := <-spendEvent.Spend:
+			if !ok {
+				log.Debugf("Spend ntfn for %v canceled",
+					outpoint)
+				return
+			}
+
+			log.Debugf("Delivering spend ntfn for %v",
+				outpoint)
+			select {
+			case s.spendChan <- spend:
+				log.Debugf("Delivered spend ntfn for %v",
+					outpoint)
+
+			case <-s.quit:
+			}
+		case <-s.quit:
+		}
+	}()
+
+	return spendEvent.Cancel, nil
+}
+
+// waitForParentConf registers a confirmation notification for the parent tx
+// of the passed outpoint with the chain notifier. It returns a function that
+// can be used to stop waiting for the confirmation.
+func (s *UtxoSweeper) waitForParentConf(outpoint wire.OutPoint,
+	heightHint uint32) (func(), error) {
+
+	log.Debugf("Wait for confirmation of parent of %v", outpoint)
+
+	confEvent, err := s.cfg.Notifier.RegisterConfirmationsNtfn(
+		&outpoint.Hash, 1, heightHint,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("register conf ntfn: %v", err)
+	}
+
+	cancel := make(chan struct{})
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		select {
+		case _, ok := <-confEvent.Confirmed:
+			if !ok {
+				return
+			}
+
+			log.Debugf("Parent of %v confirmed", outpoint)
+			select {
+			case s.parentConfChan <- outpoint:
+			case <-cancel:
+			case <-s.quit:
+			}
+
+		case <-cancel:
+		case <-s.quit:
+		}
+	}()
+
+	return func() { close(cancel) }, nil
+}
@@ -0,0 +1,730 @@
+package sweep
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	testPkScript = []byte{
+		0x00, 0x14, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12,
+		0x13, 0x14,
+	}
+
+	defaultTestTimeout = 5 * time.Second
+)
+
+// mockInput is a sweep input that produces a dummy witness, such that no
+// signer is required to sweep it.
+type mockInput struct {
+	BaseInput
+}
+
+// newMockInput creates a new mock input spending an output of the given
+// value.
+func newMockInput(index uint32, value int64) *mockInput {
+	return &mockInput{
+		BaseInput: *NewBaseInput(
+			&wire.OutPoint{Hash: chainhash.Hash{1}, Index: index},
+			lnwallet.CommitmentNoDelay,
+			&lnwallet.SignDescriptor{
+				Output: &wire.TxOut{
+					Value:    value,
+					PkScript: testPkScript,
+				},
+			}, 0,
+		),
+	}
+}
+
+// BuildWitness returns a dummy witness for the input.
+func (m *mockInput) BuildWitness(signer lnwallet.Signer, txn *wire.MsgTx,
+	hashCache *txscript.TxSigHashes, txinIdx int) ([][]byte, error) {
+
+	return [][]byte{{0x01}}, nil
+}
+
+// newMockCpfpInput creates a new mock input spending an anchor output of the
+// given unconfirmed parent tx.
+func newMockCpfpInput(index uint32, parent *TxInfo) *mockInput {
+	return &mockInput{
+		BaseInput: *NewCpfpInput(
+			&wire.OutPoint{Hash: chainhash.Hash{2}, Index: index},
+			lnwallet.CommitmentAnchor,
+			&lnwallet.SignDescriptor{
+				Output: &wire.TxOut{
+					Value:    int64(lnwallet.AnchorSize),
+					PkScript: testPkScript,
+				},
+			}, 0, parent,
+		),
+	}
+}
+
+// mockSigner is a signer that produces dummy input scripts for wallet inputs.
+type mockSigner struct{}
+
+func (m *mockSigner) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	return []byte{0x01}, nil
+}
+
+func (m *mockSigner) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	return &lnwallet.InputScript{Witness: [][]byte{{0x01}}}, nil
+}
+
+// mockWallet is a wallet whose unspent outputs can be changed by the test.
+type mockWallet struct {
+	mu    sync.Mutex
+	utxos []*lnwallet.Utxo
+}
+
+func (w *mockWallet) setUtxos(utxos []*lnwallet.Utxo) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.utxos = utxos
+}
+
+func (w *mockWallet) ListUnspentWitness(
+	confirms int32) ([]*lnwallet.Utxo, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]*lnwallet.Utxo(nil), w.utxos...), nil
+}
+
+// mockNotifier is a chain notifier that allows the test to deliver spend and
+// confirmation notifications and blocks at will.
+type mockNotifier struct {
+	mu         sync.Mutex
+	spendChans map[wire.OutPoint][]chan *chainntnfs.SpendDetail
+	confChans  map[chainhash.Hash][]chan *chainntnfs.TxConfirmation
+	epochChan  chan *chainntnfs.BlockEpoch
+	bestHeight int32
+}
+
+func newMockNotifier(bestHeight int32) *mockNotifier {
+	return &mockNotifier{
+		spendChans: make(map[wire.OutPoint][]chan *chainntnfs.SpendDetail),
+		confChans: make(
+			map[chainhash.Hash][]chan *chainntnfs.TxConfirmation,
+		),
+		epochChan:  make(chan *chainntnfs.BlockEpoch),
+		bestHeight: bestHeight,
+	}
+}
+
+func (m *mockNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	numConfs, heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	confChan := make(chan *chainntnfs.TxConfirmation, 1)
+	m.confChans[*txid] = append(m.confChans[*txid], confChan)
+
+	return &chainntnfs.ConfirmationEvent{
+		Confirmed: confChan,
+	}, nil
+}
+
+func (m *mockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
+	heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	spendChan := make(chan *chainntnfs.SpendDetail, 1)
+	m.spendChans[*outpoint] = append(m.spendChans[*outpoint], spendChan)
+
+	return &chainntnfs.SpendEvent{
+		Spend:  spendChan,
+		Cancel: func() {},
+	}, nil
+}
+
+func (m *mockNotifier) RegisterBlockEpochNtfn() (*chainntnfs.BlockEpochEvent,
+	error) {
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: m.epochChan,
+		Cancel: func() {},
+	}, nil
+}
+
+func (m *mockNotifier) Start() error {
+	return nil
+}
+
+func (m *mockNotifier) Stop() error {
+	return nil
+}
+
+func (m *mockNotifier) GetBestBlock() (*chainhash.Hash, int32, error) {
+	return &chainhash.Hash{}, m.bestHeight, nil
+}
+
+func (m *mockNotifier) GetUtxo(op *wire.OutPoint,
+	heightHint uint32) (*wire.TxOut, error) {
+
+	return nil, nil
+}
+
+func (m *mockNotifier) GetBlockHash(blockHeight int64) (*chainhash.Hash,
+	error) {
+
+	return nil, nil
+}
+
+func (m *mockNotifier) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock,
+	error) {
+
+	return nil, nil
+}
+
+// spend delivers a spend notification for every input of the passed tx.
+func (m *mockNotifier) spend(tx *wire.MsgTx) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	txHash := tx.TxHash()
+	for i, txIn := range tx.TxIn {
+		outpoint := txIn.PreviousOutPoint
+		for _, spendChan := range m.spendChans[outpoint] {
+			spendChan <- &chainntnfs.SpendDetail{
+				SpentOutPoint:     &outpoint,
+				SpenderTxHash:     &txHash,
+				SpendingTx:        tx,
+				SpenderInputIndex: uint32(i),
+			}
+		}
+		delete(m.spendChans, outpoint)
+	}
+}
+
+// confirm delivers a confirmation notification for the passed txid.
+func (m *mockNotifier) confirm(txid chainhash.Hash) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, confChan := range m.confChans[txid] {
+		confChan <- &chainntnfs.TxConfirmation{}
+	}
+	delete(m.confChans, txid)
+}
+
+// mockSweeperStore is an in-memory SweeperStore.
+type mockSweeperStore struct {
+	mu      sync.Mutex
+	ourTxes map[chainhash.Hash]struct{}
+}
+
+func newMockSweeperStore() *mockSweeperStore {
+	return &mockSweeperStore{
+		ourTxes: make(map[chainhash.Hash]struct{}),
+	}
+}
+
+func (s *mockSweeperStore) IsOurTx(hash chainhash.Hash) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.ourTxes[hash]
+	return ok, nil
+}
+
+func (s *mockSweeperStore) NotifyPublishTx(tx *wire.MsgTx) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ourTxes[tx.TxHash()] = struct{}{}
+	return nil
+}
+
+// mockFeeEstimator is a fee estimator returning a fee rate that can be
+// changed by the test.
+type mockFeeEstimator struct {
+	mu      sync.Mutex
+	feeRate lnwallet.SatPerVByte
+}
+
+func (e *mockFeeEstimator) setFeeRate(feeRate lnwallet.SatPerVByte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.feeRate = feeRate
+}
+
+func (e *mockFeeEstimator) EstimateFeePerVSize(
+	numBlocks uint32) (lnwallet.SatPerVByte, error) {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.feeRate, nil
+}
+
+func (e *mockFeeEstimator) Start() error {
+	return nil
+}
+
+func (e *mockFeeEstimator) Stop() error {
+	return nil
+}
+
+type sweeperTestContext struct {
+	t *testing.T
+
+	sweeper   *UtxoSweeper
+	notifier  *mockNotifier
+	estimator *mockFeeEstimator
+	wallet    *mockWallet
+
+	timers      chan chan time.Time
+	publishChan chan *wire.MsgTx
+}
+
+func createSweeperTestContext(t *testing.T,
+	maxInputsPerTx int) *sweeperTestContext {
+
+	ctx := &sweeperTestContext{
+		t:           t,
+		notifier:    newMockNotifier(100),
+		estimator:   &mockFeeEstimator{feeRate: 10},
+		wallet:      &mockWallet{},
+		timers:      make(chan chan time.Time, 10),
+		publishChan: make(chan *wire.MsgTx, 10),
+	}
+
+	ctx.sweeper = New(&UtxoSweeperConfig{
+		GenSweepScript: func() ([]byte, error) {
+			return testPkScript, nil
+		},
+		FeeEstimator: ctx.estimator,
+		PublishTransaction: func(tx *wire.MsgTx) error {
+			ctx.publishChan <- tx
+			return nil
+		},
+		NewBatchTimer: func() <-chan time.Time {
+			timer := make(chan time.Time, 1)
+			ctx.timers <- timer
+			return timer
+		},
+		Notifier:       ctx.notifier,
+		ChainIO:        ctx.notifier,
+		Store:          newMockSweeperStore(),
+		Signer:         &mockSigner{},
+		MaxInputsPerTx: maxInputsPerTx,
+		MaxFeeRate:     100,
+		Wallet:         ctx.wallet,
+	})
+
+	if err := ctx.sweeper.Start(); err != nil {
+		t.Fatalf("unable to start sweeper: %v", err)
+	}
+
+	return ctx
+}
+
+// tick expires the currently running batch timer.
+func (ctx *sweeperTestContext) tick() {
+	select {
+	case timer := <-ctx.timers:
+		timer <- time.Now()
+	case <-time.After(defaultTestTimeout):
+		ctx.t.Fatalf("batch timer not started")
+	}
+}
+
+// receiveTx asserts that a sweep tx is published, and returns it.
+func (ctx *sweeperTestContext) receiveTx() *wire.MsgTx {
+	select {
+	case tx := <-ctx.publishChan:
+		return tx
+	case <-time.After(defaultTestTimeout):
+		ctx.t.Fatalf("no sweep tx published")
+	}
+
+	return nil
+}
+
+// finish stops the sweeper and asserts that no unexpected txes were
+// published.
+func (ctx *sweeperTestContext) finish() {
+	if err := ctx.sweeper.Stop(); err != nil {
+		ctx.t.Fatalf("unable to stop sweeper: %v", err)
+	}
+
+	select {
+	case tx := <-ctx.publishChan:
+		ctx.t.Fatalf("unexpected tx published: %v", tx.TxHash())
+	default:
+	}
+}
+
+func (ctx *sweeperTestContext) sweepInput(input Input,
+	deadline uint32) chan Result {
+
+	resultChan, err := ctx.sweeper.SweepInput(input, deadline)
+	if err != nil {
+		ctx.t.Fatalf("unable to sweep input: %v", err)
+	}
+
+	return resultChan
+}
+
+func (ctx *sweeperTestContext) expectResult(resultChan chan Result,
+	expectedErr error) *wire.MsgTx {
+
+	select {
+	case result := <-resultChan:
+		if result.Err != expectedErr {
+			ctx.t.Fatalf("expected error %v, got %v", expectedErr,
+				result.Err)
+		}
+		return result.Tx
+
+	case <-time.After(defaultTestTimeout):
+		ctx.t.Fatalf("no result received")
+	}
+
+	return nil
+}
+
+// assertTxFeeRate asserts that the passed tx, spending mock inputs of the
+// given total amount, pays exactly the passed fee rate.
+func assertTxFeeRate(t *testing.T, tx *wire.MsgTx, totalIn int64,
+	feeRate lnwallet.SatPerVByte) {
+
+	var weightEstimate lnwallet.TxWeightEstimator
+	weightEstimate.AddP2WKHOutput()
+	for range tx.TxIn {
+		weightEstimate.AddWitnessInput(lnwallet.P2WKHWitnessSize)
+	}
+	expectedFee := feeRate.FeeForVSize(int64(weightEstimate.VSize()))
+
+	fee := totalIn - tx.TxOut[0].Value
+	if fee != int64(expectedFee) {
+		t.Fatalf("expected fee %v at fee rate %v sat/vbyte, got %v",
+			int64(expectedFee), int64(feeRate), fee)
+	}
+}
+
+// TestSweeperSuccess asserts that offered inputs are batched into a single
+// sweep tx, and that all listeners are notified once it confirms.
+func TestSweeperSuccess(t *testing.T) {
+	ctx := createSweeperTestContext(t, 10)
+
+	input1 := newMockInput(0, 100000)
+	input2 := newMockInput(1, 200000)
+
+	resultChan1 := ctx.sweepInput(input1, 0)
+	resultChan2 := ctx.sweepInput(input2, 0)
+
+	// Offering the same input twice should only result in an additional
+	// listener.
+	resultChan3 := ctx.sweepInput(input1, 0)
+
+	ctx.tick()
+
+	sweepTx := ctx.receiveTx()
+	if len(sweepTx.TxIn) != 2 {
+		t.Fatalf("expected 2 inputs in sweep tx, got %v",
+			len(sweepTx.TxIn))
+	}
+	if sweepTx.LockTime != 100 {
+		t.Fatalf("expected lock time 100, got %v", sweepTx.LockTime)
+	}
+	assertTxFeeRate(t, sweepTx, 300000, 10)
+
+	ctx.notifier.spend(sweepTx)
+
+	for _, resultChan := range []chan Result{
+		resultChan1, resultChan2, resultChan3,
+	} {
+		tx := ctx.expectResult(resultChan, nil)
+		if tx.TxHash() != sweepTx.TxHash() {
+			t.Fatalf("unexpected spending tx")
+		}
+	}
+
+	ctx.finish()
+}
+
+// TestSweeperRemoteSpend asserts that a spend of an input by a tx that wasn't
+// published by the sweeper is reported as a remote spend.
+func TestSweeperRemoteSpend(t *testing.T) {
+	ctx := createSweeperTestContext(t, 10)
+
+	input := newMockInput(0, 100000)
+	resultChan := ctx.sweepInput(input, 0)
+
+	ctx.tick()
+	ctx.receiveTx()
+
+	// Before our sweep tx confirms, the remote party spends the input.
+	remoteTx := wire.NewMsgTx(2)
+	remoteTx.AddTxIn(&wire.TxIn{PreviousOutPoint: *input.OutPoint()})
+	remoteTx.AddTxOut(&wire.TxOut{Value: 90000, PkScript: testPkScript})
+
+	ctx.notifier.spend(remoteTx)
+
+	tx := ctx.expectResult(resultChan, ErrRemoteSpend)
+	if tx.TxHash() != remoteTx.TxHash() {
+		t.Fatalf("unexpected spending tx")
+	}
+
+	ctx.finish()
+}
+
+// TestSweeperFeeBump asserts that inputs which don't confirm before the next
+// block are swept again at a higher fee rate, which is capped at the maximum
+// fee rate.
+func TestSweeperFeeBump(t *testing.T) {
+	ctx := createSweeperTestContext(t, 10)
+
+	input := newMockInput(0, 1000000)
+	resultChan := ctx.sweepInput(input, 0)
+
+	ctx.tick()
+	firstTx := ctx.receiveTx()
+	assertTxFeeRate(t, firstTx, 1000000, 10)
+
+	// A new block comes in without the sweep tx confirming, which should
+	// cause the input to be swept again at a higher fee rate.
+	ctx.notifier.epochChan <- &chainntnfs.BlockEpoch{
+		Hash:   &chainhash.Hash{},
+		Height: 101,
+	}
+	ctx.tick()
+
+	secondTx := ctx.receiveTx()
+	if secondTx.TxOut[0].Value >= firstTx.TxOut[0].Value {
+		t.Fatalf("expected fee of second sweep tx to be higher")
+	}
+	assertTxFeeRate(t, secondTx, 1000000, 11)
+
+	// Raising the fee estimate beyond the maximum fee rate should cap the
+	// fee rate.
+	ctx.estimator.setFeeRate(1000)
+	ctx.notifier.epochChan <- &chainntnfs.BlockEpoch{
+		Hash:   &chainhash.Hash{},
+		Height: 102,
+	}
+	ctx.tick()
+
+	thirdTx := ctx.receiveTx()
+	assertTxFeeRate(t, thirdTx, 1000000, 100)
+
+	ctx.notifier.spend(secondTx)
+	ctx.expectResult(resultChan, nil)
+
+	ctx.finish()
+}
+
+// TestSweeperMaxInputsPerTx asserts that inputs are split across several
+// sweep txes once the maximum number of inputs per tx is exceeded.
+func TestSweeperMaxInputsPerTx(t *testing.T) {
+	ctx := createSweeperTestContext(t, 2)
+
+	resultChans := make([]chan Result, 5)
+	for i := range resultChans {
+		resultChans[i] = ctx.sweepInput(
+			newMockInput(uint32(i), 100000), 0,
+		)
+	}
+
+	ctx.tick()
+
+	numInputs := 0
+	for i := 0; i < 3; i++ {
+		tx := ctx.receiveTx()
+		if len(tx.TxIn) > 2 {
+			t.Fatalf("expected at most 2 inputs, got %v",
+				len(tx.TxIn))
+		}
+		numInputs += len(tx.TxIn)
+
+		ctx.notifier.spend(tx)
+	}
+
+	if numInputs != 5 {
+		t.Fatalf("expected 5 inputs to be swept, got %v", numInputs)
+	}
+
+	for _, resultChan := range resultChans {
+		ctx.expectResult(resultChan, nil)
+	}
+
+	ctx.finish()
+}
+
+// TestSweeperDustInput asserts that inputs whose value doesn't cover the fee
+// of the sweep tx aren't published, and are reported as pending until the
+// sweeper shuts down.
+func TestSweeperDustInput(t *testing.T) {
+	ctx := createSweeperTestContext(t, 10)
+
+	resultChan := ctx.sweepInput(newMockInput(0, 1000), 0)
+
+	ctx.tick()
+
+	if err := ctx.sweeper.Stop(); err != nil {
+		t.Fatalf("unable to stop sweeper: %v", err)
+	}
+
+	select {
+	case tx := <-ctx.publishChan:
+		t.Fatalf("unexpected tx published: %v", tx.TxHash())
+	default:
+	}
+
+	ctx.expectResult(resultChan, ErrSweeperShuttingDown)
+}
+
+// TestSweeperCpfp asserts that inputs bumping the fee of their parent are
+// swept along with wallet inputs at the fee rate of the package, and that the
+// sweep is abandoned once the parent confirms.
+func TestSweeperCpfp(t *testing.T) {
+	ctx := createSweeperTestContext(t, 10)
+
+	walletUtxo := &lnwallet.Utxo{
+		AddressType: lnwallet.WitnessPubKey,
+		Value:       100000,
+		PkScript:    testPkScript,
+		OutPoint:    wire.OutPoint{Hash: chainhash.Hash{3}},
+	}
+	ctx.wallet.setUtxos([]*lnwallet.Utxo{walletUtxo})
+
+	// The parent has a weight of 1000, and pays a fee rate of only 1
+	// sat/vbyte.
+	parent := &TxInfo{Fee: 250, Weight: 1000}
+	cpfpInput := newMockCpfpInput(0, parent)
+
+	// A regular input offered alongside the CPFP input should be swept in
+	// a tx of its own.
+	input := newMockInput(0, 100000)
+
+	cpfpResultChan := ctx.sweepInput(cpfpInput, 0)
+	resultChan := ctx.sweepInput(input, 0)
+
+	ctx.tick()
+
+	var cpfpTx *wire.MsgTx
+	for i := 0; i < 2; i++ {
+		tx := ctx.receiveTx()
+		if tx.TxIn[0].PreviousOutPoint == *input.OutPoint() {
+			if len(tx.TxIn) != 1 {
+				t.Fatalf("expected 1 input, got %v",
+					len(tx.TxIn))
+			}
+			ctx.notifier.spend(tx)
+			continue
+		}
+		cpfpTx = tx
+	}
+	ctx.expectResult(resultChan, nil)
+
+	// The CPFP tx should spend the anchor along with the wallet input,
+	// and pay for the parent at the estimated fee rate.
+	if len(cpfpTx.TxIn) != 2 {
+		t.Fatalf("expected 2 inputs in cpfp tx, got %v",
+			len(cpfpTx.TxIn))
+	}
+	if cpfpTx.TxIn[1].PreviousOutPoint != walletUtxo.OutPoint {
+		t.Fatalf("expected wallet input to be spent")
+	}
+
+	var weightEstimate lnwallet.TxWeightEstimator
+	weightEstimate.AddP2WKHOutput()
+	weightEstimate.AddWitnessInput(lnwallet.AnchorWitnessSize)
+	weightEstimate.AddWitnessInput(lnwallet.P2WKHWitnessSize)
+	expectedFee := lnwallet.SatPerVByte(10).FeeForVSize(
+		int64(weightEstimate.VSize())+250,
+	) - parent.Fee
+
+	totalIn := int64(lnwallet.AnchorSize + walletUtxo.Value)
+	if fee := totalIn - cpfpTx.TxOut[0].Value; fee != int64(expectedFee) {
+		t.Fatalf("expected fee %v, got %v", int64(expectedFee), fee)
+	}
+
+	// The wallet no longer reports the spent output as unspent. Once a
+	// new block comes in without the parent confirming, the fee should
+	// be bumped while reusing the same wallet input.
+	ctx.wallet.setUtxos(nil)
+	ctx.notifier.epochChan <- &chainntnfs.BlockEpoch{
+		Hash:   &chainhash.Hash{},
+		Height: 101,
+	}
+	ctx.tick()
+
+	bumpTx := ctx.receiveTx()
+	if len(bumpTx.TxIn) != 2 ||
+		bumpTx.TxIn[1].PreviousOutPoint != walletUtxo.OutPoint {
+
+		t.Fatalf("expected wallet input to be reused")
+	}
+	if bumpTx.TxOut[0].Value >= cpfpTx.TxOut[0].Value {
+		t.Fatalf("expected fee of bumped tx to be higher")
+	}
+
+	// Once the parent confirms, the sweep should be abandoned.
+	ctx.notifier.confirm(cpfpInput.OutPoint().Hash)
+	ctx.expectResult(cpfpResultChan, ErrParentConfirmed)
+
+	ctx.finish()
+}
+
+// TestSweeperCpfpNotNeeded asserts that the fee of a parent that pays a
+// sufficient fee rate on its own isn't bumped, until fee estimates rise.
+func TestSweeperCpfpNotNeeded(t *testing.T) {
+	ctx := createSweeperTestContext(t, 10)
+
+	ctx.wallet.setUtxos([]*lnwallet.Utxo{{
+		AddressType: lnwallet.WitnessPubKey,
+		Value:       100000,
+		PkScript:    testPkScript,
+		OutPoint:    wire.OutPoint{Hash: chainhash.Hash{3}},
+	}})
+
+	// The parent pays a fee rate of 20 sat/vbyte, which exceeds the
+	// estimate.
+	cpfpInput := newMockCpfpInput(0, &TxInfo{Fee: 5000, Weight: 1000})
+	resultChan := ctx.sweepInput(cpfpInput, 0)
+
+	ctx.tick()
+
+	select {
+	case tx := <-ctx.publishChan:
+		t.Fatalf("unexpected tx published: %v", tx.TxHash())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Once the fee estimate exceeds the fee rate of the parent, its fee
+	// should be bumped on the next block.
+	ctx.estimator.setFeeRate(30)
+	ctx.notifier.epochChan <- &chainntnfs.BlockEpoch{
+		Hash:   &chainhash.Hash{},
+		Height: 101,
+	}
+	ctx.tick()
+
+	cpfpTx := ctx.receiveTx()
+	ctx.notifier.spend(cpfpTx)
+	ctx.expectResult(resultChan, nil)
+
+	ctx.finish()
+}
@@ -0,0 +1,195 @@
+package sweep
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// getInputWitnessSizeUpperBound returns the maximum length of the witness for
+// the given input if it would be included in a tx.
+func getInputWitnessSizeUpperBound(input Input) (int, error) {
+	switch input.WitnessType() {
+
+	// Outputs on a remote commitment transaction that pay directly to us.
+	case lnwallet.CommitmentNoDelay:
+		return lnwallet.P2WKHWitnessSize, nil
+
+	// Outputs on a past commitment transaction that pay directly to us.
+	case lnwallet.CommitmentTimeLock:
+		return lnwallet.ToLocalTimeoutWitnessSize, nil
+
+	// The to_local output of a revoked commitment transaction.
+	case lnwallet.CommitmentRevoke:
+		return lnwallet.ToLocalPenaltyWitnessSize, nil
+
+	// An HTLC we offered on a revoked commitment transaction.
+	case lnwallet.HtlcOfferedRevoke:
+		return lnwallet.OfferedHtlcPenaltyWitnessSize, nil
+
+	// An HTLC we accepted on a revoked commitment transaction.
+	case lnwallet.HtlcAcceptedRevoke:
+		return lnwallet.AcceptedHtlcPenaltyWitnessSize, nil
+
+	// Outgoing second layer HTLC's that have confirmed within the chain,
+	// and the output they produced is now mature enough to sweep.
+	case lnwallet.HtlcOfferedTimeoutSecondLevel:
+		return lnwallet.SecondLevelHtlcSuccessWitnessSize, nil
+
+	// Incoming second layer HTLC's that have confirmed within the chain,
+	// and the output they produced is now mature enough to sweep.
+	case lnwallet.HtlcAcceptedSuccessSecondLevel:
+		return lnwallet.SecondLevelHtlcSuccessWitnessSize, nil
+
+	// An HTLC on the commitment transaction of the remote party, that has
+	// had its absolute timelock expire.
+	case lnwallet.HtlcOfferedRemoteTimeout:
+		return lnwallet.AcceptedHtlcTimeoutWitnessSize, nil
+
+	// An HTLC offered to us on the commitment transaction of the remote
+	// party, which we can sweep using the preimage.
+	case lnwallet.HtlcAcceptedRemoteSuccess:
+		return lnwallet.OfferedHtlcSuccessWitnessSize, nil
+
+	// The output of a second level HTLC transaction that was broadcast by
+	// the remote party on top of a revoked commitment.
+	case lnwallet.HtlcSecondLevelRevoke:
+		return lnwallet.SecondLevelHtlcPenaltyWitnessSize, nil
+
+	// Our anchor output on a commitment transaction, which is swept in
+	// order to bump the fee of the commitment.
+	case lnwallet.CommitmentAnchor:
+		return lnwallet.AnchorWitnessSize, nil
+
+	// A p2wkh output of our wallet, which is added to a sweep tx to pay
+	// for its fee.
+	case lnwallet.WitnessKeyHash:
+		return lnwallet.P2WKHWitnessSize, nil
+	}
+
+	return 0, fmt.Errorf("unexpected witness type: %v",
+		input.WitnessType())
+}
+
+// sweepTxFee returns the total value of the passed inputs, along with the fee
+// required to sweep them at the given fee rate. If any of the inputs spends an
+// unconfirmed parent tx, the fee is increased such that the parent and the
+// sweep tx together pay the fee rate.
+func sweepTxFee(inputs []Input, feePerVSize lnwallet.SatPerVByte) (
+	btcutil.Amount, btcutil.Amount, error) {
+
+	// Our sweep transaction will pay to a single segwit p2wkh address,
+	// ensure it contributes to our weight estimate.
+	var weightEstimate lnwallet.TxWeightEstimator
+	weightEstimate.AddP2WKHOutput()
+
+	// Use each input's witness type to determine the estimated weight of
+	// its witness, and sum up the total value contained in the inputs.
+	var totalSum btcutil.Amount
+	for _, input := range inputs {
+		size, err := getInputWitnessSizeUpperBound(input)
+		if err != nil {
+			return 0, 0, err
+		}
+		weightEstimate.AddWitnessInput(size)
+
+		totalSum += btcutil.Amount(input.SignDesc().Output.Value)
+	}
+
+	txFee := feePerVSize.FeeForVSize(int64(weightEstimate.VSize()))
+
+	// Pay for any unconfirmed parent that falls short of the fee rate.
+	for _, input := range inputs {
+		parent := input.UnconfParent()
+		if parent == nil {
+			continue
+		}
+
+		parentFee := feePerVSize.FeeForVSize(txVSize(parent.Weight))
+		if parentFee > parent.Fee {
+			txFee += parentFee - parent.Fee
+		}
+	}
+
+	return totalSum, txFee, nil
+}
+
+// createSweepTx builds a signed tx spending the inputs to the given output
+// script, paying the passed fee rate.
+func createSweepTx(inputs []Input, outputPkScript []byte,
+	currentBlockHeight uint32, feePerVSize lnwallet.SatPerVByte,
+	signer lnwallet.Signer) (*wire.MsgTx, error) {
+
+	totalSum, txFee, err := sweepTxFee(inputs, feePerVSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sweep as much possible, after subtracting txn fees.
+	sweepAmt := totalSum - txFee
+	if sweepAmt < lnwallet.DefaultDustLimit() {
+		return nil, fmt.Errorf("sweep amount %v after fee %v is below "+
+			"dust limit", sweepAmt, txFee)
+	}
+
+	// Create the sweep transaction that we will be building. We use
+	// version 2 as it is required for CSV. The lock time is set to the
+	// current height, such that any CLTV locked inputs can be spent and
+	// fee sniping is discouraged.
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.LockTime = currentBlockHeight
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: outputPkScript,
+		Value:    int64(sweepAmt),
+	})
+
+	// Add all inputs to the sweep transaction. CSV locked inputs need
+	// their sequence number to be set to the relative lock time, all
+	// others signal replaceability such that we're able to bump the fee
+	// of the sweep later on.
+	for _, input := range inputs {
+		sequence := uint32(wire.MaxTxInSequenceNum - 2)
+		if input.BlocksToMaturity() > 0 {
+			sequence = input.BlocksToMaturity()
+		}
+
+		sweepTx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: *input.OutPoint(),
+			Sequence:         sequence,
+		})
+	}
+
+	// Before signing the transaction, check to ensure that it meets some
+	// basic validity requirements.
+	btx := btcutil.NewTx(sweepTx)
+	if err := blockchain.CheckTransactionSanity(btx); err != nil {
+		return nil, err
+	}
+
+	hashCache := txscript.NewTxSigHashes(sweepTx)
+
+	// With all the inputs in place, use each input's unique witness
+	// function to generate the final witness required for spending.
+	for idx, input := range inputs {
+		witness, err := input.BuildWitness(
+			signer, sweepTx, hashCache, idx,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sweepTx.TxIn[idx].Witness = witness
+	}
+
+	return sweepTx, nil
+}
+
+// txVSize returns the virtual size of a tx of the passed weight.
+func txVSize(weight int64) int64 {
+	return (weight + blockchain.WitnessScaleFactor - 1) /
+		blockchain.WitnessScaleFactor
+}
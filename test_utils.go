@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"time"
 
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
@@ -182,9 +183,10 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 	}
 	aliceCommitPoint := lnwallet.ComputeCommitmentPoint(aliceFirstRevoke[:])
 
-	aliceCommitTx, bobCommitTx, err := lnwallet.CreateCommitmentTxns(channelBal,
-		channelBal, &aliceCfg, &bobCfg, aliceCommitPoint, bobCommitPoint,
-		*fundingTxIn)
+	aliceCommitTx, bobCommitTx, err := lnwallet.CreateCommitmentTxns(
+		channeldb.SingleFunder, channelBal, channelBal, &aliceCfg,
+		&bobCfg, aliceCommitPoint, bobCommitPoint, *fundingTxIn,
+	)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -329,8 +331,9 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 
 	chainArb := contractcourt.NewChainArbitrator(
 		contractcourt.ChainArbitratorConfig{
-			Notifier: notifier,
-			ChainIO:  chainIO,
+			Notifier:            notifier,
+			ChainIO:             chainIO,
+			NotifyClosedChannel: func(wire.OutPoint) {},
 		}, dbAlice,
 	)
 	chainArb.WatchNewChannel(aliceChannelState)
@@ -341,9 +344,11 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 		breachArbiter: breachArbiter,
 		chainArb:      chainArb,
 	}
+	s.htlcNotifier = htlcswitch.NewHtlcNotifier(time.Now)
 	htlcSwitch, err := htlcswitch.New(htlcswitch.Config{
 		DB:             dbAlice,
 		SwitchPackager: channeldb.NewSwitchPackager(),
+		HtlcNotifier:   s.htlcNotifier,
 	})
 	if err != nil {
 		return nil, nil, nil, nil, err
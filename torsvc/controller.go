@@ -0,0 +1,542 @@
+package torsvc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// success is the Tor Control response code representing a successful
+	// request.
+	success = 250
+
+	// nonceLen is the length of a nonce generated by either the controller
+	// or the Tor server.
+	nonceLen = 32
+
+	// cookieLen is the length of the authentication cookie.
+	cookieLen = 32
+
+	// protocolInfoVersion is the version of the PROTOCOLINFO command that
+	// we'll request from the Tor server.
+	protocolInfoVersion = 1
+
+	// serverKey is the key used when computing the HMAC-SHA256 of a
+	// message from the Tor server.
+	serverKey = "Tor safe cookie authentication server-to-controller hash"
+
+	// controllerKey is the key used when computing the HMAC-SHA256 of a
+	// message from the controller.
+	controllerKey = "Tor safe cookie authentication controller-to-server hash"
+)
+
+var (
+	// minV3Version is the minimum version of the Tor server that supports
+	// v3 onion services.
+	minV3Version = [4]int{0, 3, 3, 6}
+)
+
+// OnionType denotes the type of the onion service.
+type OnionType int
+
+const (
+	// V2 denotes that the onion service is V2.
+	V2 OnionType = iota
+
+	// V3 denotes that the onion service is V3.
+	V3
+)
+
+// String returns a human readable description of the onion service type.
+func (o OnionType) String() string {
+	switch o {
+	case V2:
+		return "v2"
+	case V3:
+		return "v3"
+	default:
+		return "unknown"
+	}
+}
+
+// AddOnionConfig houses all of the required parameters in order to
+// successfully create a new onion service or restore an existing one.
+type AddOnionConfig struct {
+	// Type denotes the type of the onion service that should be created.
+	Type OnionType
+
+	// VirtualPort is the externally reachable port of the onion service.
+	VirtualPort int
+
+	// TargetPorts is the set of local ports that the onion service's
+	// virtual port will map to. If more than one is specified, then the
+	// Tor server will pick one at random for each incoming connection. If
+	// none are specified, then the virtual port is used as the target
+	// port.
+	TargetPorts []int
+
+	// PrivateKeyPath is the path to the file in which the private key of
+	// the onion service is stored. If the file exists, the onion service
+	// it holds the key of will be restored, otherwise a new onion service
+	// is created and its private key is written to this path. This allows
+	// the onion service to keep the same address across restarts.
+	PrivateKeyPath string
+}
+
+// Controller is an implementation of the Tor Control protocol. This is used
+// in order to communicate with a Tor server, authenticating either through a
+// password, or through the Tor server's authentication cookie.
+//
+// NOTE: The connection to the Tor server must be authenticated before
+// proceeding to send commands. Otherwise, the connection will be closed.
+type Controller struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	// conn is the underlying connection between the controller and the
+	// Tor server. It provides read and write methods to simplify the
+	// text-based messages within the connection.
+	conn *textproto.Conn
+
+	// controlAddr is the host:port the Tor server is listening locally for
+	// controller connections on.
+	controlAddr string
+
+	// password is the password used to authenticate with the Tor server.
+	// If blank, cookie authentication will be attempted instead.
+	password string
+
+	// version is the current version of the Tor server.
+	version string
+}
+
+// NewController returns a new Tor controller that will be able to interact
+// with a Tor server listening at the given address. If a password is
+// specified, then it'll be used to authenticate with the Tor server,
+// otherwise cookie authentication will be used.
+func NewController(controlAddr, password string) *Controller {
+	return &Controller{
+		controlAddr: controlAddr,
+		password:    password,
+	}
+}
+
+// Start establishes and authenticates the connection between the controller
+// and a Tor server. Once done, the controller will be able to send commands
+// and expect responses.
+func (c *Controller) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	conn, err := textproto.Dial("tcp", c.controlAddr)
+	if err != nil {
+		return fmt.Errorf("unable to connect to Tor server: %v", err)
+	}
+
+	c.conn = conn
+
+	return c.authenticate()
+}
+
+// Stop closes the connection between the controller and the Tor server. Any
+// onion services created through the controller will be removed by the Tor
+// server once the connection is closed.
+func (c *Controller) Stop() error {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// sendCommand sends a command to the Tor server and returns its response code
+// along with its reply. The lines of a multi-line reply are separated by
+// newlines.
+func (c *Controller) sendCommand(command string) (int, string, error) {
+	if err := c.conn.Writer.PrintfLine("%s", command); err != nil {
+		return 0, "", err
+	}
+
+	// We'll use ReadResponse as it has built-in support for multi-line
+	// text protocol responses.
+	code, reply, err := c.conn.Reader.ReadResponse(success)
+	if err != nil {
+		return code, reply, err
+	}
+
+	return code, reply, nil
+}
+
+// parseTorReply parses the reply from the Tor server after receiving a
+// command from a controller. This will parse the relevant reply parameters
+// into a map of keys and values.
+func parseTorReply(reply string) map[string]string {
+	params := make(map[string]string)
+
+	// Replies may span multiple lines, and each line may contain several
+	// space-delimited parameters of the form KEY=VALUE. Values may also be
+	// quoted, in which case they can contain spaces and escaped characters
+	// of their own.
+	for _, line := range strings.Split(reply, "\n") {
+		i := 0
+		for i < len(line) {
+			// Skip over any spaces in between parameters.
+			if line[i] == ' ' {
+				i++
+				continue
+			}
+
+			// Read the key of the parameter, which ends at either an
+			// equals sign or at the end of the current token.
+			start := i
+			for i < len(line) && line[i] != '=' && line[i] != ' ' {
+				i++
+			}
+			key := line[start:i]
+
+			// Tokens without a value, such as the name of the reply
+			// itself, aren't parameters, so we'll skip them.
+			if i == len(line) || line[i] != '=' {
+				continue
+			}
+
+			// Skip over the equals sign.
+			i++
+
+			// If the value isn't quoted, then it simply ends at the
+			// end of the current token.
+			if i == len(line) || line[i] != '"' {
+				start = i
+				for i < len(line) && line[i] != ' ' {
+					i++
+				}
+				params[key] = line[start:i]
+
+				continue
+			}
+
+			// Otherwise, we'll read the value up until the closing
+			// quote, unescaping any characters along the way.
+			var value bytes.Buffer
+			for i++; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) {
+					i++
+				}
+				value.WriteByte(line[i])
+			}
+
+			// Skip over the closing quote.
+			i++
+
+			params[key] = value.String()
+		}
+	}
+
+	return params
+}
+
+// authenticate authenticates the connection between the controller and the
+// Tor server using a password if one was provided, or the SAFECOOKIE or
+// COOKIE authentication methods otherwise.
+func (c *Controller) authenticate() error {
+	// Before proceeding to authenticate the connection, we'll retrieve
+	// the authentication methods supported by the Tor server, along with
+	// the path to its authentication cookie.
+	cmd := fmt.Sprintf("PROTOCOLINFO %d", protocolInfoVersion)
+	_, reply, err := c.sendCommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	info := parseTorReply(reply)
+	methods, ok := info["METHODS"]
+	if !ok {
+		return errors.New("received empty authentication methods")
+	}
+
+	c.version = info["Tor"]
+
+	authMethods := make(map[string]struct{})
+	for _, method := range strings.Split(methods, ",") {
+		authMethods[method] = struct{}{}
+	}
+
+	hasMethod := func(method string) bool {
+		_, ok := authMethods[method]
+		return ok
+	}
+
+	switch {
+	// If a password was specified, then the user expects it to be used,
+	// so it takes precedence over the other authentication methods.
+	case c.password != "":
+		if !hasMethod("HASHEDPASSWORD") {
+			return errors.New("the Tor server doesn't support " +
+				"password authentication")
+		}
+
+		_, _, err := c.sendCommand(
+			fmt.Sprintf("AUTHENTICATE %v", quoteString(c.password)),
+		)
+		return err
+
+	// The SAFECOOKIE method is preferred over the COOKIE method, as it
+	// doesn't reveal the contents of the cookie to the Tor server.
+	case hasMethod("SAFECOOKIE"):
+		return c.authenticateSafeCookie(info["COOKIEFILE"])
+
+	case hasMethod("COOKIE"):
+		cookie, err := readCookie(info["COOKIEFILE"])
+		if err != nil {
+			return err
+		}
+
+		_, _, err = c.sendCommand(fmt.Sprintf("AUTHENTICATE %x", cookie))
+		return err
+
+	case hasMethod("NULL"):
+		_, _, err := c.sendCommand("AUTHENTICATE")
+		return err
+
+	default:
+		return fmt.Errorf("no supported authentication methods, the "+
+			"Tor server supports: %v", methods)
+	}
+}
+
+// authenticateSafeCookie authenticates the connection with the Tor server
+// using the SAFECOOKIE method. This proves to the Tor server that we're able
+// to read its authentication cookie, without revealing the cookie itself.
+func (c *Controller) authenticateSafeCookie(cookieFile string) error {
+	cookie, err := readCookie(cookieFile)
+	if err != nil {
+		return err
+	}
+
+	// Send an AUTHCHALLENGE command along with a random nonce, to which
+	// the Tor server will respond with a hash that proves it knows the
+	// contents of the cookie, along with its own nonce.
+	var clientNonce [nonceLen]byte
+	if _, err := rand.Read(clientNonce[:]); err != nil {
+		return fmt.Errorf("unable to generate client nonce: %v", err)
+	}
+
+	cmd := fmt.Sprintf("AUTHCHALLENGE SAFECOOKIE %x", clientNonce[:])
+	_, reply, err := c.sendCommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	challenge := parseTorReply(reply)
+	serverHash, err := hex.DecodeString(challenge["SERVERHASH"])
+	if err != nil {
+		return fmt.Errorf("unable to decode server hash: %v", err)
+	}
+	if len(serverHash) != sha256.Size {
+		return errors.New("invalid server hash length")
+	}
+
+	serverNonce, err := hex.DecodeString(challenge["SERVERNONCE"])
+	if err != nil {
+		return fmt.Errorf("unable to decode server nonce: %v", err)
+	}
+	if len(serverNonce) != nonceLen {
+		return errors.New("invalid server nonce length")
+	}
+
+	// Before authenticating ourselves, we'll make sure the Tor server
+	// actually knows the contents of the cookie.
+	expectedServerHash := computeHMAC256(
+		[]byte(serverKey), cookie, clientNonce[:], serverNonce,
+	)
+	if !hmac.Equal(serverHash, expectedServerHash) {
+		return errors.New("received invalid server hash")
+	}
+
+	// Finally, we'll authenticate ourselves by sending back our own hash
+	// over the cookie and both nonces.
+	clientHash := computeHMAC256(
+		[]byte(controllerKey), cookie, clientNonce[:], serverNonce,
+	)
+
+	_, _, err = c.sendCommand(fmt.Sprintf("AUTHENTICATE %x", clientHash))
+	return err
+}
+
+// readCookie reads the authentication cookie of the Tor server from the
+// given path.
+func readCookie(cookieFile string) ([]byte, error) {
+	if cookieFile == "" {
+		return nil, errors.New("the Tor server didn't specify the " +
+			"location of its authentication cookie")
+	}
+
+	cookie, err := ioutil.ReadFile(cookieFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read authentication "+
+			"cookie: %v", err)
+	}
+
+	if len(cookie) != cookieLen {
+		return nil, errors.New("invalid authentication cookie length")
+	}
+
+	return cookie, nil
+}
+
+// computeHMAC256 computes the HMAC-SHA256 of a key and the concatenation of
+// the given messages.
+func computeHMAC256(key []byte, msgs ...[]byte) []byte {
+	h := hmac.New(sha256.New, key)
+	for _, msg := range msgs {
+		h.Write(msg)
+	}
+
+	return h.Sum(nil)
+}
+
+// quoteString returns the given string as a quoted string suitable to be sent
+// within a command to the Tor server.
+func quoteString(s string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + escaper.Replace(s) + `"`
+}
+
+// supportsV3 determines whether the given version of a Tor server supports
+// v3 onion services.
+func supportsV3(version string) error {
+	// The version string may carry a suffix, such as "0.3.3.7 (git-...)"
+	// or "0.4.0.1-alpha", so we'll only examine the leading numbers.
+	version = strings.SplitN(version, " ", 2)[0]
+	version = strings.SplitN(version, "-", 2)[0]
+
+	parts := strings.Split(version, ".")
+	if len(parts) != len(minV3Version) {
+		return fmt.Errorf("unable to parse Tor version %q", version)
+	}
+
+	for i, part := range parts {
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("unable to parse Tor version %q",
+				version)
+		}
+
+		switch {
+		case num > minV3Version[i]:
+			return nil
+
+		case num < minV3Version[i]:
+			return fmt.Errorf("v3 onion services require Tor "+
+				"version 0.3.3.6 or newer, have %v", version)
+		}
+	}
+
+	return nil
+}
+
+// AddOnion creates an onion service and returns its onion address. Once
+// created, the new onion service will remain active until the connection
+// between the controller and the Tor server is closed. If an onion service
+// private key is stored at the configured path, then the onion service it
+// belongs to will be restored instead.
+func (c *Controller) AddOnion(cfg AddOnionConfig) (*OnionAddr, error) {
+	if cfg.PrivateKeyPath == "" {
+		return nil, errors.New("onion service private key path " +
+			"must be specified")
+	}
+
+	// Before sending the request to create an onion service to the Tor
+	// server, we'll make sure that it supports V3 onion services if that
+	// was the type requested.
+	if cfg.Type == V3 {
+		if err := supportsV3(c.version); err != nil {
+			return nil, err
+		}
+	}
+
+	// We'll start off by checking whether we already have a private key
+	// for an onion service. If we do, we'll restore that onion service,
+	// otherwise we'll request the Tor server to create a new one of the
+	// requested type.
+	var keyParam string
+	privateKey, err := ioutil.ReadFile(cfg.PrivateKeyPath)
+	switch {
+	case err == nil:
+		keyParam = strings.TrimSpace(string(privateKey))
+
+	case os.IsNotExist(err):
+		switch cfg.Type {
+		case V2:
+			keyParam = "NEW:RSA1024"
+		case V3:
+			keyParam = "NEW:ED25519-V3"
+		default:
+			return nil, fmt.Errorf("unknown onion service type %v",
+				cfg.Type)
+		}
+
+	default:
+		return nil, fmt.Errorf("unable to read onion service private "+
+			"key: %v", err)
+	}
+
+	// Now, we'll map the virtual port of the onion service to each of the
+	// target ports.
+	cmd := fmt.Sprintf("ADD_ONION %s", keyParam)
+	for _, targetPort := range cfg.TargetPorts {
+		cmd += fmt.Sprintf(" Port=%d,%d", cfg.VirtualPort, targetPort)
+	}
+	if len(cfg.TargetPorts) == 0 {
+		cmd += fmt.Sprintf(" Port=%d", cfg.VirtualPort)
+	}
+
+	_, reply, err := c.sendCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// If successful, the reply from the server should be of the following
+	// format, depending on whether a private key has been requested:
+	//
+	//	"250-ServiceID=testonion1234567"
+	//	"250-PrivateKey=RSA1024:[Blob Redacted]"
+	//	"250 OK"
+	//
+	//	"250-ServiceID=testonion1234567"
+	//	"250 OK"
+	onionService := parseTorReply(reply)
+	serviceID, ok := onionService["ServiceID"]
+	if !ok {
+		return nil, errors.New("service id not found in reply")
+	}
+
+	// If a new onion service was created, we'll write its private key to
+	// disk so that we're able to restore it the next time we start up.
+	if privateKey, ok := onionService["PrivateKey"]; ok {
+		err := ioutil.WriteFile(
+			cfg.PrivateKeyPath, []byte(privateKey), 0600,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to write onion service "+
+				"private key to file: %v", err)
+		}
+	}
+
+	return &OnionAddr{
+		OnionService: serviceID + OnionSuffix,
+		Port:         cfg.VirtualPort,
+	}, nil
+}
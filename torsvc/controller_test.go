@@ -0,0 +1,301 @@
+package torsvc
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testServiceID  = "vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3ah7ngmcopnpyyd"
+	testPrivateKey = "ED25519-V3:aGVsbG8gd29ybGQ="
+)
+
+// mockTorServer is a minimal implementation of a Tor server's control port,
+// which authenticates controllers using the SAFECOOKIE method and is able to
+// create onion services.
+type mockTorServer struct {
+	listener net.Listener
+	cookie   []byte
+	version  string
+
+	// addOnionCmds receives every ADD_ONION command sent to the server.
+	addOnionCmds chan string
+}
+
+// newMockTorServer creates and starts a new mock Tor server, which stores its
+// authentication cookie within the given directory.
+func newMockTorServer(t *testing.T, dir, version string) *mockTorServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+
+	cookie := make([]byte, cookieLen)
+	if _, err := rand.Read(cookie); err != nil {
+		t.Fatalf("unable to generate cookie: %v", err)
+	}
+
+	s := &mockTorServer{
+		listener:     listener,
+		cookie:       cookie,
+		version:      version,
+		addOnionCmds: make(chan string, 10),
+	}
+
+	cookiePath := filepath.Join(dir, "control auth cookie "+version)
+	if err := ioutil.WriteFile(cookiePath, cookie, 0600); err != nil {
+		t.Fatalf("unable to write cookie: %v", err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(textproto.NewConn(conn), cookiePath)
+		}
+	}()
+
+	return s
+}
+
+// serve replies to all commands sent over the given connection.
+func (s *mockTorServer) serve(conn *textproto.Conn, cookiePath string) {
+	defer conn.Close()
+
+	var clientNonce, serverNonce []byte
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd := strings.SplitN(line, " ", 2)
+		switch cmd[0] {
+		case "PROTOCOLINFO":
+			conn.PrintfLine("250-PROTOCOLINFO 1")
+			conn.PrintfLine("250-AUTH METHODS=COOKIE,SAFECOOKIE "+
+				"COOKIEFILE=%v", quoteString(cookiePath))
+			conn.PrintfLine("250-VERSION Tor=%v",
+				quoteString(s.version))
+			conn.PrintfLine("250 OK")
+
+		case "AUTHCHALLENGE":
+			nonce := strings.TrimPrefix(cmd[1], "SAFECOOKIE ")
+			clientNonce, _ = hex.DecodeString(nonce)
+
+			serverNonce = make([]byte, nonceLen)
+			rand.Read(serverNonce)
+
+			serverHash := computeHMAC256(
+				[]byte(serverKey), s.cookie, clientNonce,
+				serverNonce,
+			)
+			conn.PrintfLine("250 AUTHCHALLENGE SERVERHASH=%x "+
+				"SERVERNONCE=%x", serverHash, serverNonce)
+
+		case "AUTHENTICATE":
+			clientHash := computeHMAC256(
+				[]byte(controllerKey), s.cookie, clientNonce,
+				serverNonce,
+			)
+			if len(cmd) != 2 || cmd[1] != hex.EncodeToString(clientHash) {
+				conn.PrintfLine("515 Authentication failed")
+				return
+			}
+
+			conn.PrintfLine("250 OK")
+
+		case "ADD_ONION":
+			s.addOnionCmds <- line
+
+			conn.PrintfLine("250-ServiceID=%v", testServiceID)
+			if strings.HasPrefix(cmd[1], "NEW:") {
+				conn.PrintfLine("250-PrivateKey=%v",
+					testPrivateKey)
+			}
+			conn.PrintfLine("250 OK")
+
+		default:
+			conn.PrintfLine("510 Unrecognized command")
+		}
+	}
+}
+
+// TestParseTorReply tests that we're able to properly parse the parameters
+// of both single and multi-line replies from the Tor server.
+func TestParseTorReply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		reply  string
+		params map[string]string
+	}{
+		{
+			reply:  "OK",
+			params: map[string]string{},
+		},
+		{
+			reply: "AUTHCHALLENGE SERVERHASH=abcd SERVERNONCE=1234",
+			params: map[string]string{
+				"SERVERHASH":  "abcd",
+				"SERVERNONCE": "1234",
+			},
+		},
+		{
+			reply: "PROTOCOLINFO 1\n" +
+				"AUTH METHODS=COOKIE,SAFECOOKIE " +
+				"COOKIEFILE=\"/path with/spaces \\\"x\\\"\"\n" +
+				"VERSION Tor=\"0.3.5.8\"\nOK",
+			params: map[string]string{
+				"METHODS":    "COOKIE,SAFECOOKIE",
+				"COOKIEFILE": "/path with/spaces \"x\"",
+				"Tor":        "0.3.5.8",
+			},
+		},
+		{
+			reply: "ServiceID=abc\nPrivateKey=RSA1024:ab+c/d==\nOK",
+			params: map[string]string{
+				"ServiceID":  "abc",
+				"PrivateKey": "RSA1024:ab+c/d==",
+			},
+		},
+	}
+
+	for i, test := range tests {
+		params := parseTorReply(test.reply)
+		if !reflect.DeepEqual(params, test.params) {
+			t.Fatalf("test #%d: expected params %v, got %v", i,
+				test.params, params)
+		}
+	}
+}
+
+// TestSupportsV3 tests that we only attempt to create v3 onion services with
+// Tor servers that support them.
+func TestSupportsV3(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version  string
+		expectOK bool
+	}{
+		{"0.3.3.6", true},
+		{"0.3.3.7 (git-035a35178c92da94)", true},
+		{"0.4.0.1-alpha", true},
+		{"1.0.0.0", true},
+		{"0.3.3.5", false},
+		{"0.2.9.16", false},
+		{"", false},
+		{"0.3.x.6", false},
+	}
+
+	for _, test := range tests {
+		err := supportsV3(test.version)
+		if test.expectOK && err != nil {
+			t.Fatalf("expected version %q to support v3: %v",
+				test.version, err)
+		}
+		if !test.expectOK && err == nil {
+			t.Fatalf("expected version %q to not support v3",
+				test.version)
+		}
+	}
+}
+
+// TestControllerAddOnion tests that the controller is able to authenticate
+// with a Tor server using its cookie, create a new onion service, and restore
+// that same onion service through its stored private key.
+func TestControllerAddOnion(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "torcontroller")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	server := newMockTorServer(t, tempDir, "0.3.5.8")
+	defer server.listener.Close()
+
+	keyPath := filepath.Join(tempDir, "v3_onion_private_key")
+	onionCfg := AddOnionConfig{
+		Type:           V3,
+		VirtualPort:    9735,
+		TargetPorts:    []int{9735, 9736},
+		PrivateKeyPath: keyPath,
+	}
+	expectedAddr := &OnionAddr{
+		OnionService: testServiceID + OnionSuffix,
+		Port:         9735,
+	}
+
+	// addOnion starts a new controller, and creates an onion service
+	// through it, ensuring the expected command was sent to the server.
+	addOnion := func(expectedCmd string) {
+		controller := NewController(server.listener.Addr().String(), "")
+		if err := controller.Start(); err != nil {
+			t.Fatalf("unable to start controller: %v", err)
+		}
+		defer controller.Stop()
+
+		onionAddr, err := controller.AddOnion(onionCfg)
+		if err != nil {
+			t.Fatalf("unable to add onion service: %v", err)
+		}
+		if !reflect.DeepEqual(onionAddr, expectedAddr) {
+			t.Fatalf("expected onion address %v, got %v",
+				expectedAddr, onionAddr)
+		}
+
+		cmd := <-server.addOnionCmds
+		if cmd != expectedCmd {
+			t.Fatalf("expected command %q, got %q", expectedCmd,
+				cmd)
+		}
+	}
+
+	// As we don't have a private key yet, a new onion service should be
+	// created, with its virtual port mapping to both target ports.
+	portMapping := "Port=9735,9735 Port=9735,9736"
+	addOnion("ADD_ONION NEW:ED25519-V3 " + portMapping)
+
+	// The private key of the new onion service should have been stored.
+	privateKey, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("unable to read private key: %v", err)
+	}
+	if string(privateKey) != testPrivateKey {
+		t.Fatalf("expected private key %v, got %v", testPrivateKey,
+			string(privateKey))
+	}
+
+	// Creating the onion service once more should restore it through the
+	// stored private key.
+	addOnion("ADD_ONION " + testPrivateKey + " " + portMapping)
+
+	// Finally, a Tor server that doesn't support v3 onion services should
+	// be rejected before any command is sent.
+	oldServer := newMockTorServer(t, tempDir, "0.3.2.10")
+	defer oldServer.listener.Close()
+
+	controller := NewController(oldServer.listener.Addr().String(), "")
+	if err := controller.Start(); err != nil {
+		t.Fatalf("unable to start controller: %v", err)
+	}
+	defer controller.Stop()
+
+	if _, err := controller.AddOnion(onionCfg); err == nil {
+		t.Fatalf("expected v3 onion service creation to fail")
+	}
+}
@@ -0,0 +1,52 @@
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// BreachHintSize is the length of the txid prefix used to identify remote
+// commitment broadcasts.
+const BreachHintSize = 16
+
+// BreachHint is the first 16-bytes of the txid belonging to a revoked
+// commitment transaction. The tower uses the hint to identify revoked
+// commitments that appear in the chain, without learning the full txid, which
+// is needed to decrypt the associated justice kit.
+type BreachHint [BreachHintSize]byte
+
+// NewBreachHintFromHash creates a breach hint from a transaction ID.
+func NewBreachHintFromHash(hash *chainhash.Hash) BreachHint {
+	var hint BreachHint
+	copy(hint[:], hash[:BreachHintSize])
+	return hint
+}
+
+// String returns a hex encoding of the breach hint.
+func (h BreachHint) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// BreachKey is the key used to encrypt a justice kit. The key is computed as
+// the SHA256 of the txid belonging to the revoked commitment transaction. As a
+// result, the tower is only able to decrypt the kit once the full txid of the
+// breach transaction is known.
+type BreachKey [KeySize]byte
+
+// NewBreachKeyFromHash creates a breach key from a transaction ID.
+func NewBreachKeyFromHash(hash *chainhash.Hash) BreachKey {
+	var key BreachKey
+	h := sha256.New()
+	h.Write(hash[:])
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// NewBreachHintAndKeyFromHash derives a BreachHint and BreachKey from a given
+// txid in a single pass. The hint and key are used to encrypt a justice kit
+// for the commitment transaction with the given txid.
+func NewBreachHintAndKeyFromHash(hash *chainhash.Hash) (BreachHint, BreachKey) {
+	return NewBreachHintFromHash(hash), NewBreachKeyFromHash(hash)
+}
@@ -0,0 +1,420 @@
+package blob
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// NonceSize is the length of a chacha20poly1305 nonce, 12 bytes.
+	NonceSize = chacha20poly1305.NonceSize
+
+	// KeySize is the length of a chacha20poly1305 key, 32 bytes.
+	KeySize = chacha20poly1305.KeySize
+
+	// CiphertextExpansion is the number of bytes padded to a plaintext
+	// encrypted with chacha20poly1305, which comes from a 16-byte MAC.
+	CiphertextExpansion = 16
+
+	// MaxSweepAddrSize defines the maximum sweep address size that can be
+	// encoded in a blob.
+	MaxSweepAddrSize = 42
+
+	// PlaintextSize is the size of a serialized justice kit:
+	//
+	//	sweep address length:            1 byte
+	//	padded sweep address:           42 bytes
+	//	revocation pubkey:              33 bytes
+	//	local delay pubkey:             33 bytes
+	//	csv delay:                       4 bytes
+	//	commit to-local revocation sig: 64 bytes
+	//	commit to-remote pubkey:        33 bytes, maybe blank
+	//	commit to-remote sig:           64 bytes, maybe blank
+	PlaintextSize = 274
+
+	// Size is the size of an encrypted justice kit, including the nonce
+	// and MAC.
+	Size = NonceSize + PlaintextSize + CiphertextExpansion
+)
+
+// byteOrder is the default endianness used when serializing integers.
+var byteOrder = binary.BigEndian
+
+var (
+	// ErrCiphertextTooSmall is a decryption error signaling that the
+	// ciphertext is smaller than the ciphertext expansion factor.
+	ErrCiphertextTooSmall = errors.New(
+		"ciphertext is too small for chacha20poly1305",
+	)
+
+	// ErrSweepAddressToLong is returned when trying to encode or decode a
+	// sweep address with length greater than the maximum length of 42
+	// bytes, which supports p2wkh and p2sh addresses.
+	ErrSweepAddressToLong = fmt.Errorf(
+		"sweep address must be less than or equal to %d bytes long",
+		MaxSweepAddrSize,
+	)
+
+	// ErrNoCommitToRemoteOutput is returned when trying to spend the
+	// to-remote output of a justice kit that doesn't contain a valid
+	// to-remote public key.
+	ErrNoCommitToRemoteOutput = errors.New("justice kit has no " +
+		"commit to-remote output")
+)
+
+// PubKey is a 33-byte, serialized compressed public key.
+type PubKey [33]byte
+
+// JusticeKit is the plaintext of an encrypted blob uploaded to a watchtower.
+// It contains the information required to construct a justice transaction
+// that sweeps a remote party's revoked commitment transaction. It supports
+// encryption and decryption using chacha20poly1305, allowing the client to
+// encrypt the contents of the blob, and for a watchtower to later decrypt if
+// action must be taken. The encoding format is fixed-size, so that all blobs
+// uploaded to a tower are indistinguishable from one another.
+type JusticeKit struct {
+	// SweepAddress is the witness program of the output where the client's
+	// fund will be deposited. This value is included in the blobs, as
+	// opposed to the session info, such that the sweep addresses can't be
+	// correlated across sessions and/or towers.
+	//
+	// NOTE: This is chosen to be the length of a maximally sized witness
+	// program.
+	SweepAddress []byte
+
+	// RevocationPubKey is the compressed pubkey that guards the revocation
+	// clause of the remote party's to-local output.
+	RevocationPubKey PubKey
+
+	// LocalDelayPubKey is the compressed pubkey in the to-local script of
+	// the remote party, which guards the path where the remote party
+	// claims their commitment output.
+	LocalDelayPubKey PubKey
+
+	// CSVDelay is the relative timelock in the remote party's to-local
+	// output, which the remote party must wait out before sweeping their
+	// commitment output.
+	CSVDelay uint32
+
+	// CommitToLocalSig is a signature under RevocationPubKey using
+	// SIGHASH_ALL.
+	CommitToLocalSig lnwire.Sig
+
+	// CommitToRemotePubKey is the public key in the to-remote output of
+	// the revoked commitment transaction.
+	//
+	// NOTE: This value is only used if it contains a valid compressed
+	// public key.
+	CommitToRemotePubKey PubKey
+
+	// CommitToRemoteSig is a signature under CommitToRemotePubKey using
+	// SIGHASH_ALL.
+	//
+	// NOTE: This value is only used if CommitToRemotePubKey contains a
+	// valid compressed public key.
+	CommitToRemoteSig lnwire.Sig
+}
+
+// CommitToLocalWitnessScript returns the serialized witness script for the
+// commitment to-local output.
+func (b *JusticeKit) CommitToLocalWitnessScript() ([]byte, error) {
+	revocationPubKey, err := btcec.ParsePubKey(
+		b.RevocationPubKey[:], btcec.S256(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	localDelayedPubKey, err := btcec.ParsePubKey(
+		b.LocalDelayPubKey[:], btcec.S256(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return lnwallet.CommitScriptToSelf(
+		b.CSVDelay, localDelayedPubKey, revocationPubKey,
+	)
+}
+
+// CommitToLocalRevokeWitnessStack constructs a witness stack spending the
+// revocation clause of the commitment to-local output.
+//
+//	<revocation-sig> 1
+func (b *JusticeKit) CommitToLocalRevokeWitnessStack() ([][]byte, error) {
+	toLocalSig, err := b.CommitToLocalSig.ToSignature()
+	if err != nil {
+		return nil, err
+	}
+
+	witnessStack := make([][]byte, 2)
+	witnessStack[0] = append(toLocalSig.Serialize(),
+		byte(txscript.SigHashAll))
+	witnessStack[1] = []byte{1}
+
+	return witnessStack, nil
+}
+
+// HasCommitToRemoteOutput returns true if the blob contains a to-remote p2wkh
+// pubkey.
+func (b *JusticeKit) HasCommitToRemoteOutput() bool {
+	return isCompressedPubKey(b.CommitToRemotePubKey[:])
+}
+
+// CommitToRemoteWitnessScript returns the witness script for the commitment
+// to-remote p2wkh output, which is the pubkey itself.
+func (b *JusticeKit) CommitToRemoteWitnessScript() ([]byte, error) {
+	if !b.HasCommitToRemoteOutput() {
+		return nil, ErrNoCommitToRemoteOutput
+	}
+
+	return b.CommitToRemotePubKey[:], nil
+}
+
+// CommitToRemoteWitnessStack returns a witness stack spending the commitment
+// to-remote output, which is a regular p2wkh.
+//
+//	<to-remote-sig>
+func (b *JusticeKit) CommitToRemoteWitnessStack() ([][]byte, error) {
+	toRemoteSig, err := b.CommitToRemoteSig.ToSignature()
+	if err != nil {
+		return nil, err
+	}
+
+	witnessStack := make([][]byte, 1)
+	witnessStack[0] = append(toRemoteSig.Serialize(),
+		byte(txscript.SigHashAll))
+
+	return witnessStack, nil
+}
+
+// Encrypt encodes the blob of justice using the passed breach key, and
+// encrypts it using chacha20poly1305. A random nonce is prepended to the
+// resulting ciphertext:
+//
+//	nonce || ciphertext || MAC
+func (b *JusticeKit) Encrypt(key BreachKey) ([]byte, error) {
+	// Encode the justice kit to obtain the plaintext bytes.
+	var ptxtBuf bytes.Buffer
+	if err := b.encode(&ptxtBuf); err != nil {
+		return nil, err
+	}
+
+	// Create a new chacha20poly1305 cipher, using a 32-byte key.
+	cipher, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// Allocate the ciphertext, which will contain the nonce, encrypted
+	// plaintext and MAC.
+	plaintext := ptxtBuf.Bytes()
+	ciphertext := make([]byte, Size)
+
+	// Generate a random 12-byte nonce, and place it at the beginning of
+	// the ciphertext.
+	if _, err := rand.Read(ciphertext[:NonceSize]); err != nil {
+		return nil, err
+	}
+
+	// Finally, encrypt the plaintext using the given nonce, storing the
+	// result in the ciphertext buffer.
+	cipher.Seal(ciphertext[NonceSize:NonceSize], ciphertext[:NonceSize],
+		plaintext, nil)
+
+	return ciphertext, nil
+}
+
+// Decrypt unenciphers a blob of justice by decrypting the ciphertext using
+// chacha20poly1305 with the given breach key, and then decoding the
+// plaintext.
+func Decrypt(key BreachKey, ciphertext []byte) (*JusticeKit, error) {
+	// Fail if the blob's overall length is less than required for the
+	// nonce and expansion factor.
+	if len(ciphertext) < NonceSize+CiphertextExpansion {
+		return nil, ErrCiphertextTooSmall
+	}
+
+	// Create a new chacha20poly1305 cipher, using a 32-byte key.
+	cipher, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// Allocate the final buffer that will contain the blob's plaintext
+	// bytes, which is computed by subtracting the nonce and ciphertext
+	// expansion factor from the blob's length.
+	plaintext := make([]byte, len(ciphertext)-NonceSize-CiphertextExpansion)
+
+	// Decrypt the ciphertext, placing the resulting plaintext in our
+	// plaintext buffer.
+	nonce := ciphertext[:NonceSize]
+	_, err = cipher.Open(plaintext[:0], nonce, ciphertext[NonceSize:], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// If decryption succeeded, we will then decode the plaintext bytes
+	// using the fixed-size plaintext encoding.
+	kit := &JusticeKit{}
+	err = kit.decode(bytes.NewReader(plaintext))
+	if err != nil {
+		return nil, err
+	}
+
+	return kit, nil
+}
+
+// encode encodes the JusticeKit using the fixed-size plaintext encoding
+// described by PlaintextSize.
+func (b *JusticeKit) encode(w io.Writer) error {
+	// Assert the sweep address length is sane.
+	if len(b.SweepAddress) > MaxSweepAddrSize {
+		return ErrSweepAddressToLong
+	}
+
+	// Write the actual length of the sweep address as a single byte.
+	err := binary.Write(w, byteOrder, uint8(len(b.SweepAddress)))
+	if err != nil {
+		return err
+	}
+
+	// Pad the sweep address to our maximum length of 42 bytes.
+	var sweepAddressBuf [MaxSweepAddrSize]byte
+	copy(sweepAddressBuf[:], b.SweepAddress)
+
+	// Write padded 42-byte sweep address.
+	_, err = w.Write(sweepAddressBuf[:])
+	if err != nil {
+		return err
+	}
+
+	// Write 33-byte revocation public key.
+	_, err = w.Write(b.RevocationPubKey[:])
+	if err != nil {
+		return err
+	}
+
+	// Write 33-byte local delay public key.
+	_, err = w.Write(b.LocalDelayPubKey[:])
+	if err != nil {
+		return err
+	}
+
+	// Write 4-byte CSV delay.
+	err = binary.Write(w, byteOrder, b.CSVDelay)
+	if err != nil {
+		return err
+	}
+
+	// Write 64-byte revocation signature for commit to-local output.
+	_, err = w.Write(b.CommitToLocalSig[:])
+	if err != nil {
+		return err
+	}
+
+	// Write 33-byte commit to-remote public key, which may be blank.
+	_, err = w.Write(b.CommitToRemotePubKey[:])
+	if err != nil {
+		return err
+	}
+
+	// Write 64-byte commit to-remote signature, which may be blank.
+	_, err = w.Write(b.CommitToRemoteSig[:])
+
+	return err
+}
+
+// decode reconstructs a JusticeKit from the io.Reader, using the fixed-size
+// plaintext encoding described by PlaintextSize.
+func (b *JusticeKit) decode(r io.Reader) error {
+	// Read the sweep address length as a single byte.
+	var sweepAddrLen uint8
+	err := binary.Read(r, byteOrder, &sweepAddrLen)
+	if err != nil {
+		return err
+	}
+
+	// Assert the sweep address length is sane.
+	if sweepAddrLen > MaxSweepAddrSize {
+		return ErrSweepAddressToLong
+	}
+
+	// Read padded 42-byte sweep address.
+	var sweepAddressBuf [MaxSweepAddrSize]byte
+	_, err = io.ReadFull(r, sweepAddressBuf[:])
+	if err != nil {
+		return err
+	}
+
+	// Parse sweep address from padded buffer.
+	b.SweepAddress = make([]byte, sweepAddrLen)
+	copy(b.SweepAddress, sweepAddressBuf[:])
+
+	// Read 33-byte revocation public key.
+	_, err = io.ReadFull(r, b.RevocationPubKey[:])
+	if err != nil {
+		return err
+	}
+
+	// Read 33-byte local delay public key.
+	_, err = io.ReadFull(r, b.LocalDelayPubKey[:])
+	if err != nil {
+		return err
+	}
+
+	// Read 4-byte CSV delay.
+	err = binary.Read(r, byteOrder, &b.CSVDelay)
+	if err != nil {
+		return err
+	}
+
+	// Read 64-byte revocation signature for commit to-local output.
+	_, err = io.ReadFull(r, b.CommitToLocalSig[:])
+	if err != nil {
+		return err
+	}
+
+	var (
+		commitToRemotePubkey PubKey
+		commitToRemoteSig    lnwire.Sig
+	)
+
+	// Read 33-byte commit to-remote public key, which may be discarded.
+	_, err = io.ReadFull(r, commitToRemotePubkey[:])
+	if err != nil {
+		return err
+	}
+
+	// Read 64-byte commit to-remote signature, which may be discarded.
+	_, err = io.ReadFull(r, commitToRemoteSig[:])
+	if err != nil {
+		return err
+	}
+
+	// Only populate the commit to-remote fields in the decoded blob if a
+	// valid compressed public key was read from the reader.
+	if isCompressedPubKey(commitToRemotePubkey[:]) {
+		b.CommitToRemotePubKey = commitToRemotePubkey
+		b.CommitToRemoteSig = commitToRemoteSig
+	}
+
+	return nil
+}
+
+// isCompressedPubKey returns true if the passed bytes have the length and
+// prefix of a serialized compressed public key.
+func isCompressedPubKey(pubKey []byte) bool {
+	return len(pubKey) == 33 &&
+		(pubKey[0] == 0x02 || pubKey[0] == 0x03)
+}
@@ -0,0 +1,187 @@
+package blob
+
+import (
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+func makePubKey(t *testing.T) PubKey {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate private key: %v", err)
+	}
+
+	var pk PubKey
+	copy(pk[:], priv.PubKey().SerializeCompressed())
+	return pk
+}
+
+func makeSig(i int) lnwire.Sig {
+	var sig lnwire.Sig
+	if i == 0 {
+		return sig
+	}
+
+	for j := range sig {
+		sig[j] = byte(i + j)
+	}
+	return sig
+}
+
+func makeAddr(size int) []byte {
+	addr := make([]byte, size)
+	if _, err := rand.Read(addr); err != nil {
+		panic("unable to create addr")
+	}
+
+	return addr
+}
+
+func makeBreachKey(t *testing.T) BreachKey {
+	var txid chainhash.Hash
+	if _, err := rand.Read(txid[:]); err != nil {
+		t.Fatalf("unable to generate txid: %v", err)
+	}
+
+	return NewBreachKeyFromHash(&txid)
+}
+
+type justiceKitEncryptDecryptTest struct {
+	name                 string
+	sweepAddr            []byte
+	revPubKey            PubKey
+	delayPubKey          PubKey
+	csvDelay             uint32
+	commitToLocalSig     lnwire.Sig
+	commitToRemotePubKey PubKey
+	commitToRemoteSig    lnwire.Sig
+	encErr               error
+}
+
+// TestJusticeKitEncryptDecrypt asserts that a justice kit survives an
+// encryption round trip, and that invalid kits are rejected during encoding.
+func TestJusticeKitEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	tests := []justiceKitEncryptDecryptTest{
+		{
+			name:             "p2wkh sweep, no to-remote",
+			sweepAddr:        makeAddr(22),
+			revPubKey:        makePubKey(t),
+			delayPubKey:      makePubKey(t),
+			csvDelay:         144,
+			commitToLocalSig: makeSig(1),
+		},
+		{
+			name:                 "p2wsh sweep, with to-remote",
+			sweepAddr:            makeAddr(34),
+			revPubKey:            makePubKey(t),
+			delayPubKey:          makePubKey(t),
+			csvDelay:             144,
+			commitToLocalSig:     makeSig(1),
+			commitToRemotePubKey: makePubKey(t),
+			commitToRemoteSig:    makeSig(2),
+		},
+		{
+			name:             "max sweep addr",
+			sweepAddr:        makeAddr(MaxSweepAddrSize),
+			revPubKey:        makePubKey(t),
+			delayPubKey:      makePubKey(t),
+			csvDelay:         144,
+			commitToLocalSig: makeSig(1),
+		},
+		{
+			name:             "sweep addr too long",
+			sweepAddr:        makeAddr(MaxSweepAddrSize + 1),
+			revPubKey:        makePubKey(t),
+			delayPubKey:      makePubKey(t),
+			csvDelay:         144,
+			commitToLocalSig: makeSig(1),
+			encErr:           ErrSweepAddressToLong,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testJusticeKitEncryptDecrypt(t, test)
+		})
+	}
+}
+
+func testJusticeKitEncryptDecrypt(t *testing.T,
+	test justiceKitEncryptDecryptTest) {
+
+	kit := &JusticeKit{
+		SweepAddress:         test.sweepAddr,
+		RevocationPubKey:     test.revPubKey,
+		LocalDelayPubKey:     test.delayPubKey,
+		CSVDelay:             test.csvDelay,
+		CommitToLocalSig:     test.commitToLocalSig,
+		CommitToRemotePubKey: test.commitToRemotePubKey,
+		CommitToRemoteSig:    test.commitToRemoteSig,
+	}
+
+	key := makeBreachKey(t)
+
+	ciphertext, err := kit.Encrypt(key)
+	if err != test.encErr {
+		t.Fatalf("unable to encrypt justice kit, want: %v, got: %v",
+			test.encErr, err)
+	} else if err != nil {
+		return
+	}
+
+	// All encrypted blobs must have the same size, so that they are
+	// indistinguishable to the tower.
+	if len(ciphertext) != Size {
+		t.Fatalf("expected ciphertext of size %d, got %d", Size,
+			len(ciphertext))
+	}
+
+	kit2, err := Decrypt(key, ciphertext)
+	if err != nil {
+		t.Fatalf("unable to decrypt justice kit: %v", err)
+	}
+
+	if !reflect.DeepEqual(kit, kit2) {
+		t.Fatalf("decrypted justice kit doesn't match original, "+
+			"want: %v, got: %v", kit, kit2)
+	}
+
+	if kit2.HasCommitToRemoteOutput() !=
+		(test.commitToRemotePubKey != PubKey{}) {
+
+		t.Fatalf("unexpected to-remote output presence")
+	}
+
+	// Decrypting with a different key should fail.
+	if _, err := Decrypt(makeBreachKey(t), ciphertext); err == nil {
+		t.Fatalf("decryption with wrong key should fail")
+	}
+
+	// Flipping a byte in the ciphertext should also cause decryption to
+	// fail.
+	mutated := make([]byte, len(ciphertext))
+	copy(mutated, ciphertext)
+	mutated[NonceSize] ^= 0x01
+	if _, err := Decrypt(key, mutated); err == nil {
+		t.Fatalf("decryption of modified ciphertext should fail")
+	}
+}
+
+// TestDecryptCiphertextTooSmall asserts that a ciphertext that is too small to
+// contain the nonce and MAC is rejected.
+func TestDecryptCiphertextTooSmall(t *testing.T) {
+	t.Parallel()
+
+	ciphertext := make([]byte, NonceSize+CiphertextExpansion-1)
+	_, err := Decrypt(makeBreachKey(t), ciphertext)
+	if err != ErrCiphertextTooSmall {
+		t.Fatalf("expected ErrCiphertextTooSmall, got: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package watchtower
+
+import (
+	"time"
+)
+
+// Conf specifies the watchtower options that can be configured from the
+// command line or configuration file.
+type Conf struct {
+	Active bool `long:"active" description:"If the watchtower should be active or not"`
+
+	RawListeners []string `long:"listen" description:"Add interfaces/ports to listen for peer connections"`
+
+	ReadTimeout time.Duration `long:"readtimeout" description:"Duration the watchtower server will wait for messages to be received before hanging up on clients"`
+
+	WriteTimeout time.Duration `long:"writetimeout" description:"Duration the watchtower server will wait for messages to be written before hanging up on client connections"`
+}
+
+// Apply completes the passed Config struct by applying any parsed Conf
+// options. If the corresponding values parsed by Conf are already set in the
+// Config, those fields will be not be modified. The listen addresses are not
+// applied, since resolving them depends on lnd's networking configuration.
+func (c *Conf) Apply(cfg *Config) *Config {
+	// If no read timeout was provided, use the configured value, or fall
+	// back to the default.
+	if cfg.ReadTimeout == 0 {
+		cfg.ReadTimeout = DefaultReadTimeout
+		if c.ReadTimeout != 0 {
+			cfg.ReadTimeout = c.ReadTimeout
+		}
+	}
+
+	// Similarly, the write timeout is set to the configured value if
+	// present, otherwise the default.
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = DefaultWriteTimeout
+		if c.WriteTimeout != 0 {
+			cfg.WriteTimeout = c.WriteTimeout
+		}
+	}
+
+	return cfg
+}
@@ -0,0 +1,61 @@
+package watchtower
+
+import (
+	"net"
+	"time"
+
+	"github.com/lightningnetwork/lnd/watchtower/lookout"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// DefaultPeerPort is the default server port to which clients can
+	// connect to the watchtower.
+	DefaultPeerPort = 9911
+
+	// DefaultReadTimeout is the default timeout after which the tower will
+	// hang up on a client if nothing is received.
+	DefaultReadTimeout = 15 * time.Second
+
+	// DefaultWriteTimeout is the default timeout after which the tower will
+	// hang up on a client if it is unable to send a message.
+	DefaultWriteTimeout = 15 * time.Second
+)
+
+// Config defines the resources and parameters used to configure a
+// Standalone watchtower.
+type Config struct {
+	// BlockFetcher supports the ability to fetch blocks from the network by
+	// hash or height.
+	BlockFetcher lookout.BlockFetcher
+
+	// DB provides access to persistent storage of sessions and state
+	// updates uploaded by watchtower clients, and the ability to query for
+	// breach hints when receiving new blocks.
+	DB DB
+
+	// EpochRegistrar supports the ability to register for events
+	// corresponding to newly created blocks.
+	EpochRegistrar lookout.EpochRegistrar
+
+	// NodePrivKey is the private key to be used in accepting new brontide
+	// connections.
+	NodePrivKey *btcec.PrivateKey
+
+	// PublishTx provides the ability to send a signed transaction to the
+	// network.
+	PublishTx func(*wire.MsgTx) error
+
+	// ListenAddrs specifies which addresses to listen on.
+	ListenAddrs []net.Addr
+
+	// ReadTimeout specifies how long a client may go without sending a
+	// message.
+	ReadTimeout time.Duration
+
+	// WriteTimeout specifies how long a client may go without reading a
+	// message from the other end, if the connection has stopped buffering
+	// the server's replies.
+	WriteTimeout time.Duration
+}
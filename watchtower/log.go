@@ -0,0 +1,33 @@
+package watchtower
+
+import (
+	"github.com/btcsuite/btclog"
+	"github.com/lightningnetwork/lnd/watchtower/lookout"
+	"github.com/lightningnetwork/lnd/watchtower/wtserver"
+)
+
+// log is a logger that is initialized with no output filters.  This
+// means the package will not perform any logging by default until the caller
+// requests it.
+var log btclog.Logger
+
+// The default amount of logging is none.
+func init() {
+	DisableLog()
+}
+
+// DisableLog disables all library log output.  Logging output is disabled
+// by default until UseLogger is called.
+func DisableLog() {
+	UseLogger(btclog.Disabled)
+}
+
+// UseLogger uses a specified Logger to output package logging info.
+// This should be used in preference to SetLogWriter if the caller is also
+// using btclog. The logger is also passed down to the lookout and wtserver
+// subsystems.
+func UseLogger(logger btclog.Logger) {
+	log = logger
+	lookout.UseLogger(logger)
+	wtserver.UseLogger(logger)
+}
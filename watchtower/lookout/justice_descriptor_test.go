@@ -0,0 +1,282 @@
+package lookout
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/txsort"
+)
+
+const csvDelay uint32 = 144
+
+// breachFixture holds the keys and transactions required to exercise the
+// construction of justice transactions.
+type breachFixture struct {
+	revPriv      *btcec.PrivateKey
+	toLocalPriv  *btcec.PrivateKey
+	toRemotePriv *btcec.PrivateKey
+
+	toLocalScript []byte
+
+	breachTxn *wire.MsgTx
+}
+
+func newPrivKey(t *testing.T) *btcec.PrivateKey {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate private key: %v", err)
+	}
+	return priv
+}
+
+// newBreachFixture creates a breached commitment transaction containing a
+// to-local and, optionally, a to-remote output.
+func newBreachFixture(t *testing.T, withToRemote bool) *breachFixture {
+	f := &breachFixture{
+		revPriv:      newPrivKey(t),
+		toLocalPriv:  newPrivKey(t),
+		toRemotePriv: newPrivKey(t),
+	}
+
+	var err error
+	f.toLocalScript, err = lnwallet.CommitScriptToSelf(
+		csvDelay, f.toLocalPriv.PubKey(), f.revPriv.PubKey(),
+	)
+	if err != nil {
+		t.Fatalf("unable to create to-local script: %v", err)
+	}
+	toLocalPkScript, err := lnwallet.WitnessScriptHash(f.toLocalScript)
+	if err != nil {
+		t.Fatalf("unable to create to-local pkscript: %v", err)
+	}
+
+	f.breachTxn = wire.NewMsgTx(2)
+	f.breachTxn.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash: chainhash.Hash{0x01},
+		},
+	})
+	f.breachTxn.AddTxOut(&wire.TxOut{
+		PkScript: toLocalPkScript,
+		Value:    100000000,
+	})
+
+	if withToRemote {
+		toRemotePkScript, err := lnwallet.CommitScriptUnencumbered(
+			f.toRemotePriv.PubKey(),
+		)
+		if err != nil {
+			t.Fatalf("unable to create to-remote pkscript: %v", err)
+		}
+		f.breachTxn.AddTxOut(&wire.TxOut{
+			PkScript: toRemotePkScript,
+			Value:    50000000,
+		})
+	}
+
+	return f
+}
+
+// signJusticeKit constructs the justice transaction in the same manner as a
+// client would, and populates the justice kit with signatures for each of the
+// breached outputs.
+func signJusticeKit(t *testing.T, f *breachFixture, policy wtpolicy.Policy,
+	kit *blob.JusticeKit) *wire.MsgTx {
+
+	breachTxID := f.breachTxn.TxHash()
+
+	var (
+		weightEstimate lnwallet.TxWeightEstimator
+		totalAmt       btcutil.Amount
+	)
+
+	justiceTxn := wire.NewMsgTx(2)
+	justiceTxn.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: breachTxID, Index: 0},
+	})
+	totalAmt += btcutil.Amount(f.breachTxn.TxOut[0].Value)
+	weightEstimate.AddWitnessInput(lnwallet.ToLocalPenaltyWitnessSize)
+
+	hasToRemote := len(f.breachTxn.TxOut) > 1
+	if hasToRemote {
+		justiceTxn.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{
+				Hash: breachTxID, Index: 1,
+			},
+		})
+		totalAmt += btcutil.Amount(f.breachTxn.TxOut[1].Value)
+		weightEstimate.AddWitnessInput(lnwallet.P2WKHWitnessSize)
+	}
+
+	err := AddSweepOutputWeight(&weightEstimate, kit.SweepAddress)
+	if err != nil {
+		t.Fatalf("unable to add sweep output weight: %v", err)
+	}
+
+	txOuts, err := policy.ComputeJusticeTxOuts(
+		totalAmt, int64(weightEstimate.Weight()), kit.SweepAddress,
+	)
+	if err != nil {
+		t.Fatalf("unable to compute justice tx outs: %v", err)
+	}
+	justiceTxn.TxOut = txOuts
+
+	txsort.InPlaceSort(justiceTxn)
+
+	sign := func(outIndex uint32, script []byte,
+		priv *btcec.PrivateKey) lnwire.Sig {
+
+		var inputIndex int
+		for i, txIn := range justiceTxn.TxIn {
+			if txIn.PreviousOutPoint.Index == outIndex {
+				inputIndex = i
+			}
+		}
+
+		hashCache := txscript.NewTxSigHashes(justiceTxn)
+		sigHash, err := txscript.CalcWitnessSigHash(
+			script, hashCache, txscript.SigHashAll, justiceTxn,
+			inputIndex, f.breachTxn.TxOut[outIndex].Value,
+		)
+		if err != nil {
+			t.Fatalf("unable to compute sighash: %v", err)
+		}
+
+		sig, err := priv.Sign(sigHash)
+		if err != nil {
+			t.Fatalf("unable to sign justice txn: %v", err)
+		}
+
+		wireSig, err := lnwire.NewSigFromSignature(sig)
+		if err != nil {
+			t.Fatalf("unable to convert signature: %v", err)
+		}
+
+		return wireSig
+	}
+
+	kit.CommitToLocalSig = sign(0, f.toLocalScript, f.revPriv)
+
+	if hasToRemote {
+		kit.CommitToRemoteSig = sign(
+			1, f.breachTxn.TxOut[1].PkScript, f.toRemotePriv,
+		)
+	}
+
+	return justiceTxn
+}
+
+// TestJusticeDescriptor asserts that the justice transaction assembled by the
+// tower from a justice kit matches the one signed by the client, and that the
+// resulting witnesses are valid.
+func TestJusticeDescriptor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		withToRemote bool
+		sweepAddr    []byte
+	}{
+		{
+			name:      "to-local only, p2wkh sweep",
+			sweepAddr: make([]byte, lnwallet.P2WPKHSize),
+		},
+		{
+			name:         "to-local and to-remote, p2wkh sweep",
+			withToRemote: true,
+			sweepAddr:    make([]byte, lnwallet.P2WPKHSize),
+		},
+		{
+			name:         "to-local and to-remote, p2wsh sweep",
+			withToRemote: true,
+			sweepAddr:    make([]byte, lnwallet.P2WSHSize),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			testJusticeDescriptor(
+				t, test.withToRemote, test.sweepAddr,
+			)
+		})
+	}
+}
+
+func testJusticeDescriptor(t *testing.T, withToRemote bool,
+	sweepAddr []byte) {
+
+	f := newBreachFixture(t, withToRemote)
+	policy := wtpolicy.DefaultPolicy()
+
+	// The sweep addresses are zero-filled witness programs of the
+	// appropriate size, so we'll set the witness version and push length.
+	sweepAddr[0] = txscript.OP_0
+	sweepAddr[1] = byte(len(sweepAddr) - 2)
+
+	kit := &blob.JusticeKit{
+		SweepAddress: sweepAddr,
+		CSVDelay:     csvDelay,
+	}
+	copy(kit.RevocationPubKey[:], f.revPriv.PubKey().SerializeCompressed())
+	copy(
+		kit.LocalDelayPubKey[:],
+		f.toLocalPriv.PubKey().SerializeCompressed(),
+	)
+	if withToRemote {
+		copy(
+			kit.CommitToRemotePubKey[:],
+			f.toRemotePriv.PubKey().SerializeCompressed(),
+		)
+	}
+
+	expJusticeTxn := signJusticeKit(t, f, policy, kit)
+
+	desc := &JusticeDescriptor{
+		BreachedCommitTx: f.breachTxn,
+		SessionInfo: &wtdb.SessionInfo{
+			Policy: policy,
+		},
+		JusticeKit: kit,
+	}
+
+	// Constructing the justice transaction will also validate the
+	// witnesses against the breached outputs.
+	justiceTxn, err := desc.CreateJusticeTxn()
+	if err != nil {
+		t.Fatalf("unable to create justice txn: %v", err)
+	}
+
+	if justiceTxn.TxHash() != expJusticeTxn.TxHash() {
+		t.Fatalf("justice txn mismatch, want: %v, got: %v",
+			expJusticeTxn.TxHash(), justiceTxn.TxHash())
+	}
+
+	expInputs := 1
+	if withToRemote {
+		expInputs = 2
+	}
+	if len(justiceTxn.TxIn) != expInputs {
+		t.Fatalf("expected %d inputs, got %d", expInputs,
+			len(justiceTxn.TxIn))
+	}
+
+	// Finally, corrupting the to-local signature should cause the
+	// descriptor to reject the justice transaction.
+	kit.CommitToLocalSig = kit.CommitToRemoteSig
+	if !withToRemote {
+		kit.CommitToLocalSig[10] ^= 0x01
+	}
+	if _, err := desc.CreateJusticeTxn(); err == nil {
+		t.Fatalf("justice txn with invalid signature should fail")
+	}
+}
@@ -0,0 +1,330 @@
+package lookout
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// maxCatchUpBlocks is the maximum number of blocks the lookout will process
+// when catching up to the chain tip after being offline. Any blocks beyond
+// this limit are skipped.
+const maxCatchUpBlocks = 2016
+
+// Config houses the Lookout's required resources to properly fulfill its duty,
+// including block fetching, querying accepted state updates, and construction
+// and publication of justice transactions.
+type Config struct {
+	// DB provides persistent access to the watchtower's accepted state
+	// updates such that they can be queried as new blocks arrive from the
+	// network.
+	DB DB
+
+	// EpochRegistrar supports the ability to register for events
+	// corresponding to newly created blocks.
+	EpochRegistrar EpochRegistrar
+
+	// BlockFetcher supports the ability to fetch blocks from the backend or
+	// network.
+	BlockFetcher BlockFetcher
+
+	// Punisher handles the responsibility of crafting and broadcasting
+	// justice transactions for any breached transactions.
+	Punisher Punisher
+}
+
+// Lookout will check any incoming blocks against the transactions found in the
+// database, and in case of matches will construct and publish a justice
+// transaction that penalizes the attacker on behalf of the victim.
+type Lookout struct {
+	started int32 // atomic
+	stopped int32 // atomic
+
+	cfg *Config
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// A compile-time check to ensure Lookout implements the Service interface.
+var _ Service = (*Lookout)(nil)
+
+// New constructs a new Lookout from the given Config.
+func New(cfg *Config) *Lookout {
+	return &Lookout{
+		cfg:  cfg,
+		quit: make(chan struct{}),
+	}
+}
+
+// Start safely spins up the Lookout and begins monitoring for breaches.
+func (l *Lookout) Start() error {
+	if !atomic.CompareAndSwapInt32(&l.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Starting lookout")
+
+	startEpoch, err := l.cfg.DB.GetLookoutTip()
+	if err != nil {
+		return err
+	}
+
+	if startEpoch == nil {
+		log.Infof("Starting lookout from chain tip")
+	} else {
+		log.Infof("Starting lookout from epoch(height=%d hash=%v)",
+			startEpoch.Height, startEpoch.Hash)
+	}
+
+	events, err := l.cfg.EpochRegistrar.RegisterBlockEpochNtfn()
+	if err != nil {
+		log.Errorf("Unable to register for block epochs: %v", err)
+		return err
+	}
+
+	l.wg.Add(1)
+	go l.watchBlocks(startEpoch, events)
+
+	log.Infof("Lookout started successfully")
+
+	return nil
+}
+
+// Stop safely shuts down the Lookout.
+func (l *Lookout) Stop() error {
+	if !atomic.CompareAndSwapInt32(&l.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Stopping lookout")
+
+	close(l.quit)
+	l.wg.Wait()
+
+	log.Infof("Lookout stopped successfully")
+
+	return nil
+}
+
+// watchBlocks serially pulls incoming epochs from the epoch source and
+// searches our accepted state updates for any breached transactions. If any
+// are found, we will attempt to decrypt the state updates' encrypted blobs
+// and exact justice for the victim.
+//
+// This method MUST be run as a goroutine.
+func (l *Lookout) watchBlocks(tip *chainntnfs.BlockEpoch,
+	epochs *chainntnfs.BlockEpochEvent) {
+
+	defer l.wg.Done()
+	defer epochs.Cancel()
+
+	for {
+		select {
+		case epoch, ok := <-epochs.Epochs:
+			if !ok {
+				return
+			}
+
+			// If we've been offline for some time, catch up on
+			// any blocks that were connected between our last
+			// processed tip and the new epoch.
+			l.catchUp(tip, epoch)
+
+			log.Debugf("Fetching block for (height=%d, hash=%v)",
+				epoch.Height, epoch.Hash)
+
+			// Fetch the full block from the backend corresponding
+			// to the newly arriving epoch.
+			block, err := l.cfg.BlockFetcher.GetBlock(epoch.Hash)
+			if err != nil {
+				log.Errorf("Unable to fetch block for "+
+					"(height=%d, hash=%v): %v",
+					epoch.Height, epoch.Hash, err)
+				continue
+			}
+
+			// Process the block to see if it contains any breaches
+			// that we are monitoring on behalf of our clients.
+			err = l.processEpoch(epoch, block)
+			if err != nil {
+				log.Errorf("Unable to process (height=%d, "+
+					"hash=%v): %v", epoch.Height,
+					epoch.Hash, err)
+				continue
+			}
+
+			tip = epoch
+
+		case <-l.quit:
+			return
+		}
+	}
+}
+
+// catchUp processes all blocks between the lookout's last processed tip and
+// the newly arrived epoch, exclusive. This ensures that breaches confirmed
+// while the tower was offline are still acted upon.
+func (l *Lookout) catchUp(tip, epoch *chainntnfs.BlockEpoch) {
+	if tip == nil || tip.Height >= epoch.Height-1 {
+		return
+	}
+
+	startHeight := tip.Height + 1
+	if epoch.Height-startHeight > maxCatchUpBlocks {
+		startHeight = epoch.Height - maxCatchUpBlocks
+	}
+
+	log.Infof("Catching up from height=%d to height=%d", startHeight,
+		epoch.Height-1)
+
+	for height := startHeight; height < epoch.Height; height++ {
+		select {
+		case <-l.quit:
+			return
+		default:
+		}
+
+		hash, err := l.cfg.BlockFetcher.GetBlockHash(int64(height))
+		if err != nil {
+			log.Errorf("Unable to fetch block hash for height=%d: "+
+				"%v", height, err)
+			continue
+		}
+
+		block, err := l.cfg.BlockFetcher.GetBlock(hash)
+		if err != nil {
+			log.Errorf("Unable to fetch block for (height=%d, "+
+				"hash=%v): %v", height, hash, err)
+			continue
+		}
+
+		missedEpoch := &chainntnfs.BlockEpoch{
+			Hash:   hash,
+			Height: height,
+		}
+		if err := l.processEpoch(missedEpoch, block); err != nil {
+			log.Errorf("Unable to process (height=%d, hash=%v): "+
+				"%v", height, hash, err)
+		}
+	}
+}
+
+// processEpoch accepts an Epoch and queries the database for any matching
+// state updates for the confirmed transactions. If any are found, the lookout
+// responds by attempting to decrypt the encrypted blob and publishing the
+// justice transaction.
+func (l *Lookout) processEpoch(epoch *chainntnfs.BlockEpoch,
+	block *wire.MsgBlock) error {
+
+	numTxnsInBlock := len(block.Transactions)
+
+	log.Debugf("Scanning %d transactions in block (height=%d, hash=%v) "+
+		"for breaches", numTxnsInBlock, epoch.Height, epoch.Hash)
+
+	// Iterate over the transactions contained in the block, deriving a
+	// breach hint for each transaction and constructing an index mapping
+	// the hint back to its original transaction.
+	hintToTx := make(map[blob.BreachHint]*wire.MsgTx, numTxnsInBlock)
+	txHints := make([]blob.BreachHint, 0, numTxnsInBlock)
+	for _, tx := range block.Transactions {
+		hash := tx.TxHash()
+		hint := blob.NewBreachHintFromHash(&hash)
+
+		txHints = append(txHints, hint)
+		hintToTx[hint] = tx
+	}
+
+	// Query the database to see if any of the breach hints cause a match
+	// with any of our open sessions.
+	matches, err := l.cfg.DB.QueryMatches(txHints)
+	switch {
+	case err != nil:
+		return err
+	case len(matches) == 0:
+		log.Debugf("No breaches found in (height=%d, hash=%v)",
+			epoch.Height, epoch.Hash)
+		return l.cfg.DB.SetLookoutTip(epoch)
+	}
+
+	log.Infof("Found %d breach(es) in (height=%d, hash=%v)",
+		len(matches), epoch.Height, epoch.Hash)
+
+	// For each match, use our index to retrieve the original transaction,
+	// which corresponds to the breaching commitment transaction. If the
+	// decryption succeeds, we will accumulate the assembled justice
+	// descriptors in a single slice.
+	var successes []*JusticeDescriptor
+	for _, match := range matches {
+		commitTx := hintToTx[match.Hint]
+		log.Infof("Dispatching punisher for client %s, breach-txid=%s",
+			match.ID, commitTx.TxHash().String())
+
+		// The decryption key for the state update should be the full
+		// txid of the breaching commitment transaction.
+		commitTxID := commitTx.TxHash()
+		key := blob.NewBreachKeyFromHash(&commitTxID)
+
+		// Now, decrypt the blob of justice that we received in the
+		// state update. This will contain all information required to
+		// sweep the breached commitment outputs.
+		justiceKit, err := blob.Decrypt(key, match.EncryptedBlob)
+		if err != nil {
+			// If the decryption fails, this implies either that
+			// the client sent an invalid blob, or that the breach
+			// hint caused a match on the txid, but this isn't
+			// actually the right transaction.
+			log.Debugf("Unable to decrypt blob for client %s, "+
+				"breach-txid %s: %v", match.ID,
+				commitTx.TxHash().String(), err)
+			continue
+		}
+
+		justiceDesc := &JusticeDescriptor{
+			BreachedCommitTx: commitTx,
+			SessionInfo:      match.SessionInfo,
+			JusticeKit:       justiceKit,
+		}
+		successes = append(successes, justiceDesc)
+	}
+
+	// Now, we'll dispatch a punishment for each successful match in
+	// parallel. This will assemble the justice transaction for each and
+	// watch for their confirmation on chain.
+	for _, justiceDesc := range successes {
+		l.wg.Add(1)
+		go l.dispatchPunisher(justiceDesc)
+	}
+
+	return l.cfg.DB.SetLookoutTip(epoch)
+}
+
+// dispatchPunisher accepts a justice descriptor corresponding to a successfully
+// decrypted blob.  The punisher will then construct the witness scripts and
+// witness stacks for the breached outputs. If construction of the justice
+// transaction is successful, it will be published to the network to retrieve
+// the funds and claim the watchtower's reward.
+//
+// This method MUST be run as a goroutine.
+func (l *Lookout) dispatchPunisher(desc *JusticeDescriptor) {
+	defer l.wg.Done()
+
+	// Give the justice descriptor to the punisher to construct and publish
+	// the justice transaction. The lookout's quit channel is provided so
+	// that long-running tasks that watch for on-chain events can be
+	// canceled during shutdown since this method is waitgrouped.
+	err := l.cfg.Punisher.Punish(desc, l.quit)
+	if err != nil {
+		log.Errorf("Unable to punish breach-txid %s for %s: %v",
+			desc.BreachedCommitTx.TxHash().String(),
+			desc.SessionInfo.ID, err)
+		return
+	}
+
+	log.Infof("Punishment for client %s with breach-txid=%s dispatched",
+		desc.SessionInfo.ID, desc.BreachedCommitTx.TxHash().String())
+}
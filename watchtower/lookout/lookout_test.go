@@ -0,0 +1,211 @@
+package lookout
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockEpochRegistrar hands out a single block epoch subscription, allowing
+// the test to deliver epochs directly to the lookout.
+type mockEpochRegistrar struct {
+	epochs chan *chainntnfs.BlockEpoch
+}
+
+func (m *mockEpochRegistrar) RegisterBlockEpochNtfn() (
+	*chainntnfs.BlockEpochEvent, error) {
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: m.epochs,
+		Cancel: func() {},
+	}, nil
+}
+
+// mockBlockFetcher serves blocks from an in-memory index.
+type mockBlockFetcher struct {
+	blocks map[chainhash.Hash]*wire.MsgBlock
+}
+
+func (m *mockBlockFetcher) GetBlock(
+	hash *chainhash.Hash) (*wire.MsgBlock, error) {
+
+	block, ok := m.blocks[*hash]
+	if !ok {
+		return nil, fmt.Errorf("block %v not found", hash)
+	}
+
+	return block, nil
+}
+
+func (m *mockBlockFetcher) GetBlockHash(int64) (*chainhash.Hash, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// mockPunisher forwards all justice descriptors to the test.
+type mockPunisher struct {
+	matches chan *JusticeDescriptor
+}
+
+func (p *mockPunisher) Punish(desc *JusticeDescriptor,
+	quit <-chan struct{}) error {
+
+	p.matches <- desc
+	return nil
+}
+
+// TestLookoutBreachMatching asserts that the lookout decrypts the state
+// updates matching transactions in a new block, and dispatches the punisher
+// for each of them.
+func TestLookoutBreachMatching(t *testing.T) {
+	t.Parallel()
+
+	path, err := ioutil.TempDir("", "lookout")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	db, err := wtdb.OpenTowerDB(path)
+	if err != nil {
+		t.Fatalf("unable to open tower db: %v", err)
+	}
+	defer db.Close()
+
+	epochs := make(chan *chainntnfs.BlockEpoch)
+	blockFetcher := &mockBlockFetcher{
+		blocks: make(map[chainhash.Hash]*wire.MsgBlock),
+	}
+	punisher := &mockPunisher{
+		matches: make(chan *JusticeDescriptor),
+	}
+
+	watcher := New(&Config{
+		DB:             db,
+		EpochRegistrar: &mockEpochRegistrar{epochs: epochs},
+		BlockFetcher:   blockFetcher,
+		Punisher:       punisher,
+	})
+	if err := watcher.Start(); err != nil {
+		t.Fatalf("unable to start lookout: %v", err)
+	}
+	defer watcher.Stop()
+
+	// Create a session for our client.
+	var id wtdb.SessionID
+	id[0] = 0x02
+	session := &wtdb.SessionInfo{
+		ID:     id,
+		Policy: wtpolicy.DefaultPolicy(),
+	}
+	if err := db.InsertSessionInfo(session); err != nil {
+		t.Fatalf("unable to insert session: %v", err)
+	}
+
+	// Create two transactions, only the first of which will have a state
+	// update uploaded for it.
+	breachTxn := wire.NewMsgTx(2)
+	breachTxn.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}},
+	})
+	otherTxn := wire.NewMsgTx(2)
+	otherTxn.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x02}},
+	})
+
+	breachTxID := breachTxn.TxHash()
+	hint, key := blob.NewBreachHintAndKeyFromHash(&breachTxID)
+
+	kit := &blob.JusticeKit{
+		SweepAddress: bytes.Repeat([]byte{0x01}, 22),
+		CSVDelay:     144,
+	}
+	encBlob, err := kit.Encrypt(key)
+	if err != nil {
+		t.Fatalf("unable to encrypt justice kit: %v", err)
+	}
+
+	_, err = db.InsertStateUpdate(&wtdb.SessionStateUpdate{
+		ID:            id,
+		SeqNum:        1,
+		Hint:          hint,
+		EncryptedBlob: encBlob,
+	})
+	if err != nil {
+		t.Fatalf("unable to insert state update: %v", err)
+	}
+
+	// Deliver a block that doesn't contain the breach, no punishment
+	// should be dispatched.
+	block1 := &wire.MsgBlock{
+		Header:       wire.BlockHeader{Nonce: 1},
+		Transactions: []*wire.MsgTx{otherTxn},
+	}
+	sendBlock(t, epochs, blockFetcher, block1, 1)
+
+	select {
+	case <-punisher.matches:
+		t.Fatalf("punisher dispatched for block without breach")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Now, deliver a block containing the breach, which should result in
+	// the punisher being dispatched with the decrypted justice kit.
+	block2 := &wire.MsgBlock{
+		Header:       wire.BlockHeader{Nonce: 2},
+		Transactions: []*wire.MsgTx{otherTxn, breachTxn},
+	}
+	sendBlock(t, epochs, blockFetcher, block2, 2)
+
+	select {
+	case desc := <-punisher.matches:
+		if desc.BreachedCommitTx.TxHash() != breachTxID {
+			t.Fatalf("punisher dispatched for wrong txn")
+		}
+		if !bytes.Equal(desc.JusticeKit.SweepAddress,
+			kit.SweepAddress) {
+
+			t.Fatalf("justice kit mismatch")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("punisher not dispatched for breach")
+	}
+
+	// The lookout tip should eventually reflect the last processed block.
+	var tip *chainntnfs.BlockEpoch
+	for i := 0; i < 50; i++ {
+		tip, err = db.GetLookoutTip()
+		if err != nil {
+			t.Fatalf("unable to fetch lookout tip: %v", err)
+		}
+		if tip != nil && tip.Height == 2 {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Fatalf("expected lookout tip at height 2, got: %v", tip)
+}
+
+func sendBlock(t *testing.T, epochs chan *chainntnfs.BlockEpoch,
+	blockFetcher *mockBlockFetcher, block *wire.MsgBlock, height int32) {
+
+	hash := block.BlockHash()
+	blockFetcher.blocks[hash] = block
+
+	select {
+	case epochs <- &chainntnfs.BlockEpoch{Hash: &hash, Height: height}:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("unable to deliver epoch")
+	}
+}
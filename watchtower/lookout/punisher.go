@@ -0,0 +1,53 @@
+package lookout
+
+import (
+	"github.com/roasbeef/btcd/wire"
+)
+
+// PunisherConfig houses the resources required by the BreachPunisher.
+type PunisherConfig struct {
+	// PublishTx provides the ability to send a signed transaction to the
+	// network.
+	PublishTx func(*wire.MsgTx) error
+}
+
+// BreachPunisher handles the responsibility of constructing and broadcasting
+// justice transactions. Justice transactions are constructed from previously
+// accepted state updates uploaded by the watchtower's clients.
+type BreachPunisher struct {
+	cfg *PunisherConfig
+}
+
+// NewBreachPunisher constructs a new BreachPunisher given a PunisherConfig.
+func NewBreachPunisher(cfg *PunisherConfig) *BreachPunisher {
+	return &BreachPunisher{
+		cfg: cfg,
+	}
+}
+
+// Punish constructs a justice transaction given a JusticeDescriptor and
+// publishes it to the network.
+func (p *BreachPunisher) Punish(desc *JusticeDescriptor,
+	quit <-chan struct{}) error {
+
+	justiceTxn, err := desc.CreateJusticeTxn()
+	if err != nil {
+		log.Errorf("Unable to create justice txn for client=%s "+
+			"with breach-txid=%v: %v", desc.SessionInfo.ID,
+			desc.BreachedCommitTx.TxHash(), err)
+		return err
+	}
+
+	log.Infof("Publishing justice transaction for client=%s with txid=%v",
+		desc.SessionInfo.ID, justiceTxn.TxHash())
+
+	err = p.cfg.PublishTx(justiceTxn)
+	if err != nil {
+		log.Errorf("Unable to publish justice txn for client=%s "+
+			"with breach-txid=%v: %v", desc.SessionInfo.ID,
+			desc.BreachedCommitTx.TxHash(), err)
+		return err
+	}
+
+	return nil
+}
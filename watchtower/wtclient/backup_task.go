@@ -0,0 +1,209 @@
+package wtclient
+
+import (
+	"errors"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/lookout"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/txsort"
+)
+
+var (
+	// ErrNoToLocalOutput signals that the revoked commitment transaction
+	// doesn't contain a to-local output for the remote party, meaning there
+	// is nothing for a tower to punish.
+	ErrNoToLocalOutput = errors.New("revoked commitment has no to-local " +
+		"output")
+)
+
+// backupTask is an internal struct for computing the justice transaction for a
+// particular revoked state. Upon creation, the task determines which outputs of
+// the revoked commitment will be swept, and the total amount they contain. Once
+// the session the update will be sent under is known, the task is used to sign
+// the justice transaction according to the session's policy and produce the
+// encrypted justice kit uploaded to the tower.
+type backupTask struct {
+	chanID        lnwire.ChannelID
+	breachInfo    *lnwallet.BreachRetribution
+	sweepPkScript []byte
+
+	// toLocalInput and toRemoteInput describe the breached outputs that
+	// will be swept by the justice transaction. toRemoteInput is nil if
+	// our output on the revoked commitment is dust.
+	toLocalInput  *breachedInput
+	toRemoteInput *breachedInput
+
+	totalAmt btcutil.Amount
+}
+
+// breachedInput describes a single output of the revoked commitment that the
+// justice transaction will spend.
+type breachedInput struct {
+	outPoint wire.OutPoint
+	signDesc *lnwallet.SignDescriptor
+}
+
+// newBackupTask initializes a new backupTask for the revoked state described
+// by breachInfo. An error is returned if the revoked state doesn't contain an
+// output that the tower is able to sweep.
+func newBackupTask(chanID *lnwire.ChannelID,
+	breachInfo *lnwallet.BreachRetribution,
+	sweepPkScript []byte) (*backupTask, error) {
+
+	// If the remote party doesn't have an output on the revoked
+	// commitment, then there is nothing for the tower to punish.
+	if breachInfo.RemoteOutputSignDesc == nil {
+		return nil, ErrNoToLocalOutput
+	}
+
+	task := &backupTask{
+		chanID:        *chanID,
+		breachInfo:    breachInfo,
+		sweepPkScript: sweepPkScript,
+		toLocalInput: &breachedInput{
+			outPoint: breachInfo.RemoteOutpoint,
+			signDesc: breachInfo.RemoteOutputSignDesc,
+		},
+	}
+	task.totalAmt = btcutil.Amount(
+		breachInfo.RemoteOutputSignDesc.Output.Value,
+	)
+
+	// Our own output on the revoked commitment may be dust, in which case
+	// it is omitted from the justice transaction.
+	if breachInfo.LocalOutputSignDesc != nil {
+		task.toRemoteInput = &breachedInput{
+			outPoint: breachInfo.LocalOutpoint,
+			signDesc: breachInfo.LocalOutputSignDesc,
+		}
+		task.totalAmt += btcutil.Amount(
+			breachInfo.LocalOutputSignDesc.Output.Value,
+		)
+	}
+
+	return task, nil
+}
+
+// craftSessionPayload constructs and signs the justice transaction for the
+// revoked state using the given session policy, and returns the breach hint
+// and encrypted justice kit to be uploaded to the tower. The justice
+// transaction is constructed in the same manner as the tower's
+// lookout.JusticeDescriptor, ensuring that the signatures are valid for the
+// transaction assembled by the tower.
+func (t *backupTask) craftSessionPayload(signer lnwallet.Signer,
+	policy wtpolicy.Policy) (blob.BreachHint, []byte, error) {
+
+	var hint blob.BreachHint
+
+	keyRing := t.breachInfo.KeyRing
+
+	// First, populate the justice kit with the public keys and scripts
+	// required by the tower to reconstruct the witnesses.
+	justiceKit := &blob.JusticeKit{
+		SweepAddress: t.sweepPkScript,
+		CSVDelay:     t.breachInfo.RemoteDelay,
+	}
+	copy(
+		justiceKit.RevocationPubKey[:],
+		keyRing.RevocationKey.SerializeCompressed(),
+	)
+	copy(
+		justiceKit.LocalDelayPubKey[:],
+		keyRing.DelayKey.SerializeCompressed(),
+	)
+	if t.toRemoteInput != nil {
+		copy(
+			justiceKit.CommitToRemotePubKey[:],
+			keyRing.NoDelayKey.SerializeCompressed(),
+		)
+	}
+
+	// Next, compute the weight of the justice transaction, which will be
+	// used to determine the fee paid at the session's sweep fee rate.
+	var weightEstimate lnwallet.TxWeightEstimator
+	err := lookout.AddSweepOutputWeight(&weightEstimate, t.sweepPkScript)
+	if err != nil {
+		return hint, nil, err
+	}
+
+	inputs := []*breachedInput{t.toLocalInput}
+	weightEstimate.AddWitnessInput(lnwallet.ToLocalPenaltyWitnessSize)
+	if t.toRemoteInput != nil {
+		inputs = append(inputs, t.toRemoteInput)
+		weightEstimate.AddWitnessInput(lnwallet.P2WKHWitnessSize)
+	}
+
+	// Construct the justice transaction, which spends all of the breached
+	// inputs into a single output paying to our sweep pkscript.
+	justiceTxn := wire.NewMsgTx(2)
+	for _, input := range inputs {
+		justiceTxn.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: input.outPoint,
+		})
+	}
+
+	txOuts, err := policy.ComputeJusticeTxOuts(
+		t.totalAmt, int64(weightEstimate.Weight()), t.sweepPkScript,
+	)
+	if err != nil {
+		return hint, nil, err
+	}
+	justiceTxn.TxOut = txOuts
+
+	// Apply a BIP69 sort to the transaction, which is also done by the
+	// tower, and map each outpoint to its post-sort index.
+	txsort.InPlaceSort(justiceTxn)
+
+	inputIndex := make(map[wire.OutPoint]int)
+	for i, txIn := range justiceTxn.TxIn {
+		inputIndex[txIn.PreviousOutPoint] = i
+	}
+
+	hashCache := txscript.NewTxSigHashes(justiceTxn)
+
+	// Sign each of the breached inputs using SIGHASH_ALL, committing to
+	// the final output value of the justice transaction.
+	sign := func(input *breachedInput) (lnwire.Sig, error) {
+		signDesc := *input.signDesc
+		signDesc.HashType = txscript.SigHashAll
+		signDesc.SigHashes = hashCache
+		signDesc.InputIndex = inputIndex[input.outPoint]
+
+		rawSig, err := signer.SignOutputRaw(justiceTxn, &signDesc)
+		if err != nil {
+			return lnwire.Sig{}, err
+		}
+
+		return lnwire.NewSigFromRawSignature(rawSig)
+	}
+
+	justiceKit.CommitToLocalSig, err = sign(t.toLocalInput)
+	if err != nil {
+		return hint, nil, err
+	}
+
+	if t.toRemoteInput != nil {
+		justiceKit.CommitToRemoteSig, err = sign(t.toRemoteInput)
+		if err != nil {
+			return hint, nil, err
+		}
+	}
+
+	// Finally, compute the breach hint and key from the txid of the
+	// revoked commitment, and encrypt the justice kit.
+	breachTxID := t.breachInfo.BreachTransaction.TxHash()
+	hint, key := blob.NewBreachHintAndKeyFromHash(&breachTxID)
+
+	encBlob, err := justiceKit.Encrypt(key)
+	if err != nil {
+		return hint, nil, err
+	}
+
+	return hint, encBlob, nil
+}
@@ -0,0 +1,303 @@
+package wtclient
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/lookout"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockSigner is an implementation of the lnwallet.Signer interface that signs
+// using a set of known private keys, applying any tweaks specified in the sign
+// descriptor.
+type mockSigner struct {
+	keys map[[33]byte]*btcec.PrivateKey
+}
+
+func newMockSigner() *mockSigner {
+	return &mockSigner{
+		keys: make(map[[33]byte]*btcec.PrivateKey),
+	}
+}
+
+func (s *mockSigner) addKey(t *testing.T) *btcec.PrivateKey {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate private key: %v", err)
+	}
+
+	var pub [33]byte
+	copy(pub[:], priv.PubKey().SerializeCompressed())
+	s.keys[pub] = priv
+
+	return priv
+}
+
+func (s *mockSigner) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	var pub [33]byte
+	copy(pub[:], signDesc.KeyDesc.PubKey.SerializeCompressed())
+
+	privKey, ok := s.keys[pub]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %x", pub)
+	}
+
+	switch {
+	case signDesc.SingleTweak != nil:
+		privKey = lnwallet.TweakPrivKey(privKey, signDesc.SingleTweak)
+	case signDesc.DoubleTweak != nil:
+		privKey = lnwallet.DeriveRevocationPrivKey(
+			privKey, signDesc.DoubleTweak,
+		)
+	}
+
+	sig, err := txscript.RawTxInWitnessSignature(
+		tx, signDesc.SigHashes, signDesc.InputIndex,
+		signDesc.Output.Value, signDesc.WitnessScript,
+		signDesc.HashType, privKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig[:len(sig)-1], nil
+}
+
+func (s *mockSigner) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	return nil, fmt.Errorf("unimplemented")
+}
+
+// newBreachRetribution creates a breach retribution for a revoked commitment
+// with a to-local output of toLocalAmt and a to-remote output of toRemoteAmt.
+// If either amount is zero, the corresponding output is omitted.
+func newBreachRetribution(t *testing.T, signer *mockSigner, toLocalAmt,
+	toRemoteAmt int64) *lnwallet.BreachRetribution {
+
+	const csvDelay = 144
+
+	revBasePriv := signer.addKey(t)
+	payBasePriv := signer.addKey(t)
+
+	delayPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate delay key: %v", err)
+	}
+	commitSecret, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate commit secret: %v", err)
+	}
+	commitPoint := commitSecret.PubKey()
+
+	keyRing := &lnwallet.CommitmentKeyRing{
+		CommitPoint: commitPoint,
+		DelayKey:    delayPriv.PubKey(),
+		NoDelayKey: lnwallet.TweakPubKey(
+			payBasePriv.PubKey(), commitPoint,
+		),
+		RevocationKey: lnwallet.DeriveRevocationPubkey(
+			revBasePriv.PubKey(), commitPoint,
+		),
+	}
+
+	toLocalScript, err := lnwallet.CommitScriptToSelf(
+		csvDelay, keyRing.DelayKey, keyRing.RevocationKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to create to-local script: %v", err)
+	}
+	toLocalPkScript, err := lnwallet.WitnessScriptHash(toLocalScript)
+	if err != nil {
+		t.Fatalf("unable to create to-local pkscript: %v", err)
+	}
+	toRemotePkScript, err := lnwallet.CommitScriptUnencumbered(
+		keyRing.NoDelayKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to create to-remote pkscript: %v", err)
+	}
+
+	breachTxn := wire.NewMsgTx(2)
+	breachTxn.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}},
+	})
+
+	breachInfo := &lnwallet.BreachRetribution{
+		BreachTransaction: breachTxn,
+		RevokedStateNum:   1,
+		KeyRing:           keyRing,
+		RemoteDelay:       csvDelay,
+	}
+
+	if toLocalAmt > 0 {
+		txOut := &wire.TxOut{
+			PkScript: toLocalPkScript,
+			Value:    toLocalAmt,
+		}
+		breachTxn.AddTxOut(txOut)
+		breachInfo.RemoteOutputSignDesc = &lnwallet.SignDescriptor{
+			KeyDesc: keychain.KeyDescriptor{
+				PubKey: revBasePriv.PubKey(),
+			},
+			DoubleTweak:   commitSecret,
+			WitnessScript: toLocalScript,
+			Output:        txOut,
+		}
+	}
+
+	if toRemoteAmt > 0 {
+		txOut := &wire.TxOut{
+			PkScript: toRemotePkScript,
+			Value:    toRemoteAmt,
+		}
+		breachTxn.AddTxOut(txOut)
+		breachInfo.LocalOutputSignDesc = &lnwallet.SignDescriptor{
+			KeyDesc: keychain.KeyDescriptor{
+				PubKey: payBasePriv.PubKey(),
+			},
+			SingleTweak: lnwallet.SingleTweakBytes(
+				commitPoint, payBasePriv.PubKey(),
+			),
+			WitnessScript: toRemotePkScript,
+			Output:        txOut,
+		}
+	}
+
+	// Now that the outputs have been added, fill in the final outpoints.
+	breachTxID := breachTxn.TxHash()
+	for i, txOut := range breachTxn.TxOut {
+		outPoint := wire.OutPoint{Hash: breachTxID, Index: uint32(i)}
+		switch {
+		case breachInfo.RemoteOutputSignDesc != nil &&
+			txOut == breachInfo.RemoteOutputSignDesc.Output:
+
+			breachInfo.RemoteOutpoint = outPoint
+
+		case breachInfo.LocalOutputSignDesc != nil &&
+			txOut == breachInfo.LocalOutputSignDesc.Output:
+
+			breachInfo.LocalOutpoint = outPoint
+		}
+	}
+
+	return breachInfo
+}
+
+// TestBackupTaskJusticeKit asserts that the encrypted blob produced by a
+// backup task can be decrypted using the txid of the revoked commitment, and
+// that the tower is able to assemble a valid justice transaction from it.
+func TestBackupTaskJusticeKit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		toLocalAmt  int64
+		toRemoteAmt int64
+		expErr      error
+	}{
+		{
+			name:        "to-local and to-remote",
+			toLocalAmt:  100000,
+			toRemoteAmt: 200000,
+		},
+		{
+			name:       "to-local only",
+			toLocalAmt: 100000,
+		},
+		{
+			name:        "to-remote only",
+			toRemoteAmt: 200000,
+			expErr:      ErrNoToLocalOutput,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			testBackupTaskJusticeKit(
+				t, test.toLocalAmt, test.toRemoteAmt,
+				test.expErr,
+			)
+		})
+	}
+}
+
+func testBackupTaskJusticeKit(t *testing.T, toLocalAmt, toRemoteAmt int64,
+	expErr error) {
+
+	signer := newMockSigner()
+	breachInfo := newBreachRetribution(t, signer, toLocalAmt, toRemoteAmt)
+
+	sweepPkScript := make([]byte, 22)
+	if _, err := rand.Read(sweepPkScript[2:]); err != nil {
+		t.Fatalf("unable to generate sweep pkscript: %v", err)
+	}
+	sweepPkScript[0] = txscript.OP_0
+	sweepPkScript[1] = 20
+
+	chanID := lnwire.ChannelID{0x01}
+	task, err := newBackupTask(&chanID, breachInfo, sweepPkScript)
+	if err != expErr {
+		t.Fatalf("expected error %v, got: %v", expErr, err)
+	}
+	if err != nil {
+		return
+	}
+
+	policy := wtpolicy.DefaultPolicy()
+	hint, encBlob, err := task.craftSessionPayload(signer, policy)
+	if err != nil {
+		t.Fatalf("unable to craft session payload: %v", err)
+	}
+
+	breachTxID := breachInfo.BreachTransaction.TxHash()
+	expHint, key := blob.NewBreachHintAndKeyFromHash(&breachTxID)
+	if hint != expHint {
+		t.Fatalf("breach hint mismatch, want: %v, got: %v",
+			expHint, hint)
+	}
+
+	justiceKit, err := blob.Decrypt(key, encBlob)
+	if err != nil {
+		t.Fatalf("unable to decrypt justice kit: %v", err)
+	}
+
+	if justiceKit.HasCommitToRemoteOutput() != (toRemoteAmt > 0) {
+		t.Fatalf("unexpected to-remote output in justice kit")
+	}
+
+	// The tower should be able to construct a valid justice transaction
+	// from the justice kit, which includes verifying the signatures.
+	desc := &lookout.JusticeDescriptor{
+		BreachedCommitTx: breachInfo.BreachTransaction,
+		SessionInfo: &wtdb.SessionInfo{
+			Policy: policy,
+		},
+		JusticeKit: justiceKit,
+	}
+	justiceTxn, err := desc.CreateJusticeTxn()
+	if err != nil {
+		t.Fatalf("unable to create justice txn: %v", err)
+	}
+
+	if len(justiceTxn.TxOut) != 1 {
+		t.Fatalf("expected 1 output, got %d", len(justiceTxn.TxOut))
+	}
+	if justiceTxn.TxOut[0].Value >= toLocalAmt+toRemoteAmt {
+		t.Fatalf("justice txn doesn't pay a fee")
+	}
+}
@@ -0,0 +1,746 @@
+package wtclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/lightningnetwork/lnd/watchtower/wtserver"
+	"github.com/lightningnetwork/lnd/watchtower/wtwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+const (
+	// DefaultReadTimeout specifies the default duration we will wait during
+	// a read before breaking out of a blocking read.
+	DefaultReadTimeout = 15 * time.Second
+
+	// DefaultWriteTimeout specifies the default duration we will wait during
+	// a write before breaking out of a blocking write.
+	DefaultWriteTimeout = 15 * time.Second
+
+	// DefaultMinBackoff is the minimum amount of time to back off after a
+	// failed attempt to communicate with the tower.
+	DefaultMinBackoff = time.Second
+
+	// DefaultMaxBackoff is the maximum amount of time to back off after
+	// repeated failed attempts to communicate with the tower.
+	DefaultMaxBackoff = 5 * time.Minute
+)
+
+var (
+	// ErrClientExiting signals that the watchtower client is shutting down.
+	ErrClientExiting = errors.New("watchtower client shutting down")
+)
+
+// Config provides the client with access to the resources it requires to
+// perform its duty. All nillable fields must be non-nil for the client to be
+// initialized properly.
+type Config struct {
+	// Signer provides access to the wallet so that the client can sign
+	// justice transactions that spend from a remote party's commitment
+	// transaction.
+	Signer lnwallet.Signer
+
+	// NewAddress generates a new on-chain sweep pkscript.
+	NewAddress func() ([]byte, error)
+
+	// SecretKeyRing is used to derive the session keys used to communicate
+	// with the tower. The client only stores the KeyLocators internally so
+	// that we never store private keys on disk.
+	SecretKeyRing keychain.SecretKeyRing
+
+	// Dial connects to the tower, authenticating with the given session
+	// key.
+	Dial DialFunc
+
+	// DB provides access to the client's stable storage medium.
+	DB DB
+
+	// PrivateTower is the net address of a private tower. The client will
+	// try to create all sessions with this tower.
+	PrivateTower *lnwire.NetAddress
+
+	// Policy is the session policy the client will propose when creating
+	// new sessions with the tower.
+	Policy wtpolicy.Policy
+
+	// ReadTimeout is the duration we will wait during a read before
+	// breaking out of a blocking read.
+	ReadTimeout time.Duration
+
+	// WriteTimeout is the duration we will wait during a write before
+	// breaking out of a blocking write.
+	WriteTimeout time.Duration
+
+	// MinBackoff defines the initial backoff applied to connections with
+	// watchtowers. Subsequent backoff durations will grow exponentially up
+	// until MaxBackoff.
+	MinBackoff time.Duration
+
+	// MaxBackoff defines the maximum backoff applied to connections with
+	// watchtowers.
+	MaxBackoff time.Duration
+}
+
+// clientSession couples a session persisted in the client's database with the
+// private key used to authenticate with the tower.
+type clientSession struct {
+	*wtdb.ClientSession
+
+	sessionPriv *btcec.PrivateKey
+}
+
+// exhausted returns true if the session has no remaining updates.
+func (s *clientSession) exhausted() bool {
+	return s.SeqNum >= s.Policy.MaxUpdates
+}
+
+// Client is the primary implementation of the watchtower client. Revoked
+// states are queued by BackupState, and a single dispatcher goroutine
+// negotiates sessions with the tower, signs the justice transaction for each
+// revoked state, and reliably uploads the resulting encrypted blobs.
+type Client struct {
+	started int32 // atomic
+	stopped int32 // atomic
+
+	cfg *Config
+
+	// sweepPkScripts caches the sweep pkscript used for each channel.
+	sweepMtx       sync.Mutex
+	sweepPkScripts map[lnwire.ChannelID][]byte
+
+	// pendingSessions holds sessions loaded from disk that still have
+	// committed updates which were never acknowledged by the tower.
+	pendingSessions []*clientSession
+
+	// activeSession is the session under which new updates are committed.
+	// It is only accessed by the backup dispatcher.
+	activeSession *clientSession
+
+	taskMtx  sync.Mutex
+	tasks    []*backupTask
+	newTasks chan struct{}
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// New initializes a new Client from the provided Config. Any sessions with
+// the configured tower are loaded from the database, so that updates left
+// unacknowledged by a prior run can be retransmitted.
+func New(cfg *Config) (*Client, error) {
+	// Copy the config to prevent side-effects from modifying both the
+	// internal and external version of the Config.
+	cfgCopy := *cfg
+	cfg = &cfgCopy
+
+	if cfg.ReadTimeout <= 0 {
+		cfg.ReadTimeout = DefaultReadTimeout
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = DefaultWriteTimeout
+	}
+	if cfg.MinBackoff <= 0 {
+		cfg.MinBackoff = DefaultMinBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultMaxBackoff
+	}
+
+	if err := cfg.Policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		cfg:      cfg,
+		newTasks: make(chan struct{}, 1),
+		quit:     make(chan struct{}),
+	}
+
+	sweepPkScripts, err := cfg.DB.FetchChanSweepPkScripts()
+	if err != nil {
+		return nil, err
+	}
+	c.sweepPkScripts = sweepPkScripts
+
+	sessions, err := cfg.DB.ListClientSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	towerPub := cfg.PrivateTower.IdentityKey.SerializeCompressed()
+	for _, dbSession := range sessions {
+		// Only sessions with our configured tower can be resumed.
+		if !bytes.Equal(
+			dbSession.TowerPubKey.SerializeCompressed(), towerPub,
+		) {
+			continue
+		}
+
+		// We only need to load sessions that either have updates to
+		// retransmit, or remaining capacity for new updates.
+		if len(dbSession.CommittedUpdates) == 0 &&
+			dbSession.SeqNum >= dbSession.Policy.MaxUpdates {
+
+			continue
+		}
+
+		sessionPriv, err := c.deriveSessionKey(
+			dbSession.SessionKeyIndex,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		session := &clientSession{
+			ClientSession: dbSession,
+			sessionPriv:   sessionPriv,
+		}
+
+		if len(session.CommittedUpdates) > 0 {
+			c.pendingSessions = append(c.pendingSessions, session)
+		}
+
+		// Resume the session with the remaining capacity if its
+		// policy matches our current configuration.
+		if c.activeSession == nil && !session.exhausted() &&
+			session.Policy == cfg.Policy {
+
+			c.activeSession = session
+		}
+	}
+
+	return c, nil
+}
+
+// Start initializes the watchtower client, allowing it to process requests to
+// backup revoked channel states.
+func (c *Client) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Starting watchtower client with tower %v",
+		c.cfg.PrivateTower)
+
+	c.wg.Add(1)
+	go c.backupDispatcher()
+
+	return nil
+}
+
+// Stop gracefully shuts down the watchtower client. Any revoked states that
+// have not yet been uploaded are lost, though states committed to the database
+// will be retransmitted upon restart.
+func (c *Client) Stop() error {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Stopping watchtower client")
+
+	close(c.quit)
+	c.wg.Wait()
+
+	log.Infof("Watchtower client stopped")
+
+	return nil
+}
+
+// BackupState initiates a request to back up a particular revoked state. The
+// method returns once the state has been queued, and the justice transaction
+// will be signed and uploaded to the tower in the background.
+func (c *Client) BackupState(chanID *lnwire.ChannelID,
+	breachInfo *lnwallet.BreachRetribution) error {
+
+	select {
+	case <-c.quit:
+		return ErrClientExiting
+	default:
+	}
+
+	sweepPkScript, err := c.getSweepPkScript(chanID)
+	if err != nil {
+		return err
+	}
+
+	task, err := newBackupTask(chanID, breachInfo, sweepPkScript)
+	switch {
+
+	// If the revoked state doesn't contain an output for the remote
+	// party, there is nothing for the tower to do.
+	case err == ErrNoToLocalOutput:
+		log.Debugf("Skipping backup of revoked state %d for "+
+			"ChannelID(%v): %v", breachInfo.RevokedStateNum,
+			chanID, err)
+		return nil
+
+	case err != nil:
+		return err
+	}
+
+	c.taskMtx.Lock()
+	c.tasks = append(c.tasks, task)
+	c.taskMtx.Unlock()
+
+	select {
+	case c.newTasks <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// getSweepPkScript returns the sweep pkscript of the given channel. If the
+// channel doesn't have one yet, a new address is generated and persisted so
+// that all justice transactions for the channel pay to the same output.
+func (c *Client) getSweepPkScript(chanID *lnwire.ChannelID) ([]byte, error) {
+	c.sweepMtx.Lock()
+	defer c.sweepMtx.Unlock()
+
+	if pkScript, ok := c.sweepPkScripts[*chanID]; ok {
+		return pkScript, nil
+	}
+
+	pkScript, err := c.cfg.NewAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.cfg.DB.AddChanSweepPkScript(*chanID, pkScript)
+	if err != nil {
+		return nil, err
+	}
+
+	c.sweepPkScripts[*chanID] = pkScript
+
+	return pkScript, nil
+}
+
+// popTasks removes and returns all queued backup tasks.
+func (c *Client) popTasks() []*backupTask {
+	c.taskMtx.Lock()
+	defer c.taskMtx.Unlock()
+
+	tasks := c.tasks
+	c.tasks = nil
+
+	return tasks
+}
+
+// backupDispatcher is the primary goroutine of the client. It first
+// retransmits any updates left unacknowledged by a prior run, and then
+// processes newly queued backup tasks in batches.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (c *Client) backupDispatcher() {
+	defer c.wg.Done()
+
+	for _, session := range c.pendingSessions {
+		log.Infof("Retransmitting %d committed updates for session %s",
+			len(session.CommittedUpdates), session.ID)
+
+		if !c.flushSession(session) {
+			return
+		}
+	}
+	c.pendingSessions = nil
+
+	for {
+		select {
+		case <-c.newTasks:
+		case <-c.quit:
+			return
+		}
+
+		for {
+			tasks := c.popTasks()
+			if len(tasks) == 0 {
+				break
+			}
+
+			if !c.processTasks(tasks) {
+				return
+			}
+		}
+	}
+}
+
+// processTasks commits each of the backup tasks to the active session,
+// negotiating new sessions as needed, and uploads the resulting updates to
+// the tower. False is returned if the client is shutting down.
+func (c *Client) processTasks(tasks []*backupTask) bool {
+	for len(tasks) > 0 {
+		session := c.getActiveSession()
+		if session == nil {
+			return false
+		}
+
+		// Commit as many tasks as the session has capacity for.
+		for len(tasks) > 0 && !session.exhausted() {
+			task := tasks[0]
+			tasks = tasks[1:]
+
+			err := c.commitTask(session, task)
+			if err != nil {
+				log.Errorf("Unable to back up revoked state "+
+					"%d for ChannelID(%v): %v",
+					task.breachInfo.RevokedStateNum,
+					task.chanID, err)
+			}
+		}
+
+		// Upload all committed updates to the tower.
+		if !c.flushSession(session) {
+			return false
+		}
+
+		if c.activeSession != nil && c.activeSession.exhausted() {
+			c.activeSession = nil
+		}
+	}
+
+	return true
+}
+
+// commitTask signs the justice transaction for the backup task using the
+// session's policy, and persists the encrypted blob as the session's next
+// update.
+func (c *Client) commitTask(session *clientSession, task *backupTask) error {
+	hint, encBlob, err := task.craftSessionPayload(
+		c.cfg.Signer, session.Policy,
+	)
+	if err != nil {
+		return err
+	}
+
+	update := &wtdb.CommittedUpdate{
+		SeqNum:        session.SeqNum + 1,
+		Hint:          hint,
+		EncryptedBlob: encBlob,
+	}
+
+	err = c.cfg.DB.CommitUpdate(&session.ID, update)
+	if err != nil {
+		return err
+	}
+
+	session.SeqNum = update.SeqNum
+	session.CommittedUpdates = append(session.CommittedUpdates, update)
+
+	log.Debugf("Committed revoked state %d for ChannelID(%v) as update "+
+		"%d of session %s", task.breachInfo.RevokedStateNum,
+		task.chanID, update.SeqNum, session.ID)
+
+	return nil
+}
+
+// getActiveSession returns the session under which new updates should be
+// committed, negotiating a new session with the tower if necessary. Session
+// negotiation is retried with backoff until it succeeds or the client shuts
+// down, in which case nil is returned.
+func (c *Client) getActiveSession() *clientSession {
+	backoff := c.cfg.MinBackoff
+	for c.activeSession == nil {
+		session, err := c.negotiateSession()
+		if err == nil {
+			c.activeSession = session
+			break
+		}
+
+		log.Errorf("Unable to negotiate session with tower %v: %v",
+			c.cfg.PrivateTower, err)
+
+		if !c.waitBackoff(&backoff) {
+			return nil
+		}
+	}
+
+	return c.activeSession
+}
+
+// negotiateSession derives a fresh session key, and proposes a new session
+// with the tower using the client's configured policy.
+func (c *Client) negotiateSession() (*clientSession, error) {
+	keyIndex, err := c.cfg.DB.NextSessionKeyIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	sessionPriv, err := c.deriveSessionKey(keyIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	peer, err := c.cfg.Dial(sessionPriv, c.cfg.PrivateTower)
+	if err != nil {
+		return nil, err
+	}
+	defer peer.Close()
+
+	err = c.sendMessage(peer, &wtwire.CreateSession{
+		MaxUpdates:   c.cfg.Policy.MaxUpdates,
+		SweepFeeRate: c.cfg.Policy.SweepFeeRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := c.readMessage(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, ok := msg.(*wtwire.CreateSessionReply)
+	if !ok {
+		return nil, fmt.Errorf("expected CreateSessionReply, got %T",
+			msg)
+	}
+	if reply.Code != wtwire.CodeOK {
+		return nil, fmt.Errorf("session rejected by tower: %v",
+			reply.Code)
+	}
+
+	dbSession := &wtdb.ClientSession{
+		ID:              wtdb.NewSessionIDFromPubKey(sessionPriv.PubKey()),
+		SessionKeyIndex: keyIndex,
+		TowerPubKey:     c.cfg.PrivateTower.IdentityKey,
+		Policy:          c.cfg.Policy,
+	}
+	if err := c.cfg.DB.CreateClientSession(dbSession); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Negotiated session %s with tower %v using policy %v",
+		dbSession.ID, c.cfg.PrivateTower, c.cfg.Policy)
+
+	return &clientSession{
+		ClientSession: dbSession,
+		sessionPriv:   sessionPriv,
+	}, nil
+}
+
+// deriveSessionKey derives the private key of the session at the given index
+// within the tower session key family.
+func (c *Client) deriveSessionKey(index uint32) (*btcec.PrivateKey, error) {
+	return c.cfg.SecretKeyRing.DerivePrivKey(keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{
+			Family: keychain.KeyFamilyTowerSession,
+			Index:  index,
+		},
+	})
+}
+
+// errSessionRejected signals that the tower permanently rejected the updates
+// sent under a session.
+type errSessionRejected struct {
+	code wtwire.ErrorCode
+}
+
+// Error returns a human readable description of the rejection.
+func (e *errSessionRejected) Error() string {
+	return fmt.Sprintf("tower rejected update: %v", e.code)
+}
+
+// flushSession uploads all of the session's committed updates to the tower,
+// retrying with backoff on failure. If the tower permanently rejects the
+// session's updates, the session is abandoned. False is returned if the
+// client is shutting down.
+func (c *Client) flushSession(session *clientSession) bool {
+	backoff := c.cfg.MinBackoff
+	for len(session.CommittedUpdates) > 0 {
+		err := c.sendCommittedUpdates(session)
+		switch err.(type) {
+		case nil:
+			return true
+
+		case *errSessionRejected:
+			log.Errorf("Abandoning session %s with %d "+
+				"unacknowledged updates: %v", session.ID,
+				len(session.CommittedUpdates), err)
+
+			session.CommittedUpdates = nil
+			if c.activeSession == session {
+				c.activeSession = nil
+			}
+
+			return true
+		}
+
+		log.Errorf("Unable to send updates for session %s to tower "+
+			"%v: %v", session.ID, c.cfg.PrivateTower, err)
+
+		if !c.waitBackoff(&backoff) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sendCommittedUpdates connects to the tower using the session's key, and
+// sends each of the session's committed updates in order. Each update is
+// acknowledged in the database once the tower has accepted it.
+func (c *Client) sendCommittedUpdates(session *clientSession) error {
+	towerAddr := &lnwire.NetAddress{
+		IdentityKey: session.TowerPubKey,
+		Address:     c.cfg.PrivateTower.Address,
+	}
+
+	peer, err := c.cfg.Dial(session.sessionPriv, towerAddr)
+	if err != nil {
+		return err
+	}
+	defer peer.Close()
+
+	for len(session.CommittedUpdates) > 0 {
+		update := session.CommittedUpdates[0]
+
+		var isComplete uint8
+		if len(session.CommittedUpdates) == 1 {
+			isComplete = 1
+		}
+
+		err := c.sendMessage(peer, &wtwire.StateUpdate{
+			SeqNum:        update.SeqNum,
+			LastApplied:   session.TowerLastApplied,
+			IsComplete:    isComplete,
+			Hint:          update.Hint,
+			EncryptedBlob: update.EncryptedBlob,
+		})
+		if err != nil {
+			return err
+		}
+
+		msg, err := c.readMessage(peer)
+		if err != nil {
+			return err
+		}
+
+		reply, ok := msg.(*wtwire.StateUpdateReply)
+		if !ok {
+			return fmt.Errorf("expected StateUpdateReply, got %T",
+				msg)
+		}
+
+		switch reply.Code {
+		case wtwire.CodeOK:
+
+		// The tower may have already applied this update if we failed
+		// to record its acknowledgment before a restart. In that case
+		// we'll record the ack and reconnect to send the remainder.
+		case wtwire.StateUpdateCodeSeqNumOutOfOrder,
+			wtwire.StateUpdateCodeClientBehind:
+
+			if reply.LastApplied < update.SeqNum {
+				return &errSessionRejected{reply.Code}
+			}
+
+			err := c.ackUpdate(session, update, reply.LastApplied)
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf("tower already applied update %d, "+
+				"reconnecting", update.SeqNum)
+
+		case wtwire.CodeTemporaryFailure:
+			return fmt.Errorf("tower temporarily unavailable")
+
+		default:
+			return &errSessionRejected{reply.Code}
+		}
+
+		err = c.ackUpdate(session, update, reply.LastApplied)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ackUpdate records the tower's acknowledgment of the update, and removes it
+// from the session's committed updates.
+func (c *Client) ackUpdate(session *clientSession,
+	update *wtdb.CommittedUpdate, lastApplied uint16) error {
+
+	err := c.cfg.DB.AckUpdate(&session.ID, update.SeqNum, lastApplied)
+	if err != nil {
+		return err
+	}
+
+	session.TowerLastApplied = lastApplied
+	session.CommittedUpdates = session.CommittedUpdates[1:]
+
+	log.Debugf("Update %d of session %s acknowledged by tower",
+		update.SeqNum, session.ID)
+
+	return nil
+}
+
+// waitBackoff waits for the given backoff duration, and doubles it for the
+// next attempt, up to the configured maximum. False is returned if the client
+// is shutting down.
+func (c *Client) waitBackoff(backoff *time.Duration) bool {
+	select {
+	case <-time.After(*backoff):
+	case <-c.quit:
+		return false
+	}
+
+	*backoff *= 2
+	if *backoff > c.cfg.MaxBackoff {
+		*backoff = c.cfg.MaxBackoff
+	}
+
+	return true
+}
+
+// readMessage receives and parses the next message from the given peer. An
+// error is returned if a message is not received before the client's read
+// timeout, the read off the wire failed, or the message could not be
+// deserialized.
+func (c *Client) readMessage(peer wtserver.Peer) (wtwire.Message, error) {
+	err := peer.SetReadDeadline(time.Now().Add(c.cfg.ReadTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("unable to set read deadline: %v", err)
+	}
+
+	rawMsg, err := peer.ReadNextMessage()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read message: %v", err)
+	}
+
+	msg, err := wtwire.ReadMessage(bytes.NewReader(rawMsg), 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse message: %v", err)
+	}
+
+	return msg, nil
+}
+
+// sendMessage sends a watchtower wire message to the target peer.
+func (c *Client) sendMessage(peer wtserver.Peer, msg wtwire.Message) error {
+	var b bytes.Buffer
+	if _, err := wtwire.WriteMessage(&b, msg, 0); err != nil {
+		return fmt.Errorf("unable to encode msg: %v", err)
+	}
+
+	err := peer.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
+	if err != nil {
+		return fmt.Errorf("unable to set write deadline: %v", err)
+	}
+
+	_, err = peer.Write(b.Bytes())
+	return err
+}
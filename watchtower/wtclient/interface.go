@@ -0,0 +1,44 @@
+package wtclient
+
+import (
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtserver"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// DB abstracts the persistent functionality needed by the watchtower client.
+type DB interface {
+	// NextSessionKeyIndex reserves a new session key index, which is used
+	// to derive the key of a new session.
+	NextSessionKeyIndex() (uint32, error)
+
+	// CreateClientSession saves a newly negotiated client session to the
+	// client's database.
+	CreateClientSession(*wtdb.ClientSession) error
+
+	// ListClientSessions returns all sessions known to the client, along
+	// with their committed but unacknowledged updates.
+	ListClientSessions() (map[wtdb.SessionID]*wtdb.ClientSession, error)
+
+	// CommitUpdate persists an update that the client intends to send to
+	// the tower under the given session.
+	CommitUpdate(*wtdb.SessionID, *wtdb.CommittedUpdate) error
+
+	// AckUpdate removes a committed update once it has been acknowledged
+	// by the tower, recording the tower's last applied sequence number.
+	AckUpdate(id *wtdb.SessionID, seqNum, lastApplied uint16) error
+
+	// AddChanSweepPkScript records the sweep pkscript used in all justice
+	// transactions for the given channel.
+	AddChanSweepPkScript(lnwire.ChannelID, []byte) error
+
+	// FetchChanSweepPkScripts returns the sweep pkscripts of all channels
+	// known to the client.
+	FetchChanSweepPkScripts() (map[lnwire.ChannelID][]byte, error)
+}
+
+// DialFunc connects to a watchtower at the given address, authenticating the
+// connection using the provided session key.
+type DialFunc func(localPriv *btcec.PrivateKey,
+	netAddr *lnwire.NetAddress) (wtserver.Peer, error)
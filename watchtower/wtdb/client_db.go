@@ -0,0 +1,406 @@
+package wtdb
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// clientDBName is the filename of client database.
+	clientDBName = "wtclient.db"
+)
+
+var (
+	// cSessionBkt is a top-level bucket storing all client sessions. Each
+	// session is stored within a nested bucket keyed by its session id.
+	//  session id -> {
+	//	session-body -> session
+	//	committed-updates -> { seqnum -> committed update }
+	//  }
+	cSessionBkt = []byte("client-session-bucket")
+
+	// cSessionBody is the key under which a client session's body is
+	// stored within its session bucket.
+	cSessionBody = []byte("client-session-body")
+
+	// cSessionCommits is a nested bucket within a session bucket that
+	// holds all updates that have been committed but not yet acknowledged
+	// by the tower.
+	cSessionCommits = []byte("client-session-commits")
+
+	// cSessionKeyIndexBkt is a top-level bucket storing the next unused
+	// index within the tower session key family.
+	cSessionKeyIndexBkt = []byte("client-session-key-index-bucket")
+
+	// cSessionKeyIndexKey is the key under which the next session key
+	// index is stored.
+	cSessionKeyIndexKey = []byte("client-session-key-index")
+
+	// cChanSweepPkScriptBkt is a top-level bucket storing the sweep
+	// pkscript used for each channel's justice transactions.
+	//  chan id -> sweep pkscript
+	cChanSweepPkScriptBkt = []byte("client-chan-sweep-pkscript-bucket")
+
+	// ErrClientSessionAlreadyExists signals that a client session with the
+	// same session id has already been created.
+	ErrClientSessionAlreadyExists = errors.New(
+		"client session already exists",
+	)
+
+	// ErrClientSessionNotFound signals that the requested client session
+	// could not be found.
+	ErrClientSessionNotFound = errors.New("client session not found")
+
+	// ErrCommitUnorderedUpdate signals that a client tried to commit an
+	// update whose sequence number doesn't directly follow the session's
+	// last committed sequence number.
+	ErrCommitUnorderedUpdate = errors.New("update seqnum not monotonic")
+
+	// ErrCommittedUpdateNotFound signals that the tower acked an update
+	// that was never committed by the client.
+	ErrCommittedUpdateNotFound = errors.New("committed update not found")
+
+	// ErrChanSweepPkScriptAlreadyExists signals that a sweep pkscript has
+	// already been recorded for the channel.
+	ErrChanSweepPkScriptAlreadyExists = errors.New(
+		"channel sweep pkscript already exists",
+	)
+)
+
+// ClientDB is a bolt-backed database used by the watchtower client to persist
+// its sessions with towers, the updates that have yet to be acknowledged, and
+// the sweep pkscripts used for each channel.
+type ClientDB struct {
+	*bolt.DB
+
+	dbPath string
+}
+
+// OpenClientDB opens the client database found in the given directory,
+// creating the directory and initializing the top-level buckets if necessary.
+func OpenClientDB(dbPath string) (*ClientDB, error) {
+	if err := os.MkdirAll(dbPath, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dbPath, clientDBName)
+	bdb, err := bolt.Open(path, dbFilePermission, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bdb.Update(func(tx *bolt.Tx) error {
+		topLevelBuckets := [][]byte{
+			cSessionBkt, cSessionKeyIndexBkt, cChanSweepPkScriptBkt,
+		}
+		for _, bucket := range topLevelBuckets {
+			_, err := tx.CreateBucketIfNotExists(bucket)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		bdb.Close()
+		return nil, err
+	}
+
+	return &ClientDB{
+		DB:     bdb,
+		dbPath: dbPath,
+	}, nil
+}
+
+// Path returns the directory containing the client database.
+func (c *ClientDB) Path() string {
+	return c.dbPath
+}
+
+// NextSessionKeyIndex reserves a new index within the tower session key
+// family, which is used to derive the key for a new session. Indexes are
+// never reused, ensuring that each session is authenticated with a unique key.
+func (c *ClientDB) NextSessionKeyIndex() (uint32, error) {
+	var index uint32
+	err := c.Update(func(tx *bolt.Tx) error {
+		keyIndex := tx.Bucket(cSessionKeyIndexBkt)
+		if keyIndex == nil {
+			return ErrUninitializedDB
+		}
+
+		indexBytes := keyIndex.Get(cSessionKeyIndexKey)
+		if indexBytes != nil {
+			index = byteOrder.Uint32(indexBytes)
+		}
+
+		var nextIndex [4]byte
+		byteOrder.PutUint32(nextIndex[:], index+1)
+
+		return keyIndex.Put(cSessionKeyIndexKey, nextIndex[:])
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return index, nil
+}
+
+// CreateClientSession records a newly negotiated client session. An error is
+// returned if a session with the same id already exists.
+func (c *ClientDB) CreateClientSession(session *ClientSession) error {
+	return c.Update(func(tx *bolt.Tx) error {
+		sessions := tx.Bucket(cSessionBkt)
+		if sessions == nil {
+			return ErrUninitializedDB
+		}
+
+		if sessions.Bucket(session.ID[:]) != nil {
+			return ErrClientSessionAlreadyExists
+		}
+
+		sessionBkt, err := sessions.CreateBucket(session.ID[:])
+		if err != nil {
+			return err
+		}
+
+		if _, err := sessionBkt.CreateBucket(cSessionCommits); err != nil {
+			return err
+		}
+
+		return putClientSessionBody(sessionBkt, session)
+	})
+}
+
+// ListClientSessions returns all client sessions known to the database, along
+// with any updates that have been committed but not acknowledged by the tower.
+func (c *ClientDB) ListClientSessions() (map[SessionID]*ClientSession, error) {
+	clientSessions := make(map[SessionID]*ClientSession)
+	err := c.View(func(tx *bolt.Tx) error {
+		sessions := tx.Bucket(cSessionBkt)
+		if sessions == nil {
+			return ErrUninitializedDB
+		}
+
+		return sessions.ForEach(func(k, _ []byte) error {
+			sessionBkt := sessions.Bucket(k)
+			if sessionBkt == nil {
+				return ErrClientSessionNotFound
+			}
+
+			session, err := getClientSessionBody(sessionBkt)
+			if err != nil {
+				return err
+			}
+
+			commits := sessionBkt.Bucket(cSessionCommits)
+			if commits == nil {
+				return ErrUninitializedDB
+			}
+
+			err = commits.ForEach(func(_, v []byte) error {
+				var update CommittedUpdate
+				err := update.Decode(bytes.NewReader(v))
+				if err != nil {
+					return err
+				}
+
+				session.CommittedUpdates = append(
+					session.CommittedUpdates, &update,
+				)
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			clientSessions[session.ID] = session
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clientSessions, nil
+}
+
+// CommitUpdate persists an update that the client intends to send to the
+// tower. The update's sequence number must directly follow the session's last
+// committed sequence number. Once committed, the update will be retransmitted
+// until the tower acknowledges it via AckUpdate.
+func (c *ClientDB) CommitUpdate(id *SessionID, update *CommittedUpdate) error {
+	return c.Update(func(tx *bolt.Tx) error {
+		sessionBkt, err := getClientSessionBkt(tx, id)
+		if err != nil {
+			return err
+		}
+
+		session, err := getClientSessionBody(sessionBkt)
+		if err != nil {
+			return err
+		}
+
+		if update.SeqNum != session.SeqNum+1 {
+			return ErrCommitUnorderedUpdate
+		}
+
+		commits := sessionBkt.Bucket(cSessionCommits)
+		if commits == nil {
+			return ErrUninitializedDB
+		}
+
+		var b bytes.Buffer
+		if err := update.Encode(&b); err != nil {
+			return err
+		}
+
+		var seqNum [2]byte
+		byteOrder.PutUint16(seqNum[:], update.SeqNum)
+		if err := commits.Put(seqNum[:], b.Bytes()); err != nil {
+			return err
+		}
+
+		session.SeqNum = update.SeqNum
+
+		return putClientSessionBody(sessionBkt, session)
+	})
+}
+
+// AckUpdate removes a committed update after the tower has acknowledged it,
+// and records the tower's last applied sequence number for the session.
+func (c *ClientDB) AckUpdate(id *SessionID, seqNum, lastApplied uint16) error {
+	return c.Update(func(tx *bolt.Tx) error {
+		sessionBkt, err := getClientSessionBkt(tx, id)
+		if err != nil {
+			return err
+		}
+
+		session, err := getClientSessionBody(sessionBkt)
+		if err != nil {
+			return err
+		}
+
+		commits := sessionBkt.Bucket(cSessionCommits)
+		if commits == nil {
+			return ErrUninitializedDB
+		}
+
+		var seqNumBytes [2]byte
+		byteOrder.PutUint16(seqNumBytes[:], seqNum)
+		if commits.Get(seqNumBytes[:]) == nil {
+			return ErrCommittedUpdateNotFound
+		}
+
+		if err := commits.Delete(seqNumBytes[:]); err != nil {
+			return err
+		}
+
+		session.TowerLastApplied = lastApplied
+
+		return putClientSessionBody(sessionBkt, session)
+	})
+}
+
+// AddChanSweepPkScript records the sweep pkscript to be used in all justice
+// transactions for the given channel. An error is returned if the channel
+// already has a sweep pkscript.
+func (c *ClientDB) AddChanSweepPkScript(chanID lnwire.ChannelID,
+	pkScript []byte) error {
+
+	return c.Update(func(tx *bolt.Tx) error {
+		sweepPkScripts := tx.Bucket(cChanSweepPkScriptBkt)
+		if sweepPkScripts == nil {
+			return ErrUninitializedDB
+		}
+
+		if sweepPkScripts.Get(chanID[:]) != nil {
+			return ErrChanSweepPkScriptAlreadyExists
+		}
+
+		return sweepPkScripts.Put(chanID[:], pkScript)
+	})
+}
+
+// FetchChanSweepPkScripts returns the sweep pkscripts of all channels known to
+// the client.
+func (c *ClientDB) FetchChanSweepPkScripts() (map[lnwire.ChannelID][]byte,
+	error) {
+
+	pkScripts := make(map[lnwire.ChannelID][]byte)
+	err := c.View(func(tx *bolt.Tx) error {
+		sweepPkScripts := tx.Bucket(cChanSweepPkScriptBkt)
+		if sweepPkScripts == nil {
+			return ErrUninitializedDB
+		}
+
+		return sweepPkScripts.ForEach(func(k, v []byte) error {
+			var chanID lnwire.ChannelID
+			copy(chanID[:], k)
+
+			pkScript := make([]byte, len(v))
+			copy(pkScript, v)
+
+			pkScripts[chanID] = pkScript
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pkScripts, nil
+}
+
+// getClientSessionBkt returns the nested bucket of the client session with
+// the given id.
+func getClientSessionBkt(tx *bolt.Tx, id *SessionID) (*bolt.Bucket, error) {
+	sessions := tx.Bucket(cSessionBkt)
+	if sessions == nil {
+		return nil, ErrUninitializedDB
+	}
+
+	sessionBkt := sessions.Bucket(id[:])
+	if sessionBkt == nil {
+		return nil, ErrClientSessionNotFound
+	}
+
+	return sessionBkt, nil
+}
+
+// getClientSessionBody reads the client session body from the session's
+// bucket.
+func getClientSessionBody(sessionBkt *bolt.Bucket) (*ClientSession, error) {
+	sessionBytes := sessionBkt.Get(cSessionBody)
+	if sessionBytes == nil {
+		return nil, ErrClientSessionNotFound
+	}
+
+	var session ClientSession
+	err := session.Decode(bytes.NewReader(sessionBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// putClientSessionBody writes the client session body to the session's bucket.
+func putClientSessionBody(sessionBkt *bolt.Bucket,
+	session *ClientSession) error {
+
+	var b bytes.Buffer
+	if err := session.Encode(&b); err != nil {
+		return err
+	}
+
+	return sessionBkt.Put(cSessionBody, b.Bytes())
+}
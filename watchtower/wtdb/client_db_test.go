@@ -0,0 +1,186 @@
+package wtdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+func makeClientDB(t *testing.T) (*ClientDB, func()) {
+	path, err := ioutil.TempDir("", "clientdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	db, err := OpenClientDB(path)
+	if err != nil {
+		os.RemoveAll(path)
+		t.Fatalf("unable to open client db: %v", err)
+	}
+
+	cleanup := func() {
+		db.Close()
+		os.RemoveAll(path)
+	}
+
+	return db, cleanup
+}
+
+// TestClientDBSessionKeyIndex asserts that session key indexes are handed out
+// sequentially and never reused.
+func TestClientDBSessionKeyIndex(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := makeClientDB(t)
+	defer cleanup()
+
+	for i := uint32(0); i < 3; i++ {
+		index, err := db.NextSessionKeyIndex()
+		if err != nil {
+			t.Fatalf("unable to fetch session key index: %v", err)
+		}
+		if index != i {
+			t.Fatalf("expected index %d, got %d", i, index)
+		}
+	}
+}
+
+// TestClientDBSessions asserts that client sessions and their committed
+// updates are properly persisted, and that updates are removed once acked.
+func TestClientDBSessions(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := makeClientDB(t)
+	defer cleanup()
+
+	sessionPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate session key: %v", err)
+	}
+	towerPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate tower key: %v", err)
+	}
+
+	session := &ClientSession{
+		ID:              NewSessionIDFromPubKey(sessionPriv.PubKey()),
+		SessionKeyIndex: 7,
+		TowerPubKey:     towerPriv.PubKey(),
+		Policy:          wtpolicy.DefaultPolicy(),
+	}
+
+	update := &CommittedUpdate{
+		SeqNum:        1,
+		Hint:          makeHint(1),
+		EncryptedBlob: bytes.Repeat([]byte{0x01}, blob.Size),
+	}
+
+	// Committing an update for an unknown session should fail.
+	err = db.CommitUpdate(&session.ID, update)
+	if err != ErrClientSessionNotFound {
+		t.Fatalf("expected ErrClientSessionNotFound, got: %v", err)
+	}
+
+	if err := db.CreateClientSession(session); err != nil {
+		t.Fatalf("unable to create client session: %v", err)
+	}
+	err = db.CreateClientSession(session)
+	if err != ErrClientSessionAlreadyExists {
+		t.Fatalf("expected ErrClientSessionAlreadyExists, got: %v", err)
+	}
+
+	// Committing an update that skips a sequence number should fail.
+	update.SeqNum = 2
+	err = db.CommitUpdate(&session.ID, update)
+	if err != ErrCommitUnorderedUpdate {
+		t.Fatalf("expected ErrCommitUnorderedUpdate, got: %v", err)
+	}
+
+	update.SeqNum = 1
+	if err := db.CommitUpdate(&session.ID, update); err != nil {
+		t.Fatalf("unable to commit update: %v", err)
+	}
+
+	// The committed update should be returned along with the session.
+	sessions, err := db.ListClientSessions()
+	if err != nil {
+		t.Fatalf("unable to list client sessions: %v", err)
+	}
+	dbSession, ok := sessions[session.ID]
+	if !ok {
+		t.Fatalf("client session not found")
+	}
+	if dbSession.SeqNum != 1 {
+		t.Fatalf("expected seqnum 1, got %d", dbSession.SeqNum)
+	}
+	if !reflect.DeepEqual(dbSession.CommittedUpdates,
+		[]*CommittedUpdate{update}) {
+
+		t.Fatalf("committed updates mismatch, want: %v, got: %v",
+			update, dbSession.CommittedUpdates)
+	}
+
+	// Acking an unknown update should fail.
+	err = db.AckUpdate(&session.ID, 2, 1)
+	if err != ErrCommittedUpdateNotFound {
+		t.Fatalf("expected ErrCommittedUpdateNotFound, got: %v", err)
+	}
+
+	if err := db.AckUpdate(&session.ID, 1, 1); err != nil {
+		t.Fatalf("unable to ack update: %v", err)
+	}
+
+	sessions, err = db.ListClientSessions()
+	if err != nil {
+		t.Fatalf("unable to list client sessions: %v", err)
+	}
+	dbSession = sessions[session.ID]
+	if len(dbSession.CommittedUpdates) != 0 {
+		t.Fatalf("expected no committed updates, got %d",
+			len(dbSession.CommittedUpdates))
+	}
+	if dbSession.TowerLastApplied != 1 {
+		t.Fatalf("expected tower last applied 1, got %d",
+			dbSession.TowerLastApplied)
+	}
+	if !dbSession.TowerPubKey.IsEqual(session.TowerPubKey) {
+		t.Fatalf("tower pubkey mismatch")
+	}
+}
+
+// TestClientDBChanSweepPkScripts asserts that channel sweep pkscripts are
+// persisted and can't be overwritten.
+func TestClientDBChanSweepPkScripts(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := makeClientDB(t)
+	defer cleanup()
+
+	chanID := lnwire.ChannelID{0x01}
+	pkScript := []byte{0x00, 0x14, 0x01, 0x02}
+
+	if err := db.AddChanSweepPkScript(chanID, pkScript); err != nil {
+		t.Fatalf("unable to add sweep pkscript: %v", err)
+	}
+	err := db.AddChanSweepPkScript(chanID, pkScript)
+	if err != ErrChanSweepPkScriptAlreadyExists {
+		t.Fatalf("expected ErrChanSweepPkScriptAlreadyExists, "+
+			"got: %v", err)
+	}
+
+	pkScripts, err := db.FetchChanSweepPkScripts()
+	if err != nil {
+		t.Fatalf("unable to fetch sweep pkscripts: %v", err)
+	}
+	if !bytes.Equal(pkScripts[chanID], pkScript) {
+		t.Fatalf("sweep pkscript mismatch, want: %x, got: %x",
+			pkScript, pkScripts[chanID])
+	}
+}
@@ -0,0 +1,112 @@
+package wtdb
+
+import (
+	"io"
+
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// ClientSession encapsulates a session with a watchtower from the perspective
+// of the client. The session is identified by the public key of the session
+// key used to authenticate with the tower, which the client is able to
+// re-derive using SessionKeyIndex.
+type ClientSession struct {
+	// ID is the public key of the client's session key, and uniquely
+	// identifies the session to the tower.
+	ID SessionID
+
+	// SessionKeyIndex is the index within the tower session key family
+	// of the private key used to authenticate with the tower.
+	SessionKeyIndex uint32
+
+	// TowerPubKey is the static public key of the tower with which the
+	// session was negotiated.
+	TowerPubKey *btcec.PublicKey
+
+	// Policy holds the negotiated session parameters.
+	Policy wtpolicy.Policy
+
+	// SeqNum is the sequence number of the last update committed by the
+	// client within this session.
+	SeqNum uint16
+
+	// TowerLastApplied is the last sequence number the tower has
+	// acknowledged for this session.
+	TowerLastApplied uint16
+
+	// CommittedUpdates is the set of updates that have been committed by
+	// the client, but not yet acknowledged by the tower. These updates
+	// must be retransmitted to the tower before any new updates are sent.
+	//
+	// NOTE: This field is not serialized as part of the session body, and
+	// is only populated when listing sessions from the database.
+	CommittedUpdates []*CommittedUpdate
+}
+
+// Encode serializes the client session body into the given io.Writer.
+func (s *ClientSession) Encode(w io.Writer) error {
+	return writeElements(w,
+		s.ID,
+		s.SessionKeyIndex,
+		s.TowerPubKey.SerializeCompressed(),
+		s.Policy.MaxUpdates,
+		s.Policy.SweepFeeRate,
+		s.SeqNum,
+		s.TowerLastApplied,
+	)
+}
+
+// Decode deserializes the client session body from the given io.Reader.
+func (s *ClientSession) Decode(r io.Reader) error {
+	var towerPubKey []byte
+	err := readElements(r,
+		&s.ID,
+		&s.SessionKeyIndex,
+		&towerPubKey,
+		&s.Policy.MaxUpdates,
+		&s.Policy.SweepFeeRate,
+		&s.SeqNum,
+		&s.TowerLastApplied,
+	)
+	if err != nil {
+		return err
+	}
+
+	s.TowerPubKey, err = btcec.ParsePubKey(towerPubKey, btcec.S256())
+	return err
+}
+
+// CommittedUpdate holds a state update that the client has committed to
+// sending to the tower under a particular sequence number.
+type CommittedUpdate struct {
+	// SeqNum is the sequence number assigned to the update within the
+	// session.
+	SeqNum uint16
+
+	// Hint is the 16-byte prefix of the revoked commitment transaction.
+	Hint blob.BreachHint
+
+	// EncryptedBlob is the encrypted justice kit for the revoked
+	// commitment transaction.
+	EncryptedBlob []byte
+}
+
+// Encode serializes the committed update into the given io.Writer.
+func (u *CommittedUpdate) Encode(w io.Writer) error {
+	return writeElements(w,
+		u.SeqNum,
+		u.Hint,
+		u.EncryptedBlob,
+	)
+}
+
+// Decode deserializes the committed update from the given io.Reader.
+func (u *CommittedUpdate) Decode(r io.Reader) error {
+	return readElements(r,
+		&u.SeqNum,
+		&u.Hint,
+		&u.EncryptedBlob,
+	)
+}
@@ -0,0 +1,142 @@
+package wtdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+)
+
+// byteOrder is the default endianness used when serializing integers.
+var byteOrder = binary.BigEndian
+
+// writeElement serializes a single element into the passed io.Writer.
+func writeElement(w io.Writer, element interface{}) error {
+	switch e := element.(type) {
+	case uint16:
+		var b [2]byte
+		byteOrder.PutUint16(b[:], e)
+		if _, err := w.Write(b[:]); err != nil {
+			return err
+		}
+
+	case uint32:
+		var b [4]byte
+		byteOrder.PutUint32(b[:], e)
+		if _, err := w.Write(b[:]); err != nil {
+			return err
+		}
+
+	case lnwallet.SatPerKWeight:
+		var b [8]byte
+		byteOrder.PutUint64(b[:], uint64(e))
+		if _, err := w.Write(b[:]); err != nil {
+			return err
+		}
+
+	case SessionID:
+		if _, err := w.Write(e[:]); err != nil {
+			return err
+		}
+
+	case blob.BreachHint:
+		if _, err := w.Write(e[:]); err != nil {
+			return err
+		}
+
+	case []byte:
+		if len(e) > 0xffff {
+			return fmt.Errorf("byte slice of length %d is too "+
+				"large to encode", len(e))
+		}
+
+		if err := writeElement(w, uint16(len(e))); err != nil {
+			return err
+		}
+		if _, err := w.Write(e); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown type in writeElement: %T", e)
+	}
+
+	return nil
+}
+
+// writeElements serializes a variable number of elements into the passed
+// io.Writer.
+func writeElements(w io.Writer, elements ...interface{}) error {
+	for _, element := range elements {
+		if err := writeElement(w, element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readElement deserializes a single element from the passed io.Reader.
+func readElement(r io.Reader, element interface{}) error {
+	switch e := element.(type) {
+	case *uint16:
+		var b [2]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return err
+		}
+		*e = byteOrder.Uint16(b[:])
+
+	case *uint32:
+		var b [4]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return err
+		}
+		*e = byteOrder.Uint32(b[:])
+
+	case *lnwallet.SatPerKWeight:
+		var b [8]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return err
+		}
+		*e = lnwallet.SatPerKWeight(byteOrder.Uint64(b[:]))
+
+	case *SessionID:
+		if _, err := io.ReadFull(r, e[:]); err != nil {
+			return err
+		}
+
+	case *blob.BreachHint:
+		if _, err := io.ReadFull(r, e[:]); err != nil {
+			return err
+		}
+
+	case *[]byte:
+		var length uint16
+		if err := readElement(r, &length); err != nil {
+			return err
+		}
+
+		b := make([]byte, length)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return err
+		}
+		*e = b
+
+	default:
+		return fmt.Errorf("unknown type in readElement: %T", e)
+	}
+
+	return nil
+}
+
+// readElements deserializes a variable number of elements from the passed
+// io.Reader.
+func readElements(r io.Reader, elements ...interface{}) error {
+	for _, element := range elements {
+		if err := readElement(r, element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
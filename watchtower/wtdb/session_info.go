@@ -0,0 +1,133 @@
+package wtdb
+
+import (
+	"errors"
+	"io"
+
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+)
+
+var (
+	// ErrSessionConsumed is returned if the client tries to send a sequence
+	// number larger than the session's max number of updates.
+	ErrSessionConsumed = errors.New("all session updates have been " +
+		"consumed")
+
+	// ErrUpdateOutOfOrder is returned when the sequence number is not equal
+	// to the server's LastApplied+1.
+	ErrUpdateOutOfOrder = errors.New("update sequence number is not " +
+		"sequential")
+
+	// ErrLastAppliedReversion is returned when the client echos a
+	// last-applied value that is less than it claimed in a prior update.
+	ErrLastAppliedReversion = errors.New("update last applied must be " +
+		"non-decreasing")
+
+	// ErrSeqNumAlreadyApplied is returned when the client sends a sequence
+	// number for which they already claim to have an ACK.
+	ErrSeqNumAlreadyApplied = errors.New("update sequence number has " +
+		"already been applied")
+)
+
+// SessionInfo holds the negotiated session parameters for a single session id,
+// and handles the acceptance and validation of state updates sent by the
+// client.
+type SessionInfo struct {
+	// ID is the remote public key of the watchtower client.
+	ID SessionID
+
+	// Policy holds the negotiated session parameters.
+	Policy wtpolicy.Policy
+
+	// LastApplied is the sequence number of the last successful state update.
+	LastApplied uint16
+
+	// ClientLastApplied is the last last-applied the client has echoed
+	// back.
+	ClientLastApplied uint16
+}
+
+// Encode serializes the session info to the given io.Writer.
+func (s *SessionInfo) Encode(w io.Writer) error {
+	return writeElements(w,
+		s.ID,
+		s.Policy.MaxUpdates,
+		s.Policy.SweepFeeRate,
+		s.LastApplied,
+		s.ClientLastApplied,
+	)
+}
+
+// Decode deserializes the session info from the given io.Reader.
+func (s *SessionInfo) Decode(r io.Reader) error {
+	return readElements(r,
+		&s.ID,
+		&s.Policy.MaxUpdates,
+		&s.Policy.SweepFeeRate,
+		&s.LastApplied,
+		&s.ClientLastApplied,
+	)
+}
+
+// AcceptUpdateSequence validates that a state update's sequence number and
+// last applied are valid given our past history with the client. These checks
+// ensure that clients are properly in sync and following the update protocol
+// properly. If validation is successful, the receiver's LastApplied and
+// ClientLastApplied are updated with the latest values presented by the
+// client. Any errors returned from this method should be handled by the
+// caller, and may need to be reported to the client.
+func (s *SessionInfo) AcceptUpdateSequence(seqNum, lastApplied uint16) error {
+	switch {
+
+	// Client already claims to have an ACK for this seqnum.
+	case seqNum <= lastApplied:
+		return ErrSeqNumAlreadyApplied
+
+	// Client echos a last applied that is lower than previously sent.
+	case lastApplied < s.ClientLastApplied:
+		return ErrLastAppliedReversion
+
+	// Client update exceeds capacity of session.
+	case seqNum > s.Policy.MaxUpdates:
+		return ErrSessionConsumed
+
+	// Client update does not match our expected next seqnum.
+	case seqNum != s.LastApplied+1:
+		return ErrUpdateOutOfOrder
+	}
+
+	s.LastApplied = seqNum
+	s.ClientLastApplied = lastApplied
+
+	return nil
+}
+
+// Match is returned in response to a database query for the breach hints
+// contained in a particular block. The match encapsulates all data required to
+// properly decrypt a client's encrypted blob, and pursue action on behalf of
+// the victim by reconstructing the justice transaction and broadcasting it to
+// the network.
+//
+// NOTE: It is possible for a match to cause a false positive, since they are
+// matched on a prefix of the txid. In such an event, the likely behavior is
+// that the payload will fail to decrypt.
+type Match struct {
+	// ID is the session id of the client who uploaded the state update.
+	ID SessionID
+
+	// SeqNum is the session sequence number occupied by the client's state
+	// update. Together with ID, this uniquely identifies the update.
+	SeqNum uint16
+
+	// Hint is the breach hint that triggered the match.
+	Hint blob.BreachHint
+
+	// EncryptedBlob is the encrypted payload containing the justice kit
+	// uploaded by the client.
+	EncryptedBlob []byte
+
+	// SessionInfo is the contract negotiated between tower and client, that
+	// provides input parameters such as fee rate and the sweep policy.
+	SessionInfo *SessionInfo
+}
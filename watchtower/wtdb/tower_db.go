@@ -0,0 +1,390 @@
+package wtdb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+const (
+	// towerDBName is the filename of tower database.
+	towerDBName = "watchtower.db"
+
+	// dbFilePermission is the permission used when creating the database
+	// files.
+	dbFilePermission = 0600
+)
+
+var (
+	// sessionsBkt is a bucket containing all negotiated client sessions.
+	//  session id -> session
+	sessionsBkt = []byte("sessions-bucket")
+
+	// updatesBkt is a bucket containing all state updates sent by clients.
+	// The updates are indexed by the breach hint, allowing the lookout to
+	// efficiently query for updates matching transactions in a block.
+	//  breach hint -> session id || seqnum -> encrypted blob
+	updatesBkt = []byte("updates-bucket")
+
+	// lookoutTipBkt is a bucket containing the last block epoch processed
+	// by the lookout subsystem.
+	lookoutTipBkt = []byte("lookout-tip-bucket")
+
+	// lookoutTipKey is the key under which the lookout's tip is stored.
+	lookoutTipKey = []byte("lookout-tip")
+
+	// ErrUninitializedDB signals that top-level buckets for the database
+	// have not been initialized.
+	ErrUninitializedDB = errors.New("db not initialized")
+
+	// ErrSessionNotFound is returned when querying by session id for a
+	// session that does not exist.
+	ErrSessionNotFound = errors.New("session not found in db")
+
+	// ErrSessionAlreadyExists signals that a session creation failed
+	// because a session with the same session id already exists.
+	ErrSessionAlreadyExists = errors.New("session already exists")
+)
+
+// TowerDB is a bolt-backed database used by the watchtower server to persist
+// negotiated client sessions, the encrypted state updates sent within each
+// session, and the chain tip processed by the lookout.
+type TowerDB struct {
+	*bolt.DB
+
+	dbPath string
+}
+
+// OpenTowerDB opens the tower database found in the given directory, creating
+// the directory and initializing the top-level buckets if necessary.
+func OpenTowerDB(dbPath string) (*TowerDB, error) {
+	if err := os.MkdirAll(dbPath, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dbPath, towerDBName)
+	bdb, err := bolt.Open(path, dbFilePermission, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bdb.Update(func(tx *bolt.Tx) error {
+		topLevelBuckets := [][]byte{
+			sessionsBkt, updatesBkt, lookoutTipBkt,
+		}
+		for _, bucket := range topLevelBuckets {
+			_, err := tx.CreateBucketIfNotExists(bucket)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		bdb.Close()
+		return nil, err
+	}
+
+	return &TowerDB{
+		DB:     bdb,
+		dbPath: dbPath,
+	}, nil
+}
+
+// Path returns the directory containing the tower database.
+func (t *TowerDB) Path() string {
+	return t.dbPath
+}
+
+// GetSessionInfo retrieves the session for the passed session id. An error is
+// returned if the session could not be found.
+func (t *TowerDB) GetSessionInfo(id *SessionID) (*SessionInfo, error) {
+	var session *SessionInfo
+	err := t.View(func(tx *bolt.Tx) error {
+		sessions := tx.Bucket(sessionsBkt)
+		if sessions == nil {
+			return ErrUninitializedDB
+		}
+
+		var err error
+		session, err = getSession(sessions, id[:])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// InsertSessionInfo records a negotiated session in the tower database. An
+// error is returned if the session already exists.
+func (t *TowerDB) InsertSessionInfo(session *SessionInfo) error {
+	return t.Update(func(tx *bolt.Tx) error {
+		sessions := tx.Bucket(sessionsBkt)
+		if sessions == nil {
+			return ErrUninitializedDB
+		}
+
+		if sessions.Get(session.ID[:]) != nil {
+			return ErrSessionAlreadyExists
+		}
+
+		return putSession(sessions, session)
+	})
+}
+
+// InsertStateUpdate stores an update sent by the client after validating that
+// the update is well-formed in the context of other updates sent for the same
+// session. This includes verifying that the sequence number is incremented
+// properly and the last applied values echoed by the client are sane. On
+// success, the session's new last applied value is returned.
+func (t *TowerDB) InsertStateUpdate(
+	update *SessionStateUpdate) (uint16, error) {
+
+	var lastApplied uint16
+	err := t.Update(func(tx *bolt.Tx) error {
+		sessions := tx.Bucket(sessionsBkt)
+		if sessions == nil {
+			return ErrUninitializedDB
+		}
+
+		updates := tx.Bucket(updatesBkt)
+		if updates == nil {
+			return ErrUninitializedDB
+		}
+
+		// Fetch the session corresponding to the update's session id.
+		// This will be used to validate that the update's sequence
+		// number and last applied values are sensible.
+		session, err := getSession(sessions, update.ID[:])
+		if err != nil {
+			return err
+		}
+
+		// Assert that the update is properly sequenced with respect to
+		// the session's prior updates. On success, the session's last
+		// applied values will be advanced.
+		err = session.AcceptUpdateSequence(
+			update.SeqNum, update.LastApplied,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Store the update in the hint index, keyed by the session id
+		// and sequence number so that multiple sessions may upload
+		// blobs for the same breach hint.
+		hintUpdates, err := updates.CreateBucketIfNotExists(
+			update.Hint[:],
+		)
+		if err != nil {
+			return err
+		}
+
+		updateKey := makeUpdateKey(&update.ID, update.SeqNum)
+		err = hintUpdates.Put(updateKey, update.EncryptedBlob)
+		if err != nil {
+			return err
+		}
+
+		// Finally, persist the session with its advanced last applied
+		// values.
+		if err := putSession(sessions, session); err != nil {
+			return err
+		}
+
+		lastApplied = session.LastApplied
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return lastApplied, nil
+}
+
+// QueryMatches searches against all known state updates for any that match the
+// passed breach hints. More than one Match will be returned for a given hint
+// if they exist in the database.
+func (t *TowerDB) QueryMatches(breachHints []blob.BreachHint) ([]Match, error) {
+	var matches []Match
+	err := t.View(func(tx *bolt.Tx) error {
+		sessions := tx.Bucket(sessionsBkt)
+		if sessions == nil {
+			return ErrUninitializedDB
+		}
+
+		updates := tx.Bucket(updatesBkt)
+		if updates == nil {
+			return ErrUninitializedDB
+		}
+
+		// Cache the sessions that we've already loaded, as it's likely
+		// that a block contains several hints from the same client.
+		sessionCache := make(map[SessionID]*SessionInfo)
+
+		for _, hint := range breachHints {
+			hintUpdates := updates.Bucket(hint[:])
+			if hintUpdates == nil {
+				continue
+			}
+
+			err := hintUpdates.ForEach(func(k, v []byte) error {
+				id, seqNum := parseUpdateKey(k)
+
+				session, ok := sessionCache[id]
+				if !ok {
+					var err error
+					session, err = getSession(
+						sessions, id[:],
+					)
+					if err != nil {
+						return err
+					}
+					sessionCache[id] = session
+				}
+
+				encryptedBlob := make([]byte, len(v))
+				copy(encryptedBlob, v)
+
+				matches = append(matches, Match{
+					ID:            id,
+					SeqNum:        seqNum,
+					Hint:          hint,
+					EncryptedBlob: encryptedBlob,
+					SessionInfo:   session,
+				})
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// SetLookoutTip stores the provided epoch as the latest lookout tip epoch in
+// the tower database.
+func (t *TowerDB) SetLookoutTip(epoch *chainntnfs.BlockEpoch) error {
+	return t.Update(func(tx *bolt.Tx) error {
+		lookoutTip := tx.Bucket(lookoutTipBkt)
+		if lookoutTip == nil {
+			return ErrUninitializedDB
+		}
+
+		var b bytes.Buffer
+		if _, err := b.Write(epoch.Hash[:]); err != nil {
+			return err
+		}
+		err := writeElement(&b, uint32(epoch.Height))
+		if err != nil {
+			return err
+		}
+
+		return lookoutTip.Put(lookoutTipKey, b.Bytes())
+	})
+}
+
+// GetLookoutTip retrieves the current lookout tip block epoch from the tower
+// database. If no tip has been recorded, a nil epoch is returned.
+func (t *TowerDB) GetLookoutTip() (*chainntnfs.BlockEpoch, error) {
+	var epoch *chainntnfs.BlockEpoch
+	err := t.View(func(tx *bolt.Tx) error {
+		lookoutTip := tx.Bucket(lookoutTipBkt)
+		if lookoutTip == nil {
+			return ErrUninitializedDB
+		}
+
+		tipBytes := lookoutTip.Get(lookoutTipKey)
+		if tipBytes == nil {
+			return nil
+		}
+
+		r := bytes.NewReader(tipBytes)
+
+		var hash chainhash.Hash
+		if _, err := io.ReadFull(r, hash[:]); err != nil {
+			return err
+		}
+
+		var height uint32
+		if err := readElement(r, &height); err != nil {
+			return err
+		}
+
+		epoch = &chainntnfs.BlockEpoch{
+			Hash:   &hash,
+			Height: int32(height),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return epoch, nil
+}
+
+// getSession retrieves the session info for the given session id from the
+// sessions bucket.
+func getSession(sessions *bolt.Bucket, id []byte) (*SessionInfo, error) {
+	sessionBytes := sessions.Get(id)
+	if sessionBytes == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	var session SessionInfo
+	err := session.Decode(bytes.NewReader(sessionBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// putSession serializes the session info and writes it to the sessions
+// bucket.
+func putSession(sessions *bolt.Bucket, session *SessionInfo) error {
+	var b bytes.Buffer
+	if err := session.Encode(&b); err != nil {
+		return err
+	}
+
+	return sessions.Put(session.ID[:], b.Bytes())
+}
+
+// makeUpdateKey returns the key used to store a state update within a hint's
+// bucket: session id || seqnum.
+func makeUpdateKey(id *SessionID, seqNum uint16) []byte {
+	var key [SessionIDSize + 2]byte
+	copy(key[:SessionIDSize], id[:])
+	byteOrder.PutUint16(key[SessionIDSize:], seqNum)
+	return key[:]
+}
+
+// parseUpdateKey parses the session id and sequence number from a key created
+// by makeUpdateKey.
+func parseUpdateKey(key []byte) (SessionID, uint16) {
+	var id SessionID
+	copy(id[:], key[:SessionIDSize])
+	return id, byteOrder.Uint16(key[SessionIDSize:])
+}
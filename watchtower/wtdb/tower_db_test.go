@@ -0,0 +1,221 @@
+package wtdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+func makeTowerDB(t *testing.T) (*TowerDB, func()) {
+	path, err := ioutil.TempDir("", "towerdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	db, err := OpenTowerDB(path)
+	if err != nil {
+		os.RemoveAll(path)
+		t.Fatalf("unable to open tower db: %v", err)
+	}
+
+	cleanup := func() {
+		db.Close()
+		os.RemoveAll(path)
+	}
+
+	return db, cleanup
+}
+
+func makeSessionID(i byte) SessionID {
+	var id SessionID
+	id[0] = 0x02
+	id[1] = i
+	return id
+}
+
+func makeHint(i byte) blob.BreachHint {
+	var hint blob.BreachHint
+	hint[0] = i
+	return hint
+}
+
+// TestTowerDBSessions asserts that sessions can be inserted and retrieved from
+// the tower database, and that duplicate sessions are rejected.
+func TestTowerDBSessions(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := makeTowerDB(t)
+	defer cleanup()
+
+	id := makeSessionID(1)
+	if _, err := db.GetSessionInfo(&id); err != ErrSessionNotFound {
+		t.Fatalf("expected ErrSessionNotFound, got: %v", err)
+	}
+
+	session := &SessionInfo{
+		ID:     id,
+		Policy: wtpolicy.DefaultPolicy(),
+	}
+	if err := db.InsertSessionInfo(session); err != nil {
+		t.Fatalf("unable to insert session: %v", err)
+	}
+
+	err := db.InsertSessionInfo(session)
+	if err != ErrSessionAlreadyExists {
+		t.Fatalf("expected ErrSessionAlreadyExists, got: %v", err)
+	}
+
+	session2, err := db.GetSessionInfo(&id)
+	if err != nil {
+		t.Fatalf("unable to fetch session: %v", err)
+	}
+	if !reflect.DeepEqual(session, session2) {
+		t.Fatalf("session mismatch, want: %v, got: %v",
+			session, session2)
+	}
+}
+
+// TestTowerDBStateUpdates asserts that state updates are validated against the
+// session's sequence numbers before being stored, and that stored updates can
+// be found by their breach hints.
+func TestTowerDBStateUpdates(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := makeTowerDB(t)
+	defer cleanup()
+
+	id := makeSessionID(1)
+	session := &SessionInfo{
+		ID: id,
+		Policy: wtpolicy.Policy{
+			MaxUpdates:   2,
+			SweepFeeRate: wtpolicy.DefaultSweepFeeRate,
+		},
+	}
+
+	update := &SessionStateUpdate{
+		ID:            id,
+		SeqNum:        1,
+		Hint:          makeHint(1),
+		EncryptedBlob: bytes.Repeat([]byte{0x01}, blob.Size),
+	}
+
+	// Updates for an unknown session should be rejected.
+	if _, err := db.InsertStateUpdate(update); err != ErrSessionNotFound {
+		t.Fatalf("expected ErrSessionNotFound, got: %v", err)
+	}
+
+	if err := db.InsertSessionInfo(session); err != nil {
+		t.Fatalf("unable to insert session: %v", err)
+	}
+
+	lastApplied, err := db.InsertStateUpdate(update)
+	if err != nil {
+		t.Fatalf("unable to insert state update: %v", err)
+	}
+	if lastApplied != 1 {
+		t.Fatalf("expected last applied of 1, got %d", lastApplied)
+	}
+
+	// Replaying the same update should fail, as it is out of order.
+	_, err = db.InsertStateUpdate(update)
+	if err != ErrUpdateOutOfOrder {
+		t.Fatalf("expected ErrUpdateOutOfOrder, got: %v", err)
+	}
+
+	update2 := &SessionStateUpdate{
+		ID:            id,
+		SeqNum:        2,
+		LastApplied:   1,
+		Hint:          makeHint(2),
+		EncryptedBlob: bytes.Repeat([]byte{0x02}, blob.Size),
+	}
+	if _, err := db.InsertStateUpdate(update2); err != nil {
+		t.Fatalf("unable to insert state update: %v", err)
+	}
+
+	// The session only permits two updates, so a third should be
+	// rejected.
+	update3 := &SessionStateUpdate{
+		ID:            id,
+		SeqNum:        3,
+		LastApplied:   2,
+		Hint:          makeHint(3),
+		EncryptedBlob: bytes.Repeat([]byte{0x03}, blob.Size),
+	}
+	_, err = db.InsertStateUpdate(update3)
+	if err != ErrSessionConsumed {
+		t.Fatalf("expected ErrSessionConsumed, got: %v", err)
+	}
+
+	// Finally, query for the hints of all three updates. Only the first
+	// two should be returned.
+	matches, err := db.QueryMatches([]blob.BreachHint{
+		update.Hint, update2.Hint, update3.Hint,
+	})
+	if err != nil {
+		t.Fatalf("unable to query matches: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+
+	for i, expUpdate := range []*SessionStateUpdate{update, update2} {
+		match := matches[i]
+		if match.ID != expUpdate.ID {
+			t.Fatalf("match %d session id mismatch", i)
+		}
+		if match.SeqNum != expUpdate.SeqNum {
+			t.Fatalf("match %d seqnum mismatch, want: %d, got: %d",
+				i, expUpdate.SeqNum, match.SeqNum)
+		}
+		if match.Hint != expUpdate.Hint {
+			t.Fatalf("match %d hint mismatch", i)
+		}
+		if !bytes.Equal(match.EncryptedBlob, expUpdate.EncryptedBlob) {
+			t.Fatalf("match %d blob mismatch", i)
+		}
+		if match.SessionInfo.Policy != session.Policy {
+			t.Fatalf("match %d policy mismatch", i)
+		}
+	}
+}
+
+// TestTowerDBLookoutTip asserts that the lookout tip is properly persisted.
+func TestTowerDBLookoutTip(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := makeTowerDB(t)
+	defer cleanup()
+
+	tip, err := db.GetLookoutTip()
+	if err != nil {
+		t.Fatalf("unable to fetch lookout tip: %v", err)
+	}
+	if tip != nil {
+		t.Fatalf("expected nil lookout tip, got: %v", tip)
+	}
+
+	epoch := &chainntnfs.BlockEpoch{
+		Hash:   &chainhash.Hash{0x01, 0x02, 0x03},
+		Height: 500000,
+	}
+	if err := db.SetLookoutTip(epoch); err != nil {
+		t.Fatalf("unable to set lookout tip: %v", err)
+	}
+
+	tip, err = db.GetLookoutTip()
+	if err != nil {
+		t.Fatalf("unable to fetch lookout tip: %v", err)
+	}
+	if !reflect.DeepEqual(epoch, tip) {
+		t.Fatalf("lookout tip mismatch, want: %v, got: %v", epoch, tip)
+	}
+}
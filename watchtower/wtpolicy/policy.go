@@ -0,0 +1,115 @@
+package wtpolicy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// DefaultMaxUpdates specifies the number of encrypted blobs a client
+	// can send to the tower in a single session.
+	DefaultMaxUpdates = 1024
+
+	// DefaultSweepFeeRate specifies the fee rate used to construct justice
+	// transactions. The value is expressed in satoshis per kilo-weight.
+	DefaultSweepFeeRate = 3000
+
+	// MinSweepFeeRate is the minimum sweep fee rate a policy may specify.
+	// This corresponds to the minimum relay fee of 1 sat/vbyte, rounded up
+	// to account for the flooring performed when computing fees.
+	MinSweepFeeRate lnwallet.SatPerKWeight = 253
+)
+
+var (
+	// ErrFeeExceedsInputs signals that the total input value of justice
+	// transaction is not sufficient to pay the required fee.
+	ErrFeeExceedsInputs = errors.New("sweep fee exceeds input values")
+
+	// ErrCreatesDust signals that the session's policy would create a dust
+	// output for the victim.
+	ErrCreatesDust = errors.New("justice transaction creates dust at fee rate")
+
+	// ErrNoMaxUpdates signals that the policy doesn't allow any updates to
+	// be sent within a session.
+	ErrNoMaxUpdates = errors.New("max updates must be positive")
+
+	// ErrSweepFeeRateTooLow signals that the policy's fee rate is too low
+	// to get into the mempool during low congestion.
+	ErrSweepFeeRateTooLow = errors.New("sweep fee rate too low")
+)
+
+// Policy defines the negotiated parameters for a session between a client and
+// server. The parameters specify the format of encrypted blobs sent to the
+// tower, the maximum number of updates, and the fee rate used to construct
+// justice transactions.
+type Policy struct {
+	// MaxUpdates is the maximum number of updates the watchtower will honor
+	// for this session.
+	MaxUpdates uint16
+
+	// SweepFeeRate expresses the intended fee rate to be used when
+	// constructing the justice transaction. All sweep transactions created
+	// for this session must use this value during construction, and the
+	// signatures must implicitly commit to the resulting output values.
+	SweepFeeRate lnwallet.SatPerKWeight
+}
+
+// DefaultPolicy returns a Policy containing the default parameters that can be
+// used by clients or servers.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxUpdates:   DefaultMaxUpdates,
+		SweepFeeRate: DefaultSweepFeeRate,
+	}
+}
+
+// String returns a human-readable description of the current policy.
+func (p Policy) String() string {
+	return fmt.Sprintf("(max-updates=%d sweep-fee-rate=%d)",
+		p.MaxUpdates, p.SweepFeeRate)
+}
+
+// Validate ensures that the policy satisfies some minimal correctness
+// constraints.
+func (p Policy) Validate() error {
+	if p.MaxUpdates == 0 {
+		return ErrNoMaxUpdates
+	}
+
+	if p.SweepFeeRate < MinSweepFeeRate {
+		return ErrSweepFeeRateTooLow
+	}
+
+	return nil
+}
+
+// ComputeJusticeTxOuts constructs the justice transaction's outputs for the
+// given total input amount and estimated weight of the transaction. The
+// transaction will contain a single output paying all funds, minus the fee
+// computed using the policy's sweep fee rate, to the sweep pkscript. If the
+// resulting output would be dust, or the fee exceeds the input value, then an
+// error is returned.
+func (p Policy) ComputeJusticeTxOuts(totalAmt btcutil.Amount, txWeight int64,
+	sweepPkScript []byte) ([]*wire.TxOut, error) {
+
+	txFee := p.SweepFeeRate.FeeForWeight(txWeight)
+	if txFee > totalAmt {
+		return nil, ErrFeeExceedsInputs
+	}
+
+	sweepAmt := totalAmt - txFee
+	if sweepAmt <= lnwallet.DefaultDustLimit() {
+		return nil, ErrCreatesDust
+	}
+
+	return []*wire.TxOut{
+		{
+			PkScript: sweepPkScript,
+			Value:    int64(sweepAmt),
+		},
+	}, nil
+}
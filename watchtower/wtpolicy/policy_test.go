@@ -0,0 +1,73 @@
+package wtpolicy
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestPolicyValidate asserts that a policy is only considered valid if it
+// permits at least one update and its sweep fee rate is above the minimum.
+func TestPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	if err := DefaultPolicy().Validate(); err != nil {
+		t.Fatalf("default policy should be valid: %v", err)
+	}
+
+	policy := DefaultPolicy()
+	policy.MaxUpdates = 0
+	if err := policy.Validate(); err != ErrNoMaxUpdates {
+		t.Fatalf("expected ErrNoMaxUpdates, got: %v", err)
+	}
+
+	policy = DefaultPolicy()
+	policy.SweepFeeRate = MinSweepFeeRate - 1
+	if err := policy.Validate(); err != ErrSweepFeeRateTooLow {
+		t.Fatalf("expected ErrSweepFeeRateTooLow, got: %v", err)
+	}
+}
+
+// TestComputeJusticeTxOuts asserts that the justice transaction's output pays
+// the total input value minus the fee computed using the policy's fee rate,
+// and that outputs which can't pay the fee or would be dust are rejected.
+func TestComputeJusticeTxOuts(t *testing.T) {
+	t.Parallel()
+
+	const txWeight = 1000
+
+	policy := Policy{
+		MaxUpdates:   DefaultMaxUpdates,
+		SweepFeeRate: lnwallet.SatPerKWeight(1000),
+	}
+	sweepPkScript := []byte{0x00, 0x14}
+
+	txOuts, err := policy.ComputeJusticeTxOuts(
+		btcutil.Amount(100000), txWeight, sweepPkScript,
+	)
+	if err != nil {
+		t.Fatalf("unable to compute justice tx outs: %v", err)
+	}
+	if len(txOuts) != 1 {
+		t.Fatalf("expected 1 output, got %d", len(txOuts))
+	}
+	if txOuts[0].Value != 99000 {
+		t.Fatalf("expected sweep value of %d, got %d", 99000,
+			txOuts[0].Value)
+	}
+
+	_, err = policy.ComputeJusticeTxOuts(
+		btcutil.Amount(999), txWeight, sweepPkScript,
+	)
+	if err != ErrFeeExceedsInputs {
+		t.Fatalf("expected ErrFeeExceedsInputs, got: %v", err)
+	}
+
+	_, err = policy.ComputeJusticeTxOuts(
+		btcutil.Amount(1001), txWeight, sweepPkScript,
+	)
+	if err != ErrCreatesDust {
+		t.Fatalf("expected ErrCreatesDust, got: %v", err)
+	}
+}
@@ -0,0 +1,551 @@
+package wtserver
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/lightningnetwork/lnd/watchtower/wtwire"
+	"github.com/roasbeef/btcd/connmgr"
+)
+
+var (
+	// ErrPeerAlreadyConnected signals that a peer with the same session id
+	// is already active within the server.
+	ErrPeerAlreadyConnected = fmt.Errorf("peer already connected")
+)
+
+// Config abstracts the primary components and dependencies of the server.
+type Config struct {
+	// DB provides persistent access to the server's sessions and for
+	// storing state updates.
+	DB DB
+
+	// Listeners specifies the listeners on which clients may connect.
+	Listeners []net.Listener
+
+	// ReadTimeout specifies how long a client may go without sending a
+	// message.
+	ReadTimeout time.Duration
+
+	// WriteTimeout specifies how long a client may go without reading a
+	// message from the other end, if the connection has stopped buffering
+	// the server's replies.
+	WriteTimeout time.Duration
+}
+
+// Server houses the state required to handle watchtower peers. Its primary
+// job is to accept client connections, and dispatch the messages they send
+// to the appropriate session handlers.
+type Server struct {
+	started int32 // atomic
+	stopped int32 // atomic
+
+	cfg *Config
+
+	connMgr *connmgr.ConnManager
+
+	clientMtx sync.RWMutex
+	clients   map[wtdb.SessionID]Peer
+
+	newPeers chan Peer
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// New creates a new server to handle watchtower clients. The server will
+// accept clients connecting to the listener addresses, and allows them to
+// open sessions and send state updates.
+func New(cfg *Config) (*Server, error) {
+	s := &Server{
+		cfg:      cfg,
+		clients:  make(map[wtdb.SessionID]Peer),
+		newPeers: make(chan Peer),
+		quit:     make(chan struct{}),
+	}
+
+	connMgr, err := connmgr.New(&connmgr.Config{
+		Listeners: cfg.Listeners,
+		OnAccept:  s.inboundPeerConnected,
+		Dial:      noDial,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.connMgr = connMgr
+
+	return s, nil
+}
+
+// noDial is a dummy dial method passed to the server's connmgr.
+func noDial(_ net.Addr) (net.Conn, error) {
+	return nil, fmt.Errorf("watchtower cannot make outgoing conns")
+}
+
+// Start begins listening on the server's listeners.
+func (s *Server) Start() error {
+	// Already running?
+	if !atomic.CompareAndSwapInt32(&s.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Starting watchtower server")
+
+	s.connMgr.Start()
+
+	s.wg.Add(1)
+	go s.peerHandler()
+
+	log.Infof("Watchtower server started successfully")
+
+	return nil
+}
+
+// Stop shuts down the server's listeners and any active requests.
+func (s *Server) Stop() error {
+	// Bail if we're already shutting down.
+	if !atomic.CompareAndSwapInt32(&s.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Stopping watchtower server")
+
+	s.connMgr.Stop()
+
+	close(s.quit)
+	s.wg.Wait()
+
+	log.Infof("Watchtower server stopped successfully")
+
+	return nil
+}
+
+// inboundPeerConnected is the callback given to the connection manager, and is
+// called each time a new connection is made to the watchtower. This method
+// proxies the new peers by filtering out those that do not satisfy the
+// server.Peer interface, and closes their connection. Successful connections
+// will be passed on to the public InboundPeerConnected method.
+func (s *Server) inboundPeerConnected(c net.Conn) {
+	peer, ok := c.(Peer)
+	if !ok {
+		log.Warnf("incoming connection %T does not satisfy "+
+			"server.Peer interface", c)
+		c.Close()
+		return
+	}
+
+	s.InboundPeerConnected(peer)
+}
+
+// InboundPeerConnected accepts a server.Peer, and handles the request submitted
+// by the client. This method serves also as a public endpoint for locally
+// registering new clients with the server.
+func (s *Server) InboundPeerConnected(peer Peer) {
+	select {
+	case s.newPeers <- peer:
+	case <-s.quit:
+	}
+}
+
+// peerHandler processes newly accepted peers and spawns a client handler for
+// each. The peerHandler is used to ensure that waitgrouped client handlers are
+// spawned from a waitgrouped goroutine.
+func (s *Server) peerHandler() {
+	defer s.wg.Done()
+	defer s.removeAllPeers()
+
+	for {
+		select {
+		case peer := <-s.newPeers:
+			s.wg.Add(1)
+			go s.handleClient(peer)
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// handleClient processes a series of watchtower messages sent by a client. The
+// client may either send:
+//   - a single CreateSession message.
+//   - a series of StateUpdate messages.
+//
+// This method uses the server's peer map to ensure at most one peer using the
+// same session id can enter the main event loop. The connection will be
+// dropped by the watchtower if no messages are sent or received by the
+// configured Read/WriteTimeouts.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (s *Server) handleClient(peer Peer) {
+	defer s.wg.Done()
+
+	// Use the connection's remote pubkey as the client's session id.
+	id := wtdb.NewSessionIDFromPubKey(peer.RemotePub())
+
+	// Register this peer in the server's client map, and defer the
+	// connection's cleanup.
+	err := s.addPeer(&id, peer)
+	if err != nil {
+		log.Errorf("Unable to add peer %s: %v", id, err)
+		peer.Close()
+		return
+	}
+	defer s.removePeer(&id, peer.RemoteAddr())
+
+	for {
+		select {
+		case <-s.quit:
+			return
+		default:
+		}
+
+		nextMsg, err := s.readMessage(peer)
+		if err != nil {
+			log.Errorf("Unable to read watchtower msg from %s: %v",
+				id, err)
+			return
+		}
+
+		switch msg := nextMsg.(type) {
+		case *wtwire.CreateSession:
+			err = s.handleCreateSession(peer, &id, msg)
+			if err != nil {
+				log.Errorf("Unable to handle CreateSession "+
+					"from %s: %v", id, err)
+			}
+
+			// The connection is always closed after a session
+			// has been negotiated.
+			return
+
+		case *wtwire.StateUpdate:
+			err = s.handleStateUpdate(peer, &id, msg)
+			if err != nil {
+				log.Errorf("Unable to handle StateUpdate "+
+					"from %s: %v", id, err)
+				return
+			}
+
+			// The client signals that this was its last update
+			// for the connection.
+			if msg.IsComplete == 1 {
+				return
+			}
+
+		default:
+			log.Errorf("Received unsupported message type: %T "+
+				"from %s", nextMsg, id)
+			return
+		}
+	}
+}
+
+// handleCreateSession processes a CreateSession message from the peer, and
+// returns a CreateSessionReply in response. This method will only succeed if
+// no existing session info is known about the session id, and the proposed
+// policy is acceptable to the tower.
+func (s *Server) handleCreateSession(peer Peer, id *wtdb.SessionID,
+	req *wtwire.CreateSession) error {
+
+	// Query the db for session info belonging to the client's session id.
+	_, err := s.cfg.DB.GetSessionInfo(id)
+	switch {
+
+	// We already have a session corresponding to this session id, return
+	// an error signaling that it already exists in our database.
+	case err == nil:
+		log.Debugf("Already have session for %s", id)
+		return s.replyCreateSession(
+			peer, id, wtwire.CreateSessionCodeAlreadyExists,
+		)
+
+	// Some other database error occurred, return a temporary failure.
+	case err != wtdb.ErrSessionNotFound:
+		log.Errorf("unable to load session info for %s", id)
+		return s.replyCreateSession(
+			peer, id, wtwire.CodeTemporaryFailure,
+		)
+	}
+
+	policy := wtpolicy.Policy{
+		MaxUpdates:   req.MaxUpdates,
+		SweepFeeRate: req.SweepFeeRate,
+	}
+
+	// Ensure the proposed policy is sane before accepting the session.
+	switch policy.Validate() {
+	case nil:
+
+	case wtpolicy.ErrNoMaxUpdates:
+		log.Debugf("Rejecting session for %s: %v", id, policy)
+		return s.replyCreateSession(
+			peer, id, wtwire.CreateSessionCodeRejectMaxUpdates,
+		)
+
+	case wtpolicy.ErrSweepFeeRateTooLow:
+		log.Debugf("Rejecting session for %s: %v", id, policy)
+		return s.replyCreateSession(
+			peer, id, wtwire.CreateSessionCodeRejectSweepFeeRate,
+		)
+
+	default:
+		return s.replyCreateSession(
+			peer, id, wtwire.CodePermanentFailure,
+		)
+	}
+
+	info := wtdb.SessionInfo{
+		ID:     *id,
+		Policy: policy,
+	}
+
+	// Insert the session info into the watchtower's database. If
+	// successful, the session will now be ready for use.
+	err = s.cfg.DB.InsertSessionInfo(&info)
+	if err != nil {
+		log.Errorf("unable to create session for %s", id)
+		return s.replyCreateSession(
+			peer, id, wtwire.CodeTemporaryFailure,
+		)
+	}
+
+	log.Infof("Accepted session for %s with policy %v", id, policy)
+
+	return s.replyCreateSession(peer, id, wtwire.CodeOK)
+}
+
+// replyCreateSession sends a response to a CreateSession from a client. If the
+// status code in the reply is OK, the error from the write will be bubbled up.
+// Otherwise, this method returns a connection error to ensure we don't
+// continue communication with the client.
+func (s *Server) replyCreateSession(peer Peer, id *wtdb.SessionID,
+	code wtwire.ErrorCode) error {
+
+	msg := &wtwire.CreateSessionReply{
+		Code: code,
+	}
+
+	err := s.sendMessage(peer, msg)
+	if err != nil {
+		log.Errorf("unable to send CreateSessionReply to %s", id)
+	}
+
+	// Return the write error if the request succeeded.
+	if code == wtwire.CodeOK {
+		return err
+	}
+
+	// Otherwise the request failed, return a connection failure to
+	// disconnect the client.
+	return &connFailure{
+		ID:   *id,
+		Code: code,
+	}
+}
+
+// handleStateUpdate processes a StateUpdate message request from a client, and
+// replies with a StateUpdateReply containing the result of the update.
+func (s *Server) handleStateUpdate(peer Peer, id *wtdb.SessionID,
+	update *wtwire.StateUpdate) error {
+
+	var (
+		lastApplied uint16
+		failCode    wtwire.ErrorCode
+		err         error
+	)
+
+	sessionUpdate := wtdb.SessionStateUpdate{
+		ID:            *id,
+		Hint:          update.Hint,
+		SeqNum:        update.SeqNum,
+		LastApplied:   update.LastApplied,
+		EncryptedBlob: update.EncryptedBlob,
+	}
+
+	lastApplied, err = s.cfg.DB.InsertStateUpdate(&sessionUpdate)
+	switch {
+	case err == nil:
+		log.Debugf("State update %d accepted for %s",
+			update.SeqNum, id)
+
+		failCode = wtwire.CodeOK
+
+	// Return a permanent failure if a client tries to send an update for
+	// which we have no session.
+	case err == wtdb.ErrSessionNotFound:
+		failCode = wtwire.StateUpdateCodeNotAuthorized
+
+	case err == wtdb.ErrSessionConsumed:
+		failCode = wtwire.StateUpdateCodeMaxUpdatesExceeded
+
+	case err == wtdb.ErrUpdateOutOfOrder:
+		failCode = wtwire.StateUpdateCodeSeqNumOutOfOrder
+
+	case err == wtdb.ErrSeqNumAlreadyApplied ||
+		err == wtdb.ErrLastAppliedReversion:
+
+		failCode = wtwire.StateUpdateCodeClientBehind
+
+	default:
+		failCode = wtwire.CodeTemporaryFailure
+	}
+
+	// If the update was rejected, we'll report the session's current last
+	// applied value so that the client can resynchronize.
+	if failCode != wtwire.CodeOK {
+		log.Debugf("State update %d rejected for %s: %v",
+			update.SeqNum, id, err)
+
+		if session, err := s.cfg.DB.GetSessionInfo(id); err == nil {
+			lastApplied = session.LastApplied
+		}
+	}
+
+	return s.replyStateUpdate(peer, id, failCode, lastApplied)
+}
+
+// replyStateUpdate sends a response to a StateUpdate from a client. If the
+// status code in the reply is OK, the error from the write will be bubbled up.
+// Otherwise, this method returns a connection error to ensure we don't
+// continue communication with the client.
+func (s *Server) replyStateUpdate(peer Peer, id *wtdb.SessionID,
+	code wtwire.ErrorCode, lastApplied uint16) error {
+
+	msg := &wtwire.StateUpdateReply{
+		Code:        code,
+		LastApplied: lastApplied,
+	}
+
+	err := s.sendMessage(peer, msg)
+	if err != nil {
+		log.Errorf("unable to send StateUpdateReply to %s", id)
+	}
+
+	// Return the write error if the request succeeded.
+	if code == wtwire.CodeOK {
+		return err
+	}
+
+	// Otherwise the request failed, return a connection failure to
+	// disconnect the client.
+	return &connFailure{
+		ID:   *id,
+		Code: code,
+	}
+}
+
+// connFailure is a default error used when a request failed with a non-zero
+// error code.
+type connFailure struct {
+	ID   wtdb.SessionID
+	Code wtwire.ErrorCode
+}
+
+// Error displays the SessionID and Code that caused the connection failure.
+func (f *connFailure) Error() string {
+	return fmt.Sprintf("connection with %s failed with code=%v",
+		f.ID, f.Code,
+	)
+}
+
+// readMessage receives and parses the next message from the given Peer. An
+// error is returned if a message is not received before the server's read
+// timeout, the read off the wire failed, or the message could not be
+// deserialized.
+func (s *Server) readMessage(peer Peer) (wtwire.Message, error) {
+	// Set a read timeout to ensure we drop the client if not sent in a
+	// timely manner.
+	err := peer.SetReadDeadline(time.Now().Add(s.cfg.ReadTimeout))
+	if err != nil {
+		err = fmt.Errorf("unable to set read deadline: %v", err)
+		return nil, err
+	}
+
+	// Pull the next message off the wire, and parse it according to the
+	// watchtower wire specification.
+	rawMsg, err := peer.ReadNextMessage()
+	if err != nil {
+		err = fmt.Errorf("unable to read message: %v", err)
+		return nil, err
+	}
+
+	msgReader := bytes.NewReader(rawMsg)
+	nextMsg, err := wtwire.ReadMessage(msgReader, 0)
+	if err != nil {
+		err = fmt.Errorf("unable to parse message: %v", err)
+		return nil, err
+	}
+
+	return nextMsg, nil
+}
+
+// sendMessage sends a watchtower wire message to the target peer.
+func (s *Server) sendMessage(peer Peer, msg wtwire.Message) error {
+	var b bytes.Buffer
+	_, err := wtwire.WriteMessage(&b, msg, 0)
+	if err != nil {
+		err = fmt.Errorf("unable to encode msg: %v", err)
+		return err
+	}
+
+	err = peer.SetWriteDeadline(time.Now().Add(s.cfg.WriteTimeout))
+	if err != nil {
+		err = fmt.Errorf("unable to set write deadline: %v", err)
+		return err
+	}
+
+	_, err = peer.Write(b.Bytes())
+	return err
+}
+
+// addPeer stores a client in the server's client map. An error is returned if
+// the client is already present.
+func (s *Server) addPeer(id *wtdb.SessionID, peer Peer) error {
+	s.clientMtx.Lock()
+	defer s.clientMtx.Unlock()
+
+	if _, ok := s.clients[*id]; ok {
+		return ErrPeerAlreadyConnected
+	}
+	s.clients[*id] = peer
+
+	return nil
+}
+
+// removePeer deletes a client from the server's client map. If a peer is
+// found, this method will close the peer's connection.
+func (s *Server) removePeer(id *wtdb.SessionID, addr net.Addr) {
+	log.Infof("Releasing incoming peer %s@%s", id, addr)
+
+	s.clientMtx.Lock()
+	peer, ok := s.clients[*id]
+	delete(s.clients, *id)
+	s.clientMtx.Unlock()
+
+	if ok {
+		peer.Close()
+	}
+}
+
+// removeAllPeers iterates through the server's current set of peers and closes
+// all open connections.
+func (s *Server) removeAllPeers() {
+	s.clientMtx.Lock()
+	defer s.clientMtx.Unlock()
+
+	for id, peer := range s.clients {
+		log.Infof("Releasing incoming peer %s@%s", id,
+			peer.RemoteAddr())
+
+		delete(s.clients, id)
+		peer.Close()
+	}
+}
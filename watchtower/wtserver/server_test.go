@@ -0,0 +1,296 @@
+package wtserver_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/lightningnetwork/lnd/watchtower/wtserver"
+	"github.com/lightningnetwork/lnd/watchtower/wtwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+var (
+	addr, _ = net.ResolveTCPAddr("tcp", "127.0.0.1:9911")
+
+	timeoutDuration = 500 * time.Millisecond
+)
+
+// mockPeer is an in-memory implementation of the wtserver.Peer interface.
+type mockPeer struct {
+	remotePub  *btcec.PublicKey
+	remoteAddr net.Addr
+
+	incoming chan []byte
+	outgoing chan []byte
+
+	quit chan struct{}
+}
+
+func newMockPeer(t *testing.T) *mockPeer {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate private key: %v", err)
+	}
+
+	return &mockPeer{
+		remotePub:  privKey.PubKey(),
+		remoteAddr: addr,
+		incoming:   make(chan []byte, 1),
+		outgoing:   make(chan []byte, 1),
+		quit:       make(chan struct{}),
+	}
+}
+
+func (p *mockPeer) Write(b []byte) (int, error) {
+	select {
+	case p.outgoing <- b:
+		return len(b), nil
+	case <-p.quit:
+		return 0, fmt.Errorf("connection closed")
+	}
+}
+
+func (p *mockPeer) Close() error {
+	select {
+	case <-p.quit:
+	default:
+		close(p.quit)
+	}
+	return nil
+}
+
+func (p *mockPeer) ReadNextMessage() ([]byte, error) {
+	select {
+	case b := <-p.incoming:
+		return b, nil
+	case <-p.quit:
+		return nil, fmt.Errorf("connection closed")
+	}
+}
+
+func (p *mockPeer) SetWriteDeadline(time.Time) error { return nil }
+
+func (p *mockPeer) SetReadDeadline(time.Time) error { return nil }
+
+func (p *mockPeer) RemotePub() *btcec.PublicKey { return p.remotePub }
+
+func (p *mockPeer) RemoteAddr() net.Addr { return p.remoteAddr }
+
+func initServer(t *testing.T) (*wtserver.Server, func()) {
+	path, err := ioutil.TempDir("", "wtserver")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	db, err := wtdb.OpenTowerDB(path)
+	if err != nil {
+		os.RemoveAll(path)
+		t.Fatalf("unable to open tower db: %v", err)
+	}
+
+	s, err := wtserver.New(&wtserver.Config{
+		DB:           db,
+		ReadTimeout:  timeoutDuration,
+		WriteTimeout: timeoutDuration,
+	})
+	if err != nil {
+		t.Fatalf("unable to create server: %v", err)
+	}
+
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start server: %v", err)
+	}
+
+	cleanup := func() {
+		s.Stop()
+		db.Close()
+		os.RemoveAll(path)
+	}
+
+	return s, cleanup
+}
+
+func sendMsg(t *testing.T, msg wtwire.Message, peer *mockPeer) {
+	var b bytes.Buffer
+	if _, err := wtwire.WriteMessage(&b, msg, 0); err != nil {
+		t.Fatalf("unable to encode %T message: %v", msg, err)
+	}
+
+	select {
+	case peer.incoming <- b.Bytes():
+	case <-time.After(2 * timeoutDuration):
+		t.Fatalf("unable to send %T message", msg)
+	}
+}
+
+func recvReply(t *testing.T, peer *mockPeer) wtwire.Message {
+	var b []byte
+	select {
+	case b = <-peer.outgoing:
+	case <-time.After(2 * timeoutDuration):
+		t.Fatalf("no reply received")
+	}
+
+	msg, err := wtwire.ReadMessage(bytes.NewReader(b), 0)
+	if err != nil {
+		t.Fatalf("unable to decode reply: %v", err)
+	}
+
+	return msg
+}
+
+func assertConnClosed(t *testing.T, peer *mockPeer) {
+	select {
+	case <-peer.quit:
+	case <-time.After(2 * timeoutDuration):
+		t.Fatalf("expected connection to be closed")
+	}
+}
+
+// TestServerCreateSession asserts that the server accepts valid sessions,
+// rejects duplicate sessions, and rejects invalid policies.
+func TestServerCreateSession(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := initServer(t)
+	defer cleanup()
+
+	createSession := &wtwire.CreateSession{
+		MaxUpdates:   wtpolicy.DefaultMaxUpdates,
+		SweepFeeRate: wtpolicy.DefaultSweepFeeRate,
+	}
+
+	tests := []struct {
+		name    string
+		msg     *wtwire.CreateSession
+		sameKey bool
+		expCode wtwire.ErrorCode
+	}{
+		{
+			name:    "valid session",
+			msg:     createSession,
+			expCode: wtwire.CodeOK,
+		},
+		{
+			name:    "duplicate session",
+			msg:     createSession,
+			sameKey: true,
+			expCode: wtwire.CreateSessionCodeAlreadyExists,
+		},
+		{
+			name: "zero max updates",
+			msg: &wtwire.CreateSession{
+				SweepFeeRate: wtpolicy.DefaultSweepFeeRate,
+			},
+			expCode: wtwire.CreateSessionCodeRejectMaxUpdates,
+		},
+		{
+			name: "sweep fee rate too low",
+			msg: &wtwire.CreateSession{
+				MaxUpdates:   wtpolicy.DefaultMaxUpdates,
+				SweepFeeRate: wtpolicy.MinSweepFeeRate - 1,
+			},
+			expCode: wtwire.CreateSessionCodeRejectSweepFeeRate,
+		},
+	}
+
+	var prevPeer *mockPeer
+	for _, test := range tests {
+		peer := newMockPeer(t)
+		if test.sameKey {
+			peer.remotePub = prevPeer.remotePub
+		}
+		prevPeer = peer
+
+		s.InboundPeerConnected(peer)
+		sendMsg(t, test.msg, peer)
+
+		reply, ok := recvReply(t, peer).(*wtwire.CreateSessionReply)
+		if !ok {
+			t.Fatalf("%s: expected CreateSessionReply", test.name)
+		}
+		if reply.Code != test.expCode {
+			t.Fatalf("%s: expected code %v, got %v", test.name,
+				test.expCode, reply.Code)
+		}
+
+		// The server should always disconnect after handling a
+		// CreateSession message.
+		assertConnClosed(t, peer)
+	}
+}
+
+// TestServerStateUpdates asserts that the server accepts sequential state
+// updates within a session, and rejects those that are out of order.
+func TestServerStateUpdates(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := initServer(t)
+	defer cleanup()
+
+	// First, create a session permitting two updates.
+	peer := newMockPeer(t)
+	s.InboundPeerConnected(peer)
+	sendMsg(t, &wtwire.CreateSession{
+		MaxUpdates:   2,
+		SweepFeeRate: wtpolicy.DefaultSweepFeeRate,
+	}, peer)
+	recvReply(t, peer)
+	assertConnClosed(t, peer)
+
+	// Reconnect using the same session key.
+	peer2 := newMockPeer(t)
+	peer2.remotePub = peer.remotePub
+	s.InboundPeerConnected(peer2)
+
+	newUpdate := func(seqNum, lastApplied uint16) *wtwire.StateUpdate {
+		var hint blob.BreachHint
+		hint[0] = byte(seqNum)
+
+		return &wtwire.StateUpdate{
+			SeqNum:        seqNum,
+			LastApplied:   lastApplied,
+			Hint:          hint,
+			EncryptedBlob: make([]byte, blob.Size),
+		}
+	}
+
+	// A proper first update should be accepted.
+	sendMsg(t, newUpdate(1, 0), peer2)
+	reply := recvReply(t, peer2).(*wtwire.StateUpdateReply)
+	if reply.Code != wtwire.CodeOK || reply.LastApplied != 1 {
+		t.Fatalf("unexpected reply: %v", reply)
+	}
+
+	// Skipping a sequence number should be rejected, and the client
+	// disconnected.
+	sendMsg(t, newUpdate(3, 1), peer2)
+	reply = recvReply(t, peer2).(*wtwire.StateUpdateReply)
+	if reply.Code != wtwire.StateUpdateCodeSeqNumOutOfOrder {
+		t.Fatalf("expected StateUpdateCodeSeqNumOutOfOrder, got: %v",
+			reply.Code)
+	}
+	if reply.LastApplied != 1 {
+		t.Fatalf("expected last applied 1, got %d", reply.LastApplied)
+	}
+	assertConnClosed(t, peer2)
+
+	// Updates from an unknown session should not be authorized.
+	peer3 := newMockPeer(t)
+	s.InboundPeerConnected(peer3)
+	sendMsg(t, newUpdate(1, 0), peer3)
+	reply = recvReply(t, peer3).(*wtwire.StateUpdateReply)
+	if reply.Code != wtwire.StateUpdateCodeNotAuthorized {
+		t.Fatalf("expected StateUpdateCodeNotAuthorized, got: %v",
+			reply.Code)
+	}
+	assertConnClosed(t, peer3)
+}
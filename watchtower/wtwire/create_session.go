@@ -0,0 +1,64 @@
+package wtwire
+
+import (
+	"io"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+// CreateSession is sent from a client to a tower when it wishes to begin a
+// new session. The session is identified by the static public key used by
+// the client to authenticate the brontide connection, and bounds the number
+// of updates the client may send to the tower, as well as the fee rate the
+// tower should use when constructing justice transactions.
+type CreateSession struct {
+	// MaxUpdates is the maximum number of updates the watchtower will honor
+	// for this session.
+	MaxUpdates uint16
+
+	// SweepFeeRate is the fee rate used by the tower when constructing
+	// justice transactions for this session.
+	SweepFeeRate lnwallet.SatPerKWeight
+}
+
+// A compile time check to ensure CreateSession implements the wtwire.Message
+// interface.
+var _ Message = (*CreateSession)(nil)
+
+// Decode deserializes a serialized CreateSession message stored in the passed
+// io.Reader using the protocol version pver.
+//
+// This is part of the wtwire.Message interface.
+func (m *CreateSession) Decode(r io.Reader, pver uint32) error {
+	return ReadElements(r,
+		&m.MaxUpdates,
+		&m.SweepFeeRate,
+	)
+}
+
+// Encode serializes the target CreateSession into the passed io.Writer using
+// the protocol version pver.
+//
+// This is part of the wtwire.Message interface.
+func (m *CreateSession) Encode(w io.Writer, pver uint32) error {
+	return WriteElements(w,
+		m.MaxUpdates,
+		m.SweepFeeRate,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the wtwire.Message interface.
+func (m *CreateSession) MsgType() MessageType {
+	return MsgCreateSession
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// CreateSession complete message observing the specified protocol version.
+//
+// This is part of the wtwire.Message interface.
+func (m *CreateSession) MaxPayloadLength(uint32) uint32 {
+	return 2 + 8
+}
@@ -0,0 +1,67 @@
+package wtwire
+
+import "io"
+
+const (
+	// CreateSessionCodeAlreadyExists is returned when a session is already
+	// active for the public key used to connect to the watchtower.
+	CreateSessionCodeAlreadyExists ErrorCode = 60
+
+	// CreateSessionCodeRejectMaxUpdates is returned when the tower rejects
+	// the maximum number of state updates proposed by the client.
+	CreateSessionCodeRejectMaxUpdates ErrorCode = 61
+
+	// CreateSessionCodeRejectSweepFeeRate is returned when the tower
+	// rejects the sweep fee rate proposed by the client.
+	CreateSessionCodeRejectSweepFeeRate ErrorCode = 62
+)
+
+// CreateSessionReply is a message sent from watchtower to client in response
+// to a CreateSession message, and signals either an acceptance or rejection of
+// the proposed session parameters.
+type CreateSessionReply struct {
+	// Code will be non-zero if the watchtower rejected the session
+	// creation.
+	Code ErrorCode
+}
+
+// A compile time check to ensure CreateSessionReply implements the
+// wtwire.Message interface.
+var _ Message = (*CreateSessionReply)(nil)
+
+// Decode deserializes a serialized CreateSessionReply message stored in the
+// passed io.Reader using the protocol version pver.
+//
+// This is part of the wtwire.Message interface.
+func (m *CreateSessionReply) Decode(r io.Reader, pver uint32) error {
+	return ReadElements(r,
+		&m.Code,
+	)
+}
+
+// Encode serializes the target CreateSessionReply into the passed io.Writer
+// using the protocol version pver.
+//
+// This is part of the wtwire.Message interface.
+func (m *CreateSessionReply) Encode(w io.Writer, pver uint32) error {
+	return WriteElements(w,
+		m.Code,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the wtwire.Message interface.
+func (m *CreateSessionReply) MsgType() MessageType {
+	return MsgCreateSessionReply
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// CreateSessionReply complete message observing the specified protocol
+// version.
+//
+// This is part of the wtwire.Message interface.
+func (m *CreateSessionReply) MaxPayloadLength(uint32) uint32 {
+	return 2
+}
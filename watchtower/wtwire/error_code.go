@@ -0,0 +1,57 @@
+package wtwire
+
+import "fmt"
+
+// ErrorCode represents a generic error code used when replying to watchtower
+// clients. Each reply message carries an ErrorCode, where CodeOK signals that
+// the request was processed successfully. Codes that are specific to a
+// particular message are defined alongside that message.
+type ErrorCode uint16
+
+const (
+	// CodeOK signals that the request was successfully processed by the
+	// watchtower.
+	CodeOK ErrorCode = 0
+
+	// CodeTemporaryFailure alerts the client that the watchtower is
+	// temporarily unavailable, but that it may try again at a later time.
+	CodeTemporaryFailure ErrorCode = 40
+
+	// CodePermanentFailure alerts the client that the watchtower has
+	// permanently failed, and further communication should be avoided.
+	CodePermanentFailure ErrorCode = 50
+)
+
+// String returns a human-readable description of an ErrorCode.
+func (c ErrorCode) String() string {
+	switch c {
+	case CodeOK:
+		return "CodeOK"
+	case CodeTemporaryFailure:
+		return "CodeTemporaryFailure"
+	case CodePermanentFailure:
+		return "CodePermanentFailure"
+	case CreateSessionCodeAlreadyExists:
+		return "CreateSessionCodeAlreadyExists"
+	case CreateSessionCodeRejectMaxUpdates:
+		return "CreateSessionCodeRejectMaxUpdates"
+	case CreateSessionCodeRejectSweepFeeRate:
+		return "CreateSessionCodeRejectSweepFeeRate"
+	case StateUpdateCodeClientBehind:
+		return "StateUpdateCodeClientBehind"
+	case StateUpdateCodeMaxUpdatesExceeded:
+		return "StateUpdateCodeMaxUpdatesExceeded"
+	case StateUpdateCodeSeqNumOutOfOrder:
+		return "StateUpdateCodeSeqNumOutOfOrder"
+	case StateUpdateCodeNotAuthorized:
+		return "StateUpdateCodeNotAuthorized"
+	default:
+		return fmt.Sprintf("UnknownErrorCode: %d", uint16(c))
+	}
+}
+
+// Error implements the error interface, allowing a non-OK ErrorCode to be
+// returned directly as an error.
+func (c ErrorCode) Error() string {
+	return c.String()
+}
@@ -0,0 +1,85 @@
+package wtwire
+
+import (
+	"io"
+
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+)
+
+// StateUpdate transmits a new encrypted state update from the client to the
+// watchtower. Each state update is tagged with a sequence number, which the
+// tower uses to ensure that updates are applied in order, and a breach hint
+// which allows the tower to detect the corresponding breach on-chain.
+type StateUpdate struct {
+	// SeqNum is a 1-indexed, monotonically incrementing sequence number.
+	// This value must always be less than or equal to the negotiated
+	// MaxUpdates for the session, and greater than the LastApplied sent in
+	// the same message.
+	SeqNum uint16
+
+	// LastApplied echoes the LastApplied value returned from the
+	// watchtower, allowing the tower to detect faulty clients.
+	LastApplied uint16
+
+	// IsComplete is 1 if the watchtower should close the connection after
+	// responding, and 0 otherwise.
+	IsComplete uint8
+
+	// Hint is the 16-byte prefix of the revoked commitment transaction ID
+	// for which the encrypted blob can exact justice.
+	Hint blob.BreachHint
+
+	// EncryptedBlob is the serialized ciphertext containing all necessary
+	// information to sweep the commitment transaction corresponding to the
+	// Hint. The ciphertext is to be encrypted using the full transaction ID
+	// of the revoked commitment transaction.
+	EncryptedBlob []byte
+}
+
+// A compile time check to ensure StateUpdate implements the wtwire.Message
+// interface.
+var _ Message = (*StateUpdate)(nil)
+
+// Decode deserializes a serialized StateUpdate message stored in the passed
+// io.Reader using the protocol version pver.
+//
+// This is part of the wtwire.Message interface.
+func (m *StateUpdate) Decode(r io.Reader, pver uint32) error {
+	return ReadElements(r,
+		&m.SeqNum,
+		&m.LastApplied,
+		&m.IsComplete,
+		&m.Hint,
+		&m.EncryptedBlob,
+	)
+}
+
+// Encode serializes the target StateUpdate into the passed io.Writer using
+// the protocol version pver.
+//
+// This is part of the wtwire.Message interface.
+func (m *StateUpdate) Encode(w io.Writer, pver uint32) error {
+	return WriteElements(w,
+		m.SeqNum,
+		m.LastApplied,
+		m.IsComplete,
+		m.Hint,
+		m.EncryptedBlob,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the wtwire.Message interface.
+func (m *StateUpdate) MsgType() MessageType {
+	return MsgStateUpdate
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a StateUpdate
+// complete message observing the specified protocol version.
+//
+// This is part of the wtwire.Message interface.
+func (m *StateUpdate) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
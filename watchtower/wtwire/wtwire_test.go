@@ -0,0 +1,75 @@
+package wtwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+)
+
+// TestWatchtowerWireProtocol asserts that each watchtower message survives a
+// serialization round trip, and that the decoded message matches the original.
+func TestWatchtowerWireProtocol(t *testing.T) {
+	t.Parallel()
+
+	var hint blob.BreachHint
+	for i := range hint {
+		hint[i] = byte(i)
+	}
+
+	encryptedBlob := make([]byte, blob.Size)
+	for i := range encryptedBlob {
+		encryptedBlob[i] = byte(i)
+	}
+
+	msgs := []Message{
+		&CreateSession{
+			MaxUpdates:   1024,
+			SweepFeeRate: lnwallet.SatPerKWeight(12500),
+		},
+		&CreateSessionReply{
+			Code: CreateSessionCodeRejectMaxUpdates,
+		},
+		&StateUpdate{
+			SeqNum:        10,
+			LastApplied:   9,
+			IsComplete:    1,
+			Hint:          hint,
+			EncryptedBlob: encryptedBlob,
+		},
+		&StateUpdateReply{
+			Code:        CodeOK,
+			LastApplied: 10,
+		},
+	}
+
+	for _, msg := range msgs {
+		var b bytes.Buffer
+		if _, err := WriteMessage(&b, msg, 0); err != nil {
+			t.Fatalf("unable to write %v: %v", msg.MsgType(), err)
+		}
+
+		msg2, err := ReadMessage(&b, 0)
+		if err != nil {
+			t.Fatalf("unable to read %v: %v", msg.MsgType(), err)
+		}
+
+		if !reflect.DeepEqual(msg, msg2) {
+			t.Fatalf("%v mismatch, want: %v, got: %v",
+				msg.MsgType(), msg, msg2)
+		}
+	}
+}
+
+// TestReadUnknownMessage asserts that reading a message with an unknown type
+// returns an UnknownMessage error.
+func TestReadUnknownMessage(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadMessage(bytes.NewReader([]byte{0xff, 0xff}), 0)
+	if _, ok := err.(*UnknownMessage); !ok {
+		t.Fatalf("expected UnknownMessage error, got: %v", err)
+	}
+}
@@ -83,8 +83,11 @@ func (p *preimageBeacon) LookupPreimage(payHash []byte) ([]byte, bool) {
 	}
 
 	// If we've found the invoice, then we can return the preimage
-	// directly.
-	if err != channeldb.ErrInvoiceNotFound {
+	// directly, unless it's a hold invoice whose preimage hasn't been
+	// revealed yet.
+	if err != channeldb.ErrInvoiceNotFound &&
+		invoice.Terms.PaymentPreimage != channeldb.UnknownPreimage {
+
 		return invoice.Terms.PaymentPreimage[:], true
 	}
 
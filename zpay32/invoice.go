@@ -141,10 +141,11 @@ type Invoice struct {
 	// Optional.
 	FallbackAddr btcutil.Address
 
-	// RoutingInfo is one or more entries containing extra routing
-	// information for a private route to the target node.
+	// RouteHints is a set of private routes to the target node, each
+	// consisting of one or more entries containing extra routing
+	// information. Every route is encoded within its own tagged field.
 	// Optional.
-	RoutingInfo []ExtraRoutingInfo
+	RouteHints [][]ExtraRoutingInfo
 }
 
 // ExtraRoutingInfo holds the information needed to route a payment along one
@@ -231,12 +232,13 @@ func FallbackAddr(fallbackAddr btcutil.Address) func(*Invoice) {
 	}
 }
 
-// RoutingInfo is a functional option that allows callers of NewInvoice to set
-// one or more entries containing extra routing information for a private route
-// to the target node.
+// RoutingInfo is a functional option that allows callers of NewInvoice to add
+// a private route to the target node, consisting of one or more entries
+// containing extra routing information. The option can be passed multiple
+// times in order to add several alternative routes.
 func RoutingInfo(routingInfo []ExtraRoutingInfo) func(*Invoice) {
 	return func(i *Invoice) {
-		i.RoutingInfo = routingInfo
+		i.RouteHints = append(i.RouteHints, routingInfo)
 	}
 }
 
@@ -524,10 +526,12 @@ func validateInvoice(invoice *Invoice) error {
 		return fmt.Errorf("neither description nor description hash set")
 	}
 
-	// Can have at most 20 extra hops for routing.
-	if len(invoice.RoutingInfo) > 20 {
-		return fmt.Errorf("too many extra hops: %d",
-			len(invoice.RoutingInfo))
+	// Each route can have at most 20 extra hops for routing.
+	for _, routingInfo := range invoice.RouteHints {
+		if len(routingInfo) > 20 {
+			return fmt.Errorf("too many extra hops: %d",
+				len(routingInfo))
+		}
 	}
 
 	// Check that we support the field lengths.
@@ -665,13 +669,15 @@ func parseTaggedFields(invoice *Invoice, fields []byte, net *chaincfg.Params) er
 
 			invoice.FallbackAddr, err = parseFallbackAddr(base32Data, net)
 		case fieldTypeR:
-			if invoice.RoutingInfo != nil {
-				// We skip the field if we have already seen a
-				// supported one.
-				continue
+			// Each of the routing info fields describes a
+			// separate route, so we'll collect all of them.
+			var routingInfo []ExtraRoutingInfo
+			routingInfo, err = parseRoutingInfo(base32Data)
+			if err == nil {
+				invoice.RouteHints = append(
+					invoice.RouteHints, routingInfo,
+				)
 			}
-
-			invoice.RoutingInfo, err = parseRoutingInfo(base32Data)
 		default:
 			// Ignore unknown type.
 		}
@@ -976,10 +982,10 @@ func writeTaggedFields(bufferBase32 *bytes.Buffer, invoice *Invoice) error {
 		}
 	}
 
-	if len(invoice.RoutingInfo) > 0 {
+	for _, routingInfo := range invoice.RouteHints {
 		// Each extra routing info is encoded using 51 bytes.
-		routingDataBase256 := make([]byte, 0, 51*len(invoice.RoutingInfo))
-		for _, r := range invoice.RoutingInfo {
+		routingDataBase256 := make([]byte, 0, 51*len(routingInfo))
+		for _, r := range routingInfo {
 			base256 := make([]byte, 51)
 			copy(base256[:33], r.PubKey.SerializeCompressed())
 			binary.BigEndian.PutUint64(base256[33:41], r.ShortChanID)
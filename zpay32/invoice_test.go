@@ -413,7 +413,7 @@ func TestDecodeEncode(t *testing.T) {
 					DescriptionHash: &testDescriptionHash,
 					Destination:     testPubKey,
 					FallbackAddr:    testRustyAddr,
-					RoutingInfo:     testSingleHop,
+					RouteHints:      [][]ExtraRoutingInfo{testSingleHop},
 				}
 			},
 			beforeEncoding: func(i *Invoice) {
@@ -436,7 +436,7 @@ func TestDecodeEncode(t *testing.T) {
 					DescriptionHash: &testDescriptionHash,
 					Destination:     testPubKey,
 					FallbackAddr:    testRustyAddr,
-					RoutingInfo:     testDoubleHop,
+					RouteHints:      [][]ExtraRoutingInfo{testDoubleHop},
 				}
 			},
 			beforeEncoding: func(i *Invoice) {
@@ -732,6 +732,41 @@ func TestNewInvoice(t *testing.T) {
 	}
 }
 
+// TestMultipleRouteHints tests that an invoice carrying several private routes
+// encodes each of them within its own tagged field, and that all of them are
+// recovered when decoding the invoice.
+func TestMultipleRouteHints(t *testing.T) {
+	t.Parallel()
+
+	invoice, err := NewInvoice(&chaincfg.MainNetParams,
+		testPaymentHash, time.Unix(1496314658, 0),
+		Amount(testMillisat20mBTC),
+		DescriptionHash(testDescriptionHash),
+		RoutingInfo(testSingleHop),
+		RoutingInfo(testDoubleHop),
+	)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+
+	encoded, err := invoice.Encode(testMessageSigner)
+	if err != nil {
+		t.Fatalf("unable to encode invoice: %v", err)
+	}
+
+	decoded, err := Decode(encoded, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to decode invoice: %v", err)
+	}
+
+	// As the destination was recovered from the signature, we'll set it
+	// on the original invoice before comparing the two.
+	invoice.Destination = decoded.Destination
+	if err := compareInvoices(invoice, decoded); err != nil {
+		t.Fatalf("decoded invoice doesn't match: %v", err)
+	}
+}
+
 func compareInvoices(expected, actual *Invoice) error {
 	if !reflect.DeepEqual(expected.Net, actual.Net) {
 		return fmt.Errorf("expected net %v, got %v",
@@ -778,7 +813,20 @@ func compareInvoices(expected, actual *Invoice) error {
 			expected.FallbackAddr, actual.FallbackAddr)
 	}
 
-	return compareRoutingInfos(expected.RoutingInfo, actual.RoutingInfo)
+	if len(expected.RouteHints) != len(actual.RouteHints) {
+		return fmt.Errorf("expected %d route hints, got %d",
+			len(expected.RouteHints), len(actual.RouteHints))
+	}
+	for i := range expected.RouteHints {
+		err := compareRoutingInfos(
+			expected.RouteHints[i], actual.RouteHints[i],
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func comparePubkeys(a, b *btcec.PublicKey) bool {